@@ -0,0 +1,95 @@
+package federation
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeMember struct {
+	UnimplementedFederationServer
+	slung []*SlingRequest
+}
+
+func (f *fakeMember) ListCities(context.Context, *ListCitiesRequest) (*ListCitiesResponse, error) {
+	return &ListCitiesResponse{Cities: []*City{{Name: "metro", Path: "/srv/metro", ControllerRunning: true}}}, nil
+}
+
+func (f *fakeMember) CityStatus(_ context.Context, in *CityStatusRequest) (*CityStatus, error) {
+	if in.City != "metro" {
+		return nil, status.Errorf(codes.NotFound, "city %q not found", in.City)
+	}
+	return &CityStatus{City: &City{Name: "metro"}, Agents: []*AgentStatus{{Name: "mayor", Running: true}}, ReadyBeads: 3}, nil
+}
+
+func (f *fakeMember) Sling(_ context.Context, in *SlingRequest) (*SlingResponse, error) {
+	f.slung = append(f.slung, in)
+	return &SlingResponse{Output: "Slung " + in.Bead + " → " + in.Target}, nil
+}
+
+// dial starts a member on an in-memory listener and returns a client
+// presenting token.
+func dial(t *testing.T, member FederationServer, serverToken, clientToken string) FederationClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(TokenAuth(serverToken)))
+	RegisterFederationServer(srv, member)
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		TokenCredentials(clientToken, false),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() }) //nolint:errcheck
+	return NewFederationClient(cc)
+}
+
+func TestRoundTrip(t *testing.T) {
+	member := &fakeMember{}
+	c := dial(t, member, "tok", "tok")
+	ctx := context.Background()
+
+	list, err := c.ListCities(ctx, &ListCitiesRequest{})
+	if err != nil {
+		t.Fatalf("ListCities: %v", err)
+	}
+	if len(list.Cities) != 1 || !proto.Equal(list.Cities[0], &City{Name: "metro", Path: "/srv/metro", ControllerRunning: true}) {
+		t.Errorf("cities = %+v", list.Cities)
+	}
+
+	st, err := c.CityStatus(ctx, &CityStatusRequest{City: "metro"})
+	if err != nil || st.ReadyBeads != 3 || len(st.Agents) != 1 || !st.Agents[0].Running {
+		t.Errorf("CityStatus = %+v, %v", st, err)
+	}
+	if _, err := c.CityStatus(ctx, &CityStatusRequest{City: "nope"}); status.Code(err) != codes.NotFound {
+		t.Errorf("unknown city err = %v, want NotFound", err)
+	}
+
+	out, err := c.Sling(ctx, &SlingRequest{City: "metro", Target: "mayor", Bead: "gc-1"})
+	if err != nil || out.Output != "Slung gc-1 → mayor" {
+		t.Errorf("Sling = %+v, %v", out, err)
+	}
+	if len(member.slung) != 1 || member.slung[0].Target != "mayor" {
+		t.Errorf("member saw %+v", member.slung)
+	}
+}
+
+func TestTokenRejected(t *testing.T) {
+	c := dial(t, &fakeMember{}, "tok", "wrong")
+	_, err := c.ListCities(context.Background(), &ListCitiesRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("err = %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// resolveAgentSecrets reads the env vars an agent sources from outside
+// city.toml: the entries of its env_file and the env_from_keychain names
+// looked up in the OS keychain. Keychain values win over the file. The
+// values only ever reach the session environment; they are not written
+// to any generated file.
+func resolveAgentSecrets(cityPath string, a *config.Agent) (map[string]string, error) {
+	if a.EnvFile == "" && len(a.EnvFromKeychain) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]string)
+	if a.EnvFile != "" {
+		path := a.EnvFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cityPath, path)
+		}
+		m, err := readEnvFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("env_file: %w", err)
+		}
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+	for _, name := range a.EnvFromKeychain {
+		v, err := cachedKeychainLookup(name)
+		if err != nil {
+			return nil, fmt.Errorf("env_from_keychain %q: %w", name, err)
+		}
+		out[name] = v
+	}
+	return out, nil
+}
+
+// readEnvFile parses a dotenv file: KEY=value lines, with blank lines and
+// # comments skipped, an optional "export " prefix, and one layer of
+// matching single or double quotes stripped from the value. Values are
+// taken literally; $VAR references are not expanded.
+func readEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, val, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("%s:%d: expected KEY=value", path, n)
+		}
+		val = strings.TrimSpace(val)
+		if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+			val = val[1 : len(val)-1]
+		}
+		m[key] = val
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// keychainLookup reads the secret stored under service name in the OS
+// keychain. Tests replace it to avoid touching the real keychain.
+var keychainLookup = func(name string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", name, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", name)
+	default:
+		return "", fmt.Errorf("no keychain support on %s", runtime.GOOS)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+	v := strings.TrimRight(string(out), "\r\n")
+	if v == "" {
+		return "", fmt.Errorf("no keychain item for service %q", name)
+	}
+	return v, nil
+}
+
+// keychainCache holds keychain values for the life of the process. Agent
+// templates are resolved on every reconcile tick, and a keychain read can
+// prompt the user or shell out, so each name is looked up once.
+var keychainCache sync.Map
+
+// cachedKeychainLookup returns the keychain value for name, reading the
+// keychain only on first use. Failed lookups are not cached.
+func cachedKeychainLookup(name string) (string, error) {
+	if v, ok := keychainCache.Load(name); ok {
+		return v.(string), nil
+	}
+	v, err := keychainLookup(name)
+	if err != nil {
+		return "", err
+	}
+	keychainCache.Store(name, v)
+	return v, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// fakeKeychain replaces the OS keychain with items and counts lookups.
+func fakeKeychain(t *testing.T, items map[string]string) *int {
+	t.Helper()
+	saved := keychainLookup
+	calls := 0
+	keychainLookup = func(name string) (string, error) {
+		calls++
+		v, ok := items[name]
+		if !ok {
+			return "", fmt.Errorf("no keychain item for service %q", name)
+		}
+		return v, nil
+	}
+	keychainCache.Clear()
+	t.Cleanup(func() {
+		keychainLookup = saved
+		keychainCache.Clear()
+	})
+	return &calls
+}
+
+func writeSecretsFile(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".gc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gc", "secrets.env"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	writeSecretsFile(t, dir, `# API keys
+ANTHROPIC_API_KEY=sk-ant-123
+export OPENAI_API_KEY="sk-open $HOME"
+
+SINGLE='quoted value'
+EMPTY=
+`)
+	got, err := readEnvFile(filepath.Join(dir, ".gc", "secrets.env"))
+	if err != nil {
+		t.Fatalf("readEnvFile: %v", err)
+	}
+	want := map[string]string{
+		"ANTHROPIC_API_KEY": "sk-ant-123",
+		"OPENAI_API_KEY":    "sk-open $HOME",
+		"SINGLE":            "quoted value",
+		"EMPTY":             "",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("readEnvFile = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestReadEnvFileMalformedLine(t *testing.T) {
+	dir := t.TempDir()
+	writeSecretsFile(t, dir, "GOOD=1\nnot a pair\n")
+	_, err := readEnvFile(filepath.Join(dir, ".gc", "secrets.env"))
+	if err == nil || !strings.Contains(err.Error(), "secrets.env:2: expected KEY=value") {
+		t.Errorf("err = %v, want line 2 parse error", err)
+	}
+}
+
+func TestResolveAgentSecrets(t *testing.T) {
+	calls := fakeKeychain(t, map[string]string{"ANTHROPIC_API_KEY": "from-keychain"})
+	dir := t.TempDir()
+	writeSecretsFile(t, dir, "ANTHROPIC_API_KEY=from-file\nOPENAI_API_KEY=sk-open\n")
+	a := &config.Agent{Name: "mayor", EnvFile: ".gc/secrets.env", EnvFromKeychain: []string{"ANTHROPIC_API_KEY"}}
+
+	for i := 0; i < 2; i++ {
+		got, err := resolveAgentSecrets(dir, a)
+		if err != nil {
+			t.Fatalf("resolveAgentSecrets: %v", err)
+		}
+		if got["ANTHROPIC_API_KEY"] != "from-keychain" {
+			t.Errorf("ANTHROPIC_API_KEY = %q, want keychain value to win", got["ANTHROPIC_API_KEY"])
+		}
+		if got["OPENAI_API_KEY"] != "sk-open" {
+			t.Errorf("OPENAI_API_KEY = %q, want %q", got["OPENAI_API_KEY"], "sk-open")
+		}
+	}
+	if *calls != 1 {
+		t.Errorf("keychain lookups = %d, want 1 (cached)", *calls)
+	}
+}
+
+func TestResolveAgentSecretsErrors(t *testing.T) {
+	fakeKeychain(t, nil)
+	dir := t.TempDir()
+
+	_, err := resolveAgentSecrets(dir, &config.Agent{Name: "mayor", EnvFile: "missing.env"})
+	if err == nil || !strings.HasPrefix(err.Error(), "env_file: ") {
+		t.Errorf("missing env_file: err = %v", err)
+	}
+	_, err = resolveAgentSecrets(dir, &config.Agent{Name: "mayor", EnvFromKeychain: []string{"NOPE"}})
+	if err == nil || !strings.Contains(err.Error(), `env_from_keychain "NOPE"`) {
+		t.Errorf("missing keychain item: err = %v", err)
+	}
+}
+
+func TestResolveTemplateAgentSecrets(t *testing.T) {
+	fakeKeychain(t, map[string]string{"GITHUB_TOKEN": "ghp-123"})
+	dir := t.TempDir()
+	writeSecretsFile(t, dir, "ANTHROPIC_API_KEY=from-file\nGC_AGENT=spoofed\nDEBUG=0\n")
+	cfg := &config.City{Agents: []config.Agent{{
+		Name:            "mayor",
+		StartCommand:    "echo",
+		Env:             map[string]string{"DEBUG": "1"},
+		EnvFile:         ".gc/secrets.env",
+		EnvFromKeychain: []string{"GITHUB_TOKEN"},
+	}}}
+	bp := newAgentBuildParams("test", dir, cfg, runtime.NewFake(), time.Now(), nil, io.Discard)
+
+	tp, err := resolveTemplate(bp, &cfg.Agents[0], "mayor", nil)
+	if err != nil {
+		t.Fatalf("resolveTemplate: %v", err)
+	}
+	for k, want := range map[string]string{
+		"ANTHROPIC_API_KEY": "from-file",
+		"GITHUB_TOKEN":      "ghp-123",
+		"DEBUG":             "1",     // inline env wins over env_file
+		"GC_AGENT":          "mayor", // gc's own vars are never overridden
+	} {
+		if tp.Env[k] != want {
+			t.Errorf("Env[%s] = %q, want %q", k, tp.Env[k], want)
+		}
+	}
+	if strings.Contains(tp.Prompt, "from-file") || strings.Contains(tp.Prompt, "ghp-123") {
+		t.Error("secret value leaked into the rendered prompt")
+	}
+
+	cfg.Agents[0].EnvFromKeychain = []string{"MISSING"}
+	if _, err := resolveTemplate(bp, &cfg.Agents[0], "mayor", nil); err == nil || !strings.Contains(err.Error(), `agent "mayor": env_from_keychain "MISSING"`) {
+		t.Errorf("resolveTemplate err = %v, want keychain error", err)
+	}
+}
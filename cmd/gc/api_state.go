@@ -80,12 +80,13 @@ func (cs *controllerState) buildStores(cfg *config.City) (map[string]beads.Store
 	stores := make(map[string]beads.Store, len(cfg.Rigs))
 	provs := make(map[string]mail.Provider, len(cfg.Rigs))
 
-	// For the "file" provider, all rigs share the same city-level beads.json
-	// and a single mail provider to ensure identity-based dedup works correctly.
+	// For the "file" and "sqlite" providers, all rigs share the same
+	// city-level store and a single mail provider to ensure identity-based
+	// dedup works correctly.
 	var sharedFileStore beads.Store
 	var sharedMailProv mail.Provider
-	if provider == "file" {
-		store, err := beads.OpenFileStore(fsys.OSFS{}, filepath.Join(cs.cityPath, ".gc", "beads.json"))
+	if provider == "file" || provider == "sqlite" {
+		store, err := cs.openCityLocalStore(provider)
 		if err == nil {
 			sharedFileStore = store
 			sharedMailProv = beadmail.New(store)
@@ -129,8 +130,8 @@ func (cs *controllerState) openRigStore(provider, rigPath string) beads.Store {
 		return s
 	}
 	switch provider {
-	case "file":
-		store, err := beads.OpenFileStore(fsys.OSFS{}, filepath.Join(cs.cityPath, ".gc", "beads.json"))
+	case "file", "sqlite":
+		store, err := cs.openCityLocalStore(provider)
 		if err != nil {
 			return beads.NewBdStore(rigPath, beads.ExecCommandRunner())
 		}
@@ -140,6 +141,15 @@ func (cs *controllerState) openRigStore(provider, rigPath string) beads.Store {
 	}
 }
 
+// openCityLocalStore opens the city-level store for providers that keep
+// all beads in a single file under .gc/ ("file" or "sqlite").
+func (cs *controllerState) openCityLocalStore(provider string) (beads.Store, error) {
+	if provider == "sqlite" {
+		return beads.OpenSQLiteStore(fsys.OSFS{}, filepath.Join(cs.cityPath, ".gc", "beads.db"))
+	}
+	return beads.OpenFileStore(fsys.OSFS{}, filepath.Join(cs.cityPath, ".gc", "beads.json"))
+}
+
 // update replaces the config, session provider, and reopens stores.
 // Stores are built outside the lock to avoid blocking readers during I/O.
 func (cs *controllerState) update(cfg *config.City, sp runtime.Provider) {
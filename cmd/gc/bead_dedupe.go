@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/spf13/cobra"
+)
+
+// dupThreshold is the title similarity at or above which two beads are
+// reported as likely duplicates.
+const dupThreshold = 0.8
+
+// dupDepType is the dependency type linking a merged bead to the bead it
+// duplicated. It does not block.
+const dupDepType = "duplicates"
+
+// dupStopwords are dropped from titles before comparing them.
+var dupStopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "to": true, "of": true, "in": true,
+	"on": true, "for": true, "and": true, "or": true, "with": true, "is": true,
+}
+
+// titleTokens returns the normalized words of a title: lowercased,
+// split on anything but letters and digits, stopwords dropped, and a
+// plural "s" trimmed from longer words.
+func titleTokens(title string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make(map[string]bool, len(words))
+	for _, w := range words {
+		if dupStopwords[w] {
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		tokens[w] = true
+	}
+	return tokens
+}
+
+// titleSimilarity scores two titles from 0 to 1 by token overlap (the
+// Dice coefficient of their normalized words).
+func titleSimilarity(a, b string) float64 {
+	ta, tb := titleTokens(a), titleTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range ta {
+		if tb[w] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ta)+len(tb))
+}
+
+// dupMatch is an existing bead whose title resembles another's.
+type dupMatch struct {
+	Bead  beads.Bead
+	Score float64
+}
+
+// unclosedBeadsOfType returns the open and in-progress beads of type
+// typ, oldest first.
+func unclosedBeadsOfType(store beads.Store, typ string) ([]beads.Bead, error) {
+	if typ == "" {
+		typ = "task"
+	}
+	var list []beads.Bead
+	for _, status := range []string{"open", "in_progress"} {
+		bs, err := store.Query(beads.Filter{Status: status, Type: typ, Sort: "created"})
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, bs...)
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+// findSimilarBeads returns the unclosed beads of b's type whose titles
+// score at least threshold against b's, most similar first.
+func findSimilarBeads(store beads.Store, b beads.Bead, threshold float64) ([]dupMatch, error) {
+	list, err := unclosedBeadsOfType(store, b.Type)
+	if err != nil {
+		return nil, err
+	}
+	var out []dupMatch
+	for _, other := range list {
+		if other.ID == b.ID {
+			continue
+		}
+		if s := titleSimilarity(b.Title, other.Title); s >= threshold {
+			out = append(out, dupMatch{Bead: other, Score: s})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	return out, nil
+}
+
+// dupGroup is a bead and the later beads that look like duplicates of it.
+type dupGroup struct {
+	Keep beads.Bead
+	Dups []dupMatch
+}
+
+// dedupeGroups clusters list (oldest first) into duplicate groups: each
+// ungrouped bead keeps every later ungrouped bead similar to it. Beads
+// without duplicates are left out.
+func dedupeGroups(list []beads.Bead, threshold float64) []dupGroup {
+	grouped := make(map[string]bool)
+	var groups []dupGroup
+	for i, keep := range list {
+		if grouped[keep.ID] {
+			continue
+		}
+		g := dupGroup{Keep: keep}
+		for _, other := range list[i+1:] {
+			if grouped[other.ID] {
+				continue
+			}
+			if s := titleSimilarity(keep.Title, other.Title); s >= threshold {
+				g.Dups = append(g.Dups, dupMatch{Bead: other, Score: s})
+				grouped[other.ID] = true
+			}
+		}
+		if len(g.Dups) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// mergeableDup reports whether a duplicate can be closed without taking
+// work away from anyone: it must be open and unassigned.
+func mergeableDup(b beads.Bead) bool {
+	return b.Status == "open" && b.Assignee == ""
+}
+
+func newBeadDedupeCmd(stdout, stderr io.Writer) *cobra.Command {
+	var typ string
+	var threshold float64
+	var apply bool
+	cmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "Find open beads with near-identical titles and merge them",
+		Long: `Group unclosed beads whose titles are near-identical and propose
+merging each group into its oldest bead.
+
+Titles are compared by normalized word overlap (case, punctuation,
+plurals, and filler words ignored); --threshold sets how close they
+must be, from 0 to 1. Only beads of the same type are compared.
+
+Without --apply nothing changes. With --apply each open, unassigned
+duplicate is folded into the bead it repeats as by "gc bead merge".
+Duplicates already assigned or in progress are listed but left alone.`,
+		Example: `  gc bead dedupe
+  gc bead dedupe --threshold 0.7 --type bug
+  gc bead dedupe --apply`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdBeadDedupe(typ, threshold, apply, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&typ, "type", "t", "task", "bead type to check")
+	cmd.Flags().Float64Var(&threshold, "threshold", dupThreshold, "title similarity (0-1) at which beads count as duplicates")
+	cmd.Flags().BoolVar(&apply, "apply", false, "close open, unassigned duplicates instead of only listing them")
+	return cmd
+}
+
+// cmdBeadDedupe is the CLI entry point for gc bead dedupe.
+func cmdBeadDedupe(typ string, threshold float64, apply bool, stdout, stderr io.Writer) int {
+	store, code := openCityStore(stderr, "gc bead dedupe")
+	if store == nil {
+		return code
+	}
+	return doBeadDedupe(store, typ, threshold, apply, eventActor(), stdout, stderr)
+}
+
+// doBeadDedupe lists duplicate groups among the unclosed beads of type
+// typ and, with apply, closes the mergeable duplicates.
+func doBeadDedupe(store beads.Store, typ string, threshold float64, apply bool, actor string, stdout, stderr io.Writer) int {
+	if threshold <= 0 || threshold > 1 {
+		fmt.Fprintf(stderr, "gc bead dedupe: --threshold must be in (0, 1], got %g\n", threshold) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	list, err := unclosedBeadsOfType(store, typ)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead dedupe: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	groups := dedupeGroups(list, threshold)
+	if len(groups) == 0 {
+		fmt.Fprintln(stdout, "No duplicates found") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	dups, merged, failed := 0, 0, 0
+	for _, g := range groups {
+		fmt.Fprintln(stdout, formatBeadLabel(g.Keep.ID, g.Keep.Title)) //nolint:errcheck // best-effort stdout
+		for _, d := range g.Dups {
+			dups++
+			note := ""
+			if !mergeableDup(d.Bead) {
+				note = fmt.Sprintf(" — %s, left alone", dupHeldBy(d.Bead))
+			}
+			fmt.Fprintf(stdout, "  ← %s (%.0f%%)%s\n", formatBeadLabel(d.Bead.ID, d.Bead.Title), d.Score*100, note) //nolint:errcheck // best-effort stdout
+			if !apply || note != "" {
+				continue
+			}
+			if _, err := mergeBeads(store, d.Bead.ID, g.Keep.ID, actor); err != nil {
+				fmt.Fprintf(stderr, "gc bead dedupe: %s: %v\n", d.Bead.ID, err) //nolint:errcheck // best-effort stderr
+				failed++
+				continue
+			}
+			merged++
+		}
+	}
+	if apply {
+		fmt.Fprintf(stdout, "Closed %d of %d duplicate(s) in %d group(s)\n", merged, dups, len(groups)) //nolint:errcheck // best-effort stdout
+	} else {
+		fmt.Fprintf(stdout, "%d duplicate(s) in %d group(s); run with --apply to close them\n", dups, len(groups)) //nolint:errcheck // best-effort stdout
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// dupHeldBy describes why a duplicate is not merged automatically.
+func dupHeldBy(b beads.Bead) string {
+	if b.Assignee != "" {
+		return b.Status + ", assigned to " + b.Assignee
+	}
+	return b.Status
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		dup  bool
+	}{
+		{"Fix login redirect", "fix the login redirect", true},
+		{"Fix login redirects", "Fix login redirect.", true},
+		{"Fix login redirect", "Fix logout redirect", false},
+		{"Write release notes", "Fix login redirect", false},
+		{"", "Fix login redirect", false},
+	} {
+		if got := titleSimilarity(tc.a, tc.b) >= dupThreshold; got != tc.dup {
+			t.Errorf("titleSimilarity(%q, %q) = %.2f, dup = %v, want %v", tc.a, tc.b, titleSimilarity(tc.a, tc.b), got, tc.dup)
+		}
+	}
+}
+
+func TestDoBeadCreateRejectsDuplicate(t *testing.T) {
+	store := beads.NewMemStore()
+	if _, err := store.Create(beads.Bead{Title: "Fix login redirect"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Create(beads.Bead{Title: "Fix login redirect", Type: "bug"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doBeadCreate(store, beads.Bead{Title: "fix the login redirect"}, "", "", time.Now(), false, false, &stdout, &stderr); code != 1 {
+		t.Fatalf("doBeadCreate = %d, want 1", code)
+	}
+	for _, want := range []string{"looks like a duplicate", "gc-1", "100% similar, open", "--force"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr missing %q:\n%s", want, stderr.String())
+		}
+	}
+	if strings.Contains(stderr.String(), "gc-2") {
+		t.Errorf("bead of another type reported as duplicate:\n%s", stderr.String())
+	}
+
+	stderr.Reset()
+	if code := doBeadCreate(store, beads.Bead{Title: "fix the login redirect"}, "", "", time.Now(), true, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadCreate(force) = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Created gc-3") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
+
+func TestDoBeadDedupe(t *testing.T) {
+	store := beads.NewMemStore()
+	for _, b := range []beads.Bead{
+		{Title: "Fix login redirect"},                     // gc-1 keeper
+		{Title: "Write release notes"},                    // gc-2
+		{Title: "fix the login redirect"},                 // gc-3 merged
+		{Title: "Fix login redirects", Assignee: "mayor"}, // gc-4 assigned, kept
+		{Title: "Release notes: write"},                   // gc-5 merged into gc-2
+	} {
+		if _, err := store.Create(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doBeadDedupe(store, "task", dupThreshold, false, "human", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadDedupe = %d; stderr: %s", code, stderr.String())
+	}
+	for _, want := range []string{"gc-1", "  ← gc-3", "gc-4", "assigned to mayor, left alone", "  ← gc-5", "3 duplicate(s) in 2 group(s)"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+	if b, _ := store.Get("gc-3"); b.Status != "open" {
+		t.Fatalf("dry run closed gc-3")
+	}
+
+	stdout.Reset()
+	if code := doBeadDedupe(store, "task", dupThreshold, true, "human", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadDedupe(apply) = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Closed 2 of 3 duplicate(s) in 2 group(s)") {
+		t.Errorf("apply output = %q", stdout.String())
+	}
+	for id, keep := range map[string]string{"gc-3": "gc-1", "gc-5": "gc-2"} {
+		b, _ := store.Get(id)
+		if b.Status != "closed" {
+			t.Errorf("%s status = %q, want closed", id, b.Status)
+		}
+		deps, _ := store.DepList(id, "down")
+		if len(deps) != 1 || deps[0].DependsOnID != keep || deps[0].Type != dupDepType {
+			t.Errorf("%s deps = %+v, want duplicates of %s", id, deps, keep)
+		}
+	}
+	if b, _ := store.Get("gc-4"); b.Status != "open" {
+		t.Errorf("assigned duplicate gc-4 closed")
+	}
+
+	if code := doBeadDedupe(store, "task", 1.5, false, "human", &stdout, &stderr); code != 1 {
+		t.Errorf("bad threshold = %d, want 1", code)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/gastownhall/gascity/internal/beads"
 )
@@ -108,13 +109,80 @@ func writeBeadDetail(b beads.Bead, stdout io.Writer) {
 	w(fmt.Sprintf("ID:       %s", b.ID))
 	w(fmt.Sprintf("Status:   %s", b.Status))
 	w(fmt.Sprintf("Type:     %s", b.Type))
+	w(fmt.Sprintf("Priority: %s", beads.FormatPriority(b.PriorityOrDefault())))
 	w(fmt.Sprintf("Title:    %s", b.Title))
 	w(fmt.Sprintf("Created:  %s", b.CreatedAt.Format("2006-01-02 15:04:05")))
+	if b.ClosedAt != nil {
+		w(fmt.Sprintf("Closed:   %s", b.ClosedAt.Local().Format("2006-01-02 15:04:05")))
+	}
+	if b.DueAt != nil {
+		due := b.DueAt.Local().Format("2006-01-02 15:04")
+		if now := time.Now(); b.Overdue(now) {
+			due += fmt.Sprintf(" (overdue by %s)", now.Sub(*b.DueAt).Round(time.Minute))
+		}
+		w(fmt.Sprintf("Due:      %s", due))
+	}
 	assignee := b.Assignee
 	if assignee == "" {
 		assignee = "\u2014"
 	}
 	w(fmt.Sprintf("Assignee: %s", assignee))
+	if b.ParentID != "" {
+		w(fmt.Sprintf("Parent:   %s", b.ParentID))
+	}
+	if len(b.Labels) > 0 {
+		w(fmt.Sprintf("Labels:   %s", strings.Join(b.Labels, ", ")))
+	}
+	if state := b.ReviewState(); state != "" {
+		w(fmt.Sprintf("Review:   %s (%s)", state, b.Metadata[beads.ReviewerKey]))
+	}
+	if b.Description != "" {
+		w("")
+		w(b.Description)
+	}
+	if len(b.Attachments) > 0 {
+		w("")
+		w(fmt.Sprintf("Attachments (%d):", len(b.Attachments)))
+		for _, a := range b.Attachments {
+			w(fmt.Sprintf("  %-7s %s", a.Kind, a.Ref))
+		}
+	}
+	if len(b.Comments) > 0 {
+		w("")
+		w(fmt.Sprintf("Comments (%d):", len(b.Comments)))
+		for _, c := range b.Comments {
+			author := c.Author
+			if author == "" {
+				author = "\u2014"
+			}
+			w(fmt.Sprintf("  %s  %s", c.CreatedAt.Format("2006-01-02 15:04"), author))
+			for _, line := range strings.Split(c.Text, "\n") {
+				w("    " + line)
+			}
+		}
+	}
+}
+
+// writeBeadHistory writes a bead's status and assignee transitions,
+// oldest first.
+func writeBeadHistory(b beads.Bead, stdout io.Writer) {
+	w := func(s string) { fmt.Fprintln(stdout, s) } //nolint:errcheck // best-effort stdout
+	w("")
+	if len(b.Events) == 0 {
+		w("History: none recorded")
+		return
+	}
+	w(fmt.Sprintf("History (%d):", len(b.Events)))
+	orDash := func(s string) string {
+		if s == "" {
+			return "\u2014"
+		}
+		return s
+	}
+	for _, e := range b.Events {
+		w(fmt.Sprintf("  %s  %-10s %s: %s \u2192 %s", e.At.Local().Format("2006-01-02 15:04:05"),
+			orDash(e.Actor), e.Field, orDash(e.From), orDash(e.To)))
+	}
 }
 
 // writeBeadTable writes beads in a tab-aligned table. If showAssignee is true,
@@ -122,18 +190,18 @@ func writeBeadDetail(b beads.Bead, stdout io.Writer) {
 func writeBeadTable(bs []beads.Bead, stdout io.Writer, showAssignee bool) {
 	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
 	if showAssignee {
-		fmt.Fprintln(tw, "ID\tSTATUS\tASSIGNEE\tTITLE") //nolint:errcheck // best-effort stdout
+		fmt.Fprintln(tw, "ID\tSTATUS\tPRI\tASSIGNEE\tTITLE") //nolint:errcheck // best-effort stdout
 		for _, b := range bs {
 			assignee := b.Assignee
 			if assignee == "" {
 				assignee = "\u2014"
 			}
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", b.ID, b.Status, assignee, b.Title) //nolint:errcheck // best-effort stdout
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", b.ID, b.Status, beads.FormatPriority(b.PriorityOrDefault()), assignee, b.Title) //nolint:errcheck // best-effort stdout
 		}
 	} else {
-		fmt.Fprintln(tw, "ID\tSTATUS\tTITLE") //nolint:errcheck // best-effort stdout
+		fmt.Fprintln(tw, "ID\tSTATUS\tPRI\tTITLE") //nolint:errcheck // best-effort stdout
 		for _, b := range bs {
-			fmt.Fprintf(tw, "%s\t%s\t%s\n", b.ID, b.Status, b.Title) //nolint:errcheck // best-effort stdout
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", b.ID, b.Status, beads.FormatPriority(b.PriorityOrDefault()), b.Title) //nolint:errcheck // best-effort stdout
 		}
 	}
 	tw.Flush() //nolint:errcheck // best-effort stdout
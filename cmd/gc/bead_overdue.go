@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+)
+
+// overdueWarnedMeta records the due date an overdue warning was issued
+// for. Each deadline is reported once; moving the due date re-arms it.
+const overdueWarnedMeta = "gc.overdue_warned"
+
+// overdueCheckInterval throttles the controller's overdue scan, which
+// lists beads and so is costlier than most per-tick work.
+const overdueCheckInterval = time.Minute
+
+// overdueTick reports beads that have passed their due date since the
+// last scan: each is logged, recorded as a bead.overdue event, and —
+// with [daemon] nudge_overdue — announced to its assignee by queued
+// nudge.
+func (cr *CityRuntime) overdueTick(now time.Time) {
+	if now.Sub(cr.overdueCheckedAt) < overdueCheckInterval {
+		return
+	}
+	cr.overdueCheckedAt = now
+	store := cr.cityBeadStore()
+	if store == nil {
+		return
+	}
+	late, err := newlyOverdueBeads(store, now)
+	if err != nil {
+		fmt.Fprintf(cr.stderr, "%s: overdue check: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+	}
+	for _, b := range late {
+		msg := overdueMessage(b, now)
+		fmt.Fprintf(cr.stderr, "%s: %s\n", cr.logPrefix, msg) //nolint:errcheck // best-effort stderr
+		cr.rec.Record(events.Event{
+			Type:    events.BeadOverdue,
+			Actor:   "gc",
+			Subject: b.ID,
+			Message: msg,
+		})
+		if cr.cfg.Daemon.NudgeOverdue {
+			if err := nudgeOverdueAssignee(cr.cityPath, cr.cfg, b, msg, now); err != nil {
+				fmt.Fprintf(cr.stderr, "%s: overdue nudge %s: %v\n", cr.logPrefix, b.ID, err) //nolint:errcheck // best-effort stderr
+			}
+		}
+	}
+}
+
+// newlyOverdueBeads returns the unclosed beads past their due date that
+// have not yet been warned about for that date, and marks them warned.
+// A bead whose marker cannot be written is still returned, so it may be
+// reported again on the next scan.
+func newlyOverdueBeads(store beads.Store, now time.Time) ([]beads.Bead, error) {
+	list, err := store.Query(beads.Filter{DueBefore: now, Sort: "due"})
+	if err != nil {
+		return nil, fmt.Errorf("listing overdue beads: %w", err)
+	}
+	var late []beads.Bead
+	var errs []error
+	for _, b := range list {
+		stamp := b.DueAt.UTC().Format(time.RFC3339)
+		if b.Metadata[overdueWarnedMeta] == stamp {
+			continue
+		}
+		if err := store.SetMetadata(b.ID, overdueWarnedMeta, stamp); err != nil {
+			errs = append(errs, err)
+		}
+		late = append(late, b)
+	}
+	return late, errors.Join(errs...)
+}
+
+// overdueMessage describes an overdue bead for logs, events, and nudges.
+func overdueMessage(b beads.Bead, now time.Time) string {
+	by := now.Sub(*b.DueAt).Round(time.Minute)
+	if by < time.Minute {
+		by = time.Minute
+	}
+	msg := fmt.Sprintf("bead %s %q is overdue by %s (due %s)", b.ID, b.Title, by, b.DueAt.Local().Format("2006-01-02 15:04"))
+	if b.Assignee != "" {
+		msg += "; assigned to " + b.Assignee
+	}
+	return msg
+}
+
+// nudgeOverdueAssignee queues a nudge for the agent a bead is assigned
+// to. Unassigned beads, and assignees that don't name a configured agent
+// (e.g. humans), are skipped.
+func nudgeOverdueAssignee(cityPath string, cfg *config.City, b beads.Bead, msg string, now time.Time) error {
+	if b.Assignee == "" {
+		return nil
+	}
+	agent, ok := resolveAgentIdentity(cfg, b.Assignee, "")
+	if !ok {
+		return nil
+	}
+	text := msg + ". Finish it, or update the bead (gc bead comment " + b.ID + ") with what's blocking it."
+	return enqueueQueuedNudge(cityPath, newQueuedNudge(agent.QualifiedName(), text, "overdue", now))
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func TestNewlyOverdueBeadsWarnsOncePerDueDate(t *testing.T) {
+	store := beads.NewMemStore()
+	now := time.Now()
+	past, future := now.Add(-2*time.Hour), now.Add(time.Hour)
+	late, _ := store.Create(beads.Bead{Title: "late", DueAt: &past, Assignee: "worker"})
+	store.Create(beads.Bead{Title: "on time", DueAt: &future}) //nolint:errcheck
+
+	got, err := newlyOverdueBeads(store, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != late.ID {
+		t.Fatalf("first scan = %v, want [%s]", got, late.ID)
+	}
+	if got, _ := newlyOverdueBeads(store, now.Add(time.Minute)); len(got) != 0 {
+		t.Errorf("second scan re-reported %v", got)
+	}
+
+	// Moving the deadline re-arms the warning.
+	moved := now.Add(-time.Minute)
+	store.Update(late.ID, beads.UpdateOpts{DueAt: &moved}) //nolint:errcheck
+	if got, _ := newlyOverdueBeads(store, now); len(got) != 1 {
+		t.Errorf("after moving due date = %v, want 1 bead", got)
+	}
+}
+
+func TestNudgeOverdueAssignee(t *testing.T) {
+	cityPath := t.TempDir()
+	cfg := &config.City{Agents: []config.Agent{{Name: "worker"}}}
+	now := time.Now()
+	due := now.Add(-time.Hour)
+	b := beads.Bead{ID: "gc-7", Title: "ship it", Assignee: "worker", DueAt: &due}
+
+	if err := nudgeOverdueAssignee(cityPath, cfg, b, overdueMessage(b, now), now); err != nil {
+		t.Fatal(err)
+	}
+	pending, _, _, err := listQueuedNudges(cityPath, "worker", now.Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || !strings.Contains(pending[0].Message, `bead gc-7 "ship it" is overdue by 1h0m0s`) {
+		t.Fatalf("pending = %+v", pending)
+	}
+
+	// Assignees that aren't configured agents are skipped.
+	b.Assignee = "alice"
+	if err := nudgeOverdueAssignee(cityPath, cfg, b, "late", now); err != nil {
+		t.Fatal(err)
+	}
+	if pending, _, _, _ := listQueuedNudges(cityPath, "alice", now.Add(time.Second)); len(pending) != 0 {
+		t.Errorf("queued nudge for non-agent: %+v", pending)
+	}
+}
+
+func TestParseDueAt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		in   string
+		want time.Time
+	}{
+		{"4h", now.Add(4 * time.Hour)},
+		{"3d", now.AddDate(0, 0, 3)},
+		{"2026-03-05", time.Date(2026, 3, 5, 23, 59, 59, 0, time.UTC)},
+		{"2026-03-05 15:00", time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)},
+		{"2026-03-05T15:00:00Z", time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)},
+	} {
+		got, err := parseDueAt(tt.in, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseDueAt(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseDueAt("next tuesday", now); err == nil {
+		t.Error("parseDueAt(next tuesday): want error")
+	}
+}
+
+func TestDoBeadListOverdue(t *testing.T) {
+	store := beads.NewMemStore()
+	var stdout, stderr bytes.Buffer
+	past := time.Now().Add(-48 * time.Hour)
+	if code := doBeadCreate(store, beads.Bead{Title: "late report"}, "", "1h", past, false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadCreate = %d; stderr: %s", code, stderr.String())
+	}
+	if code := doBeadCreate(store, beads.Bead{Title: "next week"}, "", "7d", time.Now(), false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadCreate = %d; stderr: %s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := doBeadList(store, beads.Filter{DueBefore: time.Now()}, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadList = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "late report") || strings.Contains(stdout.String(), "next week") {
+		t.Errorf("overdue list:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := doBeadShow(store, "", "gc-1", false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadShow = %d", code)
+	}
+	if !strings.Contains(stdout.String(), "(overdue by 47h0m0s)") {
+		t.Errorf("show output missing overdue note:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// beadTemplateDir is where bead templates live, relative to the city root.
+const beadTemplateDir = "templates/beads"
+
+// beadTemplate is a parsed templates/beads/<name>.md file: an optional
+// +++-delimited TOML header with default fields, then the markdown body
+// that becomes the bead's description.
+type beadTemplate struct {
+	Title    string   `toml:"title"`
+	Type     string   `toml:"type"`
+	Priority string   `toml:"priority"`
+	Labels   []string `toml:"labels"`
+	Requires []string `toml:"requires"`
+	Body     string   `toml:"-"`
+}
+
+// parseBeadTemplate splits a template into its TOML header and body.
+func parseBeadTemplate(data []byte) (beadTemplate, error) {
+	var t beadTemplate
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if rest, ok := strings.CutPrefix(text, "+++\n"); ok {
+		header, body, found := strings.Cut(rest, "\n+++")
+		if !found {
+			return beadTemplate{}, errors.New("unterminated +++ header")
+		}
+		if _, err := toml.Decode(header, &t); err != nil {
+			return beadTemplate{}, fmt.Errorf("parsing header: %w", err)
+		}
+		text = strings.TrimPrefix(body, "\n")
+	}
+	t.Body = strings.TrimSpace(text)
+	return t, nil
+}
+
+// loadBeadTemplate reads templates/beads/<name>.md from the city.
+func loadBeadTemplate(cityPath, name string) (beadTemplate, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return beadTemplate{}, fmt.Errorf("invalid template name %q", name)
+	}
+	data, err := os.ReadFile(filepath.Join(cityPath, beadTemplateDir, strings.TrimSuffix(name, ".md")+".md"))
+	if errors.Is(err, os.ErrNotExist) {
+		if names := beadTemplateNames(cityPath); len(names) > 0 {
+			return beadTemplate{}, fmt.Errorf("template %q not found (available: %s)", name, strings.Join(names, ", "))
+		}
+		return beadTemplate{}, fmt.Errorf("template %q not found in %s", name, beadTemplateDir)
+	}
+	if err != nil {
+		return beadTemplate{}, err
+	}
+	t, err := parseBeadTemplate(data)
+	if err != nil {
+		return beadTemplate{}, fmt.Errorf("template %q: %w", name, err)
+	}
+	return t, nil
+}
+
+// beadTemplateNames lists the city's bead templates, sorted.
+func beadTemplateNames(cityPath string) []string {
+	paths, _ := filepath.Glob(filepath.Join(cityPath, beadTemplateDir, "*.md"))
+	names := make([]string, 0, len(paths))
+	for _, p := range paths {
+		names = append(names, strings.TrimSuffix(filepath.Base(p), ".md"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyBeadTemplate fills fields left empty on the command line from the
+// template. Template labels, then its requires, come before any given
+// with --label or --requires.
+func applyBeadTemplate(b *beads.Bead, priority *string, t beadTemplate) {
+	if b.Title == "" {
+		b.Title = t.Title
+	}
+	if b.Type == "" {
+		b.Type = t.Type
+	}
+	if *priority == "" {
+		*priority = t.Priority
+	}
+	if b.Description == "" {
+		b.Description = t.Body
+	}
+	if len(t.Labels) > 0 || len(t.Requires) > 0 {
+		labels := append([]string(nil), t.Labels...)
+		for _, c := range t.Requires {
+			labels = append(labels, beads.RequiresLabelPrefix+c)
+		}
+		b.Labels = append(labels, b.Labels...)
+	}
+}
+
+// readBeadBody reads a bead description from path, or from stdin when
+// path is "-".
+func readBeadBody(path string, stdin io.Reader) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
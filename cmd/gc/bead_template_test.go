@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestParseBeadTemplate(t *testing.T) {
+	tmpl, err := parseBeadTemplate([]byte("+++\ntype = \"bug\"\npriority = \"P1\"\nlabels = [\"triage\"]\n+++\n\n## Steps to reproduce\n\n1.\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Type != "bug" || tmpl.Priority != "P1" || strings.Join(tmpl.Labels, ",") != "triage" {
+		t.Errorf("header = %+v", tmpl)
+	}
+	if tmpl.Body != "## Steps to reproduce\n\n1." {
+		t.Errorf("body = %q", tmpl.Body)
+	}
+
+	plain, err := parseBeadTemplate([]byte("Just a body.\n"))
+	if err != nil || plain.Body != "Just a body." || plain.Type != "" {
+		t.Errorf("plain = %+v, %v", plain, err)
+	}
+
+	if _, err := parseBeadTemplate([]byte("+++\ntype = \"bug\"\n")); err == nil {
+		t.Error("unterminated header accepted")
+	}
+}
+
+func TestLoadBeadTemplate(t *testing.T) {
+	city := t.TempDir()
+	dir := filepath.Join(city, beadTemplateDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bug-report.md"), []byte("+++\ntitle = \"Bug\"\n+++\nbody\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := loadBeadTemplate(city, "bug-report")
+	if err != nil || tmpl.Title != "Bug" || tmpl.Body != "body" {
+		t.Fatalf("loadBeadTemplate = %+v, %v", tmpl, err)
+	}
+	if _, err := loadBeadTemplate(city, "missing"); err == nil || !strings.Contains(err.Error(), "available: bug-report") {
+		t.Errorf("missing template err = %v", err)
+	}
+	if _, err := loadBeadTemplate(city, "../secrets"); err == nil {
+		t.Error("path traversal accepted")
+	}
+}
+
+func TestApplyBeadTemplate(t *testing.T) {
+	tmpl := beadTemplate{Title: "Bug", Type: "bug", Priority: "P1", Labels: []string{"triage"}, Requires: []string{"go"}, Body: "template body"}
+
+	b := beads.Bead{Title: "Checkout 500s", Labels: []string{"area:pay"}}
+	priority := ""
+	applyBeadTemplate(&b, &priority, tmpl)
+	if b.Title != "Checkout 500s" || b.Type != "bug" || priority != "P1" || b.Description != "template body" {
+		t.Errorf("bead = %+v, priority %q", b, priority)
+	}
+	if strings.Join(b.Labels, ",") != "triage,requires:go,area:pay" {
+		t.Errorf("labels = %v", b.Labels)
+	}
+
+	// Flags win over the template.
+	b = beads.Bead{Type: "task", Description: "mine"}
+	priority = "P3"
+	applyBeadTemplate(&b, &priority, tmpl)
+	if b.Title != "Bug" || b.Type != "task" || priority != "P3" || b.Description != "mine" {
+		t.Errorf("overridden bead = %+v, priority %q", b, priority)
+	}
+}
+
+func TestReadBeadBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.md")
+	if err := os.WriteFile(path, []byte("# Plan\n\nDo it.\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := readBeadBody(path, nil); err != nil || got != "# Plan\n\nDo it." {
+		t.Errorf("readBeadBody(file) = %q, %v", got, err)
+	}
+	if got, err := readBeadBody("-", strings.NewReader("from stdin\n")); err != nil || got != "from stdin" {
+		t.Errorf("readBeadBody(-) = %q, %v", got, err)
+	}
+}
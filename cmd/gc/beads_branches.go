@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+)
+
+// branchMergeInterval throttles the controller's agent-branch merge,
+// which runs several dolt queries per branch.
+const branchMergeInterval = time.Minute
+
+// agentBranchesOn reports whether [beads] agent_branches asks for
+// per-agent Dolt branches. Unknown modes are off.
+func agentBranchesOn(cfg *config.City) bool {
+	return cfg.Beads.AgentBranches == "manual" || cfg.Beads.AgentBranches == "auto"
+}
+
+// openBdStore returns a BdStore for the bd database under dir. In an
+// agent session (GC_AGENT set) with [beads] agent_branches on, bd is
+// pointed at the agent's branch, created from main on first use.
+func openBdStore(cityPath, dir string) (beads.Store, error) {
+	agent := os.Getenv("GC_AGENT")
+	if agent == "" {
+		return beads.NewBdStore(dir, beads.ExecCommandRunner()), nil
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil || !agentBranchesOn(cfg) {
+		return beads.NewBdStore(dir, beads.ExecCommandRunner()), nil
+	}
+	br, err := doltBranchesFor(cityPath, dir)
+	if err != nil {
+		return nil, err
+	}
+	branch := beads.AgentBranch(agent)
+	if err := br.Ensure(branch); err != nil {
+		return nil, err
+	}
+	branchDir := filepath.Join(cityPath, ".gc", "runtime", "beads-branches", br.Database(), branch)
+	if err := beads.PrepareBranchBeadsDir(filepath.Join(dir, ".beads"), branchDir, branch); err != nil {
+		return nil, fmt.Errorf("preparing branch %s: %w", branch, err)
+	}
+	return beads.NewBdStore(dir, beads.ExecCommandRunnerWithEnv(map[string]string{"BEADS_DIR": branchDir})), nil
+}
+
+// doltBranchesFor returns the branch manager for the bd database under
+// dir, reaching the city's dolt server through the GC_DOLT_* settings.
+func doltBranchesFor(cityPath, dir string) (*beads.DoltBranches, error) {
+	db, err := beads.ReadDoltDatabase(filepath.Join(dir, ".beads"))
+	if err != nil {
+		return nil, err
+	}
+	readDoltPort(cityPath)
+	conn := beads.DoltConn{
+		Host:     os.Getenv("GC_DOLT_HOST"),
+		Port:     os.Getenv("GC_DOLT_PORT"),
+		User:     os.Getenv("GC_DOLT_USER"),
+		Password: os.Getenv("GC_DOLT_PASSWORD"),
+	}
+	if conn.Host == "" || conn.Host == "0.0.0.0" {
+		conn.Host = "127.0.0.1"
+	}
+	if conn.User == "" {
+		conn.User = "root"
+	}
+	if conn.Port == "" {
+		return nil, fmt.Errorf("dolt server port unknown; is the city's bead store running?")
+	}
+	return beads.NewDoltBranches(dir, db, conn, beads.ExecCommandRunner()), nil
+}
+
+// cityDoltBranches returns a branch manager for the city's bd database
+// and for each rig's. Rigs whose database cannot be found are skipped.
+func cityDoltBranches(cityPath string, cfg *config.City) ([]*beads.DoltBranches, error) {
+	city, err := doltBranchesFor(cityPath, cityPath)
+	if err != nil {
+		return nil, err
+	}
+	out := []*beads.DoltBranches{city}
+	rigs := append([]config.Rig(nil), cfg.Rigs...)
+	resolveRigPaths(cityPath, rigs)
+	for _, r := range rigs {
+		if br, err := doltBranchesFor(cityPath, r.Path); err == nil {
+			out = append(out, br)
+		}
+	}
+	return out, nil
+}
+
+// branchMergeTick merges every agent branch with changes into main when
+// [beads] agent_branches = "auto". Each merge is logged and recorded as
+// a beads.branch_merged event; a branch that fails to merge (e.g. on a
+// conflict) is reported and left for gc beads branch merge.
+func (cr *CityRuntime) branchMergeTick(now time.Time) {
+	if cr.cfg.Beads.AgentBranches != "auto" || rawBeadsProvider(cr.cityPath) != "bd" {
+		return
+	}
+	if now.Sub(cr.branchMergedAt) < branchMergeInterval {
+		return
+	}
+	cr.branchMergedAt = now
+	sets, err := cityDoltBranches(cr.cityPath, cr.cfg)
+	if err != nil {
+		fmt.Fprintf(cr.stderr, "%s: agent branches: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+		return
+	}
+	for _, br := range sets {
+		merged, err := mergeChangedBranches(br, nil)
+		for _, m := range merged {
+			msg := fmt.Sprintf("merged %d bead changes from %s into %s", m.changes, m.branch, br.Database())
+			fmt.Fprintf(cr.stdout, "%s: %s\n", cr.logPrefix, msg) //nolint:errcheck // best-effort stdout
+			cr.rec.Record(events.Event{
+				Type:    events.BeadsBranchMerged,
+				Actor:   "gc",
+				Subject: m.branch,
+				Message: msg,
+			})
+		}
+		if err != nil {
+			fmt.Fprintf(cr.stderr, "%s: agent branches: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+		}
+	}
+}
+
+// branchMerge is one agent branch merged into main.
+type branchMerge struct {
+	branch  string
+	changes int
+}
+
+// mergeChangedBranches merges each of br's agent branches that has
+// changes, limited to only when non-empty, and returns the merges made.
+// It keeps going past a failed branch and returns the first error.
+func mergeChangedBranches(br *beads.DoltBranches, only []string) ([]branchMerge, error) {
+	branches := only
+	if len(branches) == 0 {
+		var err error
+		if branches, err = br.List(); err != nil {
+			return nil, err
+		}
+	}
+	var merged []branchMerge
+	var firstErr error
+	for _, b := range branches {
+		changes, err := br.Diff(b)
+		if err == nil && len(changes) > 0 {
+			err = br.Merge(b)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if len(changes) > 0 {
+			merged = append(merged, branchMerge{branch: b, changes: len(changes)})
+		}
+	}
+	return merged, firstErr
+}
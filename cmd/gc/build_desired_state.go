@@ -12,6 +12,7 @@ import (
 	"github.com/gastownhall/gascity/internal/hooks"
 	"github.com/gastownhall/gascity/internal/runtime"
 	sessionauto "github.com/gastownhall/gascity/internal/runtime/auto"
+	sessionssh "github.com/gastownhall/gascity/internal/runtime/ssh"
 )
 
 // buildDesiredState computes the desired session state from config,
@@ -55,6 +56,13 @@ func buildDesiredState(
 		poolDir  string
 	}
 
+	// Pools under manual control (gc pool scale / stop-instance).
+	overrides, err := loadPoolOverrides(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "buildDesiredState: %v (ignoring)\n", err) //nolint:errcheck
+		overrides = poolOverrides{}
+	}
+
 	desired := make(map[string]TemplateParams)
 	var pendingPools []poolEvalWork
 
@@ -121,6 +129,10 @@ func buildDesiredState(
 	evalResults := make([]poolEvalResult, len(pendingPools))
 	var wg sync.WaitGroup
 	for j, pw := range pendingPools {
+		if n, ok := overrides[cfg.Agents[pw.agentIdx].QualifiedName()].manualSize(pw.pool); ok {
+			evalResults[j] = poolEvalResult{desired: n}
+			continue
+		}
 		wg.Add(1)
 		go func(idx int, name string, pool config.PoolConfig, dir string) {
 			defer wg.Done()
@@ -148,6 +160,9 @@ func buildDesiredState(
 			if cfg.Agents[pw.agentIdx].Dir != "" {
 				qualifiedInstance = cfg.Agents[pw.agentIdx].Dir + "/" + name
 			}
+			if overrides[cfg.Agents[pw.agentIdx].QualifiedName()].isStopped(qualifiedInstance) {
+				continue
+			}
 			instanceAgent := deepCopyAgent(&cfg.Agents[pw.agentIdx], name, cfg.Agents[pw.agentIdx].Dir)
 			fpExtra := buildFingerprintExtra(&instanceAgent)
 			tp, err := resolveTemplate(bp, &instanceAgent, qualifiedInstance, fpExtra)
@@ -229,7 +244,7 @@ func discoverSessionBeads(
 }
 
 // installAgentSideEffects performs idempotent side effects for a resolved
-// agent: hook installation and ACP route registration. Called from
+// agent: hook installation and ACP/remote host route registration. Called from
 // buildDesiredState on every tick; safe to repeat.
 func installAgentSideEffects(bp *agentBuildParams, cfgAgent *config.Agent, tp TemplateParams, stderr io.Writer) {
 	// Install provider hooks (idempotent filesystem side effect).
@@ -238,9 +253,25 @@ func installAgentSideEffects(bp *agentBuildParams, cfgAgent *config.Agent, tp Te
 			fmt.Fprintf(stderr, "agent %q: hooks: %v\n", tp.DisplayName(), hErr) //nolint:errcheck
 		}
 	}
-	// Register ACP route on the auto provider for dynamic sessions.
+	// Register remote host and ACP routes for dynamic sessions. The ssh
+	// provider, when present, wraps the auto provider; lifecycle hooks,
+	// snapshots, usage tracking, and rate limits wrap both.
+	sp := bp.sp
+	for {
+		w, ok := sp.(interface{ Unwrap() runtime.Provider })
+		if !ok {
+			break
+		}
+		sp = w.Unwrap()
+	}
+	if sshSP, ok := sp.(*sessionssh.Provider); ok {
+		if cfgAgent.Host != "" {
+			sshSP.RouteHost(tp.SessionName, cfgAgent.Host)
+		}
+		sp = sshSP.Local()
+	}
 	if tp.IsACP {
-		if autoSP, ok := bp.sp.(*sessionauto.Provider); ok {
+		if autoSP, ok := sp.(*sessionauto.Provider); ok {
 			autoSP.RouteACP(tp.SessionName)
 		}
 	}
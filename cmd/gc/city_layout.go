@@ -14,13 +14,17 @@ func ensureCityScaffold(cityPath string) error {
 	return ensureCityScaffoldFS(fsys.OSFS{}, cityPath)
 }
 
+// cityScaffoldDirs are the runtime directories every city has, relative
+// to the city root. gc doctor --fix recreates any that go missing.
+var cityScaffoldDirs = []string{
+	citylayout.RuntimeRoot,
+	citylayout.CacheRoot,
+	citylayout.SystemRoot,
+	filepath.Join(citylayout.RuntimeRoot, "runtime"),
+}
+
 func ensureCityScaffoldFS(fs fsys.FS, cityPath string) error {
-	for _, rel := range []string{
-		citylayout.RuntimeRoot,
-		citylayout.CacheRoot,
-		citylayout.SystemRoot,
-		filepath.Join(citylayout.RuntimeRoot, "runtime"),
-	} {
+	for _, rel := range cityScaffoldDirs {
 		if err := fs.MkdirAll(filepath.Join(cityPath, rel), 0o755); err != nil {
 			return err
 		}
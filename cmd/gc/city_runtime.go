@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sync"
@@ -15,6 +16,8 @@ import (
 	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/convergence"
 	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/logging"
+	"github.com/gastownhall/gascity/internal/mail"
 	"github.com/gastownhall/gascity/internal/runtime"
 	"github.com/gastownhall/gascity/internal/supervisor"
 	"github.com/gastownhall/gascity/internal/telemetry"
@@ -54,6 +57,22 @@ type CityRuntime struct {
 
 	standaloneCityStore beads.Store // non-nil when API disabled; for chat auto-suspend
 
+	jiraLastErr      string      // last Jira bridge error; repeats are not re-logged
+	jiraRunning      atomic.Bool // a Jira bridge pass is in flight
+	overdueCheckedAt time.Time   // last overdue-bead scan; see overdueTick
+
+	heartbeatSeen    map[string]time.Time // session → first seen running; see heartbeatTick
+	heartbeatFlagged map[string]time.Time // session → heartbeat already flagged stale
+
+	claimCheckedAt time.Time            // last stale-claim scan; see claimTick
+	branchMergedAt time.Time            // last agent-branch merge; see branchMergeTick
+	claimGoneSince map[string]time.Time // bead+assignee → holder first seen gone
+
+	mailBridge     mail.Provider   // exec: mail provider; see mailPollTick
+	mailBridgeName string          // provider string mailBridge was built from
+	mailPolledAt   time.Time       // last bridge poll
+	mailAnnounced  map[string]bool // message IDs already announced by nudge
+
 	// Bead-driven reconciler state (Phase 2f).
 	sessionDrains *drainTracker // in-memory drain tracker; nil when bead reconciler disabled
 
@@ -65,6 +84,7 @@ type CityRuntime struct {
 	shutdownOnce   sync.Once
 	logPrefix      string // "gc start" or "gc supervisor"
 	stdout, stderr io.Writer
+	log            *slog.Logger // structured records; component=logPrefix
 }
 
 // CityRuntimeParams holds the caller-provided parameters for creating a
@@ -102,7 +122,7 @@ func newCityRuntime(p CityRuntimeParams) *CityRuntime {
 
 	var ct crashTracker
 	if maxR := p.Cfg.Daemon.MaxRestartsOrDefault(); maxR > 0 {
-		ct = newCrashTracker(maxR, p.Cfg.Daemon.RestartWindowDuration())
+		ct = newCityCrashTracker(p.CityPath, maxR, p.Cfg.Daemon.RestartWindowDuration())
 	}
 
 	it := buildIdleTracker(p.Cfg, p.CityName, p.CityPath, p.SP)
@@ -110,7 +130,7 @@ func newCityRuntime(p CityRuntimeParams) *CityRuntime {
 	var wg wispGC
 	if p.Cfg.Daemon.WispGCEnabled() {
 		wg = newWispGC(p.Cfg.Daemon.WispGCIntervalDuration(),
-			p.Cfg.Daemon.WispTTLDuration(), beads.ExecCommandRunner())
+			p.Cfg.Daemon.WispTTLDuration(), p.Cfg.Daemon.WispArchive, openCityStoreAt)
 	}
 
 	ad := buildAutomationDispatcher(p.CityPath, p.Cfg, beads.ExecCommandRunner(), p.Rec, p.Stderr)
@@ -151,10 +171,11 @@ func newCityRuntime(p CityRuntimeParams) *CityRuntime {
 		logPrefix: logPrefix,
 		stdout:    p.Stdout,
 		stderr:    p.Stderr,
+		log:       logging.FromWriter(p.Stderr).With("component", logPrefix),
 	}
 	cr.svc = workspacesvc.NewManager(&serviceRuntime{cr: cr})
 	if err := cr.svc.Reload(); err != nil {
-		fmt.Fprintf(cr.stderr, "%s: service init: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+		cr.log.Error("service init failed", "err", err)
 	}
 	return cr
 }
@@ -200,7 +221,7 @@ func (cr *CityRuntime) run(ctx context.Context) {
 	// When API is enabled, controllerState manages the store.
 	if cr.cs == nil {
 		if store, err := openCityStoreAt(cityRoot); err != nil {
-			fmt.Fprintf(cr.stderr, "%s: city bead store: %v (auto-suspend disabled)\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+			cr.log.Warn("city bead store unavailable; auto-suspend disabled", "err", err)
 		} else {
 			cr.standaloneCityStore = store
 		}
@@ -234,7 +255,7 @@ func (cr *CityRuntime) run(ctx context.Context) {
 			// sessions). They will be cleaned up when they naturally exit.
 			// Sessions with matching agents get beads via syncSessionBeads
 			// on the next tick.
-			fmt.Fprintf(cr.stderr, "%s: adoption barrier: %d session(s) failed bead creation\n", cr.logPrefix, result.Skipped) //nolint:errcheck
+			cr.log.Warn("adoption barrier: session bead creation failed", "skipped", result.Skipped)
 		}
 	}
 
@@ -317,7 +338,7 @@ func (cr *CityRuntime) tick(
 			for sn, info := range cr.poolDeathHandlers {
 				if (*prevPoolRunning)[sn] && !currentSet[sn] {
 					if _, err := shellScaleCheck(info.Command, info.Dir); err != nil {
-						fmt.Fprintf(cr.stderr, "on_death %s: %v\n", sn, err) //nolint:errcheck // best-effort stderr
+						cr.log.Warn("on_death failed", "session", sn, "err", err)
 					}
 				}
 			}
@@ -356,8 +377,9 @@ func (cr *CityRuntime) tick(
 	if cr.wg != nil && cr.wg.shouldRun(time.Now()) {
 		purged, gcErr := cr.wg.runGC(cityRoot, time.Now())
 		if gcErr != nil {
-			fmt.Fprintf(cr.stderr, "%s: wisp gc: %v\n", cr.logPrefix, gcErr) //nolint:errcheck // best-effort stderr
-		} else if purged > 0 {
+			cr.log.Error("wisp gc failed", "err", gcErr)
+		}
+		if purged > 0 {
 			fmt.Fprintf(cr.stdout, "Bead GC: purged %d expired bead(s)\n", purged) //nolint:errcheck // best-effort stdout
 		}
 	}
@@ -371,6 +393,29 @@ func (cr *CityRuntime) tick(
 		cr.svc.Tick(ctx, time.Now())
 	}
 
+	// Jira bridge: mirror labeled beads into Jira.
+	if cr.cfg.Bridge.Jira.Enabled() {
+		cr.jiraBridgeTick(ctx)
+	}
+
+	// SLA: warn about beads past their due date.
+	cr.overdueTick(time.Now())
+
+	// Heartbeats: flag agents whose session is alive but silent.
+	cr.heartbeatTick(time.Now())
+
+	// Stale claims: reopen beads held by agents that are gone.
+	cr.claimTick(time.Now())
+
+	// Agent branches: fold agents' bead changes into main.
+	cr.branchMergeTick(time.Now())
+
+	// Scheduled slings: run those that have come due.
+	cr.scheduleTick(ctx, time.Now())
+
+	// Mail bridge: announce mail that arrived from outside gc.
+	cr.mailPollTick(time.Now())
+
 	// Chat session auto-suspend: suspend detached idle sessions.
 	if idleTimeout := cr.cfg.ChatSessions.IdleTimeoutDuration(); idleTimeout > 0 {
 		autoSuspendChatSessions(cr.cityBeadStore(), cr.sp, idleTimeout, clock.Real{}, cr.stdout, cr.stderr)
@@ -393,7 +438,8 @@ func (cr *CityRuntime) reloadConfig(
 ) {
 	result, err := tryReloadConfig(cr.tomlPath, cr.cityName, cityRoot, cr.stderr)
 	if err != nil {
-		fmt.Fprintf(cr.stderr, "%s: config reload: %v (keeping old config)\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+		cr.log.Error("config reload failed; keeping old config", "err", err)
+		cr.rec.Record(events.Event{Type: events.ConfigRejected, Actor: "gc", Message: err.Error()})
 		telemetry.RecordConfigReload(ctx, "", err)
 		return
 	}
@@ -417,12 +463,14 @@ func (cr *CityRuntime) reloadConfig(
 				displayProviderName(*lastProviderName), displayProviderName(newProviderName), len(running))
 			gracefulStopAll(running, cr.sp, nextCfg.Daemon.ShutdownTimeoutDuration(), cr.rec, cr.stdout, cr.stderr)
 		}
-		newSp, spErr := newSessionProviderByName(newProviderName, nextCfg.Session, cr.cityName)
+		newSp, spErr := newSessionProviderByName(newProviderName, nextCfg.Session, cr.cityName, cr.cityPath)
 		if spErr != nil {
-			fmt.Fprintf(cr.stderr, "%s: new session provider %q: %v (keeping old provider)\n", //nolint:errcheck
-				cr.logPrefix, newProviderName, spErr)
+			cr.log.Error("new session provider failed; keeping old provider", "provider", newProviderName, "err", spErr)
 		} else {
-			nextSp = newSp
+			nextSp = withLifecycleHooks(newSp, nextCfg.Hooks, cr.cityPath, cr.cityName, cr.stderr)
+			nextSp = withSnapshots(nextSp, cr.cityPath, nextCfg.Session)
+			nextSp = withUsageTracking(nextSp, cr.cityPath, nextCfg.Daemon)
+			nextSp = withRateLimit(nextSp, nextCfg.RateLimit, nextCfg.Agents, cr.stderr)
 			providerSwapped = true
 			nextRops = newReconcileOps(nextSp)
 			nextDops = newDrainOps(nextSp)
@@ -435,6 +483,9 @@ func (cr *CityRuntime) reloadConfig(
 			*lastProviderName = newProviderName
 		}
 	}
+	if !providerSwapped {
+		updateRateLimits(nextSp, nextCfg.RateLimit, nextCfg.Agents, cr.stderr)
+	}
 
 	// Re-materialize and prepend system formulas.
 	sysDir, _ := MaterializeSystemFormulas(systemFormulasFS, "system_formulas", cityRoot)
@@ -444,22 +495,19 @@ func (cr *CityRuntime) reloadConfig(
 			nextCfg.FormulaLayers.Rigs[rigName] = append([]string{sysDir}, layers...)
 		}
 	}
-	if err := config.ValidateRigs(nextCfg.Rigs, cr.cityName); err != nil {
-		fmt.Fprintf(cr.stderr, "%s: config reload: %v\n", cr.logPrefix, err) //nolint:errcheck
-	}
 	resolveRigPaths(cityRoot, nextCfg.Rigs)
 	if err := startBeadsLifecycle(cityRoot, cr.cityName, nextCfg, cr.stderr); err != nil {
-		fmt.Fprintf(cr.stderr, "%s: config reload: %v\n", cr.logPrefix, err) //nolint:errcheck
+		cr.log.Error("config reload: beads lifecycle failed", "err", err)
 	}
 	if len(nextCfg.FormulaLayers.City) > 0 {
 		if err := ResolveFormulas(cityRoot, nextCfg.FormulaLayers.City); err != nil {
-			fmt.Fprintf(cr.stderr, "%s: config reload: city formulas: %v\n", cr.logPrefix, err) //nolint:errcheck
+			cr.log.Error("config reload: resolving city formulas failed", "err", err)
 		}
 	}
 	for _, r := range nextCfg.Rigs {
 		if layers, ok := nextCfg.FormulaLayers.Rigs[r.Name]; ok && len(layers) > 0 {
 			if err := ResolveFormulas(r.Path, layers); err != nil {
-				fmt.Fprintf(cr.stderr, "%s: config reload: rig %q formulas: %v\n", cr.logPrefix, r.Name, err) //nolint:errcheck
+				cr.log.Error("config reload: resolving rig formulas failed", "rig", r.Name, "err", err)
 			}
 		}
 	}
@@ -468,22 +516,22 @@ func (cr *CityRuntime) reloadConfig(
 	cr.poolDeathHandlers = computePoolDeathHandlers(nextCfg, cr.cityName, cityRoot, nextSp)
 	cr.suspendedNames = computeSuspendedNames(nextCfg, cr.cityName, cr.cityPath)
 
-	// Rebuild crash tracker if config values changed, otherwise clear all
-	// crash history so that a fixed config automatically unquarantines agents.
+	// Rebuild crash tracker if config values changed, and restart crash
+	// counting either way. Persisted quarantines survive the reload; a
+	// rebuilt tracker reads them back.
 	newMaxR := nextCfg.Daemon.MaxRestartsOrDefault()
 	newWindow := nextCfg.Daemon.RestartWindowDuration()
 	switch {
 	case newMaxR <= 0:
 		cr.ct = nil
 	case cr.ct == nil:
-		cr.ct = newCrashTracker(newMaxR, newWindow)
+		cr.ct = newCityCrashTracker(cr.cityPath, newMaxR, newWindow)
 	default:
 		oldMaxR, oldWindow := cr.ct.limits()
 		if newMaxR != oldMaxR || newWindow != oldWindow {
-			cr.ct = newCrashTracker(newMaxR, newWindow)
-		} else {
-			cr.ct.clearAll()
+			cr.ct = newCityCrashTracker(cr.cityPath, newMaxR, newWindow)
 		}
+		cr.ct.clearRestarts()
 	}
 	if cr.cs != nil {
 		cr.cs.mu.Lock()
@@ -495,13 +543,15 @@ func (cr *CityRuntime) reloadConfig(
 
 	if nextCfg.Daemon.WispGCEnabled() {
 		cr.wg = newWispGC(nextCfg.Daemon.WispGCIntervalDuration(),
-			nextCfg.Daemon.WispTTLDuration(), beads.ExecCommandRunner())
+			nextCfg.Daemon.WispTTLDuration(), nextCfg.Daemon.WispArchive, openCityStoreAt)
 	} else {
 		cr.wg = nil
 	}
 
 	cr.ad = buildAutomationDispatcher(cityRoot, nextCfg, beads.ExecCommandRunner(), cr.rec, cr.stderr)
 
+	changes := configChangeEvents(cr.cfg, nextCfg)
+
 	cr.serviceStateMu.Lock()
 	cr.cfg = nextCfg
 	cr.sp = nextSp
@@ -519,7 +569,7 @@ func (cr *CityRuntime) reloadConfig(
 	}
 	if cr.svc != nil {
 		if err := cr.svc.Reload(); err != nil {
-			fmt.Fprintf(cr.stderr, "%s: service reload: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+			cr.log.Error("service reload failed", "err", err)
 		}
 	}
 
@@ -535,7 +585,7 @@ func (cr *CityRuntime) reloadConfig(
 		// Also recovers from nil → non-nil when bd becomes available after startup.
 		if s, err := openCityStoreAt(cityRoot); err != nil {
 			if cr.standaloneCityStore != nil {
-				fmt.Fprintf(cr.stderr, "%s: city bead store reload: %v\n", cr.logPrefix, err) //nolint:errcheck
+				cr.log.Warn("city bead store reload failed", "err", err)
 			}
 		} else {
 			cr.standaloneCityStore = s
@@ -565,9 +615,17 @@ func (cr *CityRuntime) reloadConfig(
 		cr.sessionDrains = nil
 	}
 
-	fmt.Fprintf(cr.stdout, "Config reloaded: %s (rev %s)\n", //nolint:errcheck
-		configReloadSummary(oldAgentCount, oldRigCount, len(nextCfg.Agents), len(nextCfg.Rigs)),
-		shortRev(result.Revision))
+	summary := configReloadSummary(oldAgentCount, oldRigCount, len(nextCfg.Agents), len(nextCfg.Rigs))
+	for _, e := range changes {
+		cr.rec.Record(e)
+	}
+	cr.rec.Record(events.Event{
+		Type:    events.ConfigReloaded,
+		Actor:   "gc",
+		Subject: shortRev(result.Revision),
+		Message: summary,
+	})
+	fmt.Fprintf(cr.stdout, "Config reloaded: %s (rev %s)\n", summary, shortRev(result.Revision)) //nolint:errcheck
 	telemetry.RecordConfigReload(ctx, result.Revision, nil)
 }
 
@@ -583,7 +641,7 @@ func (cr *CityRuntime) beadReconcileTick(ctx context.Context, desiredState map[s
 	// Load open session beads (both legacy and new types, deduplicated).
 	open, err := loadSessionBeads(store)
 	if err != nil {
-		fmt.Fprintf(cr.stderr, "%s: loading session beads: %v\n", cr.logPrefix, err) //nolint:errcheck
+		cr.log.Error("loading session beads failed", "err", err)
 		return
 	}
 
@@ -656,7 +714,7 @@ func (cr *CityRuntime) shutdown() {
 	cr.shutdownOnce.Do(func() {
 		if cr.svc != nil {
 			if err := cr.svc.Close(); err != nil {
-				fmt.Fprintf(cr.stderr, "%s: service shutdown: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+				cr.log.Error("service shutdown failed", "err", err)
 			}
 		}
 		timeout := cr.cfg.Daemon.ShutdownTimeoutDuration()
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gastownhall/gascity/internal/beads"
 	"github.com/gastownhall/gascity/internal/config"
@@ -58,6 +59,58 @@ func TestCityRuntimeReloadProviderSwapRestoresBeadReconcileOps(t *testing.T) {
 	}
 }
 
+func TestCityRuntimeReloadKeepsQuarantines(t *testing.T) {
+	cityPath := t.TempDir()
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	writeCityRuntimeConfig(t, tomlPath, "fake")
+
+	cfg, err := config.Load(osFS{}, tomlPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	sp := runtime.NewFake()
+	cr := newCityRuntime(CityRuntimeParams{
+		CityPath: cityPath,
+		CityName: "test-city",
+		TomlPath: tomlPath,
+		Cfg:      cfg,
+		SP:       sp,
+		BuildFn: func(*config.City, runtime.Provider, beads.Store) map[string]TemplateParams {
+			return map[string]TemplateParams{}
+		},
+		Dops:   newDrainOps(sp),
+		Rec:    events.Discard,
+		Stdout: io.Discard,
+		Stderr: io.Discard,
+	})
+	if cr.ct == nil {
+		t.Fatal("crash tracker disabled by default config")
+	}
+	maxR, _ := cr.ct.limits()
+	now := time.Now()
+	for i := range maxR {
+		cr.ct.recordStart("test-city-worker", now.Add(time.Duration(i)*time.Second))
+	}
+	if !cr.ct.isQuarantined("test-city-worker", now.Add(time.Minute)) {
+		t.Fatal("worker not quarantined after max restarts")
+	}
+
+	// An unrelated edit (as gc agent add or gc config set would make).
+	data, err := os.ReadFile(tomlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tomlPath, append(data, "\n[[agent]]\nname = \"helper\"\n"...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lastProviderName := "fake"
+	cr.reloadConfig(context.Background(), &lastProviderName, cityPath)
+
+	if !cr.ct.isQuarantined("test-city-worker", now.Add(time.Minute)) {
+		t.Error("config reload released a persisted quarantine")
+	}
+}
+
 func writeCityRuntimeConfig(t *testing.T, tomlPath, provider string) {
 	t.Helper()
 	data := []byte("[workspace]\nname = \"test-city\"\n\n[beads]\nprovider = \"file\"\n\n[session]\nprovider = \"" + provider + "\"\n")
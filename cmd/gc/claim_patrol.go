@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/events"
+)
+
+// staleClaimMeta records the assignee a stale claim was flagged for, so
+// with [daemon] claim_action = "flag" each stale claim is reported once.
+const staleClaimMeta = "gc.stale_claim"
+
+// claimCheckInterval throttles the controller's stale-claim scan, which
+// lists beads and so is costlier than most per-tick work.
+const claimCheckInterval = time.Minute
+
+// claimHolder is the controller's view of an in_progress bead's assignee.
+type claimHolder int
+
+const (
+	holderUnknown claimHolder = iota // not a session or configured agent (e.g. a human)
+	holderAlive                      // session running, heartbeat fresh or never sent
+	holderGone                       // no running session
+	holderSilent                     // session running, heartbeat older than the TTL
+)
+
+// staleClaim is an in_progress bead whose holder is gone, and why.
+type staleClaim struct {
+	bead   beads.Bead
+	reason string
+}
+
+// claimTick finds in_progress beads whose assignee has had no running
+// session, or a silent heartbeat, for longer than [daemon] claim_ttl. Each
+// is logged, recorded as a bead.claim_stale event, and — unless
+// claim_action = "flag" — reopened so another agent can take it.
+func (cr *CityRuntime) claimTick(now time.Time) {
+	ttl := cr.cfg.Daemon.ClaimTTLDuration()
+	if ttl <= 0 || now.Sub(cr.claimCheckedAt) < claimCheckInterval {
+		return
+	}
+	cr.claimCheckedAt = now
+	store := cr.cityBeadStore()
+	if store == nil {
+		return
+	}
+	list, err := store.Query(beads.Filter{Status: "in_progress"})
+	if err != nil {
+		fmt.Fprintf(cr.stderr, "%s: claim check: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+		return
+	}
+	hbs, err := loadHeartbeats(cr.cityPath)
+	if err != nil {
+		fmt.Fprintf(cr.stderr, "%s: claim check: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+	}
+	if cr.claimGoneSince == nil {
+		cr.claimGoneSince = make(map[string]time.Time)
+	}
+	holder := func(assignee string) claimHolder {
+		return cr.claimHolderState(store, hbs, assignee, ttl, now)
+	}
+	unclaim := cr.cfg.Daemon.ClaimUnclaims()
+	for _, sc := range staleClaims(list, holder, cr.claimGoneSince, ttl, now) {
+		b := sc.bead
+		if !unclaim && b.Metadata[staleClaimMeta] == b.Assignee {
+			continue // already flagged for this assignee
+		}
+		msg := fmt.Sprintf("bead %s %q is claimed by %s, %s", b.ID, b.Title, b.Assignee, sc.reason)
+		if unclaim {
+			reopened, err := unclaimBead(store, b)
+			if err != nil {
+				fmt.Fprintf(cr.stderr, "%s: unclaiming %s: %v\n", cr.logPrefix, b.ID, err) //nolint:errcheck // best-effort stderr
+				continue
+			}
+			if !reopened {
+				continue // claimed or finished by someone since the scan
+			}
+			msg += "; reopened"
+		} else if err := store.SetMetadata(b.ID, staleClaimMeta, b.Assignee); err != nil {
+			fmt.Fprintf(cr.stderr, "%s: flagging %s: %v\n", cr.logPrefix, b.ID, err) //nolint:errcheck // best-effort stderr
+		}
+		fmt.Fprintf(cr.stderr, "%s: %s\n", cr.logPrefix, msg) //nolint:errcheck // best-effort stderr
+		cr.rec.Record(events.Event{
+			Type:    events.BeadClaimStale,
+			Actor:   "gc",
+			Subject: b.ID,
+			Message: msg,
+		})
+	}
+}
+
+// claimHolderState judges an assignee: a running session by that name, or
+// the session of the configured agent it names. Heartbeats only count for
+// sessions that have reported one.
+func (cr *CityRuntime) claimHolderState(store beads.Store, hbs heartbeatState, assignee string, ttl time.Duration, now time.Time) claimHolder {
+	session := assignee
+	if !cr.sp.IsRunning(session) {
+		a, ok := resolveAgentIdentity(cr.cfg, assignee, "")
+		if !ok {
+			return holderUnknown
+		}
+		session = sessionName(store, cr.cityName, a.QualifiedName(), cr.cfg.Workspace.SessionTemplate)
+		if !cr.sp.IsRunning(session) {
+			return holderGone
+		}
+	}
+	for _, hb := range hbs {
+		if hb.Session == session && now.Sub(hb.At) > ttl {
+			return holderSilent
+		}
+	}
+	return holderAlive
+}
+
+// staleClaims returns the in_progress beads whose holder has been gone
+// for longer than ttl. goneSince records when each claim's holder was
+// first seen gone, keyed by bead and assignee, and is updated in place; a
+// holder silent on heartbeats has already been quiet for ttl and is
+// returned at once.
+func staleClaims(list []beads.Bead, holder func(string) claimHolder, goneSince map[string]time.Time, ttl time.Duration, now time.Time) []staleClaim {
+	var stale []staleClaim
+	seen := make(map[string]bool, len(list))
+	for _, b := range list {
+		if b.Status != "in_progress" || b.Assignee == "" {
+			continue
+		}
+		key := b.ID + "\x00" + b.Assignee
+		switch holder(b.Assignee) {
+		case holderSilent:
+			stale = append(stale, staleClaim{bead: b, reason: fmt.Sprintf("whose heartbeat has been silent for over %s", formatDuration(ttl))})
+		case holderGone:
+			seen[key] = true
+			since, ok := goneSince[key]
+			if !ok {
+				goneSince[key] = now
+				continue
+			}
+			if gone := now.Sub(since); gone > ttl {
+				stale = append(stale, staleClaim{bead: b, reason: "which has had no running session for " + formatDuration(gone)})
+			}
+		}
+	}
+	for key := range goneSince {
+		if !seen[key] {
+			delete(goneSince, key)
+		}
+	}
+	return stale
+}
+
+// unclaimBead reopens a stale claim. Pool work (a pool:<name> label) also
+// loses its assignee so any pool member can claim it; directly assigned
+// work stays with its agent for when it restarts. Reports false, changing
+// nothing, if the bead is no longer claimed by the same assignee.
+func unclaimBead(store beads.Store, stale beads.Bead) (bool, error) {
+	changed := false
+	_, err := updateBead(store, stale.ID, func(b beads.Bead) (*beads.UpdateOpts, error) {
+		changed = b.Status == "in_progress" && b.Assignee == stale.Assignee
+		if !changed {
+			return nil, nil
+		}
+		open := "open"
+		opts := &beads.UpdateOpts{Status: &open}
+		if slices.ContainsFunc(b.Labels, func(l string) bool { return strings.HasPrefix(l, "pool:") }) {
+			none := ""
+			opts.Assignee = &none
+		}
+		return opts, nil
+	})
+	if errors.Is(err, beads.ErrConflict) {
+		return false, nil
+	}
+	return changed && err == nil, err
+}
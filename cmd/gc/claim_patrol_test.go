@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestStaleClaims(t *testing.T) {
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	ttl := 10 * time.Minute
+	holders := map[string]claimHolder{
+		"alive":  holderAlive,
+		"gone":   holderGone,
+		"silent": holderSilent,
+	}
+	holder := func(a string) claimHolder { return holders[a] }
+	list := []beads.Bead{
+		{ID: "gc-1", Status: "in_progress", Assignee: "alive"},
+		{ID: "gc-2", Status: "in_progress", Assignee: "gone"},
+		{ID: "gc-3", Status: "in_progress", Assignee: "silent"},
+		{ID: "gc-4", Status: "in_progress", Assignee: "alice"}, // unknown: a human
+		{ID: "gc-5", Status: "in_progress"},
+	}
+	goneSince := map[string]time.Time{"gc-9\x00gone": now.Add(-time.Hour)}
+
+	got := staleClaims(list, holder, goneSince, ttl, now)
+	if len(got) != 1 || got[0].bead.ID != "gc-3" || !strings.Contains(got[0].reason, "heartbeat") {
+		t.Fatalf("first scan = %+v, want only silent gc-3", got)
+	}
+	if _, ok := goneSince["gc-9\x00gone"]; ok {
+		t.Error("goneSince kept a claim no longer in progress")
+	}
+	if !goneSince["gc-2\x00gone"].Equal(now) {
+		t.Errorf("goneSince[gc-2] = %v, want %v", goneSince["gc-2\x00gone"], now)
+	}
+
+	got = staleClaims(list, holder, goneSince, ttl, now.Add(5*time.Minute))
+	if len(got) != 1 || got[0].bead.ID != "gc-3" {
+		t.Fatalf("scan within ttl = %+v, want only gc-3", got)
+	}
+
+	got = staleClaims(list, holder, goneSince, ttl, now.Add(11*time.Minute))
+	if len(got) != 2 || got[0].bead.ID != "gc-2" || !strings.Contains(got[0].reason, "no running session for 11m") {
+		t.Fatalf("scan past ttl = %+v, want gc-2 then gc-3", got)
+	}
+}
+
+func TestUnclaimBead(t *testing.T) {
+	store := beads.NewMemStore()
+	pooled, _ := store.Create(beads.Bead{Title: "pooled", Labels: []string{"pool:hw/polecat"}})
+	direct, _ := store.Create(beads.Bead{Title: "direct"})
+	inProgress := "in_progress"
+	for _, id := range []string{pooled.ID, direct.ID} {
+		who := "hw--polecat-1"
+		if err := store.Update(id, beads.UpdateOpts{Status: &inProgress, Assignee: &who}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stale, _ := store.Get(pooled.ID)
+	if ok, err := unclaimBead(store, stale); !ok || err != nil {
+		t.Fatalf("unclaimBead(pooled) = %v, %v", ok, err)
+	}
+	if b, _ := store.Get(pooled.ID); b.Status != "open" || b.Assignee != "" {
+		t.Errorf("pooled bead = %s/%q, want open and unassigned", b.Status, b.Assignee)
+	}
+
+	stale, _ = store.Get(direct.ID)
+	if ok, err := unclaimBead(store, stale); !ok || err != nil {
+		t.Fatalf("unclaimBead(direct) = %v, %v", ok, err)
+	}
+	if b, _ := store.Get(direct.ID); b.Status != "open" || b.Assignee != "hw--polecat-1" {
+		t.Errorf("direct bead = %s/%q, want open and still assigned", b.Status, b.Assignee)
+	}
+
+	// Already reopened: nothing to do.
+	if ok, err := unclaimBead(store, stale); ok || err != nil {
+		t.Errorf("unclaimBead(reopened) = %v, %v; want false, nil", ok, err)
+	}
+}
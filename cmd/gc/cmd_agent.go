@@ -7,10 +7,13 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gastownhall/gascity/internal/api"
+	"github.com/gastownhall/gascity/internal/beads"
 	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/gastownhall/gascity/internal/runtime"
 	"github.com/spf13/cobra"
 )
 
@@ -192,7 +195,7 @@ have moved to "gc session" and "gc runtime".`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(_ *cobra.Command, args []string) error {
 			if len(args) == 0 {
-				fmt.Fprintln(stderr, "gc agent: missing subcommand (add, suspend, resume)") //nolint:errcheck // best-effort stderr
+				fmt.Fprintln(stderr, "gc agent: missing subcommand (add, exec, heartbeat, next, remove, resume, suspend)") //nolint:errcheck // best-effort stderr
 			} else {
 				fmt.Fprintf(stderr, "gc agent: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
 			}
@@ -201,6 +204,10 @@ have moved to "gc session" and "gc runtime".`,
 	}
 	cmd.AddCommand(
 		newAgentAddCmd(stdout, stderr),
+		newAgentExecCmd(stdout, stderr),
+		newAgentHeartbeatCmd(stdout, stderr),
+		newAgentNextCmd(stdout, stderr),
+		newAgentRemoveCmd(stdout, stderr),
 		newAgentResumeCmd(stdout, stderr),
 		newAgentSuspendCmd(stdout, stderr),
 	)
@@ -208,7 +215,7 @@ have moved to "gc session" and "gc runtime".`,
 }
 
 func newAgentAddCmd(stdout, stderr io.Writer) *cobra.Command {
-	var name, promptTemplate, dir string
+	var name, promptTemplate, dir, role string
 	var suspended bool
 	cmd := &cobra.Command{
 		Use:   "add --name <name>",
@@ -217,13 +224,24 @@ func newAgentAddCmd(stdout, stderr io.Writer) *cobra.Command {
 
 Appends an [[agent]] block to city.toml. The agent will be started
 on the next "gc start" or controller reconcile tick. Use --dir to
-scope the agent to a rig's working directory.`,
+scope the agent to a rig's working directory.
+
+Use --role to stamp prompt_template, provider, args, work_query,
+sling_query, and env from a named role. Roles come from [[roles]] in
+city.toml or the built-in library (reviewer, tester, planner,
+doc-writer); built-in roles route work through a role:<name> label and
+write their prompt to prompts/<name>.md if it does not exist. Explicit
+flags win over the role.`,
 		Example: `  gc agent add --name mayor
   gc agent add --name polecat --dir my-project
-  gc agent add --name worker --prompt-template prompts/worker.md --suspended`,
-		Args: cobra.ArbitraryArgs,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			if cmdAgentAdd(name, promptTemplate, dir, suspended, stdout, stderr) != 0 {
+  gc agent add --name worker --prompt-template prompts/worker.md --suspended
+  gc agent add builder --role reviewer`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if name == "" && len(args) == 1 {
+				name = args[0]
+			}
+			if cmdAgentAdd(name, promptTemplate, dir, role, suspended, stdout, stderr) != 0 {
 				return errExit
 			}
 			return nil
@@ -232,13 +250,14 @@ scope the agent to a rig's working directory.`,
 	cmd.Flags().StringVar(&name, "name", "", "Name of the agent")
 	cmd.Flags().StringVar(&promptTemplate, "prompt-template", "", "Path to prompt template file (relative to city root)")
 	cmd.Flags().StringVar(&dir, "dir", "", "Working directory for the agent (relative to city root)")
+	cmd.Flags().StringVar(&role, "role", "", "Stamp fields from a [[roles]] entry or built-in role")
 	cmd.Flags().BoolVar(&suspended, "suspended", false, "Register the agent in suspended state")
 	return cmd
 }
 
 // cmdAgentAdd is the CLI entry point for adding an agent. It locates
 // the city root and delegates to doAgentAdd.
-func cmdAgentAdd(name, promptTemplate, dir string, suspended bool, stdout, stderr io.Writer) int {
+func cmdAgentAdd(name, promptTemplate, dir, role string, suspended bool, stdout, stderr io.Writer) int {
 	if name == "" {
 		fmt.Fprintln(stderr, "gc agent add: missing --name flag") //nolint:errcheck // best-effort stderr
 		return 1
@@ -248,13 +267,13 @@ func cmdAgentAdd(name, promptTemplate, dir string, suspended bool, stdout, stder
 		fmt.Fprintf(stderr, "gc agent add: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
 	}
-	return doAgentAdd(fsys.OSFS{}, cityPath, name, promptTemplate, dir, suspended, stdout, stderr)
+	return doAgentAdd(fsys.OSFS{}, cityPath, name, promptTemplate, dir, role, suspended, stdout, stderr)
 }
 
 // doAgentAdd is the pure logic for "gc agent add". It loads city.toml,
 // checks for duplicates, appends the new agent, and writes back.
 // Accepts an injected FS for testability.
-func doAgentAdd(fs fsys.FS, cityPath, name, promptTemplate, dir string, suspended bool, stdout, stderr io.Writer) int {
+func doAgentAdd(fs fsys.FS, cityPath, name, promptTemplate, dir, role string, suspended bool, stdout, stderr io.Writer) int {
 	tomlPath := filepath.Join(cityPath, "city.toml")
 	cfg, err := loadCityConfigForEditFS(fs, tomlPath)
 	if err != nil {
@@ -281,6 +300,25 @@ func doAgentAdd(fs fsys.FS, cityPath, name, promptTemplate, dir string, suspende
 		PromptTemplate: promptTemplate,
 		Suspended:      suspended,
 	}
+	if role != "" {
+		// Roles may live in include fragments; fall back to city.toml alone.
+		roles := cfg.Roles
+		if full, _, err := config.LoadWithIncludes(fs, tomlPath); err == nil {
+			roles = full.Roles
+		}
+		r, builtin, ok := config.FindRole(roles, role)
+		if !ok {
+			fmt.Fprintf(stderr, "gc agent add: unknown role %q\n", role) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		r.Apply(&newAgent)
+		if builtin && newAgent.PromptTemplate == r.PromptTemplate {
+			if err := writeRolePrompt(fs, cityPath, r); err != nil {
+				fmt.Fprintf(stderr, "gc agent add: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+		}
+	}
 	cfg.Agents = append(cfg.Agents, newAgent)
 	content, err := cfg.Marshal()
 	if err != nil {
@@ -292,10 +330,267 @@ func doAgentAdd(fs fsys.FS, cityPath, name, promptTemplate, dir string, suspende
 		return 1
 	}
 
-	fmt.Fprintf(stdout, "Added agent '%s'\n", name) //nolint:errcheck // best-effort stdout
+	if role != "" {
+		fmt.Fprintf(stdout, "Added agent '%s' (role %s)\n", name, role) //nolint:errcheck // best-effort stdout
+	} else {
+		fmt.Fprintf(stdout, "Added agent '%s'\n", name) //nolint:errcheck // best-effort stdout
+	}
 	return 0
 }
 
+// writeRolePrompt copies a built-in role's embedded prompt to its
+// prompt_template path unless the city already has a file there.
+func writeRolePrompt(fs fsys.FS, cityPath string, r config.Role) error {
+	dst := filepath.Join(cityPath, r.PromptTemplate)
+	if _, err := fs.Stat(dst); err == nil {
+		return nil
+	}
+	data, err := rolePrompts.ReadFile("roles/" + r.Name + ".md")
+	if err != nil {
+		return fmt.Errorf("reading embedded prompt for role %q: %w", r.Name, err)
+	}
+	if err := fs.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	return fs.WriteFile(dst, data, 0o644)
+}
+
+func newAgentRemoveCmd(stdout, stderr io.Writer) *cobra.Command {
+	var kill bool
+	var reassignTo string
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove an agent from the workspace",
+		Long: `Remove an agent's [[agent]] block from city.toml.
+
+Open and in-progress beads still routed to the agent are listed as a
+warning; pass --reassign-to to move them to another agent or pool first.
+Running sessions are left alone unless --kill is set — without it, the
+controller stops them on its next reconcile tick.
+
+Agents defined by a pack cannot be removed here; use [[patches]] to
+suspend them instead.`,
+		Example: `  gc agent remove worker
+  gc agent remove myrig/polecat --kill
+  gc agent remove worker --reassign-to mayor`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdAgentRemove(args[0], kill, reassignTo, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&kill, "kill", false, "stop the agent's running sessions")
+	cmd.Flags().StringVar(&reassignTo, "reassign-to", "", "move the agent's open beads to this agent or pool")
+	return cmd
+}
+
+// cmdAgentRemove is the CLI entry point for removing an agent. It opens
+// the session provider and the bead store for the agent's rig, then
+// delegates to doAgentRemove. A store that cannot be opened only
+// disables the assigned-work check.
+func cmdAgentRemove(name string, kill bool, reassignTo string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var store beads.Store
+	if a, ok := resolveAgentIdentity(cfg, name, currentRigContext(cfg)); ok {
+		store, err = openRigStoreAt(cityPath, rigDirForAgent(cfg, a))
+		if err != nil {
+			fmt.Fprintf(stderr, "gc agent remove: warning: cannot check assigned beads: %v\n", err) //nolint:errcheck // best-effort stderr
+			store = nil
+		}
+	}
+	var sp runtime.Provider
+	if kill {
+		sp = newSessionProvider()
+	}
+	return doAgentRemove(fsys.OSFS{}, cityPath, name, reassignTo, sp, store, stdout, stderr)
+}
+
+// doAgentRemove deletes the named agent from city.toml. When store is
+// non-nil, beads still routed to the agent are reassigned to reassignTo
+// or reported as a warning. When sp is non-nil, the agent's running
+// sessions are stopped. city.toml is rewritten last so a failed
+// reassignment or kill leaves the agent configured.
+// Accepts an injected FS for testability.
+func doAgentRemove(fs fsys.FS, cityPath, name, reassignTo string, sp runtime.Provider, store beads.Store, stdout, stderr io.Writer) int {
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	cfg, err := loadCityConfigForEditFS(fs, tomlPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	resolved, ok := resolveAgentIdentity(cfg, name, currentRigContext(cfg))
+	if !ok {
+		expanded, err := loadCityConfigFS(fs, tomlPath)
+		if err == nil {
+			if _, ok := resolveAgentIdentity(expanded, name, currentRigContext(expanded)); ok {
+				fmt.Fprintf(stderr, "gc agent remove: agent %q is defined by a pack — use [[patches]] to suspend it\n", name) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			cfg = expanded
+		}
+		fmt.Fprintln(stderr, agentNotFoundMsg("gc agent remove", name, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	qn := resolved.QualifiedName()
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	st := cfg.Workspace.SessionTemplate
+
+	// Resolve the reassignment target before touching anything.
+	var target config.Agent
+	if reassignTo != "" {
+		expanded, err := loadCityConfigFS(fs, tomlPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc agent remove: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		t, ok := resolveAgentIdentity(expanded, reassignTo, currentRigContext(expanded))
+		if !ok {
+			fmt.Fprintln(stderr, agentNotFoundMsg("gc agent remove", reassignTo, expanded)) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if t.QualifiedName() == qn {
+			fmt.Fprintln(stderr, "gc agent remove: --reassign-to must name a different agent") //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		target = t
+	}
+
+	if store != nil {
+		routed := routedBeads(store, resolved, sessionName(store, cityName, qn, st))
+		switch {
+		case len(routed) == 0:
+		case reassignTo != "":
+			for _, b := range routed {
+				if err := reassignBead(store, b.ID, resolved, target, cityName, st); err != nil {
+					fmt.Fprintf(stderr, "gc agent remove: reassigning %s: %v\n", b.ID, err) //nolint:errcheck // best-effort stderr
+					return 1
+				}
+			}
+			fmt.Fprintf(stdout, "Reassigned %d bead(s) from '%s' to '%s'\n", len(routed), qn, target.QualifiedName()) //nolint:errcheck // best-effort stdout
+		default:
+			ids := make([]string, len(routed))
+			for i, b := range routed {
+				ids[i] = b.ID
+			}
+			fmt.Fprintf(stderr, "warning: %d open bead(s) still routed to '%s': %s (use --reassign-to to move them)\n", //nolint:errcheck // best-effort stderr
+				len(routed), qn, strings.Join(ids, ", "))
+		}
+	}
+
+	if sp != nil {
+		var names []string
+		if resolved.IsPool() {
+			for _, inst := range discoverPoolInstances(resolved.Name, resolved.Dir, resolved.EffectivePool(), cityName, st, sp) {
+				names = append(names, sessionName(store, cityName, inst, st))
+			}
+		} else {
+			names = []string{sessionName(store, cityName, qn, st)}
+		}
+		for _, sn := range names {
+			if !sp.IsRunning(sn) {
+				continue
+			}
+			if err := sp.Stop(sn); err != nil {
+				fmt.Fprintf(stderr, "gc agent remove: stopping session %s: %v\n", sn, err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			fmt.Fprintf(stdout, "Stopped session %s\n", sn) //nolint:errcheck // best-effort stdout
+		}
+	}
+
+	for i := range cfg.Agents {
+		if cfg.Agents[i].Dir == resolved.Dir && cfg.Agents[i].Name == resolved.Name {
+			cfg.Agents = append(cfg.Agents[:i], cfg.Agents[i+1:]...)
+			break
+		}
+	}
+	content, err := cfg.Marshal()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if err := fs.WriteFile(tomlPath, content, 0o644); err != nil {
+		fmt.Fprintf(stderr, "gc agent remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "Removed agent '%s'\n", qn) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// routedBeads returns the open and in-progress beads routed to a: by
+// assignee (session name or qualified name) for fixed agents, or by pool
+// label for pools.
+func routedBeads(store beads.Store, a config.Agent, sn string) []beads.Bead {
+	var filters []beads.Filter
+	if a.IsPool() {
+		filters = []beads.Filter{{Label: poolRouteLabel(a)}}
+	} else {
+		filters = []beads.Filter{{Assignee: sn}}
+		if qn := a.QualifiedName(); qn != sn {
+			filters = append(filters, beads.Filter{Assignee: qn})
+		}
+	}
+	seen := make(map[string]bool)
+	var result []beads.Bead
+	for _, f := range filters {
+		for _, status := range []string{"open", "in_progress"} {
+			f.Status = status
+			bs, err := store.Query(f)
+			if err != nil {
+				continue // best-effort
+			}
+			for _, b := range bs {
+				if !seen[b.ID] {
+					seen[b.ID] = true
+					result = append(result, b)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// reassignBead moves bead id's routing from one agent to another the way the
+// default sling queries would: pool targets get their pool label, fixed
+// targets become the assignee. In-progress beads are reset to open so the
+// new owner picks them up.
+func reassignBead(store beads.Store, id string, from, to config.Agent, cityName, st string) error {
+	_, err := updateBead(store, id, func(b beads.Bead) (*beads.UpdateOpts, error) {
+		var opts beads.UpdateOpts
+		if from.IsPool() {
+			opts.RemoveLabels = []string{poolRouteLabel(from)}
+		}
+		if to.IsPool() {
+			empty := ""
+			opts.Assignee = &empty
+			opts.Labels = []string{poolRouteLabel(to)}
+		} else {
+			sn := sessionName(store, cityName, to.QualifiedName(), st)
+			opts.Assignee = &sn
+		}
+		if b.Status == "in_progress" {
+			open := "open"
+			opts.Status = &open
+		}
+		return &opts, nil
+	})
+	return err
+}
+
 func newAgentSuspendCmd(stdout, stderr io.Writer) *cobra.Command {
 	return &cobra.Command{
 		Use:   "suspend <name>",
@@ -398,25 +693,36 @@ func doAgentSuspend(fs fsys.FS, cityPath, name string, stdout, stderr io.Writer)
 }
 
 func newAgentResumeCmd(stdout, stderr io.Writer) *cobra.Command {
-	return &cobra.Command{
+	var clearQuarantine bool
+	cmd := &cobra.Command{
 		Use:   "resume <name>",
 		Short: "Resume a suspended agent",
 		Long: `Resume a suspended agent by clearing suspended in city.toml.
 
 The reconciler will start the agent on its next tick. Supports bare
-names (resolved via rig context) and qualified names (e.g. "myrig/worker").`,
+names (resolved via rig context) and qualified names (e.g. "myrig/worker").
+
+With --clear-quarantine, also lift a crash-loop quarantine (for a pool,
+on every instance) and reset its backoff, so the agent is restarted on
+the next tick instead of when the quarantine expires. An agent that is
+quarantined but not suspended is left unsuspended.`,
+		Example: `  gc agent resume myrig/worker
+  gc agent resume mayor --clear-quarantine`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(_ *cobra.Command, args []string) error {
-			if cmdAgentResume(args, stdout, stderr) != 0 {
+			if cmdAgentResume(args, clearQuarantine, stdout, stderr) != 0 {
 				return errExit
 			}
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&clearQuarantine, "clear-quarantine", false,
+		"also clear a crash-loop quarantine and its backoff")
+	return cmd
 }
 
 // cmdAgentResume is the CLI entry point for resuming a suspended agent.
-func cmdAgentResume(args []string, stdout, stderr io.Writer) int {
+func cmdAgentResume(args []string, clearQuarantine bool, stdout, stderr io.Writer) int {
 	if len(args) < 1 {
 		fmt.Fprintln(stderr, "gc agent resume: missing agent name") //nolint:errcheck // best-effort stderr
 		return 1
@@ -426,6 +732,25 @@ func cmdAgentResume(args []string, stdout, stderr io.Writer) int {
 		fmt.Fprintf(stderr, "gc agent resume: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
 	}
+	if clearQuarantine {
+		cfg, err := loadCityConfig(cityPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc agent resume: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		a, ok := resolveAgentIdentity(cfg, args[0], currentRigContext(cfg))
+		if !ok {
+			fmt.Fprintln(stderr, agentNotFoundMsg("gc agent resume", args[0], cfg)) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if doAgentClearQuarantine(cityPath, cfg, a, newSessionProvider(), stdout, stderr) != 0 {
+			return 1
+		}
+		pokeController(cityPath) //nolint:errcheck // best-effort: controller also notices on its next tick
+		if !a.Suspended {
+			return 0
+		}
+	}
 	if c := apiClient(cityPath); c != nil {
 		qname := resolveAgentForAPI(cityPath, args[0])
 		err := c.ResumeAgent(qname)
@@ -442,6 +767,35 @@ func cmdAgentResume(args []string, stdout, stderr io.Writer) int {
 	return doAgentResume(fsys.OSFS{}, cityPath, args[0], stdout, stderr)
 }
 
+// doAgentClearQuarantine lifts the crash-loop quarantine on every session
+// of agent a (each instance, for a pool).
+func doAgentClearQuarantine(cityPath string, cfg *config.City, a config.Agent, sp runtime.Provider, stdout, stderr io.Writer) int {
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	st := cfg.Workspace.SessionTemplate
+	names := []string{a.QualifiedName()}
+	if pool := a.EffectivePool(); pool.IsMultiInstance() {
+		names = discoverPoolInstances(a.Name, a.Dir, pool, cityName, st, sp)
+	}
+	sessions := make([]string, 0, len(names))
+	for _, qn := range names {
+		sessions = append(sessions, cliSessionName(cityPath, cityName, qn, st))
+	}
+	n, err := clearCrashQuarantines(cityPath, sessions, time.Now())
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent resume: clearing quarantine: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if n == 0 {
+		fmt.Fprintf(stdout, "Agent '%s' is not quarantined\n", a.QualifiedName()) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	fmt.Fprintf(stdout, "Cleared crash-loop quarantine for agent '%s' (%d session(s))\n", a.QualifiedName(), n) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
 // doAgentResume clears suspended on the named agent in city.toml.
 // Uses raw config (no pack expansion) to preserve includes/patches on write-back.
 // If the agent isn't found in raw config but exists in expanded config, it's
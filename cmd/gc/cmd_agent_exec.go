@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/headless"
+	"github.com/spf13/cobra"
+)
+
+func newAgentExecCmd(stdout, stderr io.Writer) *cobra.Command {
+	var opts agentExecOpts
+	cmd := &cobra.Command{
+		Use:   "exec <name> <prompt>",
+		Short: "Run a one-shot prompt as an agent and print the reply",
+		Long: `Run a single prompt through an agent's provider and print the reply.
+
+The provider runs headless in the agent's working directory with the
+agent's environment, using the provider's non-interactive mode
+(print_args, e.g. "claude -p"), and gc waits for it to exit. The
+agent's running session, if any, is left untouched, so scripts get a
+synchronous request/response path without disturbing its work.
+
+The prompt reaches the provider per its prompt_mode: as the last
+argument ("arg"), after prompt_flag ("flag"), or on stdin ("stdin").
+Pass "-" as the prompt to read it from gc's own stdin.
+
+A run fails if the provider exits nonzero or --timeout expires; with
+--retries it is tried again after --backoff, doubling each time. With
+--bead, the reply and outcome (exit code, attempts, duration) are
+recorded on that bead as a comment and metadata, even when the run
+fails.`,
+		Example: `  gc agent exec mayor "Summarize the open P0 beads"
+  git diff | gc agent exec myrig/reviewer -
+  gc agent exec planner "Draft a plan for gc-12" --timeout 30m --bead gc-12
+  gc agent exec gatekeeper "Does gc-7 meet its acceptance criteria?" --retries 2`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdAgentExec(args[0], args[1], opts, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 10*time.Minute, "give up on an attempt and kill the provider after this long")
+	cmd.Flags().IntVar(&opts.Retries, "retries", 0, "retry a failed run this many times")
+	cmd.Flags().DurationVar(&opts.Backoff, "backoff", 5*time.Second, "wait before the first retry, doubled for each one after")
+	cmd.Flags().StringVar(&opts.BeadID, "bead", "", "record the reply and outcome on this bead")
+	return cmd
+}
+
+// cmdAgentExec is the CLI entry point for gc agent exec. It resolves the
+// agent's session parameters the way the controller does and runs the
+// provider headless.
+func cmdAgentExec(name, prompt string, opts agentExecOpts, stdout, stderr io.Writer) int {
+	if prompt == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc agent exec: reading stdin: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		prompt = string(data)
+	}
+	if strings.TrimSpace(prompt) == "" {
+		fmt.Fprintln(stderr, "gc agent exec: prompt is empty") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent exec: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent exec: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	a, ok := resolveAgentIdentity(cfg, name, currentRigContext(cfg))
+	if !ok {
+		fmt.Fprintln(stderr, agentNotFoundMsg("gc agent exec", name, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	bp := newAgentBuildParams(cityName, cityPath, cfg, nil, time.Now(), nil, stderr)
+	tp, err := resolveTemplate(bp, &a, a.QualifiedName(), nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent exec: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var store beads.Store
+	if opts.BeadID != "" {
+		if store = openBeadStore(opts.BeadID, stderr, "gc agent exec"); store == nil {
+			return 1
+		}
+		if _, err := store.Get(opts.BeadID); err != nil {
+			fmt.Fprintf(stderr, "gc agent exec: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	return doAgentExec(tp, prompt, opts, store, stdout, stderr)
+}
+
+// runAgentExec runs a headless provider invocation. Tests replace it.
+var runAgentExec = headless.Run
+
+// agentExecOpts holds the gc agent exec flags.
+type agentExecOpts struct {
+	Timeout time.Duration
+	Retries int
+	Backoff time.Duration
+	BeadID  string // record the result on this bead when set
+}
+
+// doAgentExec runs prompt through the provider in tp non-interactively
+// and prints its reply. When opts.BeadID is set, the outcome is recorded
+// on that bead in store, whether or not the run succeeded.
+func doAgentExec(tp TemplateParams, prompt string, opts agentExecOpts, store beads.Store, stdout, stderr io.Writer) int {
+	inv, err := agentExecInvocation(tp, prompt)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent exec: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	inv.Timeout = opts.Timeout
+	res := runAgentExec(context.Background(), inv, headless.RetryPolicy{MaxAttempts: opts.Retries + 1, Backoff: opts.Backoff})
+	io.WriteString(stdout, res.Output) //nolint:errcheck // best-effort stdout
+	if opts.BeadID != "" {
+		if err := headless.Record(store, opts.BeadID, tp.DisplayName(), res); err != nil {
+			fmt.Fprintf(stderr, "gc agent exec: recording on %s: %v\n", opts.BeadID, err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	if !res.OK() {
+		io.WriteString(stderr, res.Stderr)                                              //nolint:errcheck // best-effort stderr
+		fmt.Fprintf(stderr, "gc agent exec: %s: %s\n", tp.DisplayName(), res.Summary()) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return 0
+}
+
+// agentExecInvocation builds the headless run for tp: the agent's start
+// command plus the provider's print_args, with the prompt delivered per
+// the provider's prompt_mode.
+func agentExecInvocation(tp TemplateParams, prompt string) (headless.Invocation, error) {
+	rp := tp.ResolvedProvider
+	if rp == nil || len(rp.PrintArgs) == 0 {
+		name := "start_command"
+		if rp != nil && rp.Name != "" {
+			name = rp.Name
+		}
+		return headless.Invocation{}, fmt.Errorf("agent %q: provider %q has no non-interactive mode (set print_args in [providers.%s])", tp.DisplayName(), name, name)
+	}
+	mode := rp.PromptMode
+	if mode == "none" {
+		// A provider that takes no launch prompt still needs this one.
+		mode = "arg"
+	}
+	return headless.Invocation{
+		Command:    tp.Command,
+		PrintArgs:  rp.PrintArgs,
+		PromptMode: mode,
+		PromptFlag: rp.PromptFlag,
+		Prompt:     prompt,
+		Dir:        tp.WorkDir,
+		Env:        agentExecEnv(tp.Env),
+	}, nil
+}
+
+// agentExecEnv layers the agent's session environment over gc's own.
+// GC_SESSION_NAME is dropped: the headless run is not the agent's
+// session, and hooks keyed on it must not act on the live one.
+func agentExecEnv(agentEnv map[string]string) []string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	for k, v := range agentEnv {
+		env[k] = v
+	}
+	delete(env, "GC_SESSION_NAME")
+	out := make([]string, 0, len(env))
+	for k, v := range env {
+		out = append(out, k+"="+v)
+	}
+	sort.Strings(out)
+	return out
+}
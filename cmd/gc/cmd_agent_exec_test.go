@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/headless"
+)
+
+// fakeAgentExec replaces runAgentExec with one returning res and
+// capturing the invocation and policy it was given.
+func fakeAgentExec(t *testing.T, res headless.Result) (*headless.Invocation, *headless.RetryPolicy) {
+	t.Helper()
+	var inv headless.Invocation
+	var policy headless.RetryPolicy
+	old := runAgentExec
+	t.Cleanup(func() { runAgentExec = old })
+	runAgentExec = func(_ context.Context, i headless.Invocation, p headless.RetryPolicy) headless.Result {
+		inv, policy = i, p
+		return res
+	}
+	return &inv, &policy
+}
+
+func TestDoAgentExec(t *testing.T) {
+	t.Setenv("GC_SESSION_NAME", "city--mayor")
+	inv, policy := fakeAgentExec(t, headless.Result{Output: "done\n", Attempts: 1})
+
+	tp := TemplateParams{
+		Command:      "claude --dangerously-skip-permissions",
+		WorkDir:      "/city/rigs/api",
+		Env:          map[string]string{"GC_AGENT": "api/reviewer"},
+		TemplateName: "api/reviewer",
+		ResolvedProvider: &config.ResolvedProvider{
+			Name:       "claude",
+			PromptMode: "arg",
+			PrintArgs:  []string{"-p"},
+		},
+	}
+	opts := agentExecOpts{Timeout: time.Minute, Retries: 2, Backoff: time.Second}
+	var stdout, stderr bytes.Buffer
+	if code := doAgentExec(tp, "what's left?", opts, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("doAgentExec = %d; stderr: %s", code, stderr.String())
+	}
+	line, err := inv.CommandLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `claude --dangerously-skip-permissions '-p' 'what'\''s left?'`; line != want {
+		t.Errorf("command = %q, want %q", line, want)
+	}
+	if inv.Dir != "/city/rigs/api" || inv.Timeout != time.Minute {
+		t.Errorf("dir = %q, timeout = %s", inv.Dir, inv.Timeout)
+	}
+	if policy.MaxAttempts != 3 || policy.Backoff != time.Second {
+		t.Errorf("policy = %+v", *policy)
+	}
+	if !slices.Contains(inv.Env, "GC_AGENT=api/reviewer") {
+		t.Errorf("env missing GC_AGENT")
+	}
+	for _, kv := range inv.Env {
+		if strings.HasPrefix(kv, "GC_SESSION_NAME=") {
+			t.Errorf("env leaks %s", kv)
+		}
+	}
+	if stdout.String() != "done\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	tp.ResolvedProvider.PromptMode = "none"
+	if code := doAgentExec(tp, "hi", opts, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("doAgentExec(none) = %d", code)
+	}
+	if inv.PromptMode != "arg" {
+		t.Errorf("prompt_mode none ran as %q, want arg", inv.PromptMode)
+	}
+}
+
+func TestDoAgentExecNoPrintMode(t *testing.T) {
+	fakeAgentExec(t, headless.Result{})
+	tp := TemplateParams{
+		Command:          "codex",
+		TemplateName:     "worker",
+		ResolvedProvider: &config.ResolvedProvider{Name: "codex"},
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doAgentExec(tp, "hi", agentExecOpts{}, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("doAgentExec = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "[providers.codex]") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestDoAgentExecRecordsFailure(t *testing.T) {
+	fakeAgentExec(t, headless.Result{ExitCode: 2, Stderr: "rate limited\n", Attempts: 3})
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "Gate check"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp := TemplateParams{
+		Command:          "claude",
+		TemplateName:     "gatekeeper",
+		ResolvedProvider: &config.ResolvedProvider{Name: "claude", PrintArgs: []string{"-p"}},
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doAgentExec(tp, "hi", agentExecOpts{BeadID: b.ID}, store, &stdout, &stderr); code != 1 {
+		t.Fatalf("doAgentExec = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "gatekeeper: exit 2 after 3 attempts") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	got, _ := store.Get(b.ID)
+	if got.Metadata[headless.MetaExitCode] != "2" || got.Metadata[headless.MetaAttempts] != "3" {
+		t.Errorf("metadata = %v", got.Metadata)
+	}
+	if len(got.Comments) != 1 || got.Comments[0].Author != "gatekeeper" || !strings.Contains(got.Comments[0].Text, "rate limited") {
+		t.Errorf("comments = %+v", got.Comments)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newAgentHeartbeatCmd(stdout, stderr io.Writer) *cobra.Command {
+	var bead string
+	cmd := &cobra.Command{
+		Use:   "heartbeat [name]",
+		Short: "Report that this agent is alive and working",
+		Long: `Record a liveness heartbeat for an agent in .gc/state/heartbeats.json.
+
+Agents call this periodically, typically from a provider hook that fires
+on every tool call or turn. The controller flags an agent whose session
+is alive but whose last heartbeat is older than [daemon]
+heartbeat_timeout (default 10m), catching a hung CLI that session
+liveness alone cannot. Agents that never send a heartbeat are not
+checked.
+
+The heartbeat records the bead the agent is working on: --bead, or else
+the first in_progress bead assigned to the agent. Uses $GC_AGENT,
+$GC_CITY, and $GC_SESSION_NAME when called without a name.`,
+		Example: `  gc agent heartbeat
+  gc agent heartbeat --bead gc-42`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdAgentHeartbeat(args, bead, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&bead, "bead", "", "bead the agent is working on (default: its in_progress bead)")
+	return cmd
+}
+
+// cmdAgentHeartbeat is the CLI entry point for recording a heartbeat.
+func cmdAgentHeartbeat(args []string, bead string, stdout, stderr io.Writer) int {
+	_ = stdout // heartbeat is silent on success
+	agentName := os.Getenv("GC_AGENT")
+	cityPath := os.Getenv("GC_CITY")
+	sessionName := os.Getenv("GC_SESSION_NAME")
+	if len(args) > 0 {
+		agentName, sessionName = args[0], ""
+	}
+	if agentName == "" {
+		fmt.Fprintln(stderr, "gc agent heartbeat: missing agent name (not in an agent session)") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if cityPath == "" || len(args) > 0 {
+		var err error
+		if cityPath, err = resolveCity(); err != nil {
+			fmt.Fprintf(stderr, "gc agent heartbeat: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent heartbeat: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if len(args) > 0 {
+		a, ok := resolveAgentIdentity(cfg, agentName, currentRigContext(cfg))
+		if !ok {
+			fmt.Fprintln(stderr, agentNotFoundMsg("gc agent heartbeat", agentName, cfg)) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		agentName = a.QualifiedName()
+	}
+	if sessionName == "" {
+		cityName := cfg.Workspace.Name
+		if cityName == "" {
+			cityName = filepath.Base(cityPath)
+		}
+		sessionName = cliSessionName(cityPath, cityName, agentName, cfg.Workspace.SessionTemplate)
+	}
+	if bead == "" {
+		bead = agentInProgressBead(cityPath, cfg, agentName)
+	}
+	return doAgentHeartbeat(cityPath, agentName, sessionName, bead, time.Now(), stderr)
+}
+
+// doAgentHeartbeat records the heartbeat.
+func doAgentHeartbeat(cityPath, agentName, sessionName, bead string, now time.Time, stderr io.Writer) int {
+	hb := agentHeartbeat{Session: sessionName, Bead: bead, At: now.UTC()}
+	if err := recordHeartbeat(cityPath, agentName, hb); err != nil {
+		fmt.Fprintf(stderr, "gc agent heartbeat: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return 0
+}
+
+// agentInProgressBead returns the ID of the first in_progress bead
+// assigned to agentName in its rig's store (or the city store), or "".
+func agentInProgressBead(cityPath string, cfg *config.City, agentName string) string {
+	dir, _ := config.ParseQualifiedName(agentName)
+	store, err := openCityStoreAt(cityPath)
+	if dir != "" {
+		resolveRigPaths(cityPath, cfg.Rigs)
+		for _, r := range cfg.Rigs {
+			if r.Name == dir {
+				store, err = openRigStoreAt(cityPath, r.Path)
+				break
+			}
+		}
+	}
+	if err != nil || store == nil {
+		return ""
+	}
+	assigned, err := store.ListByAssignee(agentName, "in_progress", 1)
+	if err != nil || len(assigned) == 0 {
+		return ""
+	}
+	return assigned[0].ID
+}
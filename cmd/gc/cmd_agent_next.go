@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/spf13/cobra"
+)
+
+func newAgentNextCmd(stdout, stderr io.Writer) *cobra.Command {
+	var assignee string
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "next [name]",
+		Short: "Claim the next ready bead in a pool's queue",
+		Long: `Claim the most urgent ready bead in a pool agent's queue and print it.
+
+The queue is the unassigned, unblocked open beads labeled
+pool:<pool-name>. The claimed bead is assigned to the caller and set to
+in_progress in one step, so pool members polling the same queue never
+claim the same bead. The file and SQLite bead stores claim under one
+lock or transaction; bd-backed stores fall back to read-then-update.
+
+The bead is assigned to --assignee, else $GC_SESSION_NAME, else the
+agent's session name. Uses $GC_AGENT when called without a name. Exits
+1 with no output when the queue is empty.`,
+		Example: `  gc agent next polecat
+  gc agent next myrig/polecat --json
+  gc agent next --assignee polecat-2`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdAgentNext(args, assignee, jsonOutput, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&assignee, "assignee", "", "claim for this assignee (default: $GC_SESSION_NAME)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the claimed bead as JSON")
+	return cmd
+}
+
+// cmdAgentNext is the CLI entry point for gc agent next. Resolves the
+// pool agent and its store, then claims from its queue.
+func cmdAgentNext(args []string, assignee string, jsonOutput bool, stdout, stderr io.Writer) int {
+	agentName := os.Getenv("GC_AGENT")
+	if len(args) > 0 {
+		agentName = args[0]
+	}
+	if agentName == "" {
+		fmt.Fprintln(stderr, "gc agent next: agent not specified (set $GC_AGENT or pass as argument)") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent next: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent next: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	a, ok := resolveAgentIdentity(cfg, agentName, currentRigContext(cfg))
+	if !ok {
+		fmt.Fprintln(stderr, agentNotFoundMsg("gc agent next", agentName, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	poolLabel := a.PoolName
+	if poolLabel == "" && a.IsPool() {
+		poolLabel = a.QualifiedName()
+	}
+	if poolLabel == "" {
+		fmt.Fprintf(stderr, "gc agent next: agent %q is not a pool; its work is assigned directly (see gc hook)\n", a.QualifiedName()) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if assignee == "" {
+		assignee = os.Getenv("GC_SESSION_NAME")
+	}
+	if assignee == "" {
+		cityName := cfg.Workspace.Name
+		if cityName == "" {
+			cityName = filepath.Base(cityPath)
+		}
+		assignee = cliSessionName(cityPath, cityName, a.QualifiedName(), cfg.Workspace.SessionTemplate)
+	}
+	store, err := openRigStoreAt(cityPath, rigDirForAgent(cfg, a))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent next: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doAgentNext(store, "pool:"+poolLabel, assignee, jsonOutput, stdout, stderr)
+}
+
+// doAgentNext claims the next ready bead labeled label for assignee and
+// prints it. Returns 1 without output when nothing is ready.
+func doAgentNext(store beads.Store, label, assignee string, jsonOutput bool, stdout, stderr io.Writer) int {
+	b, ok, err := beads.ClaimNext(store, label, assignee)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc agent next: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if !ok {
+		return 1
+	}
+	if jsonOutput {
+		writeBeadJSON(b, stdout)
+		return 0
+	}
+	writeBeadDetail(b, stdout)
+	return 0
+}
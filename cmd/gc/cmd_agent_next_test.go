@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestDoAgentNext(t *testing.T) {
+	store := beads.NewMemStore()
+	p1 := 1
+	if _, err := store.Create(beads.Bead{Title: "later", Labels: []string{"pool:polecat"}}); err != nil {
+		t.Fatal(err)
+	}
+	urgent, err := store.Create(beads.Bead{Title: "urgent", Priority: &p1, Labels: []string{"pool:polecat"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doAgentNext(store, "pool:polecat", "polecat-1", true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doAgentNext = %d; stderr: %s", code, stderr.String())
+	}
+	var got beads.Bead
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("parsing output: %v\n%s", err, stdout.String())
+	}
+	if got.ID != urgent.ID || got.Assignee != "polecat-1" || got.Status != "in_progress" {
+		t.Errorf("claimed %s (%s, %s), want %s in_progress for polecat-1", got.ID, got.Assignee, got.Status, urgent.ID)
+	}
+
+	stdout.Reset()
+	if code := doAgentNext(store, "pool:polecat", "polecat-2", false, &stdout, &stderr); code != 0 {
+		t.Fatalf("second doAgentNext = %d; stderr: %s", code, stderr.String())
+	}
+	stdout.Reset()
+	if code := doAgentNext(store, "pool:polecat", "polecat-3", false, &stdout, &stderr); code != 1 {
+		t.Errorf("doAgentNext on empty queue = %d, want 1", code)
+	}
+	if stdout.Len() != 0 || stderr.Len() != 0 {
+		t.Errorf("empty queue printed output: stdout=%q stderr=%q", stdout.String(), stderr.String())
+	}
+}
@@ -2,10 +2,16 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/gastownhall/gascity/internal/agent"
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/gastownhall/gascity/internal/runtime"
 )
 
 // ---------------------------------------------------------------------------
@@ -51,7 +57,7 @@ name = "test-city"
 `)
 
 	var stdout, stderr bytes.Buffer
-	code := doAgentAdd(fs, "/city", "myrig/worker", "", "", false, &stdout, &stderr)
+	code := doAgentAdd(fs, "/city", "myrig/worker", "", "", "", false, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -64,6 +70,66 @@ name = "test-city"
 	}
 }
 
+// ---------------------------------------------------------------------------
+// doAgentAdd --role
+// ---------------------------------------------------------------------------
+
+func TestDoAgentAddBuiltinRole(t *testing.T) {
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte("[workspace]\nname = \"test-city\"\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := doAgentAdd(fs, "/city", "builder", "", "", "reviewer", false, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	cfg, err := config.Load(fs, "/city/city.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := cfg.Agents[0]
+	if a.PromptTemplate != "prompts/reviewer.md" || a.WorkQuery != "bd ready --label=role:reviewer --limit=1" ||
+		a.SlingQuery != "bd update {} --add-label=role:reviewer" {
+		t.Errorf("agent = %+v, want reviewer role fields", a)
+	}
+	if !strings.Contains(string(fs.Files["/city/prompts/reviewer.md"]), "# Reviewer") {
+		t.Error("built-in reviewer prompt not written to prompts/reviewer.md")
+	}
+}
+
+func TestDoAgentAddCityRole(t *testing.T) {
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte(`[workspace]
+name = "test-city"
+
+[[roles]]
+name = "reviewer"
+prompt_template = "prompts/strict.md"
+provider = "codex"
+args = ["--full-auto"]
+`)
+
+	var stdout, stderr bytes.Buffer
+	if code := doAgentAdd(fs, "/city", "builder", "prompts/mine.md", "", "reviewer", false, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	cfg, err := config.Load(fs, "/city/city.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := cfg.Agents[0]
+	if a.PromptTemplate != "prompts/mine.md" || a.Provider != "codex" || len(a.Args) != 1 {
+		t.Errorf("agent = %+v, want city role with explicit prompt template", a)
+	}
+	if _, ok := fs.Files["/city/prompts/reviewer.md"]; ok {
+		t.Error("city role should shadow the built-in prompt")
+	}
+
+	stderr.Reset()
+	if code := doAgentAdd(fs, "/city", "other", "", "", "nope", false, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), `unknown role "nope"`) {
+		t.Errorf("unknown role: code = %d, stderr = %q", code, stderr.String())
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Pack-preservation tests: write-back must NOT expand includes
 // ---------------------------------------------------------------------------
@@ -108,7 +174,7 @@ func TestDoAgentAddPreservesConfig(t *testing.T) {
 	fs := packConfigWithFragment(t)
 
 	var stdout, stderr bytes.Buffer
-	code := doAgentAdd(&fs, "/city", "new-agent", "", "", false, &stdout, &stderr)
+	code := doAgentAdd(&fs, "/city", "new-agent", "", "", "", false, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -170,3 +236,125 @@ func TestDoAgentResumePackDerivedError(t *testing.T) {
 		t.Errorf("stderr should mention patches: %s", errMsg)
 	}
 }
+
+func agentRemoveFS() *fsys.Fake {
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte(`[workspace]
+name = "test-city"
+
+[[agent]]
+name = "mayor"
+
+[[agent]]
+name = "worker"
+
+[[agent]]
+name = "polecat"
+dir = "myrig"
+
+[agent.pool]
+min = 0
+max = 2
+`)
+	return fs
+}
+
+func TestDoAgentRemove(t *testing.T) {
+	fs := agentRemoveFS()
+	var stdout, stderr bytes.Buffer
+	code := doAgentRemove(fs, "/city", "worker", "", nil, nil, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	data := string(fs.Files["/city/city.toml"])
+	if strings.Contains(data, `"worker"`) {
+		t.Errorf("city.toml still contains worker:\n%s", data)
+	}
+	if !strings.Contains(data, `"mayor"`) || !strings.Contains(data, `"polecat"`) {
+		t.Errorf("city.toml lost other agents:\n%s", data)
+	}
+}
+
+func TestDoAgentRemoveNotFound(t *testing.T) {
+	fs := agentRemoveFS()
+	var stdout, stderr bytes.Buffer
+	if code := doAgentRemove(fs, "/city", "ghost", "", nil, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("code = %d, want 1", code)
+	}
+}
+
+func TestDoAgentRemovePackAgentRejected(t *testing.T) {
+	fs := packConfigWithFragment(t)
+	var stdout, stderr bytes.Buffer
+	if code := doAgentRemove(&fs, "/city", "myrig/pack-worker", "", nil, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("code = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "[[patches]]") {
+		t.Errorf("stderr = %q, want pack hint", stderr.String())
+	}
+}
+
+func TestDoAgentRemoveWarnsAboutRoutedBeads(t *testing.T) {
+	fs := agentRemoveFS()
+	store := beads.NewMemStore()
+	sn := agent.SessionNameFor("test-city", "worker", "")
+	b, err := store.Create(beads.Bead{Title: "task", Assignee: sn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doAgentRemove(fs, "/city", "worker", "", nil, store, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), b.ID) {
+		t.Errorf("stderr = %q, want warning naming %s", stderr.String(), b.ID)
+	}
+}
+
+func TestDoAgentRemoveReassigns(t *testing.T) {
+	fs := agentRemoveFS()
+	store := beads.NewMemStore()
+	worker := agent.SessionNameFor("test-city", "worker", "")
+	a, err := store.Create(beads.Bead{Title: "open task", Assignee: worker})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := store.Create(beads.Bead{Title: "active task", Assignee: "worker"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inProgress := "in_progress"
+	if err := store.Update(b.ID, beads.UpdateOpts{Status: &inProgress}); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doAgentRemove(fs, "/city", "worker", "myrig/polecat", nil, store, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	for _, id := range []string{a.ID, b.ID} {
+		got, _ := store.Get(id)
+		if got.Assignee != "" || got.Status != "open" || !slices.Contains(got.Labels, "pool:myrig/polecat") {
+			t.Errorf("bead %s = assignee %q status %q labels %v, want pool-routed and open", id, got.Assignee, got.Status, got.Labels)
+		}
+	}
+	if !strings.Contains(stdout.String(), "Reassigned 2 bead(s)") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
+
+func TestDoAgentRemoveKillsSession(t *testing.T) {
+	fs := agentRemoveFS()
+	sp := runtime.NewFake()
+	sn := agent.SessionNameFor("test-city", "worker", "")
+	if err := sp.Start(context.Background(), sn, runtime.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doAgentRemove(fs, "/city", "worker", "", sp, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	if sp.IsRunning(sn) {
+		t.Error("session still running after --kill")
+	}
+}
@@ -214,9 +214,10 @@ func scanAllAutomations(cityPath string, cfg *config.City, stderr io.Writer, cmd
 		rigAA = append(rigAA, ra...)
 	}
 
-	allAA := make([]automations.Automation, 0, len(cityAA)+len(rigAA))
+	allAA := make([]automations.Automation, 0, len(cityAA)+len(rigAA)+len(cfg.Schedules))
 	allAA = append(allAA, cityAA...)
 	allAA = append(allAA, rigAA...)
+	allAA = append(allAA, scheduleAutomations(cityPath, cfg)...)
 	return allAA, nil
 }
 
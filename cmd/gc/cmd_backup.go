@@ -0,0 +1,598 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// backupFormat is the archive layout version written by gc backup. Bump
+// it when the layout changes incompatibly; gc restore refuses archives
+// newer than it understands.
+const backupFormat = 1
+
+// backupManifestName is the manifest entry written first in every archive.
+const backupManifestName = "gc-backup.json"
+
+// backupRoots are the city-relative paths captured by gc backup, when
+// present. .beads/ holds the bd store (and formula symlinks); the file
+// and sqlite stores live under .gc/.
+var backupRoots = []string{
+	citylayout.CityConfigFile,
+	"pack.toml",
+	citylayout.RuntimeRoot,
+	".beads",
+	citylayout.PromptsRoot,
+	citylayout.FormulasRoot,
+	citylayout.AutomationsRoot,
+	citylayout.HooksRoot,
+	citylayout.ScriptsRoot,
+	"rigs",
+}
+
+// BackupManifest describes a backup archive. It is stored as
+// gc-backup.json at the archive root.
+type BackupManifest struct {
+	Format    int         `json:"format"`
+	GCVersion string      `json:"gc_version"`
+	City      string      `json:"city"`
+	CreatedAt time.Time   `json:"created_at"`
+	Entries   []string    `json:"entries"`
+	Rigs      []BackupRig `json:"rigs,omitempty"`
+	Skipped   []string    `json:"skipped,omitempty"`
+	// Excluded lists the secrets files (agent env_file targets) left out
+	// of the archive. Restore keeps the city's current copies of them.
+	Excluded []string `json:"excluded,omitempty"`
+}
+
+// BackupRig records a rig registered in city.toml at backup time. Rig
+// repositories are not archived; restore warns when a path is missing.
+type BackupRig struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// skipBackupPath reports whether a city-relative path is runtime-only
+// state that must not be archived: caches, sockets, pid and lock files.
+func skipBackupPath(rel string) bool {
+	if rel == citylayout.CacheRoot || strings.HasPrefix(rel, citylayout.CacheRoot+"/") {
+		return true
+	}
+	switch path.Ext(rel) {
+	case ".sock", ".pid", ".lock":
+		return true
+	}
+	return false
+}
+
+func newBackupCmd(stdout, stderr io.Writer) *cobra.Command {
+	var output string
+	var includeSecrets bool
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Snapshot city config and state into a tar.gz archive",
+		Long: `Snapshot the city into a gzipped tar archive.
+
+The archive holds city.toml, pack.toml, .gc/ (bead, event, and session
+state), .beads/, and the prompts/, formulas/, automations/, hooks/,
+scripts/, and rigs/ directories. Caches, sockets, and pid/lock files are
+skipped. A gc-backup.json manifest records the archive format version
+and the rigs registered in city.toml; rig repositories themselves are
+not archived.
+
+Agent env_file targets (such as .gc/secrets.env) hold API keys in
+plaintext, so they are left out unless --include-secrets is given;
+restoring such an archive keeps the city's current secrets files. With
+--include-secrets, store the archive as carefully as the secrets.
+
+Stop the city first ("gc stop") for a consistent snapshot of a running
+bd/dolt store.`,
+		Example: `  gc backup
+  gc backup --output /backups/city-$(date +%F).tar.gz
+  gc backup --include-secrets --output /secure/city.tar.gz`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdBackup(output, includeSecrets, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "archive path (default: <city>-<timestamp>.tar.gz in the current directory)")
+	cmd.Flags().BoolVar(&includeSecrets, "include-secrets", false, "also archive agent env_file secrets (stored in plaintext)")
+	return cmd
+}
+
+func newRestoreCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <file.tar.gz> [path]",
+		Short: "Restore a city from a gc backup archive",
+		Long: `Restore a city from an archive written by "gc backup".
+
+Restores into the current city, or into path (created if needed) when
+given. The archive is extracted and verified in a staging directory
+first, then each archived entry replaces its counterpart in one rename;
+if any swap fails, the entries already swapped are rolled back. Files
+not present in the archive are left alone.
+
+The city must be stopped. Archives written by a newer gc with an
+unknown format version are rejected.`,
+		Example: `  gc restore city-20261015-093000.tar.gz
+  gc restore backup.tar.gz ~/cities/recovered`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdRestore(args, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// cmdBackup is the CLI entry point for gc backup.
+func cmdBackup(output string, includeSecrets bool, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc backup: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc backup: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	name := cfg.Workspace.Name
+	if name == "" {
+		name = filepath.Base(cityPath)
+	}
+	if output == "" {
+		output = fmt.Sprintf("%s-%s.tar.gz", name, time.Now().Format("20060102-150405"))
+	}
+	if pid := controllerAlive(cityPath); pid != 0 {
+		fmt.Fprintf(stderr, "gc backup: warning: controller is running (pid %d); bead store may change during the snapshot\n", pid) //nolint:errcheck // best-effort stderr
+	}
+	m := BackupManifest{City: name}
+	for _, r := range cfg.Rigs {
+		m.Rigs = append(m.Rigs, BackupRig{Name: r.Name, Path: r.Path, Prefix: r.Prefix})
+	}
+	if !includeSecrets {
+		m.Excluded = backupSecretPaths(cityPath, cfg)
+	}
+	return doBackup(cityPath, output, m, stdout, stderr)
+}
+
+// backupSecretPaths returns the city-relative paths of the agents'
+// env_file targets that lie inside cityPath, sorted and deduplicated.
+func backupSecretPaths(cityPath string, cfg *config.City) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, a := range cfg.Agents {
+		if a.EnvFile == "" {
+			continue
+		}
+		p := a.EnvFile
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(cityPath, p)
+		}
+		rel, err := filepath.Rel(cityPath, p)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		if !seen[rel] {
+			seen[rel] = true
+			out = append(out, rel)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// doBackup writes the archive for cityPath to output. m supplies the city
+// name, rigs, and the paths to exclude; the rest of the manifest is
+// filled in here, and Excluded is narrowed to the files actually left out. The archive
+// is written to a temp file and renamed into place, so a failed backup
+// never leaves a truncated archive behind.
+func doBackup(cityPath, output string, m BackupManifest, stdout, stderr io.Writer) int {
+	m.Format = backupFormat
+	m.GCVersion = version
+	m.CreatedAt = time.Now().UTC()
+	for _, root := range backupRoots {
+		if _, err := os.Lstat(filepath.Join(cityPath, root)); err == nil {
+			m.Entries = append(m.Entries, root)
+		}
+	}
+	if len(m.Entries) == 0 {
+		fmt.Fprintf(stderr, "gc backup: nothing to back up in %s\n", cityPath) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	absOut, err := filepath.Abs(output)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc backup: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(absOut), ".gc-backup-*")
+	if err != nil {
+		fmt.Fprintf(stderr, "gc backup: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // no-op after rename
+
+	files, err := writeBackup(tmp, cityPath, absOut, &m)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), absOut)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc backup: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	for _, s := range m.Skipped {
+		fmt.Fprintf(stderr, "gc backup: skipped %s (not a regular file, directory, or symlink)\n", s) //nolint:errcheck // best-effort stderr
+	}
+	for _, s := range m.Excluded {
+		fmt.Fprintf(stderr, "gc backup: left out secrets file %s (use --include-secrets to archive it)\n", s) //nolint:errcheck // best-effort stderr
+	}
+	fmt.Fprintf(stdout, "Backed up %s (%d files) to %s\n", m.City, files, output) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// writeBackup streams the manifest and every file under m.Entries into w
+// as a gzipped tar, skipping the archive itself and the paths in
+// m.Excluded. Returns the number of files written. Unsupported file types
+// are recorded in m.Skipped; m.Excluded keeps only the paths found.
+func writeBackup(w io.Writer, cityPath, absOut string, m *BackupManifest) (int, error) {
+	// Collect paths first so the manifest (written first) can list what
+	// was skipped.
+	type item struct {
+		rel  string
+		info fs.FileInfo
+	}
+	var items []item
+	exclude := make(map[string]bool, len(m.Excluded))
+	for _, e := range m.Excluded {
+		exclude[e] = true
+	}
+	m.Excluded = nil
+	for _, root := range m.Entries {
+		err := filepath.Walk(filepath.Join(cityPath, root), func(p string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(cityPath, p)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if skipBackupPath(rel) || p == absOut || strings.HasPrefix(info.Name(), ".gc-backup-") {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if exclude[rel] && !info.IsDir() {
+				m.Excluded = append(m.Excluded, rel)
+				return nil
+			}
+			if !info.Mode().IsRegular() && !info.IsDir() && info.Mode()&fs.ModeSymlink == 0 {
+				m.Skipped = append(m.Skipped, rel)
+				return nil
+			}
+			items = append(items, item{rel, info})
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name: backupManifestName, Mode: 0o644, Size: int64(len(data)), ModTime: m.CreatedAt,
+	}); err != nil {
+		return 0, err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return 0, err
+	}
+
+	files := 0
+	for _, it := range items {
+		link := ""
+		if it.info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(filepath.Join(cityPath, it.rel)); err != nil {
+				return 0, err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(it.info, link)
+		if err != nil {
+			return 0, err
+		}
+		hdr.Name = it.rel
+		if it.info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return 0, err
+		}
+		if !it.info.Mode().IsRegular() {
+			continue
+		}
+		if err := copyFileInto(tw, filepath.Join(cityPath, it.rel)); err != nil {
+			return 0, err
+		}
+		files++
+	}
+	if err := tw.Close(); err != nil {
+		return 0, err
+	}
+	return files, gz.Close()
+}
+
+// copyFileInto copies the file at p into w.
+func copyFileInto(w io.Writer, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck // read-only
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// cmdRestore is the CLI entry point for gc restore.
+func cmdRestore(args []string, stdout, stderr io.Writer) int {
+	var cityPath string
+	var err error
+	if len(args) > 1 {
+		cityPath, err = filepath.Abs(args[1])
+		if err == nil {
+			err = os.MkdirAll(cityPath, 0o755)
+		}
+	} else {
+		cityPath, err = resolveCity()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc restore: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if pid := controllerAlive(cityPath); pid != 0 {
+		fmt.Fprintf(stderr, "gc restore: controller is running (pid %d); run \"gc stop\" first\n", pid) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doRestore(args[0], cityPath, stdout, stderr)
+}
+
+// doRestore extracts archive into a staging directory inside cityPath,
+// then swaps each manifest entry into place, rolling back on failure.
+func doRestore(archive, cityPath string, stdout, stderr io.Writer) int {
+	staging, err := os.MkdirTemp(cityPath, ".gc-restore-")
+	if err != nil {
+		fmt.Fprintf(stderr, "gc restore: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	defer os.RemoveAll(staging) //nolint:errcheck // best-effort cleanup
+
+	m, err := extractBackup(archive, filepath.Join(staging, "new"))
+	if err == nil {
+		err = keepExcluded(cityPath, filepath.Join(staging, "new"), m.Excluded)
+	}
+	if err == nil {
+		err = swapRestored(cityPath, staging, m.Entries)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc restore: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	for _, r := range m.Rigs {
+		if _, err := os.Stat(r.Path); err != nil {
+			fmt.Fprintf(stderr, "gc restore: warning: rig %q path %s is missing\n", r.Name, r.Path) //nolint:errcheck // best-effort stderr
+		}
+	}
+	fmt.Fprintf(stdout, "Restored %s from %s (backup taken %s by gc %s)\n", //nolint:errcheck // best-effort stdout
+		cityPath, archive, m.CreatedAt.Local().Format(time.RFC3339), m.GCVersion)
+	return 0
+}
+
+// extractBackup reads archive into dest and returns its manifest. The
+// manifest must come first, carry a supported format, and list every
+// top-level path in the archive. Entries are written through an os.Root
+// at dest, so neither a path nor a symlink extracted earlier can lead a
+// write outside it; symlinks that are absolute or point outside dest are
+// rejected.
+func extractBackup(archive, dest string) (BackupManifest, error) {
+	var m BackupManifest
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return m, err
+	}
+	root, err := os.OpenRoot(dest)
+	if err != nil {
+		return m, err
+	}
+	defer root.Close() //nolint:errcheck // read-only handle
+	f, err := os.Open(archive)
+	if err != nil {
+		return m, err
+	}
+	defer f.Close() //nolint:errcheck // read-only
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return m, fmt.Errorf("%s: not a gc backup: %w", archive, err)
+	}
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != backupManifestName {
+		return m, fmt.Errorf("%s: not a gc backup (missing %s)", archive, backupManifestName)
+	}
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return m, fmt.Errorf("%s: reading manifest: %w", archive, err)
+	}
+	if m.Format < 1 || m.Format > backupFormat {
+		return m, fmt.Errorf("%s: backup format %d is not supported by this gc (supports up to %d); upgrade gc", archive, m.Format, backupFormat)
+	}
+	allowed := make(map[string]bool, len(m.Entries))
+	for _, e := range m.Entries {
+		allowed[e] = true
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return m, fmt.Errorf("%s: %w", archive, err)
+		}
+		name := path.Clean(strings.TrimSuffix(hdr.Name, "/"))
+		top, _, _ := strings.Cut(name, "/")
+		if !allowed[top] || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return m, fmt.Errorf("%s: unexpected entry %q", archive, hdr.Name)
+		}
+		target := filepath.FromSlash(name)
+		if err := root.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return m, fmt.Errorf("%s: %w", archive, err)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = root.MkdirAll(target, hdr.FileInfo().Mode().Perm())
+		case tar.TypeSymlink:
+			if link := path.Join(path.Dir(name), hdr.Linkname); path.IsAbs(hdr.Linkname) || link == ".." || strings.HasPrefix(link, "../") {
+				return m, fmt.Errorf("%s: symlink %q points outside the city (%s)", archive, hdr.Name, hdr.Linkname)
+			}
+			err = root.Symlink(hdr.Linkname, target)
+		case tar.TypeReg:
+			err = writeRestoredFile(root, target, tr, hdr.FileInfo().Mode().Perm())
+		default:
+			err = fmt.Errorf("unsupported entry type for %q", hdr.Name)
+		}
+		if err != nil {
+			return m, fmt.Errorf("%s: %w", archive, err)
+		}
+	}
+	for _, e := range m.Entries {
+		if _, err := root.Lstat(e); err != nil {
+			return m, fmt.Errorf("%s: manifest lists %q but the archive has no such entry", archive, e)
+		}
+	}
+	return m, nil
+}
+
+// keepExcluded copies the city's current copy of each file the backup
+// left out (secrets) into the extracted tree at dest, so swapping the
+// restored entries in does not delete them. Files the city lacks stay
+// missing.
+func keepExcluded(cityPath, dest string, excluded []string) error {
+	root, err := os.OpenRoot(dest)
+	if err != nil {
+		return err
+	}
+	defer root.Close() //nolint:errcheck // read-only handle
+	for _, rel := range excluded {
+		name := path.Clean(rel)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			continue
+		}
+		src := filepath.Join(cityPath, filepath.FromSlash(name))
+		info, err := os.Stat(src)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		target := filepath.FromSlash(name)
+		if _, err := root.Lstat(filepath.Dir(target)); err != nil {
+			continue // its directory is not part of the restore
+		}
+		f, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		err = writeRestoredFile(root, target, f, info.Mode().Perm())
+		f.Close() //nolint:errcheck // read-only
+		if err != nil {
+			return fmt.Errorf("keeping %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+// writeRestoredFile writes r to name under root with the given
+// permissions.
+func writeRestoredFile(root *os.Root, name string, r io.Reader, perm fs.FileMode) error {
+	f, err := root.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close() //nolint:errcheck // already failing
+		return err
+	}
+	return f.Close()
+}
+
+// swapRestored moves each extracted entry from staging/new into cityPath,
+// parking the current copy in staging/old. If any rename fails, every
+// entry swapped so far is put back. The staging directory lives inside
+// cityPath, so all renames stay on one filesystem.
+func swapRestored(cityPath, staging string, entries []string) error {
+	oldDir := filepath.Join(staging, "old")
+	if err := os.MkdirAll(oldDir, 0o755); err != nil {
+		return err
+	}
+	type swap struct {
+		entry  string
+		parked bool
+	}
+	var done []swap
+	rollback := func() {
+		for i := len(done) - 1; i >= 0; i-- {
+			live := filepath.Join(cityPath, done[i].entry)
+			os.RemoveAll(live) //nolint:errcheck // best-effort rollback
+			if done[i].parked {
+				os.Rename(filepath.Join(oldDir, done[i].entry), live) //nolint:errcheck // best-effort rollback
+			}
+		}
+	}
+	for _, e := range entries {
+		live := filepath.Join(cityPath, e)
+		s := swap{entry: e}
+		if _, err := os.Lstat(live); err == nil {
+			if err := os.Rename(live, filepath.Join(oldDir, e)); err != nil {
+				rollback()
+				return fmt.Errorf("moving aside %s: %w", e, err)
+			}
+			s.parked = true
+		}
+		if err := os.Rename(filepath.Join(staging, "new", e), live); err != nil {
+			if s.parked {
+				os.Rename(filepath.Join(oldDir, e), live) //nolint:errcheck // best-effort rollback
+			}
+			rollback()
+			return fmt.Errorf("restoring %s: %w", e, err)
+		}
+		done = append(done, s)
+	}
+	return nil
+}
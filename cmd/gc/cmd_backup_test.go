@@ -0,0 +1,260 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func writeBackupFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readBackupFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBackupRestoreRoundTrip(t *testing.T) {
+	city := t.TempDir()
+	writeBackupFile(t, filepath.Join(city, "city.toml"), "[workspace]\nname = \"metro\"\n")
+	writeBackupFile(t, filepath.Join(city, ".gc", "beads.json"), `{"beads":[]}`)
+	writeBackupFile(t, filepath.Join(city, ".gc", "cache", "packs", "big"), "cached")
+	writeBackupFile(t, filepath.Join(city, ".gc", "controller.lock"), "")
+	writeBackupFile(t, filepath.Join(city, "prompts", "mayor.md"), "You are the mayor.")
+	writeBackupFile(t, filepath.Join(city, "notes.txt"), "not archived")
+	if err := os.Symlink("../prompts/mayor.md", filepath.Join(city, ".gc", "mayor-link")); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "metro.tar.gz")
+	m := BackupManifest{City: "metro", Rigs: []BackupRig{{Name: "gone", Path: filepath.Join(city, "no-such-rig")}}}
+	var stdout, stderr bytes.Buffer
+	if code := doBackup(city, archive, m, &stdout, &stderr); code != 0 {
+		t.Fatalf("backup code = %d, stderr = %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Backed up metro (3 files)") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	// Damage the city.
+	writeBackupFile(t, filepath.Join(city, "city.toml"), "broken = [")
+	if err := os.RemoveAll(filepath.Join(city, "prompts")); err != nil {
+		t.Fatal(err)
+	}
+	writeBackupFile(t, filepath.Join(city, ".gc", "stray"), "post-backup")
+
+	stdout.Reset()
+	stderr.Reset()
+	if code := doRestore(archive, city, &stdout, &stderr); code != 0 {
+		t.Fatalf("restore code = %d, stderr = %s", code, stderr.String())
+	}
+	if got := readBackupFile(t, filepath.Join(city, "city.toml")); !strings.Contains(got, "metro") {
+		t.Errorf("city.toml = %q", got)
+	}
+	if got := readBackupFile(t, filepath.Join(city, ".gc", "mayor-link")); got != "You are the mayor." {
+		t.Errorf("symlink target = %q", got)
+	}
+	for _, gone := range []string{".gc/stray", ".gc/cache", ".gc/controller.lock"} {
+		if _, err := os.Lstat(filepath.Join(city, gone)); !os.IsNotExist(err) {
+			t.Errorf("%s survived restore (err = %v)", gone, err)
+		}
+	}
+	if got := readBackupFile(t, filepath.Join(city, "notes.txt")); got != "not archived" {
+		t.Errorf("unarchived file touched: %q", got)
+	}
+	if !strings.Contains(stderr.String(), `rig "gone" path`) {
+		t.Errorf("missing rig warning, stderr = %q", stderr.String())
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(city, ".gc-restore-*")); len(leftovers) != 0 {
+		t.Errorf("staging left behind: %v", leftovers)
+	}
+}
+
+func TestBackupExcludesSecrets(t *testing.T) {
+	city := t.TempDir()
+	writeBackupFile(t, filepath.Join(city, "city.toml"), "[workspace]\nname = \"metro\"\n")
+	writeBackupFile(t, filepath.Join(city, ".gc", "beads.json"), `{"beads":[]}`)
+	writeBackupFile(t, filepath.Join(city, ".gc", "secrets.env"), "API_KEY=old\n")
+
+	archive := filepath.Join(t.TempDir(), "metro.tar.gz")
+	m := BackupManifest{City: "metro", Excluded: []string{".gc/secrets.env", ".gc/absent.env"}}
+	var stdout, stderr bytes.Buffer
+	if code := doBackup(city, archive, m, &stdout, &stderr); code != 0 {
+		t.Fatalf("backup code = %d, stderr = %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "left out secrets file .gc/secrets.env") || strings.Contains(stderr.String(), "absent.env") {
+		t.Errorf("stderr = %q, want a note for the excluded secrets file only", stderr.String())
+	}
+
+	// The archive has no secrets; restoring keeps the city's current ones.
+	writeBackupFile(t, filepath.Join(city, ".gc", "secrets.env"), "API_KEY=rotated\n")
+	stdout.Reset()
+	stderr.Reset()
+	if code := doRestore(archive, city, &stdout, &stderr); code != 0 {
+		t.Fatalf("restore code = %d, stderr = %s", code, stderr.String())
+	}
+	if got := readBackupFile(t, filepath.Join(city, ".gc", "secrets.env")); got != "API_KEY=rotated\n" {
+		t.Errorf("secrets.env = %q, want the current copy kept", got)
+	}
+
+	fresh := t.TempDir()
+	if code := doRestore(archive, fresh, &stdout, &stderr); code != 0 {
+		t.Fatalf("restore code = %d, stderr = %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(fresh, ".gc", "secrets.env")); !os.IsNotExist(err) {
+		t.Errorf("secrets.env restored into a new city (err = %v), want it absent", err)
+	}
+}
+
+func TestBackupSecretPaths(t *testing.T) {
+	city := t.TempDir()
+	cfg := &config.City{Agents: []config.Agent{
+		{Name: "mayor", EnvFile: ".gc/secrets.env"},
+		{Name: "polecat", EnvFile: filepath.Join(city, ".gc", "secrets.env")},
+		{Name: "reviewer", EnvFile: "/etc/gc/keys.env"},
+		{Name: "plain"},
+	}}
+	if got := backupSecretPaths(city, cfg); len(got) != 1 || got[0] != ".gc/secrets.env" {
+		t.Errorf("backupSecretPaths = %v, want [.gc/secrets.env]", got)
+	}
+}
+
+// writeRawBackup writes an archive with the given manifest and extra
+// file entries, bypassing doBackup.
+func writeRawBackup(t *testing.T, m BackupManifest, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	data, _ := json.Marshal(m)
+	tw.WriteHeader(&tar.Header{Name: backupManifestName, Mode: 0o644, Size: int64(len(data))}) //nolint:errcheck
+	tw.Write(data)                                                                             //nolint:errcheck
+	for name, content := range files {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}) //nolint:errcheck
+		tw.Write([]byte(content))                                                                              //nolint:errcheck
+	}
+	tw.Close() //nolint:errcheck
+	gz.Close() //nolint:errcheck
+	path := filepath.Join(t.TempDir(), "raw.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRestoreRejectsBadArchives(t *testing.T) {
+	tests := []struct {
+		name  string
+		m     BackupManifest
+		files map[string]string
+		want  string
+	}{
+		{"future format", BackupManifest{Format: backupFormat + 1, Entries: []string{"city.toml"}}, map[string]string{"city.toml": "x"}, "upgrade gc"},
+		{"escaping path", BackupManifest{Format: 1, Entries: []string{"city.toml"}}, map[string]string{"../evil": "x"}, `unexpected entry "../evil"`},
+		{"unlisted entry", BackupManifest{Format: 1, Entries: []string{"city.toml"}}, map[string]string{"city.toml": "x", "other": "y"}, `unexpected entry "other"`},
+		{"missing entry", BackupManifest{Format: 1, Entries: []string{"city.toml", "prompts"}}, map[string]string{"city.toml": "x"}, `manifest lists "prompts"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			city := t.TempDir()
+			writeBackupFile(t, filepath.Join(city, "city.toml"), "original")
+			var stdout, stderr bytes.Buffer
+			if code := doRestore(writeRawBackup(t, tt.m, tt.files), city, &stdout, &stderr); code != 1 {
+				t.Fatalf("code = %d, want 1", code)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.want)
+			}
+			if got := readBackupFile(t, filepath.Join(city, "city.toml")); got != "original" {
+				t.Errorf("city.toml changed to %q by failed restore", got)
+			}
+		})
+	}
+}
+
+func TestRestoreRejectsSymlinkEscapes(t *testing.T) {
+	outside := t.TempDir()
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{"absolute target", outside, `symlink "prompts/out" points outside the city`},
+		{"relative escape", "../../" + filepath.Base(outside), `symlink "prompts/out" points outside the city`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			city := t.TempDir()
+			writeBackupFile(t, filepath.Join(city, "city.toml"), "original")
+
+			// A symlink followed by an entry written through it.
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			data, _ := json.Marshal(BackupManifest{Format: 1, Entries: []string{"city.toml", "prompts"}})
+			tw.WriteHeader(&tar.Header{Name: backupManifestName, Mode: 0o644, Size: int64(len(data))})         //nolint:errcheck
+			tw.Write(data)                                                                                     //nolint:errcheck
+			tw.WriteHeader(&tar.Header{Name: "prompts/", Mode: 0o755, Typeflag: tar.TypeDir})                  //nolint:errcheck
+			tw.WriteHeader(&tar.Header{Name: "prompts/out", Linkname: tt.link, Typeflag: tar.TypeSymlink})     //nolint:errcheck
+			tw.WriteHeader(&tar.Header{Name: "prompts/out/evil", Mode: 0o644, Size: 4, Typeflag: tar.TypeReg}) //nolint:errcheck
+			tw.Write([]byte("evil"))                                                                           //nolint:errcheck
+			tw.WriteHeader(&tar.Header{Name: "city.toml", Mode: 0o644, Size: 8, Typeflag: tar.TypeReg})        //nolint:errcheck
+			tw.Write([]byte("restored"))                                                                       //nolint:errcheck
+			tw.Close()                                                                                         //nolint:errcheck
+			gz.Close()                                                                                         //nolint:errcheck
+			archive := filepath.Join(t.TempDir(), "evil.tar.gz")
+			if err := os.WriteFile(archive, buf.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var stdout, stderr bytes.Buffer
+			if code := doRestore(archive, city, &stdout, &stderr); code != 1 {
+				t.Fatalf("code = %d, want 1", code)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.want)
+			}
+			if _, err := os.Lstat(filepath.Join(outside, "evil")); !os.IsNotExist(err) {
+				t.Errorf("file written outside the city: %v", err)
+			}
+			if got := readBackupFile(t, filepath.Join(city, "city.toml")); got != "original" {
+				t.Errorf("city.toml changed to %q by failed restore", got)
+			}
+		})
+	}
+}
+
+func TestExtractBackupStaysInsideRoot(t *testing.T) {
+	// A symlink planted in dest before extraction must not be followed
+	// out of it.
+	outside := t.TempDir()
+	dest := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(dest, "prompts")); err != nil {
+		t.Fatal(err)
+	}
+	archive := writeRawBackup(t, BackupManifest{Format: 1, Entries: []string{"prompts"}}, map[string]string{"prompts/evil": "x"})
+	if _, err := extractBackup(archive, dest); err == nil {
+		t.Fatal("extractBackup succeeded, want error writing through symlink")
+	}
+	if _, err := os.Lstat(filepath.Join(outside, "evil")); !os.IsNotExist(err) {
+		t.Errorf("file written outside dest: %v", err)
+	}
+}
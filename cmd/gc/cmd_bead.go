@@ -0,0 +1,846 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+func newBeadCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bead",
+		Short: "Inspect and manage beads (work units)",
+		Long: `Inspect and manage beads in the city's bead store.
+
+Works against whichever store the city is configured for ([beads]
+provider in city.toml, or GC_BEADS).`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc bead: missing subcommand (archive, assign, attach, children, close, comment, create, dedupe, list, merge, move, ready, review, show, tree, unassign)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc bead: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(
+		newBeadArchiveCmd(stdout, stderr),
+		newBeadAssignCmd(stdout, stderr),
+		newBeadAttachCmd(stdout, stderr),
+		newBeadChildrenCmd(stdout, stderr),
+		newBeadCloseCmd(stdout, stderr),
+		newBeadCommentCmd(stdout, stderr),
+		newBeadCreateCmd(stdout, stderr),
+		newBeadDedupeCmd(stdout, stderr),
+		newBeadListCmd(stdout, stderr),
+		newBeadMergeCmd(stdout, stderr),
+		newBeadMoveCmd(stdout, stderr),
+		newBeadReadyCmd(stdout, stderr),
+		newBeadReviewCmd(stdout, stderr),
+		newBeadShowCmd(stdout, stderr),
+		newBeadTreeCmd(stdout, stderr),
+		newBeadUnassignCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newBeadListCmd(stdout, stderr io.Writer) *cobra.Command {
+	var f beads.Filter
+	var jsonFlag, overdue bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List beads with optional filters",
+		Long: `List beads in the city's bead store.
+
+All filters are exact matches and combine with AND. Results are sorted
+by creation time unless --sort names another field; prefix the field
+with "-" to reverse the order. --overdue keeps only unclosed beads past
+their due date.`,
+		Example: `  gc bead list
+  gc bead list --status=open --assignee=worker --sort=created
+  gc bead list --label=urgent --sort=-created --limit=10
+  gc bead list --status=open --sort=priority
+  gc bead list --overdue --sort=due`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if overdue {
+				f.DueBefore = time.Now()
+			}
+			if cmdBeadList(f, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&f.Status, "status", "", "only beads with this status (open, in_progress, closed)")
+	cmd.Flags().StringVar(&f.Assignee, "assignee", "", "only beads assigned to this agent")
+	cmd.Flags().StringVar(&f.Label, "label", "", "only beads carrying this label")
+	cmd.Flags().StringVar(&f.Type, "type", "", "only beads of this type")
+	cmd.Flags().BoolVar(&overdue, "overdue", false, "only unclosed beads past their due date")
+	cmd.Flags().StringVar(&f.Sort, "sort", "", "sort field: "+strings.Join(beads.SortFields(), ", ")+" (prefix - to reverse)")
+	cmd.Flags().IntVar(&f.Limit, "limit", 0, "max beads to show (0 = unlimited)")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// cmdBeadList is the CLI entry point for listing beads.
+func cmdBeadList(f beads.Filter, jsonOutput bool, stdout, stderr io.Writer) int {
+	store, code := openCityStore(stderr, "gc bead list")
+	if store == nil {
+		return code
+	}
+	return doBeadList(store, f, jsonOutput, stdout, stderr)
+}
+
+// doBeadList queries the store with f and prints the matching beads as a
+// table, or as a JSON array when jsonOutput is set.
+func doBeadList(store beads.Store, f beads.Filter, jsonOutput bool, stdout, stderr io.Writer) int {
+	if err := f.Validate(); err != nil {
+		fmt.Fprintf(stderr, "gc bead list: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	bs, err := store.Query(f)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead list: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if jsonOutput {
+		if bs == nil {
+			bs = []beads.Bead{}
+		}
+		writeBeadsJSON(bs, stdout)
+		return 0
+	}
+	if len(bs) == 0 {
+		fmt.Fprintln(stdout, "No beads") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	writeBeadTable(bs, stdout, true)
+	return 0
+}
+
+// defaultBeadReadyInterval is the poll interval for gc bead ready --watch.
+const defaultBeadReadyInterval = 2 * time.Second
+
+func newBeadReadyCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonFlag, watch bool
+	var interval time.Duration
+	cmd := &cobra.Command{
+		Use:   "ready",
+		Short: "List beads that are ready to work on",
+		Long: `List open beads with no open blockers, most urgent priority first
+and oldest first within a priority.
+
+With --watch, the store is re-polled every --interval and the table is
+redrawn whenever the ready set changes. Stop with Ctrl-C.`,
+		Example: `  gc bead ready
+  gc bead ready --watch
+  gc bead ready --watch --interval=10s`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdBeadReady(jsonFlag, watch, interval, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&watch, "watch", false, "re-poll and redraw when the ready set changes")
+	cmd.Flags().DurationVar(&interval, "interval", defaultBeadReadyInterval, "poll interval for --watch")
+	return cmd
+}
+
+// cmdBeadReady is the CLI entry point for listing ready beads.
+func cmdBeadReady(jsonOutput, watch bool, interval time.Duration, stdout, stderr io.Writer) int {
+	if watch && jsonOutput {
+		fmt.Fprintln(stderr, "gc bead ready: --watch and --json are mutually exclusive") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if watch && interval <= 0 {
+		fmt.Fprintf(stderr, "gc bead ready: invalid --interval %s: must be > 0\n", interval) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, code := openCityStore(stderr, "gc bead ready")
+	if store == nil {
+		return code
+	}
+	if !watch {
+		return doBeadReady(store, jsonOutput, stdout, stderr)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	f, ok := stdout.(*os.File)
+	clear := ok && isTerminal(f)
+	return doBeadReadyWatch(ctx, store, interval, clear, stdout, stderr)
+}
+
+// doBeadReady prints the store's ready beads as a table, or as a JSON
+// array when jsonOutput is set.
+func doBeadReady(store beads.Store, jsonOutput bool, stdout, stderr io.Writer) int {
+	bs, err := store.Ready()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead ready: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if jsonOutput {
+		if bs == nil {
+			bs = []beads.Bead{}
+		}
+		writeBeadsJSON(bs, stdout)
+		return 0
+	}
+	writeReadyTable(bs, stdout)
+	return 0
+}
+
+// doBeadReadyWatch polls store.Ready every interval until ctx is done and
+// redraws the table whenever the ready set changes. When clear is set the
+// screen is cleared before each redraw; otherwise snapshots are appended
+// under a timestamp header so piped output stays readable. Poll errors are
+// reported and retried rather than ending the watch.
+func doBeadReadyWatch(ctx context.Context, store beads.Store, interval time.Duration, clear bool, stdout, stderr io.Writer) int {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := ""
+	drawn := false
+	for {
+		bs, err := store.Ready()
+		if err != nil {
+			fmt.Fprintf(stderr, "gc bead ready: %v\n", err) //nolint:errcheck // best-effort stderr
+		} else if fp := readyFingerprint(bs); !drawn || fp != last {
+			if clear {
+				fmt.Fprint(stdout, "\033[H\033[2J") //nolint:errcheck // best-effort stdout
+			} else if drawn {
+				fmt.Fprintln(stdout) //nolint:errcheck // best-effort stdout
+			}
+			last, drawn = fp, true
+			fmt.Fprintf(stdout, "Ready beads at %s (every %s)\n", time.Now().Format(time.TimeOnly), interval) //nolint:errcheck // best-effort stdout
+			writeReadyTable(bs, stdout)
+		}
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeReadyTable prints ready beads, or a placeholder when there are none.
+func writeReadyTable(bs []beads.Bead, stdout io.Writer) {
+	if len(bs) == 0 {
+		fmt.Fprintln(stdout, "No ready beads") //nolint:errcheck // best-effort stdout
+		return
+	}
+	writeBeadTable(bs, stdout, true)
+}
+
+// readyFingerprint summarizes the displayed fields of bs so the watch
+// loop can tell when a redraw is needed.
+func readyFingerprint(bs []beads.Bead) string {
+	var sb strings.Builder
+	for _, b := range bs {
+		fmt.Fprintf(&sb, "%s\x00%s\x00%d\x00%s\x00%s\n", b.ID, b.Status, b.PriorityOrDefault(), b.Assignee, b.Title)
+	}
+	return sb.String()
+}
+
+func newBeadShowCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonFlag, history bool
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a single bead",
+		Long: `Show the details of a single bead by ID.
+
+IDs no longer in the store are looked up in the archive written by
+gc bead archive.
+
+With --history, also list every status and assignee change with when
+it happened and who made it, oldest first.`,
+		Example: `  gc bead show gc-12
+  gc bead show gc-12 --history
+  gc bead show gc-12 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadShow(args[0], jsonFlag, history, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&history, "history", false, "List status and assignee changes")
+	return cmd
+}
+
+// cmdBeadShow is the CLI entry point for showing a bead.
+func cmdBeadShow(id string, jsonOutput, history bool, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead show: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead show: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doBeadShow(store, beadArchiveDir(cityPath), id, jsonOutput, history, stdout, stderr)
+}
+
+// doBeadShow prints one bead in detail format, or as JSON when
+// jsonOutput is set. history appends the bead's transitions to the
+// detail format; JSON always carries them. A bead missing from the store
+// is looked up in archiveDir ("" skips the archive).
+func doBeadShow(store beads.Store, archiveDir, id string, jsonOutput, history bool, stdout, stderr io.Writer) int {
+	b, err := store.Get(id)
+	archived := false
+	if errors.Is(err, beads.ErrNotFound) && archiveDir != "" {
+		if ab, aerr := beads.FindArchived(fsys.OSFS{}, archiveDir, id); aerr == nil {
+			b, err, archived = ab, nil, true
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead show: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if jsonOutput {
+		writeBeadJSON(b, stdout)
+		return 0
+	}
+	writeBeadDetail(b, stdout)
+	if history {
+		writeBeadHistory(b, stdout)
+	}
+	if archived {
+		fmt.Fprintf(stdout, "\nArchived in %s\n", filepath.Join(archiveDir, beads.ArchiveFileName(b))) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
+
+func newBeadCommentCmd(stdout, stderr io.Writer) *cobra.Command {
+	var author string
+	cmd := &cobra.Command{
+		Use:   "comment <id> <text>",
+		Short: "Add a comment to a bead",
+		Long: `Append a comment (worklog entry) to a bead.
+
+Comments are shown oldest first by "gc bead show". The author defaults
+to $GC_AGENT inside agent sessions and "human" otherwise.`,
+		Example: `  gc bead comment gc-12 "rebased onto main, tests green"
+  gc bead comment gc-12 "needs design review" --author mayor`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadComment(args[0], args[1], author, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&author, "author", "", "Comment author (default $GC_AGENT or \"human\")")
+	return cmd
+}
+
+// cmdBeadComment is the CLI entry point for commenting on a bead.
+func cmdBeadComment(id, text, author string, stdout, stderr io.Writer) int {
+	store, code := openCityStore(stderr, "gc bead comment")
+	if store == nil {
+		return code
+	}
+	if author == "" {
+		author = os.Getenv("GC_AGENT")
+	}
+	if author == "" {
+		author = "human"
+	}
+	return doBeadComment(store, id, text, author, stdout, stderr)
+}
+
+// doBeadComment appends a comment to bead id.
+func doBeadComment(store beads.Store, id, text, author string, stdout, stderr io.Writer) int {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		fmt.Fprintln(stderr, "gc bead comment: comment text is empty") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if _, err := store.AddComment(id, beads.Comment{Author: author, Text: text}); err != nil {
+		fmt.Fprintf(stderr, "gc bead comment: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "Commented on %s\n", id) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+func newBeadAttachCmd(stdout, stderr io.Writer) *cobra.Command {
+	var prs, commits, urls, files []string
+	var author string
+	cmd := &cobra.Command{
+		Use:   "attach <id>",
+		Short: "Link files, URLs, commits, or PRs to a bead",
+		Long: `Attach links to the artifacts produced for a bead, so reviewers can
+jump from the work item to its output.
+
+Each flag may be repeated. File paths are stored as absolute paths.
+Attaching a link the bead already has is a no-op. Attachments are shown
+by "gc bead show". The author defaults to $GC_AGENT inside agent
+sessions and "human" otherwise.`,
+		Example: `  gc bead attach gc-12 --pr https://github.com/org/repo/pull/42
+  gc bead attach gc-12 --commit 3f9c2ab --commit 81d07e4
+  gc bead attach gc-12 --file docs/design.md --url https://ci.example.com/runs/991`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			var as []beads.Attachment
+			for _, group := range []struct {
+				kind string
+				refs []string
+			}{{beads.AttachPR, prs}, {beads.AttachCommit, commits}, {beads.AttachURL, urls}, {beads.AttachFile, files}} {
+				for _, ref := range group.refs {
+					as = append(as, beads.Attachment{Kind: group.kind, Ref: ref})
+				}
+			}
+			if cmdBeadAttach(args[0], as, author, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVar(&prs, "pr", nil, "Pull request URL")
+	cmd.Flags().StringArrayVar(&commits, "commit", nil, "Git commit SHA")
+	cmd.Flags().StringArrayVar(&urls, "url", nil, "Any other URL (CI run, doc, dashboard)")
+	cmd.Flags().StringArrayVar(&files, "file", nil, "File path")
+	cmd.Flags().StringVar(&author, "author", "", "Attachment author (default $GC_AGENT or \"human\")")
+	return cmd
+}
+
+// cmdBeadAttach is the CLI entry point for attaching links to a bead.
+func cmdBeadAttach(id string, as []beads.Attachment, author string, stdout, stderr io.Writer) int {
+	store, code := openCityStore(stderr, "gc bead attach")
+	if store == nil {
+		return code
+	}
+	if author == "" {
+		author = os.Getenv("GC_AGENT")
+	}
+	if author == "" {
+		author = "human"
+	}
+	return doBeadAttach(store, id, as, author, stdout, stderr)
+}
+
+// doBeadAttach validates each attachment and links it to bead id. All
+// attachments are checked before any is stored.
+func doBeadAttach(store beads.Store, id string, as []beads.Attachment, author string, stdout, stderr io.Writer) int {
+	if len(as) == 0 {
+		fmt.Fprintln(stderr, "gc bead attach: nothing to attach (use --pr, --commit, --url, or --file)") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	for i := range as {
+		ref, err := normalizeAttachmentRef(as[i].Kind, as[i].Ref)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc bead attach: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		as[i].Ref = ref
+		as[i].Author = author
+	}
+	for _, a := range as {
+		if _, err := store.Attach(id, a); err != nil {
+			fmt.Fprintf(stderr, "gc bead attach: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintf(stdout, "Attached %s %s to %s\n", a.Kind, a.Ref, id) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
+
+// normalizeAttachmentRef checks ref for its kind: PRs and URLs must be
+// http(s) URLs, commits hex SHAs, and files are made absolute.
+func normalizeAttachmentRef(kind, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", fmt.Errorf("empty --%s", kind)
+	}
+	switch kind {
+	case beads.AttachPR, beads.AttachURL:
+		if u, err := url.Parse(ref); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("--%s %q is not an http(s) URL", kind, ref)
+		}
+	case beads.AttachCommit:
+		if len(ref) < 4 || len(ref) > 64 || strings.Trim(strings.ToLower(ref), "0123456789abcdef") != "" {
+			return "", fmt.Errorf("--commit %q is not a commit SHA", ref)
+		}
+	case beads.AttachFile:
+		return filepath.Abs(ref)
+	}
+	return ref, nil
+}
+
+func newBeadCreateCmd(stdout, stderr io.Writer) *cobra.Command {
+	var b beads.Bead
+	var priority, due, file, template string
+	var requires []string
+	var jsonFlag, force bool
+	cmd := &cobra.Command{
+		Use:   "create [title]",
+		Short: "Create a bead",
+		Long: `Create a bead in the city's bead store and print its ID.
+
+Priority runs from P0 (most urgent) to P4; beads without one are
+treated as P2. Ready queues list higher-priority beads first.
+
+--due sets a deadline: a duration from now ("4h", "3d"), a date
+("2026-03-01", meaning the end of that day), a local time
+("2026-03-01 15:00"), or RFC 3339. The controller warns once a bead is
+overdue; see "gc bead list --overdue".
+
+--requires names capabilities an agent needs to take the bead, stored
+as requires:<capability> labels. "gc sling --match" routes the bead to
+an agent whose capabilities cover them all.
+
+Before creating, open and in-progress beads of the same type are checked
+for near-identical titles. If any match, nothing is created and the
+matches are listed; pass --force to create the bead anyway. "gc bead
+dedupe" finds duplicates that already exist.
+
+The description is the body an agent reads before acting on the bead.
+Give it inline with --description, from a markdown file with --file
+("-" reads stdin), or from a template with --from-template.
+
+Templates live in templates/beads/<name>.md under the city root. An
+optional header between +++ lines sets defaults in TOML (title, type,
+priority, labels, requires); the rest of the file is the description. Flags
+override the template, and --label adds to its labels. The title
+argument may be omitted when the template sets one.`,
+		Example: `  gc bead create "Fix login redirect"
+  gc bead create "Prod is down" --priority P0 --label incident
+  gc bead create "Write release notes" --type chore --description "Cover 0.4 changes"
+  gc bead create "Migrate auth store" --file plan.md
+  gc bead create "Checkout 500s" --from-template bug-report
+  gc bead create "Rotate TLS certs" --due 2026-03-01
+  gc bead create "Fix flaky e2e test" --requires go,ci`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				b.Title = args[0]
+			}
+			for _, c := range requires {
+				if c = strings.TrimSpace(c); c != "" {
+					b.Labels = append(b.Labels, beads.RequiresLabelPrefix+c)
+				}
+			}
+			if cmdBeadCreate(b, priority, due, file, template, force, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&priority, "priority", "p", "", "priority P0 (most urgent) to P4 (default P2)")
+	cmd.Flags().StringVar(&due, "due", "", "due date: duration from now (4h, 3d), date, or time")
+	cmd.Flags().StringVarP(&b.Type, "type", "t", "", "bead type (default task)")
+	cmd.Flags().StringVarP(&b.Description, "description", "d", "", "bead description")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "read the description from a markdown file (- for stdin)")
+	cmd.Flags().StringVar(&template, "from-template", "", "start from templates/beads/<name>.md")
+	cmd.Flags().StringArrayVarP(&b.Labels, "label", "l", nil, "label to attach (repeatable)")
+	cmd.Flags().StringSliceVar(&requires, "requires", nil, "capability an agent needs to take the bead (repeatable or comma-separated)")
+	cmd.Flags().StringVar(&b.ParentID, "parent", "", "parent bead ID")
+	cmd.Flags().BoolVar(&force, "force", false, "create even if an open bead has a near-identical title")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	cmd.MarkFlagsMutuallyExclusive("description", "file")
+	return cmd
+}
+
+// cmdBeadCreate is the CLI entry point for creating a bead. It resolves
+// --file and --from-template into b before creating it.
+func cmdBeadCreate(b beads.Bead, priority, due, file, template string, force, jsonOutput bool, stdout, stderr io.Writer) int {
+	if file != "" {
+		body, err := readBeadBody(file, os.Stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc bead create: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		b.Description = body
+	}
+	if template != "" {
+		cityPath, err := resolveCity()
+		if err != nil {
+			fmt.Fprintf(stderr, "gc bead create: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		t, err := loadBeadTemplate(cityPath, template)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc bead create: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		applyBeadTemplate(&b, &priority, t)
+	}
+	store, code := openCityStore(stderr, "gc bead create")
+	if store == nil {
+		return code
+	}
+	return doBeadCreate(store, b, priority, due, time.Now(), force, jsonOutput, stdout, stderr)
+}
+
+// doBeadCreate validates and creates b. An empty priority leaves the
+// bead at the default; an empty due leaves it without a deadline. Unless
+// force is set, it refuses to create a bead whose title nearly matches
+// an unclosed bead of the same type.
+func doBeadCreate(store beads.Store, b beads.Bead, priority, due string, now time.Time, force, jsonOutput bool, stdout, stderr io.Writer) int {
+	if strings.TrimSpace(b.Title) == "" {
+		fmt.Fprintln(stderr, "gc bead create: title is empty") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if priority != "" {
+		p, err := beads.ParsePriority(priority)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc bead create: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		b.Priority = &p
+	}
+	if due != "" {
+		d, err := parseDueAt(due, now)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc bead create: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		b.DueAt = &d
+	}
+	if !force {
+		dups, err := findSimilarBeads(store, b, dupThreshold)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc bead create: warning: duplicate check failed: %v\n", err) //nolint:errcheck // best-effort stderr
+		}
+		if len(dups) > 0 {
+			fmt.Fprintf(stderr, "gc bead create: %q looks like a duplicate of:\n", b.Title) //nolint:errcheck // best-effort stderr
+			for _, d := range dups {
+				fmt.Fprintf(stderr, "  %s (%.0f%% similar, %s)\n", formatBeadLabel(d.Bead.ID, d.Bead.Title), d.Score*100, d.Bead.Status) //nolint:errcheck // best-effort stderr
+			}
+			fmt.Fprintln(stderr, "Use --force to create it anyway.") //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	created, err := store.Create(b)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead create: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if jsonOutput {
+		writeBeadJSON(created, stdout)
+		return 0
+	}
+	fmt.Fprintf(stdout, "Created %s (%s)\n", created.ID, beads.FormatPriority(created.PriorityOrDefault())) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// parseDueAt parses a --due value relative to now: a duration ("4h",
+// "3d"), a bare date (end of that day, local time), a local date and
+// time, or RFC 3339.
+func parseDueAt(s string, now time.Time) (time.Time, error) {
+	if d, err := parsePruneDuration(s); err == nil {
+		return now.Add(d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid due date %q (want a duration like 4h or 3d, a date like 2026-03-01, or a time like \"2026-03-01 15:00\")", s)
+}
+
+func newBeadAssignCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "assign <id> <agent>",
+		Short: "Assign a bead to an agent",
+		Long: `Set a bead's assignee to a configured agent, bypassing sling routing.
+
+The agent must exist in city.toml; names resolve the same way as for
+"gc agent" commands. The assignee is written as the agent's session
+name, which is what default work queries match. Pools can't be
+assignees — use "gc sling" to route work to a pool. No formula is
+cooked and the agent is not nudged.`,
+		Example: `  gc bead assign gc-12 mayor
+  gc bead assign BL-7 myrig/reviewer`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadAssign(args[0], args[1], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// cmdBeadAssign is the CLI entry point for assigning a bead. Like gc
+// unsling, it operates on the store of the rig that owns the bead.
+func cmdBeadAssign(id, agentName string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead assign: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead assign: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openRigStoreAt(cityPath, rigDirForBead(cfg, id))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead assign: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	return doBeadAssign(store, cfg, cityName, id, agentName, stdout, stderr)
+}
+
+// doBeadAssign resolves agentName against cfg and sets it (as a session
+// name) as the assignee of bead id.
+func doBeadAssign(store beads.Store, cfg *config.City, cityName, id, agentName string, stdout, stderr io.Writer) int {
+	a, ok := resolveAgentIdentity(cfg, agentName, currentRigContext(cfg))
+	if !ok {
+		fmt.Fprintf(stderr, "gc bead assign: agent %q not found in city.toml\n", agentName) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if a.IsPool() {
+		fmt.Fprintf(stderr, "gc bead assign: %s is a pool; route work to it with gc sling\n", a.QualifiedName()) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	sn := sessionName(store, cityName, a.QualifiedName(), cfg.Workspace.SessionTemplate)
+	b, err := updateBead(store, id, func(b beads.Bead) (*beads.UpdateOpts, error) {
+		switch {
+		case b.Status == "closed":
+			return nil, fmt.Errorf("bead %s is closed", id)
+		case b.Assignee == sn:
+			return nil, nil
+		}
+		return &beads.UpdateOpts{Assignee: &sn}, nil
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead assign: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if b.Assignee == sn {
+		fmt.Fprintf(stdout, "Bead %s is already assigned to %s\n", id, a.QualifiedName()) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	warnInProgressHandoff(b, stderr)
+	msg := fmt.Sprintf("Assigned %s to %s", id, a.QualifiedName())
+	if b.Assignee != "" {
+		msg += fmt.Sprintf(" (was %s)", b.Assignee)
+	}
+	fmt.Fprintln(stdout, msg) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+func newBeadUnassignCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unassign <id>",
+		Short: "Clear a bead's assignee",
+		Long: `Clear a bead's assignee, leaving its labels and status unchanged.
+
+Unlike "gc unsling", pool labels and attached wisps are left alone.`,
+		Example: `  gc bead unassign gc-12`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadUnassign(args[0], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// cmdBeadUnassign is the CLI entry point for clearing a bead's assignee.
+func cmdBeadUnassign(id string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead unassign: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead unassign: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openRigStoreAt(cityPath, rigDirForBead(cfg, id))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead unassign: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doBeadUnassign(store, id, stdout, stderr)
+}
+
+// doBeadUnassign clears the assignee of bead id.
+func doBeadUnassign(store beads.Store, id string, stdout, stderr io.Writer) int {
+	empty := ""
+	b, err := updateBead(store, id, func(b beads.Bead) (*beads.UpdateOpts, error) {
+		if b.Assignee == "" {
+			return nil, nil
+		}
+		return &beads.UpdateOpts{Assignee: &empty}, nil
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead unassign: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if b.Assignee == "" {
+		fmt.Fprintf(stdout, "Bead %s is not assigned\n", id) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	warnInProgressHandoff(b, stderr)
+	fmt.Fprintf(stdout, "Unassigned %s (was %s)\n", id, b.Assignee) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// updateBead applies the update plan derives from bead id's current
+// state, conditioned on the bead not changing in between. If another
+// writer gets there first, the bead is reread and planned once more. A
+// nil update from plan skips the write. Returns the bead as plan last
+// saw it.
+func updateBead(store beads.Store, id string, plan func(beads.Bead) (*beads.UpdateOpts, error)) (beads.Bead, error) {
+	for attempt := 0; ; attempt++ {
+		b, err := store.Get(id)
+		if err != nil {
+			return beads.Bead{}, err
+		}
+		opts, err := plan(b)
+		if err != nil || opts == nil {
+			return b, err
+		}
+		opts.ExpectVersion = &b.Version
+		err = store.Update(id, *opts)
+		if errors.Is(err, beads.ErrConflict) && attempt == 0 {
+			continue
+		}
+		return b, err
+	}
+}
+
+// warnInProgressHandoff warns when an in-progress bead is taken from its
+// assignee, who may still be working on it.
+func warnInProgressHandoff(b beads.Bead, stderr io.Writer) {
+	if b.Status == "in_progress" && b.Assignee != "" {
+		fmt.Fprintf(stderr, "warning: bead %s is in progress — %s may still be working on it\n", b.ID, b.Assignee) //nolint:errcheck // best-effort stderr
+	}
+}
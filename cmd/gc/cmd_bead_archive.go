@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+func newBeadArchiveCmd(stdout, stderr io.Writer) *cobra.Command {
+	var olderThan string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Move old closed beads out of the live store",
+		Long: `Move beads closed longer ago than --older-than out of the live bead
+store into monthly archive files (.gc/archive/beads-YYYYMM.json), keeping
+the store that every command loads small.
+
+A closed bead whose parent is still live stays put until its parent is
+archived too, so open molecules and convoys keep all their children.
+gc bead show falls back to the archive for IDs the store no longer has.
+
+Stores that cannot delete beads (exec providers) are refused.`,
+		Example: `  gc bead archive --older-than 30d
+  gc bead archive --older-than 7d --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdBeadArchive(olderThan, dryRun, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&olderThan, "older-than", "30d", "archive beads closed longer ago than this (e.g., 30d, 12h)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list the beads that would be archived without moving them")
+	return cmd
+}
+
+// beadArchiveDir is where gc bead archive writes and gc bead show reads
+// archived beads.
+func beadArchiveDir(cityPath string) string {
+	return filepath.Join(cityPath, ".gc", "archive")
+}
+
+// cmdBeadArchive is the CLI entry point for archiving closed beads.
+func cmdBeadArchive(olderThan string, dryRun bool, stdout, stderr io.Writer) int {
+	age, err := parsePruneDuration(olderThan)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead archive: --older-than: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead archive: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead archive: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doBeadArchive(store, fsys.OSFS{}, beadArchiveDir(cityPath), time.Now().Add(-age), dryRun, stdout, stderr)
+}
+
+// doBeadArchive moves the beads selected by archivableBeads into dir. The
+// archive is written before the beads are removed, so an interrupted run
+// leaves duplicates rather than losing beads.
+func doBeadArchive(store beads.Store, fs fsys.FS, dir string, cutoff time.Time, dryRun bool, stdout, stderr io.Writer) int {
+	remover, ok := store.(beads.Remover)
+	if !ok {
+		fmt.Fprintln(stderr, "gc bead archive: this bead store does not support removing beads") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	all, err := store.List()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead archive: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	old := archivableBeads(all, cutoff)
+	if len(old) == 0 {
+		fmt.Fprintln(stdout, "No beads to archive.") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if dryRun {
+		for _, b := range old {
+			fmt.Fprintf(stdout, "%s  %s  %s\n", b.ID, beads.ArchiveFileName(b), b.Title) //nolint:errcheck // best-effort stdout
+		}
+		fmt.Fprintf(stdout, "Would archive %d bead(s).\n", len(old)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if err := beads.WriteArchive(fs, dir, old); err != nil {
+		fmt.Fprintf(stderr, "gc bead archive: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	ids := make([]string, len(old))
+	for i, b := range old {
+		ids[i] = b.ID
+	}
+	n, err := remover.Remove(ids)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead archive: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "Archived %d bead(s) to %s.\n", n, dir) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// archivableBeads returns the closed beads whose ArchiveTime is before
+// cutoff, less any whose parent stays in the store.
+func archivableBeads(all []beads.Bead, cutoff time.Time) []beads.Bead {
+	live := make(map[string]bool, len(all))
+	candidate := make(map[string]bool)
+	for _, b := range all {
+		live[b.ID] = true
+		if b.Status == "closed" && beads.ArchiveTime(b).Before(cutoff) {
+			candidate[b.ID] = true
+		}
+	}
+	// Drop candidates under a parent that stays; repeat until stable so
+	// grandchildren follow their parents.
+	for changed := true; changed; {
+		changed = false
+		for _, b := range all {
+			if candidate[b.ID] && live[b.ParentID] && !candidate[b.ParentID] {
+				delete(candidate, b.ID)
+				changed = true
+			}
+		}
+	}
+	var out []beads.Bead
+	for _, b := range all {
+		if candidate[b.ID] {
+			out = append(out, b)
+		}
+	}
+	return out
+}
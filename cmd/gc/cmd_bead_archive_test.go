@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestDoBeadArchive(t *testing.T) {
+	store := beads.NewMemStore()
+	mk := func(title, parent string, closed bool) beads.Bead {
+		t.Helper()
+		b, err := store.Create(beads.Bead{Title: title, ParentID: parent})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if closed {
+			if err := store.Close(b.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return b
+	}
+	done := mk("done", "", true)
+	open := mk("still open", "", false)
+	step := mk("closed step of open molecule", open.ID, true)
+	mol := mk("closed molecule", "", true)
+	molStep := mk("its step", mol.ID, true)
+
+	dir := filepath.Join(t.TempDir(), "archive")
+	cutoff := time.Now().Add(time.Minute) // everything closed so far is "old"
+	var stdout, stderr bytes.Buffer
+	if code := doBeadArchive(store, fsys.OSFS{}, dir, cutoff, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadArchive = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Archived 3 bead(s)") {
+		t.Errorf("stdout = %q, want 3 archived", stdout.String())
+	}
+	for _, id := range []string{open.ID, step.ID} {
+		if _, err := store.Get(id); err != nil {
+			t.Errorf("Get(%s) after archive: %v, want kept", id, err)
+		}
+	}
+	for _, id := range []string{done.ID, mol.ID, molStep.ID} {
+		if _, err := store.Get(id); err == nil {
+			t.Errorf("Get(%s) after archive succeeded, want archived", id)
+		}
+	}
+
+	stdout.Reset()
+	if code := doBeadShow(store, dir, done.ID, false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadShow(archived) = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "Title:    done") || !strings.Contains(out, "Archived in ") || !strings.Contains(out, "Closed:") {
+		t.Errorf("doBeadShow(archived) = %q, want detail with Closed and archive note", out)
+	}
+}
+
+func TestDoBeadArchiveDryRunAndCutoff(t *testing.T) {
+	store := beads.NewMemStore()
+	b, _ := store.Create(beads.Bead{Title: "recent"})
+	if err := store.Close(b.ID); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "archive")
+	var stdout, stderr bytes.Buffer
+
+	if code := doBeadArchive(store, fsys.OSFS{}, dir, time.Now().Add(-time.Hour), false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadArchive = %d", code)
+	}
+	if !strings.Contains(stdout.String(), "No beads to archive.") {
+		t.Errorf("stdout = %q, want nothing archived before cutoff", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := doBeadArchive(store, fsys.OSFS{}, dir, time.Now().Add(time.Minute), true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadArchive --dry-run = %d", code)
+	}
+	if !strings.Contains(stdout.String(), "Would archive 1 bead(s).") {
+		t.Errorf("dry-run stdout = %q", stdout.String())
+	}
+	if _, err := store.Get(b.ID); err != nil {
+		t.Errorf("dry run removed bead: %v", err)
+	}
+}
+
+func TestDoBeadArchiveCityStore(t *testing.T) {
+	for _, provider := range []string{"file", "sqlite"} {
+		t.Run(provider, func(t *testing.T) {
+			city := t.TempDir()
+			t.Setenv("GC_BEADS", provider)
+			store, err := openCityStoreAt(city)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, _ := store.Create(beads.Bead{Title: "done"})
+			if err := store.Close(b.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			var stdout, stderr bytes.Buffer
+			if code := doBeadArchive(store, fsys.OSFS{}, beadArchiveDir(city), time.Now().Add(time.Minute), false, &stdout, &stderr); code != 0 {
+				t.Fatalf("doBeadArchive = %d; stderr: %s", code, stderr.String())
+			}
+			reopened, err := openCityStoreAt(city)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := reopened.Get(b.ID); !errors.Is(err, beads.ErrNotFound) {
+				t.Errorf("Get(%s) after archive err = %v, want ErrNotFound", b.ID, err)
+			}
+		})
+	}
+}
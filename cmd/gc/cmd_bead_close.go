@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/headless"
+	"github.com/spf13/cobra"
+)
+
+// closeGatesKey is the bead metadata key holding the last close-gate
+// outcome: "passed", "failed", or "skipped".
+const closeGatesKey = "close_gates"
+
+// gateOutputMax caps the failing gate output kept in the bead comment.
+const gateOutputMax = 4096
+
+func newBeadCloseCmd(stdout, stderr io.Writer) *cobra.Command {
+	var skipGates bool
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "close <id>",
+		Short: "Close a bead after its quality gates pass",
+		Long: `Close a bead, first running the close gates that guard it.
+
+On a rig with require_review = true, the bead must also have an
+approved review (see "gc bead review"); --skip-gates does not bypass
+that.
+
+Gates are shell commands configured with "gates" on the bead's rig and
+on the agent the bead is assigned to (rig gates first). They run in the
+rig directory, or the city directory for city beads, with GC_BEAD_ID
+set. Gates run in order and stop at the first failure; if any fails the
+bead stays open.
+
+The outcome is recorded on the bead: a comment listing each gate's
+result (with the failing gate's output) and the close_gates metadata
+key ("passed", "failed", or "skipped"). --skip-gates closes without
+running them and records that they were skipped.`,
+		Example: `  gc bead close gc-12
+  gc bead close be-7 --gate-timeout 30m
+  gc bead close be-7 --skip-gates`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadClose(args[0], skipGates, timeout, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&skipGates, "skip-gates", false, "close without running the close gates")
+	cmd.Flags().DurationVar(&timeout, "gate-timeout", 10*time.Minute, "fail a gate that runs longer than this")
+	return cmd
+}
+
+// cmdBeadClose is the CLI entry point for closing a bead.
+func cmdBeadClose(id string, skipGates bool, timeout time.Duration, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead close: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead close: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	resolveRigPaths(cityPath, cfg.Rigs)
+	store, err := openRigStoreAt(cityPath, rigDirForBead(cfg, id))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead close: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	b, err := store.Get(id)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead close: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doBeadClose(store, b, closePolicyFor(cfg, cityPath, b), skipGates, timeout, eventActor(), stdout, stderr)
+}
+
+// closePolicy is what must hold before a bead may be closed.
+type closePolicy struct {
+	Gates         []string // commands that must pass, in order
+	Dir           string   // where the gates run
+	RequireReview bool     // the bead needs an approved review
+}
+
+// closePolicyFor returns the close policy for b: its rig's gates, then
+// its assignee's, without repeats, run in the rig directory, plus the
+// rig's review requirement.
+func closePolicyFor(cfg *config.City, cityPath string, b beads.Bead) closePolicy {
+	pol := closePolicy{Dir: cityPath}
+	var all []string
+	if rig, ok := findRigByPrefix(cfg, beadPrefix(b.ID)); ok {
+		pol.Dir = rig.Path
+		pol.RequireReview = rig.RequireReview
+		all = append(all, rig.Gates...)
+	}
+	if b.Assignee != "" {
+		if a, ok := resolveAgentIdentity(cfg, b.Assignee, ""); ok {
+			all = append(all, a.Gates...)
+		}
+	}
+	for _, g := range all {
+		if g = strings.TrimSpace(g); g != "" && !slices.Contains(pol.Gates, g) {
+			pol.Gates = append(pol.Gates, g)
+		}
+	}
+	return pol
+}
+
+// doBeadClose checks b's review if pol requires one, runs pol's gates,
+// and closes b if they all pass (or skip is set), recording the gate
+// outcome on b.
+func doBeadClose(store beads.Store, b beads.Bead, pol closePolicy, skip bool, timeout time.Duration, actor string, stdout, stderr io.Writer) int {
+	if b.Status == "closed" {
+		fmt.Fprintf(stderr, "gc bead close: %s is already closed\n", b.ID) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if pol.RequireReview && b.ReviewState() != beads.ReviewApproved {
+		state := b.ReviewState()
+		if state == "" {
+			state = "not requested"
+		}
+		fmt.Fprintf(stderr, "gc bead close: %s needs an approved review first (review: %s)\n", b.ID, state) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if gates := pol.Gates; len(gates) > 0 {
+		var report []string
+		outcome := "passed"
+		var failed *headless.Result
+		if skip {
+			outcome = "skipped"
+			for _, g := range gates {
+				report = append(report, "  - "+g)
+			}
+		} else {
+			env := append(os.Environ(), "GC_BEAD_ID="+b.ID)
+			for _, g := range gates {
+				res := headless.Run(context.Background(), headless.Invocation{
+					Command:    g,
+					PromptMode: "none",
+					Dir:        pol.Dir,
+					Env:        env,
+					Timeout:    timeout,
+				}, headless.RetryPolicy{})
+				mark := "✓"
+				if !res.OK() {
+					mark = "✗"
+				}
+				line := fmt.Sprintf("  %s %s (%s, %s)", mark, g, gateStatus(res), res.Duration.Round(100*time.Millisecond))
+				report = append(report, line)
+				fmt.Fprintln(stdout, line) //nolint:errcheck // best-effort stdout
+				if !res.OK() {
+					outcome, failed = "failed", &res
+					break
+				}
+			}
+		}
+		text := "Close gates " + outcome + ":\n" + strings.Join(report, "\n")
+		if failed != nil {
+			if out := gateOutput(*failed); out != "" {
+				text += "\n\n" + out
+			}
+		}
+		if _, err := store.AddComment(b.ID, beads.Comment{Author: actor, Text: text}); err != nil {
+			fmt.Fprintf(stderr, "gc bead close: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if err := store.SetMetadata(b.ID, closeGatesKey, outcome); err != nil {
+			fmt.Fprintf(stderr, "gc bead close: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if failed != nil {
+			io.WriteString(stderr, failed.Output+failed.Stderr)                                                          //nolint:errcheck // best-effort stderr
+			fmt.Fprintf(stderr, "gc bead close: %s left open: close gate failed (use --skip-gates to override)\n", b.ID) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	if err := store.Close(b.ID); err != nil {
+		fmt.Fprintf(stderr, "gc bead close: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "Closed %s\n", formatBeadLabel(b.ID, b.Title)) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// gateStatus describes how a gate run ended, e.g. "exit 1" or "timed out".
+func gateStatus(res headless.Result) string {
+	switch {
+	case res.TimedOut:
+		return "timed out"
+	case res.Err != nil:
+		return res.Err.Error()
+	default:
+		return fmt.Sprintf("exit %d", res.ExitCode)
+	}
+}
+
+// gateOutput returns the tail of a failed gate's combined output, where
+// test and lint failures are usually summarized.
+func gateOutput(res headless.Result) string {
+	out := strings.TrimSpace(res.Output + res.Stderr)
+	if len(out) <= gateOutputMax {
+		return out
+	}
+	cut := len(out) - gateOutputMax
+	for cut < len(out) && !utf8.RuneStart(out[cut]) {
+		cut++
+	}
+	return "… (truncated)\n" + out[cut:]
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func TestClosePolicyFor(t *testing.T) {
+	cfg := &config.City{
+		Rigs: []config.Rig{{Name: "api", Path: "/src/api", Prefix: "ap", Gates: []string{"go test ./...", "go vet ./..."}, RequireReview: true}},
+		Agents: []config.Agent{
+			{Name: "polecat", Dir: "api", Gates: []string{"golangci-lint run", "go vet ./..."}},
+		},
+	}
+	pol := closePolicyFor(cfg, "/city", beads.Bead{ID: "ap-3", Assignee: "api/polecat"})
+	if want := []string{"go test ./...", "go vet ./...", "golangci-lint run"}; strings.Join(pol.Gates, "|") != strings.Join(want, "|") {
+		t.Errorf("gates = %q, want %q", pol.Gates, want)
+	}
+	if pol.Dir != "/src/api" || !pol.RequireReview {
+		t.Errorf("policy = %+v, want dir /src/api and review required", pol)
+	}
+	pol = closePolicyFor(cfg, "/city", beads.Bead{ID: "gc-1"})
+	if len(pol.Gates) != 0 || pol.Dir != "/city" || pol.RequireReview {
+		t.Errorf("city bead: policy = %+v", pol)
+	}
+}
+
+func TestDoBeadCloseGates(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "Add retries"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pol := closePolicy{
+		Gates: []string{`test "$GC_BEAD_ID" = ` + b.ID, "echo 'FAIL: TestRetry' && exit 3", "touch never-run"},
+		Dir:   t.TempDir(),
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doBeadClose(store, b, pol, false, time.Minute, "human", &stdout, &stderr); code != 1 {
+		t.Fatalf("doBeadClose = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "FAIL: TestRetry") || !strings.Contains(stderr.String(), "--skip-gates") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	got, _ := store.Get(b.ID)
+	if got.Status != "open" || got.Metadata[closeGatesKey] != "failed" {
+		t.Fatalf("after failed gate: status %q, close_gates %q", got.Status, got.Metadata[closeGatesKey])
+	}
+	c := got.Comments[0].Text
+	for _, want := range []string{"Close gates failed:", "✓ test", "✗ echo", "exit 3", "FAIL: TestRetry"} {
+		if !strings.Contains(c, want) {
+			t.Errorf("comment missing %q:\n%s", want, c)
+		}
+	}
+	if strings.Contains(c, "never-run") {
+		t.Errorf("gate after the failure ran:\n%s", c)
+	}
+
+	stdout.Reset()
+	if code := doBeadClose(store, got, pol, true, time.Minute, "human", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadClose(skip) = %d; stderr: %s", code, stderr.String())
+	}
+	got, _ = store.Get(b.ID)
+	if got.Status != "closed" || got.Metadata[closeGatesKey] != "skipped" {
+		t.Errorf("after skip: status %q, close_gates %q", got.Status, got.Metadata[closeGatesKey])
+	}
+	if code := doBeadClose(store, got, closePolicy{}, false, time.Minute, "human", &stdout, &stderr); code != 1 {
+		t.Errorf("closing a closed bead = %d, want 1", code)
+	}
+}
+
+func TestDoBeadClosePasses(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "Docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doBeadClose(store, b, closePolicy{Gates: []string{"true"}, Dir: t.TempDir()}, false, time.Minute, "human", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadClose = %d; stderr: %s", code, stderr.String())
+	}
+	got, _ := store.Get(b.ID)
+	if got.Status != "closed" || got.Metadata[closeGatesKey] != "passed" {
+		t.Errorf("status %q, close_gates %q", got.Status, got.Metadata[closeGatesKey])
+	}
+	if !strings.Contains(stdout.String(), "✓ true (exit 0") || !strings.Contains(stdout.String(), "Closed gc-1") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
+
+func TestDoBeadCloseRequiresReview(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "Refactor auth"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pol := closePolicy{RequireReview: true}
+	var stdout, stderr bytes.Buffer
+	if code := doBeadClose(store, b, pol, true, time.Minute, "human", &stdout, &stderr); code != 1 {
+		t.Fatalf("doBeadClose without review = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "needs an approved review first (review: not requested)") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	if err := store.SetMetadata(b.ID, beads.ReviewStateKey, beads.ReviewApproved); err != nil {
+		t.Fatal(err)
+	}
+	b, _ = store.Get(b.ID)
+	if code := doBeadClose(store, b, pol, false, time.Minute, "human", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadClose after approval = %d; stderr: %s", code, stderr.String())
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/spf13/cobra"
+)
+
+func newBeadMergeCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge <duplicate> <canonical>",
+		Short: "Fold a duplicate bead into the bead it repeats",
+		Long: `Move everything attached to a duplicate bead onto its canonical bead,
+then close the duplicate.
+
+The duplicate's children are re-parented under the canonical bead, and
+its comments, attachments, and labels are copied over. Dependency edges
+are rewired: whatever the duplicate depended on, the canonical bead now
+depends on, and beads that depended on the duplicate now depend on the
+canonical bead. Finally the duplicate is labeled duplicate-of:<canonical>,
+linked to it with a "duplicates" dependency, and closed.
+
+Both beads must live in the same store. The canonical bead must be
+open and must not be a descendant of the duplicate.`,
+		Example: `  gc bead merge gc-31 gc-12`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadMerge(args[0], args[1], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// cmdBeadMerge is the CLI entry point for merging two beads.
+func cmdBeadMerge(dupID, canonID string, stdout, stderr io.Writer) int {
+	store := openBeadStore(dupID, stderr, "gc bead merge")
+	if store == nil {
+		return 1
+	}
+	return doBeadMerge(store, dupID, canonID, eventActor(), stdout, stderr)
+}
+
+// doBeadMerge merges bead dupID into canonID and reports what moved.
+func doBeadMerge(store beads.Store, dupID, canonID, actor string, stdout, stderr io.Writer) int {
+	moved, err := mergeBeads(store, dupID, canonID, actor)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead merge: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "Merged %s into %s (%s)\n", dupID, canonID, moved) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// mergeCounts tallies what mergeBeads moved onto the canonical bead.
+type mergeCounts struct {
+	Children, Comments, Attachments, Labels, Deps int
+}
+
+func (m mergeCounts) String() string {
+	return fmt.Sprintf("%d children, %d comments, %d attachments, %d labels, %d dependencies moved",
+		m.Children, m.Comments, m.Attachments, m.Labels, m.Deps)
+}
+
+// mergeBeads folds bead dupID into canonID: children, comments,
+// attachments, labels, and dependency edges move to canonID, and dupID
+// is closed with a duplicate-of marker. It checks both beads before
+// changing anything, but a store error partway through leaves the steps
+// already done in place.
+func mergeBeads(store beads.Store, dupID, canonID, actor string) (mergeCounts, error) {
+	var n mergeCounts
+	if dupID == canonID {
+		return n, fmt.Errorf("cannot merge %s into itself", dupID)
+	}
+	dup, err := store.Get(dupID)
+	if err != nil {
+		return n, err
+	}
+	canon, err := store.Get(canonID)
+	if err != nil {
+		return n, fmt.Errorf("%w (both beads must be in the same store)", err)
+	}
+	if prev := dup.DuplicateOf(); prev != "" && dup.Status == "closed" {
+		return n, fmt.Errorf("%s was already merged into %s", dupID, prev)
+	}
+	if canon.Status == "closed" {
+		return n, fmt.Errorf("%s is closed; reopen it or merge into another bead", canonID)
+	}
+	seen := map[string]bool{}
+	for cur := canon.ParentID; cur != "" && !seen[cur]; {
+		if cur == dupID {
+			return n, fmt.Errorf("%s is a descendant of %s", canonID, dupID)
+		}
+		seen[cur] = true
+		p, err := store.Get(cur)
+		if err != nil {
+			return n, err
+		}
+		cur = p.ParentID
+	}
+
+	kids, err := store.Children(dupID)
+	if err != nil {
+		return n, err
+	}
+	for _, k := range kids {
+		if err := store.Update(k.ID, beads.UpdateOpts{ParentID: &canonID}); err != nil {
+			return n, err
+		}
+		n.Children++
+	}
+
+	for _, c := range dup.Comments {
+		c.Text = "[from " + dupID + "] " + c.Text
+		if _, err := store.AddComment(canonID, beads.Comment{Author: c.Author, Text: c.Text}); err != nil {
+			return n, err
+		}
+		n.Comments++
+	}
+	for _, a := range dup.Attachments {
+		if _, err := store.Attach(canonID, beads.Attachment{Kind: a.Kind, Ref: a.Ref, Author: a.Author}); err != nil {
+			return n, err
+		}
+		n.Attachments++
+	}
+	var labels []string
+	for _, l := range dup.Labels {
+		if l == "" || strings.HasPrefix(l, beads.DuplicateOfLabelPrefix) {
+			continue
+		}
+		if slices.Contains(canon.Labels, l) || slices.Contains(labels, l) {
+			continue
+		}
+		labels = append(labels, l)
+	}
+	if len(labels) > 0 {
+		if err := store.Update(canonID, beads.UpdateOpts{Labels: labels}); err != nil {
+			return n, err
+		}
+		n.Labels = len(labels)
+	}
+
+	deps, err := mergeDeps(store, dupID, canonID)
+	n.Deps = deps
+	if err != nil {
+		return n, err
+	}
+
+	if _, err := store.AddComment(dupID, beads.Comment{Author: actor, Text: "Merged into " + canonID}); err != nil {
+		return n, err
+	}
+	if err := store.Update(dupID, beads.UpdateOpts{Labels: []string{beads.DuplicateOfLabelPrefix + canonID}}); err != nil {
+		return n, err
+	}
+	if err := store.DepAdd(dupID, canonID, dupDepType); err != nil {
+		return n, err
+	}
+	return n, store.Close(dupID)
+}
+
+// mergeDeps moves dupID's dependency edges to canonID and returns how
+// many moved. Edges between the two beads are dropped, and edges
+// canonID already has keep their type.
+func mergeDeps(store beads.Store, dupID, canonID string) (int, error) {
+	moved := 0
+	has := func(issue, dependsOn string) (bool, error) {
+		ds, err := store.DepList(issue, "down")
+		if err != nil {
+			return false, err
+		}
+		return slices.ContainsFunc(ds, func(d beads.Dep) bool { return d.DependsOnID == dependsOn }), nil
+	}
+	down, err := store.DepList(dupID, "down")
+	if err != nil {
+		return moved, err
+	}
+	for _, d := range down {
+		if d.DependsOnID != canonID {
+			exists, err := has(canonID, d.DependsOnID)
+			if err != nil {
+				return moved, err
+			}
+			if !exists {
+				if err := store.DepAdd(canonID, d.DependsOnID, d.Type); err != nil {
+					return moved, err
+				}
+			}
+			moved++
+		}
+		if err := store.DepRemove(dupID, d.DependsOnID); err != nil {
+			return moved, err
+		}
+	}
+	up, err := store.DepList(dupID, "up")
+	if err != nil {
+		return moved, err
+	}
+	for _, d := range up {
+		if d.IssueID != canonID {
+			exists, err := has(d.IssueID, canonID)
+			if err != nil {
+				return moved, err
+			}
+			if !exists {
+				if err := store.DepAdd(d.IssueID, canonID, d.Type); err != nil {
+					return moved, err
+				}
+			}
+			moved++
+		}
+		if err := store.DepRemove(d.IssueID, dupID); err != nil {
+			return moved, err
+		}
+	}
+	return moved, nil
+}
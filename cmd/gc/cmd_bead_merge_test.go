@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestDoBeadMerge(t *testing.T) {
+	store := beads.NewMemStore()
+	mk := func(b beads.Bead) string {
+		t.Helper()
+		created, err := store.Create(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return created.ID
+	}
+	canon := mk(beads.Bead{Title: "Fix login redirect", Labels: []string{"auth"}}) // gc-1
+	dup := mk(beads.Bead{Title: "fix the login redirect", Labels: []string{"auth", "p-web"}})
+	kid := mk(beads.Bead{Title: "write test", ParentID: dup})
+	blocker := mk(beads.Bead{Title: "upgrade router"})
+	waiter := mk(beads.Bead{Title: "ship release"})
+	for _, d := range [][3]string{{dup, blocker, "blocks"}, {waiter, dup, "blocks"}, {canon, dup, "relates-to"}} {
+		if err := store.DepAdd(d[0], d[1], d[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.AddComment(dup, beads.Comment{Author: "mayor", Text: "repro: log out then in"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Attach(dup, beads.Attachment{Kind: beads.AttachURL, Ref: "https://example.com/issue/9"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doBeadMerge(store, dup, canon, "human", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadMerge = %d; stderr: %s", code, stderr.String())
+	}
+	want := "Merged gc-2 into gc-1 (1 children, 1 comments, 1 attachments, 1 labels, 2 dependencies moved)"
+	if !strings.Contains(stdout.String(), want) {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+
+	if k, _ := store.Get(kid); k.ParentID != canon {
+		t.Errorf("child parent = %q, want %s", k.ParentID, canon)
+	}
+	c, _ := store.Get(canon)
+	if len(c.Comments) != 1 || c.Comments[0].Text != "[from gc-2] repro: log out then in" || c.Comments[0].Author != "mayor" {
+		t.Errorf("canonical comments = %+v", c.Comments)
+	}
+	if len(c.Attachments) != 1 || c.Attachments[0].Ref != "https://example.com/issue/9" {
+		t.Errorf("canonical attachments = %+v", c.Attachments)
+	}
+	if !slices.Equal(c.Labels, []string{"auth", "p-web"}) {
+		t.Errorf("canonical labels = %v", c.Labels)
+	}
+	if deps, _ := store.DepList(canon, "down"); len(deps) != 1 || deps[0].DependsOnID != blocker {
+		t.Errorf("canonical deps = %+v, want only %s", deps, blocker)
+	}
+	if deps, _ := store.DepList(waiter, "down"); len(deps) != 1 || deps[0].DependsOnID != canon || deps[0].Type != "blocks" {
+		t.Errorf("waiter deps = %+v, want blocks on %s", deps, canon)
+	}
+
+	d, _ := store.Get(dup)
+	if d.Status != "closed" || d.DuplicateOf() != canon {
+		t.Errorf("duplicate = %s %v, want closed duplicate-of:%s", d.Status, d.Labels, canon)
+	}
+	if deps, _ := store.DepList(dup, "down"); len(deps) != 1 || deps[0].DependsOnID != canon || deps[0].Type != dupDepType {
+		t.Errorf("duplicate deps = %+v", deps)
+	}
+
+	stderr.Reset()
+	if code := doBeadMerge(store, dup, canon, "human", &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "already merged into gc-1") {
+		t.Errorf("second merge = %d, stderr %q", code, stderr.String())
+	}
+}
+
+func TestDoBeadMergeRejects(t *testing.T) {
+	store := beads.NewMemStore()
+	parent, _ := store.Create(beads.Bead{Title: "epic"})
+	child, _ := store.Create(beads.Bead{Title: "step", ParentID: parent.ID})
+	done, _ := store.Create(beads.Bead{Title: "done"})
+	if err := store.Close(done.ID); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct{ dup, canon, want string }{
+		{parent.ID, parent.ID, "into itself"},
+		{parent.ID, child.ID, "is a descendant of"},
+		{child.ID, done.ID, "is closed"},
+		{child.ID, "gc-99", "same store"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := doBeadMerge(store, tc.dup, tc.canon, "human", &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), tc.want) {
+			t.Errorf("merge %s into %s = %d, stderr %q, want %q", tc.dup, tc.canon, code, stderr.String(), tc.want)
+		}
+	}
+	if b, _ := store.Get(child.ID); b.Status != "open" {
+		t.Errorf("rejected merge closed %s", child.ID)
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// defaultReviewFormula is slung to reviewers when the bead's rig sets no
+// review_formula.
+const defaultReviewFormula = "mol-review"
+
+func newBeadReviewCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "Request, approve, or send back reviews of beads",
+		Long: `Track a review of a bead's work.
+
+A review moves a bead through the states in_review, changes_requested,
+and approved, stored in its review_state metadata and shown by
+"gc bead show". "request" slings a review formula to the reviewer;
+the reviewer (or a human) then approves the work or requests changes,
+and the author requests another review after addressing them.
+
+On a rig with require_review = true, "gc bead close" refuses the rig's
+beads until their review is approved.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc bead review: missing subcommand (approve, changes, request)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc bead review: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(
+		newBeadReviewApproveCmd(stdout, stderr),
+		newBeadReviewChangesCmd(stdout, stderr),
+		newBeadReviewRequestCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newBeadReviewRequestCmd(stdout, stderr io.Writer) *cobra.Command {
+	var reviewer, formula string
+	cmd := &cobra.Command{
+		Use:   "request <id>",
+		Short: "Ask an agent to review a bead",
+		Long: `Put a bead in review and sling a review formula to the reviewer.
+
+The formula is --formula, else the rig's review_formula, else
+"mol-review"; the bead ID is passed to it as var "issue". A bead whose
+changes were requested, or whose approval is stale after more work, can
+be put back in review the same way.`,
+		Example: `  gc bead review request BL-7 --reviewer refinery
+  gc bead review request BL-7 --reviewer myrig/reviewer --formula mol-security-review`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadReviewRequest(args[0], reviewer, formula, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&reviewer, "reviewer", "", "agent to review the bead (required)")
+	cmd.Flags().StringVar(&formula, "formula", "", "review formula to sling (default: the rig's review_formula)")
+	_ = cmd.MarkFlagRequired("reviewer")
+	return cmd
+}
+
+func newBeadReviewApproveCmd(stdout, stderr io.Writer) *cobra.Command {
+	var message string
+	cmd := &cobra.Command{
+		Use:   "approve <id>",
+		Short: "Approve a bead in review",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadReviewVerdict(args[0], beads.ReviewApproved, message, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&message, "message", "m", "", "optional note recorded with the approval")
+	return cmd
+}
+
+func newBeadReviewChangesCmd(stdout, stderr io.Writer) *cobra.Command {
+	var message string
+	cmd := &cobra.Command{
+		Use:     "changes <id>",
+		Short:   "Send a bead in review back for changes",
+		Example: `  gc bead review changes BL-7 -m "retry loop never backs off"`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadReviewVerdict(args[0], beads.ReviewChangesRequested, message, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&message, "message", "m", "", "what needs to change (required)")
+	_ = cmd.MarkFlagRequired("message")
+	return cmd
+}
+
+// cmdBeadReviewRequest is the CLI entry point for requesting a review.
+func cmdBeadReviewRequest(id, reviewer, formula string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead review request: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead review request: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	resolveRigPaths(cityPath, cfg.Rigs)
+	a, ok := resolveAgentIdentity(cfg, reviewer, currentRigContext(cfg))
+	if !ok {
+		fmt.Fprintln(stderr, agentNotFoundMsg("gc bead review request", reviewer, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openRigStoreAt(cityPath, rigDirForBead(cfg, id))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead review request: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	slingStore, err := slingStoreFor(cityPath, cfg, a)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead review request: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if formula == "" {
+		formula = reviewFormulaFor(cfg, id)
+	}
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	deps := slingDeps{
+		CityName: cityName,
+		CityPath: cityPath,
+		Cfg:      cfg,
+		SP:       newSessionProvider(),
+		Runner:   shellSlingRunner,
+		Store:    slingStore,
+		Stdout:   stdout,
+		Stderr:   stderr,
+	}
+	return doBeadReviewRequest(store, id, a, formula, deps, eventActor())
+}
+
+// reviewFormulaFor returns the review formula for bead id: its rig's
+// review_formula, or defaultReviewFormula.
+func reviewFormulaFor(cfg *config.City, id string) string {
+	if rig, ok := findRigByPrefix(cfg, beadPrefix(id)); ok && rig.ReviewFormula != "" {
+		return rig.ReviewFormula
+	}
+	return defaultReviewFormula
+}
+
+// doBeadReviewRequest slings formula to reviewer for bead id and, once
+// that succeeds, puts the bead in review.
+func doBeadReviewRequest(store beads.Store, id string, reviewer config.Agent, formula string, deps slingDeps, actor string) int {
+	b, err := store.Get(id)
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "gc bead review request: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if b.Status == "closed" {
+		fmt.Fprintf(deps.Stderr, "gc bead review request: %s is closed\n", id) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	name := reviewer.QualifiedName()
+	if b.ReviewState() == beads.ReviewInReview {
+		fmt.Fprintf(deps.Stderr, "gc bead review request: %s is already in review by %s\n", id, b.Metadata[beads.ReviewerKey]) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	opts := slingOpts{
+		Target:        reviewer,
+		BeadOrFormula: formula,
+		IsFormula:     true,
+		Title:         "Review " + id + ": " + b.Title,
+		Vars:          []string{"issue=" + id},
+	}
+	if doSling(opts, deps, nil) != 0 {
+		return 1
+	}
+	err = store.SetMetadataBatch(id, map[string]string{
+		beads.ReviewStateKey: beads.ReviewInReview,
+		beads.ReviewerKey:    name,
+	})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "gc bead review request: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if _, err := store.AddComment(id, beads.Comment{Author: actor, Text: "Review requested from " + name}); err != nil {
+		fmt.Fprintf(deps.Stderr, "gc bead review request: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(deps.Stdout, "%s in review by %s\n", formatBeadLabel(id, b.Title), name) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// cmdBeadReviewVerdict is the CLI entry point for approving a review or
+// requesting changes.
+func cmdBeadReviewVerdict(id, verdict, message string, stdout, stderr io.Writer) int {
+	cmdName, _ := reviewVerdictNames(verdict)
+	store := openBeadStore(id, stderr, cmdName)
+	if store == nil {
+		return 1
+	}
+	return doBeadReviewVerdict(store, id, verdict, message, eventActor(), stdout, stderr)
+}
+
+// reviewVerdictNames returns the command name and comment heading for
+// a review verdict.
+func reviewVerdictNames(verdict string) (cmdName, heading string) {
+	if verdict == beads.ReviewChangesRequested {
+		return "gc bead review changes", "Changes requested"
+	}
+	return "gc bead review approve", "Approved"
+}
+
+// doBeadReviewVerdict moves bead id from in_review to verdict
+// (approved or changes_requested), recording message as a comment.
+func doBeadReviewVerdict(store beads.Store, id, verdict, message, actor string, stdout, stderr io.Writer) int {
+	cmdName, heading := reviewVerdictNames(verdict)
+	message = strings.TrimSpace(message)
+	if verdict == beads.ReviewChangesRequested && message == "" {
+		fmt.Fprintf(stderr, "%s: say what needs to change\n", cmdName) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	b, err := store.Get(id)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if state := b.ReviewState(); state != beads.ReviewInReview {
+		if state == "" {
+			state = "none"
+		}
+		fmt.Fprintf(stderr, "%s: %s is not in review (review: %s); use gc bead review request\n", cmdName, id, state) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if err := store.SetMetadata(id, beads.ReviewStateKey, verdict); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	text := heading
+	if message != "" {
+		text += ": " + message
+	}
+	if _, err := store.AddComment(id, beads.Comment{Author: actor, Text: text}); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s: %s\n", heading, formatBeadLabel(id, b.Title)) //nolint:errcheck // best-effort stdout
+	return 0
+}
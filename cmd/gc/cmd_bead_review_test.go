@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestBeadReviewWorkflow(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "Add retries"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	reviewer := config.Agent{Name: "refinery"}
+	runner := newFakeRunner()
+	deps, stdout, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+
+	if code := doBeadReviewRequest(store, b.ID, reviewer, "mol-review", deps, "human"); code != 0 {
+		t.Fatalf("request = %d; stderr: %s", code, stderr.String())
+	}
+	got, _ := store.Get(b.ID)
+	if got.ReviewState() != beads.ReviewInReview || got.Metadata[beads.ReviewerKey] != "refinery" {
+		t.Errorf("after request: metadata = %v", got.Metadata)
+	}
+	wisp, err := deps.Store.Get("gc-1")
+	if err != nil || wisp.Ref != "mol-review" || wisp.Title != "Review gc-1: Add retries" {
+		t.Errorf("review wisp = %+v, %v", wisp, err)
+	}
+	if !strings.Contains(stdout.String(), "in review by refinery") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	if code := doBeadReviewRequest(store, b.ID, reviewer, "mol-review", deps, "human"); code != 1 {
+		t.Errorf("second request while in review = %d, want 1", code)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := doBeadReviewVerdict(store, b.ID, beads.ReviewChangesRequested, " ", "refinery", &out, &errOut); code != 1 {
+		t.Errorf("changes without a message = %d, want 1", code)
+	}
+	if code := doBeadReviewVerdict(store, b.ID, beads.ReviewChangesRequested, "no backoff", "refinery", &out, &errOut); code != 0 {
+		t.Fatalf("changes = %d; stderr: %s", code, errOut.String())
+	}
+	if code := doBeadReviewVerdict(store, b.ID, beads.ReviewApproved, "", "refinery", &out, &errOut); code != 1 {
+		t.Errorf("approve after changes requested = %d, want 1", code)
+	}
+	if !strings.Contains(errOut.String(), "not in review (review: changes_requested)") {
+		t.Errorf("stderr = %q", errOut.String())
+	}
+
+	if code := doBeadReviewRequest(store, b.ID, reviewer, "mol-review", deps, "human"); code != 0 {
+		t.Fatalf("re-request = %d; stderr: %s", code, stderr.String())
+	}
+	if code := doBeadReviewVerdict(store, b.ID, beads.ReviewApproved, "LGTM", "refinery", &out, &errOut); code != 0 {
+		t.Fatalf("approve = %d; stderr: %s", code, errOut.String())
+	}
+	got, _ = store.Get(b.ID)
+	if got.ReviewState() != beads.ReviewApproved {
+		t.Errorf("review state = %q, want approved", got.ReviewState())
+	}
+	var texts []string
+	for _, c := range got.Comments {
+		texts = append(texts, c.Author+": "+c.Text)
+	}
+	want := []string{
+		"human: Review requested from refinery",
+		"refinery: Changes requested: no backoff",
+		"human: Review requested from refinery",
+		"refinery: Approved: LGTM",
+	}
+	if strings.Join(texts, "|") != strings.Join(want, "|") {
+		t.Errorf("comments = %q, want %q", texts, want)
+	}
+}
+
+func TestReviewFormulaFor(t *testing.T) {
+	cfg := &config.City{Rigs: []config.Rig{{Name: "api", Prefix: "ap", ReviewFormula: "mol-api-review"}}}
+	if got := reviewFormulaFor(cfg, "ap-4"); got != "mol-api-review" {
+		t.Errorf("rig bead formula = %q", got)
+	}
+	if got := reviewFormulaFor(cfg, "gc-4"); got != defaultReviewFormula {
+		t.Errorf("city bead formula = %q", got)
+	}
+}
@@ -0,0 +1,527 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func seedBeadListStore(t *testing.T) *beads.MemStore {
+	t.Helper()
+	store := beads.NewMemStore()
+	worker := "worker"
+	a, err := store.Create(beads.Bead{Title: "alpha", Labels: []string{"urgent"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Update(a.ID, beads.UpdateOpts{Assignee: &worker}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Create(beads.Bead{Title: "bravo", Type: "bug"}); err != nil {
+		t.Fatal(err)
+	}
+	c, err := store.Create(beads.Bead{Title: "charlie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(c.ID); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestDoBeadListFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter beads.Filter
+		want   []string
+		reject []string
+	}{
+		{"all", beads.Filter{}, []string{"alpha", "bravo", "charlie"}, nil},
+		{"status", beads.Filter{Status: "open"}, []string{"alpha", "bravo"}, []string{"charlie"}},
+		{"assignee", beads.Filter{Assignee: "worker"}, []string{"alpha"}, []string{"bravo", "charlie"}},
+		{"label", beads.Filter{Label: "urgent"}, []string{"alpha"}, []string{"bravo"}},
+		{"type", beads.Filter{Type: "bug"}, []string{"bravo"}, []string{"alpha"}},
+		{"limit", beads.Filter{Sort: "-created", Limit: 1}, []string{"charlie"}, []string{"alpha", "bravo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := seedBeadListStore(t)
+			var stdout, stderr bytes.Buffer
+			if code := doBeadList(store, tt.filter, false, &stdout, &stderr); code != 0 {
+				t.Fatalf("doBeadList = %d, want 0; stderr: %s", code, stderr.String())
+			}
+			out := stdout.String()
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("output missing %q:\n%s", w, out)
+				}
+			}
+			for _, r := range tt.reject {
+				if strings.Contains(out, r) {
+					t.Errorf("output should not contain %q:\n%s", r, out)
+				}
+			}
+		})
+	}
+}
+
+func TestDoBeadListSortOrder(t *testing.T) {
+	store := seedBeadListStore(t)
+	var stdout, stderr bytes.Buffer
+	if code := doBeadList(store, beads.Filter{Sort: "-title"}, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadList = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if strings.Index(out, "charlie") > strings.Index(out, "alpha") {
+		t.Errorf("--sort=-title should list charlie before alpha:\n%s", out)
+	}
+}
+
+func TestDoBeadListEmpty(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := doBeadList(beads.NewMemStore(), beads.Filter{}, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadList = %d", code)
+	}
+	if !strings.Contains(stdout.String(), "No beads") {
+		t.Errorf("stdout = %q, want 'No beads'", stdout.String())
+	}
+}
+
+func TestDoBeadListInvalidSort(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := doBeadList(beads.NewMemStore(), beads.Filter{Sort: "bogus"}, false, &stdout, &stderr); code != 1 {
+		t.Fatalf("doBeadList = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "invalid sort field") {
+		t.Errorf("stderr = %q, want invalid sort field", stderr.String())
+	}
+}
+
+func TestDoBeadListJSON(t *testing.T) {
+	store := seedBeadListStore(t)
+	var stdout, stderr bytes.Buffer
+	if code := doBeadList(store, beads.Filter{Status: "open"}, true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadList = %d; stderr: %s", code, stderr.String())
+	}
+	var got []beads.Bead
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, stdout.String())
+	}
+	if len(got) != 2 || got[0].Title != "alpha" {
+		t.Errorf("JSON beads = %+v, want [alpha bravo]", got)
+	}
+}
+
+func TestDoBeadListJSONEmpty(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := doBeadList(beads.NewMemStore(), beads.Filter{}, true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadList = %d", code)
+	}
+	if strings.TrimSpace(stdout.String()) != "[]" {
+		t.Errorf("stdout = %q, want []", stdout.String())
+	}
+}
+
+func TestDoBeadShow(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "fix login", Labels: []string{"urgent"}, Description: "steps here"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doBeadShow(store, "", b.ID, false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadShow = %d; stderr: %s", code, stderr.String())
+	}
+	for _, want := range []string{b.ID, "fix login", "urgent", "steps here"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := doBeadShow(store, "", b.ID, true, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadShow --json = %d", code)
+	}
+	var got beads.Bead
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.ID != b.ID || got.Title != "fix login" {
+		t.Errorf("JSON bead = %+v", got)
+	}
+}
+
+func TestDoBeadShowHistory(t *testing.T) {
+	t.Setenv("GC_AGENT", "mayor")
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "fix login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	worker := "worker"
+	if err := store.Update(b.ID, beads.UpdateOpts{Assignee: &worker}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Close(b.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doBeadShow(store, "", b.ID, false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadShow = %d; stderr: %s", code, stderr.String())
+	}
+	if strings.Contains(stdout.String(), "History") {
+		t.Errorf("history shown without --history:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := doBeadShow(store, "", b.ID, false, true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadShow --history = %d; stderr: %s", code, stderr.String())
+	}
+	for _, want := range []string{"History (3):", "status: \u2014 \u2192 open", "assignee: \u2014 \u2192 worker", "status: open \u2192 closed", "mayor"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestDoBeadShowNotFound(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := doBeadShow(beads.NewMemStore(), "", "gc-404", false, false, &stdout, &stderr); code != 1 {
+		t.Fatalf("doBeadShow = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "not found") {
+		t.Errorf("stderr = %q, want not found", stderr.String())
+	}
+}
+
+func TestDoBeadComment(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "fix login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doBeadComment(store, b.ID, "repro'd on staging\nroot cause is the session cache", "mayor", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadComment = %d; stderr: %s", code, stderr.String())
+	}
+	if code := doBeadComment(store, b.ID, "  ", "mayor", &stdout, &stderr); code != 1 {
+		t.Errorf("empty comment = %d, want 1", code)
+	}
+	if code := doBeadComment(store, "gc-404", "hello", "mayor", &stdout, &stderr); code != 1 {
+		t.Errorf("missing bead = %d, want 1", code)
+	}
+
+	stdout.Reset()
+	if code := doBeadShow(store, "", b.ID, false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadShow = %d", code)
+	}
+	for _, want := range []string{"Comments (1):", "mayor", "    repro'd on staging", "    root cause is the session cache"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestDoBeadAttach(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "fix login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	as := []beads.Attachment{
+		{Kind: beads.AttachPR, Ref: "https://github.com/org/repo/pull/42"},
+		{Kind: beads.AttachCommit, Ref: "3f9c2ab"},
+		{Kind: beads.AttachFile, Ref: "notes.md"},
+	}
+	if code := doBeadAttach(store, b.ID, as, "polecat", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadAttach = %d; stderr: %s", code, stderr.String())
+	}
+	for _, bad := range []beads.Attachment{
+		{Kind: beads.AttachPR, Ref: "github.com/org/repo/pull/42"},
+		{Kind: beads.AttachCommit, Ref: "not-a-sha"},
+	} {
+		stderr.Reset()
+		if code := doBeadAttach(store, b.ID, []beads.Attachment{bad}, "polecat", &stdout, &stderr); code != 1 {
+			t.Errorf("doBeadAttach(%+v) = %d, want 1", bad, code)
+		}
+	}
+	if code := doBeadAttach(store, b.ID, nil, "polecat", &stdout, &stderr); code != 1 {
+		t.Errorf("doBeadAttach(nothing) = %d, want 1", code)
+	}
+
+	got, _ := store.Get(b.ID)
+	if len(got.Attachments) != 3 || got.Attachments[0].Author != "polecat" || !filepath.IsAbs(got.Attachments[2].Ref) {
+		t.Fatalf("Attachments = %+v", got.Attachments)
+	}
+
+	stdout.Reset()
+	if code := doBeadShow(store, "", b.ID, false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadShow = %d", code)
+	}
+	for _, want := range []string{"Attachments (3):", "  pr      https://github.com/org/repo/pull/42", "  commit  3f9c2ab"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestDoBeadCreatePriority(t *testing.T) {
+	store := beads.NewMemStore()
+	var stdout, stderr bytes.Buffer
+	if code := doBeadCreate(store, beads.Bead{Title: "routine"}, "", "", time.Now(), false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadCreate = %d; stderr: %s", code, stderr.String())
+	}
+	if code := doBeadCreate(store, beads.Bead{Title: "outage", Labels: []string{"incident"}}, "p0", "", time.Now(), false, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadCreate = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Created gc-1 (P2)") || !strings.Contains(stdout.String(), "Created gc-2 (P0)") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	for _, bad := range []struct{ title, priority, want string }{
+		{"x", "P9", "invalid priority"},
+		{" ", "", "title is empty"},
+	} {
+		stderr.Reset()
+		if code := doBeadCreate(store, beads.Bead{Title: bad.title}, bad.priority, "", time.Now(), false, false, &stdout, &stderr); code != 1 {
+			t.Errorf("doBeadCreate(%q, %q) = %d, want 1", bad.title, bad.priority, code)
+		}
+		if !strings.Contains(stderr.String(), bad.want) {
+			t.Errorf("stderr = %q, want %q", stderr.String(), bad.want)
+		}
+	}
+
+	stdout.Reset()
+	if code := doBeadReady(store, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadReady = %d", code)
+	}
+	out := stdout.String()
+	if strings.Index(out, "outage") > strings.Index(out, "routine") {
+		t.Errorf("P0 bead not listed first:\n%s", out)
+	}
+}
+
+func TestDoBeadAssignAndUnassign(t *testing.T) {
+	t.Setenv("GC_TMUX_SESSION", "")
+	t.Setenv("GC_DIR", "")
+	cfg := &config.City{Agents: []config.Agent{
+		{Name: "mayor"},
+		{Name: "polecat", Dir: "myrig", Pool: &config.PoolConfig{Max: 3}},
+	}}
+	store := beads.NewMemStore()
+	b, _ := store.Create(beads.Bead{Title: "fix login"})
+
+	var stdout, stderr bytes.Buffer
+	if code := doBeadAssign(store, cfg, "metro", b.ID, "mayor", &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadAssign = %d; stderr: %s", code, stderr.String())
+	}
+	got, _ := store.Get(b.ID)
+	want := sessionName(store, "metro", "mayor", "")
+	if got.Assignee != want {
+		t.Errorf("Assignee = %q, want session name %q", got.Assignee, want)
+	}
+	if !strings.Contains(stdout.String(), "Assigned "+b.ID+" to mayor") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	for _, bad := range []struct{ agent, want string }{
+		{"ghost", `agent "ghost" not found`},
+		{"myrig/polecat", "is a pool"},
+	} {
+		stderr.Reset()
+		if code := doBeadAssign(store, cfg, "metro", b.ID, bad.agent, &stdout, &stderr); code != 1 {
+			t.Errorf("doBeadAssign(%s) = %d, want 1", bad.agent, code)
+		}
+		if !strings.Contains(stderr.String(), bad.want) {
+			t.Errorf("stderr = %q, want %q", stderr.String(), bad.want)
+		}
+	}
+
+	inProgress := "in_progress"
+	store.Update(b.ID, beads.UpdateOpts{Status: &inProgress}) //nolint:errcheck
+	stdout.Reset()
+	stderr.Reset()
+	if code := doBeadUnassign(store, b.ID, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadUnassign = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "may still be working on it") {
+		t.Errorf("missing in-progress warning: %q", stderr.String())
+	}
+	if got, _ := store.Get(b.ID); got.Assignee != "" || got.Status != "in_progress" {
+		t.Errorf("after unassign: assignee %q, status %q", got.Assignee, got.Status)
+	}
+	stdout.Reset()
+	doBeadUnassign(store, b.ID, &stdout, &stderr)
+	if !strings.Contains(stdout.String(), "is not assigned") {
+		t.Errorf("second unassign stdout = %q", stdout.String())
+	}
+}
+
+// racingStore lets another writer change the bead's title just before
+// each of the first races conditional updates, so they go stale.
+type racingStore struct {
+	*beads.MemStore
+	races int
+}
+
+func (s *racingStore) Update(id string, opts beads.UpdateOpts) error {
+	if opts.ExpectVersion != nil && s.races > 0 {
+		s.races--
+		title := "changed underneath"
+		if err := s.MemStore.Update(id, beads.UpdateOpts{Title: &title}); err != nil {
+			return err
+		}
+	}
+	return s.MemStore.Update(id, opts)
+}
+
+func TestUpdateBeadRetriesConflictOnce(t *testing.T) {
+	store := &racingStore{MemStore: beads.NewMemStore(), races: 1}
+	b, _ := store.Create(beads.Bead{Title: "contended"})
+	worker := "worker"
+	plans := 0
+	plan := func(beads.Bead) (*beads.UpdateOpts, error) {
+		plans++
+		return &beads.UpdateOpts{Assignee: &worker}, nil
+	}
+
+	seen, err := updateBead(store, b.ID, plan)
+	if err != nil {
+		t.Fatalf("updateBead: %v", err)
+	}
+	if plans != 2 {
+		t.Errorf("plan called %d times, want 2", plans)
+	}
+	if seen.Title != "changed underneath" {
+		t.Errorf("retry planned against %q, want the reread bead", seen.Title)
+	}
+	if got, _ := store.Get(b.ID); got.Assignee != worker || got.Title != "changed underneath" {
+		t.Errorf("after retry: assignee %q, title %q", got.Assignee, got.Title)
+	}
+
+	store.races = 2
+	plans = 0
+	if _, err := updateBead(store, b.ID, plan); !errors.Is(err, beads.ErrConflict) {
+		t.Fatalf("updateBead with two races = %v, want ErrConflict", err)
+	}
+	if plans != 2 {
+		t.Errorf("plan called %d times, want 2 (one retry)", plans)
+	}
+}
+
+func TestDoBeadReady(t *testing.T) {
+	store := seedBeadListStore(t)
+	var stdout, stderr bytes.Buffer
+	if code := doBeadReady(store, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadReady = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "alpha") || !strings.Contains(out, "bravo") {
+		t.Errorf("output missing open beads:\n%s", out)
+	}
+	if strings.Contains(out, "charlie") {
+		t.Errorf("output includes closed bead:\n%s", out)
+	}
+}
+
+// pollingStore runs hook before each Ready call so watch tests can mutate
+// the store between polls.
+type pollingStore struct {
+	*beads.MemStore
+	calls int
+	hook  func(call int)
+}
+
+func (s *pollingStore) Ready() ([]beads.Bead, error) {
+	s.calls++
+	s.hook(s.calls)
+	return s.MemStore.Ready()
+}
+
+func TestDoBeadReadyWatchRedrawsOnChange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := &pollingStore{MemStore: seedBeadListStore(t)}
+	store.hook = func(call int) {
+		switch call {
+		case 3:
+			if _, err := store.Create(beads.Bead{Title: "delta"}); err != nil {
+				t.Error(err)
+			}
+		case 4:
+			cancel()
+		}
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doBeadReadyWatch(ctx, store, time.Millisecond, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadReadyWatch = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	// Polls 1 and 2 see the same set; poll 3 adds delta; poll 4 is unchanged.
+	if n := strings.Count(out, "Ready beads at"); n != 2 {
+		t.Errorf("redraws = %d, want 2:\n%s", n, out)
+	}
+	if !strings.Contains(out, "delta") {
+		t.Errorf("output missing new bead:\n%s", out)
+	}
+}
+
+// readyHookStore runs hook before each Ready call on any store.
+type readyHookStore struct {
+	beads.Store
+	calls int
+	hook  func(call int)
+}
+
+func (s *readyHookStore) Ready() ([]beads.Bead, error) {
+	s.calls++
+	s.hook(s.calls)
+	return s.Store.Ready()
+}
+
+func TestDoBeadReadyWatchSeesOtherWriters(t *testing.T) {
+	city := t.TempDir()
+	t.Setenv("GC_BEADS", "file")
+	watched, err := openCityStoreAt(city)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := openCityStoreAt(city)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := &readyHookStore{Store: watched}
+	store.hook = func(call int) {
+		switch call {
+		case 2:
+			// Another process (gc sling, an agent) adds work.
+			if _, err := other.Create(beads.Bead{Title: "from elsewhere"}); err != nil {
+				t.Error(err)
+			}
+		case 3:
+			cancel()
+		}
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doBeadReadyWatch(ctx, store, time.Millisecond, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadReadyWatch = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "from elsewhere") {
+		t.Errorf("watch missed a bead created by another store:\n%s", stdout.String())
+	}
+}
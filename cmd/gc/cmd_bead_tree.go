@@ -0,0 +1,258 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/spf13/cobra"
+)
+
+func newBeadChildrenCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "children <id>",
+		Short: "List a bead's direct children",
+		Long: `List the beads whose parent is the given bead, in creation order.
+
+Containers such as epics and convoys group their work as children; gc
+sling expands them the same way.`,
+		Example: `  gc bead children gc-12
+  gc bead children gc-12 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadChildren(args[0], jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func newBeadTreeCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tree <id>",
+		Short: "Show a bead and its descendants as a tree",
+		Long: `Print a bead and all of its descendants as an ASCII tree with each
+bead's status and assignee.`,
+		Example: `  gc bead tree gc-12`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdBeadTree(args[0], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newBeadMoveCmd(stdout, stderr io.Writer) *cobra.Command {
+	var parent string
+	var root bool
+	cmd := &cobra.Command{
+		Use:   "move <id> --parent <new-parent>",
+		Short: "Move a bead under a different parent",
+		Long: `Re-parent a bead, moving it (and its descendants) under another bead.
+
+The bead can't be moved under itself or one of its own descendants.
+Use --root to detach it from its parent.`,
+		Example: `  gc bead move gc-14 --parent gc-12
+  gc bead move gc-14 --root`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if !root && parent == "" {
+				fmt.Fprintln(stderr, "gc bead move: --parent or --root is required") //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if cmdBeadMove(args[0], parent, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&parent, "parent", "", "new parent bead ID")
+	cmd.Flags().BoolVar(&root, "root", false, "detach the bead from its parent")
+	cmd.MarkFlagsMutuallyExclusive("parent", "root")
+	return cmd
+}
+
+// openBeadStore opens the store that owns bead id, for commands that
+// follow a bead's parent/child links within one store.
+func openBeadStore(id string, stderr io.Writer, cmdName string) beads.Store {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil
+	}
+	store, err := openRigStoreAt(cityPath, rigDirForBead(cfg, id))
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil
+	}
+	return store
+}
+
+// --- gc bead children ---
+
+// cmdBeadChildren is the CLI entry point for listing children.
+func cmdBeadChildren(id string, jsonOutput bool, stdout, stderr io.Writer) int {
+	store := openBeadStore(id, stderr, "gc bead children")
+	if store == nil {
+		return 1
+	}
+	return doBeadChildren(store, id, jsonOutput, stdout, stderr)
+}
+
+// doBeadChildren prints the direct children of bead id.
+func doBeadChildren(store beads.Store, id string, jsonOutput bool, stdout, stderr io.Writer) int {
+	if _, err := store.Get(id); err != nil {
+		fmt.Fprintf(stderr, "gc bead children: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	kids, err := store.Children(id)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead children: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if jsonOutput {
+		writeBeadsJSON(kids, stdout)
+		return 0
+	}
+	if len(kids) == 0 {
+		fmt.Fprintf(stdout, "Bead %s has no children.\n", id) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	writeBeadTable(kids, stdout, true)
+	return 0
+}
+
+// --- gc bead tree ---
+
+// cmdBeadTree is the CLI entry point for printing a bead tree.
+func cmdBeadTree(id string, stdout, stderr io.Writer) int {
+	store := openBeadStore(id, stderr, "gc bead tree")
+	if store == nil {
+		return 1
+	}
+	return doBeadTree(store, id, stdout, stderr)
+}
+
+// doBeadTree prints bead id and its descendants.
+func doBeadTree(store beads.Store, id string, stdout, stderr io.Writer) int {
+	root, err := store.Get(id)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead tree: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var b strings.Builder
+	b.WriteString(beadTreeLabel(root) + "\n")
+	if err := writeBeadSubtree(&b, store, root.ID, "", map[string]bool{root.ID: true}); err != nil {
+		fmt.Fprintf(stderr, "gc bead tree: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprint(stdout, b.String()) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// writeBeadSubtree writes the children of id beneath prefix. seen guards
+// against parent cycles in stores that don't prevent them.
+func writeBeadSubtree(b *strings.Builder, store beads.Store, id, prefix string, seen map[string]bool) error {
+	kids, err := store.Children(id)
+	if err != nil {
+		return err
+	}
+	for i, k := range kids {
+		branch, indent := "├── ", "│   "
+		if i == len(kids)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if seen[k.ID] {
+			b.WriteString(prefix + branch + k.ID + " (cycle)\n")
+			continue
+		}
+		seen[k.ID] = true
+		b.WriteString(prefix + branch + beadTreeLabel(k) + "\n")
+		if err := writeBeadSubtree(b, store, k.ID, prefix+indent, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// beadTreeLabel renders one tree node: ID, status, title, and assignee.
+func beadTreeLabel(b beads.Bead) string {
+	s := fmt.Sprintf("%s [%s] %s", b.ID, b.Status, b.Title)
+	if b.Type != "" && b.Type != "task" {
+		s += " (" + b.Type + ")"
+	}
+	if b.Assignee != "" {
+		s += " @" + b.Assignee
+	}
+	return s
+}
+
+// --- gc bead move ---
+
+// cmdBeadMove is the CLI entry point for re-parenting a bead.
+func cmdBeadMove(id, parent string, stdout, stderr io.Writer) int {
+	store := openBeadStore(id, stderr, "gc bead move")
+	if store == nil {
+		return 1
+	}
+	return doBeadMove(store, id, parent, stdout, stderr)
+}
+
+// doBeadMove sets the parent of bead id. An empty parent detaches it.
+func doBeadMove(store beads.Store, id, parent string, stdout, stderr io.Writer) int {
+	b, err := store.Get(id)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc bead move: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if b.ParentID == parent {
+		if parent == "" {
+			fmt.Fprintf(stdout, "Bead %s has no parent\n", id) //nolint:errcheck // best-effort stdout
+		} else {
+			fmt.Fprintf(stdout, "Bead %s is already under %s\n", id, parent) //nolint:errcheck // best-effort stdout
+		}
+		return 0
+	}
+	if parent != "" {
+		// Walk up from the new parent; reaching id would make a cycle.
+		seen := map[string]bool{}
+		for cur := parent; cur != "" && !seen[cur]; {
+			if cur == id {
+				fmt.Fprintf(stderr, "gc bead move: %s is %s or one of its descendants\n", parent, id) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			seen[cur] = true
+			p, err := store.Get(cur)
+			if err != nil {
+				fmt.Fprintf(stderr, "gc bead move: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			cur = p.ParentID
+		}
+	}
+	if err := store.Update(id, beads.UpdateOpts{ParentID: &parent}); err != nil {
+		fmt.Fprintf(stderr, "gc bead move: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	switch {
+	case parent == "":
+		fmt.Fprintf(stdout, "Detached %s from %s\n", id, b.ParentID) //nolint:errcheck // best-effort stdout
+	case b.ParentID == "":
+		fmt.Fprintf(stdout, "Moved %s under %s\n", id, parent) //nolint:errcheck // best-effort stdout
+	default:
+		fmt.Fprintf(stdout, "Moved %s under %s (was %s)\n", id, parent, b.ParentID) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// newTreeStore builds an epic with children a (which has child a1) and b.
+func newTreeStore(t *testing.T) (beads.Store, map[string]string) {
+	t.Helper()
+	store := beads.NewMemStore()
+	ids := map[string]string{}
+	mk := func(key, title, typ, parent string) {
+		b, err := store.Create(beads.Bead{Title: title, Type: typ, ParentID: ids[parent]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[key] = b.ID
+	}
+	mk("epic", "Launch", "epic", "")
+	mk("a", "Write docs", "", "epic")
+	mk("b", "Fix bug", "", "epic")
+	mk("a1", "Proofread", "", "a")
+	return store, ids
+}
+
+func TestDoBeadChildren(t *testing.T) {
+	store, ids := newTreeStore(t)
+	var stdout, stderr bytes.Buffer
+	if code := doBeadChildren(store, ids["epic"], false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadChildren = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, ids["a"]) || !strings.Contains(out, ids["b"]) || strings.Contains(out, ids["a1"]) {
+		t.Errorf("children output:\n%s", out)
+	}
+
+	stdout.Reset()
+	doBeadChildren(store, ids["b"], false, &stdout, &stderr)
+	if !strings.Contains(stdout.String(), "has no children") {
+		t.Errorf("leaf output = %q", stdout.String())
+	}
+}
+
+func TestDoBeadTree(t *testing.T) {
+	store, ids := newTreeStore(t)
+	var stdout, stderr bytes.Buffer
+	if code := doBeadTree(store, ids["epic"], &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadTree = %d; stderr: %s", code, stderr.String())
+	}
+	want := ids["epic"] + " [open] Launch (epic)\n" +
+		"├── " + ids["a"] + " [open] Write docs\n" +
+		"│   └── " + ids["a1"] + " [open] Proofread\n" +
+		"└── " + ids["b"] + " [open] Fix bug\n"
+	if stdout.String() != want {
+		t.Errorf("tree:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+}
+
+func TestDoBeadMove(t *testing.T) {
+	store, ids := newTreeStore(t)
+	var stdout, stderr bytes.Buffer
+
+	if code := doBeadMove(store, ids["b"], ids["a"], &stdout, &stderr); code != 0 {
+		t.Fatalf("doBeadMove = %d; stderr: %s", code, stderr.String())
+	}
+	if b, _ := store.Get(ids["b"]); b.ParentID != ids["a"] {
+		t.Errorf("parent = %q, want %q", b.ParentID, ids["a"])
+	}
+	if !strings.Contains(stdout.String(), "(was "+ids["epic"]+")") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	// Moving a bead under its own descendant is refused.
+	stderr.Reset()
+	if code := doBeadMove(store, ids["a"], ids["a1"], &stdout, &stderr); code != 1 {
+		t.Errorf("cycle move = %d, want 1", code)
+	}
+	if code := doBeadMove(store, ids["a"], ids["a"], &stdout, &stderr); code != 1 {
+		t.Errorf("self move = %d, want 1", code)
+	}
+
+	// Detach.
+	stdout.Reset()
+	if code := doBeadMove(store, ids["b"], "", &stdout, &stderr); code != 0 {
+		t.Fatalf("detach = %d; stderr: %s", code, stderr.String())
+	}
+	if b, _ := store.Get(ids["b"]); b.ParentID != "" {
+		t.Errorf("parent after detach = %q", b.ParentID)
+	}
+
+	if code := doBeadMove(store, ids["b"], "missing-1", &stdout, &stderr); code != 1 {
+		t.Errorf("move under missing parent = %d, want 1", code)
+	}
+}
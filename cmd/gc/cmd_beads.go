@@ -13,11 +13,12 @@ func newBeadsCmd(stdout, stderr io.Writer) *cobra.Command {
 		Short: "Manage the beads provider",
 		Long: `Manage the beads provider (backing store for issue tracking).
 
-Subcommands for health checking and diagnostics.`,
+Subcommands for health checking, diagnostics, moving beads between
+providers, and reviewing agents' Dolt branches.`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(_ *cobra.Command, args []string) error {
 			if len(args) == 0 {
-				fmt.Fprintln(stderr, "gc beads: missing subcommand (health)") //nolint:errcheck // best-effort stderr
+				fmt.Fprintln(stderr, "gc beads: missing subcommand (branch, health, migrate)") //nolint:errcheck // best-effort stderr
 			} else {
 				fmt.Fprintf(stderr, "gc beads: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
 			}
@@ -25,7 +26,9 @@ Subcommands for health checking and diagnostics.`,
 		},
 	}
 	cmd.AddCommand(
+		newBeadsBranchCmd(stdout, stderr),
 		newBeadsHealthCmd(stdout, stderr),
+		newBeadsMigrateCmd(stdout, stderr),
 	)
 	return cmd
 }
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/spf13/cobra"
+)
+
+func newBeadsBranchCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "branch",
+		Short: "Review and merge agents' Dolt branches",
+		Long: `Review and merge the per-agent Dolt branches kept when
+[beads] agent_branches is set.
+
+With agent branches on, beads an agent creates or changes in its
+session land on branch agent/<name> of the bd database instead of
+main. "gc beads branch diff" shows what a branch changed and
+"gc beads branch merge" folds it into main. With agent_branches =
+"auto" the controller also merges changed branches every patrol.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc beads branch: missing subcommand (list, diff, merge)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc beads branch: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(
+		newBeadsBranchListCmd(stdout, stderr),
+		newBeadsBranchDiffCmd(stdout, stderr),
+		newBeadsBranchMergeCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newBeadsBranchListCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List agent branches and their unmerged changes",
+		Long: `List the agent branches of the city's and rigs' bd databases, with
+the number of beads each has changed since it last met main.`,
+		Example: `  gc beads branch list`,
+		Args:    cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			sets, code := openCityDoltBranches(stderr, "gc beads branch list")
+			if code != 0 || doBeadsBranchList(sets, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newBeadsBranchDiffCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <agent>",
+		Short: "Show the bead changes on an agent's branch",
+		Long: `Show the beads an agent's branch added, changed, or removed since it
+last met main, in every bd database where the branch exists.
+
+The agent may be given by name (e.g. hw/polecat) or as its branch
+(agent/hw/polecat).`,
+		Example: `  gc beads branch diff mayor
+  gc beads branch diff hw/polecat-2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			sets, code := openCityDoltBranches(stderr, "gc beads branch diff")
+			if code != 0 || doBeadsBranchDiff(sets, args[0], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newBeadsBranchMergeCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge [agent...]",
+		Short: "Merge agents' branches into main",
+		Long: `Merge agent branches into main, then bring each branch up to date
+with main so its agent sees beads changed elsewhere.
+
+With no arguments every branch with changes is merged. A merge with
+conflicts changes nothing and is reported; resolve it with dolt.`,
+		Example: `  gc beads branch merge
+  gc beads branch merge mayor hw/polecat-2`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			sets, code := openCityDoltBranches(stderr, "gc beads branch merge")
+			if code != 0 || doBeadsBranchMerge(sets, args, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// openCityDoltBranches returns the branch managers for the current
+// city's bd databases. On error it writes to stderr and returns a
+// non-zero exit code.
+func openCityDoltBranches(stderr io.Writer, cmdName string) ([]*beads.DoltBranches, int) {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, 1
+	}
+	if p := rawBeadsProvider(cityPath); p != "bd" {
+		fmt.Fprintf(stderr, "%s: agent branches need the bd provider, not %q\n", cmdName, p) //nolint:errcheck // best-effort stderr
+		return nil, 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, 1
+	}
+	sets, err := cityDoltBranches(cityPath, cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, 1
+	}
+	return sets, 0
+}
+
+// branchArg returns the branch for an agent name or branch name.
+func branchArg(s string) string {
+	if strings.HasPrefix(s, beads.AgentBranchPrefix) {
+		return s
+	}
+	return beads.AgentBranch(s)
+}
+
+// doBeadsBranchList prints each agent branch with its change count.
+func doBeadsBranchList(sets []*beads.DoltBranches, stdout, stderr io.Writer) int {
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DATABASE\tBRANCH\tCHANGES") //nolint:errcheck // best-effort stdout
+	rc := 0
+	for _, br := range sets {
+		branches, err := br.List()
+		if err != nil {
+			fmt.Fprintf(stderr, "gc beads branch list: %s: %v\n", br.Database(), err) //nolint:errcheck // best-effort stderr
+			rc = 1
+			continue
+		}
+		for _, b := range branches {
+			changes, err := br.Diff(b)
+			if err != nil {
+				fmt.Fprintf(stderr, "gc beads branch list: %v\n", err) //nolint:errcheck // best-effort stderr
+				rc = 1
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\n", br.Database(), b, len(changes)) //nolint:errcheck // best-effort stdout
+		}
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+	return rc
+}
+
+// doBeadsBranchDiff prints the changes on agent's branch in each
+// database that has it.
+func doBeadsBranchDiff(sets []*beads.DoltBranches, agent string, stdout, stderr io.Writer) int {
+	branch := branchArg(agent)
+	found := false
+	for _, br := range sets {
+		branches, err := br.List()
+		if err != nil {
+			fmt.Fprintf(stderr, "gc beads branch diff: %s: %v\n", br.Database(), err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if !slices.Contains(branches, branch) {
+			continue
+		}
+		found = true
+		changes, err := br.Diff(branch)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc beads branch diff: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintf(stdout, "%s %s: %d changes\n", br.Database(), branch, len(changes)) //nolint:errcheck // best-effort stdout
+		for _, c := range changes {
+			fmt.Fprintf(stdout, "  %s\n", formatBranchChange(c)) //nolint:errcheck // best-effort stdout
+		}
+	}
+	if !found {
+		fmt.Fprintf(stderr, "gc beads branch diff: no branch %s\n", branch) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return 0
+}
+
+// formatBranchChange renders one change as "+ id title", "- id title",
+// or "~ id title (status a → b, assignee x → y)".
+func formatBranchChange(c beads.BranchChange) string {
+	switch c.Kind {
+	case "added":
+		return fmt.Sprintf("+ %s %s", c.ID, c.Title)
+	case "removed":
+		return fmt.Sprintf("- %s %s", c.ID, c.Title)
+	}
+	var parts []string
+	if c.FromStatus != c.ToStatus {
+		parts = append(parts, fmt.Sprintf("status %s → %s", c.FromStatus, c.ToStatus))
+	}
+	if c.FromAssignee != c.ToAssignee {
+		parts = append(parts, fmt.Sprintf("assignee %s → %s", orNone(c.FromAssignee), orNone(c.ToAssignee)))
+	}
+	line := fmt.Sprintf("~ %s %s", c.ID, c.Title)
+	if len(parts) > 0 {
+		line += " (" + strings.Join(parts, ", ") + ")"
+	}
+	return line
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// doBeadsBranchMerge merges the named agents' branches, or every changed
+// branch when agents is empty, in each database.
+func doBeadsBranchMerge(sets []*beads.DoltBranches, agents []string, stdout, stderr io.Writer) int {
+	rc := 0
+	total := 0
+	for _, br := range sets {
+		var only []string
+		if len(agents) > 0 {
+			branches, err := br.List()
+			if err != nil {
+				fmt.Fprintf(stderr, "gc beads branch merge: %s: %v\n", br.Database(), err) //nolint:errcheck // best-effort stderr
+				rc = 1
+				continue
+			}
+			for _, a := range agents {
+				if b := branchArg(a); slices.Contains(branches, b) {
+					only = append(only, b)
+				}
+			}
+			if len(only) == 0 {
+				continue
+			}
+		}
+		merged, err := mergeChangedBranches(br, only)
+		for _, m := range merged {
+			fmt.Fprintf(stdout, "Merged %d bead changes from %s into %s\n", m.changes, m.branch, br.Database()) //nolint:errcheck // best-effort stdout
+		}
+		total += len(merged)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc beads branch merge: %v\n", err) //nolint:errcheck // best-effort stderr
+			rc = 1
+		}
+	}
+	if total == 0 && rc == 0 {
+		fmt.Fprintln(stdout, "No branch changes to merge.") //nolint:errcheck // best-effort stdout
+	}
+	return rc
+}
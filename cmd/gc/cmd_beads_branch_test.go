@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// branchDolt answers the dolt queries gc beads branch makes: one agent
+// branch, agent/mayor, with a single modified bead.
+func branchDolt(merges *int) beads.CommandRunner {
+	return func(_, _ string, args ...string) ([]byte, error) {
+		stmt := args[len(args)-1]
+		switch {
+		case strings.Contains(stmt, "FROM dolt_branches"):
+			return []byte(`{"rows": [{"name": "agent/mayor"}]}`), nil
+		case strings.Contains(stmt, "dolt_diff"):
+			return []byte(`{"rows": [{"diff_type": "modified", "from_id": "gc-1", "to_id": "gc-1", "to_title": "fix", "from_status": "open", "to_status": "closed"}]}`), nil
+		case strings.Contains(stmt, "DOLT_MERGE('agent/mayor'"):
+			*merges++
+			return []byte(`{"rows": [{"hash": "abc", "fast_forward": 1, "conflicts": 0}]}`), nil
+		}
+		return []byte(`{"rows": []}`), nil
+	}
+}
+
+func TestDoBeadsBranchDiff(t *testing.T) {
+	var merges int
+	sets := []*beads.DoltBranches{beads.NewDoltBranches("/city", "gc", beads.DoltConn{}, branchDolt(&merges))}
+	var stdout, stderr bytes.Buffer
+	if code := doBeadsBranchDiff(sets, "mayor", &stdout, &stderr); code != 0 {
+		t.Fatalf("exit = %d; stderr = %s", code, stderr.String())
+	}
+	want := "gc agent/mayor: 1 changes\n  ~ gc-1 fix (status open → closed)\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+
+	stderr.Reset()
+	if code := doBeadsBranchDiff(sets, "deacon", &stdout, &stderr); code == 0 {
+		t.Error("diff of a missing branch succeeded, want failure")
+	}
+}
+
+func TestDoBeadsBranchMerge(t *testing.T) {
+	var merges int
+	sets := []*beads.DoltBranches{beads.NewDoltBranches("/city", "gc", beads.DoltConn{}, branchDolt(&merges))}
+	var stdout, stderr bytes.Buffer
+	if code := doBeadsBranchMerge(sets, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit = %d; stderr = %s", code, stderr.String())
+	}
+	if merges != 1 || !strings.Contains(stdout.String(), "Merged 1 bead changes from agent/mayor into gc") {
+		t.Errorf("merges = %d, stdout = %q", merges, stdout.String())
+	}
+
+	// Naming an agent without a branch merges nothing.
+	stdout.Reset()
+	if code := doBeadsBranchMerge(sets, []string{"deacon"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit = %d; stderr = %s", code, stderr.String())
+	}
+	if merges != 1 || !strings.Contains(stdout.String(), "No branch changes") {
+		t.Errorf("merges = %d, stdout = %q", merges, stdout.String())
+	}
+}
+
+func TestFormatBranchChange(t *testing.T) {
+	for _, tc := range []struct {
+		c    beads.BranchChange
+		want string
+	}{
+		{beads.BranchChange{ID: "gc-1", Kind: "added", Title: "a"}, "+ gc-1 a"},
+		{beads.BranchChange{ID: "gc-2", Kind: "removed", Title: "b"}, "- gc-2 b"},
+		{beads.BranchChange{ID: "gc-3", Kind: "modified", Title: "c", FromStatus: "open", ToStatus: "open", ToAssignee: "mayor"}, "~ gc-3 c (assignee none → mayor)"},
+	} {
+		if got := formatBranchChange(tc.c); got != tc.want {
+			t.Errorf("formatBranchChange(%+v) = %q, want %q", tc.c, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/formula"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+func newBeadsMigrateCmd(stdout, stderr io.Writer) *cobra.Command {
+	var from, to string
+	cmd := &cobra.Command{
+		Use:   "migrate --to <provider>",
+		Short: "Copy all beads to another provider and switch to it",
+		Long: `Copy every bead from one beads provider to another, then point
+city.toml's [beads] provider at the new one.
+
+Beads keep their IDs, parents, dependencies, timestamps, comments,
+attachments, and status history. The destination must be empty. After
+the copy the destination's bead count is checked against the source,
+and city.toml is changed only if they match; the source is left in
+place.
+
+--from defaults to the city's current provider. Destinations must be
+able to load beads verbatim, which the file and sqlite providers can;
+bd assigns its own IDs and cannot be a destination.
+
+Stop the city first so no beads change during the copy.`,
+		Example: `  gc beads migrate --to sqlite
+  gc beads migrate --from file --to sqlite`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdBeadsMigrate(from, to, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "provider to copy from (default: current provider)")
+	cmd.Flags().StringVar(&to, "to", "", "provider to copy to (file or sqlite)")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// cmdBeadsMigrate is the CLI entry point for gc beads migrate.
+func cmdBeadsMigrate(from, to string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc beads migrate: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if from == "" {
+		from = rawBeadsProvider(cityPath)
+	}
+	if err := doBeadsMigrate(fsys.OSFS{}, cityPath, from, to, stdout); err != nil {
+		fmt.Fprintf(stderr, "gc beads migrate: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if os.Getenv("GC_BEADS") != "" {
+		fmt.Fprintf(stderr, "gc beads migrate: GC_BEADS=%s overrides city.toml; unset it to use %s\n", //nolint:errcheck // best-effort stderr
+			os.Getenv("GC_BEADS"), to)
+	}
+	return 0
+}
+
+// doBeadsMigrate copies every bead in the city store under provider from
+// into an empty store under provider to, checks the copy's bead count,
+// and sets beads.provider in city.toml to to.
+func doBeadsMigrate(fs fsys.FS, cityPath, from, to string, stdout io.Writer) error {
+	if from == to {
+		return fmt.Errorf("--from and --to are both %q", from)
+	}
+	src, err := openStoreAt(cityPath, from, "")
+	if err != nil {
+		return fmt.Errorf("opening %s store: %w", from, err)
+	}
+	defer closeMigrateStore(src)
+	dst, err := openStoreAt(cityPath, to, "")
+	if err != nil {
+		return fmt.Errorf("opening %s store: %w", to, err)
+	}
+	defer closeMigrateStore(dst)
+	imp, ok := unwrapCookingStore(dst).(beads.Importer)
+	if !ok {
+		return fmt.Errorf("provider %q cannot keep bead IDs; use file or sqlite", to)
+	}
+	existing, err := dst.List()
+	if err != nil {
+		return fmt.Errorf("listing %s store: %w", to, err)
+	}
+	if len(existing) > 0 {
+		return fmt.Errorf("%s store already has %d beads", to, len(existing))
+	}
+
+	bs, deps, err := beads.Export(src)
+	if err != nil {
+		return err
+	}
+	if err := imp.Import(bs, deps); err != nil {
+		return err
+	}
+	copied, err := dst.List()
+	if err != nil {
+		return fmt.Errorf("verifying %s store: %w", to, err)
+	}
+	if len(copied) != len(bs) {
+		return fmt.Errorf("%s store has %d beads after copy, want %d; city.toml not changed", to, len(copied), len(bs))
+	}
+	fmt.Fprintf(stdout, "Copied %d beads and %d deps from %s to %s\n", len(bs), len(deps), from, to) //nolint:errcheck // best-effort stdout
+
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	data, err := fs.ReadFile(tomlPath)
+	if err != nil {
+		return err
+	}
+	out, err := config.SetValue(data, "beads.provider", to)
+	if err != nil {
+		return err
+	}
+	if err := fs.WriteFile(tomlPath, out, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Set beads.provider = %s\n", to) //nolint:errcheck // best-effort stdout
+	return nil
+}
+
+// unwrapCookingStore returns the store under the formula cooking wrapper
+// that openStoreAt puts around file and sqlite stores.
+func unwrapCookingStore(s beads.Store) beads.Store {
+	if cs, ok := s.(*formula.CookingStore); ok {
+		return cs.Store
+	}
+	return s
+}
+
+// closeMigrateStore releases a sqlite store's database handle.
+func closeMigrateStore(s beads.Store) {
+	if ss, ok := unwrapCookingStore(s).(*beads.SQLiteStore); ok {
+		ss.CloseDB() //nolint:errcheck // best-effort close
+	}
+}
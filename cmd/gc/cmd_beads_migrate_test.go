@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func setupMigrateCity(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".gc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	toml := "[workspace]\nname = \"test\"\n\n[beads]\nprovider = \"file\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "city.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDoBeadsMigrate_FileToSQLite(t *testing.T) {
+	dir := setupMigrateCity(t)
+	src, err := openStoreAt(dir, "file", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := src.Create(beads.Bead{Title: "epic", Type: "epic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := src.Create(beads.Bead{Title: "task", ParentID: parent.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Close(child.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	if err := doBeadsMigrate(fsys.OSFS{}, dir, "file", "sqlite", &stdout); err != nil {
+		t.Fatalf("doBeadsMigrate: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Copied 2 beads") {
+		t.Errorf("stdout = %q, want copy count", stdout.String())
+	}
+
+	dst, err := openStoreAt(dir, "sqlite", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeMigrateStore(dst)
+	got, err := dst.Get(child.ID)
+	if err != nil {
+		t.Fatalf("Get(%s) after migrate: %v", child.ID, err)
+	}
+	if got.ParentID != parent.ID || got.Status != "closed" {
+		t.Errorf("migrated child = %+v, want parent %s and closed", got, parent.ID)
+	}
+
+	cfg, err := loadCityConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Beads.Provider != "sqlite" {
+		t.Errorf("beads.provider = %q, want sqlite", cfg.Beads.Provider)
+	}
+}
+
+func TestDoBeadsMigrate_RefusesNonEmptyDestination(t *testing.T) {
+	dir := setupMigrateCity(t)
+	src, err := openStoreAt(dir, "file", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.Create(beads.Bead{Title: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := openStoreAt(dir, "sqlite", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dst.Create(beads.Bead{Title: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	closeMigrateStore(dst)
+
+	var stdout bytes.Buffer
+	err = doBeadsMigrate(fsys.OSFS{}, dir, "file", "sqlite", &stdout)
+	if err == nil || !strings.Contains(err.Error(), "already has 1 beads") {
+		t.Fatalf("err = %v, want non-empty destination error", err)
+	}
+	cfg, err := loadCityConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Beads.Provider != "file" {
+		t.Errorf("beads.provider = %q, want file unchanged", cfg.Beads.Provider)
+	}
+}
+
+func TestDoBeadsMigrate_SameProvider(t *testing.T) {
+	dir := setupMigrateCity(t)
+	var stdout bytes.Buffer
+	if err := doBeadsMigrate(fsys.OSFS{}, dir, "file", "file", &stdout); err == nil {
+		t.Fatal("migrating file to file succeeded, want error")
+	}
+}
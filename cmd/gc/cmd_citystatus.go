@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"time"
 
+	"github.com/gastownhall/gascity/internal/beads"
 	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/runtime"
 	"github.com/gastownhall/gascity/internal/session"
+	"github.com/gastownhall/gascity/internal/snapshot"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +24,14 @@ type StatusJSON struct {
 	Agents     []StatusAgentJSON `json:"agents"`
 	Rigs       []StatusRigJSON   `json:"rigs"`
 	Summary    StatusSummaryJSON `json:"summary"`
+	Crashes    []StatusCrashJSON `json:"recent_crashes,omitempty"`
+}
+
+// StatusCrashJSON is a recent crash snapshot in the JSON status output.
+type StatusCrashJSON struct {
+	Session string    `json:"session"`
+	Time    time.Time `json:"time"`
+	Path    string    `json:"path"`
 }
 
 // ControllerJSON represents controller state in JSON output.
@@ -36,7 +47,10 @@ type StatusAgentJSON struct {
 	Scope         string    `json:"scope"`
 	Running       bool      `json:"running"`
 	Suspended     bool      `json:"suspended"`
+	Quarantined   bool      `json:"quarantined,omitempty"`
 	Pool          *PoolJSON `json:"pool"`
+	OpenBeads     int       `json:"open_beads,omitempty"`
+	ActiveBeads   int       `json:"in_progress_beads,omitempty"`
 }
 
 // PoolJSON represents pool configuration in JSON output.
@@ -56,6 +70,7 @@ type StatusRigJSON struct {
 type StatusSummaryJSON struct {
 	TotalAgents       int `json:"total_agents"`
 	RunningAgents     int `json:"running_agents"`
+	QuarantinedAgents int `json:"quarantined_agents,omitempty"`
 	ActiveSessions    int `json:"active_sessions,omitempty"`
 	SuspendedSessions int `json:"suspended_sessions,omitempty"`
 }
@@ -67,7 +82,13 @@ func newStatusCmd(stdout, stderr io.Writer) *cobra.Command {
 		Use:   "status [path]",
 		Short: "Show city-wide status overview",
 		Long: `Shows a city-wide overview: controller state, suspension,
-all agents with running status, rigs, and a summary count.`,
+all agents with running status, rigs, and a summary count.
+
+Agents with assigned work show their open and in-progress bead counts
+from the city bead store. Agents quarantined for crash looping show
+when their quarantine ends; clear it early with "gc agent resume
+--clear-quarantine". The most recent crash snapshots saved to
+.gc/crash/ are listed last; see "gc logs --crashes".`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			if cmdCityStatus(args, jsonFlag, stdout, stderr) != 0 {
@@ -154,12 +175,29 @@ func doCityStatus(
 		}
 	}
 
+	// Bead store is best-effort: work counts and session totals are
+	// skipped when it is unavailable.
+	store, storeErr := openCityStoreAt(cityPath)
+	var work map[string]assigneeWork
+	if storeErr == nil {
+		work = countAssigneeWork(store)
+	}
+	quarantines, _ := readCrashQuarantines(crashStatePath(cityPath))
+	now := time.Now()
+
 	// Agents section.
 	if len(cfg.Agents) > 0 {
 		fmt.Fprintln(stdout) //nolint:errcheck // best-effort stdout
 		fmt.Fprintln(stdout, "Agents:")
 
-		var totalAgents, runningAgents int
+		var totalAgents, runningAgents, quarantinedAgents int
+		statusLine := func(sn string, suspended bool) string {
+			if q, ok := quarantines.active(sn, now); ok && !sp.IsRunning(sn) {
+				quarantinedAgents++
+				return fmt.Sprintf("stopped  (quarantined until %s, strike %d)", q.Until.Local().Format("15:04"), q.Strikes)
+			}
+			return agentStatusLine(sp, dops, sn, suspended)
+		}
 
 		for _, a := range cfg.Agents {
 			// Effective suspended: agent-level or inherited from rig.
@@ -175,7 +213,7 @@ func doCityStatus(
 				fmt.Fprintf(stdout, "  %-24spool (min=%d, %s)\n", a.QualifiedName(), pool.Min, maxDisplay) //nolint:errcheck // best-effort stdout
 				for _, qualifiedInstance := range discoverPoolInstances(a.Name, a.Dir, pool, cityName, cfg.Workspace.SessionTemplate, sp) {
 					sn := cliSessionName(cityPath, cityName, qualifiedInstance, cfg.Workspace.SessionTemplate)
-					status := agentStatusLine(sp, dops, sn, suspended)
+					status := statusLine(sn, suspended) + work[qualifiedInstance].annotation()
 					fmt.Fprintf(stdout, "    %-22s%s\n", qualifiedInstance, status) //nolint:errcheck // best-effort stdout
 					totalAgents++
 					if sp.IsRunning(sn) {
@@ -185,7 +223,7 @@ func doCityStatus(
 			} else {
 				// Singleton agent.
 				sn := cliSessionName(cityPath, cityName, a.QualifiedName(), cfg.Workspace.SessionTemplate)
-				status := agentStatusLine(sp, dops, sn, suspended)
+				status := statusLine(sn, suspended) + work[a.QualifiedName()].annotation()
 				fmt.Fprintf(stdout, "  %-24s%s\n", a.QualifiedName(), status) //nolint:errcheck // best-effort stdout
 				totalAgents++
 				if sp.IsRunning(sn) {
@@ -197,6 +235,9 @@ func doCityStatus(
 		// Summary line.
 		fmt.Fprintln(stdout)                                                      //nolint:errcheck // best-effort stdout
 		fmt.Fprintf(stdout, "%d/%d agents running\n", runningAgents, totalAgents) //nolint:errcheck // best-effort stdout
+		if quarantinedAgents > 0 {
+			fmt.Fprintf(stdout, "%d agent(s) quarantined for crash looping; see \"gc agent resume --clear-quarantine\"\n", quarantinedAgents) //nolint:errcheck // best-effort stdout
+		}
 	}
 
 	// Rigs section.
@@ -212,8 +253,16 @@ func doCityStatus(
 		}
 	}
 
+	if crashes := recentCrashes(cityPath, statusCrashLimit); len(crashes) > 0 {
+		fmt.Fprintln(stdout) //nolint:errcheck // best-effort stdout
+		fmt.Fprintln(stdout, "Recent crashes:")
+		for _, c := range crashes {
+			fmt.Fprintf(stdout, "  %-24s%s  %s\n", c.Session, c.Time.Local().Format("2006-01-02 15:04"), c.Path) //nolint:errcheck // best-effort stdout
+		}
+	}
+
 	// Chat sessions count (best-effort — skip if store unavailable).
-	if store, err := openCityStoreAt(cityPath); err == nil {
+	if storeErr == nil {
 		mgr := newSessionManagerWithConfig(store, sp, cfg)
 		if sessions, err := mgr.List("", ""); err == nil && len(sessions) > 0 {
 			var active, suspended int
@@ -256,6 +305,14 @@ func doCityStatusJSON(
 		}
 	}
 
+	store, storeErr := openCityStoreAt(cityPath)
+	var work map[string]assigneeWork
+	if storeErr == nil {
+		work = countAssigneeWork(store)
+	}
+	quarantines, _ := readCrashQuarantines(crashStatePath(cityPath))
+	now := time.Now()
+
 	// Controller.
 	var ctrl ControllerJSON
 	if pid := controllerAlive(cityPath); pid != 0 {
@@ -264,7 +321,14 @@ func doCityStatusJSON(
 
 	// Agents.
 	var agents []StatusAgentJSON
-	var totalAgents, runningAgents int
+	var totalAgents, runningAgents, quarantinedAgents int
+	isQuarantined := func(sn string, running bool) bool {
+		_, ok := quarantines.active(sn, now)
+		if ok && !running {
+			quarantinedAgents++
+		}
+		return ok && !running
+	}
 	for _, a := range cfg.Agents {
 		suspended := a.Suspended || (a.Dir != "" && suspendedRigs[a.Dir])
 		pool := a.EffectivePool()
@@ -285,7 +349,10 @@ func doCityStatusJSON(
 					Scope:         scope,
 					Running:       running,
 					Suspended:     suspended,
+					Quarantined:   isQuarantined(sn, running),
 					Pool:          &PoolJSON{Min: pool.Min, Max: pool.Max},
+					OpenBeads:     work[qualifiedInstance].open,
+					ActiveBeads:   work[qualifiedInstance].inProgress,
 				})
 				totalAgents++
 				if running {
@@ -302,7 +369,10 @@ func doCityStatusJSON(
 				Scope:         scope,
 				Running:       running,
 				Suspended:     suspended,
+				Quarantined:   isQuarantined(sn, running),
 				Pool:          nil,
+				OpenBeads:     work[a.QualifiedName()].open,
+				ActiveBeads:   work[a.QualifiedName()].inProgress,
 			})
 			totalAgents++
 			if running {
@@ -321,10 +391,14 @@ func doCityStatusJSON(
 		})
 	}
 
-	summary := StatusSummaryJSON{TotalAgents: totalAgents, RunningAgents: runningAgents}
+	summary := StatusSummaryJSON{
+		TotalAgents:       totalAgents,
+		RunningAgents:     runningAgents,
+		QuarantinedAgents: quarantinedAgents,
+	}
 
 	// Chat sessions count (best-effort).
-	if store, err := openCityStoreAt(cityPath); err == nil {
+	if storeErr == nil {
 		mgr := newSessionManagerWithConfig(store, sp, cfg)
 		if sessions, err := mgr.List("", ""); err == nil {
 			for _, s := range sessions {
@@ -347,6 +421,9 @@ func doCityStatusJSON(
 		Rigs:       rigs,
 		Summary:    summary,
 	}
+	for _, c := range recentCrashes(cityPath, statusCrashLimit) {
+		status.Crashes = append(status.Crashes, StatusCrashJSON{Session: c.Session, Time: c.Time, Path: c.Path})
+	}
 
 	data, err := json.MarshalIndent(status, "", "  ")
 	if err != nil {
@@ -356,3 +433,59 @@ func doCityStatusJSON(
 	fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
 	return 0
 }
+
+// statusCrashLimit caps the crash snapshots gc status lists.
+const statusCrashLimit = 5
+
+// recentCrashes returns up to n of the city's crash snapshots, newest
+// first. Errors are swallowed: crashes are decoration on the status view.
+func recentCrashes(cityPath string, n int) []snapshot.Snapshot {
+	snaps, _ := snapshot.List(snapshotDir(cityPath))
+	var out []snapshot.Snapshot
+	for _, s := range snaps {
+		if s.Reason == snapshot.ReasonCrash && len(out) < n {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// assigneeWork counts non-closed beads assigned to one agent.
+type assigneeWork struct {
+	open       int
+	inProgress int
+}
+
+// annotation renders the work counts as a status-line suffix, or "" when
+// the agent has no assigned work.
+func (w assigneeWork) annotation() string {
+	if w.open == 0 && w.inProgress == 0 {
+		return ""
+	}
+	return fmt.Sprintf("  [%d open, %d in progress]", w.open, w.inProgress)
+}
+
+// countAssigneeWork tallies open and in_progress beads per assignee.
+// Errors are swallowed: work counts are decoration on the status view.
+func countAssigneeWork(store beads.Store) map[string]assigneeWork {
+	work := make(map[string]assigneeWork)
+	for _, status := range []string{"open", "in_progress"} {
+		bs, err := store.Query(beads.Filter{Status: status})
+		if err != nil {
+			continue
+		}
+		for _, b := range bs {
+			if b.Assignee == "" {
+				continue
+			}
+			w := work[b.Assignee]
+			if status == "open" {
+				w.open++
+			} else {
+				w.inProgress++
+			}
+			work[b.Assignee] = w
+		}
+	}
+	return work
+}
@@ -6,9 +6,12 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gastownhall/gascity/internal/beads"
 	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/snapshot"
 )
 
 func TestCityStatusEmptyCity(t *testing.T) {
@@ -97,6 +100,32 @@ func TestCityStatusSuspended(t *testing.T) {
 	}
 }
 
+func TestCityStatusQuarantined(t *testing.T) {
+	cityPath := t.TempDir()
+	err := writeCrashQuarantines(crashStatePath(cityPath), crashQuarantines{
+		"mayor": {Since: time.Now(), Until: time.Now().Add(time.Hour), Strikes: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.City{
+		Workspace: config.Workspace{Name: "city"},
+		Agents:    []config.Agent{{Name: "mayor"}, {Name: "deacon"}},
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doCityStatus(runtime.NewFake(), newFakeDrainOps(), cfg, cityPath, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, want 0", code)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "quarantined until") || !strings.Contains(out, "strike 2") {
+		t.Errorf("mayor not shown quarantined:\n%s", out)
+	}
+	if !strings.Contains(out, "1 agent(s) quarantined") {
+		t.Errorf("missing quarantine summary:\n%s", out)
+	}
+}
+
 func TestCityStatusPoolExpansion(t *testing.T) {
 	sp := runtime.NewFake()
 	// Start 2 of 3 pool instances.
@@ -316,3 +345,96 @@ func TestCityStatusAgentSuspendedByRig(t *testing.T) {
 		t.Errorf("stdout missing 'stopped  (suspended)' for rig-suspended agent, got:\n%s", out)
 	}
 }
+
+func TestCityStatusShowsAssignedWork(t *testing.T) {
+	cityPath := t.TempDir()
+	t.Setenv("GC_BEADS", "file")
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worker := "worker"
+	inProgress := "in_progress"
+	for i := 0; i < 2; i++ {
+		b, err := store.Create(beads.Bead{Title: "task"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := beads.UpdateOpts{Assignee: &worker}
+		if i == 1 {
+			opts.Status = &inProgress
+		}
+		if err := store.Update(b.ID, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sp := runtime.NewFake()
+	cfg := &config.City{
+		Workspace: config.Workspace{Name: "city"},
+		Agents:    []config.Agent{{Name: "mayor"}, {Name: "worker"}},
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doCityStatus(sp, newFakeDrainOps(), cfg, cityPath, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "[1 open, 1 in progress]") {
+		t.Errorf("stdout missing work counts for worker:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "mayor") && strings.Contains(line, "open") {
+			t.Errorf("mayor has no assigned work, got line %q", line)
+		}
+	}
+
+	stdout.Reset()
+	if code := doCityStatusJSON(sp, cfg, cityPath, &stdout, &stderr); code != 0 {
+		t.Fatalf("json code = %d", code)
+	}
+	var status StatusJSON
+	if err := json.Unmarshal(stdout.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Agents[1].OpenBeads != 1 || status.Agents[1].ActiveBeads != 1 {
+		t.Errorf("worker JSON counts = %+v, want 1 open / 1 in progress", status.Agents[1])
+	}
+}
+
+func TestCityStatusRecentCrashes(t *testing.T) {
+	cityPath := t.TempDir()
+	d := snapshot.Dir{Path: snapshotDir(cityPath)}
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := d.Save("city--worker", snapshot.ReasonCrash, "panic: boom", t0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Save("city--mayor", snapshot.ReasonStop, "bye", t0); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.City{Workspace: config.Workspace{Name: "city"}}
+
+	var stdout, stderr bytes.Buffer
+	if code := doCityStatus(runtime.NewFake(), newFakeDrainOps(), cfg, cityPath, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "Recent crashes:") || !strings.Contains(out, "city--worker") {
+		t.Errorf("stdout missing crash, got:\n%s", out)
+	}
+	if strings.Contains(out, "city--mayor") {
+		t.Errorf("graceful stop listed as a crash, got:\n%s", out)
+	}
+
+	stdout.Reset()
+	if code := doCityStatusJSON(runtime.NewFake(), cfg, cityPath, &stdout, &stderr); code != 0 {
+		t.Fatalf("json code = %d; stderr: %s", code, stderr.String())
+	}
+	var status StatusJSON
+	if err := json.Unmarshal(stdout.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Crashes) != 1 || status.Crashes[0].Session != "city--worker" || !status.Crashes[0].Time.Equal(t0) {
+		t.Errorf("recent_crashes = %+v", status.Crashes)
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/formula"
+	"github.com/spf13/cobra"
+)
+
+// newCompletionCmd creates the "gc completion <shell>" command. It replaces
+// cobra's default completion command so the generated scripts pick up the
+// dynamic completions registered by registerCompletions.
+func newCompletionCmd(stdout, stderr io.Writer, root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion <bash|zsh|fish>",
+		Short: "Generate a shell completion script",
+		Long: `Generate a shell completion script for gc.
+
+Agent names, rig names, bead IDs, and formula names are completed from
+the city the command would run against (--city, or the city containing
+the current directory), so completions follow config edits without
+regenerating the script.`,
+		Example: `  source <(gc completion bash)
+  gc completion zsh > "${fpath[1]}/_gc"
+  gc completion fish > ~/.config/fish/completions/gc.fish`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		RunE: func(_ *cobra.Command, args []string) error {
+			var err error
+			switch args[0] {
+			case "bash":
+				err = root.GenBashCompletionV2(stdout, true)
+			case "zsh":
+				err = root.GenZshCompletion(stdout)
+			case "fish":
+				err = root.GenFishCompletion(stdout, true)
+			default:
+				err = fmt.Errorf("unsupported shell %q (want bash, zsh, or fish)", args[0])
+			}
+			if err != nil {
+				fmt.Fprintf(stderr, "gc completion: %v\n", err) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// argCompletions maps a command path (without the leading "gc ") to the
+// completer for each positional argument. The last completer of a
+// variadic command repeats; see completeArgs.
+var argCompletions = map[string][]cobra.CompletionFunc{
+	"agent remove":        {completeAgentNames},
+	"agent suspend":       {completeAgentNames},
+	"agent resume":        {completeAgentNames},
+	"agent heartbeat":     {completeAgentNames},
+	"bead show":           {completeBeadIDs},
+	"bead comment":        {completeBeadIDs},
+	"bead assign":         {completeBeadIDs, completeAgentNames},
+	"bead unassign":       {completeBeadIDs},
+	"bead children":       {completeBeadIDs},
+	"bead tree":           {completeBeadIDs},
+	"bead move":           {completeBeadIDs},
+	"converge status":     {completeBeadIDs},
+	"converge approve":    {completeBeadIDs},
+	"converge iterate":    {completeBeadIDs},
+	"converge stop":       {completeBeadIDs},
+	"converge test-gate":  {completeBeadIDs},
+	"converge retry":      {completeBeadIDs},
+	"convoy create":       {cobra.NoFileCompletions, completeBeadIDs},
+	"convoy status":       {completeBeadIDs},
+	"convoy add":          {completeBeadIDs, completeBeadIDs},
+	"convoy close":        {completeBeadIDs},
+	"convoy land":         {completeBeadIDs},
+	"convoy autoclose":    {completeBeadIDs},
+	"formula show":        {completeFormulaNames},
+	"graph":               {completeBeadIDs},
+	"hook":                {completeAgentNames},
+	"logs":                {completeAgentNames},
+	"mail check":          {completeAgentNames},
+	"mail send":           {completeAgentNames},
+	"mail inbox":          {completeAgentNames},
+	"mail count":          {completeAgentNames},
+	"nudge status":        {completeAgentNames},
+	"nudge drain":         {completeAgentNames},
+	"nudge poll":          {completeAgentNames},
+	"pool status":         {completePoolNames},
+	"prime":               {completeAgentNames},
+	"rig suspend":         {completeRigNames},
+	"rig resume":          {completeRigNames},
+	"rig remove":          {completeRigNames},
+	"rig rename":          {completeRigNames},
+	"rig restart":         {completeRigNames},
+	"rig status":          {completeRigNames},
+	"runtime drain":       {completeAgentNames},
+	"runtime undrain":     {completeAgentNames},
+	"runtime drain-check": {completeAgentNames},
+	"runtime drain-ack":   {completeAgentNames},
+	"session new":         {completeAgentNames},
+	"session logs":        {completeAgentNames},
+	"session nudge":       {completeAgentNames},
+	"sling":               {completeAgentNames, completeBeadsAndFormulas},
+	"unsling":             {completeBeadIDs},
+}
+
+// variadicCompletions lists the commands in argCompletions whose last
+// completer applies to every remaining argument.
+var variadicCompletions = map[string]bool{
+	"convoy create": true,
+	"graph":         true,
+}
+
+// flagCompletions maps a command path to completers for its flags.
+var flagCompletions = map[string]map[string]cobra.CompletionFunc{
+	"automation show":    {"rig": completeRigNames},
+	"automation run":     {"rig": completeRigNames},
+	"automation history": {"rig": completeRigNames},
+	"bead list":          {"assignee": completeAgentNames},
+	"bead create":        {"parent": completeBeadIDs},
+	"bead move":          {"parent": completeBeadIDs},
+	"config explain":     {"rig": completeRigNames, "agent": completeAgentNames},
+	"converge create":    {"formula": completeFormulaNames},
+	"formula list":       {"rig": completeRigNames},
+	"formula show":       {"rig": completeRigNames},
+	"formula lint":       {"rig": completeRigNames},
+	"formula validate":   {"rig": completeRigNames},
+	"mail send":          {"to": completeAgentNames},
+	"sling":              {"on": completeFormulaNames},
+	"sling history":      {"agent": completeAgentNames},
+	"start":              {"rig": completeRigNames},
+}
+
+// registerCompletions attaches the dynamic completers in argCompletions
+// and flagCompletions to the commands under root.
+func registerCompletions(root *cobra.Command) {
+	walkCommands(root, func(cmd *cobra.Command) {
+		path := strings.TrimPrefix(cmd.CommandPath(), root.Name()+" ")
+		if fns, ok := argCompletions[path]; ok {
+			cmd.ValidArgsFunction = completeArgs(fns, variadicCompletions[path])
+		}
+		for flag, fn := range flagCompletions[path] {
+			_ = cmd.RegisterFlagCompletionFunc(flag, fn)
+		}
+	})
+}
+
+// walkCommands calls fn for cmd and every command below it.
+func walkCommands(cmd *cobra.Command, fn func(*cobra.Command)) {
+	fn(cmd)
+	for _, c := range cmd.Commands() {
+		walkCommands(c, fn)
+	}
+}
+
+// completeArgs dispatches to the completer for the argument being typed.
+// Past the last completer, a variadic command reuses it; any other
+// command completes nothing.
+func completeArgs(fns []cobra.CompletionFunc, variadic bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		i := len(args)
+		if i >= len(fns) {
+			if !variadic {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			i = len(fns) - 1
+		}
+		return fns[i](cmd, args, toComplete)
+	}
+}
+
+// completionCity loads the config of the city a completing command would
+// run against. Completion must stay silent, so failures return nil.
+func completionCity() (string, *config.City) {
+	cityPath, err := resolveCity()
+	if err != nil {
+		return "", nil
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		return "", nil
+	}
+	return cityPath, cfg
+}
+
+func completeAgentNames(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	_, cfg := completionCity()
+	return agentCompletions(cfg, toComplete, false), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completePoolNames(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	_, cfg := completionCity()
+	return agentCompletions(cfg, toComplete, true), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeRigNames(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	_, cfg := completionCity()
+	return rigCompletions(cfg, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeBeadIDs(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	store, _ := openCityStore(io.Discard, "gc")
+	return beadCompletions(store, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeFormulaNames(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	cityPath, cfg := completionCity()
+	return formulaCompletions(cityPath, cfg, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBeadsAndFormulas completes gc sling's work argument, which is
+// either a bead ID or a formula name.
+func completeBeadsAndFormulas(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	ids, _ := completeBeadIDs(cmd, args, toComplete)
+	names, _ := completeFormulaNames(cmd, args, toComplete)
+	return append(ids, names...), cobra.ShellCompDirectiveNoFileComp
+}
+
+// agentCompletions returns the qualified names of cfg's agents that start
+// with prefix, described by their provider. poolsOnly keeps only pools.
+func agentCompletions(cfg *config.City, prefix string, poolsOnly bool) []cobra.Completion {
+	if cfg == nil {
+		return nil
+	}
+	var out []cobra.Completion
+	for i := range cfg.Agents {
+		a := &cfg.Agents[i]
+		if poolsOnly && !a.IsPool() {
+			continue
+		}
+		name := a.QualifiedName()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		desc := a.Provider
+		if a.IsPool() {
+			desc = strings.TrimSpace("pool " + desc)
+		}
+		out = append(out, cobra.CompletionWithDesc(name, desc))
+	}
+	slices.Sort(out)
+	return out
+}
+
+// rigCompletions returns the names of cfg's rigs that start with prefix,
+// described by their path.
+func rigCompletions(cfg *config.City, prefix string) []cobra.Completion {
+	if cfg == nil {
+		return nil
+	}
+	var out []cobra.Completion
+	for _, r := range cfg.Rigs {
+		if strings.HasPrefix(r.Name, prefix) {
+			out = append(out, cobra.CompletionWithDesc(r.Name, r.Path))
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// beadCompletions returns the IDs of the store's unclosed beads that
+// start with prefix, described by their title.
+func beadCompletions(store beads.Store, prefix string) []cobra.Completion {
+	if store == nil {
+		return nil
+	}
+	all, err := store.List()
+	if err != nil {
+		return nil
+	}
+	var out []cobra.Completion
+	for _, b := range all {
+		if b.Status == "closed" || !strings.HasPrefix(b.ID, prefix) {
+			continue
+		}
+		out = append(out, cobra.CompletionWithDesc(b.ID, b.Title))
+	}
+	return out
+}
+
+// formulaCompletions returns the names of the formulas visible to the
+// city that start with prefix.
+func formulaCompletions(cityPath string, cfg *config.City, prefix string) []cobra.Completion {
+	if cfg == nil {
+		return nil
+	}
+	layers, err := formulaLayersFor(cityPath, cfg, "")
+	if err != nil {
+		return nil
+	}
+	var out []cobra.Completion
+	for _, e := range formula.Scan(layers) {
+		if strings.HasPrefix(e.Name, prefix) {
+			out = append(out, e.Name)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestCompletionTablesNameRealCommands(t *testing.T) {
+	root := newRootCmd(io.Discard, io.Discard)
+	paths := map[string]bool{}
+	walkCommands(root, func(c *cobra.Command) {
+		paths[strings.TrimPrefix(c.CommandPath(), "gc ")] = true
+	})
+	for path := range argCompletions {
+		if !paths[path] {
+			t.Errorf("argCompletions: no command %q", path)
+		}
+	}
+	for path := range variadicCompletions {
+		if _, ok := argCompletions[path]; !ok {
+			t.Errorf("variadicCompletions: %q has no argCompletions entry", path)
+		}
+	}
+	for path, flags := range flagCompletions {
+		if !paths[path] {
+			t.Errorf("flagCompletions: no command %q", path)
+			continue
+		}
+		cmd, _, err := root.Find(strings.Fields(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for flag := range flags {
+			if cmd.Flags().Lookup(flag) == nil {
+				t.Errorf("flagCompletions: %q has no --%s flag", path, flag)
+			}
+		}
+	}
+}
+
+func TestCompleteFromLiveCity(t *testing.T) {
+	dir := t.TempDir()
+	toml := `[workspace]
+name = "town"
+
+[[agent]]
+name = "mayor"
+provider = "claude"
+
+[[agent]]
+name = "polecat"
+dir = "web"
+
+[agent.pool]
+max = 3
+
+[[rigs]]
+name = "web"
+path = "/src/web"
+`
+	if err := os.WriteFile(filepath.Join(dir, "city.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { cityFlag = "" }()
+
+	complete := func(args ...string) string {
+		t.Helper()
+		var stdout bytes.Buffer
+		run(append([]string{"__complete", "--city", dir}, args...), &stdout, io.Discard)
+		return stdout.String()
+	}
+	if got := complete("agent", "suspend", ""); !strings.Contains(got, "mayor\tclaude") || !strings.Contains(got, "web/polecat") {
+		t.Errorf("agent suspend completions = %q, want mayor and web/polecat", got)
+	}
+	if got := complete("agent", "suspend", "web/"); strings.Contains(got, "mayor") {
+		t.Errorf("agent suspend web/ completions = %q, want prefix-filtered", got)
+	}
+	if got := complete("rig", "suspend", ""); !strings.Contains(got, "web\t/src/web") {
+		t.Errorf("rig suspend completions = %q, want web", got)
+	}
+	if got := complete("start", "--rig", ""); !strings.Contains(got, "web") {
+		t.Errorf("start --rig completions = %q, want web", got)
+	}
+	if got := complete("agent", "suspend", "mayor", ""); strings.Contains(got, "mayor") {
+		t.Errorf("second-arg completions = %q, want none", got)
+	}
+}
+
+func TestAgentCompletionsPoolsOnly(t *testing.T) {
+	cfg := &config.City{Agents: []config.Agent{
+		{Name: "mayor"},
+		{Name: "worker", Pool: &config.PoolConfig{Max: 4}},
+	}}
+	got := agentCompletions(cfg, "", true)
+	if len(got) != 1 || !strings.HasPrefix(got[0], "worker\t") {
+		t.Errorf("agentCompletions(poolsOnly) = %q, want only worker", got)
+	}
+}
+
+func TestBeadCompletionsSkipClosed(t *testing.T) {
+	store := beads.NewMemStore()
+	open, _ := store.Create(beads.Bead{Title: "Fix login"})
+	done, _ := store.Create(beads.Bead{Title: "Old"})
+	if err := store.Close(done.ID); err != nil {
+		t.Fatal(err)
+	}
+	got := beadCompletions(store, "")
+	if len(got) != 1 || got[0] != open.ID+"\tFix login" {
+		t.Errorf("beadCompletions = %q, want only %s", got, open.ID)
+	}
+	if got := beadCompletions(nil, ""); got != nil {
+		t.Errorf("beadCompletions(nil) = %q, want nil", got)
+	}
+}
@@ -15,12 +15,15 @@ import (
 func newConfigCmd(stdout, stderr io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
-		Short: "Inspect and validate city configuration",
+		Short: "Inspect, validate, and edit city configuration",
 		Long: `Inspect, validate, and debug the resolved city configuration.
 
 The config system supports multi-file composition with includes,
 packs, patches, and overrides. Use "show" to dump the resolved
-config and "explain" to see where each value originated.`,
+config, "explain" to see where each value originated, and "validate"
+to check everything the city will need at runtime. "get" and "set"
+read and edit single values in city.toml without disturbing its
+formatting.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
@@ -28,6 +31,9 @@ config and "explain" to see where each value originated.`,
 	}
 	cmd.AddCommand(newConfigShowCmd(stdout, stderr))
 	cmd.AddCommand(newConfigExplainCmd(stdout, stderr))
+	cmd.AddCommand(newConfigValidateCmd(stdout, stderr))
+	cmd.AddCommand(newConfigGetCmd(stdout, stderr))
+	cmd.AddCommand(newConfigSetCmd(stdout, stderr))
 	return cmd
 }
 
@@ -273,6 +279,12 @@ func explainAgent(w io.Writer, a *config.Agent, prov *config.Provenance) {
 			explainField(w, "env."+k, v, source)
 		}
 	}
+	if a.EnvFile != "" {
+		explainField(w, "env_file", a.EnvFile, source)
+	}
+	if len(a.EnvFromKeychain) > 0 {
+		explainField(w, "env_from_keychain", strings.Join(a.EnvFromKeychain, ", "), source)
+	}
 
 	// Pool.
 	if a.Pool != nil {
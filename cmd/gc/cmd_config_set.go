@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+func newConfigGetCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print one value from city.toml",
+		Long: `Print the value of one key in city.toml.
+
+Keys are dotted paths through the config schema. Entries in arrays of
+tables are picked by name, so agents.mayor.idle_timeout is the
+idle_timeout of the [[agent]] named mayor; use dir/name for rig agents.
+Strings print bare and other values print as TOML.
+
+Only city.toml itself is read, not includes, packs, or patches. Use
+"gc config explain" for the resolved configuration. Exits 1 when the
+key is not set.`,
+		Example: `  gc config get workspace.name
+  gc config get agents.mayor.idle_timeout
+  gc config get agents.myrig/polecat.pool.max`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdConfigGet(args[0], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change one value in city.toml, keeping its formatting",
+		Long: `Set one key in city.toml, editing the file in place.
+
+Only the key's value changes; comments, ordering, and formatting are
+kept. A key that is not set yet is added to its table, and a missing
+table is appended to the file. Keys use the same paths as
+"gc config get".
+
+The value is converted to the key's type: strings need no quotes,
+numbers and booleans are checked, and lists take a TOML array such as
+'["a", "b"]'. Durations must parse. Values set inline (e.g.
+pool = { max = 3 }) must be edited by hand.`,
+		Example: `  gc config set daemon.patrol_interval 15s
+  gc config set agents.mayor.idle_timeout 30m
+  gc config set workspace.name metro`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdConfigSet(args[0], args[1], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// cmdConfigGet is the CLI entry point for gc config get.
+func cmdConfigGet(key string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc config get: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doConfigGet(fsys.OSFS{}, cityPath, key, stdout, stderr)
+}
+
+// doConfigGet prints the value of key in the city's city.toml.
+func doConfigGet(fs fsys.FS, cityPath, key string, stdout, stderr io.Writer) int {
+	data, err := fs.ReadFile(filepath.Join(cityPath, "city.toml"))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc config get: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	value, found, err := config.GetValue(data, key)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc config get: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if !found {
+		fmt.Fprintf(stderr, "gc config get: %s is not set in city.toml\n", key) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintln(stdout, value) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// cmdConfigSet is the CLI entry point for gc config set.
+func cmdConfigSet(key, value string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc config set: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doConfigSet(fsys.OSFS{}, cityPath, key, value, stdout, stderr)
+}
+
+// doConfigSet sets key to value in the city's city.toml, refusing edits
+// that introduce an invalid duration.
+func doConfigSet(fs fsys.FS, cityPath, key, value string, stdout, stderr io.Writer) int {
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	data, err := fs.ReadFile(tomlPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc config set: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	out, err := config.SetValue(data, key, value)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc config set: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var before []string
+	if old, err := config.Parse(data); err == nil {
+		before = config.ValidateDurations(old, "city.toml")
+	}
+	edited, err := config.Parse(out)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc config set: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	for _, w := range config.ValidateDurations(edited, "city.toml") {
+		if !slices.Contains(before, w) {
+			fmt.Fprintf(stderr, "gc config set: %s\n", w) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	if err := fs.WriteFile(tomlPath, out, 0o644); err != nil {
+		fmt.Fprintf(stderr, "gc config set: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "Set %s = %s\n", key, value) //nolint:errcheck // best-effort stdout
+	return 0
+}
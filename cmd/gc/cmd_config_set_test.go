@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestDoConfigSetAndGet(t *testing.T) {
+	fs := fsys.NewFake()
+	orig := "# my city\n[workspace]\nname = \"metro\"\n\n[daemon]\npatrol_interval = \"30s\" # tuned\n\n[[agent]]\nname = \"mayor\"\n"
+	fs.Files["/city/city.toml"] = []byte(orig)
+
+	var stdout, stderr bytes.Buffer
+	if code := doConfigSet(fs, "/city", "daemon.patrol_interval", "15s", &stdout, &stderr); code != 0 {
+		t.Fatalf("doConfigSet = %d; stderr: %s", code, stderr.String())
+	}
+	if code := doConfigSet(fs, "/city", "agents.mayor.idle_timeout", "30m", &stdout, &stderr); code != 0 {
+		t.Fatalf("doConfigSet = %d; stderr: %s", code, stderr.String())
+	}
+	want := "# my city\n[workspace]\nname = \"metro\"\n\n[daemon]\npatrol_interval = \"15s\" # tuned\n\n[[agent]]\nname = \"mayor\"\nidle_timeout = \"30m\"\n"
+	if got := string(fs.Files["/city/city.toml"]); got != want {
+		t.Errorf("city.toml =\n%s\nwant\n%s", got, want)
+	}
+
+	stdout.Reset()
+	if code := doConfigGet(fs, "/city", "agents.mayor.idle_timeout", &stdout, &stderr); code != 0 {
+		t.Fatalf("doConfigGet = %d; stderr: %s", code, stderr.String())
+	}
+	if stdout.String() != "30m\n" {
+		t.Errorf("doConfigGet stdout = %q, want %q", stdout.String(), "30m\n")
+	}
+
+	stderr.Reset()
+	if code := doConfigGet(fs, "/city", "workspace.provider", &stdout, &stderr); code != 1 {
+		t.Errorf("doConfigGet(unset) = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "not set") {
+		t.Errorf("stderr = %q, want not set", stderr.String())
+	}
+}
+
+func TestDoConfigSetRejectsBadDuration(t *testing.T) {
+	fs := fsys.NewFake()
+	orig := "[daemon]\npatrol_interval = \"30s\"\n"
+	fs.Files["/city/city.toml"] = []byte(orig)
+
+	var stdout, stderr bytes.Buffer
+	if code := doConfigSet(fs, "/city", "daemon.patrol_interval", "15 secs", &stdout, &stderr); code != 1 {
+		t.Fatalf("doConfigSet = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "not a valid duration") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	if got := string(fs.Files["/city/city.toml"]); got != orig {
+		t.Errorf("city.toml written despite error:\n%s", got)
+	}
+}
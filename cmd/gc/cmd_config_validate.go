@@ -0,0 +1,273 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/workspacesvc"
+	"github.com/spf13/cobra"
+)
+
+func newConfigValidateCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check city.toml for every problem the city would hit at runtime",
+		Long: `Check the resolved city configuration and report every problem at once.
+
+Runs agent and rig validation, pack expansion, provider resolution
+(including whether the provider binary is on PATH), and checks that
+every prompt_template exists and parses. Each problem is reported with
+the file and line it comes from. Composition and semantic warnings are
+printed but do not fail validation.
+
+Exits 0 when the config is valid and 1 when any problem was found.`,
+		Example: `  gc config validate
+  gc config validate -f overlay.toml`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if doConfigValidate(stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVarP(&extraConfigFiles, "file", "f", nil,
+		"additional config files to layer (can be repeated)")
+	return cmd
+}
+
+// doConfigValidate is the CLI entry point for gc config validate.
+func doConfigValidate(stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc config validate: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if quickCfg, qErr := config.Load(fsys.OSFS{}, filepath.Join(cityPath, "city.toml")); qErr == nil && len(quickCfg.Packs) > 0 {
+		if fErr := config.FetchPacks(quickCfg.Packs, cityPath); fErr != nil {
+			fmt.Fprintf(stderr, "gc config validate: fetching packs: %v\n", fErr) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	problems, warnings := validateCityConfig(fsys.OSFS{}, cityPath, exec.LookPath, extraConfigFiles...)
+	return reportConfigProblems(cityPath, problems, warnings, stdout, stderr)
+}
+
+// configProblem is one validation failure, located in a config file when
+// the offending element can be found there.
+type configProblem struct {
+	File string // absolute path; empty when unknown
+	Line int    // 1-based; 0 when unknown
+	Text string // the source line at Line
+	Msg  string
+}
+
+// validateCityConfig loads the city config and collects every problem
+// instead of stopping at the first. warnings are non-fatal.
+func validateCityConfig(fs fsys.FS, cityPath string, lookPath config.LookPathFunc, extra ...string) (problems []configProblem, warnings []string) {
+	root := filepath.Join(cityPath, "city.toml")
+	cfg, prov, err := config.LoadWithIncludes(fs, root, extra...)
+	if err != nil {
+		msg := err.Error()
+		var pe toml.ParseError
+		if errors.As(err, &pe) {
+			msg = strings.TrimRight(pe.ErrorWithPosition(), "\n")
+		}
+		return []configProblem{{File: root, Msg: msg}}, nil
+	}
+	warnings = prov.Warnings
+
+	loc := &configLocator{fs: fs, prov: prov, cfg: cfg, lines: map[string][]string{}}
+	add := func(err error) {
+		if err != nil {
+			problems = append(problems, loc.locate(err.Error()))
+		}
+	}
+
+	// ValidateAgents and ValidateRigs stop at the first error, so check
+	// each element alone first, then the whole list for cross-element
+	// rules (duplicates, depends_on, prefixes) once the elements pass.
+	agentsOK := true
+	for _, a := range cfg.Agents {
+		a.DependsOn = nil // cross-agent; checked with the full list
+		if err := config.ValidateAgents([]config.Agent{a}); err != nil {
+			add(err)
+			agentsOK = false
+		}
+	}
+	if agentsOK {
+		add(config.ValidateAgents(cfg.Agents))
+	}
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	rigsOK := true
+	for _, r := range cfg.Rigs {
+		if err := config.ValidateRigs([]config.Rig{r}, cityName); err != nil {
+			add(err)
+			rigsOK = false
+		}
+	}
+	if rigsOK {
+		add(config.ValidateRigs(cfg.Rigs, cityName))
+	}
+	add(config.ValidateRoles(cfg.Roles))
+	add(config.ValidateRoutes(cfg.Routes))
+	add(config.ValidateSchedules(cfg.Schedules))
+	add(config.ValidateLifecycleHooks(cfg.Hooks))
+	add(config.ValidateRateLimits(cfg))
+	providerNames := make([]string, 0, len(cfg.Providers))
+	for name := range cfg.Providers {
+		providerNames = append(providerNames, name)
+	}
+	sort.Strings(providerNames)
+	for _, name := range providerNames {
+		if _, err := runtime.ParseReadyProbe(cfg.Providers[name].ReadyProbe); err != nil {
+			add(fmt.Errorf("provider %q: %w", name, err))
+		}
+	}
+	if err := config.ValidateServices(cfg.Services); err != nil {
+		add(err)
+	} else {
+		add(workspacesvc.ValidateRuntimeSupport(cfg.Services))
+	}
+
+	for i := range cfg.Agents {
+		a := &cfg.Agents[i]
+		if a.Implicit {
+			continue // on-demand provider agents; their prompts ship with gc
+		}
+		if _, err := config.ResolveProvider(a, &cfg.Workspace, cfg.Providers, lookPath); err != nil {
+			add(fmt.Errorf("agent %q: %w", a.QualifiedName(), err))
+		}
+		if _, err := runtime.ParseReadyProbe(a.ReadyProbe); err != nil {
+			add(fmt.Errorf("agent %q: %w", a.QualifiedName(), err))
+		}
+		if a.PromptTemplate == "" {
+			continue
+		}
+		data, _, err := readPromptTemplate(fs, cityPath, a.PromptTemplate)
+		if err != nil {
+			add(fmt.Errorf("agent %q: prompt_template %q not found", a.QualifiedName(), a.PromptTemplate))
+			continue
+		}
+		tmpl := template.New("prompt").Funcs(promptFuncMap(cityName, cfg.Workspace.SessionTemplate, nil))
+		if _, err := tmpl.Parse(string(data)); err != nil {
+			add(fmt.Errorf("agent %q: prompt_template %q: %w", a.QualifiedName(), a.PromptTemplate, err))
+		}
+	}
+	return problems, warnings
+}
+
+// configSubjectRe extracts the element a validation message is about.
+var configSubjectRe = regexp.MustCompile(`^(agent|rig) "([^"]+)"`)
+
+// configLocator maps validation messages back to the file and line that
+// defined the offending agent or rig.
+type configLocator struct {
+	fs    fsys.FS
+	prov  *config.Provenance
+	cfg   *config.City
+	lines map[string][]string
+}
+
+func (l *configLocator) locate(msg string) configProblem {
+	p := configProblem{File: l.prov.Root, Msg: msg}
+	m := configSubjectRe.FindStringSubmatch(msg)
+	if m == nil {
+		return p
+	}
+	kind, name := m[1], m[2]
+	switch kind {
+	case "agent":
+		if src, ok := l.prov.Agents[name]; ok {
+			p.File = src
+		} else {
+			for _, a := range l.cfg.Agents {
+				if a.QualifiedName() == name && a.SourceDir != "" {
+					p.File = filepath.Join(a.SourceDir, "pack.toml")
+				}
+			}
+		}
+		name = name[strings.LastIndex(name, "/")+1:]
+	case "rig":
+		if src, ok := l.prov.Rigs[name]; ok {
+			p.File = src
+		}
+	}
+	p.Line, p.Text = l.findName(p.File, kind, name)
+	return p
+}
+
+// findName returns the `name = "<name>"` line inside a [[kind]] table.
+func (l *configLocator) findName(file, kind, name string) (int, string) {
+	lines, ok := l.lines[file]
+	if !ok {
+		data, err := l.fs.ReadFile(file)
+		if err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		l.lines[file] = lines
+	}
+	nameRe := regexp.MustCompile(`^\s*name\s*=\s*["']` + regexp.QuoteMeta(name) + `["']`)
+	table := ""
+	for i, line := range lines {
+		if t := strings.TrimSpace(line); strings.HasPrefix(t, "[") {
+			table = strings.Trim(t, "[] ")
+			continue
+		}
+		if table == kind && nameRe.MatchString(line) {
+			return i + 1, strings.TrimSpace(line)
+		}
+	}
+	return 0, ""
+}
+
+// reportConfigProblems prints warnings and problems (sorted by file and
+// line) and returns the exit code.
+func reportConfigProblems(cityPath string, problems []configProblem, warnings []string, stdout, stderr io.Writer) int {
+	for _, w := range warnings {
+		fmt.Fprintf(stderr, "gc config validate: warning: %s\n", w) //nolint:errcheck // best-effort stderr
+	}
+	if len(problems) == 0 {
+		fmt.Fprintln(stdout, "Config valid.") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+	for _, p := range problems {
+		where := p.File
+		if rel, err := filepath.Rel(cityPath, p.File); err == nil && !strings.HasPrefix(rel, "..") {
+			where = rel
+		}
+		if p.Line > 0 {
+			where = fmt.Sprintf("%s:%d", where, p.Line)
+		}
+		fmt.Fprintf(stdout, "%s: %s\n", where, p.Msg) //nolint:errcheck // best-effort stdout
+		if p.Text != "" {
+			fmt.Fprintf(stdout, "  %4d | %s\n", p.Line, p.Text) //nolint:errcheck // best-effort stdout
+		}
+	}
+	noun := "problems"
+	if len(problems) == 1 {
+		noun = "problem"
+	}
+	fmt.Fprintf(stdout, "%d %s found.\n", len(problems), noun) //nolint:errcheck // best-effort stdout
+	return 1
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func writeValidateCity(t *testing.T, toml string, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files["city.toml"] = toml
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func lookPathAll(name string) (string, error) { return "/usr/bin/" + name, nil }
+
+func TestValidateCityConfigReportsEveryProblem(t *testing.T) {
+	dir := writeValidateCity(t, `[workspace]
+name = "metro"
+provider = "claude"
+
+[[agent]]
+name = "mayor"
+prompt_template = "prompts/mayor.md"
+
+[[agent]]
+name = "ghost"
+provider = "nope"
+
+[[agent]]
+name = "scribe"
+prompt_template = "prompts/missing.md"
+
+[[agent]]
+name = "broken"
+prompt_template = "prompts/broken.md"
+
+[[agent]]
+name = "pooler"
+
+[agent.pool]
+min = 3
+max = 1
+`, map[string]string{
+		"prompts/mayor.md":  "You are {{ .AgentName }}.",
+		"prompts/broken.md": "{{ .AgentName ",
+	})
+
+	problems, _ := validateCityConfig(fsys.OSFS{}, dir, lookPathAll)
+	var stdout, stderr bytes.Buffer
+	if code := reportConfigProblems(dir, problems, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("code = %d, want 1", code)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		`city.toml:10: agent "ghost": `,
+		`  10 | name = "ghost"`,
+		`city.toml:14: agent "scribe": prompt_template "prompts/missing.md" not found`,
+		`city.toml:18: agent "broken": prompt_template "prompts/broken.md"`,
+		`city.toml:22: agent "pooler": pool min (3) must be <= max (1)`,
+		"4 problems found.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `"mayor"`) {
+		t.Errorf("valid agent reported:\n%s", out)
+	}
+}
+
+func TestValidateCityConfigValid(t *testing.T) {
+	dir := writeValidateCity(t, "[workspace]\nname = \"metro\"\nprovider = \"claude\"\n\n[[agent]]\nname = \"mayor\"\n", map[string]string{})
+	problems, _ := validateCityConfig(fsys.OSFS{}, dir, lookPathAll)
+	var stdout, stderr bytes.Buffer
+	if code := reportConfigProblems(dir, problems, nil, &stdout, &stderr); code != 0 || stdout.String() != "Config valid.\n" {
+		t.Errorf("code = %d, stdout = %q", code, stdout.String())
+	}
+}
+
+func TestValidateCityConfigParseError(t *testing.T) {
+	dir := writeValidateCity(t, "[workspace]\nname = \"metro\n", map[string]string{})
+	problems, _ := validateCityConfig(fsys.OSFS{}, dir, lookPathAll)
+	if len(problems) != 1 || !strings.Contains(problems[0].Msg, "line 2") {
+		t.Errorf("problems = %+v, want one parse error with line context", problems)
+	}
+}
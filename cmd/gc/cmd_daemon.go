@@ -15,11 +15,12 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/gastownhall/gascity/internal/fsys"
 	"github.com/spf13/cobra"
 )
 
 // newDaemonCmd creates the "gc daemon" command group with run, start, stop,
-// status, logs, install, and uninstall subcommands.
+// restart, status, logs, install, and uninstall subcommands.
 func newDaemonCmd(stdout, stderr io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "daemon",
@@ -38,6 +39,7 @@ service via launchd (macOS) or systemd (Linux).`,
 		newDaemonRunCmd(stdout, stderr),
 		newDaemonStartCmd(stdout, stderr),
 		newDaemonStopCmd(stdout, stderr),
+		newDaemonRestartCmd(stdout, stderr),
 		newDaemonStatusCmd(stdout, stderr),
 		newDaemonLogsCmd(stdout, stderr),
 		newDaemonInstallCmd(stdout, stderr),
@@ -55,8 +57,14 @@ func newDaemonRunCmd(stdout, stderr io.Writer) *cobra.Command {
 		Long: `Run the controller in the foreground with log file output.
 
 Starts the persistent reconciliation loop, writing output to both
-stdout and .gc/daemon.log. This is the command that "gc daemon start"
-forks in the background.`,
+stdout and .gc/daemon.log, and records its PID in .gc/daemon.pid
+for the life of the process. This is the command that "gc daemon
+start" forks in the background and that installed services run.
+
+Warnings and errors, along with everything written to stdout, are
+also recorded as structured records in .gc/logs/gc.log, rotated at
+10 MiB with five backups kept. Use --log-level and --log-format (or
+GC_LOG_LEVEL and GC_LOG_FORMAT) to control them.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			if doDaemonRun(args, stdout, stderr) != 0 {
@@ -73,7 +81,8 @@ forks in the background.`,
 }
 
 // doDaemonRun runs the controller in the foreground, tee-ing output to both
-// stdout and .gc/daemon.log. Delegates to doStart with controllerMode=true.
+// stdout and .gc/daemon.log and holding .gc/daemon.pid. Delegates to
+// doStart with controllerMode=true.
 func doDaemonRun(args []string, stdout, stderr io.Writer) int {
 	dir, err := resolveDaemonDir(args)
 	if err != nil {
@@ -96,6 +105,13 @@ func doDaemonRun(args []string, stdout, stderr io.Writer) int {
 	}
 	defer logFile.Close() //nolint:errcheck // best-effort cleanup
 
+	pid := os.Getpid()
+	if err := writeDaemonPID(dir, pid); err != nil {
+		fmt.Fprintf(stderr, "gc daemon run: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	defer removeDaemonPID(dir, pid)
+
 	logWriter := io.MultiWriter(stdout, logFile)
 	return doStart(args, true, logWriter, stderr)
 }
@@ -144,7 +160,8 @@ func doDaemonStart(args []string, stdout, stderr io.Writer) int {
 		return 1
 	}
 
-	cmdArgs := []string{"--city", dir, "daemon", "run"}
+	cmdArgs := append([]string{"--city", dir}, logFlagArgs()...)
+	cmdArgs = append(cmdArgs, "daemon", "run")
 	child := exec.Command(gcPath, cmdArgs...)
 	child.SysProcAttr = daemonSysProcAttr()
 	// Detach from parent stdio.
@@ -195,7 +212,9 @@ func newDaemonStopCmd(stdout, stderr io.Writer) *cobra.Command {
 		Long: `Signal the running daemon to shut down gracefully.
 
 Connects to the controller's unix socket and sends a stop command.
-The daemon performs graceful agent shutdown before exiting.`,
+The daemon performs graceful agent shutdown before exiting. If the
+socket does not answer but the process in .gc/daemon.pid is alive,
+that process is sent an interrupt instead.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			if doDaemonStop(args, stdout, stderr) != 0 {
@@ -218,11 +237,74 @@ func doDaemonStop(args []string, stdout, stderr io.Writer) int {
 		fmt.Fprintf(stderr, "gc daemon stop: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
 	}
-	if !tryStopController(cityPath, stdout) {
-		fmt.Fprintf(stderr, "gc daemon stop: no controller is running\n") //nolint:errcheck // best-effort stderr
+	if tryStopController(cityPath, stdout) {
+		return 0
+	}
+	if pid := readDaemonPID(cityPath); pid != 0 && daemonProcessAlive(pid) {
+		proc, err := os.FindProcess(pid)
+		if err == nil {
+			err = proc.Signal(os.Interrupt)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "gc daemon stop: signaling PID %d: %v\n", pid, err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintf(stdout, "Controller socket not responding; sent interrupt to PID %d\n", pid) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	fmt.Fprintf(stderr, "gc daemon stop: no controller is running\n") //nolint:errcheck // best-effort stderr
+	return 1
+}
+
+// daemonStopWait bounds how long gc daemon restart waits for the old
+// daemon to release the controller lock.
+var daemonStopWait = 60 * time.Second
+
+// newDaemonRestartCmd creates the "gc daemon restart" subcommand.
+func newDaemonRestartCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restart [path]",
+		Short: "Stop the running daemon and start a new one",
+		Long: `Stop the running daemon (if any), wait for it to release the
+controller lock, then start a new daemon in the background.
+
+Use this after upgrading gc so the daemon runs the new binary.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if doDaemonRestart(args, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// doDaemonRestart stops a running daemon and starts a fresh one.
+func doDaemonRestart(args []string, stdout, stderr io.Writer) int {
+	dir, err := resolveDaemonDir(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc daemon restart: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
 	}
-	return 0
+	if controllerAlive(dir) != 0 || daemonProcessAlive(readDaemonPID(dir)) {
+		if doDaemonStop(args, stdout, stderr) != 0 {
+			return 1
+		}
+		deadline := time.Now().Add(daemonStopWait)
+		for {
+			lock, err := acquireControllerLock(dir)
+			if err == nil {
+				lock.Close() //nolint:errcheck // releasing probe lock
+				break
+			}
+			if time.Now().After(deadline) {
+				fmt.Fprintf(stderr, "gc daemon restart: old daemon still running after %s\n", daemonStopWait) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			time.Sleep(250 * time.Millisecond)
+		}
+	}
+	return doDaemonStart(args, stdout, stderr)
 }
 
 // newDaemonStatusCmd creates the "gc daemon status" subcommand.
@@ -232,8 +314,10 @@ func newDaemonStatusCmd(stdout, stderr io.Writer) *cobra.Command {
 		Short: "Show daemon status (PID, uptime)",
 		Long: `Show whether the daemon is running, its PID, and uptime.
 
-Reads the PID file and verifies the process is alive. Derives uptime
-from the most recent controller.started event in the event log.`,
+Pings the controller socket for its PID, falling back to the PID file
+(.gc/daemon.pid) to report a daemon that is alive but not answering.
+Derives uptime from the most recent controller.started event in the
+event log.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			if doDaemonStatus(args, stdout, stderr) != 0 {
@@ -259,7 +343,14 @@ func doDaemonStatus(args []string, stdout, stderr io.Writer) int {
 
 	pid := controllerAlive(cityPath)
 	if pid == 0 {
-		fmt.Fprintln(stdout, "Daemon is not running") //nolint:errcheck // best-effort stdout
+		switch filePID := readDaemonPID(cityPath); {
+		case filePID != 0 && daemonProcessAlive(filePID):
+			fmt.Fprintf(stdout, "Daemon process %d is alive but its controller socket is not responding\n", filePID) //nolint:errcheck // best-effort stdout
+		case filePID != 0:
+			fmt.Fprintf(stdout, "Daemon is not running (stale PID file for %d)\n", filePID) //nolint:errcheck // best-effort stdout
+		default:
+			fmt.Fprintln(stdout, "Daemon is not running") //nolint:errcheck // best-effort stdout
+		}
 		return 1
 	}
 
@@ -432,6 +523,42 @@ func doDaemonUninstall(args []string, stdout, stderr io.Writer) int {
 
 // --- Helpers ---
 
+// daemonPIDPath returns the PID file gc daemon run holds while it runs.
+func daemonPIDPath(cityPath string) string {
+	return filepath.Join(cityPath, ".gc", "daemon.pid")
+}
+
+// writeDaemonPID records pid in the city's daemon PID file.
+func writeDaemonPID(cityPath string, pid int) error {
+	data := []byte(strconv.Itoa(pid) + "\n")
+	if err := fsys.WriteFileAtomic(fsys.OSFS{}, daemonPIDPath(cityPath), data, 0o644); err != nil {
+		return fmt.Errorf("writing daemon PID file: %w", err)
+	}
+	return nil
+}
+
+// readDaemonPID returns the PID in the daemon PID file, or 0 if the file
+// is missing or malformed.
+func readDaemonPID(cityPath string) int {
+	data, err := os.ReadFile(daemonPIDPath(cityPath))
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0
+	}
+	return pid
+}
+
+// removeDaemonPID deletes the PID file if it still names pid, so an
+// exiting daemon never removes a successor's file.
+func removeDaemonPID(cityPath string, pid int) {
+	if readDaemonPID(cityPath) == pid {
+		os.Remove(daemonPIDPath(cityPath)) //nolint:errcheck // best-effort cleanup
+	}
+}
+
 // resolveDaemonDir resolves the city directory from args or flags.
 func resolveDaemonDir(args []string) (string, error) {
 	switch {
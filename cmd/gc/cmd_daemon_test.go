@@ -357,6 +357,10 @@ func TestDaemonRunCreatesLogFile(t *testing.T) {
 	if _, err := os.Stat(logPath); os.IsNotExist(err) {
 		t.Error("daemon.log should have been created")
 	}
+	// The PID file lives only as long as the run.
+	if _, err := os.Stat(daemonPIDPath(dir)); !os.IsNotExist(err) {
+		t.Errorf("daemon.pid left behind after run exited: %v", err)
+	}
 }
 
 func TestControllerSocketPing(t *testing.T) {
@@ -449,3 +453,77 @@ func TestStartForegroundFlag(t *testing.T) {
 		t.Error("--controller flag should be hidden")
 	}
 }
+
+func TestDaemonPIDFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".gc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := readDaemonPID(dir); got != 0 {
+		t.Errorf("readDaemonPID (missing) = %d, want 0", got)
+	}
+	if err := writeDaemonPID(dir, 4242); err != nil {
+		t.Fatal(err)
+	}
+	if got := readDaemonPID(dir); got != 4242 {
+		t.Errorf("readDaemonPID = %d, want 4242", got)
+	}
+
+	// A different PID (a successor daemon) leaves the file alone.
+	removeDaemonPID(dir, 1)
+	if got := readDaemonPID(dir); got != 4242 {
+		t.Errorf("removeDaemonPID removed another daemon's file")
+	}
+	removeDaemonPID(dir, 4242)
+	if _, err := os.Stat(daemonPIDPath(dir)); !os.IsNotExist(err) {
+		t.Errorf("PID file still present: %v", err)
+	}
+}
+
+func TestDoDaemonStatusPIDFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".gc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Live process, no socket: alive but unresponsive.
+	if err := writeDaemonPID(dir, os.Getpid()); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doDaemonStatus([]string{dir}, &stdout, &stderr); code != 1 {
+		t.Errorf("code = %d, want 1", code)
+	}
+	if !strings.Contains(stdout.String(), "not responding") {
+		t.Errorf("stdout = %q, want not responding", stdout.String())
+	}
+
+	// Dead process: stale PID file.
+	if err := writeDaemonPID(dir, 1<<30); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if code := doDaemonStatus([]string{dir}, &stdout, &stderr); code != 1 {
+		t.Errorf("code = %d, want 1", code)
+	}
+	if !strings.Contains(stdout.String(), "not running (stale PID file") {
+		t.Errorf("stdout = %q, want stale PID file", stdout.String())
+	}
+}
+
+func TestDoDaemonStopStalePIDFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".gc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeDaemonPID(dir, 1<<30); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doDaemonStop([]string{dir}, &stdout, &stderr); code != 1 {
+		t.Errorf("code = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "no controller") {
+		t.Errorf("stderr = %q, want no controller", stderr.String())
+	}
+}
@@ -2,10 +2,22 @@
 
 package main
 
-import "syscall"
+import (
+	"errors"
+	"syscall"
+)
 
 // daemonSysProcAttr returns SysProcAttr for detaching the child from the
 // parent's process group (Setpgid), so the daemon survives parent exit.
 func daemonSysProcAttr() *syscall.SysProcAttr {
 	return &syscall.SysProcAttr{Setpgid: true}
 }
+
+// daemonProcessAlive reports whether a process with the given PID exists.
+func daemonProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
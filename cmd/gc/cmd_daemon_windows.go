@@ -2,9 +2,26 @@
 
 package main
 
-import "syscall"
+import (
+	"os"
+	"syscall"
+)
 
 // daemonSysProcAttr returns nil on Windows (no process group detachment).
 func daemonSysProcAttr() *syscall.SysProcAttr {
 	return nil
 }
+
+// daemonProcessAlive reports whether a process with the given PID exists.
+// On Windows, FindProcess fails for PIDs with no running process.
+func daemonProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	p.Release() //nolint:errcheck // best-effort handle cleanup
+	return true
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newDepsCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deps",
+		Short: "Inspect bead dependencies across rigs",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc deps: missing subcommand (graph)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc deps: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(newDepsGraphCmd(stdout, stderr))
+	return cmd
+}
+
+func newDepsGraphCmd(stdout, stderr io.Writer) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "graph [bead-id...]",
+		Short: "Render the bead dependency graph as Graphviz or Mermaid",
+		Long: `Render bead dependencies and parent/child links across the city and
+all of its rigs as a Graphviz (dot) or Mermaid diagram.
+
+With bead IDs, the graph holds everything connected to them: what they
+depend on, what depends on them, their parents, and their children,
+followed transitively and across rigs. Without IDs, it starts from
+every open and in-progress bead in the city.
+
+Beads are grouped by the rig that owns them and outlined in a color per
+rig prefix. Fill shows status: white open, blue in progress, red
+blocked by an unclosed bead, green closed, and grey for an ID no store
+knows. Blocking dependencies are solid arrows from blocker to blocked
+bead; other dependency types are dashed and labeled; parent/child links
+are dotted lines.
+
+Unlike "gc graph", which tabulates readiness within one store, this
+command is meant for finding where work crossing rigs is stuck.`,
+		Example: `  gc deps graph gc-42 | dot -Tsvg > convoy.svg
+  gc deps graph gc-42 --format mermaid
+  gc deps graph`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdDepsGraph(args, format, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "dot", "output format: dot or mermaid")
+	return cmd
+}
+
+// cmdDepsGraph is the CLI entry point for gc deps graph.
+func cmdDepsGraph(args []string, format string, stdout, stderr io.Writer) int {
+	if format != "dot" && format != "mermaid" {
+		fmt.Fprintf(stderr, "gc deps graph: unknown --format %q (want dot or mermaid)\n", format) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc deps graph: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc deps graph: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	resolveRigPaths(cityPath, cfg.Rigs)
+
+	stores := make(map[string]beads.Store)
+	storeAt := func(rigDir string) (beads.Store, error) {
+		if s, ok := stores[rigDir]; ok {
+			return s, nil
+		}
+		s, err := openRigStoreAt(cityPath, rigDir)
+		if err != nil {
+			return nil, err
+		}
+		stores[rigDir] = s
+		return s, nil
+	}
+	storeFor := func(id string) (beads.Store, error) { return storeAt(rigDirForBead(cfg, id)) }
+
+	var all []beads.Store
+	if len(args) == 0 {
+		dirs := []string{""}
+		if rawBeadsProvider(cityPath) == "bd" {
+			for _, r := range cfg.Rigs {
+				dirs = append(dirs, r.Path)
+			}
+		}
+		for _, d := range dirs {
+			s, err := storeAt(d)
+			if err != nil {
+				fmt.Fprintf(stderr, "gc deps graph: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			all = append(all, s)
+		}
+	}
+	return doDepsGraph(storeFor, all, depGraphRigLabeler(cfg, cityPath), args, format, stdout, stderr)
+}
+
+// doDepsGraph builds the graph around ids (or around every unclosed bead
+// in stores when ids is empty) and prints it in format.
+func doDepsGraph(storeFor func(id string) (beads.Store, error), stores []beads.Store, rigOf func(id string) string,
+	ids []string, format string, stdout, stderr io.Writer,
+) int {
+	var seeds []string
+	if len(ids) > 0 {
+		for _, id := range ids {
+			store, err := storeFor(id)
+			if err == nil {
+				_, err = store.Get(id)
+			}
+			if err != nil {
+				fmt.Fprintf(stderr, "gc deps graph: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+		}
+		seeds = ids
+	} else {
+		for _, s := range stores {
+			for _, status := range []string{"open", "in_progress"} {
+				bs, err := s.Query(beads.Filter{Status: status})
+				if err != nil {
+					fmt.Fprintf(stderr, "gc deps graph: %v\n", err) //nolint:errcheck // best-effort stderr
+					return 1
+				}
+				for _, b := range bs {
+					seeds = append(seeds, b.ID)
+				}
+			}
+		}
+	}
+	g, err := buildDepGraph(storeFor, seeds)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc deps graph: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if format == "mermaid" {
+		writeDepGraphMermaid(g, rigOf, stdout)
+	} else {
+		writeDepGraphDot(g, rigOf, stdout)
+	}
+	return 0
+}
+
+// depGraphMissing is the status given to IDs that no store knows.
+const depGraphMissing = "missing"
+
+// depEdge is a dependency from a blocker (From) to the bead it
+// constrains (To).
+type depEdge struct {
+	From, To, Type string
+}
+
+// depGraph is a set of beads with their dependency and parent edges.
+type depGraph struct {
+	beads   map[string]beads.Bead
+	deps    []depEdge
+	parents [][2]string // parent ID, child ID
+}
+
+// buildDepGraph collects every bead reachable from seeds by following
+// dependencies (both directions), parents, and children. Each bead is
+// looked up in the store storeFor picks for its ID, so links between
+// rigs are followed.
+func buildDepGraph(storeFor func(id string) (beads.Store, error), seeds []string) (*depGraph, error) {
+	g := &depGraph{beads: make(map[string]beads.Bead)}
+	seenDep := make(map[depEdge]bool)
+	seenParent := make(map[[2]string]bool)
+	queue := append([]string(nil), seeds...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, done := g.beads[id]; done {
+			continue
+		}
+		store, err := storeFor(id)
+		if err != nil {
+			return nil, err
+		}
+		b, err := store.Get(id)
+		if errors.Is(err, beads.ErrNotFound) {
+			g.beads[id] = beads.Bead{ID: id, Status: depGraphMissing}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		g.beads[id] = b
+
+		addParent := func(parent, child string) {
+			e := [2]string{parent, child}
+			if !seenParent[e] {
+				seenParent[e] = true
+				g.parents = append(g.parents, e)
+			}
+		}
+		if b.ParentID != "" {
+			addParent(b.ParentID, id)
+			queue = append(queue, b.ParentID)
+		}
+		kids, err := store.Children(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range kids {
+			addParent(id, k.ID)
+			queue = append(queue, k.ID)
+		}
+		for _, dir := range []string{"down", "up"} {
+			ds, err := store.DepList(id, dir)
+			if err != nil {
+				return nil, fmt.Errorf("listing deps for %s: %w", id, err)
+			}
+			for _, d := range ds {
+				e := depEdge{From: d.DependsOnID, To: d.IssueID, Type: d.Type}
+				if !seenDep[e] {
+					seenDep[e] = true
+					g.deps = append(g.deps, e)
+				}
+				queue = append(queue, d.DependsOnID, d.IssueID)
+			}
+		}
+	}
+	sort.Slice(g.deps, func(i, j int) bool {
+		if g.deps[i].From != g.deps[j].From {
+			return g.deps[i].From < g.deps[j].From
+		}
+		return g.deps[i].To < g.deps[j].To
+	})
+	sort.Slice(g.parents, func(i, j int) bool {
+		if g.parents[i][0] != g.parents[j][0] {
+			return g.parents[i][0] < g.parents[j][0]
+		}
+		return g.parents[i][1] < g.parents[j][1]
+	})
+	return g, nil
+}
+
+// blocked reports whether bead id is unclosed and has a blocking
+// dependency on a bead that is not closed.
+func (g *depGraph) blocked(id string) bool {
+	if b := g.beads[id]; b.Status == "closed" || b.Status == depGraphMissing {
+		return false
+	}
+	for _, e := range g.deps {
+		if e.To == id && isBlockingDep(e.Type) && g.beads[e.From].Status != "closed" {
+			return true
+		}
+	}
+	return false
+}
+
+// fill returns the fill color for bead id's status.
+func (g *depGraph) fill(id string) string {
+	switch b := g.beads[id]; {
+	case b.Status == depGraphMissing:
+		return "#D3D3D3"
+	case b.Status == "closed":
+		return "#90EE90"
+	case g.blocked(id):
+		return "#F08080"
+	case b.Status == "in_progress":
+		return "#87CEEB"
+	default:
+		return "#FFFFFF"
+	}
+}
+
+// depGraphPalette colors rig outlines, assigned in rig-label order.
+var depGraphPalette = []string{"#1F77B4", "#FF7F0E", "#2CA02C", "#9467BD", "#8C564B", "#E377C2", "#7F7F7F", "#BCBD22", "#17BECF", "#D62728"}
+
+// depGraphRigs groups the graph's bead IDs by rig label, returning the
+// labels sorted, the sorted IDs per label, and each label's outline color.
+func depGraphRigs(g *depGraph, rigOf func(id string) string) ([]string, map[string][]string, map[string]string) {
+	byRig := make(map[string][]string)
+	for id := range g.beads {
+		r := rigOf(id)
+		byRig[r] = append(byRig[r], id)
+	}
+	labels := make([]string, 0, len(byRig))
+	for r, ids := range byRig {
+		sort.Strings(ids)
+		labels = append(labels, r)
+	}
+	sort.Strings(labels)
+	colors := make(map[string]string, len(labels))
+	for i, r := range labels {
+		colors[r] = depGraphPalette[i%len(depGraphPalette)]
+	}
+	return labels, byRig, colors
+}
+
+// depGraphRigLabeler names the rig owning a bead ID from its prefix as
+// "<rig> (<prefix>)", falling back to the city for unknown prefixes.
+func depGraphRigLabeler(cfg *config.City, cityPath string) func(id string) string {
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	return func(id string) string {
+		p := beadPrefix(id)
+		if r, ok := findRigByPrefix(cfg, p); ok {
+			return r.Name + " (" + p + ")"
+		}
+		return cityName + " (" + p + ")"
+	}
+}
+
+// depGraphLabel is a node's display text: ID, title, and status.
+func depGraphLabel(b beads.Bead) string {
+	if b.Status == depGraphMissing {
+		return b.ID + " (not found)"
+	}
+	return fmt.Sprintf("%s: %s [%s]", b.ID, b.Title, b.Status)
+}
+
+// writeDepGraphDot prints g as a Graphviz digraph with one cluster per rig.
+func writeDepGraphDot(g *depGraph, rigOf func(id string) string, w io.Writer) {
+	q := func(s string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s) + `"`
+	}
+	var sb strings.Builder
+	sb.WriteString("digraph beads {\n  rankdir=LR;\n  node [shape=box, style=\"rounded,filled\"];\n")
+	labels, byRig, colors := depGraphRigs(g, rigOf)
+	for i, r := range labels {
+		fmt.Fprintf(&sb, "  subgraph cluster_%d {\n    label=%s;\n    color=%q;\n", i, q(r), colors[r])
+		for _, id := range byRig[r] {
+			fmt.Fprintf(&sb, "    %s [label=%s, fillcolor=%q, color=%q, penwidth=2];\n",
+				q(id), q(depGraphLabel(g.beads[id])), g.fill(id), colors[r])
+		}
+		sb.WriteString("  }\n")
+	}
+	for _, e := range g.deps {
+		if isBlockingDep(e.Type) {
+			fmt.Fprintf(&sb, "  %s -> %s;\n", q(e.From), q(e.To))
+		} else {
+			fmt.Fprintf(&sb, "  %s -> %s [style=dashed, label=%s];\n", q(e.From), q(e.To), q(e.Type))
+		}
+	}
+	for _, p := range g.parents {
+		fmt.Fprintf(&sb, "  %s -> %s [style=dotted, arrowhead=none];\n", q(p[0]), q(p[1]))
+	}
+	sb.WriteString("}\n")
+	fmt.Fprint(w, sb.String()) //nolint:errcheck // best-effort stdout
+}
+
+// writeDepGraphMermaid prints g as a Mermaid flowchart with one subgraph
+// per rig.
+func writeDepGraphMermaid(g *depGraph, rigOf func(id string) string, w io.Writer) {
+	nodeID := func(id string) string {
+		return strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+				return r
+			}
+			return '_'
+		}, id)
+	}
+	text := func(s string) string { return strings.ReplaceAll(s, `"`, "'") }
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	labels, byRig, colors := depGraphRigs(g, rigOf)
+	for i, r := range labels {
+		fmt.Fprintf(&sb, "  subgraph rig%d [\"%s\"]\n", i, text(r))
+		for _, id := range byRig[r] {
+			fmt.Fprintf(&sb, "    %s[\"%s\"]\n", nodeID(id), text(depGraphLabel(g.beads[id])))
+		}
+		sb.WriteString("  end\n")
+	}
+	for _, e := range g.deps {
+		if isBlockingDep(e.Type) {
+			fmt.Fprintf(&sb, "  %s --> %s\n", nodeID(e.From), nodeID(e.To))
+		} else {
+			fmt.Fprintf(&sb, "  %s -. %s .-> %s\n", nodeID(e.From), text(e.Type), nodeID(e.To))
+		}
+	}
+	for _, p := range g.parents {
+		fmt.Fprintf(&sb, "  %s -.- %s\n", nodeID(p[0]), nodeID(p[1]))
+	}
+	for _, r := range labels {
+		for _, id := range byRig[r] {
+			fmt.Fprintf(&sb, "  style %s fill:%s,stroke:%s,stroke-width:2px\n", nodeID(id), g.fill(id), colors[r])
+		}
+	}
+	fmt.Fprint(w, sb.String()) //nolint:errcheck // best-effort stdout
+}
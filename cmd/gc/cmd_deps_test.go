@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// depGraphFixture is a city store holding a convoy (gc-1) with one
+// child, and a rig store whose bead be-1 is blocked by the convoy child
+// and tracks a bead that no store knows.
+func depGraphFixture() (func(id string) (beads.Store, error), []beads.Store, func(id string) string) {
+	city := beads.NewMemStoreFrom(3, []beads.Bead{
+		{ID: "gc-1", Title: "Ship login", Status: "open", Type: "convoy"},
+		{ID: "gc-2", Title: "Fix \"auth\" flow", Status: "in_progress", ParentID: "gc-1"},
+		{ID: "gc-3", Title: "Unrelated", Status: "closed"},
+	}, nil)
+	rig := beads.NewMemStoreFrom(1, []beads.Bead{
+		{ID: "be-1", Title: "Deploy API", Status: "open"},
+	}, []beads.Dep{
+		{IssueID: "be-1", DependsOnID: "gc-2", Type: "blocks"},
+		{IssueID: "be-1", DependsOnID: "be-9", Type: "tracks"},
+	})
+	storeFor := func(id string) (beads.Store, error) {
+		if strings.HasPrefix(id, "be-") {
+			return rig, nil
+		}
+		return city, nil
+	}
+	cfg := &config.City{Workspace: config.Workspace{Name: "metro"}, Rigs: []config.Rig{{Name: "backend", Path: "/srv/backend", Prefix: "be"}}}
+	return storeFor, []beads.Store{city, rig}, depGraphRigLabeler(cfg, "/city")
+}
+
+func TestDoDepsGraphDot(t *testing.T) {
+	storeFor, _, rigOf := depGraphFixture()
+	var stdout, stderr bytes.Buffer
+	if code := doDepsGraph(storeFor, nil, rigOf, []string{"be-1"}, "dot", &stdout, &stderr); code != 0 {
+		t.Fatalf("doDepsGraph = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"digraph beads {",
+		`label="backend (be)";`,
+		`label="metro (gc)";`,
+		`"be-1" [label="be-1: Deploy API [open]", fillcolor="#F08080"`,
+		`"gc-2" [label="gc-2: Fix \"auth\" flow [in_progress]", fillcolor="#87CEEB"`,
+		`"be-9" [label="be-9 (not found)", fillcolor="#D3D3D3"`,
+		`"gc-2" -> "be-1";`,
+		`"be-9" -> "be-1" [style=dashed, label="tracks"];`,
+		`"gc-1" -> "gc-2" [style=dotted, arrowhead=none];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dot output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "gc-3") {
+		t.Errorf("unconnected bead gc-3 in graph:\n%s", out)
+	}
+}
+
+func TestDoDepsGraphMermaidWholeCity(t *testing.T) {
+	storeFor, stores, rigOf := depGraphFixture()
+	var stdout, stderr bytes.Buffer
+	if code := doDepsGraph(storeFor, stores, rigOf, nil, "mermaid", &stdout, &stderr); code != 0 {
+		t.Fatalf("doDepsGraph = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"graph LR",
+		`subgraph rig0 ["backend (be)"]`,
+		`gc_2["gc-2: Fix 'auth' flow [in_progress]"]`,
+		"gc_2 --> be_1",
+		"be_9 -. tracks .-> be_1",
+		"gc_1 -.- gc_2",
+		"style be_1 fill:#F08080,stroke:#1F77B4",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "gc_3") {
+		t.Errorf("closed, unconnected gc-3 in whole-city graph:\n%s", out)
+	}
+}
+
+func TestDoDepsGraphUnknownBead(t *testing.T) {
+	storeFor, _, rigOf := depGraphFixture()
+	var stdout, stderr bytes.Buffer
+	if code := doDepsGraph(storeFor, nil, rigOf, []string{"gc-99"}, "dot", &stdout, &stderr); code != 1 {
+		t.Errorf("doDepsGraph = %d, want 1", code)
+	}
+}
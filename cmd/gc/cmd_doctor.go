@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"io/fs"
@@ -16,10 +17,11 @@ import (
 	"github.com/gastownhall/gascity/internal/doctor"
 	"github.com/gastownhall/gascity/internal/fsys"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 func newDoctorCmd(stdout, stderr io.Writer) *cobra.Command {
-	var fix, verbose bool
+	var fix, verbose, yes bool
 	cmd := &cobra.Command{
 		Use:   "doctor",
 		Short: "Check workspace health",
@@ -28,13 +30,21 @@ func newDoctorCmd(stdout, stderr io.Writer) *cobra.Command {
 Checks city structure, config validity, binary dependencies (tmux, git,
 bd, dolt), controller status, agent sessions, zombie/orphan sessions,
 bead stores, Dolt server health, event log integrity, and per-rig
-health. Use --fix to attempt automatic repairs.`,
+health. Use --fix to attempt automatic repairs.
+
+Repairs made by --fix include recreating missing .gc/ subdirectories,
+writing stubs for missing prompt templates (the built-in prompt when the
+file name matches one), normalizing .gc/beads.json (duplicate IDs and
+invalid statuses, after a timestamped backup), and removing directories
+under rigs/ that no rig claims. Removals ask for confirmation first;
+--yes approves them, and without a terminal they are skipped.`,
 		Example: `  gc doctor
   gc doctor --fix
+  gc doctor --fix --yes
   gc doctor --verbose`,
 		Args: cobra.NoArgs,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			if doDoctor(fix, verbose, stdout, stderr) != 0 {
+			if doDoctor(fix, verbose, doctorConfirm(yes, os.Stdin, stdout), stdout, stderr) != 0 {
 				return errExit
 			}
 			return nil
@@ -42,11 +52,44 @@ health. Use --fix to attempt automatic repairs.`,
 	}
 	cmd.Flags().BoolVar(&fix, "fix", false, "attempt to fix issues automatically")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "show extra diagnostic details")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "approve destructive --fix repairs without asking")
 	return cmd
 }
 
-// doDoctor runs all health checks and prints results.
-func doDoctor(fix, verbose bool, stdout, stderr io.Writer) int {
+// doctorConfirm returns the approval prompt for destructive fixes: always
+// yes with --yes, a y/N question when stdin is a terminal, otherwise no.
+func doctorConfirm(yes bool, in *os.File, stdout io.Writer) func(string) bool {
+	if yes {
+		return func(string) bool { return true }
+	}
+	if !term.IsTerminal(int(in.Fd())) {
+		return nil
+	}
+	r := bufio.NewReader(in)
+	return func(prompt string) bool {
+		fmt.Fprintf(stdout, "  %s [y/N] ", prompt) //nolint:errcheck // best-effort stdout
+		line, _ := r.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return true
+		}
+		return false
+	}
+}
+
+// doctorPromptStub returns the content gc doctor --fix writes for a
+// missing prompt template: the built-in prompt of the same file name, or
+// a placeholder naming the agent.
+func doctorPromptStub(rel string) []byte {
+	if data, err := defaultPrompts.ReadFile("prompts/" + filepath.Base(rel)); err == nil {
+		return data
+	}
+	return []byte("# {{ .AgentName }}\n\nYou are {{ .AgentName }}, an agent in a Gas City workspace.\n")
+}
+
+// doDoctor runs all health checks and prints results. confirm approves
+// destructive fixes; nil declines them.
+func doDoctor(fix, verbose bool, confirm func(string) bool, stdout, stderr io.Writer) int {
 	cityPath, err := resolveCity()
 	if err != nil {
 		fmt.Fprintf(stderr, "gc doctor: %v\n", err) //nolint:errcheck // best-effort stderr
@@ -54,7 +97,7 @@ func doDoctor(fix, verbose bool, stdout, stderr io.Writer) int {
 	}
 
 	d := &doctor.Doctor{}
-	ctx := &doctor.CheckContext{CityPath: cityPath, Verbose: verbose}
+	ctx := &doctor.CheckContext{CityPath: cityPath, Verbose: verbose, Confirm: confirm}
 
 	// Core checks — always run.
 	d.Register(&doctor.CityStructureCheck{})
@@ -65,7 +108,9 @@ func doDoctor(fix, verbose bool, stdout, stderr io.Writer) int {
 	cfg, cfgErr := loadCityConfig(cityPath)
 	if cfgErr == nil {
 		d.Register(doctor.NewConfigValidCheck(cfg))
-		d.Register(doctor.NewConfigRefsCheck(cfg, cityPath))
+		refs := doctor.NewConfigRefsCheck(cfg, cityPath)
+		refs.PromptStub = doctorPromptStub
+		d.Register(refs)
 		d.Register(doctor.NewBuiltinPackFamilyCheck(cfg, cityPath))
 		d.Register(doctor.NewConfigSemanticsCheck(cfg, filepath.Join(cityPath, "city.toml")))
 		d.Register(doctor.NewDurationRangeCheck(cfg))
@@ -100,6 +145,12 @@ func doDoctor(fix, verbose bool, stdout, stderr io.Writer) int {
 	// Infrastructure checks.
 	d.Register(doctor.NewBinaryCheck("tmux", "", exec.LookPath))
 	d.Register(doctor.NewBinaryCheck("git", "", exec.LookPath))
+	d.Register(&doctor.RuntimeDirWritableCheck{})
+	d.Register(doctor.NewRuntimeSubdirsCheck(cityScaffoldDirs))
+	// The fake session providers never launch agent CLIs.
+	if sp := sessionProviderName(); cfgErr == nil && sp != "fake" && sp != "fail" {
+		d.Register(doctor.NewAgentProvidersCheck(cfg, exec.LookPath))
+	}
 
 	// Binary-specific version checks are handled by pack doctor scripts
 	// (check-bd.sh, check-dolt.sh) registered via the pack doctor mechanism below.
@@ -125,6 +176,9 @@ func doDoctor(fix, verbose bool, stdout, stderr io.Writer) int {
 	if cfgErr == nil {
 		d.Register(doctor.NewBeadsStoreCheck(cityPath, openStore))
 	}
+	if rawBeadsProvider(cityPath) == "file" {
+		d.Register(doctor.NewBeadsFileCheck("city", cityPath))
+	}
 	skipDolt := rawBeadsProvider(cityPath) != "bd" || os.Getenv("GC_DOLT") == "skip"
 	d.Register(doctor.NewDoltServerCheck(cityPath, skipDolt))
 	d.Register(&doctor.EventsLogCheck{})
@@ -136,7 +190,11 @@ func doDoctor(fix, verbose bool, stdout, stderr io.Writer) int {
 			d.Register(doctor.NewRigPathCheck(rig))
 			d.Register(doctor.NewRigGitCheck(rig))
 			d.Register(doctor.NewRigBeadsCheck(rig, openStore))
+			if rawBeadsProvider(rig.Path) == "file" {
+				d.Register(doctor.NewBeadsFileCheck(rig.Name, rig.Path))
+			}
 		}
+		d.Register(doctor.NewOrphanRigDirsCheck(cfg, cityPath))
 	}
 
 	// Worktree integrity check.
@@ -199,6 +257,8 @@ func openStore(dirPath string) (beads.Store, error) {
 		return store, nil
 	case prov == "file":
 		return beads.OpenFileStore(fsys.OSFS{}, filepath.Join(dirPath, ".gc", "beads.json"))
+	case prov == "sqlite":
+		return beads.OpenSQLiteStore(fsys.OSFS{}, filepath.Join(dirPath, ".gc", "beads.db"))
 	default: // "bd"
 		if _, err := exec.LookPath("bd"); err != nil {
 			return nil, fmt.Errorf("bd not found in PATH")
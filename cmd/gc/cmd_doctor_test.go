@@ -1,6 +1,9 @@
 package main
 
 import (
+	"io"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/gastownhall/gascity/internal/config"
@@ -75,3 +78,30 @@ func TestCollectPackDirsMixed(t *testing.T) {
 		t.Fatalf("expected 2 dirs, got %d: %v", len(dirs), dirs)
 	}
 }
+
+func TestDoctorConfirm(t *testing.T) {
+	if c := doctorConfirm(true, os.Stdin, io.Discard); c == nil || !c("Remove?") {
+		t.Error("--yes should approve")
+	}
+	f, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close() //nolint:errcheck // test cleanup
+	if c := doctorConfirm(false, f, io.Discard); c != nil {
+		t.Error("non-terminal stdin without --yes should decline")
+	}
+}
+
+func TestDoctorPromptStub(t *testing.T) {
+	want, err := defaultPrompts.ReadFile("prompts/worker.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := doctorPromptStub("prompts/worker.md"); string(got) != string(want) {
+		t.Error("stub for a built-in prompt name should be the built-in prompt")
+	}
+	if got := string(doctorPromptStub("prompts/reviewer.md")); !strings.Contains(got, "{{ .AgentName }}") {
+		t.Errorf("placeholder stub = %q, want agent name template", got)
+	}
+}
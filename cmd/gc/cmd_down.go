@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/spf13/cobra"
+)
+
+// downPollInterval is how often gc down checks draining agents.
+var downPollInterval = 500 * time.Millisecond
+
+func newDownCmd(stdout, stderr io.Writer) *cobra.Command {
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "down [path]",
+		Short: "Drain and stop all agent sessions in the city",
+		Long: `Stop the city without stranding work in flight.
+
+Every running agent is asked to drain: finish or park its current bead
+and exit (the same GC_DRAIN signal used for pool scale-down; agents see
+it through "gc runtime drain-check"). Sessions are then stopped in
+dependency-safe order: an agent stops before the agents it depends on.
+Each agent gets its pool drain_timeout (default 5m) to acknowledge or
+exit before it is stopped anyway; --timeout overrides that for all
+agents.
+
+Beads still in_progress once every session is down are reported.
+Use "gc stop" to stop immediately. gc down refuses to run while a
+controller is managing the city, since the controller clears drains.`,
+		Example: `  gc down
+  gc down --timeout 2m`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdDown(args, timeout, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 0,
+		"drain timeout for every agent (default: each agent's pool drain_timeout)")
+	return cmd
+}
+
+// cmdDown drains and stops the city at the given path (or cwd).
+func cmdDown(args []string, timeout time.Duration, stdout, stderr io.Writer) int {
+	var dir string
+	var err error
+	switch {
+	case len(args) > 0:
+		dir, err = filepath.Abs(args[0])
+	case cityFlag != "":
+		dir, err = filepath.Abs(cityFlag)
+	default:
+		dir, err = os.Getwd()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc down: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := findCity(dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc down: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc down: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if pid := controllerAlive(cityPath); pid != 0 {
+		fmt.Fprintf(stderr, "gc down: controller (PID %d) is managing this city and would undo the drain; use \"gc stop\"\n", pid) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+
+	sp := newSessionProvider()
+	store, _ := openCityStoreAt(cityPath)
+	targets := downTargets(cfg, cityName, store, sp, timeout)
+	desired := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		desired[t.Session] = true
+	}
+	var rec events.Recorder = events.Discard
+	if fr, err := events.NewFileRecorder(
+		filepath.Join(cityPath, ".gc", "events.jsonl"), stderr); err == nil {
+		rec = fr
+	}
+
+	code := doDown(targets, sp, newDrainOps(sp), rec, stdout, stderr)
+	doStopOrphans(sp, newReconcileOps(sp), desired, cfg.Daemon.ShutdownTimeoutDuration(), rec, stdout, stderr)
+
+	// Report work the drain did not finish.
+	stores := []beads.Store{}
+	if store != nil {
+		stores = append(stores, store)
+	}
+	resolveRigPaths(cityPath, cfg.Rigs)
+	for _, r := range cfg.Rigs {
+		if rs, err := openRigStoreAt(cityPath, r.Path); err == nil {
+			stores = append(stores, rs)
+		}
+	}
+	reportInProgressBeads(stores, stdout, stderr)
+
+	if err := shutdownBeadsProvider(cityPath); err != nil {
+		fmt.Fprintf(stderr, "gc down: bead store: %v\n", err) //nolint:errcheck // best-effort stderr
+	}
+	fmt.Fprintln(stdout, "City stopped.") //nolint:errcheck // best-effort stdout
+	return code
+}
+
+// downTarget is one agent session gc down drains and stops.
+type downTarget struct {
+	Session  string        // runtime session name
+	Agent    string        // qualified agent or pool instance name
+	Template string        // qualified agent name, for dependency order
+	Timeout  time.Duration // how long to wait for the drain
+}
+
+// downTargets lists every configured agent session, ordered so that an
+// agent comes before the agents it depends on. timeout, when non-zero,
+// replaces each agent's pool drain_timeout.
+func downTargets(cfg *config.City, cityName string, store beads.Store, sp runtime.Provider, timeout time.Duration) []downTarget {
+	st := cfg.Workspace.SessionTemplate
+	var targets []downTarget
+	for _, a := range cfg.Agents {
+		pool := a.EffectivePool()
+		qn := a.QualifiedName()
+		t := timeout
+		if t == 0 {
+			t = pool.DrainTimeoutDuration()
+		}
+		if !pool.IsMultiInstance() {
+			targets = append(targets, downTarget{
+				Session: lookupSessionNameOrLegacy(store, cityName, qn, st), Agent: qn, Template: qn, Timeout: t,
+			})
+			continue
+		}
+		for _, qi := range discoverPoolInstances(a.Name, a.Dir, pool, cityName, st, sp) {
+			targets = append(targets, downTarget{
+				Session: lookupSessionNameOrLegacy(store, cityName, qi, st), Agent: qi, Template: qn, Timeout: t,
+			})
+		}
+	}
+	return downOrder(targets, buildDepsMap(cfg))
+}
+
+// downOrder sorts targets so dependents stop before their dependencies:
+// the reverse of start order. Within a dependency level, order is by
+// agent name. On a cycle (rejected at config load) the name order stands.
+func downOrder(targets []downTarget, deps map[string][]string) []downTarget {
+	sort.SliceStable(targets, func(i, j int) bool { return targets[i].Agent < targets[j].Agent })
+	// depth is the length of the longest dependency chain below a template.
+	depth := make(map[string]int)
+	visiting := make(map[string]bool)
+	var visit func(t string) int
+	visit = func(t string) int {
+		if d, ok := depth[t]; ok {
+			return d
+		}
+		if visiting[t] {
+			return 0
+		}
+		visiting[t] = true
+		d := 0
+		for _, dep := range deps[t] {
+			if v := visit(dep) + 1; v > d {
+				d = v
+			}
+		}
+		visiting[t] = false
+		depth[t] = d
+		return d
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return visit(targets[i].Template) > visit(targets[j].Template)
+	})
+	return targets
+}
+
+// doDown drains every running target, then stops them in order, waiting
+// for each to acknowledge the drain or exit until its timeout (counted
+// from when the drain was signaled). Returns 1 if any session could not
+// be stopped.
+func doDown(targets []downTarget, sp runtime.Provider, dops drainOps, rec events.Recorder, stdout, stderr io.Writer) int {
+	var running []downTarget
+	for _, t := range targets {
+		if sp.IsRunning(t.Session) {
+			running = append(running, t)
+		}
+	}
+	if len(running) == 0 {
+		fmt.Fprintln(stdout, "No agents running.") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+
+	drainedAt := time.Now()
+	for _, t := range running {
+		if err := dops.setDrain(t.Session); err != nil {
+			fmt.Fprintf(stderr, "gc down: draining %s: %v\n", t.Agent, err) //nolint:errcheck // best-effort stderr
+			continue
+		}
+		rec.Record(events.Event{
+			Type:    events.SessionDraining,
+			Actor:   "gc",
+			Subject: t.Agent,
+			Message: "city going down",
+		})
+	}
+	fmt.Fprintf(stdout, "Draining %d agent(s)...\n", len(running)) //nolint:errcheck // best-effort stdout
+
+	code := 0
+	for _, t := range running {
+		deadline := drainedAt.Add(t.Timeout)
+		for sp.IsRunning(t.Session) && time.Now().Before(deadline) {
+			if acked, _ := dops.isDrainAcked(t.Session); acked {
+				break
+			}
+			time.Sleep(min(downPollInterval, time.Until(deadline)))
+		}
+
+		if !sp.IsRunning(t.Session) {
+			fmt.Fprintf(stdout, "Agent '%s' exited after drain\n", t.Agent) //nolint:errcheck // best-effort stdout
+		} else {
+			how := "drained"
+			if acked, _ := dops.isDrainAcked(t.Session); !acked {
+				how = fmt.Sprintf("drain timed out after %s", t.Timeout)
+			}
+			if err := sp.Stop(t.Session); err != nil {
+				fmt.Fprintf(stderr, "gc down: stopping %s: %v\n", t.Agent, err) //nolint:errcheck // best-effort stderr
+				code = 1
+				continue
+			}
+			fmt.Fprintf(stdout, "Stopped agent '%s' (%s)\n", t.Agent, how) //nolint:errcheck // best-effort stdout
+		}
+		rec.Record(events.Event{
+			Type:    events.SessionStopped,
+			Actor:   "gc",
+			Subject: t.Agent,
+		})
+	}
+	return code
+}
+
+// reportInProgressBeads lists work beads still in_progress across stores,
+// deduplicating beads shared by stores that are the same city store.
+func reportInProgressBeads(stores []beads.Store, stdout, stderr io.Writer) {
+	seen := make(map[string]bool)
+	var left []beads.Bead
+	for _, s := range stores {
+		bs, err := s.Query(beads.Filter{Status: "in_progress", Sort: "assignee"})
+		if err != nil {
+			fmt.Fprintf(stderr, "gc down: listing in-progress beads: %v\n", err) //nolint:errcheck // best-effort stderr
+			continue
+		}
+		for _, b := range bs {
+			if b.Type == sessionBeadType || seen[b.ID] {
+				continue
+			}
+			seen[b.ID] = true
+			left = append(left, b)
+		}
+	}
+	if len(left) == 0 {
+		return
+	}
+	fmt.Fprintf(stdout, "%d bead(s) left in_progress:\n", len(left)) //nolint:errcheck // best-effort stdout
+	for _, b := range left {
+		assignee := b.Assignee
+		if assignee == "" {
+			assignee = "-"
+		}
+		fmt.Fprintf(stdout, "  %s  %s  %s\n", b.ID, assignee, b.Title) //nolint:errcheck // best-effort stdout
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestDownOrder_DependentsFirst(t *testing.T) {
+	targets := []downTarget{
+		{Agent: "db", Template: "db"},
+		{Agent: "hw/polecat-1", Template: "hw/polecat"},
+		{Agent: "api", Template: "api"},
+		{Agent: "mayor", Template: "mayor"},
+	}
+	deps := map[string][]string{
+		"hw/polecat": {"api"},
+		"api":        {"db"},
+	}
+	got := downOrder(targets, deps)
+	var names []string
+	for _, t := range got {
+		names = append(names, t.Agent)
+	}
+	want := "hw/polecat-1,api,db,mayor"
+	if strings.Join(names, ",") != want {
+		t.Errorf("order = %v, want %s", names, want)
+	}
+}
+
+func TestDoDown_StopsAckedAndTimedOut(t *testing.T) {
+	sp := runtime.NewFake()
+	for _, sn := range []string{"mayor", "worker"} {
+		_ = sp.Start(context.Background(), sn, runtime.Config{Command: "echo"})
+	}
+	dops := newDrainOps(sp)
+	_ = dops.setDrainAck("mayor") // mayor parks its work right away
+
+	targets := []downTarget{
+		{Session: "mayor", Agent: "mayor", Template: "mayor", Timeout: time.Minute},
+		{Session: "worker", Agent: "worker", Template: "worker", Timeout: 10 * time.Millisecond},
+		{Session: "idle", Agent: "idle", Template: "idle", Timeout: time.Minute},
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doDown(targets, sp, dops, events.Discard, &stdout, &stderr); code != 0 {
+		t.Fatalf("doDown = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "Draining 2 agent(s)") {
+		t.Errorf("missing drain count:\n%s", out)
+	}
+	if !strings.Contains(out, "Stopped agent 'mayor' (drained)") {
+		t.Errorf("mayor not stopped as drained:\n%s", out)
+	}
+	if !strings.Contains(out, "Stopped agent 'worker' (drain timed out after 10ms)") {
+		t.Errorf("worker not stopped after timeout:\n%s", out)
+	}
+	if strings.Contains(out, "'idle'") {
+		t.Errorf("idle agent was not running and should be skipped:\n%s", out)
+	}
+	for _, sn := range []string{"mayor", "worker"} {
+		if sp.IsRunning(sn) {
+			t.Errorf("%s still running", sn)
+		}
+	}
+}
+
+func TestDoDown_NothingRunning(t *testing.T) {
+	sp := runtime.NewFake()
+	var stdout, stderr bytes.Buffer
+	code := doDown([]downTarget{{Session: "mayor", Agent: "mayor"}}, sp, newDrainOps(sp), events.Discard, &stdout, &stderr)
+	if code != 0 || !strings.Contains(stdout.String(), "No agents running.") {
+		t.Errorf("doDown = %d, stdout = %q", code, stdout.String())
+	}
+}
+
+func TestReportInProgressBeads(t *testing.T) {
+	store := beads.NewMemStore()
+	work, _ := store.Create(beads.Bead{Title: "fix login"})
+	_ = store.Update(work.ID, beads.UpdateOpts{Status: strPtr("in_progress"), Assignee: strPtr("hw/polecat-1")})
+	sess, _ := store.Create(beads.Bead{Title: "mayor", Type: sessionBeadType})
+	_ = store.Update(sess.ID, beads.UpdateOpts{Status: strPtr("in_progress")})
+	_, _ = store.Create(beads.Bead{Title: "not started"})
+
+	var stdout, stderr bytes.Buffer
+	// The same store twice (city and a non-bd rig) reports each bead once.
+	reportInProgressBeads([]beads.Store{store, store}, &stdout, &stderr)
+	out := stdout.String()
+	if !strings.Contains(out, "1 bead(s) left in_progress:") {
+		t.Errorf("want one bead reported:\n%s", out)
+	}
+	if !strings.Contains(out, work.ID) || !strings.Contains(out, "hw/polecat-1") || !strings.Contains(out, "fix login") {
+		t.Errorf("work bead not reported:\n%s", out)
+	}
+	if strings.Contains(out, "not started") {
+		t.Errorf("open bead reported:\n%s", out)
+	}
+}
@@ -52,9 +52,20 @@ attaching arbitrary JSON payloads.`,
 	return cmd
 }
 
-// cmdEventEmit records a single event to the city event log. Best-effort:
-// errors go to stderr but exit code is always 0 so bd hooks never fail.
+// cmdEventEmit records a single event to the city event log. A
+// bead.closed event also runs the city's post_close_bead hooks.
+// Best-effort: errors go to stderr but exit code is always 0 so bd hooks
+// never fail.
 func cmdEventEmit(eventType, subject, message, actor, payload string, stderr io.Writer) int {
+	if eventType == events.BeadClosed {
+		defer func() {
+			if cityPath, err := resolveCity(); err == nil {
+				if cfg, err := loadCityConfig(cityPath); err == nil {
+					fireBeadClosedHooks(cfg, cityPath, subject, message, payload, stderr)
+				}
+			}
+		}()
+	}
 	ep, code := openCityEventsProvider(stderr, "gc event emit")
 	if ep == nil {
 		// Best-effort: if we can't open the provider, still exit 0.
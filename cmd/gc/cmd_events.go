@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
@@ -72,6 +76,36 @@ scripting and automation).`,
 	cmd.Flags().Uint64Var(&afterFlag, "after", 0, "Resume watching from this sequence number (0 = current head)")
 	cmd.Flags().StringArrayVar(&payloadMatch, "payload-match", nil, "Filter by payload field (key=value, repeatable)")
 	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format (list mode only)")
+	cmd.AddCommand(newEventsTailCmd(stdout, stderr))
+	return cmd
+}
+
+func newEventsTailCmd(stdout, stderr io.Writer) *cobra.Command {
+	var typeFilter string
+	var lines int
+	var payloadMatch []string
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Show recent events and stream new ones",
+		Long: `Print the last N events, then keep printing new events as they are
+recorded, one line each, until interrupted.
+
+Unlike gc events --follow, which emits JSON lines for scripts, tail is
+meant for a human watching the city.`,
+		Example: `  gc events tail
+  gc events tail --type sling.routed
+  gc events tail -n 50 --type session.crashed`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdEventsTail(typeFilter, payloadMatch, lines, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&typeFilter, "type", "", "Filter by event type (e.g. bead.created)")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 10, "Number of recent events to show first")
+	cmd.Flags().StringArrayVar(&payloadMatch, "payload-match", nil, "Filter by payload field (key=value, repeatable)")
 	return cmd
 }
 
@@ -206,6 +240,82 @@ func filterEventsByPayload(evts []events.Event, pm map[string][]string) []events
 	return out
 }
 
+// cmdEventsTail is the CLI entry point for gc events tail.
+func cmdEventsTail(typeFilter string, payloadMatch []string, lines int, stdout, stderr io.Writer) int {
+	pm, err := parsePayloadMatch(payloadMatch)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc events tail: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	ep, code := openCityEventsProvider(stderr, "gc events tail")
+	if ep == nil {
+		return code
+	}
+	defer ep.Close() //nolint:errcheck // best-effort
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return doEventsTail(ctx, ep, typeFilter, pm, lines, 500*time.Millisecond, stdout, stderr)
+}
+
+// doEventsTail prints the last n matching events, then polls for new ones
+// until ctx is done.
+func doEventsTail(ctx context.Context, ep events.Provider, typeFilter string, payloadMatch map[string][]string,
+	n int, pollInterval time.Duration, stdout, stderr io.Writer,
+) int {
+	evts, err := ep.List(events.Filter{Type: typeFilter})
+	if err != nil {
+		fmt.Fprintf(stderr, "gc events tail: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var lastSeq uint64
+	for _, e := range evts {
+		lastSeq = max(lastSeq, e.Seq)
+	}
+	if head, err := ep.LatestSeq(); err == nil {
+		lastSeq = max(lastSeq, head)
+	}
+	evts = filterEventsByPayload(evts, payloadMatch)
+	if n >= 0 && len(evts) > n {
+		evts = evts[len(evts)-n:]
+	}
+	for _, e := range evts {
+		printEventLine(stdout, e)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+		}
+		evts, err := ep.List(events.Filter{AfterSeq: lastSeq})
+		if err != nil {
+			fmt.Fprintf(stderr, "gc events tail: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		for _, e := range filterEvents(evts, lastSeq, typeFilter, payloadMatch) {
+			printEventLine(stdout, e)
+		}
+		for _, e := range evts {
+			lastSeq = max(lastSeq, e.Seq)
+		}
+	}
+}
+
+// printEventLine prints one event as a single human-readable line.
+func printEventLine(w io.Writer, e events.Event) {
+	line := fmt.Sprintf("%s  #%d  %s  %s", e.Ts.Local().Format(time.DateTime), e.Seq, e.Type, e.Actor)
+	if e.Subject != "" {
+		line += "  " + e.Subject
+	}
+	if e.Message != "" {
+		line += "  " + e.Message
+	}
+	fmt.Fprintln(w, line) //nolint:errcheck // best-effort stdout
+}
+
 // cmdEventsFollow is the CLI entry point for follow mode — continuous streaming.
 func cmdEventsFollow(typeFilter string, payloadMatch []string, afterSeq uint64, stdout, stderr io.Writer) int {
 	pm, err := parsePayloadMatch(payloadMatch)
@@ -2,9 +2,11 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -654,3 +656,69 @@ func TestMatchPayload(t *testing.T) {
 		}
 	})
 }
+
+func TestDoEventsTail(t *testing.T) {
+	ep := newTestProvider(t, t.TempDir())
+	ep.Record(events.Event{Type: events.BeadCreated, Actor: "human", Subject: "gc-1"})
+	ep.Record(events.Event{Type: events.SlingRouted, Actor: "human", Subject: "gc-1", Message: "→ mayor"})
+	ep.Record(events.Event{Type: events.SlingRouted, Actor: "human", Subject: "gc-2", Message: "→ deputy"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var stdout syncBuffer
+	var stderr bytes.Buffer
+	done := make(chan int)
+	go func() {
+		done <- doEventsTail(ctx, ep, events.SlingRouted, nil, 1, 5*time.Millisecond, &stdout, &stderr)
+	}()
+
+	// Only the last matching event is shown up front.
+	waitForOutput(t, &stdout, "gc-2")
+	if strings.Contains(stdout.String(), "gc-1") {
+		t.Errorf("stdout = %q, want only the last event", stdout.String())
+	}
+
+	// New matching events stream in; others are filtered out.
+	ep.Record(events.Event{Type: events.BeadClosed, Actor: "human", Subject: "gc-2"})
+	ep.Record(events.Event{Type: events.SlingRouted, Actor: "mayor", Subject: "gc-3", Message: "→ polecat"})
+	waitForOutput(t, &stdout, "gc-3")
+	cancel()
+	if code := <-done; code != 0 {
+		t.Fatalf("doEventsTail = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if strings.Contains(out, events.BeadClosed) {
+		t.Errorf("stdout = %q, want bead.closed filtered out", out)
+	}
+	if !strings.Contains(out, "#5  sling.routed  mayor  gc-3  → polecat") {
+		t.Errorf("stdout = %q, want formatted gc-3 line", out)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for one writer and one reader.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func waitForOutput(t *testing.T, b *syncBuffer, want string) {
+	t.Helper()
+	for i := 0; i < 200; i++ {
+		if strings.Contains(b.String(), want) {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("output never contained %q; got %q", want, b.String())
+}
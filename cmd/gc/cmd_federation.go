@@ -0,0 +1,512 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gastownhall/gascity/api/federation"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/supervisor"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// federationCallTimeout bounds each RPC a capital makes to a member.
+const federationCallTimeout = 15 * time.Second
+
+func newFederationCmd(stdout, stderr io.Writer) *cobra.Command {
+	var allowInsecure bool
+	cmd := &cobra.Command{
+		Use:   "federation",
+		Short: "Federate cities across machines over gRPC",
+		Long: `Federate cities across machines over gRPC.
+
+A member runs "gc federation serve" to expose the cities registered on
+its machine (see "gc register"). A capital lists [[federation.remote]]
+entries in ~/.gc/supervisor.toml and uses "gc federation cities",
+"status", and "sling" to see and drive every member's cities from one
+place.
+
+Every call carries the member's federation token, generated on first
+serve and stored (mode 0600) in ~/.gc/federation-token. The capital
+reads it from the env var named by the remote's token_env (default
+GC_FEDERATION_TOKEN). Set tls_cert/tls_key on the member and tls = true
+on the remote to encrypt the connection. Without TLS the token would
+cross the network in cleartext, so both sides refuse to run unless
+--insecure is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.PersistentFlags().BoolVar(&allowInsecure, "insecure", false,
+		"allow connections without TLS (the token is sent in cleartext)")
+	cmd.AddCommand(
+		newFederationServeCmd(&allowInsecure, stdout, stderr),
+		newFederationCitiesCmd(&allowInsecure, stdout, stderr),
+		newFederationStatusCmd(&allowInsecure, stdout, stderr),
+		newFederationSlingCmd(&allowInsecure, stdout, stderr),
+	)
+	return cmd
+}
+
+func newFederationServeCmd(allowInsecure *bool, stdout, stderr io.Writer) *cobra.Command {
+	var listen string
+	var rotate bool
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve this machine's cities to a federation capital",
+		Long: `Serve the Federation gRPC service for the cities registered on this
+machine until interrupted.
+
+The listen address comes from --listen or [federation] listen in
+~/.gc/supervisor.toml. TLS is used when tls_cert and tls_key are set;
+serving without it requires --insecure.`,
+		Example: `  gc federation serve --listen 0.0.0.0:8373
+  gc federation serve --rotate-token`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdFederationServe(listen, rotate, *allowInsecure, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&listen, "listen", "", "gRPC listen address (overrides [federation] listen)")
+	cmd.Flags().BoolVar(&rotate, "rotate-token", false, "generate a new federation token before serving")
+	return cmd
+}
+
+// cmdFederationServe runs the federation member until interrupted.
+// Without TLS it refuses to start unless allowInsecure is set.
+func cmdFederationServe(listen string, rotate, allowInsecure bool, stdout, stderr io.Writer) int {
+	cfg, err := supervisor.LoadConfig(supervisor.ConfigPath())
+	if err != nil {
+		fmt.Fprintf(stderr, "gc federation serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if listen == "" {
+		listen = cfg.Federation.Listen
+	}
+	if listen == "" {
+		fmt.Fprintln(stderr, "gc federation serve: no listen address (use --listen or set [federation] listen)") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if (cfg.Federation.TLSCert == "") != (cfg.Federation.TLSKey == "") {
+		fmt.Fprintln(stderr, "gc federation serve: tls_cert and tls_key must be set together") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if cfg.Federation.TLSCert == "" && !allowInsecure {
+		fmt.Fprintln(stderr, "gc federation serve: no TLS: set [federation] tls_cert and tls_key, or pass --insecure to accept tokens in cleartext") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	token, err := loadTokenFile(supervisor.FederationTokenPath(), rotate)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc federation serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(federation.TokenAuth(token))}
+	scheme := "insecure"
+	if cfg.Federation.TLSCert != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.Federation.TLSCert, cfg.Federation.TLSKey)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc federation serve: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		opts = append(opts, grpc.Creds(creds))
+		scheme = "tls"
+	}
+	srv := grpc.NewServer(opts...)
+	federation.RegisterFederationServer(srv, &federationMember{
+		reg: supervisor.NewRegistry(supervisor.RegistryPath()),
+		sp:  newSessionProvider(),
+		run: runGCIn,
+	})
+
+	lis, err := net.Listen("tcp", listen)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc federation serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "Federation member listening on %s (%s, token in %s)\n", listen, scheme, supervisor.FederationTokenPath()) //nolint:errcheck // best-effort stdout
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+	if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		fmt.Fprintf(stderr, "gc federation serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return 0
+}
+
+// federationMember implements the Federation service over the machine's
+// city registry. Status is read in-process; slings shell out to gc in the
+// city directory so they follow exactly the local sling path.
+type federationMember struct {
+	federation.UnimplementedFederationServer
+	reg *supervisor.Registry
+	sp  runtime.Provider
+	// run executes gc with args in dir and returns its stdout.
+	run func(dir string, args ...string) ([]byte, error)
+}
+
+func (m *federationMember) ListCities(context.Context, *federation.ListCitiesRequest) (*federation.ListCitiesResponse, error) {
+	entries, err := m.reg.List()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "reading registry: %v", err)
+	}
+	resp := &federation.ListCitiesResponse{Cities: []*federation.City{}}
+	for _, e := range entries {
+		resp.Cities = append(resp.Cities, federationCity(e))
+	}
+	return resp, nil
+}
+
+func (m *federationMember) CityStatus(_ context.Context, in *federation.CityStatusRequest) (*federation.CityStatus, error) {
+	entry, err := m.lookup(in.City)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := loadCityConfig(entry.Path)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "loading %s: %v", entry.EffectiveName(), err)
+	}
+	var buf bytes.Buffer
+	doCityStatusJSON(m.sp, cfg, entry.Path, &buf, io.Discard)
+	var st StatusJSON
+	if err := json.Unmarshal(buf.Bytes(), &st); err != nil {
+		return nil, status.Errorf(codes.Internal, "decoding status: %v", err)
+	}
+
+	resp := &federation.CityStatus{City: federationCity(entry), Agents: []*federation.AgentStatus{}}
+	for _, a := range st.Agents {
+		resp.Agents = append(resp.Agents, &federation.AgentStatus{
+			Name:            a.QualifiedName,
+			Running:         a.Running,
+			Suspended:       a.Suspended,
+			OpenBeads:       int32(a.OpenBeads),
+			InProgressBeads: int32(a.ActiveBeads),
+		})
+	}
+	store, err := openCityStoreAt(entry.Path)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "opening bead store: %v", err)
+	}
+	ready, err := store.Ready()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "listing ready beads: %v", err)
+	}
+	resp.ReadyBeads = int32(len(ready))
+	return resp, nil
+}
+
+func (m *federationMember) Sling(_ context.Context, in *federation.SlingRequest) (*federation.SlingResponse, error) {
+	entry, err := m.lookup(in.City)
+	if err != nil {
+		return nil, err
+	}
+	if in.Target == "" || in.Bead == "" {
+		return nil, status.Error(codes.InvalidArgument, "target and bead are required")
+	}
+	// The values come from a peer: never let them be read as gc flags.
+	if strings.HasPrefix(in.Target, "-") || strings.HasPrefix(in.Bead, "-") {
+		return nil, status.Error(codes.InvalidArgument, "target and bead must not start with \"-\"")
+	}
+	args := []string{"sling"}
+	if in.Formula {
+		args = append(args, "--formula")
+	}
+	args = append(args, "--", in.Target, in.Bead)
+	out, err := m.run(entry.Path, args...)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "gc sling: %v", err)
+	}
+	return &federation.SlingResponse{Output: string(out)}, nil
+}
+
+// lookup finds a registered city by effective name.
+func (m *federationMember) lookup(name string) (supervisor.CityEntry, error) {
+	entries, err := m.reg.List()
+	if err != nil {
+		return supervisor.CityEntry{}, status.Errorf(codes.Internal, "reading registry: %v", err)
+	}
+	for _, e := range entries {
+		if e.EffectiveName() == name {
+			return e, nil
+		}
+	}
+	return supervisor.CityEntry{}, status.Errorf(codes.NotFound, "city %q is not registered on this member", name)
+}
+
+func federationCity(e supervisor.CityEntry) *federation.City {
+	return &federation.City{Name: e.EffectiveName(), Path: e.Path, ControllerRunning: controllerAlive(e.Path) != 0}
+}
+
+// runGCIn runs this gc binary with args in dir. On failure the error
+// carries gc's stderr.
+func runGCIn(dir string, args ...string) ([]byte, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(self, args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.Bytes(), errors.New(msg)
+		}
+		return stdout.Bytes(), err
+	}
+	return stdout.Bytes(), nil
+}
+
+// federationRemote is a dialed member.
+type federationRemote struct {
+	name   string
+	client federation.FederationClient
+}
+
+// dialFederationRemotes connects to every configured remote. Connections
+// are lazy, so dial errors surface on the first call instead. A remote
+// without TLS is refused unless allowInsecure is set, since its token
+// would be sent in cleartext.
+func dialFederationRemotes(remotes []supervisor.FederationRemote, allowInsecure bool) ([]federationRemote, func(), error) {
+	var out []federationRemote
+	var conns []*grpc.ClientConn
+	closeAll := func() {
+		for _, cc := range conns {
+			cc.Close() //nolint:errcheck // best-effort cleanup
+		}
+	}
+	for _, r := range remotes {
+		if !r.TLS && !allowInsecure {
+			closeAll()
+			return nil, nil, fmt.Errorf("remote %q: no TLS: set tls = true, or pass --insecure to send the token in cleartext", r.Name)
+		}
+		token := os.Getenv(r.TokenEnvOrDefault())
+		if token == "" {
+			closeAll()
+			return nil, nil, fmt.Errorf("remote %q: $%s is not set", r.Name, r.TokenEnvOrDefault())
+		}
+		transport := insecure.NewCredentials()
+		if r.TLS {
+			transport = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+		cc, err := grpc.NewClient(r.Address,
+			grpc.WithTransportCredentials(transport),
+			federation.TokenCredentials(token, r.TLS),
+		)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("remote %q: %w", r.Name, err)
+		}
+		conns = append(conns, cc)
+		out = append(out, federationRemote{name: r.Name, client: federation.NewFederationClient(cc)})
+	}
+	return out, closeAll, nil
+}
+
+// openFederationRemotes loads the capital's remotes and dials them.
+func openFederationRemotes(allowInsecure bool, stderr io.Writer, cmdName string) ([]federationRemote, func()) {
+	cfg, err := supervisor.LoadConfig(supervisor.ConfigPath())
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, nil
+	}
+	if len(cfg.Federation.Remotes) == 0 {
+		fmt.Fprintf(stderr, "%s: no [[federation.remote]] entries in %s\n", cmdName, supervisor.ConfigPath()) //nolint:errcheck // best-effort stderr
+		return nil, nil
+	}
+	remotes, closeAll, err := dialFederationRemotes(cfg.Federation.Remotes, allowInsecure)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, nil
+	}
+	return remotes, closeAll
+}
+
+func newFederationCitiesCmd(allowInsecure *bool, stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "cities",
+		Short:   "List the cities on every federation member",
+		Example: `  gc federation cities`,
+		Args:    cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			remotes, closeAll := openFederationRemotes(*allowInsecure, stderr, "gc federation cities")
+			if remotes == nil {
+				return errExit
+			}
+			defer closeAll()
+			if doFederationCities(remotes, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// doFederationCities prints each member's cities. An unreachable member
+// is reported and skipped; the exit code is 1 if any member failed.
+func doFederationCities(remotes []federationRemote, stdout, stderr io.Writer) int {
+	rc := 0
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "REMOTE\tCITY\tCONTROLLER\tPATH") //nolint:errcheck // best-effort stdout
+	for _, r := range remotes {
+		ctx, cancel := context.WithTimeout(context.Background(), federationCallTimeout)
+		resp, err := r.client.ListCities(ctx, &federation.ListCitiesRequest{})
+		cancel()
+		if err != nil {
+			fmt.Fprintf(stderr, "gc federation cities: %s: %s\n", r.name, status.Convert(err).Message()) //nolint:errcheck // best-effort stderr
+			rc = 1
+			continue
+		}
+		for _, c := range resp.Cities {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.name, c.Name, runningWord(c.ControllerRunning), c.Path) //nolint:errcheck // best-effort stdout
+		}
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+	return rc
+}
+
+func newFederationStatusCmd(allowInsecure *bool, stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Summarize every city on every federation member",
+		Long: `Summarize every city on every federation member: controller state,
+running agents out of configured agents, and ready beads.`,
+		Example: `  gc federation status`,
+		Args:    cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			remotes, closeAll := openFederationRemotes(*allowInsecure, stderr, "gc federation status")
+			if remotes == nil {
+				return errExit
+			}
+			defer closeAll()
+			if doFederationStatus(remotes, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// doFederationStatus prints one row per federated city.
+func doFederationStatus(remotes []federationRemote, stdout, stderr io.Writer) int {
+	rc := 0
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CITY\tCONTROLLER\tAGENTS\tREADY") //nolint:errcheck // best-effort stdout
+	for _, r := range remotes {
+		ctx, cancel := context.WithTimeout(context.Background(), federationCallTimeout)
+		list, err := r.client.ListCities(ctx, &federation.ListCitiesRequest{})
+		cancel()
+		if err != nil {
+			fmt.Fprintf(stderr, "gc federation status: %s: %s\n", r.name, status.Convert(err).Message()) //nolint:errcheck // best-effort stderr
+			rc = 1
+			continue
+		}
+		for _, c := range list.Cities {
+			ctx, cancel := context.WithTimeout(context.Background(), federationCallTimeout)
+			st, err := r.client.CityStatus(ctx, &federation.CityStatusRequest{City: c.Name})
+			cancel()
+			if err != nil {
+				fmt.Fprintf(stderr, "gc federation status: %s/%s: %s\n", r.name, c.Name, status.Convert(err).Message()) //nolint:errcheck // best-effort stderr
+				rc = 1
+				continue
+			}
+			running := 0
+			for _, a := range st.Agents {
+				if a.Running {
+					running++
+				}
+			}
+			fmt.Fprintf(tw, "%s/%s\t%s\t%d/%d\t%d\n", r.name, c.Name, runningWord(st.GetCity().GetControllerRunning()), running, len(st.Agents), st.ReadyBeads) //nolint:errcheck // best-effort stdout
+		}
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+	return rc
+}
+
+func runningWord(running bool) string {
+	if running {
+		return "running"
+	}
+	return "stopped"
+}
+
+func newFederationSlingCmd(allowInsecure *bool, stdout, stderr io.Writer) *cobra.Command {
+	var formula bool
+	cmd := &cobra.Command{
+		Use:   "sling <remote>/<city> <target> <bead-or-formula>",
+		Short: "Sling work to an agent in a federated city",
+		Long: `Route a bead (or, with --formula, a formula) to an agent in a city on
+another machine. The member runs "gc sling" in that city and the output
+is printed here.`,
+		Example: `  gc federation sling build-box/metro mayor gc-42
+  gc federation sling build-box/metro myrig/polecat code-review --formula`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(_ *cobra.Command, args []string) error {
+			remoteName, city, ok := strings.Cut(args[0], "/")
+			if !ok || remoteName == "" || city == "" {
+				fmt.Fprintf(stderr, "gc federation sling: %q is not <remote>/<city>\n", args[0]) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			remotes, closeAll := openFederationRemotes(*allowInsecure, stderr, "gc federation sling")
+			if remotes == nil {
+				return errExit
+			}
+			defer closeAll()
+			req := &federation.SlingRequest{City: city, Target: args[1], Bead: args[2], Formula: formula}
+			if doFederationSling(remotes, remoteName, req, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&formula, "formula", false, "treat the last argument as a formula name")
+	return cmd
+}
+
+// doFederationSling forwards req to the named remote.
+func doFederationSling(remotes []federationRemote, remoteName string, req *federation.SlingRequest, stdout, stderr io.Writer) int {
+	for _, r := range remotes {
+		if r.name != remoteName {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), federationCallTimeout)
+		defer cancel()
+		resp, err := r.client.Sling(ctx, req)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc federation sling: %s/%s: %s\n", r.name, req.City, status.Convert(err).Message()) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		io.WriteString(stdout, resp.Output) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	fmt.Fprintf(stderr, "gc federation sling: unknown remote %q\n", remoteName) //nolint:errcheck // best-effort stderr
+	return 1
+}
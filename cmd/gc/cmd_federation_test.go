@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/api/federation"
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/supervisor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestFederation registers one city with a mayor agent and two ready
+// beads, serves it from a member on an in-memory listener, and returns
+// the capital's view of it as remote "box".
+func newTestFederation(t *testing.T, run func(dir string, args ...string) ([]byte, error)) []federationRemote {
+	t.Helper()
+	t.Setenv("GC_BEADS", "file")
+	cityPath := filepath.Join(t.TempDir(), "metro")
+	if err := os.MkdirAll(cityPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	toml := "[workspace]\nname = \"metro\"\n\n[[agent]]\nname = \"mayor\"\n"
+	if err := os.WriteFile(filepath.Join(cityPath, "city.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Create(beads.Bead{Title: "one"}) //nolint:errcheck
+	store.Create(beads.Bead{Title: "two"}) //nolint:errcheck
+
+	reg := supervisor.NewRegistry(filepath.Join(t.TempDir(), "cities.toml"))
+	if err := reg.Register(cityPath, "metro"); err != nil {
+		t.Fatal(err)
+	}
+	sp := runtime.NewFake()
+	sp.Start(context.Background(), "mayor", runtime.Config{}) //nolint:errcheck
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(federation.TokenAuth("tok")))
+	federation.RegisterFederationServer(srv, &federationMember{reg: reg, sp: sp, run: run})
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		federation.TokenCredentials("tok", false),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() }) //nolint:errcheck
+	return []federationRemote{{name: "box", client: federation.NewFederationClient(cc)}}
+}
+
+func TestFederationCitiesAndStatus(t *testing.T) {
+	remotes := newTestFederation(t, nil)
+
+	var stdout, stderr bytes.Buffer
+	if code := doFederationCities(remotes, &stdout, &stderr); code != 0 {
+		t.Fatalf("cities code = %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "box     metro") {
+		t.Errorf("cities output:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := doFederationStatus(remotes, &stdout, &stderr); code != 0 {
+		t.Fatalf("status code = %d, stderr: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("status output:\n%s", stdout.String())
+	}
+	if f := strings.Fields(lines[1]); len(f) != 4 || f[0] != "box/metro" || f[2] != "1/1" || f[3] != "2" {
+		t.Errorf("status row = %q, want box/metro ... 1/1 2", lines[1])
+	}
+}
+
+func TestFederationSling(t *testing.T) {
+	var gotDir string
+	var gotArgs []string
+	remotes := newTestFederation(t, func(dir string, args ...string) ([]byte, error) {
+		gotDir, gotArgs = dir, args
+		if args[len(args)-1] == "gc-bad" {
+			return nil, errors.New("bead gc-bad not found")
+		}
+		return []byte("Slung gc-1 → mayor\n"), nil
+	})
+
+	var stdout, stderr bytes.Buffer
+	req := &federation.SlingRequest{City: "metro", Target: "mayor", Bead: "gc-1"}
+	if code := doFederationSling(remotes, "box", req, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, stderr: %s", code, stderr.String())
+	}
+	if stdout.String() != "Slung gc-1 → mayor\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	if filepath.Base(gotDir) != "metro" || strings.Join(gotArgs, " ") != "sling -- mayor gc-1" {
+		t.Errorf("ran gc %v in %s", gotArgs, gotDir)
+	}
+
+	stderr.Reset()
+	req.Bead = "gc-bad"
+	if code := doFederationSling(remotes, "box", req, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "bead gc-bad not found") {
+		t.Errorf("failed sling: code = %d, stderr = %q", code, stderr.String())
+	}
+
+	stderr.Reset()
+	req.City = "nowhere"
+	if code := doFederationSling(remotes, "box", req, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "not registered") {
+		t.Errorf("unknown city: code = %d, stderr = %q", code, stderr.String())
+	}
+	if code := doFederationSling(remotes, "elsewhere", req, &stdout, &stderr); code != 1 {
+		t.Errorf("unknown remote: code = %d", code)
+	}
+}
+
+func TestFederationSlingRejectsFlagValues(t *testing.T) {
+	ran := false
+	remotes := newTestFederation(t, func(string, ...string) ([]byte, error) {
+		ran = true
+		return nil, nil
+	})
+	for _, req := range []*federation.SlingRequest{
+		{City: "metro", Target: "mayor", Bead: "--from-file=/etc/passwd"},
+		{City: "metro", Target: "-q", Bead: "gc-1"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := doFederationSling(remotes, "box", req, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "must not start with") {
+			t.Errorf("sling %q %q: code = %d, stderr = %q", req.Target, req.Bead, code, stderr.String())
+		}
+	}
+	if ran {
+		t.Error("gc sling ran with a flag-like value")
+	}
+}
+
+func TestDialFederationRemotesRequiresTLS(t *testing.T) {
+	t.Setenv("GC_FEDERATION_TOKEN", "tok")
+	remotes := []supervisor.FederationRemote{{Name: "box", Address: "box:8373"}}
+	if _, _, err := dialFederationRemotes(remotes, false); err == nil || !strings.Contains(err.Error(), "--insecure") {
+		t.Errorf("dial without TLS = %v, want refusal naming --insecure", err)
+	}
+	got, closeAll, err := dialFederationRemotes(remotes, true)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("dial with --insecure = %v, %v", got, err)
+	}
+	closeAll()
+}
+
+func TestLoadTokenFileCreatesParent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "federation-token")
+	tok, err := loadTokenFile(path, false)
+	if err != nil || len(tok) != 64 {
+		t.Fatalf("token = %q, %v", tok, err)
+	}
+	again, _ := loadTokenFile(path, false)
+	if again != tok {
+		t.Errorf("second load = %q, want %q", again, tok)
+	}
+}
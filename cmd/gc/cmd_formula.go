@@ -0,0 +1,482 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/formula"
+	"github.com/spf13/cobra"
+)
+
+func newFormulaCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "formula",
+		Short: "Inspect, lint, and validate formulas",
+		Long: `Inspect the formulas available to gc sling and check formula files.
+
+Formulas are resolved across the formula layers (system, packs, city,
+rig) the same way gc start materializes them into .beads/formulas: for
+each filename the highest-priority layer wins.
+
+Cooking is normally done by bd. With the file or sqlite beads provider,
+gc cooks formulas from these layers itself: a molecule bead with one
+child bead per step, blocked on the steps it needs. Those providers
+also accept formulas written as *.formula.yaml; bd reads only TOML.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc formula: missing subcommand (list, show, lint, validate)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc formula: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(
+		newFormulaListCmd(stdout, stderr),
+		newFormulaShowCmd(stdout, stderr),
+		newFormulaLintCmd(stdout, stderr),
+		newFormulaValidateCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newFormulaListCmd(stdout, stderr io.Writer) *cobra.Command {
+	var rig string
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List available formulas",
+		Long: `List the formulas available for cooking, with the layer each one
+resolves from. Use --rig to see the formulas visible to a rig's agents.`,
+		Example: `  gc formula list
+  gc formula list --rig myrig
+  gc formula list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdFormulaList(rig, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&rig, "rig", "", "list formulas visible to this rig")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func newFormulaShowCmd(stdout, stderr io.Writer) *cobra.Command {
+	var rig string
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show a formula's variables and steps",
+		Long: `Show a formula's description, variables, and steps, with everything it
+extends merged in. Variables marked required must be passed with --var
+when the formula is slung.`,
+		Example: `  gc formula show mol-polecat-commit
+  gc formula show mol-do-work --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdFormulaShow(args[0], rig, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&rig, "rig", "", "resolve the formula as seen by this rig")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func newFormulaLintCmd(stdout, stderr io.Writer) *cobra.Command {
+	var rig string
+	cmd := &cobra.Command{
+		Use:   "lint [name-or-file...]",
+		Short: "Check formulas for errors before slinging",
+		Long: `Check formulas for problems that otherwise surface only when a cook
+fails: parse errors, unknown extends, missing or duplicate step IDs,
+needs that reference unknown steps, dependency cycles, and {{var}}
+placeholders with no matching [vars] declaration.
+
+Arguments are formula names or paths to *.formula.toml or
+*.formula.yaml files. With no
+arguments, every available formula is checked. Exits 1 if any problem
+is found.`,
+		Example: `  gc formula lint
+  gc formula lint mol-polecat-commit
+  gc formula lint ./formulas/my-work.formula.toml`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdFormulaLint(args, rig, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&rig, "rig", "", "resolve formulas as seen by this rig")
+	return cmd
+}
+
+func newFormulaValidateCmd(stdout, stderr io.Writer) *cobra.Command {
+	var rig string
+	cmd := &cobra.Command{
+		Use:   "validate [name-or-file...]",
+		Short: "Check formula files against the formula schema",
+		Long: `Check formula files against the formula schema
+(docs/schema/formula-schema.json): the file must parse, set formula to
+its filename, give every step an id, and use only known keys inside
+[vars.*] and [[steps]]. Unknown top-level keys are allowed.
+
+Validate checks each file on its own; use gc formula lint to check
+steps, needs, and variables with extends resolved. Arguments are
+formula names or file paths; with none, every available formula is
+checked. Exits 1 if any problem is found.`,
+		Example: `  gc formula validate
+  gc formula validate ./formulas/my-work.formula.toml`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdFormulaValidate(args, rig, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&rig, "rig", "", "resolve formula names as seen by this rig")
+	return cmd
+}
+
+// loadFormulaEntries resolves the city and returns the formulas visible
+// to rig (or the city when rig is empty).
+func loadFormulaEntries(rig string, stderr io.Writer, cmdName string) ([]formula.Entry, int) {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, 1
+	}
+	layers, err := formulaLayersFor(cityPath, cfg, rig)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, 1
+	}
+	return formula.Scan(layers), 0
+}
+
+// formulaLayersFor returns the formula layers visible to a rig (or to the
+// city when rig is empty), lowest priority first. The materialized system
+// formulas dir is included as layer 0 when present, matching gc start.
+func formulaLayersFor(cityPath string, cfg *config.City, rig string) ([]string, error) {
+	layers := cityFormulaLayers(cityPath, cfg)
+	if rig != "" {
+		rl, ok := cfg.FormulaLayers.Rigs[rig]
+		if !ok {
+			if !slices.ContainsFunc(cfg.Rigs, func(r config.Rig) bool { return r.Name == rig }) {
+				return nil, fmt.Errorf("rig %q not found", rig)
+			}
+		} else if len(rl) > 0 {
+			layers = rl
+		}
+	}
+	sysDir := filepath.Join(cityPath, citylayout.SystemFormulasRoot)
+	if fi, err := os.Stat(sysDir); err == nil && fi.IsDir() && !slices.Contains(layers, sysDir) {
+		layers = append([]string{sysDir}, layers...)
+	}
+	return layers, nil
+}
+
+// --- gc formula list ---
+
+// FormulaListJSON is the JSON representation of one formula in gc formula list.
+type FormulaListJSON struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Path        string `json:"path"`
+	Layer       string `json:"layer"`
+}
+
+func cmdFormulaList(rig string, jsonOutput bool, stdout, stderr io.Writer) int {
+	entries, code := loadFormulaEntries(rig, stderr, "gc formula list")
+	if code != 0 {
+		return code
+	}
+	return doFormulaList(entries, jsonOutput, stdout, stderr)
+}
+
+// doFormulaList prints the resolved formulas. Files that fail to parse are
+// still listed, with the parse error in place of the description.
+func doFormulaList(entries []formula.Entry, jsonOutput bool, stdout, stderr io.Writer) int {
+	out := make([]FormulaListJSON, 0, len(entries))
+	for _, e := range entries {
+		item := FormulaListJSON{Name: e.Name, Path: e.Path, Layer: e.Layer}
+		if f, err := formula.Load(e.Path); err != nil {
+			item.Description = "(invalid: " + err.Error() + ")"
+		} else {
+			item.Description = firstLine(f.Description)
+		}
+		out = append(out, item)
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc formula list: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if len(out) == 0 {
+		fmt.Fprintln(stdout, "No formulas found.") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	fmt.Fprintf(stdout, "%-28s %-36s %s\n", "NAME", "LAYER", "DESCRIPTION") //nolint:errcheck // best-effort stdout
+	for _, f := range out {
+		desc := f.Description
+		if desc == "" {
+			desc = "-"
+		}
+		fmt.Fprintf(stdout, "%-28s %-36s %s\n", f.Name, f.Layer, desc) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
+
+// firstLine returns the first non-blank line of s, trimmed.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if t := strings.TrimSpace(line); t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+// --- gc formula show ---
+
+// FormulaShowJSON is the JSON representation of gc formula show.
+type FormulaShowJSON struct {
+	Name        string                 `json:"name"`
+	Path        string                 `json:"path"`
+	Description string                 `json:"description,omitempty"`
+	Extends     []string               `json:"extends,omitempty"`
+	Vars        map[string]formula.Var `json:"vars"`
+	Steps       []formula.Step         `json:"steps"`
+}
+
+func cmdFormulaShow(name, rig string, jsonOutput bool, stdout, stderr io.Writer) int {
+	entries, code := loadFormulaEntries(rig, stderr, "gc formula show")
+	if code != 0 {
+		return code
+	}
+	return doFormulaShow(entries, name, jsonOutput, stdout, stderr)
+}
+
+// doFormulaShow prints the expanded formula name. Accepts pre-scanned
+// entries for testability.
+func doFormulaShow(entries []formula.Entry, name string, jsonOutput bool, stdout, stderr io.Writer) int {
+	e, ok := formula.Find(entries, name)
+	if !ok {
+		fmt.Fprintf(stderr, "gc formula show: formula %q not found\n", name) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	f, err := formula.Expand(e.Path, entries)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc formula show: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if f.Vars == nil {
+		f.Vars = map[string]formula.Var{}
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(FormulaShowJSON{
+			Name:        e.Name,
+			Path:        e.Path,
+			Description: f.Description,
+			Extends:     f.Extends,
+			Vars:        f.Vars,
+			Steps:       f.Steps,
+		}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc formula show: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+
+	w := func(s string) { fmt.Fprintln(stdout, s) } //nolint:errcheck // best-effort stdout
+	w(fmt.Sprintf("Formula:     %s", e.Name))
+	w(fmt.Sprintf("Source:      %s", e.Path))
+	if len(f.Extends) > 0 {
+		w(fmt.Sprintf("Extends:     %s", strings.Join(f.Extends, ", ")))
+	}
+	if d := firstLine(f.Description); d != "" {
+		w(fmt.Sprintf("Description: %s", d))
+	}
+
+	w("")
+	if len(f.Vars) == 0 {
+		w("Variables:   (none)")
+	} else {
+		w("Variables:")
+		names := make([]string, 0, len(f.Vars))
+		for k := range f.Vars {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			v := f.Vars[k]
+			spec := "optional"
+			switch {
+			case v.Required:
+				spec = "required"
+			case v.Default != nil:
+				spec = fmt.Sprintf("default %q", fmt.Sprint(v.Default))
+			}
+			w(strings.TrimRight(fmt.Sprintf("  %-24s %-20s %s", k, spec, v.Description), " "))
+		}
+	}
+
+	w("")
+	w("Steps:")
+	for i, s := range f.Steps {
+		line := fmt.Sprintf("  %2d. %-24s %s", i+1, s.ID, s.Title)
+		if len(s.Needs) > 0 {
+			line += fmt.Sprintf("  (needs: %s)", strings.Join(s.Needs, ", "))
+		}
+		w(strings.TrimRight(line, " "))
+	}
+	return 0
+}
+
+// --- gc formula lint ---
+
+func cmdFormulaLint(args []string, rig string, stdout, stderr io.Writer) int {
+	entries, code := loadFormulaEntries(rig, stderr, "gc formula lint")
+	if code != 0 {
+		return code
+	}
+	return doFormulaLint(entries, args, stdout, stderr)
+}
+
+// doFormulaLint lints each target, or every entry when targets is empty.
+func doFormulaLint(entries []formula.Entry, targets []string, stdout, stderr io.Writer) int {
+	todo, ok := formulaTargets(entries, targets, "gc formula lint", stderr)
+	if !ok {
+		return 1
+	}
+	return checkFormulas(todo, func(t formulaTarget) []string {
+		f, err := formula.Expand(t.path, t.entries)
+		if err != nil {
+			return []string{err.Error()}
+		}
+		return formula.Lint(f)
+	}, "gc formula lint", stdout, stderr)
+}
+
+// --- gc formula validate ---
+
+func cmdFormulaValidate(args []string, rig string, stdout, stderr io.Writer) int {
+	entries, code := loadFormulaEntries(rig, stderr, "gc formula validate")
+	if code != 0 {
+		return code
+	}
+	return doFormulaValidate(entries, args, stdout, stderr)
+}
+
+// doFormulaValidate checks each target against the formula schema, or
+// every entry when targets is empty.
+func doFormulaValidate(entries []formula.Entry, targets []string, stdout, stderr io.Writer) int {
+	todo, ok := formulaTargets(entries, targets, "gc formula validate", stderr)
+	if !ok {
+		return 1
+	}
+	return checkFormulas(todo, func(t formulaTarget) []string {
+		return formula.Validate(t.path)
+	}, "gc formula validate", stdout, stderr)
+}
+
+// formulaTarget is one formula file to check, with the entries used to
+// resolve what it extends.
+type formulaTarget struct {
+	label   string
+	path    string
+	entries []formula.Entry
+}
+
+// formulaTargets resolves command arguments to formula files. A target
+// that names an existing file is read from disk, with sibling formulas in
+// its directory taking precedence over the layers when resolving extends;
+// otherwise it is looked up by formula name. No targets means every entry.
+func formulaTargets(entries []formula.Entry, targets []string, cmdName string, stderr io.Writer) ([]formulaTarget, bool) {
+	var todo []formulaTarget
+	if len(targets) == 0 {
+		for _, e := range entries {
+			todo = append(todo, formulaTarget{label: e.Name, path: e.Path, entries: entries})
+		}
+	}
+	for _, t := range targets {
+		if fi, err := os.Stat(t); err == nil && !fi.IsDir() {
+			local := formula.Scan([]string{filepath.Dir(t)})
+			todo = append(todo, formulaTarget{label: t, path: t, entries: mergeFormulaEntries(entries, local)})
+			continue
+		}
+		e, ok := formula.Find(entries, t)
+		if !ok {
+			fmt.Fprintf(stderr, "%s: formula %q not found\n", cmdName, t) //nolint:errcheck // best-effort stderr
+			return nil, false
+		}
+		todo = append(todo, formulaTarget{label: e.Name, path: e.Path, entries: entries})
+	}
+	return todo, true
+}
+
+// checkFormulas runs check on each target and prints an ok/FAIL line per
+// formula with its problems. Returns 1 if any formula has problems.
+func checkFormulas(todo []formulaTarget, check func(formulaTarget) []string, cmdName string, stdout, stderr io.Writer) int {
+	if len(todo) == 0 {
+		fmt.Fprintln(stdout, "No formulas found.") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	failed := 0
+	for _, t := range todo {
+		problems := check(t)
+		if len(problems) == 0 {
+			fmt.Fprintf(stdout, "ok    %s\n", t.label) //nolint:errcheck // best-effort stdout
+			continue
+		}
+		failed++
+		fmt.Fprintf(stdout, "FAIL  %s\n", t.label) //nolint:errcheck // best-effort stdout
+		for _, p := range problems {
+			fmt.Fprintf(stdout, "      %s\n", p) //nolint:errcheck // best-effort stdout
+		}
+	}
+	if failed > 0 {
+		fmt.Fprintf(stderr, "%s: %d of %d formulas have problems\n", cmdName, failed, len(todo)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return 0
+}
+
+// mergeFormulaEntries returns base with override's entries replacing any
+// of the same name.
+func mergeFormulaEntries(base, override []formula.Entry) []formula.Entry {
+	out := make([]formula.Entry, 0, len(base)+len(override))
+	for _, e := range base {
+		if _, ok := formula.Find(override, e.Name); !ok {
+			out = append(out, e)
+		}
+	}
+	return append(out, override...)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/formula"
+)
+
+func writeFormula(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name+formula.Suffix)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const baseFormula = `formula = "base"
+description = "Base workflow\nwith details"
+
+[vars.issue]
+description = "The bead to work"
+required = true
+
+[vars.branch]
+description = "Target branch"
+default = "main"
+
+[[steps]]
+id = "load"
+title = "Load context for {{issue}}"
+
+[[steps]]
+id = "work"
+title = "Do the work on {{branch}}"
+needs = ["load"]
+`
+
+const childFormula = `formula = "child"
+extends = ["base"]
+
+[vars.branch]
+default = "develop"
+
+[[steps]]
+id = "work"
+title = "Commit to {{branch}}"
+needs = ["load"]
+
+[[steps]]
+id = "push"
+title = "Push"
+needs = ["work"]
+`
+
+func TestDoFormulaShow(t *testing.T) {
+	dir := t.TempDir()
+	writeFormula(t, dir, "base", baseFormula)
+	writeFormula(t, dir, "child", childFormula)
+	entries := formula.Scan([]string{dir})
+
+	var stdout, stderr bytes.Buffer
+	if code := doFormulaShow(entries, "child", false, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, stderr = %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"Extends:     base",
+		"Description: Base workflow",
+		`branch                   default "develop"`,
+		"issue                    required             The bead to work",
+		"3. push                     Push  (needs: work)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	stdout.Reset()
+	if code := doFormulaShow(entries, "child", true, &stdout, &stderr); code != 0 {
+		t.Fatalf("json code = %d", code)
+	}
+	var js FormulaShowJSON
+	if err := json.Unmarshal(stdout.Bytes(), &js); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, stdout.String())
+	}
+	if js.Name != "child" || len(js.Steps) != 3 || len(js.Vars) != 2 {
+		t.Errorf("json = %+v", js)
+	}
+
+	if code := doFormulaShow(entries, "nope", false, &stdout, &stderr); code != 1 {
+		t.Errorf("missing formula code = %d, want 1", code)
+	}
+}
+
+func TestDoFormulaList(t *testing.T) {
+	dir := t.TempDir()
+	writeFormula(t, dir, "base", baseFormula)
+	writeFormula(t, dir, "broken", "formula = [")
+
+	var stdout, stderr bytes.Buffer
+	if code := doFormulaList(formula.Scan([]string{dir}), false, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("lines = %q", lines)
+	}
+	if !strings.HasPrefix(lines[1], "base ") || !strings.HasSuffix(lines[1], "Base workflow") {
+		t.Errorf("base line = %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "(invalid: ") {
+		t.Errorf("broken line = %q", lines[2])
+	}
+}
+
+func TestDoFormulaLint(t *testing.T) {
+	dir := t.TempDir()
+	writeFormula(t, dir, "base", baseFormula)
+	writeFormula(t, dir, "bad", "formula = \"bad\"\n[[steps]]\nid = \"x\"\ntitle = \"{{who}}\"\n")
+	entries := formula.Scan([]string{dir})
+
+	var stdout, stderr bytes.Buffer
+	if code := doFormulaLint(entries, nil, &stdout, &stderr); code != 1 {
+		t.Errorf("code = %d, want 1", code)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "FAIL  bad\n      step \"x\" uses undeclared variable {{who}}") || !strings.Contains(out, "ok    base") {
+		t.Errorf("output:\n%s", out)
+	}
+
+	// A file outside the layers resolves extends from its own directory
+	// first, then from the layers.
+	local := t.TempDir()
+	path := writeFormula(t, local, "child", childFormula)
+	stdout.Reset()
+	if code := doFormulaLint(entries, []string{path}, &stdout, &stderr); code != 0 {
+		t.Errorf("file lint code = %d, output:\n%s", code, stdout.String())
+	}
+
+	if code := doFormulaLint(entries, []string{"nope"}, &stdout, &stderr); code != 1 {
+		t.Errorf("unknown name code = %d, want 1", code)
+	}
+}
+
+func TestDoFormulaValidate(t *testing.T) {
+	dir := t.TempDir()
+	writeFormula(t, dir, "base", baseFormula)
+	writeFormula(t, dir, "renamed", "formula = \"other\"\n[[steps]]\nid = \"a\"\nneed = [\"b\"]\n")
+	entries := formula.Scan([]string{dir})
+
+	var stdout, stderr bytes.Buffer
+	if code := doFormulaValidate(entries, nil, &stdout, &stderr); code != 1 {
+		t.Errorf("code = %d, want 1", code)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"ok    base",
+		"FAIL  renamed",
+		`formula = "other" does not match filename (want "renamed")`,
+		`unknown key "steps.need"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(stderr.String(), "1 of 2 formulas have problems") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestWithLocalCook(t *testing.T) {
+	cityPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cityPath, "city.toml"), []byte("[workspace]\nname = \"test\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeFormula(t, filepath.Join(cityPath, "formulas"), "base", baseFormula)
+
+	mem := beads.NewMemStore()
+	store := withLocalCook(mem, cityPath)
+	rootID, err := store.MolCook("base", "", []string{"issue=BL-7"})
+	if err != nil {
+		t.Fatalf("MolCook: %v", err)
+	}
+	steps, err := mem.Children(rootID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 2 || steps[0].Title != "Load context for BL-7" {
+		t.Errorf("steps = %+v", steps)
+	}
+}
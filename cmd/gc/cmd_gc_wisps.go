@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+func newGCWispsCmd(stdout, stderr io.Writer) *cobra.Command {
+	var ttl string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "gc-wisps",
+		Short: "Purge expired wisps now",
+		Long: `Run wisp garbage collection once, as the controller does every
+[daemon] wisp_gc_interval.
+
+Removes closed molecules (and their steps) and closed automation-tracking
+beads that closed longer ago than the TTL, unless the bead they are
+attached to is still open. With [daemon] wisp_archive = true they are
+written to .gc/archive first.
+
+The TTL defaults to [daemon] wisp_ttl; --ttl overrides it and is required
+when wisp_ttl is unset.`,
+		Example: `  gc gc-wisps --dry-run
+  gc gc-wisps --ttl 48h`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdGCWisps(ttl, dryRun, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&ttl, "ttl", "", "purge wisps closed longer ago than this (default: [daemon] wisp_ttl)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list the wisps that would be purged without removing them")
+	return cmd
+}
+
+// cmdGCWisps is the CLI entry point for a manual wisp GC run.
+func cmdGCWisps(ttlFlag string, dryRun bool, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc gc-wisps: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc gc-wisps: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	ttl := cfg.Daemon.WispTTLDuration()
+	if ttlFlag != "" {
+		if ttl, err = parsePruneDuration(ttlFlag); err != nil {
+			fmt.Fprintf(stderr, "gc gc-wisps: --ttl: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	if ttl <= 0 {
+		fmt.Fprintln(stderr, "gc gc-wisps: no TTL: set [daemon] wisp_ttl or pass --ttl") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc gc-wisps: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	archiveDir := ""
+	if cfg.Daemon.WispArchive {
+		archiveDir = beadArchiveDir(cityPath)
+	}
+	return doGCWisps(store, fsys.OSFS{}, archiveDir, time.Now().Add(-ttl), dryRun, stdout, stderr)
+}
+
+// doGCWisps purges the wisps expiredWisps selects at cutoff, or lists them
+// when dryRun is set.
+func doGCWisps(store beads.Store, fs fsys.FS, archiveDir string, cutoff time.Time, dryRun bool, stdout, stderr io.Writer) int {
+	wisps, err := expiredWisps(store, cutoff)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc gc-wisps: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if len(wisps) == 0 {
+		fmt.Fprintln(stdout, "No expired wisps.") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if dryRun {
+		for _, b := range wisps {
+			fmt.Fprintf(stdout, "%s  %-10s  %s\n", b.ID, b.Type, b.Title) //nolint:errcheck // best-effort stdout
+		}
+		fmt.Fprintf(stdout, "Would purge %d bead(s).\n", len(wisps)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	n, err := purgeWisps(store, fs, archiveDir, wisps)
+	if n > 0 {
+		fmt.Fprintf(stdout, "Purged %d bead(s).\n", n) //nolint:errcheck // best-effort stdout
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc gc-wisps: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return 0
+}
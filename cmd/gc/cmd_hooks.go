@@ -0,0 +1,297 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/git"
+	"github.com/spf13/cobra"
+)
+
+func newHooksCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Install hooks that connect outside tools to the city",
+		Long: `Install hooks that connect outside tools to the city.
+
+Agent runtime hooks are installed by "gc start" from install_agent_hooks
+in city.toml. This command installs the hooks gc cannot place itself,
+such as git hooks in a repository.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc hooks: missing subcommand (install)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc hooks: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(
+		newHooksInstallCmd(stdout, stderr),
+		newHooksCommitMsgCmd(stdout, stderr),
+		newHooksPostCommitCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newHooksInstallCmd(stdout, stderr io.Writer) *cobra.Command {
+	var gitFlag bool
+	var dir string
+	cmd := &cobra.Command{
+		Use:   "install --git",
+		Short: "Install git hooks that link commits to beads",
+		Long: `Install commit-msg and post-commit hooks into a git repository.
+
+After each commit, the post-commit hook finds bead IDs in the commit
+message (e.g. "Fix login (gc-12)") and attaches the commit SHA to each
+bead, so "gc bead show" links the bead to the code. The commit-msg hook
+warns about IDs that match no bead. Neither hook ever blocks a commit.
+
+Only IDs using the city's bead prefixes are recognized. Existing hooks
+not written by gc are left alone and reported. Rerunning refreshes the
+gc hooks.`,
+		Example: `  gc hooks install --git
+  gc hooks install --git --dir ~/src/myrig`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdHooksInstall(gitFlag, dir, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&gitFlag, "git", false, "Install git commit-msg and post-commit hooks")
+	cmd.Flags().StringVar(&dir, "dir", "", "Git repository to install into (default: current directory)")
+	return cmd
+}
+
+func newHooksCommitMsgCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:    "commit-msg <message-file>",
+		Short:  "Warn about unknown bead IDs in a commit message",
+		Long:   "Warn about bead IDs in a commit message that match no bead. Used by the git commit-msg hook.",
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdHooksCommitMsg(args[0], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newHooksPostCommitCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:    "post-commit",
+		Short:  "Record the HEAD commit on the beads it mentions",
+		Long:   "Attach the HEAD commit to each bead its message mentions. Used by the git post-commit hook.",
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdHooksPostCommit(stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// cmdHooksInstall is the CLI entry point for gc hooks install.
+func cmdHooksInstall(gitFlag bool, dir string, stdout, stderr io.Writer) int {
+	if !gitFlag {
+		fmt.Fprintln(stderr, "gc hooks install: nothing to install (use --git); agent hooks come from install_agent_hooks") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc hooks install: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if dir == "" {
+		dir, err = os.Getwd()
+	} else {
+		dir, err = filepath.Abs(dir)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc hooks install: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	hooksDir, err := git.New(dir).HooksDir()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc hooks install: %s is not a git repository: %v\n", dir, err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doHooksInstallGit(hooksDir, cityPath, stdout, stderr)
+}
+
+// doHooksInstallGit installs the gc git hooks into hooksDir and reports
+// which hooks were kept because they were not written by gc.
+func doHooksInstallGit(hooksDir, cityPath string, stdout, stderr io.Writer) int {
+	skipped, err := installGitHooks(hooksDir, cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc hooks install: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	for _, name := range gitHooks {
+		if slices.Contains(skipped, name) {
+			fmt.Fprintf(stderr, "gc hooks install: kept existing %s hook; add this line to it to link commits:\n  %s\n", //nolint:errcheck // best-effort stderr
+				name, gitHookCommand(name, cityPath))
+			continue
+		}
+		fmt.Fprintf(stdout, "Installed %s hook in %s\n", name, hooksDir) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
+
+// cmdHooksCommitMsg is the CLI entry point for the commit-msg hook.
+func cmdHooksCommitMsg(msgFile string, stdout, stderr io.Writer) int {
+	data, err := os.ReadFile(msgFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc hooks commit-msg: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	storeFor, prefixes, code := commitHookStores(stderr, "gc hooks commit-msg")
+	if storeFor == nil {
+		return code
+	}
+	return doHooksCommitMsg(storeFor, prefixes, stripCommitComments(string(data)), stdout, stderr)
+}
+
+// doHooksCommitMsg warns about each bead ID in msg that matches no bead.
+// Always succeeds: a typo in a reference should not block the commit.
+func doHooksCommitMsg(storeFor func(id string) (beads.Store, error), prefixes []string, msg string, _, stderr io.Writer) int {
+	for _, id := range beadRefs(msg, prefixes) {
+		store, err := storeFor(id)
+		if err != nil {
+			continue
+		}
+		if _, err := store.Get(id); errors.Is(err, beads.ErrNotFound) {
+			fmt.Fprintf(stderr, "gc: commit message mentions %s, but no such bead exists\n", id) //nolint:errcheck // best-effort stderr
+		}
+	}
+	return 0
+}
+
+// cmdHooksPostCommit is the CLI entry point for the post-commit hook.
+func cmdHooksPostCommit(stdout, stderr io.Writer) int {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc hooks post-commit: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	sha, msg, err := git.New(cwd).HeadCommit()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc hooks post-commit: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	storeFor, prefixes, code := commitHookStores(stderr, "gc hooks post-commit")
+	if storeFor == nil {
+		return code
+	}
+	return doHooksPostCommit(storeFor, prefixes, sha, msg, eventActor(), stdout, stderr)
+}
+
+// doHooksPostCommit attaches commit sha to every existing bead mentioned
+// in msg. IDs that match no bead are ignored; the commit-msg hook has
+// already warned about them.
+func doHooksPostCommit(storeFor func(id string) (beads.Store, error), prefixes []string, sha, msg, author string, stdout, stderr io.Writer) int {
+	code := 0
+	for _, id := range beadRefs(msg, prefixes) {
+		store, err := storeFor(id)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc hooks post-commit: %s: %v\n", id, err) //nolint:errcheck // best-effort stderr
+			code = 1
+			continue
+		}
+		_, err = store.Attach(id, beads.Attachment{Kind: beads.AttachCommit, Ref: sha, Author: author})
+		if errors.Is(err, beads.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "gc hooks post-commit: %s: %v\n", id, err) //nolint:errcheck // best-effort stderr
+			code = 1
+			continue
+		}
+		fmt.Fprintf(stdout, "gc: linked commit %.7s to %s\n", sha, id) //nolint:errcheck // best-effort stdout
+	}
+	return code
+}
+
+// commitHookStores resolves the city and returns a function opening the
+// store that holds a given bead ID, plus the bead prefixes in use.
+func commitHookStores(stderr io.Writer, cmdName string) (func(id string) (beads.Store, error), []string, int) {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, nil, 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+		return nil, nil, 1
+	}
+	storeFor := func(id string) (beads.Store, error) {
+		return openRigStoreAt(cityPath, rigDirForBead(cfg, id))
+	}
+	return storeFor, cityBeadPrefixes(cfg, cityPath), 0
+}
+
+// cityBeadPrefixes returns the bead ID prefixes a city's stores use: the
+// built-in stores' "gc", the city's own prefix, and each rig's prefix.
+func cityBeadPrefixes(cfg *config.City, cityPath string) []string {
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	prefixes := []string{"gc", strings.ToLower(config.DeriveBeadsPrefix(cityName))}
+	for i := range cfg.Rigs {
+		prefixes = append(prefixes, strings.ToLower(cfg.Rigs[i].EffectivePrefix()))
+	}
+	slices.Sort(prefixes)
+	return slices.Compact(prefixes)
+}
+
+// beadRefs returns the distinct bead IDs with one of prefixes that appear
+// in msg, in order of first mention. Matching ignores case; IDs are
+// returned as written.
+func beadRefs(msg string, prefixes []string) []string {
+	var alts []string
+	for _, p := range prefixes {
+		if p != "" {
+			alts = append(alts, regexp.QuoteMeta(p))
+		}
+	}
+	if len(alts) == 0 {
+		return nil
+	}
+	re := regexp.MustCompile(`(?i)\b(?:` + strings.Join(alts, "|") + `)-[0-9a-z]+(?:\.[0-9]+)*\b`)
+	var ids []string
+	for _, id := range re.FindAllString(msg, -1) {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// stripCommitComments drops the "#" comment lines git adds to the commit
+// message template.
+func stripCommitComments(msg string) string {
+	var kept []string
+	for _, line := range strings.Split(msg, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
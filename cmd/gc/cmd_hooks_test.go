@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func TestBeadRefs(t *testing.T) {
+	msg := "Fix login (gc-12, HW-7.2)\n\nCloses gc-12. Follow-up in hw-9; see xgc-3 and fe-1."
+	got := beadRefs(msg, []string{"gc", "hw"})
+	want := []string{"gc-12", "HW-7.2", "hw-9"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("beadRefs = %v, want %v", got, want)
+	}
+	if got := beadRefs(msg, nil); got != nil {
+		t.Errorf("beadRefs(no prefixes) = %v, want nil", got)
+	}
+}
+
+func TestCityBeadPrefixes(t *testing.T) {
+	cfg := &config.City{
+		Workspace: config.Workspace{Name: "metro"},
+		Rigs:      []config.Rig{{Name: "hello-world"}, {Name: "api", Prefix: "GC"}},
+	}
+	got := cityBeadPrefixes(cfg, "/cities/metro")
+	want := []string{"gc", "hw", "me"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cityBeadPrefixes = %v, want %v", got, want)
+	}
+}
+
+func TestDoHooksPostCommit(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "fix login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	storeFor := func(string) (beads.Store, error) { return store, nil }
+	sha := "3f9c2ab81d07e4aa000000000000000000000000"
+
+	var stdout, stderr bytes.Buffer
+	msg := "Fix login\n\nCloses " + b.ID + ", unrelated to gc-99."
+	if code := doHooksPostCommit(storeFor, []string{"gc"}, sha, msg, "polecat", &stdout, &stderr); code != 0 {
+		t.Fatalf("doHooksPostCommit = %d; stderr: %s", code, stderr.String())
+	}
+	got, _ := store.Get(b.ID)
+	if len(got.Attachments) != 1 || got.Attachments[0].Kind != beads.AttachCommit || got.Attachments[0].Ref != sha || got.Attachments[0].Author != "polecat" {
+		t.Errorf("Attachments = %+v", got.Attachments)
+	}
+	if !strings.Contains(stdout.String(), "linked commit 3f9c2ab to "+b.ID) {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	if strings.Contains(stdout.String(), "gc-99") {
+		t.Errorf("reported link to missing bead: %q", stdout.String())
+	}
+
+	// Running the hook again for the same commit adds nothing.
+	doHooksPostCommit(storeFor, []string{"gc"}, sha, msg, "polecat", &stdout, &stderr)
+	got, _ = store.Get(b.ID)
+	if len(got.Attachments) != 1 {
+		t.Errorf("rerun added duplicate attachment: %+v", got.Attachments)
+	}
+}
+
+func TestDoHooksCommitMsg(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "fix login"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	storeFor := func(string) (beads.Store, error) { return store, nil }
+
+	var stdout, stderr bytes.Buffer
+	msg := stripCommitComments("Closes " + b.ID + " and gc-99\n# Please enter the commit message (gc-42)\n")
+	if code := doHooksCommitMsg(storeFor, []string{"gc"}, msg, &stdout, &stderr); code != 0 {
+		t.Fatalf("doHooksCommitMsg = %d", code)
+	}
+	if !strings.Contains(stderr.String(), "mentions gc-99, but no such bead exists") {
+		t.Errorf("stderr = %q, want warning for gc-99", stderr.String())
+	}
+	if strings.Contains(stderr.String(), b.ID+",") || strings.Contains(stderr.String(), "gc-42") {
+		t.Errorf("unexpected warning: %q", stderr.String())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -22,7 +23,7 @@ import (
 // for non-interactive paths). doInit uses it to decide which config to write.
 type wizardConfig struct {
 	interactive      bool   // true if the wizard ran with user interaction
-	configName       string // "tutorial" or "custom"
+	configName       string // "tutorial", "custom", or a built-in template name
 	provider         string // built-in provider key, or "" if startCommand set
 	startCommand     string // custom start command (workspace-level)
 	bootstrapProfile string // hosted bootstrap profile, or "" for local defaults
@@ -77,7 +78,10 @@ func runWizard(stdin io.Reader, stdout io.Writer) wizardConfig {
 	fmt.Fprintln(stdout, "Choose a config template:")                               //nolint:errcheck // best-effort stdout
 	fmt.Fprintln(stdout, "  1. tutorial  — default coding agent (default)")         //nolint:errcheck // best-effort stdout
 	fmt.Fprintln(stdout, "  2. custom    — empty workspace, configure it yourself") //nolint:errcheck // best-effort stdout
-	fmt.Fprintf(stdout, "Template [1]: ")                                           //nolint:errcheck // best-effort stdout
+	for i, t := range cityTemplates {
+		fmt.Fprintf(stdout, "  %d. %-9s — %s\n", i+3, t.name, t.summary) //nolint:errcheck // best-effort stdout
+	}
+	fmt.Fprintf(stdout, "Template [1]: ") //nolint:errcheck // best-effort stdout
 
 	configChoice := readLine(br)
 	configName := "tutorial"
@@ -88,6 +92,10 @@ func runWizard(stdin io.Reader, stdout io.Writer) wizardConfig {
 	case "2", "custom":
 		configName = "custom"
 	default:
+		if name := resolveTemplateChoice(configChoice); name != "" {
+			configName = name
+			break
+		}
 		fmt.Fprintf(stdout, "Unknown template %q, using tutorial.\n", configChoice) //nolint:errcheck // best-effort stdout
 	}
 
@@ -137,7 +145,7 @@ func runWizard(stdin io.Reader, stdout io.Writer) wizardConfig {
 
 	return wizardConfig{
 		interactive:  true,
-		configName:   "tutorial",
+		configName:   configName,
 		provider:     provider,
 		startCommand: startCommand,
 	}
@@ -169,6 +177,8 @@ func newInitCmd(stdout, stderr io.Writer) *cobra.Command {
 	var fromFlag string
 	var providerFlag string
 	var bootstrapProfileFlag string
+	var templateFlag string
+	var varFlags []string
 	cmd := &cobra.Command{
 		Use:   "init [path]",
 		Short: "Initialize a new city",
@@ -178,16 +188,37 @@ Runs an interactive wizard to choose a config template and coding agent
 provider. Creates the .gc/ runtime directory, default
 prompts and formulas, and writes city.toml. Use --provider to create the
 default mayor city non-interactively, or --file to initialize from an
-existing TOML config file.`,
+existing TOML config file.
+
+Use --template to start from a built-in city instead of the tutorial:
+  solo-dev   mayor + reviewer for a single developer
+  gastown    mayor + witness, refinery, and polecat pool per rig
+  monorepo   mayor + one worker per repository directory
+Each template writes a pack under packs/<name>/ with its agents, prompts,
+and formulas, and a city.toml that includes it. Combine with --provider
+to pick the coding agent.
+
+Use --from to copy a city skeleton from a directory or a git repository
+(git@, ssh://, https://, or file:// URLs, with optional //subdir and
+#ref as for remote pack includes). {{gc.key}} placeholders in the copied
+files are filled in: {{gc.city_name}} and {{gc.city_path}} are built in,
+and --var key=value supplies the rest, such as rig paths. Init stops
+before copying anything if a placeholder has no value.`,
 		Example: `  gc init
   gc init ~/my-city
   gc init --provider codex ~/my-city
   gc init --provider codex --bootstrap-profile k8s-cell /city
-  gc init --file examples/gastown.toml ~/bright-lights`,
+  gc init --template gastown --provider claude ~/gas-town
+  gc init --file examples/gastown.toml ~/bright-lights
+  gc init --from git@github.com:org/city-template.git --var app_path=~/src/app ~/metro`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
+			if len(varFlags) > 0 && fromFlag == "" {
+				fmt.Fprintln(stderr, "gc init: --var requires --from") //nolint:errcheck // best-effort stderr
+				return errExit
+			}
 			if fromFlag != "" {
-				if cmdInitFromDir(fromFlag, args, stdout, stderr) != 0 {
+				if cmdInitFromDir(fromFlag, args, varFlags, stdout, stderr) != 0 {
 					return errExit
 				}
 				return nil
@@ -198,21 +229,25 @@ existing TOML config file.`,
 				}
 				return nil
 			}
-			if cmdInit(args, providerFlag, bootstrapProfileFlag, stdout, stderr) != 0 {
+			if cmdInit(args, providerFlag, bootstrapProfileFlag, templateFlag, stdout, stderr) != 0 {
 				return errExit
 			}
 			return nil
 		},
 	}
 	cmd.Flags().StringVar(&fileFlag, "file", "", "path to a TOML file to use as city.toml")
-	cmd.Flags().StringVar(&fromFlag, "from", "", "path to an example city directory to copy")
+	cmd.Flags().StringVar(&fromFlag, "from", "", "example city directory or git repository to copy")
 	cmd.Flags().StringVar(&providerFlag, "provider", "", "built-in workspace provider to use for the default mayor config")
 	cmd.Flags().StringVar(&bootstrapProfileFlag, "bootstrap-profile", "", "bootstrap profile to apply for hosted/container defaults")
+	cmd.Flags().StringVar(&templateFlag, "template", "", "built-in city template: "+strings.Join(cityTemplateNames(), ", "))
 	cmd.MarkFlagsMutuallyExclusive("file", "from")
 	cmd.MarkFlagsMutuallyExclusive("provider", "file")
 	cmd.MarkFlagsMutuallyExclusive("provider", "from")
 	cmd.MarkFlagsMutuallyExclusive("bootstrap-profile", "file")
 	cmd.MarkFlagsMutuallyExclusive("bootstrap-profile", "from")
+	cmd.MarkFlagsMutuallyExclusive("template", "file")
+	cmd.MarkFlagsMutuallyExclusive("template", "from")
+	cmd.Flags().StringArrayVar(&varFlags, "var", nil, "value for a {{gc.key}} placeholder in a --from template (key=value, repeatable)")
 	return cmd
 }
 
@@ -220,7 +255,7 @@ existing TOML config file.`,
 // Runs the interactive wizard to choose a config template and provider.
 // Creates the runtime scaffold and city.toml. If the bead provider is "bd", also
 // runs bd init.
-func cmdInit(args []string, providerFlag, bootstrapProfileFlag string, stdout, stderr io.Writer) int {
+func cmdInit(args []string, providerFlag, bootstrapProfileFlag, templateFlag string, stdout, stderr io.Writer) int {
 	var cityPath string
 	if len(args) > 0 {
 		var err error
@@ -239,9 +274,9 @@ func cmdInit(args []string, providerFlag, bootstrapProfileFlag string, stdout, s
 	}
 	var wiz wizardConfig
 	switch {
-	case providerFlag != "" || bootstrapProfileFlag != "":
+	case providerFlag != "" || bootstrapProfileFlag != "" || templateFlag != "":
 		var err error
-		wiz, err = initWizardConfig(providerFlag, bootstrapProfileFlag)
+		wiz, err = initWizardConfig(providerFlag, bootstrapProfileFlag, templateFlag)
 		if err != nil {
 			fmt.Fprintf(stderr, "gc init: %v\n", err) //nolint:errcheck // best-effort stderr
 			return 1
@@ -267,7 +302,7 @@ func cmdInit(args []string, providerFlag, bootstrapProfileFlag string, stdout, s
 	return 0
 }
 
-func initWizardConfig(providerFlag, bootstrapProfileFlag string) (wizardConfig, error) {
+func initWizardConfig(providerFlag, bootstrapProfileFlag, templateFlag string) (wizardConfig, error) {
 	provider, err := normalizeInitProvider(providerFlag)
 	if err != nil {
 		return wizardConfig{}, err
@@ -276,8 +311,15 @@ func initWizardConfig(providerFlag, bootstrapProfileFlag string) (wizardConfig,
 	if err != nil {
 		return wizardConfig{}, err
 	}
+	configName, err := normalizeInitTemplate(templateFlag)
+	if err != nil {
+		return wizardConfig{}, err
+	}
+	if configName == "" {
+		configName = "tutorial"
+	}
 	return wizardConfig{
-		configName:       "tutorial",
+		configName:       configName,
 		provider:         provider,
 		bootstrapProfile: bootstrapProfile,
 	}, nil
@@ -474,19 +516,27 @@ func doInit(fs fsys.FS, cityPath string, wiz wizardConfig, stdout, stderr io.Wri
 
 	// Write city.toml — wizard path gets one agent + provider/startCommand;
 	// --provider path gets the same city shape non-interactively;
-	// custom path gets one mayor + no provider (user configures manually).
+	// custom path gets one mayor + no provider (user configures manually);
+	// templates write their pack and its city.toml.
 	cityName := filepath.Base(cityPath)
-	var cfg config.City
-	switch {
-	case wiz.configName == "custom":
-		cfg = config.DefaultCity(cityName)
-	case wiz.provider != "" || wiz.startCommand != "":
-		cfg = config.WizardCity(cityName, wiz.provider, wiz.startCommand)
-	default:
-		cfg = config.DefaultCity(cityName)
+	tmpl, isTemplate := findCityTemplate(wiz.configName)
+	var content []byte
+	var err error
+	if isTemplate {
+		content, err = writeCityTemplate(fs, cityPath, wiz)
+	} else {
+		var cfg config.City
+		switch {
+		case wiz.configName == "custom":
+			cfg = config.DefaultCity(cityName)
+		case wiz.provider != "" || wiz.startCommand != "":
+			cfg = config.WizardCity(cityName, wiz.provider, wiz.startCommand)
+		default:
+			cfg = config.DefaultCity(cityName)
+		}
+		applyBootstrapProfile(&cfg, wiz.bootstrapProfile)
+		content, err = cfg.Marshal()
 	}
-	applyBootstrapProfile(&cfg, wiz.bootstrapProfile)
-	content, err := cfg.Marshal()
 	if err != nil {
 		fmt.Fprintf(stderr, "gc init: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
@@ -496,6 +546,22 @@ func doInit(fs fsys.FS, cityPath string, wiz wizardConfig, stdout, stderr io.Wri
 		return 1
 	}
 
+	if isTemplate {
+		// Resolve the template pack's formulas alongside the defaults.
+		expandedCfg, _, loadErr := config.LoadWithIncludes(fs, tomlPath)
+		if loadErr == nil && len(expandedCfg.FormulaLayers.City) > 0 {
+			if rfErr := ResolveFormulas(cityPath, expandedCfg.FormulaLayers.City); rfErr != nil {
+				fmt.Fprintf(stderr, "gc init: resolving formulas: %v\n", rfErr) //nolint:errcheck // best-effort stderr
+			}
+		}
+		fmt.Fprintln(stdout, "Welcome to Gas City!")                                            //nolint:errcheck // best-effort stdout
+		fmt.Fprintf(stdout, "Initialized city %q from the %s template.\n", cityName, tmpl.name) //nolint:errcheck // best-effort stdout
+		if tmpl.rigPack != "" {
+			fmt.Fprintf(stdout, "Add a rig to start its agents: gc rig add <path> --include %s\n", tmpl.rigPack) //nolint:errcheck // best-effort stdout
+		}
+		return 0
+	}
+
 	switch {
 	case wiz.interactive:
 		fmt.Fprintf(stdout, "Created %s config (Level 1) in %q.\n", wiz.configName, cityName) //nolint:errcheck // best-effort stdout
@@ -596,6 +662,9 @@ func writeDefaultFormulas(fs fsys.FS, cityPath string, stderr io.Writer) int {
 // but keeps legacy city-owned content so it can be remapped into visible roots.
 func initFromSkip(relPath string, isDir bool) bool {
 	top, rest, _ := strings.Cut(relPath, string(filepath.Separator))
+	if top == ".git" {
+		return true
+	}
 	if top == ".gc" {
 		// Let the walker enter .gc/ so it can reach legacy city-owned content.
 		if rest == "" {
@@ -617,7 +686,7 @@ func initFromSkip(relPath string, isDir bool) bool {
 
 // cmdInitFromDir initializes a city by copying an example directory.
 // Resolves source and target paths, validates, then delegates to doInitFromDir.
-func cmdInitFromDir(fromDir string, args []string, stdout, stderr io.Writer) int {
+func cmdInitFromDir(fromDir string, args, varFlags []string, stdout, stderr io.Writer) int {
 	var cityPath string
 	if len(args) > 0 {
 		var err error
@@ -634,6 +703,31 @@ func cmdInitFromDir(fromDir string, args []string, stdout, stderr io.Writer) int
 			return 1
 		}
 	}
+	vars, err := initVars(varFlags, cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc init: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	if config.IsRemoteSource(fromDir) {
+		tmp, err := os.MkdirTemp("", "gc-init-")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc init: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		defer os.RemoveAll(tmp)                         //nolint:errcheck // best-effort cleanup
+		fmt.Fprintf(stdout, "Cloning %s...\n", fromDir) //nolint:errcheck // best-effort stdout
+		// Clone into a directory named after the repo; its name shows in
+		// the "Initialized city" message.
+		repo, _, _ := strings.Cut(fromDir, "#")
+		repo = strings.TrimSuffix(path.Base(strings.TrimSuffix(repo, "/")), ".git")
+		srcDir, err := config.CloneSource(fromDir, filepath.Join(tmp, repo))
+		if err != nil {
+			fmt.Fprintf(stderr, "gc init --from: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		return doInitFromDir(srcDir, cityPath, vars, stdout, stderr)
+	}
 
 	srcDir, err := filepath.Abs(fromDir)
 	if err != nil {
@@ -641,12 +735,13 @@ func cmdInitFromDir(fromDir string, args []string, stdout, stderr io.Writer) int
 		return 1
 	}
 
-	return doInitFromDir(srcDir, cityPath, stdout, stderr)
+	return doInitFromDir(srcDir, cityPath, vars, stdout, stderr)
 }
 
 // doInitFromDir copies an example city directory to a new city path,
-// updates workspace.name, creates .gc/, and installs hooks.
-func doInitFromDir(srcDir, cityPath string, stdout, stderr io.Writer) int {
+// fills in {{gc.name}} placeholders from vars, updates workspace.name,
+// creates .gc/, and installs hooks.
+func doInitFromDir(srcDir, cityPath string, vars map[string]string, stdout, stderr io.Writer) int {
 	fs := fsys.OSFS{}
 	// Validate source has city.toml.
 	srcToml := filepath.Join(srcDir, "city.toml")
@@ -661,6 +756,17 @@ func doInitFromDir(srcDir, cityPath string, stdout, stderr io.Writer) int {
 		return 1
 	}
 
+	// Every placeholder needs a value before anything is copied.
+	varFiles, missing, err := scanInitVars(srcDir, vars)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc init --from: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(stderr, "gc init --from: template needs --var for: %s\n", strings.Join(missing, ", ")) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
 	// Create target directory if needed.
 	if err := fs.MkdirAll(cityPath, 0o755); err != nil {
 		fmt.Fprintf(stderr, "gc init: %v\n", err) //nolint:errcheck // best-effort stderr
@@ -672,6 +778,10 @@ func doInitFromDir(srcDir, cityPath string, stdout, stderr io.Writer) int {
 		fmt.Fprintf(stderr, "gc init --from: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
 	}
+	if err := applyInitVars(cityPath, varFiles, vars); err != nil {
+		fmt.Fprintf(stderr, "gc init --from: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
 	if err := normalizeInitFromLegacyContent(cityPath); err != nil {
 		fmt.Fprintf(stderr, "gc init --from: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
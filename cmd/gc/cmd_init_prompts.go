@@ -10,3 +10,9 @@ var defaultFormulas embed.FS
 
 //go:embed all:system_formulas
 var systemFormulasFS embed.FS
+
+//go:embed roles/*.md
+var rolePrompts embed.FS
+
+//go:embed init_templates
+var initTemplatesFS embed.FS
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// snapshotDir returns where a city's session snapshots are saved.
+func snapshotDir(cityPath string) string {
+	return citylayout.RuntimePath(cityPath, "crash")
+}
+
+// withSnapshots wraps sp so a session's last output is saved to
+// .gc/crash/ when it stops. Returns sp unchanged when the city path is
+// unknown or snapshot_lines is 0.
+func withSnapshots(sp runtime.Provider, cityPath string, sc config.SessionConfig) runtime.Provider {
+	lines := sc.SnapshotLinesOrDefault()
+	if cityPath == "" || lines <= 0 {
+		return sp
+	}
+	return snapshot.WrapProvider(sp, snapshot.Dir{
+		Path:  snapshotDir(cityPath),
+		Lines: lines,
+		Keep:  sc.SnapshotKeepOrDefault(),
+	})
+}
+
+func newLogsCmd(stdout, stderr io.Writer) *cobra.Command {
+	var follow bool
+	var since time.Duration
+	var lines int
+	var crashes bool
+	cmd := &cobra.Command{
+		Use:   "logs [agent]",
+		Short: "Show terminal output from an agent's session",
+		Long: `Show the scrollback of an agent's running session without attaching:
+tmux capture-pane for tmux sessions, the .gc/logs/<session>.log file for
+subprocess sessions.
+
+With --follow, new output is printed as it appears until the session
+exits or you press Ctrl-C.
+
+Scrollback has no per-line timestamps, so --since works at session
+granularity: the snapshot is skipped when the session has produced no
+output within the window. Providers that cannot report activity always
+show the snapshot. For the structured conversation transcript, use
+gc session logs.
+
+When a session stops or crashes, its last output is saved to
+.gc/crash/<session>-<timestamp>.log (see snapshot_lines and
+snapshot_keep under [session]). --crashes lists those snapshots, or
+with an agent prints the agent's most recent one.`,
+		Example: `  gc logs mayor
+  gc logs myrig/polecat-1 --follow
+  gc logs mayor --since 10m --lines 0
+  gc logs --crashes
+  gc logs myrig/polecat-1 --crashes`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			var agentName string
+			if len(args) > 0 {
+				agentName = args[0]
+			}
+			var code int
+			switch {
+			case crashes:
+				code = cmdLogsCrashes(agentName, stdout, stderr)
+			case agentName == "":
+				fmt.Fprintln(stderr, "gc logs: missing agent name") //nolint:errcheck // best-effort stderr
+				code = 1
+			default:
+				code = cmdLogs(agentName, follow, since, lines, stdout, stderr)
+			}
+			if code != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "keep printing new output")
+	cmd.Flags().DurationVar(&since, "since", 0, "only show output if the session was active this recently (e.g. 10m)")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 100, "number of scrollback lines to show (0 = all)")
+	cmd.Flags().BoolVar(&crashes, "crashes", false, "show output saved when sessions stopped or crashed")
+	return cmd
+}
+
+// logsPollInterval is how often --follow re-captures the session.
+const logsPollInterval = time.Second
+
+// cmdLogs is the CLI entry point for gc logs.
+func cmdLogs(agentName string, follow bool, since time.Duration, lines int, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc logs: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc logs: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	found, ok := resolveAgentIdentity(cfg, agentName, currentRigContext(cfg))
+	if !ok {
+		fmt.Fprintln(stderr, agentNotFoundMsg("gc logs", agentName, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	sn := cliSessionName(cityPath, cityName, found.QualifiedName(), cfg.Workspace.SessionTemplate)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return doLogs(ctx, newSessionProvider(), sn, lines, since, follow, logsPollInterval, time.Now, stdout, stderr)
+}
+
+// cmdLogsCrashes is the CLI entry point for gc logs --crashes.
+func cmdLogsCrashes(agentName string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc logs: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var sn string
+	if agentName != "" {
+		cfg, err := loadCityConfig(cityPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc logs: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		found, ok := resolveAgentIdentity(cfg, agentName, currentRigContext(cfg))
+		if !ok {
+			fmt.Fprintln(stderr, agentNotFoundMsg("gc logs", agentName, cfg)) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		cityName := cfg.Workspace.Name
+		if cityName == "" {
+			cityName = filepath.Base(cityPath)
+		}
+		sn = cliSessionName(cityPath, cityName, found.QualifiedName(), cfg.Workspace.SessionTemplate)
+	}
+	return doLogsCrashes(snapshotDir(cityPath), sn, stdout, stderr)
+}
+
+// doLogsCrashes lists the snapshots in dir or, when sn is set, prints
+// the newest snapshot of session sn.
+func doLogsCrashes(dir, sn string, stdout, stderr io.Writer) int {
+	snaps, err := snapshot.List(dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc logs: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if sn == "" {
+		if len(snaps) == 0 {
+			fmt.Fprintln(stdout, "No session snapshots.") //nolint:errcheck // best-effort stdout
+			return 0
+		}
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TIME\tSESSION\tREASON\tFILE") //nolint:errcheck // best-effort stdout
+		for _, s := range snaps {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.Time.Local().Format("2006-01-02 15:04:05"), s.Session, s.Reason, s.Path) //nolint:errcheck // best-effort stdout
+		}
+		tw.Flush() //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	for _, s := range snaps {
+		if s.Session != sn {
+			continue
+		}
+		data, err := os.ReadFile(s.Path)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc logs: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		stdout.Write(data) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	fmt.Fprintf(stderr, "gc logs: no snapshots for session %q\n", sn) //nolint:errcheck // best-effort stderr
+	return 1
+}
+
+// doLogs prints the session's scrollback and, when follow is set, polls
+// for new output until ctx is done or the session exits.
+func doLogs(ctx context.Context, sp runtime.Provider, sn string, lines int, since time.Duration, follow bool,
+	interval time.Duration, now func() time.Time, stdout, stderr io.Writer,
+) int {
+	if lines < 0 {
+		fmt.Fprintln(stderr, "gc logs: --lines must be >= 0") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if !sp.IsRunning(sn) {
+		fmt.Fprintf(stderr, "gc logs: session %q is not running\n", sn) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	out, err := sp.Peek(sn, lines)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc logs: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	prev := trimTrailingBlankLines(out)
+	if since <= 0 || activeSince(sp, sn, now().Add(-since)) {
+		writeLogChunk(stdout, prev)
+	} else {
+		fmt.Fprintf(stderr, "No output from %s in the last %s\n", sn, since) //nolint:errcheck // best-effort stderr
+	}
+	if !follow {
+		return 0
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+		}
+		if !sp.IsRunning(sn) {
+			fmt.Fprintf(stderr, "gc logs: session %q exited\n", sn) //nolint:errcheck // best-effort stderr
+			return 0
+		}
+		out, err := sp.Peek(sn, 0)
+		if err != nil {
+			continue // transient capture failure — retry next tick
+		}
+		cur := trimTrailingBlankLines(out)
+		writeLogChunk(stdout, newLogOutput(prev, cur))
+		prev = cur
+	}
+}
+
+// activeSince reports whether the session produced output at or after
+// cutoff. Sessions whose provider cannot report activity count as active.
+func activeSince(sp runtime.Provider, sn string, cutoff time.Time) bool {
+	if !sp.Capabilities().CanReportActivity {
+		return true
+	}
+	last, err := sp.GetLastActivity(sn)
+	if err != nil || last.IsZero() {
+		return true
+	}
+	return !last.Before(cutoff)
+}
+
+// newLogOutput returns the part of cur that follows prev. Scrollback is
+// re-captured on every poll, so the tail of prev is located in cur and
+// everything after it is new. When prev can't be found (the screen was
+// cleared or redrawn), all of cur is returned.
+func newLogOutput(prev, cur string) string {
+	if cur == prev {
+		return ""
+	}
+	if prev == "" {
+		return cur
+	}
+	if rest, ok := strings.CutPrefix(cur, prev); ok {
+		return strings.TrimPrefix(rest, "\n")
+	}
+	// Anchor on the last few lines of prev, shrinking the anchor when
+	// earlier lines have scrolled out of the captured history.
+	prevLines := strings.Split(prev, "\n")
+	curLines := strings.Split(cur, "\n")
+	for n := min(3, len(prevLines)); n > 0; n-- {
+		anchor := prevLines[len(prevLines)-n:]
+		for i := len(curLines) - n; i >= 0; i-- {
+			if slices.Equal(curLines[i:i+n], anchor) {
+				return strings.Join(curLines[i+n:], "\n")
+			}
+		}
+	}
+	return cur
+}
+
+// trimTrailingBlankLines drops the empty rows tmux pads a pane capture with.
+func trimTrailingBlankLines(s string) string {
+	return strings.TrimRight(s, " \t\n")
+}
+
+// writeLogChunk prints s followed by a newline, if non-empty.
+func writeLogChunk(w io.Writer, s string) {
+	if s == "" {
+		return
+	}
+	fmt.Fprintln(w, s) //nolint:errcheck // best-effort stdout
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/snapshot"
+)
+
+func startFakeSession(t *testing.T, sp *runtime.Fake, name string) {
+	t.Helper()
+	if err := sp.Start(context.Background(), name, runtime.Config{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDoLogsSnapshot(t *testing.T) {
+	sp := runtime.NewFake()
+	startFakeSession(t, sp, "city-mayor")
+	sp.SetPeekOutput("city-mayor", "line one\nline two\n\n\n")
+
+	var stdout, stderr bytes.Buffer
+	code := doLogs(context.Background(), sp, "city-mayor", 100, 0, false, time.Millisecond, time.Now, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("doLogs = %d; stderr: %s", code, stderr.String())
+	}
+	if got := stdout.String(); got != "line one\nline two\n" {
+		t.Errorf("stdout = %q", got)
+	}
+}
+
+func TestDoLogsNotRunning(t *testing.T) {
+	sp := runtime.NewFake()
+	var stdout, stderr bytes.Buffer
+	code := doLogs(context.Background(), sp, "city-mayor", 100, 0, false, time.Millisecond, time.Now, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("doLogs = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "not running") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestDoLogsSinceSkipsQuietSession(t *testing.T) {
+	sp := runtime.NewFake()
+	startFakeSession(t, sp, "city-mayor")
+	sp.SetPeekOutput("city-mayor", "old output")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sp.SetActivity("city-mayor", now.Add(-time.Hour))
+	clock := func() time.Time { return now }
+
+	var stdout, stderr bytes.Buffer
+	if code := doLogs(context.Background(), sp, "city-mayor", 100, 10*time.Minute, false, time.Millisecond, clock, &stdout, &stderr); code != 0 {
+		t.Fatalf("doLogs = %d", code)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty for quiet session", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "No output from city-mayor in the last 10m0s") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+
+	sp.SetActivity("city-mayor", now.Add(-time.Minute))
+	stdout.Reset()
+	doLogs(context.Background(), sp, "city-mayor", 100, 10*time.Minute, false, time.Millisecond, clock, &stdout, &stderr)
+	if stdout.String() != "old output\n" {
+		t.Errorf("stdout = %q, want snapshot for active session", stdout.String())
+	}
+}
+
+func TestDoLogsFollowStopsWhenSessionExits(t *testing.T) {
+	sp := runtime.NewFake()
+	startFakeSession(t, sp, "city-mayor")
+	sp.SetPeekOutput("city-mayor", "a")
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		sp.SetPeekOutput("city-mayor", "a\nb")
+		time.Sleep(20 * time.Millisecond)
+		_ = sp.Stop("city-mayor")
+	}()
+
+	var stdout, stderr bytes.Buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if code := doLogs(ctx, sp, "city-mayor", 100, 0, true, 5*time.Millisecond, time.Now, &stdout, &stderr); code != 0 {
+		t.Fatalf("doLogs = %d", code)
+	}
+	if stdout.String() != "a\nb\n" {
+		t.Errorf("stdout = %q, want a then b", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "exited") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestNewLogOutput(t *testing.T) {
+	tests := []struct {
+		name, prev, cur, want string
+	}{
+		{"unchanged", "a\nb", "a\nb", ""},
+		{"first capture", "", "a", "a"},
+		{"appended", "a\nb", "a\nb\nc", "c"},
+		{"scrolled", "a\nb\nc\nd", "c\nd\ne\nf", "e\nf"},
+		{"redrawn", "a\nb", "x\ny", "x\ny"},
+		{"partial snapshot", "c\nd", "a\nb\nc\nd\ne", "e"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newLogOutput(tt.prev, tt.cur); got != tt.want {
+				t.Errorf("newLogOutput(%q, %q) = %q, want %q", tt.prev, tt.cur, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoLogsCrashes(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "crash")
+	var stdout, stderr bytes.Buffer
+	if code := doLogsCrashes(dir, "", &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "No session snapshots") {
+		t.Fatalf("empty: code = %d, stdout = %q", code, stdout.String())
+	}
+
+	d := snapshot.Dir{Path: dir}
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, out := range []string{"first crash", "second crash"} {
+		if _, err := d.Save("city--worker", snapshot.ReasonCrash, out, t0.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stdout.Reset()
+	if code := doLogsCrashes(dir, "", &stdout, &stderr); code != 0 {
+		t.Fatalf("list: code = %d; stderr: %s", code, stderr.String())
+	}
+	if got := strings.Count(stdout.String(), "\n") - 1; got != 2 {
+		t.Errorf("list shows %d snapshots, want 2:\n%s", got, stdout.String())
+	}
+
+	stdout.Reset()
+	if code := doLogsCrashes(dir, "city--worker", &stdout, &stderr); code != 0 {
+		t.Fatalf("show: code = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "second crash") || strings.Contains(stdout.String(), "first crash") {
+		t.Errorf("show = %q, want newest snapshot", stdout.String())
+	}
+
+	if code := doLogsCrashes(dir, "city--mayor", &stdout, &stderr); code != 1 {
+		t.Errorf("unknown session: code = %d, want 1", code)
+	}
+}
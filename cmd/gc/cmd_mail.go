@@ -236,7 +236,8 @@ func newMailSendCmd(stdout, stderr io.Writer) *cobra.Command {
 
 Creates a message bead addressed to the recipient. The sender defaults
 to $GC_AGENT (in agent sessions) or "human". Use --notify to nudge
-the recipient after sending. Use --from to override the sender identity.
+the recipient after sending; [mail] notify = true in city.toml makes
+that the default. Use --from to override the sender identity.
 Use --to as an alternative to the positional <to> argument.
 Use -s/--subject for the summary line and -m/--message for the body text.
 Use --all to broadcast to all agents (excluding sender and "human").`,
@@ -459,30 +460,8 @@ func cmdMailSend(args []string, notify bool, all bool, from string, to string, s
 	}
 
 	var nf nudgeFunc
-	if notify && cfg != nil {
-		cityName := cfg.Workspace.Name
-		if cityName == "" {
-			cityName = filepath.Base(cityPath)
-		}
-		nf = func(recipient string) error {
-			found, ok := resolveAgentIdentity(cfg, recipient, currentRigContext(cfg))
-			if !ok {
-				return fmt.Errorf("agent %q not found", recipient)
-			}
-			resolved, err := config.ResolveProvider(&found, &cfg.Workspace, cfg.Providers, exec.LookPath)
-			if err != nil {
-				return err
-			}
-			target := nudgeTarget{
-				cityPath:    cityPath,
-				cityName:    cityName,
-				cfg:         cfg,
-				agent:       found,
-				resolved:    resolved,
-				sessionName: cliSessionName(cityPath, cityName, found.QualifiedName(), cfg.Workspace.SessionTemplate),
-			}
-			return sendMailNotify(target, sender)
-		}
+	if cfg != nil && (notify || cfg.Mail.Notify) {
+		nf = mailNotifyFunc(cityPath, cfg, sender)
 	}
 
 	// When --to is set, prepend it to args so doMailSend sees [to, body].
@@ -512,6 +491,34 @@ func cmdMailSend(args []string, notify bool, all bool, from string, to string, s
 	return doMailSend(mp, rec, validRecipients, sender, args, nf, stdout, stderr)
 }
 
+// mailNotifyFunc returns a nudgeFunc that tells a recipient agent it has
+// mail from sender.
+func mailNotifyFunc(cityPath string, cfg *config.City, sender string) nudgeFunc {
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	return func(recipient string) error {
+		found, ok := resolveAgentIdentity(cfg, recipient, currentRigContext(cfg))
+		if !ok {
+			return fmt.Errorf("agent %q not found", recipient)
+		}
+		resolved, err := config.ResolveProvider(&found, &cfg.Workspace, cfg.Providers, exec.LookPath)
+		if err != nil {
+			return err
+		}
+		target := nudgeTarget{
+			cityPath:    cityPath,
+			cityName:    cityName,
+			cfg:         cfg,
+			agent:       found,
+			resolved:    resolved,
+			sessionName: cliSessionName(cityPath, cityName, found.QualifiedName(), cfg.Workspace.SessionTemplate),
+		}
+		return sendMailNotify(target, sender)
+	}
+}
+
 // doMailSend creates a message addressed to a recipient. args is [to, subject, body]
 // or [to, body] (subject="" if no -s flag). When nudgeFn is non-nil, the
 // recipient is nudged after message creation (skipped for "human").
@@ -741,11 +748,19 @@ func cmdMailReply(args []string, subject, message string, notify bool, stdout, s
 		body = strings.Join(args[1:], " ")
 	}
 
-	return doMailReply(mp, rec, args[0], sender, subject, body, notify, stdout, stderr)
+	var nf nudgeFunc
+	if cityPath, err := resolveCity(); err == nil {
+		if cfg, err := loadCityConfig(cityPath); err == nil && (notify || cfg.Mail.Notify) {
+			nf = mailNotifyFunc(cityPath, cfg, sender)
+		}
+	}
+
+	return doMailReply(mp, rec, args[0], sender, subject, body, nf, stdout, stderr)
 }
 
-// doMailReply creates a reply to an existing message.
-func doMailReply(mp mail.Provider, rec events.Recorder, id, sender, subject, body string, _ bool, stdout, stderr io.Writer) int {
+// doMailReply creates a reply to an existing message. When nudgeFn is
+// non-nil, the recipient is nudged after the reply (skipped for "human").
+func doMailReply(mp mail.Provider, rec events.Recorder, id, sender, subject, body string, nudgeFn nudgeFunc, stdout, stderr io.Writer) int {
 	reply, err := mp.Reply(id, sender, subject, body)
 	telemetry.RecordMailOp(context.Background(), "reply", err)
 	if err != nil {
@@ -759,6 +774,12 @@ func doMailReply(mp mail.Provider, rec events.Recorder, id, sender, subject, bod
 		Message: reply.To,
 	})
 	fmt.Fprintf(stdout, "Replied to %s — sent message %s to %s\n", id, reply.ID, reply.To) //nolint:errcheck // best-effort stdout
+
+	if nudgeFn != nil && reply.To != "human" {
+		if err := nudgeFn(reply.To); err != nil {
+			fmt.Fprintf(stderr, "gc mail reply: nudge failed: %v\n", err) //nolint:errcheck // best-effort stderr
+		}
+	}
 	return 0
 }
 
@@ -51,7 +51,7 @@ func runMigrationPlan(stdout, stderr io.Writer) error {
 	}
 
 	provName := sessionProviderName()
-	sp, err := newSessionProviderByName(provName, cfg.Session, cityName)
+	sp, err := newSessionProviderByName(provName, cfg.Session, cityName, cityPath)
 	if err != nil {
 		return fmt.Errorf("creating session provider: %w", err)
 	}
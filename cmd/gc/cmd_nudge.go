@@ -13,7 +13,6 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
-	"syscall"
 	"text/tabwriter"
 	"time"
 
@@ -314,54 +313,60 @@ func deliverSessionNudge(target nudgeTarget, message string, mode nudgeDeliveryM
 }
 
 func deliverSessionNudgeWithProvider(target nudgeTarget, sp runtime.Provider, message string, mode nudgeDeliveryMode, stdout, stderr io.Writer) int {
+	queued, err := sendSessionNudge(target, sp, message, mode)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc session nudge: %v\n", err) //nolint:errcheck
+		return 1
+	}
+	if queued {
+		fmt.Fprintf(stdout, "Queued nudge for %s\n", target.agent.QualifiedName()) //nolint:errcheck
+	} else {
+		fmt.Fprintf(stdout, "Nudged %s\n", target.agent.QualifiedName()) //nolint:errcheck
+	}
+	return 0
+}
+
+// sendSessionNudge delivers message to target using the given mode.
+// Returns true if the nudge was queued for later delivery rather than
+// delivered now.
+func sendSessionNudge(target nudgeTarget, sp runtime.Provider, message string, mode nudgeDeliveryMode) (bool, error) {
 	switch mode {
 	case nudgeDeliveryImmediate:
 		if !sp.IsRunning(target.sessionName) {
-			fmt.Fprintf(stderr, "gc session nudge: session %q is not running\n", target.agent.QualifiedName()) //nolint:errcheck
-			return 1
+			return false, fmt.Errorf("session %q is not running", target.agent.QualifiedName())
 		}
 		if err := deliverImmediateNudge(sp, target.sessionName, runtime.TextContent(message)); err != nil {
 			telemetry.RecordNudge(context.Background(), target.agent.QualifiedName(), err)
-			fmt.Fprintf(stderr, "gc session nudge: %v\n", err) //nolint:errcheck
-			return 1
+			return false, err
 		}
 		telemetry.RecordNudge(context.Background(), target.agent.QualifiedName(), nil)
-		fmt.Fprintf(stdout, "Nudged %s\n", target.agent.QualifiedName()) //nolint:errcheck
-		return 0
+		return false, nil
 	case nudgeDeliveryQueue:
 		if err := enqueueQueuedNudge(target.cityPath, newQueuedNudge(target.agent.QualifiedName(), message, "session", time.Now())); err != nil {
-			fmt.Fprintf(stderr, "gc session nudge: %v\n", err) //nolint:errcheck
-			return 1
+			return false, err
 		}
 		if sp.IsRunning(target.sessionName) {
 			maybeStartCodexNudgePoller(target)
 		}
-		fmt.Fprintf(stdout, "Queued nudge for %s\n", target.agent.QualifiedName()) //nolint:errcheck
-		return 0
+		return true, nil
 	case nudgeDeliveryWaitIdle:
 		if !sp.IsRunning(target.sessionName) {
 			if err := enqueueQueuedNudge(target.cityPath, newQueuedNudge(target.agent.QualifiedName(), message, "session", time.Now())); err != nil {
-				fmt.Fprintf(stderr, "gc session nudge: %v\n", err) //nolint:errcheck
-				return 1
+				return false, err
 			}
-			fmt.Fprintf(stdout, "Queued nudge for %s\n", target.agent.QualifiedName()) //nolint:errcheck
-			return 0
+			return true, nil
 		}
 		if tryDeliverWaitIdleNudge(target, sp, message) {
 			telemetry.RecordNudge(context.Background(), target.agent.QualifiedName(), nil)
-			fmt.Fprintf(stdout, "Nudged %s\n", target.agent.QualifiedName()) //nolint:errcheck
-			return 0
+			return false, nil
 		}
 		if err := enqueueQueuedNudge(target.cityPath, newQueuedNudge(target.agent.QualifiedName(), message, "session", time.Now())); err != nil {
-			fmt.Fprintf(stderr, "gc session nudge: %v\n", err) //nolint:errcheck
-			return 1
+			return false, err
 		}
 		maybeStartCodexNudgePoller(target)
-		fmt.Fprintf(stdout, "Queued nudge for %s\n", target.agent.QualifiedName()) //nolint:errcheck
-		return 0
+		return true, nil
 	default:
-		fmt.Fprintf(stderr, "gc session nudge: unknown delivery mode %q\n", mode) //nolint:errcheck
-		return 1
+		return false, fmt.Errorf("unknown delivery mode %q", mode)
 	}
 }
 
@@ -514,7 +519,7 @@ func ensureNudgePoller(cityPath, agentName, sessionName string) error {
 		cmd.Env = os.Environ()
 		cmd.Stdout = io.Discard
 		cmd.Stderr = io.Discard
-		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		cmd.SysProcAttr = daemonSysProcAttr()
 		if err := cmd.Start(); err != nil {
 			return err
 		}
@@ -845,10 +850,10 @@ func withNudgeQueueState(cityPath string, fn func(*nudgeQueueState) error) error
 	}
 	defer lockFile.Close() //nolint:errcheck
 
-	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+	if err := fsys.Lock(lockFile); err != nil {
 		return fmt.Errorf("locking nudge queue: %w", err)
 	}
-	defer syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN) //nolint:errcheck
+	defer fsys.Unlock(lockFile) //nolint:errcheck
 
 	state, err := loadNudgeQueueState(cityPath)
 	if err != nil {
@@ -952,10 +957,7 @@ func existingPollerPID(pidPath string) (bool, error) {
 	if _, err := fmt.Sscanf(pidText, "%d", &pid); err != nil || pid <= 0 {
 		return false, nil
 	}
-	if err := syscall.Kill(pid, 0); err == nil || errors.Is(err, syscall.EPERM) {
-		return true, nil
-	}
-	return false, nil
+	return daemonProcessAlive(pid), nil
 }
 
 func writeNudgePollerPID(pidPath string, pid int) error {
@@ -976,9 +978,9 @@ func withNudgePollerPIDLock(pidPath string, fn func() error) error {
 		return fmt.Errorf("opening nudge poller lock: %w", err)
 	}
 	defer lockFile.Close() //nolint:errcheck
-	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+	if err := fsys.Lock(lockFile); err != nil {
 		return fmt.Errorf("locking nudge poller: %w", err)
 	}
-	defer syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN) //nolint:errcheck
+	defer fsys.Unlock(lockFile) //nolint:errcheck
 	return fn()
 }
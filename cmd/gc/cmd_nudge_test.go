@@ -359,3 +359,101 @@ func TestAcquireNudgePollerLeaseAllowsBootstrapPID(t *testing.T) {
 		t.Fatalf("pid file still exists after release: %v", err)
 	}
 }
+
+func broadcastTestCity(t *testing.T) (string, *config.City, *runtime.Fake) {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := &config.City{
+		Workspace: config.Workspace{Name: "city"},
+		Agents: []config.Agent{
+			{Name: "mayor", StartCommand: "true"},
+			{Name: "deacon", StartCommand: "true"},
+			{Name: "polecat", Dir: "hw", StartCommand: "true", Pool: &config.PoolConfig{Min: 0, Max: 3}},
+		},
+	}
+	fake := runtime.NewFake()
+	for _, qn := range []string{"mayor", "hw/polecat-1", "hw/polecat-3"} {
+		sn := cliSessionName(dir, "city", qn, "")
+		if err := fake.Start(context.Background(), sn, runtime.Config{}); err != nil {
+			t.Fatalf("Start(%s): %v", sn, err)
+		}
+	}
+	return dir, cfg, fake
+}
+
+func nudgedSessions(fake *runtime.Fake) []string {
+	var got []string
+	for _, call := range fake.Calls {
+		if call.Method == "Nudge" {
+			got = append(got, call.Name)
+		}
+	}
+	return got
+}
+
+func TestDoSessionNudgeBroadcastAll(t *testing.T) {
+	dir, cfg, fake := broadcastTestCity(t)
+
+	var stdout, stderr bytes.Buffer
+	code := doSessionNudgeBroadcast(dir, cfg, fake, nil, "prepare for shutdown", nudgeDeliveryImmediate, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	if got := nudgedSessions(fake); len(got) != 3 {
+		t.Fatalf("nudged %v, want 3 running sessions", got)
+	}
+	out := stdout.String()
+	for _, want := range []string{"Nudged mayor", "Nudged hw/polecat-1", "Nudged hw/polecat-3", "3 nudged, 0 queued, 0 failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stdout missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "deacon") {
+		t.Errorf("stopped agent nudged:\n%s", out)
+	}
+}
+
+func TestDoSessionNudgeBroadcastPool(t *testing.T) {
+	dir, cfg, fake := broadcastTestCity(t)
+	pool := cfg.Agents[2]
+
+	var stdout, stderr bytes.Buffer
+	code := doSessionNudgeBroadcast(dir, cfg, fake, &pool, "new priority bead", nudgeDeliveryImmediate, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	if strings.Contains(stdout.String(), "mayor") {
+		t.Errorf("non-pool agent nudged:\n%s", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), "2 nudged, 0 queued, 0 failed") {
+		t.Errorf("stdout = %q, want 2 nudged", stdout.String())
+	}
+}
+
+func TestDoSessionNudgeBroadcastReportsFailures(t *testing.T) {
+	dir, cfg, fake := broadcastTestCity(t)
+	cfg.Agents[0].StartCommand = ""
+	cfg.Agents[0].Provider = "no-such-provider"
+
+	var stdout, stderr bytes.Buffer
+	code := doSessionNudgeBroadcast(dir, cfg, fake, nil, "hello", nudgeDeliveryImmediate, &stdout, &stderr)
+	if code != 1 {
+		t.Fatalf("code = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "gc session nudge: mayor:") {
+		t.Errorf("stderr = %q, want per-target failure for mayor", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "2 nudged, 0 queued, 1 failed") {
+		t.Errorf("stdout = %q, want summary with 1 failure", stdout.String())
+	}
+}
+
+func TestDoSessionNudgeBroadcastNothingRunning(t *testing.T) {
+	dir, cfg, _ := broadcastTestCity(t)
+
+	var stdout, stderr bytes.Buffer
+	code := doSessionNudgeBroadcast(dir, cfg, runtime.NewFake(), nil, "hello", nudgeDeliveryImmediate, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "no running sessions") {
+		t.Errorf("code = %d, stderr = %q; want 1 and no-running message", code, stderr.String())
+	}
+}
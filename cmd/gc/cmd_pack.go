@@ -25,77 +25,115 @@ can be pinned to specific git refs.`,
 		},
 	}
 	cmd.AddCommand(newPackFetchCmd(stdout, stderr))
+	cmd.AddCommand(newPackInitCmd(stdout, stderr))
 	cmd.AddCommand(newPackListCmd(stdout, stderr))
+	cmd.AddCommand(newPackShowCmd(stdout, stderr))
+	cmd.AddCommand(newPackUpgradeCmd(stdout, stderr))
+	cmd.AddCommand(newPackValidateCmd(stdout, stderr))
 	return cmd
 }
 
 func newPackFetchCmd(stdout, stderr io.Writer) *cobra.Command {
-	return &cobra.Command{
+	var allowFloating bool
+	cmd := &cobra.Command{
 		Use:   "fetch",
 		Short: "Clone missing and update existing remote packs",
 		Long: `Clone missing and update existing remote pack caches.
 
 Fetches all configured pack sources from their git repositories,
 updates the local cache, and writes a lockfile with commit hashes
-for reproducibility. Automatically called during "gc start".`,
+for reproducibility. Automatically called during "gc start".
+
+Remote includes (git URLs in includes lists, such as
+"git+https://github.com/org/packs//gastown@v1.2.0") are re-fetched
+and re-locked too. Loading a city verifies each remote include against
+its locked commit and checksum. Includes pinned to a version tag or
+commit are locked on first use; those tracking a branch or the default
+branch are refused until locked with --allow-floating.`,
+		Example: `  gc pack fetch
+  gc pack fetch --allow-floating`,
 		Args: cobra.NoArgs,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			if doPackFetch(stdout, stderr) != 0 {
+			if doPackFetch(allowFloating, stdout, stderr) != 0 {
 				return errExit
 			}
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&allowFloating, "allow-floating", false, "lock remote includes that track a branch instead of a version tag or commit")
+	return cmd
 }
 
-// doPackFetch clones missing packs and updates existing ones.
-func doPackFetch(stdout, stderr io.Writer) int {
+// doPackFetch clones missing packs and updates existing ones, then
+// re-locks remote includes.
+func doPackFetch(allowFloating bool, stdout, stderr io.Writer) int {
 	cityPath, err := resolveCity()
 	if err != nil {
 		fmt.Fprintf(stderr, "gc pack fetch: %v\n", err) //nolint:errcheck
 		return 1
 	}
 
+	// Remote includes first: a city with an unlocked floating include
+	// does not load until they are locked.
+	includes, err := config.FetchRemoteIncludes(fsys.OSFS{}, filepath.Join(cityPath, "city.toml"), allowFloating)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pack fetch: %v\n", err) //nolint:errcheck
+		return 1
+	}
+
 	cfg, err := loadCityConfig(cityPath)
 	if err != nil {
 		fmt.Fprintf(stderr, "gc pack fetch: %v\n", err) //nolint:errcheck
 		return 1
 	}
 
-	if len(cfg.Packs) == 0 {
+	if len(cfg.Packs) == 0 && len(includes) == 0 {
 		fmt.Fprintln(stdout, "No remote packs configured.") //nolint:errcheck
 		return 0
 	}
 
-	fmt.Fprintf(stdout, "Fetching %d pack source(s)...\n", len(cfg.Packs)) //nolint:errcheck
-	if err := config.FetchPacks(cfg.Packs, cityPath); err != nil {
+	lock, err := config.ReadLock(cityPath)
+	if err != nil {
 		fmt.Fprintf(stderr, "gc pack fetch: %v\n", err) //nolint:errcheck
 		return 1
 	}
+	if len(cfg.Packs) > 0 {
+		fmt.Fprintf(stdout, "Fetching %d pack source(s)...\n", len(cfg.Packs)) //nolint:errcheck
+		if err := config.FetchPacks(cfg.Packs, cityPath); err != nil {
+			fmt.Fprintf(stderr, "gc pack fetch: %v\n", err) //nolint:errcheck
+			return 1
+		}
 
-	// Write lockfile.
-	lock, err := config.LockFromCache(cfg.Packs, cityPath)
-	if err != nil {
-		fmt.Fprintf(stderr, "gc pack fetch: building lock: %v\n", err) //nolint:errcheck
-		return 1
-	}
-	if err := config.WriteLock(cityPath, lock); err != nil {
-		fmt.Fprintf(stderr, "gc pack fetch: writing lock: %v\n", err) //nolint:errcheck
-		return 1
+		// Write lockfile, keeping the include entries just locked.
+		fresh, err := config.LockFromCache(cfg.Packs, cityPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc pack fetch: building lock: %v\n", err) //nolint:errcheck
+			return 1
+		}
+		lock.Packs = fresh.Packs
+		if err := config.WriteLock(cityPath, lock); err != nil {
+			fmt.Fprintf(stderr, "gc pack fetch: writing lock: %v\n", err) //nolint:errcheck
+			return 1
+		}
 	}
 
 	for name := range cfg.Packs {
-		lt := lock.Packs[name]
-		commit := lt.Commit
-		if len(commit) > 12 {
-			commit = commit[:12]
-		}
-		fmt.Fprintf(stdout, "  %s: %s\n", name, commit) //nolint:errcheck
+		fmt.Fprintf(stdout, "  %s: %s\n", name, shortCommit(lock.Packs[name].Commit)) //nolint:errcheck
+	}
+	for _, key := range includes {
+		fmt.Fprintf(stdout, "  %s: %s\n", key, shortCommit(lock.Includes[key].Commit)) //nolint:errcheck
 	}
 	fmt.Fprintln(stdout, "Done.") //nolint:errcheck
 	return 0
 }
 
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
 func newPackListCmd(stdout, stderr io.Writer) *cobra.Command {
 	return &cobra.Command{
 		Use:   "list",
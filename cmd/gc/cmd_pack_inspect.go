@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+func newPackShowCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <path>",
+		Short: "Show a pack's expanded agents and resources",
+		Long: `Load a pack and print what it contributes once its includes are
+expanded: metadata, agents (with scope, prompt, and pool bounds),
+providers, services, commands, doctor checks, and agent requirements.
+
+<path> is a pack directory or a remote include URL. Works inside or
+outside a city.`,
+		Example: `  gc pack show packs/swarm
+  gc pack show https://github.com/org/repo/tree/main/packs/base`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if doPackShow(fsys.OSFS{}, packCityRoot(), packRefArg(args[0]), stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newPackValidateCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate <path>",
+		Short: "Check a pack for errors before a city uses it",
+		Long: `Load and expand a pack, then report every problem a city would hit
+when expanding it: bad [pack] metadata, include cycles, duplicate agents
+across includes, invalid agent or service definitions, and missing
+prompt templates, command scripts, or doctor scripts.
+
+Exits 0 when the pack is valid and 1 otherwise.`,
+		Example: `  gc pack validate packs/swarm`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if doPackValidate(fsys.OSFS{}, packCityRoot(), packRefArg(args[0]), stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newPackInitCmd(stdout, stderr io.Writer) *cobra.Command {
+	var name string
+	cmd := &cobra.Command{
+		Use:   "init <path>",
+		Short: "Scaffold a new pack",
+		Long: `Create a pack directory with a pack.toml and a prompt stub for one
+example agent. The pack name defaults to the directory name.
+
+Refuses to overwrite an existing pack.toml.`,
+		Example: `  gc pack init packs/review
+  gc pack init packs/review --name code-review`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if doPackInit(fsys.OSFS{}, args[0], name, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "pack name (default: directory name)")
+	return cmd
+}
+
+// packCityRoot returns the city root when run inside a city, otherwise
+// the working directory. Pack prompt paths are reported relative to it.
+func packCityRoot() string {
+	if cityPath, err := resolveCity(); err == nil {
+		return cityPath
+	}
+	wd, _ := os.Getwd()
+	return wd
+}
+
+// packRefArg makes an existing local path absolute so it resolves from
+// the working directory rather than the city root. Remote refs pass
+// through unchanged.
+func packRefArg(ref string) string {
+	if _, err := os.Stat(ref); err == nil {
+		if abs, err := filepath.Abs(ref); err == nil {
+			return abs
+		}
+	}
+	return ref
+}
+
+// doPackShow prints the expanded contents of the pack at ref.
+func doPackShow(fs fsys.FS, cityRoot, ref string, stdout, stderr io.Writer) int {
+	info, err := config.InspectPack(fs, ref, cityRoot)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pack show: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	w := func(format string, args ...any) { fmt.Fprintf(stdout, format+"\n", args...) } //nolint:errcheck // best-effort stdout
+
+	version := info.Meta.Version
+	if version == "" {
+		version = "unversioned"
+	}
+	w("Pack:     %s %s (schema %d)", info.Meta.Name, version, info.Meta.Schema)
+	w("Dir:      %s", info.Dir)
+	if len(info.Meta.Includes) > 0 {
+		w("Includes: %s", strings.Join(info.Meta.Includes, ", "))
+	}
+
+	w("")
+	w("Agents (%d):", len(info.Agents))
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, a := range info.Agents {
+		scope := a.Scope
+		if scope == "" {
+			scope = "rig"
+		}
+		pool := "-"
+		if a.Pool != nil {
+			pool = fmt.Sprintf("%d-%d", a.Pool.Min, a.Pool.Max)
+		}
+		prompt := a.PromptTemplate
+		if prompt == "" {
+			prompt = "-"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\tpool %s\n", a.Name, scope, prompt, pool) //nolint:errcheck // best-effort stdout
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+
+	if len(info.Providers) > 0 {
+		names := make([]string, 0, len(info.Providers))
+		for n := range info.Providers {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		w("Providers: %s", strings.Join(names, ", "))
+	}
+	if len(info.Services) > 0 {
+		names := make([]string, 0, len(info.Services))
+		for _, s := range info.Services {
+			names = append(names, s.Name)
+		}
+		w("Services:  %s", strings.Join(names, ", "))
+	}
+	for _, c := range info.Commands {
+		w("Command:   gc %s %s — %s", info.Meta.Name, c.Name, c.Description)
+	}
+	for _, d := range info.Doctor {
+		w("Doctor:    %s:%s", info.Meta.Name, d.Name)
+	}
+	for _, r := range info.Requires {
+		w("Requires:  %s agent %q", r.Scope, r.Agent)
+	}
+	return 0
+}
+
+// doPackValidate reports every problem found in the pack at ref.
+func doPackValidate(fs fsys.FS, cityRoot, ref string, stdout, stderr io.Writer) int {
+	info, err := config.InspectPack(fs, ref, cityRoot)
+	if err != nil {
+		fmt.Fprintf(stdout, "%s: %v\n", ref, err) //nolint:errcheck // best-effort stdout
+		fmt.Fprintln(stdout, "1 problem found.")  //nolint:errcheck // best-effort stdout
+		return 1
+	}
+	errs := config.ValidatePack(fs, info, cityRoot)
+	if len(errs) == 0 {
+		fmt.Fprintf(stdout, "Pack %q valid (%d agents).\n", info.Meta.Name, len(info.Agents)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	for _, e := range errs {
+		fmt.Fprintf(stdout, "%s: %v\n", info.Meta.Name, e) //nolint:errcheck // best-effort stdout
+	}
+	noun := "problems"
+	if len(errs) == 1 {
+		noun = "problem"
+	}
+	fmt.Fprintf(stdout, "%d %s found.\n", len(errs), noun) //nolint:errcheck // best-effort stdout
+	return 1
+}
+
+// doPackInit scaffolds pack.toml and a prompt stub in dir.
+func doPackInit(fs fsys.FS, dir, name string, stdout, stderr io.Writer) int {
+	if name == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc pack init: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		name = filepath.Base(abs)
+	}
+	packPath := filepath.Join(dir, "pack.toml")
+	if _, err := fs.Stat(packPath); err == nil {
+		fmt.Fprintf(stderr, "gc pack init: %s already exists\n", packPath) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	promptPath := filepath.Join(dir, citylayout.PromptsRoot, "worker.md")
+	if err := fs.MkdirAll(filepath.Dir(promptPath), 0o755); err != nil {
+		fmt.Fprintf(stderr, "gc pack init: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	packToml := fmt.Sprintf(`# %s pack.
+#
+# Use from a city with:
+#   [workspace]
+#   includes = ["<path to this directory>"]     # city-scoped agents
+#   [[rigs]]
+#   includes = ["<path to this directory>"]     # rig-scoped agents
+
+[pack]
+name = %q
+version = "0.1.0"
+schema = 1
+
+[[agent]]
+name = "worker"
+scope = "rig"
+prompt_template = "prompts/worker.md"
+`, name, name)
+	if err := fs.WriteFile(packPath, []byte(packToml), 0o644); err != nil {
+		fmt.Fprintf(stderr, "gc pack init: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if _, err := fs.Stat(promptPath); err != nil {
+		stub := "# Worker\n\nYou are a worker agent from the " + name + " pack.\n\n" +
+			"Your agent name is available as `$GC_AGENT`.\n\n" +
+			"## How to work\n\n" +
+			"1. Find work: `bd ready --assignee=$GC_AGENT`\n" +
+			"2. Read it with `bd show <id>` and do it\n" +
+			"3. Close it: `bd close <id>`\n"
+		if err := fs.WriteFile(promptPath, []byte(stub), 0o644); err != nil {
+			fmt.Fprintf(stderr, "gc pack init: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	fmt.Fprintf(stdout, "Created pack %q in %s\n", name, dir) //nolint:errcheck // best-effort stdout
+	return 0
+}
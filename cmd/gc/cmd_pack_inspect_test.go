@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestPackInitShowValidate(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "packs", "review")
+
+	var stdout, stderr bytes.Buffer
+	if code := doPackInit(fsys.OSFS{}, dir, "", &stdout, &stderr); code != 0 {
+		t.Fatalf("init code = %d, stderr: %s", code, stderr.String())
+	}
+	if code := doPackInit(fsys.OSFS{}, dir, "", &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "already exists") {
+		t.Errorf("second init: code = %d, stderr = %q", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := doPackShow(fsys.OSFS{}, root, dir, &stdout, &stderr); code != 0 {
+		t.Fatalf("show code = %d, stderr: %s", code, stderr.String())
+	}
+	for _, want := range []string{"Pack:     review 0.1.0 (schema 1)", "Agents (1):", "worker  rig  packs/review/prompts/worker.md"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("show output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := doPackValidate(fsys.OSFS{}, root, dir, &stdout, &stderr); code != 0 {
+		t.Errorf("validate code = %d, output:\n%s", code, stdout.String())
+	}
+}
+
+func TestPackValidateReportsEveryProblem(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "broken")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	packToml := `[pack]
+name = "broken"
+schema = 1
+
+[[agent]]
+name = "scribe"
+prompt_template = "prompts/missing.md"
+
+[[agent]]
+name = "pooler"
+
+[agent.pool]
+min = 3
+max = 1
+
+[[commands]]
+name = "audit"
+description = "audit"
+long_description = "audit.txt"
+script = "commands/audit.sh"
+`
+	if err := os.WriteFile(filepath.Join(dir, "pack.toml"), []byte(packToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doPackValidate(fsys.OSFS{}, root, dir, &stdout, &stderr); code != 1 {
+		t.Fatalf("code = %d, want 1", code)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		`agent "scribe": prompt_template "broken/prompts/missing.md" not found`,
+		`agent "pooler": pool min (3) must be <= max (1)`,
+		`command "audit": script "commands/audit.sh" not found`,
+		"3 problems found.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPackValidateBadMeta(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pack.toml"), []byte("[pack]\nname = \"x\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doPackValidate(fsys.OSFS{}, dir, dir, &stdout, &stderr); code != 1 || !strings.Contains(stdout.String(), "schema is required") {
+		t.Errorf("code = %d, output = %q", code, stdout.String())
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+func newPackUpgradeCmd(stdout, stderr io.Writer) *cobra.Command {
+	var to string
+	cmd := &cobra.Command{
+		Use:   "upgrade <name>",
+		Short: "Move a remote pack to a new version",
+		Long: `Move a [packs] entry to a new git ref and check that the city still
+expands against it.
+
+Without --to, the pack moves to its highest version tag. The new ref
+is fetched, and every rig override that targets the pack is checked
+against the new pack's agents. If any override names an agent the new
+version no longer has, each is reported, the cache is restored, and
+city.toml is left unchanged.
+
+On success the entry's ref is rewritten, its version constraint is
+re-pinned to "^<new version>" if the new version falls outside it, and
+pack.lock is updated.`,
+		Example: `  gc pack upgrade gastown
+  gc pack upgrade gastown --to v2.1.0`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cityPath, err := resolveCity()
+			if err != nil {
+				fmt.Fprintf(stderr, "gc pack upgrade: %v\n", err) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if doPackUpgrade(fsys.OSFS{}, cityPath, args[0], to, config.ListPackTags, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&to, "to", "", "git ref to upgrade to (default: highest version tag)")
+	return cmd
+}
+
+// doPackUpgrade moves the named pack to ref to (or the highest version
+// tag from listTags), validating rig overrides before touching city.toml.
+func doPackUpgrade(fs fsys.FS, cityPath, name, to string, listTags func(source string) ([]string, error), stdout, stderr io.Writer) int {
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	cfg, err := loadCityConfigForEditFS(fs, tomlPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pack upgrade: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	src, ok := cfg.Packs[name]
+	if !ok {
+		fmt.Fprintf(stderr, "gc pack upgrade: pack %q not found in [packs]\n", name) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	if to == "" {
+		tags, err := listTags(src.Source)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc pack upgrade: listing tags: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if to = config.LatestPackVersion(tags); to == "" {
+			fmt.Fprintf(stderr, "gc pack upgrade: %s has no version tags; use --to\n", src.Source) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	if to == src.Ref {
+		fmt.Fprintf(stdout, "Pack '%s' already at %s\n", name, to) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+
+	cacheDir := config.PackCachePath(cityPath, name, src)
+	oldMeta, _ := config.ReadPackMeta(fs, cacheDir)
+	oldInfo, _ := config.InspectPack(fs, cacheDir, cityPath)
+	// Read rig overrides (including rigs/<name>/rig.toml) while the old
+	// pack is still in place and the city is known to load.
+	rigs := cfg.Rigs
+	if full, _, err := config.LoadWithIncludes(fs, tomlPath); err == nil {
+		rigs = full.Rigs
+	}
+
+	next := src
+	next.Ref = to
+	if err := config.FetchPacks(map[string]config.PackSource{name: next}, cityPath); err != nil {
+		fmt.Fprintf(stderr, "gc pack upgrade: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	restore := func() {
+		if err := config.FetchPacks(map[string]config.PackSource{name: src}, cityPath); err != nil {
+			fmt.Fprintf(stderr, "gc pack upgrade: restoring %s: %v\n", src.Ref, err) //nolint:errcheck // best-effort stderr
+		}
+	}
+	info, err := config.InspectPack(fs, cacheDir, cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pack upgrade: %s at %s: %v\n", name, to, err) //nolint:errcheck // best-effort stderr
+		restore()
+		return 1
+	}
+
+	if broken := staleOverrides(rigs, name, cacheDir, oldInfo, info); len(broken) > 0 {
+		for _, b := range broken {
+			fmt.Fprintf(stderr, "gc pack upgrade: %s\n", b) //nolint:errcheck // best-effort stderr
+		}
+		fmt.Fprintf(stderr, "gc pack upgrade: %d override(s) invalid for %s %s; city.toml unchanged\n", len(broken), name, to) //nolint:errcheck // best-effort stderr
+		restore()
+		return 1
+	}
+
+	if next.Version != "" && info.Meta.Version != "" {
+		if ok, _ := config.PackVersionSatisfies(info.Meta.Version, next.Version); !ok {
+			next.Version = "^" + info.Meta.Version
+		}
+	}
+	cfg.Packs[name] = next
+	content, err := cfg.Marshal()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pack upgrade: %v\n", err) //nolint:errcheck // best-effort stderr
+		restore()
+		return 1
+	}
+	if err := fs.WriteFile(tomlPath, content, 0o644); err != nil {
+		fmt.Fprintf(stderr, "gc pack upgrade: %v\n", err) //nolint:errcheck // best-effort stderr
+		restore()
+		return 1
+	}
+
+	lock, err := config.ReadLock(cityPath)
+	if err == nil {
+		var fresh *config.PackLock
+		if fresh, err = config.LockFromCache(map[string]config.PackSource{name: next}, cityPath); err == nil {
+			lock.Packs[name] = fresh.Packs[name]
+			err = config.WriteLock(cityPath, lock)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pack upgrade: updating pack.lock: %v\n", err) //nolint:errcheck // best-effort stderr
+	}
+
+	from := src.Ref
+	if from == "" {
+		from = "HEAD"
+	}
+	fmt.Fprintf(stdout, "Upgraded pack '%s': %s (%s) → %s (%s)\n", //nolint:errcheck // best-effort stdout
+		name, packVersionLabel(oldMeta.Version), from, packVersionLabel(info.Meta.Version), to)
+	if next.Version != src.Version {
+		fmt.Fprintf(stdout, "  Version constraint: %s → %s\n", src.Version, next.Version) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
+
+// staleOverrides returns a message for each rig override that targets
+// an agent the old pack had but the upgraded pack no longer has. Rigs may
+// include other packs too, so overrides for agents the old pack never
+// had are left alone; with no old pack to compare, any miss counts.
+// Rigs reference the pack by name or, once composed, by cacheDir.
+func staleOverrides(rigs []config.Rig, name, cacheDir string, oldInfo, info *config.PackInfo) []string {
+	rigAgents := func(info *config.PackInfo) []string {
+		var names []string
+		for _, a := range info.Agents {
+			if a.Scope != "city" {
+				names = append(names, a.Name)
+			}
+		}
+		return names
+	}
+	agents := rigAgents(info)
+	var oldAgents []string
+	if oldInfo != nil {
+		oldAgents = rigAgents(oldInfo)
+	}
+	var broken []string
+	for _, r := range rigs {
+		if !slices.Contains(r.Includes, name) && !slices.Contains(r.Includes, cacheDir) {
+			continue
+		}
+		for _, ov := range r.Overrides {
+			if slices.Contains(agents, ov.Agent) || (oldInfo != nil && !slices.Contains(oldAgents, ov.Agent)) {
+				continue
+			}
+			broken = append(broken, fmt.Sprintf("rig %q: override for agent %q no longer valid: pack %s %s has no such rig agent",
+				r.Name, ov.Agent, name, packVersionLabel(info.Meta.Version)))
+		}
+	}
+	return broken
+}
+
+func packVersionLabel(v string) string {
+	if v == "" {
+		return "unversioned"
+	}
+	return v
+}
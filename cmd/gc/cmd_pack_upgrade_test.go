@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// gitIn runs git in dir with hooks and parent-repo environment stripped.
+func gitIn(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "core.hooksPath="}, args...)...)
+	cmd.Dir = dir
+	for _, e := range os.Environ() {
+		if !strings.HasPrefix(e, "GIT_DIR=") && !strings.HasPrefix(e, "GIT_WORK_TREE=") && !strings.HasPrefix(e, "GIT_INDEX_FILE=") {
+			cmd.Env = append(cmd.Env, e)
+		}
+	}
+	cmd.Env = append(cmd.Env,
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %s: %v", strings.Join(args, " "), out, err)
+	}
+}
+
+// newVersionedPackRepo returns a bare repo tagged v1.0.0 (agent "worker")
+// and v2.0.0 (agent renamed to "builder").
+func newVersionedPackRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	work := filepath.Join(dir, "work")
+	gitIn(t, dir, "init", "-q", work)
+	for _, v := range []struct{ tag, version, agent string }{{"v1.0.0", "1.0.0", "worker"}, {"v2.0.0", "2.0.0", "builder"}} {
+		pack := "[pack]\nname = \"gastown\"\nversion = \"" + v.version + "\"\nschema = 1\n\n[[agent]]\nname = \"" + v.agent + "\"\n"
+		if err := os.WriteFile(filepath.Join(work, "pack.toml"), []byte(pack), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		gitIn(t, work, "add", "-A")
+		gitIn(t, work, "commit", "-qm", v.tag)
+		gitIn(t, work, "tag", v.tag)
+	}
+	bare := filepath.Join(dir, "gastown.git")
+	gitIn(t, dir, "clone", "-q", "--bare", work, bare)
+	return bare
+}
+
+func TestDoPackUpgrade(t *testing.T) {
+	bare := newVersionedPackRepo(t)
+	cityPath := t.TempDir()
+	cityToml := func(overrides string) string {
+		return "[workspace]\nname = \"test-city\"\n\n[packs.gastown]\nsource = \"" + bare + "\"\nref = \"v1.0.0\"\nversion = \"^1.0\"\n\n" +
+			"[[rigs]]\nname = \"app\"\npath = \"/app\"\nincludes = [\"gastown\"]\n" + overrides
+	}
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	withOverride := cityToml("\n[[rigs.overrides]]\nagent = \"worker\"\nsuspended = true\n")
+	if err := os.WriteFile(tomlPath, []byte(withOverride), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.FetchPacks(map[string]config.PackSource{"gastown": {Source: bare, Ref: "v1.0.0"}}, cityPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// The v2 pack drops "worker", so the override blocks the upgrade.
+	var stdout, stderr bytes.Buffer
+	if code := doPackUpgrade(fsys.OSFS{}, cityPath, "gastown", "", config.ListPackTags, &stdout, &stderr); code != 1 {
+		t.Fatalf("code = %d, want 1; stdout: %s", code, stdout.String())
+	}
+	if !strings.Contains(stderr.String(), `rig "app": override for agent "worker" no longer valid`) {
+		t.Errorf("stderr = %q, want stale override", stderr.String())
+	}
+	if data, _ := os.ReadFile(tomlPath); string(data) != withOverride {
+		t.Errorf("city.toml changed after failed upgrade:\n%s", data)
+	}
+	if meta, _ := config.ReadPackMeta(fsys.OSFS{}, config.PackCachePath(cityPath, "gastown", config.PackSource{})); meta.Version != "1.0.0" {
+		t.Errorf("cache version = %q, want restored 1.0.0", meta.Version)
+	}
+
+	if err := os.WriteFile(tomlPath, []byte(cityToml("")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	stderr.Reset()
+	if code := doPackUpgrade(fsys.OSFS{}, cityPath, "gastown", "", config.ListPackTags, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Upgraded pack 'gastown': 1.0.0 (v1.0.0) → 2.0.0 (v2.0.0)") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	cfg, _, err := config.LoadWithIncludes(fsys.OSFS{}, tomlPath)
+	if err != nil {
+		t.Fatalf("city no longer loads: %v", err)
+	}
+	if src := cfg.Packs["gastown"]; src.Ref != "v2.0.0" || src.Version != "^2.0.0" {
+		t.Errorf("pack source = %+v, want ref v2.0.0 version ^2.0.0", src)
+	}
+}
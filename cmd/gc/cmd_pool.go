@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/spf13/cobra"
+)
+
+func newPoolCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pool",
+		Short: "Inspect and manually size agent pools",
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc pool: missing subcommand (status, scale, stop-instance)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc pool: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(
+		newPoolStatusCmd(stdout, stderr),
+		newPoolScaleCmd(stdout, stderr),
+		newPoolStopInstanceCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newPoolStatusCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "status [pool]",
+		Short: "Show pool sizing, members, and backlog",
+		Long: `Show each pool's configured min/max, its member sessions, the bead
+each running member is working on, and the backlog reported by the
+pool's check command.
+
+The backlog is the raw check output; "desired" is that value clamped to
+[min, max], which is what the controller scales to — or, for a pool
+sized with "gc pool scale", the manual size.`,
+		Example: `  gc pool status
+  gc pool status myrig/polecat
+  gc pool status --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			if cmdPoolStatus(name, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// PoolStatusJSON is the JSON representation of one pool in gc pool status.
+type PoolStatusJSON struct {
+	Name       string             `json:"name"`
+	Min        int                `json:"min"`
+	Max        int                `json:"max"`
+	Running    int                `json:"running"`
+	Backlog    *int               `json:"backlog"`
+	Desired    int                `json:"desired"`
+	CheckError string             `json:"check_error,omitempty"`
+	Manual     bool               `json:"manual,omitempty"`
+	Stopped    []string           `json:"stopped,omitempty"`
+	Members    []PoolMemberStatus `json:"members"`
+}
+
+// PoolMemberStatus describes one pool instance session.
+type PoolMemberStatus struct {
+	Name        string `json:"name"`
+	Session     string `json:"session"`
+	Running     bool   `json:"running"`
+	CurrentBead string `json:"current_bead,omitempty"`
+	BeadTitle   string `json:"bead_title,omitempty"`
+}
+
+// cmdPoolStatus is the CLI entry point for gc pool status.
+func cmdPoolStatus(name string, jsonOutput bool, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool status: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool status: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	// Bead stores are best-effort: session names fall back to the legacy
+	// form and current beads are omitted without them.
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		store = nil
+	}
+	workStore := func(a config.Agent) (beads.Store, error) {
+		return openRigStoreAt(cityPath, rigDirForAgent(cfg, a))
+	}
+	return doPoolStatus(cfg, cityPath, name, newSessionProvider(), store, workStore, shellScaleCheck, jsonOutput, stdout, stderr)
+}
+
+// doPoolStatus reports on the configured pools, or only name when set.
+// store holds session beads; workStore opens the store a pool's work
+// beads live in (its rig's). Accepts injected provider, stores, and check
+// runner for testability.
+func doPoolStatus(cfg *config.City, cityPath, name string, sp runtime.Provider, store beads.Store,
+	workStore func(a config.Agent) (beads.Store, error), runner ScaleCheckRunner, jsonOutput bool, stdout, stderr io.Writer,
+) int {
+	var pools []config.Agent
+	if name != "" {
+		a, ok := resolveAgentIdentity(cfg, name, currentRigContext(cfg))
+		if !ok {
+			fmt.Fprintln(stderr, agentNotFoundMsg("gc pool status", name, cfg)) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if !a.IsPool() {
+			fmt.Fprintf(stderr, "gc pool status: agent %q is not a pool\n", a.QualifiedName()) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		pools = []config.Agent{a}
+	} else {
+		for _, a := range cfg.Agents {
+			if a.IsPool() {
+				pools = append(pools, a)
+			}
+		}
+	}
+
+	overrides, err := loadPoolOverrides(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool status: %v\n", err) //nolint:errcheck // best-effort stderr
+		overrides = poolOverrides{}
+	}
+	statuses := make([]PoolStatusJSON, 0, len(pools))
+	for _, a := range pools {
+		work, err := workStore(a)
+		if err != nil {
+			work = nil
+		}
+		statuses = append(statuses, poolStatus(cfg, cityPath, a, sp, store, work, runner, overrides[a.QualifiedName()]))
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc pool status: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if len(statuses) == 0 {
+		fmt.Fprintln(stdout, "No pools configured") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	for i, ps := range statuses {
+		if i > 0 {
+			fmt.Fprintln(stdout) //nolint:errcheck // best-effort stdout
+		}
+		maxDisplay := strconv.Itoa(ps.Max)
+		if ps.Max < 0 {
+			maxDisplay = "unlimited"
+		}
+		backlog := "?"
+		if ps.Backlog != nil {
+			backlog = strconv.Itoa(*ps.Backlog)
+		}
+		manual := ""
+		if ps.Manual {
+			manual = " (manual)"
+		}
+		fmt.Fprintf(stdout, "%s  min=%d max=%s  running=%d  backlog=%s  desired=%d%s\n", //nolint:errcheck // best-effort stdout
+			ps.Name, ps.Min, maxDisplay, ps.Running, backlog, ps.Desired, manual)
+		if len(ps.Stopped) > 0 {
+			fmt.Fprintf(stdout, "  held stopped: %s\n", strings.Join(ps.Stopped, ", ")) //nolint:errcheck // best-effort stdout
+		}
+		if ps.CheckError != "" {
+			fmt.Fprintf(stdout, "  check failed: %s\n", ps.CheckError) //nolint:errcheck // best-effort stdout
+		}
+		for _, m := range ps.Members {
+			state := "stopped"
+			if m.Running {
+				state = "running"
+			}
+			work := "—"
+			if m.CurrentBead != "" {
+				work = formatBeadLabel(m.CurrentBead, m.BeadTitle)
+			}
+			fmt.Fprintf(stdout, "  %-24s%-9s%s\n", m.Name, state, work) //nolint:errcheck // best-effort stdout
+		}
+	}
+	return 0
+}
+
+// poolStatus gathers sizing, member, and backlog information for pool a,
+// whose manual control is po. Session names come from store and current
+// beads from work.
+func poolStatus(cfg *config.City, cityPath string, a config.Agent, sp runtime.Provider, store, work beads.Store, runner ScaleCheckRunner, po poolOverride) PoolStatusJSON {
+	pool := a.EffectivePool()
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	st := cfg.Workspace.SessionTemplate
+	ps := PoolStatusJSON{
+		Name:    a.QualifiedName(),
+		Min:     pool.Min,
+		Max:     pool.Max,
+		Desired: pool.Min,
+		Stopped: po.Stopped,
+		Members: []PoolMemberStatus{},
+	}
+
+	// Same working directory the controller uses for the check, without
+	// resolveAgentDir's side effect of creating it.
+	dir := cityPath
+	if a.Dir != "" {
+		dir = a.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(cityPath, dir)
+		}
+	}
+	if out, err := runner(pool.Check, dir); err != nil {
+		ps.CheckError = err.Error()
+	} else if n, err := strconv.Atoi(strings.TrimSpace(out)); err != nil {
+		ps.CheckError = fmt.Sprintf("check output %q is not an integer", strings.TrimSpace(out))
+	} else {
+		ps.Backlog = &n
+		ps.Desired = max(n, pool.Min)
+		if pool.Max >= 0 {
+			ps.Desired = min(ps.Desired, pool.Max)
+		}
+	}
+	if n, ok := po.manualSize(pool); ok {
+		ps.Desired = n
+		ps.Manual = true
+	}
+
+	for _, qn := range discoverPoolInstances(a.Name, a.Dir, pool, cityName, st, sp) {
+		sn := sessionName(store, cityName, qn, st)
+		m := PoolMemberStatus{Name: qn, Session: sn, Running: sp.IsRunning(sn)}
+		if m.Running {
+			ps.Running++
+		}
+		if work != nil {
+			if b, ok := currentWork(work, sn, qn); ok {
+				m.CurrentBead = b.ID
+				m.BeadTitle = b.Title
+			}
+		}
+		ps.Members = append(ps.Members, m)
+	}
+	return ps
+}
+
+// currentWork returns the first in-progress bead assigned to a session,
+// matching either its session name or its qualified instance name.
+func currentWork(store beads.Store, sn, qn string) (beads.Bead, bool) {
+	for _, assignee := range []string{sn, qn} {
+		bs, err := store.Query(beads.Filter{Status: "in_progress", Assignee: assignee, Limit: 1})
+		if err == nil && len(bs) > 0 {
+			return bs[0], true
+		}
+	}
+	return beads.Bead{}, false
+}
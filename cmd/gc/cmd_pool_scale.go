@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/spf13/cobra"
+)
+
+func newPoolScaleCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "scale <pool> <count|auto>",
+		Short: "Set a pool's member count by hand",
+		Long: `Set how many members a pool runs, overriding its check command.
+
+The count must lie within the pool's min and max. The controller starts
+new members or drains the highest-numbered ones on its next tick,
+giving drained members the usual drain timeout to finish their work.
+Scaling also releases instances held by "gc pool stop-instance".
+
+"auto" hands sizing back to the check command.`,
+		Example: `  gc pool scale hw/polecat 5
+  gc pool scale hw/polecat auto`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdPoolScale(args[0], args[1], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newPoolStopInstanceCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop-instance <instance>",
+		Short: "Drain one pool member and keep it stopped",
+		Long: `Drain one pool member and hold it stopped, whatever the pool's size.
+
+The controller drains the instance on its next tick, giving it the
+usual drain timeout to finish its work, and does not restart it; the
+pool runs one member fewer. Refused when it would leave fewer running
+members than the pool's min. "gc pool scale" releases held instances.`,
+		Example: `  gc pool stop-instance hw/polecat-3`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdPoolStopInstance(args[0], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// cmdPoolScale is the CLI entry point for gc pool scale.
+func cmdPoolScale(name, count string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool scale: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool scale: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if code := doPoolScale(cfg, cityPath, name, count, stdout, stderr); code != 0 {
+		return code
+	}
+	pokeController(cityPath) //nolint:errcheck // best-effort: controller also notices on its next tick
+	return 0
+}
+
+// doPoolScale records a manual size for pool name, or clears it when
+// count is "auto".
+func doPoolScale(cfg *config.City, cityPath, name, count string, stdout, stderr io.Writer) int {
+	a, ok := resolveAgentIdentity(cfg, name, currentRigContext(cfg))
+	if !ok {
+		fmt.Fprintln(stderr, agentNotFoundMsg("gc pool scale", name, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if !a.IsPool() || !isConfiguredAgent(cfg, a) {
+		fmt.Fprintf(stderr, "gc pool scale: agent %q is not a pool\n", a.QualifiedName()) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	qn := a.QualifiedName()
+	pool := a.EffectivePool()
+
+	var size *int
+	if count != "auto" {
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc pool scale: count %q is not a number or \"auto\"\n", count) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if n < pool.Min || (pool.Max >= 0 && n > pool.Max) {
+			fmt.Fprintf(stderr, "gc pool scale: %s must have between %d and %s members, got %d\n", //nolint:errcheck // best-effort stderr
+				qn, pool.Min, poolMaxDisplay(pool), n)
+			return 1
+		}
+		size = &n
+	}
+
+	overrides, err := loadPoolOverrides(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool scale: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	overrides[qn] = poolOverride{Size: size}
+	if err := savePoolOverrides(cityPath, overrides); err != nil {
+		fmt.Fprintf(stderr, "gc pool scale: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if size == nil {
+		fmt.Fprintf(stdout, "Pool '%s' is autoscaled again\n", qn) //nolint:errcheck // best-effort stdout
+	} else {
+		fmt.Fprintf(stdout, "Scaled pool '%s' to %d\n", qn, *size) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
+
+// cmdPoolStopInstance is the CLI entry point for gc pool stop-instance.
+func cmdPoolStopInstance(name string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool stop-instance: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool stop-instance: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	// Bead store is best-effort: without it session names fall back to
+	// the legacy pattern.
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		store = nil
+	}
+	if code := doPoolStopInstance(cfg, cityPath, name, newSessionProvider(), store, stdout, stderr); code != 0 {
+		return code
+	}
+	pokeController(cityPath) //nolint:errcheck // best-effort: controller also notices on its next tick
+	return 0
+}
+
+// doPoolStopInstance holds pool instance name stopped, refusing when that
+// would leave the pool below its min.
+func doPoolStopInstance(cfg *config.City, cityPath, name string, sp runtime.Provider, store beads.Store, stdout, stderr io.Writer) int {
+	inst, ok := resolveAgentIdentity(cfg, name, currentRigContext(cfg))
+	if !ok {
+		fmt.Fprintln(stderr, agentNotFoundMsg("gc pool stop-instance", name, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	instance := inst.QualifiedName()
+	poolRef := config.Agent{Name: poolSlotPattern.ReplaceAllString(inst.Name, ""), Dir: inst.Dir}
+	poolAgent, ok := findAgentByQualified(cfg, poolRef.QualifiedName())
+	if !ok || isConfiguredAgent(cfg, inst) || !poolAgent.IsPool() || !poolAgent.Pool.IsMultiInstance() {
+		fmt.Fprintf(stderr, "gc pool stop-instance: %q is not a pool instance\n", instance) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	qn := poolAgent.QualifiedName()
+	pool := poolAgent.EffectivePool()
+
+	overrides, err := loadPoolOverrides(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc pool stop-instance: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	po := overrides[qn]
+	if po.isStopped(instance) {
+		fmt.Fprintf(stdout, "Instance '%s' is already held stopped\n", instance) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	st := cfg.Workspace.SessionTemplate
+	running := 0
+	instanceRunning := false
+	for _, q := range discoverPoolInstances(poolAgent.Name, poolAgent.Dir, pool, cityName, st, sp) {
+		if po.isStopped(q) || !sp.IsRunning(sessionName(store, cityName, q, st)) {
+			continue
+		}
+		running++
+		if q == instance {
+			instanceRunning = true
+		}
+	}
+	if instanceRunning && running-1 < pool.Min {
+		fmt.Fprintf(stderr, "gc pool stop-instance: stopping %s would leave %d running, below %s's min of %d\n", //nolint:errcheck // best-effort stderr
+			instance, running-1, qn, pool.Min)
+		return 1
+	}
+
+	po.Stopped = append(slices.Clone(po.Stopped), instance)
+	overrides[qn] = po
+	if err := savePoolOverrides(cityPath, overrides); err != nil {
+		fmt.Fprintf(stderr, "gc pool stop-instance: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if instanceRunning {
+		fmt.Fprintf(stdout, "Draining instance '%s'; it stays stopped until 'gc pool scale %s'\n", instance, qn) //nolint:errcheck // best-effort stdout
+	} else {
+		fmt.Fprintf(stdout, "Holding instance '%s' stopped until 'gc pool scale %s'\n", instance, qn) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
+
+// isConfiguredAgent reports whether a is an agent in the config itself
+// rather than an instance of a pool.
+func isConfiguredAgent(cfg *config.City, a config.Agent) bool {
+	for _, c := range cfg.Agents {
+		if c.Dir == a.Dir && c.Name == a.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// poolMaxDisplay renders a pool's max, "unlimited" when negative.
+func poolMaxDisplay(pool config.PoolConfig) string {
+	if pool.Max < 0 {
+		return "unlimited"
+	}
+	return strconv.Itoa(pool.Max)
+}
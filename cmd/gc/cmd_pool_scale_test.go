@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/agent"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestDoPoolScale(t *testing.T) {
+	cfg := poolStatusCity()
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+
+	if code := doPoolScale(cfg, dir, "myrig/polecat", "2", &stdout, &stderr); code != 0 {
+		t.Fatalf("exit = %d; stderr = %s", code, stderr.String())
+	}
+	o, err := loadPoolOverrides(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if po := o["myrig/polecat"]; po.Size == nil || *po.Size != 2 {
+		t.Errorf("override = %+v, want size 2", po)
+	}
+
+	for _, bad := range []string{"3", "0", "many"} {
+		stderr.Reset()
+		if code := doPoolScale(cfg, dir, "myrig/polecat", bad, &stdout, &stderr); code != 1 {
+			t.Errorf("scale to %q: exit = %d, want 1", bad, code)
+		}
+	}
+	if code := doPoolScale(cfg, dir, "mayor", "1", &stdout, &stderr); code != 1 {
+		t.Errorf("scaling a non-pool agent: exit = %d, want 1", code)
+	}
+
+	stdout.Reset()
+	if code := doPoolScale(cfg, dir, "myrig/polecat", "auto", &stdout, &stderr); code != 0 {
+		t.Fatalf("exit = %d; stderr = %s", code, stderr.String())
+	}
+	if o, _ = loadPoolOverrides(dir); len(o) != 0 {
+		t.Errorf("overrides after auto = %+v, want none", o)
+	}
+	if !strings.Contains(stdout.String(), "autoscaled") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
+
+func TestDoPoolStopInstance(t *testing.T) {
+	cfg := poolStatusCity()
+	dir := t.TempDir()
+	sp := runtime.NewFake()
+	for _, q := range []string{"myrig/polecat-1", "myrig/polecat-2"} {
+		if err := sp.Start(context.Background(), agent.SessionNameFor("test-city", q, ""), runtime.Config{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var stdout, stderr bytes.Buffer
+
+	if code := doPoolStopInstance(cfg, dir, "myrig/polecat-2", sp, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit = %d; stderr = %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Draining instance 'myrig/polecat-2'") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	o, err := loadPoolOverrides(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !o["myrig/polecat"].isStopped("myrig/polecat-2") {
+		t.Errorf("override = %+v, want polecat-2 held stopped", o["myrig/polecat"])
+	}
+
+	// Stopping the last running member would break min = 1.
+	stderr.Reset()
+	if code := doPoolStopInstance(cfg, dir, "myrig/polecat-1", sp, nil, &stdout, &stderr); code != 1 {
+		t.Errorf("exit = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "below myrig/polecat's min of 1") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+
+	// The pool itself is not an instance.
+	if code := doPoolStopInstance(cfg, dir, "myrig/polecat", sp, nil, &stdout, &stderr); code != 1 {
+		t.Errorf("stopping the pool: exit = %d, want 1", code)
+	}
+
+	// Scaling releases held instances.
+	if code := doPoolScale(cfg, dir, "myrig/polecat", "2", &stdout, &stderr); code != 0 {
+		t.Fatal(stderr.String())
+	}
+	if o, _ = loadPoolOverrides(dir); len(o["myrig/polecat"].Stopped) != 0 {
+		t.Errorf("stopped after scale = %v, want none", o["myrig/polecat"].Stopped)
+	}
+}
+
+func TestPoolOverrideManualSize(t *testing.T) {
+	n := 9
+	po := poolOverride{Size: &n}
+	if got, ok := po.manualSize(poolStatusCity().Agents[1].EffectivePool()); !ok || got != 2 {
+		t.Errorf("manualSize = %d, %v; want clamped to max 2", got, ok)
+	}
+	if _, ok := (poolOverride{}).manualSize(poolStatusCity().Agents[1].EffectivePool()); ok {
+		t.Error("manualSize without a size reported one")
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/agent"
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func poolStatusCity() *config.City {
+	return &config.City{
+		Workspace: config.Workspace{Name: "test-city"},
+		Agents: []config.Agent{
+			{Name: "mayor"},
+			{Name: "polecat", Dir: "myrig", Pool: &config.PoolConfig{Min: 1, Max: 2, Check: "count"}},
+		},
+	}
+}
+
+func noPoolWorkStore(config.Agent) (beads.Store, error) { return nil, errors.New("no store") }
+
+func TestDoPoolStatus(t *testing.T) {
+	cfg := poolStatusCity()
+	sp := runtime.NewFake()
+	sn := agent.SessionNameFor("test-city", "myrig/polecat-1", "")
+	if err := sp.Start(context.Background(), sn, runtime.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "fix the thing", Assignee: sn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inProgress := "in_progress"
+	if err := store.Update(b.ID, beads.UpdateOpts{Status: &inProgress}); err != nil {
+		t.Fatal(err)
+	}
+	runner := func(command, dir string) (string, error) {
+		if command != "count" || !strings.HasSuffix(dir, "myrig") {
+			t.Errorf("check ran %q in %q", command, dir)
+		}
+		return "5\n", nil
+	}
+
+	// The bead lives in the pool's rig store, not the city store.
+	workStore := func(a config.Agent) (beads.Store, error) {
+		if a.Dir != "myrig" {
+			t.Errorf("work store opened for %s", a.QualifiedName())
+		}
+		return store, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doPoolStatus(cfg, "/city", "", sp, beads.NewMemStore(), workStore, runner, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doPoolStatus = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"myrig/polecat  min=1 max=2  running=1  backlog=5  desired=2",
+		"myrig/polecat-1",
+		b.ID,
+		"myrig/polecat-2",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "mayor") {
+		t.Errorf("output includes non-pool agent:\n%s", out)
+	}
+}
+
+func TestDoPoolStatusJSONCheckError(t *testing.T) {
+	cfg := poolStatusCity()
+	runner := func(string, string) (string, error) { return "", errors.New("bd not found") }
+	var stdout, stderr bytes.Buffer
+	if code := doPoolStatus(cfg, "/city", "polecat", runtime.NewFake(), nil, noPoolWorkStore, runner, true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doPoolStatus = %d; stderr: %s", code, stderr.String())
+	}
+	var got []PoolStatusJSON
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(got) != 1 || got[0].Backlog != nil || got[0].CheckError == "" || got[0].Desired != 1 {
+		t.Errorf("got %+v, want check error with desired=min", got)
+	}
+	if len(got[0].Members) != 2 {
+		t.Errorf("members = %d, want 2", len(got[0].Members))
+	}
+}
+
+func TestDoPoolStatusNotAPool(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	runner := func(string, string) (string, error) { return "0", nil }
+	if code := doPoolStatus(poolStatusCity(), "/city", "mayor", runtime.NewFake(), nil, noPoolWorkStore, runner, false, &stdout, &stderr); code != 1 {
+		t.Fatalf("doPoolStatus = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "not a pool") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
@@ -50,7 +50,7 @@ type primeHookInput struct {
 
 // newPrimeCmd creates the "gc prime [agent-name]" command.
 func newPrimeCmd(stdout, stderr io.Writer) *cobra.Command {
-	var hookMode bool
+	var hookMode, withContext bool
 	cmd := &cobra.Command{
 		Use:   "prime [agent-name]",
 		Short: "Output the behavioral prompt for an agent",
@@ -64,16 +64,21 @@ Runtime hook profiles may call ` + "`gc prime --hook`" + `.
 When agent-name is omitted, ` + "`GC_AGENT`" + ` is used automatically.
 
 If agent-name matches a configured agent with a prompt_template,
-that template is output. Otherwise outputs a default worker prompt.`,
+that template is output. Otherwise outputs a default worker prompt.
+
+With --with-context (or [prime] context = true in city.toml), the
+prompt for a configured agent ends with its current state: claimed
+beads, the head of its ready queue, unread mail, and recent events.`,
 		Args: cobra.MaximumNArgs(1),
 	}
 	cmd.RunE = func(_ *cobra.Command, args []string) error {
-		if doPrimeWithMode(args, stdout, stderr, hookMode) != 0 {
+		if doPrimeWithMode(args, stdout, stderr, hookMode, withContext) != 0 {
 			return errExit
 		}
 		return nil
 	}
 	cmd.Flags().BoolVar(&hookMode, "hook", false, "compatibility mode for runtime hook invocations")
+	cmd.Flags().BoolVar(&withContext, "with-context", false, "append claimed work, ready queue, mail, and recent events")
 	return cmd
 }
 
@@ -81,10 +86,10 @@ that template is output. Otherwise outputs a default worker prompt.`,
 // city.toml and outputs the corresponding prompt template. Falls back to
 // the default run-once prompt if no match is found or no city exists.
 func doPrime(args []string, stdout, _ io.Writer) int { //nolint:unparam // always returns 0 by design (graceful fallback)
-	return doPrimeWithMode(args, stdout, io.Discard, false)
+	return doPrimeWithMode(args, stdout, io.Discard, false, false)
 }
 
-func doPrimeWithMode(args []string, stdout, _ io.Writer, hookMode bool) int { //nolint:unparam // always returns 0 by design (graceful fallback)
+func doPrimeWithMode(args []string, stdout, _ io.Writer, hookMode, withContext bool) int { //nolint:unparam // always returns 0 by design (graceful fallback)
 	agentName := os.Getenv("GC_AGENT")
 	if len(args) > 0 {
 		agentName = args[0]
@@ -144,6 +149,15 @@ func doPrimeWithMode(args []string, stdout, _ io.Writer, hookMode bool) int { //
 				})
 			}
 		}
+		// appendContext adds the agent's current state after its prompt.
+		appendContext := func() {
+			if !withContext && !cfg.Prime.Context {
+				return
+			}
+			src, id, closeFn := openPrimeContext(cityPath, cityName, cfg, a)
+			defer closeFn()
+			writePrimeContext(stdout, src, id, cfg.Prime)
+		}
 		if ok && a.PromptTemplate != "" {
 			ctx := buildPrimeContext(cityPath, &a, cfg.Rigs)
 			fragments := mergeFragmentLists(cfg.Workspace.GlobalFragments, a.InjectFragments)
@@ -151,6 +165,7 @@ func doPrimeWithMode(args []string, stdout, _ io.Writer, hookMode bool) int { //
 				cfg.PackDirs, fragments, nil)
 			if prompt != "" {
 				fmt.Fprint(stdout, prompt) //nolint:errcheck // best-effort stdout
+				appendContext()
 				return 0
 			}
 		}
@@ -161,6 +176,7 @@ func doPrimeWithMode(args []string, stdout, _ io.Writer, hookMode bool) int { //
 		if ok && a.PromptTemplate == "" && (a.IsPool() || isPoolInstance(cfg, a)) {
 			if content, fErr := os.ReadFile(filepath.Join(cityPath, ".gc/system/prompts/pool-worker.md")); fErr == nil {
 				fmt.Fprint(stdout, string(content)) //nolint:errcheck // best-effort stdout
+				appendContext()
 				return 0
 			}
 		}
@@ -316,11 +332,11 @@ func buildPrimeContext(cityPath string, a *config.Agent, rigs []config.Rig) Prom
 	// Rig context.
 	if gcRig := os.Getenv("GC_RIG"); gcRig != "" {
 		ctx.RigName = gcRig
-		ctx.IssuePrefix = findRigPrefix(gcRig, rigs)
 	} else if a.Dir != "" {
 		ctx.RigName = a.Dir
-		ctx.IssuePrefix = findRigPrefix(a.Dir, rigs)
 	}
+	ctx.IssuePrefix = findRigPrefix(ctx.RigName, rigs)
+	ctx.RigRoot = findRigPath(ctx.RigName, rigs)
 
 	ctx.Branch = os.Getenv("GC_BRANCH")
 	ctx.DefaultBranch = defaultBranchFor(ctx.WorkDir)
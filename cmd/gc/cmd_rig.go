@@ -1,9 +1,11 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/gastownhall/gascity/internal/api"
@@ -11,6 +13,7 @@ import (
 	"github.com/gastownhall/gascity/internal/fsys"
 	"github.com/gastownhall/gascity/internal/hooks"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func newRigCmd(stdout, stderr io.Writer) *cobra.Command {
@@ -25,7 +28,7 @@ are scoped to rigs via their "dir" field.`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(_ *cobra.Command, args []string) error {
 			if len(args) == 0 {
-				fmt.Fprintln(stderr, "gc rig: missing subcommand (add, list, restart, resume, status, suspend)") //nolint:errcheck // best-effort stderr
+				fmt.Fprintln(stderr, "gc rig: missing subcommand (add, list, remove, rename, restart, resume, status, suspend, sync)") //nolint:errcheck // best-effort stderr
 			} else {
 				fmt.Fprintf(stderr, "gc rig: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
 			}
@@ -35,17 +38,19 @@ are scoped to rigs via their "dir" field.`,
 	cmd.AddCommand(
 		newRigAddCmd(stdout, stderr),
 		newRigListCmd(stdout, stderr),
+		newRigRemoveCmd(stdout, stderr),
+		newRigRenameCmd(stdout, stderr),
 		newRigRestartCmd(stdout, stderr),
 		newRigResumeCmd(stdout, stderr),
 		newRigStatusCmd(stdout, stderr),
 		newRigSuspendCmd(stdout, stderr),
+		newRigSyncCmd(stdout, stderr),
 	)
 	return cmd
 }
 
 func newRigAddCmd(stdout, stderr io.Writer) *cobra.Command {
-	var include string
-	var startSuspended bool
+	var opts rigAddOpts
 	cmd := &cobra.Command{
 		Use:   "add <path>",
 		Short: "Register a project as a rig",
@@ -54,28 +59,59 @@ func newRigAddCmd(stdout, stderr io.Writer) *cobra.Command {
 Initializes beads database, installs agent hooks if configured,
 generates cross-rig routes, and appends the rig to city.toml.
 If the target directory doesn't exist, it is created. Use --include
-to apply a pack directory that defines the rig's agent configuration.
+(or its older name --topology) to apply a pack directory that defines
+the rig's agent configuration. The pack is expanded before city.toml
+is written, so a missing or broken pack leaves the config unchanged,
+and the agents it provides are listed.
+
+Use --prefix to choose the rig's bead ID prefix instead of deriving it
+from the directory name; it must not collide with the city's or another
+rig's prefix. Use --agents to run only some of the pack's agents: the
+rest are kept in the pack but suspended through rig overrides.
 
 Use --start-suspended to add the rig in a suspended state (dormant-by-default).
 The rig's agents won't spawn until explicitly resumed with "gc rig resume".`,
 		Example: `  gc rig add /path/to/project
   gc rig add ./my-project --include packs/gastown
+  gc rig add ./frontend --include packs/gastown --prefix fe
+  gc rig add ./frontend --include packs/gastown --agents witness,polecat
   gc rig add ./my-project --include packs/gastown --start-suspended`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(_ *cobra.Command, args []string) error {
-			if cmdRigAdd(args, include, startSuspended, stdout, stderr) != 0 {
+			if len(opts.Agents) > 0 && opts.Include == "" {
+				fmt.Fprintln(stderr, "gc rig add: --agents needs --include") //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if cmdRigAdd(args, opts, stdout, stderr) != 0 {
 				return errExit
 			}
 			return nil
 		},
 	}
-	cmd.Flags().StringVar(&include, "include", "", "pack directory for rig agents")
-	cmd.Flags().BoolVar(&startSuspended, "start-suspended", false, "add rig in suspended state (dormant-by-default)")
+	cmd.Flags().StringVar(&opts.Include, "include", "", "pack directory for rig agents")
+	cmd.Flags().StringVar(&opts.Prefix, "prefix", "", "bead ID prefix (default: derived from the directory name)")
+	cmd.Flags().StringSliceVar(&opts.Agents, "agents", nil, "pack agents to run; the others are suspended (comma-separated)")
+	cmd.Flags().BoolVar(&opts.StartSuspended, "start-suspended", false, "add rig in suspended state (dormant-by-default)")
+	cmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "topology" {
+			name = "include"
+		}
+		return pflag.NormalizedName(name)
+	})
 	return cmd
 }
 
+// rigAddOpts holds the flags of gc rig add.
+type rigAddOpts struct {
+	Include        string   // pack directory or URL for the rig's agents
+	Prefix         string   // explicit bead prefix; "" derives one from the name
+	Agents         []string // pack agents to run; nil runs them all
+	StartSuspended bool
+}
+
 func newRigListCmd(stdout, stderr io.Writer) *cobra.Command {
-	return &cobra.Command{
+	var jsonFlag bool
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List registered rigs",
 		Long: `List all registered rigs with their paths, prefixes, and beads status.
@@ -84,16 +120,18 @@ Shows the HQ rig (the city itself) and all configured rigs. Each rig
 displays its bead ID prefix and whether its beads database is initialized.`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(_ *cobra.Command, args []string) error {
-			if cmdRigList(args, stdout, stderr) != 0 {
+			if cmdRigList(args, jsonFlag, stdout, stderr) != 0 {
 				return errExit
 			}
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
 }
 
 // cmdRigAdd registers an external project directory as a rig in the city.
-func cmdRigAdd(args []string, include string, startSuspended bool, stdout, stderr io.Writer) int {
+func cmdRigAdd(args []string, opts rigAddOpts, stdout, stderr io.Writer) int {
 	if len(args) < 1 {
 		fmt.Fprintln(stderr, "gc rig add: missing path") //nolint:errcheck // best-effort stderr
 		return 1
@@ -110,14 +148,15 @@ func cmdRigAdd(args []string, include string, startSuspended bool, stdout, stder
 		fmt.Fprintf(stderr, "gc rig add: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
 	}
-	return doRigAdd(fsys.OSFS{}, cityPath, rigPath, include, startSuspended, stdout, stderr)
+	return doRigAdd(fsys.OSFS{}, cityPath, rigPath, opts, stdout, stderr)
 }
 
 // doRigAdd is the pure logic for "gc rig add". Operations are ordered so that
 // city.toml is written last — if any earlier step fails, config is unchanged.
 // This prevents partial-state bugs where city.toml lists a rig but the rig's
 // infrastructure (beads, routes) was never created.
-func doRigAdd(fs fsys.FS, cityPath, rigPath, include string, startSuspended bool, stdout, stderr io.Writer) int {
+func doRigAdd(fs fsys.FS, cityPath, rigPath string, opts rigAddOpts, stdout, stderr io.Writer) int {
+	include, startSuspended := opts.Include, opts.StartSuspended
 	fi, err := fs.Stat(rigPath)
 	if err != nil {
 		// Directory doesn't exist — create it.
@@ -167,9 +206,12 @@ func doRigAdd(fs fsys.FS, cityPath, rigPath, include string, startSuspended bool
 	// Derive prefix. On re-add, use the existing rig's effective prefix
 	// to avoid splitting bead state when an explicit prefix is configured.
 	var prefix string
-	if reAdd {
+	switch {
+	case reAdd:
 		prefix = existingRig.EffectivePrefix()
-	} else {
+	case opts.Prefix != "":
+		prefix = opts.Prefix
+	default:
 		prefix = config.DeriveBeadsPrefix(name)
 	}
 
@@ -189,6 +231,13 @@ func doRigAdd(fs fsys.FS, cityPath, rigPath, include string, startSuspended bool
 			fmt.Fprintf(stderr, "gc rig add: warning: --include=%s ignored (existing: %v); edit city.toml to change\n", //nolint:errcheck // best-effort stderr
 				include, existingRig.Includes)
 		}
+		if opts.Prefix != "" && opts.Prefix != prefix {
+			fmt.Fprintf(stderr, "gc rig add: warning: --prefix=%s ignored (existing: %s); edit city.toml to change\n", //nolint:errcheck // best-effort stderr
+				opts.Prefix, prefix)
+		}
+		if len(opts.Agents) > 0 {
+			fmt.Fprintln(stderr, "gc rig add: warning: --agents ignored for an existing rig; edit its overrides in city.toml to change") //nolint:errcheck // best-effort stderr
+		}
 	} else {
 		w(fmt.Sprintf("Adding rig '%s'...", name))
 	}
@@ -200,6 +249,36 @@ func doRigAdd(fs fsys.FS, cityPath, rigPath, include string, startSuspended bool
 		w(fmt.Sprintf("  Include: %s", include))
 	}
 
+	// Build and check the new rig entry up front so a bad prefix or pack
+	// fails before any infrastructure is created.
+	var rig config.Rig
+	if !reAdd {
+		rig = config.Rig{
+			Name:      name,
+			Path:      rigPath,
+			Prefix:    opts.Prefix,
+			Suspended: startSuspended,
+		}
+		cityName := cfg.Workspace.Name
+		if cityName == "" {
+			cityName = filepath.Base(cityPath)
+		}
+		if err := config.ValidateRigs(append(slices.Clone(cfg.Rigs), rig), cityName); err != nil {
+			fmt.Fprintf(stderr, "gc rig add: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if include != "" {
+			rig.Includes = []string{include}
+			agents, err := expandRigPack(fs, cityPath, rig, opts.Agents)
+			if err != nil {
+				fmt.Fprintf(stderr, "gc rig add: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			rig.Overrides = agents.overrides
+			w(fmt.Sprintf("  Agents: %s", agents))
+		}
+	}
+
 	// Initialize beads for the rig (ensure-ready → init → hooks).
 	// For bd provider, deferred to gc start (Dolt isn't running yet).
 	deferred, err := initDirIfReady(cityPath, rigPath, prefix)
@@ -225,25 +304,7 @@ func doRigAdd(fs fsys.FS, cityPath, rigPath, include string, startSuspended bool
 	// Skipped for re-adds (config already has this rig).
 
 	if !reAdd {
-		// Add rig to config and validate before writing.
-		rig := config.Rig{
-			Name:      name,
-			Path:      rigPath,
-			Suspended: startSuspended,
-		}
-		if include != "" {
-			rig.Includes = []string{include}
-		}
 		cfg.Rigs = append(cfg.Rigs, rig)
-		cityName := cfg.Workspace.Name
-		if cityName == "" {
-			cityName = filepath.Base(cityPath)
-		}
-		if err := config.ValidateRigs(cfg.Rigs, cityName); err != nil {
-			fmt.Fprintf(stderr, "gc rig add: %v\n", err) //nolint:errcheck // best-effort stderr
-			return 1
-		}
-
 		data, err := cfg.Marshal()
 		if err != nil {
 			fmt.Fprintf(stderr, "gc rig add: marshaling config: %v\n", err) //nolint:errcheck // best-effort stderr
@@ -280,6 +341,61 @@ func doRigAdd(fs fsys.FS, cityPath, rigPath, include string, startSuspended bool
 	return 0
 }
 
+// rigPackAgents describes the agents a rig's pack provides once
+// expanded, and the overrides suspending the ones not selected.
+type rigPackAgents struct {
+	names     []string
+	suspended map[string]bool
+	overrides []config.AgentOverride
+}
+
+func (a rigPackAgents) String() string {
+	if len(a.names) == 0 {
+		return "(none)"
+	}
+	parts := make([]string, len(a.names))
+	for i, n := range a.names {
+		parts[i] = n
+		if a.suspended[n] {
+			parts[i] += " (suspended)"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// expandRigPack expands rig's packs the way config loading will and
+// returns its agents. When only is non-empty, every pack agent not named
+// in it gets a suspended override; naming an agent the pack lacks is an
+// error.
+func expandRigPack(fs fsys.FS, cityPath string, rig config.Rig, only []string) (rigPackAgents, error) {
+	probe := config.City{Rigs: []config.Rig{rig}}
+	if err := config.ExpandPacks(&probe, fs, cityPath, nil); err != nil {
+		return rigPackAgents{}, err
+	}
+	var out rigPackAgents
+	for _, a := range probe.Agents {
+		out.names = append(out.names, a.Name)
+	}
+	for _, n := range only {
+		if !slices.Contains(out.names, n) {
+			return rigPackAgents{}, fmt.Errorf("--agents: pack %s has no agent %q (has: %s)", rig.Includes[0], n, strings.Join(out.names, ", "))
+		}
+	}
+	if len(only) == 0 {
+		return out, nil
+	}
+	out.suspended = make(map[string]bool)
+	for _, n := range out.names {
+		if slices.Contains(only, n) {
+			continue
+		}
+		suspended := true
+		out.overrides = append(out.overrides, config.AgentOverride{Agent: n, Suspended: &suspended})
+		out.suspended[n] = true
+	}
+	return out, nil
+}
+
 // findEnclosingRig returns the rig whose path is a prefix of dir. It does
 // prefix matching so that subdirectories of a rig are recognized.
 func findEnclosingRig(dir string, rigs []config.Rig) (name, rigPath string, found bool) {
@@ -303,13 +419,16 @@ func findEnclosingRig(dir string, rigs []config.Rig) (name, rigPath string, foun
 }
 
 // cmdRigList lists all registered rigs in the current city.
-func cmdRigList(args []string, stdout, stderr io.Writer) int {
+func cmdRigList(args []string, jsonOutput bool, stdout, stderr io.Writer) int {
 	_ = args // no arguments used yet
 	cityPath, err := resolveCity()
 	if err != nil {
 		fmt.Fprintf(stderr, "gc rig list: %v\n", err) //nolint:errcheck // best-effort stderr
 		return 1
 	}
+	if jsonOutput {
+		return doRigListJSON(fsys.OSFS{}, cityPath, stdout, stderr)
+	}
 	return doRigList(fsys.OSFS{}, cityPath, stdout, stderr)
 }
 
@@ -357,6 +476,62 @@ func doRigList(fs fsys.FS, cityPath string, stdout, stderr io.Writer) int {
 	return 0
 }
 
+// RigListJSON is the JSON output format for "gc rig list --json".
+type RigListJSON struct {
+	CityPath string        `json:"city_path"`
+	Rigs     []RigJSONItem `json:"rigs"`
+}
+
+// RigJSONItem represents a single rig in "gc rig list --json". The HQ
+// rig (the city itself) is listed first with HQ set.
+type RigJSONItem struct {
+	Name             string `json:"name"`
+	Path             string `json:"path"`
+	Prefix           string `json:"prefix"`
+	HQ               bool   `json:"hq,omitempty"`
+	Suspended        bool   `json:"suspended"`
+	BeadsInitialized bool   `json:"beads_initialized"`
+}
+
+// doRigListJSON is the JSON variant of doRigList.
+func doRigListJSON(fs fsys.FS, cityPath string, stdout, stderr io.Writer) int {
+	cfg, err := loadCityConfigFS(fs, filepath.Join(cityPath, "city.toml"))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc rig list: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	out := RigListJSON{CityPath: cityPath}
+	out.Rigs = append(out.Rigs, RigJSONItem{
+		Name:             cityName,
+		Path:             cityPath,
+		Prefix:           config.DeriveBeadsPrefix(cityName),
+		HQ:               true,
+		BeadsInitialized: rigBeadsStatus(fs, cityPath) == "initialized",
+	})
+	for i := range cfg.Rigs {
+		out.Rigs = append(out.Rigs, RigJSONItem{
+			Name:             cfg.Rigs[i].Name,
+			Path:             cfg.Rigs[i].Path,
+			Prefix:           cfg.Rigs[i].EffectivePrefix(),
+			Suspended:        cfg.Rigs[i].Suspended,
+			BeadsInitialized: rigBeadsStatus(fs, cfg.Rigs[i].Path) == "initialized",
+		})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "gc rig list: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
 // rigBeadsStatus returns a human-readable beads status for a directory.
 func rigBeadsStatus(fs fsys.FS, dir string) string {
 	metaPath := filepath.Join(dir, ".beads", "metadata.json")
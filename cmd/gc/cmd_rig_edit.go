@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+// rigArchiveRoot is the city-relative directory for gc rig remove
+// --archive-beads exports.
+const rigArchiveRoot = ".gc/archive"
+
+func newRigRemoveCmd(stdout, stderr io.Writer) *cobra.Command {
+	var archive bool
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Unregister a rig from the city",
+		Long: `Remove a rig's registration from city.toml and regenerate cross-rig
+routes. The project directory and its beads database are left on disk.
+
+Agents declared in city.toml with dir = "<name>" are not removed; each
+is reported so it can be deleted or re-scoped. Pack agents stamped into
+the rig go away with it.
+
+With --archive-beads, every bead with the rig's prefix is first exported
+to .gc/archive/rig-<name>-<timestamp>.jsonl and the open ones are closed.`,
+		Example: `  gc rig remove frontend
+  gc rig remove frontend --archive-beads`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdRigRemove(args[0], archive, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&archive, "archive-beads", false, "export the rig's beads to .gc/archive and close open ones")
+	return cmd
+}
+
+// cmdRigRemove is the CLI entry point for removing a rig.
+func cmdRigRemove(name string, archive bool, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc rig remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var store beads.Store
+	if archive {
+		cfg, err := loadCityConfigForEditFS(fsys.OSFS{}, filepath.Join(cityPath, "city.toml"))
+		if err != nil {
+			fmt.Fprintf(stderr, "gc rig remove: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		rigDir := ""
+		for _, r := range cfg.Rigs {
+			if r.Name == name {
+				rigDir = r.Path
+			}
+		}
+		if rigDir != "" {
+			if store, err = openRigStoreAt(cityPath, rigDir); err != nil {
+				fmt.Fprintf(stderr, "gc rig remove: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+		}
+	}
+	return doRigRemove(fsys.OSFS{}, cityPath, name, store, time.Now(), stdout, stderr)
+}
+
+// doRigRemove deletes the rig's [[rigs]] entry. When store is non-nil the
+// rig's beads are archived from it first; a failed archive leaves
+// city.toml untouched.
+func doRigRemove(fs fsys.FS, cityPath, name string, store beads.Store, now time.Time, stdout, stderr io.Writer) int {
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	cfg, err := loadCityConfigForEditFS(fs, tomlPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc rig remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	idx := -1
+	for i := range cfg.Rigs {
+		if cfg.Rigs[i].Name == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		fmt.Fprintln(stderr, rigNotFoundMsg("gc rig remove", name, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	rig := cfg.Rigs[idx]
+
+	if store != nil {
+		path, n, closed, err := archiveRigBeads(fs, cityPath, rig, store, now)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc rig remove: archiving beads: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintf(stdout, "Archived %d bead(s) to %s (%d closed)\n", n, path, closed) //nolint:errcheck // best-effort stdout
+	}
+
+	cfg.Rigs = append(cfg.Rigs[:idx], cfg.Rigs[idx+1:]...)
+	content, err := cfg.Marshal()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc rig remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if err := fs.WriteFile(tomlPath, content, 0o644); err != nil {
+		fmt.Fprintf(stderr, "gc rig remove: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	for _, a := range cfg.Agents {
+		if a.Dir == name {
+			fmt.Fprintf(stderr, "gc rig remove: warning: agent %q is still scoped to removed rig %q; delete it or change its dir\n", a.QualifiedName(), name) //nolint:errcheck // best-effort stderr
+		}
+	}
+	rewriteRigRoutes(cityPath, cfg, "gc rig remove", stderr)
+	fmt.Fprintf(stdout, "Removed rig '%s' (%s left on disk)\n", name, rig.Path) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// archiveRigBeads writes every bead carrying the rig's prefix to a JSONL
+// file under .gc/archive and closes the ones still open.
+func archiveRigBeads(fs fsys.FS, cityPath string, rig config.Rig, store beads.Store, now time.Time) (path string, archived, closed int, err error) {
+	all, err := store.List()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	prefix := rig.EffectivePrefix() + "-"
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	var open []string
+	for _, b := range all {
+		if !strings.HasPrefix(b.ID, prefix) {
+			continue
+		}
+		if err := enc.Encode(b); err != nil {
+			return "", 0, 0, err
+		}
+		archived++
+		if b.Status != "closed" {
+			open = append(open, b.ID)
+		}
+	}
+	dir := filepath.Join(cityPath, rigArchiveRoot)
+	if err := fs.MkdirAll(dir, 0o755); err != nil {
+		return "", 0, 0, err
+	}
+	path = filepath.Join(dir, fmt.Sprintf("rig-%s-%s.jsonl", rig.Name, now.UTC().Format("20060102T150405Z")))
+	if err := fs.WriteFile(path, []byte(buf.String()), 0o644); err != nil {
+		return "", 0, 0, err
+	}
+	for _, id := range open {
+		if err := store.Close(id); err != nil {
+			return path, archived, closed, fmt.Errorf("closing %s: %w", id, err)
+		}
+		closed++
+	}
+	return path, archived, closed, nil
+}
+
+func newRigRenameCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a rig and the agent references to it",
+		Long: `Rename a rig in city.toml. Agents and patches with dir = "<old>" and
+default_sling_target values under "<old>/" are updated, and
+rigs/<old>/rig.toml moves to rigs/<new>/.
+
+The bead ID prefix is kept: if the new name would derive a different
+prefix, the old one is pinned with an explicit prefix so existing bead
+IDs and routes stay valid. The project directory does not move. Running
+agents in the rig restart under their new names on the next reconcile.`,
+		Example: `  gc rig rename frontend web`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cityPath, err := resolveCity()
+			if err != nil {
+				fmt.Fprintf(stderr, "gc rig rename: %v\n", err) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if doRigRename(fsys.OSFS{}, cityPath, args[0], args[1], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// doRigRename renames a rig in city.toml and rewrites references to it.
+func doRigRename(fs fsys.FS, cityPath, oldName, newName string, stdout, stderr io.Writer) int {
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	cfg, err := loadCityConfigForEditFS(fs, tomlPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc rig rename: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var rig *config.Rig
+	for i := range cfg.Rigs {
+		switch cfg.Rigs[i].Name {
+		case oldName:
+			rig = &cfg.Rigs[i]
+		case newName:
+			fmt.Fprintf(stderr, "gc rig rename: rig %q already exists\n", newName) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	if rig == nil {
+		fmt.Fprintln(stderr, rigNotFoundMsg("gc rig rename", oldName, cfg)) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	oldPrefix := rig.EffectivePrefix()
+	rig.Name = newName
+	pinned := false
+	if rig.Prefix == "" && config.DeriveBeadsPrefix(newName) != oldPrefix {
+		rig.Prefix = oldPrefix
+		pinned = true
+	}
+
+	renameTarget := func(target string) string {
+		if rest, ok := strings.CutPrefix(target, oldName+"/"); ok {
+			return newName + "/" + rest
+		}
+		return target
+	}
+	agents := 0
+	for i := range cfg.Agents {
+		if cfg.Agents[i].Dir == oldName {
+			cfg.Agents[i].Dir = newName
+			agents++
+		}
+	}
+	for i := range cfg.Patches.Agents {
+		if cfg.Patches.Agents[i].Dir == oldName {
+			cfg.Patches.Agents[i].Dir = newName
+		}
+	}
+	for i := range cfg.Rigs {
+		cfg.Rigs[i].DefaultSlingTarget = renameTarget(cfg.Rigs[i].DefaultSlingTarget)
+	}
+
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	if err := config.ValidateRigs(cfg.Rigs, cityName); err != nil {
+		fmt.Fprintf(stderr, "gc rig rename: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	// Move rig.toml before committing city.toml so a failure leaves both
+	// under the old name.
+	oldDir := filepath.Dir(config.RigFilePath(cityPath, oldName))
+	newDir := filepath.Dir(config.RigFilePath(cityPath, newName))
+	if _, err := fs.Stat(oldDir); err == nil {
+		if err := fs.MkdirAll(filepath.Join(cityPath, citylayout.RigsRoot), 0o755); err != nil {
+			fmt.Fprintf(stderr, "gc rig rename: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if err := fs.Rename(oldDir, newDir); err != nil {
+			fmt.Fprintf(stderr, "gc rig rename: moving %s: %v\n", oldDir, err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+
+	content, err := cfg.Marshal()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc rig rename: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if err := fs.WriteFile(tomlPath, content, 0o644); err != nil {
+		fmt.Fprintf(stderr, "gc rig rename: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	rewriteRigRoutes(cityPath, cfg, "gc rig rename", stderr)
+
+	fmt.Fprintf(stdout, "Renamed rig '%s' to '%s' (%d agent(s) updated)\n", oldName, newName, agents) //nolint:errcheck // best-effort stdout
+	if pinned {
+		fmt.Fprintf(stdout, "  Prefix: kept '%s' (pinned so existing bead IDs stay valid)\n", oldPrefix) //nolint:errcheck // best-effort stdout
+	}
+	return 0
+}
+
+// rewriteRigRoutes regenerates routes.jsonl for the city and its rigs.
+// Best-effort: a failure is reported but the config change stands.
+func rewriteRigRoutes(cityPath string, cfg *config.City, cmdName string, stderr io.Writer) {
+	resolveRigPaths(cityPath, cfg.Rigs)
+	if err := writeAllRoutes(collectRigRoutes(cityPath, cfg)); err != nil {
+		fmt.Fprintf(stderr, "%s: writing routes: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+	}
+}
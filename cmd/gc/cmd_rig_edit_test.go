@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// writeRigEditCity writes a city with a frontend rig rooted in a temp dir
+// and an agent scoped to it.
+func writeRigEditCity(t *testing.T) (cityPath, rigPath string) {
+	t.Helper()
+	cityPath = t.TempDir()
+	rigPath = t.TempDir()
+	cityToml := "[workspace]\nname = \"test-city\"\n\n[[agent]]\nname = \"mayor\"\n\n[[agent]]\nname = \"polecat\"\ndir = \"frontend\"\n\n" +
+		"[[rigs]]\nname = \"frontend\"\npath = \"" + rigPath + "\"\ndefault_sling_target = \"frontend/polecat\"\n"
+	if err := os.WriteFile(filepath.Join(cityPath, "city.toml"), []byte(cityToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return cityPath, rigPath
+}
+
+func TestDoRigRemove(t *testing.T) {
+	cityPath, _ := writeRigEditCity(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := doRigRemove(fsys.OSFS{}, cityPath, "frontend", nil, time.Now(), &stdout, &stderr); code != 0 {
+		t.Fatalf("doRigRemove returned %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Removed rig 'frontend'") {
+		t.Errorf("stdout = %q, want removed message", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), `agent "frontend/polecat" is still scoped`) {
+		t.Errorf("stderr = %q, want scoped-agent warning", stderr.String())
+	}
+	cfg, err := config.Load(fsys.OSFS{}, filepath.Join(cityPath, "city.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Rigs) != 0 {
+		t.Errorf("rigs = %+v, want none", cfg.Rigs)
+	}
+}
+
+func TestDoRigRemoveArchivesBeads(t *testing.T) {
+	cityPath, _ := writeRigEditCity(t)
+	store := beads.NewMemStoreFrom(0, []beads.Bead{
+		{ID: "fr-1", Title: "open", Status: "open"},
+		{ID: "fr-2", Title: "done", Status: "closed"},
+		{ID: "gc-1", Title: "city", Status: "open"},
+	}, nil)
+
+	var stdout, stderr bytes.Buffer
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if code := doRigRemove(fsys.OSFS{}, cityPath, "frontend", store, now, &stdout, &stderr); code != 0 {
+		t.Fatalf("doRigRemove returned %d, stderr: %s", code, stderr.String())
+	}
+	data, err := os.ReadFile(filepath.Join(cityPath, ".gc", "archive", "rig-frontend-20260102T030405Z.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Errorf("archived %d beads, want 2:\n%s", n, data)
+	}
+	if b, _ := store.Get("fr-1"); b.Status != "closed" {
+		t.Errorf("fr-1 status = %q, want closed", b.Status)
+	}
+	if b, _ := store.Get("gc-1"); b.Status != "open" {
+		t.Errorf("gc-1 status = %q, want open (not the rig's bead)", b.Status)
+	}
+}
+
+func TestDoRigRemoveNotFound(t *testing.T) {
+	f := fsys.NewFake()
+	f.Files["/city/city.toml"] = []byte("[workspace]\nname = \"test-city\"\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := doRigRemove(f, "/city", "nonexistent", nil, time.Now(), &stdout, &stderr); code != 1 {
+		t.Fatalf("doRigRemove should fail for unknown rig, got code %d", code)
+	}
+	if !strings.Contains(stderr.String(), "not found") {
+		t.Errorf("stderr = %q, want not found message", stderr.String())
+	}
+}
+
+func TestDoRigRename(t *testing.T) {
+	cityPath, _ := writeRigEditCity(t)
+	rigFile := config.RigFilePath(cityPath, "frontend")
+	if err := os.MkdirAll(filepath.Dir(rigFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(rigFile, []byte("default_sling_target = \"frontend/polecat\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doRigRename(fsys.OSFS{}, cityPath, "frontend", "web", &stdout, &stderr); code != 0 {
+		t.Fatalf("doRigRename returned %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Prefix: kept 'fr'") {
+		t.Errorf("stdout = %q, want pinned prefix note", stdout.String())
+	}
+	cfg, err := config.Load(fsys.OSFS{}, filepath.Join(cityPath, "city.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := cfg.Rigs[0]
+	if r.Name != "web" || r.Prefix != "fr" || r.DefaultSlingTarget != "web/polecat" {
+		t.Errorf("rig = %+v, want web with prefix fr and target web/polecat", r)
+	}
+	if cfg.Agents[1].Dir != "web" {
+		t.Errorf("polecat dir = %q, want web", cfg.Agents[1].Dir)
+	}
+	if _, err := os.Stat(config.RigFilePath(cityPath, "web")); err != nil {
+		t.Errorf("rig.toml not moved: %v", err)
+	}
+}
+
+func TestDoRigRenameConflict(t *testing.T) {
+	f := fsys.NewFake()
+	f.Files["/city/city.toml"] = []byte("[workspace]\nname = \"test-city\"\n\n[[rigs]]\nname = \"a\"\npath = \"/a\"\n\n[[rigs]]\nname = \"b\"\npath = \"/b\"\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := doRigRename(f, "/city", "a", "b", &stdout, &stderr); code != 1 {
+		t.Fatalf("rename onto existing rig should fail, got code %d", code)
+	}
+	if !strings.Contains(stderr.String(), "already exists") {
+		t.Errorf("stderr = %q, want already exists", stderr.String())
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+// rigSyncOptions selects which kinds of drift gc rig sync fixes.
+type rigSyncOptions struct {
+	Register bool // add [[rigs]] entries for unregistered projects under rigs/
+	Prune    bool // drop [[rigs]] entries whose path no longer exists
+	Repair   bool // re-initialize beads for rigs missing .beads/
+}
+
+func newRigSyncCmd(stdout, stderr io.Writer) *cobra.Command {
+	var opts rigSyncOptions
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Reconcile registered rigs with what is on disk",
+		Long: `Compare the [[rigs]] entries in city.toml with the filesystem and
+report drift:
+
+  - registered rigs whose path no longer exists (fix with --prune)
+  - registered rigs whose beads directory is missing (fix with --repair)
+  - registered rigs whose path is not a git repository (reported only)
+  - directories under rigs/ with no [[rigs]] entry; those holding a
+    project (.git or .beads) can be registered with --register
+
+Without flags nothing is changed. Exits 1 when unfixed drift remains.
+Routes are regenerated after any change.`,
+		Example: `  gc rig sync
+  gc rig sync --prune --repair
+  gc rig sync --register`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cityPath, err := resolveCity()
+			if err != nil {
+				fmt.Fprintf(stderr, "gc rig sync: %v\n", err) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if doRigSync(fsys.OSFS{}, cityPath, opts, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&opts.Register, "register", false, "register unregistered projects found under rigs/")
+	cmd.Flags().BoolVar(&opts.Prune, "prune", false, "remove registrations whose path no longer exists")
+	cmd.Flags().BoolVar(&opts.Repair, "repair", false, "re-initialize beads for rigs missing .beads/")
+	return cmd
+}
+
+// doRigSync reports drift between city.toml and the filesystem and fixes
+// the kinds selected in opts. city.toml is only written when a
+// registration changes.
+func doRigSync(fs fsys.FS, cityPath string, opts rigSyncOptions, stdout, stderr io.Writer) int {
+	tomlPath := filepath.Join(cityPath, "city.toml")
+	cfg, err := loadCityConfigForEditFS(fs, tomlPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc rig sync: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	w := func(format string, args ...any) { fmt.Fprintf(stdout, format+"\n", args...) } //nolint:errcheck // best-effort stdout
+
+	absPath := func(p string) string {
+		if filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(cityPath, p)
+	}
+	isDir := func(p string) bool {
+		fi, err := fs.Stat(p)
+		return err == nil && fi.IsDir()
+	}
+	exists := func(p string) bool {
+		_, err := fs.Stat(p)
+		return err == nil
+	}
+
+	initBeads := func(r config.Rig) bool {
+		deferred, err := initDirIfReady(cityPath, absPath(r.Path), r.EffectivePrefix())
+		if err != nil {
+			fmt.Fprintf(stderr, "gc rig sync: rig %q: %v\n", r.Name, err) //nolint:errcheck // best-effort stderr
+			return false
+		}
+		if deferred {
+			w("Repaired rig '%s' (beads init deferred to gc start)", r.Name)
+		} else {
+			w("Repaired rig '%s' (initialized beads)", r.Name)
+		}
+		return true
+	}
+
+	issues := 0
+	configChanged, routesChanged := false, false
+	registered := make(map[string]bool, len(cfg.Rigs))
+	kept := cfg.Rigs[:0]
+	for _, r := range cfg.Rigs {
+		registered[r.Name] = true
+		path := absPath(r.Path)
+		if !isDir(path) {
+			if opts.Prune {
+				w("Removed rig '%s' (path %s no longer exists)", r.Name, path)
+				configChanged = true
+				continue
+			}
+			w("rig '%s': path %s does not exist (fix: --prune)", r.Name, path)
+			issues++
+			kept = append(kept, r)
+			continue
+		}
+		kept = append(kept, r)
+		if !exists(filepath.Join(path, ".git")) {
+			w("rig '%s': %s is not a git repository", r.Name, path)
+		}
+		if !isDir(filepath.Join(path, ".beads")) {
+			if !opts.Repair {
+				w("rig '%s': beads not initialized at %s (fix: --repair)", r.Name, path)
+				issues++
+				continue
+			}
+			if !initBeads(r) {
+				issues++
+				continue
+			}
+			routesChanged = true
+		}
+	}
+	cfg.Rigs = kept
+
+	// Directories under rigs/ that no [[rigs]] entry claims.
+	rigsRoot := filepath.Join(cityPath, citylayout.RigsRoot)
+	entries, _ := fs.ReadDir(rigsRoot)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, e := range entries {
+		if !e.IsDir() || registered[e.Name()] {
+			continue
+		}
+		dir := filepath.Join(rigsRoot, e.Name())
+		rel := filepath.Join(citylayout.RigsRoot, e.Name())
+		if !exists(filepath.Join(dir, ".git")) && !isDir(filepath.Join(dir, ".beads")) {
+			w("%s: no [[rigs]] entry and no project to register; delete it or register its project with 'gc rig add <path>'", rel)
+			issues++
+			continue
+		}
+		if !opts.Register {
+			w("%s: project is not registered (fix: --register)", rel)
+			issues++
+			continue
+		}
+		rig := config.Rig{Name: e.Name(), Path: dir}
+		cfg.Rigs = append(cfg.Rigs, rig)
+		registered[e.Name()] = true
+		configChanged = true
+		w("Registered rig '%s' at %s", e.Name(), dir)
+		if !isDir(filepath.Join(dir, ".beads")) && !initBeads(rig) {
+			issues++
+		}
+	}
+
+	if configChanged {
+		cityName := cfg.Workspace.Name
+		if cityName == "" {
+			cityName = filepath.Base(cityPath)
+		}
+		if err := config.ValidateRigs(cfg.Rigs, cityName); err != nil {
+			fmt.Fprintf(stderr, "gc rig sync: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		content, err := cfg.Marshal()
+		if err != nil {
+			fmt.Fprintf(stderr, "gc rig sync: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if err := fs.WriteFile(tomlPath, content, 0o644); err != nil {
+			fmt.Fprintf(stderr, "gc rig sync: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	if configChanged || routesChanged {
+		// Leave unpruned missing rigs out of the routes rather than
+		// recreating their directories to hold a routes file.
+		routeCfg := *cfg
+		routeCfg.Rigs = nil
+		for _, r := range cfg.Rigs {
+			if isDir(absPath(r.Path)) {
+				routeCfg.Rigs = append(routeCfg.Rigs, r)
+			}
+		}
+		rewriteRigRoutes(cityPath, &routeCfg, "gc rig sync", stderr)
+	}
+
+	if issues > 0 {
+		w("%d issue(s) found.", issues)
+		return 1
+	}
+	w("Rigs in sync.")
+	return 0
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// writeSyncCity writes a city with one healthy rig, one rig whose path is
+// gone, and an unregistered git project under rigs/.
+func writeSyncCity(t *testing.T) string {
+	t.Helper()
+	cityPath := t.TempDir()
+	healthy := filepath.Join(t.TempDir(), "api")
+	for _, d := range []string{
+		filepath.Join(healthy, ".git"),
+		filepath.Join(healthy, ".beads"),
+		filepath.Join(cityPath, "rigs", "docs", ".git"),
+	} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cityToml := "[workspace]\nname = \"test-city\"\n\n[[agent]]\nname = \"mayor\"\n\n" +
+		"[[rigs]]\nname = \"api\"\npath = \"" + healthy + "\"\n\n" +
+		"[[rigs]]\nname = \"gone\"\npath = \"" + filepath.Join(cityPath, "missing") + "\"\n"
+	if err := os.WriteFile(filepath.Join(cityPath, "city.toml"), []byte(cityToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return cityPath
+}
+
+func TestDoRigSyncReportsDrift(t *testing.T) {
+	cityPath := writeSyncCity(t)
+	before, _ := os.ReadFile(filepath.Join(cityPath, "city.toml"))
+
+	var stdout, stderr bytes.Buffer
+	if code := doRigSync(fsys.OSFS{}, cityPath, rigSyncOptions{}, &stdout, &stderr); code != 1 {
+		t.Fatalf("code = %d, want 1; stdout:\n%s", code, stdout.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"rig 'gone': path " + filepath.Join(cityPath, "missing") + " does not exist (fix: --prune)",
+		"rigs/docs: project is not registered (fix: --register)",
+		"2 issue(s) found.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "'api'") {
+		t.Errorf("healthy rig reported:\n%s", out)
+	}
+	after, _ := os.ReadFile(filepath.Join(cityPath, "city.toml"))
+	if !bytes.Equal(before, after) {
+		t.Error("report-only sync modified city.toml")
+	}
+}
+
+func TestDoRigSyncFixes(t *testing.T) {
+	t.Setenv("GC_BEADS", "file")
+	cityPath := writeSyncCity(t)
+
+	var stdout, stderr bytes.Buffer
+	opts := rigSyncOptions{Register: true, Prune: true, Repair: true}
+	if code := doRigSync(fsys.OSFS{}, cityPath, opts, &stdout, &stderr); code != 0 {
+		t.Fatalf("code = %d, stdout:\n%s\nstderr: %s", code, stdout.String(), stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"Removed rig 'gone'", "Registered rig 'docs'", "Repaired rig 'docs'", "Rigs in sync."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	cfg, err := config.Load(fsys.OSFS{}, filepath.Join(cityPath, "city.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range cfg.Rigs {
+		names = append(names, r.Name)
+	}
+	if strings.Join(names, ",") != "api,docs" {
+		t.Errorf("rigs = %v, want [api docs]", names)
+	}
+	if _, err := os.Stat(filepath.Join(cityPath, "missing")); err == nil {
+		t.Error("sync recreated the pruned rig's directory")
+	}
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -30,7 +32,7 @@ func TestDoRigAdd_Basic(t *testing.T) {
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd returned %d, stderr: %s", code, stderr.String())
 	}
@@ -75,7 +77,7 @@ func TestDoRigAdd_DuplicateNameDifferentPath(t *testing.T) {
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 1 {
 		t.Fatalf("doRigAdd should fail for duplicate with different path, got code %d", code)
 	}
@@ -115,7 +117,7 @@ func TestDoRigAdd_IdempotentSameNameSamePath(t *testing.T) {
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd should succeed for same name+path, got code %d, stderr: %s", code, stderr.String())
 	}
@@ -160,7 +162,7 @@ func TestDoRigAdd_ReAddUsesExistingPrefix(t *testing.T) {
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd should succeed, got code %d, stderr: %s", code, stderr.String())
 	}
@@ -197,7 +199,7 @@ func TestDoRigAdd_ReAddWarnsDifferingFlags(t *testing.T) {
 
 	// Re-add with --start-suspended=true (differs from existing).
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "packs/new", true, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{Include: "packs/new", StartSuspended: true}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd should succeed, got code %d, stderr: %s", code, stderr.String())
 	}
@@ -235,7 +237,7 @@ func TestDoRigAdd_ReAddNoSpuriousWarning(t *testing.T) {
 
 	// Re-add with default flags (no --start-suspended, no --include).
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd should succeed, got code %d, stderr: %s", code, stderr.String())
 	}
@@ -260,7 +262,7 @@ func TestDoRigAdd_NotADirectory(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, filePath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, filePath, rigAddOpts{}, &stdout, &stderr)
 	if code != 1 {
 		t.Fatalf("expected failure for non-directory, got code %d", code)
 	}
@@ -285,7 +287,7 @@ func TestDoRigAdd_RoutesGenerated(t *testing.T) {
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd returned %d, stderr: %s", code, stderr.String())
 	}
@@ -323,7 +325,7 @@ func TestDoRigAdd_ConfigUnchangedOnInfraFailure(t *testing.T) {
 	f.Errors[filepath.Join("/fake-rig", ".beads")] = os.ErrPermission
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(f, cityPath, "/fake-rig", "", false, &stdout, &stderr)
+	code := doRigAdd(f, cityPath, "/fake-rig", rigAddOpts{}, &stdout, &stderr)
 	if code != 1 {
 		t.Fatalf("expected failure, got code %d", code)
 	}
@@ -390,6 +392,34 @@ func TestDoRigList_WithRigs(t *testing.T) {
 	}
 }
 
+func TestDoRigListJSON(t *testing.T) {
+	cityPath := t.TempDir()
+	rigPath := filepath.Join(t.TempDir(), "my-frontend")
+	cityToml := "[workspace]\nname = \"test-city\"\n\n[[rigs]]\nname = \"my-frontend\"\npath = \"" + rigPath + "\"\nprefix = \"fe\"\nsuspended = true\n"
+	if err := os.WriteFile(filepath.Join(cityPath, "city.toml"), []byte(cityToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doRigListJSON(fsys.OSFS{}, cityPath, &stdout, &stderr); code != 0 {
+		t.Fatalf("doRigListJSON returned %d, stderr: %s", code, stderr.String())
+	}
+	var got RigListJSON
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, stdout.String())
+	}
+	if len(got.Rigs) != 2 {
+		t.Fatalf("rigs = %d, want 2 (HQ + my-frontend)", len(got.Rigs))
+	}
+	if !got.Rigs[0].HQ || got.Rigs[0].Name != "test-city" || got.Rigs[0].Prefix != "tc" {
+		t.Errorf("HQ rig = %+v", got.Rigs[0])
+	}
+	rig := got.Rigs[1]
+	if rig.Name != "my-frontend" || rig.Prefix != "fe" || !rig.Suspended || rig.BeadsInitialized {
+		t.Errorf("rig = %+v", rig)
+	}
+}
+
 func TestDoRigList_Empty(t *testing.T) {
 	cityPath := t.TempDir()
 	if err := os.MkdirAll(filepath.Join(cityPath, ".gc"), 0o755); err != nil {
@@ -608,11 +638,13 @@ func TestDoRigAdd_WithPack(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	writeTestPack(t, filepath.Join(cityPath, "packs", "gastown"), "witness", "polecat")
+
 	t.Setenv("GC_DOLT", "skip")
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "packs/gastown", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{Include: "packs/gastown"}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd returned %d, stderr: %s", code, stderr.String())
 	}
@@ -637,6 +669,83 @@ func TestDoRigAdd_WithPack(t *testing.T) {
 	if len(cfg.Rigs[0].Includes) != 1 || cfg.Rigs[0].Includes[0] != "packs/gastown" {
 		t.Errorf("rig includes = %v, want [packs/gastown]; city.toml:\n%s", cfg.Rigs[0].Includes, data)
 	}
+	if !strings.Contains(output, "Agents: witness, polecat") {
+		t.Errorf("output missing expanded agents: %s", output)
+	}
+}
+
+// writeTestPack writes a minimal pack defining the named agents.
+func writeTestPack(t *testing.T, dir string, agents ...string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	toml := "[pack]\nname = \"" + filepath.Base(dir) + "\"\nschema = 1\n"
+	for _, a := range agents {
+		toml += "\n[[agent]]\nname = \"" + a + "\"\n"
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pack.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDoRigAdd_PrefixAndAgents(t *testing.T) {
+	cityPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(cityPath, ".gc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cityToml := "[workspace]\nname = \"test-city\"\n\n[[agent]]\nname = \"mayor\"\n\n[[rigs]]\nname = \"backend\"\npath = \"/srv/backend\"\nprefix = \"be\"\n"
+	if err := os.WriteFile(filepath.Join(cityPath, "city.toml"), []byte(cityToml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeTestPack(t, filepath.Join(cityPath, "packs", "gastown"), "witness", "polecat", "refinery")
+	t.Setenv("GC_DOLT", "skip")
+	t.Setenv("GC_BEADS", "file")
+
+	var stdout, stderr bytes.Buffer
+	rigPath := filepath.Join(t.TempDir(), "frontend")
+	opts := rigAddOpts{Include: "packs/gastown", Prefix: "fe", Agents: []string{"polecat"}}
+	if code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, opts, &stdout, &stderr); code != 0 {
+		t.Fatalf("doRigAdd returned %d, stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Agents: witness (suspended), polecat, refinery (suspended)") {
+		t.Errorf("output = %s", stdout.String())
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rig := cfg.Rigs[1]
+	if rig.Prefix != "fe" || len(rig.Overrides) != 2 {
+		t.Fatalf("rig = %+v, want prefix fe and two overrides", rig)
+	}
+	for _, a := range cfg.Agents {
+		if a.Dir != "frontend" || !slices.Contains([]string{"witness", "polecat", "refinery"}, a.Name) {
+			continue
+		}
+		if want := a.Name != "polecat"; a.Suspended != want {
+			t.Errorf("agent %s suspended = %v, want %v", a.Name, a.Suspended, want)
+		}
+	}
+
+	// Prefix collisions and unknown agents fail before city.toml changes.
+	before, _ := os.ReadFile(filepath.Join(cityPath, "city.toml"))
+	for _, tc := range []struct {
+		opts rigAddOpts
+		want string
+	}{
+		{rigAddOpts{Prefix: "be"}, `prefix "be" collides with backend`},
+		{rigAddOpts{Include: "packs/gastown", Agents: []string{"mayor"}}, `has no agent "mayor"`},
+		{rigAddOpts{Include: "packs/missing"}, "packs/missing"},
+	} {
+		stderr.Reset()
+		if code := doRigAdd(fsys.OSFS{}, cityPath, filepath.Join(t.TempDir(), "other"), tc.opts, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), tc.want) {
+			t.Errorf("doRigAdd(%+v) = %d, stderr %q, want %q", tc.opts, code, stderr.String(), tc.want)
+		}
+	}
+	if after, _ := os.ReadFile(filepath.Join(cityPath, "city.toml")); !bytes.Equal(before, after) {
+		t.Errorf("city.toml changed by failed adds:\n%s", after)
+	}
 }
 
 func TestDoRigAdd_WithoutPack(t *testing.T) {
@@ -658,7 +767,7 @@ func TestDoRigAdd_WithoutPack(t *testing.T) {
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd returned %d, stderr: %s", code, stderr.String())
 	}
@@ -702,7 +811,7 @@ func TestDoRigAdd_PrefixCollision(t *testing.T) {
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 1 {
 		t.Fatalf("doRigAdd should fail for prefix collision, got code %d", code)
 	}
@@ -817,7 +926,7 @@ name = "inline-agent"
 	t.Setenv("GC_BEADS", "file")
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("code = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/automations"
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newScheduleCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Inspect recurring formula schedules",
+		Long: `Inspect recurring formula cooks declared with [[schedules]] in city.toml.
+
+Each schedule names a formula, a 5-field cron expression, and a target
+agent or pool. The controller runs schedules as cron-gated automations,
+so runs appear in both gc schedule history and gc automation history.
+
+Example:
+  [[schedules]]
+  name = "weekly-triage"
+  cron = "0 9 * * 1"
+  formula = "weekly-triage"
+  target = "mayor"`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc schedule: missing subcommand (list, history)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc schedule: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(
+		newScheduleListCmd(stdout, stderr),
+		newScheduleHistoryCmd(stdout, stderr),
+	)
+	return cmd
+}
+
+func newScheduleListCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List schedules with their next and last run",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdScheduleList(stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+func newScheduleHistoryCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [name]",
+		Short: "Show schedule run history",
+		Long: `Show the wisps dispatched by schedules, optionally for one schedule.
+
+Runs are tracked with the same automation-run labels as automations.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			}
+			if cmdScheduleHistory(name, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// scheduleAutomations converts the city's enabled [[schedules]] into
+// cron-gated formula automations so the controller dispatches them with
+// the rest. Schedules run at city scope.
+func scheduleAutomations(cityPath string, cfg *config.City) []automations.Automation {
+	if len(cfg.Schedules) == 0 {
+		return nil
+	}
+	var aa []automations.Automation
+	for _, s := range cfg.Schedules {
+		if !s.IsEnabled() {
+			continue
+		}
+		a := automations.Automation{
+			Name:        s.Name,
+			Description: s.Description,
+			Formula:     s.Formula,
+			Gate:        "cron",
+			Schedule:    s.Cron,
+			Pool:        s.Target,
+			Source:      filepath.Join(cityPath, "city.toml"),
+		}
+		aa = append(aa, a)
+	}
+	return aa
+}
+
+// --- gc schedule list ---
+
+func cmdScheduleList(stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc schedule list: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc schedule list: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store := beads.NewBdStore(cityPath, beads.ExecCommandRunner())
+	return doScheduleList(cfg.Schedules, automationLastRunFn(store), time.Now(), stdout)
+}
+
+// doScheduleList prints each schedule with its next matching minute and
+// most recent run. Accepts a LastRunFunc for testability.
+func doScheduleList(schedules []config.Schedule, lastRunFn automations.LastRunFunc, now time.Time, stdout io.Writer) int {
+	if len(schedules) == 0 {
+		fmt.Fprintln(stdout, "No schedules configured.") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	fmt.Fprintf(stdout, "%-20s %-15s %-20s %-15s %-17s %s\n", "NAME", "CRON", "FORMULA", "TARGET", "NEXT", "LAST") //nolint:errcheck
+	for _, s := range schedules {
+		target := s.Target
+		if target == "" {
+			target = "-"
+		}
+		next := "disabled"
+		if s.IsEnabled() {
+			next = "-"
+			if t, ok := automations.NextCronRun(s.Cron, now); ok {
+				next = t.Format("2006-01-02 15:04")
+			}
+		}
+		last := "never"
+		if t, err := lastRunFn(s.Name); err == nil && !t.IsZero() {
+			last = formatDuration(now.Sub(t)) + " ago"
+		}
+		fmt.Fprintf(stdout, "%-20s %-15s %-20s %-15s %-17s %s\n", s.Name, s.Cron, s.Formula, target, next, last) //nolint:errcheck
+	}
+	return 0
+}
+
+// --- gc schedule history ---
+
+func cmdScheduleHistory(name string, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc schedule history: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc schedule history: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if name != "" && !hasSchedule(cfg.Schedules, name) {
+		fmt.Fprintf(stderr, "gc schedule history: schedule %q not found\n", name) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var aa []automations.Automation
+	for _, s := range cfg.Schedules {
+		aa = append(aa, automations.Automation{Name: s.Name})
+	}
+	store := beads.NewBdStore(cityPath, beads.ExecCommandRunner())
+	return doAutomationHistory(name, "", aa, store, stdout)
+}
+
+func hasSchedule(schedules []config.Schedule, name string) bool {
+	for _, s := range schedules {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
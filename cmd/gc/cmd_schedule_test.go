@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func TestScheduleAutomations(t *testing.T) {
+	off := false
+	cfg := &config.City{Schedules: []config.Schedule{
+		{Name: "weekly-triage", Cron: "0 9 * * 1", Formula: "triage", Target: "mayor"},
+		{Name: "paused", Cron: "0 0 * * *", Formula: "report", Enabled: &off},
+	}}
+	aa := scheduleAutomations("/city", cfg)
+	if len(aa) != 1 {
+		t.Fatalf("got %d automations, want 1 (disabled skipped): %+v", len(aa), aa)
+	}
+	a := aa[0]
+	if a.Name != "weekly-triage" || a.Gate != "cron" || a.Schedule != "0 9 * * 1" || a.Formula != "triage" || a.Pool != "mayor" {
+		t.Errorf("automation = %+v", a)
+	}
+}
+
+func TestDoScheduleList(t *testing.T) {
+	off := false
+	now := time.Date(2026, 2, 27, 12, 0, 0, 0, time.UTC)
+	lastRun := func(name string) (time.Time, error) {
+		if name == "weekly-triage" {
+			return now.Add(-2 * time.Hour), nil
+		}
+		return time.Time{}, nil
+	}
+	var out bytes.Buffer
+	code := doScheduleList([]config.Schedule{
+		{Name: "weekly-triage", Cron: "0 9 * * 1", Formula: "triage", Target: "mayor"},
+		{Name: "paused", Cron: "0 0 * * *", Formula: "report", Enabled: &off},
+	}, lastRun, now, &out)
+	if code != 0 {
+		t.Fatalf("doScheduleList = %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("output:\n%s", out.String())
+	}
+	if !strings.Contains(lines[1], "2026-03-02 09:00") || !strings.Contains(lines[1], "2h ago") {
+		t.Errorf("triage row = %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "disabled") || !strings.Contains(lines[2], "never") {
+		t.Errorf("paused row = %q", lines[2])
+	}
+}
+
+func TestDoScheduleListEmpty(t *testing.T) {
+	var out bytes.Buffer
+	doScheduleList(nil, func(string) (time.Time, error) { return time.Time{}, nil }, time.Now(), &out)
+	if !strings.Contains(out.String(), "No schedules configured.") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/api"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+// defaultServeAPIPort is the port for gc serve --api: one above the
+// controller's API port so both can run side by side.
+const defaultServeAPIPort = config.DefaultAPIPort + 1
+
+// apiTokenFile is the city-relative path of the gc serve --api token.
+const apiTokenFile = ".gc/api-token"
+
+func newServeCmd(stdout, stderr io.Writer) *cobra.Command {
+	var apiFlag, rotate bool
+	var bind string
+	var port int
+	cmd := &cobra.Command{
+		Use:   "serve --api",
+		Short: "Serve the city's HTTP+JSON API with token auth",
+		Long: `Serve the versioned /v0 HTTP+JSON API (beads, agents, sessions, sling,
+mail, events) for the current city, protected by a bearer token.
+
+Unlike the controller's built-in API, which trusts every local caller,
+every request except GET /health must carry:
+
+  Authorization: Bearer <token>
+
+The token is generated on first use and stored (mode 0600) in
+.gc/api-token; --rotate-token replaces it. Because callers are
+authenticated, mutations stay enabled on non-localhost binds, so other
+machines can orchestrate the city. The server runs alongside the
+controller and works on the same stores; it does not need one.`,
+		Example: `  gc serve --api
+  gc serve --api --bind 0.0.0.0 --port 9500
+  curl -H "Authorization: Bearer $(cat .gc/api-token)" http://127.0.0.1:9444/v0/beads`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if !apiFlag {
+				fmt.Fprintln(stderr, "gc serve: nothing to serve (use --api)") //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if cmdServeAPI(bind, port, rotate, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&apiFlag, "api", false, "serve the HTTP+JSON API")
+	cmd.Flags().StringVar(&bind, "bind", "127.0.0.1", "address to listen on")
+	cmd.Flags().IntVar(&port, "port", defaultServeAPIPort, "TCP port to listen on")
+	cmd.Flags().BoolVar(&rotate, "rotate-token", false, "generate a new API token before serving")
+	return cmd
+}
+
+// cmdServeAPI runs the token-protected API server until interrupted.
+func cmdServeAPI(bind string, port int, rotate bool, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	token, err := loadAPIToken(cityPath, rotate)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	ep, code := openCityEventsProvider(stderr, "gc serve")
+	if ep == nil {
+		return code
+	}
+	defer ep.Close() //nolint:errcheck // best-effort
+
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	srv := api.NewWithToken(newControllerState(cfg, newSessionProvider(), ep, cityName, cityPath), token)
+
+	addr := net.JoinHostPort(bind, strconv.Itoa(port))
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "API server listening on http://%s/v0 (token in %s)\n", addr, filepath.Join(cityPath, apiTokenFile)) //nolint:errcheck // best-effort stdout
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutCtx) //nolint:errcheck // best-effort cleanup
+	}()
+	if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(stderr, "gc serve: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return 0
+}
+
+// loadAPIToken returns the city's API token, generating and storing a new
+// one when none exists or rotate is set.
+func loadAPIToken(cityPath string, rotate bool) (string, error) {
+	return loadTokenFile(filepath.Join(cityPath, apiTokenFile), rotate)
+}
+
+// loadTokenFile returns the secret token stored at path, generating and
+// storing (mode 0600) a new one when none exists or rotate is set.
+func loadTokenFile(path string, rotate bool) (string, error) {
+	if !rotate {
+		data, err := os.ReadFile(path)
+		if err == nil && strings.TrimSpace(string(data)) != "" {
+			return strings.TrimSpace(string(data)), nil
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("reading token: %w", err)
+		}
+	}
+	var buf [32]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	token := hex.EncodeToString(buf[:])
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("writing token: %w", err)
+	}
+	if err := fsys.WriteFileAtomic(fsys.OSFS{}, path, []byte(token+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("writing token: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAPIToken(t *testing.T) {
+	city := t.TempDir()
+	first, err := loadAPIToken(city, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 64 {
+		t.Errorf("token = %q, want 64 hex chars", first)
+	}
+	fi, err := os.Stat(filepath.Join(city, apiTokenFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0o600 {
+		t.Errorf("token file mode = %v, want 0600", fi.Mode().Perm())
+	}
+
+	again, err := loadAPIToken(city, false)
+	if err != nil || again != first {
+		t.Errorf("reload = %q, %v; want the stored token", again, err)
+	}
+	rotated, err := loadAPIToken(city, true)
+	if err != nil || rotated == first {
+		t.Errorf("rotate = %q, %v; want a new token", rotated, err)
+	}
+}
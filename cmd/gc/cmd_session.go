@@ -182,6 +182,7 @@ func cmdSessionNew(args []string, title string, noAttach bool, stdout, stderr io
 	hints := runtime.Config{
 		ReadyPromptPrefix:      resolved.ReadyPromptPrefix,
 		ReadyDelayMs:           resolved.ReadyDelayMs,
+		ReadyProbe:             resolved.ReadyProbe,
 		ProcessNames:           resolved.ProcessNames,
 		EmitsPermissionWarning: resolved.EmitsPermissionWarning,
 	}
@@ -418,7 +419,7 @@ func cliPoolDesired(cfg *config.City) map[string]int {
 // newSessionAttachCmd creates the "gc session attach <id-or-name>" command.
 func newSessionAttachCmd(stdout, stderr io.Writer) *cobra.Command {
 	return &cobra.Command{
-		Use:   "attach <session-id-or-name>",
+		Use:   "attach [session-id-or-name]",
 		Short: "Attach to (or resume) a chat session",
 		Long: `Attach to a running session or resume a suspended one.
 
@@ -426,8 +427,11 @@ If the session is active with a live tmux session, reattaches.
 If the session is suspended or the tmux session died, resumes
 using the provider's resume mechanism (if supported) or restarts.
 
-Accepts a session ID (e.g., gc-42) or template name (e.g., overseer).`,
-		Args: cobra.ExactArgs(1),
+Accepts a session ID (e.g., gc-42) or template name (e.g., overseer).
+Without an argument, lists the running sessions with their agent, rig,
+and current bead and asks which to attach to; with exactly one running
+session, attaches to it directly.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			if cmdSessionAttach(args, stdout, stderr) != 0 {
 				return errExit
@@ -455,15 +459,29 @@ func cmdSessionAttach(args []string, stdout, stderr io.Writer) int {
 		return code
 	}
 
-	sessionID, err := resolveSessionID(store, args[0])
-	if err != nil {
-		fmt.Fprintf(stderr, "gc session attach: %v\n", err) //nolint:errcheck // best-effort stderr
-		return 1
-	}
-
 	sp := newSessionProvider()
 	mgr := newSessionManager(store, sp)
 
+	var sessionID string
+	if len(args) == 0 {
+		sessions, err := mgr.List("", "")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc session attach: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		sessionID, err = pickAttachCandidate(attachCandidates(store, sessions, sp), stdin(), stdout)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc session attach: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	} else {
+		sessionID, err = resolveSessionID(store, args[0])
+		if err != nil {
+			fmt.Fprintf(stderr, "gc session attach: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+
 	// Get the session to find its template.
 	info, err := mgr.Get(sessionID)
 	if err != nil {
@@ -515,6 +533,7 @@ func buildResumeCommand(cfg *config.City, info session.Info) (string, runtime.Co
 		WorkDir:                info.WorkDir,
 		ReadyPromptPrefix:      resolved.ReadyPromptPrefix,
 		ReadyDelayMs:           resolved.ReadyDelayMs,
+		ReadyProbe:             resolved.ReadyProbe,
 		ProcessNames:           resolved.ProcessNames,
 		EmitsPermissionWarning: resolved.EmitsPermissionWarning,
 		Env:                    resolved.Env,
@@ -867,6 +886,7 @@ func cmdSessionKill(args []string, stdout, stderr io.Writer) int {
 // newSessionNudgeCmd creates the "gc session nudge <id-or-name> <message>" command.
 func newSessionNudgeCmd(stdout, stderr io.Writer) *cobra.Command {
 	var delivery string
+	var all, pool bool
 	cmd := &cobra.Command{
 		Use:   "nudge <agent-name> <message...>",
 		Short: "Send a text message to a running agent session",
@@ -876,21 +896,46 @@ The message is delivered as text content to the session's input. This is
 equivalent to typing the message into the session's terminal.
 
 Resolves the agent name from city.toml configuration to find the
-corresponding tmux session. Multi-word messages are joined automatically.`,
-		Args: cobra.MinimumNArgs(2),
+corresponding tmux session. Multi-word messages are joined automatically.
+
+With --all, the message goes to every running agent session and no agent
+name is given. With --pool, the name is a pool agent and the message goes
+to each of its running instances. Broadcasts report the outcome for every
+target and exit non-zero if any delivery failed.`,
+		Example: `  gc session nudge mayor "check the deploy"
+  gc session nudge --all "new priority bead: gc-42"
+  gc session nudge hw/polecat --pool "prepare for shutdown"`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
 			mode, err := parseNudgeDeliveryMode(delivery)
 			if err != nil {
 				fmt.Fprintf(stderr, "gc session nudge: %v\n", err) //nolint:errcheck // best-effort stderr
 				return errExit
 			}
-			if cmdSessionNudge(args, mode, stdout, stderr) != 0 {
+			var code int
+			switch {
+			case all && pool:
+				fmt.Fprintln(stderr, "gc session nudge: --all and --pool are mutually exclusive") //nolint:errcheck // best-effort stderr
+				return errExit
+			case all:
+				code = cmdSessionNudgeBroadcast("", args, mode, stdout, stderr)
+			case len(args) < 2:
+				fmt.Fprintln(stderr, "gc session nudge: missing message") //nolint:errcheck // best-effort stderr
+				return errExit
+			case pool:
+				code = cmdSessionNudgeBroadcast(args[0], args[1:], mode, stdout, stderr)
+			default:
+				code = cmdSessionNudge(args, mode, stdout, stderr)
+			}
+			if code != 0 {
 				return errExit
 			}
 			return nil
 		},
 	}
 	cmd.Flags().StringVar(&delivery, "delivery", string(nudgeDeliveryWaitIdle), "delivery mode: immediate, wait-idle, or queue")
+	cmd.Flags().BoolVar(&all, "all", false, "nudge every running agent session")
+	cmd.Flags().BoolVar(&pool, "pool", false, "nudge every running instance of the named pool")
 	return cmd
 }
 
@@ -939,6 +984,123 @@ func cmdSessionNudge(args []string, delivery nudgeDeliveryMode, stdout, stderr i
 	return deliverSessionNudge(targetInfo, message, delivery, stdout, stderr)
 }
 
+// cmdSessionNudgeBroadcast is the CLI entry point for "gc session nudge
+// --all" (poolName empty) and "gc session nudge <pool> --pool".
+func cmdSessionNudgeBroadcast(poolName string, msgArgs []string, delivery nudgeDeliveryMode, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc session nudge: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc session nudge: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var pool *config.Agent
+	if poolName != "" {
+		found, ok := resolveAgentIdentity(cfg, poolName, currentRigContext(cfg))
+		if !ok {
+			fmt.Fprintln(stderr, agentNotFoundMsg("gc session nudge", poolName, cfg)) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if !found.EffectivePool().IsMultiInstance() {
+			fmt.Fprintf(stderr, "gc session nudge: %q is not a pool agent\n", found.QualifiedName()) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		pool = &found
+	}
+	return doSessionNudgeBroadcast(cityPath, cfg, newSessionProvider(), pool, strings.Join(msgArgs, " "), delivery, stdout, stderr)
+}
+
+// doSessionNudgeBroadcast nudges every running session of the city's
+// agents, or only the instances of pool if non-nil. Each target's outcome
+// is reported; returns 1 if any delivery failed or nothing was running.
+func doSessionNudgeBroadcast(
+	cityPath string,
+	cfg *config.City,
+	sp runtime.Provider,
+	pool *config.Agent,
+	message string,
+	delivery nudgeDeliveryMode,
+	stdout, stderr io.Writer,
+) int {
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	targets := runningNudgeTargets(cityPath, cityName, cfg, sp, pool)
+	if len(targets) == 0 {
+		fmt.Fprintln(stderr, "gc session nudge: no running sessions to nudge") //nolint:errcheck // best-effort stderr
+		return 1
+	}
+
+	var nudged, queued, failed int
+	for _, t := range targets {
+		qn := t.agent.QualifiedName()
+		resolved, err := config.ResolveProvider(&t.agent, &cfg.Workspace, cfg.Providers, exec.LookPath)
+		if err != nil {
+			failed++
+			fmt.Fprintf(stderr, "gc session nudge: %s: %v\n", qn, err) //nolint:errcheck // best-effort stderr
+			continue
+		}
+		t.resolved = resolved
+		wasQueued, err := sendSessionNudge(t, sp, message, delivery)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Fprintf(stderr, "gc session nudge: %s: %v\n", qn, err) //nolint:errcheck // best-effort stderr
+		case wasQueued:
+			queued++
+			fmt.Fprintf(stdout, "Queued nudge for %s\n", qn) //nolint:errcheck // best-effort stdout
+		default:
+			nudged++
+			fmt.Fprintf(stdout, "Nudged %s\n", qn) //nolint:errcheck // best-effort stdout
+		}
+	}
+	fmt.Fprintf(stdout, "%d nudged, %d queued, %d failed\n", nudged, queued, failed) //nolint:errcheck // best-effort stdout
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runningNudgeTargets lists the running sessions of all agents, or of
+// pool's instances if non-nil, in config order.
+func runningNudgeTargets(cityPath, cityName string, cfg *config.City, sp runtime.Provider, pool *config.Agent) []nudgeTarget {
+	st := cfg.Workspace.SessionTemplate
+	var targets []nudgeTarget
+	add := func(a config.Agent) {
+		sn := cliSessionName(cityPath, cityName, a.QualifiedName(), st)
+		if !sp.IsRunning(sn) {
+			return
+		}
+		targets = append(targets, nudgeTarget{
+			cityPath:    cityPath,
+			cityName:    cityName,
+			cfg:         cfg,
+			agent:       a,
+			sessionName: sn,
+		})
+	}
+	for _, a := range cfg.Agents {
+		if pool != nil && a.QualifiedName() != pool.QualifiedName() {
+			continue
+		}
+		p := a.EffectivePool()
+		if !p.IsMultiInstance() {
+			add(a)
+			continue
+		}
+		for _, qn := range discoverPoolInstances(a.Name, a.Dir, p, cityName, st, sp) {
+			instance := a
+			_, instance.Name = config.ParseQualifiedName(qn)
+			add(instance)
+		}
+	}
+	return targets
+}
+
 // resolveWorkDir determines the working directory for a session based on
 // the agent config. Uses the rig path if set, otherwise the city directory.
 func resolveWorkDir(cityPath string, agent *config.Agent) string {
@@ -12,8 +12,10 @@ import (
 
 	"github.com/gastownhall/gascity/internal/beads"
 	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/convergence"
 	"github.com/gastownhall/gascity/internal/fsys"
 	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/shellquote"
 	"github.com/gastownhall/gascity/internal/telemetry"
 	"github.com/spf13/cobra"
 )
@@ -31,17 +33,8 @@ type BeadChildQuerier interface {
 }
 
 func newSlingCmd(stdout, stderr io.Writer) *cobra.Command {
-	var formula bool
-	var nudge bool
-	var force bool
-	var title string
-	var vars []string
-	var merge string
-	var noConvoy bool
-	var owned bool
-	var onFormula string
-	var dryRun bool
-	var noFormula bool
+	var opts slingOpts
+	var at, in string
 	cmd := &cobra.Command{
 		Use:   "sling [target] <bead-or-formula>",
 		Short: "Route work to an agent or pool",
@@ -52,44 +45,154 @@ The second argument is a bead ID, or a formula name when --formula is set.
 
 When target is omitted, the bead's rig prefix is used to look up the rig's
 default_sling_target from config. Requires --formula to have an explicit target.
+If the rig has no default target and gc is attached to a terminal, an
+interactive picker lists agents and pools with their current load; type
+to filter, use the arrow keys to move, enter to choose, and esc to
+cancel. --interactive (-i) always opens the picker, and then works with
+--formula too.
+
+With --auto, the target comes from the [[routes]] in city.toml: the
+first route whose type, label, and prefix matchers all match the bead
+wins. If none matches, the rig's default_sling_target applies as usual.
+
+With --match, the target is the least-loaded agent whose capabilities
+(capabilities = [...] on the agent) include everything the bead
+requires (its requires:<capability> labels, set with
+"gc bead create --requires"). Ties go to the agent listed first in
+city.toml. It is an error if no agent qualifies.
 
 With --formula, a wisp (ephemeral molecule) is instantiated from the formula
-and its root bead is routed to the target.`,
+and its root bead is routed to the target. Formula variables are passed with
+repeatable --var key=value flags; they apply to whichever formula is cooked
+(--formula, --on, or the target's default_sling_formula). Use
+"gc formula show <name>" to see a formula's variables.
+
+A comma-separated target list fans a convoy or epic out across several
+agents or pools: each open child is routed to one target, chosen by
+--strategy. "round-robin" cycles through the targets in order;
+"least-loaded" picks the target with the fewest open and in-progress
+beads already routed to it.
+
+--at or --in defers the sling: it is saved in .gc/schedule/ and the
+controller runs it with the same arguments when it comes due, so the
+target sees the work then rather than now. --at takes a clock time
+(its next occurrence), "YYYY-MM-DD HH:MM", or an RFC 3339 timestamp;
+--in takes a duration. "gc sling pending" lists what is waiting.
+
+--from-file routes every bead listed in a file ("-" for stdin) to the
+one target given, so a bd query can be piped straight into sling. The
+first field of each line is the bead ID; blank lines and # comments are
+skipped. As with convoy expansion, each open bead is routed and reported
+on its own, beads already routed to the target are skipped, and a
+summary line closes the run. No auto-convoy is created.
+
+--quiet (-q) prints only failures and the closing summary line, which
+keeps large convoys readable and suits scripts. --verbose (-v) also
+prints every command sling runs to stderr, "set -x" style: the sling
+query and, for bd-backed stores, each exact bd invocation.`,
+		Example: `  gc sling mayor BL-42
+  gc sling hello-world/polecat --formula code-review
+  gc sling hello-world/polecat --formula mol-polecat-commit --var issue=BL-42 --var base_branch=develop
+  gc sling mayor,polecat-pool CVY-1 --strategy=least-loaded
+  gc sling -i BL-42
+  gc sling --auto BL-42
+  gc sling --match BL-42
+  gc sling mayor BL-7 --at 22:00
+  gc sling hello-world/polecat BL-8 --in 2h
+  gc sling mayor --from-file triage.txt
+  bd list --label=triage --json | jq -r '.[].id' | gc sling mayor --from-file -
+  gc sling polecat-pool CVY-1 --quiet
+  gc sling mayor BL-42 --verbose`,
 		Args: cobra.ArbitraryArgs,
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.FromFile != "" && len(args) != 1 {
+				fmt.Fprintf(stderr, "gc sling: --from-file requires exactly one argument: <target>\n") //nolint:errcheck // best-effort stderr
+				return errExit
+			}
 			if len(args) < 1 || len(args) > 2 {
 				fmt.Fprintf(stderr, "gc sling: requires 1 or 2 arguments: [target] <bead-or-formula>\n") //nolint:errcheck // best-effort stderr
 				return errExit
 			}
-			if owned && noConvoy {
+			if opts.Owned && opts.NoConvoy {
 				fmt.Fprintf(stderr, "gc sling: --owned requires a convoy (cannot use with --no-convoy)\n") //nolint:errcheck // best-effort stderr
 				return errExit
 			}
-			if merge != "" && merge != "direct" && merge != "mr" && merge != "local" {
+			if opts.Merge != "" && opts.Merge != "direct" && opts.Merge != "mr" && opts.Merge != "local" {
 				fmt.Fprintf(stderr, "gc sling: --merge must be direct, mr, or local\n") //nolint:errcheck // best-effort stderr
 				return errExit
 			}
-			code := cmdSling(args, formula, nudge, force, title, vars, merge, noConvoy, owned, onFormula, noFormula, dryRun, stdout, stderr)
+			for _, v := range opts.Vars {
+				if key, _, ok := strings.Cut(v, "="); !ok || !convergence.ValidateVarKey(key) {
+					fmt.Fprintf(stderr, "gc sling: invalid --var %q (expected key=value)\n", v) //nolint:errcheck // best-effort stderr
+					return errExit
+				}
+			}
+			if !validFanOutStrategy(opts.Strategy) {
+				fmt.Fprintf(stderr, "gc sling: --strategy must be %s or %s\n", fanOutRoundRobin, fanOutLeastLoaded) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if at != "" || in != "" {
+				if opts.Interactive || opts.DryRun {
+					fmt.Fprintln(stderr, "gc sling: --at and --in cannot be combined with --interactive or --dry-run") //nolint:errcheck // best-effort stderr
+					return errExit
+				}
+				due, err := slingDueTime(at, in, time.Now())
+				if err != nil {
+					fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+					return errExit
+				}
+				if cmdSlingSchedule(args, slingPassthroughArgs(cmd.Flags(), args), due, stdout, stderr) != 0 {
+					return errExit
+				}
+				return nil
+			}
+			code := cmdSling(args, opts, stdout, stderr)
 			if code != 0 {
 				return errExit
 			}
 			return nil
 		},
 	}
-	cmd.Flags().BoolVarP(&formula, "formula", "f", false, "treat argument as formula name")
-	cmd.Flags().BoolVar(&nudge, "nudge", false, "nudge target after routing")
-	cmd.Flags().BoolVar(&force, "force", false, "suppress warnings and allow cross-rig routing")
-	cmd.Flags().StringVarP(&title, "title", "t", "", "wisp root bead title (with --formula or --on)")
-	cmd.Flags().StringArrayVar(&vars, "var", nil, "variable substitution for formula (key=value, repeatable)")
-	cmd.Flags().StringVar(&merge, "merge", "", "merge strategy: direct, mr, or local")
-	cmd.Flags().BoolVar(&noConvoy, "no-convoy", false, "skip auto-convoy creation")
-	cmd.Flags().BoolVar(&owned, "owned", false, "mark auto-convoy as owned (skip auto-close)")
-	cmd.Flags().StringVar(&onFormula, "on", "", "attach wisp from formula to bead before routing")
-	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "show what would be done without executing")
-	cmd.Flags().BoolVar(&noFormula, "no-formula", false, "suppress default formula (route raw bead)")
+	cmd.Flags().BoolVarP(&opts.IsFormula, "formula", "f", false, "treat argument as formula name")
+	cmd.Flags().BoolVar(&opts.Nudge, "nudge", false, "nudge target after routing")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "suppress warnings and allow cross-rig routing")
+	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "wisp root bead title (with --formula or --on)")
+	cmd.Flags().StringArrayVar(&opts.Vars, "var", nil, "variable substitution for formula (key=value, repeatable)")
+	cmd.Flags().StringVar(&opts.Merge, "merge", "", "merge strategy: direct, mr, or local")
+	cmd.Flags().BoolVar(&opts.NoConvoy, "no-convoy", false, "skip auto-convoy creation")
+	cmd.Flags().BoolVar(&opts.Owned, "owned", false, "mark auto-convoy as owned (skip auto-close)")
+	cmd.Flags().StringVar(&opts.OnFormula, "on", "", "attach wisp from formula to bead before routing")
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "n", false, "show what would be done without executing")
+	cmd.Flags().BoolVar(&opts.NoFormula, "no-formula", false, "suppress default formula (route raw bead)")
+	cmd.Flags().BoolVarP(&opts.Interactive, "interactive", "i", false, "pick the target from a list of agents and pools")
+	cmd.Flags().BoolVar(&opts.Auto, "auto", false, "pick the target from the first matching [[routes]] entry")
+	cmd.Flags().BoolVar(&opts.Match, "match", false, "pick the least-loaded agent with every capability the bead requires")
+	cmd.Flags().StringVar(&opts.Strategy, "strategy", fanOutRoundRobin, "fan-out strategy for multiple targets: round-robin or least-loaded")
+	cmd.Flags().StringVar(&at, "at", "", "run the sling later, at this time (HH:MM or YYYY-MM-DD HH:MM)")
+	cmd.Flags().StringVar(&in, "in", "", "run the sling later, after this duration (e.g. 2h)")
+	cmd.Flags().StringVar(&opts.FromFile, "from-file", "", "route every bead ID listed in this file (- for stdin)")
+	cmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "print only failures and the final summary")
+	cmd.Flags().BoolVarP(&opts.Verbose, "verbose", "v", false, "print every command run, including bd invocations")
+	cmd.AddCommand(
+		newSlingHistoryCmd(stdout, stderr),
+		newSlingPendingCmd(stdout, stderr),
+		newSlingUnscheduleCmd(stdout, stderr),
+	)
+	cmd.MarkFlagsMutuallyExclusive("at", "in")
 	cmd.MarkFlagsMutuallyExclusive("formula", "on")
+	cmd.MarkFlagsMutuallyExclusive("auto", "interactive")
+	cmd.MarkFlagsMutuallyExclusive("auto", "formula")
+	cmd.MarkFlagsMutuallyExclusive("match", "auto", "interactive", "formula")
 	cmd.MarkFlagsMutuallyExclusive("no-formula", "formula")
 	cmd.MarkFlagsMutuallyExclusive("no-formula", "on")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "formula")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "interactive")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "auto")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "match")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "at")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "in")
+	cmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	cmd.MarkFlagsMutuallyExclusive("quiet", "dry-run")
 	return cmd
 }
 
@@ -108,6 +211,16 @@ type slingOpts struct {
 	Nudge         bool
 	Force         bool
 	DryRun        bool
+	Quiet         bool           // print only failures and the closing summary
+	FanOut        []config.Agent // all targets when slinging to several; Target is the first
+	Strategy      string         // fan-out strategy: "round-robin" or "least-loaded"
+
+	// CLI-only: how cmdSling picks the target and sources the beads.
+	Interactive bool   // pick the target from a list
+	Auto        bool   // pick the target from [[routes]]
+	Match       bool   // pick the target by capability
+	FromFile    string // route the bead IDs listed in this file ("-" for stdin)
+	Verbose     bool   // trace every command run to stderr
 }
 
 // slingDeps bundles infrastructure dependencies injected for testability.
@@ -152,7 +265,9 @@ func shellSlingRunner(dir, command string, env map[string]string) (string, error
 }
 
 // cmdSling is the CLI entry point for gc sling.
-func cmdSling(args []string, isFormula, doNudge, force bool, title string, vars []string, merge string, noConvoy, owned bool, onFormula string, noFormula, dryRun bool, stdout, stderr io.Writer) int {
+// opts carries the parsed flags; cmdSling fills in Target, BeadOrFormula,
+// and FanOut from args.
+func cmdSling(args []string, opts slingOpts, stdout, stderr io.Writer) int {
 	cityPath, err := resolveCity()
 	if err != nil {
 		fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
@@ -164,83 +279,215 @@ func cmdSling(args []string, isFormula, doNudge, force bool, title string, vars
 		return 1
 	}
 
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	sp := newSessionProvider()
+
 	var target, beadOrFormula string
-	if len(args) == 2 {
+	switch {
+	case opts.FromFile != "":
+		target = args[0]
+	case len(args) == 2 && opts.Interactive:
+		fmt.Fprintln(stderr, "gc sling: --interactive picks the target; pass only the bead or formula") //nolint:errcheck // best-effort stderr
+		return 1
+	case len(args) == 2 && opts.Auto:
+		fmt.Fprintln(stderr, "gc sling: --auto picks the target from [[routes]]; pass only the bead") //nolint:errcheck // best-effort stderr
+		return 1
+	case len(args) == 2 && opts.Match:
+		fmt.Fprintln(stderr, "gc sling: --match picks the target by capability; pass only the bead") //nolint:errcheck // best-effort stderr
+		return 1
+	case len(args) == 2:
 		target = args[0]
 		beadOrFormula = args[1]
-	} else {
-		// 1-arg: bead ID only, resolve target from rig's default_sling_target.
+	default:
+		// 1-arg: bead ID only, resolve target from rig's default_sling_target,
+		// or let the user pick one.
 		beadOrFormula = args[0]
-		if isFormula {
-			fmt.Fprintf(stderr, "gc sling: --formula requires explicit target\n") //nolint:errcheck // best-effort stderr
-			return 1
+		if opts.Auto {
+			store, serr := openRigStoreAt(cityPath, rigDirForBead(cfg, beadOrFormula))
+			if serr != nil {
+				fmt.Fprintf(stderr, "gc sling: %v\n", serr) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			if target, err = routeSlingTarget(cfg, store, beadOrFormula); err != nil {
+				fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			if target != "" && !opts.Quiet {
+				fmt.Fprintf(stdout, "Route matched: %s\n", target) //nolint:errcheck // best-effort stdout
+			}
 		}
-		bp := beadPrefix(beadOrFormula)
-		if bp == "" {
-			fmt.Fprintf(stderr, "gc sling: cannot derive rig from bead %q (no prefix)\n", beadOrFormula) //nolint:errcheck // best-effort stderr
-			return 1
+		if opts.Match {
+			store, serr := openRigStoreAt(cityPath, rigDirForBead(cfg, beadOrFormula))
+			if serr != nil {
+				fmt.Fprintf(stderr, "gc sling: %v\n", serr) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			b, gerr := store.Get(beadOrFormula)
+			if gerr != nil {
+				fmt.Fprintf(stderr, "gc sling: looking up bead %s: %v\n", beadOrFormula, gerr) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			load := func(a config.Agent) int {
+				as, aerr := slingStoreFor(cityPath, cfg, a)
+				if aerr != nil {
+					return 0
+				}
+				return slingLoad(a, slingDeps{CityName: cityName, Cfg: cfg, Store: as})
+			}
+			if target, err = matchSlingTarget(cfg, b, load); err != nil {
+				fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			if !opts.Quiet {
+				fmt.Fprintf(stdout, "Capability match: %s (requires %s)\n", target, strings.Join(b.Requires(), ", ")) //nolint:errcheck // best-effort stdout
+			}
 		}
-		rig, found := findRigByPrefix(cfg, bp)
-		if !found {
-			fmt.Fprintf(stderr, "gc sling: no rig with prefix %q for bead %s\n", bp, beadOrFormula) //nolint:errcheck // best-effort stderr
-			return 1
+		if target == "" && !opts.Interactive {
+			if opts.IsFormula {
+				fmt.Fprintf(stderr, "gc sling: --formula requires explicit target\n") //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			target, err = defaultSlingTargetFor(cfg, beadOrFormula)
 		}
-		if rig.DefaultSlingTarget == "" {
-			fmt.Fprintf(stderr, "gc sling: rig %q has no default_sling_target\n", rig.Name) //nolint:errcheck // best-effort stderr
-			return 1
+		if target == "" {
+			in, out, tty := slingPickerTerminal(stdout)
+			if !tty {
+				if err == nil {
+					err = fmt.Errorf("--interactive requires a terminal")
+				}
+				fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			items := slingPickItems(cityPath, cityName, cfg, sp)
+			if target, err = runSlingPicker(items, beadOrFormula, in, out); err != nil {
+				fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
 		}
-		target = rig.DefaultSlingTarget
 	}
 
-	a, ok := resolveAgentIdentity(cfg, target, currentRigContext(cfg))
-	if !ok {
-		fmt.Fprintln(stderr, agentNotFoundMsg("gc sling", target, cfg)) //nolint:errcheck // best-effort stderr
+	var fanOut []config.Agent
+	for _, name := range strings.Split(target, ",") {
+		name = strings.TrimSpace(name)
+		fa, ok := resolveAgentIdentity(cfg, name, currentRigContext(cfg))
+		if !ok {
+			fmt.Fprintln(stderr, agentNotFoundMsg("gc sling", name, cfg)) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fanOut = append(fanOut, fa)
+	}
+	a := fanOut[0]
+	if len(fanOut) > 1 && opts.FromFile != "" {
+		fmt.Fprintln(stderr, "gc sling: multiple targets cannot be combined with --from-file") //nolint:errcheck // best-effort stderr
 		return 1
 	}
-
-	sp := newSessionProvider()
-	cityName := cfg.Workspace.Name
-	if cityName == "" {
-		cityName = filepath.Base(cityPath)
+	if len(fanOut) > 1 && opts.IsFormula {
+		fmt.Fprintln(stderr, "gc sling: multiple targets cannot be combined with --formula") //nolint:errcheck // best-effort stderr
+		return 1
 	}
 
-	opts := slingOpts{
-		Target:        a,
-		BeadOrFormula: beadOrFormula,
-		IsFormula:     isFormula,
-		OnFormula:     onFormula,
-		NoFormula:     noFormula,
-		Title:         title,
-		Vars:          vars,
-		Merge:         merge,
-		NoConvoy:      noConvoy,
-		Owned:         owned,
-		Nudge:         doNudge,
-		Force:         force,
-		DryRun:        dryRun,
-	}
-	// Use the target agent's rig directory for the store so that mol
-	// operations (MolCook, MolCookOn) create beads in the correct rig
-	// database. City-scoped agents (no Dir) fall back to cityPath.
-	storeDir := cityPath
-	if rd := rigDirForAgent(cfg, a); rd != "" {
-		storeDir = rd
+	opts.Target = a
+	opts.BeadOrFormula = beadOrFormula
+	bdRunner, runner := beads.ExecCommandRunner(), SlingRunner(shellSlingRunner)
+	if opts.Verbose {
+		bdRunner = traceCommandRunner(bdRunner, stderr)
+		runner = traceSlingRunner(runner, stderr)
+	}
+	store, err := slingStoreWith(cityPath, cfg, a, bdRunner)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
 	}
-	store := beads.NewBdStore(storeDir, beads.ExecCommandRunner())
 	deps := slingDeps{
 		CityName: cityName,
 		CityPath: cityPath,
 		Cfg:      cfg,
 		SP:       sp,
-		Runner:   shellSlingRunner,
+		Runner:   runner,
 		Store:    store,
 		Stdout:   stdout,
 		Stderr:   stderr,
 	}
 
+	if len(fanOut) > 1 {
+		opts.FanOut = fanOut
+	}
+	if slingVarsUnused(opts) && !opts.Quiet {
+		fmt.Fprintln(stderr, "warning: --var has no effect — no formula is cooked for this sling") //nolint:errcheck // best-effort stderr
+	}
+	if opts.FromFile != "" {
+		ids, err := readSlingBeadIDs(opts.FromFile, os.Stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc sling: reading %s: %v\n", slingListSource(opts.FromFile), err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		return doSlingList(opts, deps, store, ids, slingListSource(opts.FromFile))
+	}
+	if len(opts.FanOut) > 0 {
+		return doSlingFanOut(opts, deps, store)
+	}
 	return doSlingBatch(opts, deps, store)
 }
 
+// defaultSlingTargetFor returns the default_sling_target of the rig that
+// owns beadID, found by the bead's ID prefix.
+func defaultSlingTargetFor(cfg *config.City, beadID string) (string, error) {
+	bp := beadPrefix(beadID)
+	if bp == "" {
+		return "", fmt.Errorf("cannot derive rig from bead %q (no prefix)", beadID)
+	}
+	rig, found := findRigByPrefix(cfg, bp)
+	if !found {
+		return "", fmt.Errorf("no rig with prefix %q for bead %s", bp, beadID)
+	}
+	if rig.DefaultSlingTarget == "" {
+		return "", fmt.Errorf("rig %q has no default_sling_target", rig.Name)
+	}
+	return rig.DefaultSlingTarget, nil
+}
+
+// routeSlingTarget returns the target of the first [[routes]] entry
+// matching the bead, or "" if none matches.
+func routeSlingTarget(cfg *config.City, store beads.Store, beadID string) (string, error) {
+	if len(cfg.Routes) == 0 {
+		return "", nil
+	}
+	b, err := store.Get(beadID)
+	if err != nil {
+		return "", fmt.Errorf("looking up bead %s: %w", beadID, err)
+	}
+	if r, ok := config.MatchRoute(cfg.Routes, b.ID, b.Type, b.Labels); ok {
+		return r.Target, nil
+	}
+	return "", nil
+}
+
+// slingStoreFor opens the bead store work routed to a lives in. Uses the
+// agent's rig directory so that mol operations (MolCook, MolCookOn)
+// create beads in the correct rig database; city-scoped agents (no Dir)
+// fall back to cityPath.
+func slingStoreFor(cityPath string, cfg *config.City, a config.Agent) (beads.Store, error) {
+	return slingStoreWith(cityPath, cfg, a, beads.ExecCommandRunner())
+}
+
+// slingStoreWith is slingStoreFor with the runner a bd-backed store uses
+// for its bd invocations.
+func slingStoreWith(cityPath string, cfg *config.City, a config.Agent, runner beads.CommandRunner) (beads.Store, error) {
+	if p := rawBeadsProvider(cityPath); p == "file" || p == "sqlite" {
+		// No bd behind these providers: one city-wide store that cooks
+		// formulas itself.
+		return openCityStoreAt(cityPath)
+	}
+	storeDir := cityPath
+	if rd := rigDirForAgent(cfg, a); rd != "" {
+		storeDir = rd
+	}
+	return beads.NewBdStore(storeDir, runner), nil
+}
+
 // findRigByPrefix returns the rig whose effective prefix matches (case-insensitive).
 func findRigByPrefix(cfg *config.City, prefix string) (config.Rig, bool) {
 	lp := strings.ToLower(prefix)
@@ -285,12 +532,13 @@ func rigDirForAgent(cfg *config.City, a config.Agent) string {
 // and opts struct for testability.
 func doSling(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 	a := opts.Target
+	progress, warnings := slingProgress(opts, deps)
 	// Warn about suspended agents / empty pools (unless --force).
 	if a.Suspended && !opts.Force {
-		fmt.Fprintf(deps.Stderr, "warning: agent %q is suspended — bead routed but may not be picked up\n", a.QualifiedName()) //nolint:errcheck // best-effort
+		fmt.Fprintf(warnings, "warning: agent %q is suspended — bead routed but may not be picked up\n", a.QualifiedName()) //nolint:errcheck // best-effort
 	}
 	if a.IsPool() && a.Pool.Max == 0 && !opts.Force {
-		fmt.Fprintf(deps.Stderr, "warning: pool %q has max=0 — bead routed but no instances to claim it\n", a.QualifiedName()) //nolint:errcheck // best-effort
+		fmt.Fprintf(warnings, "warning: pool %q has max=0 — bead routed but no instances to claim it\n", a.QualifiedName()) //nolint:errcheck // best-effort
 	}
 
 	// Cross-rig guard — block when a rig-scoped agent receives a bead from
@@ -316,7 +564,7 @@ func doSling(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 			return 0
 		}
 		for _, w := range result.Warnings {
-			fmt.Fprintln(deps.Stderr, w) //nolint:errcheck // best-effort
+			fmt.Fprintln(warnings, w) //nolint:errcheck // best-effort
 		}
 	}
 
@@ -325,6 +573,10 @@ func doSling(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 		return dryRunSingle(opts, deps, querier)
 	}
 
+	if !fireSlingHooks(opts, deps, slingHookBead(opts)) {
+		return 1
+	}
+
 	beadID := opts.BeadOrFormula
 	method := "bead"
 
@@ -357,7 +609,7 @@ func doSling(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 			fmt.Fprintf(deps.Stderr, "gc sling: setting molecule_id on %s: %v\n", beadID, err) //nolint:errcheck // best-effort
 			// Non-fatal — wisp was already attached.
 		}
-		fmt.Fprintf(deps.Stdout, "Attached wisp %s (formula %q) to %s\n", wispRootID, opts.OnFormula, beadID) //nolint:errcheck // best-effort
+		fmt.Fprintf(progress, "Attached wisp %s (formula %q) to %s\n", wispRootID, opts.OnFormula, beadID) //nolint:errcheck // best-effort
 		// beadID unchanged — route original bead.
 	}
 
@@ -380,7 +632,7 @@ func doSling(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 			fmt.Fprintf(deps.Stderr, "gc sling: setting molecule_id on %s: %v\n", beadID, err) //nolint:errcheck // best-effort
 			// Non-fatal — wisp was already attached.
 		}
-		fmt.Fprintf(deps.Stdout, "Attached wisp %s (default formula %q) to %s\n", //nolint:errcheck // best-effort
+		fmt.Fprintf(progress, "Attached wisp %s (default formula %q) to %s\n", //nolint:errcheck // best-effort
 			wispRootID, a.DefaultSlingFormula, beadID)
 	}
 
@@ -388,15 +640,15 @@ func doSling(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 	// For fixed agents, resolve the target's session name and inject it
 	// as GC_SLING_TARGET so the sling query can assign work per-session.
 	slingEnv := resolveSlingEnv(a, deps)
-	slingCmd := buildSlingCommand(a.EffectiveSlingQuery(), beadID)
-	rigDir := rigDirForBead(deps.Cfg, beadID)
-	if _, err := deps.Runner(rigDir, slingCmd, slingEnv); err != nil {
+	if err := runSlingQuery(deps, querier, beadID, a, slingEnv); err != nil {
 		fmt.Fprintf(deps.Stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort
 		telemetry.RecordSling(context.Background(), a.QualifiedName(), targetType(&a), method, err)
+		recordSling(deps, a.QualifiedName(), beadID, slingFormula(opts), method, false, err)
 		return 1
 	}
 
 	telemetry.RecordSling(context.Background(), a.QualifiedName(), targetType(&a), method, nil)
+	recordSling(deps, a.QualifiedName(), beadID, slingFormula(opts), method, false, nil)
 
 	// Merge strategy metadata.
 	if opts.Merge != "" && deps.Store != nil {
@@ -429,7 +681,7 @@ func doSling(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 				if opts.Owned {
 					label = " (owned)"
 				}
-				fmt.Fprintf(deps.Stdout, "Auto-convoy %s%s\n", convoy.ID, label) //nolint:errcheck // best-effort
+				fmt.Fprintf(progress, "Auto-convoy %s%s\n", convoy.ID, label) //nolint:errcheck // best-effort
 			}
 		}
 	}
@@ -445,7 +697,7 @@ func doSling(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 
 	// Nudge target if requested.
 	if opts.Nudge {
-		doSlingNudge(&a, deps.CityName, deps.CityPath, deps.Cfg, deps.SP, deps.Store, deps.Stdout, deps.Stderr)
+		doSlingNudge(&a, deps.CityName, deps.CityPath, deps.Cfg, deps.SP, deps.Store, progress, deps.Stderr)
 	}
 
 	return 0
@@ -525,31 +777,64 @@ func doSlingBatch(opts slingOpts, deps slingDeps, querier BeadChildQuerier) int
 		return dryRunBatch(opts, deps, b, children, open, querier)
 	}
 
-	fmt.Fprintf(deps.Stdout, "Expanding %s %s (%d children, %d open)\n", b.Type, b.ID, len(children), len(open)) //nolint:errcheck // best-effort
+	if !fireSlingHooks(opts, deps, b.ID) {
+		return 1
+	}
+
+	progress, _ := slingProgress(opts, deps)
+	fmt.Fprintf(progress, "Expanding %s %s (%d children, %d open)\n", b.Type, b.ID, len(children), len(open)) //nolint:errcheck // best-effort
+
+	routed, failed, idempotent := slingEach(opts, deps, querier, open, "batch")
+
+	// Report skipped children.
+	for _, child := range skipped {
+		fmt.Fprintf(progress, "  Skipped %s (status: %s)\n", child.ID, child.Status) //nolint:errcheck // best-effort
+	}
+
+	// Summary line.
+	summary := fmt.Sprintf("Slung %d/%d children of %s → %s", routed, len(children), b.ID, a.QualifiedName())
+	if idempotent > 0 {
+		summary += fmt.Sprintf(" (%d already routed)", idempotent)
+	}
+	fmt.Fprintln(deps.Stdout, summary) //nolint:errcheck // best-effort
+
+	// Nudge once after all children.
+	if opts.Nudge && routed > 0 {
+		doSlingNudge(&a, deps.CityName, deps.CityPath, deps.Cfg, deps.SP, deps.Store, progress, deps.Stderr)
+	}
 
-	// Telemetry method.
-	batchMethod := "batch"
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// slingEach routes each bead in open to opts.Target, attaching wisps as
+// needed and reporting per bead. Beads already routed to the target are
+// skipped unless --force. method is the telemetry method, suffixed for
+// --on and default formulas. Shared by container expansion and
+// --from-file lists.
+func slingEach(opts slingOpts, deps slingDeps, querier BeadQuerier, open []beads.Bead, method string) (routed, failed, idempotent int) {
+	a := opts.Target
+	batchMethod := method
 	if opts.OnFormula != "" {
-		batchMethod = "batch-on"
+		batchMethod += "-on"
 	} else if !opts.NoFormula && a.DefaultSlingFormula != "" {
-		batchMethod = "batch-default-on"
+		batchMethod += "-default-on"
 	}
+	progress, warnings := slingProgress(opts, deps)
 
-	// Route each open child.
-	routed := 0
-	failed := 0
-	idempotent := 0
 	for _, child := range open {
 		// Per-child idempotency / pre-flight check (unless --force).
 		if !opts.Force {
 			result := checkBeadState(querier, child.ID, a)
 			if result.Idempotent {
-				fmt.Fprintf(deps.Stdout, "  Skipped %s — already routed to %s\n", child.ID, a.QualifiedName()) //nolint:errcheck // best-effort
+				fmt.Fprintf(progress, "  Skipped %s — already routed to %s\n", child.ID, a.QualifiedName()) //nolint:errcheck // best-effort
 				idempotent++
 				continue
 			}
 			for _, w := range result.Warnings {
-				fmt.Fprintln(deps.Stderr, w) //nolint:errcheck // best-effort
+				fmt.Fprintln(warnings, w) //nolint:errcheck // best-effort
 			}
 		}
 
@@ -559,60 +844,41 @@ func doSlingBatch(opts slingOpts, deps slingDeps, querier BeadChildQuerier) int
 			if err != nil {
 				fmt.Fprintf(deps.Stderr, "  Failed %s: instantiating formula %q: %v\n", child.ID, opts.OnFormula, err) //nolint:errcheck // best-effort
 				telemetry.RecordSling(context.Background(), a.QualifiedName(), targetType(&a), batchMethod, err)
+				recordSling(deps, a.QualifiedName(), child.ID, slingFormula(opts), batchMethod, false, err)
 				failed++
 				continue
 			}
-			_ = deps.Store.SetMetadata(child.ID, "molecule_id", wispRootID)             // best-effort
-			fmt.Fprintf(deps.Stdout, "  Attached wisp %s → %s\n", wispRootID, child.ID) //nolint:errcheck // best-effort
+			_ = deps.Store.SetMetadata(child.ID, "molecule_id", wispRootID)          // best-effort
+			fmt.Fprintf(progress, "  Attached wisp %s → %s\n", wispRootID, child.ID) //nolint:errcheck // best-effort
 		} else if !opts.NoFormula && a.DefaultSlingFormula != "" {
 			// Apply default formula per-child.
 			wispRootID, err := deps.Store.MolCookOn(a.DefaultSlingFormula, child.ID, opts.Title, opts.Vars)
 			if err != nil {
 				fmt.Fprintf(deps.Stderr, "  Failed %s: instantiating default formula %q: %v\n", child.ID, a.DefaultSlingFormula, err) //nolint:errcheck // best-effort
 				telemetry.RecordSling(context.Background(), a.QualifiedName(), targetType(&a), batchMethod, err)
+				recordSling(deps, a.QualifiedName(), child.ID, slingFormula(opts), batchMethod, false, err)
 				failed++
 				continue
 			}
-			_ = deps.Store.SetMetadata(child.ID, "molecule_id", wispRootID)                               // best-effort
-			fmt.Fprintf(deps.Stdout, "  Attached wisp %s (default formula) → %s\n", wispRootID, child.ID) //nolint:errcheck // best-effort
+			_ = deps.Store.SetMetadata(child.ID, "molecule_id", wispRootID)                            // best-effort
+			fmt.Fprintf(progress, "  Attached wisp %s (default formula) → %s\n", wispRootID, child.ID) //nolint:errcheck // best-effort
 		}
 
 		childEnv := resolveSlingEnv(a, deps)
-		slingCmd := buildSlingCommand(a.EffectiveSlingQuery(), child.ID)
-		rigDir := rigDirForBead(deps.Cfg, child.ID)
-		if _, err := deps.Runner(rigDir, slingCmd, childEnv); err != nil {
+		if err := runSlingQuery(deps, querier, child.ID, a, childEnv); err != nil {
 			fmt.Fprintf(deps.Stderr, "  Failed %s: %v\n", child.ID, err) //nolint:errcheck // best-effort
 			telemetry.RecordSling(context.Background(), a.QualifiedName(), targetType(&a), batchMethod, err)
+			recordSling(deps, a.QualifiedName(), child.ID, slingFormula(opts), batchMethod, false, err)
 			failed++
 			continue
 		}
 
 		telemetry.RecordSling(context.Background(), a.QualifiedName(), targetType(&a), batchMethod, nil)
-		fmt.Fprintf(deps.Stdout, "  Slung %s → %s\n", child.ID, a.QualifiedName()) //nolint:errcheck // best-effort
+		recordSling(deps, a.QualifiedName(), child.ID, slingFormula(opts), batchMethod, false, nil)
+		fmt.Fprintf(progress, "  Slung %s → %s\n", child.ID, a.QualifiedName()) //nolint:errcheck // best-effort
 		routed++
 	}
-
-	// Report skipped children.
-	for _, child := range skipped {
-		fmt.Fprintf(deps.Stdout, "  Skipped %s (status: %s)\n", child.ID, child.Status) //nolint:errcheck // best-effort
-	}
-
-	// Summary line.
-	summary := fmt.Sprintf("Slung %d/%d children of %s → %s", routed, len(children), b.ID, a.QualifiedName())
-	if idempotent > 0 {
-		summary += fmt.Sprintf(" (%d already routed)", idempotent)
-	}
-	fmt.Fprintln(deps.Stdout, summary) //nolint:errcheck // best-effort
-
-	// Nudge once after all children.
-	if opts.Nudge && routed > 0 {
-		doSlingNudge(&a, deps.CityName, deps.CityPath, deps.Cfg, deps.SP, deps.Store, deps.Stdout, deps.Stderr)
-	}
-
-	if failed > 0 {
-		return 1
-	}
-	return 0
+	return routed, failed, idempotent
 }
 
 // resolveSlingEnv returns extra env vars for the sling command.
@@ -630,13 +896,7 @@ func resolveSlingEnv(a config.Agent, deps slingDeps) map[string]string {
 // buildSlingCommand replaces {} in the sling query template with the bead ID.
 // The bead ID is shell-quoted to prevent command injection.
 func buildSlingCommand(template, beadID string) string {
-	return strings.ReplaceAll(template, "{}", shellQuote(beadID))
-}
-
-// shellQuote quotes a string for safe use in shell commands.
-// Uses single quotes with embedded single-quote escaping.
-func shellQuote(s string) string {
-	return "'" + strings.ReplaceAll(s, "'", "'\\''") + "'"
+	return strings.ReplaceAll(template, "{}", shellquote.Quote(beadID))
 }
 
 // formatBeadLabel formats a bead ID with optional title for display.
@@ -742,6 +1002,15 @@ func targetType(a *config.Agent) string {
 	return "agent"
 }
 
+// poolRouteLabel returns the label the default pool sling query applies
+// for a, matching EffectiveSlingQuery.
+func poolRouteLabel(a config.Agent) string {
+	if a.PoolName != "" {
+		return "pool:" + a.PoolName
+	}
+	return "pool:" + a.QualifiedName()
+}
+
 // beadCheckResult captures the outcome of a pre-flight bead state check.
 type beadCheckResult struct {
 	Idempotent bool     // bead already routed to the same target
@@ -908,6 +1177,11 @@ func deliverSlingNudge(target nudgeTarget, sp runtime.Provider, cityPath string,
 func dryRunSingle(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 	a := opts.Target
 	w := func(s string) { fmt.Fprintln(deps.Stdout, s) } //nolint:errcheck // best-effort
+	if opts.IsFormula {
+		recordSling(deps, a.QualifiedName(), "", opts.BeadOrFormula, "formula", true, nil)
+	} else {
+		recordSling(deps, a.QualifiedName(), opts.BeadOrFormula, slingFormula(opts), "bead", true, nil)
+	}
 
 	// Header.
 	header := "Dry run: gc sling " + a.QualifiedName() + " " + opts.BeadOrFormula
@@ -932,9 +1206,7 @@ func dryRunSingle(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 		w("  agent through the workflow.")
 		w("")
 		cookCmd := fmt.Sprintf("bd mol cook --formula=%s", opts.BeadOrFormula)
-		if opts.Title != "" {
-			cookCmd += fmt.Sprintf(" --title=%s", opts.Title)
-		}
+		cookCmd += cookFlags(opts)
 		w("  Would run: " + cookCmd)
 		w("  This creates a wisp and returns its root bead ID.")
 		w("")
@@ -974,9 +1246,7 @@ func dryRunSingle(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 			w("  attached, rather than a standalone wisp.")
 			w("")
 			cookCmd := fmt.Sprintf("bd mol cook --formula=%s --on=%s", opts.OnFormula, opts.BeadOrFormula)
-			if opts.Title != "" {
-				cookCmd += fmt.Sprintf(" --title=%s", opts.Title)
-			}
+			cookCmd += cookFlags(opts)
 			w("  Would run: " + cookCmd)
 			w("  Pre-check: " + opts.BeadOrFormula + " has no existing molecule/wisp children ✓")
 			w("")
@@ -992,9 +1262,7 @@ func dryRunSingle(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 			w("  A wisp will be attached automatically (use --no-formula to suppress).")
 			w("")
 			cookCmd := fmt.Sprintf("bd mol cook --formula=%s --on=%s", a.DefaultSlingFormula, opts.BeadOrFormula)
-			if opts.Title != "" {
-				cookCmd += fmt.Sprintf(" --title=%s", opts.Title)
-			}
+			cookCmd += cookFlags(opts)
 			w("  Would run: " + cookCmd)
 			w("  Pre-check: " + opts.BeadOrFormula + " has no existing molecule/wisp children ✓")
 			w("")
@@ -1022,6 +1290,40 @@ func dryRunSingle(opts slingOpts, deps slingDeps, querier BeadQuerier) int {
 	return 0
 }
 
+// slingVarsUnused reports whether --var was given but no formula will be
+// cooked: no --formula or --on, and no default formula on any target.
+func slingVarsUnused(opts slingOpts) bool {
+	if len(opts.Vars) == 0 || opts.IsFormula || opts.OnFormula != "" {
+		return false
+	}
+	if opts.NoFormula {
+		return true
+	}
+	targets := opts.FanOut
+	if len(targets) == 0 {
+		targets = []config.Agent{opts.Target}
+	}
+	for _, a := range targets {
+		if a.DefaultSlingFormula != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// cookFlags renders the --title and --var flags of a cook for dry-run
+// previews.
+func cookFlags(opts slingOpts) string {
+	var b strings.Builder
+	if opts.Title != "" {
+		fmt.Fprintf(&b, " --title=%s", opts.Title)
+	}
+	for _, v := range opts.Vars {
+		b.WriteString(" --var " + shellquote.Quote(v))
+	}
+	return b.String()
+}
+
 // dryRunBatch prints a step-by-step preview of what gc sling would do for a
 // container bead (convoy, epic) without executing any side effects.
 func dryRunBatch(opts slingOpts, deps slingDeps,
@@ -1029,6 +1331,9 @@ func dryRunBatch(opts slingOpts, deps slingDeps,
 ) int {
 	a := opts.Target
 	w := func(s string) { fmt.Fprintln(deps.Stdout, s) } //nolint:errcheck // best-effort
+	for _, child := range open {
+		recordSling(deps, a.QualifiedName(), child.ID, slingFormula(opts), "batch", true, nil)
+	}
 
 	// Header.
 	w("Dry run: gc sling " + a.QualifiedName() + " " + b.ID)
@@ -1077,7 +1382,7 @@ func dryRunBatch(opts slingOpts, deps slingDeps,
 		w("Attach formula (per open child):")
 		w("  Would run:")
 		for _, c := range open {
-			w("    bd mol cook --formula=" + opts.OnFormula + " --on=" + c.ID)
+			w("    bd mol cook --formula=" + opts.OnFormula + " --on=" + c.ID + cookFlags(opts))
 		}
 		w("")
 	} else if !opts.NoFormula && a.DefaultSlingFormula != "" {
@@ -1085,7 +1390,7 @@ func dryRunBatch(opts slingOpts, deps slingDeps,
 		w("  Formula: " + a.DefaultSlingFormula)
 		w("  Would run:")
 		for _, c := range open {
-			w("    bd mol cook --formula=" + a.DefaultSlingFormula + " --on=" + c.ID)
+			w("    bd mol cook --formula=" + a.DefaultSlingFormula + " --on=" + c.ID + cookFlags(opts))
 		}
 		w("")
 	}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -57,11 +59,24 @@ type fakeRunnerRule struct {
 // fakeRunner records the commands it receives and returns canned output.
 // Rules are matched in order (first match wins), providing deterministic behavior.
 type fakeRunner struct {
-	calls []string
-	envs  []map[string]string
-	rules []fakeRunnerRule
+	calls  []string
+	envs   []map[string]string
+	rules  []fakeRunnerRule
+	routed slingRoutable
 }
 
+// slingRoutable is a fake querier that can record the routing a default
+// sling query applies, so post-sling verification sees it.
+type slingRoutable interface {
+	route(beadID, assignee, label string)
+}
+
+// routesTo makes r apply each successful default sling query to q.
+func (r *fakeRunner) routesTo(q slingRoutable) { r.routed = q }
+
+// defaultSlingQueryRe matches the default fixed-agent and pool sling queries.
+var defaultSlingQueryRe = regexp.MustCompile(`^bd update '([^']+)' --(?:assignee=\$GC_SLING_TARGET|add-label=(\S+))$`)
+
 func newFakeRunner() *fakeRunner { return &fakeRunner{} }
 
 // on registers a rule: if a command contains prefix, return (out, err).
@@ -72,12 +87,22 @@ func (r *fakeRunner) on(prefix, out string, err error) {
 func (r *fakeRunner) run(_, command string, env map[string]string) (string, error) {
 	r.calls = append(r.calls, command)
 	r.envs = append(r.envs, env)
+	var out string
+	var err error
 	for _, rule := range r.rules {
 		if strings.Contains(command, rule.prefix) {
-			return rule.out, rule.err
+			out, err = rule.out, rule.err
+			break
+		}
+	}
+	if m := defaultSlingQueryRe.FindStringSubmatch(command); m != nil && err == nil && r.routed != nil {
+		if m[2] != "" {
+			r.routed.route(m[1], "", m[2])
+		} else {
+			r.routed.route(m[1], env["GC_SLING_TARGET"], "")
 		}
 	}
-	return "", nil
+	return out, err
 }
 
 // testOpts constructs a slingOpts for testing with the given agent and bead.
@@ -273,6 +298,80 @@ func TestDoSlingFormulaWithTitle(t *testing.T) {
 	}
 }
 
+// varsStore wraps a beads.Store and records the vars passed to each cook.
+type varsStore struct {
+	beads.Store
+	vars [][]string
+}
+
+func (v *varsStore) MolCook(formula, title string, vars []string) (string, error) {
+	v.vars = append(v.vars, vars)
+	return v.Store.MolCook(formula, title, vars)
+}
+
+func (v *varsStore) MolCookOn(formula, beadID, title string, vars []string) (string, error) {
+	v.vars = append(v.vars, vars)
+	return v.Store.MolCookOn(formula, beadID, title, vars)
+}
+
+func TestDoSlingFormulaPassesVars(t *testing.T) {
+	runner := newFakeRunner()
+	sp := runtime.NewFake()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	a := config.Agent{Name: "mayor"}
+
+	deps, _, stderr := testDeps(cfg, sp, runner.run)
+	store := &varsStore{Store: deps.Store}
+	deps.Store = store
+	opts := testOpts(a, "code-review")
+	opts.IsFormula = true
+	opts.Vars = []string{"issue=BL-42", "base_branch=develop"}
+	if code := doSling(opts, deps, nil); code != 0 {
+		t.Fatalf("doSling returned %d, want 0; stderr: %s", code, stderr.String())
+	}
+	if len(store.vars) != 1 || strings.Join(store.vars[0], " ") != "issue=BL-42 base_branch=develop" {
+		t.Errorf("cook vars = %q, want [issue=BL-42 base_branch=develop]", store.vars)
+	}
+}
+
+func TestSlingInvalidVar(t *testing.T) {
+	for _, v := range []string{"novalue", "=x", "bad-key=x"} {
+		var stdout, stderr bytes.Buffer
+		cmd := newSlingCmd(&stdout, &stderr)
+		cmd.SetArgs([]string{"mayor", "code-review", "--formula", "--var", v})
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("--var %q: want error", v)
+		}
+		if !strings.Contains(stderr.String(), "invalid --var") {
+			t.Errorf("--var %q: stderr = %q", v, stderr.String())
+		}
+	}
+}
+
+func TestSlingVarsUnused(t *testing.T) {
+	plain := config.Agent{Name: "mayor"}
+	withDefault := config.Agent{Name: "polecat", DefaultSlingFormula: "mol-do-work"}
+	vars := []string{"k=v"}
+	tests := []struct {
+		name string
+		opts slingOpts
+		want bool
+	}{
+		{"no vars", slingOpts{Target: plain}, false},
+		{"formula", slingOpts{Target: plain, Vars: vars, IsFormula: true}, false},
+		{"on", slingOpts{Target: plain, Vars: vars, OnFormula: "f"}, false},
+		{"default formula", slingOpts{Target: withDefault, Vars: vars}, false},
+		{"no-formula", slingOpts{Target: withDefault, Vars: vars, NoFormula: true}, true},
+		{"plain bead", slingOpts{Target: plain, Vars: vars}, true},
+		{"fan-out with default", slingOpts{Target: plain, FanOut: []config.Agent{plain, withDefault}, Vars: vars}, false},
+	}
+	for _, tt := range tests {
+		if got := slingVarsUnused(tt.opts); got != tt.want {
+			t.Errorf("%s: slingVarsUnused = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
 func TestDoSlingSuspendedAgentWarns(t *testing.T) {
 	runner := newFakeRunner()
 	sp := runtime.NewFake()
@@ -597,10 +696,37 @@ func TestNewSlingCmdArgs(t *testing.T) {
 type fakeQuerier struct {
 	bead beads.Bead
 	err  error
+	// errLeft, when positive, limits err to that many calls (a transient
+	// bd failure).
+	errLeft int
 }
 
 func (q *fakeQuerier) Get(_ string) (beads.Bead, error) {
-	return q.bead, q.err
+	if q.err != nil && transientLeft(&q.errLeft) {
+		return beads.Bead{}, q.err
+	}
+	return q.bead, nil
+}
+
+// route applies regardless of beadID, matching Get.
+func (q *fakeQuerier) route(_, assignee, label string) {
+	q.bead = routedBead(q.bead, assignee, label)
+}
+
+// transientLeft reports whether an injected error still fires. Zero
+// means always; a positive *left counts down to -1, after which the
+// error stops.
+func transientLeft(left *int) bool {
+	switch {
+	case *left == 0:
+		return true
+	case *left < 0:
+		return false
+	}
+	if *left--; *left == 0 {
+		*left = -1
+	}
+	return true
 }
 
 // fakeChildQuerier implements BeadChildQuerier for testing batch dispatch.
@@ -608,6 +734,7 @@ type fakeChildQuerier struct {
 	beadsByID   map[string]beads.Bead
 	childrenOf  map[string][]beads.Bead
 	getErr      error
+	getErrLeft  int // when positive, limits getErr to that many calls
 	childrenErr error
 }
 
@@ -619,7 +746,7 @@ func newFakeChildQuerier() *fakeChildQuerier {
 }
 
 func (q *fakeChildQuerier) Get(id string) (beads.Bead, error) {
-	if q.getErr != nil {
+	if q.getErr != nil && transientLeft(&q.getErrLeft) {
 		return beads.Bead{}, q.getErr
 	}
 	b, ok := q.beadsByID[id]
@@ -636,12 +763,32 @@ func (q *fakeChildQuerier) Children(parentID string) ([]beads.Bead, error) {
 	return q.childrenOf[parentID], nil
 }
 
+func (q *fakeChildQuerier) route(beadID, assignee, label string) {
+	b, ok := q.beadsByID[beadID]
+	if !ok {
+		b = beads.Bead{ID: beadID, Status: "open"}
+	}
+	q.beadsByID[beadID] = routedBead(b, assignee, label)
+}
+
+// routedBead returns b with a sling's assignee or label applied.
+func routedBead(b beads.Bead, assignee, label string) beads.Bead {
+	if assignee != "" {
+		b.Assignee = assignee
+	}
+	if label != "" {
+		b.Labels = append(slices.Clone(b.Labels), label)
+	}
+	return b
+}
+
 func TestCheckBeadStateAssigneeWarns(t *testing.T) {
 	runner := newFakeRunner()
 	sp := runtime.NewFake()
 	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
 	a := config.Agent{Name: "mayor"}
 	q := &fakeQuerier{bead: beads.Bead{ID: "BL-42", Assignee: "other-agent"}}
+	runner.routesTo(q)
 
 	deps, _, stderr := testDeps(cfg, sp, runner.run)
 	opts := testOpts(a, "BL-42")
@@ -665,6 +812,7 @@ func TestCheckBeadStatePoolLabelWarns(t *testing.T) {
 	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
 	a := config.Agent{Name: "mayor"}
 	q := &fakeQuerier{bead: beads.Bead{ID: "BL-42", Labels: []string{"pool:hw/polecat"}}}
+	runner.routesTo(q)
 
 	deps, _, stderr := testDeps(cfg, sp, runner.run)
 	opts := testOpts(a, "BL-42")
@@ -688,6 +836,7 @@ func TestCheckBeadStateBothWarnings(t *testing.T) {
 		Assignee: "other-agent",
 		Labels:   []string{"pool:hw/polecat"},
 	}}
+	runner.routesTo(q)
 
 	deps, _, stderr := testDeps(cfg, sp, runner.run)
 	opts := testOpts(a, "BL-42")
@@ -710,6 +859,7 @@ func TestCheckBeadStateCleanNoWarning(t *testing.T) {
 	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
 	a := config.Agent{Name: "mayor"}
 	q := &fakeQuerier{bead: beads.Bead{ID: "BL-42"}}
+	runner.routesTo(q)
 
 	deps, _, stderr := testDeps(cfg, sp, runner.run)
 	opts := testOpts(a, "BL-42")
@@ -728,7 +878,9 @@ func TestCheckBeadStateQueryFailsNoWarning(t *testing.T) {
 	sp := runtime.NewFake()
 	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
 	a := config.Agent{Name: "mayor"}
-	q := &fakeQuerier{err: fmt.Errorf("bd not available")}
+	// Only the pre-flight read fails; the post-sling verification succeeds.
+	q := &fakeQuerier{err: fmt.Errorf("bd not available"), errLeft: 1}
+	runner.routesTo(q)
 
 	deps, _, stderr := testDeps(cfg, sp, runner.run)
 	opts := testOpts(a, "BL-42")
@@ -766,6 +918,7 @@ func TestCheckBeadStateForceSkipsCheck(t *testing.T) {
 	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
 	a := config.Agent{Name: "mayor"}
 	q := &fakeQuerier{bead: beads.Bead{ID: "BL-42", Assignee: "other-agent"}}
+	runner.routesTo(q)
 
 	deps, _, stderr := testDeps(cfg, sp, runner.run)
 	opts := testOpts(a, "BL-42")
@@ -789,6 +942,7 @@ func TestCheckBeadStateFormulaChecksResolvedBead(t *testing.T) {
 	// The querier returns a clean bead for the wisp root — verifies check
 	// runs on WP-99, not the formula name "my-formula".
 	q := &fakeQuerier{bead: beads.Bead{ID: "WP-99"}}
+	runner.routesTo(q)
 
 	deps, _, stderr := testDeps(cfg, sp, runner.run)
 	opts := testOpts(a, "my-formula")
@@ -812,6 +966,7 @@ func TestDoSlingBatchConvoyExpandsChildren(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "BL-1", Status: "open"},
@@ -844,6 +999,7 @@ func TestDoSlingBatchConvoyMixedStatus(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-2"] = beads.Bead{ID: "CVY-2", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-2"] = []beads.Bead{
 		{ID: "BL-1", Status: "open"},
@@ -909,6 +1065,7 @@ func TestDoSlingBatchEpicExpands(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["EP-1"] = beads.Bead{ID: "EP-1", Type: "epic", Status: "open"}
 	q.childrenOf["EP-1"] = []beads.Bead{
 		{ID: "BL-10", Status: "open"},
@@ -937,6 +1094,7 @@ func TestDoSlingBatchRegularBeadPassthrough(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["BL-42"] = beads.Bead{ID: "BL-42", Type: "task", Status: "open"}
 
 	deps, stdout, stderr := testDeps(cfg, sp, runner.run)
@@ -966,6 +1124,7 @@ func TestDoSlingBatchFormulaPassthrough(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	// Even if the querier has a convoy, --formula bypasses container check.
 	q.beadsByID["convoy-formula"] = beads.Bead{ID: "convoy-formula", Type: "convoy"}
 
@@ -1008,7 +1167,9 @@ func TestDoSlingBatchGetFails(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.getErr = fmt.Errorf("bd not available")
+	q.getErrLeft = 2 // container lookup and pre-flight; verification succeeds
 
 	deps, stdout, stderr := testDeps(cfg, sp, runner.run)
 	opts := testOpts(a, "BL-42")
@@ -1053,6 +1214,7 @@ func TestDoSlingBatchPartialFailure(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "BL-1", Status: "open"},
@@ -1117,6 +1279,7 @@ func TestDoSlingBatchNudgeOnceAfterAll(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "BL-1", Status: "open"},
@@ -1156,6 +1319,7 @@ func TestDoSlingBatchForceSkipsPerChildWarnings(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	// Children already assigned — would normally warn.
 	q.beadsByID["BL-1"] = beads.Bead{ID: "BL-1", Status: "open", Assignee: "other"}
@@ -1359,6 +1523,7 @@ func TestOnFormulaAutoBurnStaleMolecule(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	// Unassigned bead — molecule is stale from failed dispatch, should be auto-burned.
 	q.beadsByID["BL-42"] = beads.Bead{ID: "BL-42", Type: "task", Status: "open", Assignee: ""}
 	q.childrenOf["BL-42"] = []beads.Bead{
@@ -1390,6 +1555,7 @@ func TestOnFormulaSkipsClosedMolecule(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["BL-42"] = beads.Bead{ID: "BL-42", Type: "task", Status: "open", Assignee: "other-agent"}
 	q.childrenOf["BL-42"] = []beads.Bead{
 		{ID: "MOL-1", Type: "molecule", Status: "closed"}, // closed — should be skipped
@@ -1412,6 +1578,7 @@ func TestOnFormulaCleanBead(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["BL-42"] = beads.Bead{ID: "BL-42", Type: "task", Status: "open"}
 	q.childrenOf["BL-42"] = []beads.Bead{
 		{ID: "STEP-1", Type: "step", Status: "open"}, // step, not molecule
@@ -1480,6 +1647,7 @@ func TestBatchOnConvoy(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "BL-1", Status: "open"},
@@ -1566,6 +1734,7 @@ func TestBatchAutoBurnStaleMolecules(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "BL-1", Status: "open"},
@@ -1601,6 +1770,7 @@ func TestBatchSkipsClosedMolecules(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "BL-1", Status: "open", Assignee: "other-agent"},
@@ -1627,6 +1797,7 @@ func TestBatchOnPartialCookFailure(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "BL-1", Status: "open"},
@@ -1673,6 +1844,7 @@ func TestBatchOnNudgeOnce(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	q.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "BL-1", Status: "open"},
@@ -1713,6 +1885,7 @@ func TestBatchOnRegularPassthrough(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["BL-42"] = beads.Bead{ID: "BL-42", Type: "task", Status: "open"}
 
 	deps, stdout, stderr := testDeps(cfg, sp, runner.run)
@@ -1827,6 +2000,27 @@ func TestDryRunFormula(t *testing.T) {
 	}
 }
 
+func TestDryRunFormulaShowsVars(t *testing.T) {
+	runner := newFakeRunner()
+	sp := runtime.NewFake()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	a := config.Agent{Name: "mayor"}
+
+	deps, stdout, stderr := testDeps(cfg, sp, runner.run)
+	opts := testOpts(a, "code-review")
+	opts.IsFormula = true
+	opts.DryRun = true
+	opts.Title = "review"
+	opts.Vars = []string{"issue=BL-42", "note=it's here"}
+	if code := doSling(opts, deps, nil); code != 0 {
+		t.Fatalf("dry-run returned %d, want 0; stderr: %s", code, stderr.String())
+	}
+	want := `Would run: bd mol cook --formula=code-review --title=review --var 'issue=BL-42' --var 'note=it'\''s here'`
+	if !strings.Contains(stdout.String(), want) {
+		t.Errorf("stdout missing %q:\n%s", want, stdout.String())
+	}
+}
+
 func TestDryRunOnFormula(t *testing.T) {
 	runner := newFakeRunner()
 	sp := runtime.NewFake()
@@ -2324,6 +2518,7 @@ func TestDoSlingBatchIdempotentChildSkipped(t *testing.T) {
 	a := config.Agent{Name: "mayor"}
 
 	q := newFakeChildQuerier()
+	runner.routesTo(q)
 	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	// BL-1 is already assigned to mayor (idempotent).
 	q.beadsByID["BL-1"] = beads.Bead{ID: "BL-1", Status: "open", Assignee: "mayor"}
@@ -2751,27 +2946,6 @@ func TestDoSlingCrossRigFormulaExempt(t *testing.T) {
 
 // --- New tests for shell quoting, helpers, and edge cases ---
 
-func TestShellQuote(t *testing.T) {
-	tests := []struct {
-		input string
-		want  string
-	}{
-		{"simple", "'simple'"},
-		{"it's", "'it'\\''s'"},
-		{"a b c", "'a b c'"},
-		{"", "''"},
-		{"$(rm -rf /)", "'$(rm -rf /)'"},
-		{"`evil`", "'`evil`'"},
-		{"hello'world'end", "'hello'\\''world'\\''end'"},
-	}
-	for _, tt := range tests {
-		got := shellQuote(tt.input)
-		if got != tt.want {
-			t.Errorf("shellQuote(%q) = %q, want %q", tt.input, got, tt.want)
-		}
-	}
-}
-
 func TestFormatBeadLabel(t *testing.T) {
 	if got := formatBeadLabel("BL-42", ""); got != "BL-42" {
 		t.Errorf("formatBeadLabel(no title) = %q, want %q", got, "BL-42")
@@ -3001,6 +3175,7 @@ func TestDefaultFormulaBatchApplied(t *testing.T) {
 	a := config.Agent{Name: "polecat", Dir: "hw", DefaultSlingFormula: "mol-polecat-work"}
 
 	querier := newFakeChildQuerier()
+	runner.routesTo(querier)
 	querier.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
 	querier.childrenOf["CVY-1"] = []beads.Bead{
 		{ID: "HW-1", Type: "task", Status: "open"},
@@ -3126,3 +3301,27 @@ func TestOneArgSlingFormulaRequiresTarget(t *testing.T) {
 		t.Fatal("expected error for --formula with 1 arg")
 	}
 }
+
+func TestRouteSlingTarget(t *testing.T) {
+	store := beads.NewMemStore()
+	bug, _ := store.Create(beads.Bead{Title: "crash", Type: "bug"})
+	task, _ := store.Create(beads.Bead{Title: "docs", Labels: []string{"area:docs"}})
+	cfg := &config.City{Routes: []config.Route{
+		{Type: "bug", Target: "hw/polecat"},
+		{Label: "area:*", Target: "mayor"},
+	}}
+
+	if got, err := routeSlingTarget(cfg, store, bug.ID); err != nil || got != "hw/polecat" {
+		t.Errorf("bug → %q, %v; want hw/polecat", got, err)
+	}
+	if got, _ := routeSlingTarget(cfg, store, task.ID); got != "mayor" {
+		t.Errorf("labeled task → %q, want mayor", got)
+	}
+	plain, _ := store.Create(beads.Bead{Title: "misc"})
+	if got, err := routeSlingTarget(cfg, store, plain.ID); err != nil || got != "" {
+		t.Errorf("unmatched → %q, %v; want empty", got, err)
+	}
+	if _, err := routeSlingTarget(cfg, store, "nope-1"); err == nil {
+		t.Error("missing bead: want error")
+	}
+}
@@ -187,16 +187,25 @@ func buildIdleTracker(cfg *config.City, cityName, cityPath string, sp runtime.Pr
 func newStartCmd(stdout, stderr io.Writer) *cobra.Command {
 	var foregroundMode bool
 	cmd := &cobra.Command{
-		Use:   "start [path]",
-		Short: "Start the city (auto-initializes if needed)",
+		Use:     "start [path]",
+		Aliases: []string{"up"},
+		Short:   "Start the city (auto-initializes if needed)",
 		Long: `Start the city by launching all configured agent sessions.
 
 Auto-initializes the city if no .gc/ directory exists. Fetches remote
-packs, resolves providers, installs hooks, and starts agent sessions
-via one-shot reconciliation. Use --foreground for a persistent controller
-that continuously reconciles agent state.`,
+packs, resolves providers, installs hooks, and starts a session for
+every non-suspended agent (pools at their scaled size) via one-shot
+reconciliation, running each agent's pre_start commands, then prints a
+summary table. Use --foreground for a persistent controller that
+continuously reconciles agent state.
+
+--only and --rig limit a one-shot start to some agents. Sessions
+outside the filter are left as they are.`,
 		Example: `  gc start
+  gc up
   gc start ~/my-city
+  gc start --only mayor --only hw/polecat
+  gc start --rig hw
   gc start --foreground
   gc start -f overlay.toml --no-strict`,
 		Args: cobra.MaximumNArgs(1),
@@ -219,6 +228,10 @@ that continuously reconciles agent state.`,
 		"disable strict config collision checking (strict is on by default)")
 	cmd.Flags().BoolVarP(&dryRunMode, "dry-run", "n", false,
 		"preview what agents would start without starting them")
+	cmd.Flags().StringArrayVar(&startOnlyAgents, "only", nil,
+		"start only this agent (qualified or bare name; can be repeated)")
+	cmd.Flags().StringVar(&startRigFilter, "rig", "",
+		"start only agents in this rig")
 	return cmd
 }
 
@@ -434,6 +447,18 @@ func doStart(args []string, controllerMode bool, stdout, stderr io.Writer) int {
 		eventProv = fr
 	}
 
+	filter := startFilter{Only: startOnlyAgents, Rig: startRigFilter}
+	if filter.active() {
+		if controllerMode {
+			fmt.Fprintln(stderr, "gc start: --only and --rig cannot be used with --foreground") //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		if err := filter.validate(cfg); err != nil {
+			fmt.Fprintf(stderr, "gc start: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+
 	// Pre-check container images once (fail fast before N serial starts).
 	if err := checkAgentImages(sp, cfg.Agents, stderr); err != nil {
 		fmt.Fprintf(stderr, "gc start: %v\n", err) //nolint:errcheck // best-effort stderr
@@ -443,6 +468,9 @@ func doStart(args []string, controllerMode bool, stdout, stderr io.Writer) int {
 	// --dry-run: build agents and print preview without starting.
 	if dryRunMode {
 		agents := buildAgents(cfg, sp, nil)
+		if filter.active() {
+			agents = filter.apply(agents)
+		}
 		printDryRunPreview(agents, cfg, cityName, stdout)
 		return 0
 	}
@@ -493,6 +521,10 @@ func doStart(args []string, controllerMode bool, stdout, stderr io.Writer) int {
 		fmt.Fprintf(stderr, "gc start: bead store unavailable, using provider hashes: %v\n", err) //nolint:errcheck
 	}
 	agents := buildAgents(cfg, sp, oneShotStore)
+	if filter.active() {
+		agents = filter.apply(agents)
+		rops = filteredReconcileOps{reconcileOps: rops, keep: agents}
+	}
 	suspendedNames := computeSuspendedNames(cfg, cityName, cityPath)
 	code := doReconcileAgents(agents, sp, rops, nil, nil, nil, recorder, nil, suspendedNames, 0, cfg.Session.StartupTimeoutDuration(), stdout, stderr, sigCtx)
 	// Post-reconcile sync: update bead state to reflect post-start reality.
@@ -501,6 +533,8 @@ func doStart(args []string, controllerMode bool, stdout, stderr io.Writer) int {
 		ds := buildDesiredState(cityName, cityPath, beaconTime, cfg, sp, oneShotStore, stderr)
 		syncSessionBeads(oneShotStore, ds, sp, cfgNames, cfg, clock.Real{}, stderr, false)
 	}
+	fmt.Fprintln(stdout) //nolint:errcheck // best-effort stdout
+	printStartSummary(agents, cfg, filter, sp, stdout)
 	if code == 0 {
 		fmt.Fprintln(stdout, "City started.") //nolint:errcheck // best-effort stdout
 	}
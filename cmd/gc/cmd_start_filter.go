@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// startOnlyAgents and startRigFilter hold the --only and --rig flags of
+// gc start.
+var (
+	startOnlyAgents []string
+	startRigFilter  string
+)
+
+// startFilter restricts a one-shot gc start to some of the city's agents.
+// The zero value matches every agent.
+type startFilter struct {
+	Only []string // agent names, qualified ("hw/polecat") or bare ("polecat")
+	Rig  string   // rig name
+}
+
+func (f startFilter) active() bool {
+	return len(f.Only) > 0 || f.Rig != ""
+}
+
+// validate checks that every --only name matches a configured agent and
+// that --rig names a configured rig.
+func (f startFilter) validate(cfg *config.City) error {
+	if f.Rig != "" {
+		found := false
+		for _, r := range cfg.Rigs {
+			if r.Name == f.Rig {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New(rigNotFoundMsg("--rig", f.Rig, cfg))
+		}
+	}
+	for _, name := range f.Only {
+		found := false
+		for _, a := range cfg.Agents {
+			if f.matchesName(name, a.QualifiedName()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New(agentNotFoundMsg("--only", name, cfg))
+		}
+	}
+	return nil
+}
+
+// matchesName reports whether an --only entry names the agent with the
+// given qualified name. A bare name matches the agent in any rig.
+func (f startFilter) matchesName(want, qualified string) bool {
+	if want == qualified {
+		return true
+	}
+	dir, name := config.ParseQualifiedName(qualified)
+	return dir != "" && want == name
+}
+
+// matches reports whether an agent (by qualified template name and rig)
+// passes the filter.
+func (f startFilter) matches(templateName, rig string) bool {
+	if f.Rig != "" && rig != f.Rig {
+		return false
+	}
+	if len(f.Only) == 0 {
+		return true
+	}
+	for _, want := range f.Only {
+		if f.matchesName(want, templateName) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the subset of desiredState that passes the filter.
+func (f startFilter) apply(desiredState map[string]TemplateParams) map[string]TemplateParams {
+	out := make(map[string]TemplateParams, len(desiredState))
+	for sn, tp := range desiredState {
+		if f.matches(tp.TemplateName, tp.RigName) || (tp.InstanceName != "" && f.matches(tp.InstanceName, tp.RigName)) {
+			out[sn] = tp
+		}
+	}
+	return out
+}
+
+// filteredReconcileOps hides sessions outside a filtered start from the
+// reconciler, so agents the filter excludes are neither drift-checked
+// nor stopped as orphans.
+type filteredReconcileOps struct {
+	reconcileOps
+	keep map[string]TemplateParams
+}
+
+func (f filteredReconcileOps) listRunning(prefix string) ([]string, error) {
+	names, err := f.reconcileOps.listRunning(prefix)
+	if err != nil {
+		return nil, err
+	}
+	kept := names[:0]
+	for _, n := range names {
+		if _, ok := f.keep[n]; ok {
+			kept = append(kept, n)
+		}
+	}
+	return kept, nil
+}
+
+// printStartSummary prints one row per agent session the start covered,
+// followed by suspended agents the filter selects.
+func printStartSummary(desiredState map[string]TemplateParams, cfg *config.City, filter startFilter, sp runtime.Provider, stdout io.Writer) {
+	sessions := make([]string, 0, len(desiredState))
+	for sn := range desiredState {
+		sessions = append(sessions, sn)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return desiredState[sessions[i]].DisplayName() < desiredState[sessions[j]].DisplayName()
+	})
+
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "AGENT\tRIG\tSESSION\tSTATUS") //nolint:errcheck // best-effort stdout
+	for _, sn := range sessions {
+		tp := desiredState[sn]
+		status := "not running"
+		if sp.IsRunning(sn) {
+			status = "running"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", tp.DisplayName(), orDash(tp.RigName), sn, status) //nolint:errcheck // best-effort stdout
+	}
+	for _, a := range cfg.Agents {
+		if !a.Suspended && !cfg.Workspace.Suspended {
+			continue
+		}
+		if !filter.matches(a.QualifiedName(), a.Dir) {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t-\tsuspended\n", a.QualifiedName(), orDash(a.Dir)) //nolint:errcheck // best-effort stdout
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+}
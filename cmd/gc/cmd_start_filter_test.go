@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func filterTestState() map[string]TemplateParams {
+	return map[string]TemplateParams{
+		"mayor":         {SessionName: "mayor", TemplateName: "mayor", Command: "echo"},
+		"hw--witness":   {SessionName: "hw--witness", TemplateName: "hw/witness", RigName: "hw", Command: "echo"},
+		"hw--polecat-1": {SessionName: "hw--polecat-1", TemplateName: "hw/polecat", InstanceName: "hw/polecat-1", RigName: "hw", Command: "echo"},
+		"fe--polecat-1": {SessionName: "fe--polecat-1", TemplateName: "fe/polecat", InstanceName: "fe/polecat-1", RigName: "fe", Command: "echo"},
+	}
+}
+
+func TestStartFilterApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter startFilter
+		want   []string
+	}{
+		{"none", startFilter{}, []string{"fe--polecat-1", "hw--polecat-1", "hw--witness", "mayor"}},
+		{"only qualified", startFilter{Only: []string{"hw/witness"}}, []string{"hw--witness"}},
+		{"only bare matches every rig", startFilter{Only: []string{"polecat"}}, []string{"fe--polecat-1", "hw--polecat-1"}},
+		{"only pool instance", startFilter{Only: []string{"hw/polecat-1"}}, []string{"hw--polecat-1"}},
+		{"rig", startFilter{Rig: "hw"}, []string{"hw--polecat-1", "hw--witness"}},
+		{"rig and only", startFilter{Rig: "hw", Only: []string{"polecat"}}, []string{"hw--polecat-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.filter.apply(filterTestState())
+			names := make([]string, 0, len(got))
+			for sn := range got {
+				names = append(names, sn)
+			}
+			sort.Strings(names)
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("apply = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartFilterValidate(t *testing.T) {
+	cfg := &config.City{
+		Rigs:   []config.Rig{{Name: "hw", Path: "/hw"}},
+		Agents: []config.Agent{{Name: "mayor"}, {Name: "polecat", Dir: "hw"}},
+	}
+	for _, f := range []startFilter{
+		{Only: []string{"mayor"}},
+		{Only: []string{"polecat"}},
+		{Only: []string{"hw/polecat"}},
+		{Rig: "hw"},
+	} {
+		if err := f.validate(cfg); err != nil {
+			t.Errorf("validate(%+v) = %v, want nil", f, err)
+		}
+	}
+	if err := (startFilter{Only: []string{"nobody"}}).validate(cfg); err == nil || !strings.Contains(err.Error(), `agent "nobody" not found`) {
+		t.Errorf("validate(--only nobody) = %v, want agent not found", err)
+	}
+	if err := (startFilter{Rig: "nope"}).validate(cfg); err == nil || !strings.Contains(err.Error(), `rig "nope" not found`) {
+		t.Errorf("validate(--rig nope) = %v, want rig not found", err)
+	}
+}
+
+func TestFilteredReconcileLeavesOthersRunning(t *testing.T) {
+	ds := filterTestState()
+	sp := runtime.NewFake()
+	rops := newFakeReconcileOps()
+	for _, sn := range []string{"mayor", "fe--polecat-1"} {
+		_ = sp.Start(context.Background(), sn, templateParamsToConfig(ds[sn]))
+		rops.running[sn] = true
+	}
+
+	keep := startFilter{Rig: "hw"}.apply(ds)
+	var stdout, stderr bytes.Buffer
+	code := doReconcileAgents(keep, sp, filteredReconcileOps{reconcileOps: rops, keep: keep},
+		nil, nil, nil, events.Discard, nil, nil, 0, 0, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("code = %d; stderr: %s", code, stderr.String())
+	}
+	for _, sn := range []string{"hw--witness", "hw--polecat-1", "mayor", "fe--polecat-1"} {
+		if !sp.IsRunning(sn) {
+			t.Errorf("%s not running after filtered start", sn)
+		}
+	}
+	if strings.Contains(stdout.String(), "orphan") {
+		t.Errorf("filtered start stopped sessions outside the filter:\n%s", stdout.String())
+	}
+}
+
+func TestPrintStartSummary(t *testing.T) {
+	ds := filterTestState()
+	sp := runtime.NewFake()
+	_ = sp.Start(context.Background(), "mayor", templateParamsToConfig(ds["mayor"]))
+	cfg := &config.City{Agents: []config.Agent{
+		{Name: "mayor"},
+		{Name: "deacon", Suspended: true},
+		{Name: "refinery", Dir: "hw", Suspended: true},
+	}}
+
+	var stdout bytes.Buffer
+	printStartSummary(map[string]TemplateParams{"mayor": ds["mayor"], "hw--witness": ds["hw--witness"]},
+		cfg, startFilter{}, sp, &stdout)
+	out := stdout.String()
+	for _, want := range []string{
+		"AGENT", "STATUS",
+		"mayor", "running",
+		"hw/witness", "not running",
+		"deacon", "hw/refinery", "suspended",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+
+	stdout.Reset()
+	printStartSummary(map[string]TemplateParams{"hw--witness": ds["hw--witness"]},
+		cfg, startFilter{Rig: "hw"}, sp, &stdout)
+	if strings.Contains(stdout.String(), "deacon") {
+		t.Errorf("rig-filtered summary lists city agent:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"slices"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+// statsSkipTypes are bead types that are plumbing rather than work and
+// are left out of throughput stats.
+var statsSkipTypes = []string{"message", "notification", sessionBeadType, "convoy", "molecule", "wisp", "agent"}
+
+func newStatsCmd(stdout, stderr io.Writer) *cobra.Command {
+	var since, by string
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize bead throughput: created, closed, WIP, and queue age",
+		Long: `Summarize whether the city is making progress on its beads.
+
+CREATED and CLOSED count beads created and closed within --since, and
+MEDIAN CLOSE is the median time from creation to close of the beads
+closed in that window. WIP (in progress) and QUEUED (open) describe the
+stores now, with the age of the queued beads at the 50th and 90th
+percentile and the oldest. A reopened bead's queue age counts from when
+it was reopened, taken from its status history.
+
+Beads from the city store and every rig store are included; messages,
+sessions, convoys, and molecules are not. With --by, the same figures
+are broken down by assignee, rig, or day (UTC); by day, WIP and queue
+figures fall on today.`,
+		Example: `  gc stats
+  gc stats --since 30d --by rig
+  gc stats --by day --json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdStats(since, by, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&since, "since", "7d", "window for created and closed counts (e.g., 7d, 12h)")
+	cmd.Flags().StringVar(&by, "by", "", "break down by agent, rig, or day")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// cmdStats is the CLI entry point for gc stats.
+func cmdStats(sinceFlag, by string, jsonOutput bool, stdout, stderr io.Writer) int {
+	if by != "" && by != usage.ByAgent && by != usage.ByRig && by != usage.ByDay {
+		fmt.Fprintf(stderr, "gc stats: unknown --by %q (want agent, rig, or day)\n", by) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	window, err := parsePruneDuration(sinceFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc stats: --since: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc stats: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc stats: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	resolveRigPaths(cityPath, cfg.Rigs)
+
+	dirs := []string{""}
+	if rawBeadsProvider(cityPath) == "bd" {
+		for _, r := range cfg.Rigs {
+			dirs = append(dirs, r.Path)
+		}
+	}
+	var all []beads.Bead
+	for _, d := range dirs {
+		store, err := openRigStoreAt(cityPath, d)
+		if err == nil {
+			var bs []beads.Bead
+			if bs, err = store.List(); err == nil {
+				all = append(all, bs...)
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "gc stats: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+	}
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	rigOf := func(id string) string {
+		if r, ok := findRigByPrefix(cfg, beadPrefix(id)); ok {
+			return r.Name
+		}
+		return cityName
+	}
+	now := time.Now()
+	return doStats(all, by, rigOf, now.Add(-window), now, jsonOutput, stdout, stderr)
+}
+
+// beadStats is the throughput of one group of beads, or of all of them.
+type beadStats struct {
+	Key         string        `json:"key"`
+	Created     int           `json:"created"`
+	Closed      int           `json:"closed"`
+	MedianClose time.Duration `json:"median_close_ns"`
+	WIP         int           `json:"wip"`
+	Queued      int           `json:"queued"`
+	QueueP50    time.Duration `json:"queue_age_p50_ns"`
+	QueueP90    time.Duration `json:"queue_age_p90_ns"`
+	QueueMax    time.Duration `json:"queue_age_max_ns"`
+
+	closeTimes []time.Duration
+	queueAges  []time.Duration
+}
+
+// summarizeBeadStats computes throughput for bs over the window from
+// since to now: totals, plus one row per group (sorted by key) when by
+// is set. rigOf names the rig that owns a bead ID.
+func summarizeBeadStats(bs []beads.Bead, by string, rigOf func(id string) string, since, now time.Time) (beadStats, []beadStats) {
+	total := &beadStats{Key: "total"}
+	groups := make(map[string]*beadStats)
+	group := func(b beads.Bead, t time.Time) *beadStats {
+		var key string
+		switch by {
+		case usage.ByAgent:
+			key = b.Assignee
+		case usage.ByRig:
+			key = rigOf(b.ID)
+		case usage.ByDay:
+			key = t.UTC().Format(time.DateOnly)
+		default:
+			return nil
+		}
+		if key == "" {
+			key = "(none)"
+		}
+		g := groups[key]
+		if g == nil {
+			g = &beadStats{Key: key}
+			groups[key] = g
+		}
+		return g
+	}
+	each := func(b beads.Bead, t time.Time, f func(*beadStats)) {
+		f(total)
+		if g := group(b, t); g != nil {
+			f(g)
+		}
+	}
+
+	for _, b := range bs {
+		if slices.Contains(statsSkipTypes, b.Type) {
+			continue
+		}
+		if !b.CreatedAt.Before(since) {
+			each(b, b.CreatedAt, func(s *beadStats) { s.Created++ })
+		}
+		switch b.Status {
+		case "closed":
+			closed, ok := beadClosedAt(b)
+			if !ok || closed.Before(since) {
+				continue
+			}
+			d := closed.Sub(b.CreatedAt)
+			each(b, closed, func(s *beadStats) {
+				s.Closed++
+				s.closeTimes = append(s.closeTimes, d)
+			})
+		case "in_progress":
+			each(b, now, func(s *beadStats) { s.WIP++ })
+		case "open":
+			age := now.Sub(beadQueuedAt(b))
+			each(b, now, func(s *beadStats) {
+				s.Queued++
+				s.queueAges = append(s.queueAges, age)
+			})
+		}
+	}
+
+	total.finish()
+	rows := make([]beadStats, 0, len(groups))
+	for _, g := range groups {
+		g.finish()
+		rows = append(rows, *g)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+	return *total, rows
+}
+
+// finish computes s's median and percentiles from the collected times.
+func (s *beadStats) finish() {
+	s.MedianClose = percentile(s.closeTimes, 0.5)
+	s.QueueP50 = percentile(s.queueAges, 0.5)
+	s.QueueP90 = percentile(s.queueAges, 0.9)
+	s.QueueMax = percentile(s.queueAges, 1)
+}
+
+// percentile returns the nearest-rank p-th percentile (0 < p <= 1) of
+// ds, or 0 when ds is empty. ds is sorted in place.
+func percentile(ds []time.Duration, p float64) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	slices.Sort(ds)
+	i := int(math.Ceil(p*float64(len(ds)))) - 1
+	return ds[max(i, 0)]
+}
+
+// beadClosedAt returns when b was last closed: ClosedAt, else its last
+// transition to closed. Stores that record neither report false.
+func beadClosedAt(b beads.Bead) (time.Time, bool) {
+	if b.ClosedAt != nil {
+		return *b.ClosedAt, true
+	}
+	for i := len(b.Events) - 1; i >= 0; i-- {
+		if e := b.Events[i]; e.Field == beads.FieldStatus && e.To == "closed" {
+			return e.At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// beadQueuedAt returns when b last entered the open state: its last
+// transition to open (a reopen or unclaim), else its creation.
+func beadQueuedAt(b beads.Bead) time.Time {
+	for i := len(b.Events) - 1; i >= 0; i-- {
+		if e := b.Events[i]; e.Field == beads.FieldStatus && e.To == "open" {
+			return e.At
+		}
+	}
+	return b.CreatedAt
+}
+
+// doStats prints throughput stats for bs.
+func doStats(bs []beads.Bead, by string, rigOf func(id string) string, since, now time.Time, jsonOutput bool, stdout, stderr io.Writer) int {
+	total, rows := summarizeBeadStats(bs, by, rigOf, since, now)
+	if jsonOutput {
+		data, err := json.MarshalIndent(struct {
+			Since  time.Time   `json:"since"`
+			Total  beadStats   `json:"total"`
+			Groups []beadStats `json:"groups,omitempty"`
+		}{since.UTC(), total, rows}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc stats: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if by == "" {
+		fmt.Fprintf(stdout, "Since %s:\n", since.Local().Format("2006-01-02 15:04"))   //nolint:errcheck // best-effort stdout
+		fmt.Fprintf(stdout, "  Created:       %d\n", total.Created)                    //nolint:errcheck // best-effort stdout
+		fmt.Fprintf(stdout, "  Closed:        %d\n", total.Closed)                     //nolint:errcheck // best-effort stdout
+		fmt.Fprintf(stdout, "  Median close:  %s\n", statsDuration(total.MedianClose)) //nolint:errcheck // best-effort stdout
+		fmt.Fprintln(stdout, "Now:")                                                   //nolint:errcheck // best-effort stdout
+		fmt.Fprintf(stdout, "  In progress:   %d\n", total.WIP)                        //nolint:errcheck // best-effort stdout
+		fmt.Fprintf(stdout, "  Queued:        %d", total.Queued)                       //nolint:errcheck // best-effort stdout
+		if total.Queued > 0 {
+			fmt.Fprintf(stdout, " (age p50 %s, p90 %s, max %s)", //nolint:errcheck // best-effort stdout
+				statsDuration(total.QueueP50), statsDuration(total.QueueP90), statsDuration(total.QueueMax))
+		}
+		fmt.Fprintln(stdout) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tCREATED\tCLOSED\tMEDIAN CLOSE\tWIP\tQUEUED\tQUEUE P50\tQUEUE P90\tQUEUE MAX\n", usageKeyHeader(by)) //nolint:errcheck // best-effort stdout
+	total.Key = "TOTAL"
+	for _, r := range append(rows, total) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%d\t%d\t%s\t%s\t%s\n", r.Key, r.Created, r.Closed, statsDuration(r.MedianClose), //nolint:errcheck // best-effort stdout
+			r.WIP, r.Queued, statsDuration(r.QueueP50), statsDuration(r.QueueP90), statsDuration(r.QueueMax))
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// statsDuration formats a stats duration, with "-" for none.
+func statsDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return formatDuration(d)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestSummarizeBeadStats(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-7 * 24 * time.Hour)
+	at := func(d time.Duration) time.Time { return now.Add(-d) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	bs := []beads.Bead{
+		// Created and closed in the window: 2h and 6h to close.
+		{ID: "ap-1", Status: "closed", Assignee: "api/worker", CreatedAt: at(30 * time.Hour), ClosedAt: ptr(at(28 * time.Hour))},
+		{ID: "ap-2", Status: "closed", Assignee: "api/worker", CreatedAt: at(10 * time.Hour), ClosedAt: ptr(at(4 * time.Hour))},
+		// Created before the window and closed in it, recorded only in
+		// its history: 4h to close.
+		{ID: "gc-3", Status: "closed", Assignee: "mayor", CreatedAt: at(7*24*time.Hour + 2*time.Hour), Events: []beads.Transition{
+			{At: at(7*24*time.Hour - 2*time.Hour), Field: beads.FieldStatus, From: "open", To: "closed"},
+		}},
+		// Closed before the window.
+		{ID: "gc-4", Status: "closed", CreatedAt: at(20 * 24 * time.Hour), ClosedAt: ptr(at(10 * 24 * time.Hour))},
+		{ID: "ap-5", Status: "in_progress", Assignee: "api/worker", CreatedAt: at(3 * time.Hour)},
+		// Queued 1h, 5h, and (reopened 2h ago) 2h.
+		{ID: "ap-6", Status: "open", CreatedAt: at(time.Hour)},
+		{ID: "gc-7", Status: "open", CreatedAt: at(5 * time.Hour)},
+		{ID: "gc-8", Status: "open", CreatedAt: at(9 * 24 * time.Hour), Events: []beads.Transition{
+			{At: at(3 * time.Hour), Field: beads.FieldStatus, From: "open", To: "closed"},
+			{At: at(2 * time.Hour), Field: beads.FieldStatus, From: "closed", To: "open"},
+		}},
+		// Plumbing is not work.
+		{ID: "gc-9", Status: "open", Type: "message", CreatedAt: at(time.Hour)},
+	}
+	rigOf := func(id string) string {
+		if strings.HasPrefix(id, "ap-") {
+			return "api"
+		}
+		return "city"
+	}
+
+	total, rows := summarizeBeadStats(bs, "", rigOf, since, now)
+	if len(rows) != 0 {
+		t.Errorf("rows without --by = %+v", rows)
+	}
+	if total.Created != 5 || total.Closed != 3 || total.WIP != 1 || total.Queued != 3 {
+		t.Errorf("total = %+v, want created 5, closed 3, wip 1, queued 3", total)
+	}
+	if total.MedianClose != 4*time.Hour {
+		t.Errorf("median close = %s, want 4h", total.MedianClose)
+	}
+	if total.QueueP50 != 2*time.Hour || total.QueueP90 != 5*time.Hour || total.QueueMax != 5*time.Hour {
+		t.Errorf("queue ages = %s/%s/%s, want 2h/5h/5h", total.QueueP50, total.QueueP90, total.QueueMax)
+	}
+
+	_, rows = summarizeBeadStats(bs, "rig", rigOf, since, now)
+	if len(rows) != 2 || rows[0].Key != "api" || rows[1].Key != "city" {
+		t.Fatalf("rig rows = %+v", rows)
+	}
+	if api := rows[0]; api.Created != 4 || api.Closed != 2 || api.WIP != 1 || api.Queued != 1 || api.MedianClose != 2*time.Hour {
+		t.Errorf("api = %+v", api)
+	}
+
+	_, rows = summarizeBeadStats(bs, "agent", rigOf, since, now)
+	keys := make([]string, len(rows))
+	for i, r := range rows {
+		keys[i] = r.Key
+	}
+	if got := strings.Join(keys, ","); got != "(none),api/worker,mayor" {
+		t.Errorf("agent keys = %s", got)
+	}
+}
+
+func TestDoStatsByDay(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	bs := []beads.Bead{
+		{ID: "gc-1", Status: "open", CreatedAt: now.Add(-26 * time.Hour)},
+		{ID: "gc-2", Status: "in_progress", CreatedAt: now.Add(-time.Hour)},
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doStats(bs, "day", nil, now.Add(-48*time.Hour), now, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doStats = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"DAY", "2026-03-09", "2026-03-10", "TOTAL"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -113,7 +113,7 @@ func acquireSupervisorLock() (*os.File, error) {
 	if err != nil {
 		return nil, fmt.Errorf("opening supervisor lock: %w", err)
 	}
-	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+	if err := fsys.TryLock(f); err != nil {
 		f.Close() //nolint:errcheck
 		return nil, fmt.Errorf("supervisor already running")
 	}
@@ -701,17 +701,21 @@ func reconcileCities(
 		}
 
 		sp, spErr := newSessionProviderByName(
-			effectiveProviderName(cfg.Session.Provider), cfg.Session, cityName)
+			effectiveProviderName(cfg.Session.Provider), cfg.Session, cityName, path)
 		if spErr != nil {
 			recordInitFailure(cityName, fmt.Sprintf("session provider: %v", spErr))
 			continue
 		}
+		sp = withLifecycleHooks(sp, cfg.Hooks, path, cityName, stderr)
 
 		// Fail-fast image pre-check for container providers (same as doStart).
 		if err := checkAgentImages(sp, cfg.Agents, stderr); err != nil {
 			recordInitFailure(cityName, err.Error())
 			continue
 		}
+		sp = withSnapshots(sp, path, cfg.Session)
+		sp = withUsageTracking(sp, path, cfg.Daemon)
+		sp = withRateLimit(sp, cfg.RateLimit, cfg.Agents, stderr)
 
 		rec := events.Discard
 		var eventProv events.Provider
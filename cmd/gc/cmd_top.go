@@ -0,0 +1,535 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/session"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultTopInterval is the refresh interval for gc top.
+const defaultTopInterval = 2 * time.Second
+
+func newTopCmd(stdout, stderr io.Writer) *cobra.Command {
+	var interval time.Duration
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Interactive terminal dashboard for the city",
+		Long: `Show agents, pools, sessions, and the ready bead queue in a live
+terminal view, refreshed every --interval.
+
+Keys:
+  tab        switch focus between the agent list and the bead queue
+  up/k down/j  move the selection in the focused list
+  enter      toggle the detail pane for the selected bead
+  s          sling the selected bead to the selected agent or pool
+  n          nudge the selected agent (prompts for a message)
+  a          attach to the selected agent's session (leaves gc top)
+  r          refresh now
+  q          quit
+
+Requires an interactive terminal; use "gc status" or "gc bead ready
+--watch" when output is piped.`,
+		Example: `  gc top
+  gc top --interval=5s`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdTop(interval, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&interval, "interval", defaultTopInterval, "refresh interval")
+	return cmd
+}
+
+// cmdTop is the CLI entry point for the interactive dashboard.
+func cmdTop(interval time.Duration, stdout, stderr io.Writer) int {
+	if interval <= 0 {
+		fmt.Fprintf(stderr, "gc top: invalid --interval %s: must be > 0\n", interval) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	out, ok := stdout.(*os.File)
+	if !ok || !term.IsTerminal(int(out.Fd())) || !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprintln(stderr, `gc top: requires an interactive terminal (try "gc status")`) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc top: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc top: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc top: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	sp := newSessionProvider()
+
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc top: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	restore := func() {
+		fmt.Fprint(out, "\033[?25h\033[H\033[2J")  //nolint:errcheck // best-effort stdout
+		term.Restore(int(os.Stdin.Fd()), oldState) //nolint:errcheck // best-effort terminal restore
+	}
+	defer restore()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	keys := make(chan string)
+	go readTopKeys(os.Stdin, keys)
+
+	gather := func() topSnapshot {
+		return gatherTop(sp, newDrainOps(sp), cfg, cityPath, store, time.Now())
+	}
+	m := &topModel{snap: gather()}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		width, height, err := term.GetSize(int(out.Fd()))
+		if err != nil {
+			width, height = 100, 40
+		}
+		fmt.Fprint(out, "\033[?25l\033[H\033[2J"+strings.ReplaceAll(renderTop(m, interval, width, height), "\n", "\r\n")) //nolint:errcheck // best-effort stdout
+
+		var act topAction
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+			m.snap = gather()
+			continue
+		case k, ok := <-keys:
+			if !ok {
+				return 0
+			}
+			act = m.handleKey(k)
+		}
+		switch act.kind {
+		case topQuit:
+			return 0
+		case topRefresh:
+			m.snap = gather()
+		case topSling:
+			m.message = runTopGC("sling", act.agent, act.bead)
+			m.snap = gather()
+		case topNudge:
+			m.message = runTopGC("session", "nudge", "--delivery", string(nudgeDeliveryImmediate), act.agent, act.text)
+		case topAttach:
+			restore()
+			stop()
+			if err := sp.Attach(act.session); err != nil {
+				fmt.Fprintf(stderr, "gc top: attaching to %s: %v\n", act.agent, err) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+			return 0
+		}
+	}
+}
+
+// readTopKeys forwards key presses from r to keys, one escape sequence or
+// byte at a time, and closes keys when r fails.
+func readTopKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, k := range splitTopKeys(buf[:n]) {
+			keys <- k
+		}
+	}
+}
+
+// splitTopKeys splits raw terminal input into keys, keeping CSI
+// sequences such as the arrow keys ("\x1b[A") together.
+func splitTopKeys(b []byte) []string {
+	var keys []string
+	for len(b) > 0 {
+		n := 1
+		if b[0] == 0x1b && len(b) >= 3 && b[1] == '[' {
+			n = 3
+		}
+		keys = append(keys, string(b[:n]))
+		b = b[n:]
+	}
+	return keys
+}
+
+// runTopGC runs a gc subcommand on behalf of the dashboard and returns a
+// one-line summary of its output for the status line.
+func runTopGC(args ...string) string {
+	gcPath, err := os.Executable()
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	out, err := exec.Command(gcPath, args...).CombinedOutput()
+	msg := strings.TrimSpace(string(out))
+	if i := strings.LastIndexByte(msg, '\n'); i >= 0 {
+		msg = msg[i+1:]
+	}
+	if err != nil && msg == "" {
+		msg = "gc " + args[0] + ": " + err.Error()
+	}
+	return msg
+}
+
+// topAgent is one row of the agent list: a singleton agent, a pool, or a
+// pool instance (indented under its pool).
+type topAgent struct {
+	Name     string
+	Session  string // empty for pool rows
+	Status   string
+	Pool     bool
+	Instance bool
+	Running  bool
+}
+
+// topSnapshot is everything gc top draws, gathered in one pass.
+type topSnapshot struct {
+	City       string
+	Taken      time.Time
+	Controller int
+	Agents     []topAgent
+	Sessions   []session.Info
+	Queue      []beads.Bead
+	Err        error
+}
+
+// gatherTop collects a dashboard snapshot. Store errors are recorded on
+// the snapshot rather than returned so the view keeps refreshing.
+func gatherTop(sp runtime.Provider, dops drainOps, cfg *config.City, cityPath string, store beads.Store, now time.Time) topSnapshot {
+	cityName := cfg.Workspace.Name
+	if cityName == "" {
+		cityName = filepath.Base(cityPath)
+	}
+	snap := topSnapshot{City: cityName, Taken: now, Controller: controllerAlive(cityPath)}
+
+	suspendedRigs := make(map[string]bool)
+	for _, r := range cfg.Rigs {
+		if r.Suspended {
+			suspendedRigs[r.Name] = true
+		}
+	}
+	work := countAssigneeWork(store)
+	for _, a := range cfg.Agents {
+		suspended := a.Suspended || (a.Dir != "" && suspendedRigs[a.Dir])
+		pool := a.EffectivePool()
+		if pool.IsMultiInstance() {
+			maxDisplay := fmt.Sprintf("max=%d", pool.Max)
+			if pool.IsUnlimited() {
+				maxDisplay = "max=unlimited"
+			}
+			snap.Agents = append(snap.Agents, topAgent{
+				Name:   a.QualifiedName(),
+				Status: fmt.Sprintf("pool (min=%d, %s)", pool.Min, maxDisplay),
+				Pool:   true,
+			})
+			for _, qi := range discoverPoolInstances(a.Name, a.Dir, pool, cityName, cfg.Workspace.SessionTemplate, sp) {
+				sn := cliSessionName(cityPath, cityName, qi, cfg.Workspace.SessionTemplate)
+				snap.Agents = append(snap.Agents, topAgent{
+					Name:     qi,
+					Session:  sn,
+					Status:   agentStatusLine(sp, dops, sn, suspended) + work[qi].annotation(),
+					Instance: true,
+					Running:  sp.IsRunning(sn),
+				})
+			}
+			continue
+		}
+		sn := cliSessionName(cityPath, cityName, a.QualifiedName(), cfg.Workspace.SessionTemplate)
+		snap.Agents = append(snap.Agents, topAgent{
+			Name:    a.QualifiedName(),
+			Session: sn,
+			Status:  agentStatusLine(sp, dops, sn, suspended) + work[a.QualifiedName()].annotation(),
+			Running: sp.IsRunning(sn),
+		})
+	}
+
+	if sessions, err := newSessionManagerWithConfig(store, sp, cfg).List("", ""); err == nil {
+		for _, s := range sessions {
+			if s.State != "" {
+				snap.Sessions = append(snap.Sessions, s)
+			}
+		}
+	}
+	snap.Queue, snap.Err = store.Ready()
+	return snap
+}
+
+// topPane identifies the list that owns the selection.
+type topPane int
+
+const (
+	topPaneAgents topPane = iota
+	topPaneQueue
+)
+
+// topActionKind is what the event loop should do after a key press.
+type topActionKind int
+
+const (
+	topNone topActionKind = iota
+	topQuit
+	topRefresh
+	topSling
+	topNudge
+	topAttach
+)
+
+// topAction is a request from the model to the event loop.
+type topAction struct {
+	kind    topActionKind
+	agent   string
+	session string
+	bead    string
+	text    string
+}
+
+// topModel is the dashboard's interactive state. It is kept free of I/O
+// so key handling and rendering can be tested directly.
+type topModel struct {
+	snap     topSnapshot
+	focus    topPane
+	agentIdx int
+	beadIdx  int
+	detail   bool
+	// prompting is set while a nudge message is being typed; input
+	// collects the message so far.
+	prompting bool
+	input     string
+	message   string
+}
+
+// selectedAgent returns the highlighted agent row, if any.
+func (m *topModel) selectedAgent() (topAgent, bool) {
+	if m.agentIdx < 0 || m.agentIdx >= len(m.snap.Agents) {
+		return topAgent{}, false
+	}
+	return m.snap.Agents[m.agentIdx], true
+}
+
+// selectedBead returns the highlighted queue bead, if any.
+func (m *topModel) selectedBead() (beads.Bead, bool) {
+	if m.beadIdx < 0 || m.beadIdx >= len(m.snap.Queue) {
+		return beads.Bead{}, false
+	}
+	return m.snap.Queue[m.beadIdx], true
+}
+
+// handleKey applies one key press and returns the action for the event
+// loop to perform.
+func (m *topModel) handleKey(k string) topAction {
+	if m.prompting {
+		return m.handlePromptKey(k)
+	}
+	m.message = ""
+	switch k {
+	case "q", "\x03":
+		return topAction{kind: topQuit}
+	case "r":
+		return topAction{kind: topRefresh}
+	case "\t":
+		if m.focus == topPaneAgents {
+			m.focus = topPaneQueue
+		} else {
+			m.focus = topPaneAgents
+		}
+	case "j", "\x1b[B":
+		m.move(1)
+	case "k", "\x1b[A":
+		m.move(-1)
+	case "\r", "\n":
+		m.detail = !m.detail
+	case "s":
+		a, okA := m.selectedAgent()
+		b, okB := m.selectedBead()
+		if !okA || !okB {
+			m.message = "select an agent and a bead to sling"
+			return topAction{}
+		}
+		return topAction{kind: topSling, agent: a.Name, bead: b.ID}
+	case "n":
+		a, ok := m.selectedAgent()
+		if !ok || a.Pool {
+			m.message = "select an agent to nudge"
+			return topAction{}
+		}
+		m.prompting, m.input = true, ""
+	case "a":
+		a, ok := m.selectedAgent()
+		if !ok || a.Pool {
+			m.message = "select an agent to attach"
+			return topAction{}
+		}
+		if !a.Running {
+			m.message = a.Name + " is not running"
+			return topAction{}
+		}
+		return topAction{kind: topAttach, agent: a.Name, session: a.Session}
+	}
+	return topAction{}
+}
+
+// handlePromptKey edits the nudge message. Enter sends it; Esc cancels.
+func (m *topModel) handlePromptKey(k string) topAction {
+	switch k {
+	case "\x1b", "\x03":
+		m.prompting, m.input = false, ""
+	case "\r", "\n":
+		m.prompting = false
+		text := strings.TrimSpace(m.input)
+		m.input = ""
+		a, ok := m.selectedAgent()
+		if !ok || text == "" {
+			return topAction{}
+		}
+		return topAction{kind: topNudge, agent: a.Name, text: text}
+	case "\x7f", "\b":
+		if r := []rune(m.input); len(r) > 0 {
+			m.input = string(r[:len(r)-1])
+		}
+	default:
+		if len(k) == 1 && k[0] >= ' ' {
+			m.input += k
+		}
+	}
+	return topAction{}
+}
+
+// move shifts the selection in the focused list by delta, clamped to
+// the list bounds.
+func (m *topModel) move(delta int) {
+	idx, n := &m.agentIdx, len(m.snap.Agents)
+	if m.focus == topPaneQueue {
+		idx, n = &m.beadIdx, len(m.snap.Queue)
+	}
+	*idx = max(0, min(*idx+delta, n-1))
+}
+
+// renderTop draws the dashboard as newline-separated text no wider than
+// width and no taller than height.
+func renderTop(m *topModel, interval time.Duration, width, height int) string {
+	// Keep the selection valid as the lists change between refreshes.
+	m.agentIdx = max(0, min(m.agentIdx, len(m.snap.Agents)-1))
+	m.beadIdx = max(0, min(m.beadIdx, len(m.snap.Queue)-1))
+
+	var lines []string
+	add := func(format string, args ...any) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	marker := func(pane topPane, selected bool) string {
+		if selected && m.focus == pane {
+			return ">"
+		}
+		return " "
+	}
+
+	ctrl := "stopped"
+	if m.snap.Controller != 0 {
+		ctrl = fmt.Sprintf("running (PID %d)", m.snap.Controller)
+	}
+	add("gc top — %s   %s   every %s   controller: %s", m.snap.City, m.snap.Taken.Format(time.TimeOnly), interval, ctrl)
+	add("")
+
+	running, total := 0, 0
+	for _, a := range m.snap.Agents {
+		if !a.Pool {
+			total++
+			if a.Running {
+				running++
+			}
+		}
+	}
+	add("AGENTS (%d/%d running)", running, total)
+	for i, a := range m.snap.Agents {
+		name := a.Name
+		if a.Instance {
+			name = "  " + name
+		}
+		add("%s %-26s%s", marker(topPaneAgents, i == m.agentIdx), name, a.Status)
+	}
+	if len(m.snap.Agents) == 0 {
+		add("  (no agents configured)")
+	}
+	add("")
+
+	add("SESSIONS (%d)", len(m.snap.Sessions))
+	for _, s := range m.snap.Sessions {
+		title := s.Title
+		if title == "" {
+			title = "-"
+		}
+		add("  %-12s %-20s %-10s %s", s.ID, s.Template, s.State, title)
+	}
+	add("")
+
+	if m.snap.Err != nil {
+		add("QUEUE: %v", m.snap.Err)
+	} else {
+		add("QUEUE (%d ready)", len(m.snap.Queue))
+	}
+	for i, b := range m.snap.Queue {
+		assignee := b.Assignee
+		if assignee == "" {
+			assignee = "—"
+		}
+		add("%s %-12s %-3s %-20s %s", marker(topPaneQueue, i == m.beadIdx), b.ID, beads.FormatPriority(b.PriorityOrDefault()), assignee, b.Title)
+	}
+
+	if b, ok := m.selectedBead(); ok && m.detail {
+		add("")
+		add("%s", strings.Repeat("─", min(width, 40)))
+		var buf bytes.Buffer
+		writeBeadDetail(b, &buf)
+		for _, l := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+			add("%s", l)
+		}
+	}
+
+	footer := []string{""}
+	switch {
+	case m.prompting:
+		a, _ := m.selectedAgent()
+		footer = append(footer, fmt.Sprintf("nudge %s: %s_", a.Name, m.input))
+	case m.message != "":
+		footer = append(footer, m.message)
+	default:
+		footer = append(footer, "")
+	}
+	footer = append(footer, "tab focus  ↑/↓ move  enter detail  s sling  n nudge  a attach  r refresh  q quit")
+
+	if room := height - len(footer); room >= 0 && len(lines) > room {
+		lines = lines[:room]
+	}
+	lines = append(lines, footer...)
+	for i, l := range lines {
+		if r := []rune(l); width > 0 && len(r) > width {
+			lines[i] = string(r[:width])
+		}
+	}
+	return strings.Join(lines, "\n")
+}
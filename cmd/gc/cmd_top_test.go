@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestGatherTopAndRender(t *testing.T) {
+	sp := runtime.NewFake()
+	if err := sp.Start(context.Background(), "mayor", runtime.Config{Command: "echo"}); err != nil {
+		t.Fatal(err)
+	}
+	store := beads.NewMemStore()
+	p1 := 1
+	store.Create(beads.Bead{Title: "Low priority"})                          //nolint:errcheck
+	urgent, _ := store.Create(beads.Bead{Title: "Fix login", Priority: &p1}) //nolint:errcheck
+	cfg := &config.City{
+		Workspace: config.Workspace{Name: "metro"},
+		Agents:    []config.Agent{{Name: "mayor"}, {Name: "worker"}},
+	}
+
+	snap := gatherTop(sp, newFakeDrainOps(), cfg, t.TempDir(), store, time.Now())
+	if len(snap.Agents) != 2 || !snap.Agents[0].Running || snap.Agents[1].Running {
+		t.Fatalf("agents = %+v", snap.Agents)
+	}
+	if len(snap.Queue) != 2 || snap.Queue[0].ID != urgent.ID {
+		t.Fatalf("queue = %+v, want %s first", snap.Queue, urgent.ID)
+	}
+
+	m := &topModel{snap: snap}
+	out := renderTop(m, time.Second, 120, 40)
+	for _, want := range []string{"gc top — metro", "AGENTS (1/2 running)", "> mayor", "QUEUE (2 ready)", "P1", "Fix login"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Priority:") {
+		t.Errorf("detail pane shown before enter:\n%s", out)
+	}
+
+	m.handleKey("\t")
+	m.handleKey("\r")
+	out = renderTop(m, time.Second, 120, 40)
+	if !strings.Contains(out, "> "+urgent.ID) || !strings.Contains(out, "Priority: P1") {
+		t.Errorf("queue selection or detail pane missing:\n%s", out)
+	}
+
+	// Output is clipped to the terminal size, keeping the key help.
+	out = renderTop(m, time.Second, 30, 6)
+	lines := strings.Split(out, "\n")
+	if len(lines) != 6 || !strings.HasPrefix(lines[5], "tab focus") {
+		t.Errorf("clipped render = %q", lines)
+	}
+	for _, l := range lines {
+		if len([]rune(l)) > 30 {
+			t.Errorf("line wider than 30: %q", l)
+		}
+	}
+}
+
+func TestGatherTopSeesOtherWriters(t *testing.T) {
+	city := t.TempDir()
+	t.Setenv("GC_BEADS", "file")
+	store, err := openCityStoreAt(city)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := openCityStoreAt(city)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.City{Workspace: config.Workspace{Name: "metro"}}
+	sp := runtime.NewFake()
+
+	if snap := gatherTop(sp, newFakeDrainOps(), cfg, city, store, time.Now()); len(snap.Queue) != 0 {
+		t.Fatalf("queue = %+v, want empty", snap.Queue)
+	}
+	b, err := other.Create(beads.Bead{Title: "slung elsewhere"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap := gatherTop(sp, newFakeDrainOps(), cfg, city, store, time.Now())
+	if len(snap.Queue) != 1 || snap.Queue[0].ID != b.ID {
+		t.Errorf("queue after another process's write = %+v, want [%s]", snap.Queue, b.ID)
+	}
+}
+
+func TestTopModelKeys(t *testing.T) {
+	m := &topModel{snap: topSnapshot{
+		Agents: []topAgent{
+			{Name: "mayor", Session: "metro-mayor", Running: true},
+			{Name: "polecat", Pool: true},
+			{Name: "polecat-1", Session: "metro-polecat-1", Instance: true},
+		},
+		Queue: []beads.Bead{{ID: "gc-1"}, {ID: "gc-2"}},
+	}}
+
+	if got := m.handleKey("a"); got != (topAction{kind: topAttach, agent: "mayor", session: "metro-mayor"}) {
+		t.Errorf("attach = %+v", got)
+	}
+
+	// Selection is clamped to the list.
+	for range 5 {
+		m.handleKey("j")
+	}
+	if m.agentIdx != 2 {
+		t.Errorf("agentIdx = %d, want 2", m.agentIdx)
+	}
+	if got := m.handleKey("a"); got.kind != topNone || m.message != "polecat-1 is not running" {
+		t.Errorf("attach stopped agent = %+v, message %q", got, m.message)
+	}
+
+	// Sling the second bead to the pool.
+	m.handleKey("\x1b[A")
+	m.handleKey("\t")
+	m.handleKey("\x1b[B")
+	if got := m.handleKey("s"); got != (topAction{kind: topSling, agent: "polecat", bead: "gc-2"}) {
+		t.Errorf("sling = %+v", got)
+	}
+	if got := m.handleKey("n"); got.kind != topNone || m.prompting {
+		t.Errorf("nudging a pool should be refused, got %+v", got)
+	}
+
+	// Nudge prompt: typed text, backspace, enter.
+	m.focus = topPaneAgents
+	m.agentIdx = 0
+	m.handleKey("n")
+	for _, k := range splitTopKeys([]byte("hi therex\x7f")) {
+		m.handleKey(k)
+	}
+	if !m.prompting || m.input != "hi there" {
+		t.Fatalf("prompting = %v, input = %q", m.prompting, m.input)
+	}
+	if got := m.handleKey("\r"); got != (topAction{kind: topNudge, agent: "mayor", text: "hi there"}) {
+		t.Errorf("nudge = %+v", got)
+	}
+	m.handleKey("n")
+	m.handleKey("x")
+	if got := m.handleKey("\x1b"); got.kind != topNone || m.prompting {
+		t.Errorf("esc should cancel the prompt, got %+v", got)
+	}
+
+	if got := m.handleKey("q"); got.kind != topQuit {
+		t.Errorf("q = %+v", got)
+	}
+}
+
+func TestSplitTopKeys(t *testing.T) {
+	got := splitTopKeys([]byte("j\x1b[Bq\x1b"))
+	want := []string{"j", "\x1b[B", "q", "\x1b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitTopKeys = %q, want %q", got, want)
+	}
+}
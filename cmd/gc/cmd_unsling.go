@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+)
+
+func newUnslingCmd(stdout, stderr io.Writer) *cobra.Command {
+	var burn bool
+	cmd := &cobra.Command{
+		Use:   "unsling <bead-id>",
+		Short: "Reverse a sling by clearing the bead's routing",
+		Long: `Reverse a sling by clearing the assignee and pool labels that the
+default sling queries apply.
+
+With --burn, any open wisp attached to the bead (via sling --on or a
+default formula) is closed and the bead's molecule_id is cleared, so the
+bead can be slung again with a fresh formula.
+
+The bead's status is left unchanged. A warning is printed when the bead
+is already in progress, since its agent may still be working on it.`,
+		Example: `  gc unsling BL-42
+  gc unsling BL-42 --burn`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if cmdUnsling(args[0], burn, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&burn, "burn", false, "also close the attached wisp")
+	return cmd
+}
+
+// cmdUnsling is the CLI entry point for gc unsling. Like gc sling, it
+// operates on the bd database of the rig that owns the bead.
+func cmdUnsling(beadID string, burn bool, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc unsling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, _, err := config.LoadWithIncludes(fsys.OSFS{}, filepath.Join(cityPath, "city.toml"))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc unsling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openRigStoreAt(cityPath, rigDirForBead(cfg, beadID))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc unsling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return doUnsling(store, beadID, burn, stdout, stderr)
+}
+
+// doUnsling clears the assignee and pool labels on a bead and, when burn
+// is set, closes its open attached molecules.
+func doUnsling(store beads.Store, beadID string, burn bool, stdout, stderr io.Writer) int {
+	var undone []string
+	b, err := updateBead(store, beadID, func(b beads.Bead) (*beads.UpdateOpts, error) {
+		undone = nil
+		var opts beads.UpdateOpts
+		if b.Assignee != "" {
+			empty := ""
+			opts.Assignee = &empty
+			undone = append(undone, fmt.Sprintf("assignee %q", b.Assignee))
+		}
+		for _, l := range b.Labels {
+			if strings.HasPrefix(l, "pool:") {
+				opts.RemoveLabels = append(opts.RemoveLabels, l)
+				undone = append(undone, fmt.Sprintf("label %q", l))
+			}
+		}
+		if len(undone) == 0 {
+			return nil, nil
+		}
+		return &opts, nil
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "gc unsling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if b.Status == "in_progress" {
+		fmt.Fprintf(stderr, "warning: bead %s is in progress — its agent may still be working on it\n", beadID) //nolint:errcheck // best-effort stderr
+	}
+
+	if burn {
+		burned, err := burnAttachedMolecules(store, beadID)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc unsling: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		for _, id := range burned {
+			undone = append(undone, "wisp "+id)
+		}
+	}
+
+	if len(undone) == 0 {
+		fmt.Fprintf(stdout, "Bead %s is not routed — nothing to undo\n", beadID) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	fmt.Fprintf(stdout, "Unslung %s (removed %s)\n", beadID, strings.Join(undone, ", ")) //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// burnAttachedMolecules closes the open molecule children of beadID and
+// clears its molecule_id metadata. Returns the IDs of the closed molecules.
+func burnAttachedMolecules(store beads.Store, beadID string) ([]string, error) {
+	children, err := store.Children(beadID)
+	if err != nil {
+		return nil, fmt.Errorf("listing children of %s: %w", beadID, err)
+	}
+	var burned []string
+	for _, c := range children {
+		if !beads.IsMoleculeType(c.Type) || c.Status == "closed" {
+			continue
+		}
+		if err := store.Close(c.ID); err != nil {
+			return burned, fmt.Errorf("burning %s %s: %w", c.Type, c.ID, err)
+		}
+		burned = append(burned, c.ID)
+	}
+	if len(burned) > 0 {
+		if err := store.SetMetadata(beadID, "molecule_id", ""); err != nil {
+			return burned, fmt.Errorf("clearing molecule_id on %s: %w", beadID, err)
+		}
+	}
+	return burned, nil
+}
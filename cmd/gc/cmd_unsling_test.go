@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestDoUnslingClearsRouting(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "work", Assignee: "mayor", Labels: []string{"pool:polecat", "urgent"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doUnsling(store, b.ID, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doUnsling = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	got, err := store.Get(b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Assignee != "" {
+		t.Errorf("assignee = %q, want empty", got.Assignee)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "urgent" {
+		t.Errorf("labels = %v, want [urgent]", got.Labels)
+	}
+	if !strings.Contains(stdout.String(), `assignee "mayor"`) || !strings.Contains(stdout.String(), `label "pool:polecat"`) {
+		t.Errorf("stdout = %q, want removed assignee and label", stdout.String())
+	}
+}
+
+func TestDoUnslingBurnsWisp(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "work", Assignee: "mayor"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wisp, err := store.MolCookOn("review", b.ID, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetMetadata(b.ID, "molecule_id", wisp); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doUnsling(store, b.ID, true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doUnsling = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	w, err := store.Get(wisp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Status != "closed" {
+		t.Errorf("wisp status = %q, want closed", w.Status)
+	}
+	got, _ := store.Get(b.ID)
+	if got.Metadata["molecule_id"] != "" {
+		t.Errorf("molecule_id = %q, want cleared", got.Metadata["molecule_id"])
+	}
+	if !strings.Contains(stdout.String(), "wisp "+wisp) {
+		t.Errorf("stdout = %q, want burned wisp", stdout.String())
+	}
+}
+
+func TestDoUnslingNotRouted(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doUnsling(store, b.ID, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doUnsling = %d, want 0", code)
+	}
+	if !strings.Contains(stdout.String(), "nothing to undo") {
+		t.Errorf("stdout = %q, want nothing-to-undo message", stdout.String())
+	}
+}
+
+func TestDoUnslingInProgressWarns(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "work", Assignee: "mayor"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := "in_progress"
+	if err := store.Update(b.ID, beads.UpdateOpts{Status: &status}); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := doUnsling(store, b.ID, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doUnsling = %d, want 0", code)
+	}
+	if !strings.Contains(stderr.String(), "in progress") {
+		t.Errorf("stderr = %q, want in-progress warning", stderr.String())
+	}
+}
+
+func TestDoUnslingMissingBead(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := doUnsling(beads.NewMemStore(), "gc-404", false, &stdout, &stderr); code != 1 {
+		t.Fatalf("doUnsling = %d, want 1", code)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/sessionlog"
+	"github.com/gastownhall/gascity/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+// usageLogPath returns the append-only usage log for a city.
+func usageLogPath(cityPath string) string {
+	return citylayout.RuntimePath(cityPath, "metrics", "usage.jsonl")
+}
+
+// withUsageTracking wraps sp so session starts, stops, and nudges are
+// recorded to the city's usage log, with token usage read from the
+// agents' session logs on stop. Returns sp unchanged when the city path
+// is unknown.
+func withUsageTracking(sp runtime.Provider, cityPath string, daemon config.DaemonConfig) runtime.Provider {
+	if cityPath == "" {
+		return sp
+	}
+	searchPaths := sessionlog.MergeSearchPaths(daemon.ObservePaths)
+	return usage.WrapProvider(sp, usageLogPath(cityPath), func(workDir string, since time.Time) sessionlog.TokenUsage {
+		return sessionlog.SumTokenUsage(searchPaths, workDir, since)
+	})
+}
+
+func newUsageCmd(stdout, stderr io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Report agent runtime, nudges, closed beads, and tokens",
+		Long: `Report what agents spend.
+
+gc records every session start, stop, and nudge in
+.gc/metrics/usage.jsonl, with the tokens a session used (read from its
+Claude session logs) when it stops. Closed beads come from the bead
+store.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				fmt.Fprintln(stderr, "gc usage: missing subcommand (report)") //nolint:errcheck // best-effort stderr
+			} else {
+				fmt.Fprintf(stderr, "gc usage: unknown subcommand %q\n", args[0]) //nolint:errcheck // best-effort stderr
+			}
+			return errExit
+		},
+	}
+	cmd.AddCommand(newUsageReportCmd(stdout, stderr))
+	return cmd
+}
+
+func newUsageReportCmd(stdout, stderr io.Writer) *cobra.Command {
+	var by, since string
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize usage by agent, rig, or day",
+		Long: `Summarize recorded usage by agent (the default), rig, or day (UTC).
+
+SESSIONS and RUNTIME count session starts and time until stop; a session
+still running counts until now, and one that crashed counts until it was
+restarted. CLOSED counts beads closed while assigned to the agent's
+sessions. Token columns cover sessions that have stopped: uncached input
+and cache writes, cache reads, and output.`,
+		Example: `  gc usage report
+  gc usage report --by rig
+  gc usage report --by day --since 7d`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdUsageReport(by, since, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&by, "by", usage.ByAgent, "group by agent, rig, or day")
+	cmd.Flags().StringVar(&since, "since", "", "only usage within this long ago (e.g., 7d, 12h)")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// cmdUsageReport is the CLI entry point for gc usage report.
+func cmdUsageReport(by, sinceFlag string, jsonOutput bool, stdout, stderr io.Writer) int {
+	var since time.Time
+	if sinceFlag != "" {
+		age, err := parsePruneDuration(sinceFlag)
+		if err != nil {
+			fmt.Fprintf(stderr, "gc usage report: --since: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		since = time.Now().Add(-age)
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc usage report: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	evs, err := usage.ReadEvents(usageLogPath(cityPath))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc usage report: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	var closed []beads.Bead
+	if store, err := openCityStoreAt(cityPath); err != nil {
+		fmt.Fprintf(stderr, "gc usage report: beads closed unavailable: %v\n", err) //nolint:errcheck // best-effort stderr
+	} else if closed, err = store.List(); err != nil {
+		fmt.Fprintf(stderr, "gc usage report: beads closed unavailable: %v\n", err) //nolint:errcheck // best-effort stderr
+	}
+	return doUsageReport(evs, closed, by, since, time.Now(), jsonOutput, stdout, stderr)
+}
+
+// doUsageReport prints the usage summary for evs and closed.
+func doUsageReport(evs []usage.Event, closed []beads.Bead, by string, since, now time.Time, jsonOutput bool, stdout, stderr io.Writer) int {
+	rows, err := usage.Summarize(evs, closed, by, since, now)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc usage report: --by: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if jsonOutput {
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc usage report: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(stdout, "No usage recorded") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tSESSIONS\tRUNTIME\tNUDGES\tCLOSED\tTOKENS IN\tCACHED\tTOKENS OUT\n", usageKeyHeader(by)) //nolint:errcheck // best-effort stdout
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%d\t%d\t%d\t%d\n", //nolint:errcheck // best-effort stdout
+			r.Key, r.Sessions, r.Runtime.Round(time.Second), r.Nudges, r.BeadsClosed, r.InputTokens, r.CacheReadTokens, r.OutputTokens)
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+// usageKeyHeader is the report's first column header for a grouping.
+func usageKeyHeader(by string) string {
+	switch by {
+	case usage.ByRig:
+		return "RIG"
+	case usage.ByDay:
+		return "DAY"
+	}
+	return "AGENT"
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/usage"
+)
+
+func TestDoUsageReport(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	closedAt := start.Add(time.Hour)
+	evs := []usage.Event{
+		{Time: start, Type: usage.EventStart, Session: "s-1", Agent: "mayor"},
+		{Time: start.Add(90 * time.Minute), Type: usage.EventStop, Session: "s-1", Agent: "mayor", InputTokens: 1200, OutputTokens: 300},
+	}
+	closed := []beads.Bead{{ID: "b-1", Status: "closed", Assignee: "s-1", ClosedAt: &closedAt}}
+
+	var stdout, stderr bytes.Buffer
+	if code := doUsageReport(evs, closed, "agent", time.Time{}, start.Add(2*time.Hour), false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doUsageReport = %d; stderr: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "AGENT") {
+		t.Fatalf("stdout = %q", stdout.String())
+	}
+	if f := strings.Fields(lines[1]); strings.Join(f, " ") != "mayor 1 1h30m0s 0 1 1200 0 300" {
+		t.Errorf("row = %q", lines[1])
+	}
+
+	stdout.Reset()
+	if code := doUsageReport(nil, nil, "agent", time.Time{}, start, false, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "No usage recorded") {
+		t.Errorf("empty report = %d, %q", code, stdout.String())
+	}
+	if code := doUsageReport(nil, nil, "week", time.Time{}, start, false, &stdout, &stderr); code != 1 {
+		t.Errorf("--by week = %d, want 1", code)
+	}
+}
+
+func TestWithUsageTracking(t *testing.T) {
+	fake := runtime.NewFake()
+	if sp := withUsageTracking(fake, "", config.DaemonConfig{}); sp != runtime.Provider(fake) {
+		t.Errorf("withUsageTracking without a city = %T, want the provider unchanged", sp)
+	}
+	if _, ok := withUsageTracking(fake, t.TempDir(), config.DaemonConfig{}).(*usage.Provider); !ok {
+		t.Error("withUsageTracking did not wrap the provider")
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/session"
+	"github.com/spf13/cobra"
+)
+
+//go:embed web/board.html
+var webBoardHTML string
+
+var webBoardTmpl = template.Must(template.New("board").Funcs(template.FuncMap{
+	"priority": func(b beads.Bead) string { return beads.FormatPriority(b.PriorityOrDefault()) },
+	"clock":    func(t time.Time) string { return t.Local().Format("Jan 2 15:04:05") },
+}).Parse(webBoardHTML))
+
+// Defaults for gc web.
+const (
+	defaultWebPort        = 8080
+	defaultWebRefresh     = 10 * time.Second
+	webClosedColumnLimit  = 20
+	webRecentEventsLimit  = 25
+	webReadHeaderDeadline = 10 * time.Second
+)
+
+func newWebCmd(stdout, stderr io.Writer) *cobra.Command {
+	var port int
+	var bind string
+	var refresh time.Duration
+	cmd := &cobra.Command{
+		Use:   "web",
+		Short: "Serve a read-only web dashboard for the city",
+		Long: `Serve a small read-only web dashboard: a beads board with open,
+in-progress, and recently closed columns, agent status, and recent events.
+
+Unlike "gc dashboard serve", gc web reads the bead store, session
+provider, and event log directly, so it works without a running
+controller. The page reloads every --refresh; the same data is served as
+JSON at /api/board. Binds to 127.0.0.1 unless --bind says otherwise.`,
+		Example: `  gc web
+  gc web --port 9000 --bind 0.0.0.0`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if cmdWeb(bind, port, refresh, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&port, "port", defaultWebPort, "HTTP port")
+	cmd.Flags().StringVar(&bind, "bind", "127.0.0.1", "address to listen on")
+	cmd.Flags().DurationVar(&refresh, "refresh", defaultWebRefresh, "page reload interval")
+	return cmd
+}
+
+// cmdWeb is the CLI entry point for the web dashboard.
+func cmdWeb(bind string, port int, refresh time.Duration, stdout, stderr io.Writer) int {
+	if refresh <= 0 {
+		fmt.Fprintf(stderr, "gc web: invalid --refresh %s: must be > 0\n", refresh) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc web: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, err := loadCityConfig(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc web: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	store, err := openCityStoreAt(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc web: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	ep, code := openCityEventsProvider(stderr, "gc web")
+	if ep == nil {
+		return code
+	}
+	defer ep.Close() //nolint:errcheck // best-effort
+
+	sp := newSessionProvider()
+	srv := &webServer{
+		sp: sp, dops: newDrainOps(sp), cfg: cfg, cityPath: cityPath,
+		store: store, events: ep, refresh: refresh, now: time.Now,
+	}
+	addr := net.JoinHostPort(bind, strconv.Itoa(port))
+	fmt.Fprintf(stdout, "Serving dashboard for %s on http://%s\n", cityPath, addr) //nolint:errcheck // best-effort stdout
+	hs := &http.Server{Addr: addr, Handler: srv, ReadHeaderTimeout: webReadHeaderDeadline}
+	if err := hs.ListenAndServe(); err != nil {
+		fmt.Fprintf(stderr, "gc web: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	return 0
+}
+
+// webServer serves the read-only dashboard from the city's providers.
+type webServer struct {
+	sp       runtime.Provider
+	dops     drainOps
+	cfg      *config.City
+	cityPath string
+	store    beads.Store
+	events   events.Provider
+	refresh  time.Duration
+	now      func() time.Time
+}
+
+// webBoard is the data behind one page render (and /api/board).
+type webBoard struct {
+	City       string         `json:"city"`
+	Generated  time.Time      `json:"generated"`
+	Controller bool           `json:"controller_running"`
+	Open       []beads.Bead   `json:"open"`
+	InProgress []beads.Bead   `json:"in_progress"`
+	Closed     []beads.Bead   `json:"closed"`
+	Agents     []webAgent     `json:"agents"`
+	Events     []events.Event `json:"events"`
+	// Errors lists data sources that could not be read; the rest of the
+	// board is still served.
+	Errors []string `json:"errors,omitempty"`
+	// Refresh is the page reload interval in seconds.
+	Refresh int `json:"-"`
+}
+
+// webAgent is one agent row on the board.
+type webAgent struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Running  bool   `json:"running"`
+	Pool     bool   `json:"pool,omitempty"`
+	Instance bool   `json:"instance,omitempty"`
+}
+
+func (s *webServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "gc web is read-only", http.StatusMethodNotAllowed)
+		return
+	}
+	switch r.URL.Path {
+	case "/":
+		// Render into a buffer so a template error can still become a 500.
+		var buf bytes.Buffer
+		if err := webBoardTmpl.Execute(&buf, s.board()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		buf.WriteTo(w) //nolint:errcheck // best-effort response
+	case "/api/board":
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(s.board()) //nolint:errcheck // best-effort response
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// board gathers the current dashboard data. Each source is best-effort:
+// a failing store or event log is reported on the page, not as an error.
+func (s *webServer) board() webBoard {
+	snap := gatherTop(s.sp, s.dops, s.cfg, s.cityPath, s.store, s.now())
+	b := webBoard{
+		City:       snap.City,
+		Generated:  snap.Taken,
+		Controller: snap.Controller != 0,
+		Refresh:    int(s.refresh.Seconds()),
+		Agents:     []webAgent{},
+	}
+	for _, a := range snap.Agents {
+		b.Agents = append(b.Agents, webAgent{Name: a.Name, Status: a.Status, Running: a.Running, Pool: a.Pool, Instance: a.Instance})
+	}
+
+	columns := []struct {
+		dst *[]beads.Bead
+		f   beads.Filter
+	}{
+		{&b.Open, beads.Filter{Status: "open", Sort: "priority"}},
+		{&b.InProgress, beads.Filter{Status: "in_progress", Sort: "priority"}},
+		{&b.Closed, beads.Filter{Status: "closed", Sort: "-created"}},
+	}
+	for _, col := range columns {
+		bs, err := s.store.Query(col.f)
+		if err != nil {
+			b.Errors = append(b.Errors, fmt.Sprintf("beads (%s): %v", col.f.Status, err))
+		}
+		*col.dst = boardBeads(bs, col.f.Status == "closed")
+	}
+
+	evts, err := s.events.List(events.Filter{})
+	if err != nil {
+		b.Errors = append(b.Errors, fmt.Sprintf("events: %v", err))
+	}
+	b.Events = []events.Event{}
+	for i := len(evts) - 1; i >= 0 && len(b.Events) < webRecentEventsLimit; i-- {
+		b.Events = append(b.Events, evts[i])
+	}
+	return b
+}
+
+// boardBeads drops session beads, which are controller bookkeeping rather
+// than work, and caps the closed column.
+func boardBeads(bs []beads.Bead, closed bool) []beads.Bead {
+	out := []beads.Bead{}
+	for _, b := range bs {
+		if b.Type == session.BeadType {
+			continue
+		}
+		if closed && len(out) == webClosedColumnLimit {
+			break
+		}
+		out = append(out, b)
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/session"
+)
+
+func newTestWebServer(t *testing.T) (*webServer, beads.Store) {
+	t.Helper()
+	store := beads.NewMemStore()
+	ep := events.NewFake()
+	ep.Record(events.Event{Type: events.BeadCreated, Actor: "mayor", Subject: "gc-1"})
+	sp := runtime.NewFake()
+	return &webServer{
+		sp:       sp,
+		dops:     newFakeDrainOps(),
+		cfg:      &config.City{Workspace: config.Workspace{Name: "metro"}, Agents: []config.Agent{{Name: "mayor"}}},
+		cityPath: t.TempDir(),
+		store:    store,
+		events:   ep,
+		refresh:  5 * time.Second,
+		now:      time.Now,
+	}, store
+}
+
+func TestWebBoard(t *testing.T) {
+	srv, store := newTestWebServer(t)
+	open, _ := store.Create(beads.Bead{Title: "Write docs"})
+	active, _ := store.Create(beads.Bead{Title: "Fix <login>"})
+	inProgress := "in_progress"
+	store.Update(active.ID, beads.UpdateOpts{Status: &inProgress}) //nolint:errcheck
+	done, _ := store.Create(beads.Bead{Title: "Ship it"})
+	store.Close(done.ID)                                                     //nolint:errcheck
+	store.Create(beads.Bead{Title: "mayor session", Type: session.BeadType}) //nolint:errcheck
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/board", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	var b webBoard
+	if err := json.Unmarshal(rec.Body.Bytes(), &b); err != nil {
+		t.Fatal(err)
+	}
+	if b.City != "metro" || len(b.Agents) != 1 || len(b.Events) != 1 {
+		t.Errorf("board = %+v", b)
+	}
+	if len(b.Open) != 1 || b.Open[0].ID != open.ID {
+		t.Errorf("open = %+v, want only %s (session beads hidden)", b.Open, open.ID)
+	}
+	if len(b.InProgress) != 1 || b.InProgress[0].ID != active.ID || len(b.Closed) != 1 || b.Closed[0].ID != done.ID {
+		t.Errorf("in_progress = %+v, closed = %+v", b.InProgress, b.Closed)
+	}
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	page := rec.Body.String()
+	for _, want := range []string{`content="5"`, "In progress (1)", "Fix &lt;login&gt;", "bead.created", "mayor"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+}
+
+func TestWebBoardSeesOtherWriters(t *testing.T) {
+	srv, _ := newTestWebServer(t)
+	t.Setenv("GC_BEADS", "file")
+	store, err := openCityStoreAt(srv.cityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := openCityStoreAt(srv.cityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.store = store
+	board := func() webBoard {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/board", nil))
+		var b webBoard
+		if err := json.Unmarshal(rec.Body.Bytes(), &b); err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	if b := board(); len(b.Open) != 0 {
+		t.Fatalf("open = %+v, want empty", b.Open)
+	}
+	created, err := other.Create(beads.Bead{Title: "slung elsewhere"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := board(); len(b.Open) != 1 || b.Open[0].ID != created.ID {
+		t.Errorf("open after another process's write = %+v, want [%s]", b.Open, created.ID)
+	}
+}
+
+func TestWebReadOnly(t *testing.T) {
+	srv, _ := newTestWebServer(t)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/board", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/nope", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown path status = %d, want 404", rec.Code)
+	}
+}
+
+func TestBoardBeadsCapsClosed(t *testing.T) {
+	var bs []beads.Bead
+	for range webClosedColumnLimit + 5 {
+		bs = append(bs, beads.Bead{})
+	}
+	if got := len(boardBeads(bs, true)); got != webClosedColumnLimit {
+		t.Errorf("closed = %d, want %d", got, webClosedColumnLimit)
+	}
+	if got := len(boardBeads(bs, false)); got != len(bs) {
+		t.Errorf("open = %d, want %d", got, len(bs))
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/gastownhall/gascity/internal/convergence"
 	"github.com/gastownhall/gascity/internal/events"
 	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/gastownhall/gascity/internal/logging"
 	"github.com/gastownhall/gascity/internal/runtime"
 	"github.com/gastownhall/gascity/internal/supervisor"
 	"github.com/gastownhall/gascity/internal/telemetry"
@@ -40,7 +41,7 @@ func acquireControllerLock(cityPath string) (*os.File, error) {
 	if err != nil {
 		return nil, fmt.Errorf("opening controller lock: %w", err)
 	}
-	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+	if err := fsys.TryLock(f); err != nil {
 		f.Close() //nolint:errcheck // closing after flock failure
 		return nil, fmt.Errorf("controller already running")
 	}
@@ -283,6 +284,9 @@ func tryReloadConfig(tomlPath, lockedCityName, cityRoot string, stderr io.Writer
 	if newName != lockedCityName {
 		return nil, fmt.Errorf("workspace.name changed from %q to %q (restart controller to apply)", lockedCityName, newName)
 	}
+	if err := config.ValidateRigs(newCfg.Rigs, lockedCityName); err != nil {
+		return nil, fmt.Errorf("validating rigs: %w", err)
+	}
 	rev := config.Revision(fsys.OSFS{}, prov, newCfg, cityRoot)
 	return &reloadResult{Cfg: newCfg, Prov: prov, Revision: rev}, nil
 }
@@ -425,6 +429,7 @@ func controllerLoop(
 		logPrefix:         "gc start",
 		stdout:            stdout,
 		stderr:            stderr,
+		log:               logging.FromWriter(stderr).With("component", "gc start"),
 	}
 	cr.run(ctx)
 }
@@ -460,6 +465,73 @@ func configReloadSummary(oldAgents, oldRigs, newAgents, newRigs int) string {
 	return strings.Join(parts, ", ")
 }
 
+// configChangeEvents describes what a reload changes for agents and rigs:
+// additions, removals, pool resizes, and suspension toggles. Implicit
+// provider agents are not reported.
+func configChangeEvents(oldCfg, newCfg *config.City) []events.Event {
+	var evts []events.Event
+	add := func(typ, subject, msg string) {
+		evts = append(evts, events.Event{Type: typ, Actor: "gc", Subject: subject, Message: msg})
+	}
+	oldAgents := make(map[string]config.Agent)
+	for _, a := range oldCfg.Agents {
+		if !a.Implicit {
+			oldAgents[a.QualifiedName()] = a
+		}
+	}
+	seen := make(map[string]bool)
+	for _, a := range newCfg.Agents {
+		if a.Implicit {
+			continue
+		}
+		qn := a.QualifiedName()
+		seen[qn] = true
+		prev, ok := oldAgents[qn]
+		if !ok {
+			add(events.AgentAdded, qn, "")
+			continue
+		}
+		if oldPool, newPool := poolBounds(prev), poolBounds(a); oldPool != newPool {
+			add(events.AgentPoolResized, qn, oldPool+" → "+newPool)
+		}
+		switch {
+		case a.Suspended && !prev.Suspended:
+			add(events.AgentSuspended, qn, "")
+		case !a.Suspended && prev.Suspended:
+			add(events.AgentResumed, qn, "")
+		}
+	}
+	for _, a := range oldCfg.Agents {
+		if qn := a.QualifiedName(); !a.Implicit && !seen[qn] {
+			add(events.AgentRemoved, qn, "")
+		}
+	}
+
+	oldRigs := make(map[string]bool)
+	for _, r := range oldCfg.Rigs {
+		oldRigs[r.Name] = r.Suspended
+	}
+	for _, r := range newCfg.Rigs {
+		was, ok := oldRigs[r.Name]
+		switch {
+		case !ok || r.Suspended == was:
+		case r.Suspended:
+			add(events.RigSuspended, r.Name, "")
+		default:
+			add(events.RigResumed, r.Name, "")
+		}
+	}
+	return evts
+}
+
+// poolBounds renders an agent's pool size for change messages.
+func poolBounds(a config.Agent) string {
+	if a.Pool == nil {
+		return "singleton"
+	}
+	return fmt.Sprintf("pool %d..%d", a.Pool.Min, a.Pool.Max)
+}
+
 // runController runs the persistent controller loop. It acquires a lock,
 // opens a control socket, runs the reconciliation loop, and on shutdown
 // stops all agents. Returns an exit code. initialWatchDirs is the set of
@@ -485,6 +557,11 @@ func runController(
 	}
 	defer lock.Close() //nolint:errcheck // best-effort cleanup
 
+	// From here on, everything the controller reports is also recorded
+	// as structured records in .gc/logs/gc.log.
+	stdout, stderr, closeLog := openControllerLog(cityPath, stdout, stderr)
+	defer closeLog()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
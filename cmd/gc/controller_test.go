@@ -496,3 +496,41 @@ func (osFS) Stat(name string) (os.FileInfo, error)                { return os.St
 func (osFS) ReadDir(name string) ([]os.DirEntry, error)           { return os.ReadDir(name) }
 func (osFS) Rename(oldpath, newpath string) error                 { return os.Rename(oldpath, newpath) }
 func (osFS) Remove(name string) error                             { return os.Remove(name) }
+
+func TestConfigChangeEvents(t *testing.T) {
+	oldCfg := &config.City{
+		Agents: []config.Agent{
+			{Name: "mayor"},
+			{Name: "worker", Dir: "app", Pool: &config.PoolConfig{Min: 0, Max: 2}},
+			{Name: "scribe"},
+			{Name: "claude", Implicit: true},
+		},
+		Rigs: []config.Rig{{Name: "app"}, {Name: "docs", Suspended: true}},
+	}
+	newCfg := &config.City{
+		Agents: []config.Agent{
+			{Name: "mayor", Suspended: true},
+			{Name: "worker", Dir: "app", Pool: &config.PoolConfig{Min: 1, Max: 5}},
+			{Name: "reviewer"},
+		},
+		Rigs: []config.Rig{{Name: "app", Suspended: true}, {Name: "docs"}},
+	}
+	var got []string
+	for _, e := range configChangeEvents(oldCfg, newCfg) {
+		got = append(got, e.Type+" "+e.Subject+" "+e.Message)
+	}
+	want := []string{
+		"agent.suspended mayor ",
+		"agent.pool_resized app/worker pool 0..2 → pool 1..5",
+		"agent.added reviewer ",
+		"agent.removed scribe ",
+		"rig.suspended app ",
+		"rig.resumed docs ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if evts := configChangeEvents(newCfg, newCfg); len(evts) != 0 {
+		t.Errorf("unchanged config produced %d events", len(evts))
+	}
+}
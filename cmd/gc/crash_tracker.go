@@ -1,41 +1,85 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/gastownhall/gascity/internal/fsys"
 )
 
 // crashTracker tracks agent restart history for crash loop detection.
-// The controller holds one instance for its lifetime. State is in-memory
-// only — intentionally lost on controller restart (counter reset, same as
-// Erlang/OTP). Nil means no crash tracking (backward compatible).
+// The controller holds one instance for its lifetime. Restart counts are
+// in-memory only — intentionally lost on controller restart (counter
+// reset, same as Erlang/OTP) — but quarantines, once tripped, persist in
+// .gc/state so a restarted controller keeps honoring them. Nil means no
+// crash tracking (backward compatible).
 type crashTracker interface {
 	// recordStart notes that a session was (re)started at the given time.
 	recordStart(sessionName string, at time.Time)
 
 	// isQuarantined returns true if the session has exceeded max_restarts
-	// within the restart window and the window hasn't expired yet.
+	// within the restart window and its quarantine hasn't expired yet.
 	isQuarantined(sessionName string, now time.Time) bool
 
 	// clearHistory removes all tracking for a session (used when an agent
 	// is removed from config so orphan cleanup doesn't leave stale tracking).
 	clearHistory(sessionName string)
 
-	// clearAll removes all tracking for all sessions (used on config reload
-	// so that a fixed config automatically unquarantines all agents).
-	clearAll()
+	// clearRestarts forgets the restart counts of all sessions (used on
+	// config reload so that a fixed config starts counting afresh).
+	// Quarantines are kept: only expiry or "gc agent resume
+	// --clear-quarantine" releases them.
+	clearRestarts()
 
 	// limits returns the current maxRestarts and restartWindow so the
 	// controller can detect config changes and rebuild the tracker.
 	limits() (maxRestarts int, window time.Duration)
 }
 
+// maxQuarantineBackoff caps how long repeated quarantines of one session
+// can grow.
+const maxQuarantineBackoff = 24 * time.Hour
+
+// crashQuarantine records a session's crash-loop quarantine. Strikes
+// counts back-to-back quarantines: each one lasts twice as long as the
+// last, starting at one restart window.
+type crashQuarantine struct {
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+	Strikes int       `json:"strikes"`
+}
+
+// crashQuarantines maps session names to their quarantine records,
+// including expired ones kept to count strikes.
+type crashQuarantines map[string]crashQuarantine
+
+// active returns the session's quarantine if it has not expired at now.
+func (qs crashQuarantines) active(sessionName string, now time.Time) (crashQuarantine, bool) {
+	q, ok := qs[sessionName]
+	if !ok || !now.Before(q.Until) {
+		return crashQuarantine{}, false
+	}
+	return q, true
+}
+
 // memoryCrashTracker is the production implementation of crashTracker.
 type memoryCrashTracker struct {
 	mu            sync.Mutex
 	maxRestarts   int
 	restartWindow time.Duration
 	starts        map[string][]time.Time // session → recent start timestamps
+	quarantined   crashQuarantines
+
+	// statePath, when set, is where quarantines persist. stateMod is the
+	// file's mtime at last read or write, so edits by "gc agent resume
+	// --clear-quarantine" are picked up.
+	statePath string
+	stateMod  time.Time
 }
 
 // newCrashTracker creates a crash tracker with the given thresholds. Returns
@@ -49,30 +93,68 @@ func newCrashTracker(maxRestarts int, window time.Duration) crashTracker {
 		maxRestarts:   maxRestarts,
 		restartWindow: window,
 		starts:        make(map[string][]time.Time),
+		quarantined:   make(crashQuarantines),
 	}
 }
 
+// newCityCrashTracker creates a crash tracker whose quarantines persist
+// in the city's .gc/state directory. Returns nil when disabled, like
+// newCrashTracker.
+func newCityCrashTracker(cityPath string, maxRestarts int, window time.Duration) crashTracker {
+	ct := newCrashTracker(maxRestarts, window)
+	if m, ok := ct.(*memoryCrashTracker); ok {
+		m.statePath = crashStatePath(cityPath)
+		m.reload()
+	}
+	return ct
+}
+
 func (m *memoryCrashTracker) recordStart(sessionName string, at time.Time) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.reload()
 	m.prune(sessionName, at)
 	m.starts[sessionName] = append(m.starts[sessionName], at)
+	if len(m.starts[sessionName]) < m.maxRestarts {
+		return
+	}
+
+	// Crash loop: quarantine from the first start in the window. A session
+	// that trips again within a window of its last quarantine ending
+	// takes another strike and a doubled quarantine.
+	q := m.quarantined[sessionName]
+	if q.Strikes > 0 && at.Sub(q.Until) > m.restartWindow {
+		q.Strikes = 0
+	}
+	q.Strikes++
+	q.Since = at
+	q.Until = m.starts[sessionName][0].Add(quarantineBackoff(m.restartWindow, q.Strikes))
+	m.quarantined[sessionName] = q
+	delete(m.starts, sessionName)
+	m.save(at)
 }
 
 func (m *memoryCrashTracker) isQuarantined(sessionName string, now time.Time) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.reload()
 	m.prune(sessionName, now)
-	return len(m.starts[sessionName]) >= m.maxRestarts
+	_, ok := m.quarantined.active(sessionName, now)
+	return ok
 }
 
 func (m *memoryCrashTracker) clearHistory(sessionName string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.reload()
 	delete(m.starts, sessionName)
+	if _, ok := m.quarantined[sessionName]; ok {
+		delete(m.quarantined, sessionName)
+		m.save(time.Now())
+	}
 }
 
-func (m *memoryCrashTracker) clearAll() {
+func (m *memoryCrashTracker) clearRestarts() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.starts = make(map[string][]time.Time)
@@ -101,3 +183,120 @@ func (m *memoryCrashTracker) prune(sessionName string, now time.Time) {
 		delete(m.starts, sessionName)
 	}
 }
+
+// quarantineBackoff returns how long the given strike's quarantine lasts:
+// the restart window, doubled for each strike after the first, capped at
+// maxQuarantineBackoff (but never shorter than the window itself).
+func quarantineBackoff(window time.Duration, strikes int) time.Duration {
+	d := window
+	for i := 1; i < strikes && d < maxQuarantineBackoff; i++ {
+		d *= 2
+	}
+	return max(window, min(d, maxQuarantineBackoff))
+}
+
+// reload re-reads persisted quarantines if the state file changed since
+// the tracker last saw it. Caller holds m.mu.
+func (m *memoryCrashTracker) reload() {
+	if m.statePath == "" {
+		return
+	}
+	info, err := os.Stat(m.statePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !m.stateMod.IsZero() {
+			m.quarantined = make(crashQuarantines)
+			m.stateMod = time.Time{}
+		}
+		return
+	}
+	if info.ModTime().Equal(m.stateMod) {
+		return
+	}
+	qs, err := readCrashQuarantines(m.statePath)
+	if err != nil {
+		return
+	}
+	m.quarantined = qs
+	m.stateMod = info.ModTime()
+}
+
+// save persists quarantines, dropping records too old to count as a
+// prior strike. Best-effort: the in-memory state stays authoritative for
+// this controller. Caller holds m.mu.
+func (m *memoryCrashTracker) save(now time.Time) {
+	for sn, q := range m.quarantined {
+		if now.Sub(q.Until) > m.restartWindow {
+			delete(m.quarantined, sn)
+		}
+	}
+	if m.statePath == "" {
+		return
+	}
+	if err := writeCrashQuarantines(m.statePath, m.quarantined); err != nil {
+		return
+	}
+	if info, err := os.Stat(m.statePath); err == nil {
+		m.stateMod = info.ModTime()
+	}
+}
+
+// crashStatePath returns the file holding a city's crash-loop quarantines.
+func crashStatePath(cityPath string) string {
+	return filepath.Join(cityPath, ".gc", "state", "crash-quarantine.json")
+}
+
+// readCrashQuarantines reads persisted quarantines. A missing file means
+// no quarantines.
+func readCrashQuarantines(path string) (crashQuarantines, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(crashQuarantines), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	qs := make(crashQuarantines)
+	if err := json.Unmarshal(data, &qs); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return qs, nil
+}
+
+// writeCrashQuarantines atomically replaces the quarantine state file.
+func writeCrashQuarantines(path string, qs crashQuarantines) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(qs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsys.WriteFileAtomic(fsys.OSFS{}, path, append(data, '\n'), 0o644)
+}
+
+// clearCrashQuarantines removes the given sessions' quarantines (and
+// strike history) from a city's state file. Returns how many sessions
+// were actively quarantined. A running controller picks up the change on
+// its next check.
+func clearCrashQuarantines(cityPath string, sessionNames []string, now time.Time) (int, error) {
+	path := crashStatePath(cityPath)
+	qs, err := readCrashQuarantines(path)
+	if err != nil {
+		return 0, err
+	}
+	cleared, changed := 0, false
+	for _, sn := range sessionNames {
+		if _, ok := qs[sn]; !ok {
+			continue
+		}
+		if _, ok := qs.active(sn, now); ok {
+			cleared++
+		}
+		delete(qs, sn)
+		changed = true
+	}
+	if !changed {
+		return 0, nil
+	}
+	return cleared, writeCrashQuarantines(path, qs)
+}
@@ -31,8 +31,7 @@ func (f *fakeCrashTracker) clearHistory(sessionName string) {
 	delete(f.quarantined, sessionName)
 }
 
-func (f *fakeCrashTracker) clearAll() {
-	f.quarantined = make(map[string]bool)
+func (f *fakeCrashTracker) clearRestarts() {
 	f.starts = make(map[string][]time.Time)
 }
 
@@ -194,3 +193,89 @@ func TestCrashTrackerUnknownSession(t *testing.T) {
 		t.Error("unknown session should not be quarantined")
 	}
 }
+
+func TestCrashTrackerBackoffDoubles(t *testing.T) {
+	ct := newCrashTracker(2, 10*time.Minute).(*memoryCrashTracker)
+	now := time.Now()
+
+	// First crash loop: quarantined for one window from the first start.
+	ct.recordStart("gc-test-agent", now)
+	ct.recordStart("gc-test-agent", now.Add(time.Minute))
+	if q := ct.quarantined["gc-test-agent"]; q.Strikes != 1 || !q.Until.Equal(now.Add(10*time.Minute)) {
+		t.Fatalf("first quarantine = %+v, want strike 1 until +10m", q)
+	}
+
+	// Loops again right after release: strike 2, twice as long.
+	ct.recordStart("gc-test-agent", now.Add(10*time.Minute))
+	ct.recordStart("gc-test-agent", now.Add(11*time.Minute))
+	q := ct.quarantined["gc-test-agent"]
+	if q.Strikes != 2 || !q.Until.Equal(now.Add(30*time.Minute)) {
+		t.Fatalf("second quarantine = %+v, want strike 2 until +30m", q)
+	}
+	if !ct.isQuarantined("gc-test-agent", now.Add(25*time.Minute)) {
+		t.Error("should still be quarantined during doubled backoff")
+	}
+
+	// Stable for over a window after release: strikes reset.
+	ct.recordStart("gc-test-agent", now.Add(50*time.Minute))
+	ct.recordStart("gc-test-agent", now.Add(51*time.Minute))
+	if q := ct.quarantined["gc-test-agent"]; q.Strikes != 1 {
+		t.Errorf("strikes = %d after a stable window, want 1", q.Strikes)
+	}
+}
+
+func TestQuarantineBackoffCapped(t *testing.T) {
+	if got := quarantineBackoff(time.Hour, 10); got != maxQuarantineBackoff {
+		t.Errorf("backoff(1h, 10) = %s, want %s", got, maxQuarantineBackoff)
+	}
+	if got := quarantineBackoff(48*time.Hour, 3); got != 48*time.Hour {
+		t.Errorf("backoff(48h, 3) = %s, want the window", got)
+	}
+}
+
+func TestCityCrashTrackerPersists(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	ct := newCityCrashTracker(dir, 2, time.Hour)
+	ct.recordStart("gc-test-agent", now)
+	ct.recordStart("gc-test-agent", now.Add(time.Minute))
+
+	// A new tracker (controller restart) still honors the quarantine.
+	restarted := newCityCrashTracker(dir, 2, time.Hour)
+	if !restarted.isQuarantined("gc-test-agent", now.Add(2*time.Minute)) {
+		t.Fatal("quarantine lost across tracker restart")
+	}
+
+	// Clearing through the state file (gc agent resume --clear-quarantine)
+	// is picked up by the live tracker.
+	n, err := clearCrashQuarantines(dir, []string{"gc-test-agent", "gc-test-other"}, now.Add(2*time.Minute))
+	if err != nil || n != 1 {
+		t.Fatalf("clearCrashQuarantines = %d, %v; want 1, nil", n, err)
+	}
+	if restarted.isQuarantined("gc-test-agent", now.Add(3*time.Minute)) {
+		t.Error("live tracker still quarantined after state file cleared")
+	}
+}
+
+func TestCrashTrackerClearRestartsKeepsQuarantine(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	ct := newCityCrashTracker(dir, 2, time.Hour)
+	ct.recordStart("gc-test-agent", now)
+	ct.recordStart("gc-test-agent", now.Add(time.Minute))
+	ct.recordStart("gc-test-other", now)
+
+	ct.clearRestarts()
+	if !ct.isQuarantined("gc-test-agent", now.Add(2*time.Minute)) {
+		t.Error("clearRestarts released a quarantine")
+	}
+	// The other session's single start was forgotten: one more start does
+	// not trip the limit.
+	ct.recordStart("gc-test-other", now.Add(2*time.Minute))
+	if ct.isQuarantined("gc-test-other", now.Add(3*time.Minute)) {
+		t.Error("restart count survived clearRestarts")
+	}
+	if !newCityCrashTracker(dir, 2, time.Hour).isQuarantined("gc-test-agent", now.Add(2*time.Minute)) {
+		t.Error("persisted quarantine lost after clearRestarts")
+	}
+}
@@ -160,9 +160,9 @@ func eventCategory(eventType string) string {
 	switch eventType {
 	case "session.woke", "session.stopped", "session.crashed",
 		"session.draining", "session.undrained", "session.quarantined",
-		"session.idle_killed", "session.suspended", "session.updated":
+		"session.idle_killed", "session.heartbeat_stale", "session.suspended", "session.updated":
 		return "session"
-	case "bead.created", "bead.closed", "bead.updated":
+	case "bead.created", "bead.closed", "bead.updated", "bead.overdue", "bead.claim_stale", "sling.routed":
 		return "work"
 	case "mail.sent", "mail.read", "mail.archived",
 		"mail.marked_read", "mail.marked_unread",
@@ -191,31 +191,35 @@ func extractRig(actor string) string {
 // eventIcon returns an emoji for an event type.
 func eventIcon(eventType string) string {
 	icons := map[string]string{
-		"session.woke":         "\u25b6\ufe0f", // play
-		"session.stopped":      "\u23f9\ufe0f", // stop
-		"session.crashed":      "\u2620\ufe0f", // skull and crossbones
-		"session.draining":     "\u23f3",       // hourglass
-		"session.undrained":    "\u25b6\ufe0f", // play (resumed)
-		"session.quarantined":  "\U0001f6ab",   // no entry
-		"session.idle_killed":  "\U0001f480",   // skull
-		"session.suspended":    "\u23f8\ufe0f", // pause
-		"session.updated":      "\U0001f504",   // counterclockwise arrows
-		"bead.created":         "\U0001fa9d",   // hook
-		"bead.closed":          "\u2705",       // check mark
-		"bead.updated":         "\U0001f4dd",   // memo
-		"mail.sent":            "\U0001f4ec",   // mailbox
-		"mail.read":            "\U0001f4e8",   // incoming envelope
-		"mail.archived":        "\U0001f4e6",   // package
-		"controller.started":   "\U0001f680",   // rocket
-		"controller.stopped":   "\U0001f6d1",   // stop sign
-		"city.suspended":       "\u23f8\ufe0f", // pause
-		"city.resumed":         "\u25b6\ufe0f", // play
-		"convoy.created":       "\U0001f69a",   // delivery truck
-		"convoy.closed":        "\u2705",       // check mark
-		"automation.fired":     "\u26a1",       // lightning
-		"automation.completed": "\u2714\ufe0f", // check
-		"automation.failed":    "\u274c",       // cross mark
-		"provider.swapped":     "\U0001f500",   // shuffle
+		"session.woke":            "\u25b6\ufe0f", // play
+		"session.stopped":         "\u23f9\ufe0f", // stop
+		"session.crashed":         "\u2620\ufe0f", // skull and crossbones
+		"session.draining":        "\u23f3",       // hourglass
+		"session.undrained":       "\u25b6\ufe0f", // play (resumed)
+		"session.quarantined":     "\U0001f6ab",   // no entry
+		"session.idle_killed":     "\U0001f480",   // skull
+		"session.heartbeat_stale": "\U0001f494",   // broken heart
+		"session.suspended":       "\u23f8\ufe0f", // pause
+		"session.updated":         "\U0001f504",   // counterclockwise arrows
+		"bead.created":            "\U0001fa9d",   // hook
+		"bead.closed":             "\u2705",       // check mark
+		"bead.updated":            "\U0001f4dd",   // memo
+		"bead.overdue":            "\u23f0",       // alarm clock
+		"bead.claim_stale":        "\U0001f9df",   // zombie
+		"mail.sent":               "\U0001f4ec",   // mailbox
+		"mail.read":               "\U0001f4e8",   // incoming envelope
+		"mail.archived":           "\U0001f4e6",   // package
+		"controller.started":      "\U0001f680",   // rocket
+		"controller.stopped":      "\U0001f6d1",   // stop sign
+		"city.suspended":          "\u23f8\ufe0f", // pause
+		"city.resumed":            "\u25b6\ufe0f", // play
+		"convoy.created":          "\U0001f69a",   // delivery truck
+		"convoy.closed":           "\u2705",       // check mark
+		"sling.routed":            "\U0001f3af",   // direct hit
+		"automation.fired":        "\u26a1",       // lightning
+		"automation.completed":    "\u2714\ufe0f", // check
+		"automation.failed":       "\u274c",       // cross mark
+		"provider.swapped":        "\U0001f500",   // shuffle
 	}
 	if icon, ok := icons[eventType]; ok {
 		return icon
@@ -243,6 +247,8 @@ func eventSummary(eventType, actor, subject, message string) string {
 		return fmt.Sprintf("%s quarantined", formatAgentAddress(subject))
 	case "session.idle_killed":
 		return fmt.Sprintf("%s idle-killed", formatAgentAddress(subject))
+	case "session.heartbeat_stale":
+		return fmt.Sprintf("%s heartbeat stale", formatAgentAddress(subject))
 	case "session.suspended":
 		return fmt.Sprintf("%s suspended", formatAgentAddress(subject))
 	case "session.updated":
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// agentHeartbeat is the last liveness report from an agent, written by
+// "gc agent heartbeat" from inside the agent's session.
+type agentHeartbeat struct {
+	Session string    `json:"session"`
+	Bead    string    `json:"bead,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// heartbeatState maps qualified agent names to their last heartbeat.
+type heartbeatState map[string]agentHeartbeat
+
+func heartbeatStatePath(cityPath string) string {
+	return citylayout.RuntimePath(cityPath, "state", "heartbeats.json")
+}
+
+func heartbeatLockPath(cityPath string) string {
+	return citylayout.RuntimePath(cityPath, "state", "heartbeats.lock")
+}
+
+// loadHeartbeats reads the heartbeat file. A missing file means no agent
+// has reported yet.
+func loadHeartbeats(cityPath string) (heartbeatState, error) {
+	data, err := os.ReadFile(heartbeatStatePath(cityPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return heartbeatState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading heartbeats: %w", err)
+	}
+	state := heartbeatState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing heartbeats: %w", err)
+	}
+	return state, nil
+}
+
+// recordHeartbeat stores hb as agent's latest heartbeat. Agents report
+// concurrently, so the read-modify-write runs under a file lock.
+func recordHeartbeat(cityPath, agent string, hb agentHeartbeat) error {
+	if err := os.MkdirAll(filepath.Dir(heartbeatStatePath(cityPath)), 0o755); err != nil {
+		return fmt.Errorf("creating state dir: %w", err)
+	}
+	lockFile, err := os.OpenFile(heartbeatLockPath(cityPath), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening heartbeat lock: %w", err)
+	}
+	defer lockFile.Close() //nolint:errcheck
+
+	if err := fsys.Lock(lockFile); err != nil {
+		return fmt.Errorf("locking heartbeats: %w", err)
+	}
+	defer fsys.Unlock(lockFile) //nolint:errcheck
+
+	state, err := loadHeartbeats(cityPath)
+	if err != nil {
+		return err
+	}
+	state[agent] = hb
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal heartbeats: %w", err)
+	}
+	if err := fsys.WriteFileAtomic(fsys.OSFS{}, heartbeatStatePath(cityPath), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write heartbeats: %w", err)
+	}
+	return nil
+}
+
+// heartbeatTick flags agents whose session is alive but whose heartbeat
+// has gone stale — a hung CLI the session-liveness check cannot see.
+// Only agents that have reported at least once are checked, and each
+// stale heartbeat is flagged once. A session the controller has only just
+// seen running gets a full timeout to report, so a restarted session is
+// not judged by its predecessor's last heartbeat.
+func (cr *CityRuntime) heartbeatTick(now time.Time) {
+	timeout := cr.cfg.Daemon.HeartbeatTimeoutDuration()
+	if timeout <= 0 {
+		return
+	}
+	state, err := loadHeartbeats(cr.cityPath)
+	if err != nil {
+		fmt.Fprintf(cr.stderr, "%s: heartbeat check: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+		return
+	}
+	if cr.heartbeatSeen == nil {
+		cr.heartbeatSeen = make(map[string]time.Time)
+		cr.heartbeatFlagged = make(map[string]time.Time)
+	}
+	for _, agent := range staleHeartbeats(state, cr.sp.IsRunning, cr.heartbeatSeen, cr.heartbeatFlagged, timeout, now) {
+		hb := state[agent]
+		msg := fmt.Sprintf("session alive but no heartbeat for %s", formatDuration(now.Sub(hb.At)))
+		if hb.Bead != "" {
+			msg += " (last on " + hb.Bead + ")"
+		}
+		fmt.Fprintf(cr.stderr, "%s: agent '%s' may be hung: %s\n", cr.logPrefix, agent, msg) //nolint:errcheck // best-effort stderr
+		cr.rec.Record(events.Event{
+			Type:    events.SessionHeartbeatStale,
+			Actor:   "gc",
+			Subject: agent,
+			Message: msg,
+		})
+	}
+}
+
+// staleHeartbeats returns, sorted, the agents to flag this tick. seen
+// records when each session was first observed running and flagged the
+// heartbeat already reported; both are updated in place.
+func staleHeartbeats(
+	state heartbeatState,
+	isRunning func(string) bool,
+	seen, flagged map[string]time.Time,
+	timeout time.Duration,
+	now time.Time,
+) []string {
+	var stale []string
+	for agent, hb := range state {
+		if hb.Session == "" || !isRunning(hb.Session) {
+			delete(seen, hb.Session)
+			delete(flagged, hb.Session)
+			continue
+		}
+		since, ok := seen[hb.Session]
+		if !ok {
+			since = now
+			seen[hb.Session] = now
+		}
+		last := hb.At
+		if since.After(last) {
+			last = since
+		}
+		if now.Sub(last) <= timeout {
+			delete(flagged, hb.Session)
+			continue
+		}
+		if f, ok := flagged[hb.Session]; ok && f.Equal(hb.At) {
+			continue // already flagged this heartbeat
+		}
+		flagged[hb.Session] = hb.At
+		stale = append(stale, agent)
+	}
+	sort.Strings(stale)
+	return stale
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordHeartbeat(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	if code := doAgentHeartbeat(dir, "hw/polecat-1", "hw--polecat-1", "gc-7", now, io.Discard); code != 0 {
+		t.Fatalf("doAgentHeartbeat = %d", code)
+	}
+	if code := doAgentHeartbeat(dir, "mayor", "mayor", "", now.Add(time.Minute), io.Discard); code != 0 {
+		t.Fatalf("doAgentHeartbeat = %d", code)
+	}
+
+	state, err := loadHeartbeats(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state) != 2 {
+		t.Fatalf("got %d heartbeats, want 2: %+v", len(state), state)
+	}
+	hb := state["hw/polecat-1"]
+	if hb.Session != "hw--polecat-1" || hb.Bead != "gc-7" || !hb.At.Equal(now) {
+		t.Errorf("polecat heartbeat = %+v", hb)
+	}
+}
+
+func TestLoadHeartbeatsMissingFile(t *testing.T) {
+	state, err := loadHeartbeats(t.TempDir())
+	if err != nil || len(state) != 0 {
+		t.Errorf("loadHeartbeats = %v, %v; want empty, nil", state, err)
+	}
+}
+
+func TestStaleHeartbeats(t *testing.T) {
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	state := heartbeatState{
+		"mayor":  {Session: "mayor", At: now.Add(-time.Hour)},
+		"fresh":  {Session: "fresh", At: now.Add(-time.Minute)},
+		"gone":   {Session: "gone", At: now.Add(-time.Hour)},
+		"worker": {Session: "worker", At: now.Add(-time.Hour)},
+	}
+	running := map[string]bool{"mayor": true, "fresh": true, "worker": true}
+	isRunning := func(sn string) bool { return running[sn] }
+	// mayor has been seen running for a while; worker is newly seen.
+	seen := map[string]time.Time{"mayor": now.Add(-2 * time.Hour)}
+	flagged := map[string]time.Time{}
+
+	got := staleHeartbeats(state, isRunning, seen, flagged, 10*time.Minute, now)
+	if strings.Join(got, ",") != "mayor" {
+		t.Fatalf("stale = %v, want [mayor]", got)
+	}
+
+	// Flagged once per heartbeat.
+	if got := staleHeartbeats(state, isRunning, seen, flagged, 10*time.Minute, now.Add(time.Minute)); len(got) != 0 {
+		t.Errorf("mayor flagged twice: %v", got)
+	}
+
+	// worker gets a full timeout from when it was first seen.
+	later := now.Add(11 * time.Minute)
+	state["fresh"] = agentHeartbeat{Session: "fresh", At: later.Add(-time.Minute)}
+	got = staleHeartbeats(state, isRunning, seen, flagged, 10*time.Minute, later)
+	if strings.Join(got, ",") != "worker" {
+		t.Errorf("stale at +11m = %v, want [worker]", got)
+	}
+
+	// A new heartbeat re-arms the flag.
+	state["mayor"] = agentHeartbeat{Session: "mayor", At: later}
+	delete(state, "fresh")
+	_ = staleHeartbeats(state, isRunning, seen, flagged, 10*time.Minute, later)
+	got = staleHeartbeats(state, isRunning, seen, flagged, 10*time.Minute, later.Add(11*time.Minute))
+	if strings.Join(got, ",") != "mayor" {
+		t.Errorf("stale after fresh heartbeat went quiet = %v, want [mayor]", got)
+	}
+}
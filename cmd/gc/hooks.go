@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/shellquote"
 )
 
 // beadHooks maps bd hook filenames to the Gas City event types they emit.
@@ -61,3 +64,50 @@ func installBeadHooks(dir string) error {
 	}
 	return nil
 }
+
+// gitHooks lists the git hooks installed by gc hooks install --git. Each
+// runs the gc hooks subcommand of the same name.
+var gitHooks = []string{"commit-msg", "post-commit"}
+
+// gitHookMarker identifies git hooks written by gc, so reinstalling
+// refreshes them while hand-written hooks are left alone.
+const gitHookMarker = "# Installed by gc hooks install --git"
+
+// gitHookCommand returns the shell line a git hook runs. The city path
+// is baked in because rigs may live outside the city directory.
+func gitHookCommand(name, cityPath string) string {
+	args := ""
+	if name == "commit-msg" {
+		args = ` "$1"`
+	}
+	return fmt.Sprintf("gc --city %s hooks %s%s || true", shellquote.Quote(cityPath), name, args)
+}
+
+// gitHookScript returns the script for the named git hook.
+func gitHookScript(name, cityPath string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s — links commits to the beads they mention.
+command -v gc >/dev/null 2>&1 || exit 0
+%s
+`, gitHookMarker, gitHookCommand(name, cityPath))
+}
+
+// installGitHooks writes the gc git hooks into hooksDir. Hooks previously
+// written by gc are overwritten; other existing hooks are kept and
+// returned as skipped.
+func installGitHooks(hooksDir, cityPath string) (skipped []string, err error) {
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating hooks directory: %w", err)
+	}
+	for _, name := range gitHooks {
+		path := filepath.Join(hooksDir, name)
+		if data, err := os.ReadFile(path); err == nil && !strings.Contains(string(data), gitHookMarker) {
+			skipped = append(skipped, name)
+			continue
+		}
+		if err := os.WriteFile(path, []byte(gitHookScript(name, cityPath)), 0o755); err != nil {
+			return skipped, fmt.Errorf("writing hook %s: %w", name, err)
+		}
+	}
+	return skipped, nil
+}
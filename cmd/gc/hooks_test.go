@@ -159,3 +159,42 @@ func TestInstallBeadHooksRigAddIntegration(t *testing.T) {
 		t.Errorf("gc rig add did not install bd hooks: %v", err)
 	}
 }
+
+func TestInstallGitHooks(t *testing.T) {
+	hooksDir := filepath.Join(t.TempDir(), "hooks")
+	userHook := "#!/bin/sh\nmake lint\n"
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(hooksDir, "commit-msg"), []byte(userHook), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	skipped, err := installGitHooks(hooksDir, "/cities/metro")
+	if err != nil {
+		t.Fatalf("installGitHooks: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0] != "commit-msg" {
+		t.Errorf("skipped = %v, want [commit-msg]", skipped)
+	}
+	data, _ := os.ReadFile(filepath.Join(hooksDir, "commit-msg"))
+	if string(data) != userHook {
+		t.Errorf("user commit-msg hook overwritten:\n%s", data)
+	}
+	data, err = os.ReadFile(filepath.Join(hooksDir, "post-commit"))
+	if err != nil {
+		t.Fatalf("reading post-commit: %v", err)
+	}
+	if !strings.Contains(string(data), "gc --city '/cities/metro' hooks post-commit || true") {
+		t.Errorf("post-commit hook = %q", data)
+	}
+
+	// Reinstalling refreshes gc's own hooks.
+	if _, err := installGitHooks(hooksDir, "/cities/gotham"); err != nil {
+		t.Fatalf("reinstall: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(hooksDir, "post-commit"))
+	if !strings.Contains(string(data), "'/cities/gotham'") {
+		t.Errorf("post-commit hook not refreshed:\n%s", data)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// cityTemplate is a curated starter city shipped with gc. Its files live
+// under init_templates/<name>/: a city.toml that includes a pack, and the
+// pack's agents, prompts, and formulas under packs/<name>/.
+type cityTemplate struct {
+	name    string
+	summary string
+	// rigPack is the pack to include when adding rigs, or "" if the
+	// template has no rig-scoped agents.
+	rigPack string
+}
+
+// cityTemplates lists the built-in templates in wizard order.
+var cityTemplates = []cityTemplate{
+	{name: "solo-dev", summary: "mayor + reviewer for a single developer"},
+	{name: "gastown", summary: "mayor + witness, refinery, and polecat pool per rig", rigPack: "packs/gastown"},
+	{name: "monorepo", summary: "mayor + one worker per repository directory", rigPack: "packs/monorepo"},
+}
+
+// findCityTemplate returns the built-in template with the given name.
+func findCityTemplate(name string) (cityTemplate, bool) {
+	for _, t := range cityTemplates {
+		if t.name == name {
+			return t, true
+		}
+	}
+	return cityTemplate{}, false
+}
+
+// cityTemplateNames returns the built-in template names in wizard order.
+func cityTemplateNames() []string {
+	names := make([]string, len(cityTemplates))
+	for i, t := range cityTemplates {
+		names[i] = t.name
+	}
+	return names
+}
+
+// resolveTemplateChoice maps wizard input to a template name. Input can be
+// a menu number (templates follow tutorial and custom, starting at 3) or a
+// template name. Returns "" if the input matches no template.
+func resolveTemplateChoice(input string) string {
+	if n, err := strconv.Atoi(input); err == nil && n >= 3 && n < 3+len(cityTemplates) {
+		return cityTemplates[n-3].name
+	}
+	if _, ok := findCityTemplate(input); ok {
+		return input
+	}
+	return ""
+}
+
+func normalizeInitTemplate(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil
+	}
+	if _, ok := findCityTemplate(name); ok {
+		return name, nil
+	}
+	return "", fmt.Errorf("unknown template %q (expected one of: %s)", name, strings.Join(cityTemplateNames(), ", "))
+}
+
+// writeCityTemplate copies the named template's pack files into cityPath
+// and returns its city.toml with the workspace name, provider or start
+// command, and bootstrap profile applied. Edits are made in place so the
+// template's comments survive.
+func writeCityTemplate(out fsys.FS, cityPath string, wiz wizardConfig) ([]byte, error) {
+	root := path.Join("init_templates", wiz.configName)
+	var cityTOML []byte
+	err := fs.WalkDir(initTemplatesFS, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := initTemplatesFS.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(p, root+"/")
+		if rel == "city.toml" {
+			cityTOML = data
+			return nil
+		}
+		dst := filepath.Join(cityPath, filepath.FromSlash(rel))
+		if err := out.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		return out.WriteFile(dst, data, 0o644)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("writing template %q: %w", wiz.configName, err)
+	}
+	if cityTOML == nil {
+		return nil, fmt.Errorf("template %q has no city.toml", wiz.configName)
+	}
+
+	edits := [][2]string{{"workspace.name", filepath.Base(cityPath)}}
+	switch {
+	case wiz.startCommand != "":
+		edits = append(edits, [2]string{"workspace.start_command", wiz.startCommand})
+	case wiz.provider != "":
+		edits = append(edits, [2]string{"workspace.provider", wiz.provider})
+	}
+	if wiz.bootstrapProfile == bootstrapProfileK8sCell {
+		edits = append(edits,
+			[2]string{"api.port", strconv.Itoa(config.DefaultAPIPort)},
+			[2]string{"api.bind", "0.0.0.0"},
+			[2]string{"api.allow_mutations", "true"})
+	}
+	for _, e := range edits {
+		if cityTOML, err = config.SetValue(cityTOML, e[0], e[1]); err != nil {
+			return nil, fmt.Errorf("template %q: %w", wiz.configName, err)
+		}
+	}
+	return cityTOML, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestDoInitTemplates(t *testing.T) {
+	for _, tc := range []struct {
+		template   string
+		cityAgents []string
+		rigAgents  []string
+	}{
+		{"solo-dev", []string{"mayor", "reviewer"}, nil},
+		{"gastown", []string{"mayor"}, []string{"witness", "refinery", "polecat"}},
+		{"monorepo", []string{"mayor"}, []string{"worker"}},
+	} {
+		t.Run(tc.template, func(t *testing.T) {
+			cityPath := filepath.Join(t.TempDir(), "bright-lights")
+			wiz := wizardConfig{configName: tc.template, provider: "codex"}
+			var stdout, stderr bytes.Buffer
+			if code := doInit(fsys.OSFS{}, cityPath, wiz, &stdout, &stderr); code != 0 {
+				t.Fatalf("doInit = %d; stderr: %s", code, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), "from the "+tc.template+" template") {
+				t.Errorf("stdout = %q", stdout.String())
+			}
+
+			// Add a rig so rig-scoped pack agents are stamped too.
+			tomlPath := filepath.Join(cityPath, "city.toml")
+			data, err := os.ReadFile(tomlPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rig := "\n[[rigs]]\nname = \"proj\"\npath = \"" + filepath.ToSlash(t.TempDir()) + "\"\nincludes = [\"packs/" + tc.template + "\"]\n"
+			writeFile(t, tomlPath, string(data)+rig)
+
+			cfg, _, err := config.LoadWithIncludes(fsys.OSFS{}, tomlPath)
+			if err != nil {
+				t.Fatalf("LoadWithIncludes: %v", err)
+			}
+			if cfg.Workspace.Name != "bright-lights" || cfg.Workspace.Provider != "codex" {
+				t.Errorf("workspace = %q/%q, want bright-lights/codex", cfg.Workspace.Name, cfg.Workspace.Provider)
+			}
+			have := make(map[string]bool)
+			for _, a := range cfg.Agents {
+				have[a.QualifiedName()] = true
+			}
+			for _, name := range tc.cityAgents {
+				if !have[name] {
+					t.Errorf("missing city agent %q; have %v", name, have)
+				}
+			}
+			for _, name := range tc.rigAgents {
+				if !have["proj/"+name] {
+					t.Errorf("missing rig agent %q; have %v", "proj/"+name, have)
+				}
+			}
+			if len(cfg.FormulaLayers.City) == 0 {
+				t.Error("template pack contributes no formula layer")
+			}
+		})
+	}
+}
+
+func TestDoInitTemplateKeepsComments(t *testing.T) {
+	f := fsys.NewFake()
+	wiz := wizardConfig{configName: "gastown", startCommand: "my-agent --yolo"}
+	var stdout, stderr bytes.Buffer
+	if code := doInit(f, "/metro", wiz, &stdout, &stderr); code != 0 {
+		t.Fatalf("doInit = %d; stderr: %s", code, stderr.String())
+	}
+	data := string(f.Files["/metro/city.toml"])
+	for _, want := range []string{"# Gas Town", `name = "metro"`, `start_command = "my-agent --yolo"`} {
+		if !strings.Contains(data, want) {
+			t.Errorf("city.toml missing %q:\n%s", want, data)
+		}
+	}
+	if _, ok := f.Files["/metro/packs/gastown/pack.toml"]; !ok {
+		t.Error("pack.toml not written")
+	}
+}
+
+func TestRunWizardSelectTemplate(t *testing.T) {
+	for input, want := range map[string]string{"4": "gastown", "monorepo": "monorepo", "9": "tutorial"} {
+		var stdout bytes.Buffer
+		wiz := runWizard(strings.NewReader(input+"\n\n"), &stdout)
+		if wiz.configName != want || wiz.provider != "claude" {
+			t.Errorf("runWizard(%q) = %q/%q, want %q/claude", input, wiz.configName, wiz.provider, want)
+		}
+	}
+}
+
+func TestInitWizardConfigTemplate(t *testing.T) {
+	wiz, err := initWizardConfig("", "", "solo-dev")
+	if err != nil || wiz.configName != "solo-dev" {
+		t.Errorf("initWizardConfig(solo-dev) = %+v, %v", wiz, err)
+	}
+	if _, err := initWizardConfig("", "", "hello-world"); err == nil || !strings.Contains(err.Error(), "unknown template") {
+		t.Errorf("initWizardConfig(hello-world) = %v, want unknown template", err)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// initVarRef matches the {{gc.name}} placeholders filled in when a city is
+// initialized with --from. The gc. prefix keeps them apart from formula
+// {{vars}} and Go template {{.Fields}} in the same files.
+var initVarRef = regexp.MustCompile(`\{\{gc\.([A-Za-z_][A-Za-z0-9_-]*)\}\}`)
+
+// validInitVarKey matches the names usable in {{gc.name}} placeholders.
+var validInitVarKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// initVars returns the placeholder values for initializing cityPath: the
+// --var key=value pairs plus the built-in city_name and city_path, which
+// cannot be overridden.
+func initVars(pairs []string, cityPath string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs)+2)
+	for _, p := range pairs {
+		key, value, ok := strings.Cut(p, "=")
+		if !ok || !validInitVarKey.MatchString(key) {
+			return nil, fmt.Errorf("invalid --var %q (expected key=value)", p)
+		}
+		vars[key] = value
+	}
+	vars["city_name"] = filepath.Base(cityPath)
+	vars["city_path"] = cityPath
+	return vars, nil
+}
+
+// scanInitVars walks the files under srcDir that a --from copy keeps and
+// returns the relative paths of text files containing {{gc.name}}
+// placeholders, plus the sorted placeholder names with no value in vars.
+func scanInitVars(srcDir string, vars map[string]string) (files, missing []string, err error) {
+	err = filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if initFromSkip(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(data, 0) >= 0 {
+			return nil // binary
+		}
+		refs := initVarRef.FindAllSubmatch(data, -1)
+		if len(refs) == 0 {
+			return nil
+		}
+		files = append(files, rel)
+		for _, m := range refs {
+			name := string(m[1])
+			if _, ok := vars[name]; !ok && !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+		}
+		return nil
+	})
+	slices.Sort(missing)
+	return files, missing, err
+}
+
+// applyInitVars replaces {{gc.name}} placeholders in the given files under
+// dir. Files that are no longer there (moved during init) are skipped.
+func applyInitVars(dir string, files []string, vars map[string]string) error {
+	for _, rel := range files {
+		path := filepath.Join(dir, rel)
+		fi, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		out := initVarRef.ReplaceAllFunc(data, func(m []byte) []byte {
+			if v, ok := vars[string(m[5:len(m)-2])]; ok {
+				return []byte(v)
+			}
+			return m
+		})
+		if err := os.WriteFile(path, out, fi.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const initVarsCityTOML = `# Team skeleton for {{gc.city_name}}.
+[workspace]
+name = "skeleton"
+
+[[agent]]
+name = "mayor"
+prompt_template = "prompts/mayor.md"
+
+[[rigs]]
+name = "app"
+path = "{{gc.app_path}}"
+`
+
+func TestScanAndApplyInitVars(t *testing.T) {
+	src := t.TempDir()
+	for _, d := range []string{"prompts", ".git"} {
+		if err := os.MkdirAll(filepath.Join(src, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, filepath.Join(src, "city.toml"), initVarsCityTOML)
+	writeFile(t, filepath.Join(src, "prompts", "mayor.md"), "Issue {{issue}} in {{.Rig}} of {{gc.city_name}}.\n")
+	writeFile(t, filepath.Join(src, "README.md"), "no placeholders\n")
+	writeFile(t, filepath.Join(src, ".git", "config"), "{{gc.ignored}}\n")
+
+	vars, err := initVars([]string{"app_path=/src/app"}, "/cities/metro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, missing, err := scanInitVars(src, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 0 || len(files) != 2 {
+		t.Fatalf("scanInitVars = %v, %v; want 2 files, none missing", files, missing)
+	}
+	if _, missing, _ := scanInitVars(src, map[string]string{"city_name": "metro"}); strings.Join(missing, ",") != "app_path" {
+		t.Errorf("missing = %v, want [app_path]", missing)
+	}
+
+	if err := applyInitVars(src, files, vars); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(src, "prompts", "mayor.md"))
+	if got := string(data); got != "Issue {{issue}} in {{.Rig}} of metro.\n" {
+		t.Errorf("mayor.md = %q", got)
+	}
+	data, _ = os.ReadFile(filepath.Join(src, "city.toml"))
+	if !strings.Contains(string(data), `path = "/src/app"`) {
+		t.Errorf("city.toml rig path not substituted:\n%s", data)
+	}
+
+	if _, err := initVars([]string{"no-equals"}, "/c"); err == nil {
+		t.Error("initVars accepted a pair without =")
+	}
+}
+
+func TestCmdInitFromGitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GC_BEADS", "file")
+	t.Setenv("GC_DOLT", "skip")
+
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, "prompts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(repo, "city.toml"), initVarsCityTOML)
+	writeFile(t, filepath.Join(repo, "prompts", "mayor.md"), "Mayor of {{gc.city_name}}.\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "-m", "skeleton"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	dir := t.TempDir()
+	cityPath := filepath.Join(dir, "metro")
+	appPath := filepath.Join(dir, "app")
+	var stdout, stderr bytes.Buffer
+
+	// A placeholder without a value stops init before anything is copied.
+	if code := cmdInitFromDir("file://"+repo, []string{cityPath}, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("cmdInitFromDir without --var = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "needs --var for: app_path") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(cityPath, "city.toml")); err == nil {
+		t.Error("city.toml copied despite missing var")
+	}
+
+	stderr.Reset()
+	if code := cmdInitFromDir("file://"+repo, []string{cityPath}, []string{"app_path=" + appPath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("cmdInitFromDir = %d; stderr: %s", code, stderr.String())
+	}
+	data, err := os.ReadFile(filepath.Join(cityPath, "city.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), appPath) || !strings.Contains(string(data), `name = "metro"`) {
+		t.Errorf("city.toml not filled in:\n%s", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(cityPath, "prompts", "mayor.md")); string(data) != "Mayor of metro.\n" {
+		t.Errorf("mayor.md = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(cityPath, ".git")); err == nil {
+		t.Error("template's .git copied into the city")
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/bridge/jira"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// jiraSyncTimeout bounds one Jira bridge pass. Beads not reached before
+// the deadline are picked up by the next pass.
+const jiraSyncTimeout = 2 * time.Minute
+
+// jiraBridgeTick starts one Jira bridge pass over the city bead store in
+// its own goroutine, so a slow Jira does not stall the tick. A pass still
+// running from an earlier tick is not overlapped. The bridge is rebuilt
+// each pass so config reloads and credential changes take effect without
+// a restart. A failure identical to the previous pass's is not logged
+// again.
+func (cr *CityRuntime) jiraBridgeTick(ctx context.Context) {
+	store := cr.cityBeadStore()
+	if store == nil {
+		return
+	}
+	if !cr.jiraRunning.CompareAndSwap(false, true) {
+		return
+	}
+	cfg := cr.cfg.Bridge.Jira
+	go func() {
+		defer cr.jiraRunning.Store(false)
+		ctx, cancel := context.WithTimeout(ctx, jiraSyncTimeout)
+		defer cancel()
+		actions, err := syncJiraBridge(ctx, cfg, store)
+		for _, a := range actions {
+			fmt.Fprintf(cr.stdout, "Jira bridge: %s → %s (%s)\n", a.BeadID, a.Key, a.Detail) //nolint:errcheck // best-effort stdout
+		}
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+		}
+		if msg != "" && msg != cr.jiraLastErr {
+			fmt.Fprintf(cr.stderr, "%s: jira bridge: %s\n", cr.logPrefix, msg) //nolint:errcheck // best-effort stderr
+		}
+		cr.jiraLastErr = msg
+	}()
+}
+
+// syncJiraBridge builds a bridge from cfg and the process environment and
+// runs one pass. Tests replace it to observe or stall passes.
+var syncJiraBridge = func(ctx context.Context, cfg config.JiraBridgeConfig, store beads.Store) ([]jira.Action, error) {
+	b, err := jira.New(cfg, os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+	return b.Sync(ctx, store)
+}
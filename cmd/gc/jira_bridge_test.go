@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/bridge/jira"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// waitJiraIdle waits for the in-flight Jira bridge pass to finish.
+func waitJiraIdle(t *testing.T, cr *CityRuntime) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for cr.jiraRunning.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("jira bridge pass did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestJiraBridgeTickLogsRepeatedErrorOnce(t *testing.T) {
+	t.Setenv("GC_TEST_JIRA_TOKEN", "")
+	cfg := &config.City{Bridge: config.BridgeConfig{Jira: config.JiraBridgeConfig{
+		BaseURL:  "https://acme.atlassian.net",
+		Project:  "ENG",
+		TokenEnv: "GC_TEST_JIRA_TOKEN",
+	}}}
+	var stderr bytes.Buffer
+	cr := &CityRuntime{
+		cfg:                 cfg,
+		standaloneCityStore: beads.NewMemStore(),
+		logPrefix:           "gc test",
+		stdout:              &bytes.Buffer{},
+		stderr:              &stderr,
+	}
+
+	cr.jiraBridgeTick(context.Background())
+	waitJiraIdle(t, cr)
+	cr.jiraBridgeTick(context.Background())
+	waitJiraIdle(t, cr)
+	if n := strings.Count(stderr.String(), "$GC_TEST_JIRA_TOKEN is not set"); n != 1 {
+		t.Errorf("error logged %d times, want 1:\n%s", n, stderr.String())
+	}
+}
+
+func TestJiraBridgeTickSingleFlight(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan bool, 4)
+	old := syncJiraBridge
+	syncJiraBridge = func(ctx context.Context, _ config.JiraBridgeConfig, _ beads.Store) ([]jira.Action, error) {
+		_, hasDeadline := ctx.Deadline()
+		started <- hasDeadline
+		<-release
+		return nil, nil
+	}
+	t.Cleanup(func() { syncJiraBridge = old })
+
+	cr := &CityRuntime{
+		cfg:                 &config.City{},
+		standaloneCityStore: beads.NewMemStore(),
+		logPrefix:           "gc test",
+		stdout:              &bytes.Buffer{},
+		stderr:              &bytes.Buffer{},
+	}
+	cr.jiraBridgeTick(context.Background())
+	if hasDeadline := <-started; !hasDeadline {
+		t.Error("jira bridge pass has no deadline")
+	}
+	// The tick returns while the pass is blocked, and a second tick does
+	// not start another pass.
+	cr.jiraBridgeTick(context.Background())
+	close(release)
+	waitJiraIdle(t, cr)
+	if n := len(started); n != 0 {
+		t.Errorf("%d overlapping passes started", n)
+	}
+
+	cr.jiraBridgeTick(context.Background())
+	<-started
+	waitJiraIdle(t, cr)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/lifecycle"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// withLifecycleHooks wraps sp so session starts and stops fire the city's
+// pre_start, post_start, and pre_stop [[hooks]]. Returns sp unchanged
+// when none are configured. Hooks are captured now; edits take effect
+// when the provider is next built (controller restart or provider swap).
+func withLifecycleHooks(sp runtime.Provider, hooks []config.LifecycleHook, cityPath, cityName string, stderr io.Writer) runtime.Provider {
+	if len(config.HooksFor(hooks, config.HookPreStart)) == 0 &&
+		len(config.HooksFor(hooks, config.HookPostStart)) == 0 &&
+		len(config.HooksFor(hooks, config.HookPreStop)) == 0 {
+		return sp
+	}
+	return lifecycle.WrapProvider(sp, newLifecycleRunner(hooks, cityPath, cityName, stderr))
+}
+
+// newLifecycleRunner returns a hook runner for a city's [[hooks]].
+func newLifecycleRunner(hooks []config.LifecycleHook, cityPath, cityName string, stderr io.Writer) *lifecycle.Runner {
+	return &lifecycle.Runner{
+		Hooks:    func() []config.LifecycleHook { return hooks },
+		CityPath: cityPath,
+		CityName: cityName,
+		Stderr:   stderr,
+	}
+}
+
+// fireSlingHooks runs the city's on_sling hooks before beadID (or, for a
+// formula sling, the formula) is routed to opts.Target. Returns false when
+// an aborting hook failed and the sling must be canceled.
+func fireSlingHooks(opts slingOpts, deps slingDeps, beadID string) bool {
+	if deps.Cfg == nil || len(config.HooksFor(deps.Cfg.Hooks, config.HookOnSling)) == 0 {
+		return true
+	}
+	r := newLifecycleRunner(deps.Cfg.Hooks, deps.CityPath, deps.CityName, deps.Stderr)
+	err := r.Fire(context.Background(), lifecycle.Payload{
+		Event:   config.HookOnSling,
+		Bead:    beadID,
+		Target:  opts.Target.QualifiedName(),
+		Formula: slingFormula(opts),
+	})
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return false
+	}
+	return true
+}
+
+// slingHookBead returns the bead an on_sling hook is told about: the bead
+// being routed, or "" when a formula sling has yet to cook one.
+func slingHookBead(opts slingOpts) string {
+	if opts.IsFormula {
+		return ""
+	}
+	return opts.BeadOrFormula
+}
+
+// fireBeadClosedHooks runs the city's post_close_bead hooks for a
+// bead.closed event. data is the event payload (the bead's JSON from the
+// bd on_close hook), passed through when valid.
+func fireBeadClosedHooks(cfg *config.City, cityPath, beadID, title, data string, stderr io.Writer) {
+	if len(config.HooksFor(cfg.Hooks, config.HookPostCloseBead)) == 0 {
+		return
+	}
+	p := lifecycle.Payload{Event: config.HookPostCloseBead, Bead: beadID, Title: title}
+	if data != "" && json.Valid([]byte(data)) {
+		p.Data = json.RawMessage(data)
+	}
+	r := newLifecycleRunner(cfg.Hooks, cityPath, cfg.Workspace.Name, stderr)
+	r.Fire(context.Background(), p) //nolint:errcheck // post_close_bead hooks only warn
+}
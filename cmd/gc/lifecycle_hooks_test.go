@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestFireSlingHooksPassesPayload(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.City{Hooks: []config.LifecycleHook{
+		{Event: config.HookOnSling, Run: "cat > payload.json"},
+	}}
+	var stderr bytes.Buffer
+	deps := slingDeps{CityName: "town", CityPath: dir, Cfg: cfg, Stderr: &stderr}
+	opts := slingOpts{Target: config.Agent{Name: "polecat", Dir: "rig"}, BeadOrFormula: "gc-1"}
+
+	if !fireSlingHooks(opts, deps, slingHookBead(opts)) {
+		t.Fatalf("fireSlingHooks = false, stderr: %s", stderr.String())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "payload.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("payload %q: %v", data, err)
+	}
+	want := map[string]any{"event": "on_sling", "city": "town", "bead": "gc-1", "target": "rig/polecat"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("payload[%q] = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestFireSlingHooksAbortCancels(t *testing.T) {
+	cfg := &config.City{Hooks: []config.LifecycleHook{
+		{Event: config.HookOnSling, Run: "echo frozen; exit 1", OnFailure: "abort"},
+	}}
+	var stderr bytes.Buffer
+	deps := slingDeps{CityPath: t.TempDir(), Cfg: cfg, Stderr: &stderr}
+	opts := slingOpts{Target: config.Agent{Name: "mayor"}, BeadOrFormula: "gc-1"}
+
+	if fireSlingHooks(opts, deps, "gc-1") {
+		t.Fatal("fireSlingHooks = true, want false for failing abort hook")
+	}
+	if !strings.Contains(stderr.String(), "frozen") {
+		t.Errorf("stderr = %q, want hook output", stderr.String())
+	}
+}
+
+func TestSlingHookBeadFormula(t *testing.T) {
+	if got := slingHookBead(slingOpts{BeadOrFormula: "review", IsFormula: true}); got != "" {
+		t.Errorf("slingHookBead(formula) = %q, want empty", got)
+	}
+}
+
+func TestWithLifecycleHooksOnlyWrapsForSessionHooks(t *testing.T) {
+	sp := runtime.NewFake()
+	sling := []config.LifecycleHook{{Event: config.HookOnSling, Run: "true"}}
+	if got := withLifecycleHooks(sp, sling, "", "", nil); got != runtime.Provider(sp) {
+		t.Error("withLifecycleHooks wrapped provider with no session hooks")
+	}
+	start := []config.LifecycleHook{{Event: config.HookPreStart, Run: "true"}}
+	if got := withLifecycleHooks(sp, start, "", "", nil); got == runtime.Provider(sp) {
+		t.Error("withLifecycleHooks did not wrap provider with a pre_start hook")
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/gastownhall/gascity/internal/logging"
+)
+
+// controllerLogRel is the controller's structured log, relative to the
+// city root. Rotated at logging.DefaultMaxSize.
+var controllerLogRel = filepath.Join(".gc", "logs", "gc.log")
+
+// envOr returns the value of the environment variable key, or def when
+// it is unset or empty.
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// configureLogging validates the --log-level and --log-format values and
+// installs them as the process-wide logging options. The default slog
+// logger writes to stderr.
+func configureLogging(level, format string, stderr io.Writer) error {
+	lvl, err := logging.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	f, err := logging.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	logging.Configure(logging.Options{Level: lvl, Format: f})
+	slog.SetDefault(logging.New(stderr))
+	return nil
+}
+
+// logFlagArgs returns the --log-level and --log-format flags matching the
+// current logging options, for forwarding to a child gc process.
+func logFlagArgs() []string {
+	o := logging.Current()
+	return []string{"--log-level", logging.LevelName(o.Level), "--log-format", o.Format}
+}
+
+// openControllerLog opens .gc/logs/gc.log and returns writers that replace
+// the controller's stdout and stderr. Both pass their output through to
+// the originals unchanged and also record each line in the log file:
+// stdout lines at info level, stderr lines at warn level, or error level
+// when they report a failure. Code that wants richer records can recover
+// a logger with logging.FromWriter(stderr); its records go to both the
+// original stderr and the file. The returned func flushes pending lines
+// and closes the file. If the file cannot be opened, the original writers
+// are returned unchanged.
+func openControllerLog(cityPath string, stdout, stderr io.Writer) (io.Writer, io.Writer, func()) {
+	f, err := logging.OpenRotatingFile(filepath.Join(cityPath, controllerLogRel),
+		logging.DefaultMaxSize, logging.DefaultBackups)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc start: opening log: %v\n", err) //nolint:errcheck // best-effort stderr
+		return stdout, stderr, func() {}
+	}
+	fileLog := slog.New(logging.NewHandler(f, logging.Current()))
+	errW := logging.NewMirrorWriter(stderr, fileLog, slog.LevelWarn)
+	outW := logging.NewMirrorWriter(stdout, fileLog, slog.LevelInfo)
+	return outW, errW, func() {
+		errW.Flush()
+		outW.Flush()
+		f.Close() //nolint:errcheck // best-effort cleanup
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/logging"
+)
+
+func TestRunRejectsBadLogFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"--log-level", "loud", "version"},
+		{"--log-format", "xml", "version"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := run(args, &stdout, &stderr); code != 1 {
+			t.Errorf("run(%q) = %d, want 1", args, code)
+		}
+		if !strings.Contains(stderr.String(), "unknown log") {
+			t.Errorf("run(%q) stderr = %q", args, stderr.String())
+		}
+	}
+}
+
+func TestOpenControllerLog(t *testing.T) {
+	prev := logging.Current()
+	t.Cleanup(func() { logging.Configure(prev) })
+	logging.Configure(logging.Options{Format: "json"})
+
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	out, errW, closeLog := openControllerLog(dir, &stdout, &stderr)
+	out.Write([]byte("Controller started.\n"))                //nolint:errcheck // test writer
+	errW.Write([]byte("gc start: config reload: bad toml\n")) //nolint:errcheck // test writer
+	logging.FromWriter(errW).Error("wisp gc failed", "err", "boom")
+	closeLog()
+
+	if stdout.String() != "Controller started.\n" {
+		t.Errorf("stdout = %q, want the line passed through", stdout.String())
+	}
+	if got := stderr.String(); strings.Contains(got, "Controller started") ||
+		!strings.HasPrefix(got, "gc start: config reload: bad toml\n") ||
+		!strings.Contains(got, "wisp gc failed") {
+		t.Errorf("stderr = %q, want the raw line then the record", got)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, controllerLogRel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"msg":"Controller started."`, `"msg":"config reload: bad toml"`, `"component":"gc start"`, `"level":"ERROR"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("gc.log missing %s:\n%s", want, data)
+		}
+	}
+}
+
+func TestOpenControllerLogErrorLevel(t *testing.T) {
+	prev := logging.Current()
+	t.Cleanup(func() { logging.Configure(prev) })
+	logging.Configure(logging.Options{Level: slog.LevelError, Format: "json"})
+
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	_, errW, closeLog := openControllerLog(dir, &stdout, &stderr)
+	errW.Write([]byte("gc start: config reload failed: bad toml\n")) //nolint:errcheck // test writer
+	errW.Write([]byte("gc start: agent mayor: session failed\n"))    //nolint:errcheck // test writer
+	logging.FromWriter(errW).Warn("slow tick")
+	closeLog()
+
+	if got, want := stderr.String(), "gc start: config reload failed: bad toml\ngc start: agent mayor: session failed\n"; got != want {
+		t.Errorf("stderr = %q, want controller errors passed through as written", got)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, controllerLogRel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	if strings.Count(log, `"level":"ERROR"`) != 2 || strings.Contains(log, "slow tick") {
+		t.Errorf("gc.log = %s, want both errors at ERROR and no warnings", log)
+	}
+}
+
+func TestLogFlagArgs(t *testing.T) {
+	prev, prevDefault := logging.Current(), slog.Default()
+	t.Cleanup(func() {
+		logging.Configure(prev)
+		slog.SetDefault(prevDefault)
+	})
+	var stderr bytes.Buffer
+	if err := configureLogging("debug", "json", &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(logFlagArgs(), " "); got != "--log-level debug --log-format json" {
+		t.Errorf("logFlagArgs = %q", got)
+	}
+}
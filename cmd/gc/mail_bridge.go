@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/mail"
+	mailexec "github.com/gastownhall/gascity/internal/mail/exec"
+)
+
+// mailPollTick asks an exec: mail bridge for each running agent's unread
+// mail and queues a nudge for every message not yet announced. Mail sent
+// with gc mail send is announced by the sender; this covers mail that
+// arrives through the bridge from outside gc (Slack, email, chat). Other
+// providers are not polled.
+func (cr *CityRuntime) mailPollTick(now time.Time) {
+	name := os.Getenv("GC_MAIL")
+	if name == "" {
+		name = cr.cfg.Mail.Provider
+	}
+	interval := cr.cfg.Mail.PollIntervalDuration()
+	if !strings.HasPrefix(name, "exec:") || interval <= 0 {
+		return
+	}
+	if now.Sub(cr.mailPolledAt) < interval {
+		return
+	}
+	cr.mailPolledAt = now
+	if cr.mailBridge == nil || cr.mailBridgeName != name {
+		cr.mailBridge = mailexec.NewProvider(strings.TrimPrefix(name, "exec:"))
+		cr.mailBridgeName = name
+	}
+	if cr.mailAnnounced == nil {
+		cr.mailAnnounced = make(map[string]bool)
+	}
+	cityName := cr.cityName
+	if cityName == "" {
+		cityName = filepath.Base(cr.cityPath)
+	}
+	var agents []string
+	for _, t := range runningNudgeTargets(cr.cityPath, cityName, cr.cfg, cr.sp, nil) {
+		agents = append(agents, t.agent.QualifiedName())
+	}
+	for _, err := range announceBridgeMail(cr.cityPath, cr.mailBridge, agents, cr.mailAnnounced, now) {
+		fmt.Fprintf(cr.stderr, "%s: mail poll: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+	}
+}
+
+// announceBridgeMail checks each agent's unread mail and queues a nudge
+// for messages not in announced. announced is rebuilt from the agents
+// polled, so it stays bounded by the unread mail in flight.
+func announceBridgeMail(cityPath string, mp mail.Provider, agents []string, announced map[string]bool, now time.Time) []error {
+	var errs []error
+	current := make(map[string]bool)
+	for _, agent := range agents {
+		msgs, err := mp.Check(agent)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("checking %s: %w", agent, err))
+			// Keep what was announced so a transient bridge error
+			// doesn't re-announce this agent's mail next poll.
+			for id := range announced {
+				current[id] = true
+			}
+			continue
+		}
+		for _, m := range msgs {
+			current[m.ID] = true
+			if announced[m.ID] {
+				continue
+			}
+			text := fmt.Sprintf("You have mail from %s", m.From)
+			if m.Subject != "" {
+				text += ": " + m.Subject
+			}
+			text += " (gc mail read " + m.ID + ")"
+			if err := enqueueQueuedNudge(cityPath, newQueuedNudge(agent, text, "mail", now)); err != nil {
+				errs = append(errs, fmt.Errorf("nudging %s: %w", agent, err))
+				delete(current, m.ID) // retry next poll
+			}
+		}
+	}
+	for id := range announced {
+		delete(announced, id)
+	}
+	for id := range current {
+		announced[id] = true
+	}
+	return errs
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/mail"
+)
+
+func TestAnnounceBridgeMail(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	mp := mail.NewFake()
+	first, _ := mp.Send("slack:alice", "mayor", "deploy", "ship it")
+	announced := map[string]bool{}
+
+	if errs := announceBridgeMail(dir, mp, []string{"mayor", "deacon"}, announced, now); len(errs) != 0 {
+		t.Fatalf("errors: %v", errs)
+	}
+	pending, _, _, err := listQueuedNudges(dir, "mayor", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("pending = %d, want 1", len(pending))
+	}
+	if msg := pending[0].Message; !strings.Contains(msg, "slack:alice") || !strings.Contains(msg, first.ID) {
+		t.Errorf("nudge = %q, want sender and message ID", msg)
+	}
+
+	// Already announced: no second nudge. New mail is announced.
+	_, _ = mp.Send("slack:bob", "mayor", "", "hello")
+	_ = announceBridgeMail(dir, mp, []string{"mayor"}, announced, now)
+	pending, _, _, _ = listQueuedNudges(dir, "mayor", now)
+	if len(pending) != 2 {
+		t.Errorf("pending = %d, want 2", len(pending))
+	}
+
+	// Read mail drops out of the announced set.
+	_ = mp.MarkRead(first.ID)
+	_ = announceBridgeMail(dir, mp, []string{"mayor"}, announced, now)
+	if announced[first.ID] || len(announced) != 1 {
+		t.Errorf("announced = %v, want only the unread message", announced)
+	}
+}
+
+func TestAnnounceBridgeMailErrorKeepsAnnounced(t *testing.T) {
+	announced := map[string]bool{"m-1": true}
+	errs := announceBridgeMail(t.TempDir(), mail.NewFailFake(), []string{"mayor"}, announced, time.Now())
+	if len(errs) != 1 {
+		t.Fatalf("errors = %v, want 1", errs)
+	}
+	if !announced["m-1"] {
+		t.Error("announced set cleared by bridge error")
+	}
+}
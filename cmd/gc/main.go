@@ -17,6 +17,7 @@ import (
 	beadsexec "github.com/gastownhall/gascity/internal/beads/exec"
 	"github.com/gastownhall/gascity/internal/citylayout"
 	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/formula"
 	"github.com/gastownhall/gascity/internal/fsys"
 	"github.com/gastownhall/gascity/internal/telemetry"
 	"github.com/spf13/cobra"
@@ -34,6 +35,13 @@ var errExit = errors.New("exit")
 // Empty means "discover from cwd."
 var cityFlag string
 
+// logLevelFlag and logFormatFlag hold the --log-level and --log-format
+// persistent flags. Defaults come from GC_LOG_LEVEL and GC_LOG_FORMAT.
+var (
+	logLevelFlag  string
+	logFormatFlag string
+)
+
 // run executes the gc CLI with the given args, writing output to stdout and
 // errors to stderr. Returns the exit code.
 func run(args []string, stdout, stderr io.Writer) int {
@@ -87,38 +95,67 @@ func newRootCmd(stdout, stderr io.Writer) *cobra.Command {
 	}
 	root.PersistentFlags().StringVar(&cityFlag, "city", "",
 		"path to the city directory (default: walk up from cwd)")
+	root.PersistentFlags().StringVar(&logLevelFlag, "log-level", envOr("GC_LOG_LEVEL", "info"),
+		"minimum log level: debug, info, warn, or error (env GC_LOG_LEVEL)")
+	root.PersistentFlags().StringVar(&logFormatFlag, "log-format", envOr("GC_LOG_FORMAT", "text"),
+		"log record format: text or json (env GC_LOG_FORMAT)")
+	root.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		if err := configureLogging(logLevelFlag, logFormatFlag, stderr); err != nil {
+			fmt.Fprintf(stderr, "gc: %v\n", err) //nolint:errcheck // best-effort stderr
+			return errExit
+		}
+		return nil
+	}
 	root.CompletionOptions.DisableDefaultCmd = true
 	root.AddCommand(
 		newStartCmd(stdout, stderr),
 		newInitCmd(stdout, stderr),
 		newStopCmd(stdout, stderr),
+		newDownCmd(stdout, stderr),
 		newRestartCmd(stdout, stderr),
 		newStatusCmd(stdout, stderr),
 		newServiceCmd(stdout, stderr),
 		newSuspendCmd(stdout, stderr),
 		newResumeCmd(stdout, stderr),
+		newBackupCmd(stdout, stderr),
+		newRestoreCmd(stdout, stderr),
 		newRigCmd(stdout, stderr),
 		newMailCmd(stdout, stderr),
 		newNudgeCmd(stdout, stderr),
 		newAgentCmd(stdout, stderr),
+		newPoolCmd(stdout, stderr),
 		newEventCmd(stdout, stderr),
 		newEventsCmd(stdout, stderr),
+		newLogsCmd(stdout, stderr),
 		newAutomationCmd(stdout, stderr),
+		newScheduleCmd(stdout, stderr),
+		newFormulaCmd(stdout, stderr),
 		newConfigCmd(stdout, stderr),
 		newPackCmd(stdout, stderr),
 		newDoctorCmd(stdout, stderr),
 		newHookCmd(stdout, stderr),
+		newHooksCmd(stdout, stderr),
 		newSlingCmd(stdout, stderr),
+		newUnslingCmd(stdout, stderr),
 		newConvoyCmd(stdout, stderr),
 		newPrimeCmd(stdout, stderr),
 		newHandoffCmd(stdout, stderr),
 		newDaemonCmd(stdout, stderr),
 		newBeadsCmd(stdout, stderr),
+		newBeadCmd(stdout, stderr),
+		newGCWispsCmd(stdout, stderr),
+		newUsageCmd(stdout, stderr),
+		newStatsCmd(stdout, stderr),
 		newBuildImageCmd(stdout, stderr),
 		newSkillCmd(stdout, stderr),
 		newVersionCmd(stdout),
 		newDashboardCmd(stdout, stderr),
+		newTopCmd(stdout, stderr),
+		newWebCmd(stdout, stderr),
+		newServeCmd(stdout, stderr),
+		newFederationCmd(stdout, stderr),
 		newGraphCmd(stdout, stderr),
+		newDepsCmd(stdout, stderr),
 		newRegisterCmd(stdout, stderr),
 		newUnregisterCmd(stdout, stderr),
 		newCitiesCmd(stdout, stderr),
@@ -128,12 +165,16 @@ func newRootCmd(stdout, stderr io.Writer) *cobra.Command {
 		newConvergeCmd(stdout, stderr),
 		newRuntimeCmd(stdout, stderr),
 	)
-	// gen-doc needs the root command to walk the tree; add after construction.
+	// gen-doc and completion need the root command to walk the tree; add
+	// after construction.
 	root.AddCommand(newGenDocCmd(stdout, stderr, root))
+	root.AddCommand(newCompletionCmd(stdout, stderr, root))
 
 	// Best-effort: discover pack CLI commands if we're inside a city.
 	registerPackCommands(root, stdout, stderr)
 
+	registerCompletions(root)
+
 	return root
 }
 
@@ -272,11 +313,29 @@ func openCityStore(stderr io.Writer, cmdName string) (beads.Store, int) {
 	return store, 0
 }
 
+// openRigStoreAt opens the bead store that holds a rig's beads. With the
+// bd provider each rig has its own database under rigDir; other providers
+// keep a single city-level store, opened so that beads created through it
+// get the rig's ID prefix. An empty rigDir means the city store.
+func openRigStoreAt(cityPath, rigDir string) (beads.Store, error) {
+	provider := rawBeadsProvider(cityPath)
+	if rigDir != "" && provider == "bd" {
+		return openBdStore(cityPath, rigDir)
+	}
+	return openStoreAt(cityPath, provider, rigDir)
+}
+
 // openCityStoreAt opens a bead store at the given city path.
 // Used by the controller (which already knows the city path) and by
 // openCityStore (which resolves the path first).
 func openCityStoreAt(cityPath string) (beads.Store, error) {
-	provider := rawBeadsProvider(cityPath)
+	return openStoreAt(cityPath, rawBeadsProvider(cityPath), "")
+}
+
+// openStoreAt opens the city's store for provider, minting IDs for the
+// rig at rigDir (or the city when "") under the file and sqlite
+// providers.
+func openStoreAt(cityPath, provider, rigDir string) (beads.Store, error) {
 	if strings.HasPrefix(provider, "exec:") {
 		store := beadsexec.NewStore(strings.TrimPrefix(provider, "exec:"))
 		store.SetEnv(citylayout.CityRuntimeEnvMap(cityPath))
@@ -284,15 +343,83 @@ func openCityStoreAt(cityPath string) (beads.Store, error) {
 	}
 	switch provider {
 	case "file":
+		ids, err := beadIDScheme(cityPath, rigDir)
+		if err != nil {
+			return nil, err
+		}
 		store, err := beads.OpenFileStore(fsys.OSFS{}, filepath.Join(cityPath, ".gc", "beads.json"))
 		if err != nil {
 			return nil, err
 		}
-		return store, nil
+		store.SetIDScheme(ids)
+		return withLocalCook(store, cityPath), nil
+	case "sqlite":
+		ids, err := beadIDScheme(cityPath, rigDir)
+		if err != nil {
+			return nil, err
+		}
+		store, err := beads.OpenSQLiteStore(fsys.OSFS{}, filepath.Join(cityPath, ".gc", "beads.db"))
+		if err != nil {
+			return nil, err
+		}
+		store.SetIDScheme(ids)
+		return withLocalCook(store, cityPath), nil
 	default: // "bd" or unrecognized → use bd
 		if _, err := exec.LookPath("bd"); err != nil {
 			return nil, fmt.Errorf("bd not found in PATH (install beads or set GC_BEADS=file)")
 		}
-		return beads.NewBdStore(cityPath, beads.ExecCommandRunner()), nil
+		return openBdStore(cityPath, cityPath)
+	}
+}
+
+// beadIDScheme returns how the file and sqlite providers mint IDs for
+// beads of the rig at rigDir, or of the city when rigDir is "": the
+// [beads] id_* settings, with the rig's prefix and overrides for a rig.
+// A city without a city.toml gets the default gc-N scheme; one that
+// fails to load is an error, since its prefixes cannot be known.
+func beadIDScheme(cityPath, rigDir string) (beads.IDScheme, error) {
+	cfg, err := loadCityConfig(cityPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return beads.IDScheme{}, nil
+	}
+	if err != nil {
+		return beads.IDScheme{}, err
+	}
+	ids := beads.IDScheme{Prefix: cfg.Beads.IDPrefix, Format: cfg.Beads.IDFormat, Pad: cfg.Beads.IDPad}
+	if rigDir != "" {
+		resolveRigPaths(cityPath, cfg.Rigs)
+		for _, r := range cfg.Rigs {
+			if filepath.Clean(r.Path) != filepath.Clean(rigDir) {
+				continue
+			}
+			ids.Prefix = r.EffectivePrefix()
+			if r.IDFormat != "" {
+				ids.Format = r.IDFormat
+			}
+			if r.IDPad != nil {
+				ids.Pad = *r.IDPad
+			}
+		}
 	}
+	if err := ids.Validate(); err != nil {
+		return beads.IDScheme{}, fmt.Errorf("city.toml: %w", err)
+	}
+	return ids, nil
+}
+
+// withLocalCook wraps a store that has no bd behind it so MolCook and
+// MolCookOn cook formulas from the city's formula layers. The layers are
+// resolved at cook time so formula edits need no restart.
+func withLocalCook(store beads.Store, cityPath string) beads.Store {
+	return formula.NewCookingStore(store, func(name string) (formula.Formula, error) {
+		cfg, err := loadCityConfig(cityPath)
+		if err != nil {
+			return formula.Formula{}, err
+		}
+		layers, err := formulaLayersFor(cityPath, cfg, "")
+		if err != nil {
+			return formula.Formula{}, err
+		}
+		return formula.LayerResolver(layers)(name)
+	})
 }
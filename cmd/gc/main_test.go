@@ -15,6 +15,7 @@ import (
 	"github.com/gastownhall/gascity/internal/beads"
 	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/formula"
 	"github.com/gastownhall/gascity/internal/fsys"
 	"github.com/gastownhall/gascity/internal/runtime"
 	"github.com/rogpeppe/go-internal/testscript"
@@ -219,7 +220,7 @@ func TestDoRigAddCreatesDirIfMissing(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -239,7 +240,7 @@ func TestDoRigAddMkdirRigPathFails(t *testing.T) {
 	f.Errors["/projects/myapp"] = fmt.Errorf("permission denied")
 
 	var stderr bytes.Buffer
-	code := doRigAdd(f, "/city", "/projects/myapp", "", false, &bytes.Buffer{}, &stderr)
+	code := doRigAdd(f, "/city", "/projects/myapp", rigAddOpts{}, &bytes.Buffer{}, &stderr)
 	if code != 1 {
 		t.Errorf("doRigAdd = %d, want 1", code)
 	}
@@ -253,7 +254,7 @@ func TestDoRigAddNotADirectory(t *testing.T) {
 	f.Files["/projects/myapp"] = []byte("not a dir") // file, not directory
 
 	var stderr bytes.Buffer
-	code := doRigAdd(f, "/city", "/projects/myapp", "", false, &bytes.Buffer{}, &stderr)
+	code := doRigAdd(f, "/city", "/projects/myapp", rigAddOpts{}, &bytes.Buffer{}, &stderr)
 	if code != 1 {
 		t.Errorf("doRigAdd = %d, want 1", code)
 	}
@@ -283,7 +284,7 @@ func TestDoRigAddWithGit(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -313,7 +314,7 @@ func TestDoRigAddWithoutGit(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, "", false, &stdout, &stderr)
+	code := doRigAdd(fsys.OSFS{}, cityPath, rigPath, rigAddOpts{}, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doRigAdd = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -1267,13 +1268,13 @@ func TestDoInitWithProviderFlagAndBootstrapProfile(t *testing.T) {
 }
 
 func TestInitWizardConfigRejectsUnknownProvider(t *testing.T) {
-	if _, err := initWizardConfig("not-a-provider", ""); err == nil {
+	if _, err := initWizardConfig("not-a-provider", "", ""); err == nil {
 		t.Fatal("expected error for unknown provider")
 	}
 }
 
 func TestInitWizardConfigNormalizesBootstrapAliases(t *testing.T) {
-	wiz, err := initWizardConfig("codex", "kubernetes")
+	wiz, err := initWizardConfig("codex", "kubernetes", "")
 	if err != nil {
 		t.Fatalf("initWizardConfig returned error: %v", err)
 	}
@@ -1463,7 +1464,7 @@ func TestDoInitFromDirSuccess(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doInitFromDir(srcDir, cityPath, &stdout, &stderr)
+	code := doInitFromDir(srcDir, cityPath, nil, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doInitFromDir = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -1528,7 +1529,7 @@ func TestDoInitFromDirSkipsGCDir(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doInitFromDir(srcDir, cityPath, &stdout, &stderr)
+	code := doInitFromDir(srcDir, cityPath, nil, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doInitFromDir = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -1572,7 +1573,7 @@ func TestDoInitFromDirSkipsTestFiles(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doInitFromDir(srcDir, cityPath, &stdout, &stderr)
+	code := doInitFromDir(srcDir, cityPath, nil, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doInitFromDir = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -1591,7 +1592,7 @@ func TestDoInitFromDirNoCityToml(t *testing.T) {
 	srcDir := t.TempDir() // no city.toml
 
 	var stderr bytes.Buffer
-	code := doInitFromDir(srcDir, filepath.Join(t.TempDir(), "dst"), &bytes.Buffer{}, &stderr)
+	code := doInitFromDir(srcDir, filepath.Join(t.TempDir(), "dst"), nil, &bytes.Buffer{}, &stderr)
 	if code != 1 {
 		t.Errorf("code = %d, want 1", code)
 	}
@@ -1618,7 +1619,7 @@ func TestDoInitFromDirAlreadyInitialized(t *testing.T) {
 	}
 
 	var stderr bytes.Buffer
-	code := doInitFromDir(srcDir, cityPath, &bytes.Buffer{}, &stderr)
+	code := doInitFromDir(srcDir, cityPath, nil, &bytes.Buffer{}, &stderr)
 	if code != 1 {
 		t.Errorf("code = %d, want 1", code)
 	}
@@ -1649,7 +1650,7 @@ func TestDoInitFromDirAlreadyInitializedByCityToml(t *testing.T) {
 	}
 
 	var stderr bytes.Buffer
-	code := doInitFromDir(srcDir, cityPath, &bytes.Buffer{}, &stderr)
+	code := doInitFromDir(srcDir, cityPath, nil, &bytes.Buffer{}, &stderr)
 	if code != 1 {
 		t.Errorf("code = %d, want 1", code)
 	}
@@ -1683,7 +1684,7 @@ func TestDoInitFromDirPreservesPermissions(t *testing.T) {
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doInitFromDir(srcDir, cityPath, &stdout, &stderr)
+	code := doInitFromDir(srcDir, cityPath, nil, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doInitFromDir = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -1832,7 +1833,7 @@ func TestDoAgentAddSuccess(t *testing.T) {
 	f.Files[filepath.Join("/city", "city.toml")] = data
 
 	var stdout, stderr bytes.Buffer
-	code := doAgentAdd(f, "/city", "worker", "", "", false, &stdout, &stderr)
+	code := doAgentAdd(f, "/city", "worker", "", "", "", false, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doAgentAdd = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -1870,7 +1871,7 @@ func TestDoAgentAddDuplicate(t *testing.T) {
 	f.Files[filepath.Join("/city", "city.toml")] = data
 
 	var stderr bytes.Buffer
-	code := doAgentAdd(f, "/city", "mayor", "", "", false, &bytes.Buffer{}, &stderr)
+	code := doAgentAdd(f, "/city", "mayor", "", "", "", false, &bytes.Buffer{}, &stderr)
 	if code != 1 {
 		t.Errorf("doAgentAdd = %d, want 1", code)
 	}
@@ -1884,7 +1885,7 @@ func TestDoAgentAddLoadFails(t *testing.T) {
 	// No city.toml → load fails.
 
 	var stderr bytes.Buffer
-	code := doAgentAdd(f, "/city", "worker", "", "", false, &bytes.Buffer{}, &stderr)
+	code := doAgentAdd(f, "/city", "worker", "", "", "", false, &bytes.Buffer{}, &stderr)
 	if code != 1 {
 		t.Errorf("doAgentAdd = %d, want 1", code)
 	}
@@ -2009,7 +2010,7 @@ func TestDoAgentAddWithPromptTemplate(t *testing.T) {
 	f.Files[filepath.Join("/city", "city.toml")] = data
 
 	var stdout, stderr bytes.Buffer
-	code := doAgentAdd(f, "/city", "worker", "prompts/worker.md", "", false, &stdout, &stderr)
+	code := doAgentAdd(f, "/city", "worker", "prompts/worker.md", "", "", false, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doAgentAdd = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -2314,7 +2315,7 @@ prompt_template = "prompts/mayor.md"
 	}
 
 	var stdout, stderr bytes.Buffer
-	code := doPrimeWithMode(nil, &stdout, &stderr, true)
+	code := doPrimeWithMode(nil, &stdout, &stderr, true, false)
 	if code != 0 {
 		t.Fatalf("doPrimeWithMode = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -2380,7 +2381,7 @@ func TestDoAgentAddWithDir(t *testing.T) {
 	f.Files[filepath.Join("/city", "city.toml")] = data
 
 	var stdout, stderr bytes.Buffer
-	code := doAgentAdd(f, "/city", "builder", "prompts/worker.md", "hello-world", false, &stdout, &stderr)
+	code := doAgentAdd(f, "/city", "builder", "prompts/worker.md", "hello-world", "", false, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doAgentAdd = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -2408,7 +2409,7 @@ func TestDoAgentAddWithSuspended(t *testing.T) {
 	f.Files[filepath.Join("/city", "city.toml")] = data
 
 	var stdout, stderr bytes.Buffer
-	code := doAgentAdd(f, "/city", "builder", "prompts/worker.md", "hello-world", true, &stdout, &stderr)
+	code := doAgentAdd(f, "/city", "builder", "prompts/worker.md", "hello-world", "", true, &stdout, &stderr)
 	if code != 0 {
 		t.Fatalf("doAgentAdd = %d, want 0; stderr: %s", code, stderr.String())
 	}
@@ -2548,3 +2549,100 @@ func TestDoAgentResumeNotFound(t *testing.T) {
 		t.Errorf("stderr = %q, want 'not found'", stderr.String())
 	}
 }
+
+func TestOpenCityStoreAtSQLite(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GC_BEADS", "sqlite")
+
+	store, err := openCityStoreAt(dir)
+	if err != nil {
+		t.Fatalf("openCityStoreAt: %v", err)
+	}
+	cooking, ok := store.(*formula.CookingStore)
+	if !ok {
+		t.Fatalf("store = %T, want *formula.CookingStore", store)
+	}
+	if _, ok := cooking.Store.(*beads.SQLiteStore); !ok {
+		t.Fatalf("wrapped store = %T, want *beads.SQLiteStore", cooking.Store)
+	}
+	b, err := store.Create(beads.Bead{Title: "persisted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".gc", "beads.db")); err != nil {
+		t.Errorf("expected .gc/beads.db: %v", err)
+	}
+
+	reopened, err := openCityStoreAt(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.Get(b.ID); err != nil {
+		t.Errorf("Get(%q) after reopen: %v", b.ID, err)
+	}
+}
+
+func TestOpenRigStoreAtFileUsesRigPrefix(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GC_BEADS", "file")
+	toml := `[workspace]
+name = "city"
+
+[beads]
+id_pad = 3
+
+[[rigs]]
+name = "api-server"
+path = "api"
+
+[[rigs]]
+name = "web"
+path = "web"
+prefix = "wb"
+id_format = "ulid"
+`
+	if err := os.WriteFile(filepath.Join(dir, "city.toml"), []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	city, err := openCityStoreAt(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api, err := openRigStoreAt(dir, filepath.Join(dir, "api"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	web, err := openRigStoreAt(dir, filepath.Join(dir, "web"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := city.Create(beads.Bead{Title: "city"})
+	a, _ := api.Create(beads.Bead{Title: "api"})
+	w, _ := web.Create(beads.Bead{Title: "web"})
+	if c.ID != "gc-001" || a.ID != "as-002" {
+		t.Errorf("IDs = %q, %q; want gc-001, as-002", c.ID, a.ID)
+	}
+	if !strings.HasPrefix(w.ID, "wb-") || len(w.ID) != len("wb-")+26 {
+		t.Errorf("web ID = %q, want wb-<ulid>", w.ID)
+	}
+	// One file backs them all.
+	reopened, err := openCityStoreAt(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.Get(a.ID); err != nil {
+		t.Errorf("city store Get(%s): %v", a.ID, err)
+	}
+}
+
+func TestOpenCityStoreAtFileBrokenConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GC_BEADS", "file")
+	if err := os.WriteFile(filepath.Join(dir, "city.toml"), []byte("[workspace\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openCityStoreAt(dir); err == nil {
+		t.Fatal("openCityStoreAt with a broken city.toml succeeded, want error")
+	}
+}
@@ -145,6 +145,7 @@ func deepCopyAgent(src *config.Agent, name, dir string) config.Agent {
 		Dir:                 dir,
 		Scope:               src.Scope,
 		Session:             src.Session,
+		Host:                src.Host,
 		Provider:            src.Provider,
 		PromptTemplate:      src.PromptTemplate,
 		Nudge:               src.Nudge,
@@ -152,6 +153,7 @@ func deepCopyAgent(src *config.Agent, name, dir string) config.Agent {
 		PromptMode:          src.PromptMode,
 		PromptFlag:          src.PromptFlag,
 		ReadyPromptPrefix:   src.ReadyPromptPrefix,
+		ReadyProbe:          src.ReadyProbe,
 		DefaultSlingFormula: src.DefaultSlingFormula,
 		WorkQuery:           src.WorkQuery,
 		SlingQuery:          src.SlingQuery,
@@ -160,11 +162,13 @@ func deepCopyAgent(src *config.Agent, name, dir string) config.Agent {
 		SourceDir:           src.SourceDir,
 		Fallback:            src.Fallback,
 		IdleTimeout:         src.IdleTimeout,
+		RateLimit:           src.RateLimit,
 		Suspended:           src.Suspended,
 		ResumeCommand:       src.ResumeCommand,
 		WakeMode:            src.WakeMode,
 		PoolName:            src.QualifiedName(),
 		Implicit:            src.Implicit,
+		EnvFile:             src.EnvFile,
 	}
 	if len(src.DependsOn) > 0 {
 		dst.DependsOn = make([]string, len(src.DependsOn))
@@ -184,6 +188,10 @@ func deepCopyAgent(src *config.Agent, name, dir string) config.Agent {
 			dst.Env[k] = v
 		}
 	}
+	if len(src.EnvFromKeychain) > 0 {
+		dst.EnvFromKeychain = make([]string, len(src.EnvFromKeychain))
+		copy(dst.EnvFromKeychain, src.EnvFromKeychain)
+	}
 	if len(src.PreStart) > 0 {
 		dst.PreStart = make([]string, len(src.PreStart))
 		copy(dst.PreStart, src.PreStart)
@@ -204,6 +212,14 @@ func deepCopyAgent(src *config.Agent, name, dir string) config.Agent {
 		dst.InstallAgentHooks = make([]string, len(src.InstallAgentHooks))
 		copy(dst.InstallAgentHooks, src.InstallAgentHooks)
 	}
+	if len(src.Capabilities) > 0 {
+		dst.Capabilities = make([]string, len(src.Capabilities))
+		copy(dst.Capabilities, src.Capabilities)
+	}
+	if len(src.Gates) > 0 {
+		dst.Gates = make([]string, len(src.Gates))
+		copy(dst.Gates, src.Gates)
+	}
 	if src.Pool != nil {
 		poolCopy := *src.Pool
 		dst.Pool = &poolCopy
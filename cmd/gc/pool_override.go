@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// poolOverride is an operator's manual control of one pool, set by
+// "gc pool scale" and "gc pool stop-instance". The controller honors it
+// in place of the pool's check command.
+type poolOverride struct {
+	// Size is the member count set by gc pool scale; nil leaves sizing
+	// to the check command.
+	Size *int `json:"size,omitempty"`
+	// Stopped lists qualified instance names held stopped by
+	// gc pool stop-instance.
+	Stopped []string `json:"stopped,omitempty"`
+}
+
+// poolOverrides maps pool qualified names to their overrides.
+type poolOverrides map[string]poolOverride
+
+func poolOverridesPath(cityPath string) string {
+	return citylayout.RuntimePath(cityPath, "state", "pool-overrides.json")
+}
+
+// loadPoolOverrides reads the override file. A missing file means no
+// pool is under manual control.
+func loadPoolOverrides(cityPath string) (poolOverrides, error) {
+	data, err := os.ReadFile(poolOverridesPath(cityPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return poolOverrides{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading pool overrides: %w", err)
+	}
+	o := poolOverrides{}
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("parsing pool overrides: %w", err)
+	}
+	return o, nil
+}
+
+// savePoolOverrides writes o, dropping pools with nothing overridden.
+func savePoolOverrides(cityPath string, o poolOverrides) error {
+	for name, po := range o {
+		if po.Size == nil && len(po.Stopped) == 0 {
+			delete(o, name)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(poolOverridesPath(cityPath)), 0o755); err != nil {
+		return fmt.Errorf("creating state dir: %w", err)
+	}
+	data, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal pool overrides: %w", err)
+	}
+	if err := fsys.WriteFileAtomic(fsys.OSFS{}, poolOverridesPath(cityPath), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write pool overrides: %w", err)
+	}
+	return nil
+}
+
+// manualSize returns the pool's manual size clamped to [min, max], and
+// whether one is set.
+func (po poolOverride) manualSize(pool config.PoolConfig) (int, bool) {
+	if po.Size == nil {
+		return 0, false
+	}
+	n := max(*po.Size, pool.Min)
+	if pool.Max >= 0 {
+		n = min(n, pool.Max)
+	}
+	return n, true
+}
+
+// isStopped reports whether instance is held stopped.
+func (po poolOverride) isStopped(instance string) bool {
+	return slices.Contains(po.Stopped, instance)
+}
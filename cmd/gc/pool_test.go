@@ -360,6 +360,7 @@ func TestDeepCopyAgentCoversAllFields(t *testing.T) {
 		PromptTemplate:         "prompts/test.md",
 		Nudge:                  "nudge text",
 		Session:                "acp",
+		Host:                   "dev@build-box",
 		Provider:               "claude",
 		StartCommand:           "claude --dangerously",
 		Args:                   []string{"--arg1"},
@@ -367,13 +368,17 @@ func TestDeepCopyAgentCoversAllFields(t *testing.T) {
 		PromptFlag:             "--prompt",
 		ReadyDelayMs:           &intVal,
 		ReadyPromptPrefix:      "ready>",
+		ReadyProbe:             "regex:^> $",
 		ProcessNames:           []string{"claude"},
 		EmitsPermissionWarning: &trueVal,
 		Env:                    map[string]string{"K": "V"},
+		EnvFile:                ".gc/secrets.env",
+		EnvFromKeychain:        []string{"ANTHROPIC_API_KEY"},
 		Pool:                   &config.PoolConfig{Min: 1, Max: 5, Check: "echo 3"},
 		WorkQuery:              "bd ready",
 		SlingQuery:             "bd update {}",
 		IdleTimeout:            "15m",
+		RateLimit:              "4/m",
 		InstallAgentHooks:      []string{"claude"},
 		HooksInstalled:         &trueVal,
 		SessionSetup:           []string{"setup-cmd"},
@@ -382,6 +387,8 @@ func TestDeepCopyAgentCoversAllFields(t *testing.T) {
 		OverlayDir:             "overlays/test",
 		SourceDir:              "/src",
 		DefaultSlingFormula:    "mol-work",
+		Capabilities:           []string{"go"},
+		Gates:                  []string{"go test ./..."},
 		InjectFragments:        []string{"frag1"},
 		Attach:                 &trueVal,
 		Fallback:               true,
@@ -431,6 +438,8 @@ func TestDeepCopyAgentCoversAllFields(t *testing.T) {
 	src.ProcessNames[0] = "MUTATED"
 	src.InjectFragments[0] = "MUTATED"
 	src.InstallAgentHooks[0] = "MUTATED"
+	src.Capabilities[0] = "MUTATED"
+	src.Gates[0] = "MUTATED"
 	src.Pool.Min = 999
 
 	if dst.PreStart[0] == "MUTATED" {
@@ -454,6 +463,12 @@ func TestDeepCopyAgentCoversAllFields(t *testing.T) {
 	if dst.InstallAgentHooks[0] == "MUTATED" {
 		t.Error("InstallAgentHooks is not a deep copy")
 	}
+	if dst.Capabilities[0] == "MUTATED" {
+		t.Error("Capabilities is not a deep copy")
+	}
+	if dst.Gates[0] == "MUTATED" {
+		t.Error("Gates is not a deep copy")
+	}
 	if dst.Pool.Min == 999 {
 		t.Error("Pool is not a deep copy")
 	}
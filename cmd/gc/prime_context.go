@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/mail"
+)
+
+// primeContextSources holds the backends gc prime --with-context reads.
+// A nil source skips its section.
+type primeContextSources struct {
+	store  beads.Store
+	mail   mail.Provider
+	events events.Provider
+}
+
+// primeIdentity names an agent the ways its work is addressed: mail goes
+// to the qualified agent name, beads are assigned to the session name, and
+// pool work carries a pool:<label> label.
+type primeIdentity struct {
+	agent     string
+	session   string
+	poolLabel string // empty for fixed agents
+}
+
+// openPrimeContext opens the context sources and identity for agent a.
+// Sources that fail to open are left nil. Callers must call closeFn.
+func openPrimeContext(cityPath, cityName string, cfg *config.City, a config.Agent) (src primeContextSources, id primeIdentity, closeFn func()) {
+	id.agent = a.QualifiedName()
+	id.session = os.Getenv("GC_SESSION_NAME")
+	if id.session == "" {
+		id.session = cliSessionName(cityPath, cityName, id.agent, cfg.Workspace.SessionTemplate)
+	}
+	switch {
+	case a.PoolName != "":
+		id.poolLabel = a.PoolName
+	case a.IsPool():
+		id.poolLabel = id.agent
+	}
+
+	if store, err := openRigStoreAt(cityPath, rigDirForAgent(cfg, a)); err == nil {
+		src.store = store
+	}
+	if mp, code := openCityMailProvider(io.Discard, "gc prime"); code == 0 {
+		src.mail = mp
+	}
+	closeFn = func() {}
+	if ep, code := openCityEventsProvider(io.Discard, "gc prime"); code == 0 {
+		src.events = ep
+		closeFn = func() { ep.Close() } //nolint:errcheck // best-effort close
+	}
+	return src, id, closeFn
+}
+
+// writePrimeContext appends a "Current context" section to a primed
+// prompt: the agent's claimed beads, the head of its ready queue, unread
+// mail, and recent events about it. Every source is best-effort; a
+// failing backend drops its section instead of the prompt.
+func writePrimeContext(w io.Writer, src primeContextSources, id primeIdentity, pc config.PrimeConfig) {
+	var b strings.Builder
+	b.WriteString("\n\n## Current context\n")
+
+	if src.store != nil && id.session != "" {
+		claimed, err := src.store.ListByAssignee(id.session, "in_progress", 0)
+		if err == nil {
+			b.WriteString("\n### Claimed work\n\n")
+			if len(claimed) == 0 {
+				b.WriteString("Nothing claimed.\n")
+			}
+			for _, c := range claimed {
+				fmt.Fprintf(&b, "- %s: %s\n", c.ID, c.Title)
+				if d := strings.TrimSpace(c.Description); d != "" {
+					b.WriteString(indentLines(d, "  ") + "\n")
+				}
+			}
+		}
+		if ready, err := src.store.Ready(); err == nil {
+			queue := primeReadyQueue(ready, id, pc.EffectiveQueueLimit())
+			fmt.Fprintf(&b, "\n### Ready queue (%d)\n\n", len(queue))
+			if len(queue) == 0 {
+				b.WriteString("No ready work.\n")
+			}
+			for _, r := range queue {
+				fmt.Fprintf(&b, "- %s [P%d]: %s\n", r.ID, r.PriorityOrDefault(), r.Title)
+			}
+		}
+	}
+
+	if src.mail != nil && id.agent != "" {
+		if msgs, err := src.mail.Check(id.agent); err == nil {
+			fmt.Fprintf(&b, "\n### Unread mail (%d)\n\n", len(msgs))
+			if len(msgs) == 0 {
+				b.WriteString("No unread mail.\n")
+			}
+			for _, m := range msgs {
+				fmt.Fprintf(&b, "- %s from %s: %s\n", m.ID, m.From, m.Subject)
+			}
+		}
+	}
+
+	if src.events != nil {
+		if evs, err := src.events.List(events.Filter{}); err == nil {
+			recent := primeRecentEvents(evs, id, pc.EffectiveEventLimit())
+			if len(recent) > 0 {
+				b.WriteString("\n### Recent events\n\n")
+				for _, e := range recent {
+					line := fmt.Sprintf("- %s %s", e.Ts.Format("2006-01-02 15:04"), e.Type)
+					if e.Subject != "" {
+						line += " " + e.Subject
+					}
+					if e.Message != "" {
+						line += ": " + e.Message
+					}
+					b.WriteString(line + "\n")
+				}
+			}
+		}
+	}
+
+	fmt.Fprint(w, b.String()) //nolint:errcheck // best-effort stdout
+}
+
+// primeReadyQueue picks the ready beads addressed to the agent: those
+// assigned to its session, plus unassigned pool work for pool agents.
+// Ordered by priority; at most limit entries.
+func primeReadyQueue(ready []beads.Bead, id primeIdentity, limit int) []beads.Bead {
+	var out []beads.Bead
+	for _, r := range ready {
+		switch {
+		case id.session != "" && r.Assignee == id.session:
+		case id.poolLabel != "" && r.Assignee == "" && slices.Contains(r.Labels, "pool:"+id.poolLabel):
+		default:
+			continue
+		}
+		out = append(out, r)
+	}
+	beads.SortByPriority(out)
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// primeRecentEvents returns the last limit events whose actor or subject
+// is the agent or its session, oldest first.
+func primeRecentEvents(evs []events.Event, id primeIdentity, limit int) []events.Event {
+	mine := func(s string) bool {
+		return s != "" && (s == id.agent || s == id.session)
+	}
+	var out []events.Event
+	for i := len(evs) - 1; i >= 0 && len(out) < limit; i-- {
+		if mine(evs[i].Actor) || mine(evs[i].Subject) {
+			out = append(out, evs[i])
+		}
+	}
+	slices.Reverse(out)
+	return out
+}
+
+// indentLines prefixes every line of s with indent.
+func indentLines(s, indent string) string {
+	return indent + strings.ReplaceAll(s, "\n", "\n"+indent)
+}
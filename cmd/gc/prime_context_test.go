@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/mail"
+)
+
+func TestWritePrimeContext(t *testing.T) {
+	store := beads.NewMemStore()
+	claimed, _ := store.Create(beads.Bead{Title: "Fix login", Description: "Users get 500s.\nSee logs."})
+	inProgress, session := "in_progress", "mayor"
+	if err := store.Update(claimed.ID, beads.UpdateOpts{Status: &inProgress, Assignee: &session}); err != nil {
+		t.Fatal(err)
+	}
+	mine, _ := store.Create(beads.Bead{Title: "Write docs", Assignee: "mayor"})
+	store.Create(beads.Bead{Title: "Someone else's", Assignee: "deacon"}) //nolint:errcheck
+
+	mp := mail.NewFake()
+	mp.Send("human", "mayor", "Ship it", "today") //nolint:errcheck
+
+	ep := events.NewFake()
+	ep.Record(events.Event{Type: events.BeadClosed, Actor: "deacon", Subject: "gc-9"})
+	ep.Record(events.Event{Type: events.SessionWoke, Subject: "mayor", Ts: time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)})
+
+	var out strings.Builder
+	writePrimeContext(&out, primeContextSources{store: store, mail: mp, events: ep},
+		primeIdentity{agent: "mayor", session: "mayor"}, config.PrimeConfig{})
+	got := out.String()
+
+	for _, want := range []string{
+		"## Current context",
+		"- " + claimed.ID + ": Fix login\n  Users get 500s.\n  See logs.",
+		"### Ready queue (1)",
+		"- " + mine.ID + " [P2]: Write docs",
+		"### Unread mail (1)",
+		"from human: Ship it",
+		"- 2026-01-02 03:04 session.woke mayor",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("context missing %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"Someone else's", "gc-9"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("context contains %q:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestWritePrimeContextSkipsFailingSources(t *testing.T) {
+	var out strings.Builder
+	writePrimeContext(&out, primeContextSources{mail: mail.NewFailFake(), events: events.NewFailFake()},
+		primeIdentity{agent: "mayor", session: "mayor"}, config.PrimeConfig{})
+	if got := out.String(); got != "\n\n## Current context\n" {
+		t.Errorf("context = %q, want only the heading", got)
+	}
+}
+
+func TestPrimeReadyQueuePoolAndLimit(t *testing.T) {
+	p0, p3 := 0, 3
+	ready := []beads.Bead{
+		{ID: "a", Labels: []string{"pool:hw/polecat"}, Priority: &p3},
+		{ID: "b", Labels: []string{"pool:hw/polecat"}, Assignee: "hw--polecat-2"},
+		{ID: "c", Labels: []string{"pool:other"}},
+		{ID: "d", Assignee: "hw--polecat-1", Priority: &p0},
+		{ID: "e", Labels: []string{"pool:hw/polecat"}},
+	}
+	id := primeIdentity{agent: "hw/polecat-1", session: "hw--polecat-1", poolLabel: "hw/polecat"}
+	got := primeReadyQueue(ready, id, 2)
+	if len(got) != 2 || got[0].ID != "d" || got[1].ID != "e" {
+		t.Errorf("primeReadyQueue = %v, want [d e]", beadIDs(got))
+	}
+}
+
+func beadIDs(bs []beads.Bead) []string {
+	ids := make([]string, len(bs))
+	for i, b := range bs {
+		ids[i] = b.ID
+	}
+	return ids
+}
@@ -19,9 +19,11 @@ import (
 // PromptContext holds template data for prompt rendering.
 type PromptContext struct {
 	CityRoot      string
+	CityName      string // workspace name; renderPrompt fills it from cityName when empty
 	AgentName     string // qualified: "rig/polecat-1" or "mayor"
 	TemplateName  string // config name: "polecat" (pool template) or "mayor" (singleton)
 	RigName       string
+	RigRoot       string // rig repository path; empty for city-scoped agents
 	WorkDir       string
 	IssuePrefix   string
 	Branch        string
@@ -32,8 +34,8 @@ type PromptContext struct {
 }
 
 // renderPrompt reads a prompt template file and renders it with the given
-// context. cityName is used internally by template functions (e.g. session)
-// but not exposed as a template variable. sessionTemplate is the custom
+// context. cityName is used by template functions (e.g. session) and is
+// exposed as CityName. sessionTemplate is the custom
 // session naming template (empty = default). packDirs are the ordered
 // pack directories; each may contain prompts/shared/ subdirectories
 // loaded as cross-pack shared templates (lower priority than the
@@ -45,22 +47,23 @@ func renderPrompt(fs fsys.FS, cityPath, cityName, templatePath string, ctx Promp
 	if templatePath == "" {
 		return ""
 	}
-	resolved := citylayout.ResolveCityOwnedPath(fs, cityPath, templatePath)
-	sourcePath := citylayout.ResolveReadPath(fs, cityPath, templatePath)
-	data, err := fs.ReadFile(sourcePath)
-	if err != nil && resolved.Asset == citylayout.AssetPrompt {
-		rel := strings.TrimPrefix(resolved.Canonical, citylayout.PromptsRoot+"/")
-		fallback := filepath.Join(cityPath, citylayout.SystemPromptsRoot, rel)
-		data, err = fs.ReadFile(fallback)
-		sourcePath = fallback
-	}
+	data, sourcePath, err := readPromptTemplate(fs, cityPath, templatePath)
 	if err != nil {
 		return ""
 	}
 	raw := string(data)
 
+	if ctx.CityName == "" {
+		ctx.CityName = cityName
+	}
+	td := buildTemplateData(ctx)
+	funcs := promptFuncMap(cityName, sessionTemplate, store)
+	inc := &promptIncluder{fs: fs, cityPath: cityPath, baseDir: filepath.Dir(sourcePath), funcs: funcs, data: td}
+	funcs["include"] = inc.include
+	funcs["queue"] = func() string { return promptQueueSnapshot(td["WorkQuery"]) }
+
 	tmpl := template.New("prompt").
-		Funcs(promptFuncMap(cityName, sessionTemplate, store)).
+		Funcs(funcs).
 		Option("missingkey=zero")
 
 	// Load shared templates from pack dirs (lower priority).
@@ -82,7 +85,6 @@ func renderPrompt(fs fsys.FS, cityPath, cityName, templatePath string, ctx Promp
 		return raw
 	}
 
-	td := buildTemplateData(ctx)
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, td); err != nil {
 		fmt.Fprintf(stderr, "gc: prompt template %q: %v\n", templatePath, err) //nolint:errcheck // best-effort stderr
@@ -147,9 +149,11 @@ func buildTemplateData(ctx PromptContext) map[string]string {
 	}
 	// SDK fields override Env.
 	m["CityRoot"] = ctx.CityRoot
+	m["CityName"] = ctx.CityName
 	m["AgentName"] = ctx.AgentName
 	m["TemplateName"] = ctx.TemplateName
 	m["RigName"] = ctx.RigName
+	m["RigRoot"] = ctx.RigRoot
 	m["WorkDir"] = ctx.WorkDir
 	m["IssuePrefix"] = ctx.IssuePrefix
 	m["Branch"] = ctx.Branch
@@ -170,6 +174,17 @@ func findRigPrefix(rigName string, rigs []config.Rig) string {
 	return ""
 }
 
+// findRigPath returns the repository path of the named rig.
+// Returns empty string if rigName is empty or not found.
+func findRigPath(rigName string, rigs []config.Rig) string {
+	for i := range rigs {
+		if rigs[i].Name == rigName {
+			return rigs[i].Path
+		}
+	}
+	return ""
+}
+
 // defaultBranchFor returns the default branch for the repo at dir.
 // Returns "main" on any error (best-effort).
 func defaultBranchFor(dir string) string {
@@ -181,12 +196,32 @@ func defaultBranchFor(dir string) string {
 	return branch
 }
 
+// readPromptTemplate reads a prompt template the way agents see it: the
+// city-owned path first, then the system prompts fallback for prompt
+// assets. Returns the data and the path it was read from.
+func readPromptTemplate(fs fsys.FS, cityPath, templatePath string) ([]byte, string, error) {
+	resolved := citylayout.ResolveCityOwnedPath(fs, cityPath, templatePath)
+	sourcePath := citylayout.ResolveReadPath(fs, cityPath, templatePath)
+	data, err := fs.ReadFile(sourcePath)
+	if err != nil && resolved.Asset == citylayout.AssetPrompt {
+		rel := strings.TrimPrefix(resolved.Canonical, citylayout.PromptsRoot+"/")
+		fallback := filepath.Join(cityPath, citylayout.SystemPromptsRoot, rel)
+		data, err = fs.ReadFile(fallback)
+		sourcePath = fallback
+	}
+	return data, sourcePath, err
+}
+
 // promptFuncMap returns template functions available in prompt templates.
 // sessionTemplate is the custom session naming template (empty = default).
 // store is used by the "session" function to look up bead-derived session
-// names; nil falls back to legacy naming.
+// names; nil falls back to legacy naming. The "include" and "queue"
+// entries here are placeholders so templates parse; renderPrompt binds
+// them to the prompt being rendered.
 func promptFuncMap(cityName, sessionTemplate string, store beads.Store) template.FuncMap {
 	return template.FuncMap{
+		"include": func(string) (string, error) { return "", nil },
+		"queue":   func() string { return "" },
 		"cmd": func() string {
 			return filepath.Base(os.Args[0])
 		},
@@ -199,3 +234,70 @@ func promptFuncMap(cityName, sessionTemplate string, store beads.Store) template
 		},
 	}
 }
+
+// maxPromptIncludeDepth bounds nested {{ include }} calls, which is how an
+// include cycle is reported.
+const maxPromptIncludeDepth = 8
+
+// promptIncluder implements the "include" template function for one
+// render: it reads a partial, renders it with the prompt's data and
+// functions, and returns the result.
+type promptIncluder struct {
+	fs       fsys.FS
+	cityPath string
+	baseDir  string // directory of the prompt template being rendered
+	funcs    template.FuncMap
+	data     map[string]string
+	depth    int
+}
+
+// include renders the partial at path. Relative paths are tried against
+// the prompt template's directory first, then the city root.
+func (in *promptIncluder) include(path string) (string, error) {
+	if in.depth >= maxPromptIncludeDepth {
+		return "", fmt.Errorf("include %q: nested more than %d deep (include cycle?)", path, maxPromptIncludeDepth)
+	}
+	candidates := []string{path}
+	if !filepath.IsAbs(path) {
+		candidates = []string{filepath.Join(in.baseDir, path), filepath.Join(in.cityPath, path)}
+	}
+	var data []byte
+	var err error
+	for _, c := range candidates {
+		if data, err = in.fs.ReadFile(c); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("include %q: %w", path, err)
+	}
+	t, err := template.New(path).Funcs(in.funcs).Option("missingkey=zero").Parse(string(data))
+	if err != nil {
+		return "", fmt.Errorf("include %q: %w", path, err)
+	}
+	in.depth++
+	defer func() { in.depth-- }()
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, in.data); err != nil {
+		return "", fmt.Errorf("include %q: %w", path, err)
+	}
+	return buf.String(), nil
+}
+
+// promptWorkQuery runs an agent's work query for the "queue" template
+// function. A variable so tests can stub out the shell.
+var promptWorkQuery WorkQueryRunner = shellWorkQuery
+
+// promptQueueSnapshot returns the trimmed output of workQuery at render
+// time, or "" when there is no query or it fails. A prompt never fails to
+// render because the bead store is unreachable.
+func promptQueueSnapshot(workQuery string) string {
+	if workQuery == "" {
+		return ""
+	}
+	out, err := promptWorkQuery(workQuery)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"io"
 	"strings"
 	"testing"
@@ -499,3 +500,88 @@ func TestMergeFragmentLists(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderPromptCityNameAndRigRoot(t *testing.T) {
+	f := fsys.NewFake()
+	f.Files["/city/prompts/test.md.tmpl"] = []byte("{{ .CityName }} {{ .RigRoot }}")
+	ctx := PromptContext{RigName: "fe", RigRoot: "/src/frontend"}
+	got := renderPrompt(f, "/city", "bright-lights", "prompts/test.md.tmpl", ctx, "", io.Discard, nil, nil, nil)
+	if got != "bright-lights /src/frontend" {
+		t.Errorf("renderPrompt = %q, want %q", got, "bright-lights /src/frontend")
+	}
+}
+
+func TestRenderPromptInclude(t *testing.T) {
+	f := fsys.NewFake()
+	f.Files["/city/prompts/worker.md.tmpl"] = []byte(`A {{ include "partials/common.md" }} C`)
+	f.Files["/city/prompts/partials/common.md"] = []byte(`B={{ .AgentName }} {{ include "partials/leaf.md" }}`)
+	f.Files["/city/partials/leaf.md"] = []byte("leaf")
+	ctx := PromptContext{AgentName: "mayor"}
+	got := renderPrompt(f, "/city", "", "prompts/worker.md.tmpl", ctx, "", io.Discard, nil, nil, nil)
+	if got != "A B=mayor leaf C" {
+		t.Errorf("renderPrompt(include) = %q, want %q", got, "A B=mayor leaf C")
+	}
+}
+
+func TestRenderPromptIncludeCycle(t *testing.T) {
+	f := fsys.NewFake()
+	raw := `{{ include "loop.md" }}`
+	f.Files["/city/prompts/test.md.tmpl"] = []byte(raw)
+	f.Files["/city/prompts/loop.md"] = []byte(`{{ include "loop.md" }}`)
+	var stderr strings.Builder
+	got := renderPrompt(f, "/city", "", "prompts/test.md.tmpl", PromptContext{}, "", &stderr, nil, nil, nil)
+	if got != raw {
+		t.Errorf("renderPrompt(cycle) = %q, want raw fallback %q", got, raw)
+	}
+	if !strings.Contains(stderr.String(), "include cycle") {
+		t.Errorf("stderr = %q, want include cycle warning", stderr.String())
+	}
+}
+
+func TestRenderPromptIncludeMissing(t *testing.T) {
+	f := fsys.NewFake()
+	raw := `{{ include "nope.md" }}`
+	f.Files["/city/prompts/test.md.tmpl"] = []byte(raw)
+	var stderr strings.Builder
+	got := renderPrompt(f, "/city", "", "prompts/test.md.tmpl", PromptContext{}, "", &stderr, nil, nil, nil)
+	if got != raw {
+		t.Errorf("renderPrompt(missing include) = %q, want raw fallback", got)
+	}
+	if !strings.Contains(stderr.String(), `include "nope.md"`) {
+		t.Errorf("stderr = %q, want include error", stderr.String())
+	}
+}
+
+func TestRenderPromptQueue(t *testing.T) {
+	var ran string
+	old := promptWorkQuery
+	promptWorkQuery = func(command string) (string, error) {
+		ran = command
+		return "gc-1 Fix login\n", nil
+	}
+	t.Cleanup(func() { promptWorkQuery = old })
+
+	f := fsys.NewFake()
+	f.Files["/city/prompts/test.md.tmpl"] = []byte("Queue:\n{{ queue }}")
+	ctx := PromptContext{WorkQuery: "bd ready --assignee=mayor"}
+	got := renderPrompt(f, "/city", "", "prompts/test.md.tmpl", ctx, "", io.Discard, nil, nil, nil)
+	if got != "Queue:\ngc-1 Fix login" {
+		t.Errorf("renderPrompt(queue) = %q, want %q", got, "Queue:\ngc-1 Fix login")
+	}
+	if ran != "bd ready --assignee=mayor" {
+		t.Errorf("work query run = %q, want %q", ran, "bd ready --assignee=mayor")
+	}
+}
+
+func TestRenderPromptQueueErrorIsEmpty(t *testing.T) {
+	old := promptWorkQuery
+	promptWorkQuery = func(string) (string, error) { return "", fmt.Errorf("store down") }
+	t.Cleanup(func() { promptWorkQuery = old })
+
+	f := fsys.NewFake()
+	f.Files["/city/prompts/test.md.tmpl"] = []byte("[{{ queue }}]")
+	got := renderPrompt(f, "/city", "", "prompts/test.md.tmpl", PromptContext{WorkQuery: "x"}, "", io.Discard, nil, nil, nil)
+	if got != "[]" {
+		t.Errorf("renderPrompt(queue error) = %q, want %q", got, "[]")
+	}
+}
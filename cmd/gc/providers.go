@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -15,18 +16,21 @@ import (
 	"github.com/gastownhall/gascity/internal/mail"
 	"github.com/gastownhall/gascity/internal/mail/beadmail"
 	mailexec "github.com/gastownhall/gascity/internal/mail/exec"
+	"github.com/gastownhall/gascity/internal/mail/filemail"
 	"github.com/gastownhall/gascity/internal/runtime"
 	sessionacp "github.com/gastownhall/gascity/internal/runtime/acp"
 	sessionauto "github.com/gastownhall/gascity/internal/runtime/auto"
 	sessionexec "github.com/gastownhall/gascity/internal/runtime/exec"
 	sessionhybrid "github.com/gastownhall/gascity/internal/runtime/hybrid"
 	sessionk8s "github.com/gastownhall/gascity/internal/runtime/k8s"
+	sessionssh "github.com/gastownhall/gascity/internal/runtime/ssh"
 	sessionsubprocess "github.com/gastownhall/gascity/internal/runtime/subprocess"
 	sessiontmux "github.com/gastownhall/gascity/internal/runtime/tmux"
 )
 
 // sessionProviderName returns the session provider name.
-// Priority: GC_SESSION env var → city.toml [session].provider → "" (default: tmux).
+// Priority: GC_SESSION env var → city.toml [session].provider → "" (default: tmux,
+// or subprocess when tmux is not installed).
 func sessionProviderName() string {
 	if v := os.Getenv("GC_SESSION"); v != "" {
 		return v
@@ -61,16 +65,18 @@ func tmuxConfigFromSession(sc config.SessionConfig, cityName string) sessiontmux
 
 // newSessionProviderByName constructs a runtime.Provider from a provider name.
 // cityName is used to auto-default the tmux socket when none is configured.
+// cityPath locates .gc/logs for providers that capture session output.
 // Returns error instead of os.Exit, making it safe for the hot-reload path.
 //
 //   - "fake" → in-memory fake (all ops succeed)
 //   - "fail" → broken fake (all ops return errors)
-//   - "subprocess" → headless child processes
+//   - "subprocess" → headless child processes, logging to .gc/logs
 //   - "acp" → ACP (Agent Client Protocol) JSON-RPC over stdio
 //   - "exec:<script>" → user-supplied script (absolute path or PATH lookup)
 //   - "k8s" → native Kubernetes provider (client-go)
-//   - default → real tmux provider
-func newSessionProviderByName(name string, sc config.SessionConfig, cityName string) (runtime.Provider, error) {
+//   - default → real tmux provider, or subprocess when tmux is not
+//     installed (e.g. on Windows)
+func newSessionProviderByName(name string, sc config.SessionConfig, cityName, cityPath string) (runtime.Provider, error) {
 	if strings.HasPrefix(name, "exec:") {
 		return sessionexec.NewProvider(strings.TrimPrefix(name, "exec:")), nil
 	}
@@ -80,7 +86,10 @@ func newSessionProviderByName(name string, sc config.SessionConfig, cityName str
 	case "fail":
 		return runtime.NewFailFake(), nil
 	case "subprocess":
-		return sessionsubprocess.NewProvider(), nil
+		if cityPath == "" {
+			return sessionsubprocess.NewProvider(), nil
+		}
+		return sessionsubprocess.NewProviderWithLogDir(citylayout.RuntimePath(cityPath, "logs")), nil
 	case "acp":
 		return sessionacp.NewProvider(sessionacp.Config{
 			HandshakeTimeout:  sc.ACP.HandshakeTimeoutDuration(),
@@ -92,20 +101,36 @@ func newSessionProviderByName(name string, sc config.SessionConfig, cityName str
 	case "hybrid":
 		return newHybridProvider(sc, cityName)
 	default:
+		if name == "" && !tmuxAvailable() {
+			return newSessionProviderByName("subprocess", sc, cityName, cityPath)
+		}
 		return sessiontmux.NewProviderWithConfig(tmuxConfigFromSession(sc, cityName)), nil
 	}
 }
 
+// tmuxAvailable reports whether a tmux binary is on PATH. Tests override
+// it to exercise the fallback.
+var tmuxAvailable = func() bool {
+	_, err := exec.LookPath("tmux")
+	return err == nil
+}
+
 // newSessionProvider returns a runtime.Provider based on the session provider
 // name (env var → city.toml → default). When the city-level provider is not
 // "acp" but some agents have session = "acp", returns an auto.Provider that
-// routes per-session. Startup path — exits on error.
+// routes per-session. When agents declare a host, the result is wrapped in
+// an ssh.Provider that routes those sessions to remote tmux. Lifecycle
+// hooks, usage tracking, and rate limits wrap the result. Startup path —
+// exits on error.
 func newSessionProvider() runtime.Provider {
 	var sc config.SessionConfig
 	var cityName string
 	var cityPath string
 	var agents []config.Agent
 	var sessionTemplate string
+	var lifecycleHooks []config.LifecycleHook
+	var rateLimit config.RateLimitConfig
+	var daemon config.DaemonConfig
 	if cp, err := resolveCity(); err == nil {
 		cityPath = cp
 		if cfg, err := loadCityConfig(cp); err == nil {
@@ -116,20 +141,29 @@ func newSessionProvider() runtime.Provider {
 			}
 			agents = cfg.Agents
 			sessionTemplate = cfg.Workspace.SessionTemplate
+			lifecycleHooks = cfg.Hooks
+			rateLimit = cfg.RateLimit
+			daemon = cfg.Daemon
 		}
 	}
 	provName := sessionProviderName()
-	sp, err := newSessionProviderByName(provName, sc, cityName)
+	sp, err := newSessionProviderByName(provName, sc, cityName, cityPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err) //nolint:errcheck // best-effort stderr
 		os.Exit(1)
 	}
+	// Best-effort store for bead-derived session name lookup when
+	// pre-registering routes below.
+	var store beads.Store
+	if cityPath != "" && (hasACPAgents(agents) || hasRemoteAgents(agents)) {
+		store, _ = openCityStoreAt(cityPath)
+	}
 	// If the city-level provider is not ACP but some agents need ACP,
 	// wrap in an auto provider that routes per-session.
 	// NOTE: agents comes from loadCityConfig which applies pack overrides,
 	// so the Session field from overrides is already resolved here.
 	if provName != "acp" && hasACPAgents(agents) {
-		acpSP, acpErr := newSessionProviderByName("acp", sc, cityName)
+		acpSP, acpErr := newSessionProviderByName("acp", sc, cityName, cityPath)
 		if acpErr != nil {
 			fmt.Fprintf(os.Stderr, "acp provider: %v\n", acpErr) //nolint:errcheck // best-effort stderr
 			os.Exit(1)
@@ -137,20 +171,41 @@ func newSessionProvider() runtime.Provider {
 		autoSP := sessionauto.New(sp, acpSP)
 		// Pre-register routes for known ACP agents so one-off commands
 		// (gc status, gc agent nudge, etc.) route correctly.
-		// Best-effort store for bead-derived session name lookup.
-		var store beads.Store
-		if cityPath != "" {
-			store, _ = openCityStoreAt(cityPath)
-		}
 		for _, a := range agents {
 			if a.Session == "acp" {
 				sessName := lookupSessionNameOrLegacy(store, cityName, a.QualifiedName(), sessionTemplate)
 				autoSP.RouteACP(sessName)
 			}
 		}
-		return autoSP
+		sp = autoSP
+	}
+	// Agents with host = "..." run in tmux on that machine over SSH.
+	if hasRemoteAgents(agents) {
+		sshSP := sessionssh.New(sp, sessionssh.Config{
+			SocketName: tmuxConfigFromSession(sc, cityName).SocketName,
+		})
+		for _, a := range agents {
+			if a.Host != "" {
+				sessName := lookupSessionNameOrLegacy(store, cityName, a.QualifiedName(), sessionTemplate)
+				sshSP.RouteHost(sessName, a.Host)
+			}
+		}
+		sp = sshSP
+	}
+	sp = withLifecycleHooks(sp, lifecycleHooks, cityPath, cityName, os.Stderr)
+	sp = withSnapshots(sp, cityPath, sc)
+	sp = withUsageTracking(sp, cityPath, daemon)
+	return withRateLimit(sp, rateLimit, agents, os.Stderr)
+}
+
+// hasRemoteAgents reports whether any agent in the config sets host.
+func hasRemoteAgents(agents []config.Agent) bool {
+	for _, a := range agents {
+		if a.Host != "" {
+			return true
+		}
 	}
-	return sp
+	return false
 }
 
 // hasACPAgents reports whether any agent in the config uses session = "acp".
@@ -224,6 +279,9 @@ func mailProviderName() string {
 //   - "fail" → broken fake (all ops return errors)
 //   - "exec:<script>" → user-supplied script (absolute path or PATH lookup)
 //   - default → beadmail (backed by beads.Store, no subprocess)
+//
+// The "file" provider needs the city path and is opened by
+// openCityMailProvider instead.
 func newMailProvider(store beads.Store) mail.Provider {
 	v := mailProviderName()
 	if strings.HasPrefix(v, "exec:") {
@@ -247,6 +305,14 @@ func openCityMailProvider(stderr io.Writer, cmdName string) (mail.Provider, int)
 	if strings.HasPrefix(v, "exec:") || v == "fake" || v == "fail" {
 		return newMailProvider(nil), 0
 	}
+	if v == "file" {
+		cityPath, err := resolveCity()
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", cmdName, err) //nolint:errcheck // best-effort stderr
+			return nil, 1
+		}
+		return filemail.New(citylayout.RuntimePath(cityPath, "mail")), 0
+	}
 
 	store, code := openCityStore(stderr, cmdName)
 	if store == nil {
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/ratelimit"
+	"github.com/gastownhall/gascity/internal/runtime"
+	sessionsubprocess "github.com/gastownhall/gascity/internal/runtime/subprocess"
+	sessiontmux "github.com/gastownhall/gascity/internal/runtime/tmux"
+)
+
+func TestNewSessionProviderByNameFallsBackWithoutTmux(t *testing.T) {
+	orig := tmuxAvailable
+	t.Cleanup(func() { tmuxAvailable = orig })
+
+	tmuxAvailable = func() bool { return false }
+	sp, err := newSessionProviderByName("", config.SessionConfig{}, "metro", t.TempDir())
+	if err != nil {
+		t.Fatalf("newSessionProviderByName: %v", err)
+	}
+	if _, ok := sp.(*sessionsubprocess.Provider); !ok {
+		t.Errorf("provider = %T, want subprocess fallback", sp)
+	}
+
+	tmuxAvailable = func() bool { return true }
+	sp, err = newSessionProviderByName("", config.SessionConfig{}, "metro", t.TempDir())
+	if err != nil {
+		t.Fatalf("newSessionProviderByName: %v", err)
+	}
+	if _, ok := sp.(*sessiontmux.Provider); !ok {
+		t.Errorf("provider = %T, want tmux", sp)
+	}
+}
+
+// TestNewSessionProviderKeepsExtensions checks that the decorators
+// newSessionProvider stacks (lifecycle hooks, snapshots, usage, rate
+// limits) leave the optional extensions of the base provider visible.
+func TestNewSessionProviderKeepsExtensions(t *testing.T) {
+	for _, tc := range []struct {
+		name, session, agents string
+		check                 func(runtime.Provider) bool
+	}{
+		{
+			name:    "exec image check",
+			session: "exec:/bin/true",
+			agents:  `[[agents]]` + "\n" + `name = "mayor"`,
+			check:   func(sp runtime.Provider) bool { _, ok := sp.(imageChecker); return ok },
+		},
+		{
+			name:    "acp routing",
+			session: "fake",
+			agents:  `[[agents]]` + "\n" + `name = "mayor"` + "\n" + `session = "acp"`,
+			check: func(sp runtime.Provider) bool {
+				_, routes := sp.(interface {
+					RouteACP(string)
+					Unroute(string)
+				})
+				_, detects := sp.(interface{ DetectTransport(string) string })
+				return routes && detects
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			toml := "[workspace]\nname = \"metro\"\n\n[rate_limit]\nrate = \"100/m\"\n\n" +
+				"[[hooks]]\nevent = \"pre_start\"\nrun = \"true\"\n\n" + tc.agents + "\n"
+			if err := os.WriteFile(filepath.Join(dir, "city.toml"), []byte(toml), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cityFlag = dir
+			t.Cleanup(func() { cityFlag = "" })
+			t.Setenv("GC_SESSION", tc.session)
+
+			sp := newSessionProvider()
+			if _, ok := sp.(*ratelimit.Provider); !ok {
+				t.Fatalf("provider = %T, want the full decorator stack", sp)
+			}
+			if !tc.check(sp) {
+				t.Errorf("%s hidden by provider decorators", tc.name)
+			}
+		})
+	}
+}
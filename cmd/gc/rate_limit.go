@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gastownhall/gascity/internal/clock"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/ratelimit"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// withRateLimit wraps sp so session starts and nudges spend tokens from
+// the city's [rate_limit] and the agents' rate_limit budgets. Returns sp
+// unchanged when no limit is set.
+func withRateLimit(sp runtime.Provider, rl config.RateLimitConfig, agents []config.Agent, stderr io.Writer) runtime.Provider {
+	city, perAgent := rateLimits(rl, agents, stderr)
+	limiter := ratelimit.NewLimiter(city, perAgent, clock.Real{})
+	if !limiter.Enabled() {
+		return sp
+	}
+	return ratelimit.WrapProvider(sp, limiter)
+}
+
+// updateRateLimits applies reloaded rate limits to sp's limiter. A city
+// that had no limits when the controller started has no limiter to
+// update, so newly added limits wait for a restart.
+func updateRateLimits(sp runtime.Provider, rl config.RateLimitConfig, agents []config.Agent, stderr io.Writer) {
+	city, perAgent := rateLimits(rl, agents, stderr)
+	if rp, ok := sp.(*ratelimit.Provider); ok {
+		rp.Limiter().SetLimits(city, perAgent)
+		return
+	}
+	if ratelimit.NewLimiter(city, perAgent, clock.Real{}).Enabled() {
+		fmt.Fprintln(stderr, "rate limits: new limits take effect after the controller restarts") //nolint:errcheck // best-effort stderr
+	}
+}
+
+// rateLimits converts the config's rate strings to limits keyed by agent
+// template (the agent's qualified name, shared by its pool instances).
+// Unparseable rates are reported and ignored; gc config validate rejects
+// them.
+func rateLimits(rl config.RateLimitConfig, agents []config.Agent, stderr io.Writer) (ratelimit.Limit, map[string]ratelimit.Limit) {
+	var city ratelimit.Limit
+	if rl.Rate != "" {
+		n, per, err := config.ParseRate(rl.Rate)
+		if err != nil {
+			fmt.Fprintf(stderr, "rate limits: [rate_limit]: %v (ignored)\n", err) //nolint:errcheck // best-effort stderr
+		} else {
+			city = ratelimit.Limit{Count: n, Per: per}
+		}
+	}
+	perAgent := make(map[string]ratelimit.Limit)
+	for _, a := range agents {
+		if a.RateLimit == "" {
+			continue
+		}
+		n, per, err := config.ParseRate(a.RateLimit)
+		if err != nil {
+			fmt.Fprintf(stderr, "rate limits: agent %q: %v (ignored)\n", a.QualifiedName(), err) //nolint:errcheck // best-effort stderr
+			continue
+		}
+		perAgent[a.QualifiedName()] = ratelimit.Limit{Count: n, Per: per}
+	}
+	return city, perAgent
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/ratelimit"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestRateLimitsKeyedByTemplate(t *testing.T) {
+	var stderr bytes.Buffer
+	city, perAgent := rateLimits(config.RateLimitConfig{Rate: "30/m"}, []config.Agent{
+		{Name: "mayor"},
+		{Name: "polecat", Dir: "rig", RateLimit: "4/m"},
+		{Name: "typo", RateLimit: "4 per minute"},
+	}, &stderr)
+
+	if city != (ratelimit.Limit{Count: 30, Per: time.Minute}) {
+		t.Errorf("city = %v, want 30/m", city)
+	}
+	if len(perAgent) != 1 || perAgent["rig/polecat"] != (ratelimit.Limit{Count: 4, Per: time.Minute}) {
+		t.Errorf("perAgent = %v, want only rig/polecat 4/m", perAgent)
+	}
+	if !strings.Contains(stderr.String(), `agent "typo"`) {
+		t.Errorf("stderr = %q, want warning for the bad rate", stderr.String())
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	fake := runtime.NewFake()
+	if sp := withRateLimit(fake, config.RateLimitConfig{}, []config.Agent{{Name: "mayor"}}, &bytes.Buffer{}); sp != runtime.Provider(fake) {
+		t.Errorf("withRateLimit without limits = %T, want the provider unchanged", sp)
+	}
+
+	sp := withRateLimit(fake, config.RateLimitConfig{Rate: "1/h"}, nil, &bytes.Buffer{})
+	if _, ok := sp.(*ratelimit.Provider); !ok {
+		t.Fatalf("withRateLimit = %T, want *ratelimit.Provider", sp)
+	}
+	if err := sp.Start(context.Background(), "a", runtime.Config{}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := sp.Start(context.Background(), "b", runtime.Config{}); !errors.Is(err, ratelimit.ErrRateLimited) {
+		t.Fatalf("second Start = %v, want ErrRateLimited", err)
+	}
+
+	// A reload that raises the limit takes effect immediately.
+	updateRateLimits(sp, config.RateLimitConfig{Rate: "10/h"}, nil, &bytes.Buffer{})
+	if err := sp.Start(context.Background(), "b", runtime.Config{}); err != nil {
+		t.Errorf("Start after reload: %v", err)
+	}
+}
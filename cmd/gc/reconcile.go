@@ -9,6 +9,7 @@ import (
 
 	"github.com/gastownhall/gascity/internal/events"
 	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/snapshot"
 	"github.com/gastownhall/gascity/internal/telemetry"
 )
 
@@ -177,6 +178,9 @@ func doReconcileAgents(desiredState map[string]TemplateParams,
 					})
 					telemetry.RecordAgentCrash(context.Background(), tp.DisplayName(), output)
 				}
+				if snap, ok := snapshot.Find(sp); ok {
+					_, _ = snap.Snapshot(name, snapshot.ReasonCrash)
+				}
 			}
 
 			// Check crash loop quarantine.
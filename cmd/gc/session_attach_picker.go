@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/session"
+)
+
+// attachCandidate is a running session offered by bare gc session attach.
+type attachCandidate struct {
+	ID       string
+	Template string // qualified agent name, e.g. "hw/polecat"
+	Session  string // runtime session name
+	Bead     string // in-progress bead assigned to the session, if any
+}
+
+// rig returns the rig part of the candidate's qualified template, or "".
+func (c attachCandidate) rig() string {
+	if rig, _, ok := strings.Cut(c.Template, "/"); ok {
+		return rig
+	}
+	return ""
+}
+
+// attachCandidates lists open sessions whose runtime session is alive,
+// with the bead each is currently working.
+func attachCandidates(store beads.Store, sessions []session.Info, sp runtime.Provider) []attachCandidate {
+	var out []attachCandidate
+	for _, s := range sessions {
+		if s.Closed || s.SessionName == "" || !sp.IsRunning(s.SessionName) {
+			continue
+		}
+		c := attachCandidate{ID: s.ID, Template: s.Template, Session: s.SessionName}
+		if work, err := store.ListByAssignee(s.SessionName, "in_progress", 1); err == nil && len(work) > 0 {
+			c.Bead = work[0].ID
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// pickAttachCandidate chooses a session to attach to: the only one when
+// there is exactly one, otherwise a numbered choice read from in.
+func pickAttachCandidate(cands []attachCandidate, in io.Reader, out io.Writer) (string, error) {
+	switch len(cands) {
+	case 0:
+		return "", errors.New("no running sessions; name one to resume it")
+	case 1:
+		return cands[0].ID, nil
+	}
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tAGENT\tRIG\tSESSION\tBEAD") //nolint:errcheck // best-effort stdout
+	for i, c := range cands {
+		rig, bead := c.rig(), c.Bead
+		if rig == "" {
+			rig = "-"
+		}
+		if bead == "" {
+			bead = "-"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", i+1, c.Template, rig, c.Session, bead) //nolint:errcheck // best-effort stdout
+	}
+	tw.Flush()                                         //nolint:errcheck // best-effort stdout
+	fmt.Fprintf(out, "Attach to [1-%d]: ", len(cands)) //nolint:errcheck // best-effort stdout
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no session selected")
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || n < 1 || n > len(cands) {
+		return "", fmt.Errorf("invalid selection %q", strings.TrimSpace(line))
+	}
+	return cands[n-1].ID, nil
+}
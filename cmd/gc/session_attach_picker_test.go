@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/session"
+)
+
+func TestAttachCandidates(t *testing.T) {
+	store := beads.NewMemStore()
+	work, _ := store.Create(beads.Bead{Title: "fix it", Assignee: "hw--polecat"})
+	inProgress := "in_progress"
+	if err := store.Update(work.ID, beads.UpdateOpts{Status: &inProgress}); err != nil {
+		t.Fatal(err)
+	}
+	sp := runtime.NewFake()
+	for _, name := range []string{"mayor", "hw--polecat"} {
+		if err := sp.Start(context.Background(), name, runtime.Config{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sessions := []session.Info{
+		{ID: "gc-1", Template: "mayor", SessionName: "mayor"},
+		{ID: "gc-2", Template: "hw/polecat", SessionName: "hw--polecat"},
+		{ID: "gc-3", Template: "hw/refinery", SessionName: "hw--refinery"}, // not running
+		{ID: "gc-4", Template: "deacon", SessionName: "deacon", Closed: true},
+	}
+	got := attachCandidates(store, sessions, sp)
+	if len(got) != 2 {
+		t.Fatalf("candidates = %+v, want 2", got)
+	}
+	if got[1].Bead != work.ID || got[1].rig() != "hw" || got[0].rig() != "" {
+		t.Errorf("candidates = %+v", got)
+	}
+}
+
+func TestPickAttachCandidate(t *testing.T) {
+	one := []attachCandidate{{ID: "gc-1", Template: "mayor", Session: "mayor"}}
+	two := append(one, attachCandidate{ID: "gc-2", Template: "hw/polecat", Session: "hw--polecat", Bead: "hw-7"})
+
+	if _, err := pickAttachCandidate(nil, strings.NewReader(""), &bytes.Buffer{}); err == nil {
+		t.Error("no candidates should be an error")
+	}
+
+	var out bytes.Buffer
+	if id, err := pickAttachCandidate(one, strings.NewReader(""), &out); err != nil || id != "gc-1" || out.Len() != 0 {
+		t.Errorf("single candidate = %q, %v, output %q", id, err, out.String())
+	}
+
+	out.Reset()
+	id, err := pickAttachCandidate(two, strings.NewReader("2\n"), &out)
+	if err != nil || id != "gc-2" {
+		t.Errorf("pick 2 = %q, %v", id, err)
+	}
+	if !strings.Contains(out.String(), "hw-7") || !strings.Contains(out.String(), "Attach to [1-2]:") {
+		t.Errorf("menu:\n%s", out.String())
+	}
+
+	for _, in := range []string{"3\n", "x\n", ""} {
+		if _, err := pickAttachCandidate(two, strings.NewReader(in), &bytes.Buffer{}); err == nil {
+			t.Errorf("input %q accepted", in)
+		}
+	}
+}
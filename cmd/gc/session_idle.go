@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/clock"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// idleSleepReason marks a session drained for exceeding its agent's
+// idle_timeout. wakeReasons ignores WakeConfig for such sessions, so
+// they stay asleep until work is assigned or a terminal attaches.
+const idleSleepReason = "idle"
+
+// reconcileSessionIdle enforces the agent's idle_timeout on a running
+// session. A session with no pane output, no wake, and no activity on
+// its in-progress beads for longer than the timeout is marked idle and
+// drained. Sessions with a terminal attached or pending work are never
+// idle; if one was marked idle, the mark is cleared. Returns true if an
+// idle drain began this tick.
+func reconcileSessionIdle(
+	session *beads.Bead,
+	agent *config.Agent,
+	sp runtime.Provider,
+	store beads.Store,
+	dt *drainTracker,
+	workSet map[string]bool,
+	clk clock.Clock,
+	rec events.Recorder,
+	subject string,
+	stdout io.Writer,
+) bool {
+	if agent == nil {
+		return false
+	}
+	timeout := agent.IdleTimeoutDuration()
+	if timeout <= 0 {
+		return false
+	}
+	name := session.Metadata["session_name"]
+	if sp.IsAttached(name) || workSet[session.Metadata["template"]] {
+		if session.Metadata["sleep_reason"] == idleSleepReason {
+			if err := store.SetMetadata(session.ID, "sleep_reason", ""); err == nil {
+				session.Metadata["sleep_reason"] = ""
+			}
+		}
+		return false
+	}
+	if session.Metadata["sleep_reason"] == idleSleepReason {
+		return false // already idling out
+	}
+
+	last := sessionLastActivity(*session, sp, store)
+	if last.IsZero() {
+		return false // provider reports no activity data — can't judge
+	}
+	idle := clk.Now().Sub(last)
+	if idle <= timeout {
+		return false
+	}
+
+	if err := store.SetMetadata(session.ID, "sleep_reason", idleSleepReason); err != nil {
+		return false
+	}
+	session.Metadata["sleep_reason"] = idleSleepReason
+	beginSessionDrain(*session, sp, dt, idleSleepReason, clk, defaultDrainTimeout)
+	fmt.Fprintf(stdout, "Draining session '%s': idle %s (idle_timeout %s)\n", name, formatDuration(idle), timeout) //nolint:errcheck
+	rec.Record(events.Event{
+		Type:    events.SessionIdleKilled,
+		Actor:   "gc",
+		Subject: subject,
+		Message: fmt.Sprintf("idle for %s", formatDuration(idle)),
+	})
+	return true
+}
+
+// sessionLastActivity returns the latest sign of life for a session: pane
+// output, its last wake, or a new comment on a bead its agent has in
+// progress. Returns zero if the provider has no pane activity data.
+func sessionLastActivity(session beads.Bead, sp runtime.Provider, store beads.Store) time.Time {
+	last, err := sp.GetLastActivity(session.Metadata["session_name"])
+	if err != nil || last.IsZero() {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, session.Metadata["last_woke_at"]); err == nil && t.After(last) {
+		last = t
+	}
+	agentName := session.Metadata["agent_name"]
+	if agentName == "" {
+		return last
+	}
+	assigned, err := store.ListByAssignee(agentName, "in_progress", 0)
+	if err != nil {
+		return last
+	}
+	for _, b := range assigned {
+		if b.CreatedAt.After(last) {
+			last = b.CreatedAt
+		}
+		for _, c := range b.Comments {
+			if c.CreatedAt.After(last) {
+				last = c.CreatedAt
+			}
+		}
+	}
+	return last
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func idleTestEnv(lastActivity time.Duration) (*reconcilerTestEnv, beads.Bead) {
+	env := newReconcilerTestEnv()
+	env.cfg = &config.City{Agents: []config.Agent{{Name: "worker", IdleTimeout: "30m"}}}
+	env.addDesired("worker", "worker", true)
+	env.sp.SetActivity("worker", env.clk.Now().Add(-lastActivity))
+	session := env.createSessionBead("worker", "worker")
+	return env, session
+}
+
+func TestReconcileSessionBeads_IdleTimeoutDrains(t *testing.T) {
+	env, session := idleTestEnv(time.Hour)
+
+	env.reconcile([]beads.Bead{session})
+
+	ds := env.dt.get(session.ID)
+	if ds == nil || ds.reason != idleSleepReason {
+		t.Fatalf("drain = %+v, want idle drain; stdout: %s", ds, env.stdout.String())
+	}
+	b, _ := env.store.Get(session.ID)
+	if b.Metadata["sleep_reason"] != idleSleepReason {
+		t.Errorf("sleep_reason = %q, want %q", b.Metadata["sleep_reason"], idleSleepReason)
+	}
+	if !strings.Contains(env.stdout.String(), "idle") {
+		t.Errorf("stdout missing idle drain: %s", env.stdout.String())
+	}
+}
+
+func TestReconcileSessionBeads_IdleTimeoutNotReached(t *testing.T) {
+	env, session := idleTestEnv(10 * time.Minute)
+
+	env.reconcile([]beads.Bead{session})
+
+	if ds := env.dt.get(session.ID); ds != nil {
+		t.Errorf("expected no drain, got %+v", ds)
+	}
+}
+
+func TestSessionLastActivity_BeadComment(t *testing.T) {
+	env, session := idleTestEnv(time.Hour)
+	// Quiet pane, but the agent logged progress on its bead recently.
+	work, _ := env.store.Create(beads.Bead{Title: "task", Assignee: "worker"})
+	_ = env.store.Update(work.ID, beads.UpdateOpts{Status: strPtr("in_progress")})
+	_, _ = env.store.AddComment(work.ID, beads.Comment{Text: "still going"})
+	if got := sessionLastActivity(session, env.sp, env.store); time.Since(got) > time.Minute {
+		t.Fatalf("bead comment not counted as activity: %v", got)
+	}
+}
+
+func TestReconcileSessionBeads_IdleSessionStaysAsleep(t *testing.T) {
+	env, session := idleTestEnv(time.Hour)
+	_ = env.sp.Stop("worker")
+	_ = env.store.SetMetadata(session.ID, "sleep_reason", idleSleepReason)
+	session.Metadata["sleep_reason"] = idleSleepReason
+
+	if woken := env.reconcile([]beads.Bead{session}); woken != 0 {
+		t.Fatalf("idle session woken by config alone")
+	}
+
+	// An attached terminal wakes it.
+	env.sp.SetAttached("worker", true)
+	if woken := env.reconcile([]beads.Bead{session}); woken != 1 {
+		t.Errorf("idle session not woken by attach; stderr: %s", env.stderr.String())
+	}
+}
+
+func TestReconcileSessionBeads_NoIdleTimeoutNoDrain(t *testing.T) {
+	env, session := idleTestEnv(48 * time.Hour)
+	env.cfg = &config.City{Agents: []config.Agent{{Name: "worker"}}}
+
+	env.reconcile([]beads.Bead{session})
+
+	if ds := env.dt.get(session.ID); ds != nil {
+		t.Errorf("agent without idle_timeout drained: %+v", ds)
+	}
+}
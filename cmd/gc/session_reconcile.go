@@ -46,9 +46,11 @@ func wakeReasons(
 
 	var reasons []WakeReason
 
-	// Config presence — per-instance for pools.
+	// Config presence — per-instance for pools. A session put to sleep by
+	// its idle_timeout gets no WakeConfig: only work or an attach wakes it.
 	template := session.Metadata["template"]
-	if agent := findAgentByTemplate(cfg, template); agent != nil {
+	idled := session.Metadata["sleep_reason"] == idleSleepReason
+	if agent := findAgentByTemplate(cfg, template); agent != nil && !idled {
 		if agent.Pool == nil {
 			reasons = append(reasons, WakeConfig)
 		} else {
@@ -10,6 +10,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -20,6 +21,7 @@ import (
 	"github.com/gastownhall/gascity/internal/clock"
 	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/ratelimit"
 	"github.com/gastownhall/gascity/internal/runtime"
 )
 
@@ -40,18 +42,25 @@ func buildDepsMap(cfg *config.City) map[string][]string {
 
 // derivePoolDesired computes pool desired counts from the desired state map.
 // Since buildDesiredState already ran evaluatePool, the number of instances
-// per template in the desired state IS the desired count.
+// per template in the desired state IS the desired count — or, when
+// gc pool stop-instance has left a gap, the highest desired slot, so the
+// members above the gap keep their wake reasons.
 func derivePoolDesired(desiredState map[string]TemplateParams, cfg *config.City) map[string]int {
 	if cfg == nil {
 		return nil
 	}
 	counts := make(map[string]int)
+	slots := make(map[string]int)
 	for _, tp := range desiredState {
 		cfgAgent := findAgentByTemplate(cfg, tp.TemplateName)
 		if cfgAgent != nil && cfgAgent.Pool != nil {
 			counts[tp.TemplateName]++
+			slots[tp.TemplateName] = max(slots[tp.TemplateName], resolvePoolSlot(tp.InstanceName, tp.TemplateName))
 		}
 	}
+	for t, s := range slots {
+		counts[t] = max(counts[t], s)
+	}
 	return counts
 }
 
@@ -278,6 +287,13 @@ func reconcileSessionBeads(
 			}
 		}
 
+		// Idle timeout: drain a session with no recent activity. It stays
+		// asleep until work is assigned or a terminal attaches.
+		if alive && reconcileSessionIdle(session, findAgentByTemplate(cfg, session.Metadata["template"]),
+			sp, store, dt, workSet, clk, rec, tp.DisplayName(), stdout) {
+			continue
+		}
+
 		// Compute wake reasons using the full contract (includes held_until,
 		// attachment checks, pool desired counts).
 		reasons := wakeReasons(*session, cfg, sp, poolDesired, workSet, clk)
@@ -325,11 +341,17 @@ func reconcileSessionBeads(
 				startCancel()
 			}
 			if err != nil {
-				fmt.Fprintf(stderr, "session reconciler: starting %s: %v\n", name, err) //nolint:errcheck
 				// Clear last_woke_at so checkStability on the next tick
 				// doesn't see a recent wake and double-count this failure.
 				_ = store.SetMetadata(session.ID, "last_woke_at", "")
 				session.Metadata["last_woke_at"] = ""
+				if errors.Is(err, ratelimit.ErrRateLimited) {
+					// Nothing ran, so it isn't a failed wake; a later
+					// tick retries once the budget refills.
+					fmt.Fprintf(stderr, "session reconciler: deferring %s: %v\n", name, err) //nolint:errcheck
+					continue
+				}
+				fmt.Fprintf(stderr, "session reconciler: starting %s: %v\n", name, err) //nolint:errcheck
 				recordWakeFailure(session, store, clk)
 				continue
 			}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/gastownhall/gascity/internal/clock"
 	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/ratelimit"
 	"github.com/gastownhall/gascity/internal/runtime"
 )
 
@@ -183,6 +185,22 @@ func TestDerivePoolDesired_CountsPoolInstances(t *testing.T) {
 	}
 }
 
+func TestDerivePoolDesired_StoppedInstanceGap(t *testing.T) {
+	cfg := &config.City{
+		Agents: []config.Agent{
+			{Name: "worker", Pool: &config.PoolConfig{Min: 1, Max: 5}},
+		},
+	}
+	// worker-2 held stopped by gc pool stop-instance.
+	desired := map[string]TemplateParams{
+		"worker-1": {TemplateName: "worker", InstanceName: "worker-1"},
+		"worker-3": {TemplateName: "worker", InstanceName: "worker-3"},
+	}
+	if got := derivePoolDesired(desired, cfg)["worker"]; got != 3 {
+		t.Errorf("worker desired = %d, want 3 so worker-3 keeps its wake reason", got)
+	}
+}
+
 // --- allDependenciesAlive tests ---
 
 func TestAllDependenciesAlive_NoDeps(t *testing.T) {
@@ -622,6 +640,26 @@ func TestReconcileSessionBeads_StartFailureNoDoubleCounting(t *testing.T) {
 	}
 }
 
+func TestReconcileSessionBeads_RateLimitedStartIsNotAFailure(t *testing.T) {
+	env := newReconcilerTestEnv()
+	env.cfg = &config.City{Agents: []config.Agent{{Name: "worker"}}}
+	env.addDesired("worker", "worker", false)
+	env.sp.StartErrors = map[string]error{"worker": fmt.Errorf("%w: city limit 1/m", ratelimit.ErrRateLimited)}
+	session := env.createSessionBead("worker", "worker")
+
+	env.reconcile([]beads.Bead{session})
+	b, _ := env.store.Get(session.ID)
+	if b.Metadata["wake_attempts"] != "" {
+		t.Errorf("wake_attempts = %q, want unset for a rate-limited start", b.Metadata["wake_attempts"])
+	}
+	if b.Metadata["last_woke_at"] != "" {
+		t.Errorf("last_woke_at = %q, want cleared", b.Metadata["last_woke_at"])
+	}
+	if !strings.Contains(env.stderr.String(), "deferring") {
+		t.Errorf("stderr = %q, want deferral note", env.stderr.String())
+	}
+}
+
 func TestReconcileSessionBeads_PoolScaleDownOrphansExcess(t *testing.T) {
 	env := newReconcilerTestEnv()
 	env.cfg = &config.City{
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// Fan-out strategies for slinging a container across several targets.
+const (
+	fanOutRoundRobin  = "round-robin"
+	fanOutLeastLoaded = "least-loaded"
+)
+
+// validFanOutStrategy reports whether s names a known fan-out strategy.
+func validFanOutStrategy(s string) bool {
+	return s == fanOutRoundRobin || s == fanOutLeastLoaded
+}
+
+// doSlingFanOut distributes the open children of a container bead across
+// opts.FanOut using opts.Strategy. Each child is routed through doSling
+// with its assigned target; children keep their existing container as
+// parent, so no auto-convoy is created. Each target that received work is
+// nudged once at the end when --nudge is set.
+func doSlingFanOut(opts slingOpts, deps slingDeps, querier BeadChildQuerier) int {
+	if querier == nil {
+		fmt.Fprintln(deps.Stderr, "gc sling: fan-out requires a bead store") //nolint:errcheck // best-effort
+		return 1
+	}
+	b, err := querier.Get(opts.BeadOrFormula)
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort
+		return 1
+	}
+	if !beads.IsContainerType(b.Type) {
+		fmt.Fprintf(deps.Stderr, "gc sling: fan-out requires a container bead (convoy, epic); %s is type %q\n", b.ID, b.Type) //nolint:errcheck // best-effort
+		return 1
+	}
+	children, err := querier.Children(b.ID)
+	if err != nil {
+		fmt.Fprintf(deps.Stderr, "gc sling: listing children of %s: %v\n", b.ID, err) //nolint:errcheck // best-effort
+		return 1
+	}
+	var open []beads.Bead
+	for _, c := range children {
+		if c.Status == "open" {
+			open = append(open, c)
+		}
+	}
+	if len(open) == 0 {
+		fmt.Fprintf(deps.Stderr, "gc sling: %s %s has no open children\n", b.Type, b.ID) //nolint:errcheck // best-effort
+		return 1
+	}
+
+	plan := planFanOut(open, opts.FanOut, opts.Strategy, deps)
+	names := make([]string, len(opts.FanOut))
+	for i, a := range opts.FanOut {
+		names[i] = a.QualifiedName()
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(deps.Stdout, "Would fan out %s %s (%d open) across %s (%s):\n", //nolint:errcheck // best-effort
+			b.Type, b.ID, len(open), strings.Join(names, ", "), opts.Strategy)
+		for i, child := range open {
+			target := opts.FanOut[plan[i]]
+			fmt.Fprintf(deps.Stdout, "  %s → %s\n", formatBeadLabel(child.ID, child.Title), target.QualifiedName()) //nolint:errcheck // best-effort
+			childOpts := opts
+			childOpts.Target = target
+			recordSling(deps, target.QualifiedName(), child.ID, slingFormula(childOpts), "fan-out", true, nil)
+		}
+		return 0
+	}
+
+	// Each child's doSling reports on its own; with --quiet only its
+	// failures get through.
+	progress, _ := slingProgress(opts, deps)
+	childDeps := deps
+	childDeps.Stdout = progress
+	fmt.Fprintf(progress, "Fanning out %s %s (%d open) across %s (%s)\n", //nolint:errcheck // best-effort
+		b.Type, b.ID, len(open), strings.Join(names, ", "), opts.Strategy)
+	routed := 0
+	failed := 0
+	received := make([]bool, len(opts.FanOut))
+	for i, child := range open {
+		childOpts := opts
+		childOpts.Target = opts.FanOut[plan[i]]
+		childOpts.BeadOrFormula = child.ID
+		childOpts.NoConvoy = true
+		childOpts.Nudge = false
+		if doSling(childOpts, childDeps, querier) != 0 {
+			failed++
+			continue
+		}
+		received[plan[i]] = true
+		routed++
+	}
+	fmt.Fprintf(deps.Stdout, "Fanned out %d/%d children of %s across %d targets\n", routed, len(open), b.ID, len(opts.FanOut)) //nolint:errcheck // best-effort
+
+	if opts.Nudge {
+		for i := range opts.FanOut {
+			if received[i] {
+				doSlingNudge(&opts.FanOut[i], deps.CityName, deps.CityPath, deps.Cfg, deps.SP, deps.Store, progress, deps.Stderr)
+			}
+		}
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// planFanOut returns, for each child in order, the index of the target it
+// is routed to. Round-robin cycles through targets; least-loaded starts
+// from each target's current open and in-progress work and always picks
+// the lightest, breaking ties by target order.
+func planFanOut(children []beads.Bead, targets []config.Agent, strategy string, deps slingDeps) []int {
+	plan := make([]int, len(children))
+	if strategy != fanOutLeastLoaded {
+		for i := range children {
+			plan[i] = i % len(targets)
+		}
+		return plan
+	}
+	loads := make([]int, len(targets))
+	for i, a := range targets {
+		loads[i] = slingLoad(a, deps)
+	}
+	for i := range children {
+		best := 0
+		for j := 1; j < len(targets); j++ {
+			if loads[j] < loads[best] {
+				best = j
+			}
+		}
+		plan[i] = best
+		loads[best]++
+	}
+	return plan
+}
+
+// slingLoad counts the open and in-progress beads already routed to a.
+// Pools are matched by their pool label and fixed agents by the session
+// name the default sling query assigns. Agents with a custom sling_query
+// report zero since their routing is opaque.
+func slingLoad(a config.Agent, deps slingDeps) int {
+	if deps.Store == nil || isCustomSlingQuery(a) {
+		return 0
+	}
+	var f beads.Filter
+	if a.IsPool() {
+		f.Label = poolRouteLabel(a)
+	} else {
+		f.Assignee = resolveSlingEnv(a, deps)["GC_SLING_TARGET"]
+	}
+	n := 0
+	for _, status := range []string{"open", "in_progress"} {
+		f.Status = status
+		bs, err := deps.Store.Query(f)
+		if err != nil {
+			continue // best-effort
+		}
+		n += len(bs)
+	}
+	return n
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/agent"
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func fanOutQuerier(n int) *fakeChildQuerier {
+	q := newFakeChildQuerier()
+	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
+	var children []beads.Bead
+	for i := 1; i <= n; i++ {
+		children = append(children, beads.Bead{ID: "BL-" + string(rune('0'+i)), Status: "open"})
+	}
+	children = append(children, beads.Bead{ID: "BL-9", Status: "closed"})
+	q.childrenOf["CVY-1"] = children
+	return q
+}
+
+func TestDoSlingFanOutRoundRobin(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	mayor := config.Agent{Name: "mayor"}
+	pool := config.Agent{Name: "polecat", Pool: &config.PoolConfig{Min: 0, Max: 3}}
+
+	deps, stdout, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	opts := testOpts(mayor, "CVY-1")
+	opts.FanOut = []config.Agent{mayor, pool}
+	opts.Strategy = fanOutRoundRobin
+	q := fanOutQuerier(3)
+	runner.routesTo(q)
+	if code := doSlingFanOut(opts, deps, q); code != 0 {
+		t.Fatalf("doSlingFanOut = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	want := []string{
+		"bd update 'BL-1' --assignee=$GC_SLING_TARGET",
+		"bd update 'BL-2' --add-label=pool:polecat",
+		"bd update 'BL-3' --assignee=$GC_SLING_TARGET",
+	}
+	if strings.Join(runner.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("runner calls = %v, want %v", runner.calls, want)
+	}
+	if !strings.Contains(stdout.String(), "Fanned out 3/3 children of CVY-1") {
+		t.Errorf("stdout = %q, want summary", stdout.String())
+	}
+	if strings.Contains(stdout.String(), "Auto-convoy") {
+		t.Errorf("fan-out should not create auto-convoys: %q", stdout.String())
+	}
+}
+
+func TestDoSlingFanOutLeastLoaded(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	mayor := config.Agent{Name: "mayor"}
+	deputy := config.Agent{Name: "deputy"}
+
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	// mayor already holds two beads; deputy holds none.
+	busy := agent.SessionNameFor("test-city", "mayor", "")
+	for range 2 {
+		if _, err := deps.Store.Create(beads.Bead{Title: "existing", Assignee: busy}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := testOpts(mayor, "CVY-1")
+	opts.FanOut = []config.Agent{mayor, deputy}
+	opts.Strategy = fanOutLeastLoaded
+	q := fanOutQuerier(3)
+	runner.routesTo(q)
+	if code := doSlingFanOut(opts, deps, q); code != 0 {
+		t.Fatalf("doSlingFanOut = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	// deputy takes BL-1 and BL-2 to catch up; the tie on BL-3 goes to mayor.
+	var targets []string
+	for _, env := range runner.envs {
+		targets = append(targets, env["GC_SLING_TARGET"])
+	}
+	deputySN := agent.SessionNameFor("test-city", "deputy", "")
+	want := []string{deputySN, deputySN, busy}
+	if strings.Join(targets, ",") != strings.Join(want, ",") {
+		t.Errorf("targets = %v, want %v", targets, want)
+	}
+}
+
+func TestDoSlingFanOutRequiresContainer(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	a := config.Agent{Name: "mayor"}
+	q := newFakeChildQuerier()
+	q.beadsByID["BL-1"] = beads.Bead{ID: "BL-1", Type: "task", Status: "open"}
+
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	opts := testOpts(a, "BL-1")
+	opts.FanOut = []config.Agent{a, {Name: "deputy"}}
+	opts.Strategy = fanOutRoundRobin
+	if code := doSlingFanOut(opts, deps, q); code != 1 {
+		t.Fatalf("doSlingFanOut = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "requires a container bead") {
+		t.Errorf("stderr = %q, want container error", stderr.String())
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("runner called for non-container: %v", runner.calls)
+	}
+}
+
+func TestDoSlingFanOutDryRun(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	mayor := config.Agent{Name: "mayor"}
+	deputy := config.Agent{Name: "deputy"}
+
+	deps, stdout, _ := testDeps(cfg, runtime.NewFake(), runner.run)
+	opts := testOpts(mayor, "CVY-1")
+	opts.FanOut = []config.Agent{mayor, deputy}
+	opts.Strategy = fanOutRoundRobin
+	opts.DryRun = true
+	if code := doSlingFanOut(opts, deps, fanOutQuerier(2)); code != 0 {
+		t.Fatalf("doSlingFanOut = %d, want 0", code)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("dry-run executed commands: %v", runner.calls)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "BL-1 → mayor") || !strings.Contains(out, "BL-2 → deputy") {
+		t.Errorf("dry-run plan missing assignments:\n%s", out)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// readSlingBeadIDs reads the bead list for gc sling --from-file from path,
+// or from stdin when path is "-". Each line's first field is a bead ID, so
+// listings that lead with the ID can be piped in as is; blank lines and
+// # comments are skipped, and repeated IDs are read once.
+func readSlingBeadIDs(path string, stdin io.Reader) ([]string, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close() //nolint:errcheck // read-only
+		r = f
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		ids = append(ids, fields[0])
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// slingListSource names where a --from-file list came from, for output.
+func slingListSource(path string) string {
+	if path == "-" {
+		return "stdin"
+	}
+	return path
+}
+
+// doSlingList routes a list of beads to opts.Target the way container
+// expansion routes a convoy's children: open beads are routed one by one
+// with per-bead reporting, beads already routed to the target are
+// skipped, and a summary closes the run. Beads that are not open are
+// skipped; beads that cannot be read fail. No auto-convoy is created,
+// since the beads may already belong to one.
+func doSlingList(opts slingOpts, deps slingDeps, querier BeadChildQuerier, ids []string, source string) int {
+	a := opts.Target
+	if querier == nil {
+		fmt.Fprintln(deps.Stderr, "gc sling: --from-file requires a bead store") //nolint:errcheck // best-effort
+		return 1
+	}
+	if len(ids) == 0 {
+		fmt.Fprintf(deps.Stderr, "gc sling: no bead IDs in %s\n", source) //nolint:errcheck // best-effort
+		return 1
+	}
+
+	var open, skipped []beads.Bead
+	var unreadable []string
+	for _, id := range ids {
+		b, err := querier.Get(id)
+		switch {
+		case err != nil:
+			fmt.Fprintf(deps.Stderr, "  Failed %s: %v\n", id, err) //nolint:errcheck // best-effort
+			unreadable = append(unreadable, id)
+		case b.Status == "open":
+			open = append(open, b)
+		default:
+			skipped = append(skipped, b)
+		}
+	}
+
+	// Cross-rig guard — checked per bead since a list can span rigs; any
+	// mismatch refuses the whole list before anything is routed.
+	if !opts.Force && !opts.DryRun {
+		crossed := false
+		for _, b := range open {
+			if msg := checkCrossRig(b.ID, a, deps.Cfg); msg != "" {
+				fmt.Fprintln(deps.Stderr, msg) //nolint:errcheck // best-effort
+				crossed = true
+			}
+		}
+		if crossed {
+			return 1
+		}
+	}
+
+	useFormula := opts.OnFormula
+	if useFormula == "" && !opts.NoFormula && a.DefaultSlingFormula != "" {
+		useFormula = a.DefaultSlingFormula
+	}
+	if useFormula != "" && len(open) > 0 {
+		if err := checkBatchNoMoleculeChildren(querier, open, deps.Store, deps.Stderr); err != nil {
+			fmt.Fprintf(deps.Stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort
+			return 1
+		}
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(deps.Stdout, "Would sling %d beads from %s (%d open) → %s:\n", len(ids), source, len(open), a.QualifiedName()) //nolint:errcheck // best-effort
+		for _, b := range open {
+			suffix := "would route"
+			if checkBeadState(querier, b.ID, a).Idempotent {
+				suffix = "already routed (skip)"
+			}
+			fmt.Fprintf(deps.Stdout, "  %s (open) → %s\n", formatBeadLabel(b.ID, b.Title), suffix) //nolint:errcheck // best-effort
+			recordSling(deps, a.QualifiedName(), b.ID, slingFormula(opts), "list", true, nil)
+		}
+		for _, b := range skipped {
+			fmt.Fprintf(deps.Stdout, "  %s (%s) → skip\n", formatBeadLabel(b.ID, b.Title), b.Status) //nolint:errcheck // best-effort
+		}
+		fmt.Fprintln(deps.Stdout, "No side effects executed (--dry-run).") //nolint:errcheck // best-effort
+		if len(unreadable) > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	// on_sling hooks run per bead; one that aborts fails only that bead.
+	failed := len(unreadable)
+	var allowed []beads.Bead
+	for _, b := range open {
+		if !fireSlingHooks(opts, deps, b.ID) {
+			failed++
+			continue
+		}
+		allowed = append(allowed, b)
+	}
+
+	progress, _ := slingProgress(opts, deps)
+	fmt.Fprintf(progress, "Slinging %d beads from %s (%d open)\n", len(ids), source, len(open)) //nolint:errcheck // best-effort
+	routed, routeFailed, idempotent := slingEach(opts, deps, querier, allowed, "list")
+	failed += routeFailed
+
+	for _, b := range skipped {
+		fmt.Fprintf(progress, "  Skipped %s (status: %s)\n", b.ID, b.Status) //nolint:errcheck // best-effort
+	}
+
+	summary := fmt.Sprintf("Slung %d/%d beads from %s → %s", routed, len(ids), source, a.QualifiedName())
+	if idempotent > 0 {
+		summary += fmt.Sprintf(" (%d already routed)", idempotent)
+	}
+	fmt.Fprintln(deps.Stdout, summary) //nolint:errcheck // best-effort
+
+	if opts.Nudge && routed > 0 {
+		doSlingNudge(&a, deps.CityName, deps.CityPath, deps.Cfg, deps.SP, deps.Store, progress, deps.Stderr)
+	}
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestReadSlingBeadIDs(t *testing.T) {
+	in := "BL-1\n\n# triage\nBL-2  fix the thing  open\n  BL-3\nBL-1\n"
+	ids, err := readSlingBeadIDs("-", strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"BL-1", "BL-2", "BL-3"}; !slices.Equal(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "beads.txt")
+	if err := os.WriteFile(path, []byte("HW-7\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ids, err = readSlingBeadIDs(path, strings.NewReader("ignored"))
+	if err != nil || !slices.Equal(ids, []string{"HW-7"}) {
+		t.Errorf("ids = %v, %v; want [HW-7]", ids, err)
+	}
+
+	if _, err := readSlingBeadIDs(filepath.Join(t.TempDir(), "missing"), nil); err == nil {
+		t.Error("missing file succeeded, want error")
+	}
+}
+
+func TestDoSlingList(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	a := config.Agent{Name: "mayor"}
+	q := newFakeChildQuerier()
+	q.beadsByID["BL-1"] = beads.Bead{ID: "BL-1", Status: "open"}
+	q.beadsByID["BL-2"] = beads.Bead{ID: "BL-2", Status: "open", Assignee: "mayor"}
+	q.beadsByID["BL-3"] = beads.Bead{ID: "BL-3", Status: "closed"}
+	runner.routesTo(q)
+
+	deps, stdout, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	code := doSlingList(testOpts(a, ""), deps, q, []string{"BL-1", "BL-2", "BL-3", "BL-4"}, "stdin")
+
+	if code != 1 {
+		t.Errorf("doSlingList = %d, want 1 for the unreadable BL-4", code)
+	}
+	if want := []string{"bd update 'BL-1' --assignee=$GC_SLING_TARGET"}; !slices.Equal(runner.calls, want) {
+		t.Errorf("runner calls = %v, want %v", runner.calls, want)
+	}
+	for _, want := range []string{
+		"Slinging 4 beads from stdin (2 open)",
+		"  Slung BL-1 → mayor",
+		"  Skipped BL-2 — already routed to mayor",
+		"  Skipped BL-3 (status: closed)",
+		"Slung 1/4 beads from stdin → mayor (1 already routed)",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("stdout missing %q:\n%s", want, stdout.String())
+		}
+	}
+	if !strings.Contains(stderr.String(), "Failed BL-4") {
+		t.Errorf("stderr = %q, want BL-4 failure", stderr.String())
+	}
+	if strings.Contains(stdout.String(), "Auto-convoy") {
+		t.Errorf("list sling should not create an auto-convoy: %q", stdout.String())
+	}
+}
+
+func TestDoSlingListCrossRigRefusesAll(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{
+		Workspace: config.Workspace{Name: "test-city"},
+		Rigs:      []config.Rig{{Name: "hw", Path: "/hw", Prefix: "hw"}, {Name: "fe", Path: "/fe", Prefix: "fe"}},
+	}
+	a := config.Agent{Name: "polecat", Dir: "hw"}
+	q := newFakeChildQuerier()
+	q.beadsByID["HW-1"] = beads.Bead{ID: "HW-1", Status: "open"}
+	q.beadsByID["FE-1"] = beads.Bead{ID: "FE-1", Status: "open"}
+
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	if code := doSlingList(testOpts(a, ""), deps, q, []string{"HW-1", "FE-1"}, "beads.txt"); code != 1 {
+		t.Fatalf("doSlingList = %d, want 1", code)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("routed despite cross-rig bead: %v", runner.calls)
+	}
+	if !strings.Contains(stderr.String(), "FE-1") {
+		t.Errorf("stderr = %q, want cross-rig refusal naming FE-1", stderr.String())
+	}
+}
+
+func TestDoSlingListDryRun(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	q := newFakeChildQuerier()
+	q.beadsByID["BL-1"] = beads.Bead{ID: "BL-1", Title: "first", Status: "open"}
+	q.beadsByID["BL-2"] = beads.Bead{ID: "BL-2", Status: "in_progress"}
+
+	deps, stdout, _ := testDeps(cfg, runtime.NewFake(), runner.run)
+	opts := testOpts(config.Agent{Name: "mayor"}, "")
+	opts.DryRun = true
+	if code := doSlingList(opts, deps, q, []string{"BL-1", "BL-2"}, "beads.txt"); code != 0 {
+		t.Fatalf("doSlingList = %d, want 0", code)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("dry-run executed commands: %v", runner.calls)
+	}
+	for _, want := range []string{
+		`BL-1 — "first" (open) → would route`,
+		"BL-2 (in_progress) → skip",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("stdout missing %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestDoSlingListEmpty(t *testing.T) {
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), newFakeRunner().run)
+	if code := doSlingList(testOpts(config.Agent{Name: "mayor"}, ""), deps, newFakeChildQuerier(), nil, "stdin"); code != 1 {
+		t.Fatalf("doSlingList = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "no bead IDs in stdin") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/spf13/cobra"
+)
+
+// slingRecord is one entry in the city's sling history log.
+type slingRecord struct {
+	Ts      time.Time `json:"ts"`
+	Actor   string    `json:"actor"`
+	Target  string    `json:"target"`
+	Bead    string    `json:"bead"`
+	Formula string    `json:"formula,omitempty"`
+	Method  string    `json:"method"`
+	DryRun  bool      `json:"dry_run,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// slingHistoryPath returns the append-only sling log for a city.
+func slingHistoryPath(cityPath string) string {
+	return citylayout.RuntimePath(cityPath, "history", "sling.jsonl")
+}
+
+// appendSlingHistory appends rec to the city's sling log as one JSON line.
+// O_APPEND keeps concurrent writers from interleaving partial lines.
+func appendSlingHistory(cityPath string, rec slingRecord) error {
+	path := slingHistoryPath(cityPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating sling history directory: %w", err)
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshaling sling record: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening sling history: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close() //nolint:errcheck // write error takes precedence
+		return fmt.Errorf("writing sling history: %w", err)
+	}
+	return f.Close()
+}
+
+// readSlingHistory returns all records in the city's sling log, oldest
+// first. A missing log is not an error. Malformed lines are skipped.
+func readSlingHistory(cityPath string) ([]slingRecord, error) {
+	f, err := os.Open(slingHistoryPath(cityPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening sling history: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // read-only
+	var recs []slingRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var rec slingRecord
+		if json.Unmarshal(scanner.Bytes(), &rec) == nil {
+			recs = append(recs, rec)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading sling history: %w", err)
+	}
+	return recs, nil
+}
+
+// recordSling appends a sling outcome to the history log and, for
+// successful non-dry-run slings, records a sling.routed event. Recording
+// is best-effort: failures are reported on stderr and never fail the
+// sling. No-op when the city path is unknown (unit tests).
+func recordSling(deps slingDeps, target, beadID, formula, method string, dryRun bool, slingErr error) {
+	if deps.CityPath == "" {
+		return
+	}
+	rec := slingRecord{
+		Ts:      time.Now().UTC(),
+		Actor:   eventActor(),
+		Target:  target,
+		Bead:    beadID,
+		Formula: formula,
+		Method:  method,
+		DryRun:  dryRun,
+	}
+	if slingErr != nil {
+		rec.Error = slingErr.Error()
+	}
+	if err := appendSlingHistory(deps.CityPath, rec); err != nil {
+		fmt.Fprintf(deps.Stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort
+	}
+	if dryRun || slingErr != nil {
+		return
+	}
+	er, err := events.NewFileRecorder(citylayout.RuntimePath(deps.CityPath, "events.jsonl"), deps.Stderr)
+	if err != nil {
+		return
+	}
+	defer er.Close() //nolint:errcheck // best-effort
+	payload, _ := json.Marshal(map[string]string{"bead": beadID, "target": target, "formula": formula, "method": method})
+	er.Record(events.Event{
+		Type:    events.SlingRouted,
+		Actor:   rec.Actor,
+		Subject: beadID,
+		Message: "→ " + target,
+		Payload: payload,
+	})
+}
+
+// slingFormula returns the formula a sling applied, if any.
+func slingFormula(opts slingOpts) string {
+	switch {
+	case opts.IsFormula:
+		return opts.BeadOrFormula
+	case opts.OnFormula != "":
+		return opts.OnFormula
+	case !opts.NoFormula:
+		return opts.Target.DefaultSlingFormula
+	}
+	return ""
+}
+
+func newSlingHistoryCmd(stdout, stderr io.Writer) *cobra.Command {
+	var beadID, agentName string
+	var limit int
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the sling audit log",
+		Long: `Show past slings recorded in .gc/history/sling.jsonl, oldest first.
+
+Every gc sling invocation appends one entry per routed bead, including
+dry runs and failures, with the acting agent (or "human"), target, and
+formula.`,
+		Example: `  gc sling history
+  gc sling history --bead BL-42
+  gc sling history --agent mayor --limit 20`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cityPath, err := resolveCity()
+			if err != nil {
+				fmt.Fprintf(stderr, "gc sling history: %v\n", err) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if doSlingHistory(cityPath, beadID, agentName, limit, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&beadID, "bead", "", "only slings of this bead")
+	cmd.Flags().StringVar(&agentName, "agent", "", "only slings to this target")
+	cmd.Flags().IntVar(&limit, "limit", 0, "show only the most recent N entries (0 = all)")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// doSlingHistory prints the sling log filtered by bead and target.
+func doSlingHistory(cityPath, beadID, agentName string, limit int, jsonOutput bool, stdout, stderr io.Writer) int {
+	recs, err := readSlingHistory(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc sling history: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	matched := []slingRecord{}
+	for _, r := range recs {
+		if beadID != "" && r.Bead != beadID {
+			continue
+		}
+		if agentName != "" && r.Target != agentName {
+			continue
+		}
+		matched = append(matched, r)
+	}
+	if limit > 0 && len(matched) > limit {
+		matched = matched[len(matched)-limit:]
+	}
+	if jsonOutput {
+		data, err := json.MarshalIndent(matched, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc sling history: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if len(matched) == 0 {
+		fmt.Fprintln(stdout, "No slings recorded") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tACTOR\tBEAD\tTARGET\tFORMULA\tRESULT") //nolint:errcheck // best-effort stdout
+	for _, r := range matched {
+		formula := r.Formula
+		if formula == "" {
+			formula = "—"
+		}
+		result := "ok"
+		switch {
+		case r.Error != "":
+			result = "failed: " + r.Error
+		case r.DryRun:
+			result = "dry-run"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", //nolint:errcheck // best-effort stdout
+			r.Ts.Local().Format(time.DateTime), r.Actor, r.Bead, r.Target, formula, result)
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+	return 0
+}
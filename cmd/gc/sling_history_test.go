@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/events"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestDoSlingRecordsHistory(t *testing.T) {
+	t.Setenv("GC_AGENT", "")
+	runner := newFakeRunner()
+	runner.on("BL-2", "", errors.New("boom"))
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	a := config.Agent{Name: "mayor"}
+
+	deps, _, _ := testDeps(cfg, runtime.NewFake(), runner.run)
+	deps.CityPath = t.TempDir()
+	opts := testOpts(a, "BL-1")
+	opts.NoConvoy = true
+	if code := doSling(opts, deps, nil); code != 0 {
+		t.Fatalf("doSling BL-1 = %d, want 0", code)
+	}
+	opts.BeadOrFormula = "BL-2"
+	if code := doSling(opts, deps, nil); code != 1 {
+		t.Fatalf("doSling BL-2 = %d, want 1", code)
+	}
+
+	recs, err := readSlingHistory(deps.CityPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("got %d records, want 2: %+v", len(recs), recs)
+	}
+	if recs[0].Bead != "BL-1" || recs[0].Target != "mayor" || recs[0].Actor != "human" || recs[0].Error != "" {
+		t.Errorf("record 0 = %+v", recs[0])
+	}
+	if recs[1].Bead != "BL-2" || recs[1].Error == "" {
+		t.Errorf("record 1 = %+v, want failure", recs[1])
+	}
+
+	// Only the successful sling is published on the event bus.
+	evts, err := events.ReadAll(citylayout.RuntimePath(deps.CityPath, "events.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evts) != 1 || evts[0].Type != events.SlingRouted || evts[0].Subject != "BL-1" {
+		t.Errorf("events = %+v, want one sling.routed for BL-1", evts)
+	}
+}
+
+func TestDoSlingHistoryFilters(t *testing.T) {
+	cityPath := t.TempDir()
+	for _, r := range []slingRecord{
+		{Actor: "human", Target: "mayor", Bead: "BL-1", Method: "bead"},
+		{Actor: "human", Target: "deputy", Bead: "BL-2", Method: "bead", DryRun: true},
+		{Actor: "mayor", Target: "deputy", Bead: "BL-1", Method: "bead", Formula: "review"},
+	} {
+		if err := appendSlingHistory(cityPath, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := doSlingHistory(cityPath, "BL-1", "", 0, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doSlingHistory = %d; stderr: %s", code, stderr.String())
+	}
+	out := stdout.String()
+	if strings.Contains(out, "BL-2") || strings.Count(out, "BL-1") != 2 {
+		t.Errorf("--bead output wrong:\n%s", out)
+	}
+
+	stdout.Reset()
+	if code := doSlingHistory(cityPath, "", "deputy", 1, true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doSlingHistory = %d; stderr: %s", code, stderr.String())
+	}
+	var recs []slingRecord
+	if err := json.Unmarshal(stdout.Bytes(), &recs); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(recs) != 1 || recs[0].Formula != "review" {
+		t.Errorf("--agent --limit 1 = %+v, want most recent deputy sling", recs)
+	}
+}
+
+func TestDoSlingHistoryEmpty(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := doSlingHistory(t.TempDir(), "", "", 0, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doSlingHistory = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "No slings recorded") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// capableAgents returns the agents, in config order, whose capabilities
+// include every one in required. Suspended agents are skipped.
+func capableAgents(cfg *config.City, required []string) []config.Agent {
+	var out []config.Agent
+	for i := range cfg.Agents {
+		a := &cfg.Agents[i]
+		if isAgentEffectivelySuspended(cfg, a) {
+			continue
+		}
+		capable := true
+		for _, c := range required {
+			if !slices.Contains(a.Capabilities, c) {
+				capable = false
+				break
+			}
+		}
+		if capable {
+			out = append(out, *a)
+		}
+	}
+	return out
+}
+
+// matchSlingTarget picks the agent for gc sling --match: among agents
+// with every capability b requires, the one with the fewest open and
+// in-progress beads routed to it, breaking ties by config order. load
+// reports an agent's current work.
+func matchSlingTarget(cfg *config.City, b beads.Bead, load func(config.Agent) int) (string, error) {
+	required := b.Requires()
+	if len(required) == 0 {
+		return "", fmt.Errorf("bead %s requires no capabilities (add requires:<capability> labels)", b.ID)
+	}
+	candidates := capableAgents(cfg, required)
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no agent has all capabilities %s required by %s", strings.Join(required, ", "), b.ID)
+	}
+	best, bestLoad := 0, load(candidates[0])
+	for i := 1; i < len(candidates); i++ {
+		if l := load(candidates[i]); l < bestLoad {
+			best, bestLoad = i, l
+		}
+	}
+	return candidates[best].QualifiedName(), nil
+}
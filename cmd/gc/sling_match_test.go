@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func TestMatchSlingTarget(t *testing.T) {
+	cfg := &config.City{Agents: []config.Agent{
+		{Name: "mayor", Capabilities: []string{"docs"}},
+		{Name: "gopher", Dir: "api", Capabilities: []string{"go", "docs"}},
+		{Name: "polecat", Dir: "api", Capabilities: []string{"go", "frontend", "docs"}},
+		{Name: "sleeper", Capabilities: []string{"go", "docs"}, Suspended: true},
+	}}
+	loads := map[string]int{"mayor": 0, "api/gopher": 3, "api/polecat": 1, "sleeper": 0}
+	load := func(a config.Agent) int { return loads[a.QualifiedName()] }
+	bead := func(requires ...string) beads.Bead {
+		b := beads.Bead{ID: "BL-7"}
+		for _, c := range requires {
+			b.Labels = append(b.Labels, beads.RequiresLabelPrefix+c)
+		}
+		return b
+	}
+
+	for _, tc := range []struct {
+		requires []string
+		want     string
+	}{
+		{[]string{"docs"}, "mayor"},
+		{[]string{"go"}, "api/polecat"},
+		{[]string{"go", "frontend"}, "api/polecat"},
+	} {
+		got, err := matchSlingTarget(cfg, bead(tc.requires...), load)
+		if err != nil || got != tc.want {
+			t.Errorf("matchSlingTarget(%v) = %q, %v; want %q", tc.requires, got, err, tc.want)
+		}
+	}
+
+	// Ties go to the agent listed first.
+	loads["api/gopher"] = 1
+	if got, _ := matchSlingTarget(cfg, bead("go"), load); got != "api/gopher" {
+		t.Errorf("tie = %q, want api/gopher", got)
+	}
+
+	for _, tc := range []struct {
+		b    beads.Bead
+		want string
+	}{
+		{bead(), "requires no capabilities"},
+		{bead("go", "rust"), "no agent has all capabilities go, rust"},
+	} {
+		if _, err := matchSlingTarget(cfg, tc.b, load); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("matchSlingTarget(%v) err = %v, want %q", tc.b.Labels, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/shellquote"
+)
+
+// slingProgress returns the writers for per-bead progress (attached
+// wisps, auto-convoys, skips, per-child results, nudges) and non-fatal
+// warnings. With --quiet both discard, leaving only failures and the
+// closing summary, which go to deps.Stderr and deps.Stdout directly.
+func slingProgress(opts slingOpts, deps slingDeps) (stdout, stderr io.Writer) {
+	if opts.Quiet {
+		return io.Discard, io.Discard
+	}
+	return deps.Stdout, deps.Stderr
+}
+
+// traceSlingRunner wraps r to print each sling query to w before running
+// it, as a shell line that can be pasted to reproduce it (--verbose).
+func traceSlingRunner(r SlingRunner, w io.Writer) SlingRunner {
+	return func(dir, command string, env map[string]string) (string, error) {
+		keys := make([]string, 0, len(env))
+		for k := range env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var line strings.Builder
+		for _, k := range keys {
+			line.WriteString(k + "=" + shellquote.Quote(env[k]) + " ")
+		}
+		line.WriteString(command)
+		fmt.Fprintln(w, traceLine(dir, line.String())) //nolint:errcheck // best-effort
+		return r(dir, command, env)
+	}
+}
+
+// traceCommandRunner wraps r to print each command, typically a bd
+// invocation made by the bead store, to w before running it (--verbose).
+func traceCommandRunner(r beads.CommandRunner, w io.Writer) beads.CommandRunner {
+	return func(dir, name string, args ...string) ([]byte, error) {
+		parts := []string{name}
+		for _, a := range args {
+			parts = append(parts, traceArg(a))
+		}
+		fmt.Fprintln(w, traceLine(dir, strings.Join(parts, " "))) //nolint:errcheck // best-effort
+		return r(dir, name, args...)
+	}
+}
+
+// traceLine formats a traced command in the style of "set -x".
+func traceLine(dir, command string) string {
+	if dir == "" {
+		return "+ " + command
+	}
+	return "+ (cd " + shellquote.Quote(dir) + " && " + command + ")"
+}
+
+// traceArg quotes a only when the shell would need it.
+func traceArg(a string) string {
+	if a != "" && strings.IndexFunc(a, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=,@+", r))
+	}) < 0 {
+		return a
+	}
+	return shellquote.Quote(a)
+}
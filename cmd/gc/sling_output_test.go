@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestDoSlingBatchQuiet(t *testing.T) {
+	runner := newFakeRunner()
+	runner.on("'BL-2'", "", errors.New("bd: connection refused"))
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	a := config.Agent{Name: "mayor", DefaultSlingFormula: "mol-work"}
+
+	q := newFakeChildQuerier()
+	runner.routesTo(q)
+	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
+	q.childrenOf["CVY-1"] = []beads.Bead{
+		{ID: "BL-1", Status: "open"},
+		{ID: "BL-2", Status: "open"},
+		{ID: "BL-3", Status: "closed"},
+	}
+
+	deps, stdout, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	opts := testOpts(a, "CVY-1")
+	opts.Quiet = true
+	if code := doSlingBatch(opts, deps, q); code != 1 {
+		t.Fatalf("doSlingBatch = %d, want 1 for the failed child", code)
+	}
+	if got := stdout.String(); got != "Slung 1/3 children of CVY-1 → mayor\n" {
+		t.Errorf("stdout = %q, want only the summary", got)
+	}
+	if got := stderr.String(); !strings.Contains(got, "Failed BL-2") || strings.Count(got, "\n") != 1 {
+		t.Errorf("stderr = %q, want only the BL-2 failure", got)
+	}
+}
+
+func TestDoSlingQuietKeepsResult(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	a := config.Agent{Name: "mayor", Suspended: true}
+
+	q := newFakeChildQuerier()
+	runner.routesTo(q)
+	q.beadsByID["BL-1"] = beads.Bead{ID: "BL-1", Status: "open"}
+
+	deps, stdout, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	opts := testOpts(a, "BL-1")
+	opts.NoConvoy = true
+	opts.Quiet = true
+	if code := doSling(opts, deps, q); code != 0 {
+		t.Fatalf("doSling = %d; stderr: %s", code, stderr.String())
+	}
+	if got := stdout.String(); got != "Slung BL-1 → mayor\n" {
+		t.Errorf("stdout = %q, want only the result line", got)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want warnings suppressed", stderr.String())
+	}
+}
+
+func TestTraceSlingRunner(t *testing.T) {
+	var trace bytes.Buffer
+	runner := newFakeRunner()
+	traced := traceSlingRunner(runner.run, &trace)
+	if _, err := traced("/rigs/my rig", "bd update 'BL-1' --assignee=$GC_SLING_TARGET",
+		map[string]string{"GC_SLING_TARGET": "mayor", "A": "1"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "+ (cd '/rigs/my rig' && A='1' GC_SLING_TARGET='mayor' bd update 'BL-1' --assignee=$GC_SLING_TARGET)\n"
+	if trace.String() != want {
+		t.Errorf("trace = %q, want %q", trace.String(), want)
+	}
+	if len(runner.calls) != 1 {
+		t.Errorf("runner calls = %v, want the command run once", runner.calls)
+	}
+}
+
+func TestTraceCommandRunner(t *testing.T) {
+	var trace bytes.Buffer
+	var ran []string
+	r := traceCommandRunner(func(_, name string, args ...string) ([]byte, error) {
+		ran = append(ran, name+" "+strings.Join(args, " "))
+		return []byte("{}"), nil
+	}, &trace)
+	if _, err := r("", "bd", "update", "BL-1", "--set-metadata", "note=hello world", "--json"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := trace.String(), "+ bd update BL-1 --set-metadata 'note=hello world' --json\n"; got != want {
+		t.Errorf("trace = %q, want %q", got, want)
+	}
+	if len(ran) != 1 {
+		t.Errorf("ran = %v, want one call", ran)
+	}
+}
+
+func TestSlingQuietVerboseExclusive(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	cmd := newSlingCmd(&stdout, &stderr)
+	cmd.SetArgs([]string{"mayor", "BL-1", "--quiet", "--verbose"})
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("--quiet with --verbose succeeded, want error")
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+	"golang.org/x/term"
+)
+
+// slingPickItem is one target offered by the sling picker.
+type slingPickItem struct {
+	name string // qualified agent or pool name
+	pool bool
+	load int // open + in-progress beads already routed; see slingLoad
+}
+
+// slingPickItems lists the unsuspended agents and pools a bead can be
+// slung to, in config order, with their current load.
+func slingPickItems(cityPath, cityName string, cfg *config.City, sp runtime.Provider) []slingPickItem {
+	var items []slingPickItem
+	for _, a := range cfg.Agents {
+		if a.Suspended {
+			continue
+		}
+		deps := slingDeps{CityName: cityName, CityPath: cityPath, Cfg: cfg, SP: sp}
+		if store, err := slingStoreFor(cityPath, cfg, a); err == nil {
+			deps.Store = store
+		}
+		items = append(items, slingPickItem{
+			name: a.QualifiedName(),
+			pool: a.IsPool(),
+			load: slingLoad(a, deps),
+		})
+	}
+	return items
+}
+
+// slingPickerTerminal returns the terminal the picker runs on, if both
+// stdin and stdout are one.
+func slingPickerTerminal(stdout io.Writer) (*os.File, *os.File, bool) {
+	out, ok := stdout.(*os.File)
+	if !ok || !term.IsTerminal(int(out.Fd())) || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, nil, false
+	}
+	return os.Stdin, out, true
+}
+
+// errSlingPickCancelled is returned when the user leaves the picker
+// without choosing.
+var errSlingPickCancelled = errors.New("no target selected")
+
+// runSlingPicker shows the picker on the terminal and returns the chosen
+// target's name.
+func runSlingPicker(items []slingPickItem, bead string, in, out *os.File) (string, error) {
+	if len(items) == 0 {
+		return "", errors.New("no agents or pools to sling to")
+	}
+	oldState, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(int(in.Fd()), oldState) //nolint:errcheck // best-effort terminal restore
+	defer fmt.Fprint(out, "\033[H\033[2J")     //nolint:errcheck // best-effort stdout
+
+	p := &slingPicker{items: items}
+	buf := make([]byte, 64)
+	for {
+		_, height, err := term.GetSize(int(out.Fd()))
+		if err != nil {
+			height = 24
+		}
+		fmt.Fprint(out, "\033[H\033[2J"+strings.ReplaceAll(p.render(bead, height), "\n", "\r\n")) //nolint:errcheck // best-effort stdout
+		n, err := in.Read(buf)
+		if err != nil {
+			return "", err
+		}
+		for _, k := range splitTopKeys(buf[:n]) {
+			if choice, done := p.handleKey(k); done {
+				if choice == "" {
+					return "", errSlingPickCancelled
+				}
+				return choice, nil
+			}
+		}
+	}
+}
+
+// slingPicker is the picker's state: a fuzzy filter over items and the
+// highlighted row within the matches.
+type slingPicker struct {
+	items  []slingPickItem
+	query  string
+	cursor int
+}
+
+// matches returns the items whose name fuzzily matches the query.
+func (p *slingPicker) matches() []slingPickItem {
+	var out []slingPickItem
+	for _, it := range p.items {
+		if fuzzyMatch(p.query, it.name) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// handleKey applies one key press. done is true when the picker should
+// close; choice is then the selected name, or "" if cancelled.
+func (p *slingPicker) handleKey(k string) (choice string, done bool) {
+	m := p.matches()
+	switch k {
+	case "\r", "\n":
+		if len(m) == 0 {
+			return "", false
+		}
+		return m[p.cursor].name, true
+	case "\x1b", "\x03", "\x04": // esc, ctrl-c, ctrl-d
+		return "", true
+	case "\x1b[A", "\x10": // up, ctrl-p
+		p.cursor--
+	case "\x1b[B", "\x0e": // down, ctrl-n
+		p.cursor++
+	case "\x7f", "\b":
+		if p.query != "" {
+			p.query = p.query[:len(p.query)-1]
+			p.cursor = 0
+		}
+	default:
+		if len(k) == 1 && k[0] >= ' ' && k[0] < 0x7f {
+			p.query += k
+			p.cursor = 0
+		}
+	}
+	if n := len(p.matches()); p.cursor >= n {
+		p.cursor = n - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	return "", false
+}
+
+// render draws the picker into at most height lines.
+func (p *slingPicker) render(bead string, height int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sling %s to: %s_\n\n", bead, p.query) //nolint:errcheck // strings.Builder
+	m := p.matches()
+	rows := height - 4
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if p.cursor >= rows {
+		start = p.cursor - rows + 1
+	}
+	for i := start; i < len(m) && i < start+rows; i++ {
+		it := m[i]
+		marker := "  "
+		if i == p.cursor {
+			marker = "> "
+		}
+		kind := "agent"
+		if it.pool {
+			kind = "pool"
+		}
+		fmt.Fprintf(&b, "%s%-30s %-6s %d queued\n", marker, it.name, kind, it.load) //nolint:errcheck // strings.Builder
+	}
+	if len(m) == 0 {
+		b.WriteString("  (no match)\n")
+	}
+	b.WriteString("\nenter select  esc cancel")
+	return b.String()
+}
+
+// fuzzyMatch reports whether the characters of query appear in s in
+// order, ignoring case.
+func fuzzyMatch(query, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(query) {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+utf8.RuneLen(r):]
+	}
+	return true
+}
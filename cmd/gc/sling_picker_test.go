@@ -0,0 +1,109 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	for _, tc := range []struct {
+		query, s string
+		want     bool
+	}{
+		{"", "mayor", true},
+		{"pcat", "hw/polecat", true},
+		{"HWP", "hw/polecat", true},
+		{"tacp", "hw/polecat", false},
+		{"é", "café", true},
+	} {
+		if got := fuzzyMatch(tc.query, tc.s); got != tc.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tc.query, tc.s, got, tc.want)
+		}
+	}
+}
+
+func testSlingPicker() *slingPicker {
+	return &slingPicker{items: []slingPickItem{
+		{name: "mayor"},
+		{name: "hw/polecat", pool: true, load: 3},
+		{name: "hw/refinery"},
+	}}
+}
+
+func TestSlingPickerFilterAndSelect(t *testing.T) {
+	p := testSlingPicker()
+	for _, k := range []string{"h", "w"} {
+		p.handleKey(k)
+	}
+	if got := len(p.matches()); got != 2 {
+		t.Fatalf("matches for %q = %d, want 2", p.query, got)
+	}
+	p.handleKey("\x1b[B")
+	choice, done := p.handleKey("\r")
+	if !done || choice != "hw/refinery" {
+		t.Errorf("choice = %q, %v; want hw/refinery, true", choice, done)
+	}
+}
+
+func TestSlingPickerCursorClamped(t *testing.T) {
+	p := testSlingPicker()
+	p.handleKey("\x1b[A")
+	if p.cursor != 0 {
+		t.Errorf("cursor = %d after up at top, want 0", p.cursor)
+	}
+	for i := 0; i < 5; i++ {
+		p.handleKey("\x1b[B")
+	}
+	if p.cursor != 2 {
+		t.Errorf("cursor = %d after moving past end, want 2", p.cursor)
+	}
+	// Narrowing the filter pulls the cursor back into range.
+	p.handleKey("m")
+	p.handleKey("a")
+	p.handleKey("y")
+	if choice, _ := p.handleKey("\r"); choice != "mayor" {
+		t.Errorf("choice = %q, want mayor", choice)
+	}
+}
+
+func TestSlingPickerCancelAndNoMatch(t *testing.T) {
+	p := testSlingPicker()
+	p.handleKey("z")
+	if choice, done := p.handleKey("\r"); done || choice != "" {
+		t.Errorf("enter with no match = %q, %v; want ignored", choice, done)
+	}
+	if !strings.Contains(p.render("BL-1", 20), "(no match)") {
+		t.Error("render missing no-match line")
+	}
+	p.handleKey("\x7f")
+	if p.query != "" {
+		t.Errorf("query after backspace = %q, want empty", p.query)
+	}
+	if choice, done := p.handleKey("\x1b"); !done || choice != "" {
+		t.Errorf("esc = %q, %v; want cancel", choice, done)
+	}
+}
+
+func TestSlingPickerRenderShowsLoad(t *testing.T) {
+	out := testSlingPicker().render("BL-42", 20)
+	for _, want := range []string{"Sling BL-42 to:", "> mayor", "hw/polecat", "pool", "3 queued"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDefaultSlingTargetFor(t *testing.T) {
+	cfg := &config.City{Rigs: []config.Rig{
+		{Name: "hw", Prefix: "hw", DefaultSlingTarget: "hw/polecat"},
+		{Name: "docs", Prefix: "dc"},
+	}}
+	if got, err := defaultSlingTargetFor(cfg, "hw-12"); err != nil || got != "hw/polecat" {
+		t.Errorf("defaultSlingTargetFor(hw-12) = %q, %v", got, err)
+	}
+	if _, err := defaultSlingTargetFor(cfg, "dc-3"); err == nil || !strings.Contains(err.Error(), "no default_sling_target") {
+		t.Errorf("defaultSlingTargetFor(dc-3) err = %v", err)
+	}
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// scheduledSlingTimeout bounds one scheduled sling run by the controller.
+const scheduledSlingTimeout = 5 * time.Minute
+
+// pendingSling is a sling deferred with --at or --in, stored as one JSON
+// file in .gc/schedule/ until the controller runs it.
+type pendingSling struct {
+	ID        string    `json:"id"`
+	Due       time.Time `json:"due"`
+	Args      []string  `json:"args"` // gc sling arguments and flags, minus --at/--in
+	Target    string    `json:"target,omitempty"`
+	Bead      string    `json:"bead"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// slingScheduleDir returns the directory holding a city's pending slings.
+func slingScheduleDir(cityPath string) string {
+	return citylayout.RuntimePath(cityPath, "schedule")
+}
+
+func newPendingSlingID() string {
+	var buf [4]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("sched-%d", time.Now().UnixNano())
+	}
+	return "sched-" + hex.EncodeToString(buf[:])
+}
+
+// parseSlingAt parses an --at time relative to now. A bare clock time
+// ("22:00") means its next occurrence; a date and time ("2026-05-01
+// 09:30") or RFC 3339 timestamp must be in the future.
+func parseSlingAt(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		due := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !due.After(now) {
+			due = due.AddDate(0, 0, 1)
+		}
+		return due, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", time.RFC3339} {
+		if due, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			if !due.After(now) {
+				return time.Time{}, fmt.Errorf("--at %q is in the past", s)
+			}
+			return due, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --at %q (want HH:MM, \"YYYY-MM-DD HH:MM\", or RFC 3339)", s)
+}
+
+// slingDueTime returns when a sling with the given --at and --in values
+// should run. Exactly one of at and in must be set.
+func slingDueTime(at, in string, now time.Time) (time.Time, error) {
+	if at != "" {
+		return parseSlingAt(at, now)
+	}
+	d, err := time.ParseDuration(in)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid --in %q (want a positive duration such as 2h or 90m)", in)
+	}
+	return now.Add(d), nil
+}
+
+// slingPassthroughArgs rebuilds the gc sling command line from the parsed
+// flags and positional args, dropping the scheduling flags and --city
+// (the controller supplies its own).
+func slingPassthroughArgs(flags *pflag.FlagSet, args []string) []string {
+	out := append([]string{}, args...)
+	flags.Visit(func(f *pflag.Flag) {
+		switch f.Name {
+		case "at", "in", "city":
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			for _, v := range sv.GetSlice() {
+				out = append(out, "--"+f.Name+"="+v)
+			}
+			return
+		}
+		out = append(out, "--"+f.Name+"="+f.Value.String())
+	})
+	return out
+}
+
+// cmdSlingSchedule is the CLI entry point for gc sling --at/--in. It
+// checks the target exists now, so typos surface immediately rather than
+// at run time.
+func cmdSlingSchedule(args, slingArgs []string, due time.Time, stdout, stderr io.Writer) int {
+	cityPath, err := resolveCity()
+	if err != nil {
+		fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	cfg, _, err := config.LoadWithIncludes(fsys.OSFS{}, filepath.Join(cityPath, "city.toml"))
+	if err != nil {
+		fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	ps := pendingSling{
+		ID:        newPendingSlingID(),
+		Due:       due,
+		Args:      slingArgs,
+		Bead:      args[len(args)-1],
+		Actor:     eventActor(),
+		CreatedAt: time.Now().UTC(),
+	}
+	if len(args) == 2 {
+		ps.Target = args[0]
+		for _, name := range strings.Split(ps.Target, ",") {
+			if _, ok := resolveAgentIdentity(cfg, strings.TrimSpace(name), currentRigContext(cfg)); !ok {
+				fmt.Fprintln(stderr, agentNotFoundMsg("gc sling", strings.TrimSpace(name), cfg)) //nolint:errcheck // best-effort stderr
+				return 1
+			}
+		}
+	}
+	return doSlingSchedule(cityPath, ps, stdout, stderr)
+}
+
+// doSlingSchedule persists ps for the controller to run when it is due.
+func doSlingSchedule(cityPath string, ps pendingSling, stdout, stderr io.Writer) int {
+	if err := writePendingSling(cityPath, ps); err != nil {
+		fmt.Fprintf(stderr, "gc sling: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	target := ps.Target
+	if target == "" {
+		target = "its default target"
+	}
+	fmt.Fprintf(stdout, "Scheduled %s to %s at %s (%s)\n", //nolint:errcheck // best-effort stdout
+		ps.Bead, target, ps.Due.Local().Format(time.DateTime+" MST"), ps.ID)
+	return 0
+}
+
+// writePendingSling stores ps atomically in the schedule directory.
+func writePendingSling(cityPath string, ps pendingSling) error {
+	dir := slingScheduleDir(cityPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating schedule directory: %w", err)
+	}
+	data, err := json.MarshalIndent(ps, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling pending sling: %w", err)
+	}
+	return fsys.WriteFileAtomic(fsys.OSFS{}, filepath.Join(dir, ps.ID+".json"), append(data, '\n'), 0o644)
+}
+
+// readPendingSlings returns the city's pending slings, soonest first. A
+// missing schedule directory means none; unreadable entries are skipped.
+func readPendingSlings(cityPath string) ([]pendingSling, error) {
+	entries, err := os.ReadDir(slingScheduleDir(cityPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading schedule: %w", err)
+	}
+	var list []pendingSling
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(slingScheduleDir(cityPath), e.Name()))
+		if err != nil {
+			continue
+		}
+		var ps pendingSling
+		if json.Unmarshal(data, &ps) == nil && ps.ID != "" {
+			list = append(list, ps)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Due.Before(list[j].Due) })
+	return list, nil
+}
+
+// claimDueSlings removes and returns the pending slings due at now.
+// Removing before running means a sling runs at most once, even if the
+// controller restarts mid-run.
+func claimDueSlings(cityPath string, now time.Time) ([]pendingSling, error) {
+	list, err := readPendingSlings(cityPath)
+	if err != nil {
+		return nil, err
+	}
+	var due []pendingSling
+	var errs []error
+	for _, ps := range list {
+		if ps.Due.After(now) {
+			break
+		}
+		if err := os.Remove(filepath.Join(slingScheduleDir(cityPath), ps.ID+".json")); err != nil {
+			errs = append(errs, fmt.Errorf("claiming %s: %w", ps.ID, err))
+			continue
+		}
+		due = append(due, ps)
+	}
+	return due, errors.Join(errs...)
+}
+
+// runScheduledSling runs a pending sling as a gc sling subprocess. A
+// variable so tests can stub it.
+var runScheduledSling = func(ctx context.Context, cityPath string, ps pendingSling) ([]byte, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, scheduledSlingTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, exe, append([]string{"--city", cityPath, "sling"}, ps.Args...)...)
+	return cmd.CombinedOutput()
+}
+
+// scheduleTick runs the pending slings that have come due. Each runs in
+// its own goroutine so a slow formula cook does not stall the tick.
+func (cr *CityRuntime) scheduleTick(ctx context.Context, now time.Time) {
+	due, err := claimDueSlings(cr.cityPath, now)
+	if err != nil {
+		fmt.Fprintf(cr.stderr, "%s: sling schedule: %v\n", cr.logPrefix, err) //nolint:errcheck // best-effort stderr
+	}
+	for _, ps := range due {
+		go func(ps pendingSling) {
+			out, err := runScheduledSling(ctx, cr.cityPath, ps)
+			if err != nil {
+				fmt.Fprintf(cr.stderr, "%s: scheduled sling %s (%s): %v: %s\n", //nolint:errcheck // best-effort stderr
+					cr.logPrefix, ps.ID, strings.Join(ps.Args, " "), err, strings.TrimSpace(string(out)))
+				return
+			}
+			fmt.Fprintf(cr.stdout, "Scheduled sling %s: %s\n", ps.ID, strings.TrimSpace(string(out))) //nolint:errcheck // best-effort stdout
+		}(ps)
+	}
+}
+
+func newSlingPendingCmd(stdout, stderr io.Writer) *cobra.Command {
+	var jsonFlag bool
+	cmd := &cobra.Command{
+		Use:   "pending",
+		Short: "List slings scheduled with --at or --in",
+		Long: `List slings waiting in .gc/schedule/, soonest first.
+
+The controller runs each one when it comes due, exactly as if
+"gc sling" had been typed then with the same arguments. Nothing is
+routed by this command; use "gc sling unschedule" to drop an entry.`,
+		Example: `  gc sling pending
+  gc sling pending --json`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cityPath, err := resolveCity()
+			if err != nil {
+				fmt.Fprintf(stderr, "gc sling pending: %v\n", err) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if doSlingPending(cityPath, jsonFlag, stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Output in JSON format")
+	return cmd
+}
+
+// doSlingPending prints the city's pending slings.
+func doSlingPending(cityPath string, jsonOutput bool, stdout, stderr io.Writer) int {
+	list, err := readPendingSlings(cityPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "gc sling pending: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if jsonOutput {
+		if list == nil {
+			list = []pendingSling{}
+		}
+		data, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "gc sling pending: %v\n", err) //nolint:errcheck // best-effort stderr
+			return 1
+		}
+		fmt.Fprintln(stdout, string(data)) //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	if len(list) == 0 {
+		fmt.Fprintln(stdout, "No pending slings") //nolint:errcheck // best-effort stdout
+		return 0
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDUE\tBEAD\tTARGET\tACTOR\tCOMMAND") //nolint:errcheck // best-effort stdout
+	for _, ps := range list {
+		target := ps.Target
+		if target == "" {
+			target = "(default)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\tgc sling %s\n", //nolint:errcheck // best-effort stdout
+			ps.ID, ps.Due.Local().Format(time.DateTime), ps.Bead, target, ps.Actor, strings.Join(ps.Args, " "))
+	}
+	tw.Flush() //nolint:errcheck // best-effort stdout
+	return 0
+}
+
+func newSlingUnscheduleCmd(stdout, stderr io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unschedule <id>",
+		Short: "Drop a pending scheduled sling",
+		Long:  `Remove a sling scheduled with --at or --in before it runs. IDs are shown by "gc sling pending".`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			cityPath, err := resolveCity()
+			if err != nil {
+				fmt.Fprintf(stderr, "gc sling unschedule: %v\n", err) //nolint:errcheck // best-effort stderr
+				return errExit
+			}
+			if doSlingUnschedule(cityPath, args[0], stdout, stderr) != 0 {
+				return errExit
+			}
+			return nil
+		},
+	}
+}
+
+// doSlingUnschedule deletes the pending sling with the given ID.
+func doSlingUnschedule(cityPath, id string, stdout, stderr io.Writer) int {
+	if id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		fmt.Fprintf(stderr, "gc sling unschedule: invalid id %q\n", id) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	err := os.Remove(filepath.Join(slingScheduleDir(cityPath), id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(stderr, "gc sling unschedule: no pending sling %q (already run or removed?)\n", id) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gc sling unschedule: %v\n", err) //nolint:errcheck // best-effort stderr
+		return 1
+	}
+	fmt.Fprintf(stdout, "Unscheduled %s\n", id) //nolint:errcheck // best-effort stdout
+	return 0
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSlingDueTime(t *testing.T) {
+	now := time.Date(2026, 5, 1, 21, 30, 0, 0, time.Local)
+	for _, tc := range []struct {
+		at, in string
+		want   time.Time
+	}{
+		{at: "22:00", want: time.Date(2026, 5, 1, 22, 0, 0, 0, time.Local)},
+		{at: "09:15", want: time.Date(2026, 5, 2, 9, 15, 0, 0, time.Local)},
+		{at: "2026-05-03 08:00", want: time.Date(2026, 5, 3, 8, 0, 0, 0, time.Local)},
+		{in: "2h", want: now.Add(2 * time.Hour)},
+	} {
+		got, err := slingDueTime(tc.at, tc.in, now)
+		if err != nil || !got.Equal(tc.want) {
+			t.Errorf("slingDueTime(%q, %q) = %v, %v; want %v", tc.at, tc.in, got, err, tc.want)
+		}
+	}
+	for _, tc := range []struct{ at, in, want string }{
+		{at: "tonight", want: "invalid --at"},
+		{at: "2026-04-30 08:00", want: "in the past"},
+		{in: "soon", want: "invalid --in"},
+		{in: "-5m", want: "invalid --in"},
+	} {
+		if _, err := slingDueTime(tc.at, tc.in, now); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("slingDueTime(%q, %q) = %v, want error containing %q", tc.at, tc.in, err, tc.want)
+		}
+	}
+}
+
+func TestSlingPassthroughArgs(t *testing.T) {
+	cmd := newSlingCmd(io.Discard, io.Discard)
+	cmd.Flags().String("city", "", "")
+	args := []string{"polecat", "BL-7", "--at", "22:00", "--city", "/c", "--nudge", "--var", "a=1", "--var", "b=2", "--on", "review"}
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+	got := slingPassthroughArgs(cmd.Flags(), cmd.Flags().Args())
+	want := []string{"polecat", "BL-7", "--nudge=true", "--on=review", "--var=a=1", "--var=b=2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("slingPassthroughArgs = %q, want %q", got, want)
+	}
+}
+
+func TestClaimDueSlings(t *testing.T) {
+	cityPath := t.TempDir()
+	now := time.Now()
+	for _, ps := range []pendingSling{
+		{ID: "sched-late", Due: now.Add(time.Hour), Args: []string{"BL-3"}, Bead: "BL-3"},
+		{ID: "sched-a", Due: now.Add(-time.Minute), Args: []string{"mayor", "BL-1"}, Target: "mayor", Bead: "BL-1"},
+		{ID: "sched-b", Due: now, Args: []string{"BL-2"}, Bead: "BL-2"},
+	} {
+		if err := writePendingSling(cityPath, ps); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	due, err := claimDueSlings(cityPath, now)
+	if err != nil {
+		t.Fatalf("claimDueSlings: %v", err)
+	}
+	if len(due) != 2 || due[0].ID != "sched-a" || due[1].ID != "sched-b" {
+		t.Fatalf("due = %+v, want sched-a, sched-b", due)
+	}
+	left, _ := readPendingSlings(cityPath)
+	if len(left) != 1 || left[0].ID != "sched-late" {
+		t.Errorf("left = %+v, want only sched-late", left)
+	}
+	if due, _ := claimDueSlings(cityPath, now); len(due) != 0 {
+		t.Errorf("second claim = %+v, want none", due)
+	}
+}
+
+func TestScheduleTickRunsDueSlings(t *testing.T) {
+	cityPath := t.TempDir()
+	if err := writePendingSling(cityPath, pendingSling{ID: "sched-a", Due: time.Now().Add(-time.Second), Args: []string{"mayor", "BL-1"}, Bead: "BL-1"}); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var ran [][]string
+	done := make(chan struct{})
+	old := runScheduledSling
+	runScheduledSling = func(_ context.Context, city string, ps pendingSling) ([]byte, error) {
+		mu.Lock()
+		ran = append(ran, append([]string{city}, ps.Args...))
+		mu.Unlock()
+		close(done)
+		return []byte("Slung BL-1 → mayor\n"), nil
+	}
+	t.Cleanup(func() { runScheduledSling = old })
+
+	cr := &CityRuntime{cityPath: cityPath, logPrefix: "gc test", stdout: io.Discard, stderr: io.Discard}
+	cr.scheduleTick(context.Background(), time.Now())
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduled sling not run")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := [][]string{{cityPath, "mayor", "BL-1"}}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran = %q, want %q", ran, want)
+	}
+}
+
+func TestDoSlingPendingAndUnschedule(t *testing.T) {
+	cityPath := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	if code := doSlingPending(cityPath, false, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "No pending slings") {
+		t.Fatalf("empty pending = %d, %q", code, stdout.String())
+	}
+
+	ps := pendingSling{ID: "sched-1", Due: time.Now().Add(time.Hour), Args: []string{"BL-7", "--nudge=true"}, Bead: "BL-7", Actor: "human"}
+	stdout.Reset()
+	if code := doSlingSchedule(cityPath, ps, &stdout, &stderr); code != 0 {
+		t.Fatalf("doSlingSchedule = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Scheduled BL-7 to its default target") {
+		t.Errorf("schedule output = %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := doSlingPending(cityPath, false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doSlingPending = %d", code)
+	}
+	for _, want := range []string{"sched-1", "(default)", "gc sling BL-7 --nudge=true"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("pending output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := doSlingUnschedule(cityPath, "sched-1", &stdout, &stderr); code != 0 {
+		t.Fatalf("doSlingUnschedule = %d; stderr: %s", code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(slingScheduleDir(cityPath), "sched-1.json")); !os.IsNotExist(err) {
+		t.Errorf("pending file still present: %v", err)
+	}
+	if code := doSlingUnschedule(cityPath, "sched-1", &stdout, &stderr); code != 1 {
+		t.Errorf("second unschedule = %d, want 1", code)
+	}
+	if code := doSlingUnschedule(cityPath, "../city", &stdout, &stderr); code != 1 {
+		t.Errorf("unschedule of path = %d, want 1", code)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// slingVerifyBackoff is the wait before each retry of a sling query or
+// re-read when verifying a sling. bd can fail transiently while Dolt is
+// busy, so a failed query or a failed or stale read is retried; one that
+// still fails after the last wait fails the sling.
+var slingVerifyBackoff = []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, time.Second}
+
+// runSlingQuery runs a's sling query for beadID and verifies it routed
+// the bead. The default queries are idempotent, so one that fails with a
+// transient bd error is run again; a custom sling_query fails on its
+// first error.
+func runSlingQuery(deps slingDeps, q BeadQuerier, beadID string, a config.Agent, env map[string]string) error {
+	slingCmd := buildSlingCommand(a.EffectiveSlingQuery(), beadID)
+	rigDir := rigDirForBead(deps.Cfg, beadID)
+	for attempt := 0; ; attempt++ {
+		out, err := deps.Runner(rigDir, slingCmd, env)
+		if err == nil {
+			break
+		}
+		if isCustomSlingQuery(a) || !isTransientBdError(out, err) || attempt == len(slingVerifyBackoff) {
+			return err
+		}
+		time.Sleep(slingVerifyBackoff[attempt])
+	}
+	fresh, closeFresh := freshSlingQuerier(deps.CityPath, q)
+	defer closeFresh()
+	return verifySling(fresh, beadID, a, env)
+}
+
+// isTransientBdError reports whether a failed bd command, given its output
+// and error, failed on a busy or unreachable database and may succeed if
+// run again.
+func isTransientBdError(out string, err error) bool {
+	msg := strings.ToLower(out + " " + err.Error())
+	for _, s := range []string{"database is locked", "sqlite_busy", "connection refused", "connection reset", "i/o timeout", "try again"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// freshSlingQuerier returns a newly opened store to verify a sling with
+// when the city uses the file or sqlite provider: the sling query ran in
+// another process, and q, opened in this one, may not see its write.
+// Other providers (bd) read through to the database, so q is returned.
+// The returned func releases the fresh store.
+func freshSlingQuerier(cityPath string, q BeadQuerier) (BeadQuerier, func()) {
+	if q == nil || cityPath == "" {
+		return q, func() {}
+	}
+	switch rawBeadsProvider(cityPath) {
+	case "file":
+		if s, err := beads.OpenFileStore(fsys.OSFS{}, filepath.Join(cityPath, ".gc", "beads.json")); err == nil {
+			return s, func() {}
+		}
+	case "sqlite":
+		if s, err := beads.OpenSQLiteStore(fsys.OSFS{}, filepath.Join(cityPath, ".gc", "beads.db")); err == nil {
+			return s, func() { s.CloseDB() } //nolint:errcheck // read-only use
+		}
+	}
+	return q, func() {}
+}
+
+// verifySling re-reads beadID through q after the sling query ran and
+// confirms the query routed it to a: the pool label for pools, the
+// GC_SLING_TARGET assignee for fixed agents. A sling query can exit 0
+// without touching the bead (wrong rig database, a bd shim), which would
+// otherwise leave the bead unrouted behind a success message.
+// Nil querier and custom sling_query targets are not verified.
+func verifySling(q BeadQuerier, beadID string, a config.Agent, env map[string]string) error {
+	if q == nil || isCustomSlingQuery(a) {
+		return nil
+	}
+	var err error
+	for attempt := 0; ; attempt++ {
+		var b beads.Bead
+		if b, err = q.Get(beadID); err != nil {
+			err = fmt.Errorf("re-reading bead: %w", err)
+		} else if err = checkSlingApplied(b, a, env); err == nil {
+			return nil
+		}
+		if errors.Is(err, beads.ErrNotFound) || attempt == len(slingVerifyBackoff) {
+			break
+		}
+		time.Sleep(slingVerifyBackoff[attempt])
+	}
+	return fmt.Errorf("verifying %s → %s: %w", beadID, a.QualifiedName(), err)
+}
+
+// checkSlingApplied reports how b differs from what the default sling
+// query for a would have left.
+func checkSlingApplied(b beads.Bead, a config.Agent, env map[string]string) error {
+	if a.IsPool() {
+		label := poolRouteLabel(a)
+		if !slices.Contains(b.Labels, label) {
+			return fmt.Errorf("sling query succeeded but bead lacks label %q", label)
+		}
+		return nil
+	}
+	want := env["GC_SLING_TARGET"]
+	if b.Assignee == want || b.Assignee == a.QualifiedName() {
+		return nil
+	}
+	if b.Assignee == "" {
+		return fmt.Errorf("sling query succeeded but bead is unassigned, want %q", want)
+	}
+	return fmt.Errorf("sling query succeeded but bead is assigned to %q, want %q", b.Assignee, want)
+}
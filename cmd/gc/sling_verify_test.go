@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// noVerifyBackoff retries sling verification without waiting.
+func noVerifyBackoff(t *testing.T) {
+	t.Helper()
+	saved := slingVerifyBackoff
+	slingVerifyBackoff = []time.Duration{0, 0, 0}
+	t.Cleanup(func() { slingVerifyBackoff = saved })
+}
+
+func TestDoSlingVerifyUnroutedFails(t *testing.T) {
+	noVerifyBackoff(t)
+	runner := newFakeRunner() // exits 0 without touching the bead
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	q := &fakeQuerier{bead: beads.Bead{ID: "BL-42"}}
+
+	deps, stdout, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	if code := doSling(testOpts(config.Agent{Name: "mayor"}, "BL-42"), deps, q); code != 1 {
+		t.Fatalf("doSling = %d, want 1", code)
+	}
+	want := `gc sling: verifying BL-42 → mayor: sling query succeeded but bead is unassigned, want "mayor"`
+	if !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+	if strings.Contains(stdout.String(), "Slung") {
+		t.Errorf("stdout = %q, want no success message", stdout.String())
+	}
+}
+
+func TestDoSlingVerifyRetriesTransientFailure(t *testing.T) {
+	noVerifyBackoff(t)
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	q := &fakeQuerier{bead: beads.Bead{ID: "BL-42"}, err: fmt.Errorf("database is locked"), errLeft: 2}
+	runner.routesTo(q)
+
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	opts := testOpts(config.Agent{Name: "mayor"}, "BL-42")
+	opts.Force = true // skip the pre-flight read
+	if code := doSling(opts, deps, q); code != 0 {
+		t.Fatalf("doSling = %d, want 0; stderr: %s", code, stderr.String())
+	}
+}
+
+func TestDoSlingVerifyPersistentFailure(t *testing.T) {
+	noVerifyBackoff(t)
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	q := &fakeQuerier{err: fmt.Errorf("database is locked")}
+
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	if code := doSling(testOpts(config.Agent{Name: "mayor"}, "BL-42"), deps, q); code != 1 {
+		t.Fatalf("doSling = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "re-reading bead: database is locked") {
+		t.Errorf("stderr = %q, want re-read error", stderr.String())
+	}
+}
+
+func TestDoSlingRetriesTransientQueryFailure(t *testing.T) {
+	noVerifyBackoff(t)
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	q := &fakeQuerier{bead: beads.Bead{ID: "BL-42"}}
+	runner.routesTo(q)
+	busy := 2
+	flaky := func(dir, command string, env map[string]string) (string, error) {
+		if busy > 0 {
+			busy--
+			return "Error: database is locked", errors.New("exit status 1")
+		}
+		return runner.run(dir, command, env)
+	}
+
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), flaky)
+	opts := testOpts(config.Agent{Name: "mayor"}, "BL-42")
+	opts.Force = true
+	opts.NoConvoy = true
+	if code := doSling(opts, deps, q); code != 0 {
+		t.Fatalf("doSling = %d, want 0; stderr: %s", code, stderr.String())
+	}
+	if len(runner.calls) != 1 || q.bead.Assignee != "mayor" {
+		t.Errorf("calls = %v, assignee = %q; want the query retried until it ran", runner.calls, q.bead.Assignee)
+	}
+}
+
+func TestDoSlingNoRetryOnPermanentQueryFailure(t *testing.T) {
+	noVerifyBackoff(t)
+	calls := 0
+	failing := func(string, string, map[string]string) (string, error) {
+		calls++
+		return "Error: no issue found", errors.New("exit status 1")
+	}
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	deps, _, _ := testDeps(cfg, runtime.NewFake(), failing)
+	opts := testOpts(config.Agent{Name: "mayor"}, "BL-42")
+	opts.Force = true
+	if code := doSling(opts, deps, &fakeQuerier{bead: beads.Bead{ID: "BL-42"}}); code != 1 {
+		t.Fatalf("doSling = %d, want 1", code)
+	}
+	if calls != 1 {
+		t.Errorf("sling query ran %d times, want 1", calls)
+	}
+}
+
+func TestDoSlingVerifiesAgainstFreshFileStore(t *testing.T) {
+	noVerifyBackoff(t)
+	t.Setenv("GC_BEADS", "file")
+	cityPath := t.TempDir()
+	path := filepath.Join(cityPath, ".gc", "beads.json")
+	disk, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := disk.Create(beads.Bead{Title: "work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The sling query runs in another process: it writes the file, which
+	// the store opened before it ran (stale) does not see.
+	stale := &fakeQuerier{bead: b}
+	external := func(_, _ string, env map[string]string) (string, error) {
+		other, err := beads.OpenFileStore(fsys.OSFS{}, path)
+		if err != nil {
+			return "", err
+		}
+		assignee := env["GC_SLING_TARGET"]
+		return "", other.Update(b.ID, beads.UpdateOpts{Assignee: &assignee})
+	}
+
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), external)
+	deps.CityPath = cityPath
+	opts := testOpts(config.Agent{Name: "mayor"}, b.ID)
+	opts.Force = true
+	opts.NoConvoy = true
+	if code := doSling(opts, deps, stale); code != 0 {
+		t.Fatalf("doSling = %d, want 0; stderr: %s", code, stderr.String())
+	}
+}
+
+func TestDoSlingVerifyPoolLabel(t *testing.T) {
+	noVerifyBackoff(t)
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	pool := config.Agent{Name: "polecat", Dir: "hw", Pool: &config.PoolConfig{Max: 3}}
+	q := &fakeQuerier{bead: beads.Bead{ID: "HW-1", Labels: []string{"pool:hw/other"}}}
+
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	opts := testOpts(pool, "HW-1")
+	opts.NoConvoy = true
+	if code := doSling(opts, deps, q); code != 1 {
+		t.Fatalf("doSling = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), `bead lacks label "pool:hw/polecat"`) {
+		t.Errorf("stderr = %q, want missing label error", stderr.String())
+	}
+
+	stderr.Reset()
+	runner.routesTo(q)
+	if code := doSling(opts, deps, q); code != 0 {
+		t.Fatalf("doSling = %d, want 0; stderr: %s", code, stderr.String())
+	}
+}
+
+func TestDoSlingVerifySkipsCustomQuery(t *testing.T) {
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	a := config.Agent{Name: "mayor", SlingQuery: "my-router {}"}
+	q := &fakeQuerier{bead: beads.Bead{ID: "BL-42"}}
+
+	deps, _, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	if code := doSling(testOpts(a, "BL-42"), deps, q); code != 0 {
+		t.Fatalf("doSling = %d, want 0; stderr: %s", code, stderr.String())
+	}
+}
+
+func TestDoSlingBatchVerifyFailureCountsAsFailed(t *testing.T) {
+	noVerifyBackoff(t)
+	runner := newFakeRunner()
+	cfg := &config.City{Workspace: config.Workspace{Name: "test-city"}}
+	q := newFakeChildQuerier()
+	q.beadsByID["CVY-1"] = beads.Bead{ID: "CVY-1", Type: "convoy", Status: "open"}
+	q.childrenOf["CVY-1"] = []beads.Bead{{ID: "BL-1", Status: "open"}, {ID: "BL-2", Status: "open"}}
+	// Only BL-1's sling lands; BL-2's query exits 0 but routes nothing.
+	q.beadsByID["BL-2"] = beads.Bead{ID: "BL-2", Status: "open"}
+	routed := &skipRoute{fakeChildQuerier: q, skip: "BL-2"}
+	runner.routesTo(routed)
+
+	deps, stdout, stderr := testDeps(cfg, runtime.NewFake(), runner.run)
+	if code := doSlingBatch(testOpts(config.Agent{Name: "mayor"}, "CVY-1"), deps, q); code != 1 {
+		t.Fatalf("doSlingBatch = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "Failed BL-2: verifying BL-2 → mayor") {
+		t.Errorf("stderr = %q, want BL-2 verification failure", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Slung 1/2 children of CVY-1") {
+		t.Errorf("stdout = %q, want 1/2 summary", stdout.String())
+	}
+}
+
+// skipRoute drops the routing for one bead, simulating a sling query that
+// exits 0 without applying.
+type skipRoute struct {
+	*fakeChildQuerier
+	skip string
+}
+
+func (s *skipRoute) route(beadID, assignee, label string) {
+	if beadID != s.skip {
+		s.fakeChildQuerier.route(beadID, assignee, label)
+	}
+}
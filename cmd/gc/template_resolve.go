@@ -21,6 +21,7 @@ import (
 	"github.com/gastownhall/gascity/internal/config"
 	"github.com/gastownhall/gascity/internal/convergence"
 	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/shellquote"
 )
 
 // TemplateParams holds all resolved values needed to start a session.
@@ -136,15 +137,17 @@ func resolveTemplate(p *agentBuildParams, cfgAgent *config.Agent, qualifiedName
 		agentEnv["GC_RIG"] = rigName
 	}
 
-	// Step 9: Render prompt with beacon.
+	// Step 9: Render prompt with beacon. A stdin-mode provider has no
+	// launch argument to carry it, so interactive sessions start bare.
 	var prompt string
-	if resolved.PromptMode != "none" {
+	if resolved.PromptMode != "none" && resolved.PromptMode != "stdin" {
 		fragments := mergeFragmentLists(p.globalFragments, cfgAgent.InjectFragments)
 		prompt = renderPrompt(p.fs, p.cityPath, p.cityName, cfgAgent.PromptTemplate, PromptContext{
 			CityRoot:      p.cityPath,
 			AgentName:     qualifiedName,
 			TemplateName:  cfgAgent.Name,
 			RigName:       rigName,
+			RigRoot:       findRigPath(rigName, p.rigs),
 			WorkDir:       workDir,
 			IssuePrefix:   findRigPrefix(rigName, p.rigs),
 			DefaultBranch: defaultBranchFor(workDir),
@@ -161,8 +164,13 @@ func resolveTemplate(p *agentBuildParams, cfgAgent *config.Agent, qualifiedName
 		}
 	}
 
-	// Step 10: Merge environment layers.
-	env := convergence.ScrubTokenEnv(mergeEnv(passthroughEnv(), expandEnvMap(resolved.Env), expandEnvMap(cfgAgent.Env), agentEnv))
+	// Step 10: Merge environment layers. Secrets from env_file and the
+	// keychain sit between provider and agent env, so inline env wins.
+	secrets, err := resolveAgentSecrets(p.cityPath, cfgAgent)
+	if err != nil {
+		return TemplateParams{}, fmt.Errorf("agent %q: %w", qualifiedName, err)
+	}
+	env := convergence.ScrubTokenEnv(mergeEnv(passthroughEnv(), expandEnvMap(resolved.Env), secrets, expandEnvMap(cfgAgent.Env), agentEnv))
 
 	// Step 11: Expand session setup templates.
 	configDir := p.cityPath
@@ -191,6 +199,7 @@ func resolveTemplate(p *agentBuildParams, cfgAgent *config.Agent, qualifiedName
 	hints := agent.StartupHints{
 		ReadyPromptPrefix:      resolved.ReadyPromptPrefix,
 		ReadyDelayMs:           resolved.ReadyDelayMs,
+		ReadyProbe:             resolved.ReadyProbe,
 		ProcessNames:           resolved.ProcessNames,
 		EmitsPermissionWarning: resolved.EmitsPermissionWarning,
 		Nudge:                  cfgAgent.Nudge,
@@ -226,7 +235,7 @@ func resolveTemplate(p *agentBuildParams, cfgAgent *config.Agent, qualifiedName
 func templateParamsToConfig(tp TemplateParams) runtime.Config {
 	var promptSuffix string
 	if tp.Prompt != "" {
-		promptSuffix = shellQuote(tp.Prompt)
+		promptSuffix = shellquote.Quote(tp.Prompt)
 	}
 	return runtime.Config{
 		Command:                tp.Command,
@@ -235,6 +244,7 @@ func templateParamsToConfig(tp TemplateParams) runtime.Config {
 		WorkDir:                tp.WorkDir,
 		ReadyPromptPrefix:      tp.Hints.ReadyPromptPrefix,
 		ReadyDelayMs:           tp.Hints.ReadyDelayMs,
+		ReadyProbe:             tp.Hints.ReadyProbe,
 		ProcessNames:           tp.Hints.ProcessNames,
 		EmitsPermissionWarning: tp.Hints.EmitsPermissionWarning,
 		Nudge:                  tp.Hints.Nudge,
@@ -1,11 +1,12 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/fsys"
 )
 
 // wispGC performs mechanical garbage collection of closed molecules that
@@ -15,31 +16,33 @@ type wispGC interface {
 	// shouldRun returns true if enough time has elapsed since the last run.
 	shouldRun(now time.Time) bool
 
-	// runGC lists closed molecules, deletes those older than TTL, and returns
-	// the count of purged entries. Errors from individual deletes are
-	// best-effort (logged but not fatal); the returned error is for list
-	// failures.
+	// runGC removes the city's expired wisps (see expiredWisps) and returns
+	// how many beads were purged. Individual removal failures are reported
+	// in the error alongside the count of beads that did go.
 	runGC(cityPath string, now time.Time) (int, error)
 }
 
 // memoryWispGC is the production implementation of wispGC.
 type memoryWispGC struct {
-	interval time.Duration
-	ttl      time.Duration
-	lastRun  time.Time
-	runner   beads.CommandRunner
+	interval  time.Duration
+	ttl       time.Duration
+	archive   bool
+	lastRun   time.Time
+	openStore func(cityPath string) (beads.Store, error)
 }
 
 // newWispGC creates a wisp GC tracker. Returns nil if disabled (interval or
-// TTL is zero). Callers nil-guard before use.
-func newWispGC(interval, ttl time.Duration, runner beads.CommandRunner) wispGC {
+// TTL is zero). Callers nil-guard before use. With archive set, purged
+// beads are first written to the city's bead archive (see gc bead archive).
+func newWispGC(interval, ttl time.Duration, archive bool, openStore func(string) (beads.Store, error)) wispGC {
 	if interval <= 0 || ttl <= 0 {
 		return nil
 	}
 	return &memoryWispGC{
-		interval: interval,
-		ttl:      ttl,
-		runner:   runner,
+		interval:  interval,
+		ttl:       ttl,
+		archive:   archive,
+		openStore: openStore,
 	}
 }
 
@@ -47,67 +50,122 @@ func (m *memoryWispGC) shouldRun(now time.Time) bool {
 	return now.Sub(m.lastRun) >= m.interval
 }
 
-// gcEntry is the JSON structure returned by bd list --json for a bead.
-type gcEntry struct {
-	ID        string `json:"id"`
-	CreatedAt string `json:"created_at"`
-	Status    string `json:"status"`
-	Type      string `json:"type"`
-}
-
 func (m *memoryWispGC) runGC(cityPath string, now time.Time) (int, error) {
 	m.lastRun = now
+	store, err := m.openStore(cityPath)
+	if err != nil {
+		return 0, err
+	}
+	wisps, err := expiredWisps(store, now.Add(-m.ttl))
+	if err != nil {
+		return 0, err
+	}
+	archiveDir := ""
+	if m.archive {
+		archiveDir = beadArchiveDir(cityPath)
+	}
+	return purgeWisps(store, fsys.OSFS{}, archiveDir, wisps)
+}
 
-	// List closed molecules.
-	out, err := m.runner(cityPath, "bd", "list", "--json", "--limit=0", "--status=closed", "--type=molecule")
+// expiredWisps picks the beads wisp GC removes from store: closed
+// molecules and closed automation-tracking beads whose ArchiveTime is
+// before cutoff and whose parent is closed or gone, together with every
+// descendant of such a molecule. A wisp attached to work that is still
+// open is kept for as long as the work is. Only closed wisp and tracking
+// beads are queried; parents and descendants are fetched per candidate,
+// so the cost does not grow with the city's closed work.
+func expiredWisps(store beads.Store, cutoff time.Time) ([]beads.Bead, error) {
+	var candidates []beads.Bead
+	for _, t := range beads.MoleculeTypes() {
+		mols, err := store.Query(beads.Filter{Status: "closed", Type: t})
+		if err != nil {
+			return nil, fmt.Errorf("listing closed %s beads: %w", t, err)
+		}
+		candidates = append(candidates, mols...)
+	}
+	tracking, err := store.Query(beads.Filter{Status: "closed", Label: "automation-tracking"})
 	if err != nil {
-		return 0, fmt.Errorf("listing closed molecules: %w", err)
+		return nil, fmt.Errorf("listing automation-tracking beads: %w", err)
 	}
+	candidates = append(candidates, tracking...)
 
-	var entries []gcEntry
-	if err := json.Unmarshal(out, &entries); err != nil {
-		return 0, fmt.Errorf("parsing molecule list: %w", err)
+	parentLive := make(map[string]bool)
+	isParentLive := func(id string) (bool, error) {
+		if live, ok := parentLive[id]; ok {
+			return live, nil
+		}
+		p, err := store.Get(id)
+		if err != nil && !errors.Is(err, beads.ErrNotFound) {
+			return false, fmt.Errorf("reading parent %s: %w", id, err)
+		}
+		live := err == nil && p.Status != "closed"
+		parentLive[id] = live
+		return live, nil
 	}
 
-	cutoff := now.Add(-m.ttl)
-	purged := 0
-	for _, e := range entries {
-		created, err := time.Parse(time.RFC3339, e.CreatedAt)
+	var out []beads.Bead
+	expired := make(map[string]bool)
+	var collect func(b beads.Bead) error
+	collect = func(b beads.Bead) error {
+		if expired[b.ID] {
+			return nil
+		}
+		expired[b.ID] = true
+		out = append(out, b)
+		children, err := store.Children(b.ID)
 		if err != nil {
-			continue // skip unparseable timestamps
+			return fmt.Errorf("listing steps of %s: %w", b.ID, err)
 		}
-		if created.Before(cutoff) {
-			_, delErr := m.runner(cityPath, "bd", "delete", e.ID, "--force")
-			if delErr != nil {
-				continue // best-effort: skip failed deletes
+		for _, c := range children {
+			if err := collect(c); err != nil {
+				return err
 			}
-			purged++
 		}
+		return nil
 	}
-
-	// Purge expired closed tracking beads.
-	trackOut, trackErr := m.runner(cityPath, "bd", "list", "--json",
-		"--label=automation-tracking", "--all", "--limit=0")
-	if trackErr == nil {
-		var trackEntries []gcEntry
-		if err := json.Unmarshal(trackOut, &trackEntries); err == nil {
-			for _, e := range trackEntries {
-				if e.Status != "closed" {
-					continue
-				}
-				created, err := time.Parse(time.RFC3339, e.CreatedAt)
-				if err != nil {
-					continue
-				}
-				if created.Before(cutoff) {
-					_, delErr := m.runner(cityPath, "bd", "delete", e.ID, "--force")
-					if delErr == nil {
-						purged++
-					}
-				}
+	for _, b := range candidates {
+		if expired[b.ID] || !beads.ArchiveTime(b).Before(cutoff) {
+			continue
+		}
+		if b.ParentID != "" {
+			live, err := isParentLive(b.ParentID)
+			if err != nil {
+				return nil, err
 			}
+			if live {
+				continue
+			}
+		}
+		if !beads.IsMoleculeType(b.Type) {
+			expired[b.ID] = true
+			out = append(out, b)
+			continue
+		}
+		if err := collect(b); err != nil {
+			return nil, err
 		}
 	}
+	return out, nil
+}
 
-	return purged, nil
+// purgeWisps removes wisps from store, archiving them to archiveDir first
+// unless it is "". Returns how many beads the store removed.
+func purgeWisps(store beads.Store, fs fsys.FS, archiveDir string, wisps []beads.Bead) (int, error) {
+	if len(wisps) == 0 {
+		return 0, nil
+	}
+	remover, ok := store.(beads.Remover)
+	if !ok {
+		return 0, errors.New("bead store does not support removing beads")
+	}
+	if archiveDir != "" {
+		if err := beads.WriteArchive(fs, archiveDir, wisps); err != nil {
+			return 0, err
+		}
+	}
+	ids := make([]string, len(wisps))
+	for i, b := range wisps {
+		ids[i] = b.ID
+	}
+	return remover.Remove(ids)
 }
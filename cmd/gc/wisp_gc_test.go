@@ -1,13 +1,16 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/fsys"
 )
 
 func TestWispGC_NilSafe(t *testing.T) {
@@ -20,18 +23,18 @@ func TestWispGC_NilSafe(t *testing.T) {
 
 func TestWispGC_DisabledReturnsNil(t *testing.T) {
 	// interval=0 or ttl=0 → disabled → nil.
-	wg := newWispGC(0, time.Hour, nil)
+	wg := newWispGC(0, time.Hour, false, nil)
 	if wg != nil {
 		t.Error("zero interval should return nil")
 	}
-	wg = newWispGC(time.Hour, 0, nil)
+	wg = newWispGC(time.Hour, 0, false, nil)
 	if wg != nil {
 		t.Error("zero TTL should return nil")
 	}
 }
 
 func TestWispGC_ShouldRunRespectsInterval(t *testing.T) {
-	wg := newWispGC(5*time.Minute, time.Hour, nil)
+	wg := newWispGC(5*time.Minute, time.Hour, false, nil)
 	now := time.Now()
 
 	// First call: should run (never run before).
@@ -56,15 +59,13 @@ func TestWispGC_ShouldRunRespectsInterval(t *testing.T) {
 func TestWispGC_PurgesExpiredMolecules(t *testing.T) {
 	now := time.Now()
 	ttl := time.Hour
-	runner := &fakeGCRunner{
-		listOutput: makeMoleculeList([]fakeMol{
-			{ID: "mol-1", CreatedAt: now.Add(-2 * time.Hour), Status: "closed", Type: "molecule"},
-			{ID: "mol-2", CreatedAt: now.Add(-30 * time.Minute), Status: "closed", Type: "molecule"},
-			{ID: "mol-3", CreatedAt: now.Add(-3 * time.Hour), Status: "closed", Type: "molecule"},
-		}),
-	}
+	store := gcStore(t, []fakeMol{
+		{ID: "mol-1", ClosedAt: now.Add(-2 * time.Hour), Status: "closed", Type: "molecule"},
+		{ID: "mol-2", ClosedAt: now.Add(-30 * time.Minute), Status: "closed", Type: "molecule"},
+		{ID: "mol-3", ClosedAt: now.Add(-3 * time.Hour), Status: "closed", Type: "wisp"},
+	})
 
-	wg := newWispGC(5*time.Minute, ttl, runner.run)
+	wg := newWispGC(5*time.Minute, ttl, false, store.open)
 	purged, err := wg.runGC("/city", now)
 	if err != nil {
 		t.Fatalf("runGC: %v", err)
@@ -74,28 +75,16 @@ func TestWispGC_PurgesExpiredMolecules(t *testing.T) {
 	if purged != 2 {
 		t.Errorf("purged = %d, want 2", purged)
 	}
-	if len(runner.deletedIDs) != 2 {
-		t.Fatalf("deleted = %v, want 2 entries", runner.deletedIDs)
-	}
-	// Verify correct IDs deleted.
-	deleted := map[string]bool{}
-	for _, id := range runner.deletedIDs {
-		deleted[id] = true
-	}
-	if !deleted["mol-1"] || !deleted["mol-3"] {
-		t.Errorf("deleted = %v, want mol-1 and mol-3", runner.deletedIDs)
-	}
+	store.wantLeft(t, "mol-2")
 }
 
 func TestWispGC_NothingExpired(t *testing.T) {
 	now := time.Now()
-	runner := &fakeGCRunner{
-		listOutput: makeMoleculeList([]fakeMol{
-			{ID: "mol-1", CreatedAt: now.Add(-10 * time.Minute), Status: "closed", Type: "molecule"},
-		}),
-	}
+	store := gcStore(t, []fakeMol{
+		{ID: "mol-1", ClosedAt: now.Add(-10 * time.Minute), Status: "closed", Type: "molecule"},
+	})
 
-	wg := newWispGC(5*time.Minute, time.Hour, runner.run)
+	wg := newWispGC(5*time.Minute, time.Hour, false, store.open)
 	purged, err := wg.runGC("/city", now)
 	if err != nil {
 		t.Fatalf("runGC: %v", err)
@@ -103,17 +92,13 @@ func TestWispGC_NothingExpired(t *testing.T) {
 	if purged != 0 {
 		t.Errorf("purged = %d, want 0", purged)
 	}
-	if len(runner.deletedIDs) != 0 {
-		t.Errorf("should not have deleted anything, got %v", runner.deletedIDs)
-	}
+	store.wantLeft(t, "mol-1")
 }
 
 func TestWispGC_EmptyList(t *testing.T) {
-	runner := &fakeGCRunner{
-		listOutput: []byte("[]\n"),
-	}
+	store := gcStore(t, nil)
 
-	wg := newWispGC(5*time.Minute, time.Hour, runner.run)
+	wg := newWispGC(5*time.Minute, time.Hour, false, store.open)
 	purged, err := wg.runGC("/city", time.Now())
 	if err != nil {
 		t.Fatalf("runGC: %v", err)
@@ -125,26 +110,154 @@ func TestWispGC_EmptyList(t *testing.T) {
 
 func TestWispGC_DeleteErrorContinues(t *testing.T) {
 	now := time.Now()
-	runner := &fakeGCRunner{
-		listOutput: makeMoleculeList([]fakeMol{
-			{ID: "mol-1", CreatedAt: now.Add(-2 * time.Hour), Status: "closed", Type: "molecule"},
-			{ID: "mol-2", CreatedAt: now.Add(-2 * time.Hour), Status: "closed", Type: "molecule"},
-		}),
-		deleteErrors: map[string]error{
-			"mol-1": fmt.Errorf("delete failed"),
-		},
+	runner := &fakeGCRunner{deleteErrors: map[string]error{"mol-1": fmt.Errorf("delete failed")}}
+	store := beads.NewBdStore("/city", runner.run)
+
+	n, err := purgeWisps(store, nil, "", []beads.Bead{
+		{ID: "mol-1", ClosedAt: &now, Status: "closed", Type: "molecule"},
+		{ID: "mol-2", ClosedAt: &now, Status: "closed", Type: "molecule"},
+	})
+	// The failed delete is reported, but doesn't stop the rest.
+	if err == nil || !strings.Contains(err.Error(), "mol-1") {
+		t.Errorf("purgeWisps err = %v, want mol-1 failure", err)
+	}
+	// Only mol-2 was successfully purged.
+	if n != 1 || len(runner.deletedIDs) != 1 || runner.deletedIDs[0] != "mol-2" {
+		t.Errorf("purged = %d, deleted = %v; want 1, [mol-2]", n, runner.deletedIDs)
 	}
+}
+
+func TestWispGC_PurgesExpiredTrackingBeads(t *testing.T) {
+	now := time.Now()
+	ttl := time.Hour
+	store := gcStore(t, []fakeMol{
+		// One expired molecule.
+		{ID: "mol-1", ClosedAt: now.Add(-2 * time.Hour), Status: "closed", Type: "molecule"},
+		// Tracking beads: one expired+closed, one recent+closed, one open.
+		{ID: "track-old", ClosedAt: now.Add(-3 * time.Hour), Status: "closed", Type: "task", Tracking: true},
+		{ID: "track-new", ClosedAt: now.Add(-10 * time.Minute), Status: "closed", Type: "task", Tracking: true},
+		{ID: "track-open", CreatedAt: now.Add(-5 * time.Hour), Status: "open", Type: "task", Tracking: true},
+		// Old closed work that is neither a wisp nor tracking.
+		{ID: "task-old", ClosedAt: now.Add(-5 * time.Hour), Status: "closed", Type: "task"},
+	})
 
-	wg := newWispGC(5*time.Minute, time.Hour, runner.run)
+	wg := newWispGC(5*time.Minute, ttl, false, store.open)
 	purged, err := wg.runGC("/city", now)
-	// Should return nil (best-effort) even though one delete failed.
 	if err != nil {
 		t.Fatalf("runGC: %v", err)
 	}
-	// Only mol-2 was successfully purged.
-	if purged != 1 {
-		t.Errorf("purged = %d, want 1 (one delete failed)", purged)
+
+	// mol-1 (expired molecule) + track-old (expired+closed tracking bead) = 2 purged.
+	// track-new is too recent; track-open is not closed.
+	if purged != 2 {
+		t.Errorf("purged = %d, want 2", purged)
 	}
+	store.wantLeft(t, "track-new", "track-open", "task-old")
+}
+
+func TestWispGC_KeepsWispsOfOpenWorkAndTakesSteps(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-2 * time.Hour)
+	store := gcStore(t, []fakeMol{
+		{ID: "work-open", CreatedAt: old, Status: "open", Type: "task"},
+		{ID: "wisp-on-open", ClosedAt: old, Status: "closed", Type: "molecule", Parent: "work-open"},
+		{ID: "work-done", ClosedAt: old, Status: "closed", Type: "task"},
+		{ID: "wisp-on-done", ClosedAt: old, Status: "closed", Type: "molecule", Parent: "work-done"},
+		{ID: "step-1", ClosedAt: old, Status: "closed", Type: "task", Parent: "wisp-on-done"},
+		{ID: "step-2", CreatedAt: old, Status: "open", Type: "task", Parent: "wisp-on-done"},
+	})
+
+	wg := newWispGC(5*time.Minute, time.Hour, false, store.open)
+	purged, err := wg.runGC("/city", now)
+	if err != nil {
+		t.Fatalf("runGC: %v", err)
+	}
+	if purged != 3 {
+		t.Errorf("purged = %d, want 3 (wisp-on-done and its steps)", purged)
+	}
+	store.wantLeft(t, "work-open", "wisp-on-open", "work-done")
+}
+
+func TestWispGC_BdQueriesOnlyClosedWisps(t *testing.T) {
+	// Against bd, GC must not list every bead ever created: only closed
+	// wisp and tracking beads, plus the steps of molecules it purges.
+	old := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+	var lists []string
+	runner := func(_, _ string, args ...string) ([]byte, error) {
+		switch args[0] {
+		case "list":
+			lists = append(lists, strings.Join(args, " "))
+			if slices.Contains(args, "--type=molecule") {
+				return []byte(`[{"id":"mol-1","status":"closed","issue_type":"molecule","closed_at":"` + old + `"}]`), nil
+			}
+			if slices.Contains(args, "--parent=mol-1") {
+				return []byte(`[{"id":"step-1","status":"closed","issue_type":"task","parent_id":"mol-1","closed_at":"` + old + `"}]`), nil
+			}
+			return []byte(`[]`), nil
+		case "delete":
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected command: bd %s", strings.Join(args, " "))
+	}
+	store := beads.NewBdStore("/city", runner)
+	wg := newWispGC(5*time.Minute, time.Hour, false, func(string) (beads.Store, error) { return store, nil })
+	purged, err := wg.runGC("/city", time.Now())
+	if err != nil {
+		t.Fatalf("runGC: %v", err)
+	}
+	if purged != 2 {
+		t.Errorf("purged = %d, want 2 (mol-1 and step-1)", purged)
+	}
+	for _, l := range lists {
+		if !strings.Contains(l, "--status=closed") && !strings.Contains(l, "--parent=") {
+			t.Errorf("unfiltered bd %s", l)
+		}
+	}
+}
+
+func TestWispGC_ArchivesBeforeDelete(t *testing.T) {
+	now := time.Now()
+	store := gcStore(t, []fakeMol{
+		{ID: "mol-1", ClosedAt: now.Add(-2 * time.Hour), Status: "closed", Type: "molecule"},
+	})
+	city := t.TempDir()
+
+	wg := newWispGC(5*time.Minute, time.Hour, true, store.open)
+	if _, err := wg.runGC(city, now); err != nil {
+		t.Fatalf("runGC: %v", err)
+	}
+	store.wantLeft(t)
+	b, err := beads.FindArchived(fsys.OSFS{}, filepath.Join(city, ".gc", "archive"), "mol-1")
+	if err != nil {
+		t.Fatalf("archived mol-1: %v", err)
+	}
+	if b.Type != "molecule" {
+		t.Errorf("archived Type = %q, want molecule", b.Type)
+	}
+}
+
+func TestDoGCWispsDryRun(t *testing.T) {
+	now := time.Now()
+	store := gcStore(t, []fakeMol{
+		{ID: "mol-1", ClosedAt: now.Add(-2 * time.Hour), Status: "closed", Type: "molecule"},
+	})
+	var stdout, stderr bytes.Buffer
+	if code := doGCWisps(store, fsys.OSFS{}, "", now.Add(-time.Hour), true, &stdout, &stderr); code != 0 {
+		t.Fatalf("doGCWisps = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "mol-1") || !strings.Contains(stdout.String(), "Would purge 1 bead(s).") {
+		t.Errorf("stdout = %q, want mol-1 listed", stdout.String())
+	}
+	store.wantLeft(t, "mol-1")
+
+	stdout.Reset()
+	if code := doGCWisps(store, fsys.OSFS{}, "", now.Add(-time.Hour), false, &stdout, &stderr); code != 0 {
+		t.Fatalf("doGCWisps = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Purged 1 bead(s).") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	store.wantLeft(t)
 }
 
 // --- test helpers ---
@@ -152,62 +265,65 @@ func TestWispGC_DeleteErrorContinues(t *testing.T) {
 type fakeMol struct {
 	ID        string
 	CreatedAt time.Time
+	ClosedAt  time.Time
 	Status    string
 	Type      string
+	Parent    string
+	Tracking  bool // carries the automation-tracking label
 }
 
-func makeMoleculeList(mols []fakeMol) []byte {
-	type entry struct {
-		ID        string `json:"id"`
-		CreatedAt string `json:"created_at"`
-		Status    string `json:"status"`
-		Type      string `json:"type"`
-	}
-	var entries []entry
+// gcTestStore is a MemStore seeded with fixed IDs and timestamps.
+type gcTestStore struct {
+	*beads.MemStore
+}
+
+func gcStore(t *testing.T, mols []fakeMol) gcTestStore {
+	t.Helper()
+	var seeded []beads.Bead
 	for _, m := range mols {
-		entries = append(entries, entry{
-			ID:        m.ID,
-			CreatedAt: m.CreatedAt.Format(time.RFC3339),
-			Status:    m.Status,
-			Type:      m.Type,
-		})
+		b := beads.Bead{ID: m.ID, Title: m.ID, Status: m.Status, Type: m.Type, ParentID: m.Parent, CreatedAt: m.CreatedAt}
+		if !m.ClosedAt.IsZero() {
+			closed := m.ClosedAt
+			b.ClosedAt = &closed
+			b.CreatedAt = closed.Add(-time.Minute)
+		}
+		if m.Tracking {
+			b.Labels = []string{"automation-tracking"}
+		}
+		seeded = append(seeded, b)
+	}
+	return gcTestStore{beads.NewMemStoreFrom(len(seeded), seeded, nil)}
+}
+
+func (s gcTestStore) open(string) (beads.Store, error) { return s.MemStore, nil }
+
+// wantLeft checks that exactly ids remain in the store.
+func (s gcTestStore) wantLeft(t *testing.T, ids ...string) {
+	t.Helper()
+	all, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, b := range all {
+		got = append(got, b.ID)
+	}
+	if strings.Join(got, ",") != strings.Join(ids, ",") {
+		t.Errorf("beads left = %v, want %v", got, ids)
 	}
-	data, _ := json.Marshal(entries)
-	return data
 }
 
 type fakeGCRunner struct {
-	listOutput         []byte
-	trackingListOutput []byte
-	deleteErrors       map[string]error
-	deletedIDs         []string
+	deleteErrors map[string]error
+	deletedIDs   []string
 }
 
 func (f *fakeGCRunner) run(_, name string, args ...string) ([]byte, error) {
-	// Detect whether this is a "list" or "delete" call.
 	cmdLine := strings.Join(append([]string{name}, args...), " ")
-	if strings.Contains(cmdLine, "list") {
-		// Route tracking bead queries to trackingListOutput.
-		if strings.Contains(cmdLine, "automation-tracking") {
-			if f.trackingListOutput != nil {
-				return f.trackingListOutput, nil
-			}
-			return []byte("[]"), nil
-		}
-		return f.listOutput, nil
-	}
-	if strings.Contains(cmdLine, "delete") {
-		// Extract ID: last arg before --force, or second-to-last.
-		id := ""
-		for _, a := range args {
-			if a != "--force" && a != "delete" {
-				id = a
-			}
-		}
-		if f.deleteErrors != nil {
-			if err, ok := f.deleteErrors[id]; ok {
-				return nil, err
-			}
+	if len(args) > 1 && args[0] == "delete" {
+		id := args[1]
+		if err, ok := f.deleteErrors[id]; ok {
+			return nil, err
 		}
 		f.deletedIDs = append(f.deletedIDs, id)
 		return nil, nil
@@ -215,51 +331,40 @@ func (f *fakeGCRunner) run(_, name string, args ...string) ([]byte, error) {
 	return nil, fmt.Errorf("unexpected command: %s", cmdLine)
 }
 
-func TestWispGC_PurgesExpiredTrackingBeads(t *testing.T) {
-	now := time.Now()
-	ttl := time.Hour
-	runner := &fakeGCRunner{
-		// One expired molecule.
-		listOutput: makeMoleculeList([]fakeMol{
-			{ID: "mol-1", CreatedAt: now.Add(-2 * time.Hour), Status: "closed", Type: "molecule"},
-		}),
-		// Two tracking beads: one expired+closed, one recent+closed.
-		trackingListOutput: makeMoleculeList([]fakeMol{
-			{ID: "track-old", CreatedAt: now.Add(-3 * time.Hour), Status: "closed", Type: "task"},
-			{ID: "track-new", CreatedAt: now.Add(-10 * time.Minute), Status: "closed", Type: "task"},
-			{ID: "track-open", CreatedAt: now.Add(-5 * time.Hour), Status: "open", Type: "task"},
-		}),
-	}
-
-	wg := newWispGC(5*time.Minute, ttl, runner.run)
-	purged, err := wg.runGC("/city", now)
-	if err != nil {
-		t.Fatalf("runGC: %v", err)
-	}
+// Verify fakeGCRunner satisfies beads.CommandRunner type.
+var _ beads.CommandRunner = (&fakeGCRunner{}).run
 
-	// mol-1 (expired molecule) + track-old (expired+closed tracking bead) = 2 purged.
-	// track-new is too recent; track-open is not closed.
-	if purged != 2 {
-		t.Errorf("purged = %d, want 2", purged)
-	}
+func TestDoGCWispsCityStore(t *testing.T) {
+	for _, provider := range []string{"file", "sqlite"} {
+		t.Run(provider, func(t *testing.T) {
+			city := t.TempDir()
+			t.Setenv("GC_BEADS", provider)
+			store, err := openCityStoreAt(city)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mol, _ := store.Create(beads.Bead{Title: "wisp", Type: "molecule"})
+			step, _ := store.Create(beads.Bead{Title: "step", ParentID: mol.ID})
+			for _, id := range []string{step.ID, mol.ID} {
+				if err := store.Close(id); err != nil {
+					t.Fatal(err)
+				}
+			}
 
-	deleted := map[string]bool{}
-	for _, id := range runner.deletedIDs {
-		deleted[id] = true
-	}
-	if !deleted["mol-1"] {
-		t.Error("expected mol-1 to be deleted")
-	}
-	if !deleted["track-old"] {
-		t.Error("expected track-old to be deleted")
-	}
-	if deleted["track-new"] {
-		t.Error("track-new should not be deleted (too recent)")
-	}
-	if deleted["track-open"] {
-		t.Error("track-open should not be deleted (not closed)")
+			var stdout, stderr bytes.Buffer
+			if code := doGCWisps(store, fsys.OSFS{}, "", time.Now().Add(time.Minute), false, &stdout, &stderr); code != 0 {
+				t.Fatalf("doGCWisps = %d; stderr: %s", code, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), "Purged 2 bead(s).") {
+				t.Errorf("stdout = %q, want 2 purged", stdout.String())
+			}
+			reopened, err := openCityStoreAt(city)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if left, _ := reopened.List(); len(left) != 0 {
+				t.Errorf("beads left = %v, want none", left)
+			}
+		})
 	}
 }
-
-// Verify fakeGCRunner satisfies beads.CommandRunner type.
-var _ beads.CommandRunner = (&fakeGCRunner{}).run
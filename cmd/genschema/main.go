@@ -6,6 +6,7 @@
 // Output:
 //
 //	docs/schema/city-schema.json
+//	docs/schema/formula-schema.json
 //	docs/reference/config.md
 //	docs/reference/cli.md
 package main
@@ -51,6 +52,14 @@ func run() error {
 		return err
 	}
 
+	formulaSchema, err := docgen.GenerateFormulaSchema()
+	if err != nil {
+		return fmt.Errorf("generating formula schema: %w", err)
+	}
+	if err := writeSchema("docs/schema/formula-schema.json", formulaSchema); err != nil {
+		return err
+	}
+
 	// Write markdown reference doc.
 	if err := docgen.WriteMarkdown("docs/reference/config.md", citySchema); err != nil {
 		return fmt.Errorf("writing config.md: %w", err)
@@ -66,6 +75,7 @@ func run() error {
 
 	files := []string{
 		"docs/schema/city-schema.json",
+		"docs/schema/formula-schema.json",
 		"docs/reference/config.md",
 		"docs/reference/cli.md",
 	}
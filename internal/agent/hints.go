@@ -9,6 +9,7 @@ import "github.com/gastownhall/gascity/internal/runtime"
 type StartupHints struct {
 	ReadyPromptPrefix      string
 	ReadyDelayMs           int
+	ReadyProbe             string
 	ProcessNames           []string
 	EmitsPermissionWarning bool
 	// Nudge is text typed into the session after the agent is ready.
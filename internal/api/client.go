@@ -55,6 +55,7 @@ func ShouldFallback(err error) bool {
 // through the API when a controller is running.
 type Client struct {
 	baseURL    string
+	token      string // bearer token; empty for the unauthenticated controller API
 	httpClient *http.Client
 }
 
@@ -69,6 +70,21 @@ func NewClient(baseURL string) *Client {
 	}
 }
 
+// NewClientWithToken creates a client for a server started with
+// [NewWithToken] (gc serve --api).
+func NewClientWithToken(baseURL, token string) *Client {
+	c := NewClient(baseURL)
+	c.token = token
+	return c
+}
+
+// authorize adds the bearer token, if any, to req.
+func (c *Client) authorize(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
 // ListServices fetches the current workspace service statuses.
 func (c *Client) ListServices() ([]workspacesvc.Status, error) {
 	var resp struct {
@@ -164,6 +180,7 @@ func (c *Client) doMutation(method, path string, body any) error {
 		return fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("X-GC-Request", "true")
+	c.authorize(req)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -211,6 +228,7 @@ func (c *Client) doGet(path string, out any) error {
 	if err != nil {
 		return fmt.Errorf("create request: %w", err)
 	}
+	c.authorize(req)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -373,3 +373,28 @@ func TestClientCSRFHeader(t *testing.T) {
 		t.Errorf("X-GC-Request = %q, want %q", gotHeader, "true")
 	}
 }
+
+func TestClientWithTokenSendsBearer(t *testing.T) {
+	var gotAuth []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items":[]}`)) //nolint:errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClientWithToken(ts.URL, "s3cret")
+	if err := c.SuspendCity(); err != nil {
+		t.Fatalf("SuspendCity: %v", err)
+	}
+	if _, err := c.ListServices(); err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	if _, err := NewClient(ts.URL).ListServices(); err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	want := []string{"Bearer s3cret", "Bearer s3cret", ""}
+	if len(gotAuth) != 3 || gotAuth[0] != want[0] || gotAuth[1] != want[1] || gotAuth[2] != want[2] {
+		t.Errorf("Authorization headers = %q, want %q", gotAuth, want)
+	}
+}
@@ -85,6 +85,7 @@ func sessionCreateHints(resolved *config.ResolvedProvider) runtime.Config {
 	return runtime.Config{
 		ReadyPromptPrefix:      resolved.ReadyPromptPrefix,
 		ReadyDelayMs:           resolved.ReadyDelayMs,
+		ReadyProbe:             resolved.ReadyProbe,
 		ProcessNames:           resolved.ProcessNames,
 		EmitsPermissionWarning: resolved.EmitsPermissionWarning,
 	}
@@ -95,6 +96,7 @@ func sessionResumeHints(resolved *config.ResolvedProvider, workDir string) runti
 		WorkDir:                workDir,
 		ReadyPromptPrefix:      resolved.ReadyPromptPrefix,
 		ReadyDelayMs:           resolved.ReadyDelayMs,
+		ReadyProbe:             resolved.ReadyProbe,
 		ProcessNames:           resolved.ProcessNames,
 		EmitsPermissionWarning: resolved.EmitsPermissionWarning,
 		Env:                    resolved.Env,
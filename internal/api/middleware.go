@@ -2,6 +2,7 @@ package api
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"log"
 	"net/http"
@@ -40,7 +41,7 @@ func withCORS(next http.Handler) http.Handler {
 		if isLocalhostOrigin(origin) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Last-Event-ID, X-GC-Request")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Last-Event-ID, X-GC-Request")
 			w.Header().Set("Access-Control-Expose-Headers", "X-GC-Index, X-GC-Request-Id")
 		}
 		if r.Method == http.MethodOptions {
@@ -84,6 +85,20 @@ func withCSRFCheck(next http.Handler) http.Handler {
 	})
 }
 
+// withToken requires "Authorization: Bearer <token>" on every request
+// except /health, so liveness probes keep working without credentials.
+func withToken(token string, next http.Handler) http.Handler {
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid API token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // isLocalhostOrigin checks if an origin is from localhost/127.0.0.1.
 // Rejects origins like http://localhost.evil.com by requiring the host
 // to be exactly localhost, 127.0.0.1, or [::1] with an optional port.
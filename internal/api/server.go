@@ -16,6 +16,9 @@ type Server struct {
 	mux      *http.ServeMux
 	server   *http.Server
 	readOnly bool // when true, POST endpoints return 403
+	// token, when set, must be presented as a bearer token on every
+	// request except /health.
+	token string
 
 	// sessionLogSearchPaths overrides the default search paths for Claude
 	// session JSONL files. Nil means use sessionlog.DefaultSearchPaths().
@@ -85,6 +88,17 @@ func NewReadOnly(state State) *Server {
 	return s
 }
 
+// NewWithToken creates a Server that requires token as a bearer token on
+// every request except /health. Mutations are allowed on any bind address
+// because callers are authenticated; the X-GC-Request CSRF header is not
+// required since browsers cannot attach the Authorization header
+// cross-origin without a preflight.
+func NewWithToken(state State, token string) *Server {
+	s := New(state)
+	s.token = token
+	return s
+}
+
 // ServeHTTP implements http.Handler for testing with httptest.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.handler().ServeHTTP(w, r)
@@ -92,10 +106,13 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handler() http.Handler {
 	apiInner := withCSRFCheck(s.mux)
+	if s.token != "" {
+		apiInner = s.mux
+	}
 	if s.readOnly {
 		apiInner = withReadOnly(apiInner)
 	}
-	root := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	var root http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasPrefix(r.URL.Path, "/svc/") {
 			// Workspace services apply their own publication and CSRF rules in
 			// handleServiceProxy; they do not inherit controller API policy.
@@ -104,6 +121,9 @@ func (s *Server) handler() http.Handler {
 		}
 		apiInner.ServeHTTP(w, r)
 	})
+	if s.token != "" {
+		root = withToken(s.token, root)
+	}
 	return withLogging(withRecovery(withRequestID(withCORS(root))))
 }
 
@@ -146,3 +146,33 @@ func TestPanicRecovery(t *testing.T) {
 		t.Errorf("error code = %q, want %q", apiErr.Code, "internal")
 	}
 }
+
+func TestTokenAuth(t *testing.T) {
+	srv := NewWithToken(newFakeState(t), "s3cret")
+
+	tests := []struct {
+		name, method, path, auth string
+		want                     int
+	}{
+		{"health is open", "GET", "/health", "", http.StatusOK},
+		{"missing token", "GET", "/v0/status", "", http.StatusUnauthorized},
+		{"wrong token", "GET", "/v0/status", "Bearer nope", http.StatusUnauthorized},
+		{"valid token", "GET", "/v0/status", "Bearer s3cret", http.StatusOK},
+		{"services need token", "GET", "/svc/x", "", http.StatusUnauthorized},
+		// Token auth replaces the X-GC-Request CSRF header.
+		{"mutation without csrf header", "POST", "/v0/bead/nope/close", "Bearer s3cret", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d; body = %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -184,3 +184,24 @@ func MaxSeqFromLabels(labelSets [][]string) uint64 {
 	}
 	return maxSeq
 }
+
+// NextCronRun returns the first minute strictly after after that matches
+// the 5-field schedule, searching up to a year ahead. Returns false for a
+// malformed schedule or one that never matches.
+func NextCronRun(schedule string, after time.Time) (time.Time, bool) {
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return time.Time{}, false
+	}
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	for end := t.AddDate(1, 0, 1); t.Before(end); t = t.Add(time.Minute) {
+		if cronFieldMatches(fields[0], t.Minute()) &&
+			cronFieldMatches(fields[1], t.Hour()) &&
+			cronFieldMatches(fields[2], t.Day()) &&
+			cronFieldMatches(fields[3], int(t.Month())) &&
+			cronFieldMatches(fields[4], int(t.Weekday())) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -333,3 +333,28 @@ func TestMaxSeqFromLabelsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestNextCronRun(t *testing.T) {
+	// 2026-02-27 is a Friday.
+	now := time.Date(2026, 2, 27, 12, 0, 30, 0, time.UTC)
+	tests := []struct {
+		schedule string
+		want     time.Time
+	}{
+		{"* * * * *", time.Date(2026, 2, 27, 12, 1, 0, 0, time.UTC)},
+		{"0 9 * * 1", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"0,30 12 * * *", time.Date(2026, 2, 27, 12, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, ok := NextCronRun(tt.schedule, now)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("NextCronRun(%q) = %v, %v; want %v", tt.schedule, got, ok, tt.want)
+		}
+	}
+	if _, ok := NextCronRun("0 9 * *", now); ok {
+		t.Error("NextCronRun accepted a 4-field schedule")
+	}
+	if _, ok := NextCronRun("0 0 31 2 *", now); ok {
+		t.Error("NextCronRun found a Feb 31")
+	}
+}
@@ -0,0 +1,175 @@
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// Remover is implemented by stores that can delete beads outright
+// (MemStore, FileStore, SQLiteStore, BdStore). Archival (see [WriteArchive]) and wisp
+// GC use it to take closed beads out of the live store.
+type Remover interface {
+	// Remove deletes the beads with the given IDs, along with the
+	// dependencies they declare, and returns how many were removed.
+	// Unknown IDs are ignored.
+	Remove(ids []string) (int, error)
+}
+
+// ArchiveTime is the time a bead's archive age is measured from: when it
+// was closed, or when it was created for beads closed before close times
+// were recorded.
+func ArchiveTime(b Bead) time.Time {
+	if b.ClosedAt != nil {
+		return *b.ClosedAt
+	}
+	return b.CreatedAt
+}
+
+// ArchiveFileName returns the archive file a bead belongs in, named for
+// the month of its [ArchiveTime]: beads-YYYYMM.json.
+func ArchiveFileName(b Bead) string {
+	return "beads-" + ArchiveTime(b).UTC().Format("200601") + ".json"
+}
+
+// WriteArchive adds beads to the monthly archive files in dir, creating
+// dir and files as needed. A bead already in its archive file is replaced,
+// so re-archiving after an interrupted run is safe.
+func WriteArchive(fs fsys.FS, dir string, bs []Bead) error {
+	byFile := make(map[string][]Bead)
+	for _, b := range bs {
+		name := ArchiveFileName(b)
+		byFile[name] = append(byFile[name], b)
+	}
+	if len(byFile) == 0 {
+		return nil
+	}
+	if err := fs.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating archive dir: %w", err)
+	}
+	names := make([]string, 0, len(byFile))
+	for name := range byFile {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		existing, err := readArchiveFile(fs, path)
+		if err != nil {
+			return err
+		}
+		merged := mergeArchived(existing, byFile[name])
+		data, err := json.MarshalIndent(merged, "", "  ")
+		if err != nil {
+			return fmt.Errorf("writing archive %s: %w", name, err)
+		}
+		tmp := path + ".tmp"
+		if err := fs.WriteFile(tmp, data, 0o644); err != nil {
+			return fmt.Errorf("writing archive %s: %w", name, err)
+		}
+		if err := fs.Rename(tmp, path); err != nil {
+			return fmt.Errorf("writing archive %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// mergeArchived returns existing with each of added appended, or swapped
+// in place of the archived bead with the same ID.
+func mergeArchived(existing, added []Bead) []Bead {
+	index := make(map[string]int, len(existing))
+	for i, b := range existing {
+		index[b.ID] = i
+	}
+	for _, b := range added {
+		if i, ok := index[b.ID]; ok {
+			existing[i] = b
+			continue
+		}
+		index[b.ID] = len(existing)
+		existing = append(existing, b)
+	}
+	return existing
+}
+
+// FindArchived looks id up in the archive files in dir, newest month
+// first. Returns a wrapped ErrNotFound if no archive holds it.
+func FindArchived(fs fsys.FS, dir, id string) (Bead, error) {
+	entries, err := fs.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Bead{}, fmt.Errorf("reading archive dir: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), "beads-") && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	slices.Sort(names)
+	slices.Reverse(names)
+	for _, name := range names {
+		bs, err := readArchiveFile(fs, filepath.Join(dir, name))
+		if err != nil {
+			return Bead{}, err
+		}
+		for _, b := range bs {
+			if b.ID == id {
+				return b, nil
+			}
+		}
+	}
+	return Bead{}, fmt.Errorf("getting archived bead %q: %w", id, ErrNotFound)
+}
+
+// readArchiveFile reads one archive file. A missing file is empty.
+func readArchiveFile(fs fsys.FS, path string) ([]Bead, error) {
+	data, err := fs.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %w", err)
+	}
+	var bs []Bead
+	if err := json.Unmarshal(data, &bs); err != nil {
+		return nil, fmt.Errorf("parsing archive %s: %w", filepath.Base(path), err)
+	}
+	return bs, nil
+}
+
+// Remove deletes the beads with the given IDs and the dependencies they
+// declare. Unknown IDs are ignored. The sequence counter is kept, so
+// removed IDs are never reissued.
+func (m *MemStore) Remove(ids []string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	drop := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		drop[id] = true
+	}
+	before := len(m.beads)
+	m.beads = slices.DeleteFunc(m.beads, func(b Bead) bool { return drop[b.ID] })
+	m.deps = slices.DeleteFunc(m.deps, func(d Dep) bool { return drop[d.IssueID] })
+	return before - len(m.beads), nil
+}
+
+// Remove delegates to MemStore.Remove and flushes to disk.
+func (fs *FileStore) Remove(ids []string) (int, error) {
+	var n int
+	err := fs.mutate(func() error {
+		var err error
+		n, err = fs.MemStore.Remove(ids)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,89 @@
+package beads
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestWriteArchiveGroupsByMonthAndFinds(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "archive")
+	aug := time.Date(2026, 8, 3, 12, 0, 0, 0, time.UTC)
+	sep := time.Date(2026, 9, 20, 12, 0, 0, 0, time.UTC)
+	bs := []Bead{
+		{ID: "gc-1", Title: "aug", Status: "closed", ClosedAt: &aug},
+		{ID: "gc-2", Title: "sep", Status: "closed", ClosedAt: &sep},
+		{ID: "gc-3", Title: "legacy", Status: "closed", CreatedAt: aug},
+	}
+	fs := fsys.OSFS{}
+	if err := WriteArchive(fs, dir, bs); err != nil {
+		t.Fatal(err)
+	}
+	if got := ArchiveFileName(bs[0]); got != "beads-202608.json" {
+		t.Errorf("ArchiveFileName = %q, want beads-202608.json", got)
+	}
+	for _, b := range bs {
+		got, err := FindArchived(fs, dir, b.ID)
+		if err != nil {
+			t.Fatalf("FindArchived(%s): %v", b.ID, err)
+		}
+		if got.Title != b.Title {
+			t.Errorf("FindArchived(%s).Title = %q, want %q", b.ID, got.Title, b.Title)
+		}
+	}
+
+	// Re-archiving replaces rather than duplicates.
+	bs[0].Title = "aug v2"
+	if err := WriteArchive(fs, dir, bs[:1]); err != nil {
+		t.Fatal(err)
+	}
+	aged, err := readArchiveFile(fs, filepath.Join(dir, "beads-202608.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(aged) != 2 || aged[0].Title != "aug v2" {
+		t.Errorf("beads-202608.json = %+v, want gc-1 replaced and gc-3 kept", aged)
+	}
+
+	if _, err := FindArchived(fs, dir, "gc-9"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindArchived(missing) err = %v, want ErrNotFound", err)
+	}
+	if _, err := FindArchived(fs, filepath.Join(dir, "none"), "gc-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindArchived(no dir) err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFileStoreRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.json")
+	s, err := OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := s.Create(Bead{Title: "a"})
+	b, _ := s.Create(Bead{Title: "b"})
+	if err := s.DepAdd(a.ID, b.ID, "blocks"); err != nil {
+		t.Fatal(err)
+	}
+	n, err := s.Remove([]string{a.ID, "gc-404"})
+	if err != nil || n != 1 {
+		t.Fatalf("Remove = %d, %v; want 1, nil", n, err)
+	}
+
+	reopened, err := OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.Get(a.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(removed) err = %v, want ErrNotFound", err)
+	}
+	if deps, _ := reopened.DepList(a.ID, "down"); len(deps) != 0 {
+		t.Errorf("deps of removed bead = %v, want none", deps)
+	}
+	c, _ := reopened.Create(Bead{Title: "c"})
+	if c.ID == a.ID {
+		t.Errorf("new bead reused removed ID %s", a.ID)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,10 +25,23 @@ type CommandRunner func(dir, name string, args ...string) ([]byte, error)
 // Captures stdout for parsing and stderr for error diagnostics.
 // When the command is "bd", records telemetry (duration, status, output).
 func ExecCommandRunner() CommandRunner {
+	return ExecCommandRunnerWithEnv(nil)
+}
+
+// ExecCommandRunnerWithEnv is ExecCommandRunner with env set in each
+// command's environment, replacing any inherited values of the same keys.
+func ExecCommandRunnerWithEnv(env map[string]string) CommandRunner {
 	return func(dir, name string, args ...string) ([]byte, error) {
 		start := time.Now()
 		cmd := exec.Command(name, args...)
 		cmd.Dir = dir
+		if len(env) > 0 {
+			environ := os.Environ()
+			for k, v := range env {
+				environ = append(envWithout(environ, k), k+"="+v)
+			}
+			cmd.Env = environ
+		}
 		var stderr bytes.Buffer
 		cmd.Stderr = &stderr
 		out, err := cmd.Output()
@@ -301,19 +317,23 @@ func (m *StringMap) UnmarshalJSON(data []byte) error {
 // bdIssue is the JSON shape returned by bd CLI commands. We decode only the
 // fields Gas City cares about; all others are silently ignored.
 type bdIssue struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Status      string    `json:"status"`
-	IssueType   string    `json:"issue_type"`
-	CreatedAt   time.Time `json:"created_at"`
-	Assignee    string    `json:"assignee"`
-	From        string    `json:"from"`
-	ParentID    string    `json:"parent_id"`
-	Ref         string    `json:"ref"`
-	Needs       []string  `json:"needs"`
-	Description string    `json:"description"`
-	Labels      []string  `json:"labels"`
-	Metadata    StringMap `json:"metadata,omitempty"`
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Status      string     `json:"status"`
+	IssueType   string     `json:"issue_type"`
+	Priority    *int       `json:"priority"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	Assignee    string     `json:"assignee"`
+	From        string     `json:"from"`
+	ParentID    string     `json:"parent_id"`
+	Ref         string     `json:"ref"`
+	Needs       []string   `json:"needs"`
+	Description string     `json:"description"`
+	Labels      []string   `json:"labels"`
+	Metadata    StringMap  `json:"metadata,omitempty"`
+	Comments    []Comment  `json:"comments,omitempty"`
 }
 
 // parseIssuesTolerant unmarshals a JSON array of bdIssue objects, skipping
@@ -341,16 +361,29 @@ func parseIssuesTolerant(data []byte) []bdIssue {
 	return result
 }
 
+// bdAttachmentsKey is the metadata key holding a bead's attachments as a
+// JSON array; bd has no attachments of its own.
+const bdAttachmentsKey = "gc.attachments"
+
 // toBead converts a bdIssue to a Gas City Bead. CreatedAt is truncated to
 // second precision because dolt stores timestamps at second granularity —
 // bd create may return sub-second precision that bd show then truncates.
+// Attachments are decoded from their metadata key, which is then hidden.
 func (b *bdIssue) toBead() Bead {
-	return Bead{
+	var attachments []Attachment
+	if raw, ok := b.Metadata[bdAttachmentsKey]; ok {
+		_ = json.Unmarshal([]byte(raw), &attachments)
+		delete(b.Metadata, bdAttachmentsKey)
+	}
+	bead := Bead{
 		ID:          b.ID,
 		Title:       b.Title,
 		Status:      mapBdStatus(b.Status),
 		Type:        b.IssueType,
+		Priority:    b.Priority,
 		CreatedAt:   b.CreatedAt.Truncate(time.Second),
+		DueAt:       b.DueAt,
+		ClosedAt:    b.ClosedAt,
 		Assignee:    b.Assignee,
 		From:        b.From,
 		ParentID:    b.ParentID,
@@ -359,7 +392,30 @@ func (b *bdIssue) toBead() Bead {
 		Description: b.Description,
 		Labels:      b.Labels,
 		Metadata:    b.Metadata,
-	}
+		Comments:    b.Comments,
+		Attachments: attachments,
+	}
+	bead.Version = bdVersion(bead)
+	return bead
+}
+
+// bdVersion stands in for the version counter bd does not keep: a hash of
+// the fields an update can change, so any change to them moves it. It is
+// positive, so callers treat BdStore beads as versioned.
+func bdVersion(b Bead) int {
+	data, _ := json.Marshal(struct {
+		Title, Status, Type, Assignee, ParentID, Description string
+		Priority                                             *int
+		DueAt, ClosedAt                                      *time.Time
+		Labels                                               []string
+		Metadata                                             map[string]string
+	}{
+		b.Title, b.Status, b.Type, b.Assignee, b.ParentID, b.Description,
+		b.Priority, b.DueAt, b.ClosedAt, b.Labels, b.Metadata,
+	})
+	h := fnv.New32a()
+	h.Write(data) //nolint:errcheck // hash writes never fail
+	return int(h.Sum32()>>1) | 1
 }
 
 // isBdNotFound returns true if the error from bd CLI indicates a "not found" condition.
@@ -399,6 +455,12 @@ func (s *BdStore) Create(b Bead) (Bead, error) {
 	if b.ParentID != "" {
 		args = append(args, "--parent", b.ParentID)
 	}
+	if b.Priority != nil {
+		args = append(args, "--priority", strconv.Itoa(*b.Priority))
+	}
+	if b.DueAt != nil {
+		args = append(args, "--due", b.DueAt.UTC().Format(time.RFC3339))
+	}
 	if len(b.Metadata) > 0 {
 		metaJSON, err := json.Marshal(b.Metadata)
 		if err != nil {
@@ -436,7 +498,10 @@ func (s *BdStore) Get(id string) (Bead, error) {
 	return issues[0].toBead(), nil
 }
 
-// Update modifies fields of an existing bead via bd update.
+// Update modifies fields of an existing bead via bd update. bd has no
+// compare-and-set, so opts.ExpectVersion is checked against a fresh bd
+// show just before the update; this narrows the race with other writers
+// to the gap between the two commands rather than closing it.
 func (s *BdStore) Update(id string, opts UpdateOpts) error {
 	args := []string{"update", "--json", id}
 	if opts.Title != nil {
@@ -454,6 +519,16 @@ func (s *BdStore) Update(id string, opts UpdateOpts) error {
 	if opts.Assignee != nil {
 		args = append(args, "--assignee", *opts.Assignee)
 	}
+	if opts.Priority != nil {
+		args = append(args, "--priority", strconv.Itoa(*opts.Priority))
+	}
+	if opts.DueAt != nil {
+		due := "" // empty clears the due date
+		if !opts.DueAt.IsZero() {
+			due = opts.DueAt.UTC().Format(time.RFC3339)
+		}
+		args = append(args, "--due", due)
+	}
 	for _, l := range opts.Labels {
 		args = append(args, "--add-label", l)
 	}
@@ -464,6 +539,15 @@ func (s *BdStore) Update(id string, opts UpdateOpts) error {
 	if len(args) == 3 {
 		return nil
 	}
+	if opts.ExpectVersion != nil {
+		cur, err := s.Get(id)
+		if err != nil {
+			return err
+		}
+		if cur.Version != *opts.ExpectVersion {
+			return fmt.Errorf("updating bead %q: at version %d, expected %d: %w", id, cur.Version, *opts.ExpectVersion, ErrConflict)
+		}
+	}
 	_, err := s.runner(s.dir, "bd", args...)
 	if err != nil {
 		if isBdNotFound(err) {
@@ -522,6 +606,68 @@ func (s *BdStore) Close(id string) error {
 	return nil
 }
 
+// Remove deletes beads one at a time via bd delete --force. A failed
+// delete does not stop the rest; failures are joined into the returned
+// error alongside the count of beads that were removed.
+func (s *BdStore) Remove(ids []string) (int, error) {
+	var errs []error
+	n := 0
+	for _, id := range ids {
+		if _, err := s.runner(s.dir, "bd", "delete", id, "--force"); err != nil {
+			errs = append(errs, fmt.Errorf("deleting bead %q: %w", id, err))
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
+
+// AddComment appends a comment to a bead via bd comments add. The stored
+// comment is parsed from bd's JSON output when available.
+func (s *BdStore) AddComment(id string, c Comment) (Comment, error) {
+	args := []string{"comments", "add", "--json", id, c.Text}
+	if c.Author != "" {
+		args = append(args, "--author", c.Author)
+	}
+	out, err := s.runner(s.dir, "bd", args...)
+	if err != nil {
+		if isBdNotFound(err) {
+			return Comment{}, fmt.Errorf("adding comment to %q: %w", id, ErrNotFound)
+		}
+		return Comment{}, fmt.Errorf("adding comment to %q: %w", id, err)
+	}
+	var stored Comment
+	if json.Unmarshal(extractJSON(out), &stored) == nil && !stored.CreatedAt.IsZero() {
+		return stored, nil
+	}
+	c.CreatedAt = time.Now()
+	return c, nil
+}
+
+// Attach links an artifact to a bead, storing the bead's attachments as
+// JSON in its gc.attachments metadata. A Kind and Ref already attached
+// returns the existing attachment unchanged.
+func (s *BdStore) Attach(id string, a Attachment) (Attachment, error) {
+	b, err := s.Get(id)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("attaching to %q: %w", id, err)
+	}
+	for _, have := range b.Attachments {
+		if have.Kind == a.Kind && have.Ref == a.Ref {
+			return have, nil
+		}
+	}
+	a.CreatedAt = time.Now()
+	data, err := json.Marshal(append(b.Attachments, a))
+	if err != nil {
+		return Attachment{}, fmt.Errorf("attaching to %q: %w", id, err)
+	}
+	if err := s.SetMetadata(id, bdAttachmentsKey, string(data)); err != nil {
+		return Attachment{}, fmt.Errorf("attaching to %q: %w", id, err)
+	}
+	return a, nil
+}
+
 // List returns all beads via bd list.
 func (s *BdStore) List() ([]Bead, error) {
 	out, err := s.runner(s.dir, "bd", "list", "--json", "--limit", "0", "--all")
@@ -569,24 +715,54 @@ func (s *BdStore) ListByAssignee(assignee, status string, limit int) ([]Bead, er
 	return result, nil
 }
 
-// Children returns all beads whose ParentID matches the given ID. The bd CLI
-// does not know about ParentID, so this filters List() results client-side.
-// Returns empty for now since Tutorial 06 uses FileStore.
+// Query returns beads matching the filter via bd list. Selection fields
+// are passed to bd; sorting and limit are applied client-side so the
+// result order matches the in-process stores.
+func (s *BdStore) Query(f Filter) ([]Bead, error) {
+	args := []string{"list", "--json", "--limit", "0"}
+	if f.Status != "" {
+		args = append(args, "--status="+f.Status)
+	} else {
+		args = append(args, "--all")
+	}
+	if f.Assignee != "" {
+		args = append(args, "--assignee="+f.Assignee)
+	}
+	if f.Label != "" {
+		args = append(args, "--label="+f.Label)
+	}
+	if f.Type != "" {
+		args = append(args, "--type="+f.Type)
+	}
+	out, err := s.runner(s.dir, "bd", args...)
+	if err != nil {
+		return nil, fmt.Errorf("bd list: %w", err)
+	}
+	issues := parseIssuesTolerant(extractJSON(out))
+	result := make([]Bead, len(issues))
+	for i := range issues {
+		result[i] = issues[i].toBead()
+	}
+	return ApplyFilter(result, f), nil
+}
+
+// Children returns all beads whose ParentID matches the given ID, open or
+// closed, via bd list --parent.
 func (s *BdStore) Children(parentID string) ([]Bead, error) {
-	all, err := s.List()
+	out, err := s.runner(s.dir, "bd", "list", "--json", "--all", "--parent="+parentID, "--limit", "0")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("bd list: %w", err)
 	}
-	var result []Bead
-	for _, b := range all {
-		if b.ParentID == parentID {
-			result = append(result, b)
-		}
+	issues := parseIssuesTolerant(extractJSON(out))
+	result := make([]Bead, len(issues))
+	for i := range issues {
+		result[i] = issues[i].toBead()
 	}
 	return result, nil
 }
 
-// Ready returns all open beads via bd ready.
+// Ready returns all open beads via bd ready, re-sorted by priority then
+// age (bd's default hybrid order favors recent beads).
 func (s *BdStore) Ready() ([]Bead, error) {
 	out, err := s.runner(s.dir, "bd", "ready", "--json", "--limit", "0")
 	if err != nil {
@@ -597,6 +773,7 @@ func (s *BdStore) Ready() ([]Bead, error) {
 	for i := range issues {
 		result[i] = issues[i].toBead()
 	}
+	SortByPriority(result)
 	return result, nil
 }
 
@@ -3,8 +3,10 @@ package beads_test
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gastownhall/gascity/internal/beads"
 )
@@ -90,6 +92,50 @@ func TestBdStoreCreatePreservesExplicitType(t *testing.T) {
 	}
 }
 
+func TestBdStoreCreatePassesPriority(t *testing.T) {
+	runner := fakeRunner(map[string]struct {
+		out []byte
+		err error
+	}{
+		`bd create --json Prod down -t task --priority 0`: {
+			out: []byte(`{"id":"bd-1","title":"Prod down","status":"open","issue_type":"task","priority":0,"created_at":"2025-01-15T10:30:00Z"}`),
+		},
+	})
+	s := beads.NewBdStore("/city", runner)
+	p0 := 0
+	b, err := s.Create(beads.Bead{Title: "Prod down", Priority: &p0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Priority == nil || *b.Priority != 0 {
+		t.Errorf("Priority = %v, want P0", b.Priority)
+	}
+}
+
+func TestBdStoreReadySortsByPriority(t *testing.T) {
+	runner := fakeRunner(map[string]struct {
+		out []byte
+		err error
+	}{
+		`bd ready --json --limit 0`: {
+			out: []byte(`[{"id":"bd-2","title":"newer","status":"open","priority":2,"created_at":"2025-01-15T11:00:00Z"},
+				{"id":"bd-3","title":"urgent","status":"open","priority":1,"created_at":"2025-01-15T12:00:00Z"},
+				{"id":"bd-1","title":"older","status":"open","priority":2,"created_at":"2025-01-15T10:00:00Z"}]`),
+		},
+	})
+	got, err := beads.NewBdStore("/city", runner).Ready()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, b := range got {
+		titles = append(titles, b.Title)
+	}
+	if want := []string{"urgent", "older", "newer"}; !slices.Equal(titles, want) {
+		t.Errorf("Ready() = %v, want %v", titles, want)
+	}
+}
+
 func TestBdStoreCreateError(t *testing.T) {
 	runner := func(_, _ string, _ ...string) ([]byte, error) {
 		return nil, fmt.Errorf("exit status 1")
@@ -232,6 +278,25 @@ func TestBdStoreClose(t *testing.T) {
 	}
 }
 
+func TestBdStoreRemove(t *testing.T) {
+	runner := fakeRunner(map[string]struct {
+		out []byte
+		err error
+	}{
+		`bd delete bd-1 --force`: {},
+		`bd delete bd-2 --force`: {err: fmt.Errorf("exit status 1")},
+		`bd delete bd-3 --force`: {},
+	})
+	s := beads.NewBdStore("/city", runner)
+	n, err := s.Remove([]string{"bd-1", "bd-2", "bd-3"})
+	if n != 2 {
+		t.Errorf("Remove = %d, want 2", n)
+	}
+	if err == nil || !strings.Contains(err.Error(), "bd-2") {
+		t.Errorf("Remove err = %v, want bd-2 failure", err)
+	}
+}
+
 func TestBdStoreCloseNotFound(t *testing.T) {
 	// Generic CLI error without "not found" should NOT be ErrNotFound.
 	runner := func(_, _ string, _ ...string) ([]byte, error) {
@@ -291,6 +356,52 @@ func TestBdStoreUpdateEmptyOpts(t *testing.T) {
 	}
 }
 
+func TestBdStoreUpdateExpectVersion(t *testing.T) {
+	// Another writer reassigns the bead between our read and our update;
+	// the stale version must fail with ErrConflict and skip bd update.
+	assignee := "alice"
+	updates := 0
+	runner := func(_, _ string, args ...string) ([]byte, error) {
+		switch args[0] {
+		case "show":
+			return []byte(fmt.Sprintf(`[{"id":"bd-1","title":"t","status":"open","issue_type":"task","assignee":%q,"created_at":"2025-01-15T10:30:00Z"}]`, assignee)), nil
+		case "update":
+			updates++
+			return []byte(`{}`), nil
+		}
+		return nil, fmt.Errorf("unexpected command: bd %s", strings.Join(args, " "))
+	}
+	s := beads.NewBdStore("/city", runner)
+	b, err := s.Get("bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Version == 0 {
+		t.Fatal("Version = 0, want BdStore beads to carry a version")
+	}
+	seen := b.Version
+	assignee = "bob"
+	mine := "carol"
+	err = s.Update("bd-1", beads.UpdateOpts{Assignee: &mine, ExpectVersion: &seen})
+	if !errors.Is(err, beads.ErrConflict) {
+		t.Fatalf("Update at stale version = %v, want ErrConflict", err)
+	}
+	if updates != 0 {
+		t.Errorf("bd update ran %d times after a conflict, want 0", updates)
+	}
+
+	cur, err := s.Get("bd-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Update("bd-1", beads.UpdateOpts{Assignee: &mine, ExpectVersion: &cur.Version}); err != nil {
+		t.Fatalf("Update at current version: %v", err)
+	}
+	if updates != 1 {
+		t.Errorf("bd update ran %d times, want 1", updates)
+	}
+}
+
 func TestBdStoreCloseCLIError(t *testing.T) {
 	// CLI error should NOT be wrapped as ErrNotFound.
 	runner := func(_, _ string, _ ...string) ([]byte, error) {
@@ -309,6 +420,92 @@ func TestBdStoreCloseCLIError(t *testing.T) {
 	}
 }
 
+// --- AddComment ---
+
+func TestBdStoreAddComment(t *testing.T) {
+	runner := fakeRunner(map[string]struct {
+		out []byte
+		err error
+	}{
+		`bd comments add --json bd-abc-123 looks good --author alice`: {
+			out: []byte(`{"id":1,"issue_id":"bd-abc-123","author":"alice","text":"looks good","created_at":"2025-01-15T10:30:00Z"}`),
+		},
+	})
+	s := beads.NewBdStore("/city", runner)
+	c, err := s.AddComment("bd-abc-123", beads.Comment{Author: "alice", Text: "looks good"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Author != "alice" || c.Text != "looks good" {
+		t.Errorf("comment = %+v", c)
+	}
+	if want := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC); !c.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v", c.CreatedAt, want)
+	}
+}
+
+func TestBdStoreGetComments(t *testing.T) {
+	runner := fakeRunner(map[string]struct {
+		out []byte
+		err error
+	}{
+		`bd show --json bd-abc-123`: {
+			out: []byte(`[{"id":"bd-abc-123","title":"Build a widget","status":"open","issue_type":"task","created_at":"2025-01-15T10:30:00Z","comments":[{"author":"alice","text":"first","created_at":"2025-01-15T11:00:00Z"}]}]`),
+		},
+	})
+	s := beads.NewBdStore("/city", runner)
+	b, err := s.Get("bd-abc-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Comments) != 1 || b.Comments[0].Text != "first" {
+		t.Errorf("Comments = %+v", b.Comments)
+	}
+}
+
+func TestBdStoreAttach(t *testing.T) {
+	show := `[{"id":"bd-abc-123","title":"Build a widget","status":"open","issue_type":"task","created_at":"2025-01-15T10:30:00Z",` +
+		`"metadata":{"gc.attachments":"[{\"kind\":\"commit\",\"ref\":\"abc123\",\"created_at\":\"2025-01-15T11:00:00Z\"}]"}}]`
+	var set string
+	runner := func(_, name string, args ...string) ([]byte, error) {
+		cmd := name + " " + strings.Join(args, " ")
+		switch {
+		case cmd == "bd show --json bd-abc-123":
+			return []byte(show), nil
+		case strings.HasPrefix(cmd, "bd update --json bd-abc-123 --set-metadata gc.attachments="):
+			set = strings.TrimPrefix(cmd, "bd update --json bd-abc-123 --set-metadata gc.attachments=")
+			return []byte(`{}`), nil
+		}
+		return nil, fmt.Errorf("unexpected command: %s", cmd)
+	}
+	s := beads.NewBdStore("/city", runner)
+
+	b, err := s.Get("bd-abc-123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Attachments) != 1 || b.Attachments[0].Ref != "abc123" {
+		t.Errorf("Attachments = %+v, want the commit", b.Attachments)
+	}
+	if _, ok := b.Metadata["gc.attachments"]; ok {
+		t.Error("gc.attachments metadata not hidden")
+	}
+
+	if _, err := s.Attach("bd-abc-123", beads.Attachment{Kind: beads.AttachCommit, Ref: "abc123"}); err != nil || set != "" {
+		t.Errorf("re-Attach = %v, wrote %q; want no-op", err, set)
+	}
+	a, err := s.Attach("bd-abc-123", beads.Attachment{Kind: beads.AttachPR, Ref: "https://example.com/pr/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.CreatedAt.IsZero() {
+		t.Error("CreatedAt not stamped")
+	}
+	if !strings.HasPrefix(set, `[{"kind":"commit","ref":"abc123"`) || !strings.Contains(set, `{"kind":"pr","ref":"https://example.com/pr/1"`) {
+		t.Errorf("stored attachments = %s", set)
+	}
+}
+
 // --- List ---
 
 func TestBdStoreList(t *testing.T) {
@@ -1001,6 +1198,43 @@ func TestBdStoreListByLabelError(t *testing.T) {
 	}
 }
 
+// --- Query ---
+
+func TestBdStoreQuery(t *testing.T) {
+	runner := fakeRunner(map[string]struct {
+		out []byte
+		err error
+	}{
+		`bd list --json --limit 0 --status=open --assignee=worker --label=urgent --type=bug`: {
+			out: []byte(`[{"id":"bd-b","title":"beta","status":"open","issue_type":"bug","assignee":"worker","created_at":"2026-02-27T10:00:00Z","labels":["urgent"]},` +
+				`{"id":"bd-a","title":"alpha","status":"open","issue_type":"bug","assignee":"worker","created_at":"2026-02-27T11:00:00Z","labels":["urgent"]}]`),
+		},
+	})
+	s := beads.NewBdStore("/city", runner)
+	got, err := s.Query(beads.Filter{Status: "open", Assignee: "worker", Label: "urgent", Type: "bug", Sort: "title", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "bd-a" {
+		t.Errorf("Query = %v, want [bd-a]", got)
+	}
+}
+
+func TestBdStoreQueryNoStatusIncludesClosed(t *testing.T) {
+	var gotArgs []string
+	runner := func(_, _ string, args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`[]`), nil
+	}
+	s := beads.NewBdStore("/city", runner)
+	if _, err := s.Query(beads.Filter{}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(gotArgs, "--all") {
+		t.Errorf("args = %v, want --all when no status filter", gotArgs)
+	}
+}
+
 func TestBdStoreListByLabelZeroLimit(t *testing.T) {
 	var gotArgs []string
 	runner := func(_, _ string, args ...string) ([]byte, error) {
@@ -1157,3 +1391,93 @@ func TestBdStoreDepListEmpty(t *testing.T) {
 		t.Errorf("DepList = %d deps, want 0", len(deps))
 	}
 }
+
+// --- ClaimNext ---
+
+func TestBdStoreClaimNextSecondClaimerLoses(t *testing.T) {
+	// bd ready keeps listing both beads, as a poller that read the queue
+	// before the first claim landed would see it; only bd update --claim
+	// decides who wins.
+	assignees := map[string]string{}
+	runner := func(_, _ string, args ...string) ([]byte, error) {
+		switch args[0] {
+		case "ready":
+			return []byte(`[{"id":"bd-1","title":"one","status":"open","issue_type":"task","labels":["pool:w"],"created_at":"2025-01-15T10:30:00Z"},` +
+				`{"id":"bd-2","title":"two","status":"open","issue_type":"task","labels":["pool:w"],"created_at":"2025-01-15T10:31:00Z"}]`), nil
+		case "update":
+			id, who := args[2], args[len(args)-1]
+			if prev := assignees[id]; prev != "" {
+				return nil, fmt.Errorf("bd update: issue %s already claimed by %s", id, prev)
+			}
+			assignees[id] = who
+			return []byte(`{}`), nil
+		case "show":
+			id := args[2]
+			return []byte(fmt.Sprintf(`[{"id":%q,"status":"in_progress","issue_type":"task","assignee":%q,"created_at":"2025-01-15T10:30:00Z"}]`, id, assignees[id])), nil
+		}
+		return nil, fmt.Errorf("unexpected command: bd %s", strings.Join(args, " "))
+	}
+	s := beads.NewBdStore("/city", runner)
+
+	first, ok, err := s.ClaimNext("pool:w", "w-1")
+	if err != nil || !ok {
+		t.Fatalf("first ClaimNext = %v, %v", ok, err)
+	}
+	if first.ID != "bd-1" || first.Assignee != "w-1" {
+		t.Errorf("first claim = %s by %q, want bd-1 by w-1", first.ID, first.Assignee)
+	}
+	second, ok, err := s.ClaimNext("pool:w", "w-2")
+	if err != nil || !ok {
+		t.Fatalf("second ClaimNext = %v, %v", ok, err)
+	}
+	if second.ID != "bd-2" || second.Assignee != "w-2" {
+		t.Errorf("second claim = %s by %q, want bd-2 by w-2", second.ID, second.Assignee)
+	}
+	if _, ok, err := s.ClaimNext("pool:w", "w-3"); err != nil || ok {
+		t.Errorf("third ClaimNext = %v, %v; want nothing claimed", ok, err)
+	}
+	if assignees["bd-1"] != "w-1" || assignees["bd-2"] != "w-2" {
+		t.Errorf("assignees = %v, want bd-1:w-1 bd-2:w-2", assignees)
+	}
+}
+
+func TestBdStoreClaimNextPassesClaimFlag(t *testing.T) {
+	var gotArgs []string
+	runner := func(_, _ string, args ...string) ([]byte, error) {
+		switch args[0] {
+		case "ready":
+			return []byte(`[{"id":"bd-1","title":"one","status":"open","issue_type":"task","created_at":"2025-01-15T10:30:00Z"}]`), nil
+		case "update":
+			gotArgs = args
+			return []byte(`{}`), nil
+		}
+		return []byte(`[{"id":"bd-1","status":"in_progress","issue_type":"task","assignee":"w-1","created_at":"2025-01-15T10:30:00Z"}]`), nil
+	}
+	s := beads.NewBdStore("/city", runner)
+	if _, _, err := s.ClaimNext("", "w-1"); err != nil {
+		t.Fatal(err)
+	}
+	want := "update --json bd-1 --claim --assignee w-1"
+	if got := strings.Join(gotArgs, " "); got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+func TestBdStoreChildren(t *testing.T) {
+	runner := fakeRunner(map[string]struct {
+		out []byte
+		err error
+	}{
+		`bd list --json --all --parent=bd-mol --limit 0`: {
+			out: []byte(`[{"id":"bd-s1","title":"step","status":"closed","issue_type":"task","parent_id":"bd-mol","created_at":"2025-01-15T10:30:00Z"}]`),
+		},
+	})
+	s := beads.NewBdStore("/city", runner)
+	got, err := s.Children("bd-mol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "bd-s1" || got[0].ParentID != "bd-mol" {
+		t.Errorf("Children = %+v, want bd-s1", got)
+	}
+}
@@ -4,6 +4,11 @@ package beads
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,9 +20,12 @@ var ErrNotFound = errors.New("bead not found")
 type Bead struct {
 	ID          string            `json:"id"`
 	Title       string            `json:"title"`
-	Status      string            `json:"status"` // "open", "in_progress", "closed"
-	Type        string            `json:"type"`   // "task" default
+	Status      string            `json:"status"`             // "open", "in_progress", "closed"
+	Type        string            `json:"type"`               // "task" default
+	Priority    *int              `json:"priority,omitempty"` // 0 (P0, most urgent) to 4; nil = DefaultPriority
 	CreatedAt   time.Time         `json:"created_at"`
+	DueAt       *time.Time        `json:"due_at,omitempty"`    // deadline; nil = none
+	ClosedAt    *time.Time        `json:"closed_at,omitempty"` // when last closed; nil = open or unrecorded
 	Assignee    string            `json:"assignee,omitempty"`
 	From        string            `json:"from,omitempty"`
 	ParentID    string            `json:"parent_id,omitempty"`   // step → molecule
@@ -26,6 +34,177 @@ type Bead struct {
 	Description string            `json:"description,omitempty"` // step instructions
 	Labels      []string          `json:"labels,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	Comments    []Comment         `json:"comments,omitempty"`    // worklog, oldest first
+	Attachments []Attachment      `json:"attachments,omitempty"` // artifact links, oldest first
+	Events      []Transition      `json:"events,omitempty"`      // status/assignee history, oldest first
+	Version     int               `json:"version,omitempty"`     // bumped on every change; 0 = store does not track
+}
+
+// Priority bounds. P0 is the most urgent; beads without an explicit
+// priority sort as DefaultPriority.
+const (
+	MinPriority     = 0
+	MaxPriority     = 4
+	DefaultPriority = 2
+)
+
+// PriorityOrDefault returns the bead's priority, or DefaultPriority when
+// none was set.
+func (b Bead) PriorityOrDefault() int {
+	if b.Priority == nil {
+		return DefaultPriority
+	}
+	return *b.Priority
+}
+
+// Overdue reports whether the bead has a due date before now and is not
+// yet closed.
+func (b Bead) Overdue(now time.Time) bool {
+	return b.DueAt != nil && b.Status != "closed" && b.DueAt.Before(now)
+}
+
+// RequiresLabelPrefix marks a label naming a capability an agent needs
+// to work the bead: "requires:go" means only agents with the "go"
+// capability should take it.
+const RequiresLabelPrefix = "requires:"
+
+// Requires returns the capabilities the bead needs, from its
+// requires:<capability> labels, in label order.
+func (b Bead) Requires() []string {
+	var caps []string
+	for _, l := range b.Labels {
+		if c, ok := strings.CutPrefix(l, RequiresLabelPrefix); ok && c != "" {
+			caps = append(caps, c)
+		}
+	}
+	return caps
+}
+
+// DuplicateOfLabelPrefix marks a bead closed as a duplicate:
+// "duplicate-of:gc-12" means its work continues on gc-12.
+const DuplicateOfLabelPrefix = "duplicate-of:"
+
+// DuplicateOf returns the ID of the bead this one was merged into, or ""
+// if it was not closed as a duplicate.
+func (b Bead) DuplicateOf() string {
+	for _, l := range b.Labels {
+		if id, ok := strings.CutPrefix(l, DuplicateOfLabelPrefix); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// Review metadata: gc bead review records the review state of a bead
+// and the agent reviewing it under these keys.
+const (
+	ReviewStateKey = "review_state"
+	ReviewerKey    = "reviewer"
+)
+
+// Review states, in the order a review normally moves through them.
+const (
+	ReviewInReview         = "in_review"
+	ReviewChangesRequested = "changes_requested"
+	ReviewApproved         = "approved"
+)
+
+// ReviewState returns the bead's review state, or "" if no review was
+// ever requested.
+func (b Bead) ReviewState() string {
+	return b.Metadata[ReviewStateKey]
+}
+
+// dueOrNil returns a pointer to t, or nil for the zero time. Stores use
+// it to apply UpdateOpts.DueAt, where the zero time clears the due date.
+func dueOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// ParsePriority parses a priority written as "P0".."P4" (case-insensitive)
+// or a bare "0".."4".
+func ParsePriority(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "P"))
+	if err != nil || n < MinPriority || n > MaxPriority {
+		return 0, fmt.Errorf("invalid priority %q (want P%d-P%d)", s, MinPriority, MaxPriority)
+	}
+	return n, nil
+}
+
+// FormatPriority renders a priority as "P<n>".
+func FormatPriority(p int) string {
+	return "P" + strconv.Itoa(p)
+}
+
+// Comment is a timestamped note attached to a bead — a durable worklog
+// entry for progress, findings, and handoff context.
+type Comment struct {
+	Author    string    `json:"author,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Attachment kinds.
+const (
+	AttachFile   = "file"
+	AttachURL    = "url"
+	AttachCommit = "commit"
+	AttachPR     = "pr"
+)
+
+// Attachment links a bead to an artifact produced for it — a file, a URL,
+// a git commit, or a pull request — so reviewers can jump from the work
+// item to its output.
+type Attachment struct {
+	Kind      string    `json:"kind"` // AttachFile, AttachURL, AttachCommit, or AttachPR
+	Ref       string    `json:"ref"`  // path, URL, or commit SHA
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Transition fields: the bead fields whose changes stores record as
+// Transition events.
+const (
+	FieldStatus   = "status"
+	FieldAssignee = "assignee"
+)
+
+// Transition records one change to a bead's status or assignee: when it
+// happened, who made it, and the values before and after. Creation is
+// recorded as a transition from "".
+type Transition struct {
+	At    time.Time `json:"at"`
+	Actor string    `json:"actor,omitempty"`
+	Field string    `json:"field"` // FieldStatus or FieldAssignee
+	From  string    `json:"from,omitempty"`
+	To    string    `json:"to,omitempty"`
+}
+
+// Actor returns who is changing beads from this process: $GC_AGENT inside
+// agent sessions, "human" otherwise. Stores stamp it on each Transition.
+func Actor() string {
+	if a := os.Getenv("GC_AGENT"); a != "" {
+		return a
+	}
+	return "human"
+}
+
+// transitions returns the Transition events for setting b's status and
+// assignee to the given values (nil = unchanged). Values equal to the
+// current ones record nothing.
+func transitions(b Bead, status, assignee *string, at time.Time) []Transition {
+	var out []Transition
+	actor := Actor()
+	if status != nil && *status != b.Status {
+		out = append(out, Transition{At: at, Actor: actor, Field: FieldStatus, From: b.Status, To: *status})
+	}
+	if assignee != nil && *assignee != b.Assignee {
+		out = append(out, Transition{At: at, Actor: actor, Field: FieldAssignee, From: b.Assignee, To: *assignee})
+	}
+	return out
 }
 
 // UpdateOpts specifies which fields to change. Nil pointers are skipped.
@@ -34,9 +213,17 @@ type UpdateOpts struct {
 	Status       *string // set status (nil = no change)
 	Description  *string
 	ParentID     *string
-	Assignee     *string  // set assignee (nil = no change)
-	Labels       []string // append these labels (nil = no change)
-	RemoveLabels []string // remove these labels (nil = no change)
+	Assignee     *string    // set assignee (nil = no change)
+	Priority     *int       // set priority (nil = no change)
+	DueAt        *time.Time // set due date (nil = no change, zero time = clear)
+	Labels       []string   // append these labels (nil = no change)
+	RemoveLabels []string   // remove these labels (nil = no change)
+
+	// ExpectVersion makes the update conditional: it fails with
+	// ErrConflict unless the bead is still at this Version (nil = no
+	// check). Stores that do not track versions ignore it; BdStore checks
+	// it with a read just before the update rather than atomically.
+	ExpectVersion *int
 }
 
 // containerTypes enumerates bead types that group child beads for
@@ -59,6 +246,16 @@ var moleculeTypes = map[string]bool{
 	"wisp":     true,
 }
 
+// MoleculeTypes returns the bead types IsMoleculeType accepts, sorted.
+func MoleculeTypes() []string {
+	types := make([]string, 0, len(moleculeTypes))
+	for t := range moleculeTypes {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
 // IsMoleculeType reports whether the bead type represents a molecule
 // or wisp attached to a parent bead.
 func IsMoleculeType(t string) bool {
@@ -74,10 +271,27 @@ type Dep struct {
 	Type        string `json:"type"` // "blocks", "tracks", "relates-to", etc.
 }
 
+// blockingDepTypes enumerates dependency types that keep the dependent
+// bead out of the ready queue until the dependency is closed.
+var blockingDepTypes = map[string]bool{
+	"blocks": true,
+}
+
+// IsBlockingDepType reports whether a dependency of the given type
+// blocks the dependent bead from being ready. An empty type is treated
+// as "blocks".
+func IsBlockingDepType(t string) bool {
+	return t == "" || blockingDepTypes[t]
+}
+
 // Store is the interface for bead persistence. Implementations must assign
 // unique non-empty IDs, default Status to "open", default Type to "task",
 // and set CreatedAt on Create. The ID format is implementation-specific
-// (e.g. "gc-1" for FileStore, "bd-XXXX" for BdStore).
+// (e.g. "gc-1" for FileStore, "bd-XXXX" for BdStore). MemStore, FileStore,
+// and SQLiteStore record status and assignee changes in Bead.Events; other
+// stores return whatever history their backend provides. The same three
+// stores track Version and enforce UpdateOpts.ExpectVersion; BdStore
+// derives Version from the bead's fields and checks it best-effort.
 type Store interface {
 	// Create persists a new bead. The caller provides Title and optionally
 	// Type; the store fills in ID, Status, and CreatedAt. Returns the
@@ -101,8 +315,9 @@ type Store interface {
 	// order when beads share the same second-precision timestamp.
 	List() ([]Bead, error)
 
-	// Ready returns all beads with status "open". Same ordering note
-	// as List.
+	// Ready returns all beads with status "open" that have no unclosed
+	// blocking dependency (see DepAdd), most urgent priority first and
+	// oldest first within a priority (see ComparePriority).
 	Ready() ([]Bead, error)
 
 	// Children returns all beads whose ParentID matches the given ID,
@@ -118,6 +333,10 @@ type Store interface {
 	// specified status. Limit controls max results (0 = unlimited).
 	ListByAssignee(assignee, status string, limit int) ([]Bead, error)
 
+	// Query returns beads matching the filter, sorted and truncated as
+	// the filter specifies. Callers should Validate the filter first.
+	Query(f Filter) ([]Bead, error)
+
 	// SetMetadata sets a key-value metadata pair on a bead. Returns
 	// ErrNotFound if the bead does not exist.
 	SetMetadata(id, key, value string) error
@@ -142,6 +361,18 @@ type Store interface {
 	// to an existing bead, and returns the wisp root bead ID.
 	MolCookOn(formula, beadID, title string, vars []string) (string, error)
 
+	// AddComment appends a comment to a bead's worklog. The caller
+	// provides Text and optionally Author; the store fills in CreatedAt.
+	// Returns the stored comment, or ErrNotFound if the bead does not
+	// exist.
+	AddComment(id string, c Comment) (Comment, error)
+
+	// Attach links an artifact to a bead. The caller provides Kind, Ref,
+	// and optionally Author; the store fills in CreatedAt. Attaching a
+	// Kind and Ref the bead already has changes nothing and returns the
+	// existing attachment. Returns ErrNotFound if the bead does not exist.
+	Attach(id string, a Attachment) (Attachment, error)
+
 	// DepAdd records a dependency: issueID depends on (is blocked by)
 	// dependsOnID. The depType describes the relationship ("blocks",
 	// "tracks", "relates-to", etc.).
@@ -40,3 +40,23 @@ func TestIsMoleculeType(t *testing.T) {
 		}
 	}
 }
+
+func TestBeadRequires(t *testing.T) {
+	b := Bead{Labels: []string{"pool:polecat", "requires:go", "requires:", "requires:frontend"}}
+	got := b.Requires()
+	if len(got) != 2 || got[0] != "go" || got[1] != "frontend" {
+		t.Errorf("Requires() = %v, want [go frontend]", got)
+	}
+	if got := (Bead{}).Requires(); got != nil {
+		t.Errorf("Requires() on unlabeled bead = %v, want nil", got)
+	}
+}
+
+func TestBeadDuplicateOf(t *testing.T) {
+	if got := (Bead{Labels: []string{"duplicate-of:", "bug", "duplicate-of:gc-12"}}).DuplicateOf(); got != "gc-12" {
+		t.Errorf("DuplicateOf() = %q, want gc-12", got)
+	}
+	if got := (Bead{Labels: []string{"bug"}}).DuplicateOf(); got != "" {
+		t.Errorf("DuplicateOf() on unmerged bead = %q, want empty", got)
+	}
+}
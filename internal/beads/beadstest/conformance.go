@@ -5,7 +5,9 @@ package beadstest
 
 import (
 	"errors"
+	"slices"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -533,6 +535,74 @@ func RunStoreTests(t *testing.T, newStore func() beads.Store) {
 			t.Errorf("ListByLabel on empty store returned %d beads, want 0", len(got))
 		}
 	})
+
+	t.Run("QueryFilters", func(t *testing.T) {
+		s := newStore()
+		a, err := s.Create(beads.Bead{Title: "a", Type: "bug", Labels: []string{"urgent"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Create(beads.Bead{Title: "b", Labels: []string{"urgent"}}); err != nil {
+			t.Fatal(err)
+		}
+		c, err := s.Create(beads.Bead{Title: "c"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		worker := "worker"
+		if err := s.Update(a.ID, beads.UpdateOpts{Assignee: &worker}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Close(c.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name   string
+			filter beads.Filter
+			want   []string
+		}{
+			{"all", beads.Filter{}, []string{"a", "b", "c"}},
+			{"status", beads.Filter{Status: "closed"}, []string{"c"}},
+			{"assignee", beads.Filter{Assignee: "worker"}, []string{"a"}},
+			{"label", beads.Filter{Label: "urgent"}, []string{"a", "b"}},
+			{"type", beads.Filter{Type: "bug"}, []string{"a"}},
+			{"combined", beads.Filter{Status: "open", Label: "urgent", Assignee: "worker"}, []string{"a"}},
+		}
+		for _, tt := range tests {
+			got, err := s.Query(tt.filter)
+			if err != nil {
+				t.Fatalf("Query(%s): %v", tt.name, err)
+			}
+			titles := titlesOf(got)
+			if len(titles) != len(tt.want) || !containsAll(titles, tt.want...) {
+				t.Errorf("Query(%s) = %v, want %v", tt.name, titles, tt.want)
+			}
+		}
+	})
+
+	t.Run("QuerySortAndLimit", func(t *testing.T) {
+		s := newStore()
+		for _, title := range []string{"bravo", "charlie", "alpha"} {
+			if _, err := s.Create(beads.Bead{Title: title}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := s.Query(beads.Filter{Sort: "title"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 3 || got[0].Title != "alpha" || got[2].Title != "charlie" {
+			t.Errorf("Query(sort=title) = %v, want [alpha bravo charlie]", got)
+		}
+		got, err = s.Query(beads.Filter{Sort: "-title", Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || got[0].Title != "charlie" || got[1].Title != "bravo" {
+			t.Errorf("Query(sort=-title, limit=2) = %v, want [charlie bravo]", got)
+		}
+	})
 }
 
 // RunMetadataTests runs conformance tests for metadata absent-vs-empty
@@ -572,6 +642,248 @@ func RunMetadataTests(t *testing.T, newStore func() beads.Store) {
 	})
 }
 
+// RunCommentTests runs conformance tests for AddComment and the Comments
+// field. Comments must come back from Get in the order they were added.
+func RunCommentTests(t *testing.T, newStore func() beads.Store) {
+	t.Helper()
+
+	t.Run("AddCommentAppearsOnGet", func(t *testing.T) {
+		s := newStore()
+		b, err := s.Create(beads.Bead{Title: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, err := s.AddComment(b.ID, beads.Comment{Author: "mayor", Text: "started"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first.CreatedAt.IsZero() {
+			t.Error("AddComment CreatedAt is zero")
+		}
+		if _, err := s.AddComment(b.ID, beads.Comment{Text: "halfway"}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Comments) != 2 {
+			t.Fatalf("len(Comments) = %d, want 2", len(got.Comments))
+		}
+		if got.Comments[0].Author != "mayor" || got.Comments[0].Text != "started" {
+			t.Errorf("Comments[0] = %+v, want mayor/started", got.Comments[0])
+		}
+		if got.Comments[1].Text != "halfway" {
+			t.Errorf("Comments[1].Text = %q, want %q", got.Comments[1].Text, "halfway")
+		}
+	})
+
+	t.Run("AddCommentNotFound", func(t *testing.T) {
+		s := newStore()
+		_, err := s.AddComment("nonexistent-999", beads.Comment{Text: "x"})
+		if !errors.Is(err, beads.ErrNotFound) {
+			t.Errorf("AddComment(nonexistent) error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+// RunAttachmentTests runs conformance tests for Attach and the
+// Attachments field. Attachments come back from Get in the order they
+// were added, and re-attaching the same Kind and Ref is a no-op.
+func RunAttachmentTests(t *testing.T, newStore func() beads.Store) {
+	t.Helper()
+
+	t.Run("AttachAppearsOnGet", func(t *testing.T) {
+		s := newStore()
+		b, err := s.Create(beads.Bead{Title: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pr, err := s.Attach(b.ID, beads.Attachment{Kind: beads.AttachPR, Ref: "https://example.com/pr/7", Author: "polecat"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.CreatedAt.IsZero() {
+			t.Error("Attach CreatedAt is zero")
+		}
+		if _, err := s.Attach(b.ID, beads.Attachment{Kind: beads.AttachCommit, Ref: "abc123"}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Attachments) != 2 {
+			t.Fatalf("len(Attachments) = %d, want 2", len(got.Attachments))
+		}
+		if a := got.Attachments[0]; a.Kind != beads.AttachPR || a.Ref != "https://example.com/pr/7" || a.Author != "polecat" {
+			t.Errorf("Attachments[0] = %+v, want the PR", a)
+		}
+		if a := got.Attachments[1]; a.Kind != beads.AttachCommit || a.Ref != "abc123" {
+			t.Errorf("Attachments[1] = %+v, want the commit", a)
+		}
+	})
+
+	t.Run("AttachDuplicateIsNoop", func(t *testing.T) {
+		s := newStore()
+		b, err := s.Create(beads.Bead{Title: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, err := s.Attach(b.ID, beads.Attachment{Kind: beads.AttachFile, Ref: "out/report.md"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		before, _ := s.Get(b.ID)
+		again, err := s.Attach(b.ID, beads.Attachment{Kind: beads.AttachFile, Ref: "out/report.md", Author: "other"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !again.CreatedAt.Equal(first.CreatedAt) || again.Author != "" {
+			t.Errorf("re-Attach = %+v, want the original %+v", again, first)
+		}
+		got, _ := s.Get(b.ID)
+		if len(got.Attachments) != 1 {
+			t.Errorf("len(Attachments) = %d after duplicate, want 1", len(got.Attachments))
+		}
+		if got.Version != before.Version {
+			t.Errorf("Version = %d after duplicate, want unchanged %d", got.Version, before.Version)
+		}
+	})
+
+	t.Run("AttachNotFound", func(t *testing.T) {
+		s := newStore()
+		_, err := s.Attach("nonexistent-999", beads.Attachment{Kind: beads.AttachURL, Ref: "https://example.com"})
+		if !errors.Is(err, beads.ErrNotFound) {
+			t.Errorf("Attach(nonexistent) error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+// RunPriorityTests runs conformance tests for bead priority: Create and
+// Update round-trip it, and Ready orders by priority then age.
+func RunPriorityTests(t *testing.T, newStore func() beads.Store) {
+	t.Helper()
+
+	t.Run("PriorityRoundTrip", func(t *testing.T) {
+		s := newStore()
+		p0 := 0
+		b, err := s.Create(beads.Bead{Title: "urgent", Priority: &p0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Priority == nil || *got.Priority != 0 {
+			t.Errorf("Priority = %v, want P0", got.Priority)
+		}
+		p3 := 3
+		if err := s.Update(b.ID, beads.UpdateOpts{Priority: &p3}); err != nil {
+			t.Fatal(err)
+		}
+		got, err = s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.PriorityOrDefault() != 3 {
+			t.Errorf("Priority after update = %d, want 3", got.PriorityOrDefault())
+		}
+	})
+
+	t.Run("ReadyPriorityOrder", func(t *testing.T) {
+		s := newStore()
+		p0, p1, p4 := 0, 1, 4
+		for _, b := range []beads.Bead{
+			{Title: "low", Priority: &p4},
+			{Title: "default-old"},
+			{Title: "high-old", Priority: &p1},
+			{Title: "critical", Priority: &p0},
+			{Title: "high-new", Priority: &p1},
+			{Title: "default-new"},
+		} {
+			if _, err := s.Create(b); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := s.Ready()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var titles []string
+		for _, b := range got {
+			titles = append(titles, b.Title)
+		}
+		want := []string{"critical", "high-old", "high-new", "default-old", "default-new", "low"}
+		if !slices.Equal(titles, want) {
+			t.Errorf("Ready() = %v, want %v", titles, want)
+		}
+	})
+}
+
+// RunDueTests runs conformance tests for bead due dates: Create and
+// Update round-trip DueAt (a zero time clears it), and Query with
+// DueBefore selects unclosed overdue beads.
+func RunDueTests(t *testing.T, newStore func() beads.Store) {
+	t.Helper()
+
+	t.Run("DueAtRoundTrip", func(t *testing.T) {
+		s := newStore()
+		due := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+		b, err := s.Create(beads.Bead{Title: "deadline", DueAt: &due})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.DueAt == nil || !got.DueAt.Equal(due) {
+			t.Fatalf("DueAt = %v, want %v", got.DueAt, due)
+		}
+		if err := s.Update(b.ID, beads.UpdateOpts{DueAt: &time.Time{}}); err != nil {
+			t.Fatal(err)
+		}
+		got, err = s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.DueAt != nil {
+			t.Errorf("DueAt after clear = %v, want nil", got.DueAt)
+		}
+	})
+
+	t.Run("QueryDueBefore", func(t *testing.T) {
+		s := newStore()
+		now := time.Now()
+		past, future := now.Add(-time.Hour), now.Add(time.Hour)
+		late, err := s.Create(beads.Bead{Title: "late", DueAt: &past})
+		if err != nil {
+			t.Fatal(err)
+		}
+		done, err := s.Create(beads.Bead{Title: "done-late", DueAt: &past})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Close(done.ID); err != nil {
+			t.Fatal(err)
+		}
+		for _, b := range []beads.Bead{{Title: "on-time", DueAt: &future}, {Title: "undated"}} {
+			if _, err := s.Create(b); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := s.Query(beads.Filter{DueBefore: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].ID != late.ID {
+			t.Errorf("Query(DueBefore=now) = %v, want [late]", got)
+		}
+	})
+}
+
 // RunSequentialIDTests runs tests that assert gc-N sequential IDs. Call this
 // only for Store implementations that use sequential IDs (MemStore, FileStore).
 func RunSequentialIDTests(t *testing.T, newStore func() beads.Store) {
@@ -739,6 +1051,47 @@ func RunDepTests(t *testing.T, newStore func() beads.Store) {
 		}
 	})
 
+	t.Run("ReadyExcludesBlocked", func(t *testing.T) {
+		s := newStore()
+		blocker, err := s.Create(beads.Bead{Title: "blocker"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocked, err := s.Create(beads.Bead{Title: "blocked"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tracked, err := s.Create(beads.Bead{Title: "tracked"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.DepAdd(blocked.ID, blocker.ID, "blocks"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.DepAdd(tracked.ID, blocker.ID, "tracks"); err != nil {
+			t.Fatal(err)
+		}
+
+		ready, err := s.Ready()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if titles := titlesOf(ready); len(titles) != 2 || !containsAll(titles, "blocker", "tracked") {
+			t.Errorf("Ready() with open blocker = %v, want [blocker tracked]", titles)
+		}
+
+		if err := s.Close(blocker.ID); err != nil {
+			t.Fatal(err)
+		}
+		ready, err = s.Ready()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if titles := titlesOf(ready); len(titles) != 2 || !containsAll(titles, "blocked", "tracked") {
+			t.Errorf("Ready() after closing blocker = %v, want [blocked tracked]", titles)
+		}
+	})
+
 	t.Run("DepListEmpty", func(t *testing.T) {
 		s := newStore()
 		deps, err := s.DepList("nonexistent", "down")
@@ -831,3 +1184,266 @@ func containsAll(sorted []string, want ...string) bool {
 	}
 	return true
 }
+
+// RunClosedAtTests runs conformance tests for close timestamps: Close
+// stamps ClosedAt, closing again keeps the first stamp, and reopening via
+// Update clears it.
+func RunClosedAtTests(t *testing.T, newStore func() beads.Store) {
+	t.Helper()
+
+	t.Run("ClosedAtLifecycle", func(t *testing.T) {
+		s := newStore()
+		b, err := s.Create(beads.Bead{Title: "finish me"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.ClosedAt != nil {
+			t.Fatalf("new bead ClosedAt = %v, want nil", b.ClosedAt)
+		}
+		before := time.Now().Add(-time.Second)
+		if err := s.Close(b.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ClosedAt == nil || got.ClosedAt.Before(before) {
+			t.Fatalf("ClosedAt after Close = %v, want >= %v", got.ClosedAt, before)
+		}
+		first := *got.ClosedAt
+		if err := s.Close(b.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err = s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ClosedAt == nil || !got.ClosedAt.Equal(first) {
+			t.Errorf("ClosedAt after second Close = %v, want %v", got.ClosedAt, first)
+		}
+		open := "open"
+		if err := s.Update(b.ID, beads.UpdateOpts{Status: &open}); err != nil {
+			t.Fatal(err)
+		}
+		got, err = s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ClosedAt != nil {
+			t.Errorf("ClosedAt after reopen = %v, want nil", got.ClosedAt)
+		}
+	})
+}
+
+// RunTransitionTests runs conformance tests for the Events history:
+// Create, Update, and Close record status and assignee changes with the
+// acting agent, oldest first, and no-op changes record nothing.
+func RunTransitionTests(t *testing.T, newStore func() beads.Store) {
+	t.Helper()
+
+	t.Run("TransitionsRecorded", func(t *testing.T) {
+		t.Setenv("GC_AGENT", "mayor")
+		s := newStore()
+		b, err := s.Create(beads.Bead{Title: "track me"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		worker, inProgress := "worker", "in_progress"
+		if err := s.Update(b.ID, beads.UpdateOpts{Assignee: &worker, Status: &inProgress}); err != nil {
+			t.Fatal(err)
+		}
+		title := "renamed"
+		if err := s.Update(b.ID, beads.UpdateOpts{Title: &title, Assignee: &worker}); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("GC_AGENT", "")
+		if err := s.Close(b.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Close(b.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []beads.Transition{
+			{Actor: "mayor", Field: beads.FieldStatus, To: "open"},
+			{Actor: "mayor", Field: beads.FieldStatus, From: "open", To: "in_progress"},
+			{Actor: "mayor", Field: beads.FieldAssignee, To: "worker"},
+			{Actor: "human", Field: beads.FieldStatus, From: "in_progress", To: "closed"},
+		}
+		if len(got.Events) != len(want) {
+			t.Fatalf("Events = %+v, want %d transitions", got.Events, len(want))
+		}
+		for i, w := range want {
+			e := got.Events[i]
+			if e.Actor != w.Actor || e.Field != w.Field || e.From != w.From || e.To != w.To {
+				t.Errorf("Events[%d] = %+v, want %+v", i, e, w)
+			}
+			if e.At.IsZero() {
+				t.Errorf("Events[%d].At is zero", i)
+			}
+		}
+		if got.Events[3].At.Before(got.Events[0].At) {
+			t.Errorf("Events not oldest first: %+v", got.Events)
+		}
+	})
+}
+
+// RunVersionTests runs conformance tests for optimistic locking: every
+// change bumps Version, and Update with a stale ExpectVersion fails with
+// ErrConflict without applying anything.
+func RunVersionTests(t *testing.T, newStore func() beads.Store) {
+	t.Helper()
+
+	t.Run("VersionBumpedOnChange", func(t *testing.T) {
+		s := newStore()
+		b, err := s.Create(beads.Bead{Title: "versioned"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Version != 1 {
+			t.Fatalf("Create Version = %d, want 1", b.Version)
+		}
+		title := "renamed"
+		if err := s.Update(b.ID, beads.UpdateOpts{Title: &title}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SetMetadata(b.ID, "k", "v"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Close(b.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Close(b.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err := s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Version != 4 {
+			t.Errorf("Version = %d, want 4 (create, update, metadata, close; re-close is a no-op)", got.Version)
+		}
+	})
+
+	t.Run("ExpectVersion", func(t *testing.T) {
+		s := newStore()
+		b, err := s.Create(beads.Bead{Title: "contended"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		mine, theirs := "mine", "theirs"
+		stale := b.Version
+		if err := s.Update(b.ID, beads.UpdateOpts{Assignee: &theirs, ExpectVersion: &stale}); err != nil {
+			t.Fatalf("Update at current version: %v", err)
+		}
+		err = s.Update(b.ID, beads.UpdateOpts{Assignee: &mine, ExpectVersion: &stale})
+		if !errors.Is(err, beads.ErrConflict) {
+			t.Fatalf("Update at stale version = %v, want ErrConflict", err)
+		}
+		got, err := s.Get(b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Assignee != theirs {
+			t.Errorf("Assignee = %q after conflict, want %q", got.Assignee, theirs)
+		}
+		if got.Version != stale+1 {
+			t.Errorf("Version = %d after conflict, want %d", got.Version, stale+1)
+		}
+	})
+}
+
+// RunClaimTests runs conformance tests for beads.ClaimNext: it pops the
+// most urgent unassigned ready bead in the label's queue, marks it
+// in_progress for the claimer, and hands each bead to exactly one of many
+// concurrent claimers.
+func RunClaimTests(t *testing.T, newStore func() beads.Store) {
+	t.Helper()
+
+	t.Run("ClaimNextOrder", func(t *testing.T) {
+		s := newStore()
+		p0, p1, p2 := 0, 1, 2
+		mustCreate := func(b beads.Bead) beads.Bead {
+			t.Helper()
+			created, err := s.Create(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return created
+		}
+		mustCreate(beads.Bead{Title: "other queue", Priority: &p0})
+		low := mustCreate(beads.Bead{Title: "low", Priority: &p2, Labels: []string{"pool:dog"}})
+		high := mustCreate(beads.Bead{Title: "high", Priority: &p1, Labels: []string{"pool:dog"}})
+		mustCreate(beads.Bead{Title: "taken", Priority: &p0, Labels: []string{"pool:dog"}, Assignee: "cat"})
+		blocked := mustCreate(beads.Bead{Title: "blocked", Priority: &p0, Labels: []string{"pool:dog"}})
+		blocker := mustCreate(beads.Bead{Title: "blocker"})
+		if err := s.DepAdd(blocked.ID, blocker.ID, "blocks"); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, want := range []beads.Bead{high, low} {
+			got, ok, err := beads.ClaimNext(s, "pool:dog", "dog-1")
+			if err != nil || !ok {
+				t.Fatalf("ClaimNext = %v, %v; want %s", ok, err, want.Title)
+			}
+			if got.ID != want.ID {
+				t.Fatalf("ClaimNext claimed %q, want %q", got.Title, want.Title)
+			}
+			if got.Status != "in_progress" || got.Assignee != "dog-1" {
+				t.Errorf("claimed bead status/assignee = %s/%s, want in_progress/dog-1", got.Status, got.Assignee)
+			}
+		}
+		if got, ok, err := beads.ClaimNext(s, "pool:dog", "dog-1"); err != nil || ok {
+			t.Errorf("ClaimNext on drained queue = %q, %v, %v; want nothing", got.Title, ok, err)
+		}
+		stored, err := s.Get(high.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != "in_progress" || stored.Assignee != "dog-1" {
+			t.Errorf("stored bead status/assignee = %s/%s, want in_progress/dog-1", stored.Status, stored.Assignee)
+		}
+	})
+
+	t.Run("ClaimNextConcurrent", func(t *testing.T) {
+		s := newStore()
+		const n = 8
+		for i := 0; i < n; i++ {
+			if _, err := s.Create(beads.Bead{Title: "work", Labels: []string{"pool:dog"}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var wg sync.WaitGroup
+		ids := make(chan string, 2*n)
+		for i := 0; i < 2*n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				b, ok, err := beads.ClaimNext(s, "pool:dog", "dog")
+				if err != nil {
+					t.Errorf("ClaimNext: %v", err)
+					return
+				}
+				if ok {
+					ids <- b.ID
+				}
+			}()
+		}
+		wg.Wait()
+		close(ids)
+		seen := make(map[string]bool)
+		for id := range ids {
+			if seen[id] {
+				t.Errorf("bead %s claimed twice", id)
+			}
+			seen[id] = true
+		}
+		if len(seen) != n {
+			t.Errorf("claimed %d beads, want %d", len(seen), n)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package beads
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Claimer is implemented by stores that can pop the ready queue
+// atomically (MemStore, FileStore, SQLiteStore, BdStore). Pool members polling the
+// same label use it so that two of them never claim the same bead.
+type Claimer interface {
+	// ClaimNext takes the first unassigned ready bead (in Ready order)
+	// carrying label, or any such bead when label is empty, assigns it to
+	// assignee, and sets its status to "in_progress" under one lock or
+	// transaction. Reports false when no bead is ready.
+	ClaimNext(label, assignee string) (Bead, bool, error)
+}
+
+// claimable reports whether a ready bead can be claimed from the queue
+// selected by label.
+func claimable(b Bead, label string) bool {
+	return b.Assignee == "" && (label == "" || slices.Contains(b.Labels, label))
+}
+
+// ClaimNext claims the next ready bead carrying label for assignee (see
+// [Claimer]). Stores that are not Claimers get a best-effort fallback:
+// Ready, then an Update conditioned on the version read, moving on to the
+// next candidate when another claimer wins. Stores that do not track
+// versions (exec) can still double-claim under the fallback.
+func ClaimNext(store Store, label, assignee string) (Bead, bool, error) {
+	if c, ok := store.(Claimer); ok {
+		return c.ClaimNext(label, assignee)
+	}
+	ready, err := store.Ready()
+	if err != nil {
+		return Bead{}, false, err
+	}
+	status := "in_progress"
+	for _, b := range ready {
+		if !claimable(b, label) {
+			continue
+		}
+		opts := UpdateOpts{Status: &status, Assignee: &assignee}
+		if b.Version > 0 {
+			v := b.Version
+			opts.ExpectVersion = &v
+		}
+		err := store.Update(b.ID, opts)
+		if errors.Is(err, ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return Bead{}, false, err
+		}
+		claimed, err := store.Get(b.ID)
+		return claimed, err == nil, err
+	}
+	return Bead{}, false, nil
+}
+
+// ClaimNext claims the most urgent unblocked open bead carrying label for
+// assignee. Reports false when none is ready.
+func (m *MemStore) ClaimNext(label, assignee string) (Bead, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	best := -1
+	for i, b := range m.beads {
+		if b.Status != "open" || !claimable(b, label) || m.blockedLocked(b.ID) {
+			continue
+		}
+		if best < 0 || ComparePriority(b, m.beads[best]) < 0 {
+			best = i
+		}
+	}
+	if best < 0 {
+		return Bead{}, false, nil
+	}
+	b := &m.beads[best]
+	status := "in_progress"
+	b.Version++
+	b.Events = append(b.Events, transitions(*b, &status, &assignee, time.Now())...)
+	setStatus(b, status)
+	b.Assignee = assignee
+	return cloneBead(*b), true, nil
+}
+
+// errNothingReady stops FileStore.ClaimNext's mutate before it flushes an
+// unchanged store, so idle pollers do not rewrite the file.
+var errNothingReady = errors.New("no ready bead")
+
+// ClaimNext delegates to MemStore.ClaimNext under the file lock and
+// flushes to disk when a bead was claimed.
+func (fs *FileStore) ClaimNext(label, assignee string) (Bead, bool, error) {
+	var claimed Bead
+	err := fs.mutate(func() error {
+		b, ok, err := fs.MemStore.ClaimNext(label, assignee)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errNothingReady
+		}
+		claimed = b
+		return nil
+	})
+	if errors.Is(err, errNothingReady) {
+		return Bead{}, false, nil
+	}
+	if err != nil {
+		return Bead{}, false, err
+	}
+	return claimed, true, nil
+}
+
+// ClaimNext claims the first claimable bead from bd ready with bd update
+// --claim, which bd applies atomically and refuses when the bead already
+// has an assignee. A candidate lost to another claimer is skipped in
+// favor of the next one.
+func (s *BdStore) ClaimNext(label, assignee string) (Bead, bool, error) {
+	ready, err := s.Ready()
+	if err != nil {
+		return Bead{}, false, err
+	}
+	for _, b := range ready {
+		if !claimable(b, label) {
+			continue
+		}
+		_, err := s.runner(s.dir, "bd", "update", "--json", b.ID, "--claim", "--assignee", assignee)
+		if isBdAlreadyClaimed(err) || isBdNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return Bead{}, false, fmt.Errorf("claiming bead %q: %w", b.ID, err)
+		}
+		claimed, err := s.Get(b.ID)
+		return claimed, err == nil, err
+	}
+	return Bead{}, false, nil
+}
+
+// isBdAlreadyClaimed reports whether bd update --claim failed because
+// another assignee holds the bead.
+func isBdAlreadyClaimed(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "already claimed")
+}
@@ -0,0 +1,253 @@
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// AgentBranchPrefix starts the name of every per-agent Dolt branch.
+const AgentBranchPrefix = "agent/"
+
+// doltMainBranch is the branch bd reads and writes outside agent branches.
+const doltMainBranch = "main"
+
+// DoltConn holds how to reach the dolt sql-server behind bd.
+type DoltConn struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+}
+
+// DoltBranches manages the per-agent branches of one bd database on a
+// dolt sql-server. Each agent's bead mutations land on its own branch
+// (see [AgentBranch] and [PrepareBranchBeadsDir]); Diff shows what a
+// branch changed and Merge folds it into main.
+type DoltBranches struct {
+	dir      string // directory dolt runs in
+	database string // bd's dolt_database
+	conn     DoltConn
+	runner   CommandRunner
+}
+
+// NewDoltBranches returns a DoltBranches for database, running dolt in
+// dir with runner.
+func NewDoltBranches(dir, database string, conn DoltConn, runner CommandRunner) *DoltBranches {
+	return &DoltBranches{dir: dir, database: database, conn: conn, runner: runner}
+}
+
+// Database returns the bd database the branches belong to.
+func (d *DoltBranches) Database() string {
+	return d.database
+}
+
+var branchUnsafe = regexp.MustCompile(`[^A-Za-z0-9._/-]+`)
+
+// AgentBranch returns the Dolt branch that holds agent's bead mutations,
+// e.g. "agent/hw/polecat-3".
+func AgentBranch(agent string) string {
+	return AgentBranchPrefix + branchUnsafe.ReplaceAllString(agent, "-")
+}
+
+// BranchChange is one bead a branch added, modified, or removed
+// relative to main.
+type BranchChange struct {
+	ID           string `json:"id"`
+	Kind         string `json:"kind"` // "added", "modified", or "removed"
+	Title        string `json:"title"`
+	FromStatus   string `json:"from_status,omitempty"`
+	ToStatus     string `json:"to_status,omitempty"`
+	FromAssignee string `json:"from_assignee,omitempty"`
+	ToAssignee   string `json:"to_assignee,omitempty"`
+}
+
+// Ensure creates branch from main if it does not exist yet.
+func (d *DoltBranches) Ensure(branch string) error {
+	rows, err := d.query(d.database,
+		"SELECT name FROM dolt_branches WHERE name = "+sqlQuote(branch))
+	if err != nil {
+		return fmt.Errorf("dolt branch %s: %w", branch, err)
+	}
+	if len(rows) > 0 {
+		return nil
+	}
+	if _, err := d.query(d.database,
+		"CALL DOLT_BRANCH("+sqlQuote(branch)+", "+sqlQuote(doltMainBranch)+")"); err != nil {
+		return fmt.Errorf("dolt branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// List returns the agent branches of the database, sorted by name.
+func (d *DoltBranches) List() ([]string, error) {
+	rows, err := d.query(d.database,
+		"SELECT name FROM dolt_branches WHERE name LIKE "+sqlQuote(AgentBranchPrefix+"%")+" ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("listing dolt branches: %w", err)
+	}
+	out := make([]string, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, rowString(r, "name"))
+	}
+	return out, nil
+}
+
+// Diff returns the beads branch changed since it last met main.
+func (d *DoltBranches) Diff(branch string) ([]BranchChange, error) {
+	rows, err := d.query(d.database,
+		"SELECT diff_type, from_id, to_id, from_title, to_title, from_status, to_status, from_assignee, to_assignee"+
+			" FROM dolt_diff("+sqlQuote(doltMainBranch+"..."+branch)+", 'issues') ORDER BY COALESCE(to_id, from_id)")
+	if err != nil {
+		return nil, fmt.Errorf("diffing %s: %w", branch, err)
+	}
+	out := make([]BranchChange, 0, len(rows))
+	for _, r := range rows {
+		c := BranchChange{
+			Kind:         rowString(r, "diff_type"),
+			ID:           rowString(r, "to_id"),
+			Title:        rowString(r, "to_title"),
+			FromStatus:   rowString(r, "from_status"),
+			ToStatus:     rowString(r, "to_status"),
+			FromAssignee: rowString(r, "from_assignee"),
+			ToAssignee:   rowString(r, "to_assignee"),
+		}
+		if c.Kind == "removed" {
+			c.ID = rowString(r, "from_id")
+			c.Title = rowString(r, "from_title")
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// Merge commits any uncommitted changes on branch, merges the branch
+// into main, and then brings the branch up to date with main so the
+// agent sees beads changed elsewhere. A merge with conflicts changes
+// nothing and returns an error.
+func (d *DoltBranches) Merge(branch string) error {
+	branchDB := d.database + "/" + branch
+	if _, err := d.query(branchDB,
+		"CALL DOLT_COMMIT('-Am', "+sqlQuote("gc: bead changes on "+branch)+")"); err != nil &&
+		!strings.Contains(err.Error(), "nothing to commit") {
+		return fmt.Errorf("committing %s: %w", branch, err)
+	}
+	rows, err := d.query(d.database,
+		"CALL DOLT_MERGE("+sqlQuote(branch)+", '-m', "+sqlQuote("gc: merge "+branch)+")")
+	if err != nil {
+		return fmt.Errorf("merging %s: %w", branch, err)
+	}
+	if len(rows) > 0 {
+		if c := rowString(rows[0], "conflicts"); c != "" && c != "0" {
+			return fmt.Errorf("merging %s: %s conflicts; resolve them with dolt", branch, c)
+		}
+	}
+	if _, err := d.query(branchDB, "CALL DOLT_MERGE("+sqlQuote(doltMainBranch)+")"); err != nil {
+		return fmt.Errorf("updating %s from main: %w", branch, err)
+	}
+	return nil
+}
+
+// query runs one statement against db and returns its result rows.
+func (d *DoltBranches) query(db, stmt string) ([]map[string]any, error) {
+	args := []string{"--host", d.conn.Host, "--port", d.conn.Port, "--user", d.conn.User,
+		"--password", d.conn.Password, "--no-tls", "--use-db", db, "sql", "-r", "json", "-q", stmt}
+	out, err := d.runner(d.dir, "dolt", args...)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return nil, nil
+	}
+	var res struct {
+		Rows []map[string]any `json:"rows"`
+	}
+	if err := json.Unmarshal(extractJSON([]byte(trimmed)), &res); err != nil {
+		return nil, fmt.Errorf("unexpected dolt output: %s", trimmed)
+	}
+	return res.Rows, nil
+}
+
+// rowString returns column key of a dolt JSON row as a string, or ""
+// when it is NULL or absent.
+func rowString(r map[string]any, key string) string {
+	switch v := r[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sqlQuote returns s as a single-quoted SQL string literal.
+func sqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// ReadDoltDatabase returns the dolt_database bd uses for the .beads
+// directory beadsDir.
+func ReadDoltDatabase(beadsDir string) (string, error) {
+	meta, err := readBeadsMetadata(beadsDir)
+	if err != nil {
+		return "", err
+	}
+	db, _ := meta["dolt_database"].(string)
+	if db == "" {
+		return "", fmt.Errorf("%s: no dolt_database", filepath.Join(beadsDir, "metadata.json"))
+	}
+	return db, nil
+}
+
+// PrepareBranchBeadsDir writes a .beads directory at branchDir that
+// points bd at branch of the database beadsDir uses, by naming the
+// revision database <database>/<branch>. Running bd with BEADS_DIR set
+// to branchDir then reads and writes the branch instead of main.
+func PrepareBranchBeadsDir(beadsDir, branchDir, branch string) error {
+	meta, err := readBeadsMetadata(beadsDir)
+	if err != nil {
+		return err
+	}
+	db, _ := meta["dolt_database"].(string)
+	if db == "" {
+		return fmt.Errorf("%s: no dolt_database", filepath.Join(beadsDir, "metadata.json"))
+	}
+	meta["dolt_database"] = db + "/" + branch
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(branchDir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(branchDir, "metadata.json"), append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	// bd's settings apply on the branch too.
+	cfg, err := os.ReadFile(filepath.Join(beadsDir, "config.yaml"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(branchDir, "config.yaml"), cfg, 0o644)
+}
+
+func readBeadsMetadata(beadsDir string) (map[string]any, error) {
+	data, err := os.ReadFile(filepath.Join(beadsDir, "metadata.json"))
+	if err != nil {
+		return nil, err
+	}
+	var meta map[string]any
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(beadsDir, "metadata.json"), err)
+	}
+	return meta, nil
+}
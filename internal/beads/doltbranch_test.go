@@ -0,0 +1,181 @@
+package beads_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// doltCall is one dolt invocation seen by a scripted runner.
+type doltCall struct {
+	db, stmt string
+}
+
+// scriptedDolt returns a runner that answers dolt sql queries with the
+// first reply whose key the statement contains, and records each call.
+func scriptedDolt(t *testing.T, replies map[string]string, calls *[]doltCall) beads.CommandRunner {
+	t.Helper()
+	return func(_, name string, args ...string) ([]byte, error) {
+		if name != "dolt" {
+			t.Fatalf("ran %s, want dolt", name)
+		}
+		var c doltCall
+		for i, a := range args {
+			switch a {
+			case "--use-db":
+				c.db = args[i+1]
+			case "-q":
+				c.stmt = args[i+1]
+			}
+		}
+		*calls = append(*calls, c)
+		for k, v := range replies {
+			if strings.Contains(c.stmt, k) {
+				if strings.HasPrefix(v, "error:") {
+					return nil, errors.New(strings.TrimPrefix(v, "error:"))
+				}
+				return []byte(v), nil
+			}
+		}
+		return []byte(`{"rows": []}`), nil
+	}
+}
+
+var testConn = beads.DoltConn{Host: "127.0.0.1", Port: "3307", User: "root"}
+
+func TestAgentBranch(t *testing.T) {
+	for agent, want := range map[string]string{
+		"mayor":          "agent/mayor",
+		"hw/polecat-3":   "agent/hw/polecat-3",
+		"odd name;drop'": "agent/odd-name-drop-",
+	} {
+		if got := beads.AgentBranch(agent); got != want {
+			t.Errorf("AgentBranch(%q) = %q, want %q", agent, got, want)
+		}
+	}
+}
+
+func TestDoltBranchesEnsureCreatesMissing(t *testing.T) {
+	var calls []doltCall
+	br := beads.NewDoltBranches("/city", "gc", testConn, scriptedDolt(t, nil, &calls))
+	if err := br.Ensure("agent/mayor"); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || !strings.Contains(calls[1].stmt, "CALL DOLT_BRANCH('agent/mayor', 'main')") {
+		t.Errorf("calls = %+v, want lookup then DOLT_BRANCH", calls)
+	}
+}
+
+func TestDoltBranchesEnsureExisting(t *testing.T) {
+	var calls []doltCall
+	br := beads.NewDoltBranches("/city", "gc", testConn, scriptedDolt(t, map[string]string{
+		"FROM dolt_branches": `{"rows": [{"name": "agent/mayor"}]}`,
+	}, &calls))
+	if err := br.Ensure("agent/mayor"); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 1 {
+		t.Errorf("calls = %+v, want only the lookup", calls)
+	}
+}
+
+func TestDoltBranchesDiff(t *testing.T) {
+	var calls []doltCall
+	br := beads.NewDoltBranches("/city", "gc", testConn, scriptedDolt(t, map[string]string{
+		"dolt_diff": `{"rows": [
+			{"diff_type": "added", "from_id": null, "to_id": "gc-9", "to_title": "new", "to_status": "open"},
+			{"diff_type": "modified", "from_id": "gc-2", "to_id": "gc-2", "to_title": "fix", "from_status": "open", "to_status": "closed", "from_assignee": "mayor", "to_assignee": "mayor"},
+			{"diff_type": "removed", "from_id": "gc-3", "to_id": null, "from_title": "gone"}
+		]}`,
+	}, &calls))
+	changes, err := br.Diff("agent/mayor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(calls[0].stmt, "dolt_diff('main...agent/mayor', 'issues')") {
+		t.Errorf("stmt = %q, want three-dot diff of issues", calls[0].stmt)
+	}
+	want := []beads.BranchChange{
+		{ID: "gc-9", Kind: "added", Title: "new", ToStatus: "open"},
+		{ID: "gc-2", Kind: "modified", Title: "fix", FromStatus: "open", ToStatus: "closed", FromAssignee: "mayor", ToAssignee: "mayor"},
+		{ID: "gc-3", Kind: "removed", Title: "gone"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+}
+
+func TestDoltBranchesMerge(t *testing.T) {
+	var calls []doltCall
+	br := beads.NewDoltBranches("/city", "gc", testConn, scriptedDolt(t, map[string]string{
+		"DOLT_COMMIT":              "error:nothing to commit",
+		"DOLT_MERGE('agent/mayor'": `{"rows": [{"hash": "abc", "fast_forward": 1, "conflicts": 0}]}`,
+	}, &calls))
+	if err := br.Merge("agent/mayor"); err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 3 {
+		t.Fatalf("calls = %+v, want commit, merge, update", calls)
+	}
+	if calls[0].db != "gc/agent/mayor" || calls[1].db != "gc" || calls[2].db != "gc/agent/mayor" {
+		t.Errorf("databases = %q, %q, %q; want branch, main, branch", calls[0].db, calls[1].db, calls[2].db)
+	}
+	if !strings.Contains(calls[2].stmt, "DOLT_MERGE('main')") {
+		t.Errorf("last stmt = %q, want branch updated from main", calls[2].stmt)
+	}
+}
+
+func TestDoltBranchesMergeConflict(t *testing.T) {
+	var calls []doltCall
+	br := beads.NewDoltBranches("/city", "gc", testConn, scriptedDolt(t, map[string]string{
+		"DOLT_MERGE('agent/mayor'": `{"rows": [{"hash": "", "fast_forward": 0, "conflicts": 2}]}`,
+	}, &calls))
+	err := br.Merge("agent/mayor")
+	if err == nil || !strings.Contains(err.Error(), "2 conflicts") {
+		t.Fatalf("err = %v, want conflict error", err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("calls = %+v, want no branch update after conflict", calls)
+	}
+}
+
+func TestPrepareBranchBeadsDir(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	meta := `{"backend": "dolt", "dolt_mode": "server", "dolt_database": "gc"}`
+	if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if db, err := beads.ReadDoltDatabase(beadsDir); err != nil || db != "gc" {
+		t.Fatalf("ReadDoltDatabase = %q, %v; want gc", db, err)
+	}
+
+	branchDir := filepath.Join(dir, "branches", "agent", "mayor")
+	if err := beads.PrepareBranchBeadsDir(beadsDir, branchDir, "agent/mayor"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(branchDir, "metadata.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["dolt_database"] != "gc/agent/mayor" || got["dolt_mode"] != "server" {
+		t.Errorf("branch metadata = %v, want revision database and other fields kept", got)
+	}
+}
@@ -41,12 +41,27 @@ func NewStore(script string) *Store {
 	}
 }
 
+// errUnknownOp is returned by invoke when the script exits 2 (unknown
+// operation).
+var errUnknownOp = errors.New("operation not supported by script")
+
 // run executes the script with the given args, optionally piping stdinData
 // to its stdin. Returns the trimmed stdout on success.
 //
 // Exit code 2 is treated as success (unknown operation — forward compatible).
 // Any other non-zero exit code returns an error wrapping stderr.
 func (s *Store) run(stdinData []byte, args ...string) (string, error) {
+	out, err := s.invoke(stdinData, args...)
+	if errors.Is(err, errUnknownOp) {
+		return "", nil
+	}
+	return out, err
+}
+
+// invoke is run without the exit-2 leniency: an unknown operation is
+// returned as errUnknownOp. Used by operations whose silent no-op would
+// lose data.
+func (s *Store) invoke(stdinData []byte, args ...string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
 	defer cancel()
 
@@ -75,7 +90,7 @@ func (s *Store) run(stdinData []byte, args ...string) (string, error) {
 		var exitErr *exec.ExitError
 		if errors.As(err, &exitErr) {
 			if exitErr.ExitCode() == 2 {
-				return "", nil
+				return "", fmt.Errorf("%s: %w", args[0], errUnknownOp)
 			}
 		}
 		errMsg := strings.TrimSpace(stderr.String())
@@ -128,7 +143,10 @@ func (w *beadWire) toBead() beads.Bead {
 		Title:       w.Title,
 		Status:      w.Status,
 		Type:        w.Type,
+		Priority:    w.Priority,
 		CreatedAt:   w.CreatedAt,
+		DueAt:       w.DueAt,
+		ClosedAt:    w.ClosedAt,
 		Assignee:    w.Assignee,
 		From:        w.From,
 		ParentID:    w.ParentID,
@@ -137,6 +155,10 @@ func (w *beadWire) toBead() beads.Bead {
 		Description: w.Description,
 		Labels:      w.Labels,
 		Metadata:    w.Metadata,
+		Comments:    w.Comments,
+		Attachments: w.Attachments,
+		Events:      w.Events,
+		Version:     w.Version,
 	}
 }
 
@@ -178,7 +200,7 @@ func (s *Store) Get(id string) (beads.Bead, error) {
 
 // Update modifies fields of an existing bead: script update <id> (stdin: JSON)
 func (s *Store) Update(id string, opts beads.UpdateOpts) error {
-	data, err := marshalUpdate(opts.Title, opts.Description, opts.ParentID, opts.Assignee, opts.Labels)
+	data, err := marshalUpdate(opts.Title, opts.Description, opts.ParentID, opts.Assignee, opts.Priority, opts.DueAt, opts.Labels)
 	if err != nil {
 		return fmt.Errorf("exec beads update: marshaling: %w", err)
 	}
@@ -213,13 +235,19 @@ func (s *Store) List() ([]beads.Bead, error) {
 	return parseBeadList(out)
 }
 
-// Ready returns all open beads: script ready
+// Ready returns all open beads: script ready. Results are re-sorted by
+// priority so scripts need not order them.
 func (s *Store) Ready() ([]beads.Bead, error) {
 	out, err := s.run(nil, "ready")
 	if err != nil {
 		return nil, fmt.Errorf("exec beads ready: %w", err)
 	}
-	return parseBeadList(out)
+	result, err := parseBeadList(out)
+	if err != nil {
+		return nil, err
+	}
+	beads.SortByPriority(result)
+	return result, nil
 }
 
 // Children returns all beads whose ParentID matches: script children <parent-id>
@@ -260,6 +288,16 @@ func (s *Store) ListByAssignee(assignee, status string, limit int) ([]beads.Bead
 	return result, nil
 }
 
+// Query returns beads matching the filter. Falls back to filtering List()
+// since the exec protocol does not have a dedicated command for this.
+func (s *Store) Query(f beads.Filter) ([]beads.Bead, error) {
+	all, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	return beads.ApplyFilter(all, f), nil
+}
+
 // SetMetadata sets a key-value metadata pair: script set-metadata <id> <key> (stdin: value)
 func (s *Store) SetMetadata(id, key, value string) error {
 	_, err := s.run([]byte(value), "set-metadata", id, key)
@@ -280,6 +318,48 @@ func (s *Store) SetMetadataBatch(id string, kvs map[string]string) error {
 	return nil
 }
 
+// AddComment appends a comment: script add-comment <id> (stdin: JSON).
+// The script may print the stored comment as JSON; otherwise CreatedAt
+// is stamped locally. A script without add-comment support (exit 2) is
+// reported as an error so comments are never silently dropped.
+func (s *Store) AddComment(id string, c beads.Comment) (beads.Comment, error) {
+	data, err := json.Marshal(commentRequest{Author: c.Author, Text: c.Text})
+	if err != nil {
+		return beads.Comment{}, fmt.Errorf("exec beads add-comment: marshaling: %w", err)
+	}
+	out, err := s.invoke(data, "add-comment", id)
+	if err != nil {
+		return beads.Comment{}, fmt.Errorf("adding comment to %q: %w", id, err)
+	}
+	var stored beads.Comment
+	if out != "" && json.Unmarshal([]byte(out), &stored) == nil && !stored.CreatedAt.IsZero() {
+		return stored, nil
+	}
+	c.CreatedAt = time.Now()
+	return c, nil
+}
+
+// Attach links an artifact: script attach <id> (stdin: JSON). The script
+// may print the stored attachment as JSON; otherwise CreatedAt is stamped
+// locally. A script without attach support (exit 2) is reported as an
+// error so attachments are never silently dropped.
+func (s *Store) Attach(id string, a beads.Attachment) (beads.Attachment, error) {
+	data, err := json.Marshal(attachRequest{Kind: a.Kind, Ref: a.Ref, Author: a.Author})
+	if err != nil {
+		return beads.Attachment{}, fmt.Errorf("exec beads attach: marshaling: %w", err)
+	}
+	out, err := s.invoke(data, "attach", id)
+	if err != nil {
+		return beads.Attachment{}, fmt.Errorf("attaching to %q: %w", id, err)
+	}
+	var stored beads.Attachment
+	if out != "" && json.Unmarshal([]byte(out), &stored) == nil && !stored.CreatedAt.IsZero() {
+		return stored, nil
+	}
+	a.CreatedAt = time.Now()
+	return a, nil
+}
+
 // Ping verifies the store script is accessible by running a list operation.
 func (s *Store) Ping() error {
 	_, err := s.run(nil, "list")
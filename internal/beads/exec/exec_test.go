@@ -354,6 +354,74 @@ esac
 	}
 }
 
+func TestAddComment(t *testing.T) {
+	dir := t.TempDir()
+	outFile := filepath.Join(dir, "comment.json")
+
+	script := writeScript(t, dir, `
+case "$1" in
+  add-comment) cat > "`+outFile+`"; echo '{"author":"mayor","text":"done","created_at":"2026-02-27T10:00:00Z"}' ;;
+  *) exit 2 ;;
+esac
+`)
+	s := NewStore(script)
+
+	got, err := s.AddComment("EX-1", beads.Comment{Author: "mayor", Text: "done"})
+	if err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if got.CreatedAt.Year() != 2026 {
+		t.Errorf("CreatedAt = %v, want script's timestamp", got.CreatedAt)
+	}
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"author":"mayor","text":"done"}` {
+		t.Errorf("stdin = %s", data)
+	}
+}
+
+func TestAttach(t *testing.T) {
+	dir := t.TempDir()
+	outFile := filepath.Join(dir, "attach.json")
+
+	script := writeScript(t, dir, `
+case "$1" in
+  attach) cat > "`+outFile+`" ;;
+  *) exit 2 ;;
+esac
+`)
+	s := NewStore(script)
+
+	got, err := s.Attach("EX-1", beads.Attachment{Kind: beads.AttachCommit, Ref: "abc123"})
+	if err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	if got.CreatedAt.IsZero() {
+		t.Error("CreatedAt not stamped locally")
+	}
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"kind":"commit","ref":"abc123"}` {
+		t.Errorf("stdin = %s", data)
+	}
+}
+
+func TestAddComment_unsupported(t *testing.T) {
+	dir := t.TempDir()
+	script := writeScript(t, dir, `exit 2`)
+	s := NewStore(script)
+
+	// Unlike other operations, exit 2 is an error: a dropped comment
+	// would be silent data loss.
+	if _, err := s.AddComment("EX-1", beads.Comment{Text: "lost"}); err == nil {
+		t.Fatal("AddComment on exit 2 should fail")
+	}
+}
+
 func TestMolCook(t *testing.T) {
 	dir := t.TempDir()
 	script := writeScript(t, dir, allOpsScript())
@@ -14,23 +14,27 @@ import (
 // createRequest is the JSON wire format sent on stdin for create operations.
 // Intentionally separate from [beads.Bead] to own the serialization contract.
 type createRequest struct {
-	Title       string   `json:"title"`
-	Type        string   `json:"type,omitempty"`
-	Labels      []string `json:"labels,omitempty"`
-	ParentID    string   `json:"parent_id,omitempty"`
-	Ref         string   `json:"ref,omitempty"`
-	Needs       []string `json:"needs,omitempty"`
-	Description string   `json:"description,omitempty"`
+	Title       string     `json:"title"`
+	Type        string     `json:"type,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+	ParentID    string     `json:"parent_id,omitempty"`
+	Ref         string     `json:"ref,omitempty"`
+	Needs       []string   `json:"needs,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Priority    *int       `json:"priority,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
 }
 
 // updateRequest is the JSON wire format sent on stdin for update operations.
 // Null/missing fields are not applied. Labels appends (does not replace).
 type updateRequest struct {
-	Title       *string  `json:"title,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	ParentID    *string  `json:"parent_id,omitempty"`
-	Assignee    *string  `json:"assignee,omitempty"`
-	Labels      []string `json:"labels,omitempty"`
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	ParentID    *string    `json:"parent_id,omitempty"`
+	Assignee    *string    `json:"assignee,omitempty"`
+	Priority    *int       `json:"priority,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"` // zero time clears
+	Labels      []string   `json:"labels,omitempty"`
 }
 
 // molCookRequest is the JSON wire format sent on stdin for mol-cook.
@@ -43,19 +47,39 @@ type molCookRequest struct {
 // beadWire is the JSON wire format returned by the script for bead data.
 // Matches [beads.Bead] JSON tags — the same shape that bd already produces.
 type beadWire struct {
-	ID          string            `json:"id"`
-	Title       string            `json:"title"`
-	Status      string            `json:"status"`
-	Type        string            `json:"type"`
-	CreatedAt   time.Time         `json:"created_at"`
-	Assignee    string            `json:"assignee"`
-	From        string            `json:"from"`
-	ParentID    string            `json:"parent_id"`
-	Ref         string            `json:"ref"`
-	Needs       []string          `json:"needs"`
-	Description string            `json:"description"`
-	Labels      []string          `json:"labels"`
-	Metadata    map[string]string `json:"metadata,omitempty"`
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	Status      string             `json:"status"`
+	Type        string             `json:"type"`
+	Priority    *int               `json:"priority,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	DueAt       *time.Time         `json:"due_at,omitempty"`
+	ClosedAt    *time.Time         `json:"closed_at,omitempty"`
+	Assignee    string             `json:"assignee"`
+	From        string             `json:"from"`
+	ParentID    string             `json:"parent_id"`
+	Ref         string             `json:"ref"`
+	Needs       []string           `json:"needs"`
+	Description string             `json:"description"`
+	Labels      []string           `json:"labels"`
+	Metadata    map[string]string  `json:"metadata,omitempty"`
+	Comments    []beads.Comment    `json:"comments,omitempty"`
+	Attachments []beads.Attachment `json:"attachments,omitempty"`
+	Events      []beads.Transition `json:"events,omitempty"`
+	Version     int                `json:"version,omitempty"`
+}
+
+// commentRequest is the JSON wire format sent on stdin for add-comment.
+type commentRequest struct {
+	Author string `json:"author,omitempty"`
+	Text   string `json:"text"`
+}
+
+// attachRequest is the JSON wire format sent on stdin for attach.
+type attachRequest struct {
+	Kind   string `json:"kind"`
+	Ref    string `json:"ref"`
+	Author string `json:"author,omitempty"`
 }
 
 // marshalCreate converts a Bead to JSON for the exec script's create operation.
@@ -68,17 +92,21 @@ func marshalCreate(b beads.Bead) ([]byte, error) {
 		Ref:         b.Ref,
 		Needs:       b.Needs,
 		Description: b.Description,
+		Priority:    b.Priority,
+		DueAt:       b.DueAt,
 	}
 	return json.Marshal(r)
 }
 
 // marshalUpdate converts update options to JSON for the exec script.
-func marshalUpdate(title, description, parentID, assignee *string, labels []string) ([]byte, error) {
+func marshalUpdate(title, description, parentID, assignee *string, priority *int, dueAt *time.Time, labels []string) ([]byte, error) {
 	r := updateRequest{
 		Title:       title,
 		Description: description,
 		ParentID:    parentID,
 		Assignee:    assignee,
+		Priority:    priority,
+		DueAt:       dueAt,
 		Labels:      labels,
 	}
 	return json.Marshal(r)
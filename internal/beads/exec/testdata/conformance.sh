@@ -125,6 +125,13 @@ case "$op" in
       current=$(echo "$current" | jq --arg p "$new_pid" '.parent_id = $p')
     fi
 
+    # Apply assignee if present (non-null).
+    has_assignee=$(echo "$input" | jq 'has("assignee") and .assignee != null')
+    if [ "$has_assignee" = "true" ]; then
+      new_assignee=$(echo "$input" | jq -r '.assignee')
+      current=$(echo "$current" | jq --arg a "$new_assignee" '.assignee = $a')
+    fi
+
     # Append labels if present.
     new_labels=$(echo "$input" | jq -c '.labels // []')
     if [ "$new_labels" != "[]" ]; then
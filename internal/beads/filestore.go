@@ -2,6 +2,7 @@ package beads
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,19 +13,58 @@ import (
 
 // fileData is the on-disk JSON format for the bead store.
 type fileData struct {
-	Seq   int    `json:"seq"`
-	Beads []Bead `json:"beads"`
-	Deps  []Dep  `json:"deps,omitempty"`
+	// Version increments on every write. Files written before versioning
+	// have no version and read as 0.
+	Version int64  `json:"version,omitempty"`
+	Seq     int    `json:"seq"`
+	Beads   []Bead `json:"beads"`
+	Deps    []Dep  `json:"deps,omitempty"`
+}
+
+// ErrConflict is returned when a write was based on stale state and was
+// abandoned rather than clobbering another writer's changes: by Update
+// when [UpdateOpts].ExpectVersion no longer matches the bead's Version,
+// and (wrapped in a [*ConflictError]) when the store file changed between
+// a FileStore's read and its write.
+var ErrConflict = errors.New("bead store modified concurrently")
+
+// ConflictError reports a write abandoned because the store file's version
+// moved on underneath it. It matches [ErrConflict] with errors.Is.
+type ConflictError struct {
+	Path string
+	// Want is the version the write was based on; Got is the version
+	// found on disk.
+	Want, Got int64
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %v (expected version %d, found %d); retry", e.Path, ErrConflict, e.Want, e.Got)
+}
+
+// Is reports whether target is [ErrConflict].
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
 }
 
 // FileStore is a file-backed Store implementation. It embeds a MemStore for
 // all bead logic and adds JSON persistence — load on open, flush on every
 // write. Fine for Tutorial 01 volumes.
+//
+// Writes are safe across processes: each one takes an exclusive flock on
+// a sibling .lock file, reloads the file if another process has written
+// since, applies the change, and writes the result back with the version
+// bumped. If the version on disk moved anyway (a writer that ignores the
+// lock), the write fails with a [*ConflictError] and memory is rolled back.
+// Reads are served from memory after a stat of the file; when another
+// process has replaced it since, it is reloaded first, so a long-lived
+// store (a watch loop, gc top, gc web) sees other processes' writes.
 type FileStore struct {
 	*MemStore
-	fmu  sync.Mutex // guards mutate-then-save atomicity
-	fs   fsys.FS
-	path string
+	fmu     sync.Mutex // guards mutate-then-save atomicity
+	fs      fsys.FS
+	path    string
+	version int64       // on-disk version the in-memory state reflects
+	seen    os.FileInfo // stat of the file the in-memory state was read from
 }
 
 // OpenFileStore opens or creates a file-backed bead store at path. All file
@@ -35,106 +75,288 @@ func OpenFileStore(fs fsys.FS, path string) (*FileStore, error) {
 	if err := fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return nil, fmt.Errorf("opening file store: %w", err)
 	}
+	fd, err := readFileData(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("opening file store: %w", err)
+	}
+	return &FileStore{
+		MemStore: NewMemStoreFrom(fd.Seq, fd.Beads, fd.Deps),
+		fs:       fs,
+		path:     path,
+		version:  fd.Version,
+	}, nil
+}
 
+// readFileData reads and parses the store file. A missing file is an
+// empty store at version 0.
+func readFileData(fs fsys.FS, path string) (fileData, error) {
+	var fd fileData
 	data, err := fs.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return &FileStore{MemStore: NewMemStore(), fs: fs, path: path}, nil
+			return fd, nil
 		}
-		return nil, fmt.Errorf("opening file store: %w", err)
+		return fd, err
 	}
-
-	var fd fileData
 	if err := json.Unmarshal(data, &fd); err != nil {
-		return nil, fmt.Errorf("opening file store: %w", err)
+		return fd, err
 	}
-	return &FileStore{MemStore: NewMemStoreFrom(fd.Seq, fd.Beads, fd.Deps), fs: fs, path: path}, nil
+	return fd, nil
 }
 
-// Create delegates to MemStore.Create and flushes to disk.
-// If the disk flush fails, the in-memory mutation is rolled back to keep
-// the MemStore and file in sync.
-func (fs *FileStore) Create(b Bead) (Bead, error) {
+// mutate runs op as one cross-process read-modify-write: lock the file,
+// pick up other writers' changes, apply op in memory, flush. If the flush
+// fails, the in-memory state is rolled back to match the file.
+func (fs *FileStore) mutate(op func() error) error {
 	fs.fmu.Lock()
 	defer fs.fmu.Unlock()
-	result, err := fs.MemStore.Create(b)
+	unlock, err := fs.lockFile()
 	if err != nil {
-		return Bead{}, err
+		return err
+	}
+	defer unlock()
+
+	if err := fs.refresh(); err != nil {
+		return err
+	}
+	fs.mu.Lock()
+	seq, beads, deps := fs.snapshot()
+	fs.mu.Unlock()
+	if err := op(); err != nil {
+		return err
 	}
 	if err := fs.save(); err != nil {
-		// Roll back in-memory state: remove the bead that was just created.
-		_ = fs.MemStore.Close(result.ID)
-		return Bead{}, err
+		fs.replace(seq, beads, deps)
+		return err
 	}
-	return result, nil
+	return nil
 }
 
-// Update delegates to MemStore.Update and flushes to disk.
-func (fs *FileStore) Update(id string, opts UpdateOpts) error {
+// refresh reloads the in-memory state when another process has written
+// the file since this store last read or wrote it. Called with fmu and the
+// file lock held.
+func (fs *FileStore) refresh() error {
+	fd, err := readFileData(fs.fs, fs.path)
+	if err != nil {
+		return fmt.Errorf("reloading file store: %w", err)
+	}
+	if fd.Version == fs.version {
+		return nil
+	}
+	fs.replace(fd.Seq, fd.Beads, fd.Deps)
+	fs.version = fd.Version
+	return nil
+}
+
+// reload brings the in-memory state up to date with the file before a
+// read. The stat is taken before the file is read, so a write that lands
+// in between is picked up by the next read rather than missed. Writes
+// replace the file by rename, so an unchanged stat means an unchanged
+// file.
+func (fs *FileStore) reload() error {
+	fi, err := fs.fs.Stat(fs.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reloading file store: %w", err)
+	}
 	fs.fmu.Lock()
 	defer fs.fmu.Unlock()
-	if err := fs.MemStore.Update(id, opts); err != nil {
+	if fs.seen != nil && os.SameFile(fs.seen, fi) && fi.ModTime().Equal(fs.seen.ModTime()) && fi.Size() == fs.seen.Size() {
+		return nil
+	}
+	if err := fs.refresh(); err != nil {
 		return err
 	}
-	return fs.save()
+	fs.seen = fi
+	return nil
 }
 
-// Close delegates to MemStore.Close and flushes to disk.
-func (fs *FileStore) Close(id string) error {
-	fs.fmu.Lock()
-	defer fs.fmu.Unlock()
-	if err := fs.MemStore.Close(id); err != nil {
+// Get reloads the file if another process changed it, then delegates to
+// MemStore.Get.
+func (fs *FileStore) Get(id string) (Bead, error) {
+	if err := fs.reload(); err != nil {
+		return Bead{}, err
+	}
+	return fs.MemStore.Get(id)
+}
+
+// List reloads the file if another process changed it, then delegates to
+// MemStore.List.
+func (fs *FileStore) List() ([]Bead, error) {
+	if err := fs.reload(); err != nil {
+		return nil, err
+	}
+	return fs.MemStore.List()
+}
+
+// Ready reloads the file if another process changed it, then delegates to
+// MemStore.Ready.
+func (fs *FileStore) Ready() ([]Bead, error) {
+	if err := fs.reload(); err != nil {
+		return nil, err
+	}
+	return fs.MemStore.Ready()
+}
+
+// Children reloads the file if another process changed it, then delegates
+// to MemStore.Children.
+func (fs *FileStore) Children(parentID string) ([]Bead, error) {
+	if err := fs.reload(); err != nil {
+		return nil, err
+	}
+	return fs.MemStore.Children(parentID)
+}
+
+// ListByLabel reloads the file if another process changed it, then
+// delegates to MemStore.ListByLabel.
+func (fs *FileStore) ListByLabel(label string, limit int) ([]Bead, error) {
+	if err := fs.reload(); err != nil {
+		return nil, err
+	}
+	return fs.MemStore.ListByLabel(label, limit)
+}
+
+// ListByAssignee reloads the file if another process changed it, then
+// delegates to MemStore.ListByAssignee.
+func (fs *FileStore) ListByAssignee(assignee, status string, limit int) ([]Bead, error) {
+	if err := fs.reload(); err != nil {
+		return nil, err
+	}
+	return fs.MemStore.ListByAssignee(assignee, status, limit)
+}
+
+// Query reloads the file if another process changed it, then delegates to
+// MemStore.Query.
+func (fs *FileStore) Query(f Filter) ([]Bead, error) {
+	if err := fs.reload(); err != nil {
+		return nil, err
+	}
+	return fs.MemStore.Query(f)
+}
+
+// DepList reloads the file if another process changed it, then delegates
+// to MemStore.DepList.
+func (fs *FileStore) DepList(id, direction string) ([]Dep, error) {
+	if err := fs.reload(); err != nil {
+		return nil, err
+	}
+	return fs.MemStore.DepList(id, direction)
+}
+
+// lockFile takes an exclusive lock on the sibling .lock file and returns
+// the unlock function. Stores backed by a non-OS filesystem (tests) are
+// single-process, so locking is skipped for them.
+func (fs *FileStore) lockFile() (func(), error) {
+	if _, ok := fs.fs.(fsys.OSFS); !ok {
+		return func() {}, nil
+	}
+	unlock, err := fsys.LockFile(fs.path+".lock", 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("acquiring file store lock: %w", err)
+	}
+	return unlock, nil
+}
+
+// Create delegates to MemStore.Create and flushes to disk.
+// If the disk flush fails, the in-memory mutation is rolled back to keep
+// the MemStore and file in sync.
+func (fs *FileStore) Create(b Bead) (Bead, error) {
+	var result Bead
+	err := fs.mutate(func() error {
+		var err error
+		result, err = fs.MemStore.Create(b)
 		return err
+	})
+	if err != nil {
+		return Bead{}, err
 	}
-	return fs.save()
+	return result, nil
+}
+
+// Update delegates to MemStore.Update and flushes to disk.
+func (fs *FileStore) Update(id string, opts UpdateOpts) error {
+	return fs.mutate(func() error {
+		return fs.MemStore.Update(id, opts)
+	})
+}
+
+// Close delegates to MemStore.Close and flushes to disk.
+func (fs *FileStore) Close(id string) error {
+	return fs.mutate(func() error {
+		return fs.MemStore.Close(id)
+	})
 }
 
 // MolCook delegates to MemStore.MolCook and flushes to disk.
 func (fs *FileStore) MolCook(formula, title string, vars []string) (string, error) {
-	fs.fmu.Lock()
-	defer fs.fmu.Unlock()
-	id, err := fs.MemStore.MolCook(formula, title, vars)
+	var id string
+	err := fs.mutate(func() error {
+		var err error
+		id, err = fs.MemStore.MolCook(formula, title, vars)
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
-	if err := fs.save(); err != nil {
-		return "", err
-	}
 	return id, nil
 }
 
 // MolCookOn delegates to MemStore.MolCookOn and flushes to disk.
 func (fs *FileStore) MolCookOn(formula, beadID, title string, vars []string) (string, error) {
-	fs.fmu.Lock()
-	defer fs.fmu.Unlock()
-	id, err := fs.MemStore.MolCookOn(formula, beadID, title, vars)
+	var id string
+	err := fs.mutate(func() error {
+		var err error
+		id, err = fs.MemStore.MolCookOn(formula, beadID, title, vars)
+		return err
+	})
 	if err != nil {
 		return "", err
 	}
-	if err := fs.save(); err != nil {
-		return "", err
-	}
 	return id, nil
 }
 
 // SetMetadata delegates to MemStore.SetMetadata and flushes to disk.
 func (fs *FileStore) SetMetadata(id, key, value string) error {
-	fs.fmu.Lock()
-	defer fs.fmu.Unlock()
-	if err := fs.MemStore.SetMetadata(id, key, value); err != nil {
-		return err
-	}
-	return fs.save()
+	return fs.mutate(func() error {
+		return fs.MemStore.SetMetadata(id, key, value)
+	})
 }
 
 // SetMetadataBatch delegates to MemStore.SetMetadataBatch and flushes to disk.
 func (fs *FileStore) SetMetadataBatch(id string, kvs map[string]string) error {
-	fs.fmu.Lock()
-	defer fs.fmu.Unlock()
-	if err := fs.MemStore.SetMetadataBatch(id, kvs); err != nil {
+	return fs.mutate(func() error {
+		return fs.MemStore.SetMetadataBatch(id, kvs)
+	})
+}
+
+// AddComment delegates to MemStore.AddComment and flushes to disk.
+func (fs *FileStore) AddComment(id string, c Comment) (Comment, error) {
+	var result Comment
+	err := fs.mutate(func() error {
+		var err error
+		result, err = fs.MemStore.AddComment(id, c)
+		return err
+	})
+	if err != nil {
+		return Comment{}, err
+	}
+	return result, nil
+}
+
+// Attach delegates to MemStore.Attach and flushes to disk.
+func (fs *FileStore) Attach(id string, a Attachment) (Attachment, error) {
+	var result Attachment
+	err := fs.mutate(func() error {
+		var err error
+		result, err = fs.MemStore.Attach(id, a)
 		return err
+	})
+	if err != nil {
+		return Attachment{}, err
 	}
-	return fs.save()
+	return result, nil
 }
 
 // Ping checks that the store file is accessible.
@@ -144,32 +366,29 @@ func (fs *FileStore) Ping() error {
 
 // DepAdd delegates to MemStore.DepAdd and flushes to disk.
 func (fs *FileStore) DepAdd(issueID, dependsOnID, depType string) error {
-	fs.fmu.Lock()
-	defer fs.fmu.Unlock()
-	if err := fs.MemStore.DepAdd(issueID, dependsOnID, depType); err != nil {
-		return err
-	}
-	return fs.save()
+	return fs.mutate(func() error {
+		return fs.MemStore.DepAdd(issueID, dependsOnID, depType)
+	})
 }
 
 // DepRemove delegates to MemStore.DepRemove and flushes to disk.
 func (fs *FileStore) DepRemove(issueID, dependsOnID string) error {
-	fs.fmu.Lock()
-	defer fs.fmu.Unlock()
-	if err := fs.MemStore.DepRemove(issueID, dependsOnID); err != nil {
-		return err
-	}
-	return fs.save()
+	return fs.mutate(func() error {
+		return fs.MemStore.DepRemove(issueID, dependsOnID)
+	})
 }
 
-// save writes the full store state to disk atomically (temp file + rename).
-// Called with fmu held, so snapshot under MemStore.mu then release before I/O.
+// save writes the full store state to disk atomically (temp file + rename)
+// as the next version. Called from mutate with fmu and the file lock held,
+// so snapshot under MemStore.mu then release before I/O. The version is
+// re-checked just before the rename so a writer that bypassed the lock is
+// detected instead of overwritten.
 func (fs *FileStore) save() error {
 	fs.mu.Lock()
 	seq, beads, deps := fs.snapshot()
 	fs.mu.Unlock()
 
-	fd := fileData{Seq: seq, Beads: beads, Deps: deps}
+	fd := fileData{Version: fs.version + 1, Seq: seq, Beads: beads, Deps: deps}
 	data, err := json.MarshalIndent(fd, "", "  ")
 	if err != nil {
 		return fmt.Errorf("saving file store: %w", err)
@@ -179,8 +398,17 @@ func (fs *FileStore) save() error {
 	if err := fs.fs.WriteFile(tmp, data, 0o644); err != nil {
 		return fmt.Errorf("saving file store: %w", err)
 	}
+	current, err := readFileData(fs.fs, fs.path)
+	if err != nil {
+		return fmt.Errorf("saving file store: %w", err)
+	}
+	if current.Version != fs.version {
+		_ = fs.fs.Remove(tmp)
+		return &ConflictError{Path: fs.path, Want: fs.version, Got: current.Version}
+	}
 	if err := fs.fs.Rename(tmp, fs.path); err != nil {
 		return fmt.Errorf("saving file store: %w", err)
 	}
+	fs.version = fd.Version
 	return nil
 }
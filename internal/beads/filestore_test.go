@@ -1,11 +1,13 @@
 package beads_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/gastownhall/gascity/internal/beads"
@@ -27,6 +29,14 @@ func TestFileStore(t *testing.T) {
 	beadstest.RunCreationOrderTests(t, factory)
 	beadstest.RunDepTests(t, factory)
 	beadstest.RunMetadataTests(t, factory)
+	beadstest.RunCommentTests(t, factory)
+	beadstest.RunAttachmentTests(t, factory)
+	beadstest.RunPriorityTests(t, factory)
+	beadstest.RunDueTests(t, factory)
+	beadstest.RunClosedAtTests(t, factory)
+	beadstest.RunTransitionTests(t, factory)
+	beadstest.RunVersionTests(t, factory)
+	beadstest.RunClaimTests(t, factory)
 }
 
 func TestFileStorePersistence(t *testing.T) {
@@ -301,3 +311,231 @@ func TestFileStoreCloseWriteFails(t *testing.T) {
 		t.Errorf("error = %q, want 'disk full'", err)
 	}
 }
+
+// --- cross-process concurrency ---
+
+func TestFileStoreSeesOtherWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.json")
+	s1, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := s1.Create(beads.Bead{Title: "from s1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := s2.Create(beads.Bead{Title: "from s2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID == b.ID {
+		t.Fatalf("both stores allocated %s", a.ID)
+	}
+
+	s3, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := s3.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Errorf("on disk: %d beads, want 2 (a write was lost)", len(all))
+	}
+}
+
+func TestFileStoreReadsSeeOtherWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.json")
+	reader, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ready, _ := reader.Ready(); len(ready) != 0 {
+		t.Fatalf("Ready = %v, want none", ready)
+	}
+
+	b, err := writer.Create(beads.Bead{Title: "from writer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ready, err := reader.Ready()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ready) != 1 || ready[0].ID != b.ID {
+		t.Errorf("Ready after other write = %v, want [%s]", ready, b.ID)
+	}
+
+	if err := writer.Close(b.ID); err != nil {
+		t.Fatal(err)
+	}
+	got, err := reader.Get(b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "closed" {
+		t.Errorf("Status after other close = %q, want closed", got.Status)
+	}
+}
+
+func TestFileStoreConcurrentWritersLoseNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.json")
+	const writers, perWriter = 4, 10
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*perWriter)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each writer has its own store, like a separate gc process.
+			s, err := beads.OpenFileStore(fsys.OSFS{}, path)
+			if err != nil {
+				errs <- err
+				return
+			}
+			for i := 0; i < perWriter; i++ {
+				if _, err := s.Create(beads.Bead{Title: fmt.Sprintf("w%d-%d", w, i)}); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	s, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, _ := s.List()
+	if len(all) != writers*perWriter {
+		t.Errorf("got %d beads, want %d", len(all), writers*perWriter)
+	}
+}
+
+func TestFileStoreClaimNextAcrossProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.json")
+	seed, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n, workers = 10, 4
+	for i := 0; i < n; i++ {
+		if _, err := seed.Create(beads.Bead{Title: fmt.Sprintf("work-%d", i), Labels: []string{"pool:dog"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	claims := make(chan string, n*workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each worker has its own store, like a separate pool member.
+			s, err := beads.OpenFileStore(fsys.OSFS{}, path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for {
+				b, ok, err := s.ClaimNext("pool:dog", fmt.Sprintf("dog-%d", w))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if !ok {
+					return
+				}
+				claims <- b.ID
+			}
+		}()
+	}
+	wg.Wait()
+	close(claims)
+	seen := make(map[string]bool)
+	for id := range claims {
+		if seen[id] {
+			t.Errorf("bead %s claimed twice", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != n {
+		t.Errorf("claimed %d beads, want %d", len(seen), n)
+	}
+}
+
+// racingFS simulates a writer that ignores the lock: it bumps the store
+// file's version whenever the FileStore writes its temp file.
+type racingFS struct {
+	*fsys.Fake
+	path string
+}
+
+func (r racingFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	if name == r.path+".tmp" {
+		r.Files[r.path] = []byte(`{"version": 99, "seq": 0, "beads": []}`)
+	}
+	return r.Fake.WriteFile(name, data, perm)
+}
+
+func TestFileStoreConflict(t *testing.T) {
+	const path = "/city/.gc/beads.json"
+	fake := fsys.NewFake()
+	s, err := beads.OpenFileStore(racingFS{Fake: fake, path: path}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Create(beads.Bead{Title: "lost race"})
+	var conflict *beads.ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, beads.ErrConflict) {
+		t.Fatalf("err = %v, want *ConflictError", err)
+	}
+	if conflict.Want != 0 || conflict.Got != 99 {
+		t.Errorf("conflict = %+v, want 0 → 99", conflict)
+	}
+	if all, _ := s.List(); len(all) != 0 {
+		t.Errorf("failed write left %d beads in memory", len(all))
+	}
+	if _, ok := fake.Files[path+".tmp"]; ok {
+		t.Error("temp file left behind after conflict")
+	}
+}
+
+func TestFileStoreFailedSaveRollsBack(t *testing.T) {
+	f := fsys.NewFake()
+	s, err := beads.OpenFileStore(f, "/city/.gc/beads.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Errors["/city/.gc/beads.json.tmp"] = fmt.Errorf("disk full")
+	if _, err := s.Create(beads.Bead{Title: "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if all, _ := s.List(); len(all) != 0 {
+		t.Errorf("failed Create left %+v in memory", all)
+	}
+
+	delete(f.Errors, "/city/.gc/beads.json.tmp")
+	b, err := s.Create(beads.Bead{Title: "retry"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.ID != "gc-1" {
+		t.Errorf("retry ID = %s, want gc-1 (sequence rolled back)", b.ID)
+	}
+}
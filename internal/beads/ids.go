@@ -0,0 +1,88 @@
+package beads
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// ID formats an [IDScheme] can mint.
+const (
+	IDFormatCounter = "counter" // <prefix>-<n>, from the store's sequence
+	IDFormatULID    = "ulid"    // <prefix>-<ulid>, sortable by creation time
+)
+
+// DefaultIDPrefix is the prefix of IDs minted by a zero [IDScheme].
+const DefaultIDPrefix = "gc"
+
+// maxIDPad bounds IDScheme.Pad; counters never need more digits.
+const maxIDPad = 12
+
+// idPrefixPattern is what an ID prefix may contain. No "-", since the
+// prefix of an ID is everything before its first one.
+var idPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// IDScheme decides how a store mints bead IDs. The zero value mints
+// gc-1, gc-2, and so on, the IDs stores have always used.
+type IDScheme struct {
+	// Prefix starts every ID, followed by "-". Default "gc".
+	Prefix string
+	// Format is IDFormatCounter (default) or IDFormatULID.
+	Format string
+	// Pad zero-pads counter IDs to this many digits, so that they sort
+	// as strings (e.g., 4 gives gc-0042). Ignored for ULIDs.
+	Pad int
+}
+
+// Validate reports whether s can mint IDs.
+func (s IDScheme) Validate() error {
+	if s.Prefix != "" && !idPrefixPattern.MatchString(s.Prefix) {
+		return fmt.Errorf("bead ID prefix %q: want letters, digits, or _", s.Prefix)
+	}
+	switch s.Format {
+	case "", IDFormatCounter, IDFormatULID:
+	default:
+		return fmt.Errorf("bead ID format %q: want %s or %s", s.Format, IDFormatCounter, IDFormatULID)
+	}
+	if s.Pad < 0 || s.Pad > maxIDPad {
+		return fmt.Errorf("bead ID pad %d: want 0 to %d", s.Pad, maxIDPad)
+	}
+	return nil
+}
+
+// ID returns the ID for the bead numbered seq in its store, created at
+// now.
+func (s IDScheme) ID(seq int, now time.Time) string {
+	prefix := s.Prefix
+	if prefix == "" {
+		prefix = DefaultIDPrefix
+	}
+	if s.Format == IDFormatULID {
+		return prefix + "-" + newULID(now)
+	}
+	return fmt.Sprintf("%s-%0*d", prefix, s.Pad, seq)
+}
+
+// ulidAlphabet is Crockford's base32, lowercased to match the rest of
+// the ID.
+const ulidAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// newULID returns a ULID for now: 48 bits of Unix milliseconds then 80
+// random bits, as 26 base32 characters.
+func newULID(now time.Time) string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(now.UnixMilli())<<16)
+	_, _ = rand.Read(b[6:])
+	// Encode 128 bits as 26 five-bit groups, the first holding 3 bits.
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = ulidAlphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
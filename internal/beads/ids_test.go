@@ -0,0 +1,110 @@
+package beads
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestIDSchemeID(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		s    IDScheme
+		seq  int
+		want string
+	}{
+		{IDScheme{}, 7, "gc-7"},
+		{IDScheme{Prefix: "ap"}, 7, "ap-7"},
+		{IDScheme{Prefix: "ap", Format: IDFormatCounter, Pad: 4}, 42, "ap-0042"},
+		{IDScheme{Pad: 2}, 123, "gc-123"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.ID(tt.seq, now); got != tt.want {
+			t.Errorf("%+v.ID(%d) = %q, want %q", tt.s, tt.seq, got, tt.want)
+		}
+	}
+}
+
+func TestIDSchemeULID(t *testing.T) {
+	s := IDScheme{Prefix: "ap", Format: IDFormatULID}
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	a, b, later := s.ID(1, t0), s.ID(2, t0), s.ID(3, t0.Add(time.Millisecond))
+	if !regexp.MustCompile(`^ap-[0-9a-hjkmnp-tv-z]{26}$`).MatchString(a) {
+		t.Fatalf("ULID ID = %q", a)
+	}
+	if a == b {
+		t.Errorf("two IDs in the same millisecond collide: %q", a)
+	}
+	if a[:13] != b[:13] || !(a < later && b < later) {
+		t.Errorf("IDs do not sort by time: %q %q %q", a, b, later)
+	}
+	// The timestamp is the leading 10 characters; Unix epoch starts with zeros.
+	if got := (IDScheme{Format: IDFormatULID}).ID(1, time.UnixMilli(0)); !strings.HasPrefix(got, "gc-0000000000") {
+		t.Errorf("epoch ULID = %q", got)
+	}
+}
+
+func TestIDSchemeValidate(t *testing.T) {
+	for _, s := range []IDScheme{{}, {Prefix: "my_rig", Format: IDFormatULID}, {Format: IDFormatCounter, Pad: 6}} {
+		if err := s.Validate(); err != nil {
+			t.Errorf("%+v: %v", s, err)
+		}
+	}
+	for _, s := range []IDScheme{{Prefix: "a-b"}, {Format: "uuid"}, {Pad: -1}, {Pad: 40}} {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%+v: want error", s)
+		}
+	}
+}
+
+func TestStoresHonorIDScheme(t *testing.T) {
+	dir := t.TempDir()
+	fs, err := OpenFileStore(fsys.OSFS{}, filepath.Join(dir, "beads.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sq, err := OpenSQLiteStore(fsys.OSFS{}, filepath.Join(dir, "beads.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sq.CloseDB() //nolint:errcheck // test cleanup
+	stores := map[string]interface {
+		Store
+		SetIDScheme(IDScheme)
+	}{"mem": NewMemStore(), "file": fs, "sqlite": sq}
+	for name, s := range stores {
+		s.SetIDScheme(IDScheme{Prefix: "ap", Pad: 3})
+		b, err := s.Create(Bead{Title: "one"})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if b.ID != "ap-001" {
+			t.Errorf("%s: ID = %q, want ap-001", name, b.ID)
+		}
+		if _, err := s.Get("ap-001"); err != nil {
+			t.Errorf("%s: Get: %v", name, err)
+		}
+	}
+}
+
+func TestFileStoreHandlesShareSequence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.json")
+	city, err := OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rig, err := OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rig.SetIDScheme(IDScheme{Prefix: "ap"})
+	a, _ := city.Create(Bead{Title: "city work"})
+	b, _ := rig.Create(Bead{Title: "rig work"})
+	if a.ID != "gc-1" || b.ID != "ap-2" {
+		t.Errorf("IDs = %q, %q; want gc-1, ap-2", a.ID, b.ID)
+	}
+}
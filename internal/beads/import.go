@@ -0,0 +1,160 @@
+package beads
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Importer is implemented by stores that can load beads verbatim, keeping
+// their IDs, timestamps, status history, comments, and attachments, as
+// gc beads migrate needs when copying one store into another.
+type Importer interface {
+	// Import adds bs and deps exactly as given. It fails without
+	// importing anything if any bead's ID is already in the store.
+	Import(bs []Bead, deps []Dep) error
+}
+
+// Export reads every bead in s, fully populated, and the dependencies
+// among them, in the form [Importer.Import] takes.
+func Export(s Store) ([]Bead, []Dep, error) {
+	list, err := s.List()
+	if err != nil {
+		return nil, nil, fmt.Errorf("exporting beads: %w", err)
+	}
+	bs := make([]Bead, 0, len(list))
+	var deps []Dep
+	for _, b := range list {
+		// List may leave out comments and history; Get has them all.
+		full, err := s.Get(b.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("exporting beads: %w", err)
+		}
+		bs = append(bs, full)
+		ds, err := s.DepList(b.ID, "down")
+		if err != nil {
+			return nil, nil, fmt.Errorf("exporting beads: %w", err)
+		}
+		deps = append(deps, ds...)
+	}
+	return bs, deps, nil
+}
+
+// idCounter returns the counter in a <prefix>-<n> ID, or 0 for IDs that
+// have none (such as ULIDs). Importers move their sequence past it so
+// new IDs cannot collide with imported ones.
+func idCounter(id string) int {
+	i := strings.LastIndex(id, "-")
+	if i < 0 {
+		return 0
+	}
+	n, err := strconv.Atoi(id[i+1:])
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// Import adds beads and deps verbatim. See [Importer].
+func (m *MemStore) Import(bs []Bead, deps []Dep) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	have := make(map[string]bool, len(m.beads)+len(bs))
+	for _, b := range m.beads {
+		have[b.ID] = true
+	}
+	for _, b := range bs {
+		if b.ID == "" || have[b.ID] {
+			return fmt.Errorf("importing bead %q: ID empty or already in store", b.ID)
+		}
+		have[b.ID] = true
+	}
+	for _, b := range bs {
+		m.beads = append(m.beads, cloneBead(b))
+		m.seq = max(m.seq, idCounter(b.ID))
+	}
+	m.deps = append(m.deps, deps...)
+	return nil
+}
+
+// Import adds beads and deps verbatim and flushes to disk. See [Importer].
+func (fs *FileStore) Import(bs []Bead, deps []Dep) error {
+	return fs.mutate(func() error {
+		return fs.MemStore.Import(bs, deps)
+	})
+}
+
+var (
+	_ Importer = (*MemStore)(nil)
+	_ Importer = (*FileStore)(nil)
+	_ Importer = (*SQLiteStore)(nil)
+)
+
+// Import adds beads and deps verbatim in one transaction. See [Importer].
+func (s *SQLiteStore) Import(bs []Bead, deps []Dep) error {
+	err := s.withTx(func(tx *sql.Tx) error {
+		maxSeq := 0
+		for _, b := range bs {
+			if b.ID == "" {
+				return fmt.Errorf("bead with empty ID")
+			}
+			if err := requireBead(tx, b.ID); err == nil {
+				return fmt.Errorf("bead %q already in store", b.ID)
+			} else if !errors.Is(err, ErrNotFound) {
+				return err
+			}
+			needs, err := json.Marshal(b.Needs)
+			if err != nil {
+				return err
+			}
+			_, err = tx.Exec(`INSERT INTO beads (id, title, status, type, created_at, assignee, from_agent, parent_id, ref, needs, description, priority, due_at, closed_at, version)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				b.ID, b.Title, b.Status, b.Type, b.CreatedAt.Format(time.RFC3339Nano),
+				b.Assignee, b.From, b.ParentID, b.Ref, string(needs), b.Description, b.Priority,
+				sqlTime(b.DueAt), sqlTime(b.ClosedAt), max(b.Version, 1))
+			if err != nil {
+				return err
+			}
+			if err := writeLabels(tx, b.ID, b.Labels); err != nil {
+				return err
+			}
+			if err := writeMetadata(tx, b.ID, b.Metadata); err != nil {
+				return err
+			}
+			if err := writeTransitions(tx, b.ID, b.Events); err != nil {
+				return err
+			}
+			for _, c := range b.Comments {
+				if _, err := tx.Exec(`INSERT INTO comments (bead_id, author, text, created_at) VALUES (?, ?, ?, ?)`,
+					b.ID, c.Author, c.Text, c.CreatedAt.Format(time.RFC3339Nano)); err != nil {
+					return err
+				}
+			}
+			for _, a := range b.Attachments {
+				if _, err := tx.Exec(`INSERT OR IGNORE INTO attachments (bead_id, kind, ref, author, created_at) VALUES (?, ?, ?, ?, ?)`,
+					b.ID, a.Kind, a.Ref, a.Author, a.CreatedAt.Format(time.RFC3339Nano)); err != nil {
+					return err
+				}
+			}
+			maxSeq = max(maxSeq, idCounter(b.ID))
+		}
+		for _, d := range deps {
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO deps (issue_id, depends_on_id, type) VALUES (?, ?, ?)`,
+				d.IssueID, d.DependsOnID, d.Type); err != nil {
+				return err
+			}
+		}
+		// Move the row sequence that counter IDs come from past the
+		// imported ones.
+		_, err := tx.Exec(`UPDATE sqlite_sequence SET seq = ? WHERE name = 'beads' AND seq < ?`, maxSeq, maxSeq)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("importing beads: %w", err)
+	}
+	return nil
+}
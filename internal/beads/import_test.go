@@ -0,0 +1,116 @@
+package beads_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// seedExportSource fills a MemStore with a parent, a closed child with a
+// comment, and a dependency between them.
+func seedExportSource(t *testing.T) *beads.MemStore {
+	t.Helper()
+	src := beads.NewMemStore()
+	parent, err := src.Create(beads.Bead{Title: "epic", Type: "epic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := src.Create(beads.Bead{Title: "task", ParentID: parent.ID, Labels: []string{"x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.AddComment(child.ID, beads.Comment{Author: "mayor", Text: "done"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetMetadata(child.ID, "k", "v"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Close(child.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.DepAdd(parent.ID, child.ID, "blocks"); err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	fileStore, err := beads.OpenFileStore(fsys.OSFS{}, filepath.Join(dir, "beads.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dests := map[string]interface {
+		beads.Store
+		beads.Importer
+	}{
+		"mem":    beads.NewMemStore(),
+		"file":   fileStore,
+		"sqlite": openTestSQLiteStore(t, filepath.Join(dir, "beads.db")),
+	}
+	for name, dst := range dests {
+		t.Run(name, func(t *testing.T) {
+			src := seedExportSource(t)
+			bs, deps, err := beads.Export(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := dst.Import(bs, deps); err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			for _, want := range bs {
+				got, err := dst.Get(want.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !got.CreatedAt.Equal(want.CreatedAt) || got.Status != want.Status ||
+					got.ParentID != want.ParentID || len(got.Comments) != len(want.Comments) ||
+					len(got.Events) != len(want.Events) || got.Metadata["k"] != want.Metadata["k"] {
+					t.Errorf("Get(%s) = %+v, want %+v", want.ID, got, want)
+				}
+			}
+			gotDeps, err := dst.DepList(bs[0].ID, "down")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotDeps, deps) {
+				t.Errorf("DepList = %+v, want %+v", gotDeps, deps)
+			}
+			// New beads must not reuse an imported ID.
+			b, err := dst.Create(beads.Bead{Title: "after"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, old := range bs {
+				if b.ID == old.ID {
+					t.Errorf("Create reused imported ID %s", b.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestImportRejectsExistingID(t *testing.T) {
+	src := seedExportSource(t)
+	bs, deps, err := beads.Export(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := openTestSQLiteStore(t, filepath.Join(t.TempDir(), "beads.db"))
+	if _, err := dst.Create(beads.Bead{Title: "already here"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Import(bs, deps); err == nil {
+		t.Fatal("Import over an existing ID succeeded, want error")
+	}
+	list, err := dst.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Errorf("store has %d beads after failed import, want 1", len(list))
+	}
+}
@@ -16,6 +16,7 @@ type MemStore struct {
 	beads []Bead
 	deps  []Dep
 	seq   int
+	ids   IDScheme
 }
 
 // NewMemStore returns a new empty MemStore.
@@ -33,6 +34,14 @@ func NewMemStoreFrom(seq int, existing []Bead, deps []Dep) *MemStore {
 	return &MemStore{seq: seq, beads: b, deps: d}
 }
 
+// SetIDScheme sets how the store mints IDs for new beads. Existing beads
+// keep their IDs.
+func (m *MemStore) SetIDScheme(s IDScheme) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ids = s
+}
+
 // snapshot returns the current sequence counter, a deep copy of all beads, and
 // a copy of all deps. Used by FileStore for serialization. Caller must hold m.mu.
 func (m *MemStore) snapshot() (int, []Bead, []Dep) {
@@ -45,28 +54,55 @@ func (m *MemStore) snapshot() (int, []Bead, []Dep) {
 	return m.seq, b, d
 }
 
+// replace swaps in a new state wholesale. Used by FileStore to pick up
+// writes made by other processes and to roll back a failed flush.
+func (m *MemStore) replace(seq int, beads []Bead, deps []Dep) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seq, m.beads, m.deps = seq, beads, deps
+}
+
 // cloneBead returns a deep copy of a bead, cloning reference fields
-// (Metadata, Labels, Needs) to prevent shared-state races between callers
-// and the store.
+// (Metadata, Labels, Needs, Comments, Attachments, Events, Priority, DueAt,
+// ClosedAt) to prevent
+// shared-state races between callers and the store.
 func cloneBead(b Bead) Bead {
 	b.Metadata = maps.Clone(b.Metadata)
 	b.Labels = slices.Clone(b.Labels)
 	b.Needs = slices.Clone(b.Needs)
+	b.Comments = slices.Clone(b.Comments)
+	b.Attachments = slices.Clone(b.Attachments)
+	b.Events = slices.Clone(b.Events)
+	if b.Priority != nil {
+		p := *b.Priority
+		b.Priority = &p
+	}
+	if b.DueAt != nil {
+		d := *b.DueAt
+		b.DueAt = &d
+	}
+	if b.ClosedAt != nil {
+		c := *b.ClosedAt
+		b.ClosedAt = &c
+	}
 	return b
 }
 
-// Create persists a new bead in memory with a sequential ID.
+// Create persists a new bead in memory with an ID from the store's
+// [IDScheme].
 func (m *MemStore) Create(b Bead) (Bead, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.seq++
-	b.ID = fmt.Sprintf("gc-%d", m.seq)
+	b.CreatedAt = time.Now()
+	b.ID = m.ids.ID(m.seq, b.CreatedAt)
 	b.Status = "open"
 	if b.Type == "" {
 		b.Type = "task"
 	}
-	b.CreatedAt = time.Now()
+	b.Events = transitions(Bead{}, &b.Status, &b.Assignee, b.CreatedAt)
+	b.Version = 1
 
 	stored := cloneBead(b)
 	m.beads = append(m.beads, stored)
@@ -74,17 +110,23 @@ func (m *MemStore) Create(b Bead) (Bead, error) {
 }
 
 // Update modifies fields of an existing bead. Only non-nil fields in opts
-// are applied. Returns a wrapped ErrNotFound if the ID does not exist.
+// are applied. Returns a wrapped ErrNotFound if the ID does not exist, or
+// a wrapped ErrConflict if opts.ExpectVersion is stale.
 func (m *MemStore) Update(id string, opts UpdateOpts) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	for i := range m.beads {
 		if m.beads[i].ID == id {
+			if opts.ExpectVersion != nil && *opts.ExpectVersion != m.beads[i].Version {
+				return fmt.Errorf("updating bead %q: at version %d, expected %d: %w", id, m.beads[i].Version, *opts.ExpectVersion, ErrConflict)
+			}
+			m.beads[i].Version++
+			m.beads[i].Events = append(m.beads[i].Events, transitions(m.beads[i], opts.Status, opts.Assignee, time.Now())...)
 			if opts.Title != nil {
 				m.beads[i].Title = *opts.Title
 			}
 			if opts.Status != nil {
-				m.beads[i].Status = *opts.Status
+				setStatus(&m.beads[i], *opts.Status)
 			}
 			if opts.Description != nil {
 				m.beads[i].Description = *opts.Description
@@ -95,6 +137,13 @@ func (m *MemStore) Update(id string, opts UpdateOpts) error {
 			if opts.Assignee != nil {
 				m.beads[i].Assignee = *opts.Assignee
 			}
+			if opts.Priority != nil {
+				p := *opts.Priority
+				m.beads[i].Priority = &p
+			}
+			if opts.DueAt != nil {
+				m.beads[i].DueAt = dueOrNil(*opts.DueAt)
+			}
 			if len(opts.Labels) > 0 {
 				m.beads[i].Labels = append(m.beads[i].Labels, opts.Labels...)
 			}
@@ -124,13 +173,31 @@ func (m *MemStore) Close(id string) error {
 	defer m.mu.Unlock()
 	for i := range m.beads {
 		if m.beads[i].ID == id {
-			m.beads[i].Status = "closed"
+			closed := "closed"
+			if m.beads[i].Status != closed {
+				m.beads[i].Version++
+			}
+			m.beads[i].Events = append(m.beads[i].Events, transitions(m.beads[i], &closed, nil, time.Now())...)
+			setStatus(&m.beads[i], closed)
 			return nil
 		}
 	}
 	return fmt.Errorf("closing bead %q: %w", id, ErrNotFound)
 }
 
+// setStatus changes b's status, stamping ClosedAt when it becomes closed
+// and clearing it when it is reopened.
+func setStatus(b *Bead, status string) {
+	switch {
+	case status == "closed" && b.Status != "closed":
+		now := time.Now()
+		b.ClosedAt = &now
+	case status != "closed":
+		b.ClosedAt = nil
+	}
+	b.Status = status
+}
+
 // List returns all beads in creation order.
 func (m *MemStore) List() ([]Bead, error) {
 	m.mu.Lock()
@@ -142,19 +209,38 @@ func (m *MemStore) List() ([]Bead, error) {
 	return result, nil
 }
 
-// Ready returns all beads with status "open", in creation order.
+// Ready returns all beads with status "open" that are not blocked by an
+// unclosed dependency, in priority order.
 func (m *MemStore) Ready() ([]Bead, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	var result []Bead
 	for _, b := range m.beads {
-		if b.Status == "open" {
+		if b.Status == "open" && !m.blockedLocked(b.ID) {
 			result = append(result, cloneBead(b))
 		}
 	}
+	SortByPriority(result)
 	return result, nil
 }
 
+// blockedLocked reports whether the bead has a blocking dependency on a
+// bead that exists and is not yet closed. Dependencies on unknown IDs do
+// not block. Caller must hold m.mu.
+func (m *MemStore) blockedLocked(id string) bool {
+	for _, d := range m.deps {
+		if d.IssueID != id || !IsBlockingDepType(d.Type) {
+			continue
+		}
+		for _, b := range m.beads {
+			if b.ID == d.DependsOnID && b.Status != "closed" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Get retrieves a bead by ID. Returns a wrapped ErrNotFound if the ID does
 // not exist.
 func (m *MemStore) Get(id string) (Bead, error) {
@@ -225,6 +311,16 @@ func (m *MemStore) ListByAssignee(assignee, status string, limit int) ([]Bead, e
 	return result, nil
 }
 
+// Query returns beads matching the filter, sorted and truncated as the
+// filter specifies.
+func (m *MemStore) Query(f Filter) ([]Bead, error) {
+	all, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	return ApplyFilter(all, f), nil
+}
+
 // SetMetadata sets a key-value metadata pair on a bead. Returns a wrapped
 // ErrNotFound if the bead does not exist.
 func (m *MemStore) SetMetadata(id, key, value string) error {
@@ -236,6 +332,7 @@ func (m *MemStore) SetMetadata(id, key, value string) error {
 				m.beads[i].Metadata = make(map[string]string)
 			}
 			m.beads[i].Metadata[key] = value
+			m.beads[i].Version++
 			return nil
 		}
 	}
@@ -254,12 +351,51 @@ func (m *MemStore) SetMetadataBatch(id string, kvs map[string]string) error {
 			for k, v := range kvs {
 				m.beads[i].Metadata[k] = v
 			}
+			m.beads[i].Version++
 			return nil
 		}
 	}
 	return fmt.Errorf("setting metadata batch on %q: %w", id, ErrNotFound)
 }
 
+// AddComment appends a comment to a bead's worklog, stamping CreatedAt.
+// Returns a wrapped ErrNotFound if the bead does not exist.
+func (m *MemStore) AddComment(id string, c Comment) (Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, b := range m.beads {
+		if b.ID == id {
+			c.CreatedAt = time.Now()
+			m.beads[i].Comments = append(m.beads[i].Comments, c)
+			m.beads[i].Version++
+			return c, nil
+		}
+	}
+	return Comment{}, fmt.Errorf("adding comment to %q: %w", id, ErrNotFound)
+}
+
+// Attach links an artifact to a bead, stamping CreatedAt. A Kind and Ref
+// already attached returns the existing attachment unchanged. Returns a
+// wrapped ErrNotFound if the bead does not exist.
+func (m *MemStore) Attach(id string, a Attachment) (Attachment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, b := range m.beads {
+		if b.ID == id {
+			for _, have := range b.Attachments {
+				if have.Kind == a.Kind && have.Ref == a.Ref {
+					return have, nil
+				}
+			}
+			a.CreatedAt = time.Now()
+			m.beads[i].Attachments = append(m.beads[i].Attachments, a)
+			m.beads[i].Version++
+			return a, nil
+		}
+	}
+	return Attachment{}, fmt.Errorf("attaching to %q: %w", id, ErrNotFound)
+}
+
 // Ping always succeeds for MemStore (in-memory, always available).
 func (m *MemStore) Ping() error {
 	return nil
@@ -15,6 +15,14 @@ func TestMemStore(t *testing.T) {
 	beadstest.RunCreationOrderTests(t, factory)
 	beadstest.RunDepTests(t, factory)
 	beadstest.RunMetadataTests(t, factory)
+	beadstest.RunCommentTests(t, factory)
+	beadstest.RunAttachmentTests(t, factory)
+	beadstest.RunPriorityTests(t, factory)
+	beadstest.RunDueTests(t, factory)
+	beadstest.RunClosedAtTests(t, factory)
+	beadstest.RunTransitionTests(t, factory)
+	beadstest.RunVersionTests(t, factory)
+	beadstest.RunClaimTests(t, factory)
 }
 
 func TestMemStoreSetMetadata(t *testing.T) {
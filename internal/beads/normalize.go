@@ -0,0 +1,83 @@
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// InspectFileStore reports problems in the FileStore file at path that
+// the store loads without complaint but serves inconsistently: beads that
+// share an ID (only the first is ever read or updated) and statuses
+// outside open/in_progress/closed. A missing file has no problems.
+func InspectFileStore(fs fsys.FS, path string) ([]string, error) {
+	fd, err := readFileData(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeFileData(&fd), nil
+}
+
+// NormalizeFileStore repairs the problems [InspectFileStore] reports and
+// returns them. Duplicate beads after the first with a given ID are
+// dropped, and invalid statuses are mapped the way bd statuses are (see
+// mapBdStatus). Before rewriting, the original file is copied to backup.
+// The file is left untouched when there is nothing to repair.
+func NormalizeFileStore(fs fsys.FS, path, backup string) ([]string, error) {
+	unlock, err := (&FileStore{fs: fs, path: path}).lockFile()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	raw, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fd fileData
+	if err := json.Unmarshal(raw, &fd); err != nil {
+		return nil, err
+	}
+	issues := normalizeFileData(&fd)
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	if err := fs.WriteFile(backup, raw, 0o644); err != nil {
+		return nil, fmt.Errorf("backing up %s: %w", path, err)
+	}
+	fd.Version++
+	data, err := json.MarshalIndent(fd, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	tmp := path + ".tmp"
+	if err := fs.WriteFile(tmp, data, 0o644); err != nil {
+		return nil, err
+	}
+	if err := fs.Rename(tmp, path); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// normalizeFileData repairs fd in place and describes each repair.
+func normalizeFileData(fd *fileData) []string {
+	var issues []string
+	seen := make(map[string]bool, len(fd.Beads))
+	kept := fd.Beads[:0]
+	for _, b := range fd.Beads {
+		if seen[b.ID] {
+			issues = append(issues, fmt.Sprintf("duplicate bead %s dropped (%q)", b.ID, b.Title))
+			continue
+		}
+		seen[b.ID] = true
+		if s := mapBdStatus(b.Status); s != b.Status {
+			issues = append(issues, fmt.Sprintf("bead %s: invalid status %q set to %q", b.ID, b.Status, s))
+			b.Status = s
+		}
+		kept = append(kept, b)
+	}
+	fd.Beads = kept
+	return issues
+}
@@ -0,0 +1,107 @@
+package beads_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+const brokenStore = `{
+  "version": 4,
+  "seq": 3,
+  "beads": [
+    {"id": "gc-1", "title": "first", "status": "open"},
+    {"id": "gc-2", "title": "second", "status": "blocked"},
+    {"id": "gc-1", "title": "first copy", "status": "closed"},
+    {"id": "gc-3", "title": "third", "status": ""}
+  ]
+}`
+
+func TestInspectFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.json")
+	if err := os.WriteFile(path, []byte(brokenStore), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := beads.InspectFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`bead gc-2: invalid status "blocked" set to "open"`,
+		`duplicate bead gc-1 dropped ("first copy")`,
+		`bead gc-3: invalid status "" set to "open"`,
+	}
+	if strings.Join(issues, "\n") != strings.Join(want, "\n") {
+		t.Errorf("issues = %q, want %q", issues, want)
+	}
+
+	// Inspecting does not modify the file.
+	data, _ := os.ReadFile(path)
+	if string(data) != brokenStore {
+		t.Error("InspectFileStore modified the store file")
+	}
+}
+
+func TestInspectFileStoreMissing(t *testing.T) {
+	issues, err := beads.InspectFileStore(fsys.OSFS{}, filepath.Join(t.TempDir(), "beads.json"))
+	if err != nil || len(issues) != 0 {
+		t.Errorf("InspectFileStore = %v, %v; want no issues", issues, err)
+	}
+}
+
+func TestNormalizeFileStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "beads.json")
+	backup := filepath.Join(dir, "beads.json.bak")
+	if err := os.WriteFile(path, []byte(brokenStore), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := beads.NormalizeFileStore(fsys.OSFS{}, path, backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 {
+		t.Errorf("issues = %q, want 3", issues)
+	}
+	data, err := os.ReadFile(backup)
+	if err != nil || string(data) != brokenStore {
+		t.Errorf("backup = %q, %v; want original contents", data, err)
+	}
+
+	s, err := beads.OpenFileStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("len(beads) = %d, want 3", len(all))
+	}
+	for _, b := range all {
+		if b.Status != "open" {
+			t.Errorf("%s status = %q, want open", b.ID, b.Status)
+		}
+		if b.ID == "gc-1" && b.Title != "first" {
+			t.Errorf("gc-1 title = %q, want the first copy kept", b.Title)
+		}
+	}
+
+	// A clean store is left alone.
+	if err := os.Remove(backup); err != nil {
+		t.Fatal(err)
+	}
+	issues, err = beads.NormalizeFileStore(fsys.OSFS{}, path, backup)
+	if err != nil || len(issues) != 0 {
+		t.Errorf("second NormalizeFileStore = %v, %v; want no issues", issues, err)
+	}
+	if _, err := os.Stat(backup); !os.IsNotExist(err) {
+		t.Error("backup written for a clean store")
+	}
+}
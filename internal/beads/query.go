@@ -0,0 +1,139 @@
+package beads
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Filter selects and orders beads for Store.Query. Zero-valued fields
+// match everything.
+type Filter struct {
+	Status   string // exact status match
+	Assignee string // exact assignee match
+	Label    string // bead must carry this label
+	Type     string // exact type match
+	// DueBefore, when set, selects unclosed beads whose due date is
+	// before it — pass the current time to list overdue beads.
+	DueBefore time.Time
+	// Sort orders results by a field: "created" (default), "priority",
+	// "due", "title", "status", "assignee", "type", or "id". A leading
+	// "-" reverses the order.
+	Sort  string
+	Limit int // max results after sorting (0 = unlimited)
+}
+
+// sortFields maps Filter.Sort keys to comparison functions.
+var sortFields = map[string]func(a, b Bead) int{
+	"created":  func(a, b Bead) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"title":    func(a, b Bead) int { return cmp.Compare(a.Title, b.Title) },
+	"status":   func(a, b Bead) int { return cmp.Compare(a.Status, b.Status) },
+	"assignee": func(a, b Bead) int { return cmp.Compare(a.Assignee, b.Assignee) },
+	"type":     func(a, b Bead) int { return cmp.Compare(a.Type, b.Type) },
+	"id":       func(a, b Bead) int { return cmp.Compare(a.ID, b.ID) },
+	"priority": ComparePriority,
+	"due":      compareDue,
+}
+
+// compareDue orders beads by due date, earliest first; beads without a
+// due date sort last.
+func compareDue(a, b Bead) int {
+	switch {
+	case a.DueAt == nil && b.DueAt == nil:
+		return 0
+	case a.DueAt == nil:
+		return 1
+	case b.DueAt == nil:
+		return -1
+	}
+	return a.DueAt.Compare(*b.DueAt)
+}
+
+// ComparePriority orders beads most urgent first (P0 before P4), then
+// oldest first within a priority. It is the ready-queue order.
+func ComparePriority(a, b Bead) int {
+	if c := cmp.Compare(a.PriorityOrDefault(), b.PriorityOrDefault()); c != 0 {
+		return c
+	}
+	return a.CreatedAt.Compare(b.CreatedAt)
+}
+
+// SortByPriority sorts bs in place by ComparePriority. Beads that compare
+// equal keep their input order.
+func SortByPriority(bs []Bead) {
+	slices.SortStableFunc(bs, ComparePriority)
+}
+
+// SortFields returns the accepted Filter.Sort keys in sorted order.
+func SortFields() []string {
+	keys := make([]string, 0, len(sortFields))
+	for k := range sortFields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Validate reports an error if the filter's sort key is not recognized
+// or its limit is negative.
+func (f Filter) Validate() error {
+	if f.Limit < 0 {
+		return fmt.Errorf("invalid limit %d: must be >= 0", f.Limit)
+	}
+	key := strings.TrimPrefix(f.Sort, "-")
+	if key != "" && sortFields[key] == nil {
+		return fmt.Errorf("invalid sort field %q (want one of %s)", f.Sort, strings.Join(SortFields(), ", "))
+	}
+	return nil
+}
+
+// Matches reports whether b satisfies the filter's selection fields.
+// Sort and Limit are ignored.
+func (f Filter) Matches(b Bead) bool {
+	if f.Status != "" && b.Status != f.Status {
+		return false
+	}
+	if f.Assignee != "" && b.Assignee != f.Assignee {
+		return false
+	}
+	if f.Type != "" && b.Type != f.Type {
+		return false
+	}
+	if f.Label != "" && !slices.Contains(b.Labels, f.Label) {
+		return false
+	}
+	if !f.DueBefore.IsZero() && !b.Overdue(f.DueBefore) {
+		return false
+	}
+	return true
+}
+
+// ApplyFilter selects, sorts, and truncates bs according to f. The input
+// order is preserved among beads that compare equal. Stores that cannot
+// filter natively use this over List results.
+func ApplyFilter(bs []Bead, f Filter) []Bead {
+	var result []Bead
+	for _, b := range bs {
+		if f.Matches(b) {
+			result = append(result, b)
+		}
+	}
+	key, desc := strings.CutPrefix(f.Sort, "-")
+	if key == "" {
+		key = "created"
+	}
+	if less := sortFields[key]; less != nil {
+		slices.SortStableFunc(result, func(a, b Bead) int {
+			if desc {
+				return less(b, a)
+			}
+			return less(a, b)
+		})
+	}
+	if f.Limit > 0 && len(result) > f.Limit {
+		result = result[:f.Limit]
+	}
+	return result
+}
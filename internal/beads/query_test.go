@@ -0,0 +1,88 @@
+package beads
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       Filter
+		wantErr bool
+	}{
+		{"empty", Filter{}, false},
+		{"known sort", Filter{Sort: "created"}, false},
+		{"descending sort", Filter{Sort: "-assignee"}, false},
+		{"priority sort", Filter{Sort: "-priority"}, false},
+		{"unknown sort", Filter{Sort: "urgency"}, true},
+		{"negative limit", Filter{Limit: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.f.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyFilterDefaultSortIsCreated(t *testing.T) {
+	now := time.Now()
+	bs := []Bead{
+		{ID: "gc-2", Title: "newer", CreatedAt: now},
+		{ID: "gc-1", Title: "older", CreatedAt: now.Add(-time.Minute)},
+		{ID: "gc-3", Title: "tie", CreatedAt: now},
+	}
+	got := ApplyFilter(bs, Filter{})
+	want := []string{"older", "newer", "tie"}
+	for i, w := range want {
+		if got[i].Title != w {
+			t.Fatalf("ApplyFilter order = %v, want %v", got, want)
+		}
+	}
+
+	got = ApplyFilter(bs, Filter{Sort: "-created", Limit: 1})
+	if len(got) != 1 || got[0].Title != "newer" {
+		t.Errorf("ApplyFilter(-created, limit 1) = %v, want [newer]", got)
+	}
+}
+
+func TestApplyFilterPrioritySort(t *testing.T) {
+	now := time.Now()
+	p0, p3 := 0, 3
+	bs := []Bead{
+		{Title: "default", CreatedAt: now},
+		{Title: "low", Priority: &p3, CreatedAt: now.Add(-time.Hour)},
+		{Title: "critical", Priority: &p0, CreatedAt: now},
+		{Title: "default-older", CreatedAt: now.Add(-time.Minute)},
+	}
+	got := ApplyFilter(bs, Filter{Sort: "priority"})
+	want := []string{"critical", "default-older", "default", "low"}
+	for i, w := range want {
+		if got[i].Title != w {
+			t.Errorf("got[%d] = %q, want %q", i, got[i].Title, w)
+		}
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"P0", 0, false},
+		{"p3", 3, false},
+		{"4", 4, false},
+		{"P5", 0, true},
+		{"-1", 0, true},
+		{"high", 0, true},
+	} {
+		got, err := ParsePriority(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParsePriority(%q) = %d, %v; want %d, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
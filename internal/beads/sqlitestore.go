@@ -0,0 +1,957 @@
+package beads
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// sqliteSchema creates the bead tables and indexes. Statements are
+// idempotent so the schema can be applied on every open.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS beads (
+	seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+	id          TEXT UNIQUE,
+	title       TEXT NOT NULL DEFAULT '',
+	status      TEXT NOT NULL DEFAULT 'open',
+	type        TEXT NOT NULL DEFAULT 'task',
+	created_at  TEXT NOT NULL,
+	assignee    TEXT NOT NULL DEFAULT '',
+	from_agent  TEXT NOT NULL DEFAULT '',
+	parent_id   TEXT NOT NULL DEFAULT '',
+	ref         TEXT NOT NULL DEFAULT '',
+	needs       TEXT NOT NULL DEFAULT '[]',
+	description TEXT NOT NULL DEFAULT '',
+	priority    INTEGER,
+	due_at      TEXT,
+	closed_at   TEXT,
+	version     INTEGER NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS beads_status ON beads(status);
+CREATE INDEX IF NOT EXISTS beads_assignee ON beads(assignee, status);
+CREATE INDEX IF NOT EXISTS beads_parent ON beads(parent_id);
+CREATE TABLE IF NOT EXISTS labels (
+	bead_id TEXT NOT NULL,
+	pos     INTEGER NOT NULL,
+	label   TEXT NOT NULL,
+	PRIMARY KEY (bead_id, pos)
+);
+CREATE INDEX IF NOT EXISTS labels_label ON labels(label);
+CREATE TABLE IF NOT EXISTS metadata (
+	bead_id TEXT NOT NULL,
+	key     TEXT NOT NULL,
+	value   TEXT NOT NULL,
+	PRIMARY KEY (bead_id, key)
+);
+CREATE TABLE IF NOT EXISTS deps (
+	issue_id      TEXT NOT NULL,
+	depends_on_id TEXT NOT NULL,
+	type          TEXT NOT NULL,
+	PRIMARY KEY (issue_id, depends_on_id)
+);
+CREATE INDEX IF NOT EXISTS deps_depends_on ON deps(depends_on_id);
+CREATE TABLE IF NOT EXISTS comments (
+	seq        INTEGER PRIMARY KEY AUTOINCREMENT,
+	bead_id    TEXT NOT NULL,
+	author     TEXT NOT NULL DEFAULT '',
+	text       TEXT NOT NULL,
+	created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS comments_bead ON comments(bead_id, seq);
+CREATE TABLE IF NOT EXISTS attachments (
+	seq        INTEGER PRIMARY KEY AUTOINCREMENT,
+	bead_id    TEXT NOT NULL,
+	kind       TEXT NOT NULL,
+	ref        TEXT NOT NULL,
+	author     TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL,
+	UNIQUE (bead_id, kind, ref)
+);
+CREATE TABLE IF NOT EXISTS transitions (
+	seq        INTEGER PRIMARY KEY AUTOINCREMENT,
+	bead_id    TEXT NOT NULL,
+	at         TEXT NOT NULL,
+	actor      TEXT NOT NULL DEFAULT '',
+	field      TEXT NOT NULL,
+	from_value TEXT NOT NULL DEFAULT '',
+	to_value   TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS transitions_bead ON transitions(bead_id, seq);
+`
+
+// sqliteAddedColumns lists bead columns added after the original schema,
+// with their definitions. Databases created before a column existed get
+// it via ALTER TABLE on open.
+var sqliteAddedColumns = []struct{ name, def string }{
+	{"priority", "INTEGER"},
+	{"due_at", "TEXT"},
+	{"closed_at", "TEXT"},
+	{"version", "INTEGER NOT NULL DEFAULT 1"},
+}
+
+// migrateSQLite adds any sqliteAddedColumns missing from the beads table.
+func migrateSQLite(db *sql.DB) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info('beads')`)
+	if err != nil {
+		return err
+	}
+	have := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close() //nolint:errcheck // already failing
+			return err
+		}
+		have[name] = true
+	}
+	rows.Close() //nolint:errcheck // read-only
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, c := range sqliteAddedColumns {
+		if have[c.name] {
+			continue
+		}
+		if _, err := db.Exec(`ALTER TABLE beads ADD COLUMN ` + c.name + ` ` + c.def); err != nil {
+			return fmt.Errorf("adding column %s: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
+// beadColumns is the column list shared by every bead SELECT. Order must
+// match scanBead.
+const beadColumns = `id, title, status, type, created_at, assignee, from_agent, parent_id, ref, needs, description, priority, due_at, closed_at, version`
+
+// SQLiteStore is a Store implementation backed by a single SQLite database
+// file. Unlike FileStore, each mutation is a small transaction rather than
+// a full rewrite, and multiple processes can read concurrently (WAL mode).
+// IDs are minted from the row sequence like MemStore and FileStore's.
+type SQLiteStore struct {
+	db  *sql.DB
+	ids IDScheme
+}
+
+// OpenSQLiteStore opens or creates a SQLite bead store at path. Parent
+// directories are created through fs; the database itself is opened
+// directly because SQLite owns its file I/O.
+func OpenSQLiteStore(fs fsys.FS, path string) (*SQLiteStore, error) {
+	if err := fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("opening sqlite store: %w", err)
+	}
+	// Transactions take the write lock up front (BEGIN IMMEDIATE): a
+	// deferred transaction that reads and then writes fails with
+	// SQLITE_BUSY on the lock upgrade instead of waiting out busy_timeout.
+	dsn := "file:" + path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite store: %w", err)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close() //nolint:errcheck // already failing
+		return nil, fmt.Errorf("opening sqlite store: creating schema: %w", err)
+	}
+	if err := migrateSQLite(db); err != nil {
+		db.Close() //nolint:errcheck // already failing
+		return nil, fmt.Errorf("opening sqlite store: migrating schema: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// SetIDScheme sets how the store mints IDs for new beads. Existing beads
+// keep their IDs. Not safe to call concurrently with Create.
+func (s *SQLiteStore) SetIDScheme(ids IDScheme) {
+	s.ids = ids
+}
+
+// CloseDB releases the underlying database handle. Named to avoid
+// colliding with Store.Close, which closes a bead.
+func (s *SQLiteStore) CloseDB() error {
+	return s.db.Close()
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanBead reads one row selected with beadColumns. Labels and Metadata
+// are filled separately by hydrate.
+func scanBead(r rowScanner) (Bead, error) {
+	var b Bead
+	var created, needs string
+	var priority sql.NullInt64
+	var due, closed sql.NullString
+	if err := r.Scan(&b.ID, &b.Title, &b.Status, &b.Type, &created,
+		&b.Assignee, &b.From, &b.ParentID, &b.Ref, &needs, &b.Description, &priority, &due, &closed, &b.Version); err != nil {
+		return Bead{}, err
+	}
+	if priority.Valid {
+		p := int(priority.Int64)
+		b.Priority = &p
+	}
+	if due.Valid {
+		d, err := time.Parse(time.RFC3339Nano, due.String)
+		if err != nil {
+			return Bead{}, fmt.Errorf("parsing due_at %q: %w", due.String, err)
+		}
+		b.DueAt = &d
+	}
+	if closed.Valid {
+		c, err := time.Parse(time.RFC3339Nano, closed.String)
+		if err != nil {
+			return Bead{}, fmt.Errorf("parsing closed_at %q: %w", closed.String, err)
+		}
+		b.ClosedAt = &c
+	}
+	t, err := time.Parse(time.RFC3339Nano, created)
+	if err != nil {
+		return Bead{}, fmt.Errorf("parsing created_at %q: %w", created, err)
+	}
+	b.CreatedAt = t
+	if err := json.Unmarshal([]byte(needs), &b.Needs); err != nil {
+		return Bead{}, fmt.Errorf("parsing needs for %q: %w", b.ID, err)
+	}
+	if len(b.Needs) == 0 {
+		b.Needs = nil
+	}
+	return b, nil
+}
+
+// queryBeads runs a SELECT over beads (the caller supplies everything
+// after the column list) and returns fully hydrated results.
+func (s *SQLiteStore) queryBeads(tail string, args ...any) ([]Bead, error) {
+	rows, err := s.db.Query("SELECT "+beadColumns+" FROM beads "+tail, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck // read-only
+	var result []Bead
+	for rows.Next() {
+		b, err := scanBead(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		if err := s.hydrate(&result[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// hydrate loads labels, metadata, comments, attachments, and transitions
+// for a bead.
+func (s *SQLiteStore) hydrate(b *Bead) error {
+	rows, err := s.db.Query(`SELECT label FROM labels WHERE bead_id = ? ORDER BY pos`, b.ID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var l string
+		if err := rows.Scan(&l); err != nil {
+			rows.Close() //nolint:errcheck // already failing
+			return err
+		}
+		b.Labels = append(b.Labels, l)
+	}
+	rows.Close() //nolint:errcheck // read-only
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = s.db.Query(`SELECT key, value FROM metadata WHERE bead_id = ?`, b.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close() //nolint:errcheck // read-only
+	for rows.Next() {
+		var k, v string
+		if err := rows.Scan(&k, &v); err != nil {
+			return err
+		}
+		if b.Metadata == nil {
+			b.Metadata = make(map[string]string)
+		}
+		b.Metadata[k] = v
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	crows, err := s.db.Query(`SELECT author, text, created_at FROM comments WHERE bead_id = ? ORDER BY seq`, b.ID)
+	if err != nil {
+		return err
+	}
+	defer crows.Close() //nolint:errcheck // read-only
+	for crows.Next() {
+		var c Comment
+		var created string
+		if err := crows.Scan(&c.Author, &c.Text, &created); err != nil {
+			return err
+		}
+		if c.CreatedAt, err = time.Parse(time.RFC3339Nano, created); err != nil {
+			return fmt.Errorf("parsing comment created_at %q: %w", created, err)
+		}
+		b.Comments = append(b.Comments, c)
+	}
+	if err := crows.Err(); err != nil {
+		return err
+	}
+
+	arows, err := s.db.Query(`SELECT kind, ref, author, created_at FROM attachments WHERE bead_id = ? ORDER BY seq`, b.ID)
+	if err != nil {
+		return err
+	}
+	defer arows.Close() //nolint:errcheck // read-only
+	for arows.Next() {
+		a, err := scanAttachment(arows)
+		if err != nil {
+			return err
+		}
+		b.Attachments = append(b.Attachments, a)
+	}
+	if err := arows.Err(); err != nil {
+		return err
+	}
+
+	trows, err := s.db.Query(`SELECT at, actor, field, from_value, to_value FROM transitions WHERE bead_id = ? ORDER BY seq`, b.ID)
+	if err != nil {
+		return err
+	}
+	defer trows.Close() //nolint:errcheck // read-only
+	for trows.Next() {
+		var tr Transition
+		var at string
+		if err := trows.Scan(&at, &tr.Actor, &tr.Field, &tr.From, &tr.To); err != nil {
+			return err
+		}
+		if tr.At, err = time.Parse(time.RFC3339Nano, at); err != nil {
+			return fmt.Errorf("parsing transition at %q: %w", at, err)
+		}
+		b.Events = append(b.Events, tr)
+	}
+	return trows.Err()
+}
+
+// sqlDue renders a due date for the due_at column; nil is NULL.
+func sqlTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// withTx runs fn inside a transaction, committing on success.
+func (s *SQLiteStore) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback() //nolint:errcheck // already failing
+		return err
+	}
+	return tx.Commit()
+}
+
+// writeLabels replaces the stored labels for a bead.
+func writeLabels(tx *sql.Tx, id string, labels []string) error {
+	if _, err := tx.Exec(`DELETE FROM labels WHERE bead_id = ?`, id); err != nil {
+		return err
+	}
+	for i, l := range labels {
+		if _, err := tx.Exec(`INSERT INTO labels (bead_id, pos, label) VALUES (?, ?, ?)`, id, i, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMetadata upserts metadata pairs for a bead.
+func writeMetadata(tx *sql.Tx, id string, kvs map[string]string) error {
+	for k, v := range kvs {
+		if _, err := tx.Exec(`INSERT INTO metadata (bead_id, key, value) VALUES (?, ?, ?)
+			ON CONFLICT(bead_id, key) DO UPDATE SET value = excluded.value`, id, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTransitions appends transition events for a bead.
+func writeTransitions(tx *sql.Tx, id string, ts []Transition) error {
+	for _, tr := range ts {
+		if _, err := tx.Exec(`INSERT INTO transitions (bead_id, at, actor, field, from_value, to_value) VALUES (?, ?, ?, ?, ?, ?)`,
+			id, tr.At.UTC().Format(time.RFC3339Nano), tr.Actor, tr.Field, tr.From, tr.To); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// currentState reads the status, assignee, and version of a bead inside
+// tx: the "before" values of any transition and the version an update is
+// checked against. Returns ErrNotFound if id is not in the store.
+func currentState(tx *sql.Tx, id string) (Bead, error) {
+	b := Bead{ID: id}
+	err := tx.QueryRow(`SELECT status, assignee, version FROM beads WHERE id = ?`, id).Scan(&b.Status, &b.Assignee, &b.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Bead{}, ErrNotFound
+	}
+	return b, err
+}
+
+// bumpVersion increments a bead's version inside tx.
+func bumpVersion(tx *sql.Tx, id string) error {
+	_, err := tx.Exec(`UPDATE beads SET version = version + 1 WHERE id = ?`, id)
+	return err
+}
+
+// requireBead returns a wrapped ErrNotFound if id is not in the store.
+func requireBead(tx *sql.Tx, id string) error {
+	var one int
+	err := tx.QueryRow(`SELECT 1 FROM beads WHERE id = ?`, id).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Create persists a new bead with an ID from the store's [IDScheme].
+func (s *SQLiteStore) Create(b Bead) (Bead, error) {
+	b.Status = "open"
+	if b.Type == "" {
+		b.Type = "task"
+	}
+	b.CreatedAt = time.Now()
+	b.Events = transitions(Bead{}, &b.Status, &b.Assignee, b.CreatedAt)
+	b.Version = 1
+	needs, err := json.Marshal(b.Needs)
+	if err != nil {
+		return Bead{}, fmt.Errorf("creating bead: %w", err)
+	}
+	err = s.withTx(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`INSERT INTO beads (title, status, type, created_at, assignee, from_agent, parent_id, ref, needs, description, priority, due_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			b.Title, b.Status, b.Type, b.CreatedAt.Format(time.RFC3339Nano),
+			b.Assignee, b.From, b.ParentID, b.Ref, string(needs), b.Description, b.Priority, sqlTime(b.DueAt))
+		if err != nil {
+			return err
+		}
+		seq, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		b.ID = s.ids.ID(int(seq), b.CreatedAt)
+		if _, err := tx.Exec(`UPDATE beads SET id = ? WHERE seq = ?`, b.ID, seq); err != nil {
+			return err
+		}
+		if err := writeLabels(tx, b.ID, b.Labels); err != nil {
+			return err
+		}
+		if err := writeTransitions(tx, b.ID, b.Events); err != nil {
+			return err
+		}
+		return writeMetadata(tx, b.ID, b.Metadata)
+	})
+	if err != nil {
+		return Bead{}, fmt.Errorf("creating bead: %w", err)
+	}
+	return cloneBead(b), nil
+}
+
+// Get retrieves a bead by ID. Returns a wrapped ErrNotFound if the ID does
+// not exist.
+func (s *SQLiteStore) Get(id string) (Bead, error) {
+	b, err := scanBead(s.db.QueryRow("SELECT "+beadColumns+" FROM beads WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Bead{}, fmt.Errorf("getting bead %q: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return Bead{}, fmt.Errorf("getting bead %q: %w", id, err)
+	}
+	if err := s.hydrate(&b); err != nil {
+		return Bead{}, fmt.Errorf("getting bead %q: %w", id, err)
+	}
+	return b, nil
+}
+
+// Update modifies fields of an existing bead. Only non-nil fields in opts
+// are applied. Returns a wrapped ErrNotFound if the ID does not exist, or
+// a wrapped ErrConflict if opts.ExpectVersion is stale.
+func (s *SQLiteStore) Update(id string, opts UpdateOpts) error {
+	err := s.withTx(func(tx *sql.Tx) error {
+		before, err := currentState(tx, id)
+		if err != nil {
+			return err
+		}
+		if opts.ExpectVersion != nil && *opts.ExpectVersion != before.Version {
+			return fmt.Errorf("at version %d, expected %d: %w", before.Version, *opts.ExpectVersion, ErrConflict)
+		}
+		if err := bumpVersion(tx, id); err != nil {
+			return err
+		}
+		if err := writeTransitions(tx, id, transitions(before, opts.Status, opts.Assignee, time.Now())); err != nil {
+			return err
+		}
+		if opts.Status != nil {
+			now := time.Now()
+			// Runs before the status column changes so a bead that is
+			// already closed keeps its original close time.
+			if _, err := tx.Exec(`UPDATE beads SET closed_at = CASE
+				WHEN ? != 'closed' THEN NULL
+				WHEN status = 'closed' THEN closed_at
+				ELSE ? END WHERE id = ?`, *opts.Status, sqlTime(&now), id); err != nil {
+				return err
+			}
+		}
+		set := map[string]*string{
+			"title":       opts.Title,
+			"status":      opts.Status,
+			"description": opts.Description,
+			"parent_id":   opts.ParentID,
+			"assignee":    opts.Assignee,
+		}
+		for col, v := range set {
+			if v == nil {
+				continue
+			}
+			if _, err := tx.Exec(`UPDATE beads SET `+col+` = ? WHERE id = ?`, *v, id); err != nil {
+				return err
+			}
+		}
+		if opts.Priority != nil {
+			if _, err := tx.Exec(`UPDATE beads SET priority = ? WHERE id = ?`, *opts.Priority, id); err != nil {
+				return err
+			}
+		}
+		if opts.DueAt != nil {
+			if _, err := tx.Exec(`UPDATE beads SET due_at = ? WHERE id = ?`, sqlTime(dueOrNil(*opts.DueAt)), id); err != nil {
+				return err
+			}
+		}
+		if len(opts.Labels) == 0 && len(opts.RemoveLabels) == 0 {
+			return nil
+		}
+		var labels []string
+		rows, err := tx.Query(`SELECT label FROM labels WHERE bead_id = ? ORDER BY pos`, id)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var l string
+			if err := rows.Scan(&l); err != nil {
+				rows.Close() //nolint:errcheck // already failing
+				return err
+			}
+			labels = append(labels, l)
+		}
+		rows.Close() //nolint:errcheck // read-only
+		labels = append(labels, opts.Labels...)
+		labels = slices.DeleteFunc(labels, func(l string) bool {
+			return slices.Contains(opts.RemoveLabels, l)
+		})
+		return writeLabels(tx, id, labels)
+	})
+	if err != nil {
+		return fmt.Errorf("updating bead %q: %w", id, err)
+	}
+	return nil
+}
+
+// Close sets a bead's status to "closed". Returns a wrapped ErrNotFound if
+// the ID does not exist. Closing an already-closed bead is a no-op.
+func (s *SQLiteStore) Close(id string) error {
+	now := time.Now()
+	err := s.withTx(func(tx *sql.Tx) error {
+		before, err := currentState(tx, id)
+		if err != nil {
+			return err
+		}
+		closed := "closed"
+		if err := writeTransitions(tx, id, transitions(before, &closed, nil, now)); err != nil {
+			return err
+		}
+		if before.Status == closed {
+			return nil
+		}
+		_, err = tx.Exec(`UPDATE beads SET status = 'closed', closed_at = ?, version = version + 1
+			WHERE id = ?`, sqlTime(&now), id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("closing bead %q: %w", id, err)
+	}
+	return nil
+}
+
+// Remove deletes the beads with the given IDs along with their labels,
+// metadata, comments, attachments, transitions, and the dependencies they
+// declare. Unknown IDs are ignored. Sequence numbers come from
+// AUTOINCREMENT, so removed IDs are never reissued.
+func (s *SQLiteStore) Remove(ids []string) (int, error) {
+	var n int
+	err := s.withTx(func(tx *sql.Tx) error {
+		for _, id := range ids {
+			res, err := tx.Exec(`DELETE FROM beads WHERE id = ?`, id)
+			if err != nil {
+				return err
+			}
+			removed, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if removed == 0 {
+				continue
+			}
+			n++
+			for _, q := range []string{
+				`DELETE FROM labels WHERE bead_id = ?`,
+				`DELETE FROM metadata WHERE bead_id = ?`,
+				`DELETE FROM deps WHERE issue_id = ?`,
+				`DELETE FROM comments WHERE bead_id = ?`,
+				`DELETE FROM attachments WHERE bead_id = ?`,
+				`DELETE FROM transitions WHERE bead_id = ?`,
+			} {
+				if _, err := tx.Exec(q, id); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("removing beads: %w", err)
+	}
+	return n, nil
+}
+
+// List returns all beads in creation order.
+func (s *SQLiteStore) List() ([]Bead, error) {
+	result, err := s.queryBeads(`ORDER BY seq`)
+	if err != nil {
+		return nil, fmt.Errorf("listing beads: %w", err)
+	}
+	return result, nil
+}
+
+// Ready returns all beads with status "open" that are not blocked by an
+// unclosed dependency, in priority order.
+func (s *SQLiteStore) Ready() ([]Bead, error) {
+	where, args := readyWhere()
+	result, err := s.queryBeads(where+` ORDER BY COALESCE(priority, `+strconv.Itoa(DefaultPriority)+`), seq`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing ready beads: %w", err)
+	}
+	return result, nil
+}
+
+// readyWhere returns the WHERE clause selecting open beads with no
+// unclosed blocking dependency, and its arguments.
+func readyWhere() (string, []any) {
+	types := make([]string, 0, len(blockingDepTypes)+1)
+	types = append(types, "")
+	for t := range blockingDepTypes {
+		types = append(types, t)
+	}
+	args := make([]any, len(types))
+	for i, t := range types {
+		args[i] = t
+	}
+	return `WHERE status = 'open' AND NOT EXISTS (
+		SELECT 1 FROM deps d JOIN beads blocker ON blocker.id = d.depends_on_id
+		WHERE d.issue_id = beads.id AND blocker.status != 'closed'
+		AND d.type IN (?` + strings.Repeat(", ?", len(types)-1) + `)
+	)`, args
+}
+
+// ClaimNext claims the most urgent unblocked open bead carrying label for
+// assignee with a single conditional UPDATE, so concurrent claimers cannot
+// both win the same row. Reports false when none is ready.
+func (s *SQLiteStore) ClaimNext(label, assignee string) (Bead, bool, error) {
+	where, args := readyWhere()
+	where += ` AND assignee = ''`
+	if label != "" {
+		where += ` AND id IN (SELECT bead_id FROM labels WHERE label = ?)`
+		args = append(args, label)
+	}
+	var id string
+	err := s.withTx(func(tx *sql.Tx) error {
+		err := tx.QueryRow(`UPDATE beads SET status = 'in_progress', assignee = ?, closed_at = NULL,
+			version = version + 1
+			WHERE id = (SELECT id FROM beads `+where+`
+				ORDER BY COALESCE(priority, `+strconv.Itoa(DefaultPriority)+`), seq LIMIT 1)
+			RETURNING id`, append([]any{assignee}, args...)...).Scan(&id)
+		if err != nil {
+			return err
+		}
+		status := "in_progress"
+		return writeTransitions(tx, id, transitions(Bead{Status: "open"}, &status, &assignee, time.Now()))
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Bead{}, false, nil
+	}
+	if err != nil {
+		return Bead{}, false, fmt.Errorf("claiming next bead: %w", err)
+	}
+	b, err := s.Get(id)
+	if err != nil {
+		return Bead{}, false, err
+	}
+	return b, true, nil
+}
+
+// Children returns all beads whose ParentID matches the given ID, in
+// creation order.
+func (s *SQLiteStore) Children(parentID string) ([]Bead, error) {
+	result, err := s.queryBeads(`WHERE parent_id = ? ORDER BY seq`, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("listing children of %q: %w", parentID, err)
+	}
+	return result, nil
+}
+
+// limitClause renders a LIMIT clause; 0 means unlimited.
+func limitClause(limit int) string {
+	if limit > 0 {
+		return fmt.Sprintf(" LIMIT %d", limit)
+	}
+	return ""
+}
+
+// ListByLabel returns beads matching an exact label string, newest first.
+// Limit controls max results (0 = unlimited).
+func (s *SQLiteStore) ListByLabel(label string, limit int) ([]Bead, error) {
+	result, err := s.queryBeads(`WHERE id IN (SELECT bead_id FROM labels WHERE label = ?)
+		ORDER BY seq DESC`+limitClause(limit), label)
+	if err != nil {
+		return nil, fmt.Errorf("listing beads by label %q: %w", label, err)
+	}
+	return result, nil
+}
+
+// ListByAssignee returns beads assigned to the given agent with the
+// specified status, newest first. Limit controls max results (0 = unlimited).
+func (s *SQLiteStore) ListByAssignee(assignee, status string, limit int) ([]Bead, error) {
+	result, err := s.queryBeads(`WHERE assignee = ? AND status = ?
+		ORDER BY seq DESC`+limitClause(limit), assignee, status)
+	if err != nil {
+		return nil, fmt.Errorf("listing beads for %q: %w", assignee, err)
+	}
+	return result, nil
+}
+
+// Query returns beads matching the filter. Selection runs in SQL against
+// the indexed columns; sorting and limit are applied by ApplyFilter.
+func (s *SQLiteStore) Query(f Filter) ([]Bead, error) {
+	var where []string
+	var args []any
+	for col, v := range map[string]string{"status": f.Status, "assignee": f.Assignee, "type": f.Type} {
+		if v != "" {
+			where = append(where, col+" = ?")
+			args = append(args, v)
+		}
+	}
+	if f.Label != "" {
+		where = append(where, "id IN (SELECT bead_id FROM labels WHERE label = ?)")
+		args = append(args, f.Label)
+	}
+	tail := "ORDER BY seq"
+	if len(where) > 0 {
+		tail = "WHERE " + strings.Join(where, " AND ") + " " + tail
+	}
+	result, err := s.queryBeads(tail, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying beads: %w", err)
+	}
+	return ApplyFilter(result, f), nil
+}
+
+// SetMetadata sets a key-value metadata pair on a bead. Returns a wrapped
+// ErrNotFound if the bead does not exist.
+func (s *SQLiteStore) SetMetadata(id, key, value string) error {
+	if err := s.setMetadata(id, map[string]string{key: value}); err != nil {
+		return fmt.Errorf("setting metadata on %q: %w", id, err)
+	}
+	return nil
+}
+
+// SetMetadataBatch atomically sets multiple key-value metadata pairs on a
+// bead in a single transaction.
+func (s *SQLiteStore) SetMetadataBatch(id string, kvs map[string]string) error {
+	if err := s.setMetadata(id, kvs); err != nil {
+		return fmt.Errorf("setting metadata batch on %q: %w", id, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) setMetadata(id string, kvs map[string]string) error {
+	return s.withTx(func(tx *sql.Tx) error {
+		if err := requireBead(tx, id); err != nil {
+			return err
+		}
+		if err := bumpVersion(tx, id); err != nil {
+			return err
+		}
+		return writeMetadata(tx, id, kvs)
+	})
+}
+
+// AddComment appends a comment to a bead's worklog. Returns a wrapped
+// ErrNotFound if the bead does not exist.
+func (s *SQLiteStore) AddComment(id string, c Comment) (Comment, error) {
+	c.CreatedAt = time.Now()
+	err := s.withTx(func(tx *sql.Tx) error {
+		if err := requireBead(tx, id); err != nil {
+			return err
+		}
+		if err := bumpVersion(tx, id); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`INSERT INTO comments (bead_id, author, text, created_at) VALUES (?, ?, ?, ?)`,
+			id, c.Author, c.Text, c.CreatedAt.Format(time.RFC3339Nano))
+		return err
+	})
+	if err != nil {
+		return Comment{}, fmt.Errorf("adding comment to %q: %w", id, err)
+	}
+	return c, nil
+}
+
+// Attach links an artifact to a bead. A Kind and Ref already attached
+// returns the existing attachment unchanged. Returns a wrapped
+// ErrNotFound if the bead does not exist.
+func (s *SQLiteStore) Attach(id string, a Attachment) (Attachment, error) {
+	a.CreatedAt = time.Now()
+	err := s.withTx(func(tx *sql.Tx) error {
+		if err := requireBead(tx, id); err != nil {
+			return err
+		}
+		res, err := tx.Exec(`INSERT OR IGNORE INTO attachments (bead_id, kind, ref, author, created_at) VALUES (?, ?, ?, ?, ?)`,
+			id, a.Kind, a.Ref, a.Author, a.CreatedAt.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			a, err = scanAttachment(tx.QueryRow(`SELECT kind, ref, author, created_at FROM attachments WHERE bead_id = ? AND kind = ? AND ref = ?`,
+				id, a.Kind, a.Ref))
+			return err
+		}
+		return bumpVersion(tx, id)
+	})
+	if err != nil {
+		return Attachment{}, fmt.Errorf("attaching to %q: %w", id, err)
+	}
+	return a, nil
+}
+
+// scanAttachment reads one attachments row (kind, ref, author, created_at).
+func scanAttachment(row interface{ Scan(...any) error }) (Attachment, error) {
+	var a Attachment
+	var created string
+	if err := row.Scan(&a.Kind, &a.Ref, &a.Author, &created); err != nil {
+		return Attachment{}, err
+	}
+	var err error
+	if a.CreatedAt, err = time.Parse(time.RFC3339Nano, created); err != nil {
+		return Attachment{}, fmt.Errorf("parsing attachment created_at %q: %w", created, err)
+	}
+	return a, nil
+}
+
+// Ping verifies the database is reachable.
+func (s *SQLiteStore) Ping() error {
+	if err := s.db.Ping(); err != nil {
+		return fmt.Errorf("sqlite store: %w", err)
+	}
+	return nil
+}
+
+// MolCook instantiates an ephemeral molecule (wisp) from a formula and
+// returns the root bead ID. Like MemStore, it creates a single bead with
+// Type "molecule" and the formula name as Ref.
+func (s *SQLiteStore) MolCook(formula, title string, _ []string) (string, error) {
+	if title == "" {
+		title = formula
+	}
+	b, err := s.Create(Bead{Title: title, Type: "molecule", Ref: formula})
+	if err != nil {
+		return "", fmt.Errorf("mol cook %q: %w", formula, err)
+	}
+	return b.ID, nil
+}
+
+// MolCookOn instantiates an ephemeral molecule attached to an existing bead.
+func (s *SQLiteStore) MolCookOn(formula, beadID, title string, _ []string) (string, error) {
+	if title == "" {
+		title = formula
+	}
+	b, err := s.Create(Bead{Title: title, Type: "molecule", Ref: formula, ParentID: beadID})
+	if err != nil {
+		return "", fmt.Errorf("mol cook --on %q: %w", formula, err)
+	}
+	return b.ID, nil
+}
+
+// DepAdd records a dependency: issueID depends on dependsOnID. Re-adding
+// an existing pair updates its type.
+func (s *SQLiteStore) DepAdd(issueID, dependsOnID, depType string) error {
+	_, err := s.db.Exec(`INSERT INTO deps (issue_id, depends_on_id, type) VALUES (?, ?, ?)
+		ON CONFLICT(issue_id, depends_on_id) DO UPDATE SET type = excluded.type`,
+		issueID, dependsOnID, depType)
+	if err != nil {
+		return fmt.Errorf("adding dep %s→%s: %w", issueID, dependsOnID, err)
+	}
+	return nil
+}
+
+// DepRemove removes a dependency between two beads. Removing a
+// nonexistent dependency is a no-op.
+func (s *SQLiteStore) DepRemove(issueID, dependsOnID string) error {
+	_, err := s.db.Exec(`DELETE FROM deps WHERE issue_id = ? AND depends_on_id = ?`, issueID, dependsOnID)
+	if err != nil {
+		return fmt.Errorf("removing dep %s→%s: %w", issueID, dependsOnID, err)
+	}
+	return nil
+}
+
+// DepList returns dependencies for a bead. Direction "down" (default)
+// returns what this bead depends on; "up" returns what depends on this bead.
+func (s *SQLiteStore) DepList(id, direction string) ([]Dep, error) {
+	col := "issue_id"
+	if direction == "up" {
+		col = "depends_on_id"
+	}
+	rows, err := s.db.Query(`SELECT issue_id, depends_on_id, type FROM deps WHERE `+col+` = ? ORDER BY rowid`, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing deps for %q: %w", id, err)
+	}
+	defer rows.Close() //nolint:errcheck // read-only
+	var result []Dep
+	for rows.Next() {
+		var d Dep
+		if err := rows.Scan(&d.IssueID, &d.DependsOnID, &d.Type); err != nil {
+			return nil, fmt.Errorf("listing deps for %q: %w", id, err)
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing deps for %q: %w", id, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,220 @@
+package beads_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/beads/beadstest"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func openTestSQLiteStore(t *testing.T, path string) *beads.SQLiteStore {
+	t.Helper()
+	s, err := beads.OpenSQLiteStore(fsys.OSFS{}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.CloseDB() }) //nolint:errcheck // test cleanup
+	return s
+}
+
+func TestSQLiteStore(t *testing.T) {
+	factory := func() beads.Store {
+		return openTestSQLiteStore(t, filepath.Join(t.TempDir(), "beads.db"))
+	}
+	beadstest.RunStoreTests(t, factory)
+	beadstest.RunSequentialIDTests(t, factory)
+	beadstest.RunCreationOrderTests(t, factory)
+	beadstest.RunDepTests(t, factory)
+	beadstest.RunMetadataTests(t, factory)
+	beadstest.RunCommentTests(t, factory)
+	beadstest.RunAttachmentTests(t, factory)
+	beadstest.RunPriorityTests(t, factory)
+	beadstest.RunDueTests(t, factory)
+	beadstest.RunClosedAtTests(t, factory)
+	beadstest.RunTransitionTests(t, factory)
+	beadstest.RunVersionTests(t, factory)
+	beadstest.RunClaimTests(t, factory)
+}
+
+func TestSQLiteStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "beads.db")
+
+	s1 := openTestSQLiteStore(t, path)
+	b, err := s1.Create(beads.Bead{
+		Title:    "persisted",
+		Labels:   []string{"a", "b"},
+		Needs:    []string{"step-1"},
+		Metadata: map[string]string{"k": "v"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s1.DepAdd(b.ID, "gc-99", "blocks"); err != nil {
+		t.Fatal(err)
+	}
+
+	s2 := openTestSQLiteStore(t, path)
+	got, err := s2.Get(b.ID)
+	if err != nil {
+		t.Fatalf("Get(%q) after reopen: %v", b.ID, err)
+	}
+	if got.Title != "persisted" || len(got.Labels) != 2 || got.Metadata["k"] != "v" {
+		t.Errorf("reopened bead = %+v, want title/labels/metadata preserved", got)
+	}
+	if len(got.Needs) != 1 || got.Needs[0] != "step-1" {
+		t.Errorf("Needs = %v, want [step-1]", got.Needs)
+	}
+	if !got.CreatedAt.Equal(b.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, b.CreatedAt)
+	}
+	deps, err := s2.DepList(b.ID, "down")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "gc-99" {
+		t.Errorf("DepList after reopen = %+v, want one dep on gc-99", deps)
+	}
+
+	// Sequence continues across opens.
+	b2, err := s2.Create(beads.Bead{Title: "second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b2.ID != "gc-2" {
+		t.Errorf("second ID = %q, want gc-2", b2.ID)
+	}
+}
+
+func TestSQLiteStoreUpdateLabels(t *testing.T) {
+	s := openTestSQLiteStore(t, filepath.Join(t.TempDir(), "beads.db"))
+	b, err := s.Create(beads.Bead{Title: "x", Labels: []string{"keep", "drop"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Update(b.ID, beads.UpdateOpts{Labels: []string{"new"}, RemoveLabels: []string{"drop"}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Get(b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Labels) != 2 || got.Labels[0] != "keep" || got.Labels[1] != "new" {
+		t.Errorf("Labels = %v, want [keep new]", got.Labels)
+	}
+}
+
+func TestSQLiteStoreConcurrentUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.db")
+	// Separate handles stand in for separate processes sharing the file.
+	stores := []*beads.SQLiteStore{openTestSQLiteStore(t, path), openTestSQLiteStore(t, path)}
+	b, err := stores[0].Create(beads.Bead{Title: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const perWorker = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*perWorker)
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			s := stores[w%len(stores)]
+			for i := 0; i < perWorker; i++ {
+				// Update reads the bead before writing, the pattern that
+				// fails with SQLITE_BUSY when the lock is taken lazily.
+				if err := s.Update(b.ID, beads.UpdateOpts{Labels: []string{fmt.Sprintf("w%d-%d", w, i)}}); err != nil {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent Update: %v", err)
+	}
+	got, err := stores[1].Get(b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Labels) != 4*perWorker {
+		t.Errorf("got %d labels, want %d", len(got.Labels), 4*perWorker)
+	}
+}
+
+func TestSQLiteStoreNotFound(t *testing.T) {
+	s := openTestSQLiteStore(t, filepath.Join(t.TempDir(), "beads.db"))
+	title := "x"
+	if err := s.Update("gc-404", beads.UpdateOpts{Title: &title}); !errors.Is(err, beads.ErrNotFound) {
+		t.Errorf("Update missing = %v, want ErrNotFound", err)
+	}
+	if err := s.SetMetadataBatch("gc-404", map[string]string{"k": "v"}); !errors.Is(err, beads.ErrNotFound) {
+		t.Errorf("SetMetadataBatch missing = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLiteStoreRemove(t *testing.T) {
+	s := openTestSQLiteStore(t, filepath.Join(t.TempDir(), "beads.db"))
+	a, _ := s.Create(beads.Bead{Title: "a"})
+	b, _ := s.Create(beads.Bead{Title: "b", Labels: []string{"x"}, Metadata: map[string]string{"k": "v"}})
+	if err := s.DepAdd(b.ID, a.ID, "blocks"); err != nil {
+		t.Fatal(err)
+	}
+	n, err := s.Remove([]string{b.ID, "gc-404"})
+	if err != nil || n != 1 {
+		t.Fatalf("Remove = %d, %v; want 1, nil", n, err)
+	}
+	if _, err := s.Get(b.ID); !errors.Is(err, beads.ErrNotFound) {
+		t.Errorf("Get(removed) err = %v, want ErrNotFound", err)
+	}
+	if deps, _ := s.DepList(b.ID, "down"); len(deps) != 0 {
+		t.Errorf("deps of removed bead = %v, want none", deps)
+	}
+	if got, _ := s.ListByLabel("x", 0); len(got) != 0 {
+		t.Errorf("ListByLabel(x) = %v, want none", got)
+	}
+	c, _ := s.Create(beads.Bead{Title: "c"})
+	if c.ID == b.ID {
+		t.Errorf("new bead reused removed ID %s", b.ID)
+	}
+}
+
+func TestSQLiteStoreMigratesOldSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "beads.db")
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Beads table as created before the priority column existed.
+	if _, err := db.Exec(`CREATE TABLE beads (
+		seq INTEGER PRIMARY KEY AUTOINCREMENT, id TEXT UNIQUE,
+		title TEXT NOT NULL DEFAULT '', status TEXT NOT NULL DEFAULT 'open',
+		type TEXT NOT NULL DEFAULT 'task', created_at TEXT NOT NULL,
+		assignee TEXT NOT NULL DEFAULT '', from_agent TEXT NOT NULL DEFAULT '',
+		parent_id TEXT NOT NULL DEFAULT '', ref TEXT NOT NULL DEFAULT '',
+		needs TEXT NOT NULL DEFAULT '[]', description TEXT NOT NULL DEFAULT '');
+		INSERT INTO beads (id, title, created_at) VALUES ('gc-1', 'legacy', '2026-01-01T00:00:00Z')`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close() //nolint:errcheck // test setup
+
+	s := openTestSQLiteStore(t, path)
+	got, err := s.Get("gc-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "legacy" || got.Priority != nil {
+		t.Errorf("legacy bead = %+v", got)
+	}
+	p := 1
+	if err := s.Update("gc-1", beads.UpdateOpts{Priority: &p}); err != nil {
+		t.Fatalf("Update priority on migrated store: %v", err)
+	}
+}
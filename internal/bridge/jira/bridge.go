@@ -0,0 +1,199 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// Bead metadata keys written by the bridge.
+const (
+	// KeyMeta holds the linked Jira issue key (e.g., "ENG-42"). Set it by
+	// hand to link a bead to an existing issue instead of creating one.
+	KeyMeta = "jira.key"
+	// URLMeta holds the browse URL of the linked issue.
+	URLMeta = "jira.url"
+	// StatusMeta holds the bead status last mirrored to Jira.
+	StatusMeta = "jira.status"
+)
+
+// Bridge mirrors labeled beads into a Jira project.
+type Bridge struct {
+	client      *Client
+	project     string
+	issueType   string
+	label       string
+	transitions map[string]string
+}
+
+// New returns a Bridge for cfg. Credentials are read through getenv from
+// the env vars cfg names; a missing token is an error.
+func New(cfg config.JiraBridgeConfig, getenv func(string) string) (*Bridge, error) {
+	if !cfg.Enabled() || cfg.Project == "" {
+		return nil, errors.New("[bridge.jira] requires base_url and project")
+	}
+	token := getenv(cfg.TokenEnvOrDefault())
+	if token == "" {
+		return nil, fmt.Errorf("[bridge.jira] $%s is not set", cfg.TokenEnvOrDefault())
+	}
+	return &Bridge{
+		client:      NewClient(cfg.BaseURL, getenv(cfg.UserEnvOrDefault()), token),
+		project:     cfg.Project,
+		issueType:   cfg.IssueTypeOrDefault(),
+		label:       cfg.LabelOrDefault(),
+		transitions: cfg.TransitionsOrDefault(),
+	}, nil
+}
+
+// Action describes one change the bridge made in Jira.
+type Action struct {
+	BeadID string
+	Key    string
+	// Detail is "created", "linked" (an existing issue for the bead was
+	// found), or the name of the applied transition.
+	Detail string
+}
+
+// Sync makes one pass over the beads in store carrying the bridge label.
+// Beads without a linked issue are linked to the issue the bridge already
+// created for them, if any, or get one created; beads whose status changed
+// since the last pass get the mapped transition applied. Progress is
+// recorded in bead metadata, so Sync is safe to call on every controller
+// tick. Request failures are returned joined and retried on the next pass.
+func (b *Bridge) Sync(ctx context.Context, store beads.Store) ([]Action, error) {
+	list, err := store.ListByLabel(b.label, 0)
+	if err != nil {
+		return nil, fmt.Errorf("listing beads labeled %q: %w", b.label, err)
+	}
+	var actions []Action
+	var errs []error
+	for _, bead := range list {
+		acts, err := b.syncBead(ctx, store, bead)
+		actions = append(actions, acts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bead %s: %w", bead.ID, err))
+		}
+	}
+	return actions, errors.Join(errs...)
+}
+
+// syncBead links one bead to a Jira issue (creating it if needed) and
+// mirrors its status.
+func (b *Bridge) syncBead(ctx context.Context, store beads.Store, bead beads.Bead) ([]Action, error) {
+	var actions []Action
+	key := bead.Metadata[KeyMeta]
+	if key == "" {
+		// An earlier pass may have created the issue and then failed to
+		// record its key; link that issue rather than creating another.
+		found, err := b.findIssue(ctx, bead.ID)
+		if err != nil {
+			return nil, err
+		}
+		detail := "linked"
+		if key = found; key == "" {
+			issue, err := b.client.CreateIssue(ctx, b.project, b.issueType, bead.Title, issueDescription(bead))
+			if err != nil {
+				return nil, err
+			}
+			key, detail = issue.Key, "created"
+		}
+		if err := store.SetMetadataBatch(bead.ID, map[string]string{
+			KeyMeta: key,
+			URLMeta: b.client.BrowseURL(key),
+		}); err != nil {
+			return nil, fmt.Errorf("recording %s: %w", key, err)
+		}
+		actions = append(actions, Action{BeadID: bead.ID, Key: key, Detail: detail})
+	}
+
+	if bead.Metadata[StatusMeta] == bead.Status {
+		return actions, nil
+	}
+	var unmatched error
+	if want := b.transitions[bead.Status]; want != "" {
+		applied, err := b.transition(ctx, key, want)
+		if err != nil && !errors.Is(err, errNoTransition) {
+			return actions, err
+		}
+		unmatched = err
+		if applied != "" {
+			actions = append(actions, Action{BeadID: bead.ID, Key: key, Detail: applied})
+		}
+	}
+	// Record the status even when no transition matched so the problem
+	// is reported once rather than on every pass.
+	if err := store.SetMetadata(bead.ID, StatusMeta, bead.Status); err != nil {
+		return actions, fmt.Errorf("recording status: %w", err)
+	}
+	return actions, unmatched
+}
+
+// errNoTransition reports that an issue offers no transition matching the
+// configured name. It is not retried: Jira workflows don't offer a
+// transition into the current status, so this usually means the issue was
+// already moved by hand.
+var errNoTransition = errors.New("no matching transition")
+
+// transition applies the transition named want (or leading to a status
+// named want) to key and returns its name.
+func (b *Bridge) transition(ctx context.Context, key, want string) (string, error) {
+	available, err := b.client.Transitions(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, t := range available {
+		if strings.EqualFold(t.Name, want) || strings.EqualFold(t.To.Name, want) {
+			if err := b.client.DoTransition(ctx, key, t.ID); err != nil {
+				return "", err
+			}
+			return t.Name, nil
+		}
+		names = append(names, t.Name)
+	}
+	return "", fmt.Errorf("%s: %w %q (available: %s)", key, errNoTransition, want, strings.Join(names, ", "))
+}
+
+// findIssue returns the key of an issue in the project that the bridge
+// created for beadID, or "" if there is none. The reference is searched
+// as a quoted phrase, but Jira's text search still matches loosely, so
+// candidates are confirmed against the exact reference line.
+func (b *Bridge) findIssue(ctx context.Context, beadID string) (string, error) {
+	ref := beadRef(beadID)
+	jql := fmt.Sprintf("project = %s AND description ~ %s ORDER BY created ASC", jqlQuote(b.project), jqlQuote(jqlQuote(ref)))
+	issues, err := b.client.SearchIssues(ctx, jql, 10)
+	if err != nil {
+		return "", err
+	}
+	for _, issue := range issues {
+		for _, line := range strings.Split(issue.Fields.Description, "\n") {
+			if strings.TrimSpace(line) == ref {
+				return issue.Key, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// jqlQuote quotes s as a JQL string literal.
+func jqlQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// beadRef is the line that ties a Jira issue to its bead.
+func beadRef(beadID string) string {
+	return "Gas City bead " + beadID
+}
+
+// issueDescription is the Jira description for a newly created issue.
+func issueDescription(bead beads.Bead) string {
+	ref := beadRef(bead.ID)
+	if bead.Description == "" {
+		return ref
+	}
+	return bead.Description + "\n\n" + ref
+}
@@ -0,0 +1,242 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// fakeJira serves the issue and transition endpoints the bridge uses.
+// Issues start in "To Do"; each status offers transitions to the others.
+type fakeJira struct {
+	mu       sync.Mutex
+	statuses map[string]string // key → status
+	created  []map[string]any
+	searches []string // JQL of each search
+	auth     string
+}
+
+// jqlRef pulls the phrase out of the bridge's description search.
+var jqlRef = regexp.MustCompile(`description ~ "\\"([^\\]*)\\""`)
+
+var jiraStatuses = map[string]string{"11": "To Do", "21": "In Progress", "31": "Done"}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = r.Header.Get("Authorization")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		if body.Fields["summary"] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":{"summary":"You must specify a summary of the issue."}}`)) //nolint:errcheck
+			return
+		}
+		f.created = append(f.created, body.Fields)
+		key := "ENG-" + string(rune('0'+len(f.created)))
+		f.statuses[key] = "To Do"
+		json.NewEncoder(w).Encode(Issue{ID: "100", Key: key}) //nolint:errcheck
+	case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/search":
+		f.searches = append(f.searches, r.URL.Query().Get("jql"))
+		ref := jqlRef.FindStringSubmatch(r.URL.Query().Get("jql"))
+		var issues []map[string]any
+		for i, fields := range f.created {
+			if desc, _ := fields["description"].(string); ref != nil && strings.Contains(desc, ref[1]) {
+				issues = append(issues, map[string]any{"key": "ENG-" + string(rune('1'+i)), "fields": map[string]any{"description": desc}})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"issues": issues}) //nolint:errcheck
+	case strings.HasSuffix(r.URL.Path, "/transitions"):
+		key := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/"), "/transitions")
+		if r.Method == http.MethodGet {
+			var ts []map[string]any
+			for id, to := range jiraStatuses {
+				if to != f.statuses[key] {
+					ts = append(ts, map[string]any{"id": id, "name": "Move to " + to, "to": map[string]string{"name": to}})
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"transitions": ts}) //nolint:errcheck
+			return
+		}
+		var body struct {
+			Transition struct{ ID string } `json:"transition"`
+		}
+		json.NewDecoder(r.Body).Decode(&body) //nolint:errcheck
+		f.statuses[key] = jiraStatuses[body.Transition.ID]
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestBridge(t *testing.T, env map[string]string) (*Bridge, *fakeJira) {
+	t.Helper()
+	fake := &fakeJira{statuses: map[string]string{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	b, err := New(config.JiraBridgeConfig{BaseURL: srv.URL + "/", Project: "ENG"}, func(k string) string { return env[k] })
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return b, fake
+}
+
+func TestSyncCreatesAndTransitions(t *testing.T) {
+	b, fake := newTestBridge(t, map[string]string{"JIRA_API_TOKEN": "secret"})
+	store := beads.NewMemStore()
+	tracked, _ := store.Create(beads.Bead{Title: "Fix login", Description: "Users can't log in", Labels: []string{"jira"}})
+	store.Create(beads.Bead{Title: "Internal chore"}) //nolint:errcheck
+
+	actions, err := b.Sync(context.Background(), store)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Detail != "created" || actions[0].Key != "ENG-1" {
+		t.Fatalf("actions = %+v", actions)
+	}
+	if fake.auth != "Bearer secret" {
+		t.Errorf("auth = %q, want bearer token", fake.auth)
+	}
+	if desc := fake.created[0]["description"]; desc != "Users can't log in\n\nGas City bead "+tracked.ID {
+		t.Errorf("description = %q", desc)
+	}
+	got, _ := store.Get(tracked.ID)
+	if got.Metadata[KeyMeta] != "ENG-1" || !strings.HasSuffix(got.Metadata[URLMeta], "/browse/ENG-1") {
+		t.Errorf("metadata = %v", got.Metadata)
+	}
+
+	// A second pass with no status change does nothing.
+	if actions, err = b.Sync(context.Background(), store); err != nil || len(actions) != 0 {
+		t.Errorf("idle sync = %+v, %v", actions, err)
+	}
+
+	inProgress := "in_progress"
+	store.Update(tracked.ID, beads.UpdateOpts{Status: &inProgress}) //nolint:errcheck
+	if actions, err = b.Sync(context.Background(), store); err != nil || len(actions) != 1 || actions[0].Detail != "Move to In Progress" {
+		t.Errorf("in_progress sync = %+v, %v", actions, err)
+	}
+	store.Close(tracked.ID) //nolint:errcheck
+	if _, err = b.Sync(context.Background(), store); err != nil {
+		t.Fatal(err)
+	}
+	if fake.statuses["ENG-1"] != "Done" {
+		t.Errorf("jira status = %q, want Done", fake.statuses["ENG-1"])
+	}
+}
+
+func TestSyncUnmatchedTransitionReportedOnce(t *testing.T) {
+	b, fake := newTestBridge(t, map[string]string{"JIRA_USER": "me@acme.com", "JIRA_API_TOKEN": "secret"})
+	store := beads.NewMemStore()
+	bead, _ := store.Create(beads.Bead{Title: "Linked", Labels: []string{"jira"}, Metadata: map[string]string{KeyMeta: "ENG-7"}})
+	fake.statuses["ENG-7"] = "Done" // moved by hand in Jira
+	store.Close(bead.ID)            //nolint:errcheck
+
+	_, err := b.Sync(context.Background(), store)
+	if err == nil || !strings.Contains(err.Error(), `no matching transition "Done"`) {
+		t.Errorf("err = %v, want unmatched transition", err)
+	}
+	if !strings.HasPrefix(fake.auth, "Basic ") {
+		t.Errorf("auth = %q, want basic auth", fake.auth)
+	}
+	if len(fake.created) != 0 {
+		t.Errorf("linked bead created a new issue: %+v", fake.created)
+	}
+	if _, err := b.Sync(context.Background(), store); err != nil {
+		t.Errorf("second sync err = %v, want none", err)
+	}
+}
+
+func TestSyncCreateErrorRetried(t *testing.T) {
+	b, fake := newTestBridge(t, map[string]string{"JIRA_API_TOKEN": "secret"})
+	store := beads.NewMemStore()
+	bead, _ := store.Create(beads.Bead{Labels: []string{"jira"}})
+
+	_, err := b.Sync(context.Background(), store)
+	if err == nil || !strings.Contains(err.Error(), "summary: You must specify a summary") {
+		t.Fatalf("err = %v, want Jira error message", err)
+	}
+	title := "Now titled"
+	store.Update(bead.ID, beads.UpdateOpts{Title: &title}) //nolint:errcheck
+	if _, err := b.Sync(context.Background(), store); err != nil || len(fake.created) != 1 {
+		t.Errorf("retry: err = %v, created = %d", err, len(fake.created))
+	}
+}
+
+// failingMetaStore fails SetMetadataBatch while fail is set.
+type failingMetaStore struct {
+	beads.Store
+	fail bool
+}
+
+func (s *failingMetaStore) SetMetadataBatch(id string, kvs map[string]string) error {
+	if s.fail {
+		return errors.New("disk full")
+	}
+	return s.Store.SetMetadataBatch(id, kvs)
+}
+
+func TestSyncLinksIssueWhoseKeyWasNotRecorded(t *testing.T) {
+	b, fake := newTestBridge(t, map[string]string{"JIRA_API_TOKEN": "secret"})
+	store := &failingMetaStore{Store: beads.NewMemStore(), fail: true}
+	other, _ := store.Create(beads.Bead{Title: "Other", Labels: []string{"jira"}})
+	bead, _ := store.Create(beads.Bead{Title: "Fix login", Labels: []string{"jira"}})
+
+	if _, err := b.Sync(context.Background(), store); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("err = %v, want recording failure", err)
+	}
+	if len(fake.created) != 2 {
+		t.Fatalf("created %d issues, want 2", len(fake.created))
+	}
+	want := `project = "ENG" AND description ~ "\"Gas City bead ` + bead.ID + `\"" ORDER BY created ASC`
+	if !slices.Contains(fake.searches, want) {
+		t.Errorf("searches = %q, want %q", fake.searches, want)
+	}
+
+	store.fail = false
+	actions, err := b.Sync(context.Background(), store)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if len(fake.created) != 2 {
+		t.Errorf("retry created a duplicate issue: %d created", len(fake.created))
+	}
+	if len(actions) != 2 || actions[0].Detail != "linked" || actions[1].Detail != "linked" {
+		t.Errorf("actions = %+v, want both linked", actions)
+	}
+	for i, fields := range fake.created {
+		if fields["description"] != "Gas City bead "+bead.ID {
+			continue
+		}
+		got, _ := store.Get(bead.ID)
+		if key := "ENG-" + string(rune('1'+i)); got.Metadata[KeyMeta] != key {
+			t.Errorf("%s linked to %q, want %s", bead.ID, got.Metadata[KeyMeta], key)
+		}
+	}
+	if got, _ := store.Get(other.ID); got.Metadata[KeyMeta] == "" {
+		t.Errorf("%s not linked", other.ID)
+	}
+}
+
+func TestNewRequiresToken(t *testing.T) {
+	cfg := config.JiraBridgeConfig{BaseURL: "https://acme.atlassian.net", Project: "ENG", TokenEnv: "ACME_JIRA"}
+	if _, err := New(cfg, func(string) string { return "" }); err == nil || !strings.Contains(err.Error(), "$ACME_JIRA is not set") {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := New(config.JiraBridgeConfig{BaseURL: "https://acme.atlassian.net"}, func(string) string { return "x" }); err == nil {
+		t.Error("missing project: want error")
+	}
+}
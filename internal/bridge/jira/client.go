@@ -0,0 +1,193 @@
+// Package jira mirrors beads into a Jira project. The bridge creates one
+// Jira issue per labeled bead, records the issue key in the bead's
+// metadata, and maps bead status changes onto Jira workflow transitions.
+// It talks to the Jira REST API (v2), which Jira Cloud and Data Center
+// both serve.
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client is a minimal Jira REST client covering issue creation and
+// search and workflow transitions.
+type Client struct {
+	baseURL string
+	user    string
+	token   string
+	http    *http.Client
+}
+
+// NewClient returns a Client for the Jira site at baseURL. When user is
+// non-empty requests use basic auth (Jira Cloud email + API token);
+// otherwise token is sent as a bearer token (Data Center personal access
+// token).
+func NewClient(baseURL, user, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		user:    user,
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Issue is the subset of a Jira issue the bridge uses. Fields is only
+// filled in by SearchIssues.
+type Issue struct {
+	ID     string `json:"id"`
+	Key    string `json:"key"`
+	Fields struct {
+		Description string `json:"description"`
+	} `json:"fields"`
+}
+
+// Transition is one workflow transition available on an issue.
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   struct {
+		Name string `json:"name"`
+	} `json:"to"`
+}
+
+// BrowseURL returns the human-facing URL for an issue key.
+func (c *Client) BrowseURL(key string) string {
+	return c.baseURL + "/browse/" + key
+}
+
+// CreateIssue creates an issue in project and returns its key.
+func (c *Client) CreateIssue(ctx context.Context, project, issueType, summary, description string) (Issue, error) {
+	body := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": project},
+			"issuetype":   map[string]string{"name": issueType},
+			"summary":     summary,
+			"description": description,
+		},
+	}
+	var issue Issue
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue", body, &issue); err != nil {
+		return Issue{}, fmt.Errorf("creating issue in %s: %w", project, err)
+	}
+	return issue, nil
+}
+
+// SearchIssues returns up to limit issues matching jql, with their
+// descriptions.
+func (c *Client) SearchIssues(ctx context.Context, jql string, limit int) ([]Issue, error) {
+	q := url.Values{}
+	q.Set("jql", jql)
+	q.Set("fields", "description")
+	q.Set("maxResults", strconv.Itoa(limit))
+	var resp struct {
+		Issues []Issue `json:"issues"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rest/api/2/search?"+q.Encode(), nil, &resp); err != nil {
+		return nil, fmt.Errorf("searching issues: %w", err)
+	}
+	return resp.Issues, nil
+}
+
+// Transitions lists the workflow transitions currently available on key.
+func (c *Client) Transitions(ctx context.Context, key string) ([]Transition, error) {
+	var resp struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rest/api/2/issue/"+key+"/transitions", nil, &resp); err != nil {
+		return nil, fmt.Errorf("listing transitions for %s: %w", key, err)
+	}
+	return resp.Transitions, nil
+}
+
+// DoTransition applies the transition with the given ID to key.
+func (c *Client) DoTransition(ctx context.Context, key, transitionID string) error {
+	body := map[string]any{"transition": map[string]string{"id": transitionID}}
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue/"+key+"/transitions", body, nil); err != nil {
+		return fmt.Errorf("transitioning %s: %w", key, err)
+	}
+	return nil
+}
+
+// do sends one JSON request and decodes the response into out (when
+// non-nil). Non-2xx responses become errors carrying Jira's message.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.token)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck // read-only body
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &HTTPError{Status: resp.StatusCode, Message: errorMessage(data)}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// HTTPError is a non-2xx response from Jira.
+type HTTPError struct {
+	Status  int
+	Message string
+}
+
+func (e *HTTPError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("jira: HTTP %d", e.Status)
+	}
+	return fmt.Sprintf("jira: HTTP %d: %s", e.Status, e.Message)
+}
+
+// errorMessage extracts Jira's errorMessages/errors from a response body,
+// falling back to the raw text.
+func errorMessage(data []byte) string {
+	var resp struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(data, &resp) != nil {
+		return strings.TrimSpace(string(data))
+	}
+	msgs := resp.ErrorMessages
+	for _, field := range slices.Sorted(maps.Keys(resp.Errors)) {
+		msgs = append(msgs, field+": "+resp.Errors[field])
+	}
+	return strings.Join(msgs, "; ")
+}
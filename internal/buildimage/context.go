@@ -36,6 +36,7 @@ type Manifest struct {
 
 // excludedPaths returns true for paths that should never be baked.
 func excludedPath(rel string) bool {
+	rel = filepath.ToSlash(rel)
 	if rel == citylayout.RuntimeRoot {
 		return false
 	}
@@ -69,7 +70,7 @@ func excludedPath(rel string) bool {
 	base := filepath.Base(rel)
 	ext := filepath.Ext(base)
 	if base == ".env" || base == "credentials.json" || base == "credentials.yaml" ||
-		base == "credentials.yml" || ext == ".secret" || ext == ".pem" || ext == ".key" {
+		base == "credentials.yml" || ext == ".secret" || ext == ".pem" || ext == ".key" || ext == ".env" {
 		return true
 	}
 	return false
@@ -221,6 +221,7 @@ func TestExcludedPath(t *testing.T) {
 		{".gc/scripts/setup.sh", false},
 		{".gc/settings.json", false},
 		{".env", true},
+		{".gc/secrets.env", true},
 		{"credentials.json", true},
 		{"path/to/secret.key", true},
 		{"city.toml", false},
@@ -32,6 +32,10 @@ const (
 	ScriptsRoot       = "scripts"
 	LegacyScriptsRoot = ".gc/scripts"
 
+	// RigsRoot holds per-rig config: rigs/<name>/rig.toml.
+	RigsRoot    = "rigs"
+	RigFileName = "rig.toml"
+
 	SystemRoot         = ".gc/system"
 	SystemPromptsRoot  = ".gc/system/prompts"
 	SystemFormulasRoot = ".gc/system/formulas"
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -24,6 +26,9 @@ type Provenance struct {
 	Rigs map[string]string
 	// Workspace maps workspace field name → source file path.
 	Workspace map[string]string
+	// IncludeDirs lists directories scanned for glob or directory
+	// includes, so new fragments there are picked up on reload.
+	IncludeDirs []string
 	// Warnings collects non-fatal collision warnings from composition.
 	Warnings []string
 }
@@ -60,33 +65,42 @@ func LoadWithIncludes(fs fsys.FS, path string, extraIncludes ...string) (*City,
 	includes = append(includes, extraIncludes...)
 	root.Include = origInclude
 
+	var fragPaths []string
 	for _, inc := range includes {
-		var fragPath string
 		if isRemoteInclude(inc) || isGitHubTreeURL(inc) {
 			resolved, err := resolvePackRef(inc, cityRoot, cityRoot)
 			if err != nil {
 				return nil, nil, fmt.Errorf("fetching include %q: %w", inc, err)
 			}
-			fragPath = resolved
-		} else {
-			fragPath = resolveConfigPath(inc, cityRoot, cityRoot)
+			fragPaths = append(fragPaths, resolved)
+			continue
+		}
+		paths, err := expandInclude(fs, inc, cityRoot, prov)
+		if err != nil {
+			return nil, nil, err
 		}
+		fragPaths = append(fragPaths, paths...)
+	}
 
+	for _, fragPath := range fragPaths {
 		fragData, err := fs.ReadFile(fragPath)
 		if err != nil {
-			return nil, nil, fmt.Errorf("loading fragment %q: %w", inc, err)
+			return nil, nil, fmt.Errorf("loading fragment %q: %w", fragPath, err)
 		}
 
 		frag, fragMeta, fragWarnings, err := parseWithMeta(fragData, fragPath)
 		if err != nil {
-			return nil, nil, fmt.Errorf("fragment %q: %w", inc, err)
+			return nil, nil, fmt.Errorf("fragment %q: %w", fragPath, err)
 		}
 		prov.Warnings = append(prov.Warnings, fragWarnings...)
 
 		// Fragments cannot include other fragments.
 		if len(frag.Include) > 0 {
 			return nil, nil, fmt.Errorf(
-				"fragment %q: includes are not allowed in fragments (no recursive includes)", inc)
+				"fragment %q: includes are not allowed in fragments (no recursive includes)", fragPath)
+		}
+		if err := checkFragmentDuplicates(frag, fragPath, prov); err != nil {
+			return nil, nil, err
 		}
 
 		// Adjust fragment agent paths to be city-root-relative.
@@ -98,7 +112,15 @@ func LoadWithIncludes(fs fsys.FS, path string, extraIncludes ...string) (*City,
 		prov.Sources = append(prov.Sources, fragPath)
 	}
 
+	// Merge rigs/<name>/rig.toml into their rigs before pack expansion.
+	if err := mergeRigFiles(fs, root, cityRoot, prov); err != nil {
+		return nil, nil, err
+	}
+
 	// Resolve named pack references to cache paths before any expansion.
+	if err := checkNamedPackVersions(fs, root, cityRoot); err != nil {
+		return nil, nil, err
+	}
 	resolveNamedPacks(root, cityRoot)
 
 	// Expand city packs before patches (so patches can target city-topo agents).
@@ -140,7 +162,10 @@ func LoadWithIncludes(fs fsys.FS, path string, extraIncludes ...string) (*City,
 				topoDir, _ := resolvePackRef(ref, cityRoot, cityRoot)
 				topoPath := filepath.Join(topoDir, packFile)
 				for _, a := range root.Agents {
-					if a.Dir == r.Name {
+					if a.Dir != r.Name {
+						continue
+					}
+					if _, tracked := prov.Agents[a.QualifiedName()]; !tracked {
 						prov.Agents[a.QualifiedName()] = topoPath
 					}
 				}
@@ -151,6 +176,11 @@ func LoadWithIncludes(fs fsys.FS, path string, extraIncludes ...string) (*City,
 	// Apply [global] sections from packs to agents in scope.
 	applyPackGlobals(root)
 
+	// Add drop-in provider definitions from providers.d directories.
+	if err := mergeProviderDirs(fs, root, cityRoot); err != nil {
+		return nil, nil, err
+	}
+
 	// Validate city-scoped pack requirements.
 	if err := validateCityRequirements(cityReqs, root.Agents); err != nil {
 		return nil, nil, err
@@ -177,6 +207,68 @@ func LoadWithIncludes(fs fsys.FS, path string, extraIncludes ...string) (*City,
 	return root, prov, nil
 }
 
+// expandInclude resolves one local include entry to fragment paths. An
+// entry ending in "/" or naming a directory includes every *.toml file in
+// it; an entry whose last element has glob metacharacters includes every
+// match. Matches are sorted so merge order is deterministic. Globs may
+// match nothing; plain files and directories must exist.
+func expandInclude(fs fsys.FS, inc, cityRoot string, prov *Provenance) ([]string, error) {
+	p := resolveConfigPath(inc, cityRoot, cityRoot)
+	dir, pattern := p, "*.toml"
+	glob := false
+	switch base := filepath.Base(p); {
+	case strings.HasSuffix(inc, "/"):
+	case strings.ContainsAny(base, "*?["):
+		dir, pattern, glob = filepath.Dir(p), base, true
+		if strings.ContainsAny(dir, "*?[") {
+			return nil, fmt.Errorf("include %q: only the last path element may contain a glob", inc)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("include %q: %w", inc, err)
+		}
+	default:
+		if fi, err := fs.Stat(p); err != nil || !fi.IsDir() {
+			return []string{p}, nil // plain file; a missing one fails on read
+		}
+	}
+
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		if glob && os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("include %q: %w", inc, err)
+	}
+	prov.IncludeDirs = append(prov.IncludeDirs, dir)
+	var paths []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, e.Name()); ok {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// checkFragmentDuplicates rejects agents and rigs a fragment redefines,
+// naming both source files.
+func checkFragmentDuplicates(frag *City, fragPath string, prov *Provenance) error {
+	for _, a := range frag.Agents {
+		if prev, ok := prov.Agents[a.QualifiedName()]; ok {
+			return fmt.Errorf("agent %q: duplicate name (defined in %s and %s)", a.QualifiedName(), prev, fragPath)
+		}
+	}
+	for _, r := range frag.Rigs {
+		if prev, ok := prov.Rigs[r.Name]; ok {
+			return fmt.Errorf("rig %q: duplicate name (defined in %s and %s)", r.Name, prev, fragPath)
+		}
+	}
+	return nil
+}
+
 // validateCityRequirements checks that all city-scoped pack requirements
 // are satisfied by the expanded agent list.
 func validateCityRequirements(reqs []PackRequirement, agents []Agent) error {
@@ -212,6 +304,18 @@ func mergeFragment(base, fragment *City, fragMeta toml.MetaData, fragPath string
 	// Services: concatenate.
 	base.Services = append(base.Services, fragment.Services...)
 
+	// Roles: concatenate.
+	base.Roles = append(base.Roles, fragment.Roles...)
+
+	// Routes: concatenate (fragment routes match after the base's).
+	base.Routes = append(base.Routes, fragment.Routes...)
+
+	// Schedules: concatenate.
+	base.Schedules = append(base.Schedules, fragment.Schedules...)
+
+	// Hooks: concatenate.
+	base.Hooks = append(base.Hooks, fragment.Hooks...)
+
 	// Providers: deep-merge per-field.
 	mergeProviders(base, fragment, fragMeta, fragPath, prov)
 
@@ -248,6 +352,9 @@ func mergeFragment(base, fragment *City, fragMeta toml.MetaData, fragPath string
 	if fragMeta.IsDefined("events") {
 		base.Events = fragment.Events
 	}
+	if fragMeta.IsDefined("prime") {
+		base.Prime = fragment.Prime
+	}
 	if fragMeta.IsDefined("automations") {
 		base.Automations = fragment.Automations
 	}
@@ -257,6 +364,12 @@ func mergeFragment(base, fragment *City, fragMeta toml.MetaData, fragPath string
 	if fragMeta.IsDefined("convergence") {
 		base.Convergence = fragment.Convergence
 	}
+	if fragMeta.IsDefined("rate_limit") {
+		base.RateLimit = fragment.RateLimit
+	}
+	if fragMeta.IsDefined("bridge") {
+		base.Bridge = fragment.Bridge
+	}
 	if fragMeta.IsDefined("agent_defaults") {
 		base.AgentDefaults = fragment.AgentDefaults
 	}
@@ -344,6 +457,11 @@ func deepMergeProvider(base, frag ProviderSpec, name string, fragMeta toml.MetaD
 			func() bool { return base.ReadyPromptPrefix != "" },
 			func() { result.ReadyPromptPrefix = frag.ReadyPromptPrefix },
 		},
+		{
+			"ready_probe",
+			func() bool { return base.ReadyProbe != "" },
+			func() { result.ReadyProbe = frag.ReadyProbe },
+		},
 		{
 			"emits_permission_warning",
 			func() bool { return base.EmitsPermissionWarning },
@@ -369,6 +487,14 @@ func deepMergeProvider(base, frag ProviderSpec, name string, fragMeta toml.MetaD
 		result.Args = make([]string, len(frag.Args))
 		copy(result.Args, frag.Args)
 	}
+	if fragMeta.IsDefined("providers", name, "print_args") {
+		if len(base.PrintArgs) > 0 {
+			prov.Warnings = append(prov.Warnings,
+				fmt.Sprintf("provider %q.print_args redefined by %q", name, fragPath))
+		}
+		result.PrintArgs = make([]string, len(frag.PrintArgs))
+		copy(result.PrintArgs, frag.PrintArgs)
+	}
 	if fragMeta.IsDefined("providers", name, "process_names") {
 		if len(base.ProcessNames) > 0 {
 			prov.Warnings = append(prov.Warnings,
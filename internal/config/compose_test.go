@@ -1117,6 +1117,14 @@ name = "mayor"
 		t.Fatal(err)
 	}
 
+	// The include tracks the default branch, so it must be locked first.
+	if _, _, err := LoadWithIncludes(fsys.OSFS{}, cityTomlPath); err == nil || !strings.Contains(err.Error(), "floating ref") {
+		t.Fatalf("LoadWithIncludes before lock: err = %v, want floating ref error", err)
+	}
+	if _, err := FetchRemoteIncludes(fsys.OSFS{}, cityTomlPath, true); err != nil {
+		t.Fatalf("FetchRemoteIncludes: %v", err)
+	}
+
 	cfg, _, err := LoadWithIncludes(fsys.OSFS{}, cityTomlPath)
 	if err != nil {
 		t.Fatalf("LoadWithIncludes with remote include: %v", err)
@@ -1216,3 +1224,73 @@ name = "coder"
 		}
 	}
 }
+
+func TestLoadWithIncludes_GlobAndDirectory(t *testing.T) {
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte(`
+include = ["agents/*.toml", "rigs.d/"]
+
+[workspace]
+name = "test"
+
+[[agent]]
+name = "mayor"
+`)
+	// Deliberately out of lexical order; merge order must be sorted.
+	fs.Files["/city/agents/b.toml"] = []byte("[[agent]]\nname = \"reviewer\"\n")
+	fs.Files["/city/agents/a.toml"] = []byte("[[agent]]\nname = \"coder\"\n")
+	fs.Files["/city/agents/notes.md"] = []byte("not config")
+	fs.Dirs["/city/rigs.d"] = true
+	fs.Files["/city/rigs.d/app.toml"] = []byte("[[rigs]]\nname = \"app\"\npath = \"/src/app\"\n")
+
+	cfg, prov, err := LoadWithIncludes(fs, "/city/city.toml")
+	if err != nil {
+		t.Fatalf("LoadWithIncludes: %v", err)
+	}
+	var names []string
+	for _, a := range explicitAgents(cfg.Agents) {
+		names = append(names, a.Name)
+	}
+	if strings.Join(names, ",") != "mayor,coder,reviewer" {
+		t.Errorf("agents = %v, want [mayor coder reviewer]", names)
+	}
+	if len(cfg.Rigs) != 1 || cfg.Rigs[0].Name != "app" {
+		t.Errorf("rigs = %+v", cfg.Rigs)
+	}
+	if prov.Agents["reviewer"] != "/city/agents/b.toml" || prov.Rigs["app"] != "/city/rigs.d/app.toml" {
+		t.Errorf("provenance agents=%v rigs=%v", prov.Agents, prov.Rigs)
+	}
+	if strings.Join(prov.IncludeDirs, ",") != "/city/agents,/city/rigs.d" {
+		t.Errorf("IncludeDirs = %v", prov.IncludeDirs)
+	}
+}
+
+func TestLoadWithIncludes_GlobMatchesNothing(t *testing.T) {
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte("include = [\"agents/*.toml\"]\n\n[workspace]\nname = \"test\"\n")
+	if _, _, err := LoadWithIncludes(fs, "/city/city.toml"); err != nil {
+		t.Errorf("empty glob: %v", err)
+	}
+}
+
+func TestLoadWithIncludes_DuplicateNamesAcrossFiles(t *testing.T) {
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte(`
+include = ["agents/*.toml"]
+
+[workspace]
+name = "test"
+`)
+	fs.Files["/city/agents/a.toml"] = []byte("[[agent]]\nname = \"coder\"\n")
+	fs.Files["/city/agents/b.toml"] = []byte("[[agent]]\nname = \"coder\"\n")
+
+	_, _, err := LoadWithIncludes(fs, "/city/city.toml")
+	if err == nil {
+		t.Fatal("expected duplicate-name error")
+	}
+	for _, want := range []string{`agent "coder": duplicate name`, "/city/agents/a.toml", "/city/agents/b.toml"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
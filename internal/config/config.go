@@ -42,7 +42,10 @@ func ParseQualifiedName(identity string) (dir, name string) {
 // Parsed from city.toml at the root of a city directory.
 type City struct {
 	// Include lists config fragment files to merge into this config.
-	// Processed by LoadWithIncludes; not recursive (fragments cannot include).
+	// Entries may be files, directories (every *.toml inside, e.g.
+	// "rigs.d/"), or globs in the last path element (e.g. "agents/*.toml");
+	// matches merge in sorted order. Processed by LoadWithIncludes; not
+	// recursive (fragments cannot include).
 	Include []string `toml:"include,omitempty"`
 	// Workspace holds city-level metadata (name, default provider).
 	Workspace Workspace `toml:"workspace"`
@@ -54,6 +57,17 @@ type City struct {
 	Agents []Agent `toml:"agent"`
 	// Rigs lists external projects registered in the city.
 	Rigs []Rig `toml:"rigs,omitempty"`
+	// Roles declares named agent templates for gc agent add --role.
+	Roles []Role `toml:"roles,omitempty"`
+	// Routes maps bead types, labels, and ID prefixes to sling targets
+	// for gc sling --auto. The first matching route wins.
+	Routes []Route `toml:"routes,omitempty"`
+	// Schedules declares recurring formula cooks run by the controller
+	// on a cron schedule.
+	Schedules []Schedule `toml:"schedules,omitempty"`
+	// Hooks declares shell commands run on lifecycle events (session
+	// start and stop, bead close, sling).
+	Hooks []LifecycleHook `toml:"hooks,omitempty"`
 	// Patches holds targeted modifications applied after fragment merge.
 	Patches Patches `toml:"patches,omitempty"`
 	// Beads configures the bead store backend.
@@ -64,6 +78,8 @@ type City struct {
 	Mail MailConfig `toml:"mail,omitempty"`
 	// Events configures the events provider backend.
 	Events EventsConfig `toml:"events,omitempty"`
+	// Prime configures what gc prime adds to an agent's prompt.
+	Prime PrimeConfig `toml:"prime,omitempty"`
 	// Dolt configures optional dolt server connection overrides.
 	Dolt DoltConfig `toml:"dolt,omitempty"`
 	// Formulas configures formula directory settings.
@@ -78,9 +94,14 @@ type City struct {
 	ChatSessions ChatSessionsConfig `toml:"chat_sessions,omitempty"`
 	// Convergence configures convergence loop limits.
 	Convergence ConvergenceConfig `toml:"convergence,omitempty"`
+	// RateLimit caps how often gc starts sessions and nudges agents
+	// city-wide. Per-agent limits are set with the agent's rate_limit.
+	RateLimit RateLimitConfig `toml:"rate_limit,omitempty"`
 	// Services declares workspace-owned HTTP services mounted on the
 	// controller edge under /svc/{name}.
 	Services []Service `toml:"service,omitempty"`
+	// Bridge configures bridges that mirror beads into external trackers.
+	Bridge BridgeConfig `toml:"bridge,omitempty"`
 	// AgentDefaults provides default values applied to all agents that
 	// don't override them. Useful for setting city-wide model, wake_mode,
 	// and overlay allowlists.
@@ -153,6 +174,23 @@ type Rig struct {
 	// invoked with only a bead ID (no explicit target). Resolved via
 	// resolveAgentIdentity. Example: "rig/polecat"
 	DefaultSlingTarget string `toml:"default_sling_target,omitempty"`
+	// Gates are shell commands gc bead close runs in the rig directory
+	// before closing any of the rig's beads (e.g., "go test ./...",
+	// "golangci-lint run"). Any failure blocks the close.
+	Gates []string `toml:"gates,omitempty"`
+	// RequireReview makes gc bead close refuse the rig's beads until a
+	// review requested with gc bead review request has been approved.
+	RequireReview bool `toml:"require_review,omitempty"`
+	// ReviewFormula is the formula gc bead review request slings to the
+	// reviewer, with the bead under review in var "issue". Default
+	// "mol-review".
+	ReviewFormula string `toml:"review_formula,omitempty"`
+	// IDFormat overrides [beads] id_format for the rig's beads under the
+	// file and sqlite providers.
+	IDFormat string `toml:"id_format,omitempty" jsonschema:"enum=counter,enum=ulid"`
+	// IDPad overrides [beads] id_pad for the rig's beads under the file
+	// and sqlite providers.
+	IDPad *int `toml:"id_pad,omitempty"`
 }
 
 // AgentOverride modifies a pack-stamped agent for a specific rig.
@@ -172,6 +210,10 @@ type AgentOverride struct {
 	Env map[string]string `toml:"env,omitempty"`
 	// EnvRemove lists env var keys to remove.
 	EnvRemove []string `toml:"env_remove,omitempty"`
+	// EnvFile overrides the agent's dotenv secrets file.
+	EnvFile *string `toml:"env_file,omitempty"`
+	// EnvFromKeychain overrides the env vars read from the OS keychain.
+	EnvFromKeychain []string `toml:"env_from_keychain,omitempty"`
 	// PreStart overrides the agent's pre_start commands.
 	PreStart []string `toml:"pre_start,omitempty"`
 	// PromptTemplate overrides the prompt template path.
@@ -179,6 +221,8 @@ type AgentOverride struct {
 	PromptTemplate *string `toml:"prompt_template,omitempty"`
 	// Session overrides the session transport ("acp").
 	Session *string `toml:"session,omitempty"`
+	// Host overrides the remote SSH host ("" runs locally).
+	Host *string `toml:"host,omitempty"`
 	// Provider overrides the provider name.
 	Provider *string `toml:"provider,omitempty"`
 	// StartCommand overrides the start command.
@@ -187,6 +231,8 @@ type AgentOverride struct {
 	Nudge *string `toml:"nudge,omitempty"`
 	// IdleTimeout overrides the idle timeout duration string (e.g., "30s", "5m", "1h").
 	IdleTimeout *string `toml:"idle_timeout,omitempty"`
+	// RateLimit overrides the agent's rate_limit ("count/period").
+	RateLimit *string `toml:"rate_limit,omitempty"`
 	// InstallAgentHooks overrides the agent's install_agent_hooks list.
 	InstallAgentHooks []string `toml:"install_agent_hooks,omitempty"`
 	// HooksInstalled overrides automatic hook detection.
@@ -204,6 +250,10 @@ type AgentOverride struct {
 	OverlayDir *string `toml:"overlay_dir,omitempty"`
 	// DefaultSlingFormula overrides the default sling formula.
 	DefaultSlingFormula *string `toml:"default_sling_formula,omitempty"`
+	// Capabilities overrides the agent's capabilities list.
+	Capabilities []string `toml:"capabilities,omitempty"`
+	// Gates overrides the agent's close gates.
+	Gates []string `toml:"gates,omitempty"`
 	// InjectFragments overrides the agent's inject_fragments list.
 	InjectFragments []string `toml:"inject_fragments,omitempty"`
 	// PreStartAppend appends commands to the agent's pre_start list
@@ -236,6 +286,9 @@ type PackSource struct {
 	Ref string `toml:"ref,omitempty"`
 	// Path is a subdirectory within the repo containing the pack files.
 	Path string `toml:"path,omitempty"`
+	// Version constrains the fetched pack's [pack] version, e.g. "^1.2"
+	// or ">=1.0, <2". Checked at load; gc pack upgrade rewrites it.
+	Version string `toml:"version,omitempty"`
 }
 
 // PackMeta holds metadata from a pack's [pack] header.
@@ -424,14 +477,33 @@ type Workspace struct {
 // BeadsConfig holds bead store settings.
 type BeadsConfig struct {
 	// Provider selects the bead store backend: "bd" (default), "file",
-	// or "exec:<script>" for a user-supplied script.
+	// "sqlite", or "exec:<script>" for a user-supplied script.
 	Provider string `toml:"provider,omitempty" jsonschema:"default=bd"`
+	// IDPrefix starts the IDs of city beads minted by the file and sqlite
+	// providers (bd keeps its own configured prefix). Rig beads use the
+	// rig's prefix. Default "gc".
+	IDPrefix string `toml:"id_prefix,omitempty" jsonschema:"default=gc"`
+	// IDFormat is how the file and sqlite providers mint bead IDs:
+	// "counter" (<prefix>-<n>) or "ulid" (<prefix>-<ulid>, unique across
+	// stores and sortable by creation time). Rigs may override it.
+	IDFormat string `toml:"id_format,omitempty" jsonschema:"enum=counter,enum=ulid,default=counter"`
+	// IDPad zero-pads counter IDs to this many digits so they sort as
+	// strings (e.g., 4 gives gc-0042). Rigs may override it.
+	IDPad int `toml:"id_pad,omitempty"`
+	// AgentBranches gives each agent a Dolt branch of the bd database:
+	// beads created or changed in an agent's session land on branch
+	// agent/<name> until merged into main, so they can be reviewed with
+	// gc beads branch diff. "manual" merges only with gc beads branch
+	// merge; "auto" also has the controller merge every changed branch
+	// each patrol. Only the bd provider supports it.
+	AgentBranches string `toml:"agent_branches,omitempty" jsonschema:"enum=manual,enum=auto"`
 }
 
 // SessionConfig holds session provider settings.
 type SessionConfig struct {
 	// Provider selects the session backend: "fake", "fail", "subprocess",
-	// "acp", "exec:<script>", "k8s", or "" (default: tmux).
+	// "acp", "exec:<script>", "k8s", or "" (default: tmux, or subprocess
+	// when tmux is not installed).
 	Provider string `toml:"provider,omitempty"`
 	// K8s holds Kubernetes-specific settings for the native K8s provider.
 	K8s K8sConfig `toml:"k8s,omitempty"`
@@ -455,6 +527,13 @@ type SessionConfig struct {
 	// DisplayMs is the default display duration in milliseconds for status messages.
 	// Defaults to 5000.
 	DisplayMs *int `toml:"display_ms,omitempty" jsonschema:"default=5000"`
+	// SnapshotLines is how many lines of pane output to save to
+	// .gc/crash/ when a session stops or crashes. 0 disables snapshots.
+	// Defaults to 200.
+	SnapshotLines *int `toml:"snapshot_lines,omitempty" jsonschema:"default=200"`
+	// SnapshotKeep is how many snapshots to keep per session; older ones
+	// are deleted. Defaults to 10.
+	SnapshotKeep *int `toml:"snapshot_keep,omitempty" jsonschema:"default=10"`
 	// StartupTimeout is how long to wait for each agent's Start() call before
 	// treating it as failed. Duration string (e.g., "60s", "2m"). Defaults to "60s".
 	StartupTimeout string `toml:"startup_timeout,omitempty" jsonschema:"default=60s"`
@@ -554,6 +633,24 @@ func (s *SessionConfig) DisplayMsOrDefault() int {
 	return *s.DisplayMs
 }
 
+// SnapshotLinesOrDefault returns the lines of output to snapshot when a
+// session stops. Defaults to 200 if nil.
+func (s *SessionConfig) SnapshotLinesOrDefault() int {
+	if s.SnapshotLines == nil {
+		return 200
+	}
+	return *s.SnapshotLines
+}
+
+// SnapshotKeepOrDefault returns the snapshots to keep per session.
+// Defaults to 10 if nil.
+func (s *SessionConfig) SnapshotKeepOrDefault() int {
+	if s.SnapshotKeep == nil {
+		return 10
+	}
+	return *s.SnapshotKeep
+}
+
 // ACPSessionConfig holds settings for the ACP session provider.
 type ACPSessionConfig struct {
 	// HandshakeTimeout is how long to wait for the ACP handshake to complete.
@@ -624,11 +721,61 @@ type K8sConfig struct {
 	Prebaked bool `toml:"prebaked,omitempty"`
 }
 
+// PrimeConfig holds gc prime settings.
+type PrimeConfig struct {
+	// Context appends the agent's claimed work, ready queue, unread mail,
+	// and recent events to every primed prompt, as if --with-context were
+	// always passed. Defaults to false.
+	Context bool `toml:"context,omitempty"`
+	// QueueLimit caps how many ready beads the context lists. Defaults to 5.
+	QueueLimit int `toml:"queue_limit,omitempty" jsonschema:"default=5"`
+	// EventLimit caps how many recent events the context lists. Defaults to 5.
+	EventLimit int `toml:"event_limit,omitempty" jsonschema:"default=5"`
+}
+
+// EffectiveQueueLimit returns QueueLimit, or 5 when unset.
+func (p PrimeConfig) EffectiveQueueLimit() int {
+	if p.QueueLimit > 0 {
+		return p.QueueLimit
+	}
+	return 5
+}
+
+// EffectiveEventLimit returns EventLimit, or 5 when unset.
+func (p PrimeConfig) EffectiveEventLimit() int {
+	if p.EventLimit > 0 {
+		return p.EventLimit
+	}
+	return 5
+}
+
 // MailConfig holds mail provider settings.
 type MailConfig struct {
-	// Provider selects the mail backend: "fake", "fail",
-	// "exec:<script>", or "" (default: beadmail).
+	// Provider selects the mail backend: "file" (one JSON file per
+	// message under .gc/mail/), "fake", "fail", "exec:<script>", or ""
+	// (default: beadmail).
 	Provider string `toml:"provider,omitempty"`
+	// Notify nudges the recipient whenever mail is sent or replied to,
+	// as if --notify were always passed. Defaults to false.
+	Notify bool `toml:"notify,omitempty"`
+	// PollInterval is how often the controller asks an exec: mail
+	// bridge for unread messages, so mail arriving from outside gc
+	// (Slack, email) nudges its recipient. Duration string. "0s"
+	// disables polling. Defaults to "1m". Ignored for other providers.
+	PollInterval string `toml:"poll_interval,omitempty" jsonschema:"default=1m"`
+}
+
+// PollIntervalDuration returns the bridge poll interval as a
+// time.Duration. Defaults to 1m if empty or unparseable. Zero disables.
+func (m *MailConfig) PollIntervalDuration() time.Duration {
+	if m.PollInterval == "" {
+		return time.Minute
+	}
+	dur, err := time.ParseDuration(m.PollInterval)
+	if err != nil {
+		return time.Minute
+	}
+	return dur
 }
 
 // EventsConfig holds events provider settings.
@@ -638,6 +785,85 @@ type EventsConfig struct {
 	Provider string `toml:"provider,omitempty"`
 }
 
+// BridgeConfig holds external tracker bridge settings.
+type BridgeConfig struct {
+	// Jira mirrors labeled beads into a Jira project.
+	Jira JiraBridgeConfig `toml:"jira,omitempty"`
+}
+
+// JiraBridgeConfig configures the Jira bridge. Progressive activation:
+// absent or empty base_url = bridge disabled. The controller creates a
+// Jira issue for each city bead carrying Label, records the issue key in
+// the bead's "jira.key" metadata, and applies Transitions as the bead's
+// status changes.
+type JiraBridgeConfig struct {
+	// BaseURL is the Jira site URL (e.g., "https://acme.atlassian.net").
+	BaseURL string `toml:"base_url,omitempty"`
+	// Project is the key of the Jira project issues are created in (e.g., "ENG").
+	Project string `toml:"project,omitempty"`
+	// IssueType is the Jira issue type for created issues. Defaults to "Task".
+	IssueType string `toml:"issue_type,omitempty" jsonschema:"default=Task"`
+	// Label selects which beads are mirrored. Defaults to "jira".
+	Label string `toml:"label,omitempty" jsonschema:"default=jira"`
+	// UserEnv names the env var holding the Jira account email. When the
+	// variable is empty the token is sent as a bearer token (Jira Data
+	// Center personal access tokens). Defaults to "JIRA_USER".
+	UserEnv string `toml:"user_env,omitempty" jsonschema:"default=JIRA_USER"`
+	// TokenEnv names the env var holding the Jira API token.
+	// Defaults to "JIRA_API_TOKEN".
+	TokenEnv string `toml:"token_env,omitempty" jsonschema:"default=JIRA_API_TOKEN"`
+	// Transitions maps bead status to the Jira workflow transition applied
+	// when a bead enters that status, matched by transition name or target
+	// status name. Defaults to in_progress = "In Progress", closed = "Done".
+	Transitions map[string]string `toml:"transitions,omitempty"`
+}
+
+// Enabled reports whether the Jira bridge is configured.
+func (j JiraBridgeConfig) Enabled() bool {
+	return j.BaseURL != ""
+}
+
+// IssueTypeOrDefault returns IssueType, defaulting to "Task".
+func (j JiraBridgeConfig) IssueTypeOrDefault() string {
+	if j.IssueType == "" {
+		return "Task"
+	}
+	return j.IssueType
+}
+
+// LabelOrDefault returns Label, defaulting to "jira".
+func (j JiraBridgeConfig) LabelOrDefault() string {
+	if j.Label == "" {
+		return "jira"
+	}
+	return j.Label
+}
+
+// UserEnvOrDefault returns UserEnv, defaulting to "JIRA_USER".
+func (j JiraBridgeConfig) UserEnvOrDefault() string {
+	if j.UserEnv == "" {
+		return "JIRA_USER"
+	}
+	return j.UserEnv
+}
+
+// TokenEnvOrDefault returns TokenEnv, defaulting to "JIRA_API_TOKEN".
+func (j JiraBridgeConfig) TokenEnvOrDefault() string {
+	if j.TokenEnv == "" {
+		return "JIRA_API_TOKEN"
+	}
+	return j.TokenEnv
+}
+
+// TransitionsOrDefault returns Transitions, defaulting to
+// in_progress → "In Progress" and closed → "Done".
+func (j JiraBridgeConfig) TransitionsOrDefault() map[string]string {
+	if len(j.Transitions) == 0 {
+		return map[string]string{"in_progress": "In Progress", "closed": "Done"}
+	}
+	return j.Transitions
+}
+
 // DoltConfig holds optional dolt server overrides.
 // When present in city.toml, these override the defaults.
 type DoltConfig struct {
@@ -794,9 +1020,13 @@ type DaemonConfig struct {
 	// Wisp GC is disabled unless both WispGCInterval and WispTTL are set.
 	WispGCInterval string `toml:"wisp_gc_interval,omitempty"`
 	// WispTTL is how long a closed molecule survives before being purged.
-	// Duration string (e.g., "24h", "7d"). Wisp GC is disabled unless both
+	// Duration string (e.g., "24h", "168h"). Wisp GC is disabled unless both
 	// WispGCInterval and WispTTL are set.
 	WispTTL string `toml:"wisp_ttl,omitempty"`
+	// WispArchive writes purged wisps to the bead archive
+	// (.gc/archive/beads-YYYYMM.json, as gc bead archive does) before wisp
+	// GC deletes them. Defaults to false: wisps are deleted outright.
+	WispArchive bool `toml:"wisp_archive,omitempty"`
 	// DriftDrainTimeout is the maximum time to wait for an agent to acknowledge
 	// a drain signal during a config-drift restart. If the agent doesn't ack
 	// within this window, the controller force-kills and restarts it.
@@ -811,6 +1041,29 @@ type DaemonConfig struct {
 	// dependency-aware wake ordering, config drift detection, and crash
 	// quarantine. When false (default), the legacy reconciler is used.
 	BeadReconciler bool `toml:"bead_reconciler,omitempty"`
+	// NudgeOverdue queues a nudge to a bead's assignee when the controller
+	// finds the bead past its due date. Overdue beads are always logged
+	// and recorded as bead.overdue events; this adds the nudge.
+	NudgeOverdue bool `toml:"nudge_overdue,omitempty"`
+	// HeartbeatTimeout is how long an agent that reports heartbeats (via
+	// "gc agent heartbeat") may go silent while its session is alive before
+	// the controller flags it as possibly hung. Agents that never report
+	// are not checked. Duration string (e.g., "5m"). "0s" disables.
+	// Defaults to "10m".
+	HeartbeatTimeout string `toml:"heartbeat_timeout,omitempty" jsonschema:"default=10m"`
+	// ClaimTTL is how long a bead may stay in_progress while its assignee
+	// has no running session, or a running session whose heartbeat is
+	// older than the TTL, before the controller treats the claim as stale
+	// (see ClaimAction). Assignees that are neither a session nor a
+	// configured agent (e.g. humans) are never judged. Duration string
+	// (e.g., "30m"). Empty (default) disables the check.
+	ClaimTTL string `toml:"claim_ttl,omitempty"`
+	// ClaimAction is what the controller does with a stale claim.
+	// "unclaim" (default) reopens the bead; pool work also loses its
+	// assignee so any pool member can claim it. "flag" leaves the bead
+	// alone and only reports it. Either way a bead.claim_stale event is
+	// recorded.
+	ClaimAction string `toml:"claim_action,omitempty" jsonschema:"enum=unclaim,enum=flag"`
 }
 
 // PatrolIntervalDuration returns the patrol interval as a time.Duration.
@@ -848,6 +1101,38 @@ func (d *DaemonConfig) RestartWindowDuration() time.Duration {
 	return dur
 }
 
+// HeartbeatTimeoutDuration returns the heartbeat timeout as a time.Duration.
+// Defaults to 10m if empty or unparseable. Zero disables the check.
+func (d *DaemonConfig) HeartbeatTimeoutDuration() time.Duration {
+	if d.HeartbeatTimeout == "" {
+		return 10 * time.Minute
+	}
+	dur, err := time.ParseDuration(d.HeartbeatTimeout)
+	if err != nil {
+		return 10 * time.Minute
+	}
+	return dur
+}
+
+// ClaimTTLDuration returns the stale-claim TTL as a time.Duration.
+// Returns 0 (disabled) if empty or unparseable.
+func (d *DaemonConfig) ClaimTTLDuration() time.Duration {
+	if d.ClaimTTL == "" {
+		return 0
+	}
+	dur, err := time.ParseDuration(d.ClaimTTL)
+	if err != nil {
+		return 0
+	}
+	return dur
+}
+
+// ClaimUnclaims reports whether stale claims are reopened rather than
+// only flagged. Unknown actions only flag.
+func (d *DaemonConfig) ClaimUnclaims() bool {
+	return d.ClaimAction == "" || d.ClaimAction == "unclaim"
+}
+
 // ShutdownTimeoutDuration returns the shutdown timeout as a time.Duration.
 // Defaults to 5s if empty or unparseable. Zero means immediate kill.
 func (d *DaemonConfig) ShutdownTimeoutDuration() time.Duration {
@@ -1009,26 +1294,47 @@ type Agent struct {
 	// "acp" uses the Agent Client Protocol (JSON-RPC over stdio).
 	// The agent's resolved provider must have supports_acp = true.
 	Session string `toml:"session,omitempty" jsonschema:"enum=acp"`
+	// Host runs this agent's tmux session on a remote machine over SSH
+	// (an ssh destination such as "user@build-box"). The remote host needs
+	// sshd and tmux; the work dir must exist at the same path there.
+	// Empty (default) runs the session locally.
+	Host string `toml:"host,omitempty"`
 	// Provider names the provider preset to use for this agent.
 	Provider string `toml:"provider,omitempty"`
 	// StartCommand overrides the provider's command for this agent.
 	StartCommand string `toml:"start_command,omitempty"`
 	// Args overrides the provider's default arguments.
 	Args []string `toml:"args,omitempty"`
-	// PromptMode controls how prompts are delivered: "arg", "flag", or "none".
-	PromptMode string `toml:"prompt_mode,omitempty" jsonschema:"enum=arg,enum=flag,enum=none,default=arg"`
+	// PromptMode controls how prompts are delivered: "arg", "flag", "stdin",
+	// or "none". "stdin" applies to headless runs only; interactive sessions
+	// treat it like "none".
+	PromptMode string `toml:"prompt_mode,omitempty" jsonschema:"enum=arg,enum=flag,enum=stdin,enum=none,default=arg"`
 	// PromptFlag is the CLI flag used to pass prompts when prompt_mode is "flag".
 	PromptFlag string `toml:"prompt_flag,omitempty"`
 	// ReadyDelayMs is milliseconds to wait after launch before considering the agent ready.
 	ReadyDelayMs *int `toml:"ready_delay_ms,omitempty" jsonschema:"minimum=0"`
 	// ReadyPromptPrefix is the string prefix that indicates the agent is ready for input.
 	ReadyPromptPrefix string `toml:"ready_prompt_prefix,omitempty"`
+	// ReadyProbe checks that the agent is accepting input: a regular
+	// expression matched against the session's recent output, or
+	// "exec:<command>" that must exit 0. Takes precedence over
+	// ready_prompt_prefix and ready_delay_ms.
+	ReadyProbe string `toml:"ready_probe,omitempty"`
 	// ProcessNames lists process names to look for when checking if the agent is running.
 	ProcessNames []string `toml:"process_names,omitempty"`
 	// EmitsPermissionWarning indicates whether the agent emits permission prompts that should be suppressed.
 	EmitsPermissionWarning *bool `toml:"emits_permission_warning,omitempty"`
 	// Env sets additional environment variables for the agent process.
 	Env map[string]string `toml:"env,omitempty"`
+	// EnvFile is a dotenv file (KEY=value lines) whose entries are added to
+	// the agent's environment at session start. Relative paths resolve
+	// against the city directory. Keeps API keys out of city.toml; values
+	// set in env take precedence.
+	EnvFile string `toml:"env_file,omitempty"`
+	// EnvFromKeychain lists env vars whose values are read from the OS
+	// keychain at session start (macOS Keychain via security, Linux Secret
+	// Service via secret-tool), using the var name as the item's service.
+	EnvFromKeychain []string `toml:"env_from_keychain,omitempty"`
 	// Pool configures elastic pool behavior. When set, the agent becomes a pool.
 	Pool *PoolConfig `toml:"pool,omitempty"`
 	// WorkQuery is the shell command to find available work for this agent.
@@ -1047,10 +1353,18 @@ type Agent struct {
 	// Default for pool agents: "bd update {} --add-label=pool:<qualified-name>".
 	// Pool agents must set both sling_query and work_query, or neither.
 	SlingQuery string `toml:"sling_query,omitempty"`
-	// IdleTimeout is the maximum time an agent session can be inactive before
-	// the controller kills and restarts it. Duration string (e.g., "15m", "1h").
+	// IdleTimeout is the maximum time an agent session can be inactive (no
+	// pane output and no new activity on its in-progress beads) before the
+	// controller acts. With [daemon] bead_reconciler, the session is drained
+	// and sleeps until work is assigned or a terminal attaches; the legacy
+	// reconciler kills and restarts it. Duration string (e.g., "15m", "1h").
 	// Empty (default) disables idle checking.
 	IdleTimeout string `toml:"idle_timeout,omitempty"`
+	// RateLimit caps how often gc starts or nudges this agent's sessions,
+	// pool instances included, as "count/period" (e.g., "4/m"). Applies
+	// on top of the city-wide [rate_limit]. Empty (default) means no
+	// per-agent limit.
+	RateLimit string `toml:"rate_limit,omitempty"`
 	// InstallAgentHooks overrides workspace-level install_agent_hooks for this agent.
 	// When set, replaces (not adds to) the workspace default.
 	InstallAgentHooks []string `toml:"install_agent_hooks,omitempty"`
@@ -1094,6 +1408,14 @@ type Agent struct {
 	// when beads are slung to this agent, unless --no-formula is set.
 	// Example: "mol-polecat-work"
 	DefaultSlingFormula string `toml:"default_sling_formula,omitempty"`
+	// Capabilities lists what this agent is good at (e.g., "go",
+	// "frontend", "docs"). gc sling --match routes a bead to the
+	// least-loaded agent that has every capability the bead requires.
+	Capabilities []string `toml:"capabilities,omitempty"`
+	// Gates are shell commands gc bead close runs in the rig directory
+	// before closing a bead assigned to this agent, after the rig's own
+	// gates (e.g., "go test ./..."). Any failure blocks the close.
+	Gates []string `toml:"gates,omitempty"`
 	// InjectFragments lists named template fragments to append to this agent's
 	// rendered prompt. Fragments come from shared template directories across
 	// all loaded packs. Each name must match a {{ define "name" }} block.
@@ -1358,10 +1680,10 @@ func ValidateAgents(agents []Agent) error {
 		}
 		// PromptMode enum.
 		switch a.PromptMode {
-		case "", "arg", "flag", "none":
+		case "", "arg", "flag", "stdin", "none":
 			// valid
 		default:
-			return fmt.Errorf("agent %q: prompt_mode must be \"arg\", \"flag\", \"none\", or empty, got %q", a.QualifiedName(), a.PromptMode)
+			return fmt.Errorf("agent %q: prompt_mode must be \"arg\", \"flag\", \"stdin\", \"none\", or empty, got %q", a.QualifiedName(), a.PromptMode)
 		}
 		// PromptFlag required when prompt_mode = "flag".
 		if a.PromptMode == "flag" && a.PromptFlag == "" {
@@ -1374,6 +1696,11 @@ func ValidateAgents(agents []Agent) error {
 		default:
 			return fmt.Errorf("agent %q: wake_mode must be \"resume\", \"fresh\", or empty, got %q", a.QualifiedName(), a.WakeMode)
 		}
+		for _, name := range a.EnvFromKeychain {
+			if name == "" || strings.ContainsAny(name, "= \t") {
+				return fmt.Errorf("agent %q: env_from_keychain entry %q is not a valid env var name", a.QualifiedName(), name)
+			}
+		}
 		if a.Pool != nil {
 			if a.Pool.Min < 0 {
 				return fmt.Errorf("agent %q: pool min must be >= 0", a.Name)
@@ -1489,6 +1816,13 @@ func ValidateRigs(rigs []Rig, cityName string) error {
 		}
 		seenNames[r.Name] = true
 
+		if r.IDFormat != "" && r.IDFormat != "counter" && r.IDFormat != "ulid" {
+			return fmt.Errorf("rig %q: id_format %q: want counter or ulid", r.Name, r.IDFormat)
+		}
+		if r.IDPad != nil && *r.IDPad < 0 {
+			return fmt.Errorf("rig %q: id_pad must be >= 0, got %d", r.Name, *r.IDPad)
+		}
+
 		prefix := r.EffectivePrefix()
 		if other, ok := seenPrefixes[prefix]; ok {
 			return fmt.Errorf("rig %q: prefix %q collides with %s", r.Name, prefix, other)
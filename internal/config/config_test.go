@@ -974,6 +974,29 @@ DEBUG = "1"
 	}
 }
 
+func TestParseAgentEnvSecrets(t *testing.T) {
+	data := []byte(`
+[workspace]
+name = "test"
+
+[[agent]]
+name = "worker"
+env_file = ".gc/secrets.env"
+env_from_keychain = ["ANTHROPIC_API_KEY"]
+`)
+	cfg, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	a := cfg.Agents[0]
+	if a.EnvFile != ".gc/secrets.env" {
+		t.Errorf("EnvFile = %q, want %q", a.EnvFile, ".gc/secrets.env")
+	}
+	if len(a.EnvFromKeychain) != 1 || a.EnvFromKeychain[0] != "ANTHROPIC_API_KEY" {
+		t.Errorf("EnvFromKeychain = %v, want [ANTHROPIC_API_KEY]", a.EnvFromKeychain)
+	}
+}
+
 // --- Pool-in-agent tests ---
 
 func TestParseAgentWithPool(t *testing.T) {
@@ -1523,6 +1546,15 @@ func TestValidateAgentsValid(t *testing.T) {
 	}
 }
 
+func TestValidateAgentsEnvFromKeychainName(t *testing.T) {
+	for _, name := range []string{"", "API KEY", "A=B"} {
+		err := ValidateAgents([]Agent{{Name: "worker", EnvFromKeychain: []string{name}}})
+		if err == nil || !strings.Contains(err.Error(), "not a valid env var name") {
+			t.Errorf("ValidateAgents(%q) = %v, want invalid env var name error", name, err)
+		}
+	}
+}
+
 func TestValidateAgentsMissingName(t *testing.T) {
 	agents := []Agent{{Pool: &PoolConfig{Min: 0, Max: 5}}}
 	err := ValidateAgents(agents)
@@ -1780,6 +1812,62 @@ func TestMarshalOmitsEmptyDaemonSection(t *testing.T) {
 	}
 }
 
+// --- Mail poll interval tests ---
+
+func TestMailPollIntervalDefault(t *testing.T) {
+	m := MailConfig{}
+	if got := m.PollIntervalDuration(); got != time.Minute {
+		t.Errorf("PollIntervalDuration() = %v, want 1m", got)
+	}
+}
+
+func TestMailPollIntervalZero(t *testing.T) {
+	m := MailConfig{PollInterval: "0s"}
+	if got := m.PollIntervalDuration(); got != 0 {
+		t.Errorf("PollIntervalDuration() = %v, want 0", got)
+	}
+}
+
+// --- HeartbeatTimeout tests ---
+
+func TestDaemonHeartbeatTimeoutDefault(t *testing.T) {
+	d := DaemonConfig{}
+	if got := d.HeartbeatTimeoutDuration(); got != 10*time.Minute {
+		t.Errorf("HeartbeatTimeoutDuration() = %v, want 10m", got)
+	}
+}
+
+func TestDaemonHeartbeatTimeoutZero(t *testing.T) {
+	d := DaemonConfig{HeartbeatTimeout: "0s"}
+	if got := d.HeartbeatTimeoutDuration(); got != 0 {
+		t.Errorf("HeartbeatTimeoutDuration() = %v, want 0", got)
+	}
+}
+
+// --- ClaimTTL tests ---
+
+func TestDaemonClaimTTL(t *testing.T) {
+	for _, tc := range []struct {
+		ttl  string
+		want time.Duration
+	}{
+		{"", 0},
+		{"30m", 30 * time.Minute},
+		{"soon", 0},
+	} {
+		d := DaemonConfig{ClaimTTL: tc.ttl}
+		if got := d.ClaimTTLDuration(); got != tc.want {
+			t.Errorf("ClaimTTLDuration(%q) = %v, want %v", tc.ttl, got, tc.want)
+		}
+	}
+	for action, want := range map[string]bool{"": true, "unclaim": true, "flag": false, "bogus": false} {
+		d := DaemonConfig{ClaimAction: action}
+		if got := d.ClaimUnclaims(); got != want {
+			t.Errorf("ClaimUnclaims(%q) = %v, want %v", action, got, want)
+		}
+	}
+}
+
 // --- ShutdownTimeout tests ---
 
 func TestDaemonShutdownTimeoutDefault(t *testing.T) {
@@ -2181,6 +2269,21 @@ func TestValidateRigs_DuplicateName(t *testing.T) {
 	}
 }
 
+func TestValidateRigs_IDScheme(t *testing.T) {
+	pad := -1
+	for _, r := range []Rig{
+		{Name: "frontend", Path: "/a", IDFormat: "uuid"},
+		{Name: "frontend", Path: "/a", IDPad: &pad},
+	} {
+		if err := ValidateRigs([]Rig{r}, "city"); err == nil {
+			t.Errorf("ValidateRigs(%+v): expected error", r)
+		}
+	}
+	if err := ValidateRigs([]Rig{{Name: "frontend", Path: "/a", IDFormat: "ulid"}}, "city"); err != nil {
+		t.Errorf("ValidateRigs(ulid): %v", err)
+	}
+}
+
 // Regression: Bug 3 — prefix collisions between rigs must be detected.
 func TestValidateRigs_PrefixCollision(t *testing.T) {
 	rigs := []Rig{
@@ -2945,6 +3048,24 @@ func TestSessionDisplayMsCustom(t *testing.T) {
 	}
 }
 
+func TestSessionSnapshotDefaults(t *testing.T) {
+	s := SessionConfig{}
+	if got := s.SnapshotLinesOrDefault(); got != 200 {
+		t.Errorf("SnapshotLinesOrDefault() = %d, want 200", got)
+	}
+	if got := s.SnapshotKeepOrDefault(); got != 10 {
+		t.Errorf("SnapshotKeepOrDefault() = %d, want 10", got)
+	}
+	lines, keep := 0, 3
+	s = SessionConfig{SnapshotLines: &lines, SnapshotKeep: &keep}
+	if got := s.SnapshotLinesOrDefault(); got != 0 {
+		t.Errorf("SnapshotLinesOrDefault() = %d, want 0", got)
+	}
+	if got := s.SnapshotKeepOrDefault(); got != 3 {
+		t.Errorf("SnapshotKeepOrDefault() = %d, want 3", got)
+	}
+}
+
 func TestSessionSocketDefault(t *testing.T) {
 	s := SessionConfig{}
 	if s.Socket != "" {
@@ -0,0 +1,620 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// GetValue returns the value of key in the TOML document data. Keys are
+// dotted paths through the City schema; entries in arrays of tables are
+// picked by name (or dir/name), so "agents.mayor.idle_timeout" is the
+// idle_timeout of the [[agent]] named mayor. Strings are returned bare,
+// other values as TOML. found is false when key is valid but not set.
+func GetValue(data []byte, key string) (value string, found bool, err error) {
+	p, err := resolveEditPath(key)
+	if err != nil {
+		return "", false, err
+	}
+	var doc map[string]any
+	if _, err := toml.Decode(string(data), &doc); err != nil {
+		return "", false, fmt.Errorf("parsing config: %w", err)
+	}
+	table, ok := walkTables(doc, p.tables)
+	if ok && p.array != "" {
+		entries, _ := table[p.array].([]map[string]any)
+		refs := make([]entryRef, len(entries))
+		for i, e := range entries {
+			refs[i].name, _ = e["name"].(string)
+			refs[i].dir, _ = e["dir"].(string)
+		}
+		i, err := pickEntry(p.array, p.elem, refs)
+		if err != nil {
+			return "", false, err
+		}
+		table, ok = walkTables(entries[i], p.within)
+	}
+	if !ok {
+		return "", false, nil
+	}
+	v, ok := table[p.key]
+	if !ok {
+		return "", false, nil
+	}
+	if s, isString := v.(string); isString {
+		return s, true, nil
+	}
+	value, err = encodeTOMLValue(v)
+	return value, true, err
+}
+
+// SetValue returns data with key (a GetValue path) set to value. Only the
+// key's own value is rewritten, or one line is added when the key is not
+// set yet, so comments, ordering, and formatting elsewhere survive. value
+// is converted to the field's type: strings are quoted, numbers and
+// booleans are checked, and lists take a TOML array literal. The result
+// must still parse as a City.
+func SetValue(data []byte, key, value string) ([]byte, error) {
+	p, err := resolveEditPath(key)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := encodeFieldValue(p.typ, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	doc, err := scanTOML(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+	out, err := doc.set(p, encoded)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Parse([]byte(out)); err != nil {
+		return nil, fmt.Errorf("setting %s: %w", key, err)
+	}
+	return []byte(out), nil
+}
+
+// editPath is a config key resolved against the City schema. When the
+// key runs through an array of tables, tables leads to the array, elem
+// selects the entry, and within is the table path below the entry.
+type editPath struct {
+	tables []string
+	array  string // array-of-tables key; "" if the path has none
+	elem   string
+	within []string
+	key    string
+	typ    reflect.Type
+}
+
+// table returns the full TOML path of the table holding the value.
+func (p editPath) table() []string {
+	if p.array == "" {
+		return p.tables
+	}
+	return append(append(append([]string{}, p.tables...), p.array), p.within...)
+}
+
+// resolveEditPath maps a dotted key onto the City schema. Segments match
+// TOML keys; an array of tables may also be named in the plural
+// ("agents" for [[agent]]).
+func resolveEditPath(key string) (editPath, error) {
+	segs := strings.Split(key, ".")
+	var p editPath
+	path := &p.tables
+	t := reflect.TypeOf(City{})
+	for i := 0; i < len(segs); i++ {
+		seg := segs[i]
+		name, next := seg, reflect.Type(nil)
+		switch t.Kind() {
+		case reflect.Struct:
+			var ok bool
+			if name, next, ok = tomlField(t, seg); !ok {
+				return editPath{}, fmt.Errorf("unknown config key %q", strings.Join(segs[:i+1], "."))
+			}
+		case reflect.Map:
+			next = indirectType(t.Elem())
+		}
+		if seg == "" || next == nil {
+			return editPath{}, fmt.Errorf("unknown config key %q", key)
+		}
+		if isArrayOfTables(next) {
+			if p.array != "" {
+				return editPath{}, fmt.Errorf("config key %q: nested arrays of tables are not supported", key)
+			}
+			if i+2 >= len(segs) {
+				return editPath{}, fmt.Errorf("config key %q: want %s.<name>.<key>", key, strings.Join(segs[:i+1], "."))
+			}
+			p.array, p.elem = name, segs[i+1]
+			path = &p.within
+			t = indirectType(next.Elem())
+			i++
+			continue
+		}
+		if i == len(segs)-1 {
+			if next.Kind() == reflect.Struct || next.Kind() == reflect.Map {
+				return editPath{}, fmt.Errorf("config key %q is a table; name a key inside it", key)
+			}
+			p.key, p.typ = name, next
+			return p, nil
+		}
+		*path = append(*path, name)
+		t = next
+	}
+	return editPath{}, fmt.Errorf("unknown config key %q", key)
+}
+
+// tomlField finds the field of struct t whose TOML key is seg (or, for an
+// array of tables, seg minus a trailing "s").
+func tomlField(t reflect.Type, seg string) (string, reflect.Type, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		ft := indirectType(f.Type)
+		if seg == name || (isArrayOfTables(ft) && seg == name+"s") {
+			return name, ft, true
+		}
+	}
+	return "", nil, false
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isArrayOfTables(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && indirectType(t.Elem()).Kind() == reflect.Struct
+}
+
+// entryRef identifies one entry of an array of tables.
+type entryRef struct{ name, dir string }
+
+// pickEntry returns the index of the entry sel names: by dir/name, or by
+// bare name when exactly one entry has it.
+func pickEntry(array, sel string, entries []entryRef) (int, error) {
+	found := -1
+	for i, e := range entries {
+		if e.dir != "" && e.dir+"/"+e.name == sel {
+			return i, nil
+		}
+		if e.name == sel {
+			if found >= 0 {
+				return 0, fmt.Errorf("%s %q is ambiguous; use dir/name", array, sel)
+			}
+			found = i
+		}
+	}
+	if found < 0 {
+		return 0, fmt.Errorf("no %s %q in config", array, sel)
+	}
+	return found, nil
+}
+
+// walkTables follows path through nested tables of a decoded document.
+func walkTables(m map[string]any, path []string) (map[string]any, bool) {
+	for _, k := range path {
+		next, ok := m[k].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		m = next
+	}
+	return m, true
+}
+
+// encodeFieldValue converts s to a TOML value of Go type t.
+func encodeFieldValue(t reflect.Type, s string) (string, error) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a boolean", s)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return "", fmt.Errorf("%q is not an integer", s)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return "", fmt.Errorf("%q is not a non-negative integer", s)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return "", fmt.Errorf("%q is not a number", s)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		holder := reflect.New(reflect.StructOf([]reflect.StructField{{
+			Name: "V", Type: t, Tag: `toml:"v"`,
+		}}))
+		if _, err := toml.Decode("v = "+s, holder.Interface()); err != nil {
+			return "", fmt.Errorf(`%q is not a TOML array of the right type (e.g. ["a", "b"])`, s)
+		}
+		v = holder.Elem().Field(0)
+	default:
+		return "", fmt.Errorf("values of type %s cannot be set", t)
+	}
+	return encodeTOMLValue(v.Interface())
+}
+
+// encodeTOMLValue renders v as the right-hand side of a TOML key/value.
+func encodeTOMLValue(v any) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]any{"v": v}); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(buf.String(), "v = "), "\n"), nil
+}
+
+// tomlDoc is a TOML document scanned just far enough to edit it in place:
+// where each table starts and where each value sits in src.
+type tomlDoc struct {
+	src      string
+	sections []tomlSection // [0] is the root table
+}
+
+// tomlSection is a table header and the key/values under it.
+type tomlSection struct {
+	path  []string // nil for the root table
+	array bool     // [[header]]
+	start int      // offset of the header line
+	body  int      // offset just past the header line
+	keys  []tomlKey
+}
+
+// tomlKey is one key/value line. Offsets index tomlDoc.src.
+type tomlKey struct {
+	path             []string // dotted key as written
+	line             int      // start of the line
+	valStart, valEnd int
+	lineEnd          int // just past the line the value ends on
+}
+
+// scanTOML splits src into sections and key/values. It checks structure
+// only; values are located, not decoded.
+func scanTOML(src string) (*tomlDoc, error) {
+	d := &tomlDoc{src: src, sections: []tomlSection{{}}}
+	for pos := 0; pos < len(src); {
+		line := pos
+		i := skipBlank(src, pos)
+		switch {
+		case i >= len(src) || src[i] == '\n' || src[i] == '\r' || src[i] == '#':
+			pos = nextLine(src, i)
+		case src[i] == '[':
+			array := strings.HasPrefix(src[i:], "[[")
+			closer := "]"
+			if array {
+				closer = "]]"
+				i++
+			}
+			path, j, err := scanKeyPath(src, i+1)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber(src, line), err)
+			}
+			j = skipBlank(src, j)
+			if !strings.HasPrefix(src[j:], closer) {
+				return nil, fmt.Errorf("line %d: malformed table header", lineNumber(src, line))
+			}
+			pos = nextLine(src, j+len(closer))
+			d.sections = append(d.sections, tomlSection{path: path, array: array, start: line, body: pos})
+		default:
+			path, j, err := scanKeyPath(src, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber(src, line), err)
+			}
+			j = skipBlank(src, j)
+			if j >= len(src) || src[j] != '=' {
+				return nil, fmt.Errorf("line %d: expected key = value", lineNumber(src, line))
+			}
+			vs := skipBlank(src, j+1)
+			ve, err := scanValue(src, vs)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber(src, line), err)
+			}
+			pos = nextLine(src, ve)
+			sec := &d.sections[len(d.sections)-1]
+			sec.keys = append(sec.keys, tomlKey{path: path, line: line, valStart: vs, valEnd: ve, lineEnd: pos})
+		}
+	}
+	return d, nil
+}
+
+// set returns the document with the value at p replaced by, or added as,
+// encoded.
+func (d *tomlDoc) set(p editPath, encoded string) (string, error) {
+	scope := make([]int, len(d.sections))
+	for i := range scope {
+		scope[i] = i
+	}
+	entry := -1
+	if p.array != "" {
+		var err error
+		if entry, scope, err = d.entryScope(p); err != nil {
+			return "", err
+		}
+	}
+
+	table := p.table()
+	want := append(append([]string{}, table...), p.key)
+	for _, si := range scope {
+		s := d.sections[si]
+		for _, k := range s.keys {
+			full := append(append([]string{}, s.path...), k.path...)
+			if slices.Equal(full, want) {
+				return d.src[:k.valStart] + encoded + d.src[k.valEnd:], nil
+			}
+			if len(full) < len(want) && slices.Equal(full, want[:len(full)]) {
+				return "", fmt.Errorf("%s is set inline as %s; edit it by hand", strings.Join(want, "."), strings.Join(full, "."))
+			}
+		}
+	}
+
+	// Not set yet: add it to its table, or failing that to the array
+	// entry as a dotted key, or in a new table at the end.
+	for _, si := range scope {
+		s := d.sections[si]
+		if !slices.Equal(s.path, table) || (s.array && si != entry) {
+			continue
+		}
+		return d.insertInto(si, renderKey([]string{p.key})+" = "+encoded), nil
+	}
+	if entry >= 0 {
+		dotted := append(append([]string{}, p.within...), p.key)
+		return d.insertInto(entry, renderKey(dotted)+" = "+encoded), nil
+	}
+	out := d.src
+	if out != "" && !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	if out != "" {
+		out += "\n"
+	}
+	return out + "[" + renderKey(table) + "]\n" + renderKey([]string{p.key}) + " = " + encoded + "\n", nil
+}
+
+// entryScope finds the [[array]] entry p names. It returns the entry's
+// section and the sections that belong to it (its header plus any
+// [array.sub] tables before the next entry or unrelated table).
+func (d *tomlDoc) entryScope(p editPath) (int, []int, error) {
+	arrayPath := append(append([]string{}, p.tables...), p.array)
+	var heads []int
+	var refs []entryRef
+	for i, s := range d.sections {
+		if !s.array || !slices.Equal(s.path, arrayPath) {
+			continue
+		}
+		var ref entryRef
+		for _, k := range s.keys {
+			if len(k.path) != 1 {
+				continue
+			}
+			switch k.path[0] {
+			case "name":
+				ref.name = d.decodeString(k)
+			case "dir":
+				ref.dir = d.decodeString(k)
+			}
+		}
+		heads = append(heads, i)
+		refs = append(refs, ref)
+	}
+	n, err := pickEntry(p.array, p.elem, refs)
+	if err != nil {
+		return 0, nil, err
+	}
+	entry := heads[n]
+	scope := []int{entry}
+	for i := entry + 1; i < len(d.sections); i++ {
+		s := d.sections[i]
+		if len(s.path) <= len(arrayPath) || !slices.Equal(s.path[:len(arrayPath)], arrayPath) {
+			break
+		}
+		scope = append(scope, i)
+	}
+	return entry, scope, nil
+}
+
+// decodeString returns the string value of k, or "" if it is not one.
+func (d *tomlDoc) decodeString(k tomlKey) string {
+	var v struct {
+		V string `toml:"v"`
+	}
+	if _, err := toml.Decode("v = "+d.src[k.valStart:k.valEnd], &v); err != nil {
+		return ""
+	}
+	return v.V
+}
+
+// insertInto adds line to section si after its last key/value, matching
+// that line's indentation, or right after the header when it has none.
+// A root table with no keys gets the line just above the first header.
+func (d *tomlDoc) insertInto(si int, line string) string {
+	s := d.sections[si]
+	pos, indent, suffix := s.body, "", ""
+	switch {
+	case len(s.keys) > 0:
+		last := s.keys[len(s.keys)-1]
+		pos = last.lineEnd
+		indent = d.src[last.line:skipBlank(d.src, last.line)]
+	case si == 0 && len(d.sections) > 1:
+		pos, suffix = d.sections[1].start, "\n"
+	}
+	prefix := ""
+	if pos > 0 && d.src[pos-1] != '\n' {
+		prefix = "\n"
+	}
+	return d.src[:pos] + prefix + indent + line + "\n" + suffix + d.src[pos:]
+}
+
+var bareKeyRE = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// renderKey renders a dotted key, quoting parts that are not bare keys.
+func renderKey(path []string) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		if bareKeyRE.MatchString(p) {
+			parts[i] = p
+		} else {
+			parts[i] = strconv.Quote(p)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// scanKeyPath reads a possibly dotted, possibly quoted key starting at i.
+func scanKeyPath(src string, i int) ([]string, int, error) {
+	var path []string
+	for {
+		i = skipBlank(src, i)
+		switch {
+		case i < len(src) && src[i] == '"':
+			end, err := scanValue(src, i)
+			if err != nil {
+				return nil, 0, err
+			}
+			part, err := strconv.Unquote(src[i:end])
+			if err != nil {
+				return nil, 0, fmt.Errorf("bad quoted key %s", src[i:end])
+			}
+			path, i = append(path, part), end
+		case i < len(src) && src[i] == '\'':
+			end, err := scanValue(src, i)
+			if err != nil {
+				return nil, 0, err
+			}
+			path, i = append(path, src[i+1:end-1]), end
+		default:
+			j := i
+			for j < len(src) && isBareKeyChar(src[j]) {
+				j++
+			}
+			if j == i {
+				return nil, 0, fmt.Errorf("expected a key")
+			}
+			path, i = append(path, src[i:j]), j
+		}
+		i = skipBlank(src, i)
+		if i >= len(src) || src[i] != '.' {
+			return path, i, nil
+		}
+		i++
+	}
+}
+
+// scanValue returns the offset just past the value starting at i:
+// strings of every kind, arrays and inline tables (which may span lines),
+// or a bare scalar ending before any comment.
+func scanValue(src string, i int) (int, error) {
+	rest := src[i:]
+	switch {
+	case strings.HasPrefix(rest, `"""`), strings.HasPrefix(rest, `'''`):
+		quote := rest[:3]
+		for j := i + 3; j < len(src); j++ {
+			if quote[0] == '"' && src[j] == '\\' {
+				j++
+				continue
+			}
+			if strings.HasPrefix(src[j:], quote) {
+				end := j + 3
+				for end < len(src) && end < j+5 && src[end] == quote[0] {
+					end++
+				}
+				return end, nil
+			}
+		}
+		return 0, fmt.Errorf("unterminated multi-line string")
+	case strings.HasPrefix(rest, `"`):
+		for j := i + 1; j < len(src) && src[j] != '\n'; j++ {
+			switch src[j] {
+			case '\\':
+				j++
+			case '"':
+				return j + 1, nil
+			}
+		}
+		return 0, fmt.Errorf("unterminated string")
+	case strings.HasPrefix(rest, "'"):
+		if end := strings.IndexAny(src[i+1:], "'\n"); end >= 0 && src[i+1+end] == '\'' {
+			return i + 1 + end + 1, nil
+		}
+		return 0, fmt.Errorf("unterminated string")
+	case strings.HasPrefix(rest, "["), strings.HasPrefix(rest, "{"):
+		depth := 0
+		for j := i; j < len(src); j++ {
+			switch src[j] {
+			case '"', '\'':
+				end, err := scanValue(src, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end - 1
+			case '#':
+				j = nextLine(src, j) - 1
+			case '[', '{':
+				depth++
+			case ']', '}':
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("unterminated %c", rest[0])
+	}
+	j := i
+	for j < len(src) && src[j] != '\n' && src[j] != '#' {
+		j++
+	}
+	for j > i && (src[j-1] == ' ' || src[j-1] == '\t' || src[j-1] == '\r') {
+		j--
+	}
+	if j == i {
+		return 0, fmt.Errorf("missing value")
+	}
+	return j, nil
+}
+
+func isBareKeyChar(c byte) bool {
+	return c == '_' || c == '-' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func skipBlank(src string, i int) int {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+func nextLine(src string, i int) int {
+	if j := strings.IndexByte(src[i:], '\n'); j >= 0 {
+		return i + j + 1
+	}
+	return len(src)
+}
+
+func lineNumber(src string, off int) int {
+	return strings.Count(src[:off], "\n") + 1
+}
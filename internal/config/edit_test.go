@@ -0,0 +1,147 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const editTestCity = `# Metro city — hand-tuned, keep the comments.
+[workspace]
+name = "metro"   # shown in gc status
+provider = "claude"
+
+[daemon]
+# how often the controller wakes up
+patrol_interval = "30s"
+
+[[agent]]
+name = "mayor"
+prompt_template = "prompts/mayor.md"
+
+[[agent]]
+name = "polecat"
+dir = "myrig"
+idle_timeout = "1h"
+[agent.pool]
+max = 3
+
+[[agent]]
+name = "polecat"
+dir = "otherrig"
+`
+
+func TestSetValuePreservesFormatting(t *testing.T) {
+	out, err := SetValue([]byte(editTestCity), "daemon.patrol_interval", "15s")
+	if err != nil {
+		t.Fatalf("SetValue: %v", err)
+	}
+	want := strings.Replace(editTestCity, `patrol_interval = "30s"`, `patrol_interval = "15s"`, 1)
+	if string(out) != want {
+		t.Errorf("SetValue changed more than the value:\n%s", out)
+	}
+
+	out, err = SetValue([]byte(editTestCity), "workspace.name", "gotham")
+	if err != nil {
+		t.Fatalf("SetValue: %v", err)
+	}
+	if !strings.Contains(string(out), `name = "gotham"   # shown in gc status`) {
+		t.Errorf("trailing comment lost:\n%s", out)
+	}
+}
+
+func TestSetValueAgents(t *testing.T) {
+	for _, tc := range []struct {
+		key, value, want string
+	}{
+		// New key appended after the entry's last key/value.
+		{"agents.mayor.idle_timeout", "30m", "prompt_template = \"prompts/mayor.md\"\nidle_timeout = \"30m\"\n\n[[agent]]\nname = \"polecat\""},
+		// Existing key in a rig agent picked by dir/name.
+		{"agents.myrig/polecat.idle_timeout", "2h", `idle_timeout = "2h"`},
+		// Key in an entry's sub-table.
+		{"agent.myrig/polecat.pool.max", "5", "[agent.pool]\nmax = 5\n"},
+		// Missing sub-table becomes a dotted key in the entry.
+		{"agents.otherrig/polecat.pool.max", "2", "dir = \"otherrig\"\npool.max = 2\n"},
+	} {
+		out, err := SetValue([]byte(editTestCity), tc.key, tc.value)
+		if err != nil {
+			t.Errorf("SetValue(%s): %v", tc.key, err)
+			continue
+		}
+		if !strings.Contains(string(out), tc.want) {
+			t.Errorf("SetValue(%s) missing %q:\n%s", tc.key, tc.want, out)
+		}
+		got, found, err := GetValue(out, tc.key)
+		if err != nil || !found || got != tc.value {
+			t.Errorf("GetValue(%s) after set = %q, %v, %v; want %q", tc.key, got, found, err, tc.value)
+		}
+	}
+}
+
+func TestSetValueNewTable(t *testing.T) {
+	out, err := SetValue([]byte(editTestCity), "mail.poll_interval", "1m")
+	if err != nil {
+		t.Fatalf("SetValue: %v", err)
+	}
+	if !strings.HasPrefix(string(out), editTestCity) || !strings.HasSuffix(string(out), "\n[mail]\npoll_interval = \"1m\"\n") {
+		t.Errorf("new table not appended:\n%s", out)
+	}
+}
+
+func TestSetValueErrors(t *testing.T) {
+	for _, tc := range []struct {
+		key, value, want string
+	}{
+		{"daemon.no_such_key", "1", "unknown config key"},
+		{"daemon", "1", "is a table"},
+		{"agents.mayor", "x", "want agents.<name>.<key>"},
+		{"agents.ghost.idle_timeout", "1m", `no agent "ghost"`},
+		{"agents.polecat.idle_timeout", "1m", "ambiguous"},
+		{"agents.mayor.suspended", "maybe", "not a boolean"},
+		{"agents.mayor.pool.max", "lots", "not an integer"},
+	} {
+		_, err := SetValue([]byte(editTestCity), tc.key, tc.value)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("SetValue(%s, %s) = %v, want error containing %q", tc.key, tc.value, err, tc.want)
+		}
+	}
+
+	inline := "[[agent]]\nname = \"mayor\"\npool = { max = 1 }\n"
+	if _, err := SetValue([]byte(inline), "agents.mayor.pool.max", "2"); err == nil || !strings.Contains(err.Error(), "inline") {
+		t.Errorf("SetValue into inline table = %v, want inline error", err)
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	for _, tc := range []struct {
+		key, want string
+		found     bool
+	}{
+		{"workspace.name", "metro", true},
+		{"agents.myrig/polecat.pool.max", "3", true},
+		{"agents.mayor.idle_timeout", "", false},
+		{"session.provider", "", false},
+	} {
+		got, found, err := GetValue([]byte(editTestCity), tc.key)
+		if err != nil || got != tc.want || found != tc.found {
+			t.Errorf("GetValue(%s) = %q, %v, %v; want %q, %v", tc.key, got, found, err, tc.want, tc.found)
+		}
+	}
+}
+
+func TestScanTOMLMultilineValues(t *testing.T) {
+	src := "include = [\n  \"a.toml\",  # first\n  \"[not] a header\",\n]\nnote = \"\"\"\n[also not]\n\"\"\"\n\n[workspace]\nname = \"x\"\n"
+	d, err := scanTOML(src)
+	if err != nil {
+		t.Fatalf("scanTOML: %v", err)
+	}
+	if len(d.sections) != 2 || len(d.sections[0].keys) != 2 {
+		t.Fatalf("sections = %+v, want root with 2 keys and [workspace]", d.sections)
+	}
+	out, err := SetValue([]byte(src), "include", `["b.toml"]`)
+	if err != nil {
+		t.Fatalf("SetValue: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "include = [\"b.toml\"]\nnote = ") {
+		t.Errorf("multi-line array not replaced whole:\n%s", out)
+	}
+}
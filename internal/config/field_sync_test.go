@@ -27,6 +27,7 @@ func TestAgentFieldSync(t *testing.T) {
 		"PromptFlag":             "provider field, set via ResolveProvider",
 		"ReadyDelayMs":           "provider field, set via ResolveProvider",
 		"ReadyPromptPrefix":      "provider field, set via ResolveProvider",
+		"ReadyProbe":             "provider field, set via ResolveProvider",
 		"ProcessNames":           "provider field, set via ResolveProvider",
 		"EmitsPermissionWarning": "provider field, set via ResolveProvider",
 		"WorkQuery":              "agent-specific, derived from name — not a patch concern",
@@ -125,10 +126,12 @@ func TestApplyAgentPatchCoversAllFields(t *testing.T) {
 		PreStart:                []string{"pre-cmd"},
 		PromptTemplate:          strVal("prompts/test.md"),
 		Session:                 strVal("acp"),
+		Host:                    strVal("dev@build-box"),
 		Provider:                strVal("claude"),
 		StartCommand:            strVal("claude --dangerously"),
 		Nudge:                   strVal("wake up"),
 		IdleTimeout:             strVal("15m"),
+		RateLimit:               strVal("4/m"),
 		InstallAgentHooks:       []string{"claude"},
 		HooksInstalled:          &trueVal,
 		SessionSetup:            []string{"setup-cmd"},
@@ -136,10 +139,14 @@ func TestApplyAgentPatchCoversAllFields(t *testing.T) {
 		SessionLive:             []string{"live-cmd"},
 		OverlayDir:              strVal("overlays/test"),
 		DefaultSlingFormula:     strVal("mol-work"),
+		Capabilities:            []string{"go"},
+		Gates:                   []string{"go test ./..."},
 		InjectFragments:         []string{"frag1"},
 		DependsOn:               []string{"other-agent"},
 		ResumeCommand:           strVal("claude --resume {{.SessionKey}}"),
 		WakeMode:                strVal("fresh"),
+		EnvFile:                 strVal(".gc/secrets.env"),
+		EnvFromKeychain:         []string{"ANTHROPIC_API_KEY"},
 		PreStartAppend:          []string{"pre-append"},
 		SessionSetupAppend:      []string{"setup-append"},
 		SessionLiveAppend:       []string{"live-append"},
@@ -251,10 +258,12 @@ func TestApplyAgentOverrideCoversAllFields(t *testing.T) {
 		PreStart:                []string{"pre-cmd"},
 		PromptTemplate:          strVal("prompts/test.md"),
 		Session:                 strVal("acp"),
+		Host:                    strVal("dev@build-box"),
 		Provider:                strVal("claude"),
 		StartCommand:            strVal("claude --dangerously"),
 		Nudge:                   strVal("wake up"),
 		IdleTimeout:             strVal("15m"),
+		RateLimit:               strVal("4/m"),
 		InstallAgentHooks:       []string{"claude"},
 		HooksInstalled:          &trueVal,
 		SessionSetup:            []string{"setup-cmd"},
@@ -262,10 +271,14 @@ func TestApplyAgentOverrideCoversAllFields(t *testing.T) {
 		SessionLive:             []string{"live-cmd"},
 		OverlayDir:              strVal("overlays/test"),
 		DefaultSlingFormula:     strVal("mol-work"),
+		Capabilities:            []string{"go"},
+		Gates:                   []string{"go test ./..."},
 		InjectFragments:         []string{"frag1"},
 		DependsOn:               []string{"other-agent"},
 		ResumeCommand:           strVal("claude --resume {{.SessionKey}}"),
 		WakeMode:                strVal("fresh"),
+		EnvFile:                 strVal(".gc/secrets.env"),
+		EnvFromKeychain:         []string{"ANTHROPIC_API_KEY"},
 		PreStartAppend:          []string{"pre-append"},
 		SessionSetupAppend:      []string{"setup-append"},
 		SessionLiveAppend:       []string{"live-append"},
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Lifecycle hook events, the values of [LifecycleHook.Event].
+const (
+	// HookPreStart runs before a session is created. Aborting prevents
+	// the start.
+	HookPreStart = "pre_start"
+	// HookPostStart runs after a session started. Aborting stops the
+	// session again and fails the start.
+	HookPostStart = "post_start"
+	// HookPreStop runs before a session is stopped. Aborting keeps the
+	// session running.
+	HookPreStop = "pre_stop"
+	// HookPostCloseBead runs after a bead is closed. It can't abort.
+	HookPostCloseBead = "post_close_bead"
+	// HookOnSling runs before gc sling routes work. Aborting cancels the
+	// sling.
+	HookOnSling = "on_sling"
+)
+
+// LifecycleHookEvents lists the valid lifecycle hook events.
+var LifecycleHookEvents = []string{HookPreStart, HookPostStart, HookPreStop, HookPostCloseBead, HookOnSling}
+
+// LifecycleHook runs a shell command when a city lifecycle event happens,
+// declared with [[hooks]] in city.toml. The command receives a JSON
+// description of the event on stdin. Unlike an agent's pre_start list,
+// which runs inside the session's environment, lifecycle hooks run on the
+// controller host from the city root.
+type LifecycleHook struct {
+	// Event selects when the hook runs: "pre_start", "post_start",
+	// "pre_stop", "post_close_bead", or "on_sling".
+	Event string `toml:"event" jsonschema:"required,enum=pre_start,enum=post_start,enum=pre_stop,enum=post_close_bead,enum=on_sling"`
+	// Run is the command, run with sh -c from the city root.
+	Run string `toml:"run" jsonschema:"required"`
+	// OnFailure is "warn" (log and continue, the default) or "abort"
+	// (fail the operation that triggered the hook). post_close_bead
+	// hooks can only warn.
+	OnFailure string `toml:"on_failure,omitempty" jsonschema:"enum=warn,enum=abort,default=warn"`
+	// Timeout bounds one run of the hook. Duration string. Defaults to "30s".
+	Timeout string `toml:"timeout,omitempty" jsonschema:"default=30s"`
+}
+
+// Aborts reports whether a failure of this hook fails the triggering
+// operation.
+func (h LifecycleHook) Aborts() bool {
+	return h.OnFailure == "abort"
+}
+
+// TimeoutDuration returns the hook timeout, defaulting to 30s when unset
+// or invalid.
+func (h LifecycleHook) TimeoutDuration() time.Duration {
+	if d, err := time.ParseDuration(h.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return 30 * time.Second
+}
+
+// HooksFor returns the hooks registered for event, in declaration order.
+func HooksFor(hooks []LifecycleHook, event string) []LifecycleHook {
+	var out []LifecycleHook
+	for _, h := range hooks {
+		if h.Event == event {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// ValidateLifecycleHooks checks [[hooks]] entries for unknown events,
+// empty commands, and bad failure policies or timeouts.
+func ValidateLifecycleHooks(hooks []LifecycleHook) error {
+	for i, h := range hooks {
+		if !slices.Contains(LifecycleHookEvents, h.Event) {
+			return fmt.Errorf("hooks[%d]: unknown event %q (want %s)", i, h.Event, strings.Join(LifecycleHookEvents, ", "))
+		}
+		if strings.TrimSpace(h.Run) == "" {
+			return fmt.Errorf("hooks[%d] (%s): run is required", i, h.Event)
+		}
+		switch h.OnFailure {
+		case "", "warn":
+		case "abort":
+			if h.Event == HookPostCloseBead {
+				return fmt.Errorf("hooks[%d] (%s): on_failure = \"abort\" is not supported; the bead is already closed", i, h.Event)
+			}
+		default:
+			return fmt.Errorf("hooks[%d] (%s): on_failure must be \"warn\" or \"abort\", got %q", i, h.Event, h.OnFailure)
+		}
+		if h.Timeout != "" {
+			if d, err := time.ParseDuration(h.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("hooks[%d] (%s): bad timeout %q", i, h.Event, h.Timeout)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateLifecycleHooks(t *testing.T) {
+	valid := []LifecycleHook{
+		{Event: HookPreStart, Run: "./check.sh", OnFailure: "abort", Timeout: "5s"},
+		{Event: HookPostCloseBead, Run: "./notify.sh", OnFailure: "warn"},
+		{Event: HookOnSling, Run: "true"},
+	}
+	if err := ValidateLifecycleHooks(valid); err != nil {
+		t.Fatalf("ValidateLifecycleHooks(valid) = %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		h    LifecycleHook
+		want string
+	}{
+		{"unknown event", LifecycleHook{Event: "on_boot", Run: "x"}, "unknown event"},
+		{"no run", LifecycleHook{Event: HookPreStop, Run: " "}, "run is required"},
+		{"bad policy", LifecycleHook{Event: HookPreStop, Run: "x", OnFailure: "ignore"}, "must be \"warn\" or \"abort\""},
+		{"abort after close", LifecycleHook{Event: HookPostCloseBead, Run: "x", OnFailure: "abort"}, "not supported"},
+		{"bad timeout", LifecycleHook{Event: HookOnSling, Run: "x", Timeout: "soon"}, "bad timeout"},
+	} {
+		err := ValidateLifecycleHooks([]LifecycleHook{tc.h})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want containing %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestLifecycleHookDefaults(t *testing.T) {
+	h := LifecycleHook{Event: HookPreStart, Run: "x"}
+	if h.Aborts() {
+		t.Error("Aborts() = true for default policy, want false")
+	}
+	if got := h.TimeoutDuration(); got != 30*time.Second {
+		t.Errorf("TimeoutDuration() = %v, want 30s", got)
+	}
+	h.Timeout = "2m"
+	if got := h.TimeoutDuration(); got != 2*time.Minute {
+		t.Errorf("TimeoutDuration() = %v, want 2m", got)
+	}
+}
+
+func TestHooksFor(t *testing.T) {
+	hooks := []LifecycleHook{
+		{Event: HookPreStart, Run: "a"},
+		{Event: HookPreStop, Run: "b"},
+		{Event: HookPreStart, Run: "c"},
+	}
+	got := HooksFor(hooks, HookPreStart)
+	if len(got) != 2 || got[0].Run != "a" || got[1].Run != "c" {
+		t.Errorf("HooksFor(pre_start) = %+v, want [a c]", got)
+	}
+}
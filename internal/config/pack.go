@@ -829,6 +829,9 @@ func applyAgentOverride(a *Agent, ov *AgentOverride) {
 	if ov.Session != nil {
 		a.Session = *ov.Session
 	}
+	if ov.Host != nil {
+		a.Host = *ov.Host
+	}
 	if ov.Provider != nil {
 		a.Provider = *ov.Provider
 	}
@@ -841,6 +844,9 @@ func applyAgentOverride(a *Agent, ov *AgentOverride) {
 	if ov.IdleTimeout != nil {
 		a.IdleTimeout = *ov.IdleTimeout
 	}
+	if ov.RateLimit != nil {
+		a.RateLimit = *ov.RateLimit
+	}
 	if len(ov.InstallAgentHooks) > 0 {
 		a.InstallAgentHooks = append([]string(nil), ov.InstallAgentHooks...)
 	}
@@ -871,6 +877,12 @@ func applyAgentOverride(a *Agent, ov *AgentOverride) {
 	if ov.DefaultSlingFormula != nil {
 		a.DefaultSlingFormula = *ov.DefaultSlingFormula
 	}
+	if len(ov.Capabilities) > 0 {
+		a.Capabilities = append([]string(nil), ov.Capabilities...)
+	}
+	if len(ov.Gates) > 0 {
+		a.Gates = append([]string(nil), ov.Gates...)
+	}
 	if ov.Attach != nil {
 		a.Attach = ov.Attach
 	}
@@ -883,6 +895,12 @@ func applyAgentOverride(a *Agent, ov *AgentOverride) {
 	if ov.WakeMode != nil {
 		a.WakeMode = *ov.WakeMode
 	}
+	if ov.EnvFile != nil {
+		a.EnvFile = *ov.EnvFile
+	}
+	if len(ov.EnvFromKeychain) > 0 {
+		a.EnvFromKeychain = append([]string(nil), ov.EnvFromKeychain...)
+	}
 	if len(ov.InjectFragments) > 0 {
 		a.InjectFragments = append([]string(nil), ov.InjectFragments...)
 	}
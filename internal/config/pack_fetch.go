@@ -19,6 +19,9 @@ const packCacheDir = citylayout.CachePacksRoot
 // PackLock represents the lockfile state for reproducible builds.
 type PackLock struct {
 	Packs map[string]LockedPack `toml:"packs"`
+	// Includes locks remote pack includes (URLs in includes lists),
+	// keyed by source#ref.
+	Includes map[string]LockedPack `toml:"includes,omitempty"`
 }
 
 // LockedPack records the exact state of a cached pack.
@@ -105,7 +108,7 @@ func ReadLock(cityRoot string) (*PackLock, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return &PackLock{Packs: make(map[string]LockedPack)}, nil
+			return &PackLock{Packs: make(map[string]LockedPack), Includes: make(map[string]LockedPack)}, nil
 		}
 		return nil, fmt.Errorf("reading pack.lock: %w", err)
 	}
@@ -116,6 +119,9 @@ func ReadLock(cityRoot string) (*PackLock, error) {
 	if lock.Packs == nil {
 		lock.Packs = make(map[string]LockedPack)
 	}
+	if lock.Includes == nil {
+		lock.Includes = make(map[string]LockedPack)
+	}
 	return &lock, nil
 }
 
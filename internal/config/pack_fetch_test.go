@@ -487,8 +487,8 @@ func TestFetchRemoteInclude(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// First fetch: clone.
-	cacheDir, err := fetchRemoteInclude(bare, "", cityRoot)
+	// First fetch: clone and lock the floating default branch.
+	cacheDir, err := syncRemoteInclude(bare, "", cityRoot, false, true)
 	if err != nil {
 		t.Fatalf("fetchRemoteInclude: %v", err)
 	}
@@ -503,7 +503,7 @@ func TestFetchRemoteInclude(t *testing.T) {
 		t.Errorf("cacheDir = %q, want under cache/includes/", cacheDir)
 	}
 
-	// Idempotent: second fetch succeeds (updates existing).
+	// Idempotent: second fetch succeeds against the lock.
 	cacheDir2, err := fetchRemoteInclude(bare, "", cityRoot)
 	if err != nil {
 		t.Fatalf("second fetchRemoteInclude: %v", err)
@@ -534,6 +534,81 @@ func TestFetchRemoteInclude_WithRef(t *testing.T) {
 	}
 }
 
+func TestFetchRemoteInclude_LocksAndVerifies(t *testing.T) {
+	bare := initBareRepoWithTag(t, "inc-locked", "v1.0.0")
+	cityRoot := t.TempDir()
+
+	cacheDir, err := fetchRemoteInclude(bare, "v1.0.0", cityRoot)
+	if err != nil {
+		t.Fatalf("fetchRemoteInclude: %v", err)
+	}
+	lock, err := ReadLock(cityRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	locked, ok := lock.Includes[bare+"#v1.0.0"]
+	if !ok {
+		t.Fatalf("lock.Includes = %v, want entry for %s#v1.0.0", lock.Includes, bare)
+	}
+	if len(locked.Commit) != 40 || !strings.HasPrefix(locked.Hash, "sha256:") {
+		t.Errorf("locked = %+v, want commit and sha256 hash", locked)
+	}
+
+	// Tampering with the cache fails verification.
+	if err := os.WriteFile(filepath.Join(cacheDir, "pack.toml"), []byte("[pack]\nname = \"evil\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fetchRemoteInclude(bare, "v1.0.0", cityRoot); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("fetchRemoteInclude after tamper: err = %v, want checksum mismatch", err)
+	}
+
+	// Re-fetching re-locks.
+	if _, err := syncRemoteInclude(bare, "v1.0.0", cityRoot, true, false); err != nil {
+		t.Fatalf("syncRemoteInclude refresh: %v", err)
+	}
+	if _, err := fetchRemoteInclude(bare, "v1.0.0", cityRoot); err != nil {
+		t.Fatalf("fetchRemoteInclude after refresh: %v", err)
+	}
+}
+
+func TestFetchRemoteInclude_RefusesFloating(t *testing.T) {
+	bare := initBareRepoWithBranch(t, "inc-floating", "develop")
+	cityRoot := t.TempDir()
+
+	if _, err := fetchRemoteInclude(bare, "develop", cityRoot); err == nil || !strings.Contains(err.Error(), "--allow-floating") {
+		t.Fatalf("fetchRemoteInclude(develop): err = %v, want floating ref error", err)
+	}
+	if _, err := syncRemoteInclude(bare, "develop", cityRoot, true, true); err != nil {
+		t.Fatalf("syncRemoteInclude allowFloating: %v", err)
+	}
+	// Once locked, the floating ref loads from the lock.
+	if _, err := fetchRemoteInclude(bare, "develop", cityRoot); err != nil {
+		t.Fatalf("fetchRemoteInclude after lock: %v", err)
+	}
+}
+
+func TestFetchRemoteInclude_CommitRef(t *testing.T) {
+	bare := initBareRepoWithTag(t, "inc-sha", "v1.0.0")
+	commit, err := runGit(bare, "rev-parse", "v1.0.0^{commit}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit = strings.TrimSpace(commit)
+	cityRoot := t.TempDir()
+
+	cacheDir, err := fetchRemoteInclude(bare, commit, cityRoot)
+	if err != nil {
+		t.Fatalf("fetchRemoteInclude(%s): %v", commit, err)
+	}
+	head, err := runGit(cacheDir, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(head) != commit {
+		t.Errorf("HEAD = %s, want %s", head, commit)
+	}
+}
+
 func TestLoadPack_RemoteInclude(t *testing.T) {
 	// Create a bare repo as the "remote" pack.
 	bare := initBareRepo(t, "remote-maint")
@@ -7,7 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/fsys"
 )
 
 // includeCacheDir is the subdirectory under .gc/cache/includes/ where
@@ -15,9 +17,10 @@ import (
 const includeCacheDir = citylayout.CacheIncludesRoot
 
 // isRemoteInclude reports whether s is a remote include URL
-// (git@, ssh://, https://, http://, or file://).
+// (git+<url>, git@, ssh://, https://, http://, or file://).
 func isRemoteInclude(s string) bool {
-	return strings.HasPrefix(s, "git@") ||
+	return strings.HasPrefix(s, "git+") ||
+		strings.HasPrefix(s, "git@") ||
 		strings.HasPrefix(s, "ssh://") ||
 		strings.HasPrefix(s, "https://") ||
 		strings.HasPrefix(s, "http://") ||
@@ -26,18 +29,35 @@ func isRemoteInclude(s string) bool {
 
 // parseRemoteInclude splits a remote include string into source, subpath,
 // and ref components. Format: <source>//<subpath>#<ref>
-// Both //subpath and #ref are optional.
+// Both //subpath and #ref are optional. With a git+ prefix the ref may
+// also be written <source>//<subpath>@<ref>.
 //
 // Examples:
 //
-//	"git@github.com:org/repo.git//topo#v1.0" → ("git@github.com:org/repo.git", "topo", "v1.0")
-//	"https://github.com/org/repo.git#main"   → ("https://github.com/org/repo.git", "", "main")
-//	"git@github.com:org/repo.git"            → ("git@github.com:org/repo.git", "", "")
+//	"git@github.com:org/repo.git//topo#v1.0"            → ("git@github.com:org/repo.git", "topo", "v1.0")
+//	"https://github.com/org/repo.git#main"              → ("https://github.com/org/repo.git", "", "main")
+//	"git@github.com:org/repo.git"                       → ("git@github.com:org/repo.git", "", "")
+//	"git+https://github.com/org/packs//gastown@v1.2.0" → ("https://github.com/org/packs", "gastown", "v1.2.0")
 func parseRemoteInclude(s string) (source, subpath, ref string) {
+	gitPlus := strings.HasPrefix(s, "git+")
+	s = strings.TrimPrefix(s, "git+")
+
 	// Split off #ref first.
 	if i := strings.LastIndex(s, "#"); i >= 0 {
 		ref = s[i+1:]
 		s = s[:i]
+	} else if gitPlus {
+		// @ref, but not the user@ of an ssh URL: it must follow the
+		// first path separator after the host.
+		hostStart := 0
+		if idx := strings.Index(s, "://"); idx >= 0 {
+			hostStart = idx + 3
+		}
+		pathStart := strings.Index(s[hostStart:], "/")
+		if i := strings.LastIndex(s, "@"); pathStart >= 0 && i > hostStart+pathStart {
+			ref = s[i+1:]
+			s = s[:i]
+		}
 	}
 
 	// Find // for subpath. For URLs with scheme (https://...), we need
@@ -129,6 +149,34 @@ func parseGitHubTreeURL(s string) (source, subpath, ref string) {
 	return source, subpath, ref
 }
 
+// IsRemoteSource reports whether s names a git repository rather than a
+// local path. It accepts the same forms as remote pack includes.
+func IsRemoteSource(s string) bool {
+	return isRemoteInclude(s) || isGitHubTreeURL(s)
+}
+
+// CloneSource clones the repository named by a remote source (in any form
+// IsRemoteSource accepts) into dir, which must not exist, and returns the
+// directory it points at: dir itself, or dir/<subpath> when the source
+// names one.
+func CloneSource(s, dir string) (string, error) {
+	source, subpath, ref := parseRemoteInclude(s)
+	if isGitHubTreeURL(s) {
+		source, subpath, ref = parseGitHubTreeURL(s)
+	}
+	if err := cloneInclude(source, dir, ref); err != nil {
+		return "", err
+	}
+	if subpath == "" {
+		return dir, nil
+	}
+	sub := filepath.Join(dir, filepath.FromSlash(subpath))
+	if fi, err := os.Stat(sub); err != nil || !fi.IsDir() {
+		return "", fmt.Errorf("%s: no directory %q in repository", source, subpath)
+	}
+	return sub, nil
+}
+
 // resolvePackRef resolves a pack reference to a local directory.
 // Handles local paths, GitHub tree URLs, and git source//sub#ref URLs.
 func resolvePackRef(ref, declDir, cityRoot string) (string, error) {
@@ -157,25 +205,264 @@ func resolvePackRef(ref, declDir, cityRoot string) (string, error) {
 	return resolveConfigPath(ref, declDir, cityRoot), nil
 }
 
-// fetchRemoteInclude ensures a remote pack include is cached locally.
-// Returns the path to the cached pack directory (including subpath
-// resolution). Clones on first access, updates on subsequent calls.
-// Cache location: <cityRoot>/.gc/cache/includes/<cache-name>/
+// fetchRemoteInclude ensures a remote pack include is cached locally and
+// matches pack.lock. Returns the cache directory (before subpath
+// resolution). Cache location: <cityRoot>/.gc/cache/includes/<cache-name>/
+//
+// A locked include is checked out at its locked commit and its content
+// hash verified; nothing is fetched. An unlocked include is fetched and
+// locked on first use if its ref is pinned (a version tag or commit);
+// floating refs must be locked explicitly with gc pack fetch
+// --allow-floating.
 func fetchRemoteInclude(source, ref, cityRoot string) (string, error) {
-	cacheName := includeCacheName(source)
-	cacheDir := filepath.Join(cityRoot, includeCacheDir, cacheName)
+	return syncRemoteInclude(source, ref, cityRoot, false, false)
+}
+
+// syncRemoteInclude implements fetchRemoteInclude. refresh re-fetches
+// and re-locks even when a lock entry exists (gc pack fetch).
+func syncRemoteInclude(source, ref, cityRoot string, refresh, allowFloating bool) (string, error) {
+	key := includeLockKey(source, ref)
+	cacheDir := filepath.Join(cityRoot, includeCacheDir, includeCacheName(key))
+	lock, err := ReadLock(cityRoot)
+	if err != nil {
+		return "", err
+	}
+	if locked, ok := lock.Includes[key]; ok && !refresh {
+		if err := checkoutLockedInclude(source, ref, cacheDir, locked.Commit); err != nil {
+			return "", fmt.Errorf("include %s: %w", key, err)
+		}
+		if got := "sha256:" + packDirHash(cacheDir); got != locked.Hash {
+			return "", fmt.Errorf("include %s: checksum mismatch (locked %s, cached %s); run \"gc pack fetch\" to re-lock if the change is expected",
+				key, locked.Hash, got)
+		}
+		return cacheDir, nil
+	}
+	if !isPinnedRef(ref) && !allowFloating {
+		shown := ref
+		if shown == "" {
+			shown = "default branch"
+		}
+		return "", fmt.Errorf("include %s: floating ref %q is not locked; pin a version tag or commit, or run \"gc pack fetch --allow-floating\"",
+			key, shown)
+	}
 
 	if _, err := os.Stat(filepath.Join(cacheDir, ".git")); err != nil {
-		// Not yet cloned.
-		if err := clonePack(source, cacheDir, ref); err != nil {
+		if err := cloneInclude(source, cacheDir, ref); err != nil {
 			return "", fmt.Errorf("fetching include %s: %w", source, err)
 		}
-	} else {
-		// Already cloned — update.
-		if err := updatePack(cacheDir, ref); err != nil {
-			return "", fmt.Errorf("updating include %s: %w", source, err)
+	} else if err := updatePack(cacheDir, ref); err != nil {
+		return "", fmt.Errorf("updating include %s: %w", source, err)
+	}
+	commit, err := runGit(cacheDir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("include %s: %w", key, err)
+	}
+	lock.Includes[key] = LockedPack{
+		Source: source,
+		Ref:    ref,
+		Commit: strings.TrimSpace(commit),
+		Hash:   "sha256:" + packDirHash(cacheDir),
+	}
+	if err := WriteLock(cityRoot, lock); err != nil {
+		return "", err
+	}
+	return cacheDir, nil
+}
+
+// includeLockKey is the pack.lock key for a remote include: the clone
+// depends only on source and ref, not the subpath.
+func includeLockKey(source, ref string) string {
+	if ref == "" {
+		return source
+	}
+	return source + "#" + ref
+}
+
+// isPinnedRef reports whether ref names an immutable revision: a
+// version tag (v1.2.0) or a full commit SHA.
+func isPinnedRef(ref string) bool {
+	if isCommitSHA(ref) {
+		return true
+	}
+	_, ok := parsePackVersion(ref)
+	return ok && strings.ContainsAny(ref, ".")
+}
+
+func isCommitSHA(ref string) bool {
+	if len(ref) != 40 {
+		return false
+	}
+	for _, c := range ref {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
 		}
 	}
+	return true
+}
 
-	return cacheDir, nil
+// cloneInclude clones source into cacheDir at ref. Commit SHAs cannot
+// be cloned with --branch, so they get a full clone and a checkout.
+func cloneInclude(source, cacheDir, ref string) error {
+	if !isCommitSHA(ref) {
+		return clonePack(source, cacheDir, ref)
+	}
+	if _, err := runGit("", "clone", source, cacheDir); err != nil {
+		return fmt.Errorf("cloning %s: %w", source, err)
+	}
+	if _, err := runGit(cacheDir, "checkout", ref); err != nil {
+		return fmt.Errorf("checking out %s: %w", ref, err)
+	}
+	return nil
+}
+
+// checkoutLockedInclude makes cacheDir's HEAD the locked commit, cloning
+// and fetching only when the commit is not already present.
+func checkoutLockedInclude(source, ref, cacheDir, commit string) error {
+	if _, err := os.Stat(filepath.Join(cacheDir, ".git")); err != nil {
+		if err := cloneInclude(source, cacheDir, ref); err != nil {
+			return err
+		}
+	}
+	head, err := runGit(cacheDir, "rev-parse", "HEAD")
+	if err == nil && strings.TrimSpace(head) == commit {
+		return nil
+	}
+	if _, err := runGit(cacheDir, "checkout", commit); err == nil {
+		return nil
+	}
+	if _, err := runGit(cacheDir, "fetch", "origin", commit); err != nil {
+		return fmt.Errorf("locked commit %s not available: %w", commit, err)
+	}
+	if _, err := runGit(cacheDir, "checkout", commit); err != nil {
+		return fmt.Errorf("checking out locked commit %s: %w", commit, err)
+	}
+	return nil
+}
+
+// FetchRemoteIncludes re-fetches every remote include reachable from the
+// city.toml at path and re-locks it in pack.lock: the root include list,
+// workspace and rig pack includes (including rigs/<name>/rig.toml), and
+// includes nested inside those packs. Floating refs are refused unless
+// allowFloating is set. Returns the pack.lock keys fetched, in visit order.
+func FetchRemoteIncludes(fs fsys.FS, path string, allowFloating bool) ([]string, error) {
+	root, err := Load(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	cityRoot := filepath.Dir(path)
+	prov := newProvenance(path)
+
+	var fetched []string
+	fetchedSet := make(map[string]bool)
+	fetch := func(ref string) (string, error) {
+		source, subpath, gitRef := parseRemoteInclude(ref)
+		if isGitHubTreeURL(ref) {
+			source, subpath, gitRef = parseGitHubTreeURL(ref)
+		}
+		key := includeLockKey(source, gitRef)
+		cacheDir := filepath.Join(cityRoot, includeCacheDir, includeCacheName(key))
+		if !fetchedSet[key] {
+			var err error
+			if cacheDir, err = syncRemoteInclude(source, gitRef, cityRoot, true, allowFloating); err != nil {
+				return "", err
+			}
+			fetchedSet[key] = true
+			fetched = append(fetched, key)
+		}
+		return filepath.Join(cacheDir, subpath), nil
+	}
+
+	// Collect pack refs from the root and its local fragments; remote
+	// fragments are fetched directly.
+	var packRefs []string
+	collect := func(c *City) {
+		packRefs = append(packRefs, c.Workspace.Includes...)
+		for _, r := range c.Rigs {
+			packRefs = append(packRefs, r.Includes...)
+		}
+	}
+	collect(root)
+	for _, inc := range root.Include {
+		if isRemoteInclude(inc) || isGitHubTreeURL(inc) {
+			if _, err := fetch(inc); err != nil {
+				return fetched, err
+			}
+			continue
+		}
+		paths, err := expandInclude(fs, inc, cityRoot, prov)
+		if err != nil {
+			return fetched, err
+		}
+		for _, p := range paths {
+			frag, err := Load(fs, p)
+			if err != nil {
+				return fetched, err
+			}
+			collect(frag)
+			for name, src := range frag.Packs {
+				if root.Packs == nil {
+					root.Packs = make(map[string]PackSource)
+				}
+				root.Packs[name] = src
+			}
+		}
+	}
+	rigOnly := &City{Rigs: make([]Rig, len(root.Rigs))}
+	for i, r := range root.Rigs {
+		rigOnly.Rigs[i] = Rig{Name: r.Name}
+	}
+	if err := mergeRigFiles(fs, rigOnly, cityRoot, prov); err != nil {
+		return fetched, err
+	}
+	collect(rigOnly)
+
+	// Walk packs, fetching remote refs and descending into their includes.
+	seen := make(map[string]bool)
+	var visit func(dir string) error
+	visit = func(dir string) error {
+		if seen[dir] {
+			return nil
+		}
+		seen[dir] = true
+		data, err := fs.ReadFile(filepath.Join(dir, packFile))
+		if err != nil {
+			return nil // missing packs are reported when the city loads
+		}
+		var pc packConfig
+		if _, err := toml.Decode(string(data), &pc); err != nil {
+			return fmt.Errorf("parsing %s: %w", filepath.Join(dir, packFile), err)
+		}
+		for _, ref := range pc.Pack.Includes {
+			if err := visitRef(ref, dir, cityRoot, root.Packs, fetch, visit); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, ref := range packRefs {
+		if err := visitRef(ref, cityRoot, cityRoot, root.Packs, fetch, visit); err != nil {
+			return fetched, err
+		}
+	}
+	return fetched, nil
+}
+
+// visitRef resolves one pack ref for FetchRemoteIncludes, fetching it if
+// remote, and visits the resulting pack directory.
+func visitRef(ref, declDir, cityRoot string, packs map[string]PackSource,
+	fetch func(string) (string, error), visit func(string) error,
+) error {
+	var dir string
+	switch src, named := packs[ref]; {
+	case named:
+		dir = PackCachePath(cityRoot, ref, src)
+	case isRemoteInclude(ref) || isGitHubTreeURL(ref):
+		var err error
+		if dir, err = fetch(ref); err != nil {
+			return err
+		}
+	default:
+		dir = resolveConfigPath(ref, declDir, cityRoot)
+	}
+	return visit(dir)
 }
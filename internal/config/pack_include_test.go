@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -32,6 +34,9 @@ func TestIsRemoteInclude(t *testing.T) {
 
 		// File protocol (local git repos).
 		{"file:///tmp/repo.git", true},
+
+		// git+ prefix.
+		{"git+https://github.com/org/packs//gastown@v1.2.0", true},
 	}
 
 	for _, tt := range tests {
@@ -93,6 +98,33 @@ func TestParseRemoteInclude(t *testing.T) {
 			"",
 			"feature-branch",
 		},
+		// git+ with @ref.
+		{
+			"git+https://github.com/org/packs//gastown@v1.2.0",
+			"https://github.com/org/packs",
+			"gastown",
+			"v1.2.0",
+		},
+		// git+ ssh: user@ is not a ref.
+		{
+			"git+ssh://git@github.com/org/packs//gastown",
+			"ssh://git@github.com/org/packs",
+			"gastown",
+			"",
+		},
+		{
+			"git+ssh://git@github.com/org/packs@v2.0.0",
+			"ssh://git@github.com/org/packs",
+			"",
+			"v2.0.0",
+		},
+		// git+ still accepts #ref.
+		{
+			"git+https://github.com/org/packs//gastown#main",
+			"https://github.com/org/packs",
+			"gastown",
+			"main",
+		},
 	}
 
 	for _, tt := range tests {
@@ -231,3 +263,47 @@ func TestIncludeCacheName(t *testing.T) {
 		t.Errorf("collision: %q == %q for different sources", a, c)
 	}
 }
+
+func TestIsPinnedRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"v1.2.0", true},
+		{"1.2", true},
+		{"v2.0.0-rc1", true},
+		{"0123456789abcdef0123456789abcdef01234567", true},
+		{"", false},
+		{"main", false},
+		{"v1", false},
+		{"feature-branch", false},
+		{"0123456", false},
+	}
+	for _, tt := range tests {
+		if got := isPinnedRef(tt.ref); got != tt.want {
+			t.Errorf("isPinnedRef(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestCloneSource(t *testing.T) {
+	bare := initBareRepo(t, "city-skeleton")
+
+	dir, err := CloneSource("file://"+bare+"//prompts", filepath.Join(t.TempDir(), "clone"))
+	if err != nil {
+		t.Fatalf("CloneSource: %v", err)
+	}
+	if filepath.Base(dir) != "prompts" {
+		t.Errorf("CloneSource dir = %q, want the prompts subpath", dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "worker.md")); err != nil {
+		t.Errorf("subpath content missing: %v", err)
+	}
+
+	if _, err := CloneSource("file://"+bare+"//nope", filepath.Join(t.TempDir(), "clone")); err == nil || !strings.Contains(err.Error(), "no directory") {
+		t.Errorf("CloneSource(missing subpath) = %v, want no directory error", err)
+	}
+	if !IsRemoteSource("git@github.com:org/city.git") || IsRemoteSource("./examples/gastown") {
+		t.Error("IsRemoteSource misclassified a source")
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// PackInfo is a standalone view of one pack, expanded through its
+// includes the same way ExpandPacks would, but without a city or rig.
+type PackInfo struct {
+	// Meta is the pack's [pack] header.
+	Meta PackMeta
+	// Dir is the resolved pack directory.
+	Dir string
+	// Agents are the expanded agents, included packs first. Prompt
+	// template paths are relative to the cityRoot passed to InspectPack.
+	Agents []Agent
+	// Services are the pack's [[service]] entries, including included packs.
+	Services []Service
+	// Providers are the merged [providers] from the pack and its includes.
+	Providers map[string]ProviderSpec
+	// Requires lists agent requirements from the pack and its includes.
+	Requires []PackRequirement
+	// Commands and Doctor are this pack's own entries (not its includes').
+	Commands []PackCommandEntry
+	Doctor   []PackDoctorEntry
+	// Dirs is the ordered list of pack directories: includes, then Dir.
+	Dirs []string
+}
+
+// InspectPack loads the pack at ref (a local path or remote include) and
+// expands its includes. cityRoot anchors relative refs and the returned
+// prompt paths; remote refs are cached under it.
+func InspectPack(fs fsys.FS, ref, cityRoot string) (*PackInfo, error) {
+	dir, err := resolvePackRef(ref, cityRoot, cityRoot)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, packFile)
+	agents, providers, services, dirs, reqs, _, err := loadPack(fs, path, dir, cityRoot, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	// loadPack already decoded and validated the header; re-read for the
+	// fields it does not return.
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", packFile, err)
+	}
+	var pc packConfig
+	if _, err := toml.Decode(string(data), &pc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", packFile, err)
+	}
+	return &PackInfo{
+		Meta:      pc.Pack,
+		Dir:       dir,
+		Agents:    agents,
+		Services:  services,
+		Providers: providers,
+		Requires:  reqs,
+		Commands:  pc.Commands,
+		Doctor:    pc.Doctor,
+		Dirs:      dirs,
+	}, nil
+}
+
+// ValidatePack checks an inspected pack for the problems that otherwise
+// surface only when a city expands it: duplicate agents across includes,
+// invalid agent or service definitions, and missing prompt templates or
+// scripts. Every problem is returned, not just the first.
+func ValidatePack(fs fsys.FS, info *PackInfo, cityRoot string) []error {
+	var errs []error
+	if err := checkPackAgentCollisions(info.Agents, ""); err != nil {
+		errs = append(errs, err)
+	}
+	for _, a := range info.Agents {
+		a.DependsOn = nil // cross-agent; checked with the full list below
+		if err := ValidateAgents([]Agent{a}); err != nil {
+			errs = append(errs, err)
+		}
+		if a.PromptTemplate == "" {
+			continue
+		}
+		p := a.PromptTemplate
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(cityRoot, p)
+		}
+		if _, err := fs.Stat(p); err != nil {
+			errs = append(errs, fmt.Errorf("agent %q: prompt_template %q not found", a.Name, a.PromptTemplate))
+		}
+	}
+	if len(errs) == 0 {
+		if err := ValidateAgents(info.Agents); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := ValidateServices(info.Services); err != nil {
+		errs = append(errs, err)
+	}
+	for _, c := range info.Commands {
+		if _, err := fs.Stat(filepath.Join(info.Dir, c.Script)); err != nil {
+			errs = append(errs, fmt.Errorf("command %q: script %q not found", c.Name, c.Script))
+		}
+	}
+	for _, d := range info.Doctor {
+		if _, err := fs.Stat(filepath.Join(info.Dir, d.Script)); err != nil {
+			errs = append(errs, fmt.Errorf("doctor %q: script %q not found", d.Name, d.Script))
+		}
+	}
+	return errs
+}
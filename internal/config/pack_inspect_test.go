@@ -0,0 +1,61 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestInspectPackExpandsIncludes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "packs/base/pack.toml", `
+[pack]
+name = "base"
+schema = 1
+
+[[agent]]
+name = "mayor"
+scope = "city"
+`)
+	writeFile(t, dir, "packs/top/pack.toml", `
+[pack]
+name = "top"
+schema = 1
+includes = ["../base"]
+
+[[agent]]
+name = "coder"
+prompt_template = "prompts/coder.md"
+`)
+	writeFile(t, dir, "packs/top/prompts/coder.md", "code")
+
+	info, err := InspectPack(fsys.OSFS{}, "packs/top", dir)
+	if err != nil {
+		t.Fatalf("InspectPack: %v", err)
+	}
+	if info.Meta.Name != "top" || len(info.Agents) != 2 || len(info.Dirs) != 2 {
+		t.Fatalf("info = %+v, want top with 2 agents from 2 dirs", info)
+	}
+	if info.Agents[0].Name != "mayor" || info.Agents[1].PromptTemplate != "packs/top/prompts/coder.md" {
+		t.Errorf("agents = %+v", info.Agents)
+	}
+	if errs := ValidatePack(fsys.OSFS{}, info, dir); len(errs) != 0 {
+		t.Errorf("ValidatePack = %v, want none", errs)
+	}
+}
+
+func TestValidatePackDuplicateAcrossIncludes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a/pack.toml", "[pack]\nname = \"a\"\nschema = 1\n\n[[agent]]\nname = \"worker\"\n")
+	writeFile(t, dir, "b/pack.toml", "[pack]\nname = \"b\"\nschema = 1\nincludes = [\"../a\"]\n\n[[agent]]\nname = \"worker\"\n")
+
+	info, err := InspectPack(fsys.OSFS{}, "b", dir)
+	if err != nil {
+		t.Fatalf("InspectPack: %v", err)
+	}
+	errs := ValidatePack(fsys.OSFS{}, info, dir)
+	if len(errs) == 0 || !strings.Contains(errs[0].Error(), `duplicate agent "worker"`) {
+		t.Errorf("errs = %v, want duplicate agent", errs)
+	}
+}
@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// packVersion is a parsed major.minor.patch version. Pre-release and
+// build suffixes are ignored for ordering.
+type packVersion [3]int
+
+// parsePackVersion parses "1", "1.2", "v1.2.3", or "1.2.3-rc1".
+func parsePackVersion(s string) (packVersion, bool) {
+	var v packVersion
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+func (v packVersion) compare(o packVersion) int {
+	for i := range v {
+		if v[i] != o[i] {
+			if v[i] < o[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v packVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// PackVersionSatisfies reports whether version meets constraint. A
+// constraint is one or more comma-separated terms, all of which must
+// hold: "1.2.3" (exact), "^1.2" (same major, at least 1.2), "~1.2" (same
+// major.minor, at least 1.2), or a comparison (">=", ">", "<=", "<", "=").
+// An empty constraint accepts any version.
+func PackVersionSatisfies(version, constraint string) (bool, error) {
+	if strings.TrimSpace(constraint) == "" {
+		return true, nil
+	}
+	v, ok := parsePackVersion(version)
+	if !ok {
+		return false, fmt.Errorf("invalid version %q", version)
+	}
+	for _, term := range strings.Split(constraint, ",") {
+		term = strings.TrimSpace(term)
+		op := strings.TrimRight(term[:len(term)-len(strings.TrimLeft(term, "^~<>="))], " ")
+		want, ok := parsePackVersion(term[len(op):])
+		if !ok {
+			return false, fmt.Errorf("invalid version constraint %q", constraint)
+		}
+		c := v.compare(want)
+		var met bool
+		switch op {
+		case "", "=":
+			met = c == 0
+		case "^":
+			met = c >= 0 && v[0] == want[0]
+		case "~":
+			met = c >= 0 && v[0] == want[0] && v[1] == want[1]
+		case ">=":
+			met = c >= 0
+		case ">":
+			met = c > 0
+		case "<=":
+			met = c <= 0
+		case "<":
+			met = c < 0
+		default:
+			return false, fmt.Errorf("invalid version constraint %q", constraint)
+		}
+		if !met {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// LatestPackVersion returns the tag with the highest version among tags,
+// ignoring tags that are not versions. Returns "" when none are.
+func LatestPackVersion(tags []string) string {
+	best, bestTag := packVersion{}, ""
+	for _, t := range tags {
+		v, ok := parsePackVersion(t)
+		if !ok || strings.ContainsAny(t, "-+") {
+			continue // not a version, or a pre-release
+		}
+		if bestTag == "" || v.compare(best) > 0 {
+			best, bestTag = v, t
+		}
+	}
+	return bestTag
+}
+
+// ListPackTags returns the tag names in a remote pack repository.
+func ListPackTags(source string) ([]string, error) {
+	out, err := runGit("", "ls-remote", "--tags", "--refs", source)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, line := range strings.Split(out, "\n") {
+		if _, ref, ok := strings.Cut(line, "\t"); ok {
+			tags = append(tags, strings.TrimPrefix(strings.TrimSpace(ref), "refs/tags/"))
+		}
+	}
+	return tags, nil
+}
+
+// ReadPackMeta reads the [pack] header of the pack in dir.
+func ReadPackMeta(fs fsys.FS, dir string) (PackMeta, error) {
+	data, err := fs.ReadFile(filepath.Join(dir, packFile))
+	if err != nil {
+		return PackMeta{}, err
+	}
+	var pc packConfig
+	if _, err := toml.Decode(string(data), &pc); err != nil {
+		return PackMeta{}, fmt.Errorf("parsing %s: %w", packFile, err)
+	}
+	return pc.Pack, nil
+}
+
+// checkNamedPackVersions verifies each cached named pack against its
+// [packs] version constraint. Packs not yet fetched are skipped; they
+// are checked on the next load after gc pack fetch.
+func checkNamedPackVersions(fs fsys.FS, cfg *City, cityRoot string) error {
+	for name, src := range cfg.Packs {
+		if src.Version == "" {
+			continue
+		}
+		meta, err := ReadPackMeta(fs, PackCachePath(cityRoot, name, src))
+		if err != nil {
+			continue
+		}
+		if meta.Version == "" {
+			return fmt.Errorf("pack %q: version %q required but pack declares no version", name, src.Version)
+		}
+		ok, err := PackVersionSatisfies(meta.Version, src.Version)
+		if err != nil {
+			return fmt.Errorf("pack %q: %w", name, err)
+		}
+		if !ok {
+			return fmt.Errorf("pack %q: version %s does not satisfy %q (run \"gc pack upgrade %s\" or relax the constraint)",
+				name, meta.Version, src.Version, name)
+		}
+	}
+	return nil
+}
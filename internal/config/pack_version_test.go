@@ -0,0 +1,69 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestPackVersionSatisfies(t *testing.T) {
+	tests := []struct {
+		version, constraint string
+		want                bool
+	}{
+		{"1.2.3", "", true},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.4", "1.2.3", false},
+		{"v1.4.0", "^1.2", true},
+		{"2.0.0", "^1.2", false},
+		{"1.1.9", "^1.2", false},
+		{"1.2.9", "~1.2", true},
+		{"1.3.0", "~1.2", false},
+		{"1.5.0", ">=1.0, <2", true},
+		{"2.0.0", ">=1.0, <2", false},
+		{"1.0.0-rc1", ">= 1.0.0", true},
+	}
+	for _, tt := range tests {
+		got, err := PackVersionSatisfies(tt.version, tt.constraint)
+		if err != nil || got != tt.want {
+			t.Errorf("PackVersionSatisfies(%q, %q) = %v, %v; want %v", tt.version, tt.constraint, got, err, tt.want)
+		}
+	}
+	if _, err := PackVersionSatisfies("1.0.0", "!1.0"); err == nil {
+		t.Error("invalid constraint accepted")
+	}
+	if _, err := PackVersionSatisfies("latest", "^1"); err == nil {
+		t.Error("invalid version accepted")
+	}
+}
+
+func TestLatestPackVersion(t *testing.T) {
+	got := LatestPackVersion([]string{"v1.2.0", "v1.10.0", "v2.0.0-rc1", "nightly", "v1.9.3"})
+	if got != "v1.10.0" {
+		t.Errorf("LatestPackVersion = %q, want v1.10.0", got)
+	}
+	if got := LatestPackVersion([]string{"main"}); got != "" {
+		t.Errorf("LatestPackVersion = %q, want empty", got)
+	}
+}
+
+func TestCheckNamedPackVersions(t *testing.T) {
+	fs := fsys.NewFake()
+	src := PackSource{Source: "https://example.com/gastown.git", Version: "^1.0"}
+	fs.Files[PackCachePath("/city", "gastown", src)+"/pack.toml"] = []byte("[pack]\nname = \"gastown\"\nversion = \"2.1.0\"\nschema = 1\n")
+	cfg := &City{Packs: map[string]PackSource{
+		"gastown":   src,
+		"unfetched": {Source: "https://example.com/other.git", Version: "^3"},
+	}}
+
+	err := checkNamedPackVersions(fs, cfg, "/city")
+	if err == nil || !strings.Contains(err.Error(), `version 2.1.0 does not satisfy "^1.0"`) {
+		t.Errorf("err = %v, want constraint violation", err)
+	}
+	src.Version = "^2"
+	cfg.Packs["gastown"] = src
+	if err := checkNamedPackVersions(fs, cfg, "/city"); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+}
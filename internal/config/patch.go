@@ -32,6 +32,10 @@ type AgentPatch struct {
 	Env map[string]string `toml:"env,omitempty"`
 	// EnvRemove lists env var keys to remove after merging.
 	EnvRemove []string `toml:"env_remove,omitempty"`
+	// EnvFile overrides the agent's dotenv secrets file.
+	EnvFile *string `toml:"env_file,omitempty"`
+	// EnvFromKeychain overrides the env vars read from the OS keychain.
+	EnvFromKeychain []string `toml:"env_from_keychain,omitempty"`
 	// PreStart overrides the agent's pre_start commands.
 	PreStart []string `toml:"pre_start,omitempty"`
 	// PromptTemplate overrides the prompt template path.
@@ -39,6 +43,8 @@ type AgentPatch struct {
 	PromptTemplate *string `toml:"prompt_template,omitempty"`
 	// Session overrides the session transport ("acp").
 	Session *string `toml:"session,omitempty"`
+	// Host overrides the remote SSH host ("" runs locally).
+	Host *string `toml:"host,omitempty"`
 	// Provider overrides the provider name.
 	Provider *string `toml:"provider,omitempty"`
 	// StartCommand overrides the start command.
@@ -47,6 +53,8 @@ type AgentPatch struct {
 	Nudge *string `toml:"nudge,omitempty"`
 	// IdleTimeout overrides the idle timeout. Duration string (e.g., "30s", "5m", "1h").
 	IdleTimeout *string `toml:"idle_timeout,omitempty"`
+	// RateLimit overrides the agent's rate_limit ("count/period").
+	RateLimit *string `toml:"rate_limit,omitempty"`
 	// InstallAgentHooks overrides the agent's install_agent_hooks list.
 	InstallAgentHooks []string `toml:"install_agent_hooks,omitempty"`
 	// HooksInstalled overrides automatic hook detection.
@@ -64,6 +72,10 @@ type AgentPatch struct {
 	OverlayDir *string `toml:"overlay_dir,omitempty"`
 	// DefaultSlingFormula overrides the default sling formula.
 	DefaultSlingFormula *string `toml:"default_sling_formula,omitempty"`
+	// Capabilities overrides the agent's capabilities list.
+	Capabilities []string `toml:"capabilities,omitempty"`
+	// Gates overrides the agent's close gates.
+	Gates []string `toml:"gates,omitempty"`
 	// InjectFragments overrides the agent's inject_fragments list.
 	InjectFragments []string `toml:"inject_fragments,omitempty"`
 	// Attach overrides the agent's attach setting.
@@ -124,7 +136,7 @@ type ProviderPatch struct {
 	// Args overrides the provider args.
 	Args []string `toml:"args,omitempty"`
 	// PromptMode overrides prompt delivery mode.
-	PromptMode *string `toml:"prompt_mode,omitempty" jsonschema:"enum=arg,enum=flag,enum=none"`
+	PromptMode *string `toml:"prompt_mode,omitempty" jsonschema:"enum=arg,enum=flag,enum=stdin,enum=none"`
 	// PromptFlag overrides the prompt flag.
 	PromptFlag *string `toml:"prompt_flag,omitempty"`
 	// ReadyDelayMs overrides the ready delay in milliseconds.
@@ -200,6 +212,9 @@ func applyAgentPatchFields(a *Agent, p *AgentPatch) {
 	if p.Session != nil {
 		a.Session = *p.Session
 	}
+	if p.Host != nil {
+		a.Host = *p.Host
+	}
 	if p.Provider != nil {
 		a.Provider = *p.Provider
 	}
@@ -212,6 +227,9 @@ func applyAgentPatchFields(a *Agent, p *AgentPatch) {
 	if p.IdleTimeout != nil {
 		a.IdleTimeout = *p.IdleTimeout
 	}
+	if p.RateLimit != nil {
+		a.RateLimit = *p.RateLimit
+	}
 	if len(p.InstallAgentHooks) > 0 {
 		a.InstallAgentHooks = append([]string(nil), p.InstallAgentHooks...)
 	}
@@ -242,6 +260,12 @@ func applyAgentPatchFields(a *Agent, p *AgentPatch) {
 	if p.DefaultSlingFormula != nil {
 		a.DefaultSlingFormula = *p.DefaultSlingFormula
 	}
+	if len(p.Capabilities) > 0 {
+		a.Capabilities = append([]string(nil), p.Capabilities...)
+	}
+	if len(p.Gates) > 0 {
+		a.Gates = append([]string(nil), p.Gates...)
+	}
 	if p.Attach != nil {
 		a.Attach = p.Attach
 	}
@@ -258,6 +282,12 @@ func applyAgentPatchFields(a *Agent, p *AgentPatch) {
 	if p.WakeMode != nil {
 		a.WakeMode = *p.WakeMode
 	}
+	if p.EnvFile != nil {
+		a.EnvFile = *p.EnvFile
+	}
+	if len(p.EnvFromKeychain) > 0 {
+		a.EnvFromKeychain = append([]string(nil), p.EnvFromKeychain...)
+	}
 	if len(p.InjectFragments) > 0 {
 		a.InjectFragments = append([]string(nil), p.InjectFragments...)
 	}
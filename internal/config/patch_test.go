@@ -151,6 +151,7 @@ func TestApplyPatches_AgentScalars(t *testing.T) {
 				PromptTemplate: ptrStr("new.md"),
 				Provider:       ptrStr("gemini"),
 				PreStart:       []string{"echo setup"},
+				Capabilities:   []string{"go", "docs"},
 			},
 		},
 	})
@@ -167,6 +168,9 @@ func TestApplyPatches_AgentScalars(t *testing.T) {
 	if len(a.PreStart) != 1 || a.PreStart[0] != "echo setup" {
 		t.Errorf("PreStart = %v, want [echo setup]", a.PreStart)
 	}
+	if !sliceEqual(a.Capabilities, []string{"go", "docs"}) {
+		t.Errorf("Capabilities = %v, want [go docs]", a.Capabilities)
+	}
 }
 
 func TestApplyPatches_AgentNotFound(t *testing.T) {
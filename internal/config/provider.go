@@ -32,14 +32,25 @@ type ProviderSpec struct {
 	Command string `toml:"command,omitempty"`
 	// Args are default command-line arguments passed to the provider.
 	Args []string `toml:"args,omitempty"`
-	// PromptMode controls how prompts are delivered: "arg", "flag", or "none".
-	PromptMode string `toml:"prompt_mode,omitempty" jsonschema:"enum=arg,enum=flag,enum=none,default=arg"`
+	// PromptMode controls how prompts are delivered: "arg", "flag", "stdin",
+	// or "none". "stdin" applies to headless runs only; interactive sessions
+	// treat it like "none".
+	PromptMode string `toml:"prompt_mode,omitempty" jsonschema:"enum=arg,enum=flag,enum=stdin,enum=none,default=arg"`
 	// PromptFlag is the CLI flag used when prompt_mode is "flag" (e.g. "--prompt").
 	PromptFlag string `toml:"prompt_flag,omitempty"`
+	// PrintArgs are appended after args to run the provider non-interactively:
+	// it answers the prompt, prints the reply, and exits (e.g. ["-p"] for
+	// claude). Used by gc agent exec; empty means the provider has no such mode.
+	PrintArgs []string `toml:"print_args,omitempty"`
 	// ReadyDelayMs is milliseconds to wait after launch before the provider is considered ready.
 	ReadyDelayMs int `toml:"ready_delay_ms,omitempty" jsonschema:"minimum=0"`
 	// ReadyPromptPrefix is the string prefix that indicates the provider is ready for input.
 	ReadyPromptPrefix string `toml:"ready_prompt_prefix,omitempty"`
+	// ReadyProbe checks that the provider is accepting input: a regular
+	// expression matched against the session's recent output, or
+	// "exec:<command>" that must exit 0. Takes precedence over
+	// ready_prompt_prefix and ready_delay_ms.
+	ReadyProbe string `toml:"ready_probe,omitempty"`
 	// ProcessNames lists process names to look for when checking if the provider is running.
 	ProcessNames []string `toml:"process_names,omitempty"`
 	// EmitsPermissionWarning indicates whether the provider emits permission prompts.
@@ -99,8 +110,10 @@ type ResolvedProvider struct {
 	Args                   []string
 	PromptMode             string
 	PromptFlag             string
+	PrintArgs              []string
 	ReadyDelayMs           int
 	ReadyPromptPrefix      string
+	ReadyProbe             string
 	ProcessNames           []string
 	EmitsPermissionWarning bool
 	Env                    map[string]string
@@ -159,6 +172,7 @@ func BuiltinProviders() map[string]ProviderSpec {
 			Command:                "claude",
 			Args:                   []string{"--dangerously-skip-permissions"},
 			PromptMode:             "arg",
+			PrintArgs:              []string{"-p"},
 			ReadyDelayMs:           10000,
 			ReadyPromptPrefix:      "\u276f ", // ❯
 			ProcessNames:           []string{"node", "claude"},
@@ -248,6 +262,7 @@ func BuiltinProviders() map[string]ProviderSpec {
 			Command:          "gemini",
 			Args:             []string{"--approval-mode", "yolo"},
 			PromptMode:       "arg",
+			PrintArgs:        []string{"-p"},
 			ReadyDelayMs:     5000,
 			ProcessNames:     []string{"gemini"},
 			SupportsHooks:    true,
@@ -285,6 +300,7 @@ func BuiltinProviders() map[string]ProviderSpec {
 			Command:          "cursor-agent",
 			Args:             []string{"-f"},
 			PromptMode:       "arg",
+			PrintArgs:        []string{"-p"},
 			ProcessNames:     []string{"cursor-agent"},
 			SupportsHooks:    true,
 			InstructionsFile: "AGENTS.md",
@@ -294,6 +310,7 @@ func BuiltinProviders() map[string]ProviderSpec {
 			Command:           "copilot",
 			Args:              []string{"--yolo"},
 			PromptMode:        "arg",
+			PrintArgs:         []string{"-p"},
 			ReadyPromptPrefix: "\u276f ", // ❯
 			ReadyDelayMs:      5000,
 			ProcessNames:      []string{"copilot"},
@@ -305,6 +322,7 @@ func BuiltinProviders() map[string]ProviderSpec {
 			Command:          "amp",
 			Args:             []string{"--dangerously-allow-all", "--no-ide"},
 			PromptMode:       "arg",
+			PrintArgs:        []string{"-x"},
 			ProcessNames:     []string{"amp"},
 			InstructionsFile: "AGENTS.md",
 		},
@@ -313,6 +331,7 @@ func BuiltinProviders() map[string]ProviderSpec {
 			Command:          "opencode",
 			Args:             []string{},
 			PromptMode:       "arg",
+			PrintArgs:        []string{"run"},
 			ReadyDelayMs:     8000,
 			ProcessNames:     []string{"opencode", "node", "bun"},
 			Env:              map[string]string{"OPENCODE_PERMISSION": `{"*":"allow"}`},
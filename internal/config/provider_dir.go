@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// ProvidersDirName is the directory of drop-in provider definitions, one
+// <name>.toml file per provider. It is read from the user's config
+// directory and from the city root.
+const ProvidersDirName = "providers.d"
+
+// userProvidersDir returns the user-level providers.d directory:
+// $XDG_CONFIG_HOME/gascity/providers.d, else ~/.config/gascity/providers.d.
+// A variable so tests can isolate themselves from the real home directory.
+var userProvidersDir = func() string {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "gascity", ProvidersDirName)
+}
+
+// LoadProviderDir reads every *.toml file in dir as a ProviderSpec named
+// after the file ("aider.toml" defines provider "aider"). The file holds
+// the same keys as a [providers.<name>] table. A missing directory yields
+// no providers.
+func LoadProviderDir(fs fsys.FS, dir string) (map[string]ProviderSpec, error) {
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".toml") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	specs := make(map[string]ProviderSpec, len(names))
+	for _, n := range names {
+		path := filepath.Join(dir, n)
+		data, err := fs.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading provider %q: %w", path, err)
+		}
+		var spec ProviderSpec
+		md, err := toml.Decode(string(data), &spec)
+		if err != nil {
+			return nil, fmt.Errorf("parsing provider %q: %w", path, err)
+		}
+		if undec := md.Undecoded(); len(undec) > 0 {
+			return nil, fmt.Errorf("provider %q: unknown field %q", path, undec[0].String())
+		}
+		specs[strings.TrimSuffix(n, ".toml")] = spec
+	}
+	return specs, nil
+}
+
+// mergeProviderDirs adds drop-in providers from the user and city
+// providers.d directories to cfg.Providers. Precedence, highest first:
+// [providers.*] in city config, the city's providers.d, the user's
+// providers.d. Drop-ins replace built-in presets of the same name.
+func mergeProviderDirs(fs fsys.FS, cfg *City, cityRoot string) error {
+	var dirs []string
+	if d := userProvidersDir(); d != "" {
+		dirs = append(dirs, d)
+	}
+	dirs = append(dirs, filepath.Join(cityRoot, ProvidersDirName))
+
+	dropIns := make(map[string]ProviderSpec)
+	for _, dir := range dirs {
+		specs, err := LoadProviderDir(fs, dir)
+		if err != nil {
+			return err
+		}
+		for name, spec := range specs {
+			dropIns[name] = spec
+		}
+	}
+	if len(dropIns) == 0 {
+		return nil
+	}
+	if cfg.Providers == nil {
+		cfg.Providers = make(map[string]ProviderSpec, len(dropIns))
+	}
+	for name, spec := range dropIns {
+		if _, ok := cfg.Providers[name]; !ok {
+			cfg.Providers[name] = spec
+		}
+	}
+	return nil
+}
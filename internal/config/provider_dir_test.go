@@ -0,0 +1,88 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func withUserProvidersDir(t *testing.T, dir string) {
+	t.Helper()
+	old := userProvidersDir
+	userProvidersDir = func() string { return dir }
+	t.Cleanup(func() { userProvidersDir = old })
+}
+
+func TestLoadProviderDir(t *testing.T) {
+	fs := fsys.NewFake()
+	fs.Files["/p/aider.toml"] = []byte(`
+command = "aider"
+args = ["--yes"]
+prompt_mode = "none"
+ready_delay_ms = 2000
+process_names = ["python", "aider"]
+`)
+	fs.Files["/p/README.md"] = []byte("ignored")
+
+	specs, err := LoadProviderDir(fs, "/p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 1 {
+		t.Fatalf("specs = %v, want only aider", specs)
+	}
+	a := specs["aider"]
+	if a.Command != "aider" || a.PromptMode != "none" || a.ReadyDelayMs != 2000 || strings.Join(a.ProcessNames, ",") != "python,aider" {
+		t.Errorf("aider = %+v", a)
+	}
+
+	if specs, err := LoadProviderDir(fs, "/missing"); err != nil || len(specs) != 0 {
+		t.Errorf("missing dir = %v, %v", specs, err)
+	}
+
+	fs.Files["/p/typo.toml"] = []byte(`comand = "x"`)
+	if _, err := LoadProviderDir(fs, "/p"); err == nil || !strings.Contains(err.Error(), "comand") {
+		t.Errorf("unknown field err = %v", err)
+	}
+}
+
+func TestLoadWithIncludesProvidersDir(t *testing.T) {
+	withUserProvidersDir(t, "/home/u/.config/gascity/providers.d")
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte(`
+[workspace]
+name = "test"
+
+[providers.inline]
+command = "from-city-toml"
+`)
+	fs.Files["/home/u/.config/gascity/providers.d/aider.toml"] = []byte(`command = "user-aider"`)
+	fs.Files["/home/u/.config/gascity/providers.d/goose.toml"] = []byte(`command = "goose"`)
+	fs.Files["/city/providers.d/aider.toml"] = []byte(`command = "city-aider"`)
+	fs.Files["/city/providers.d/inline.toml"] = []byte(`command = "from-drop-in"`)
+
+	cfg, _, err := LoadWithIncludes(fs, "/city/city.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"aider":  "city-aider",     // city providers.d beats user providers.d
+		"goose":  "goose",          // user-only drop-in
+		"inline": "from-city-toml", // city.toml beats drop-ins
+	} {
+		if got := cfg.Providers[name].Command; got != want {
+			t.Errorf("providers[%s].command = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLoadWithIncludesProvidersDirBadFile(t *testing.T) {
+	withUserProvidersDir(t, "")
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte("[workspace]\nname = \"test\"\n")
+	fs.Files["/city/providers.d/bad.toml"] = []byte("command = [")
+	if _, _, err := LoadWithIncludes(fs, "/city/city.toml"); err == nil || !strings.Contains(err.Error(), "bad.toml") {
+		t.Errorf("err = %v, want parse error naming bad.toml", err)
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitConfig caps how often gc invokes agent CLIs. Session starts
+// and prompt nudges each spend one token from a bucket that holds a
+// period's worth of tokens and refills evenly over the period.
+// Progressive activation: absent or empty = unlimited.
+type RateLimitConfig struct {
+	// Rate is the city-wide budget shared by every agent, as
+	// "count/period": "30/m", "500/h", or "10/30s". Period is s, m, h, or
+	// a duration string. Empty (default) means unlimited.
+	Rate string `toml:"rate,omitempty"`
+}
+
+// ParseRate parses a "count/period" rate such as "30/m" or "10/30s".
+// The period is "s", "m", "h", or a duration string. Count and period
+// must be positive.
+func ParseRate(s string) (int, time.Duration, error) {
+	countStr, periodStr, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("rate %q: want count/period (e.g., 30/m)", s)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(countStr))
+	if err != nil || count <= 0 {
+		return 0, 0, fmt.Errorf("rate %q: count must be a positive integer", s)
+	}
+	periodStr = strings.TrimSpace(periodStr)
+	var period time.Duration
+	switch periodStr {
+	case "s":
+		period = time.Second
+	case "m":
+		period = time.Minute
+	case "h":
+		period = time.Hour
+	default:
+		period, err = time.ParseDuration(periodStr)
+		if err != nil || period <= 0 {
+			return 0, 0, fmt.Errorf("rate %q: period must be s, m, h, or a positive duration", s)
+		}
+	}
+	return count, period, nil
+}
+
+// ValidateRateLimits checks [rate_limit] rate and every agent's
+// rate_limit parse.
+func ValidateRateLimits(cfg *City) error {
+	if cfg.RateLimit.Rate != "" {
+		if _, _, err := ParseRate(cfg.RateLimit.Rate); err != nil {
+			return fmt.Errorf("[rate_limit]: %w", err)
+		}
+	}
+	for _, a := range cfg.Agents {
+		if a.RateLimit == "" {
+			continue
+		}
+		if _, _, err := ParseRate(a.RateLimit); err != nil {
+			return fmt.Errorf("agent %q: rate_limit: %w", a.QualifiedName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		count int
+		per   time.Duration
+	}{
+		{"30/m", 30, time.Minute},
+		{"1/s", 1, time.Second},
+		{"500/h", 500, time.Hour},
+		{"10/30s", 10, 30 * time.Second},
+		{" 4 / 5m ", 4, 5 * time.Minute},
+	} {
+		n, per, err := ParseRate(tc.in)
+		if err != nil || n != tc.count || per != tc.per {
+			t.Errorf("ParseRate(%q) = %d, %v, %v; want %d, %v", tc.in, n, per, err, tc.count, tc.per)
+		}
+	}
+	for _, bad := range []string{"30", "0/m", "-1/m", "x/m", "5/fortnight", "5/0s", "5/-1m"} {
+		if _, _, err := ParseRate(bad); err == nil {
+			t.Errorf("ParseRate(%q) = nil error", bad)
+		}
+	}
+}
+
+func TestValidateRateLimits(t *testing.T) {
+	cfg := &City{
+		RateLimit: RateLimitConfig{Rate: "30/m"},
+		Agents:    []Agent{{Name: "mayor"}, {Name: "polecat", Dir: "rig", RateLimit: "4/m"}},
+	}
+	if err := ValidateRateLimits(cfg); err != nil {
+		t.Fatalf("ValidateRateLimits(valid) = %v", err)
+	}
+
+	cfg.RateLimit.Rate = "lots"
+	if err := ValidateRateLimits(cfg); err == nil || !strings.Contains(err.Error(), "[rate_limit]") {
+		t.Errorf("bad city rate: err = %v", err)
+	}
+	cfg.RateLimit.Rate = ""
+	cfg.Agents[1].RateLimit = "4"
+	if err := ValidateRateLimits(cfg); err == nil || !strings.Contains(err.Error(), `agent "rig/polecat"`) {
+		t.Errorf("bad agent rate: err = %v", err)
+	}
+}
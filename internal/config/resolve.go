@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -47,7 +48,7 @@ func ResolveProvider(agent *Agent, ws *Workspace, cityProviders map[string]Provi
 			return &ResolvedProvider{Command: ws.StartCommand, PromptMode: "arg"}, nil
 		}
 		// Auto-detect: scan PATH for known binaries.
-		detected, err := detectProviderName(lookPath)
+		detected, err := detectProviderName(cityProviders, lookPath)
 		if err != nil {
 			return nil, err
 		}
@@ -112,13 +113,30 @@ func lookupProvider(name string, cityProviders map[string]ProviderSpec, lookPath
 	return nil, fmt.Errorf("%w: %q", ErrProviderNotFound, name)
 }
 
-// detectProviderName scans PATH for known built-in provider binaries.
-// Returns the first found in priority order (see BuiltinProviderOrder).
-func detectProviderName(lookPath LookPathFunc) (string, error) {
+// detectProviderName scans PATH for provider binaries and returns the
+// first found: built-ins in priority order (see BuiltinProviderOrder),
+// then city-defined providers by name. A city spec for a built-in name
+// that sets a command is checked in place of the preset, so the
+// overridden command is the binary looked for.
+func detectProviderName(cityProviders map[string]ProviderSpec, lookPath LookPathFunc) (string, error) {
 	builtins := BuiltinProviders()
 	order := BuiltinProviderOrder()
+	custom := make([]string, 0, len(cityProviders))
+	for name := range cityProviders {
+		if _, ok := builtins[name]; !ok {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+	order = append(order, custom...)
 	for _, name := range order {
-		spec := builtins[name]
+		spec, ok := cityProviders[name]
+		if !ok || spec.pathCheckBinary() == "" {
+			spec = builtins[name]
+		}
+		if spec.pathCheckBinary() == "" {
+			continue
+		}
 		if _, err := lookPath(spec.pathCheckBinary()); err == nil {
 			return name, nil
 		}
@@ -135,6 +153,7 @@ func specToResolved(name string, spec *ProviderSpec) *ResolvedProvider {
 		PromptFlag:             spec.PromptFlag,
 		ReadyDelayMs:           spec.ReadyDelayMs,
 		ReadyPromptPrefix:      spec.ReadyPromptPrefix,
+		ReadyProbe:             spec.ReadyProbe,
 		EmitsPermissionWarning: spec.EmitsPermissionWarning,
 		SupportsACP:            spec.SupportsACP,
 		SupportsHooks:          spec.SupportsHooks,
@@ -170,6 +189,10 @@ func specToResolved(name string, spec *ProviderSpec) *ResolvedProvider {
 		rp.Args = make([]string, len(spec.Args))
 		copy(rp.Args, spec.Args)
 	}
+	if len(spec.PrintArgs) > 0 {
+		rp.PrintArgs = make([]string, len(spec.PrintArgs))
+		copy(rp.PrintArgs, spec.PrintArgs)
+	}
 	if len(spec.ProcessNames) > 0 {
 		rp.ProcessNames = make([]string, len(spec.ProcessNames))
 		copy(rp.ProcessNames, spec.ProcessNames)
@@ -235,6 +258,9 @@ func mergeAgentOverrides(rp *ResolvedProvider, agent *Agent) {
 	if agent.ReadyPromptPrefix != "" {
 		rp.ReadyPromptPrefix = agent.ReadyPromptPrefix
 	}
+	if agent.ReadyProbe != "" {
+		rp.ReadyProbe = agent.ReadyProbe
+	}
 	if len(agent.ProcessNames) > 0 {
 		rp.ProcessNames = make([]string, len(agent.ProcessNames))
 		copy(rp.ProcessNames, agent.ProcessNames)
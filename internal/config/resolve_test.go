@@ -62,6 +62,26 @@ func TestResolveProviderAgentProvider(t *testing.T) {
 	}
 }
 
+func TestResolveProviderPrintArgs(t *testing.T) {
+	agent := &Agent{Name: "mayor", Provider: "claude"}
+	rp, err := ResolveProvider(agent, nil, nil, lookPathOnly("claude"))
+	if err != nil {
+		t.Fatalf("ResolveProvider: %v", err)
+	}
+	if len(rp.PrintArgs) != 1 || rp.PrintArgs[0] != "-p" {
+		t.Errorf("PrintArgs = %v, want [-p]", rp.PrintArgs)
+	}
+
+	agent = &Agent{Name: "worker", Provider: "codex"}
+	rp, err = ResolveProvider(agent, nil, nil, lookPathOnly("codex"))
+	if err != nil {
+		t.Fatalf("ResolveProvider: %v", err)
+	}
+	if len(rp.PrintArgs) != 0 {
+		t.Errorf("codex PrintArgs = %v, want none", rp.PrintArgs)
+	}
+}
+
 func TestResolveProviderWorkspaceProvider(t *testing.T) {
 	agent := &Agent{Name: "worker"}
 	ws := &Workspace{Name: "city", Provider: "codex"}
@@ -326,7 +346,7 @@ func TestResolveProviderDefaultPromptModeWhenEmpty(t *testing.T) {
 // --- detectProviderName ---
 
 func TestDetectProviderNameClaude(t *testing.T) {
-	name, err := detectProviderName(lookPathOnly("claude"))
+	name, err := detectProviderName(nil, lookPathOnly("claude"))
 	if err != nil {
 		t.Fatalf("detectProviderName: %v", err)
 	}
@@ -336,7 +356,7 @@ func TestDetectProviderNameClaude(t *testing.T) {
 }
 
 func TestDetectProviderNameFallbackToCodex(t *testing.T) {
-	name, err := detectProviderName(lookPathOnly("codex"))
+	name, err := detectProviderName(nil, lookPathOnly("codex"))
 	if err != nil {
 		t.Fatalf("detectProviderName: %v", err)
 	}
@@ -346,12 +366,53 @@ func TestDetectProviderNameFallbackToCodex(t *testing.T) {
 }
 
 func TestDetectProviderNameNone(t *testing.T) {
-	_, err := detectProviderName(lookPathNone)
+	_, err := detectProviderName(nil, lookPathNone)
 	if err == nil {
 		t.Fatal("expected error when no provider found")
 	}
 }
 
+func TestDetectProviderNameCityOverride(t *testing.T) {
+	city := map[string]ProviderSpec{"claude": {Command: "/opt/claude/bin/claude-wrapper"}}
+	name, err := detectProviderName(city, lookPathOnly("/opt/claude/bin/claude-wrapper"))
+	if err != nil || name != "claude" {
+		t.Errorf("detectProviderName = %q, %v; want claude via overridden command", name, err)
+	}
+	// Overriding only non-command fields still detects the preset binary.
+	name, err = detectProviderName(map[string]ProviderSpec{"claude": {Env: map[string]string{"X": "1"}}}, lookPathOnly("claude"))
+	if err != nil || name != "claude" {
+		t.Errorf("detectProviderName = %q, %v; want claude via preset binary", name, err)
+	}
+}
+
+func TestDetectProviderNameCustomProvider(t *testing.T) {
+	city := map[string]ProviderSpec{
+		"zeta":  {Command: "zeta"},
+		"aider": {Command: "aider"},
+	}
+	name, err := detectProviderName(city, lookPathOnly("aider", "zeta"))
+	if err != nil || name != "aider" {
+		t.Errorf("detectProviderName = %q, %v; want aider (custom, sorted)", name, err)
+	}
+	// Built-ins still come first.
+	name, _ = detectProviderName(city, lookPathOnly("aider", "codex"))
+	if name != "codex" {
+		t.Errorf("detectProviderName = %q, want codex before custom providers", name)
+	}
+}
+
+func TestResolveProviderAutoDetectCustom(t *testing.T) {
+	agent := &Agent{Name: "worker"}
+	city := map[string]ProviderSpec{"aider": {Command: "aider", Args: []string{"--yes"}}}
+	rp, err := ResolveProvider(agent, &Workspace{}, city, lookPathOnly("aider"))
+	if err != nil {
+		t.Fatalf("ResolveProvider: %v", err)
+	}
+	if rp.Name != "aider" || rp.CommandString() != "aider --yes" {
+		t.Errorf("resolved = %s %q", rp.Name, rp.CommandString())
+	}
+}
+
 // --- lookupProvider ---
 
 func TestLookupProviderBuiltin(t *testing.T) {
@@ -633,3 +694,25 @@ func TestResolveProviderResumeCommandAgentOverride(t *testing.T) {
 		t.Errorf("ResumeFlag = %q, want %q (builtin preserved)", rp.ResumeFlag, "--resume")
 	}
 }
+
+func TestResolveProviderAgentReadyProbeOverridesProvider(t *testing.T) {
+	cityProviders := map[string]ProviderSpec{
+		"aider": {Command: "aider", ReadyProbe: "regex:^> $"},
+	}
+	rp, err := ResolveProvider(&Agent{Name: "scout", Provider: "aider"}, nil, cityProviders, lookPathOnly("aider"))
+	if err != nil {
+		t.Fatalf("ResolveProvider: %v", err)
+	}
+	if rp.ReadyProbe != "regex:^> $" {
+		t.Errorf("ReadyProbe = %q, want provider's %q", rp.ReadyProbe, "regex:^> $")
+	}
+
+	agent := &Agent{Name: "scout", Provider: "aider", ReadyProbe: "exec:test -f /tmp/ready"}
+	rp, err = ResolveProvider(agent, nil, cityProviders, lookPathOnly("aider"))
+	if err != nil {
+		t.Fatalf("ResolveProvider: %v", err)
+	}
+	if rp.ReadyProbe != "exec:test -f /tmp/ready" {
+		t.Errorf("ReadyProbe = %q, want agent's %q", rp.ReadyProbe, "exec:test -f /tmp/ready")
+	}
+}
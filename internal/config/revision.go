@@ -80,6 +80,9 @@ func WatchDirs(prov *Provenance, cfg *City, cityRoot string) []string {
 		for _, src := range prov.Sources {
 			addDir(filepath.Dir(src))
 		}
+		for _, dir := range prov.IncludeDirs {
+			addDir(dir)
+		}
 	}
 
 	// Rig pack directories (all pack sources).
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// RigFile is the rig-local config at <city>/rigs/<name>/rig.toml. Its
+// contents merge into the matching [[rigs]] entry so rig-specific agents,
+// packs, and overrides can live next to each other instead of in
+// city.toml.
+type RigFile struct {
+	// Includes lists pack directories or URLs appended to the rig's
+	// includes.
+	Includes []string `toml:"includes,omitempty"`
+	// Overrides are appended to the rig's per-agent pack overrides.
+	Overrides []AgentOverride `toml:"overrides,omitempty"`
+	// DefaultSlingTarget replaces the rig's default_sling_target when set.
+	DefaultSlingTarget string `toml:"default_sling_target,omitempty"`
+	// Agents are rig-scoped agents. Dir defaults to the rig name and may
+	// not name another rig.
+	Agents []Agent `toml:"agent,omitempty"`
+}
+
+// RigFilePath returns the path of rigName's rig.toml under cityRoot.
+func RigFilePath(cityRoot, rigName string) string {
+	return filepath.Join(cityRoot, citylayout.RigsRoot, rigName, citylayout.RigFileName)
+}
+
+// mergeRigFiles merges each rig's rig.toml (when present) into root.
+// Runs after fragments and before pack expansion, so rig.toml includes
+// and overrides take part in expansion like city.toml ones.
+func mergeRigFiles(fs fsys.FS, root *City, cityRoot string, prov *Provenance) error {
+	for i := range root.Rigs {
+		rig := &root.Rigs[i]
+		path := RigFilePath(cityRoot, rig.Name)
+		if fi, err := fs.Stat(filepath.Dir(path)); err == nil && fi.IsDir() {
+			prov.IncludeDirs = append(prov.IncludeDirs, filepath.Dir(path))
+		}
+		data, err := fs.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return fmt.Errorf("loading rig file %q: %w", path, err)
+		}
+		var rf RigFile
+		md, err := toml.Decode(string(data), &rf)
+		if err != nil {
+			return fmt.Errorf("rig file %q: parsing config: %w", path, err)
+		}
+		prov.Warnings = append(prov.Warnings, CheckUndecodedKeys(md, path)...)
+
+		for j := range rf.Agents {
+			a := &rf.Agents[j]
+			if a.Dir == "" {
+				a.Dir = rig.Name
+			}
+			if a.Dir != rig.Name {
+				return fmt.Errorf("rig file %q: agent %q: dir must be %q", path, a.Name, rig.Name)
+			}
+		}
+		frag := &City{Agents: rf.Agents}
+		if err := checkFragmentDuplicates(frag, path, prov); err != nil {
+			return err
+		}
+		adjustAgentPaths(rf.Agents, filepath.Dir(path), cityRoot)
+		trackAgents(prov, rf.Agents, path)
+		root.Agents = append(root.Agents, rf.Agents...)
+
+		rig.Includes = append(rig.Includes, rf.Includes...)
+		rig.Overrides = append(rig.Overrides, rf.Overrides...)
+		if rf.DefaultSlingTarget != "" {
+			rig.DefaultSlingTarget = rf.DefaultSlingTarget
+		}
+		prov.Sources = append(prov.Sources, path)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestLoadWithIncludes_RigFile(t *testing.T) {
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte(`
+[workspace]
+name = "test"
+
+[[rigs]]
+name = "app"
+path = "/src/app"
+includes = ["packs/base"]
+
+[[rigs]]
+name = "docs"
+path = "/src/docs"
+`)
+	fs.Files["/city/packs/base/pack.toml"] = []byte("[pack]\nname = \"base\"\nschema = 1\n\n[[agent]]\nname = \"polecat\"\n")
+	fs.Files["/city/packs/extra/pack.toml"] = []byte("[pack]\nname = \"extra\"\nschema = 1\n\n[[agent]]\nname = \"reviewer\"\n")
+	fs.Dirs["/city/rigs/app"] = true
+	fs.Files["/city/rigs/app/rig.toml"] = []byte(`
+includes = ["packs/extra"]
+default_sling_target = "app/coder"
+
+[[overrides]]
+agent = "polecat"
+suspended = true
+
+[[agent]]
+name = "coder"
+prompt_template = "coder.md"
+`)
+
+	cfg, prov, err := LoadWithIncludes(fs, "/city/city.toml")
+	if err != nil {
+		t.Fatalf("LoadWithIncludes: %v", err)
+	}
+	byName := make(map[string]Agent)
+	for _, a := range cfg.Agents {
+		byName[a.QualifiedName()] = a
+	}
+	coder, ok := byName["app/coder"]
+	if !ok {
+		t.Fatalf("app/coder not merged; agents = %+v", cfg.Agents)
+	}
+	if _, ok := byName["app/reviewer"]; !ok {
+		t.Error("rig.toml include not expanded: app/reviewer missing")
+	}
+	if !byName["app/polecat"].Suspended {
+		t.Error("rig.toml override not applied: app/polecat not suspended")
+	}
+	if coder.PromptTemplate != "rigs/app/coder.md" {
+		t.Errorf("prompt_template = %q, want rig-file-relative rigs/app/coder.md", coder.PromptTemplate)
+	}
+	app := cfg.Rigs[0]
+	if strings.Join(app.Includes, ",") != "packs/base,packs/extra" {
+		t.Errorf("includes = %v", app.Includes)
+	}
+	if len(app.Overrides) != 1 || app.Overrides[0].Agent != "polecat" || app.DefaultSlingTarget != "app/coder" {
+		t.Errorf("rig = %+v", app)
+	}
+	if prov.Agents["app/coder"] != "/city/rigs/app/rig.toml" {
+		t.Errorf("provenance = %q", prov.Agents["app/coder"])
+	}
+	if len(cfg.Rigs[1].Includes) != 0 {
+		t.Errorf("docs rig picked up %v", cfg.Rigs[1].Includes)
+	}
+}
+
+func TestLoadWithIncludes_RigFileRejectsForeignDir(t *testing.T) {
+	fs := fsys.NewFake()
+	fs.Files["/city/city.toml"] = []byte("[workspace]\nname = \"test\"\n\n[[rigs]]\nname = \"app\"\npath = \"/src/app\"\n")
+	fs.Files["/city/rigs/app/rig.toml"] = []byte("[[agent]]\nname = \"coder\"\ndir = \"docs\"\n")
+	_, _, err := LoadWithIncludes(fs, "/city/city.toml")
+	if err == nil || !strings.Contains(err.Error(), `dir must be "app"`) {
+		t.Errorf("err = %v, want dir mismatch", err)
+	}
+}
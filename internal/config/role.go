@@ -0,0 +1,107 @@
+package config
+
+import "fmt"
+
+// Role is a named agent template. "gc agent add --role <name>" stamps a
+// role's fields into a new [[agent]] block so common agent shapes don't
+// have to be spelled out field by field. Roles are declared with
+// [[roles]] in city.toml; a city role shadows a built-in of the same name.
+type Role struct {
+	// Name is the identifier passed to --role.
+	Name string `toml:"name" jsonschema:"required"`
+	// Description is a human-readable summary shown by gc agent add --help.
+	Description string `toml:"description,omitempty"`
+	// PromptTemplate is the prompt template path (relative to city root)
+	// stamped onto agents created from this role.
+	PromptTemplate string `toml:"prompt_template,omitempty"`
+	// Provider names the provider preset for agents created from this role.
+	Provider string `toml:"provider,omitempty"`
+	// Args overrides the provider's default arguments.
+	Args []string `toml:"args,omitempty"`
+	// WorkQuery is stamped as the agent's work_query.
+	WorkQuery string `toml:"work_query,omitempty"`
+	// SlingQuery is stamped as the agent's sling_query.
+	SlingQuery string `toml:"sling_query,omitempty"`
+	// Env holds extra environment variables for agents created from this role.
+	Env map[string]string `toml:"env,omitempty"`
+}
+
+// builtinRole returns a role whose agents share a role:<name> label queue
+// and use the prompt shipped with gc at prompts/<name>.md.
+func builtinRole(name, description string) Role {
+	return Role{
+		Name:           name,
+		Description:    description,
+		PromptTemplate: "prompts/" + name + ".md",
+		WorkQuery:      "bd ready --label=role:" + name + " --limit=1",
+		SlingQuery:     "bd update {} --add-label=role:" + name,
+	}
+}
+
+// BuiltinRoles returns the role library that ships with gc.
+func BuiltinRoles() []Role {
+	return []Role{
+		builtinRole("reviewer", "reviews changes and files findings as beads"),
+		builtinRole("tester", "writes and runs tests for completed work"),
+		builtinRole("planner", "breaks large requests into dependent beads"),
+		builtinRole("doc-writer", "keeps documentation in step with the code"),
+	}
+}
+
+// FindRole looks name up in the city's roles, then the built-in library.
+// builtin reports whether the result came from the built-in library.
+func FindRole(roles []Role, name string) (role Role, builtin, ok bool) {
+	for _, r := range roles {
+		if r.Name == name {
+			return r, false, true
+		}
+	}
+	for _, r := range BuiltinRoles() {
+		if r.Name == name {
+			return r, true, true
+		}
+	}
+	return Role{}, false, false
+}
+
+// Apply copies the role's fields onto a, leaving fields a already sets.
+func (r Role) Apply(a *Agent) {
+	if a.PromptTemplate == "" {
+		a.PromptTemplate = r.PromptTemplate
+	}
+	if a.Provider == "" {
+		a.Provider = r.Provider
+	}
+	if len(a.Args) == 0 && len(r.Args) > 0 {
+		a.Args = append([]string(nil), r.Args...)
+	}
+	if a.WorkQuery == "" {
+		a.WorkQuery = r.WorkQuery
+	}
+	if a.SlingQuery == "" {
+		a.SlingQuery = r.SlingQuery
+	}
+	for k, v := range r.Env {
+		if a.Env == nil {
+			a.Env = make(map[string]string, len(r.Env))
+		}
+		if _, set := a.Env[k]; !set {
+			a.Env[k] = v
+		}
+	}
+}
+
+// ValidateRoles checks [[roles]] entries for missing and duplicate names.
+func ValidateRoles(roles []Role) error {
+	seen := make(map[string]bool, len(roles))
+	for i, r := range roles {
+		if r.Name == "" {
+			return fmt.Errorf("role[%d]: name is required", i)
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("role %q: duplicate name", r.Name)
+		}
+		seen[r.Name] = true
+	}
+	return nil
+}
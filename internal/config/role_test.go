@@ -0,0 +1,47 @@
+package config
+
+import "testing"
+
+func TestFindRoleCityShadowsBuiltin(t *testing.T) {
+	city := []Role{{Name: "reviewer", Provider: "codex"}}
+	r, builtin, ok := FindRole(city, "reviewer")
+	if !ok || builtin || r.Provider != "codex" {
+		t.Errorf("FindRole = %+v, builtin=%v, ok=%v; want city role", r, builtin, ok)
+	}
+	r, builtin, ok = FindRole(city, "planner")
+	if !ok || !builtin || r.PromptTemplate != "prompts/planner.md" {
+		t.Errorf("FindRole = %+v, builtin=%v, ok=%v; want built-in planner", r, builtin, ok)
+	}
+	if _, _, ok := FindRole(city, "nope"); ok {
+		t.Error("FindRole found unknown role")
+	}
+}
+
+func TestRoleApplyKeepsAgentFields(t *testing.T) {
+	r := Role{
+		PromptTemplate: "prompts/role.md",
+		Provider:       "claude",
+		WorkQuery:      "wq",
+		Env:            map[string]string{"A": "role", "B": "role"},
+	}
+	a := Agent{Name: "x", PromptTemplate: "prompts/mine.md", Env: map[string]string{"A": "agent"}}
+	r.Apply(&a)
+	if a.PromptTemplate != "prompts/mine.md" || a.Provider != "claude" || a.WorkQuery != "wq" {
+		t.Errorf("agent = %+v", a)
+	}
+	if a.Env["A"] != "agent" || a.Env["B"] != "role" {
+		t.Errorf("env = %v, want agent A and role B", a.Env)
+	}
+}
+
+func TestValidateRoles(t *testing.T) {
+	if err := ValidateRoles([]Role{{Name: "a"}, {Name: "b"}}); err != nil {
+		t.Errorf("ValidateRoles = %v", err)
+	}
+	if err := ValidateRoles([]Role{{Name: "a"}, {Name: "a"}}); err == nil {
+		t.Error("duplicate role names accepted")
+	}
+	if err := ValidateRoles([]Role{{}}); err == nil {
+		t.Error("empty role name accepted")
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Route maps beads to a sling target by type, label, or ID prefix.
+// Declared with [[routes]] in city.toml and applied in order by
+// gc sling --auto: the first route whose matchers all match wins. Type
+// and Label accept glob patterns ("area:*").
+type Route struct {
+	// Type matches the bead type (e.g. "bug").
+	Type string `toml:"type,omitempty"`
+	// Label matches if any of the bead's labels match.
+	Label string `toml:"label,omitempty"`
+	// Prefix matches the bead ID prefix, the part before the first dash
+	// ("hw" matches "hw-12"), case-insensitively.
+	Prefix string `toml:"prefix,omitempty"`
+	// Target is the agent or pool the bead is slung to. A comma-separated
+	// list fans containers out as with gc sling.
+	Target string `toml:"target" jsonschema:"required"`
+}
+
+// Matches reports whether a bead with the given ID, type, and labels
+// satisfies every matcher set on the route.
+func (r Route) Matches(id, beadType string, labels []string) bool {
+	if r.Type != "" {
+		if ok, _ := path.Match(r.Type, beadType); !ok {
+			return false
+		}
+	}
+	if r.Prefix != "" {
+		p, _, found := strings.Cut(id, "-")
+		if !found || !strings.EqualFold(p, strings.TrimSuffix(r.Prefix, "-")) {
+			return false
+		}
+	}
+	if r.Label != "" {
+		hit := false
+		for _, l := range labels {
+			if ok, _ := path.Match(r.Label, l); ok {
+				hit = true
+				break
+			}
+		}
+		if !hit {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchRoute returns the first route matching the bead.
+func MatchRoute(routes []Route, id, beadType string, labels []string) (Route, bool) {
+	for _, r := range routes {
+		if r.Matches(id, beadType, labels) {
+			return r, true
+		}
+	}
+	return Route{}, false
+}
+
+// ValidateRoutes checks [[routes]] entries for a missing target, a
+// missing matcher, and malformed glob patterns.
+func ValidateRoutes(routes []Route) error {
+	for i, r := range routes {
+		if strings.TrimSpace(r.Target) == "" {
+			return fmt.Errorf("routes[%d]: target is required", i)
+		}
+		if r.Type == "" && r.Label == "" && r.Prefix == "" {
+			return fmt.Errorf("routes[%d]: needs at least one of type, label, or prefix", i)
+		}
+		if _, err := path.Match(r.Type, ""); err != nil {
+			return fmt.Errorf("routes[%d]: bad type pattern %q: %w", i, r.Type, err)
+		}
+		if _, err := path.Match(r.Label, ""); err != nil {
+			return fmt.Errorf("routes[%d]: bad label pattern %q: %w", i, r.Label, err)
+		}
+	}
+	return nil
+}
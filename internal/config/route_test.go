@@ -0,0 +1,101 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRouteMatches(t *testing.T) {
+	labels := []string{"area:auth", "p1"}
+	for _, tc := range []struct {
+		name  string
+		route Route
+		want  bool
+	}{
+		{"type", Route{Type: "bug"}, true},
+		{"type mismatch", Route{Type: "task"}, false},
+		{"label glob", Route{Label: "area:*"}, true},
+		{"label mismatch", Route{Label: "area:ui"}, false},
+		{"prefix", Route{Prefix: "HW"}, true},
+		{"prefix with dash", Route{Prefix: "hw-"}, true},
+		{"prefix mismatch", Route{Prefix: "h"}, false},
+		{"all must match", Route{Type: "bug", Label: "area:ui"}, false},
+	} {
+		if got := tc.route.Matches("hw-12", "bug", labels); got != tc.want {
+			t.Errorf("%s: Matches = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMatchRouteFirstWins(t *testing.T) {
+	routes := []Route{
+		{Type: "task", Target: "mayor"},
+		{Label: "p1", Target: "hw/polecat"},
+		{Prefix: "hw", Target: "hw/refinery"},
+	}
+	r, ok := MatchRoute(routes, "hw-3", "bug", []string{"p1"})
+	if !ok || r.Target != "hw/polecat" {
+		t.Errorf("MatchRoute = %+v, %v; want hw/polecat", r, ok)
+	}
+	if _, ok := MatchRoute(routes, "fe-1", "bug", nil); ok {
+		t.Error("MatchRoute matched with no applicable route")
+	}
+}
+
+func TestValidateRoutes(t *testing.T) {
+	if err := ValidateRoutes([]Route{{Type: "bug", Target: "mayor"}}); err != nil {
+		t.Errorf("ValidateRoutes = %v", err)
+	}
+	for _, tc := range []struct {
+		route Route
+		want  string
+	}{
+		{Route{Type: "bug"}, "target is required"},
+		{Route{Target: "mayor"}, "at least one of"},
+		{Route{Label: "[", Target: "mayor"}, "bad label pattern"},
+	} {
+		err := ValidateRoutes([]Route{tc.route})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ValidateRoutes(%+v) = %v, want %q", tc.route, err, tc.want)
+		}
+	}
+}
+
+func TestValidateSemanticsRouteTarget(t *testing.T) {
+	cfg := &City{
+		Agents: []Agent{{Name: "mayor"}, {Name: "polecat", Dir: "hw"}},
+		Routes: []Route{
+			{Type: "bug", Target: "hw/polecat"},
+			{Type: "task", Target: "mayor,ghost"},
+		},
+	}
+	w := ValidateSemantics(cfg, "city.toml")
+	if len(w) != 1 || !strings.Contains(w[0], `"ghost"`) {
+		t.Errorf("warnings = %v, want one for ghost", w)
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	cfg, err := Parse([]byte(`
+[workspace]
+name = "test"
+
+[[agent]]
+name = "mayor"
+
+[[routes]]
+type = "bug"
+target = "mayor"
+
+[[routes]]
+prefix = "hw"
+label = "p1"
+target = "mayor"
+`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(cfg.Routes) != 2 || cfg.Routes[1].Prefix != "hw" || cfg.Routes[1].Label != "p1" {
+		t.Errorf("Routes = %+v", cfg.Routes)
+	}
+}
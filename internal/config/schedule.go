@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Schedule is a recurring formula cook declared with [[schedules]] in
+// city.toml. The controller runs each schedule as a cron-gated formula
+// automation, so runs share automation dispatch and history.
+type Schedule struct {
+	// Name identifies the schedule in gc schedule list and run history.
+	Name string `toml:"name" jsonschema:"required"`
+	// Cron is a 5-field schedule: "minute hour day-of-month month
+	// day-of-week". Each field is "*" or a comma-separated list of values.
+	Cron string `toml:"cron" jsonschema:"required"`
+	// Formula is the formula cooked and slung on each run.
+	Formula string `toml:"formula" jsonschema:"required"`
+	// Target is the agent or pool the wisp is slung to.
+	Target string `toml:"target,omitempty"`
+	// Description is shown by gc schedule list.
+	Description string `toml:"description,omitempty"`
+	// Enabled controls whether the schedule runs. Defaults to true.
+	Enabled *bool `toml:"enabled,omitempty"`
+}
+
+// IsEnabled reports whether the schedule is enabled. Defaults to true.
+func (s Schedule) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// cronFieldRanges bounds each of the five cron fields.
+var cronFieldRanges = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// validateCron checks a 5-field cron expression in the subset the
+// automation cron gate understands.
+func validateCron(expr string) error {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return fmt.Errorf("want 5 fields, got %d", len(fields))
+	}
+	for i, f := range fields {
+		if f == "*" {
+			continue
+		}
+		for _, part := range strings.Split(f, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return fmt.Errorf("field %d: %q is not * or a number list", i+1, f)
+			}
+			if n < cronFieldRanges[i][0] || n > cronFieldRanges[i][1] {
+				return fmt.Errorf("field %d: %d out of range %d-%d", i+1, n, cronFieldRanges[i][0], cronFieldRanges[i][1])
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateSchedules checks [[schedules]] entries for missing fields,
+// duplicate names, and malformed cron expressions.
+func ValidateSchedules(schedules []Schedule) error {
+	seen := make(map[string]bool, len(schedules))
+	for i, s := range schedules {
+		if strings.TrimSpace(s.Name) == "" {
+			return fmt.Errorf("schedules[%d]: name is required", i)
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("schedules[%d]: duplicate name %q", i, s.Name)
+		}
+		seen[s.Name] = true
+		if strings.TrimSpace(s.Formula) == "" {
+			return fmt.Errorf("schedule %q: formula is required", s.Name)
+		}
+		if err := validateCron(s.Cron); err != nil {
+			return fmt.Errorf("schedule %q: bad cron %q: %w", s.Name, s.Cron, err)
+		}
+	}
+	return nil
+}
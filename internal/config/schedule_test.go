@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSchedules(t *testing.T) {
+	ok := Schedule{Name: "weekly-triage", Cron: "0 9 * * 1", Formula: "weekly-triage", Target: "mayor"}
+	if err := ValidateSchedules([]Schedule{ok, {Name: "twice", Cron: "0,30 * * * *", Formula: "f"}}); err != nil {
+		t.Fatalf("ValidateSchedules(valid) = %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		s    []Schedule
+		want string
+	}{
+		{"no name", []Schedule{{Cron: "* * * * *", Formula: "f"}}, "name is required"},
+		{"duplicate", []Schedule{ok, ok}, "duplicate name"},
+		{"no formula", []Schedule{{Name: "x", Cron: "* * * * *"}}, "formula is required"},
+		{"short cron", []Schedule{{Name: "x", Cron: "0 9 * *", Formula: "f"}}, "want 5 fields"},
+		{"step syntax", []Schedule{{Name: "x", Cron: "*/5 * * * *", Formula: "f"}}, "not * or a number list"},
+		{"out of range", []Schedule{{Name: "x", Cron: "0 24 * * *", Formula: "f"}}, "out of range"},
+	} {
+		err := ValidateSchedules(tc.s)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want containing %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestScheduleIsEnabled(t *testing.T) {
+	off := false
+	if !(Schedule{}).IsEnabled() {
+		t.Error("schedule without enabled should default to enabled")
+	}
+	if (Schedule{Enabled: &off}).IsEnabled() {
+		t.Error("enabled = false should disable the schedule")
+	}
+}
@@ -32,6 +32,9 @@ func ValidateDurations(cfg *City, source string) []string {
 	// Daemon config durations.
 	check("[daemon]", "patrol_interval", cfg.Daemon.PatrolInterval)
 	check("[daemon]", "restart_window", cfg.Daemon.RestartWindow)
+	check("[daemon]", "heartbeat_timeout", cfg.Daemon.HeartbeatTimeout)
+	check("[daemon]", "claim_ttl", cfg.Daemon.ClaimTTL)
+	check("[mail]", "poll_interval", cfg.Mail.PollInterval)
 	check("[daemon]", "shutdown_timeout", cfg.Daemon.ShutdownTimeout)
 	check("[daemon]", "wisp_gc_interval", cfg.Daemon.WispGCInterval)
 	check("[daemon]", "wisp_ttl", cfg.Daemon.WispTTL)
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ValidateSemantics checks cross-entity semantic constraints in the config
 // and returns warnings for issues that cannot be caught by individual struct
@@ -52,11 +55,11 @@ func ValidateSemantics(cfg *City, source string) []string {
 	// Check PromptMode on city-defined providers.
 	for name, spec := range cfg.Providers {
 		switch spec.PromptMode {
-		case "", "arg", "flag", "none":
+		case "", "arg", "flag", "stdin", "none":
 			// valid
 		default:
 			warnings = append(warnings, fmt.Sprintf(
-				"%s: [providers.%s] prompt_mode must be \"arg\", \"flag\", \"none\", or empty, got %q",
+				"%s: [providers.%s] prompt_mode must be \"arg\", \"flag\", \"stdin\", \"none\", or empty, got %q",
 				source, name, spec.PromptMode))
 		}
 		if spec.PromptMode == "flag" && spec.PromptFlag == "" {
@@ -66,5 +69,59 @@ func ValidateSemantics(cfg *City, source string) []string {
 		}
 	}
 
+	// Check the Jira bridge has a project to create issues in.
+	if j := cfg.Bridge.Jira; j.Project != "" && !j.Enabled() {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: [bridge.jira] project is set but base_url is empty; the bridge is disabled",
+			source))
+	} else if j.Enabled() && j.Project == "" {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: [bridge.jira] project is required when base_url is set",
+			source))
+	}
+
+	// Check the stale-claim action.
+	switch cfg.Daemon.ClaimAction {
+	case "", "unclaim", "flag":
+		// valid
+	default:
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: [daemon] claim_action must be \"unclaim\", \"flag\", or empty, got %q; stale claims will only be flagged",
+			source, cfg.Daemon.ClaimAction))
+	}
+
+	// Check the agent branch mode.
+	switch cfg.Beads.AgentBranches {
+	case "", "manual", "auto":
+		// valid
+	default:
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: [beads] agent_branches must be \"manual\", \"auto\", or empty, got %q; agents will write to main",
+			source, cfg.Beads.AgentBranches))
+	}
+
+	// Check route targets name configured agents or pools.
+	known := make(map[string]bool, 2*len(cfg.Agents))
+	for _, a := range cfg.Agents {
+		known[a.QualifiedName()] = true
+		known[a.Name] = true
+	}
+	for i, r := range cfg.Routes {
+		for _, t := range strings.Split(r.Target, ",") {
+			if t = strings.TrimSpace(t); t != "" && !known[t] {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s: routes[%d]: target %q is not a configured agent or pool",
+					source, i, t))
+			}
+		}
+	}
+	for _, sc := range cfg.Schedules {
+		if sc.Target != "" && !known[sc.Target] {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: schedule %q: target %q is not a configured agent or pool",
+				source, sc.Name, sc.Target))
+		}
+	}
+
 	return warnings
 }
@@ -190,7 +190,7 @@ func TestValidateAgentsPromptModeBadEnum(t *testing.T) {
 }
 
 func TestValidateAgentsPromptModeValidValues(t *testing.T) {
-	for _, mode := range []string{"", "arg", "flag", "none"} {
+	for _, mode := range []string{"", "arg", "flag", "stdin", "none"} {
 		agents := []Agent{
 			{Name: "ok", PromptMode: mode, PromptFlag: "--p"},
 		}
@@ -221,3 +221,36 @@ func TestValidateAgentsPromptFlagWithFlagModeOK(t *testing.T) {
 		t.Errorf("should be valid: %v", err)
 	}
 }
+
+func TestValidateSemanticsJiraBridge(t *testing.T) {
+	cfg := &City{Bridge: BridgeConfig{Jira: JiraBridgeConfig{BaseURL: "https://acme.atlassian.net"}}}
+	warnings := ValidateSemantics(cfg, "city.toml")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "project is required") {
+		t.Errorf("missing project: got %v", warnings)
+	}
+
+	cfg.Bridge.Jira = JiraBridgeConfig{Project: "ENG"}
+	warnings = ValidateSemantics(cfg, "city.toml")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "base_url is empty") {
+		t.Errorf("missing base_url: got %v", warnings)
+	}
+
+	cfg.Bridge.Jira.BaseURL = "https://acme.atlassian.net"
+	if warnings = ValidateSemantics(cfg, "city.toml"); len(warnings) != 0 {
+		t.Errorf("complete config: got %v", warnings)
+	}
+}
+
+func TestValidateSemanticsAgentBranches(t *testing.T) {
+	for _, mode := range []string{"", "manual", "auto"} {
+		cfg := &City{Beads: BeadsConfig{AgentBranches: mode}}
+		if warnings := ValidateSemantics(cfg, "city.toml"); len(warnings) != 0 {
+			t.Errorf("agent_branches = %q: got %v", mode, warnings)
+		}
+	}
+	cfg := &City{Beads: BeadsConfig{AgentBranches: "always"}}
+	warnings := ValidateSemantics(cfg, "city.toml")
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "agent_branches") {
+		t.Errorf("bad mode: got %v", warnings)
+	}
+}
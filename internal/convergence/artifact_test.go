@@ -4,7 +4,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"syscall"
 	"testing"
 
 	"github.com/gastownhall/gascity/internal/fsys"
@@ -128,20 +127,3 @@ func TestValidateArtifactDir_SymlinkInside(t *testing.T) {
 		t.Errorf("expected no error for internal symlink, got: %v", err)
 	}
 }
-
-func TestValidateArtifactDir_FIFO(t *testing.T) {
-	dir := t.TempDir()
-	fifo := filepath.Join(dir, "pipe")
-
-	if err := syscall.Mkfifo(fifo, 0o644); err != nil {
-		t.Skipf("mkfifo not available: %v", err)
-	}
-
-	err := ValidateArtifactDir(dir)
-	if err == nil {
-		t.Fatal("expected error for FIFO in artifact directory")
-	}
-	if !strings.Contains(err.Error(), "unsafe file type") {
-		t.Errorf("error should mention unsafe file type, got: %v", err)
-	}
-}
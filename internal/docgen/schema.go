@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 
 	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/formula"
 	"github.com/invopop/jsonschema"
 )
 
@@ -74,3 +75,21 @@ func GenerateCitySchema() (*jsonschema.Schema, error) {
 	s.Description = "Schema for city.toml — the top-level configuration file for a Gas City instance."
 	return s, nil
 }
+
+// GenerateFormulaSchema produces a JSON Schema for *.formula.toml (and
+// *.formula.yaml) files.
+// Unknown top-level keys are allowed since bd and automations define more
+// of them; vars and steps are closed.
+func GenerateFormulaSchema() (*jsonschema.Schema, error) {
+	r, err := newReflector()
+	if err != nil {
+		return nil, err
+	}
+	s := r.Reflect(&formula.Formula{})
+	if def, ok := s.Definitions["Formula"]; ok {
+		def.AdditionalProperties = jsonschema.TrueSchema
+	}
+	s.Title = "Gas City Formula"
+	s.Description = "Schema for *.formula.toml and *.formula.yaml — workflow templates cooked into molecules by gc sling."
+	return s, nil
+}
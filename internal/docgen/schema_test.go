@@ -57,6 +57,38 @@ func TestGenerateCitySchema(t *testing.T) {
 	}
 }
 
+func TestGenerateFormulaSchema(t *testing.T) {
+	s, err := GenerateFormulaSchema()
+	if err != nil {
+		t.Fatalf("GenerateFormulaSchema: %v", err)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	props := defProperties(t, raw, "Formula")
+	for _, expected := range []string{"formula", "description", "extends", "vars", "steps"} {
+		if _, ok := props[expected]; !ok {
+			t.Errorf("missing Formula property %q", expected)
+		}
+	}
+	stepProps := defProperties(t, raw, "Step")
+	for _, expected := range []string{"id", "title", "description", "needs"} {
+		if _, ok := stepProps[expected]; !ok {
+			t.Errorf("missing Step property %q", expected)
+		}
+	}
+	def := raw["$defs"].(map[string]interface{})["Formula"].(map[string]interface{})
+	if def["additionalProperties"] != true {
+		t.Errorf("Formula additionalProperties = %v, want true", def["additionalProperties"])
+	}
+}
+
 func TestCitySchemaDescriptions(t *testing.T) {
 	s, err := GenerateCitySchema()
 	if err != nil {
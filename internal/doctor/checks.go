@@ -1,13 +1,13 @@
 package doctor
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -140,10 +140,16 @@ func (c *ConfigValidCheck) Fix(_ *CheckContext) error { return nil }
 
 // ConfigRefsCheck validates that file/directory paths referenced in agent
 // config (prompt_template, session_setup_script, overlay_dir) actually exist,
-// and that provider names reference defined providers.
+// and that provider names reference defined providers. Missing prompt
+// templates inside the city can be regenerated as stubs by --fix.
 type ConfigRefsCheck struct {
 	cfg      *config.City
 	cityPath string
+	// PromptStub returns the content to write for a missing prompt
+	// template, given its configured path. Nil disables the fix.
+	PromptStub func(rel string) []byte
+
+	missingPrompts []string // populated by Run for Fix to use
 }
 
 // NewConfigRefsCheck creates a check for config reference validity.
@@ -158,6 +164,7 @@ func (c *ConfigRefsCheck) Name() string { return "config-refs" }
 func (c *ConfigRefsCheck) Run(_ *CheckContext) *CheckResult {
 	r := &CheckResult{Name: c.Name()}
 	var issues []string
+	c.missingPrompts = nil
 
 	for _, a := range c.cfg.Agents {
 		qn := a.QualifiedName()
@@ -165,6 +172,7 @@ func (c *ConfigRefsCheck) Run(_ *CheckContext) *CheckResult {
 			path := citylayout.ResolveReadPath(fsys.OSFS{}, c.cityPath, a.PromptTemplate)
 			if _, err := os.Stat(path); err != nil {
 				issues = append(issues, fmt.Sprintf("agent %q: prompt_template %q not found", qn, a.PromptTemplate))
+				c.missingPrompts = append(c.missingPrompts, a.PromptTemplate)
 			}
 		}
 		if a.SessionSetupScript != "" {
@@ -196,14 +204,51 @@ func (c *ConfigRefsCheck) Run(_ *CheckContext) *CheckResult {
 	r.Status = StatusWarning
 	r.Message = fmt.Sprintf("%d config reference issue(s)", len(issues))
 	r.Details = issues
+	if c.CanFix() && len(c.stubbablePrompts()) > 0 {
+		r.FixHint = "run gc doctor --fix to write stubs for missing prompt templates"
+	}
 	return r
 }
 
-// CanFix returns false — missing files must be created by the user.
-func (c *ConfigRefsCheck) CanFix() bool { return false }
+// CanFix returns true when a prompt stub source is configured. Other
+// missing files must be created by the user.
+func (c *ConfigRefsCheck) CanFix() bool { return c.PromptStub != nil }
+
+// Fix writes a stub for each missing prompt template found by the last
+// Run that lives inside the city.
+func (c *ConfigRefsCheck) Fix(_ *CheckContext) error {
+	for _, rel := range c.stubbablePrompts() {
+		path := rel
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(c.cityPath, rel)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, c.PromptStub(rel), 0o644); err != nil {
+			return fmt.Errorf("writing prompt stub %s: %w", rel, err)
+		}
+	}
+	return nil
+}
 
-// Fix is a no-op.
-func (c *ConfigRefsCheck) Fix(_ *CheckContext) error { return nil }
+// stubbablePrompts returns the missing prompt templates that are safe to
+// create: inside the city directory and outside .gc/, where pack caches
+// and system files live and would be overwritten on the next sync.
+func (c *ConfigRefsCheck) stubbablePrompts() []string {
+	var out []string
+	for _, rel := range c.missingPrompts {
+		path := rel
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(c.cityPath, path)
+		}
+		if !isSubpath(c.cityPath, path) || isSubpath(filepath.Join(c.cityPath, citylayout.RuntimeRoot), path) {
+			continue
+		}
+		out = append(out, rel)
+	}
+	return out
+}
 
 // BuiltinPackFamilyCheck fails when a city overrides only one member of the
 // builtin bd/dolt pack family. Mixed system/user families are unsupported.
@@ -413,6 +458,107 @@ func (c *BinaryCheck) CanFix() bool { return false }
 // Fix is a no-op.
 func (c *BinaryCheck) Fix(_ *CheckContext) error { return nil }
 
+// AgentProvidersCheck verifies that the CLI each agent launches (claude,
+// codex, gemini, or a city-defined provider command) is on PATH.
+// Suspended agents and agents using start_command are skipped.
+type AgentProvidersCheck struct {
+	cfg      *config.City
+	lookPath LookPathFunc
+}
+
+// NewAgentProvidersCheck creates a check for agent provider binaries.
+func NewAgentProvidersCheck(cfg *config.City, lp LookPathFunc) *AgentProvidersCheck {
+	return &AgentProvidersCheck{cfg: cfg, lookPath: lp}
+}
+
+// Name returns the check identifier.
+func (c *AgentProvidersCheck) Name() string { return "agent-providers" }
+
+// Run resolves each agent's provider and reports those whose binary is
+// missing. Unknown provider names are left to config-refs.
+func (c *AgentProvidersCheck) Run(_ *CheckContext) *CheckResult {
+	r := &CheckResult{Name: c.Name()}
+	var missing []string
+	checked := 0
+	for i := range c.cfg.Agents {
+		a := &c.cfg.Agents[i]
+		if a.Suspended {
+			continue
+		}
+		checked++
+		_, err := config.ResolveProvider(a, &c.cfg.Workspace, c.cfg.Providers, config.LookPathFunc(c.lookPath))
+		if err == nil || errors.Is(err, config.ErrProviderNotFound) {
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("%s: %v", a.QualifiedName(), err))
+	}
+	if len(missing) > 0 {
+		// A warning, not an error: the CLI may only be installed where
+		// sessions actually run (containers, remote hosts).
+		r.Status = StatusWarning
+		r.Message = fmt.Sprintf("%d agent(s) reference a provider CLI not in PATH", len(missing))
+		r.Details = missing
+		r.FixHint = "install the agent CLI, or set provider/start_command in city.toml"
+		return r
+	}
+	r.Status = StatusOK
+	r.Message = fmt.Sprintf("%d agent provider(s) found", checked)
+	return r
+}
+
+// CanFix returns false — agent CLIs must be installed by the user.
+func (c *AgentProvidersCheck) CanFix() bool { return false }
+
+// Fix is a no-op.
+func (c *AgentProvidersCheck) Fix(_ *CheckContext) error { return nil }
+
+// RuntimeDirWritableCheck verifies the city's .gc/ runtime directory
+// exists and accepts new files. The controller, bead stores, and event
+// log all write there.
+type RuntimeDirWritableCheck struct{}
+
+// Name returns the check identifier.
+func (c *RuntimeDirWritableCheck) Name() string { return "runtime-dir" }
+
+// Run creates and removes a probe file inside .gc/.
+func (c *RuntimeDirWritableCheck) Run(ctx *CheckContext) *CheckResult {
+	r := &CheckResult{Name: c.Name()}
+	dir := filepath.Join(ctx.CityPath, citylayout.RuntimeRoot)
+	fi, err := os.Stat(dir)
+	if err != nil {
+		r.Status = StatusError
+		r.Message = fmt.Sprintf("%s/ missing", citylayout.RuntimeRoot)
+		r.FixHint = "run gc doctor --fix or gc init"
+		return r
+	}
+	if !fi.IsDir() {
+		r.Status = StatusError
+		r.Message = fmt.Sprintf("%s is not a directory", dir)
+		return r
+	}
+	f, err := os.CreateTemp(dir, ".doctor-probe-*")
+	if err != nil {
+		r.Status = StatusError
+		r.Message = fmt.Sprintf("%s/ not writable: %v", citylayout.RuntimeRoot, err)
+		r.FixHint = fmt.Sprintf("check ownership and permissions of %s", dir)
+		return r
+	}
+	name := f.Name()
+	f.Close()       //nolint:errcheck // probe file
+	os.Remove(name) //nolint:errcheck // probe file
+	r.Status = StatusOK
+	r.Message = fmt.Sprintf("%s/ writable", citylayout.RuntimeRoot)
+	return r
+}
+
+// CanFix returns true — a missing .gc/ can be recreated.
+func (c *RuntimeDirWritableCheck) CanFix() bool { return true }
+
+// Fix creates the .gc/ directory if it is missing.
+func (c *RuntimeDirWritableCheck) Fix(ctx *CheckContext) error {
+	return os.MkdirAll(filepath.Join(ctx.CityPath, citylayout.RuntimeRoot), 0o755)
+}
+
 // --- Session checks (skipped when controller is running) ---
 
 // AgentSessionsCheck verifies non-suspended agents have running sessions.
@@ -1118,12 +1264,12 @@ func IsControllerRunning(cityPath string) bool {
 	}
 	defer f.Close() //nolint:errcheck // probe only
 
-	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	err = fsys.TryLock(f)
 	if err != nil {
 		// EWOULDBLOCK means the lock is held — controller is running.
 		return true
 	}
 	// We got the lock, release immediately — no controller running.
-	syscall.Flock(int(f.Fd()), syscall.LOCK_UN) //nolint:errcheck // best-effort unlock
+	fsys.Unlock(f) //nolint:errcheck // best-effort unlock
 	return false
 }
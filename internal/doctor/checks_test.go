@@ -376,6 +376,63 @@ func TestBinaryCheck_Skipped(t *testing.T) {
 	}
 }
 
+func TestAgentProvidersCheck_AllFound(t *testing.T) {
+	cfg := &config.City{Agents: []config.Agent{
+		{Name: "mayor", Provider: "claude"},
+		{Name: "worker", StartCommand: "my-agent"},
+	}}
+	c := NewAgentProvidersCheck(cfg, func(f string) (string, error) {
+		return "/usr/bin/" + f, nil
+	})
+	r := c.Run(&CheckContext{})
+	if r.Status != StatusOK {
+		t.Errorf("status = %d, want OK; msg = %s", r.Status, r.Message)
+	}
+}
+
+func TestAgentProvidersCheck_Missing(t *testing.T) {
+	cfg := &config.City{Agents: []config.Agent{
+		{Name: "mayor", Provider: "claude"},
+		{Name: "idle", Provider: "codex", Suspended: true},
+		{Name: "custom", Provider: "ghost"}, // unknown: left to config-refs
+	}}
+	c := NewAgentProvidersCheck(cfg, func(_ string) (string, error) {
+		return "", fmt.Errorf("not found")
+	})
+	r := c.Run(&CheckContext{})
+	if r.Status != StatusWarning {
+		t.Fatalf("status = %d, want Warning", r.Status)
+	}
+	if len(r.Details) != 1 || !strings.Contains(r.Details[0], "mayor") {
+		t.Errorf("details = %v, want only mayor", r.Details)
+	}
+	if r.FixHint == "" {
+		t.Error("expected FixHint")
+	}
+}
+
+func TestRuntimeDirWritableCheck(t *testing.T) {
+	dir := t.TempDir()
+	c := &RuntimeDirWritableCheck{}
+	ctx := &CheckContext{CityPath: dir}
+	if r := c.Run(ctx); r.Status != StatusError {
+		t.Fatalf("missing .gc: status = %d, want Error", r.Status)
+	}
+	if err := c.Fix(ctx); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if r := c.Run(ctx); r.Status != StatusOK {
+		t.Fatalf("after fix: status = %d, want OK; msg = %s", r.Status, r.Message)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, ".gc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("probe file left behind: %v", entries)
+	}
+}
+
 func TestBinaryCheck_VersionOK(t *testing.T) {
 	c := NewVersionedBinaryCheck("bd", "", func(_ string) (string, error) {
 		return "/usr/local/bin/bd", nil
@@ -0,0 +1,194 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+	"github.com/gastownhall/gascity/internal/citylayout"
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+// --- Repair checks ---
+
+// RuntimeSubdirsCheck verifies the .gc/ subdirectories gc init creates
+// are present. Commands that write there assume they exist.
+type RuntimeSubdirsCheck struct {
+	// Dirs lists the required directories, relative to the city root.
+	Dirs []string
+
+	missing []string // populated by Run for Fix to use
+}
+
+// NewRuntimeSubdirsCheck creates a check for the given city-relative dirs.
+func NewRuntimeSubdirsCheck(dirs []string) *RuntimeSubdirsCheck {
+	return &RuntimeSubdirsCheck{Dirs: dirs}
+}
+
+// Name returns the check identifier.
+func (c *RuntimeSubdirsCheck) Name() string { return "runtime-subdirs" }
+
+// Run stats each required directory.
+func (c *RuntimeSubdirsCheck) Run(ctx *CheckContext) *CheckResult {
+	r := &CheckResult{Name: c.Name()}
+	c.missing = nil
+	for _, rel := range c.Dirs {
+		if fi, err := os.Stat(filepath.Join(ctx.CityPath, rel)); err != nil || !fi.IsDir() {
+			c.missing = append(c.missing, rel)
+		}
+	}
+	if len(c.missing) == 0 {
+		r.Status = StatusOK
+		r.Message = fmt.Sprintf("all %d runtime directories present", len(c.Dirs))
+		return r
+	}
+	r.Status = StatusWarning
+	r.Message = fmt.Sprintf("%d runtime director(ies) missing", len(c.missing))
+	r.Details = c.missing
+	r.FixHint = "run gc doctor --fix to recreate them"
+	return r
+}
+
+// CanFix returns true — missing directories can be recreated.
+func (c *RuntimeSubdirsCheck) CanFix() bool { return true }
+
+// Fix creates the directories found missing by the last Run.
+func (c *RuntimeSubdirsCheck) Fix(ctx *CheckContext) error {
+	for _, rel := range c.missing {
+		if err := os.MkdirAll(filepath.Join(ctx.CityPath, rel), 0o755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OrphanRigDirsCheck finds directories under rigs/ that no [[rigs]] entry
+// claims and that hold no project (.git or .beads) — leftovers from a rig
+// removed by hand. Directories that do hold a project are left to
+// gc rig sync --register.
+type OrphanRigDirsCheck struct {
+	cfg      *config.City
+	cityPath string
+
+	orphans []string // populated by Run for Fix to use
+}
+
+// NewOrphanRigDirsCheck creates a check for orphaned rig directories.
+func NewOrphanRigDirsCheck(cfg *config.City, cityPath string) *OrphanRigDirsCheck {
+	return &OrphanRigDirsCheck{cfg: cfg, cityPath: cityPath}
+}
+
+// Name returns the check identifier.
+func (c *OrphanRigDirsCheck) Name() string { return "orphan-rig-dirs" }
+
+// Run lists rigs/ and compares it against the configured rigs.
+func (c *OrphanRigDirsCheck) Run(_ *CheckContext) *CheckResult {
+	r := &CheckResult{Name: c.Name()}
+	c.orphans = nil
+	registered := make(map[string]bool, len(c.cfg.Rigs))
+	for _, rig := range c.cfg.Rigs {
+		registered[rig.Name] = true
+	}
+	entries, _ := os.ReadDir(filepath.Join(c.cityPath, citylayout.RigsRoot))
+	for _, e := range entries {
+		if !e.IsDir() || registered[e.Name()] {
+			continue
+		}
+		dir := filepath.Join(c.cityPath, citylayout.RigsRoot, e.Name())
+		if pathExists(filepath.Join(dir, ".git")) || pathExists(filepath.Join(dir, ".beads")) {
+			continue
+		}
+		c.orphans = append(c.orphans, filepath.Join(citylayout.RigsRoot, e.Name()))
+	}
+	sort.Strings(c.orphans)
+	if len(c.orphans) == 0 {
+		r.Status = StatusOK
+		r.Message = "no orphaned rig directories"
+		return r
+	}
+	r.Status = StatusWarning
+	r.Message = fmt.Sprintf("%d orphaned rig director(ies)", len(c.orphans))
+	r.Details = c.orphans
+	r.FixHint = "run gc doctor --fix to remove them (asks first; --yes to skip)"
+	return r
+}
+
+// CanFix returns true — orphaned rig directories can be removed.
+func (c *OrphanRigDirsCheck) CanFix() bool { return true }
+
+// Fix removes each orphaned directory the user confirms.
+func (c *OrphanRigDirsCheck) Fix(ctx *CheckContext) error {
+	for _, rel := range c.orphans {
+		if ctx.Confirm == nil || !ctx.Confirm(fmt.Sprintf("Remove orphaned rig directory %s?", rel)) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(c.cityPath, rel)); err != nil {
+			return fmt.Errorf("removing %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+// BeadsFileCheck looks for duplicate bead IDs and invalid statuses in a
+// file-provider bead store (.gc/beads.json). --fix rewrites the file with
+// the problems repaired, after copying it to a timestamped backup.
+type BeadsFileCheck struct {
+	name string
+	path string
+	// Now returns the time used to name backups. Nil uses time.Now.
+	Now func() time.Time
+}
+
+// NewBeadsFileCheck creates a check for the bead file under dir's .gc/.
+// label names the store in the check name ("city" or a rig name).
+func NewBeadsFileCheck(label, dir string) *BeadsFileCheck {
+	return &BeadsFileCheck{name: label, path: filepath.Join(dir, citylayout.RuntimeRoot, "beads.json")}
+}
+
+// Name returns the check identifier.
+func (c *BeadsFileCheck) Name() string { return "beads-file:" + c.name }
+
+// Run inspects the bead file without modifying it.
+func (c *BeadsFileCheck) Run(_ *CheckContext) *CheckResult {
+	r := &CheckResult{Name: c.Name()}
+	issues, err := beads.InspectFileStore(fsys.OSFS{}, c.path)
+	if err != nil {
+		r.Status = StatusError
+		r.Message = fmt.Sprintf("reading %s: %v", c.path, err)
+		return r
+	}
+	if len(issues) == 0 {
+		r.Status = StatusOK
+		r.Message = "bead file consistent"
+		return r
+	}
+	r.Status = StatusWarning
+	r.Message = fmt.Sprintf("%d bead file issue(s)", len(issues))
+	r.Details = issues
+	r.FixHint = "run gc doctor --fix to normalize (a backup is written first)"
+	return r
+}
+
+// CanFix returns true — the file can be normalized.
+func (c *BeadsFileCheck) CanFix() bool { return true }
+
+// Fix backs up the bead file and rewrites it normalized.
+func (c *BeadsFileCheck) Fix(_ *CheckContext) error {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	backup := c.path + ".bak-" + now().UTC().Format("20060102T150405Z")
+	_, err := beads.NormalizeFileStore(fsys.OSFS{}, c.path, backup)
+	return err
+}
+
+// pathExists reports whether path exists.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,151 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+func TestRuntimeSubdirsCheck_Fix(t *testing.T) {
+	dir := t.TempDir()
+	c := NewRuntimeSubdirsCheck([]string{".gc", ".gc/cache", ".gc/runtime"})
+	ctx := &CheckContext{CityPath: dir}
+
+	r := c.Run(ctx)
+	if r.Status != StatusWarning || len(r.Details) != 3 {
+		t.Fatalf("status = %d, details = %v; want Warning with 3 missing", r.Status, r.Details)
+	}
+	if err := c.Fix(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if r := c.Run(ctx); r.Status != StatusOK {
+		t.Errorf("after fix: status = %d, msg = %s", r.Status, r.Message)
+	}
+}
+
+func TestConfigRefsCheck_FixWritesPromptStubs(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.City{Agents: []config.Agent{
+		{Name: "mayor", PromptTemplate: "prompts/mayor.md"},
+		{Name: "cached", PromptTemplate: filepath.Join(dir, ".gc", "cache", "packs", "p", "x.md")},
+		{Name: "outside", PromptTemplate: filepath.Join(t.TempDir(), "y.md")},
+	}}
+	c := NewConfigRefsCheck(cfg, dir)
+	c.PromptStub = func(rel string) []byte { return []byte("stub for " + rel) }
+	ctx := &CheckContext{CityPath: dir}
+
+	r := c.Run(ctx)
+	if r.Status != StatusWarning || len(r.Details) != 3 || r.FixHint == "" {
+		t.Fatalf("status = %d, details = %v, hint = %q", r.Status, r.Details, r.FixHint)
+	}
+	if err := c.Fix(ctx); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "prompts", "mayor.md"))
+	if err != nil || string(data) != "stub for prompts/mayor.md" {
+		t.Errorf("mayor stub = %q, %v", data, err)
+	}
+	// Pack cache and out-of-city paths are never written.
+	if r := c.Run(ctx); len(r.Details) != 2 {
+		t.Errorf("after fix: details = %v, want the 2 unfixable paths", r.Details)
+	}
+}
+
+func TestConfigRefsCheck_NoStubNoFix(t *testing.T) {
+	c := NewConfigRefsCheck(&config.City{}, t.TempDir())
+	if c.CanFix() {
+		t.Error("CanFix = true without a PromptStub")
+	}
+}
+
+func TestOrphanRigDirsCheck(t *testing.T) {
+	dir := t.TempDir()
+	for _, rel := range []string{"rigs/kept", "rigs/stale", "rigs/project/.git", "rigs/declined"} {
+		if err := os.MkdirAll(filepath.Join(dir, rel), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.City{Rigs: []config.Rig{{Name: "kept", Path: filepath.Join(dir, "rigs", "kept")}}}
+	c := NewOrphanRigDirsCheck(cfg, dir)
+
+	var asked []string
+	ctx := &CheckContext{CityPath: dir, Confirm: func(prompt string) bool {
+		asked = append(asked, prompt)
+		return !strings.Contains(prompt, "declined")
+	}}
+	r := c.Run(ctx)
+	if r.Status != StatusWarning || strings.Join(r.Details, ",") != "rigs/declined,rigs/stale" {
+		t.Fatalf("status = %d, details = %v", r.Status, r.Details)
+	}
+	if err := c.Fix(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(asked) != 2 {
+		t.Errorf("asked = %v, want one prompt per orphan", asked)
+	}
+	if pathExists(filepath.Join(dir, "rigs", "stale")) {
+		t.Error("confirmed orphan not removed")
+	}
+	for _, rel := range []string{"rigs/declined", "rigs/kept", "rigs/project"} {
+		if !pathExists(filepath.Join(dir, rel)) {
+			t.Errorf("%s removed, want kept", rel)
+		}
+	}
+}
+
+func TestOrphanRigDirsCheck_NoConfirmKeepsDirs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "rigs", "stale"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	c := NewOrphanRigDirsCheck(&config.City{}, dir)
+	ctx := &CheckContext{CityPath: dir}
+	c.Run(ctx)
+	if err := c.Fix(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !pathExists(filepath.Join(dir, "rigs", "stale")) {
+		t.Error("orphan removed without confirmation")
+	}
+}
+
+func TestBeadsFileCheck_Fix(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".gc", "beads.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	broken := `{"seq": 2, "beads": [{"id": "gc-1", "status": "open"}, {"id": "gc-1", "status": "open"}, {"id": "gc-2", "status": "done"}]}`
+	if err := os.WriteFile(path, []byte(broken), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewBeadsFileCheck("city", dir)
+	c.Now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	ctx := &CheckContext{CityPath: dir}
+
+	r := c.Run(ctx)
+	if r.Status != StatusWarning || len(r.Details) != 2 {
+		t.Fatalf("status = %d, details = %v", r.Status, r.Details)
+	}
+	if err := c.Fix(ctx); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path + ".bak-20260301T120000Z")
+	if err != nil || string(data) != broken {
+		t.Errorf("backup = %q, %v; want original", data, err)
+	}
+	if r := c.Run(ctx); r.Status != StatusOK {
+		t.Errorf("after fix: status = %d, details = %v", r.Status, r.Details)
+	}
+}
+
+func TestBeadsFileCheck_Missing(t *testing.T) {
+	c := NewBeadsFileCheck("city", t.TempDir())
+	if r := c.Run(&CheckContext{}); r.Status != StatusOK {
+		t.Errorf("status = %d, want OK for missing file", r.Status)
+	}
+}
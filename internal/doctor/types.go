@@ -35,6 +35,9 @@ type CheckContext struct {
 	CityPath string
 	// Verbose enables extra diagnostic output in check results.
 	Verbose bool
+	// Confirm asks the user to approve a destructive fix, such as
+	// deleting files. A nil Confirm declines.
+	Confirm func(prompt string) bool
 }
 
 // CheckResult holds the outcome of a single check execution.
@@ -17,35 +17,49 @@ import (
 
 // Event type constants. Only types we actually emit today.
 const (
-	SessionWoke         = "session.woke"
-	SessionStopped      = "session.stopped"
-	SessionCrashed      = "session.crashed"
-	BeadCreated         = "bead.created"
-	BeadClosed          = "bead.closed"
-	BeadUpdated         = "bead.updated"
-	MailSent            = "mail.sent"
-	MailRead            = "mail.read"
-	MailArchived        = "mail.archived"
-	MailMarkedRead      = "mail.marked_read"
-	MailMarkedUnread    = "mail.marked_unread"
-	MailReplied         = "mail.replied"
-	MailDeleted         = "mail.deleted"
-	SessionDraining     = "session.draining"
-	SessionUndrained    = "session.undrained"
-	SessionQuarantined  = "session.quarantined"
-	SessionIdleKilled   = "session.idle_killed"
-	SessionSuspended    = "session.suspended"
-	SessionUpdated      = "session.updated"
-	ConvoyCreated       = "convoy.created"
-	ConvoyClosed        = "convoy.closed"
-	ControllerStarted   = "controller.started"
-	ControllerStopped   = "controller.stopped"
-	CitySuspended       = "city.suspended"
-	CityResumed         = "city.resumed"
-	AutomationFired     = "automation.fired"
-	AutomationCompleted = "automation.completed"
-	AutomationFailed    = "automation.failed"
-	ProviderSwapped     = "provider.swapped"
+	SessionWoke           = "session.woke"
+	SessionStopped        = "session.stopped"
+	SessionCrashed        = "session.crashed"
+	BeadCreated           = "bead.created"
+	BeadClosed            = "bead.closed"
+	BeadUpdated           = "bead.updated"
+	BeadOverdue           = "bead.overdue"
+	BeadClaimStale        = "bead.claim_stale"
+	BeadsBranchMerged     = "beads.branch_merged"
+	MailSent              = "mail.sent"
+	MailRead              = "mail.read"
+	MailArchived          = "mail.archived"
+	MailMarkedRead        = "mail.marked_read"
+	MailMarkedUnread      = "mail.marked_unread"
+	MailReplied           = "mail.replied"
+	MailDeleted           = "mail.deleted"
+	SessionDraining       = "session.draining"
+	SessionUndrained      = "session.undrained"
+	SessionQuarantined    = "session.quarantined"
+	SessionIdleKilled     = "session.idle_killed"
+	SessionHeartbeatStale = "session.heartbeat_stale"
+	SessionSuspended      = "session.suspended"
+	SessionUpdated        = "session.updated"
+	ConvoyCreated         = "convoy.created"
+	ConvoyClosed          = "convoy.closed"
+	SlingRouted           = "sling.routed"
+	ControllerStarted     = "controller.started"
+	ControllerStopped     = "controller.stopped"
+	CitySuspended         = "city.suspended"
+	CityResumed           = "city.resumed"
+	AutomationFired       = "automation.fired"
+	AutomationCompleted   = "automation.completed"
+	AutomationFailed      = "automation.failed"
+	ProviderSwapped       = "provider.swapped"
+	ConfigReloaded        = "config.reloaded"
+	ConfigRejected        = "config.rejected"
+	AgentAdded            = "agent.added"
+	AgentRemoved          = "agent.removed"
+	AgentPoolResized      = "agent.pool_resized"
+	AgentSuspended        = "agent.suspended"
+	AgentResumed          = "agent.resumed"
+	RigSuspended          = "rig.suspended"
+	RigResumed            = "rig.resumed"
 )
 
 // Event is a single recorded occurrence in the system.
@@ -0,0 +1,106 @@
+package formula
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// Cook instantiates f into store as a molecule: a root bead of type
+// "molecule" (Ref = formula name, parented to parentID when set) with one
+// child bead per step (Ref = step id). Each step's needs become blocking
+// deps, so steps turn ready in dependency order. vars are key=value
+// pairs; declared defaults fill in the rest and required vars must be
+// set. {{name}} placeholders in step titles and descriptions are
+// substituted. Returns the root bead ID.
+func Cook(store beads.Store, f Formula, title, parentID string, vars []string) (string, error) {
+	if problems := stepProblems(f.Steps); len(problems) > 0 {
+		return "", fmt.Errorf("formula %q: %s", f.Formula, strings.Join(problems, "; "))
+	}
+	values, err := resolveVars(f, vars)
+	if err != nil {
+		return "", fmt.Errorf("formula %q: %w", f.Formula, err)
+	}
+
+	if title == "" {
+		title = f.Formula
+	}
+	root, err := store.Create(beads.Bead{
+		Title:    title,
+		Type:     "molecule",
+		Ref:      f.Formula,
+		ParentID: parentID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating molecule for %q: %w", f.Formula, err)
+	}
+
+	stepIDs := make(map[string]string, len(f.Steps))
+	for _, s := range f.Steps {
+		stepTitle := s.Title
+		if stepTitle == "" {
+			stepTitle = s.ID
+		}
+		b, err := store.Create(beads.Bead{
+			Title:       substitute(stepTitle, values),
+			Description: substitute(s.Description, values),
+			ParentID:    root.ID,
+			Ref:         s.ID,
+			Needs:       s.Needs,
+		})
+		if err != nil {
+			return "", fmt.Errorf("creating step %q of %s: %w", s.ID, root.ID, err)
+		}
+		stepIDs[s.ID] = b.ID
+	}
+	for _, s := range f.Steps {
+		for _, n := range s.Needs {
+			if err := store.DepAdd(stepIDs[s.ID], stepIDs[n], "blocks"); err != nil {
+				return "", fmt.Errorf("linking step %q to %q: %w", s.ID, n, err)
+			}
+		}
+	}
+	return root.ID, nil
+}
+
+// resolveVars merges key=value pairs over the formula's declared
+// defaults and checks that every required variable is set.
+func resolveVars(f Formula, vars []string) (map[string]string, error) {
+	values := make(map[string]string, len(f.Vars)+len(vars))
+	for name, v := range f.Vars {
+		if v.Default != nil {
+			values[name] = fmt.Sprint(v.Default)
+		}
+	}
+	for _, kv := range vars {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || !varName.MatchString(k) {
+			return nil, fmt.Errorf("invalid var %q (expected key=value)", kv)
+		}
+		values[k] = v
+	}
+	var missing []string
+	for name, v := range f.Vars {
+		if _, ok := values[name]; v.Required && !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return nil, fmt.Errorf("missing required var(s): %s", strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+// substitute replaces {{name}} placeholders with their values. Unknown
+// names are left as-is.
+func substitute(text string, values map[string]string) string {
+	return varRef.ReplaceAllStringFunc(text, func(m string) string {
+		if v, ok := values[m[2:len(m)-2]]; ok {
+			return v
+		}
+		return m
+	})
+}
@@ -0,0 +1,119 @@
+package formula
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestCookCreatesMoleculeAndSteps(t *testing.T) {
+	store := beads.NewMemStore()
+	f := Formula{
+		Formula: "work",
+		Vars: map[string]Var{
+			"issue":  {Required: true},
+			"branch": {Default: "main"},
+		},
+		Steps: []Step{
+			{ID: "load", Title: "Load {{issue}}"},
+			{ID: "work", Description: "Push to {{branch}}; keep {{unknown}}", Needs: []string{"load"}},
+		},
+	}
+	rootID, err := Cook(store, f, "", "", []string{"issue=BL-42"})
+	if err != nil {
+		t.Fatalf("Cook: %v", err)
+	}
+	root, err := store.Get(rootID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Type != "molecule" || root.Ref != "work" || root.Title != "work" {
+		t.Errorf("root = %+v", root)
+	}
+
+	steps, err := store.Children(rootID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("steps = %+v, want 2", steps)
+	}
+	if steps[0].Title != "Load BL-42" || steps[0].Ref != "load" {
+		t.Errorf("step 0 = %+v", steps[0])
+	}
+	if steps[1].Title != "work" || steps[1].Description != "Push to main; keep {{unknown}}" {
+		t.Errorf("step 1 = %+v", steps[1])
+	}
+
+	// Only the first step is ready until it closes.
+	ready, _ := store.Ready()
+	var readyRefs []string
+	for _, b := range ready {
+		if b.ParentID == rootID {
+			readyRefs = append(readyRefs, b.Ref)
+		}
+	}
+	if strings.Join(readyRefs, ",") != "load" {
+		t.Errorf("ready steps = %q, want [load]", readyRefs)
+	}
+}
+
+func TestCookErrors(t *testing.T) {
+	store := beads.NewMemStore()
+	f := Formula{
+		Formula: "work",
+		Vars:    map[string]Var{"issue": {Required: true}, "repo": {Required: true}},
+		Steps:   []Step{{ID: "a"}},
+	}
+	if _, err := Cook(store, f, "", "", []string{"issue=x"}); err == nil || !strings.Contains(err.Error(), "missing required var(s): repo") {
+		t.Errorf("missing var err = %v", err)
+	}
+	if _, err := Cook(store, f, "", "", []string{"noequals"}); err == nil || !strings.Contains(err.Error(), "invalid var") {
+		t.Errorf("bad var err = %v", err)
+	}
+	cyclic := Formula{Formula: "c", Steps: []Step{{ID: "a", Needs: []string{"a"}}}}
+	if _, err := Cook(store, cyclic, "", "", nil); err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("cycle err = %v", err)
+	}
+	if all, _ := store.List(); len(all) != 0 {
+		t.Errorf("failed cooks created beads: %+v", all)
+	}
+}
+
+func TestCookingStore(t *testing.T) {
+	dir := t.TempDir()
+	writeFormula(t, dir, "base", baseFormula)
+	writeFormula(t, dir, "child", childFormula)
+	mem := beads.NewMemStore()
+	store := NewCookingStore(mem, LayerResolver([]string{dir}))
+
+	rootID, err := store.MolCook("child", "my run", []string{"issue=BL-1"})
+	if err != nil {
+		t.Fatalf("MolCook: %v", err)
+	}
+	root, _ := mem.Get(rootID)
+	if root.Title != "my run" || root.Ref != "child" {
+		t.Errorf("root = %+v", root)
+	}
+	steps, _ := mem.Children(rootID)
+	if len(steps) != 3 || steps[1].Title != "Commit to develop" {
+		t.Errorf("steps = %+v", steps)
+	}
+
+	task, _ := mem.Create(beads.Bead{Title: "task"})
+	wispID, err := store.MolCookOn("base", task.ID, "", []string{"issue=" + task.ID})
+	if err != nil {
+		t.Fatalf("MolCookOn: %v", err)
+	}
+	if wisp, _ := mem.Get(wispID); wisp.ParentID != task.ID {
+		t.Errorf("wisp parent = %q, want %s", wisp.ParentID, task.ID)
+	}
+
+	if _, err := store.MolCook("nope", "", nil); err == nil || !strings.Contains(err.Error(), `formula "nope" not found`) {
+		t.Errorf("unknown formula err = %v", err)
+	}
+	if _, err := store.MolCookOn("base", "gc-999", "", nil); err == nil {
+		t.Error("MolCookOn missing bead: want error")
+	}
+}
@@ -0,0 +1,234 @@
+// Package formula parses, resolves, lints, and cooks Gas City formula
+// files (*.formula.toml, *.formula.yaml).
+//
+// bd owns the full formula format and normally does the cooking. This
+// package covers the subset gc needs to inspect formulas before they are
+// slung, and to cook them itself into a beads.Store that has no bd behind
+// it (the file and sqlite providers). bd reads only TOML, so YAML
+// formulas are cooked by gc alone.
+package formula
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Suffix is the filename suffix bd uses to discover formulas.
+const Suffix = ".formula.toml"
+
+// Suffixes lists every formula filename suffix gc reads, in the order
+// that decides which file wins when one layer holds the same name twice.
+var Suffixes = []string{Suffix, ".formula.yaml", ".formula.yml"}
+
+// Formula is a workflow template: a named set of steps with dependencies,
+// parameterized by variables. Keys not listed here (automation gates,
+// pools, …) are allowed and ignored.
+type Formula struct {
+	// Formula is the formula name. Must match the filename without the
+	// .formula.toml (or .formula.yaml) suffix.
+	Formula string `toml:"formula" yaml:"formula" json:"formula" jsonschema:"required"`
+	// Description explains what the formula does. The first line is shown
+	// by gc formula list.
+	Description string `toml:"description,omitempty" yaml:"description,omitempty" json:"description,omitempty"`
+	// Version is the formula's revision number.
+	Version int `toml:"version,omitempty" yaml:"version,omitempty" json:"version,omitempty"`
+	// Extends lists base formulas whose vars and steps are inherited.
+	// Steps with the same id replace the base step in place.
+	Extends []string `toml:"extends,omitempty" yaml:"extends,omitempty" json:"extends,omitempty"`
+	// Vars declares the variables steps may reference as {{name}}.
+	Vars map[string]Var `toml:"vars,omitempty" yaml:"vars,omitempty" json:"vars,omitempty"`
+	// Steps are the units of work, instantiated as child beads of the
+	// molecule when the formula is cooked.
+	Steps []Step `toml:"steps,omitempty" yaml:"steps,omitempty" json:"steps,omitempty"`
+}
+
+// Var declares a variable that can be passed with --var at cook time.
+type Var struct {
+	// Description explains what the variable controls.
+	Description string `toml:"description,omitempty" yaml:"description,omitempty" json:"description,omitempty"`
+	// Required makes cooking fail when the variable is not passed.
+	Required bool `toml:"required,omitempty" yaml:"required,omitempty" json:"required,omitempty"`
+	// Default is used when the variable is not passed.
+	Default any `toml:"default,omitempty" yaml:"default,omitempty" json:"default,omitempty"`
+}
+
+// Step is one unit of work in a formula.
+type Step struct {
+	// ID identifies the step within the formula. Referenced by needs.
+	ID string `toml:"id" yaml:"id" json:"id" jsonschema:"required"`
+	// Title is the step bead's title. Defaults to the id.
+	Title string `toml:"title,omitempty" yaml:"title,omitempty" json:"title,omitempty"`
+	// Description holds the step's instructions for the agent.
+	Description string `toml:"description,omitempty" yaml:"description,omitempty" json:"-"`
+	// Needs lists step ids that must close before this step is ready.
+	Needs []string `toml:"needs,omitempty" yaml:"needs,omitempty" json:"needs,omitempty"`
+}
+
+// Entry is a formula file that won filename resolution across layers.
+type Entry struct {
+	Name  string // formula name (filename without suffix)
+	Path  string // absolute path of the winning file
+	Layer string // layer directory the file came from
+}
+
+// Load parses a formula file, as YAML when its name ends in .yaml or
+// .yml and as TOML otherwise.
+func Load(path string) (Formula, error) {
+	var f Formula
+	if isYAML(path) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Formula{}, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if err := yaml.Unmarshal(data, &f); err != nil {
+			return Formula{}, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		return f, nil
+	}
+	if _, err := toml.DecodeFile(path, &f); err != nil {
+		return Formula{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Name returns the formula name for a formula file path.
+func Name(path string) string {
+	base := filepath.Base(path)
+	for _, suffix := range Suffixes {
+		if name, ok := strings.CutSuffix(base, suffix); ok {
+			return name
+		}
+	}
+	return base
+}
+
+// IsFormulaFile reports whether name has one of the formula suffixes.
+func IsFormulaFile(name string) bool {
+	return slices.ContainsFunc(Suffixes, func(suffix string) bool { return strings.HasSuffix(name, suffix) })
+}
+
+func isYAML(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Scan returns the winning formula for each name across layers, sorted
+// by name. Layers are ordered lowest→highest priority; later layers win,
+// as when gc materializes .beads/formulas. Within one layer a TOML file
+// wins over a YAML file of the same name.
+func Scan(layers []string) []Entry {
+	winners := make(map[string]Entry)
+	for _, layer := range layers {
+		entries, err := os.ReadDir(layer)
+		if err != nil {
+			continue // missing layer dirs are not an error
+		}
+		layerWinners := make(map[string]Entry)
+		for _, e := range entries {
+			if e.IsDir() || !IsFormulaFile(e.Name()) {
+				continue
+			}
+			abs, err := filepath.Abs(filepath.Join(layer, e.Name()))
+			if err != nil {
+				continue
+			}
+			name := Name(e.Name())
+			if prev, ok := layerWinners[name]; ok && suffixRank(prev.Path) < suffixRank(abs) {
+				continue
+			}
+			layerWinners[name] = Entry{Name: name, Path: abs, Layer: layer}
+		}
+		for name, e := range layerWinners {
+			winners[name] = e
+		}
+	}
+	out := make([]Entry, 0, len(winners))
+	for _, e := range winners {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// suffixRank returns the index in Suffixes of path's suffix.
+func suffixRank(path string) int {
+	return slices.IndexFunc(Suffixes, func(suffix string) bool { return strings.HasSuffix(path, suffix) })
+}
+
+// Find looks up a formula by name.
+func Find(entries []Entry, name string) (Entry, bool) {
+	for _, e := range entries {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Expand loads the formula at path and merges in everything it extends.
+// Base vars and steps come first; the extending formula's description
+// and vars override by name, and its steps replace base steps with the
+// same ID in place. Bases are looked up by name in entries.
+func Expand(path string, entries []Entry) (Formula, error) {
+	return expandChain(path, entries, nil)
+}
+
+func expandChain(path string, entries []Entry, chain []string) (Formula, error) {
+	f, err := Load(path)
+	if err != nil {
+		return Formula{}, err
+	}
+	name := Name(path)
+	if slices.Contains(chain, name) {
+		return Formula{}, fmt.Errorf("extends cycle: %s", strings.Join(append(chain, name), " → "))
+	}
+	if len(f.Extends) == 0 {
+		return f, nil
+	}
+	chain = append(chain, name)
+
+	merged := Formula{
+		Formula: f.Formula,
+		Version: f.Version,
+		Extends: f.Extends,
+		Vars:    make(map[string]Var),
+	}
+	for _, baseName := range f.Extends {
+		e, ok := Find(entries, baseName)
+		if !ok {
+			return Formula{}, fmt.Errorf("%s extends unknown formula %q", name, baseName)
+		}
+		base, err := expandChain(e.Path, entries, chain)
+		if err != nil {
+			return Formula{}, err
+		}
+		merge(&merged, base)
+	}
+	merge(&merged, f)
+	return merged, nil
+}
+
+// merge layers src's description, vars, and steps onto dst.
+func merge(dst *Formula, src Formula) {
+	if src.Description != "" {
+		dst.Description = src.Description
+	}
+	for k, v := range src.Vars {
+		dst.Vars[k] = v
+	}
+	for _, s := range src.Steps {
+		i := slices.IndexFunc(dst.Steps, func(d Step) bool { return d.ID == s.ID && s.ID != "" })
+		if i >= 0 {
+			dst.Steps[i] = s
+		} else {
+			dst.Steps = append(dst.Steps, s)
+		}
+	}
+}
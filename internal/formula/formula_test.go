@@ -0,0 +1,248 @@
+package formula
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFormula(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name+Suffix)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const baseFormula = `formula = "base"
+description = "Base workflow"
+
+[vars.issue]
+description = "The bead to work"
+required = true
+
+[vars.branch]
+default = "main"
+
+[[steps]]
+id = "load"
+title = "Load context for {{issue}}"
+
+[[steps]]
+id = "work"
+title = "Do the work on {{branch}}"
+needs = ["load"]
+`
+
+const childFormula = `formula = "child"
+extends = ["base"]
+
+[vars.branch]
+default = "develop"
+
+[[steps]]
+id = "work"
+title = "Commit to {{branch}}"
+needs = ["load"]
+
+[[steps]]
+id = "push"
+title = "Push"
+needs = ["work"]
+`
+
+func TestScanLaterLayerWins(t *testing.T) {
+	low := filepath.Join(t.TempDir(), "low")
+	high := filepath.Join(t.TempDir(), "high")
+	writeFormula(t, low, "base", baseFormula)
+	writeFormula(t, low, "shared", `formula = "shared"`)
+	writeFormula(t, high, "shared", `formula = "shared"`)
+
+	entries := Scan([]string{low, high, filepath.Join(t.TempDir(), "missing")})
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v, want 2", entries)
+	}
+	if entries[0].Name != "base" || entries[0].Layer != low {
+		t.Errorf("entries[0] = %+v, want base from low", entries[0])
+	}
+	if entries[1].Name != "shared" || entries[1].Layer != high {
+		t.Errorf("entries[1] = %+v, want shared from high", entries[1])
+	}
+}
+
+const childYAML = `formula: child
+extends: [base]
+vars:
+  branch:
+    default: develop
+steps:
+  - id: work
+    title: "Commit to {{branch}}"
+    needs: [load]
+  - id: push
+    title: Push
+    needs: [work]
+`
+
+func TestYAMLFormula(t *testing.T) {
+	dir := t.TempDir()
+	writeFormula(t, dir, "base", baseFormula)
+	path := filepath.Join(dir, "child.formula.yaml")
+	if err := os.WriteFile(path, []byte(childYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "base.formula.yml"), []byte("formula: base\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := Scan([]string{dir})
+	if len(entries) != 2 || entries[0].Path != filepath.Join(dir, "base"+Suffix) || entries[1].Path != path {
+		t.Fatalf("entries = %+v, want TOML base and YAML child", entries)
+	}
+	f, err := Expand(path, entries)
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	var ids []string
+	for _, s := range f.Steps {
+		ids = append(ids, s.ID)
+	}
+	if got := strings.Join(ids, ","); got != "load,work,push" {
+		t.Errorf("steps = %s, want load,work,push", got)
+	}
+	if f.Vars["branch"].Default != "develop" || !f.Vars["issue"].Required {
+		t.Errorf("vars = %+v", f.Vars)
+	}
+	if problems := Validate(path); len(problems) != 0 {
+		t.Errorf("Validate = %q, want none", problems)
+	}
+
+	bad := filepath.Join(dir, "bad.formula.yaml")
+	if err := os.WriteFile(bad, []byte("vars:\n  x:\n    descripton: typo\nsteps:\n  - title: no id\n    need: [a]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`missing required key "formula"`,
+		`step 1: missing required key "id"`,
+		`unknown key "steps.need"`,
+		`unknown key "vars.x.descripton"`,
+	}
+	if got := Validate(bad); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Validate(bad) =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestExpandMergesExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeFormula(t, dir, "base", baseFormula)
+	path := writeFormula(t, dir, "child", childFormula)
+
+	f, err := Expand(path, Scan([]string{dir}))
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	var ids []string
+	for _, s := range f.Steps {
+		ids = append(ids, s.ID)
+	}
+	if got := strings.Join(ids, ","); got != "load,work,push" {
+		t.Errorf("steps = %s, want load,work,push", got)
+	}
+	if f.Steps[1].Title != "Commit to {{branch}}" {
+		t.Errorf("work step not overridden: %q", f.Steps[1].Title)
+	}
+	if f.Description != "Base workflow" {
+		t.Errorf("Description = %q, want inherited from base", f.Description)
+	}
+	if !f.Vars["issue"].Required {
+		t.Error("issue var not inherited from base")
+	}
+	if f.Vars["branch"].Default != "develop" {
+		t.Errorf("branch default = %v, want develop", f.Vars["branch"].Default)
+	}
+	if problems := Lint(f); len(problems) != 0 {
+		t.Errorf("lint problems = %q, want none", problems)
+	}
+}
+
+func TestExpandErrors(t *testing.T) {
+	dir := t.TempDir()
+	a := writeFormula(t, dir, "a", "formula = \"a\"\nextends = [\"b\"]\n")
+	writeFormula(t, dir, "b", "formula = \"b\"\nextends = [\"a\"]\n")
+	orphan := writeFormula(t, dir, "orphan", "formula = \"orphan\"\nextends = [\"nope\"]\n")
+	entries := Scan([]string{dir})
+
+	if _, err := Expand(a, entries); err == nil || !strings.Contains(err.Error(), "extends cycle: a → b → a") {
+		t.Errorf("cycle err = %v", err)
+	}
+	if _, err := Expand(orphan, entries); err == nil || !strings.Contains(err.Error(), `unknown formula "nope"`) {
+		t.Errorf("unknown extends err = %v", err)
+	}
+}
+
+func TestLint(t *testing.T) {
+	f := Formula{
+		Vars: map[string]Var{"issue": {}},
+		Steps: []Step{
+			{ID: "a", Title: "{{issue}} {{ .NotAVar }}", Needs: []string{"c"}},
+			{ID: "b", Description: "uses {{missing}} twice: {{missing}}", Needs: []string{"a", "ghost"}},
+			{ID: "c", Needs: []string{"b"}},
+			{ID: "b"},
+			{},
+		},
+	}
+	got := Lint(f)
+	want := []string{
+		`duplicate step id "b"`,
+		"step 5 has no id",
+		`step "b" needs unknown step "ghost"`,
+		"dependency cycle: a → c → b → a",
+		`step "b" uses undeclared variable {{missing}}`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Lint =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	good := writeFormula(t, dir, "base", "gate = \"cooldown\"\ninterval = \"1h\"\n"+baseFormula)
+	if problems := Validate(good); len(problems) != 0 {
+		t.Errorf("Validate(good) = %q, want none (unknown top-level keys allowed)", problems)
+	}
+
+	bad := writeFormula(t, dir, "bad", `formula = "other"
+
+[vars.bad-name]
+descripton = "typo"
+
+[[steps]]
+title = "no id"
+need = ["x"]
+`)
+	want := []string{
+		`formula = "other" does not match filename (want "bad")`,
+		`invalid variable name "bad-name" (letters, digits, underscores)`,
+		`step 1: missing required key "id"`,
+		`unknown key "steps.need"`,
+		`unknown key "vars.bad-name.descripton"`,
+	}
+	if got := Validate(bad); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Validate(bad) =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	missing := writeFormula(t, dir, "missing", "[[steps]]\nid = \"a\"\n")
+	if got := Validate(missing); len(got) != 1 || got[0] != `missing required key "formula"` {
+		t.Errorf("Validate(missing) = %q", got)
+	}
+
+	broken := writeFormula(t, dir, "broken", "formula = [")
+	if got := Validate(broken); len(got) != 1 {
+		t.Errorf("Validate(broken) = %q, want one parse error", got)
+	}
+}
@@ -0,0 +1,219 @@
+package formula
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// varRef matches the {{name}} placeholders substituted at cook time.
+// Go-template actions ({{ .Field }}, {{ end }}) don't match.
+var varRef = regexp.MustCompile(`\{\{([A-Za-z_][A-Za-z0-9_]*)\}\}`)
+
+// varName matches a valid variable name.
+var varName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks a single formula file against the formula schema
+// (docs/schema/formula-schema.json): it must parse, declare a formula
+// name matching its filename, give every step an id, and use only known
+// keys inside vars and steps. Unknown top-level keys are allowed since
+// bd and automations define more of them. Returns one message per
+// problem.
+func Validate(path string) []string {
+	decode := decodeTOML
+	if isYAML(path) {
+		decode = decodeYAML
+	}
+	f, named, unknown, err := decode(path)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var problems []string
+	switch {
+	case !named:
+		problems = append(problems, `missing required key "formula"`)
+	case f.Formula != Name(path):
+		problems = append(problems, fmt.Sprintf("formula = %q does not match filename (want %q)", f.Formula, Name(path)))
+	}
+	for _, k := range unknown {
+		problems = append(problems, fmt.Sprintf("unknown key %q", k))
+	}
+	for name := range f.Vars {
+		if !varName.MatchString(name) {
+			problems = append(problems, fmt.Sprintf("invalid variable name %q (letters, digits, underscores)", name))
+		}
+	}
+	for i, s := range f.Steps {
+		if s.ID == "" {
+			problems = append(problems, fmt.Sprintf("step %d: missing required key \"id\"", i+1))
+		}
+	}
+	slices.Sort(problems)
+	return problems
+}
+
+// decodeTOML parses a TOML formula file for Validate. It reports whether
+// the formula key is set and the unknown keys inside vars and steps.
+func decodeTOML(path string) (Formula, bool, []string, error) {
+	var f Formula
+	md, err := toml.DecodeFile(path, &f)
+	if err != nil {
+		return Formula{}, false, nil, err
+	}
+	var unknown []string
+	for _, k := range md.Undecoded() {
+		if len(k) > 1 && (k[0] == "vars" || k[0] == "steps") {
+			unknown = append(unknown, k.String())
+		}
+	}
+	return f, md.IsDefined("formula"), unknown, nil
+}
+
+// decodeYAML is decodeTOML for YAML formula files. Unknown keys are
+// named the way TOML reports them: vars.<name>.<key> and steps.<key>.
+func decodeYAML(path string) (Formula, bool, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Formula{}, false, nil, err
+	}
+	var f Formula
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return Formula{}, false, nil, err
+	}
+	var raw struct {
+		Formula *string                   `yaml:"formula"`
+		Vars    map[string]map[string]any `yaml:"vars"`
+		Steps   []map[string]any          `yaml:"steps"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return Formula{}, false, nil, err
+	}
+	var unknown []string
+	for name, v := range raw.Vars {
+		for k := range v {
+			if !slices.Contains(varKeys, k) {
+				unknown = append(unknown, "vars."+name+"."+k)
+			}
+		}
+	}
+	for _, step := range raw.Steps {
+		for k := range step {
+			if !slices.Contains(stepKeys, k) && !slices.Contains(unknown, "steps."+k) {
+				unknown = append(unknown, "steps."+k)
+			}
+		}
+	}
+	return f, raw.Formula != nil, unknown, nil
+}
+
+// varKeys and stepKeys are the keys Var and Step decode.
+var (
+	varKeys  = []string{"description", "required", "default"}
+	stepKeys = []string{"id", "title", "description", "needs"}
+)
+
+// Lint checks an expanded formula for problems that would otherwise
+// surface only when a cook fails: missing or duplicate step IDs, needs
+// that reference unknown steps, dependency cycles, and {{var}}
+// placeholders with no matching [vars] declaration. Returns one message
+// per problem, in a stable order.
+func Lint(f Formula) []string {
+	var problems []string
+	if len(f.Steps) == 0 {
+		problems = append(problems, "formula has no steps")
+	}
+	problems = append(problems, stepProblems(f.Steps)...)
+
+	seen := make(map[string]bool)
+	for _, s := range f.Steps {
+		for _, text := range []string{s.Title, s.Description} {
+			for _, m := range varRef.FindAllStringSubmatch(text, -1) {
+				v := m[1]
+				if _, ok := f.Vars[v]; ok || seen[v] {
+					continue
+				}
+				seen[v] = true
+				problems = append(problems, fmt.Sprintf("step %q uses undeclared variable {{%s}}", s.ID, v))
+			}
+		}
+	}
+	return problems
+}
+
+// stepProblems reports step graph problems that make a formula impossible
+// to cook: missing or duplicate IDs, unknown needs, and cycles.
+func stepProblems(steps []Step) []string {
+	var problems []string
+	ids := make(map[string]bool, len(steps))
+	for i, s := range steps {
+		switch {
+		case s.ID == "":
+			problems = append(problems, fmt.Sprintf("step %d has no id", i+1))
+		case ids[s.ID]:
+			problems = append(problems, fmt.Sprintf("duplicate step id %q", s.ID))
+		}
+		ids[s.ID] = true
+	}
+	for _, s := range steps {
+		for _, n := range s.Needs {
+			if !ids[n] {
+				problems = append(problems, fmt.Sprintf("step %q needs unknown step %q", s.ID, n))
+			}
+		}
+	}
+	if cycle := stepCycle(steps); cycle != nil {
+		problems = append(problems, "dependency cycle: "+strings.Join(cycle, " → "))
+	}
+	return problems
+}
+
+// stepCycle returns the first cycle found in the steps' needs graph, as
+// a path that starts and ends on the same step, or nil.
+func stepCycle(steps []Step) []string {
+	needs := make(map[string][]string, len(steps))
+	for _, s := range steps {
+		needs[s.ID] = append(needs[s.ID], s.Needs...)
+	}
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(steps))
+	var stack []string
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, n := range needs[id] {
+			if _, ok := needs[n]; !ok {
+				continue // unknown step — reported separately
+			}
+			switch state[n] {
+			case visiting:
+				i := slices.Index(stack, n)
+				return append(slices.Clone(stack[i:]), n)
+			case unvisited:
+				if c := visit(n); c != nil {
+					return c
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+		return nil
+	}
+	for _, s := range steps {
+		if state[s.ID] == unvisited {
+			if c := visit(s.ID); c != nil {
+				return c
+			}
+		}
+	}
+	return nil
+}
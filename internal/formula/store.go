@@ -0,0 +1,75 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// Resolver returns the expanded formula for a name.
+type Resolver func(name string) (Formula, error)
+
+// LayerResolver resolves formula names across layers (lowest priority
+// first), rescanning on every call so edits take effect immediately.
+func LayerResolver(layers []string) Resolver {
+	return func(name string) (Formula, error) {
+		entries := Scan(layers)
+		e, ok := Find(entries, name)
+		if !ok {
+			return Formula{}, fmt.Errorf("formula %q not found", name)
+		}
+		return Expand(e.Path, entries)
+	}
+}
+
+// CookingStore wraps a beads.Store whose backend can't cook formulas
+// itself (no bd behind it) and implements MolCook and MolCookOn with
+// Cook. All other operations pass through.
+type CookingStore struct {
+	beads.Store
+	resolve Resolver
+}
+
+// NewCookingStore returns store with MolCook and MolCookOn served by
+// formulas from resolve.
+func NewCookingStore(store beads.Store, resolve Resolver) *CookingStore {
+	return &CookingStore{Store: store, resolve: resolve}
+}
+
+// MolCook cooks the named formula into a standalone molecule.
+func (s *CookingStore) MolCook(name, title string, vars []string) (string, error) {
+	f, err := s.resolve(name)
+	if err != nil {
+		return "", fmt.Errorf("mol cook: %w", err)
+	}
+	return Cook(s.Store, f, title, "", vars)
+}
+
+// MolCookOn cooks the named formula into a molecule attached to beadID.
+func (s *CookingStore) MolCookOn(name, beadID, title string, vars []string) (string, error) {
+	if _, err := s.Get(beadID); err != nil {
+		return "", fmt.Errorf("mol cook --on %s: %w", beadID, err)
+	}
+	f, err := s.resolve(name)
+	if err != nil {
+		return "", fmt.Errorf("mol cook --on %s: %w", beadID, err)
+	}
+	return Cook(s.Store, f, title, beadID, vars)
+}
+
+// ClaimNext claims through the wrapped store, so a backend that pops its
+// ready queue atomically (see beads.Claimer) keeps doing so when wrapped.
+func (s *CookingStore) ClaimNext(label, assignee string) (beads.Bead, bool, error) {
+	return beads.ClaimNext(s.Store, label, assignee)
+}
+
+// Remove removes through the wrapped store, so archival and wisp GC work
+// on wrapped file and sqlite stores (see beads.Remover).
+func (s *CookingStore) Remove(ids []string) (int, error) {
+	r, ok := s.Store.(beads.Remover)
+	if !ok {
+		return 0, errors.New("bead store does not support removing beads")
+	}
+	return r.Remove(ids)
+}
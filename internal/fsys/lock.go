@@ -0,0 +1,27 @@
+package fsys
+
+import (
+	"os"
+)
+
+// Advisory file locks. Lock, TryLock, and Unlock use flock(2) on Unix
+// and LockFileEx on Windows; either way the lock is released if the
+// process dies.
+
+// LockFile opens the lock file at path, creating it if needed, and takes
+// an exclusive lock on it, blocking until the lock is free. The returned
+// function releases the lock and closes the file.
+func LockFile(path string, perm os.FileMode) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, perm)
+	if err != nil {
+		return nil, err
+	}
+	if err := Lock(f); err != nil {
+		f.Close() //nolint:errcheck
+		return nil, err
+	}
+	return func() {
+		Unlock(f) //nolint:errcheck
+		f.Close() //nolint:errcheck
+	}, nil
+}
@@ -0,0 +1,34 @@
+package fsys_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+)
+
+func TestLockFileExcludesTryLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.lock")
+	unlock, err := fsys.LockFile(path, 0o600)
+	if err != nil {
+		t.Fatalf("LockFile: %v", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close() //nolint:errcheck
+	if err := fsys.TryLock(f); err == nil {
+		t.Fatal("TryLock succeeded while LockFile held the lock")
+	}
+
+	unlock()
+	if err := fsys.TryLock(f); err != nil {
+		t.Fatalf("TryLock after unlock: %v", err)
+	}
+	if err := fsys.Unlock(f); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+}
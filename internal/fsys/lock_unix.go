@@ -0,0 +1,24 @@
+//go:build !windows
+
+package fsys
+
+import (
+	"os"
+	"syscall"
+)
+
+// Lock takes an exclusive lock on f, blocking until it is free.
+func Lock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// TryLock takes an exclusive lock on f without blocking. It returns an
+// error if another process holds the lock.
+func TryLock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
+
+// Unlock releases a lock taken by Lock or TryLock.
+func Unlock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
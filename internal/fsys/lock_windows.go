@@ -0,0 +1,29 @@
+//go:build windows
+
+package fsys
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Locks cover the file's first byte, which is enough for lock files that
+// hold no data and does not stop other processes from reading them.
+
+// Lock takes an exclusive lock on f, blocking until it is free.
+func Lock(f *os.File) error {
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, new(windows.Overlapped))
+}
+
+// TryLock takes an exclusive lock on f without blocking. It returns an
+// error if another process holds the lock.
+func TryLock(f *os.File) error {
+	flags := uint32(windows.LOCKFILE_EXCLUSIVE_LOCK | windows.LOCKFILE_FAIL_IMMEDIATELY)
+	return windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, 1, 0, new(windows.Overlapped))
+}
+
+// Unlock releases a lock taken by Lock or TryLock.
+func Unlock(f *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, new(windows.Overlapped))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
@@ -140,6 +141,31 @@ func (g *Git) WorktreePrune() error {
 	return nil
 }
 
+// HooksDir returns the absolute path of the directory git runs hooks
+// from. Honors core.hooksPath and resolves linked worktrees to the
+// shared hooks directory.
+func (g *Git) HooksDir() (string, error) {
+	out, err := g.run("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("locating hooks directory: %w", err)
+	}
+	dir := strings.TrimSpace(out)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(g.workDir, dir)
+	}
+	return dir, nil
+}
+
+// HeadCommit returns the full SHA and message of the HEAD commit.
+func (g *Git) HeadCommit() (sha, message string, err error) {
+	out, err := g.run("log", "-1", "--format=%H%n%B")
+	if err != nil {
+		return "", "", fmt.Errorf("reading HEAD commit: %w", err)
+	}
+	sha, message, _ = strings.Cut(out, "\n")
+	return strings.TrimSpace(sha), strings.TrimSpace(message), nil
+}
+
 // Fetch runs git fetch origin to update remote tracking branches.
 func (g *Git) Fetch() error {
 	_, err := g.run("fetch", "origin")
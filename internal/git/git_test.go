@@ -412,3 +412,38 @@ func TestParseWorktreeList_Empty(t *testing.T) {
 		t.Errorf("len(worktrees) = %d, want 0", len(wts))
 	}
 }
+
+func TestHooksDir(t *testing.T) {
+	repo := initTestRepo(t)
+	dir, err := New(repo).HooksDir()
+	if err != nil {
+		t.Fatalf("HooksDir: %v", err)
+	}
+	if want := filepath.Join(repo, ".git", "hooks"); dir != want {
+		t.Errorf("HooksDir() = %q, want %q", dir, want)
+	}
+
+	runGit(t, repo, "config", "core.hooksPath", "githooks")
+	dir, err = New(repo).HooksDir()
+	if err != nil {
+		t.Fatalf("HooksDir: %v", err)
+	}
+	if want := filepath.Join(repo, "githooks"); dir != want {
+		t.Errorf("HooksDir() with core.hooksPath = %q, want %q", dir, want)
+	}
+}
+
+func TestHeadCommit(t *testing.T) {
+	repo := initTestRepo(t)
+	runGit(t, repo, "commit", "--allow-empty", "-m", "fix login\n\nCloses gc-7.")
+	sha, msg, err := New(repo).HeadCommit()
+	if err != nil {
+		t.Fatalf("HeadCommit: %v", err)
+	}
+	if len(sha) != 40 {
+		t.Errorf("sha = %q, want 40 hex chars", sha)
+	}
+	if msg != "fix login\n\nCloses gc-7." {
+		t.Errorf("message = %q", msg)
+	}
+}
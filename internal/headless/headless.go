@@ -0,0 +1,203 @@
+// Package headless runs a provider CLI non-interactively: one prompt in,
+// one reply out, no tmux session. Gates, reviews, and gc agent exec use
+// it when they need an answer rather than a running agent.
+package headless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/shellquote"
+)
+
+// MaxOutputBytes caps the captured stdout and stderr of a run (each).
+const MaxOutputBytes = 1 << 20
+
+// Invocation describes one headless provider run.
+type Invocation struct {
+	// Command is the provider command line, including its configured
+	// args (e.g. "claude --dangerously-skip-permissions"). It runs via
+	// sh -c, so it may use shell syntax.
+	Command string
+	// PrintArgs switch the provider to non-interactive mode (e.g. ["-p"]).
+	PrintArgs []string
+	// PromptMode is how the prompt reaches the provider: "arg" (default)
+	// appends it as the last argument, "flag" passes it after PromptFlag,
+	// "stdin" writes it to the provider's stdin, and "none" drops it.
+	PromptMode string
+	PromptFlag string
+	Prompt     string
+	// Dir is the working directory; empty means the caller's.
+	Dir string
+	// Env is the full process environment; nil means the caller's.
+	Env []string
+	// Timeout bounds each attempt; zero means no limit.
+	Timeout time.Duration
+}
+
+// RetryPolicy controls how often a failed run is retried. A run fails
+// when the provider exits nonzero, times out, or cannot be started.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts; values below 1 mean 1.
+	MaxAttempts int
+	// Backoff is the wait before the second attempt, doubled for each
+	// attempt after that.
+	Backoff time.Duration
+}
+
+// Result is the outcome of the last attempt of a run.
+type Result struct {
+	Output    string        // captured stdout (the provider's reply)
+	Stderr    string        // captured stderr
+	ExitCode  int           // provider exit code; -1 if it did not exit on its own
+	TimedOut  bool          // the last attempt hit Invocation.Timeout
+	Truncated bool          // stdout or stderr exceeded MaxOutputBytes
+	Attempts  int           // attempts made, including the last
+	Duration  time.Duration // wall-clock time of the last attempt
+	Err       error         // start failure or context error, if any
+}
+
+// OK reports whether the run succeeded.
+func (r Result) OK() bool {
+	return r.Err == nil && !r.TimedOut && r.ExitCode == 0
+}
+
+// Summary describes the outcome in a few words, e.g. "exit 0 after 1
+// attempt" or "timed out after 3 attempts".
+func (r Result) Summary() string {
+	var what string
+	switch {
+	case r.TimedOut:
+		what = "timed out"
+	case r.Err != nil && r.ExitCode < 0:
+		what = "failed: " + r.Err.Error()
+	default:
+		what = fmt.Sprintf("exit %d", r.ExitCode)
+	}
+	noun := "attempts"
+	if r.Attempts == 1 {
+		noun = "attempt"
+	}
+	return fmt.Sprintf("%s after %d %s", what, r.Attempts, noun)
+}
+
+// CommandLine returns the shell command line inv runs. The prompt is
+// shell-quoted; it is absent in "stdin" and "none" modes.
+func (inv Invocation) CommandLine() (string, error) {
+	if strings.TrimSpace(inv.Command) == "" {
+		return "", errors.New("headless: empty command")
+	}
+	parts := []string{inv.Command}
+	for _, a := range inv.PrintArgs {
+		parts = append(parts, shellquote.Quote(a))
+	}
+	switch inv.PromptMode {
+	case "", "arg":
+		parts = append(parts, shellquote.Quote(inv.Prompt))
+	case "flag":
+		if inv.PromptFlag == "" {
+			return "", errors.New("headless: prompt_mode \"flag\" needs a prompt_flag")
+		}
+		parts = append(parts, inv.PromptFlag, shellquote.Quote(inv.Prompt))
+	case "stdin", "none":
+	default:
+		return "", fmt.Errorf("headless: unknown prompt_mode %q", inv.PromptMode)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// Run executes inv, retrying per policy until an attempt succeeds, the
+// attempts run out, or ctx is done. It returns the last attempt's
+// result; an invalid invocation is reported in Result.Err.
+func Run(ctx context.Context, inv Invocation, policy RetryPolicy) Result {
+	line, err := inv.CommandLine()
+	if err != nil {
+		return Result{ExitCode: -1, Err: err}
+	}
+	attempts := max(policy.MaxAttempts, 1)
+	backoff := policy.Backoff
+	var res Result
+	for n := 1; ; n++ {
+		res = runOnce(ctx, inv, line)
+		res.Attempts = n
+		if res.OK() || n >= attempts || ctx.Err() != nil {
+			return res
+		}
+		if backoff > 0 {
+			select {
+			case <-ctx.Done():
+				return res
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+}
+
+// runOnce makes a single attempt.
+func runOnce(ctx context.Context, inv Invocation, line string) Result {
+	execCtx, cancel := ctx, context.CancelFunc(func() {})
+	if inv.Timeout > 0 {
+		execCtx, cancel = context.WithTimeout(ctx, inv.Timeout)
+	}
+	defer cancel()
+
+	cmd := exec.CommandContext(execCtx, "sh", "-c", line)
+	cmd.Dir = inv.Dir
+	cmd.Env = inv.Env
+	if inv.PromptMode == "stdin" {
+		cmd.Stdin = strings.NewReader(inv.Prompt)
+	}
+	// WaitDelay keeps Wait from hanging on pipes held open by children
+	// after the provider is killed.
+	cmd.WaitDelay = time.Second
+	stdout := &limitedBuffer{max: MaxOutputBytes}
+	stderr := &limitedBuffer{max: MaxOutputBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	res := Result{
+		Output:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.overflow || stderr.overflow,
+		Duration:  time.Since(start),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		res.ExitCode, res.Err = -1, ctx.Err()
+	case execCtx.Err() != nil:
+		res.ExitCode, res.TimedOut = -1, true
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		res.ExitCode, res.Err = -1, err
+	}
+	return res
+}
+
+// limitedBuffer keeps the first max bytes written to it and drops the
+// rest, so a chatty provider cannot exhaust memory.
+type limitedBuffer struct {
+	buf      []byte
+	max      int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := b.max - len(b.buf); n > room {
+		p = p[:max(room, 0)]
+		b.overflow = true
+	}
+	b.buf = append(b.buf, p...)
+	return n, nil
+}
+
+func (b *limitedBuffer) String() string { return string(b.buf) }
@@ -0,0 +1,113 @@
+package headless
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestCommandLine(t *testing.T) {
+	for _, tc := range []struct {
+		inv     Invocation
+		want    string
+		wantErr bool
+	}{
+		{Invocation{Command: "claude --x", PrintArgs: []string{"-p"}, Prompt: "it's"}, `claude --x '-p' 'it'\''s'`, false},
+		{Invocation{Command: "kiro", PromptMode: "flag", PromptFlag: "--prompt", Prompt: "hi"}, `kiro --prompt 'hi'`, false},
+		{Invocation{Command: "llm", PromptMode: "stdin", Prompt: "hi"}, `llm`, false},
+		{Invocation{Command: "kiro", PromptMode: "flag", Prompt: "hi"}, "", true},
+		{Invocation{Command: "kiro", PromptMode: "pipe"}, "", true},
+		{Invocation{}, "", true},
+	} {
+		got, err := tc.inv.CommandLine()
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("CommandLine(%+v) = %q, %v; want %q, err %v", tc.inv, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestRunPromptModes(t *testing.T) {
+	res := Run(context.Background(), Invocation{Command: "echo", Prompt: "hello world"}, RetryPolicy{})
+	if !res.OK() || res.Output != "hello world\n" || res.Attempts != 1 {
+		t.Errorf("arg mode: %+v", res)
+	}
+	res = Run(context.Background(), Invocation{Command: "tr a-z A-Z", PromptMode: "stdin", Prompt: "shout"}, RetryPolicy{})
+	if !res.OK() || res.Output != "SHOUT" {
+		t.Errorf("stdin mode: %+v", res)
+	}
+}
+
+func TestRunRetries(t *testing.T) {
+	count := filepath.Join(t.TempDir(), "n")
+	inv := Invocation{
+		Command:    "echo x >> " + count + "; echo try >&2; [ $(wc -l < " + count + ") -ge 3 ]",
+		PromptMode: "none",
+	}
+	res := Run(context.Background(), inv, RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond})
+	if res.OK() || res.ExitCode != 1 || res.Attempts != 2 || res.Stderr != "try\n" {
+		t.Errorf("exhausted retries: %+v", res)
+	}
+	if got := res.Summary(); got != "exit 1 after 2 attempts" {
+		t.Errorf("Summary = %q", got)
+	}
+	res = Run(context.Background(), inv, RetryPolicy{MaxAttempts: 5})
+	if !res.OK() || res.Attempts != 1 {
+		t.Errorf("third run overall should pass: %+v", res)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	res := Run(context.Background(), Invocation{Command: "sleep 5", PromptMode: "none", Timeout: 50 * time.Millisecond}, RetryPolicy{})
+	if res.OK() || !res.TimedOut || res.ExitCode != -1 {
+		t.Errorf("timeout: %+v", res)
+	}
+	if got := res.Summary(); got != "timed out after 1 attempt" {
+		t.Errorf("Summary = %q", got)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	store := beads.NewMemStore()
+	b, err := store.Create(beads.Bead{Title: "Review PR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := Result{Output: "LGTM\n", Attempts: 2, Duration: 1500 * time.Millisecond}
+	if err := Record(store, b.ID, "reviewer", res); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	got, err := store.Get(b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range map[string]string{MetaExitCode: "0", MetaAttempts: "2", MetaDurationMs: "1500", MetaTimedOut: "false"} {
+		if got.Metadata[k] != want {
+			t.Errorf("metadata %s = %q, want %q", k, got.Metadata[k], want)
+		}
+	}
+	if len(got.Comments) != 1 || got.Comments[0].Author != "reviewer" ||
+		got.Comments[0].Text != "Headless run: exit 0 after 2 attempts\n\nLGTM" {
+		t.Errorf("comments = %+v", got.Comments)
+	}
+
+	if err := Record(store, b.ID, "reviewer", Result{ExitCode: 2, Output: "partial", Stderr: "boom", Attempts: 1}); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = store.Get(b.ID)
+	if c := got.Comments[1].Text; !strings.HasSuffix(c, "\n\nboom") {
+		t.Errorf("failed run comment = %q, want stderr", c)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("héllo", 2); got != "h\n… (truncated)" {
+		t.Errorf("truncate = %q", got)
+	}
+	if got := truncate("hi", 2); got != "hi" {
+		t.Errorf("truncate = %q", got)
+	}
+}
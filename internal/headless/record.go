@@ -0,0 +1,55 @@
+package headless
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// Metadata keys Record sets on a bead.
+const (
+	MetaExitCode   = "headless_exit_code"
+	MetaAttempts   = "headless_attempts"
+	MetaDurationMs = "headless_duration_ms"
+	MetaTimedOut   = "headless_timed_out"
+)
+
+// MaxCommentBytes caps the output Record copies into a bead comment.
+const MaxCommentBytes = 16 << 10
+
+// Record stores res on bead id: the outcome as metadata, and the reply
+// (or, for a failed run, its stderr) as a comment by actor.
+func Record(store beads.Store, id, actor string, res Result) error {
+	err := store.SetMetadataBatch(id, map[string]string{
+		MetaExitCode:   strconv.Itoa(res.ExitCode),
+		MetaAttempts:   strconv.Itoa(res.Attempts),
+		MetaDurationMs: strconv.FormatInt(res.Duration.Milliseconds(), 10),
+		MetaTimedOut:   strconv.FormatBool(res.TimedOut),
+	})
+	if err != nil {
+		return err
+	}
+	body := res.Output
+	if !res.OK() && strings.TrimSpace(res.Stderr) != "" {
+		body = res.Stderr
+	}
+	text := "Headless run: " + res.Summary()
+	if body = strings.TrimSpace(body); body != "" {
+		text += "\n\n" + truncate(body, MaxCommentBytes)
+	}
+	_, err = store.AddComment(id, beads.Comment{Author: actor, Text: text})
+	return err
+}
+
+// truncate cuts s to at most n bytes on a rune boundary, marking the cut.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "\n… (truncated)"
+}
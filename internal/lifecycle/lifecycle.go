@@ -0,0 +1,119 @@
+// Package lifecycle runs the [[hooks]] declared in city.toml when city
+// lifecycle events happen: session start and stop, bead close, and sling.
+//
+// Each hook is a shell command run from the city root with a JSON
+// [Payload] on stdin. A failing hook either warns (logged, the operation
+// proceeds) or aborts (the operation fails with the hook's error),
+// according to its on_failure policy.
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// Payload is the JSON object a hook receives on stdin. Fields that don't
+// apply to the event are omitted.
+type Payload struct {
+	Event   string `json:"event"`
+	City    string `json:"city,omitempty"`
+	Session string `json:"session,omitempty"`
+	Agent   string `json:"agent,omitempty"`
+	WorkDir string `json:"work_dir,omitempty"`
+	Bead    string `json:"bead,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Target  string `json:"target,omitempty"`
+	Formula string `json:"formula,omitempty"`
+	Method  string `json:"method,omitempty"`
+	// Data carries the raw record behind the event when there is one,
+	// such as the closed bead's JSON.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// runFunc runs one hook command and returns its combined output.
+type runFunc func(ctx context.Context, dir, command, event string, stdin []byte) ([]byte, error)
+
+// Runner fires lifecycle hooks for one city.
+type Runner struct {
+	// Hooks returns the city's current hooks. Called on every Fire so a
+	// long-lived runner sees config reloads.
+	Hooks func() []config.LifecycleHook
+	// CityPath is the working directory for hook commands.
+	CityPath string
+	// CityName is reported in every payload.
+	CityName string
+	// Stderr receives warnings from hooks that fail with on_failure = "warn".
+	Stderr io.Writer
+
+	run runFunc
+}
+
+// Fire runs every hook registered for p.Event, in declaration order. A
+// failing "warn" hook is logged to Stderr; the first failing "abort" hook
+// stops the remaining hooks and its error is returned.
+func (r *Runner) Fire(ctx context.Context, p Payload) error {
+	if r == nil || r.Hooks == nil {
+		return nil
+	}
+	hooks := config.HooksFor(r.Hooks(), p.Event)
+	if len(hooks) == 0 {
+		return nil
+	}
+	if p.City == "" {
+		p.City = r.CityName
+	}
+	stdin, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("%s hook payload: %w", p.Event, err)
+	}
+	run := r.run
+	if run == nil {
+		run = shellRun
+	}
+	for _, h := range hooks {
+		hctx, cancel := context.WithTimeout(ctx, h.TimeoutDuration())
+		out, err := run(hctx, r.CityPath, h.Run, p.Event, stdin)
+		cancel()
+		if err == nil {
+			continue
+		}
+		herr := fmt.Errorf("%s hook %q: %w", p.Event, h.Run, err)
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			herr = fmt.Errorf("%w: %s", herr, msg)
+		}
+		if h.Aborts() && p.Event != config.HookPostCloseBead {
+			return herr
+		}
+		if r.Stderr != nil {
+			fmt.Fprintf(r.Stderr, "warning: %v\n", herr) //nolint:errcheck // best-effort stderr
+		}
+	}
+	return nil
+}
+
+// shellRun runs command with sh -c in dir, feeding stdin. The event name
+// is also set as GC_HOOK_EVENT so one script can serve several events.
+func shellRun(ctx context.Context, dir, command, event string, stdin []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GC_HOOK_EVENT="+event)
+	cmd.Stdin = bytes.NewReader(stdin)
+	// Background children of a timed-out hook can hold its output pipe
+	// open; don't wait on them.
+	cmd.WaitDelay = time.Second
+	out, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return out, errors.New("timed out")
+	}
+	return out, err
+}
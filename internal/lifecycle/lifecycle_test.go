@@ -0,0 +1,152 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+)
+
+// recordRun is a runFunc that records each command and fails the ones
+// listed in fail.
+type recordRun struct {
+	ran   []string
+	stdin [][]byte
+	fail  map[string]bool
+}
+
+func (r *recordRun) run(_ context.Context, _, command, _ string, stdin []byte) ([]byte, error) {
+	r.ran = append(r.ran, command)
+	r.stdin = append(r.stdin, stdin)
+	if r.fail[command] {
+		return []byte("boom\n"), errors.New("exit status 1")
+	}
+	return nil, nil
+}
+
+func newTestRunner(hooks []config.LifecycleHook, rec *recordRun, stderr *bytes.Buffer) *Runner {
+	return &Runner{
+		Hooks:    func() []config.LifecycleHook { return hooks },
+		CityName: "bright-lights",
+		Stderr:   stderr,
+		run:      rec.run,
+	}
+}
+
+func TestFireRunsMatchingHooksInOrder(t *testing.T) {
+	rec := &recordRun{}
+	r := newTestRunner([]config.LifecycleHook{
+		{Event: config.HookPreStart, Run: "one"},
+		{Event: config.HookPreStop, Run: "other"},
+		{Event: config.HookPreStart, Run: "two"},
+	}, rec, &bytes.Buffer{})
+
+	if err := r.Fire(context.Background(), Payload{Event: config.HookPreStart, Session: "mayor"}); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+	if strings.Join(rec.ran, ",") != "one,two" {
+		t.Errorf("ran = %v, want [one two]", rec.ran)
+	}
+	var p Payload
+	if err := json.Unmarshal(rec.stdin[0], &p); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if p.Event != config.HookPreStart || p.Session != "mayor" || p.City != "bright-lights" {
+		t.Errorf("payload = %+v", p)
+	}
+}
+
+func TestFireWarnContinues(t *testing.T) {
+	rec := &recordRun{fail: map[string]bool{"bad": true}}
+	var stderr bytes.Buffer
+	r := newTestRunner([]config.LifecycleHook{
+		{Event: config.HookOnSling, Run: "bad"},
+		{Event: config.HookOnSling, Run: "good"},
+	}, rec, &stderr)
+
+	if err := r.Fire(context.Background(), Payload{Event: config.HookOnSling}); err != nil {
+		t.Fatalf("Fire = %v, want nil for warn hook", err)
+	}
+	if len(rec.ran) != 2 {
+		t.Errorf("ran = %v, want both hooks", rec.ran)
+	}
+	if !strings.Contains(stderr.String(), `warning: on_sling hook "bad": exit status 1: boom`) {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestFireAbortStops(t *testing.T) {
+	rec := &recordRun{fail: map[string]bool{"bad": true}}
+	r := newTestRunner([]config.LifecycleHook{
+		{Event: config.HookOnSling, Run: "bad", OnFailure: "abort"},
+		{Event: config.HookOnSling, Run: "never"},
+	}, rec, &bytes.Buffer{})
+
+	err := r.Fire(context.Background(), Payload{Event: config.HookOnSling})
+	if err == nil || !strings.Contains(err.Error(), `on_sling hook "bad"`) {
+		t.Fatalf("Fire = %v, want abort error", err)
+	}
+	if len(rec.ran) != 1 {
+		t.Errorf("ran = %v, want only the aborting hook", rec.ran)
+	}
+}
+
+func TestFirePostCloseBeadNeverAborts(t *testing.T) {
+	rec := &recordRun{fail: map[string]bool{"bad": true}}
+	r := newTestRunner([]config.LifecycleHook{
+		{Event: config.HookPostCloseBead, Run: "bad", OnFailure: "abort"},
+	}, rec, &bytes.Buffer{})
+	if err := r.Fire(context.Background(), Payload{Event: config.HookPostCloseBead}); err != nil {
+		t.Errorf("Fire = %v, want nil", err)
+	}
+}
+
+func TestFireNilRunner(t *testing.T) {
+	var r *Runner
+	if err := r.Fire(context.Background(), Payload{Event: config.HookPreStart}); err != nil {
+		t.Errorf("nil Runner Fire = %v, want nil", err)
+	}
+}
+
+func TestShellRunPassesPayloadAndEvent(t *testing.T) {
+	dir := t.TempDir()
+	r := &Runner{
+		Hooks: func() []config.LifecycleHook {
+			return []config.LifecycleHook{{Event: config.HookPostStart, Run: `cat > payload.json; echo "$GC_HOOK_EVENT" > event`}}
+		},
+		CityPath: dir,
+	}
+	if err := r.Fire(context.Background(), Payload{Event: config.HookPostStart, Agent: "mayor"}); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "payload.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"agent":"mayor"`) {
+		t.Errorf("payload = %s", data)
+	}
+	event, _ := os.ReadFile(filepath.Join(dir, "event"))
+	if strings.TrimSpace(string(event)) != config.HookPostStart {
+		t.Errorf("GC_HOOK_EVENT = %q, want %q", event, config.HookPostStart)
+	}
+}
+
+func TestShellRunTimeout(t *testing.T) {
+	r := &Runner{
+		Hooks: func() []config.LifecycleHook {
+			return []config.LifecycleHook{{Event: config.HookPreStop, Run: "sleep 5", OnFailure: "abort", Timeout: "50ms"}}
+		},
+		CityPath: t.TempDir(),
+	}
+	err := r.Fire(context.Background(), Payload{Event: config.HookPreStop})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Fire = %v, want timeout", err)
+	}
+}
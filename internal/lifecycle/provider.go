@@ -0,0 +1,54 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// Provider wraps a [runtime.Provider] so session starts and stops fire
+// the pre_start, post_start, and pre_stop hooks. Every other operation,
+// including optional extensions, goes straight to the wrapped provider.
+type Provider struct {
+	runtime.Wrapper
+	hooks *Runner
+}
+
+var _ runtime.Provider = (*Provider)(nil)
+
+// WrapProvider returns sp with session lifecycle hooks from hooks.
+func WrapProvider(sp runtime.Provider, hooks *Runner) *Provider {
+	return &Provider{Wrapper: runtime.Wrapper{Provider: sp}, hooks: hooks}
+}
+
+// Start fires pre_start, starts the session, then fires post_start. An
+// aborting pre_start hook prevents the start; an aborting post_start hook
+// stops the new session and fails the start.
+func (p *Provider) Start(ctx context.Context, name string, cfg runtime.Config) error {
+	payload := Payload{Session: name, Agent: cfg.Env["GC_AGENT"], WorkDir: cfg.WorkDir}
+	payload.Event = config.HookPreStart
+	if err := p.hooks.Fire(ctx, payload); err != nil {
+		return err
+	}
+	if err := p.Provider.Start(ctx, name, cfg); err != nil {
+		return err
+	}
+	payload.Event = config.HookPostStart
+	if err := p.hooks.Fire(ctx, payload); err != nil {
+		return errors.Join(err, p.Provider.Stop(name))
+	}
+	return nil
+}
+
+// Stop fires pre_stop, then stops the session. An aborting pre_stop hook
+// leaves the session running.
+func (p *Provider) Stop(name string) error {
+	if p.Provider.IsRunning(name) {
+		if err := p.hooks.Fire(context.Background(), Payload{Event: config.HookPreStop, Session: name}); err != nil {
+			return err
+		}
+	}
+	return p.Provider.Stop(name)
+}
@@ -0,0 +1,92 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/config"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestProviderStartFiresHooks(t *testing.T) {
+	rec := &recordRun{}
+	r := newTestRunner([]config.LifecycleHook{
+		{Event: config.HookPreStart, Run: "pre"},
+		{Event: config.HookPostStart, Run: "post"},
+	}, rec, &bytes.Buffer{})
+	sp := WrapProvider(runtime.NewFake(), r)
+
+	cfg := runtime.Config{WorkDir: "/w", Env: map[string]string{"GC_AGENT": "mayor"}}
+	if err := sp.Start(context.Background(), "mayor", cfg); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if strings.Join(rec.ran, ",") != "pre,post" {
+		t.Errorf("ran = %v, want [pre post]", rec.ran)
+	}
+	if !strings.Contains(string(rec.stdin[0]), `"agent":"mayor"`) || !strings.Contains(string(rec.stdin[0]), `"work_dir":"/w"`) {
+		t.Errorf("pre_start payload = %s", rec.stdin[0])
+	}
+	if !sp.IsRunning("mayor") {
+		t.Error("session not running after Start")
+	}
+}
+
+func TestProviderPreStartAbortPreventsStart(t *testing.T) {
+	rec := &recordRun{fail: map[string]bool{"pre": true}}
+	r := newTestRunner([]config.LifecycleHook{{Event: config.HookPreStart, Run: "pre", OnFailure: "abort"}}, rec, &bytes.Buffer{})
+	sp := WrapProvider(runtime.NewFake(), r)
+
+	if err := sp.Start(context.Background(), "mayor", runtime.Config{}); err == nil {
+		t.Fatal("Start = nil, want abort error")
+	}
+	if sp.IsRunning("mayor") {
+		t.Error("session started despite aborting pre_start hook")
+	}
+}
+
+func TestProviderPostStartAbortStopsSession(t *testing.T) {
+	rec := &recordRun{fail: map[string]bool{"post": true}}
+	r := newTestRunner([]config.LifecycleHook{{Event: config.HookPostStart, Run: "post", OnFailure: "abort"}}, rec, &bytes.Buffer{})
+	sp := WrapProvider(runtime.NewFake(), r)
+
+	if err := sp.Start(context.Background(), "mayor", runtime.Config{}); err == nil {
+		t.Fatal("Start = nil, want abort error")
+	}
+	if sp.IsRunning("mayor") {
+		t.Error("session left running after aborting post_start hook")
+	}
+}
+
+func TestProviderPreStopAbortKeepsSession(t *testing.T) {
+	rec := &recordRun{fail: map[string]bool{"guard": true}}
+	r := newTestRunner([]config.LifecycleHook{{Event: config.HookPreStop, Run: "guard", OnFailure: "abort"}}, rec, &bytes.Buffer{})
+	fake := runtime.NewFake()
+	sp := WrapProvider(fake, r)
+	if err := fake.Start(context.Background(), "mayor", runtime.Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sp.Stop("mayor"); err == nil {
+		t.Fatal("Stop = nil, want abort error")
+	}
+	if !sp.IsRunning("mayor") {
+		t.Error("session stopped despite aborting pre_stop hook")
+	}
+	// Stopping a session that isn't running doesn't fire pre_stop.
+	rec.ran = nil
+	if err := sp.Stop("ghost"); err != nil {
+		t.Errorf("Stop(ghost) = %v", err)
+	}
+	if len(rec.ran) != 0 {
+		t.Errorf("pre_stop ran for a missing session: %v", rec.ran)
+	}
+}
+
+func TestProviderUnwrap(t *testing.T) {
+	fake := runtime.NewFake()
+	if got := WrapProvider(fake, nil).Unwrap(); got != fake {
+		t.Errorf("Unwrap() = %v, want the wrapped provider", got)
+	}
+}
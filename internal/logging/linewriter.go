@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// LineWriter is an io.Writer that logs each complete line written to it
+// as one record at a fixed level. A leading "component: " prefix, as in
+// "gc start: config reload: ...", is split into a component attribute.
+// Incomplete trailing lines are held until the newline arrives or Flush
+// is called. Safe for concurrent use.
+type LineWriter struct {
+	mu    sync.Mutex
+	log   *slog.Logger
+	level slog.Level
+	raw   io.Writer
+	buf   []byte
+}
+
+// NewLineWriter returns a LineWriter logging to l at level.
+func NewLineWriter(l *slog.Logger, level slog.Level) *LineWriter {
+	return &LineWriter{log: l, level: level}
+}
+
+// NewMirrorWriter returns a LineWriter that passes everything written to
+// it through to raw unchanged and mirrors each line into l. Mirrored lines
+// that report an error or failure are logged at error level, the rest at
+// level, so a minimum level above level keeps the errors.
+func NewMirrorWriter(raw io.Writer, l *slog.Logger, level slog.Level) *LineWriter {
+	return &LineWriter{log: l, level: level, raw: raw}
+}
+
+// Write logs every complete line in p, after passing p to the raw writer
+// of a mirror.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.raw != nil {
+		if n, err := w.raw.Write(p); err != nil {
+			return n, err
+		}
+	}
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush logs any buffered partial line.
+func (w *LineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.emit(string(w.buf))
+		w.buf = nil
+	}
+}
+
+// emit logs one line. Called with mu held.
+func (w *LineWriter) emit(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	level := w.level
+	if w.raw != nil && level < slog.LevelError && reportsError(line) {
+		level = slog.LevelError
+	}
+	if comp, msg, ok := strings.Cut(line, ": "); ok && isComponent(comp) {
+		w.log.Log(context.Background(), level, msg, "component", comp)
+		return
+	}
+	w.log.Log(context.Background(), level, line)
+}
+
+// reportsError reports whether line reads as an error report rather than
+// a notice ("... failed", "error: ...", "panic: ...").
+func reportsError(line string) bool {
+	l := strings.ToLower(line)
+	for _, word := range []string{"error", "fail", "panic", "fatal"} {
+		if strings.Contains(l, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// isComponent reports whether s looks like a command or subsystem prefix
+// ("gc start", "api", "session reconciler") rather than part of a message.
+func isComponent(s string) bool {
+	if s == "" || len(s) > 32 {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == ' ' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
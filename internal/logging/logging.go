@@ -0,0 +1,146 @@
+// Package logging configures structured (log/slog) logging for gc and the
+// city controller: level and format selection, a size-rotated log file,
+// and an adapter that turns lines written to an io.Writer into records so
+// code that reports through a threaded stderr writer logs structurally.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// Options selects the minimum level and output format of log records.
+type Options struct {
+	// Level is the minimum level emitted.
+	Level slog.Level
+	// Format is "text" (logfmt key=value) or "json".
+	Format string
+}
+
+var (
+	mu      sync.RWMutex
+	current = Options{Level: slog.LevelInfo, Format: "text"}
+)
+
+// Configure sets the process-wide options used by [New] and [FromWriter].
+func Configure(o Options) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = o
+}
+
+// Current returns the process-wide options.
+func Current() Options {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// ParseLevel parses a level name: debug, info, warn (or warning), error.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q (want debug, info, warn, or error)", s)
+}
+
+// LevelName returns the name [ParseLevel] accepts for l.
+func LevelName(l slog.Level) string {
+	return strings.ToLower(l.String())
+}
+
+// ParseFormat validates a format name: text or json.
+func ParseFormat(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "text", "":
+		return "text", nil
+	case "json":
+		return "json", nil
+	}
+	return "", fmt.Errorf("unknown log format %q (want text or json)", s)
+}
+
+// NewHandler returns a handler writing records to w per o.
+func NewHandler(w io.Writer, o Options) slog.Handler {
+	ho := &slog.HandlerOptions{Level: o.Level}
+	if o.Format == "json" {
+		return slog.NewJSONHandler(w, ho)
+	}
+	return slog.NewTextHandler(w, ho)
+}
+
+// New returns a logger writing to w with the process-wide options.
+func New(w io.Writer) *slog.Logger {
+	return slog.New(NewHandler(w, Current()))
+}
+
+// FromWriter returns the logger behind w when w is a [LineWriter], so
+// records logged directly and lines written to w end up in the same
+// place; for a mirror, records go to both its raw writer and its logger.
+// Otherwise it returns [New](w).
+func FromWriter(w io.Writer) *slog.Logger {
+	if lw, ok := w.(*LineWriter); ok {
+		if lw.raw != nil {
+			return slog.New(Tee(NewHandler(lw.raw, Current()), lw.log.Handler()))
+		}
+		return lw.log
+	}
+	return New(w)
+}
+
+// Tee returns a handler that passes each record to every handler in hs
+// that is enabled for its level.
+func Tee(hs ...slog.Handler) slog.Handler {
+	return teeHandler(hs)
+}
+
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, l) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, h := range t {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	for in, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "": slog.LevelInfo,
+		"warn": slog.LevelWarn, "warning": slog.LevelWarn, "error": slog.LevelError,
+	} {
+		got, err := ParseLevel(in)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", in, got, err, want)
+		}
+		if in != "" && in != "warning" && in != "INFO" && LevelName(got) != in {
+			t.Errorf("LevelName(%v) = %q, want %q", got, LevelName(got), in)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("ParseLevel(loud) succeeded, want error")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("JSON"); err != nil || f != "json" {
+		t.Errorf("ParseFormat(JSON) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) succeeded, want error")
+	}
+}
+
+func TestTeeRespectsEachLevel(t *testing.T) {
+	var warnOnly, all bytes.Buffer
+	l := slog.New(Tee(
+		NewHandler(&warnOnly, Options{Level: slog.LevelWarn, Format: "text"}),
+		NewHandler(&all, Options{Level: slog.LevelDebug, Format: "json"}),
+	))
+	l.Info("tick", "n", 1)
+	l.Warn("reload failed")
+
+	if strings.Contains(warnOnly.String(), "tick") || !strings.Contains(warnOnly.String(), "reload failed") {
+		t.Errorf("warn handler got %q", warnOnly.String())
+	}
+	lines := strings.Split(strings.TrimSpace(all.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("debug handler got %d records, want 2", len(lines))
+	}
+	var rec map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil || rec["msg"] != "tick" {
+		t.Errorf("json record = %q, %v", lines[0], err)
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewLineWriter(slog.New(NewHandler(&buf, Options{Level: slog.LevelInfo, Format: "json"})), slog.LevelWarn)
+	w.Write([]byte("gc start: config reload: bad toml\nno prefix here\npart")) //nolint:errcheck // LineWriter never fails
+	w.Write([]byte("ial\n"))                                                   //nolint:errcheck // LineWriter never fails
+	w.Write([]byte("Error: x=1: not a component\ntrailing"))                   //nolint:errcheck // LineWriter never fails
+	w.Flush()
+
+	var recs []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, rec)
+	}
+	want := []struct{ msg, comp string }{
+		{"config reload: bad toml", "gc start"},
+		{"no prefix here", ""},
+		{"partial", ""},
+		{"Error: x=1: not a component", ""},
+		{"trailing", ""},
+	}
+	if len(recs) != len(want) {
+		t.Fatalf("got %d records, want %d: %s", len(recs), len(want), buf.String())
+	}
+	for i, w := range want {
+		if recs[i]["msg"] != w.msg || recs[i]["level"] != "WARN" {
+			t.Errorf("record %d = %v, want msg %q at WARN", i, recs[i], w.msg)
+		}
+		if comp, _ := recs[i]["component"].(string); comp != w.comp {
+			t.Errorf("record %d component = %q, want %q", i, comp, w.comp)
+		}
+	}
+	if FromWriter(w) != w.log {
+		t.Error("FromWriter(LineWriter) should return the writer's logger")
+	}
+}
+
+func TestMirrorWriter(t *testing.T) {
+	var raw, buf bytes.Buffer
+	w := NewMirrorWriter(&raw, slog.New(NewHandler(&buf, Options{Level: slog.LevelWarn, Format: "json"})), slog.LevelInfo)
+	w.Write([]byte("Controller started.\napi: listen failed: address in use\n")) //nolint:errcheck // test writer
+
+	if raw.String() != "Controller started.\napi: listen failed: address in use\n" {
+		t.Errorf("raw = %q, want the bytes passed through", raw.String())
+	}
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("mirror = %q: %v", buf.String(), err)
+	}
+	if rec["level"] != "ERROR" || rec["component"] != "api" || rec["msg"] != "listen failed: address in use" {
+		t.Errorf("record = %v, want the failure at ERROR", rec)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "gc.log")
+	f, err := OpenRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"aaaaaa\n", "bbbbbb\n", "cccccc\n", "dddddd\n"} {
+		if _, err := f.Write([]byte(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"gc.log":   "dddddd\n",
+		"gc.log.1": "cccccc\n",
+		"gc.log.2": "bbbbbb\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(filepath.Dir(path), name))
+		if err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("kept more backups than configured")
+	}
+
+	// Reopening appends and accounts for the existing size.
+	f, err = OpenRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte("eeeeee\n")) //nolint:errcheck // checked via file contents
+	f.Close()                   //nolint:errcheck // test cleanup
+	if data, _ := os.ReadFile(path + ".1"); string(data) != "dddddd\n" {
+		t.Errorf("reopened file did not rotate on size: gc.log.1 = %q", data)
+	}
+}
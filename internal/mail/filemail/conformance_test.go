@@ -0,0 +1,14 @@
+package filemail
+
+import (
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/mail"
+	"github.com/gastownhall/gascity/internal/mail/mailtest"
+)
+
+func TestFilemailConformance(t *testing.T) {
+	mailtest.RunProviderTests(t, func(t *testing.T) mail.Provider {
+		return New(t.TempDir())
+	})
+}
@@ -0,0 +1,307 @@
+// Package filemail implements [mail.Provider] as plain files: one
+// directory per recipient, one JSON file per message. It needs no bead
+// store, so mail keeps working in cities that run without one, and
+// mailboxes can be inspected with ordinary tools.
+package filemail
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/fsys"
+	"github.com/gastownhall/gascity/internal/mail"
+)
+
+// record is the on-disk form of a message.
+type record struct {
+	mail.Message
+	Archived bool `json:"archived,omitempty"`
+}
+
+// Provider stores mail under a root directory (normally .gc/mail).
+// Safe for concurrent use within a process; across processes each
+// message file is replaced atomically.
+type Provider struct {
+	root string
+	mu   sync.Mutex
+}
+
+// New returns a provider rooted at dir. The directory is created on
+// first send.
+func New(dir string) *Provider {
+	return &Provider{root: dir}
+}
+
+// mailboxDir returns the directory holding recipient's messages. Qualified
+// names like "hw/polecat" map to "hw--polecat", matching session naming.
+func (p *Provider) mailboxDir(recipient string) string {
+	name := strings.ReplaceAll(strings.TrimSuffix(recipient, "/"), "/", "--")
+	if name == "" || name == "." || name == ".." {
+		name = "_"
+	}
+	return filepath.Join(p.root, name)
+}
+
+// Send writes a new message to the recipient's mailbox.
+func (p *Provider) Send(from, to, subject, body string) (mail.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	m := mail.Message{
+		ID:        newID("mail"),
+		From:      from,
+		To:        to,
+		Subject:   subject,
+		Body:      body,
+		CreatedAt: time.Now().UTC(),
+		ThreadID:  newID("thread"),
+	}
+	if err := p.write(record{Message: m}); err != nil {
+		return mail.Message{}, fmt.Errorf("sending message: %w", err)
+	}
+	return m, nil
+}
+
+// Inbox returns unread, non-archived messages for the recipient.
+func (p *Provider) Inbox(recipient string) ([]mail.Message, error) {
+	return p.list(recipient, true)
+}
+
+// Check returns unread messages without marking them read.
+func (p *Provider) Check(recipient string) ([]mail.Message, error) {
+	return p.list(recipient, true)
+}
+
+// All returns all non-archived messages for the recipient.
+func (p *Provider) All(recipient string) ([]mail.Message, error) {
+	return p.list(recipient, false)
+}
+
+// Get returns a message by ID without marking it read.
+func (p *Provider) Get(id string) (mail.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, err := p.find(id)
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("getting message %q: %w", id, err)
+	}
+	return r.Message, nil
+}
+
+// Read returns a message by ID and marks it read.
+func (p *Provider) Read(id string) (mail.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, err := p.find(id)
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("reading message %q: %w", id, err)
+	}
+	if !r.Read {
+		r.Read = true
+		if err := p.write(r); err != nil {
+			return mail.Message{}, fmt.Errorf("reading message %q: %w", id, err)
+		}
+	}
+	return r.Message, nil
+}
+
+// MarkRead marks a message as read.
+func (p *Provider) MarkRead(id string) error {
+	return p.setRead(id, true)
+}
+
+// MarkUnread marks a message as unread.
+func (p *Provider) MarkUnread(id string) error {
+	return p.setRead(id, false)
+}
+
+func (p *Provider) setRead(id string, read bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, err := p.find(id)
+	if err != nil {
+		return fmt.Errorf("marking message %q: %w", id, err)
+	}
+	r.Read = read
+	if err := p.write(r); err != nil {
+		return fmt.Errorf("marking message %q: %w", id, err)
+	}
+	return nil
+}
+
+// Archive hides a message from all mailbox views. The file is kept so
+// threads stay complete.
+func (p *Provider) Archive(id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, err := p.find(id)
+	if err != nil {
+		return fmt.Errorf("archiving message %q: %w", id, err)
+	}
+	if r.Archived {
+		return mail.ErrAlreadyArchived
+	}
+	r.Archived = true
+	if err := p.write(r); err != nil {
+		return fmt.Errorf("archiving message %q: %w", id, err)
+	}
+	return nil
+}
+
+// Delete is an alias for Archive.
+func (p *Provider) Delete(id string) error {
+	return p.Archive(id)
+}
+
+// Reply sends a message back to the original's sender in the same thread.
+func (p *Provider) Reply(id, from, subject, body string) (mail.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	orig, err := p.find(id)
+	if err != nil {
+		return mail.Message{}, fmt.Errorf("replying to %q: %w", id, err)
+	}
+	threadID := orig.ThreadID
+	if threadID == "" {
+		threadID = newID("thread")
+	}
+	m := mail.Message{
+		ID:        newID("mail"),
+		From:      from,
+		To:        orig.From,
+		Subject:   subject,
+		Body:      body,
+		CreatedAt: time.Now().UTC(),
+		ThreadID:  threadID,
+		ReplyTo:   id,
+	}
+	if err := p.write(record{Message: m}); err != nil {
+		return mail.Message{}, fmt.Errorf("replying to %q: %w", id, err)
+	}
+	return m, nil
+}
+
+// Thread returns all messages sharing a thread ID, oldest first.
+func (p *Provider) Thread(threadID string) ([]mail.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	all, err := p.scan(filepath.Join(p.root, "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var result []mail.Message
+	for _, r := range all {
+		if r.ThreadID == threadID {
+			result = append(result, r.Message)
+		}
+	}
+	sortByTime(result)
+	return result, nil
+}
+
+// Count returns (total, unread) non-archived message counts.
+func (p *Provider) Count(recipient string) (int, int, error) {
+	msgs, err := p.list(recipient, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	unread := 0
+	for _, m := range msgs {
+		if !m.Read {
+			unread++
+		}
+	}
+	return len(msgs), unread, nil
+}
+
+// list returns the recipient's non-archived messages, oldest first.
+func (p *Provider) list(recipient string, unreadOnly bool) ([]mail.Message, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	recs, err := p.scan(filepath.Join(p.mailboxDir(recipient), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var result []mail.Message
+	for _, r := range recs {
+		if r.To != recipient || r.Archived || (unreadOnly && r.Read) {
+			continue
+		}
+		result = append(result, r.Message)
+	}
+	sortByTime(result)
+	return result, nil
+}
+
+// find locates a message by ID in any mailbox.
+func (p *Provider) find(id string) (record, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return record{}, mail.ErrNotFound
+	}
+	recs, err := p.scan(filepath.Join(p.root, "*", id+".json"))
+	if err != nil {
+		return record{}, err
+	}
+	if len(recs) == 0 {
+		return record{}, mail.ErrNotFound
+	}
+	return recs[0], nil
+}
+
+// scan reads every message file matching pattern.
+func (p *Provider) scan(pattern string) ([]record, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	recs := make([]record, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // archived or rewritten concurrently
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		var r record
+		if err := json.Unmarshal(data, &r); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		recs = append(recs, r)
+	}
+	return recs, nil
+}
+
+func (p *Provider) write(r record) error {
+	dir := p.mailboxDir(r.To)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsys.WriteFileAtomic(fsys.OSFS{}, filepath.Join(dir, r.ID+".json"), append(data, '\n'), 0o644)
+}
+
+func sortByTime(msgs []mail.Message) {
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].CreatedAt.Before(msgs[j].CreatedAt)
+	})
+}
+
+func newID(prefix string) string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+	}
+	return prefix + "-" + hex.EncodeToString(b)
+}
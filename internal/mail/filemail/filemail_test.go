@@ -0,0 +1,39 @@
+package filemail
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSendWritesRecipientMailbox(t *testing.T) {
+	dir := t.TempDir()
+	p := New(dir)
+	m, err := p.Send("mayor", "hw/polecat", "handoff", "context for gc-42")
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "hw--polecat", m.ID+".json")); err != nil {
+		t.Fatalf("message file: %v", err)
+	}
+}
+
+func TestMailboxPersistsAcrossProviders(t *testing.T) {
+	dir := t.TempDir()
+	m, err := New(dir).Send("mayor", "deacon", "", "hello")
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := New(dir).MarkRead(m.ID); err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+
+	p := New(dir)
+	total, unread, err := p.Count("deacon")
+	if err != nil {
+		t.Fatalf("Count: %v", err)
+	}
+	if total != 1 || unread != 0 {
+		t.Errorf("Count = (%d, %d), want (1, 0)", total, unread)
+	}
+}
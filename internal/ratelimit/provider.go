@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// Provider wraps a [runtime.Provider] so session starts and nudges spend
+// a token from a [Limiter]. Starts are charged to the agent template in
+// the session's GC_TEMPLATE env var; nudges to the template the session
+// was started with, or only to the city-wide limit for sessions this
+// provider did not start. Every other operation, including optional
+// extensions, goes straight to the wrapped provider.
+type Provider struct {
+	runtime.Wrapper
+	limiter *Limiter
+
+	mu        sync.Mutex
+	templates map[string]string // session name → agent template
+}
+
+var _ runtime.Provider = (*Provider)(nil)
+
+// WrapProvider returns sp with starts and nudges limited by limiter.
+func WrapProvider(sp runtime.Provider, limiter *Limiter) *Provider {
+	return &Provider{Wrapper: runtime.Wrapper{Provider: sp}, limiter: limiter, templates: make(map[string]string)}
+}
+
+// Limiter returns the provider's limiter, to update its limits on a
+// config reload.
+func (p *Provider) Limiter() *Limiter {
+	return p.limiter
+}
+
+// Start starts the session if the rate limits allow, else returns an
+// error wrapping [ErrRateLimited] without starting it.
+func (p *Provider) Start(ctx context.Context, name string, cfg runtime.Config) error {
+	template := cfg.Env["GC_TEMPLATE"]
+	if err := p.limiter.Allow(template); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.templates[name] = template
+	p.mu.Unlock()
+	return p.Provider.Start(ctx, name, cfg)
+}
+
+// Nudge nudges the session if the rate limits allow, else returns an
+// error wrapping [ErrRateLimited] without nudging it.
+func (p *Provider) Nudge(name string, content []runtime.ContentBlock) error {
+	if err := p.limiter.Allow(p.template(name)); err != nil {
+		return err
+	}
+	return p.Provider.Nudge(name, content)
+}
+
+// NudgeNow is Nudge without waiting for the agent to go idle, when the
+// wrapped provider supports it.
+func (p *Provider) NudgeNow(name string, content []runtime.ContentBlock) error {
+	if err := p.limiter.Allow(p.template(name)); err != nil {
+		return err
+	}
+	return p.Wrapper.NudgeNow(name, content)
+}
+
+// Stop stops the session and forgets its template.
+func (p *Provider) Stop(name string) error {
+	p.mu.Lock()
+	delete(p.templates, name)
+	p.mu.Unlock()
+	return p.Provider.Stop(name)
+}
+
+// template returns the agent template a session was started with.
+func (p *Provider) template(name string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.templates[name]
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/clock"
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestProviderStartChargesTemplate(t *testing.T) {
+	clk := &clock.Fake{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	fake := runtime.NewFake()
+	sp := WrapProvider(fake, NewLimiter(Limit{}, map[string]Limit{"polecat": {Count: 1, Per: time.Minute}}, clk))
+	cfg := runtime.Config{Env: map[string]string{"GC_TEMPLATE": "polecat"}}
+
+	if err := sp.Start(context.Background(), "polecat-1", cfg); err != nil {
+		t.Fatalf("Start polecat-1: %v", err)
+	}
+	err := sp.Start(context.Background(), "polecat-2", cfg)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Start polecat-2 = %v, want ErrRateLimited", err)
+	}
+	if fake.IsRunning("polecat-2") {
+		t.Error("rate-limited session was started")
+	}
+}
+
+func TestProviderNudgeChargesStartedTemplate(t *testing.T) {
+	clk := &clock.Fake{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	fake := runtime.NewFake()
+	sp := WrapProvider(fake, NewLimiter(Limit{}, map[string]Limit{"polecat": {Count: 2, Per: time.Minute}}, clk))
+	cfg := runtime.Config{Env: map[string]string{"GC_TEMPLATE": "polecat"}}
+	if err := sp.Start(context.Background(), "polecat-1", cfg); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	msg := runtime.TextContent("go")
+	if err := sp.Nudge("polecat-1", msg); err != nil {
+		t.Fatalf("Nudge: %v", err)
+	}
+	if err := sp.NudgeNow("polecat-1", msg); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("NudgeNow over budget = %v, want ErrRateLimited", err)
+	}
+	// A session this provider didn't start only meets the city limit.
+	if err := sp.Nudge("mayor", msg); err != nil {
+		t.Errorf("Nudge(mayor): %v", err)
+	}
+}
+
+func TestProviderUnwrap(t *testing.T) {
+	fake := runtime.NewFake()
+	sp := WrapProvider(fake, NewLimiter(Limit{}, nil, clock.Real{}))
+	if sp.Unwrap() != fake {
+		t.Error("Unwrap() did not return the wrapped provider")
+	}
+}
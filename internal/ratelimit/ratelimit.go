@@ -0,0 +1,157 @@
+// Package ratelimit throttles agent CLI invocations with token buckets:
+// one shared by the whole city and one per agent template. Every session
+// start and prompt nudge spends a token from both; when either bucket is
+// empty the operation is refused with [ErrRateLimited] instead of
+// waiting, so the controller simply retries on a later tick.
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/clock"
+)
+
+// ErrRateLimited is returned (wrapped) when a start or nudge would exceed
+// a rate limit. The operation was not attempted.
+var ErrRateLimited = errors.New("rate limited")
+
+// Limit allows Count operations per Per. The zero Limit is unlimited.
+type Limit struct {
+	Count int
+	Per   time.Duration
+}
+
+// unlimited reports whether l places no limit.
+func (l Limit) unlimited() bool {
+	return l.Count <= 0 || l.Per <= 0
+}
+
+// String formats l as "count/period", the form config.ParseRate reads.
+func (l Limit) String() string {
+	per := l.Per.String()
+	switch l.Per {
+	case time.Second:
+		per = "s"
+	case time.Minute:
+		per = "m"
+	case time.Hour:
+		per = "h"
+	}
+	return fmt.Sprintf("%d/%s", l.Count, per)
+}
+
+// bucket is a token bucket holding up to limit.Count tokens, refilled at
+// limit.Count per limit.Per. It starts full.
+type bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(l Limit, now time.Time) *bucket {
+	return &bucket{limit: l, tokens: float64(l.Count), last: now}
+}
+
+// refill adds the tokens accrued since the last refill.
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += float64(b.limit.Count) * float64(elapsed) / float64(b.limit.Per)
+		b.tokens = min(b.tokens, float64(b.limit.Count))
+	}
+	b.last = now
+}
+
+// wait returns how long until the bucket holds a whole token.
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(b.limit.Per) / float64(b.limit.Count))
+}
+
+// Limiter holds the city-wide bucket and the per-agent buckets. It is
+// safe for concurrent use.
+type Limiter struct {
+	clock clock.Clock
+
+	mu          sync.Mutex
+	city        *bucket
+	agentLimits map[string]Limit
+	agents      map[string]*bucket
+}
+
+// NewLimiter returns a limiter allowing city operations across all agents
+// and agents[name] operations for each named agent template.
+func NewLimiter(city Limit, agents map[string]Limit, clk clock.Clock) *Limiter {
+	l := &Limiter{clock: clk, agents: make(map[string]*bucket)}
+	l.SetLimits(city, agents)
+	return l
+}
+
+// SetLimits replaces the limits, as on a config reload. Buckets whose
+// limit is unchanged keep their tokens; changed ones start full.
+func (l *Limiter) SetLimits(city Limit, agents map[string]Limit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	switch {
+	case city.unlimited():
+		l.city = nil
+	case l.city == nil || l.city.limit != city:
+		l.city = newBucket(city, now)
+	}
+	l.agentLimits = make(map[string]Limit, len(agents))
+	for name, lim := range agents {
+		if !lim.unlimited() {
+			l.agentLimits[name] = lim
+		}
+	}
+	for name, b := range l.agents {
+		if b.limit != l.agentLimits[name] {
+			delete(l.agents, name)
+		}
+	}
+}
+
+// Enabled reports whether any limit is set.
+func (l *Limiter) Enabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.city != nil || len(l.agentLimits) > 0
+}
+
+// Allow spends a token for one operation by agent ("" when the agent is
+// unknown, which only the city-wide limit applies to). Tokens are taken
+// only when both buckets have one; otherwise the returned error wraps
+// ErrRateLimited and says when to retry.
+func (l *Limiter) Allow(agent string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	var ab *bucket
+	if lim, ok := l.agentLimits[agent]; ok {
+		ab = l.agents[agent]
+		if ab == nil {
+			ab = newBucket(lim, now)
+			l.agents[agent] = ab
+		}
+		ab.refill(now)
+		if w := ab.wait(); w > 0 {
+			return fmt.Errorf("%w: agent %q limit %s, retry in %s", ErrRateLimited, agent, ab.limit, w.Round(time.Millisecond))
+		}
+	}
+	if l.city != nil {
+		l.city.refill(now)
+		if w := l.city.wait(); w > 0 {
+			return fmt.Errorf("%w: city limit %s, retry in %s", ErrRateLimited, l.city.limit, w.Round(time.Millisecond))
+		}
+		l.city.tokens--
+	}
+	if ab != nil {
+		ab.tokens--
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/clock"
+)
+
+func TestAllowCityLimit(t *testing.T) {
+	clk := &clock.Fake{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Limit{Count: 2, Per: time.Minute}, nil, clk)
+
+	for i := 0; i < 2; i++ {
+		if err := l.Allow("worker"); err != nil {
+			t.Fatalf("Allow #%d: %v", i+1, err)
+		}
+	}
+	err := l.Allow("other")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Allow over budget = %v, want ErrRateLimited", err)
+	}
+	if !strings.Contains(err.Error(), "city limit 2/m") || !strings.Contains(err.Error(), "retry in 30s") {
+		t.Errorf("err = %q, want city limit and retry time", err)
+	}
+
+	// Tokens refill evenly: one every 30s.
+	clk.Advance(30 * time.Second)
+	if err := l.Allow("worker"); err != nil {
+		t.Errorf("Allow after refill: %v", err)
+	}
+	if err := l.Allow("worker"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("second Allow after one refill = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestAllowRefillCapsAtCount(t *testing.T) {
+	clk := &clock.Fake{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Limit{Count: 2, Per: time.Minute}, nil, clk)
+
+	clk.Advance(time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := l.Allow(""); err != nil {
+			t.Fatalf("Allow #%d: %v", i+1, err)
+		}
+	}
+	if err := l.Allow(""); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("third Allow = %v, want ErrRateLimited (bucket holds at most 2)", err)
+	}
+}
+
+func TestAllowAgentLimit(t *testing.T) {
+	clk := &clock.Fake{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Limit{}, map[string]Limit{"rig/polecat": {Count: 1, Per: time.Hour}}, clk)
+
+	if err := l.Allow("rig/polecat"); err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	err := l.Allow("rig/polecat")
+	if !errors.Is(err, ErrRateLimited) || !strings.Contains(err.Error(), `agent "rig/polecat" limit 1/h`) {
+		t.Errorf("Allow over agent budget = %v", err)
+	}
+	// Other agents and unknown sessions are unaffected.
+	for i := 0; i < 5; i++ {
+		if err := l.Allow("mayor"); err != nil {
+			t.Fatalf("Allow(mayor): %v", err)
+		}
+		if err := l.Allow(""); err != nil {
+			t.Fatalf(`Allow(""): %v`, err)
+		}
+	}
+}
+
+func TestAllowRefusalSpendsNothing(t *testing.T) {
+	clk := &clock.Fake{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Limit{Count: 1, Per: time.Minute}, map[string]Limit{"worker": {Count: 1, Per: time.Minute}}, clk)
+
+	// Spend the city token on another agent; worker's own token must
+	// survive the refused attempt.
+	if err := l.Allow("mayor"); err != nil {
+		t.Fatalf("Allow(mayor): %v", err)
+	}
+	if err := l.Allow("worker"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Allow(worker) = %v, want city limit", err)
+	}
+	clk.Advance(time.Minute)
+	if err := l.Allow("worker"); err != nil {
+		t.Errorf("Allow(worker) after city refill: %v", err)
+	}
+}
+
+func TestSetLimits(t *testing.T) {
+	clk := &clock.Fake{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	city := Limit{Count: 1, Per: time.Minute}
+	l := NewLimiter(city, nil, clk)
+	if err := l.Allow(""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unchanged limits keep the spent bucket.
+	l.SetLimits(city, nil)
+	if err := l.Allow(""); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Allow after same-limit reload = %v, want ErrRateLimited", err)
+	}
+
+	// A changed limit starts a fresh bucket.
+	l.SetLimits(Limit{Count: 5, Per: time.Minute}, nil)
+	if err := l.Allow(""); err != nil {
+		t.Errorf("Allow after raising the limit: %v", err)
+	}
+
+	// Removing every limit disables the limiter.
+	l.SetLimits(Limit{}, map[string]Limit{"worker": {}})
+	if l.Enabled() {
+		t.Error("Enabled() = true with no limits")
+	}
+}
@@ -162,7 +162,7 @@ func (p *Provider) Start(ctx context.Context, name string, cfg runtime.Config) e
 	}
 
 	cmd := exec.Command("sh", "-c", command)
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.SysProcAttr = groupSysProcAttr()
 	if cfg.WorkDir != "" {
 		cmd.Dir = cfg.WorkDir
 	}
@@ -228,7 +228,7 @@ func (p *Provider) Start(ctx context.Context, name string, cfg runtime.Config) e
 	// Create control socket for cross-process discovery.
 	lis, err := p.startControlSocket(name, cmd)
 	if err != nil {
-		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		_ = signalGroup(cmd.Process.Pid, syscall.SIGKILL)
 		_ = cmd.Wait()
 		clearSentinel()
 		return fmt.Errorf("creating control socket for %q: %w", name, err)
@@ -259,7 +259,7 @@ func (p *Provider) Start(ctx context.Context, name string, cfg runtime.Config) e
 		// Handshake failed — kill the process. The monitor goroutine
 		// handles listener/socket cleanup when the process exits.
 		_ = stdinPipe.Close()
-		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		_ = signalGroup(cmd.Process.Pid, syscall.SIGKILL)
 		<-sc.done
 		clearSentinel()
 		// Include stderr tail in the error for diagnostics.
@@ -274,7 +274,7 @@ func (p *Provider) Start(ctx context.Context, name string, cfg runtime.Config) e
 	// and clean up — the caller of Stop expects the session to be gone.
 	if err := hsCtx.Err(); err != nil {
 		_ = stdinPipe.Close()
-		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		_ = signalGroup(cmd.Process.Pid, syscall.SIGKILL)
 		<-sc.done
 		clearSentinel()
 		return fmt.Errorf("session %q was stopped during startup", name)
@@ -395,7 +395,7 @@ func (p *Provider) Interrupt(name string) error {
 		if sc.cmd == nil {
 			return nil
 		}
-		return syscall.Kill(-sc.cmd.Process.Pid, syscall.SIGINT)
+		return signalGroup(sc.cmd.Process.Pid, syscall.SIGINT)
 	}
 
 	// Fall back to socket (cross-process case).
@@ -677,7 +677,7 @@ func handleControlConn(conn net.Conn, cmd *exec.Cmd) {
 	}
 	switch scanner.Text() {
 	case "stop":
-		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+		_ = signalGroup(cmd.Process.Pid, syscall.SIGTERM)
 		deadline := time.After(5 * time.Second)
 		ticker := time.NewTicker(50 * time.Millisecond)
 		defer ticker.Stop()
@@ -685,7 +685,7 @@ func handleControlConn(conn net.Conn, cmd *exec.Cmd) {
 		for alive {
 			select {
 			case <-deadline:
-				_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+				_ = signalGroup(cmd.Process.Pid, syscall.SIGKILL)
 				alive = false
 			case <-ticker.C:
 				if err := cmd.Process.Signal(syscall.Signal(0)); err != nil {
@@ -695,7 +695,7 @@ func handleControlConn(conn net.Conn, cmd *exec.Cmd) {
 		}
 		conn.Write([]byte("ok\n")) //nolint:errcheck
 	case "interrupt":
-		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGINT)
+		_ = signalGroup(cmd.Process.Pid, syscall.SIGINT)
 		conn.Write([]byte("ok\n")) //nolint:errcheck
 	case "ping":
 		conn.Write([]byte("ok\n")) //nolint:errcheck
@@ -750,13 +750,13 @@ func (p *Provider) Capabilities() runtime.ProviderCapabilities {
 
 // terminateProcess sends SIGTERM then SIGKILL to a tracked process group.
 func terminateProcess(sc *sessionConn) error {
-	_ = syscall.Kill(-sc.cmd.Process.Pid, syscall.SIGTERM)
+	_ = signalGroup(sc.cmd.Process.Pid, syscall.SIGTERM)
 	select {
 	case <-sc.done:
 		return nil
 	case <-time.After(5 * time.Second):
 	}
-	_ = syscall.Kill(-sc.cmd.Process.Pid, syscall.SIGKILL)
+	_ = signalGroup(sc.cmd.Process.Pid, syscall.SIGKILL)
 	<-sc.done
 	return nil
 }
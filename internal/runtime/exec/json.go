@@ -30,6 +30,7 @@ type startConfig struct {
 	Nudge              string            `json:"nudge,omitempty"`
 	ReadyPromptPrefix  string            `json:"ready_prompt_prefix,omitempty"`
 	ReadyDelayMs       int               `json:"ready_delay_ms,omitempty"`
+	ReadyProbe         string            `json:"ready_probe,omitempty"`
 	PreStart           []string          `json:"pre_start,omitempty"`
 	SessionSetup       []string          `json:"session_setup,omitempty"`
 	SessionSetupScript string            `json:"session_setup_script,omitempty"`
@@ -53,6 +54,7 @@ func marshalStartConfig(cfg runtime.Config) ([]byte, error) {
 		Nudge:              cfg.Nudge,
 		ReadyPromptPrefix:  cfg.ReadyPromptPrefix,
 		ReadyDelayMs:       cfg.ReadyDelayMs,
+		ReadyProbe:         cfg.ReadyProbe,
 		PreStart:           cfg.PreStart,
 		SessionSetup:       cfg.SessionSetup,
 		SessionSetupScript: cfg.SessionSetupScript,
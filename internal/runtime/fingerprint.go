@@ -16,7 +16,7 @@ import (
 // SessionLive.
 //
 // Excluded (observation-only hints): WorkDir, ReadyPromptPrefix,
-// ReadyDelayMs, ProcessNames, EmitsPermissionWarning.
+// ReadyDelayMs, ReadyProbe, ProcessNames, EmitsPermissionWarning.
 //
 // The hash is a hex-encoded SHA-256. Same config always produces the same
 // hash regardless of map iteration order.
@@ -0,0 +1,75 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ReadyProbeEnv is the session environment variable providers use to
+// remember a session's ready probe, so later nudges can wait on it.
+const ReadyProbeEnv = "GC_READY_PROBE"
+
+// readyProbeExecTimeout bounds a single run of an exec: probe.
+const readyProbeExecTimeout = 5 * time.Second
+
+// ReadyProbe is a parsed [Config.ReadyProbe]: either a pattern matched
+// against recent session output or a command that must exit 0.
+type ReadyProbe struct {
+	// Pattern is matched against the session's recent output, with ^ and
+	// $ anchoring at line boundaries. Nil for command probes.
+	Pattern *regexp.Regexp
+	// Command is run with sh -c; the session is ready once it exits 0.
+	// Empty for pattern probes.
+	Command string
+}
+
+// ParseReadyProbe parses a ready_probe value: "exec:<command>", or a
+// regular expression optionally written as "regex:<pattern>". Returns
+// nil for an empty value.
+func ParseReadyProbe(s string) (*ReadyProbe, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if cmd, ok := strings.CutPrefix(s, "exec:"); ok {
+		if strings.TrimSpace(cmd) == "" {
+			return nil, errors.New("ready_probe: exec: needs a command")
+		}
+		return &ReadyProbe{Command: cmd}, nil
+	}
+	pattern := strings.TrimPrefix(s, "regex:")
+	re, err := regexp.Compile("(?m)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("ready_probe: bad pattern %q: %w", pattern, err)
+	}
+	return &ReadyProbe{Pattern: re}, nil
+}
+
+// MatchOutput reports whether a pattern probe matches the given lines of
+// session output. Non-breaking spaces are treated as spaces, since some
+// agent CLIs draw their prompt with one.
+func (p *ReadyProbe) MatchOutput(lines []string) bool {
+	if p.Pattern == nil {
+		return false
+	}
+	text := strings.ReplaceAll(strings.Join(lines, "\n"), "\u00a0", " ")
+	return p.Pattern.MatchString(text)
+}
+
+// RunCommand runs a command probe for the named session and reports
+// whether it exited 0. The session name is passed as GC_SESSION.
+func (p *ReadyProbe) RunCommand(ctx context.Context, session string) bool {
+	if p.Command == "" {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, readyProbeExecTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", p.Command)
+	cmd.Env = append(os.Environ(), "GC_SESSION="+session)
+	return cmd.Run() == nil
+}
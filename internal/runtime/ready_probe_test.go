@@ -0,0 +1,81 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseReadyProbe(t *testing.T) {
+	tests := []struct {
+		in      string
+		pattern string
+		command string
+		wantErr bool
+	}{
+		{in: ""},
+		{in: "exec:tmux capture-pane -p", command: "tmux capture-pane -p"},
+		{in: "regex:^> $", pattern: "(?m)^> $"},
+		{in: "^❯ ", pattern: "(?m)^❯ "},
+		{in: "exec:  ", wantErr: true},
+		{in: "regex:(", wantErr: true},
+	}
+	for _, tt := range tests {
+		p, err := ParseReadyProbe(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseReadyProbe(%q) = nil error, want error", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseReadyProbe(%q): %v", tt.in, err)
+			continue
+		}
+		if tt.in == "" {
+			if p != nil {
+				t.Errorf("ParseReadyProbe(\"\") = %+v, want nil", p)
+			}
+			continue
+		}
+		if got := p.Command; got != tt.command {
+			t.Errorf("ParseReadyProbe(%q).Command = %q, want %q", tt.in, got, tt.command)
+		}
+		var got string
+		if p.Pattern != nil {
+			got = p.Pattern.String()
+		}
+		if got != tt.pattern {
+			t.Errorf("ParseReadyProbe(%q).Pattern = %q, want %q", tt.in, got, tt.pattern)
+		}
+	}
+}
+
+func TestReadyProbeMatchOutput(t *testing.T) {
+	p, err := ParseReadyProbe(`^> $`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.MatchOutput([]string{"Loading...", "still loading"}) {
+		t.Error("matched output without a prompt line")
+	}
+	if !p.MatchOutput([]string{"Welcome", "> ", ""}) {
+		t.Error("did not match a prompt line")
+	}
+	if !p.MatchOutput([]string{"Welcome", ">\u00a0"}) {
+		t.Error("did not match a prompt drawn with a non-breaking space")
+	}
+}
+
+func TestReadyProbeRunCommand(t *testing.T) {
+	ok, _ := ParseReadyProbe(`exec:test "$GC_SESSION" = gc-city-mayor`)
+	if !ok.RunCommand(context.Background(), "gc-city-mayor") {
+		t.Error("command probe exiting 0 reported not ready")
+	}
+	if ok.RunCommand(context.Background(), "gc-city-other") {
+		t.Error("command probe exiting 1 reported ready")
+	}
+	pat, _ := ParseReadyProbe("regex:x")
+	if pat.RunCommand(context.Background(), "s") {
+		t.Error("pattern probe reported ready from RunCommand")
+	}
+}
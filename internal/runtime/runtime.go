@@ -214,6 +214,11 @@ type Config struct {
 	// ReadyDelayMs is a fallback fixed delay when no prompt prefix is available.
 	ReadyDelayMs int
 
+	// ReadyProbe, when set, replaces ReadyPromptPrefix and ReadyDelayMs: a
+	// regular expression matched against recent session output, or
+	// "exec:<command>" that must exit 0. See ParseReadyProbe.
+	ReadyProbe string
+
 	// ProcessNames lists expected process names for liveness checks.
 	ProcessNames []string
 
@@ -0,0 +1,575 @@
+// Package ssh provides a composite [runtime.Provider] that runs selected
+// sessions in tmux on remote machines over SSH.
+//
+// Sessions are registered with a host via [Provider.RouteHost] before
+// [Provider.Start] is called (the reconciler does this for agents that
+// declare host = "user@machine"). Unregistered sessions route to the
+// local backend. Every remote operation is a single ssh invocation that
+// pipes a short sh script (usually one tmux command) to the host on
+// stdin, so the remote machine needs only sshd, sh, and tmux — no gc
+// installation — and no script appears in a process list. Session
+// environment is handed over in a 0600 file that the session reads and
+// removes, never on a command line.
+//
+// Limitations compared to local tmux:
+//   - Startup hints (ready prompt, permission warnings) are ignored;
+//     remote sessions are fire-and-forget apart from the startup nudge
+//   - ProcessAlive only reports whether the remote session exists
+//   - Overlays and copy_files are staged with scp, best-effort
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/runtime/hybrid"
+	"github.com/gastownhall/gascity/internal/shellquote"
+)
+
+// Config holds settings shared by all remote hosts.
+type Config struct {
+	// SocketName is the tmux socket used on remote hosts ("tmux -L").
+	// Empty means the remote user's default tmux server.
+	SocketName string
+	// SSHArgs are extra options passed to every ssh and scp invocation
+	// (e.g. "-i", "~/.ssh/agents").
+	SSHArgs []string
+}
+
+// runFunc executes a shell script on a remote host and returns its
+// trimmed stdout.
+type runFunc func(ctx context.Context, host, script string) (string, error)
+
+// copyFunc copies a local file or directory to a path on a remote host.
+type copyFunc func(host, src, dst string) error
+
+// Provider routes registered sessions to remote tmux over SSH and all
+// other sessions to a local backend.
+type Provider struct {
+	*hybrid.Provider
+	local  runtime.Provider
+	remote *remote
+}
+
+// Compile-time check.
+var _ runtime.Provider = (*Provider)(nil)
+
+// New creates a composite provider. local handles sessions that were
+// not registered with a host.
+func New(local runtime.Provider, cfg Config) *Provider {
+	return newProvider(local, newRemote(cfg, sshRun(cfg.SSHArgs), scpCopy(cfg.SSHArgs)))
+}
+
+func newProvider(local runtime.Provider, r *remote) *Provider {
+	return &Provider{
+		Provider: hybrid.New(local, r, r.routed),
+		local:    local,
+		remote:   r,
+	}
+}
+
+// RouteHost registers a session name to run on host (an ssh
+// destination such as "user@build-box"). Must be called before Start
+// for that session.
+func (p *Provider) RouteHost(name, host string) {
+	p.remote.mu.Lock()
+	p.remote.hosts[name] = host
+	p.remote.mu.Unlock()
+}
+
+// UnrouteHost removes a session's host registration. It is distinct
+// from Unroute, which un-routes ACP on the local backend.
+func (p *Provider) UnrouteHost(name string) {
+	p.remote.mu.Lock()
+	delete(p.remote.hosts, name)
+	p.remote.mu.Unlock()
+}
+
+// Host returns the host a session is registered to, or "" for local.
+func (p *Provider) Host(name string) string {
+	return p.remote.host(name)
+}
+
+// Local returns the backend that handles unregistered sessions.
+func (p *Provider) Local() runtime.Provider {
+	return p.local
+}
+
+// RouteACP forwards ACP routing to the local backend (auto), so agents
+// without a host keep their ACP transport.
+func (p *Provider) RouteACP(name string) {
+	runtime.Wrapper{Provider: p.local}.RouteACP(name)
+}
+
+// Unroute forwards ACP un-routing to the local backend. The session's
+// host registration is untouched; see UnrouteHost.
+func (p *Provider) Unroute(name string) {
+	runtime.Wrapper{Provider: p.local}.Unroute(name)
+}
+
+// DetectTransport reports "" for remote sessions, which always run in
+// tmux, and asks the local backend otherwise.
+func (p *Provider) DetectTransport(name string) string {
+	if p.remote.routed(name) {
+		return ""
+	}
+	return runtime.Wrapper{Provider: p.local}.DetectTransport(name)
+}
+
+// CheckImage forwards container image pre-checks to the local backend.
+func (p *Provider) CheckImage(image string) error {
+	return runtime.Wrapper{Provider: p.local}.CheckImage(image)
+}
+
+// remote implements [runtime.Provider] for sessions registered to a host.
+type remote struct {
+	cfg  Config
+	run  runFunc
+	copy copyFunc
+
+	mu    sync.RWMutex
+	hosts map[string]string // session name → ssh destination
+}
+
+var _ runtime.Provider = (*remote)(nil)
+
+func newRemote(cfg Config, run runFunc, cp copyFunc) *remote {
+	return &remote{cfg: cfg, run: run, copy: cp, hosts: make(map[string]string)}
+}
+
+func (r *remote) routed(name string) bool {
+	return r.host(name) != ""
+}
+
+func (r *remote) host(name string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hosts[name]
+}
+
+// distinctHosts returns every registered host once, sorted.
+func (r *remote) distinctHosts() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seen := make(map[string]bool)
+	var hosts []string
+	for _, h := range r.hosts {
+		if !seen[h] {
+			seen[h] = true
+			hosts = append(hosts, h)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// tmux runs a tmux command on the session's host.
+func (r *remote) tmux(name string, args ...string) (string, error) {
+	host := r.host(name)
+	if host == "" {
+		return "", fmt.Errorf("session %q has no remote host", name)
+	}
+	return r.tmuxOn(context.Background(), host, args...)
+}
+
+func (r *remote) tmuxOn(ctx context.Context, host string, args ...string) (string, error) {
+	return r.run(ctx, host, r.tmuxCommand(args...))
+}
+
+// tmuxCommand renders a remote tmux invocation as a shell command line.
+func (r *remote) tmuxCommand(args ...string) string {
+	all := []string{"tmux", "-u"}
+	if r.cfg.SocketName != "" {
+		all = append(all, "-L", r.cfg.SocketName)
+	}
+	return shellquote.Join(append(all, args...))
+}
+
+// Start creates a detached tmux session on the registered host. PreStart
+// commands run on the host first, in the working directory.
+func (r *remote) Start(ctx context.Context, name string, cfg runtime.Config) error {
+	host := r.host(name)
+	if host == "" {
+		return fmt.Errorf("session %q has no remote host", name)
+	}
+	if _, err := r.tmuxOn(ctx, host, "has-session", "-t", exact(name)); err == nil {
+		return fmt.Errorf("%w: session %q on %s", runtime.ErrSessionExists, name, host)
+	}
+
+	if cfg.WorkDir != "" {
+		if _, err := r.run(ctx, host, shellquote.Join([]string{"mkdir", "-p", cfg.WorkDir})); err != nil {
+			return fmt.Errorf("creating %s on %s: %w", cfg.WorkDir, host, err)
+		}
+		r.stage(host, cfg)
+	}
+	for _, ps := range cfg.PreStart {
+		script := ps
+		if cfg.WorkDir != "" {
+			script = "cd " + shellquote.Quote(cfg.WorkDir) + " && " + ps
+		}
+		if _, err := r.run(ctx, host, script); err != nil {
+			return fmt.Errorf("pre_start on %s: %w", host, err)
+		}
+	}
+
+	args := []string{"new-session", "-d", "-s", name}
+	if cfg.WorkDir != "" {
+		args = append(args, "-c", cfg.WorkDir)
+	}
+	command := cfg.Command
+	var writeEnv, cleanup string
+	if len(cfg.Env) > 0 {
+		envFile, write, err := envFileScript(cfg.Env)
+		if err != nil {
+			return fmt.Errorf("starting session %q on %s: %w", name, host, err)
+		}
+		if command == "" {
+			command = `exec "${SHELL:-/bin/sh}" -l`
+		}
+		q := shellquote.Quote(envFile)
+		command = ". " + q + "; rm -f " + q + "; " + command
+		writeEnv, cleanup = write, " || { rm -f "+q+"; exit 1; }"
+	}
+	if command != "" {
+		args = append(args, command)
+	}
+	if _, err := r.run(ctx, host, writeEnv+r.tmuxCommand(args...)+cleanup); err != nil {
+		return fmt.Errorf("starting session %q on %s: %w", name, host, err)
+	}
+
+	if cfg.Nudge != "" {
+		if cfg.ReadyDelayMs > 0 {
+			time.Sleep(time.Duration(cfg.ReadyDelayMs) * time.Millisecond)
+		}
+		if err := r.Nudge(name, runtime.TextContent(cfg.Nudge)); err != nil {
+			return fmt.Errorf("nudging session %q on %s: %w", name, host, err)
+		}
+	}
+	return nil
+}
+
+// envFileScript returns a remote path and the script lines that write env
+// to it as shell exports, readable only by the remote user. The values
+// travel in a here-document on the script's stdin, so they never appear
+// in a process's arguments; the session sources the file and removes it.
+// noclobber makes the write fail rather than follow a planted file.
+func envFileScript(env map[string]string) (path, script string, err error) {
+	var token [8]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return "", "", err
+	}
+	tag := hex.EncodeToString(token[:])
+	path = "/tmp/gc-env-" + tag
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		if !envName.MatchString(k) {
+			return "", "", fmt.Errorf("invalid environment variable name %q", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "(umask 077; set -C; cat > %s) <<'GC_ENV_%s' || exit 1\n", shellquote.Quote(path), tag)
+	for _, k := range keys {
+		sb.WriteString("export " + k + "=" + shellquote.Quote(env[k]) + "\n")
+	}
+	sb.WriteString("GC_ENV_" + tag + "\n")
+	return path, sb.String(), nil
+}
+
+// envName matches a portable environment variable name.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// stage copies overlays and copy_files into the remote working directory.
+// Best-effort: copy failures do not prevent the session from starting.
+func (r *remote) stage(host string, cfg runtime.Config) {
+	dirs := append(append([]string{}, cfg.PackOverlayDirs...), cfg.OverlayDir)
+	for _, od := range dirs {
+		if od == "" {
+			continue
+		}
+		if _, err := os.Stat(od); err != nil {
+			continue
+		}
+		_ = r.copy(host, od+string(filepath.Separator)+".", cfg.WorkDir)
+	}
+	for _, cf := range cfg.CopyFiles {
+		dst := cfg.WorkDir
+		if cf.RelDst != "" {
+			dst = filepath.Join(cfg.WorkDir, cf.RelDst)
+		}
+		_ = r.copy(host, cf.Src, dst)
+	}
+}
+
+// Stop kills the remote session. Returns nil if it doesn't exist.
+func (r *remote) Stop(name string) error {
+	if _, err := r.tmux(name, "kill-session", "-t", exact(name)); err != nil && !isGone(err) {
+		return err
+	}
+	return nil
+}
+
+// Interrupt sends Ctrl-C to the remote session. Best-effort.
+func (r *remote) Interrupt(name string) error {
+	_, _ = r.tmux(name, "send-keys", "-t", name, "C-c")
+	return nil
+}
+
+// IsRunning reports whether the session exists on its host.
+func (r *remote) IsRunning(name string) bool {
+	_, err := r.tmux(name, "has-session", "-t", exact(name))
+	return err == nil
+}
+
+// IsAttached reports whether any client is attached to the remote session.
+func (r *remote) IsAttached(name string) bool {
+	out, err := r.tmux(name, "display-message", "-p", "-t", name, "#{session_attached}")
+	return err == nil && out != "" && out != "0"
+}
+
+// Attach connects the terminal to the remote session via ssh -t.
+func (r *remote) Attach(name string) error {
+	host := r.host(name)
+	if host == "" {
+		return fmt.Errorf("session %q has no remote host", name)
+	}
+	args := append(append([]string{"-t"}, r.cfg.SSHArgs...), host, "--",
+		r.tmuxCommand("attach-session", "-t", name))
+	cmd := exec.Command("ssh", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// ProcessAlive reports whether the remote session exists. The remote
+// process tree is not inspected. Returns true when processNames is empty
+// (per the Provider contract).
+func (r *remote) ProcessAlive(name string, processNames []string) bool {
+	if len(processNames) == 0 {
+		return true
+	}
+	return r.IsRunning(name)
+}
+
+// Nudge types the message text into the remote session and presses Enter.
+// Returns nil if the session doesn't exist (best-effort).
+func (r *remote) Nudge(name string, content []runtime.ContentBlock) error {
+	text := runtime.FlattenText(content)
+	if text == "" {
+		return nil
+	}
+	if _, err := r.tmux(name, "send-keys", "-t", name, "-l", "--", text); err != nil {
+		if isGone(err) {
+			return nil
+		}
+		return err
+	}
+	if _, err := r.tmux(name, "send-keys", "-t", name, "Enter"); err != nil && !isGone(err) {
+		return err
+	}
+	return nil
+}
+
+// SendKeys sends bare keystrokes to the remote session. Best-effort.
+func (r *remote) SendKeys(name string, keys ...string) error {
+	for _, k := range keys {
+		if _, err := r.tmux(name, "send-keys", "-t", name, k); err != nil {
+			if isGone(err) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// RunLive is not supported for remote sessions. Returns nil.
+func (r *remote) RunLive(_ string, _ runtime.Config) error {
+	return nil
+}
+
+// Peek captures the last N lines of the remote pane, or all scrollback
+// if lines <= 0.
+func (r *remote) Peek(name string, lines int) (string, error) {
+	start := "-"
+	if lines > 0 {
+		start = "-" + strconv.Itoa(lines)
+	}
+	return r.tmux(name, "capture-pane", "-p", "-J", "-t", name, "-S", start)
+}
+
+// SetMeta stores a key-value pair in the remote session's tmux environment.
+func (r *remote) SetMeta(name, key, value string) error {
+	_, err := r.tmux(name, "set-environment", "-t", name, key, value)
+	return err
+}
+
+// GetMeta reads a key from the remote session's tmux environment.
+// Returns ("", nil) if the key is not set.
+func (r *remote) GetMeta(name, key string) (string, error) {
+	out, err := r.tmux(name, "show-environment", "-t", name, key)
+	if err != nil {
+		if isGone(err) {
+			return "", err
+		}
+		return "", nil // key not set
+	}
+	_, val, ok := strings.Cut(out, "=")
+	if !ok {
+		return "", nil // "-KEY" marks a removed variable
+	}
+	return val, nil
+}
+
+// RemoveMeta removes a key from the remote session's tmux environment.
+func (r *remote) RemoveMeta(name, key string) error {
+	_, err := r.tmux(name, "set-environment", "-u", "-t", name, key)
+	return err
+}
+
+// ListRunning returns the sessions matching prefix on every registered
+// host. Unreachable hosts are skipped; the error is returned only when
+// no host answered.
+func (r *remote) ListRunning(prefix string) ([]string, error) {
+	var names []string
+	var firstErr error
+	answered := false
+	for _, host := range r.distinctHosts() {
+		out, err := r.tmuxOn(context.Background(), host, "list-sessions", "-F", "#{session_name}")
+		if err != nil {
+			if isGone(err) {
+				answered = true // no tmux server: no sessions
+				continue
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		answered = true
+		for _, n := range strings.Split(out, "\n") {
+			if n != "" && strings.HasPrefix(n, prefix) {
+				names = append(names, n)
+			}
+		}
+	}
+	if !answered && firstErr != nil {
+		return nil, firstErr
+	}
+	return names, nil
+}
+
+// GetLastActivity returns the remote session's last activity time.
+func (r *remote) GetLastActivity(name string) (time.Time, error) {
+	out, err := r.tmux(name, "display-message", "-p", "-t", name, "#{session_activity}")
+	if err != nil {
+		return time.Time{}, err
+	}
+	secs, err := strconv.ParseInt(out, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing session activity %q: %w", out, err)
+	}
+	return time.Unix(secs, 0), nil
+}
+
+// ClearScrollback clears the remote pane's history.
+func (r *remote) ClearScrollback(name string) error {
+	_, err := r.tmux(name, "clear-history", "-t", name)
+	return err
+}
+
+// CopyTo copies src into the remote session's working directory at
+// relDst. The working directory is read from the pane's current path.
+// Best-effort: returns nil if the session is unknown or src missing.
+func (r *remote) CopyTo(name, src, relDst string) error {
+	if _, err := os.Stat(src); err != nil {
+		return nil
+	}
+	wd, err := r.tmux(name, "display-message", "-p", "-t", name, "#{pane_current_path}")
+	if err != nil || wd == "" {
+		return nil
+	}
+	dst := wd
+	if relDst != "" {
+		dst = filepath.Join(wd, relDst)
+	}
+	return r.copy(r.host(name), src, dst)
+}
+
+// Capabilities reports that remote tmux tracks attachment and activity.
+func (r *remote) Capabilities() runtime.ProviderCapabilities {
+	return runtime.ProviderCapabilities{CanReportAttachment: true, CanReportActivity: true}
+}
+
+// sshRun returns a runFunc that pipes scripts to sh on the host with the
+// ssh client. BatchMode makes ssh fail instead of prompting for a
+// password.
+func sshRun(extra []string) runFunc {
+	return func(ctx context.Context, host, script string) (string, error) {
+		args := append(append([]string{"-o", "BatchMode=yes"}, extra...), host, "--", "sh", "-s")
+		cmd := exec.CommandContext(ctx, "ssh", args...)
+		cmd.Stdin = strings.NewReader(script)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("ssh %s: %s", host, msg)
+			}
+			return "", fmt.Errorf("ssh %s: %w", host, err)
+		}
+		return strings.TrimRight(stdout.String(), "\n"), nil
+	}
+}
+
+// scpCopy returns a copyFunc that copies recursively with scp. -O selects
+// the original scp protocol, which always hands the destination path to
+// a remote shell, so the path is quoted for it.
+func scpCopy(extra []string) copyFunc {
+	return func(host, src, dst string) error {
+		args := append(append([]string{"-O", "-o", "BatchMode=yes", "-rq"}, extra...), src, host+":"+shellquote.Quote(dst))
+		cmd := exec.Command("scp", args...)
+		cmd.Stdout = io.Discard
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("scp to %s: %s", host, msg)
+			}
+			return fmt.Errorf("scp to %s: %w", host, err)
+		}
+		return nil
+	}
+}
+
+// isGone reports whether a tmux error means the session or server
+// doesn't exist.
+func isGone(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "can't find session") ||
+		strings.Contains(msg, "session not found") ||
+		strings.Contains(msg, "no server running") ||
+		strings.Contains(msg, "error connecting to")
+}
+
+// exact returns a tmux target that matches the session name exactly
+// rather than by prefix.
+func exact(name string) string {
+	return "=" + name
+}
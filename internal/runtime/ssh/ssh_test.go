@@ -0,0 +1,326 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// fakeSSH records remote commands and answers them from canned responses
+// keyed by a substring of the command line.
+type fakeSSH struct {
+	calls     []string // "host: script"
+	responses map[string]fakeResponse
+	copies    []string // "host:src→dst"
+}
+
+type fakeResponse struct {
+	out string
+	err error
+}
+
+func newFakeSSH() *fakeSSH {
+	return &fakeSSH{responses: make(map[string]fakeResponse)}
+}
+
+func (f *fakeSSH) on(substr, out string, err error) {
+	f.responses[substr] = fakeResponse{out: out, err: err}
+}
+
+func (f *fakeSSH) run(_ context.Context, host, script string) (string, error) {
+	f.calls = append(f.calls, host+": "+script)
+	for substr, r := range f.responses {
+		if strings.Contains(script, substr) {
+			return r.out, r.err
+		}
+	}
+	return "", nil
+}
+
+func (f *fakeSSH) copy(host, src, dst string) error {
+	f.copies = append(f.copies, host+":"+src+"→"+dst)
+	return nil
+}
+
+func newTestProvider(cfg Config) (*Provider, *runtime.Fake, *fakeSSH) {
+	local := runtime.NewFake()
+	f := newFakeSSH()
+	return newProvider(local, newRemote(cfg, f.run, f.copy)), local, f
+}
+
+var errNoSession = errors.New("ssh box: can't find session: worker")
+
+func TestStartRoutesToHost(t *testing.T) {
+	p, local, f := newTestProvider(Config{SocketName: "city"})
+	f.on("has-session", "", errNoSession)
+	p.RouteHost("worker", "dev@box")
+
+	err := p.Start(context.Background(), "worker", runtime.Config{
+		WorkDir: "/srv/rig",
+		Command: "claude --dangerously-skip-permissions",
+		Env:     map[string]string{"GC_AGENT": "worker"},
+	})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if local.IsRunning("worker") {
+		t.Error("routed session should not start locally")
+	}
+	last := f.calls[len(f.calls)-1]
+	envFile := regexp.MustCompile(`/tmp/gc-env-[0-9a-f]{16}`).FindString(last)
+	if envFile == "" {
+		t.Fatalf("last call = %q, want env written to a remote file", last)
+	}
+	for _, want := range []string{
+		"dev@box: (umask 077; set -C; cat > '" + envFile + "')",
+		"\nexport GC_AGENT='worker'\n",
+		"'tmux' '-u' '-L' 'city' 'new-session' '-d' '-s' 'worker' '-c' '/srv/rig' '. '\\''" + envFile + "'\\''; rm -f '\\''" + envFile + "'\\''; claude --dangerously-skip-permissions'",
+	} {
+		if !strings.Contains(last, want) {
+			t.Errorf("last call = %q, want it to contain %q", last, want)
+		}
+	}
+	if strings.Contains(last, "'-e'") {
+		t.Errorf("last call = %q, env must not appear on the tmux command line", last)
+	}
+	if !strings.Contains(f.calls[1], "'mkdir' '-p' '/srv/rig'") {
+		t.Errorf("calls = %q, want mkdir of work dir before start", f.calls)
+	}
+}
+
+func TestStartUnroutedUsesLocal(t *testing.T) {
+	p, local, f := newTestProvider(Config{})
+	if err := p.Start(context.Background(), "mayor", runtime.Config{}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if !local.IsRunning("mayor") {
+		t.Error("unrouted session should start locally")
+	}
+	if len(f.calls) != 0 {
+		t.Errorf("unexpected ssh calls: %q", f.calls)
+	}
+}
+
+func TestStartExistingSessionFails(t *testing.T) {
+	p, _, _ := newTestProvider(Config{})
+	p.RouteHost("worker", "box")
+	err := p.Start(context.Background(), "worker", runtime.Config{})
+	if !errors.Is(err, runtime.ErrSessionExists) {
+		t.Errorf("Start = %v, want ErrSessionExists", err)
+	}
+}
+
+func TestStartRunsPreStartAndNudge(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	f.on("has-session", "", errNoSession)
+	p.RouteHost("worker", "box")
+	err := p.Start(context.Background(), "worker", runtime.Config{
+		WorkDir:  "/w",
+		PreStart: []string{"git pull"},
+		Nudge:    "check your hook",
+	})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	joined := strings.Join(f.calls, "\n")
+	for _, want := range []string{
+		"box: cd '/w' && git pull",
+		"'send-keys' '-t' 'worker' '-l' '--' 'check your hook'",
+		"'send-keys' '-t' 'worker' 'Enter'",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("calls missing %q:\n%s", want, joined)
+		}
+	}
+}
+
+func TestStopMissingSessionIsNil(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	f.on("kill-session", "", errNoSession)
+	p.RouteHost("worker", "box")
+	if err := p.Stop("worker"); err != nil {
+		t.Errorf("Stop = %v, want nil", err)
+	}
+}
+
+func TestIsRunning(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	p.RouteHost("worker", "box")
+	if !p.IsRunning("worker") {
+		t.Error("IsRunning = false, want true when has-session succeeds")
+	}
+	f.on("has-session", "", errNoSession)
+	if p.IsRunning("worker") {
+		t.Error("IsRunning = true, want false when has-session fails")
+	}
+}
+
+func TestNudgeQuotesText(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	p.RouteHost("worker", "box")
+	if err := p.Nudge("worker", runtime.TextContent("it's done")); err != nil {
+		t.Fatalf("Nudge: %v", err)
+	}
+	want := `box: 'tmux' '-u' 'send-keys' '-t' 'worker' '-l' '--' 'it'\''s done'`
+	if f.calls[0] != want {
+		t.Errorf("call = %q, want %q", f.calls[0], want)
+	}
+}
+
+func TestNudgeLeadingDash(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	p.RouteHost("worker", "box")
+	if err := p.Nudge("worker", runtime.TextContent("-n is the flag")); err != nil {
+		t.Fatalf("Nudge: %v", err)
+	}
+	want := `box: 'tmux' '-u' 'send-keys' '-t' 'worker' '-l' '--' '-n is the flag'`
+	if f.calls[0] != want {
+		t.Errorf("call = %q, want %q", f.calls[0], want)
+	}
+}
+
+func TestStartRejectsBadEnvName(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	f.on("has-session", "", errNoSession)
+	p.RouteHost("worker", "box")
+	err := p.Start(context.Background(), "worker", runtime.Config{
+		Env: map[string]string{"BAD;NAME": "x"},
+	})
+	if err == nil {
+		t.Fatal("Start with an invalid env name succeeded")
+	}
+	for _, c := range f.calls {
+		if strings.Contains(c, "new-session") {
+			t.Errorf("session started despite bad env: %q", c)
+		}
+	}
+}
+
+func TestPeek(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	f.on("capture-pane", "line1\nline2", nil)
+	p.RouteHost("worker", "box")
+	out, err := p.Peek("worker", 2)
+	if err != nil {
+		t.Fatalf("Peek: %v", err)
+	}
+	if out != "line1\nline2" {
+		t.Errorf("Peek = %q", out)
+	}
+	if !strings.Contains(f.calls[0], "'-S' '-2'") {
+		t.Errorf("call = %q, want -S -2", f.calls[0])
+	}
+}
+
+func TestGetMeta(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	p.RouteHost("worker", "box")
+	f.on("show-environment", "GC_HASH=abc", nil)
+	got, err := p.GetMeta("worker", "GC_HASH")
+	if err != nil || got != "abc" {
+		t.Errorf("GetMeta = %q, %v; want abc", got, err)
+	}
+	f.on("show-environment", "", errors.New("ssh box: unknown variable: GC_HASH"))
+	got, err = p.GetMeta("worker", "GC_HASH")
+	if err != nil || got != "" {
+		t.Errorf("GetMeta unset = %q, %v; want empty, nil", got, err)
+	}
+}
+
+func TestListRunningMergesHosts(t *testing.T) {
+	p, local, f := newTestProvider(Config{})
+	if err := local.Start(context.Background(), "city-mayor", runtime.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	p.RouteHost("city-a", "box1")
+	p.RouteHost("city-b", "box2")
+	f.on("list-sessions", "city-a\ncity-b\nother", nil)
+
+	names, err := p.ListRunning("city-")
+	if err != nil {
+		t.Fatalf("ListRunning: %v", err)
+	}
+	// Local session plus both remote answers (each host reports the same
+	// canned list in this fake).
+	if len(names) != 5 || names[0] != "city-mayor" {
+		t.Errorf("ListRunning = %q", names)
+	}
+}
+
+func TestCopyToUsesPaneDir(t *testing.T) {
+	p, _, f := newTestProvider(Config{})
+	p.RouteHost("worker", "box")
+	f.on("pane_current_path", "/srv/rig", nil)
+	src := t.TempDir()
+	if err := p.CopyTo("worker", src, ".claude"); err != nil {
+		t.Fatalf("CopyTo: %v", err)
+	}
+	if len(f.copies) != 1 || f.copies[0] != "box:"+src+"→/srv/rig/.claude" {
+		t.Errorf("copies = %q", f.copies)
+	}
+}
+
+// acpLocal is a local backend with the ACP-routing and image-check
+// extensions, as auto and exec provide them.
+type acpLocal struct {
+	*runtime.Fake
+	acp      map[string]bool
+	imageErr error
+}
+
+func (l *acpLocal) RouteACP(name string)    { l.acp[name] = true }
+func (l *acpLocal) Unroute(name string)     { delete(l.acp, name) }
+func (l *acpLocal) CheckImage(string) error { return l.imageErr }
+
+func (l *acpLocal) DetectTransport(name string) string {
+	if l.acp[name] {
+		return "acp"
+	}
+	return ""
+}
+
+func TestExtensionsSurviveWrapper(t *testing.T) {
+	local := &acpLocal{Fake: runtime.NewFake(), acp: map[string]bool{}, imageErr: errors.New("no image")}
+	f := newFakeSSH()
+	p := newProvider(local, newRemote(Config{}, f.run, f.copy))
+	p.RouteHost("worker", "box")
+	var sp runtime.Provider = runtime.Wrapper{Provider: p}
+
+	r, ok := sp.(interface {
+		RouteACP(string)
+		Unroute(string)
+		DetectTransport(string) string
+		CheckImage(string) error
+	})
+	if !ok {
+		t.Fatal("wrapped ssh provider hides the ACP and image extensions")
+	}
+	if r.CheckImage("img") == nil {
+		t.Error("CheckImage not forwarded to the local backend")
+	}
+	r.RouteACP("mayor")
+	if got := r.DetectTransport("mayor"); got != "acp" {
+		t.Errorf("DetectTransport(mayor) = %q, want acp", got)
+	}
+	if got := r.DetectTransport("worker"); got != "" {
+		t.Errorf("DetectTransport(worker) = %q, want \"\" for a remote session", got)
+	}
+
+	// Un-routing ACP must not drop a session's host.
+	r.Unroute("mayor")
+	r.Unroute("worker")
+	if local.acp["mayor"] {
+		t.Error("Unroute not forwarded to the local backend")
+	}
+	if p.Host("worker") != "box" {
+		t.Error("ACP Unroute removed the session's host route")
+	}
+	p.UnrouteHost("worker")
+	if p.Host("worker") != "" {
+		t.Error("UnrouteHost kept the host route")
+	}
+}
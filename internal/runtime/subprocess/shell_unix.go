@@ -0,0 +1,22 @@
+//go:build !windows
+
+package subprocess
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// defaultShell runs when a session has no command.
+const defaultShell = "sh"
+
+// shellCommand runs command through the POSIX shell.
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("sh", "-c", command)
+}
+
+// terminate asks p to exit with SIGTERM.
+func terminate(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package subprocess
+
+import (
+	"os"
+	"os/exec"
+)
+
+// defaultShell runs when a session has no command.
+const defaultShell = "cmd"
+
+// shellCommand runs command through cmd.exe.
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("cmd", "/C", command)
+}
+
+// terminate kills p: Windows cannot deliver SIGTERM, so there is no
+// graceful stop to wait for.
+func terminate(p *os.Process) error {
+	return p.Kill()
+}
@@ -11,14 +11,25 @@
 //     Each session gets a per-session unix socket (<name>.sock) that serves
 //     as both proof of liveness and control channel (stop/interrupt/ping).
 //
+// Output and input:
+//   - When a log directory is configured, stdout and stderr are appended
+//     to <logdir>/<name>.log and Peek returns the tail of that file.
+//   - Each process reads stdin from a pipe held by the starting gc
+//     process; Nudge writes the message text there, followed by a newline.
+//     Cross-process nudges are relayed through the control socket.
+//
 // Limitations compared to tmux:
 //   - No interactive attach (Attach always returns an error)
 //   - No startup hint support (fire-and-forget only)
+//   - Stdin closes when the gc process that started the session exits
 package subprocess
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -39,6 +50,7 @@ import (
 type Provider struct {
 	mu       sync.Mutex
 	dir      string                  // socket/meta file directory
+	logDir   string                  // session log directory ("" = discard output)
 	procs    map[string]*sessionConn // in-process tracking
 	workDirs map[string]string       // session name → workDir (for CopyTo)
 }
@@ -48,8 +60,20 @@ type sessionConn struct {
 	cmd      *exec.Cmd
 	done     chan struct{} // closed when process exits
 	listener net.Listener  // unix socket listener
+	input    *sessionInput // write end of the process's stdin
+}
+
+// sessionInput serializes writes to a session's stdin pipe so that
+// concurrent nudges never interleave.
+type sessionInput struct {
+	mu sync.Mutex
+	w  *os.File
 }
 
+// nudgeWriteTimeout bounds how long a nudge waits on a full stdin pipe
+// (a process that never reads its input).
+const nudgeWriteTimeout = 5 * time.Second
+
 // Compile-time check.
 var _ runtime.Provider = (*Provider)(nil)
 
@@ -68,6 +92,24 @@ func NewProviderWithDir(dir string) *Provider {
 	return &Provider{dir: dir, procs: make(map[string]*sessionConn), workDirs: make(map[string]string)}
 }
 
+// NewProviderWithLogDir returns a subprocess [Provider] like [NewProvider]
+// that also captures each session's stdout and stderr to
+// <logDir>/<name>.log. The city uses .gc/logs.
+func NewProviderWithLogDir(logDir string) *Provider {
+	p := NewProvider()
+	p.logDir = logDir
+	return p
+}
+
+// LogPath returns the log file for the named session, or "" when output
+// is not captured.
+func (p *Provider) LogPath(name string) string {
+	if p.logDir == "" {
+		return ""
+	}
+	return filepath.Join(p.logDir, name+".log")
+}
+
 // Start spawns a child process for the given session name and config.
 // Returns an error if a session with that name is already running.
 // Startup hints (ReadyPromptPrefix, ProcessNames, etc.) are ignored —
@@ -113,10 +155,10 @@ func (p *Provider) Start(_ context.Context, name string, cfg runtime.Config) err
 
 	command := cfg.Command
 	if command == "" {
-		command = "sh"
+		command = defaultShell
 	}
 
-	cmd := exec.Command("sh", "-c", command)
+	cmd := shellCommand(command)
 	if cfg.WorkDir != "" {
 		cmd.Dir = cfg.WorkDir
 	}
@@ -135,16 +177,48 @@ func (p *Provider) Start(_ context.Context, name string, cfg runtime.Config) err
 	}
 	cmd.Env = env
 
-	if err := cmd.Start(); err != nil {
+	var logFile *os.File
+	if lp := p.LogPath(name); lp != "" {
+		if err := os.MkdirAll(p.logDir, 0o755); err != nil {
+			return fmt.Errorf("creating log directory for %q: %w", name, err)
+		}
+		f, err := os.OpenFile(lp, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("opening log for %q: %w", name, err)
+		}
+		logFile = f
+		cmd.Stdout = f
+		cmd.Stderr = f
+	}
+
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		if logFile != nil {
+			logFile.Close() //nolint:errcheck
+		}
+		return fmt.Errorf("creating stdin pipe for %q: %w", name, err)
+	}
+	cmd.Stdin = stdinR
+
+	err = cmd.Start()
+	// The child holds its own copies of these descriptors.
+	stdinR.Close() //nolint:errcheck
+	if logFile != nil {
+		logFile.Close() //nolint:errcheck
+	}
+	if err != nil {
+		stdinW.Close() //nolint:errcheck
 		return fmt.Errorf("starting session %q: %w", name, err)
 	}
+	input := &sessionInput{w: stdinW}
 
 	// Create control socket for cross-process discovery.
-	lis, err := p.startControlSocket(name, cmd)
+	lis, err := p.startControlSocket(name, cmd, input)
 	if err != nil {
 		// Socket creation failed — kill the process and bail.
 		_ = cmd.Process.Kill()
 		_ = cmd.Wait()
+		stdinW.Close() //nolint:errcheck
 		return fmt.Errorf("creating control socket for %q: %w", name, err)
 	}
 
@@ -155,10 +229,11 @@ func (p *Provider) Start(_ context.Context, name string, cfg runtime.Config) err
 		// never sees a stale socket after Stop returns.
 		lis.Close()                 //nolint:errcheck
 		os.Remove(p.sockPath(name)) //nolint:errcheck
+		input.close()
 		close(done)
 	}()
 
-	p.procs[name] = &sessionConn{cmd: cmd, done: done, listener: lis}
+	p.procs[name] = &sessionConn{cmd: cmd, done: done, listener: lis, input: input}
 	return nil
 }
 
@@ -238,9 +313,34 @@ func (p *Provider) ProcessAlive(name string, processNames []string) bool {
 	return p.IsRunning(name)
 }
 
-// Nudge is not supported by the subprocess provider — there is no
-// interactive terminal to send messages to. Returns nil (best-effort).
-func (p *Provider) Nudge(_ string, _ []runtime.ContentBlock) error {
+// Nudge writes the flattened message text, followed by a newline, to the
+// named session's stdin. Returns nil if the session doesn't exist or has
+// already exited (best-effort).
+func (p *Provider) Nudge(name string, content []runtime.ContentBlock) error {
+	text := runtime.FlattenText(content)
+	if text == "" {
+		return nil
+	}
+
+	p.mu.Lock()
+	sc, ok := p.procs[name]
+	p.mu.Unlock()
+	if ok {
+		if !sc.alive() {
+			return nil
+		}
+		return sc.input.send(text)
+	}
+
+	// Cross-process: the gc process that started the session holds the
+	// stdin pipe, so relay the text through its control socket.
+	if !p.socketAlive(name) {
+		return nil
+	}
+	cmd := "nudge " + base64.StdEncoding.EncodeToString([]byte(text))
+	if err := p.sendSocketCommand(name, cmd, nudgeWriteTimeout+time.Second); err != nil {
+		return fmt.Errorf("nudging session %q: %w", name, err)
+	}
 	return nil
 }
 
@@ -255,10 +355,54 @@ func (p *Provider) RunLive(_ string, _ runtime.Config) error {
 	return nil
 }
 
-// Peek is not supported by the subprocess provider — there is no
-// terminal with scrollback to capture. Returns an empty string.
-func (p *Provider) Peek(_ string, _ int) (string, error) {
-	return "", nil
+// peekMaxBytes bounds how much of a log file Peek reads when only the
+// last few lines are requested.
+const peekMaxBytes = 1 << 20
+
+// Peek returns the last N lines of the named session's log file, or the
+// whole file if lines <= 0. Returns an empty string when output is not
+// captured or the session has not written anything yet.
+func (p *Provider) Peek(name string, lines int) (string, error) {
+	lp := p.LogPath(name)
+	if lp == "" {
+		return "", nil
+	}
+	f, err := os.Open(lp)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close() //nolint:errcheck // read-only
+	if lines > 0 {
+		if fi, err := f.Stat(); err == nil && fi.Size() > peekMaxBytes {
+			if _, err := f.Seek(-peekMaxBytes, io.SeekEnd); err != nil {
+				return "", err
+			}
+		}
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return tailLines(data, lines), nil
+}
+
+// tailLines returns the last n lines of data, or all of it if n <= 0.
+func tailLines(data []byte, n int) string {
+	if n <= 0 {
+		return string(data)
+	}
+	trimmed := bytes.TrimSuffix(data, []byte("\n"))
+	idx := len(trimmed)
+	for i := 0; i < n; i++ {
+		idx = bytes.LastIndexByte(trimmed[:idx], '\n')
+		if idx < 0 {
+			return string(data)
+		}
+	}
+	return string(data[idx+1:])
 }
 
 // SetMeta stores a key-value pair for the named session in a sidecar file.
@@ -361,7 +505,8 @@ func (p *Provider) sockPath(name string) string {
 //   - "interrupt" — SIGINT; replies "ok"
 //   - "ping" — replies "ok"
 //   - "pid" — replies with the PID (diagnostics)
-func (p *Provider) startControlSocket(name string, cmd *exec.Cmd) (net.Listener, error) {
+//   - "nudge <base64 text>" — writes the text to stdin; replies "ok"
+func (p *Provider) startControlSocket(name string, cmd *exec.Cmd, input *sessionInput) (net.Listener, error) {
 	sp := p.sockPath(name)
 	// Remove stale socket from a previous crash.
 	os.Remove(sp) //nolint:errcheck
@@ -375,23 +520,38 @@ func (p *Provider) startControlSocket(name string, cmd *exec.Cmd) (net.Listener,
 			if err != nil {
 				return // listener closed
 			}
-			go handleSessionConn(conn, cmd)
+			go handleSessionConn(conn, cmd, input)
 		}
 	}()
 	return lis, nil
 }
 
 // handleSessionConn reads a command from the connection and acts on the process.
-func handleSessionConn(conn net.Conn, cmd *exec.Cmd) {
+func handleSessionConn(conn net.Conn, cmd *exec.Cmd, input *sessionInput) {
 	defer conn.Close()                                     //nolint:errcheck
 	conn.SetReadDeadline(time.Now().Add(10 * time.Second)) //nolint:errcheck
 	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
 	if !scanner.Scan() {
 		return
 	}
-	switch scanner.Text() {
+	line := scanner.Text()
+	if encoded, ok := strings.CutPrefix(line, "nudge "); ok {
+		text, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			fmt.Fprintf(conn, "error: %v\n", err) //nolint:errcheck
+			return
+		}
+		if err := input.send(string(text)); err != nil {
+			fmt.Fprintf(conn, "error: %v\n", err) //nolint:errcheck
+			return
+		}
+		conn.Write([]byte("ok\n")) //nolint:errcheck
+		return
+	}
+	switch line {
 	case "stop":
-		_ = cmd.Process.Signal(syscall.SIGTERM)
+		_ = terminate(cmd.Process)
 		// Wait up to 5s for graceful exit, then SIGKILL.
 		deadline := time.After(5 * time.Second)
 		ticker := time.NewTicker(50 * time.Millisecond)
@@ -464,7 +624,7 @@ func (p *Provider) stopBySocket(name string) error {
 
 // terminateSessionConn sends SIGTERM then SIGKILL to an in-memory tracked process.
 func terminateSessionConn(sc *sessionConn) error {
-	_ = sc.cmd.Process.Signal(syscall.SIGTERM)
+	_ = terminate(sc.cmd.Process)
 
 	select {
 	case <-sc.done:
@@ -483,6 +643,35 @@ func (p *Provider) Capabilities() runtime.ProviderCapabilities {
 	return runtime.ProviderCapabilities{}
 }
 
+// send writes text and a trailing newline to the session's stdin. A
+// closed pipe (the process exited) is not an error.
+func (in *sessionInput) send(text string) error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.w == nil {
+		return nil
+	}
+	in.w.SetWriteDeadline(time.Now().Add(nudgeWriteTimeout)) //nolint:errcheck // unsupported deadline just blocks
+	_, err := in.w.WriteString(text + "\n")
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("writing to stdin: %w", err)
+	}
+	return nil
+}
+
+// close releases the stdin pipe once the process has exited.
+func (in *sessionInput) close() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.w != nil {
+		in.w.Close() //nolint:errcheck
+		in.w = nil
+	}
+}
+
 // alive reports whether the process is still running.
 func (sc *sessionConn) alive() bool {
 	select {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -332,3 +333,106 @@ func TestListRunningViaSocket(t *testing.T) {
 		t.Errorf("ListRunning('') = %v, want 3 results", all)
 	}
 }
+
+func newLoggingProvider(t *testing.T, dir string) *Provider {
+	t.Helper()
+	p := NewProviderWithDir(dir)
+	p.logDir = filepath.Join(filepath.Dir(dir), "logs")
+	return p
+}
+
+// waitForLog polls the session log until it contains want.
+func waitForLog(t *testing.T, p *Provider, name, want string) string {
+	t.Helper()
+	var out string
+	for i := 0; i < 100; i++ {
+		out, _ = p.Peek(name, 0)
+		if strings.Contains(out, want) {
+			return out
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("log for %q never contained %q; got %q", name, want, out)
+	return ""
+}
+
+func TestStartCapturesOutputToLog(t *testing.T) {
+	p := newLoggingProvider(t, filepath.Join(t.TempDir(), "socks"))
+	if err := p.Start(context.Background(), "logged", runtime.Config{Command: "echo out; echo err >&2"}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	out := waitForLog(t, p, "logged", "err")
+	if !strings.Contains(out, "out\n") {
+		t.Errorf("log = %q, want stdout captured", out)
+	}
+	if _, err := os.Stat(filepath.Join(p.logDir, "logged.log")); err != nil {
+		t.Errorf("log file: %v", err)
+	}
+}
+
+func TestPeekWithoutLogDir(t *testing.T) {
+	p := newTestProvider(t)
+	out, err := p.Peek("anything", 10)
+	if err != nil || out != "" {
+		t.Errorf("Peek = %q, %v; want empty, nil", out, err)
+	}
+}
+
+func TestNudgeWritesToStdin(t *testing.T) {
+	p := newLoggingProvider(t, filepath.Join(t.TempDir(), "socks"))
+	cmd := `while read line; do echo "got:$line"; done`
+	if err := p.Start(context.Background(), "reader", runtime.Config{Command: cmd}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer p.Stop("reader") //nolint:errcheck
+
+	if err := p.Nudge("reader", runtime.TextContent("check mail")); err != nil {
+		t.Fatalf("Nudge: %v", err)
+	}
+	waitForLog(t, p, "reader", "got:check mail")
+}
+
+func TestCrossProcessNudgeBySocket(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "socks")
+	p1 := newLoggingProvider(t, dir)
+	cmd := `while read line; do echo "got:$line"; done`
+	if err := p1.Start(context.Background(), "relay", runtime.Config{Command: cmd}); err != nil {
+		t.Fatalf("p1.Start: %v", err)
+	}
+	defer p1.Stop("relay") //nolint:errcheck
+
+	p2 := NewProviderWithDir(dir)
+	if err := p2.Nudge("relay", runtime.TextContent("from afar")); err != nil {
+		t.Fatalf("p2.Nudge: %v", err)
+	}
+	waitForLog(t, p1, "relay", "got:from afar")
+}
+
+func TestNudgeExitedSession(t *testing.T) {
+	p := newTestProvider(t)
+	if err := p.Start(context.Background(), "gone", runtime.Config{Command: "true"}); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if err := p.Nudge("gone", runtime.TextContent("hello")); err != nil {
+		t.Errorf("Nudge on exited session should not error: %v", err)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	data := []byte("a\nb\nc\n")
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "a\nb\nc\n"},
+		{1, "c\n"},
+		{2, "b\nc\n"},
+		{5, "a\nb\nc\n"},
+	}
+	for _, tt := range tests {
+		if got := tailLines(data, tt.n); got != tt.want {
+			t.Errorf("tailLines(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
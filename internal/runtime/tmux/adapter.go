@@ -85,6 +85,17 @@ func (p *Provider) Start(ctx context.Context, name string, cfg runtime.Config) e
 		_ = overlay.CopyFileOrDir(cf.Src, dst, io.Discard)
 	}
 
+	// Remember the ready probe in the session environment so Nudge can
+	// wait on it instead of the default prompt heuristic.
+	if cfg.ReadyProbe != "" {
+		env := make(map[string]string, len(cfg.Env)+1)
+		for k, v := range cfg.Env {
+			env[k] = v
+		}
+		env[runtime.ReadyProbeEnv] = cfg.ReadyProbe
+		cfg.Env = env
+	}
+
 	return doStartSession(ctx, &tmuxStartOps{tm: p.tm}, name, cfg, p.cfg.SetupTimeout)
 }
 
@@ -166,11 +177,30 @@ func (p *Provider) Nudge(name string, content []runtime.ContentBlock) error {
 		// Best-effort wait — if it fails (session gone, timeout), proceed
 		// with the nudge anyway. The message may arrive during active work,
 		// but Claude's cooperative queue will handle it at the next turn.
-		_ = p.tm.WaitForIdle(name, idleTimeout)
+		// A session started with a ready probe is waited on with that probe.
+		if probe := p.sessionReadyProbe(name); probe != nil {
+			_ = p.tm.WaitForReadyProbe(context.Background(), name, probe, idleTimeout)
+		} else {
+			_ = p.tm.WaitForIdle(name, idleTimeout)
+		}
 	}
 	return p.NudgeNow(name, content)
 }
 
+// sessionReadyProbe returns the ready probe the session was started with,
+// or nil if it has none.
+func (p *Provider) sessionReadyProbe(name string) *runtime.ReadyProbe {
+	v, err := p.tm.GetEnvironment(name, runtime.ReadyProbeEnv)
+	if err != nil || v == "" {
+		return nil
+	}
+	probe, err := runtime.ParseReadyProbe(v)
+	if err != nil {
+		return nil
+	}
+	return probe
+}
+
 // NudgeNow sends a message immediately without performing a wait-idle check.
 func (p *Provider) NudgeNow(name string, content []runtime.ContentBlock) error {
 	var parts []string
@@ -415,7 +445,7 @@ func doStartSession(ctx context.Context, ops startOps, name string, cfg runtime.
 	// Enable remain-on-exit for crash forensics. Best-effort.
 	_ = ops.setRemainOnExit(name)
 
-	hasHints := cfg.ReadyPromptPrefix != "" || cfg.ReadyDelayMs > 0 ||
+	hasHints := cfg.ReadyPromptPrefix != "" || cfg.ReadyDelayMs > 0 || cfg.ReadyProbe != "" ||
 		len(cfg.ProcessNames) > 0 || cfg.EmitsPermissionWarning ||
 		cfg.Nudge != "" || len(cfg.PreStart) > 0 || len(cfg.SessionSetup) > 0 || cfg.SessionSetupScript != "" ||
 		len(cfg.SessionLive) > 0
@@ -437,10 +467,11 @@ func doStartSession(ctx context.Context, ops startOps, name string, cfg runtime.
 	}
 
 	// Step 4: Wait for runtime readiness.
-	if cfg.ReadyPromptPrefix != "" || cfg.ReadyDelayMs > 0 {
+	if cfg.ReadyPromptPrefix != "" || cfg.ReadyDelayMs > 0 || cfg.ReadyProbe != "" {
 		rc := &RuntimeConfig{Tmux: &RuntimeTmuxConfig{
 			ReadyPromptPrefix: cfg.ReadyPromptPrefix,
 			ReadyDelayMs:      cfg.ReadyDelayMs,
+			ReadyProbe:        cfg.ReadyProbe,
 			ProcessNames:      cfg.ProcessNames,
 		}}
 		_ = ops.waitForReady(ctx, name, rc, 60*time.Second) // best-effort
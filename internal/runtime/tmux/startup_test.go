@@ -253,6 +253,27 @@ func TestDoStartSession_FullSequence(t *testing.T) {
 	}
 }
 
+func TestDoStartSession_PassesReadyProbe(t *testing.T) {
+	ops := &fakeStartOps{hasSessionResult: true}
+	cfg := runtime.Config{Command: "aider", ReadyProbe: "regex:^> $"}
+
+	if err := doStartSession(context.Background(), ops, "gc-city-aider", cfg, DefaultConfig().SetupTimeout); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var wfr *startCall
+	for i := range ops.calls {
+		if ops.calls[i].method == "waitForReady" {
+			wfr = &ops.calls[i]
+		}
+	}
+	if wfr == nil {
+		t.Fatal("waitForReady not called for a session with only a ready probe")
+	}
+	if wfr.rc == nil || wfr.rc.Tmux == nil || wfr.rc.Tmux.ReadyProbe != "regex:^> $" {
+		t.Errorf("waitForReady rc = %+v, want ReadyProbe %q", wfr.rc, "regex:^> $")
+	}
+}
+
 func TestDoStartSession_CreateFails(t *testing.T) {
 	ops := &fakeStartOps{
 		createErrs: []error{errors.New("tmux not found")},
@@ -98,6 +98,7 @@ type RuntimeTmuxConfig struct {
 	ProcessNames      []string // tmux pane commands indicating runtime is running
 	ReadyPromptPrefix string   // prompt prefix to detect readiness (e.g., "> ")
 	ReadyDelayMs      int      // fixed delay used when prompt detection unavailable
+	ReadyProbe        string   // regex or exec: probe; replaces prefix and delay (see runtime.ParseReadyProbe)
 }
 
 // sessionNudgeLocks serializes nudges to the same session.
@@ -1886,12 +1887,21 @@ func matchesPromptPrefix(line, readyPromptPrefix string) bool {
 }
 
 // WaitForRuntimeReady polls until the agent runtime's ready prompt appears in
-// the pane. Falls back to a fixed delay when prompt detection is unavailable.
+// the pane. A configured ready probe takes precedence; without one, falls
+// back to a fixed delay when prompt detection is unavailable.
 func (t *Tmux) WaitForRuntimeReady(ctx context.Context, session string, rc *RuntimeConfig, timeout time.Duration) error {
 	if rc == nil || rc.Tmux == nil {
 		return nil
 	}
 
+	if rc.Tmux.ReadyProbe != "" {
+		probe, err := runtime.ParseReadyProbe(rc.Tmux.ReadyProbe)
+		if err != nil {
+			return err
+		}
+		return t.WaitForReadyProbe(ctx, session, probe, timeout)
+	}
+
 	if rc.Tmux.ReadyPromptPrefix == "" {
 		if rc.Tmux.ReadyDelayMs <= 0 {
 			return nil
@@ -1941,6 +1951,28 @@ func (t *Tmux) WaitForRuntimeReady(ctx context.Context, session string, rc *Runt
 	return fmt.Errorf("timeout waiting for runtime prompt")
 }
 
+// WaitForReadyProbe polls until probe reports the session ready: a
+// pattern probe matches the last lines of the pane, or a command probe
+// exits 0.
+func (t *Tmux) WaitForReadyProbe(ctx context.Context, session string, probe *runtime.ReadyProbe, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if probe.Command != "" {
+			if probe.RunCommand(ctx, session) {
+				return nil
+			}
+		} else if lines, err := t.CapturePaneLines(session, 20); err == nil && probe.MatchOutput(lines) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	return fmt.Errorf("timeout waiting for ready probe")
+}
+
 // DefaultReadyPromptPrefix is the Claude Code prompt prefix used for idle detection.
 // Claude Code uses ❯ (U+276F) as the prompt character.
 const DefaultReadyPromptPrefix = "❯ "
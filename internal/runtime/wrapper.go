@@ -0,0 +1,95 @@
+package runtime
+
+import "time"
+
+// Wrapper is an embeddable base for providers that decorate another
+// [Provider], such as those adding lifecycle hooks, usage tracking,
+// snapshots, or rate limits. It forwards every operation, including the
+// optional extensions callers discover by type assertion, to the wrapped
+// provider, so decorating a provider never hides a capability. Decorators
+// override only the methods they change.
+//
+// When the wrapped provider lacks an extension, Wrapper behaves as if the
+// caller had found none: interactions report [ErrInteractionUnsupported],
+// NudgeNow falls back to Nudge, CheckImage accepts every image,
+// RouteACP and Unroute do nothing, and DetectTransport reports "".
+type Wrapper struct {
+	Provider
+}
+
+var (
+	_ InteractionProvider    = Wrapper{}
+	_ IdleWaitProvider       = Wrapper{}
+	_ ImmediateNudgeProvider = Wrapper{}
+)
+
+// Unwrap returns the wrapped provider.
+func (w Wrapper) Unwrap() Provider {
+	return w.Provider
+}
+
+// NudgeNow delegates when the wrapped provider supports it, else nudges.
+func (w Wrapper) NudgeNow(name string, content []ContentBlock) error {
+	if np, ok := w.Provider.(ImmediateNudgeProvider); ok {
+		return np.NudgeNow(name, content)
+	}
+	return w.Provider.Nudge(name, content)
+}
+
+// WaitForIdle delegates when the wrapped provider supports it.
+func (w Wrapper) WaitForIdle(name string, timeout time.Duration) error {
+	if wp, ok := w.Provider.(IdleWaitProvider); ok {
+		return wp.WaitForIdle(name, timeout)
+	}
+	return ErrInteractionUnsupported
+}
+
+// Pending delegates when the wrapped provider supports interactions.
+func (w Wrapper) Pending(name string) (*PendingInteraction, error) {
+	if ip, ok := w.Provider.(InteractionProvider); ok {
+		return ip.Pending(name)
+	}
+	return nil, ErrInteractionUnsupported
+}
+
+// Respond delegates when the wrapped provider supports interactions.
+func (w Wrapper) Respond(name string, response InteractionResponse) error {
+	if ip, ok := w.Provider.(InteractionProvider); ok {
+		return ip.Respond(name, response)
+	}
+	return ErrInteractionUnsupported
+}
+
+// CheckImage delegates to a wrapped provider that pre-checks container
+// images (exec), and accepts every image otherwise.
+func (w Wrapper) CheckImage(image string) error {
+	if ic, ok := w.Provider.(interface{ CheckImage(string) error }); ok {
+		return ic.CheckImage(image)
+	}
+	return nil
+}
+
+// RouteACP delegates to a wrapped provider that routes sessions between
+// backends (auto).
+func (w Wrapper) RouteACP(name string) {
+	if r, ok := w.Provider.(interface{ RouteACP(string) }); ok {
+		r.RouteACP(name)
+	}
+}
+
+// Unroute delegates to a wrapped provider that routes sessions between
+// backends (auto).
+func (w Wrapper) Unroute(name string) {
+	if r, ok := w.Provider.(interface{ Unroute(string) }); ok {
+		r.Unroute(name)
+	}
+}
+
+// DetectTransport delegates to a wrapped provider that hosts sessions on
+// several backends (auto), and reports "" otherwise.
+func (w Wrapper) DetectTransport(name string) string {
+	if d, ok := w.Provider.(interface{ DetectTransport(string) string }); ok {
+		return d.DetectTransport(name)
+	}
+	return ""
+}
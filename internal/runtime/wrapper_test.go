@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"errors"
+	"testing"
+)
+
+// routingFake is a Fake with the image-check and ACP-routing extensions.
+type routingFake struct {
+	*Fake
+	routed   map[string]bool
+	imageErr error
+}
+
+func (f *routingFake) CheckImage(string) error       { return f.imageErr }
+func (f *routingFake) RouteACP(name string)          { f.routed[name] = true }
+func (f *routingFake) Unroute(name string)           { delete(f.routed, name) }
+func (f *routingFake) DetectTransport(string) string { return "acp" }
+
+// decorator is a minimal Wrapper-based provider, as decorators define them.
+type decorator struct {
+	Wrapper
+}
+
+func TestWrapperForwardsExtensions(t *testing.T) {
+	inner := &routingFake{Fake: NewFake(), routed: map[string]bool{}, imageErr: errors.New("no image")}
+	// Two layers, as in a real decorator chain.
+	var sp Provider = &decorator{Wrapper{Provider: &decorator{Wrapper{Provider: inner}}}}
+
+	ic, ok := sp.(interface{ CheckImage(string) error })
+	if !ok || ic.CheckImage("img") == nil {
+		t.Error("CheckImage not forwarded to the wrapped provider")
+	}
+	r, ok := sp.(interface {
+		RouteACP(string)
+		Unroute(string)
+	})
+	if !ok {
+		t.Fatal("RouteACP/Unroute hidden by wrapper")
+	}
+	r.RouteACP("s1")
+	if !inner.routed["s1"] {
+		t.Error("RouteACP not forwarded")
+	}
+	r.Unroute("s1")
+	if inner.routed["s1"] {
+		t.Error("Unroute not forwarded")
+	}
+	if d, ok := sp.(interface{ DetectTransport(string) string }); !ok || d.DetectTransport("s1") != "acp" {
+		t.Error("DetectTransport not forwarded")
+	}
+	if sp.(interface{ Unwrap() Provider }).Unwrap() == Provider(inner) {
+		t.Error("Unwrap skipped a layer")
+	}
+}
+
+func TestWrapperFallbacks(t *testing.T) {
+	w := Wrapper{Provider: NewFake()}
+	if err := w.CheckImage("img"); err != nil {
+		t.Errorf("CheckImage = %v, want nil without an image checker", err)
+	}
+	if got := w.DetectTransport("s1"); got != "" {
+		t.Errorf("DetectTransport = %q, want empty", got)
+	}
+	w.RouteACP("s1") // no-op, must not panic
+}
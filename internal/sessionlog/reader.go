@@ -541,9 +541,12 @@ func MergeSearchPaths(extraPaths []string) []string {
 }
 
 // ProjectSlug converts an absolute path to the project directory slug
-// convention: all "/" and "." are replaced with "-".
+// convention: all "/" and "." are replaced with "-". Windows paths are
+// slashed first and their drive colon replaced too, so C:\src\x becomes
+// C--src-x.
 func ProjectSlug(absPath string) string {
-	s := strings.ReplaceAll(absPath, "/", "-")
+	s := strings.ReplaceAll(filepath.ToSlash(absPath), "/", "-")
+	s = strings.ReplaceAll(s, ":", "-")
 	s = strings.ReplaceAll(s, ".", "-")
 	return s
 }
@@ -0,0 +1,109 @@
+package sessionlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TokenUsage totals the tokens billed for assistant turns.
+type TokenUsage struct {
+	// InputTokens counts uncached input plus cache writes.
+	InputTokens int `json:"input_tokens"`
+	// CacheReadTokens counts input served from the prompt cache.
+	CacheReadTokens int `json:"cache_read_tokens"`
+	// OutputTokens counts generated tokens.
+	OutputTokens int `json:"output_tokens"`
+}
+
+// usageEntry is the part of a Claude JSONL line SumTokenUsage needs.
+type usageEntry struct {
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Message   json.RawMessage `json:"message"`
+}
+
+// usageMessage is an assistant message with its API usage block.
+type usageMessage struct {
+	ID    string `json:"id"`
+	Usage *struct {
+		InputTokens              int `json:"input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// SumTokenUsage totals the token usage of assistant turns at or after
+// since in the Claude session files for workDir across searchPaths.
+// Claude writes one line per content block, each repeating the turn's
+// usage, so turns are counted once by message ID. Unreadable files and
+// lines are skipped; other providers' logs are not read.
+func SumTokenUsage(searchPaths []string, workDir string, since time.Time) TokenUsage {
+	turns := make(map[string]TokenUsage)
+	var anon TokenUsage // turns without a message ID
+	slug := ProjectSlug(workDir)
+	for _, base := range searchPaths {
+		dir := filepath.Join(base, slug)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".jsonl") {
+				continue
+			}
+			if info, err := e.Info(); err != nil || info.ModTime().Before(since) {
+				continue
+			}
+			sumFileUsage(filepath.Join(dir, e.Name()), since, turns, &anon)
+		}
+	}
+	total := anon
+	for _, u := range turns {
+		total.InputTokens += u.InputTokens
+		total.CacheReadTokens += u.CacheReadTokens
+		total.OutputTokens += u.OutputTokens
+	}
+	return total
+}
+
+// sumFileUsage adds the usage of one session file's assistant turns to
+// turns (by message ID) or anon.
+func sumFileUsage(path string, since time.Time, turns map[string]TokenUsage, anon *TokenUsage) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close() //nolint:errcheck // read-only
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry usageEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil || entry.Type != "assistant" || entry.Timestamp.Before(since) {
+			continue
+		}
+		var msg usageMessage
+		if json.Unmarshal(unwrapJSONString(entry.Message), &msg) != nil || msg.Usage == nil {
+			continue
+		}
+		u := TokenUsage{
+			InputTokens:     msg.Usage.InputTokens + msg.Usage.CacheCreationInputTokens,
+			CacheReadTokens: msg.Usage.CacheReadInputTokens,
+			OutputTokens:    msg.Usage.OutputTokens,
+		}
+		if msg.ID == "" {
+			anon.InputTokens += u.InputTokens
+			anon.CacheReadTokens += u.CacheReadTokens
+			anon.OutputTokens += u.OutputTokens
+			continue
+		}
+		// Later lines of a streamed turn carry the final output count.
+		if prev, ok := turns[msg.ID]; !ok || u.OutputTokens >= prev.OutputTokens {
+			turns[msg.ID] = u
+		}
+	}
+}
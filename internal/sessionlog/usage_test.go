@@ -0,0 +1,47 @@
+package sessionlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSumTokenUsage(t *testing.T) {
+	base := t.TempDir()
+	workDir := "/rigs/app"
+	dir := filepath.Join(base, ProjectSlug(workDir))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	since := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	usage := func(in, read, create, out int) map[string]any {
+		return map[string]any{
+			"input_tokens":                in,
+			"cache_read_input_tokens":     read,
+			"cache_creation_input_tokens": create,
+			"output_tokens":               out,
+		}
+	}
+	writeTailJSONL(t, filepath.Join(dir, "a.jsonl"), []map[string]any{
+		// Before since: not counted.
+		{"type": "assistant", "timestamp": since.Add(-time.Minute), "message": map[string]any{"id": "m0", "usage": usage(1000, 0, 0, 1000)}},
+		// One streamed turn written as two lines: counted once.
+		{"type": "assistant", "timestamp": since.Add(time.Minute), "message": map[string]any{"id": "m1", "usage": usage(10, 100, 5, 1)}},
+		{"type": "assistant", "timestamp": since.Add(time.Minute), "message": map[string]any{"id": "m1", "usage": usage(10, 100, 5, 7)}},
+		{"type": "user", "timestamp": since.Add(2 * time.Minute), "message": map[string]any{"role": "user", "content": "more"}},
+	})
+	writeTailJSONL(t, filepath.Join(dir, "b.jsonl"), []map[string]any{
+		{"type": "assistant", "timestamp": since.Add(3 * time.Minute), "message": map[string]any{"id": "m2", "usage": usage(20, 200, 0, 3)}},
+	})
+
+	got := SumTokenUsage([]string{base}, workDir, since)
+	want := TokenUsage{InputTokens: 35, CacheReadTokens: 300, OutputTokens: 10}
+	if got != want {
+		t.Errorf("SumTokenUsage = %+v, want %+v", got, want)
+	}
+
+	if got := SumTokenUsage([]string{base}, "/elsewhere", since); got != (TokenUsage{}) {
+		t.Errorf("SumTokenUsage(other dir) = %+v, want zero", got)
+	}
+}
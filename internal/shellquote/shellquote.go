@@ -0,0 +1,20 @@
+// Package shellquote quotes strings for POSIX shell command lines.
+package shellquote
+
+import "strings"
+
+// Quote wraps s in single quotes, escaping embedded single quotes, so
+// sh reads it back as one literal word.
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Join quotes each of args and joins them with spaces into a command
+// line.
+func Join(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = Quote(a)
+	}
+	return strings.Join(quoted, " ")
+}
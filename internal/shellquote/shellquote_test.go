@@ -0,0 +1,29 @@
+package shellquote
+
+import "testing"
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"simple", "'simple'"},
+		{"it's", "'it'\\''s'"},
+		{"a b c", "'a b c'"},
+		{"", "''"},
+		{"$(rm -rf /)", "'$(rm -rf /)'"},
+		{"`evil`", "'`evil`'"},
+		{"hello'world'end", "'hello'\\''world'\\''end'"},
+	}
+	for _, tt := range tests {
+		if got := Quote(tt.input); got != tt.want {
+			t.Errorf("Quote(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if got, want := Join([]string{"tmux", "new-session", "-s", "it's"}), `'tmux' 'new-session' '-s' 'it'\''s'`; got != want {
+		t.Errorf("Join = %q, want %q", got, want)
+	}
+}
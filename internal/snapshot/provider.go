@@ -0,0 +1,65 @@
+package snapshot
+
+import (
+	"time"
+
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+// Capture saves the last lines of session name's output to d for reason.
+// It returns the snapshot's path, or "" when the output is empty.
+func (d Dir) Capture(sp runtime.Provider, name, reason string, now time.Time) (string, error) {
+	out, err := sp.Peek(name, d.LinesOrDefault())
+	if err != nil {
+		return "", err
+	}
+	if len(out) == 0 {
+		return "", nil
+	}
+	return d.Save(name, reason, out, now)
+}
+
+// Provider wraps a [runtime.Provider] so the output of a running session
+// is saved to a snapshot [Dir] before the session is stopped. Capture is
+// best-effort and never fails the stop. Every other operation, including
+// optional extensions, goes straight to the wrapped provider.
+type Provider struct {
+	runtime.Wrapper
+	dir Dir
+	now func() time.Time
+}
+
+var _ runtime.Provider = (*Provider)(nil)
+
+// WrapProvider returns sp saving snapshots to dir on stop.
+func WrapProvider(sp runtime.Provider, dir Dir) *Provider {
+	return &Provider{Wrapper: runtime.Wrapper{Provider: sp}, dir: dir, now: time.Now}
+}
+
+// Find returns the snapshot Provider in sp's chain of wrappers, if any.
+func Find(sp runtime.Provider) (*Provider, bool) {
+	for {
+		if p, ok := sp.(*Provider); ok {
+			return p, true
+		}
+		w, ok := sp.(interface{ Unwrap() runtime.Provider })
+		if !ok {
+			return nil, false
+		}
+		sp = w.Unwrap()
+	}
+}
+
+// Snapshot saves the output of session name for reason, for callers that
+// notice a session ending without stopping it, such as a crash.
+func (p *Provider) Snapshot(name, reason string) (string, error) {
+	return p.dir.Capture(p.Provider, name, reason, p.now())
+}
+
+// Stop snapshots the session's output if it is running, then stops it.
+func (p *Provider) Stop(name string) error {
+	if p.Provider.IsRunning(name) {
+		_, _ = p.Snapshot(name, ReasonStop)
+	}
+	return p.Provider.Stop(name)
+}
@@ -0,0 +1,153 @@
+// Package snapshot saves the last lines of a session's output when the
+// session stops or crashes, so agent failures can be examined after the
+// pane is gone.
+package snapshot
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Defaults for [Dir] fields left zero.
+const (
+	DefaultLines = 200
+	DefaultKeep  = 10
+)
+
+// Reasons a snapshot is taken.
+const (
+	ReasonStop  = "stop"
+	ReasonCrash = "crash"
+)
+
+// timeFormat is the timestamp in snapshot file names. It contains no
+// "-", so the session name is everything before the last one.
+const timeFormat = "20060102T150405.000Z"
+
+// Dir is a directory of snapshot files named <session>-<timestamp>.log.
+type Dir struct {
+	Path  string
+	Lines int // lines of output to capture; 0 means DefaultLines
+	Keep  int // snapshots kept per session; 0 means DefaultKeep
+}
+
+// Snapshot describes one saved snapshot file.
+type Snapshot struct {
+	Path    string
+	Session string
+	Reason  string
+	Time    time.Time
+}
+
+// LinesOrDefault returns the number of lines to capture.
+func (d Dir) LinesOrDefault() int {
+	if d.Lines <= 0 {
+		return DefaultLines
+	}
+	return d.Lines
+}
+
+func (d Dir) keep() int {
+	if d.Keep <= 0 {
+		return DefaultKeep
+	}
+	return d.Keep
+}
+
+// Save writes output as a snapshot of session taken at now for reason,
+// then removes that session's oldest snapshots beyond Keep. It returns
+// the new file's path.
+func (d Dir) Save(session, reason, output string, now time.Time) (string, error) {
+	if err := os.MkdirAll(d.Path, 0o755); err != nil {
+		return "", fmt.Errorf("snapshot: %w", err)
+	}
+	now = now.UTC()
+	path := filepath.Join(d.Path, fileName(session)+"-"+now.Format(timeFormat)+".log")
+	var b strings.Builder
+	fmt.Fprintf(&b, "# session: %s\n# reason: %s\n# time: %s\n\n", session, reason, now.Format(time.RFC3339)) //nolint:errcheck // strings.Builder
+	b.WriteString(strings.TrimRight(output, "\n"))
+	b.WriteString("\n")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return "", fmt.Errorf("snapshot: %w", err)
+	}
+	all, err := List(d.Path)
+	if err != nil {
+		return path, err
+	}
+	kept := 0
+	for _, s := range all {
+		if s.Session != session {
+			continue
+		}
+		if kept++; kept > d.keep() {
+			_ = os.Remove(s.Path)
+		}
+	}
+	return path, nil
+}
+
+// List returns the snapshots in dir, newest first. A missing directory
+// has none.
+func List(dir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	var out []Snapshot
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".log")
+		if !ok || e.IsDir() {
+			continue
+		}
+		i := strings.LastIndex(name, "-")
+		if i < 0 {
+			continue
+		}
+		t, err := time.Parse(timeFormat, name[i+1:])
+		if err != nil {
+			continue
+		}
+		s := Snapshot{Path: filepath.Join(dir, e.Name()), Session: name[:i], Time: t}
+		s.Session, s.Reason = readHeader(s.Path, s.Session)
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.After(out[j].Time) })
+	return out, nil
+}
+
+// readHeader returns the session and reason recorded at the top of a
+// snapshot file, falling back to session when the file has no header.
+func readHeader(path, session string) (string, string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return session, ""
+	}
+	defer f.Close() //nolint:errcheck // read-only
+	var reason string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line, ok := strings.CutPrefix(sc.Text(), "# ")
+		if !ok {
+			break
+		}
+		if v, ok := strings.CutPrefix(line, "session: "); ok {
+			session = v
+		} else if v, ok := strings.CutPrefix(line, "reason: "); ok {
+			reason = v
+		}
+	}
+	return session, reason
+}
+
+// fileName makes a session name safe to use in a file name.
+func fileName(session string) string {
+	return strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(session)
+}
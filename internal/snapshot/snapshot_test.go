@@ -0,0 +1,124 @@
+package snapshot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/runtime"
+)
+
+func TestSaveAndList(t *testing.T) {
+	d := Dir{Path: filepath.Join(t.TempDir(), "crash")}
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	path, err := d.Save("city--app/worker", ReasonCrash, "panic: boom\n", t0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := filepath.Base(path); got != "city--app_worker-20260301T120000.000Z.log" {
+		t.Errorf("file = %q", got)
+	}
+	if _, err := d.Save("city--mayor", ReasonStop, "bye", t0.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := List(d.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("List = %+v, want 2", got)
+	}
+	if got[0].Session != "city--mayor" || got[0].Reason != ReasonStop {
+		t.Errorf("newest = %+v", got[0])
+	}
+	if got[1].Session != "city--app/worker" || got[1].Reason != ReasonCrash || !got[1].Time.Equal(t0) {
+		t.Errorf("oldest = %+v", got[1])
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), "\n\npanic: boom\n") {
+		t.Errorf("contents = %q", data)
+	}
+}
+
+func TestSavePrunesPerSession(t *testing.T) {
+	d := Dir{Path: t.TempDir(), Keep: 2}
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		if _, err := d.Save("s-1", ReasonStop, "x", t0.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := d.Save("s-2", ReasonStop, "x", t0); err != nil {
+		t.Fatal(err)
+	}
+	got, err := List(d.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := map[string]int{}
+	for _, s := range got {
+		count[s.Session]++
+	}
+	if count["s-1"] != 2 || count["s-2"] != 1 {
+		t.Errorf("kept = %v, want s-1:2 s-2:1", count)
+	}
+	if !got[0].Time.Equal(t0.Add(3 * time.Second)) {
+		t.Errorf("newest kept = %v", got[0].Time)
+	}
+}
+
+func TestListMissingDir(t *testing.T) {
+	got, err := List(filepath.Join(t.TempDir(), "nope"))
+	if err != nil || len(got) != 0 {
+		t.Errorf("List = %v, %v", got, err)
+	}
+}
+
+func TestProviderSnapshotsOnStop(t *testing.T) {
+	dir := t.TempDir()
+	fake := runtime.NewFake()
+	sp := WrapProvider(fake, Dir{Path: dir})
+	if err := sp.Start(context.Background(), "s-1", runtime.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	fake.SetPeekOutput("s-1", "last words")
+	if err := sp.Stop("s-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sp.Stop("s-1"); err != nil { // already stopped: nothing to capture
+		t.Fatal(err)
+	}
+	got, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Session != "s-1" || got[0].Reason != ReasonStop {
+		t.Fatalf("snapshots = %+v", got)
+	}
+	if fake.IsRunning("s-1") {
+		t.Error("session still running")
+	}
+}
+
+func TestFindThroughWrappers(t *testing.T) {
+	sp := WrapProvider(runtime.NewFake(), Dir{Path: t.TempDir()})
+	if got, ok := Find(unwrapper{sp}); !ok || got != sp {
+		t.Errorf("Find = %v, %v", got, ok)
+	}
+	if _, ok := Find(runtime.NewFake()); ok {
+		t.Error("Find(fake) found a snapshot provider")
+	}
+}
+
+// unwrapper is a stand-in for another provider wrapper.
+type unwrapper struct{ runtime.Provider }
+
+func (u unwrapper) Unwrap() runtime.Provider { return u.Provider }
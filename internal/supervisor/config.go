@@ -14,6 +14,44 @@ import (
 type Config struct {
 	Supervisor  Section           `toml:"supervisor"`
 	Publication PublicationConfig `toml:"publication,omitempty"`
+	Federation  FederationConfig  `toml:"federation,omitempty"`
+}
+
+// FederationConfig holds the [federation] table. Listen makes this machine
+// a member that serves its registered cities to a capital; Remotes are the
+// members this machine (as capital) talks to.
+type FederationConfig struct {
+	// Listen is the member's gRPC listen address (e.g., "0.0.0.0:8373").
+	// Empty disables "gc federation serve" unless --listen is given.
+	Listen string `toml:"listen,omitempty"`
+	// TLSCert and TLSKey are PEM files for serving over TLS. Both or
+	// neither must be set.
+	TLSCert string `toml:"tls_cert,omitempty"`
+	TLSKey  string `toml:"tls_key,omitempty"`
+	// Remotes are the members a capital federates.
+	Remotes []FederationRemote `toml:"remote,omitempty"`
+}
+
+// FederationRemote is one member a capital dials.
+type FederationRemote struct {
+	// Name identifies the member in gc federation output and in
+	// "<remote>/<city>" sling targets.
+	Name string `toml:"name"`
+	// Address is the member's host:port.
+	Address string `toml:"address"`
+	// TokenEnv names the env var holding the member's federation token.
+	// Defaults to GC_FEDERATION_TOKEN.
+	TokenEnv string `toml:"token_env,omitempty"`
+	// TLS dials the member over TLS using the system roots.
+	TLS bool `toml:"tls,omitempty"`
+}
+
+// TokenEnvOrDefault returns the env var holding the remote's token.
+func (r FederationRemote) TokenEnvOrDefault() string {
+	if r.TokenEnv == "" {
+		return "GC_FEDERATION_TOKEN"
+	}
+	return r.TokenEnv
 }
 
 // Section holds the [supervisor] table fields.
@@ -152,6 +190,11 @@ func ConfigPath() string {
 	return filepath.Join(DefaultHome(), "supervisor.toml")
 }
 
+// FederationTokenPath returns the path to the member's federation token.
+func FederationTokenPath() string {
+	return filepath.Join(DefaultHome(), "federation-token")
+}
+
 // PublicationsPath returns the authoritative publication store path.
 func PublicationsPath() string {
 	return filepath.Join(DefaultHome(), "supervisor", "publications.json")
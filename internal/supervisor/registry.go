@@ -10,9 +10,9 @@ import (
 	"path/filepath"
 	"regexp"
 	"sync"
-	"syscall"
 
 	"github.com/BurntSushi/toml"
+	"github.com/gastownhall/gascity/internal/fsys"
 )
 
 // validCityName matches names safe for use in URL path segments.
@@ -182,7 +182,7 @@ func (r *Registry) loadLocked() ([]CityEntry, error) {
 	return rf.Cities, nil
 }
 
-// fileLock acquires an exclusive flock on a sibling .lock file for
+// fileLock acquires an exclusive lock on a sibling .lock file for
 // cross-process safety during read-modify-write operations. Returns
 // an unlock function. Caller must hold r.mu.Lock.
 func (r *Registry) fileLock() (func(), error) {
@@ -190,18 +190,11 @@ func (r *Registry) fileLock() (func(), error) {
 	if err := os.MkdirAll(filepath.Dir(lockPath), 0o700); err != nil {
 		return nil, fmt.Errorf("creating lock dir: %w", err)
 	}
-	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	unlock, err := fsys.LockFile(lockPath, 0o600)
 	if err != nil {
-		return nil, fmt.Errorf("opening registry lock: %w", err)
-	}
-	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
-		f.Close() //nolint:errcheck
 		return nil, fmt.Errorf("acquiring registry lock: %w", err)
 	}
-	return func() {
-		syscall.Flock(int(f.Fd()), syscall.LOCK_UN) //nolint:errcheck
-		f.Close()                                   //nolint:errcheck
-	}, nil
+	return unlock, nil
 }
 
 // saveLocked writes the registry file atomically. Caller must hold r.mu.Lock.
@@ -0,0 +1,118 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/sessionlog"
+)
+
+// Session metadata keys the wrapper stores at start, so nudges and stops
+// from other gc processes are attributed to the right agent.
+const (
+	metaAgent   = "GC_USAGE_AGENT"
+	metaRig     = "GC_USAGE_RIG"
+	metaWorkDir = "GC_USAGE_WORK_DIR"
+	metaStarted = "GC_USAGE_STARTED"
+)
+
+// TokenCounter returns the tokens used by the agent working in workDir
+// since the given time.
+type TokenCounter func(workDir string, since time.Time) sessionlog.TokenUsage
+
+// Provider wraps a [runtime.Provider] so successful starts, stops, and
+// nudges are appended to a usage log. Recording is best-effort and never
+// fails the operation. Every other operation, including optional
+// extensions, goes straight to the wrapped provider.
+type Provider struct {
+	runtime.Wrapper
+	path   string
+	tokens TokenCounter
+	now    func() time.Time
+}
+
+var _ runtime.Provider = (*Provider)(nil)
+
+// WrapProvider returns sp recording usage to the log at path. tokens,
+// when non-nil, is consulted when a session stops.
+func WrapProvider(sp runtime.Provider, path string, tokens TokenCounter) *Provider {
+	return &Provider{Wrapper: runtime.Wrapper{Provider: sp}, path: path, tokens: tokens, now: time.Now}
+}
+
+// Start starts the session and records it, keyed to the agent and rig
+// in the session's GC_AGENT and GC_RIG env vars.
+func (p *Provider) Start(ctx context.Context, name string, cfg runtime.Config) error {
+	if err := p.Provider.Start(ctx, name, cfg); err != nil {
+		return err
+	}
+	now := p.now().UTC()
+	ev := Event{Time: now, Type: EventStart, Session: name, Agent: cfg.Env["GC_AGENT"], Rig: cfg.Env["GC_RIG"]}
+	_ = p.Provider.SetMeta(name, metaAgent, ev.Agent)
+	_ = p.Provider.SetMeta(name, metaRig, ev.Rig)
+	_ = p.Provider.SetMeta(name, metaWorkDir, cfg.WorkDir)
+	_ = p.Provider.SetMeta(name, metaStarted, now.Format(time.RFC3339Nano))
+	_ = Append(p.path, ev)
+	return nil
+}
+
+// Stop stops the session and, if it was running, records the stop with
+// the tokens it used.
+func (p *Provider) Stop(name string) error {
+	if !p.Provider.IsRunning(name) {
+		return p.Provider.Stop(name)
+	}
+	ev := p.event(EventStop, name)
+	if p.tokens != nil {
+		workDir, _ := p.Provider.GetMeta(name, metaWorkDir)
+		started, _ := p.Provider.GetMeta(name, metaStarted)
+		if since, err := time.Parse(time.RFC3339Nano, started); err == nil && workDir != "" {
+			t := p.tokens(workDir, since)
+			ev.InputTokens, ev.CacheReadTokens, ev.OutputTokens = t.InputTokens, t.CacheReadTokens, t.OutputTokens
+		}
+	}
+	if err := p.Provider.Stop(name); err != nil {
+		return err
+	}
+	ev.Time = p.now().UTC()
+	_ = Append(p.path, ev)
+	return nil
+}
+
+// Nudge nudges the session and records the nudge.
+func (p *Provider) Nudge(name string, content []runtime.ContentBlock) error {
+	if err := p.Provider.Nudge(name, content); err != nil {
+		return err
+	}
+	p.recordNudge(name)
+	return nil
+}
+
+// NudgeNow delegates when the wrapped provider supports it, else nudges,
+// and records the nudge.
+func (p *Provider) NudgeNow(name string, content []runtime.ContentBlock) error {
+	if err := p.Wrapper.NudgeNow(name, content); err != nil {
+		return err
+	}
+	p.recordNudge(name)
+	return nil
+}
+
+// recordNudge records a nudge to a running session. Nudges to missing
+// sessions succeed without delivering anything and are not counted.
+func (p *Provider) recordNudge(name string) {
+	if !p.Provider.IsRunning(name) {
+		return
+	}
+	ev := p.event(EventNudge, name)
+	ev.Time = p.now().UTC()
+	_ = Append(p.path, ev)
+}
+
+// event returns an event of type typ for session name, attributed from
+// the metadata stored at start.
+func (p *Provider) event(typ, name string) Event {
+	agent, _ := p.Provider.GetMeta(name, metaAgent)
+	rig, _ := p.Provider.GetMeta(name, metaRig)
+	return Event{Type: typ, Session: name, Agent: agent, Rig: rig}
+}
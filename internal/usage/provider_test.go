@@ -0,0 +1,75 @@
+package usage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/runtime"
+	"github.com/gastownhall/gascity/internal/sessionlog"
+)
+
+func TestProviderRecordsSessionUsage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.jsonl")
+	fake := runtime.NewFake()
+	var tokensFor string
+	var tokensSince time.Time
+	sp := WrapProvider(fake, path, func(workDir string, since time.Time) sessionlog.TokenUsage {
+		tokensFor, tokensSince = workDir, since
+		return sessionlog.TokenUsage{InputTokens: 100, CacheReadTokens: 900, OutputTokens: 20}
+	})
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sp.now = func() time.Time { return start }
+
+	cfg := runtime.Config{WorkDir: "/rigs/app", Env: map[string]string{"GC_AGENT": "app/polecat-1", "GC_RIG": "app"}}
+	if err := sp.Start(context.Background(), "s-1", cfg); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := sp.Nudge("s-1", runtime.TextContent("work")); err != nil {
+		t.Fatalf("Nudge: %v", err)
+	}
+	if err := sp.Nudge("missing", runtime.TextContent("work")); err != nil {
+		t.Fatalf("Nudge(missing): %v", err)
+	}
+	sp.now = func() time.Time { return start.Add(time.Hour) }
+	if err := sp.Stop("s-1"); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if err := sp.Stop("s-1"); err != nil { // already stopped: not recorded again
+		t.Fatalf("second Stop: %v", err)
+	}
+
+	evs, err := ReadEvents(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evs) != 3 {
+		t.Fatalf("events = %+v, want start, nudge, stop", evs)
+	}
+	for i, typ := range []string{EventStart, EventNudge, EventStop} {
+		if evs[i].Type != typ || evs[i].Agent != "app/polecat-1" || evs[i].Rig != "app" {
+			t.Errorf("event %d = %+v, want %s for app/polecat-1 in app", i, evs[i], typ)
+		}
+	}
+	if stop := evs[2]; stop.InputTokens != 100 || stop.CacheReadTokens != 900 || stop.OutputTokens != 20 {
+		t.Errorf("stop tokens = %+v", stop)
+	}
+	if tokensFor != "/rigs/app" || !tokensSince.Equal(start) {
+		t.Errorf("tokens counted for %q since %v, want /rigs/app since %v", tokensFor, tokensSince, start)
+	}
+}
+
+func TestProviderStartFailureNotRecorded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.jsonl")
+	fake := runtime.NewFake()
+	fake.StartErrors["s-1"] = context.DeadlineExceeded
+	sp := WrapProvider(fake, path, nil)
+
+	if err := sp.Start(context.Background(), "s-1", runtime.Config{}); err == nil {
+		t.Fatal("Start = nil, want error")
+	}
+	if evs, _ := ReadEvents(path); len(evs) != 0 {
+		t.Errorf("events = %+v, want none", evs)
+	}
+}
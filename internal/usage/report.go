@@ -0,0 +1,129 @@
+package usage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+// Report groupings, the values Summarize accepts for by.
+const (
+	ByAgent = "agent"
+	ByRig   = "rig"
+	ByDay   = "day"
+)
+
+// noKey labels usage with no agent or rig to group it under.
+const noKey = "(none)"
+
+// Row is the usage attributed to one agent, rig, or day.
+type Row struct {
+	Key             string        `json:"key"`
+	Sessions        int           `json:"sessions"`
+	Runtime         time.Duration `json:"runtime_ns"`
+	Nudges          int           `json:"nudges"`
+	BeadsClosed     int           `json:"beads_closed"`
+	InputTokens     int           `json:"input_tokens"`
+	CacheReadTokens int           `json:"cache_read_tokens"`
+	OutputTokens    int           `json:"output_tokens"`
+}
+
+// Summarize rolls events and closed beads up into one row per agent,
+// rig, or day (UTC), sorted by key.
+//
+// A session's runtime runs from its start to its stop; a session that is
+// still running counts until now, and one that was started again without
+// a recorded stop (it crashed) counts until the restart. Runtime and
+// sessions belong to the day the session started; tokens to the day it
+// stopped. Only events and closes at or after since count.
+//
+// Closed beads are credited to the agent whose session they are assigned
+// to, found from the sessions in events; beads assigned elsewhere are
+// credited to the assignee as written, and unassigned beads are skipped.
+func Summarize(events []Event, closed []beads.Bead, by string, since, now time.Time) ([]Row, error) {
+	if by != ByAgent && by != ByRig && by != ByDay {
+		return nil, fmt.Errorf("unknown grouping %q: want agent, rig, or day", by)
+	}
+	rows := make(map[string]*Row)
+	row := func(agent, rig string, t time.Time) *Row {
+		var key string
+		switch by {
+		case ByAgent:
+			key = agent
+		case ByRig:
+			key = rig
+		case ByDay:
+			key = t.UTC().Format(time.DateOnly)
+		}
+		if key == "" {
+			key = noKey
+		}
+		r := rows[key]
+		if r == nil {
+			r = &Row{Key: key}
+			rows[key] = r
+		}
+		return r
+	}
+
+	sorted := append([]Event(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+	open := make(map[string]Event)  // session → its unmatched start
+	owner := make(map[string]Event) // session → its latest start, for bead credit
+	finish := func(start Event, end time.Time) {
+		if start.Time.Before(since) {
+			return
+		}
+		r := row(start.Agent, start.Rig, start.Time)
+		r.Sessions++
+		r.Runtime += end.Sub(start.Time)
+	}
+	for _, ev := range sorted {
+		switch ev.Type {
+		case EventStart:
+			if prev, ok := open[ev.Session]; ok {
+				finish(prev, ev.Time)
+			}
+			open[ev.Session] = ev
+			owner[ev.Session] = ev
+		case EventStop:
+			if start, ok := open[ev.Session]; ok {
+				finish(start, ev.Time)
+				delete(open, ev.Session)
+			}
+			if !ev.Time.Before(since) {
+				r := row(ev.Agent, ev.Rig, ev.Time)
+				r.InputTokens += ev.InputTokens
+				r.CacheReadTokens += ev.CacheReadTokens
+				r.OutputTokens += ev.OutputTokens
+			}
+		case EventNudge:
+			if !ev.Time.Before(since) {
+				row(ev.Agent, ev.Rig, ev.Time).Nudges++
+			}
+		}
+	}
+	for _, start := range open {
+		finish(start, now)
+	}
+
+	for _, b := range closed {
+		if b.Status != "closed" || b.Assignee == "" || b.ClosedAt == nil || b.ClosedAt.Before(since) {
+			continue
+		}
+		agent, rig := b.Assignee, ""
+		if start, ok := owner[b.Assignee]; ok {
+			agent, rig = start.Agent, start.Rig
+		}
+		row(agent, rig, *b.ClosedAt).BeadsClosed++
+	}
+
+	out := make([]Row, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
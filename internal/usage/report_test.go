@@ -0,0 +1,65 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gastownhall/gascity/internal/beads"
+)
+
+func TestSummarize(t *testing.T) {
+	day1 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	evs := []Event{
+		{Time: day1, Type: EventStart, Session: "s-1", Agent: "app/polecat", Rig: "app"},
+		{Time: day1.Add(10 * time.Minute), Type: EventNudge, Session: "s-1", Agent: "app/polecat", Rig: "app"},
+		{Time: day1.Add(time.Hour), Type: EventStop, Session: "s-1", Agent: "app/polecat", Rig: "app", InputTokens: 10, CacheReadTokens: 5, OutputTokens: 3},
+		{Time: day1, Type: EventStart, Session: "s-2", Agent: "mayor"},
+		// s-2 crashed and was restarted: the first run ends at the restart.
+		{Time: day1.Add(30 * time.Minute), Type: EventStart, Session: "s-2", Agent: "mayor"},
+		{Time: day2, Type: EventNudge, Session: "s-2", Agent: "mayor"},
+	}
+	closedAt := day2
+	closed := []beads.Bead{
+		{ID: "b-1", Status: "closed", Assignee: "s-1", ClosedAt: &closedAt},
+		{ID: "b-2", Status: "closed", Assignee: "human", ClosedAt: &closedAt},
+		{ID: "b-3", Status: "closed", ClosedAt: &closedAt},
+		{ID: "b-4", Status: "open", Assignee: "s-1"},
+	}
+	now := day2.Add(time.Hour)
+
+	rows, err := Summarize(evs, closed, ByAgent, time.Time{}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Row{
+		{Key: "app/polecat", Sessions: 1, Runtime: time.Hour, Nudges: 1, BeadsClosed: 1, InputTokens: 10, CacheReadTokens: 5, OutputTokens: 3},
+		{Key: "human", BeadsClosed: 1},
+		// 30m until the restart, then day1+30m → now (still running).
+		{Key: "mayor", Sessions: 2, Runtime: 30*time.Minute + now.Sub(day1.Add(30*time.Minute)), Nudges: 1},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %+v, want %+v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+
+	byRig, _ := Summarize(evs, closed, ByRig, time.Time{}, now)
+	if len(byRig) != 2 || byRig[0].Key != "(none)" || byRig[1].Key != "app" || byRig[1].BeadsClosed != 1 {
+		t.Errorf("by rig = %+v", byRig)
+	}
+
+	byDay, _ := Summarize(evs, closed, ByDay, day2, now)
+	if len(byDay) != 1 || byDay[0].Key != "2026-03-02" || byDay[0].Nudges != 1 || byDay[0].BeadsClosed != 2 || byDay[0].Sessions != 0 {
+		t.Errorf("by day since day2 = %+v", byDay)
+	}
+}
+
+func TestSummarizeUnknownGrouping(t *testing.T) {
+	if _, err := Summarize(nil, nil, "team", time.Time{}, time.Now()); err == nil {
+		t.Error("Summarize(by team) = nil error")
+	}
+}
@@ -0,0 +1,88 @@
+// Package usage records what agents spend: session runtime, nudges, and
+// (where the provider's session logs expose it) token usage. Events are
+// appended as JSON lines to .gc/metrics/usage.jsonl by a
+// [runtime.Provider] wrapper, and [Summarize] rolls them up, together
+// with the beads agents closed, for gc usage report.
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Event types.
+const (
+	// EventStart records a session start.
+	EventStart = "start"
+	// EventStop records a session stop, with the tokens used since the
+	// start when known.
+	EventStop = "stop"
+	// EventNudge records a prompt nudge delivered to a session.
+	EventNudge = "nudge"
+)
+
+// Event is one line in the usage log.
+type Event struct {
+	Time    time.Time `json:"ts"`
+	Type    string    `json:"type"`
+	Session string    `json:"session"`
+	Agent   string    `json:"agent,omitempty"`
+	Rig     string    `json:"rig,omitempty"`
+	// Token counts, set on stop events.
+	InputTokens     int `json:"input_tokens,omitempty"`
+	CacheReadTokens int `json:"cache_read_tokens,omitempty"`
+	OutputTokens    int `json:"output_tokens,omitempty"`
+}
+
+// Append appends ev to the usage log at path as one JSON line, creating
+// the file and its directory as needed. O_APPEND keeps concurrent
+// writers from interleaving partial lines.
+func Append(path string, ev Event) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating usage log directory: %w", err)
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("marshaling usage event: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening usage log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close() //nolint:errcheck // write error takes precedence
+		return fmt.Errorf("writing usage log: %w", err)
+	}
+	return f.Close()
+}
+
+// ReadEvents returns the events in the usage log at path, oldest first.
+// A missing log is empty. Malformed lines are skipped.
+func ReadEvents(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening usage log: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // read-only
+	var evs []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var ev Event
+		if json.Unmarshal(scanner.Bytes(), &ev) == nil {
+			evs = append(evs, ev)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading usage log: %w", err)
+	}
+	return evs, nil
+}
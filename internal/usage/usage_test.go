@@ -0,0 +1,40 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendReadEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics", "usage.jsonl")
+	if evs, err := ReadEvents(path); err != nil || evs != nil {
+		t.Fatalf("ReadEvents(missing) = %v, %v; want nil, nil", evs, err)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, ev := range []Event{
+		{Time: now, Type: EventStart, Session: "s-1", Agent: "mayor"},
+		{Time: now.Add(time.Minute), Type: EventStop, Session: "s-1", Agent: "mayor", OutputTokens: 42},
+	} {
+		if err := Append(path, ev); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	// A torn or foreign line doesn't hide the rest.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{not json\n") //nolint:errcheck
+	f.Close()                    //nolint:errcheck
+
+	evs, err := ReadEvents(path)
+	if err != nil {
+		t.Fatalf("ReadEvents: %v", err)
+	}
+	if len(evs) != 2 || evs[1].Type != EventStop || evs[1].OutputTokens != 42 || !evs[0].Time.Equal(now) {
+		t.Errorf("ReadEvents = %+v", evs)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package workspacesvc
+
+import "syscall"
+
+// groupSysProcAttr starts the child in its own process group so the
+// whole tree can be signaled together.
+func groupSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// signalGroup sends sig to the process group led by pid.
+func signalGroup(pid int, sig syscall.Signal) error {
+	return syscall.Kill(-pid, sig)
+}
@@ -0,0 +1,32 @@
+//go:build windows
+
+package workspacesvc
+
+import (
+	"os"
+	"syscall"
+)
+
+// groupSysProcAttr returns nil on Windows (no POSIX process groups).
+func groupSysProcAttr() *syscall.SysProcAttr {
+	return nil
+}
+
+// signalGroup approximates a group signal on Windows, which has no
+// process groups or signals: signal 0 probes for the process, SIGTERM
+// and SIGKILL terminate it, and anything else is unsupported.
+func signalGroup(pid int, sig syscall.Signal) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return syscall.ESRCH
+	}
+	defer p.Release() //nolint:errcheck // best-effort handle cleanup
+	switch sig {
+	case 0:
+		return nil
+	case syscall.SIGTERM, syscall.SIGKILL:
+		return p.Kill()
+	default:
+		return syscall.EWINDOWS
+	}
+}
@@ -182,7 +182,7 @@ func (p *proxyProcessInstance) start(now time.Time) error {
 	)
 	cmd.Stdout = logFile
 	cmd.Stderr = logFile
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.SysProcAttr = groupSysProcAttr()
 	if err := cmd.Start(); err != nil {
 		_ = logFile.Close()
 		return fmt.Errorf("start process: %w", err)
@@ -332,14 +332,14 @@ func stopProcessGroup(cmd *exec.Cmd) error {
 	if cmd == nil || cmd.Process == nil {
 		return nil
 	}
-	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	_ = signalGroup(cmd.Process.Pid, syscall.SIGTERM)
 	deadline := time.Now().Add(proxyProcessShutdownWait)
 	for time.Now().Before(deadline) {
-		if err := syscall.Kill(-cmd.Process.Pid, 0); errors.Is(err, syscall.ESRCH) {
+		if err := signalGroup(cmd.Process.Pid, 0); errors.Is(err, syscall.ESRCH) {
 			return nil
 		}
 		time.Sleep(50 * time.Millisecond)
 	}
-	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	_ = signalGroup(cmd.Process.Pid, syscall.SIGKILL)
 	return nil
 }
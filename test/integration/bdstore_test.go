@@ -127,6 +127,9 @@ func TestBdStoreConformance(t *testing.T) {
 	// uses bd's ID format (prefix-XXXX), not gc-N sequential format.
 	beadstest.RunStoreTests(t, newStore)
 	beadstest.RunMetadataTests(t, newStore)
+	beadstest.RunCommentTests(t, newStore)
+	beadstest.RunPriorityTests(t, newStore)
+	beadstest.RunDueTests(t, newStore)
 }
 
 // ensureDoltIdentity ensures dolt has user.name and user.email set.